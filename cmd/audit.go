@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// newAuditCmd builds the audit command.
+func newAuditCmd(inv *invocation) *cobra.Command {
+	return &cobra.Command{
+		Use:   "audit <plugin-dir>",
+		Short: "Audit a third-party plugin before installing it",
+		Long: `Lint a downloaded plugin, or any other third-party tree, in untrusted mode.
+It is cclint --untrusted run on the directory, with the configuration of the
+current directory rather than the audited one, so the plugin cannot relax
+its own audit:
+
+  - every security finding is an error
+  - hook commands that reach the network (curl, wget, ssh, URLs, /dev/tcp)
+    and http hooks are reported
+  - hook commands that write outside the plugin root and the project
+    (absolute, home, or ../ paths) are reported
+  - the plugin's hooks/hooks.json and any hook files its manifest names are
+    scanned along with the manifest
+
+EXAMPLES:
+
+  cclint audit ~/Downloads/some-plugin
+  cclint audit ./vendor/plugin --fail-on warning`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runAudit(inv, args)
+		}),
+	}
+}
+
+func runAudit(inv *invocation, args []string) (cmdResult, error) {
+	dir, err := filepath.Abs(args[0])
+	if err != nil {
+		return cmdResult{}, usageErrorf("invalid plugin directory: %w", err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return cmdResult{}, usageErrorf("%s is not a directory", args[0])
+	}
+
+	inv.untrusted = true
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	cfg.Root = dir
+	return runFullLint(inv, cfg)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/dotcommander/cclint/internal/badge"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+// badgeOptions holds the flags of the badge command.
+type badgeOptions struct {
+	endpoint string // shields.io endpoint file to write (--endpoint)
+	style    string // "score" or "status" (--style)
+	label    string // left-hand text (--label)
+}
+
+// newBadgeCmd builds the badge command.
+func newBadgeCmd(inv *invocation) *cobra.Command {
+	opts := &badgeOptions{}
+	badgeCmd := &cobra.Command{
+		Use:   "badge",
+		Short: "Generate a README badge for the project's lint health",
+		Long: `Lint every component and write a badge summarizing the result, for display
+in a README.
+
+With --style score (the default) the badge shows the average quality score
+of the scored components and its tier, e.g. "87 A". With --style status it
+shows "passing", or the error and warning counts. A project with no scored
+components always gets a status badge.
+
+--output writes the badge as an SVG image; without it the SVG is printed.
+--endpoint writes a shields.io endpoint file, so the badge can be served
+from the repository through https://img.shields.io/endpoint?url=<raw file URL>.
+
+EXAMPLES:
+
+  cclint badge --output badge.svg
+  cclint badge --style status --output badge.svg --endpoint badge.json`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return resultOK, runBadge(inv, opts)
+		}),
+	}
+
+	badgeCmd.Flags().StringVar(&opts.endpoint, "endpoint", "", "shields.io endpoint JSON file to write")
+	badgeCmd.Flags().StringVar(&opts.style, "style", "score", "badge content: score or status")
+	badgeCmd.Flags().StringVar(&opts.label, "label", "cclint", "badge label")
+	return badgeCmd
+}
+
+func runBadge(inv *invocation, opts *badgeOptions) error {
+	if opts.style != "score" && opts.style != "status" {
+		return usageErrorf("invalid --style %q. Use score or status", opts.style)
+	}
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return err
+	}
+	result, err := runOrchestratedLint(inv, cfg, nil)
+	if err != nil {
+		return fmt.Errorf("error building badge: %w", err)
+	}
+
+	b := buildBadge(result, opts.style, opts.label)
+	if opts.endpoint != "" {
+		data, err := b.Endpoint()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(opts.endpoint, data, 0o644); err != nil {
+			return fmt.Errorf("error writing %s: %w", opts.endpoint, err)
+		}
+	}
+	if cfg.Output == "" {
+		fmt.Print(b.SVG())
+		return nil
+	}
+	if err := os.WriteFile(cfg.Output, []byte(b.SVG()), 0o644); err != nil {
+		return fmt.Errorf("error writing %s: %w", cfg.Output, err)
+	}
+	if !cfg.Quiet() {
+		fmt.Fprintf(os.Stderr, "Wrote %s badge: %s\n", cfg.Output, b.Message)
+	}
+	return nil
+}
+
+// buildBadge returns the badge for a lint run in the given style, falling
+// back to a status badge when no component was scored.
+func buildBadge(result *lint.Result, style, label string) badge.Badge {
+	if style == "score" {
+		if score, ok := averageScore(result.Summaries); ok {
+			return badge.FromScore(label, score)
+		}
+	}
+	return badge.FromCounts(label, result.TotalErrors, result.TotalWarnings)
+}
+
+// averageScore returns the mean overall quality score of the scored
+// results, rounded, and whether any result was scored.
+func averageScore(summaries []*lint.LintSummary) (int, bool) {
+	total, n := 0, 0
+	for _, summary := range summaries {
+		for _, r := range summary.Results {
+			if r.Quality != nil {
+				total += r.Quality.Overall
+				n++
+			}
+		}
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return int(math.Round(float64(total) / float64(n))), true
+}
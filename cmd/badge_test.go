@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/badge"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/scoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildBadge(t *testing.T) {
+	scored := &lint.Result{
+		TotalErrors: 1,
+		Summaries: []*lint.LintSummary{{Results: []lint.LintResult{
+			{File: "a.md", Quality: &scoring.QualityScore{Overall: 90}},
+			{File: "b.md", Quality: &scoring.QualityScore{Overall: 81}},
+			{File: "settings.json"},
+		}}},
+	}
+	unscored := &lint.Result{
+		TotalWarnings: 2,
+		Summaries:     []*lint.LintSummary{{Results: []lint.LintResult{{File: "settings.json"}}}},
+	}
+
+	tests := []struct {
+		name   string
+		result *lint.Result
+		style  string
+		want   badge.Badge
+	}{
+		{name: "score averages scored components", result: scored, style: "score", want: badge.Badge{Label: "cclint", Message: "86 A", Color: "brightgreen"}},
+		{name: "status counts findings", result: scored, style: "status", want: badge.Badge{Label: "cclint", Message: "1 error", Color: "red"}},
+		{name: "score falls back to status", result: unscored, style: "score", want: badge.Badge{Label: "cclint", Message: "2 warnings", Color: "yellow"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildBadge(tt.result, tt.style, "cclint"))
+		})
+	}
+}
+
+func TestRunBadge(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, ".claude/agents/helper.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("---\nname: helper\ndescription: Helps with things. Use PROACTIVELY when asked.\nmodel: sonnet\n---\n\nHelp.\n"), 0o600))
+
+	inv := testInvocation()
+	opts := &badgeOptions{}
+	inv.rootPath, inv.quiet = root, true
+	inv.outputFile = filepath.Join(root, "badge.svg")
+	opts.endpoint = filepath.Join(root, "badge.json")
+	opts.style, opts.label = "score", "claude config"
+
+	require.NoError(t, runBadge(inv, opts))
+
+	svg, err := os.ReadFile(inv.outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "claude config")
+
+	data, err := os.ReadFile(opts.endpoint)
+	require.NoError(t, err)
+	var endpoint map[string]any
+	require.NoError(t, json.Unmarshal(data, &endpoint))
+	assert.Equal(t, "claude config", endpoint["label"])
+	assert.Regexp(t, `^\d+ [ABCDF]$`, endpoint["message"])
+
+	opts.style = "stars"
+	assert.Equal(t, ExitUsage, exitCodeForError(runBadge(inv, opts)))
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/baseline"
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/fix"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+// newBaselineCmd builds the baseline command and its subcommands. Their
+// --dry-run flag prints the edits instead of applying them.
+func newBaselineCmd(inv *invocation) *cobra.Command {
+	var dryRun bool
+	baselineCmd := &cobra.Command{
+		Use:   "baseline",
+		Short: "Move suppressions between the baseline file and inline comments",
+		Long: `Known issues can be suppressed in two ways: listed in the baseline file
+(cclint --baseline-create, read with --baseline), or with a comment next to
+the issue in the markdown file itself:
+
+  <!-- cclint-disable-next-line rule-id[, rule-id...] -->
+  <!-- cclint-disable-file rule-id -->
+
+In frontmatter the same comments are written as YAML comments
+("# cclint-disable-next-line rule-id"), but cclint fmt drops those. The
+subcommands convert one form into the other without losing suppressions.
+The baseline file is --baseline-path (default .cclintbaseline.json).
+
+EXAMPLES:
+
+  cclint baseline to-inline --dry-run
+  cclint baseline to-inline
+  cclint baseline from-inline`,
+	}
+
+	baselineToInlineCmd := &cobra.Command{
+		Use:   "to-inline",
+		Short: "Replace baseline entries with inline suppression comments",
+		Long: `Lint the project, and for each issue the baseline knows add a
+cclint-disable-next-line comment above its line, or a cclint-disable-file
+comment at the end of the file when the issue is in the frontmatter, a
+fenced code block, or a table, or has no line. The converted entries are
+removed from the baseline.
+
+Issues without a rule ID and issues in JSON files cannot be suppressed
+inline; their entries stay in the baseline, as do entries that match no
+current issue. Files are rewritten together, so either every edit lands or
+none does.`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return resultOK, runBaselineToInline(inv, dryRun)
+		}),
+	}
+
+	baselineFromInlineCmd := &cobra.Command{
+		Use:   "from-inline",
+		Short: "Replace inline suppression comments with baseline entries",
+		Long: `Lint the project ignoring cclint-disable comments, add every issue a
+comment suppresses to the baseline (creating it if needed), and remove the
+comments from the markdown files, including comments that no longer
+suppress anything.`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return resultOK, runBaselineFromInline(inv, dryRun)
+		}),
+	}
+
+	baselineCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the file changes instead of applying them")
+	baselineCmd.AddCommand(baselineToInlineCmd)
+	baselineCmd.AddCommand(baselineFromInlineCmd)
+	return baselineCmd
+}
+
+// lintedFile is one linted file and its issues.
+type lintedFile struct {
+	path    string // absolute path
+	relPath string
+	issues  []cue.ValidationError
+}
+
+// lintForBaseline lints the whole project without baseline filtering and
+// returns its files in path order, with the baseline file's path. With
+// keepInline, issues cclint-disable comments suppress are kept.
+func lintForBaseline(inv *invocation, cfg *config.Config, keepInline bool) ([]lintedFile, string, error) {
+	orchestrator := lint.NewOrchestrator(cfg, lint.OrchestratorConfig{RootPath: inv.rootPath, KeepInlineSuppressed: keepInline})
+	result, err := orchestrator.Run()
+	if err != nil {
+		return nil, "", err
+	}
+	var files []lintedFile
+	for _, summary := range result.Summaries {
+		for _, r := range summary.Results {
+			f := lintedFile{path: r.File, relPath: r.File}
+			if filepath.IsAbs(r.File) {
+				if rel, err := filepath.Rel(summary.ProjectRoot, r.File); err == nil {
+					f.relPath = rel
+				}
+			} else {
+				f.path = filepath.Join(summary.ProjectRoot, r.File)
+			}
+			f.issues = append(f.issues, r.Errors...)
+			f.issues = append(f.issues, r.Warnings...)
+			f.issues = append(f.issues, r.Suggestions...)
+			files = append(files, f)
+		}
+	}
+	slices.SortFunc(files, func(a, b lintedFile) int { return strings.Compare(a.relPath, b.relPath) })
+
+	path := inv.baselinePath
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cfg.Root, path)
+	}
+	return files, path, nil
+}
+
+// isMarkdown reports whether path can hold inline suppression comments.
+func isMarkdown(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".md")
+}
+
+func runBaselineToInline(inv *invocation, dryRun bool) error {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return err
+	}
+	files, path, err := lintForBaseline(inv, cfg, false)
+	if err != nil {
+		return err
+	}
+	b, err := baseline.LoadBaseline(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return usageErrorf("no baseline at %s; create one with cclint --baseline-create", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	var edits []fix.Edit
+	var placed, kept []cue.ValidationError
+	for _, f := range files {
+		var known []cue.ValidationError
+		for _, issue := range f.issues {
+			if b.IsKnown(issue) {
+				known = append(known, issue)
+			}
+		}
+		if len(known) == 0 {
+			continue
+		}
+		if !isMarkdown(f.path) {
+			kept = append(kept, known...)
+			continue
+		}
+		data, err := os.ReadFile(f.path) //nolint:gosec // G304: path of a linted file
+		if err != nil {
+			return fmt.Errorf("cannot read %s: %w", f.relPath, err)
+		}
+		after, skipped := baseline.AddInline(string(data), known)
+		kept = append(kept, skipped...)
+		for _, issue := range known {
+			if issue.Rule != "" {
+				placed = append(placed, issue)
+			}
+		}
+		if after != string(data) {
+			edits = append(edits, fix.Edit{Path: f.path, Name: f.relPath, Before: string(data), After: after})
+		}
+	}
+
+	// An entry stays while any issue it covers is still unplaced.
+	b.Remove(placed)
+	b.Add(kept)
+	if err := applyBaselineEdits(edits, b, path, dryRun); err != nil || dryRun {
+		return err
+	}
+	if !cfg.Quiet() {
+		fmt.Printf("Suppressed %d issues inline in %d files; %d entries left in %s\n", len(placed), len(edits), len(b.Fingerprints), path)
+	}
+	return nil
+}
+
+func runBaselineFromInline(inv *invocation, dryRun bool) error {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return err
+	}
+	files, path, err := lintForBaseline(inv, cfg, true)
+	if err != nil {
+		return err
+	}
+	b, err := baseline.LoadBaseline(path)
+	if errors.Is(err, os.ErrNotExist) {
+		b, err = baseline.CreateBaseline(nil), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var edits []fix.Edit
+	var suppressed []cue.ValidationError
+	comments := 0
+	for _, f := range files {
+		if !isMarkdown(f.path) {
+			continue
+		}
+		data, err := os.ReadFile(f.path) //nolint:gosec // G304: path of a linted file
+		if err != nil {
+			return fmt.Errorf("cannot read %s: %w", f.relPath, err)
+		}
+		inline := baseline.ParseInline(string(data))
+		if inline.Len() == 0 {
+			continue
+		}
+		for _, issue := range f.issues {
+			if inline.Suppresses(issue) {
+				suppressed = append(suppressed, issue)
+			}
+		}
+		after, removed := baseline.RemoveInline(string(data))
+		comments += removed
+		edits = append(edits, fix.Edit{Path: f.path, Name: f.relPath, Before: string(data), After: after})
+	}
+
+	b.Add(suppressed)
+	if err := applyBaselineEdits(edits, b, path, dryRun); err != nil || dryRun {
+		return err
+	}
+	if !cfg.Quiet() {
+		fmt.Printf("Moved %d inline-suppressed issues to %s and removed %d comments from %d files\n", len(suppressed), path, comments, len(edits))
+	}
+	return nil
+}
+
+// applyBaselineEdits rewrites the edited files together and then saves the
+// baseline. With --dry-run it prints the file diffs and saves nothing.
+func applyBaselineEdits(edits []fix.Edit, b *baseline.Baseline, path string, dryRun bool) error {
+	if dryRun {
+		fmt.Print(fix.Diff(edits))
+		fmt.Printf("%d files would change; %s would keep %d entries\n", len(edits), path, len(b.Fingerprints))
+		return nil
+	}
+	tx, err := fix.Begin(edits)
+	if err != nil {
+		return err
+	}
+	if err := tx.Apply(); err != nil {
+		return err
+	}
+	b.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := b.SaveBaseline(path); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
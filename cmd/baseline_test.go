@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/baseline"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaselineToInlineAndBack(t *testing.T) {
+	root := t.TempDir()
+	skill := filepath.Join(root, ".claude/skills/fmt-go/SKILL.md")
+	original := "---\nname: fmt-go\ndescription: Formats Go source code consistently. Use when needed.\n---\n# Fmt\n\nBody."
+	require.NoError(t, os.MkdirAll(filepath.Dir(skill), 0o755))
+	require.NoError(t, os.WriteFile(skill, []byte(original), 0o600))
+
+	inv := testInvocation()
+	inv.rootPath = root
+	path := filepath.Join(root, inv.baselinePath)
+
+	run := func(fn func(*invocation, bool) error, dryRun bool) string {
+		out, _, err := captureStdout(t, func() (cmdResult, error) { return resultOK, fn(inv, dryRun) })
+		require.NoError(t, err)
+		return out
+	}
+	readSkill := func() string {
+		data, err := os.ReadFile(skill)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	_, _, err := captureStdout(t, func() (cmdResult, error) { return resultOK, runBaselineToInline(inv, false) })
+	assert.Equal(t, ExitUsage, exitCodeForError(err), "to-inline without a baseline is a usage error")
+
+	// Start from a baseline holding every current issue.
+	cfg, err := loadCLIConfig(inv)
+	require.NoError(t, err)
+	files, _, err := lintForBaseline(inv, cfg, false)
+	require.NoError(t, err)
+	b := baseline.CreateBaseline(nil)
+	for _, f := range files {
+		b.Add(f.issues)
+	}
+	require.NoError(t, b.SaveBaseline(path))
+	total := len(b.Fingerprints)
+
+	out := run(runBaselineToInline, true)
+	assert.Contains(t, out, "+ <!-- cclint-disable-file")
+	assert.Equal(t, original, readSkill(), "--dry-run writes nothing")
+
+	out = run(runBaselineToInline, false)
+	assert.Contains(t, out, "Suppressed")
+	assert.Contains(t, readSkill(), "<!-- cclint-disable-file content-final-newline")
+	assert.Contains(t, readSkill(), "# cclint-disable-next-line skill-trigger-vague\ndescription:", "frontmatter findings keep their line scope")
+	b, err = baseline.LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Less(t, len(b.Fingerprints), total, "converted entries leave the baseline")
+
+	out = run(runBaselineFromInline, false)
+	assert.Contains(t, out, "removed 2 comments from 1 files")
+	assert.Equal(t, original, readSkill())
+	b, err = baseline.LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Len(t, b.Fingerprints, total, "every suppression is back in the baseline")
+}
+
+func TestBaselineToInlineSurvivesFmt(t *testing.T) {
+	root := t.TempDir()
+	agent := filepath.Join(root, ".claude/agents/reviewer.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(agent), 0o755))
+	require.NoError(t, os.WriteFile(agent, []byte("---\nmodel: opus\ndescription: Reviews code\nname: reviewer\ntools: Bashh, Read\n---\n\nReview the code.\n"), 0o600))
+
+	inv := testInvocation()
+	inv.rootPath = root
+	cfg, err := loadCLIConfig(inv)
+	require.NoError(t, err)
+	lintIssues := func() []cue.ValidationError {
+		files, _, err := lintForBaseline(inv, cfg, false)
+		require.NoError(t, err)
+		var issues []cue.ValidationError
+		for _, f := range files {
+			issues = append(issues, f.issues...)
+		}
+		return issues
+	}
+	before := lintIssues()
+	require.True(t, slices.ContainsFunc(before, func(e cue.ValidationError) bool { return e.Rule == cue.RuleAgentToolUnknown }))
+
+	b := baseline.CreateBaseline(nil)
+	b.Add(before)
+	require.NoError(t, b.SaveBaseline(filepath.Join(root, inv.baselinePath)))
+	_, _, err = captureStdout(t, func() (cmdResult, error) { return resultOK, runBaselineToInline(inv, false) })
+	require.NoError(t, err)
+
+	// fmt moves the tools field, and its suppression comment with it.
+	_, _, err = captureStdout(t, func() (cmdResult, error) { return runFmt(inv, &fmtOptions{write: true}, []string{agent}) })
+	require.NoError(t, err)
+	data, err := os.ReadFile(agent)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nname: reviewer\n# cclint-disable-next-line agent-description-proactive\ndescription: Reviews code\nmodel: opus\n# cclint-disable-next-line agent-tool-unknown\ntools: Bashh, Read\n---\nReview the code.\n<!-- cclint-disable-file agent-tool-unused -->\n", string(data))
+
+	for _, issue := range lintIssues() {
+		assert.NotEqual(t, cue.RuleAgentToolUnknown, issue.Rule, "the suppression still applies after fmt")
+	}
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/dotcommander/cclint/internal/cache"
+)
+
+// remoteCache opens the shared cache for remote data, honoring --offline.
+func remoteCache(inv *invocation) (*cache.Cache, error) {
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return cache.New(dir).WithOffline(inv.offline), nil
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dotcommander/cclint/internal/git"
+	"github.com/dotcommander/cclint/internal/project"
+	"github.com/dotcommander/cclint/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+// churnOptions are the churn command's flags.
+type churnOptions struct {
+	since      string
+	minCommits int
+	top        int
+}
+
+// newChurnCmd builds the churn command.
+func newChurnCmd(inv *invocation) *cobra.Command {
+	var opts churnOptions
+	churnCmd := &cobra.Command{
+		Use:   "churn",
+		Short: "Rate how often each component changes, next to its findings and score",
+		Long: `Read the git history of the project and list each component by how many
+commits changed it, with its current findings and quality score. A
+component that changes often and is low quality, with an error or a score
+below 50 (tier D or F), is marked as a refactor candidate: every edit to
+it is a chance to make things worse.
+
+Commits are counted per file with git log, without merge commits, and
+renames are not followed. --since limits the history, with any date git
+log --since accepts. Findings are counted as a normal run reports them,
+after rules config, overrides, and the baseline.
+
+Console output lists the --top components (default 20; 0 lists all). With
+--format json every changed component is listed, as an object with since,
+minCommits, candidates, and a files array.
+
+EXAMPLES:
+
+  cclint churn
+  cclint churn --since "90 days ago" --min-commits 3
+  cclint churn --format json`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return resultOK, runChurn(inv, opts)
+		}),
+	}
+	churnCmd.Flags().StringVar(&opts.since, "since", "", `only count commits after this date, e.g. "90 days ago" or 2026-01-01 (default all history)`)
+	churnCmd.Flags().IntVar(&opts.minCommits, "min-commits", 5, "commits at which a low-quality component becomes a refactor candidate")
+	churnCmd.Flags().IntVar(&opts.top, "top", 20, "components to list in console output (0 for all)")
+	return churnCmd
+}
+
+func runChurn(inv *invocation, opts churnOptions) error {
+	if opts.top < 0 {
+		return usageErrorf("invalid --top: must not be negative")
+	}
+	if opts.minCommits < 1 {
+		return usageErrorf("invalid --min-commits: must be at least 1")
+	}
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return err
+	}
+	if cfg.Format != "console" && cfg.Format != "json" {
+		return usageErrorf("churn supports --format console or json, not %q", cfg.Format)
+	}
+
+	root := cfg.Root
+	if root == "" {
+		if root, err = project.FindProjectRoot("."); err != nil {
+			return fmt.Errorf("error finding project root: %w", err)
+		}
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return fmt.Errorf("error resolving project root: %w", err)
+	}
+	if !git.IsGitRepo(root) {
+		return usageErrorf("churn reads git history, and %s is not in a git repository", root)
+	}
+	history, err := git.GetFileChurn(root, opts.since)
+	if err != nil {
+		return fmt.Errorf("error reading git history: %w", err)
+	}
+
+	result, err := runOrchestratedLint(inv, cfg, nil)
+	if err != nil {
+		return fmt.Errorf("error building churn report: %w", err)
+	}
+
+	report := stats.ComputeChurn(result.Summaries, history, opts.since, opts.minCommits)
+	if cfg.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printChurn(report, opts.top)
+	return nil
+}
+
+// printChurn prints the components as a table, most changed first, with
+// refactor candidates marked.
+func printChurn(report stats.ChurnReport, top int) {
+	period := "in all history"
+	if report.Since != "" {
+		period = "since " + report.Since
+	}
+	changed := "components"
+	if len(report.Files) == 1 {
+		changed = "component"
+	}
+	fmt.Printf("%d %s changed %s\n", len(report.Files), changed, period)
+	if len(report.Files) == 0 {
+		return
+	}
+
+	styles := newPrintStyles()
+	fmt.Println()
+	fmt.Println(styles.header.Render(fmt.Sprintf("  %7s %7s  %-12s %-6s %s", "COMMITS", "AUTHORS", "FINDINGS", "SCORE", "FILE")))
+	for i, e := range report.Files {
+		if top > 0 && i == top {
+			fmt.Println(styles.dim.Render(fmt.Sprintf("  ... and %d more", len(report.Files)-top)))
+			break
+		}
+		score := "-"
+		if e.Score != nil {
+			score = fmt.Sprintf("%d %s", *e.Score, e.Tier)
+		}
+		line := fmt.Sprintf("  %7d %7d  %-12s %-6s %s", e.Commits, e.Authors,
+			fmt.Sprintf("%dE %dW %dS", e.Errors, e.Warnings, e.Suggestions), score, e.File)
+		if e.Candidate {
+			line += "  " + styles.tierDF.Render("refactor candidate")
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Println()
+	switch report.Candidates {
+	case 0:
+		fmt.Printf("No refactor candidates: no component changed %d or more times has an error or a score below %d\n", report.MinCommits, stats.LowScore)
+	case 1:
+		fmt.Printf("1 refactor candidate: changed %d or more times, with an error or a score below %d\n", report.MinCommits, stats.LowScore)
+	default:
+		fmt.Printf("%d refactor candidates: changed %d or more times, with an error or a score below %d\n", report.Candidates, report.MinCommits, stats.LowScore)
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunChurn(t *testing.T) {
+	if err := exec.Command("git", "--version").Run(); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v: %s", args, out)
+	}
+	git("init", "-q")
+	git("config", "user.email", "test@test.com")
+	git("config", "user.name", "Test User")
+
+	agent := filepath.Join(root, ".claude/agents/helper.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(agent), 0o755))
+	for _, name := range []string{"Helper_One", "Helper_Two", "Helper_Three"} {
+		require.NoError(t, os.WriteFile(agent, []byte("---\nname: "+name+"\n---\nHelps.\n"), 0o600))
+		git("add", ".")
+		git("commit", "-q", "-m", name)
+	}
+
+	inv := testInvocation()
+	inv.rootPath = root
+
+	inv.outputFormat = "json"
+	out, _, err := captureStdout(t, func() (cmdResult, error) {
+		return resultOK, runChurn(inv, churnOptions{minCommits: 3, top: 20})
+	})
+	require.NoError(t, err)
+	var report stats.ChurnReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Len(t, report.Files, 1)
+	assert.Equal(t, ".claude/agents/helper.md", report.Files[0].File)
+	assert.Equal(t, 3, report.Files[0].Commits)
+	assert.Positive(t, report.Files[0].Errors)
+	assert.True(t, report.Files[0].Candidate)
+	assert.Equal(t, 1, report.Candidates)
+
+	inv.outputFormat = "console"
+	out, _, err = captureStdout(t, func() (cmdResult, error) {
+		return resultOK, runChurn(inv, churnOptions{minCommits: 4, top: 20})
+	})
+	require.NoError(t, err)
+	assert.Contains(t, out, "1 component changed in all history")
+	assert.Contains(t, out, ".claude/agents/helper.md")
+	assert.NotContains(t, out, "refactor candidate\n")
+	assert.Contains(t, out, "No refactor candidates")
+
+	for _, opts := range []churnOptions{{minCommits: 0}, {minCommits: 5, top: -1}} {
+		_, _, err = captureStdout(t, func() (cmdResult, error) { return resultOK, runChurn(inv, opts) })
+		assert.Equal(t, ExitUsage, exitCodeForError(err))
+	}
+
+	inv.rootPath = t.TempDir()
+	_, _, err = captureStdout(t, func() (cmdResult, error) { return resultOK, runChurn(inv, churnOptions{minCommits: 5}) })
+	assert.Equal(t, ExitUsage, exitCodeForError(err), "not a git repository")
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/textutil"
+	"github.com/spf13/cobra"
+)
+
+// newContextCmd builds the context command.
+func newContextCmd(inv *invocation) *cobra.Command {
+	contextCmd := &cobra.Command{
+		Use:   "context",
+		Short: "Lint CLAUDE.md context files and show their size and import trees",
+		Long: `Lint only the context files (CLAUDE.md and the other memory files), like
+cclint agents does for agents, and follow the findings with a summary
+section: for each file its lines and estimated tokens, its @path import
+tree with the size of each import, and its findings, then the tokens all
+context files load at session start, counting each import once.
+
+Imports are followed as Claude Code follows them: relative to the
+importing file, up to 5 levels deep. Missing imports are marked; imports
+outside the project are left out.
+
+The summary section is console output; other --format values print the
+lint report alone. The exit code follows --fail-on as for a full run.
+
+EXAMPLES:
+
+  cclint context
+  cclint context --format json`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runContext(inv, args)
+		}),
+	}
+
+	// The autofix flags work here as they do for "cclint agents".
+	contextCmd.Flags().BoolVar(&inv.fixMode, "fix", false, "Apply autofixes; they are rolled back if the tree then lints worse")
+	contextCmd.Flags().BoolVar(&inv.fixDryRun, "fix-dry-run", false, "Print the combined diff of all autofixes without writing")
+	return contextCmd
+}
+
+// runContext lints the context files. Further type names, as in
+// "cclint context agents", keep their root command meaning: each type is
+// linted in turn.
+func runContext(inv *invocation, args []string) (cmdResult, error) {
+	if len(args) > 0 {
+		return runRootCommand(inv, append([]string{"context"}, args...))
+	}
+	if err := validateFixFlags(inv, false); err != nil {
+		return cmdResult{}, asUsageError(err)
+	}
+	return runComponentLintWith(inv, "context", lint.LintContext, printContextReport)
+}
+
+// contextFile is one context file in the context summary.
+type contextFile struct {
+	rel      string
+	lines    int
+	tokens   int
+	imports  []crossfile.ContextImport
+	sizes    map[string]int // estimated tokens of each present import
+	findings string
+}
+
+// printContextReport prints the context summary section after the lint
+// findings, for console output.
+func printContextReport(cfg *config.Config, summary *lint.LintSummary) {
+	if cfg.Format != "console" || cfg.Quiet() || len(summary.Results) == 0 {
+		return
+	}
+	root := summary.ProjectRoot
+	files := make([]contextFile, 0, len(summary.Results))
+	for _, r := range summary.Results {
+		path := r.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+		data, err := os.ReadFile(path) //nolint:gosec // G304: context files discovered under the project root
+		if err != nil {
+			continue
+		}
+		contents := string(data)
+		f := contextFile{
+			rel:      displayPath(root, path),
+			lines:    textutil.CountLines(contents),
+			tokens:   textutil.EstimateTokens(contents),
+			imports:  crossfile.ContextImports(root, path, contents),
+			sizes:    make(map[string]int),
+			findings: findingCounts(r),
+		}
+		for _, imp := range f.imports {
+			if imp.Missing {
+				continue
+			}
+			if data, err := os.ReadFile(imp.Path); err == nil { //nolint:gosec // G304: import targets inside the project
+				f.sizes[imp.Path] = textutil.EstimateTokens(string(data))
+			}
+		}
+		files = append(files, f)
+	}
+	printContextFiles(files, root)
+}
+
+// printContextFiles prints each context file with its import tree, then
+// the session-start total. An import shared by several files is loaded,
+// and counted, once.
+func printContextFiles(files []contextFile, root string) {
+	styles := newPrintStyles()
+	fmt.Println()
+	fmt.Println(styles.header.Render("CONTEXT FILES"))
+
+	total := 0
+	loaded := make(map[string]bool)
+	for _, f := range files {
+		withImports := f.tokens
+		for _, size := range f.sizes {
+			withImports += size
+		}
+		fmt.Printf("  %s  %s\n", f.rel, styles.dim.Render(fmt.Sprintf("%d lines  ~%d tokens  ~%d with imports  %s", f.lines, f.tokens, withImports, f.findings)))
+		for _, imp := range f.imports {
+			indent := strings.Repeat("  ", imp.Depth)
+			if imp.Missing {
+				fmt.Printf("  %s@%s  %s\n", indent, imp.Ref, styles.tierDF.Render("missing"))
+				continue
+			}
+			fmt.Printf("  %s@%s  %s\n", indent, imp.Ref, styles.dim.Render(fmt.Sprintf("%s  ~%d tokens", displayPath(root, imp.Path), f.sizes[imp.Path])))
+		}
+
+		total += f.tokens
+		for path, size := range f.sizes {
+			if !loaded[path] {
+				loaded[path] = true
+				total += size
+			}
+		}
+	}
+	fmt.Printf("\n  %d context %s, %d %s, ~%d tokens loaded at session start\n",
+		len(files), pluralWord("file", len(files)), len(loaded), pluralWord("import", len(loaded)), total)
+}
+
+// findingCounts summarizes a result's findings for the context summary.
+func findingCounts(r lint.LintResult) string {
+	if len(r.Errors)+len(r.Warnings)+len(r.Suggestions) == 0 {
+		return "no findings"
+	}
+	return fmt.Sprintf("%dE %dW %dS", len(r.Errors), len(r.Warnings), len(r.Suggestions))
+}
+
+// displayPath shows path relative to root when it is inside it.
+func displayPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+func pluralWord(word string, n int) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
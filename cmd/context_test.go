@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextCommand(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, contents string) {
+		path := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+	write(".claude/agents/ignored.md", "---\nname: Not Linted\n---\n")
+	write("CLAUDE.md", "# Project\n\n@docs/guide.md\n\n## Build\n\nRun make.\n")
+	write("docs/guide.md", "# Guide\n\nSee @docs/gone.md\n")
+
+	inv := testInvocation()
+	inv.rootPath, inv.outputFormat, inv.quiet = root, "console", false
+
+	out, result, err := captureStdout(t, func() (cmdResult, error) {
+		return runComponentLintWith(inv, "context", lint.LintContext, printContextReport)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ExitFindings, result.ExitCode, "the nested import is missing")
+	assert.Contains(t, out, "CONTEXT FILES")
+	assert.Contains(t, out, "CLAUDE.md  8 lines")
+	assert.Contains(t, out, "    @docs/guide.md  docs/guide.md  ~7 tokens")
+	assert.Contains(t, out, "      @docs/gone.md  missing")
+	assert.Contains(t, out, "1 context file, 1 import, ~")
+	assert.NotContains(t, out, "ignored.md")
+
+	inv.outputFormat = "json"
+	out, _, err = captureStdout(t, func() (cmdResult, error) {
+		return runComponentLintWith(inv, "context", lint.LintContext, printContextReport)
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, out, "CONTEXT FILES", "the summary section is console only")
+}
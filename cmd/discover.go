@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dotcommander/cclint/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// newDiscoverCmd builds the discover command.
+func newDiscoverCmd(inv *invocation) *cobra.Command {
+	return &cobra.Command{
+		Use:   "discover",
+		Short: "List the files a lint run would check, and the candidates it skips",
+		Long: `Run discovery without linting: list each file a full run would check,
+its detected type, and the discovery pattern that matched it. Then list
+candidate files discovery passed over, with the reason:
+
+  excluded        matched an exclude pattern from config
+  binary          contains NUL bytes, so it is not a text component
+  symlink         refused by the symlinks policy
+  ambiguous type  a .md or .json file under a component directory, or
+                  named like a component file, that no pattern matches
+
+Use it to answer "why wasn't my agent linted?". Discovery honors the same
+exclude, fileTypes, and symlinks config as a lint run.
+
+With --format json the output is an object with root, files (path, type,
+pattern), and skipped (path, reason, detail) fields.
+
+EXAMPLES:
+
+  cclint discover
+  cclint discover --root ~/.claude
+  cclint discover --format json`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return resultOK, runDiscover(inv)
+		}),
+	}
+}
+
+// discoverReport is the --format json output of discover.
+type discoverReport struct {
+	Root    string            `json:"root"`
+	Files   []discoveredFile  `json:"files"`
+	Skipped []discoverSkipped `json:"skipped"`
+}
+
+type discoveredFile struct {
+	Path    string `json:"path"`
+	Type    string `json:"type"`
+	Pattern string `json:"pattern"`
+}
+
+type discoverSkipped struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func runDiscover(inv *invocation) error {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return err
+	}
+	if cfg.Format != "console" && cfg.Format != "json" {
+		return usageErrorf("discover supports --format console or json, not %q", cfg.Format)
+	}
+
+	root := cfg.Root
+	if root == "" {
+		if root, err = project.FindProjectRoot("."); err != nil {
+			return fmt.Errorf("error finding project root: %w", err)
+		}
+	}
+	explained, err := cfg.NewFileDiscovery(root).WithExclude(cfg.Exclude).Explain()
+	if err != nil {
+		return fmt.Errorf("error discovering files: %w", err)
+	}
+
+	report := discoverReport{
+		Root:    root,
+		Files:   make([]discoveredFile, len(explained.Files)),
+		Skipped: make([]discoverSkipped, len(explained.Skipped)),
+	}
+	for i, f := range explained.Files {
+		report.Files[i] = discoveredFile{Path: f.RelPath, Type: f.Type.String(), Pattern: f.Pattern}
+	}
+	for i, s := range explained.Skipped {
+		report.Skipped[i] = discoverSkipped{Path: s.RelPath, Reason: s.Reason, Detail: s.Detail}
+	}
+
+	if cfg.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printDiscoverReport(report)
+	return nil
+}
+
+// printDiscoverReport prints the discovered files and skipped candidates as
+// aligned columns.
+func printDiscoverReport(report discoverReport) {
+	styles := newPrintStyles()
+
+	files := "files"
+	if len(report.Files) == 1 {
+		files = "file"
+	}
+	fmt.Println(styles.header.Render(fmt.Sprintf("%d %s to lint under %s", len(report.Files), files, report.Root)))
+	typeWidth, pathWidth := 0, 0
+	for _, f := range report.Files {
+		typeWidth = max(typeWidth, len(f.Type))
+		pathWidth = max(pathWidth, len(f.Path))
+	}
+	for _, f := range report.Files {
+		fmt.Printf("  %-*s  %-*s  %s\n", typeWidth, f.Type, pathWidth, f.Path, styles.dim.Render(f.Pattern))
+	}
+
+	if len(report.Skipped) == 0 {
+		return
+	}
+	candidates := "candidates"
+	if len(report.Skipped) == 1 {
+		candidates = "candidate"
+	}
+	fmt.Println()
+	fmt.Println(styles.header.Render(fmt.Sprintf("%d %s skipped", len(report.Skipped), candidates)))
+	reasonWidth, pathWidth := 0, 0
+	for _, s := range report.Skipped {
+		reasonWidth = max(reasonWidth, len(s.Reason))
+		pathWidth = max(pathWidth, len(s.Path))
+	}
+	for _, s := range report.Skipped {
+		if s.Detail == "" {
+			fmt.Printf("  %-*s  %s\n", pathWidth, s.Path, s.Reason)
+			continue
+		}
+		fmt.Printf("  %-*s  %-*s  %s\n", pathWidth, s.Path, reasonWidth, s.Reason, styles.dim.Render(s.Detail))
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDiscover(t *testing.T) {
+	root := t.TempDir()
+	for path, contents := range map[string]string{
+		".claude/agents/reviewer.md": "---\nname: reviewer\n---\n",
+		".claude/agents/draft.md":    "---\nname: draft\n---\n",
+		".claude/agent/typo.md":      "---\nname: typo\n---\n",
+		".cclintrc.yaml":             "exclude:\n  - \"**/draft.md\"\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(contents), 0o600))
+	}
+
+	inv := testInvocation()
+	inv.rootPath = root
+
+	inv.outputFormat = "json"
+	out, _, err := captureStdout(t, func() (cmdResult, error) { return resultOK, runDiscover(inv) })
+	require.NoError(t, err)
+	var report discoverReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.Equal(t, []discoveredFile{{Path: ".claude/agents/reviewer.md", Type: "agent", Pattern: ".claude/agents/**/*.md"}}, report.Files)
+	assert.Equal(t, []discoverSkipped{
+		{Path: ".claude/agent/typo.md", Reason: "ambiguous type", Detail: "no discovery pattern matches; lint it directly with --type"},
+		{Path: ".claude/agents/draft.md", Reason: "excluded", Detail: "exclude pattern **/draft.md"},
+	}, report.Skipped)
+
+	inv.outputFormat = "console"
+	out, _, err = captureStdout(t, func() (cmdResult, error) { return resultOK, runDiscover(inv) })
+	require.NoError(t, err)
+	assert.Contains(t, out, "1 file to lint under "+root)
+	assert.Contains(t, out, "  agent  .claude/agents/reviewer.md  .claude/agents/**/*.md")
+	assert.Contains(t, out, "2 candidates skipped")
+
+	inv.outputFormat = "markdown"
+	err = runDiscover(inv)
+	assert.Equal(t, ExitUsage, exitCodeForError(err))
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes form the documented process contract for cclint.
+// Commands never exit themselves: they return a cmdResult and an error, and
+// Execute maps that outcome to a process exit through exitWithCode.
+const (
+	ExitClean    = 0 // no findings at or above the --fail-on threshold
+	ExitFindings = 1 // findings at or above the --fail-on threshold
+	ExitUsage    = 2 // invalid flags, arguments, or configuration
+	ExitInternal = 3 // unexpected failure while linting or writing output
+	ExitGates    = 4 // a quality gate in the gates config block failed
+)
+
+// exitFunc is the function called to exit the program.
+// It can be overridden in tests to prevent actual process termination.
+var exitFunc = os.Exit
+
+// usageError marks an error caused by the invocation (bad flags, paths,
+// or configuration) rather than by cclint itself.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// usageErrorf formats a usage error. Use %w to keep the wrapped cause.
+func usageErrorf(format string, args ...any) error {
+	return &usageError{err: fmt.Errorf(format, args...)}
+}
+
+// asUsageError marks err as a usage error. Returns nil for a nil error.
+func asUsageError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &usageError{err: err}
+}
+
+// exitCodeForError maps an error returned by a command to its exit code.
+func exitCodeForError(err error) int {
+	if err == nil {
+		return ExitClean
+	}
+	var ue *usageError
+	if errors.As(err, &ue) {
+		return ExitUsage
+	}
+	return ExitInternal
+}
+
+// cmdResult is the outcome of a successful command run. A run that fails
+// returns an error instead; exitCodeForError classifies it.
+type cmdResult struct {
+	ExitCode int
+}
+
+// resultOK is the outcome of a run with nothing to report.
+var resultOK = cmdResult{ExitCode: ExitClean}
+
+// resultKey is the context key under which Execute collects the cmdResult
+// of whichever command cobra dispatches to.
+type resultKey struct{}
+
+// runCommand adapts a command implementation to cobra's RunE and records
+// its result for Execute. Usage output stays reserved for flag and argument
+// errors that cobra reports before RunE is reached.
+func runCommand(run func(args []string) (cmdResult, error)) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		res, err := run(args)
+		if ctx := cmd.Context(); ctx != nil {
+			if sink, ok := ctx.Value(resultKey{}).(*cmdResult); ok {
+				*sink = res
+			}
+		}
+		return err
+	}
+}
+
+// executeRoot runs the root command and returns the exit code for its
+// outcome. Cobra has already printed any error by the time it returns.
+func executeRoot(rootCmd *cobra.Command) int {
+	var res cmdResult
+	ctx := context.WithValue(context.Background(), resultKey{}, &res)
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		return exitCodeForError(err)
+	}
+	return res.ExitCode
+}
+
+// exitWithCode terminates the process with code. ExitClean is a no-op so
+// callers can pass the result of a policy check unconditionally.
+func exitWithCode(code int) {
+	if code == ExitClean {
+		return
+	}
+	exitFunc(code)
+}
+
+// findingsExitCode applies the --fail-on policy to the given summaries.
+//
+// Summaries produced by a type-specific linter are judged against the
+// threshold for that type. Summaries without a component type (single-file
+// and git modes mix types) are judged per result using each result's type.
+func findingsExitCode(cfg *config.Config, summaries ...*lint.LintSummary) int {
+	policy, err := config.ParseFailOn(cfg.FailOn)
+	if err != nil {
+		// loadCLIConfig validates the value; fall back to the default policy.
+		policy = config.FailOnPolicy{Default: config.FailOnError}
+	}
+
+	for _, s := range summaries {
+		if s == nil {
+			continue
+		}
+		if s.ComponentType != "" || len(s.Results) == 0 {
+			if policy.ShouldFail(canonicalType(s.ComponentType), s.TotalErrors, s.TotalWarnings, s.TotalSuggestions) {
+				return ExitFindings
+			}
+			continue
+		}
+		for _, r := range s.Results {
+			if policy.ShouldFail(canonicalType(r.Type), len(r.Errors), len(r.Warnings), len(r.Suggestions)) {
+				return ExitFindings
+			}
+		}
+	}
+	return ExitClean
+}
+
+// canonicalType normalizes a component type name ("agents" → "agent") so
+// it matches the keys of a FailOnPolicy.
+func canonicalType(name string) string {
+	if ft, err := discovery.ParseFileType(name); err == nil {
+		return ft.String()
+	}
+	return name
+}
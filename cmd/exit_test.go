@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCodeForError(t *testing.T) {
+	assert.Equal(t, ExitClean, exitCodeForError(nil))
+	assert.Equal(t, ExitInternal, exitCodeForError(errors.New("boom")))
+	assert.Equal(t, ExitUsage, exitCodeForError(usageErrorf("bad flag %q", "x")))
+	assert.Equal(t, ExitUsage, exitCodeForError(asUsageError(errors.New("no such file"))))
+
+	// Usage classification survives further wrapping
+	wrapped := fmt.Errorf("outer: %w", usageErrorf("inner"))
+	assert.Equal(t, ExitUsage, exitCodeForError(wrapped))
+	assert.Nil(t, asUsageError(nil))
+}
+
+func TestExitWithCode(t *testing.T) {
+	originalExitFunc := exitFunc
+	var codes []int
+	exitFunc = func(code int) { codes = append(codes, code) }
+	defer func() { exitFunc = originalExitFunc }()
+
+	exitWithCode(ExitClean)
+	exitWithCode(ExitFindings)
+	exitWithCode(ExitInternal)
+
+	assert.Equal(t, []int{ExitFindings, ExitInternal}, codes)
+}
+
+func TestRunCommandRecordsResult(t *testing.T) {
+	var res cmdResult
+	ctx := context.WithValue(context.Background(), resultKey{}, &res)
+	c := &cobra.Command{Use: "probe"}
+	c.SetContext(ctx)
+
+	runE := runCommand(func(args []string) (cmdResult, error) {
+		return cmdResult{ExitCode: ExitFindings}, nil
+	})
+	require.NoError(t, runE(c, nil))
+	assert.Equal(t, ExitFindings, res.ExitCode)
+	assert.True(t, c.SilenceUsage, "usage is only shown for flag errors")
+
+	// Without a result sink in the context the run still succeeds
+	bare := &cobra.Command{Use: "bare"}
+	wantErr := usageErrorf("bad")
+	err := runCommand(func([]string) (cmdResult, error) { return cmdResult{}, wantErr })(bare, nil)
+	assert.ErrorIs(t, err, wantErr)
+}
+
+func TestFindingsExitCode(t *testing.T) {
+	agentWarnings := &lint.LintSummary{ComponentType: "agent", TotalWarnings: 2}
+	skillSuggestions := &lint.LintSummary{ComponentType: "skills", TotalSuggestions: 1}
+	mixed := &lint.LintSummary{
+		Results: []lint.LintResult{
+			{Type: "command", Warnings: []cue.ValidationError{{Severity: "warning"}}},
+			{Type: "agent", Warnings: []cue.ValidationError{{Severity: "warning"}}},
+		},
+		TotalWarnings: 2,
+	}
+
+	tests := []struct {
+		name      string
+		failOn    string
+		summaries []*lint.LintSummary
+		want      int
+	}{
+		{"errors only ignores warnings", "error", []*lint.LintSummary{agentWarnings}, ExitClean},
+		{"global warning threshold", "warning", []*lint.LintSummary{agentWarnings}, ExitFindings},
+		{"per-type warning threshold", "agents=warning", []*lint.LintSummary{agentWarnings}, ExitFindings},
+		{"per-type on other type", "commands=warning", []*lint.LintSummary{agentWarnings}, ExitClean},
+		{"plural summary type", "skill=suggestion", []*lint.LintSummary{skillSuggestions}, ExitFindings},
+		{"mixed summary per result", "error,agent=warning", []*lint.LintSummary{mixed}, ExitFindings},
+		{"mixed summary below threshold", "error,skill=warning", []*lint.LintSummary{mixed}, ExitClean},
+		{"nil summary", "suggestion", []*lint.LintSummary{nil}, ExitClean},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{FailOn: tt.failOn}
+			assert.Equal(t, tt.want, findingsExitCode(cfg, tt.summaries...))
+		})
+	}
+}
+
+func TestLoadCLIConfigRejectsInvalidFailOn(t *testing.T) {
+	inv := testInvocation()
+
+	inv.rootPath = t.TempDir()
+	inv.failOn = "agents=loud"
+
+	_, err := loadCLIConfig(inv)
+	assert.Error(t, err)
+	assert.Equal(t, ExitUsage, exitCodeForError(err))
+}
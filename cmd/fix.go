@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/fix"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// validateFixFlags rejects --fix and --fix-dry-run together, and with modes
+// they do not support: file paths and git modes (targeted) lint a subset of
+// the tree, so the post-fix check could not see what a fix breaks elsewhere,
+// and --baseline-create should record the tree as it is.
+func validateFixFlags(inv *invocation, targeted bool) error {
+	switch {
+	case !inv.fixMode && !inv.fixDryRun:
+		return nil
+	case inv.fixMode && inv.fixDryRun:
+		return errors.New("--fix and --fix-dry-run cannot be combined")
+	case targeted:
+		return errors.New("--fix and --fix-dry-run apply to full and component-type runs, not file paths or --diff/--staged")
+	case inv.createBaseline:
+		return errors.New("--fix and --fix-dry-run cannot be combined with --baseline-create")
+	}
+	return nil
+}
+
+// applyLintFixes handles --fix and --fix-dry-run after a lint run. With
+// --fix-dry-run it prints the combined diff of every autofix and reports
+// done, so the caller prints nothing else. With --fix it applies the fixes
+// in one transaction, re-runs linters, and keeps the fixes only when the
+// tree lints no worse than before (no more errors, no more warnings); the
+// returned result is the one to report.
+func applyLintFixes(inv *invocation, cfg *config.Config, linters []lint.LinterEntry, before *lint.Result) (after *lint.Result, done bool, err error) {
+	if !inv.fixMode && !inv.fixDryRun {
+		return before, false, nil
+	}
+	plan := lint.PlanFixes(before.Summaries)
+	edits := make([]fix.Edit, 0, len(plan))
+	applied, skipped := 0, 0
+	for _, ff := range plan {
+		edits = append(edits, fix.Edit{Path: ff.Path, Name: ff.RelPath, Before: ff.Before, After: ff.After})
+		applied += len(ff.Applied)
+		skipped += ff.Skipped
+	}
+
+	if inv.fixDryRun {
+		fmt.Print(fix.Diff(edits))
+		if !cfg.Quiet() {
+			fmt.Fprintf(os.Stderr, "%d fixes in %d files would be applied\n", applied, len(edits))
+		}
+		return before, true, nil
+	}
+	if len(edits) == 0 {
+		if !cfg.Quiet() {
+			fmt.Fprintln(os.Stderr, "No findings have an autofix")
+		}
+		return before, false, nil
+	}
+
+	tx, err := fix.Begin(edits)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := tx.Apply(); err != nil {
+		return nil, false, err
+	}
+	after, err = runOrchestratedLint(inv, cfg, linters)
+	if err != nil {
+		return nil, false, errors.Join(err, tx.Rollback())
+	}
+	if after.TotalErrors > before.TotalErrors || after.TotalWarnings > before.TotalWarnings {
+		if err := tx.Rollback(); err != nil {
+			return nil, false, err
+		}
+		if !cfg.Quiet() {
+			fmt.Fprintf(os.Stderr, "Fixes rolled back: they raised errors from %d to %d and warnings from %d to %d\n",
+				before.TotalErrors, after.TotalErrors, before.TotalWarnings, after.TotalWarnings)
+		}
+		return before, false, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	if !cfg.Quiet() {
+		fmt.Fprintf(os.Stderr, "Applied %d fixes in %d files", applied, len(edits))
+		if skipped > 0 {
+			fmt.Fprintf(os.Stderr, "; run --fix again for %d more", skipped)
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+	return after, false, nil
+}
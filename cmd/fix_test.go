@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFixFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		fix       bool
+		dryRun    bool
+		baseline  bool
+		targeted  bool
+		wantError bool
+	}{
+		{name: "neither flag", targeted: true, baseline: true},
+		{name: "fix on a full run", fix: true},
+		{name: "dry run on a full run", dryRun: true},
+		{name: "both flags", fix: true, dryRun: true, wantError: true},
+		{name: "fix with file paths", fix: true, targeted: true, wantError: true},
+		{name: "dry run with baseline create", dryRun: true, baseline: true, wantError: true},
+	}
+
+	inv := testInvocation()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv.fixMode, inv.fixDryRun, inv.createBaseline = tt.fix, tt.dryRun, tt.baseline
+			err := validateFixFlags(inv, tt.targeted)
+			assert.Equal(t, tt.wantError, err != nil, "validateFixFlags() error = %v", err)
+		})
+	}
+}
+
+func TestRunLintFix(t *testing.T) {
+	const before = "---\nname: fixer\ndescription: Use when checking fixes.\ntools: Read, Read, Grep\nmodel: sonnet\n---\n\nChecks fixes.\n"
+	const after = "---\nname: fixer\ndescription: Use when checking fixes.\ntools: Read, Grep\nmodel: sonnet\n---\n\nChecks fixes.\n"
+
+	tests := []struct {
+		name     string
+		dryRun   bool
+		wantFile string
+		wantOut  string
+	}{
+		{name: "dry run prints the diff", dryRun: true, wantFile: before, wantOut: "+ tools: Read, Grep"},
+		{name: "fix rewrites the file", wantFile: after},
+	}
+
+	inv := testInvocation()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			agent := filepath.Join(root, ".claude", "agents", "fixer.md")
+			require.NoError(t, os.MkdirAll(filepath.Dir(agent), 0o755))
+			require.NoError(t, os.WriteFile(agent, []byte(before), 0o600))
+
+			inv.rootPath, inv.quiet, inv.outputFormat = root, true, "console"
+			inv.fixMode, inv.fixDryRun = !tt.dryRun, tt.dryRun
+			out, _, err := captureStdout(t, func() (cmdResult, error) { return runLint(inv) })
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(agent)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFile, string(got))
+			assert.Contains(t, out, tt.wantOut)
+
+			entries, err := os.ReadDir(filepath.Dir(agent))
+			require.NoError(t, err)
+			assert.Len(t, entries, 1, "staged or backup files left behind")
+		})
+	}
+}
@@ -12,29 +12,48 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var (
-	fmtCheck bool
-	fmtWrite bool
-	fmtDiff  bool
-	fmtFiles []string // Explicit file paths
-	fmtType  string   // Force component type
-)
+// fmtOptions holds the flags of the fmt command.
+type fmtOptions struct {
+	check bool
+	write bool
+	diff  bool
+	files []string // Explicit file paths
+	typ   string   // Force component type
+
+	stripJSONC bool // Also rewrite settings JSONC as plain JSON (--strip-jsonc)
+}
 
-var fmtCmd = &cobra.Command{
-	Use:   "fmt [files...]",
-	Short: "Format Claude Code component files canonically",
-	Long: `Format Claude Code component files with canonical style.
+// newFmtCmd builds the fmt command.
+func newFmtCmd(inv *invocation) *cobra.Command {
+	opts := &fmtOptions{}
+	fmtCmd := &cobra.Command{
+		Use:   "fmt [files...]",
+		Short: "Format Claude Code component files canonically",
+		Long: `Format Claude Code component files with canonical style.
 
 FORMATTING RULES:
 
   Frontmatter:
   - Normalize field order: name, description, model, tools/allowed-tools, then alphabetical
   - Ensure exactly one blank line after frontmatter
+  - Indent with spaces instead of tabs
+  - Keep YAML anchors, aliases, and merge keys; with fmt.anchors: expand
+    in config, write out the values they stand for instead. Fields are not
+    reordered when that would move an alias above its anchor
 
   Markdown:
   - Trim trailing whitespace from lines
   - Ensure file ends with exactly one newline
 
+  Encoding (markdown and JSON components):
+  - Remove a leading UTF-8 byte order mark
+  - Use one line ending style: lineEndings from config, or the file's
+    most common style when it is auto
+  - Ensure file ends with a newline
+
+  Settings (only with --strip-jsonc):
+  - Remove // and /* */ comments and trailing commas, leaving plain JSON
+
 USAGE MODES:
 
   Format all components (preview):
@@ -54,6 +73,7 @@ FLAGS:
   --check      Exit 1 if files would change (for CI)
   -w, --write  Write changes in place
   --diff       Show diff of what would change
+  --strip-jsonc  Also remove JSONC comments and trailing commas from settings files
 
 EXAMPLES:
 
@@ -68,40 +88,39 @@ EXAMPLES:
 
   # Format all components
   cclint fmt --write`,
-	Args: cobra.ArbitraryArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := runFmt(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			exitFunc(1)
-		}
-	},
-}
-
-func init() {
-	rootCmd.AddCommand(fmtCmd)
+		Args: cobra.ArbitraryArgs,
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runFmt(inv, opts, args)
+		}),
+	}
 
-	fmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "Exit 1 if files would change (for CI)")
-	fmtCmd.Flags().BoolVarP(&fmtWrite, "write", "w", false, "Write changes in place")
-	fmtCmd.Flags().BoolVar(&fmtDiff, "diff", false, "Show diff of what would change")
-	fmtCmd.Flags().StringArrayVar(&fmtFiles, "file", nil, "Explicit file path(s) to format")
-	fmtCmd.Flags().StringVarP(&fmtType, "type", "t", "", "Force component type (agent|command|skill)")
+	fmtCmd.Flags().BoolVar(&opts.check, "check", false, "Exit 1 if files would change (for CI)")
+	fmtCmd.Flags().BoolVarP(&opts.write, "write", "w", false, "Write changes in place")
+	fmtCmd.Flags().BoolVar(&opts.diff, "diff", false, "Show diff of what would change")
+	fmtCmd.Flags().StringArrayVar(&opts.files, "file", nil, "Explicit file path(s) to format")
+	fmtCmd.Flags().StringVarP(&opts.typ, "type", "t", "", "Force component type (agent|command|skill)")
+	fmtCmd.Flags().BoolVar(&opts.stripJSONC, "strip-jsonc", false, "Also remove JSONC comments and trailing commas from settings files")
+	return fmtCmd
 }
 
-func runFmt(args []string) error {
+func runFmt(inv *invocation, opts *fmtOptions, args []string) (cmdResult, error) {
 	// Load configuration
-	cfg, err := config.LoadConfig(rootPath)
+	cfg, err := config.LoadConfig(inv.rootPath)
 	if err != nil {
-		return fmt.Errorf("error loading configuration: %w", err)
+		return cmdResult{}, usageErrorf("error loading configuration: %w", err)
+	}
+	if err := applyVerbosity(inv, cfg); err != nil {
+		return cmdResult{}, err
 	}
 
 	// Determine which files to format
-	filesToFormat, err := collectFilesToFormat(args, cfg.Root)
+	filesToFormat, err := collectFilesToFormat(opts, args, cfg)
 	if err != nil {
-		return err
+		return cmdResult{}, asUsageError(err)
 	}
 
 	if len(filesToFormat) == 0 {
-		return fmt.Errorf("no files to format")
+		return cmdResult{}, usageErrorf("no files to format")
 	}
 
 	// Format each file, collecting results
@@ -109,37 +128,39 @@ func runFmt(args []string) error {
 	totalFiles := len(filesToFormat)
 
 	for _, filePath := range filesToFormat {
-		changed, fmtErr := formatOneFile(filePath, cfg.Root)
+		changed, fmtErr := formatOneFile(inv, opts, filePath, cfg)
 		if fmtErr != nil {
-			return fmtErr
+			return cmdResult{}, fmtErr
 		}
 		if changed {
 			needsFormatting = append(needsFormatting, filePath)
 		}
 	}
 
-	printFmtSummary(totalFiles, len(needsFormatting))
+	printFmtSummary(inv, opts, totalFiles, len(needsFormatting))
 
-	// Check mode: exit 1 if files need formatting
-	if fmtCheck && len(needsFormatting) > 0 {
-		exitFunc(1)
+	// Check mode: files needing formatting are findings
+	if opts.check && len(needsFormatting) > 0 {
+		return cmdResult{ExitCode: ExitFindings}, nil
 	}
 
-	return nil
+	return resultOK, nil
 }
 
-// formatOneFile validates, reads, formats, and outputs a single file.
-// Returns true if the file needed formatting, or an error for fatal failures.
-func formatOneFile(filePath, root string) (bool, error) {
+// formatOneFile validates, reads, formats, and outputs a single file, with
+// line breaks in the style the lineEndings policy in cfg asks for and YAML
+// anchors handled as fmt.anchors says. Returns true if
+// the file needed formatting, or an error for fatal failures.
+func formatOneFile(inv *invocation, opts *fmtOptions, filePath string, cfg *config.Config) (bool, error) {
 	absPath, err := discovery.ValidateFilePath(filePath)
 	if err != nil {
-		if !quiet {
+		if !inv.quiet {
 			fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", filePath, err)
 		}
 		return false, nil
 	}
 
-	fileType, skip, err := resolveFileType(absPath, filePath, root)
+	fileType, skip, err := resolveFileType(inv, opts, absPath, filePath, cfg)
 	if err != nil {
 		return false, err
 	}
@@ -147,51 +168,62 @@ func formatOneFile(filePath, root string) (bool, error) {
 		return false, nil
 	}
 
-	if !strings.HasSuffix(strings.ToLower(absPath), ".md") {
-		if verbose {
-			fmt.Fprintf(os.Stderr, "Skipping %s: not a markdown file\n", filePath)
+	ext := strings.ToLower(filepath.Ext(absPath))
+	if ext != ".md" && ext != ".json" {
+		if inv.verbose {
+			fmt.Fprintf(os.Stderr, "Skipping %s: not a markdown or JSON file\n", filePath)
 		}
 		return false, nil
 	}
 
 	content, err := os.ReadFile(absPath)
 	if err != nil {
-		if !quiet {
+		if !inv.quiet {
 			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", filePath, err)
 		}
 		return false, nil
 	}
 
-	formatter := format.NewComponentFormatter(fileType.String())
-	formatted, err := formatter.Format(string(content))
-	if err != nil {
-		if !quiet {
-			fmt.Fprintf(os.Stderr, "Error formatting %s: %v\n", filePath, err)
+	// JSON components only get encoding fixes, plus comment stripping for
+	// settings with --strip-jsonc.
+	formatted, eol := format.ToLF(string(content), cfg.LineEndings)
+	if ext == ".json" {
+		if opts.stripJSONC && fileType == discovery.FileTypeSettings {
+			formatted = format.StripJSONC(formatted)
+		}
+	} else {
+		formatter := format.NewComponentFormatter(fileType.String(), formatOptions(cfg))
+		formatted, err = formatter.Format(formatted)
+		if err != nil {
+			if !inv.quiet {
+				fmt.Fprintf(os.Stderr, "Error formatting %s: %v\n", filePath, err)
+			}
+			return false, nil
 		}
-		return false, nil
 	}
+	formatted = format.FromLF(formatted, eol)
 
 	if string(content) == formatted {
-		if verbose {
+		if inv.verbose {
 			fmt.Printf("%s already formatted\n", filePath)
 		}
 		return false, nil
 	}
 
-	return true, emitFormatted(absPath, filePath, string(content), formatted)
+	return true, emitFormatted(inv, opts, absPath, filePath, string(content), formatted)
 }
 
 // resolveFileType determines the component type for a file. If the type cannot
 // be resolved (and is not a fatal error), skip is returned as true.
-func resolveFileType(absPath, displayPath, root string) (discovery.FileType, bool, error) {
-	if fmtType != "" {
-		ft, err := discovery.ParseFileType(fmtType)
-		return ft, false, err
+func resolveFileType(inv *invocation, opts *fmtOptions, absPath, displayPath string, cfg *config.Config) (discovery.FileType, bool, error) {
+	if opts.typ != "" {
+		ft, err := discovery.ParseFileType(opts.typ)
+		return ft, false, asUsageError(err)
 	}
 
-	ft, err := discovery.DetectFileType(absPath, root)
+	ft, err := cfg.DetectFileType(absPath, cfg.Root)
 	if err != nil {
-		if !quiet {
+		if !inv.quiet {
 			fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", displayPath, err)
 		}
 		return 0, true, nil
@@ -200,19 +232,19 @@ func resolveFileType(absPath, displayPath, root string) (discovery.FileType, boo
 }
 
 // emitFormatted writes or displays the formatted output based on the active mode.
-func emitFormatted(absPath, displayPath, original, formatted string) error {
+func emitFormatted(inv *invocation, opts *fmtOptions, absPath, displayPath, original, formatted string) error {
 	switch {
-	case fmtCheck:
-		if !quiet {
+	case opts.check:
+		if !inv.quiet {
 			fmt.Printf("%s needs formatting\n", displayPath)
 		}
-	case fmtDiff:
+	case opts.diff:
 		fmt.Print(format.Diff(original, formatted, displayPath))
-	case fmtWrite:
+	case opts.write:
 		if err := os.WriteFile(absPath, []byte(formatted), 0600); err != nil {
 			return fmt.Errorf("error writing %s: %w", absPath, err)
 		}
-		if !quiet {
+		if !inv.quiet {
 			fmt.Printf("Formatted %s\n", displayPath)
 		}
 	default:
@@ -222,8 +254,8 @@ func emitFormatted(absPath, displayPath, original, formatted string) error {
 }
 
 // printFmtSummary prints the formatting summary when multiple files were processed.
-func printFmtSummary(totalFiles, changedCount int) {
-	if quiet || totalFiles <= 1 {
+func printFmtSummary(inv *invocation, opts *fmtOptions, totalFiles, changedCount int) {
+	if inv.quiet || totalFiles <= 1 {
 		return
 	}
 
@@ -232,18 +264,21 @@ func printFmtSummary(totalFiles, changedCount int) {
 		return
 	}
 
-	if fmtWrite {
+	if opts.write {
 		fmt.Printf("\nFormatted %d of %d files\n", changedCount, totalFiles)
 	} else {
 		fmt.Printf("\n%d of %d files need formatting\n", changedCount, totalFiles)
 	}
 }
 
-// collectFilesToFormat determines which files to format based on args and flags.
-func collectFilesToFormat(args []string, rootPath string) ([]string, error) {
+// collectComponentFiles resolves the files a fmt-style command operates on:
+// explicit --file paths, else path and component type arguments, else
+// every discovered markdown component, plus JSON components when withJSON
+// is set.
+func collectComponentFiles(args, explicit []string, cfg *config.Config, withJSON bool) ([]string, error) {
 	// 1. Explicit --file flag
-	if len(fmtFiles) > 0 {
-		return fmtFiles, nil
+	if len(explicit) > 0 {
+		return explicit, nil
 	}
 
 	// 2. Check args for file paths
@@ -272,11 +307,11 @@ func collectFilesToFormat(args []string, rootPath string) ([]string, error) {
 
 	// 4. If we have component type arg, discover those files
 	if componentTypeArg != "" {
-		return discoverFilesByType(rootPath, componentTypeArg)
+		return discoverFilesByType(cfg, componentTypeArg)
 	}
 
 	// 5. No args: discover all component files
-	return discoverAllFiles(rootPath)
+	return discoverAllFiles(cfg, withJSON)
 }
 
 // resolvePathArgs expands a list of file/directory paths into individual file paths.
@@ -332,8 +367,8 @@ func discoverFilesInDir(dirPath string) ([]string, error) {
 }
 
 // discoverFilesByType discovers files of a specific component type.
-func discoverFilesByType(rootPath, componentType string) ([]string, error) {
-	discoverer := discovery.NewFileDiscovery(rootPath, false)
+func discoverFilesByType(cfg *config.Config, componentType string) ([]string, error) {
+	discoverer := cfg.NewFileDiscovery(cfg.Root)
 	allFiles, err := discoverer.DiscoverFiles()
 	if err != nil {
 		return nil, err
@@ -370,9 +405,10 @@ func discoverFilesByType(rootPath, componentType string) ([]string, error) {
 	return files, nil
 }
 
-// discoverAllFiles discovers all component files.
-func discoverAllFiles(rootPath string) ([]string, error) {
-	discoverer := discovery.NewFileDiscovery(rootPath, false)
+// discoverAllFiles discovers all markdown component files, and the JSON
+// ones when withJSON is set.
+func discoverAllFiles(cfg *config.Config, withJSON bool) ([]string, error) {
+	discoverer := cfg.NewFileDiscovery(cfg.Root)
 	allFiles, err := discoverer.DiscoverFiles()
 	if err != nil {
 		return nil, err
@@ -380,11 +416,21 @@ func discoverAllFiles(rootPath string) ([]string, error) {
 
 	var files []string
 	for _, f := range allFiles {
-		// Only format markdown files
-		if strings.HasSuffix(strings.ToLower(f.Path), ".md") {
+		ext := strings.ToLower(filepath.Ext(f.Path))
+		if ext == ".md" || (withJSON && ext == ".json") {
 			files = append(files, f.Path)
 		}
 	}
 
 	return files, nil
 }
+
+// collectFilesToFormat determines which files to format based on args and flags.
+func collectFilesToFormat(opts *fmtOptions, args []string, cfg *config.Config) ([]string, error) {
+	return collectComponentFiles(args, opts.files, cfg, true)
+}
+
+// formatOptions returns the formatter options the fmt config section sets.
+func formatOptions(cfg *config.Config) format.Options {
+	return format.Options{ExpandAnchors: cfg.Fmt.Anchors == config.AnchorsExpand}
+}
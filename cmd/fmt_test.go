@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,10 +22,10 @@ func TestIsComponentType(t *testing.T) {
 		{"context", true},
 		{"plugins", true},
 		{"rules", true},
-		{"AGENTS", true},  // Case insensitive
+		{"AGENTS", true}, // Case insensitive
 		{"Commands", true},
 		{"unknown", false},
-		{"agent", false},  // Singular form
+		{"agent", false}, // Singular form
 		{"./agents", false},
 		{"agents.md", false},
 		{"/path/to/agents", false},
@@ -116,11 +117,9 @@ func TestCollectFilesToFormat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Set test state
-			fmtFiles = tt.fmtFiles
-
 			// Run test
-			files, err := collectFilesToFormat(tt.args, tmpDir)
+			opts := &fmtOptions{files: tt.fmtFiles}
+			files, err := collectFilesToFormat(opts, tt.args, rootConfig(tmpDir))
 
 			if tt.wantError {
 				assert.Error(t, err)
@@ -128,9 +127,6 @@ func TestCollectFilesToFormat(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Len(t, files, tt.wantCount)
 			}
-
-			// Clean up
-			fmtFiles = nil
 		})
 	}
 }
@@ -249,7 +245,7 @@ func TestDiscoverFilesByType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			files, err := discoverFilesByType(tmpDir, tt.componentType)
+			files, err := discoverFilesByType(rootConfig(tmpDir), tt.componentType)
 
 			if tt.wantError {
 				assert.Error(t, err)
@@ -278,7 +274,7 @@ func TestDiscoverAllFiles(t *testing.T) {
 	require.NoError(t, os.WriteFile(command, []byte("# Command"), 0644))
 	require.NoError(t, os.WriteFile(jsonFile, []byte("{}"), 0644))
 
-	files, err := discoverAllFiles(tmpDir)
+	files, err := discoverAllFiles(rootConfig(tmpDir), false)
 	assert.NoError(t, err)
 
 	// Should find markdown files, but not json
@@ -370,20 +366,16 @@ End of file`
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Set global flags
-			oldRootPath := rootPath
-			oldQuiet := quiet
-			oldVerbose := verbose
-			oldFmtCheck := fmtCheck
-			oldFmtWrite := fmtWrite
-			oldFmtDiff := fmtDiff
-
-			rootPath = tmpDir
-			quiet = tt.quiet
-			verbose = false
-			fmtCheck = tt.fmtCheck
-			fmtWrite = tt.fmtWrite
-			fmtDiff = tt.fmtDiff
+			// Set flags
+			inv := testInvocation()
+			opts := &fmtOptions{}
+
+			inv.rootPath = tmpDir
+			inv.quiet = tt.quiet
+			inv.verbose = false
+			opts.check = tt.fmtCheck
+			opts.write = tt.fmtWrite
+			opts.diff = tt.fmtDiff
 
 			// Capture exit behavior
 			originalOsExit := osExit
@@ -393,7 +385,7 @@ End of file`
 			defer func() { osExit = originalOsExit }()
 
 			// Run test
-			err := runFmt(tt.args)
+			_, err := runFmt(inv, opts, tt.args)
 
 			if tt.wantError {
 				assert.Error(t, err)
@@ -402,14 +394,6 @@ End of file`
 				t.Logf("Got error: %v", err)
 			}
 
-			// Restore global flags
-			rootPath = oldRootPath
-			quiet = oldQuiet
-			verbose = oldVerbose
-			fmtCheck = oldFmtCheck
-			fmtWrite = oldFmtWrite
-			fmtDiff = oldFmtDiff
-
 			// Recreate test file for next test
 			_ = os.WriteFile(testFile, []byte(unformatted), 0644)
 		})
@@ -418,7 +402,7 @@ End of file`
 
 func TestFmtCmdFlags(t *testing.T) {
 	// Verify fmt command has expected flags
-	flags := fmtCmd.Flags()
+	flags := newFmtCmd(testInvocation()).Flags()
 
 	assert.NotNil(t, flags.Lookup("check"))
 	assert.NotNil(t, flags.Lookup("write"))
@@ -437,12 +421,10 @@ func TestCollectFilesToFormat_Precedence(t *testing.T) {
 	require.NoError(t, os.WriteFile(file2, []byte("# File 2"), 0644))
 
 	// Test that --file flag takes precedence over args
-	fmtFiles = []string{file1}
-	files, err := collectFilesToFormat([]string{file2}, tmpDir)
+	opts := &fmtOptions{files: []string{file1}}
+	files, err := collectFilesToFormat(opts, []string{file2}, rootConfig(tmpDir))
 	assert.NoError(t, err)
 	assert.Equal(t, []string{file1}, files)
-
-	fmtFiles = nil
 }
 
 func TestDiscoverFilesByType_AllTypes(t *testing.T) {
@@ -465,7 +447,7 @@ func TestDiscoverFilesByType_AllTypes(t *testing.T) {
 	for _, componentType := range componentTypes {
 		t.Run(componentType, func(t *testing.T) {
 			// Should not error even if no files found
-			files, err := discoverFilesByType(tmpDir, componentType)
+			files, err := discoverFilesByType(rootConfig(tmpDir), componentType)
 			assert.NoError(t, err)
 			// Files may be nil or empty slice when no files found - that's ok
 			_ = files
@@ -480,22 +462,16 @@ func TestRunFmt_NonMarkdownFile(t *testing.T) {
 	jsonFile := filepath.Join(tmpDir, "config.json")
 	require.NoError(t, os.WriteFile(jsonFile, []byte("{}"), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	rootPath = tmpDir
-	quiet = true
-	verbose = true
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.verbose = true
 
 	// When passing a non-markdown file, it may be skipped or processed
 	// The behavior depends on implementation - just verify no panic
-	err := runFmt([]string{jsonFile})
+	_, err := runFmt(inv, opts, []string{jsonFile})
 	_ = err // Behavior varies - just verify no panic
 }
 
@@ -516,24 +492,16 @@ Already formatted content.
 `
 	require.NoError(t, os.WriteFile(testFile, []byte(formatted), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldFmtWrite := fmtWrite
-	rootPath = tmpDir
-	quiet = false
-	verbose = true
-	fmtWrite = true
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		fmtWrite = oldFmtWrite
-	}()
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
+	inv.rootPath = tmpDir
+	inv.quiet = false
+	inv.verbose = true
+	opts.write = true
 
 	// Should handle already-formatted files gracefully
-	err := runFmt([]string{testFile})
+	_, err := runFmt(inv, opts, []string{testFile})
 	assert.NoError(t, err)
 }
 
@@ -550,7 +518,7 @@ func TestCollectFilesToFormat_MixedArgs(t *testing.T) {
 	require.NoError(t, os.WriteFile(customFile, []byte("# Custom"), 0644))
 
 	// Mix of component type and file path
-	files, err := collectFilesToFormat([]string{"agents", customFile}, tmpDir)
+	files, err := collectFilesToFormat(&fmtOptions{}, []string{"agents", customFile}, rootConfig(tmpDir))
 	assert.NoError(t, err)
 	// Should only get files from "agents" component type, not both
 	// because when component type is found, file args are ignored
@@ -573,23 +541,15 @@ Some content here.
 `
 	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldFmtType := fmtType
-	rootPath = tmpDir
-	quiet = true
-	verbose = false
-	fmtType = "agent" // Force type override
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		fmtType = oldFmtType
-	}()
-
-	err := runFmt([]string{testFile})
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.verbose = false
+	opts.typ = "agent" // Force type override
+
+	_, err := runFmt(inv, opts, []string{testFile})
 	assert.NoError(t, err)
 }
 
@@ -599,20 +559,14 @@ func TestRunFmt_InvalidTypeOverride(t *testing.T) {
 	testFile := filepath.Join(tmpDir, "test.md")
 	require.NoError(t, os.WriteFile(testFile, []byte("# Test"), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldFmtType := fmtType
-	rootPath = tmpDir
-	quiet = true
-	fmtType = "invalid-type"
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		fmtType = oldFmtType
-	}()
-
-	err := runFmt([]string{testFile})
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	opts.typ = "invalid-type"
+
+	_, err := runFmt(inv, opts, []string{testFile})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid type")
 }
@@ -638,23 +592,15 @@ Content.
 	require.NoError(t, os.WriteFile(file2, []byte(content), 0644))
 	require.NoError(t, os.WriteFile(file3, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldFmtWrite := fmtWrite
-	rootPath = tmpDir
-	quiet = false // Show summary
-	verbose = false
-	fmtWrite = true
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		fmtWrite = oldFmtWrite
-	}()
-
-	err := runFmt([]string{file1, file2, file3})
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
+	inv.rootPath = tmpDir
+	inv.quiet = false // Show summary
+	inv.verbose = false
+	opts.write = true
+
+	_, err := runFmt(inv, opts, []string{file1, file2, file3})
 	assert.NoError(t, err)
 }
 
@@ -678,20 +624,14 @@ Already formatted.
 	require.NoError(t, os.WriteFile(file1, []byte(content), 0644))
 	require.NoError(t, os.WriteFile(file2, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	rootPath = tmpDir
-	quiet = false // Show "All X files already formatted" message
-	verbose = true
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
-
-	err := runFmt([]string{file1, file2})
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
+	inv.rootPath = tmpDir
+	inv.quiet = false // Show "All X files already formatted" message
+	inv.verbose = true
+
+	_, err := runFmt(inv, opts, []string{file1, file2})
 	assert.NoError(t, err)
 }
 
@@ -702,20 +642,14 @@ func TestRunFmt_VerboseSkipNonMarkdown(t *testing.T) {
 	jsonFile := filepath.Join(tmpDir, "config.json")
 	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"key": "value"}`), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	rootPath = tmpDir
-	quiet = false
-	verbose = true // Enable verbose to show skip message
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
-
-	err := runFmt([]string{jsonFile})
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
+	inv.rootPath = tmpDir
+	inv.quiet = false
+	inv.verbose = true // Enable verbose to show skip message
+
+	_, err := runFmt(inv, opts, []string{jsonFile})
 	// May error because no valid markdown files
 	_ = err
 }
@@ -743,21 +677,15 @@ name: test
 	require.NoError(t, os.Chmod(tmpDir, 0555))
 	defer func() { _ = os.Chmod(tmpDir, 0755) }()
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldFmtWrite := fmtWrite
-	rootPath = tmpDir
-	quiet = true
-	fmtWrite = true
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		fmtWrite = oldFmtWrite
-	}()
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	opts.write = true
 
 	// This should return an error when trying to write
-	err := runFmt([]string{testFile})
+	_, err := runFmt(inv, opts, []string{testFile})
 	// Error expected due to permission issues
 	_ = err
 }
@@ -779,26 +707,21 @@ Formatted content.
 `
 	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldFmtCheck := fmtCheck
-	rootPath = tmpDir
-	quiet = true
-	fmtCheck = true
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		fmtCheck = oldFmtCheck
-	}()
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	opts.check = true
 
 	// Should not exit because file is already formatted
-	err := runFmt([]string{testFile})
+	_, err := runFmt(inv, opts, []string{testFile})
 	assert.NoError(t, err)
 }
 
 func TestFmtCmdLongDescription(t *testing.T) {
 	// Test long description content
+	fmtCmd := newFmtCmd(testInvocation())
 	assert.Contains(t, fmtCmd.Long, "FORMATTING RULES")
 	assert.Contains(t, fmtCmd.Long, "USAGE MODES")
 	assert.Contains(t, fmtCmd.Long, "Frontmatter")
@@ -825,38 +748,20 @@ Content here.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Mock exitFunc to prevent test termination
-	originalExitFunc := exitFunc
-	exitCalled := false
-	exitCode := -1
-	exitFunc = func(code int) {
-		exitCalled = true
-		exitCode = code
-	}
-	defer func() { exitFunc = originalExitFunc }()
-
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldFmtCheck := fmtCheck
-
-	rootPath = tmpDir
-	quiet = false // Show "needs formatting" message
-	fmtCheck = true
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		fmtCheck = oldFmtCheck
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = false // Show "needs formatting" message
+	opts.check = true
 
 	// Run the function
-	err := runFmt([]string{agentPath})
+	res, err := runFmt(inv, opts, []string{agentPath})
 	assert.NoError(t, err)
 
-	// Should have called exit with code 1
-	assert.True(t, exitCalled, "Should call exit in check mode")
-	assert.Equal(t, 1, exitCode, "Exit code should be 1")
+	// Check mode reports findings through the result, not a process exit
+	assert.Equal(t, ExitFindings, res.ExitCode, "Exit code should be 1")
 }
 
 func TestRunFmt_WriteModeSummary(t *testing.T) {
@@ -882,22 +787,15 @@ Content.
 	require.NoError(t, os.WriteFile(file1, []byte(content), 0644))
 	require.NoError(t, os.WriteFile(file2, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldFmtWrite := fmtWrite
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
 
-	rootPath = tmpDir
-	quiet = false // Show summary
-	fmtWrite = true
+	inv.rootPath = tmpDir
+	inv.quiet = false // Show summary
+	opts.write = true
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		fmtWrite = oldFmtWrite
-	}()
-
-	err := runFmt([]string{file1, file2})
+	_, err := runFmt(inv, opts, []string{file1, file2})
 	assert.NoError(t, err)
 }
 
@@ -921,28 +819,17 @@ Content.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags - default mode (no check, no write, no diff)
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldFmtCheck := fmtCheck
-	oldFmtWrite := fmtWrite
-	oldFmtDiff := fmtDiff
-
-	rootPath = tmpDir
-	quiet = true
-	fmtCheck = false
-	fmtWrite = false
-	fmtDiff = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		fmtCheck = oldFmtCheck
-		fmtWrite = oldFmtWrite
-		fmtDiff = oldFmtDiff
-	}()
-
-	err := runFmt([]string{agentPath})
+	// Set flags - default mode (no check, no write, no diff)
+	inv := testInvocation()
+	opts := &fmtOptions{}
+
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	opts.check = false
+	opts.write = false
+	opts.diff = false
+
+	_, err := runFmt(inv, opts, []string{agentPath})
 	assert.NoError(t, err)
 }
 
@@ -968,25 +855,16 @@ Content.
 	require.NoError(t, os.WriteFile(file1, []byte(content), 0644))
 	require.NoError(t, os.WriteFile(file2, []byte(content), 0644))
 
-	// Set global flags - neither write nor check mode
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldFmtCheck := fmtCheck
-	oldFmtWrite := fmtWrite
-
-	rootPath = tmpDir
-	quiet = false // Show summary
-	fmtCheck = false
-	fmtWrite = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		fmtCheck = oldFmtCheck
-		fmtWrite = oldFmtWrite
-	}()
-
-	err := runFmt([]string{file1, file2})
+	// Set flags - neither write nor check mode
+	inv := testInvocation()
+	opts := &fmtOptions{}
+
+	inv.rootPath = tmpDir
+	inv.quiet = false // Show summary
+	opts.check = false
+	opts.write = false
+
+	_, err := runFmt(inv, opts, []string{file1, file2})
 	assert.NoError(t, err)
 }
 
@@ -1009,20 +887,15 @@ model: sonnet
 `
 	require.NoError(t, os.WriteFile(validFile, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
 
-	rootPath = tmpDir
-	quiet = true // Quiet mode - suppress skip messages
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true // Quiet mode - suppress skip messages
 
 	// Pass a non-existent file along with a valid one
-	err := runFmt([]string{filepath.Join(tmpDir, "nonexistent.md"), validFile})
+	_, err := runFmt(inv, opts, []string{filepath.Join(tmpDir, "nonexistent.md"), validFile})
 	// May return error for non-existent file
 	_ = err
 }
@@ -1045,23 +918,16 @@ invalid yaml: [
 `
 	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-
-	rootPath = tmpDir
-	quiet = false
-	verbose = true
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = false
+	inv.verbose = true
 
 	// Should handle format errors gracefully
-	err := runFmt([]string{testFile})
+	_, err := runFmt(inv, opts, []string{testFile})
 	// May return error or skip
 	_ = err
 }
@@ -1085,23 +951,16 @@ model: sonnet
 `
 	require.NoError(t, os.WriteFile(validFile, []byte(content), 0644))
 
-	// Set global flags for quiet mode
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
+	// Set flags for quiet mode
+	inv := testInvocation()
+	opts := &fmtOptions{}
 
-	rootPath = tmpDir
-	quiet = false
-	verbose = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = false
+	inv.verbose = false
 
 	// Run with valid file - should not error
-	err := runFmt([]string{validFile})
+	_, err := runFmt(inv, opts, []string{validFile})
 	assert.NoError(t, err)
 }
 
@@ -1125,22 +984,15 @@ Already formatted content.
 `
 	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-
-	rootPath = tmpDir
-	quiet = false
-	verbose = true // Verbose to show "already formatted" message
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = false
+	inv.verbose = true // Verbose to show "already formatted" message
 
-	err := runFmt([]string{testFile})
+	_, err := runFmt(inv, opts, []string{testFile})
 	assert.NoError(t, err)
 }
 
@@ -1164,39 +1016,24 @@ Content.
 `
 	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
 
-	// Mock exitFunc
-	originalExitFunc := exitFunc
-	exitCalled := false
-	exitFunc = func(code int) {
-		exitCalled = true
-	}
-	defer func() { exitFunc = originalExitFunc }()
-
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldFmtCheck := fmtCheck
-
-	rootPath = tmpDir
-	quiet = true // Quiet mode in check
-	fmtCheck = true
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		fmtCheck = oldFmtCheck
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true // Quiet mode in check
+	opts.check = true
 
-	err := runFmt([]string{testFile})
+	res, err := runFmt(inv, opts, []string{testFile})
 	assert.NoError(t, err)
-	assert.True(t, exitCalled)
+	assert.Equal(t, ExitFindings, res.ExitCode)
 }
 
 func TestCollectFilesToFormat_NonExistentPath(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	// Test with non-existent path
-	_, err := collectFilesToFormat([]string{"/nonexistent/path/file.md"}, tmpDir)
+	_, err := collectFilesToFormat(&fmtOptions{}, []string{"/nonexistent/path/file.md"}, rootConfig(tmpDir))
 	assert.Error(t, err)
 }
 
@@ -1231,20 +1068,15 @@ func TestRunFmt_ReadError(t *testing.T) {
 	require.NoError(t, os.Chmod(testFile, 0000))
 	defer func() { _ = os.Chmod(testFile, 0644) }()
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
+	// Set flags
+	inv := testInvocation()
+	opts := &fmtOptions{}
 
-	rootPath = tmpDir
-	quiet = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = false
 
 	// Should handle read error gracefully
-	err := runFmt([]string{testFile})
+	_, err := runFmt(inv, opts, []string{testFile})
 	_ = err
 }
 
@@ -1255,22 +1087,49 @@ func TestRunFmt_DetectTypeError(t *testing.T) {
 	testFile := filepath.Join(tmpDir, "random.md")
 	require.NoError(t, os.WriteFile(testFile, []byte("# Test"), 0644))
 
-	// Set global flags - no type override
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldFmtType := fmtType
-
-	rootPath = tmpDir
-	quiet = false
-	fmtType = "" // No type override
+	// Set flags - no type override
+	inv := testInvocation()
+	opts := &fmtOptions{}
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		fmtType = oldFmtType
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = false
+	opts.typ = "" // No type override
 
 	// Should skip file that can't be type-detected
-	err := runFmt([]string{testFile})
+	_, err := runFmt(inv, opts, []string{testFile})
 	_ = err
 }
+
+func TestRunFmt_WriteNormalizesEncoding(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	agentPath := filepath.Join(tmpDir, ".claude", "agents", "test.md")
+	settingsPath := filepath.Join(tmpDir, ".claude", "settings.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(agentPath), 0755))
+	require.NoError(t, os.WriteFile(agentPath, []byte("\uFEFF---\r\nname: test\r\ndescription: d\r\n---\r\nBody.\r\n"), 0644))
+	require.NoError(t, os.WriteFile(settingsPath, []byte("{\n  \"model\": \"sonnet\"\r\n}"), 0644))
+
+	inv := testInvocation()
+	opts := &fmtOptions{}
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	opts.write = true
+
+	_, err := runFmt(inv, opts, []string{agentPath, settingsPath})
+	require.NoError(t, err)
+
+	agent, err := os.ReadFile(agentPath)
+	require.NoError(t, err)
+	assert.Equal(t, "---\r\nname: test\r\ndescription: d\r\n---\r\nBody.\r\n", string(agent), "BOM removed, CRLF kept")
+
+	settings, err := os.ReadFile(settingsPath)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"model\": \"sonnet\"\n}\n", string(settings), "tie resolves to LF, final newline added")
+}
+
+// rootConfig returns the default configuration rooted at root.
+func rootConfig(root string) *config.Config {
+	cfg := config.Default()
+	cfg.Root = root
+	return cfg
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/fixture"
+	"github.com/dotcommander/cclint/internal/selftest"
+	"github.com/spf13/cobra"
+)
+
+// generateFixtureOptions are the generate-fixture command's flags.
+type generateFixtureOptions struct {
+	agents   int
+	skills   int
+	commands int
+	errors   string
+	seed     uint64
+}
+
+// newGenerateFixtureCmd builds the generate-fixture command.
+func newGenerateFixtureCmd(inv *invocation) *cobra.Command {
+	var opts generateFixtureOptions
+	generateCmd := &cobra.Command{
+		Use:   "generate-fixture [dir]",
+		Short: "Generate a synthetic .claude tree with seeded problems",
+		Long: `Write a synthetic but realistic .claude tree of agents, skills, and
+commands to dir (default the current directory), for demos, benchmarks,
+and reproducing bug reports. --errors seeds that share of the components
+with a known problem, such as an unknown tool or an invalid field value.
+
+The tree depends only on the flags: the same sizes, --errors, and --seed
+always produce the same files, so a report can name them instead of
+attaching the tree.
+
+An expect.yaml next to .claude lists the finding each seeded problem
+causes, in the format of cclint selftest, so a tree generated into a
+subdirectory of .cclint/selftest becomes a fixture. dir must not have a
+.claude directory already.
+
+EXAMPLES:
+
+  cclint generate-fixture /tmp/demo
+  cclint generate-fixture --agents 50 --skills 100 --errors 10% /tmp/bench
+  cclint generate-fixture --seed 42 .cclint/selftest/seed-42`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return resultOK, runGenerateFixture(inv, opts, args)
+		}),
+	}
+	generateCmd.Flags().IntVar(&opts.agents, "agents", 10, "agents to generate")
+	generateCmd.Flags().IntVar(&opts.skills, "skills", 10, "skills to generate")
+	generateCmd.Flags().IntVar(&opts.commands, "commands", 10, "commands to generate")
+	generateCmd.Flags().StringVar(&opts.errors, "errors", "0%", "share of components seeded with a problem, e.g. 10%")
+	generateCmd.Flags().Uint64Var(&opts.seed, "seed", 1, "seed for the generator; the same seed gives the same tree")
+	return generateCmd
+}
+
+func runGenerateFixture(inv *invocation, opts generateFixtureOptions, args []string) error {
+	if opts.agents < 0 || opts.skills < 0 || opts.commands < 0 {
+		return usageErrorf("invalid component count: must not be negative")
+	}
+	rate, err := parsePercent(opts.errors)
+	if err != nil {
+		return usageErrorf("invalid --errors: %w", err)
+	}
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	tree := fixture.Generate(fixture.Options{
+		Agents:    opts.agents,
+		Skills:    opts.skills,
+		Commands:  opts.commands,
+		ErrorRate: rate,
+		Seed:      opts.seed,
+	})
+	if err := tree.Write(dir); err != nil {
+		return fmt.Errorf("error writing fixture: %w", err)
+	}
+	if !inv.quiet {
+		fmt.Printf("Generated %d agents, %d skills, and %d commands in %s (seed %d)\n", opts.agents, opts.skills, opts.commands, dir, opts.seed)
+		fmt.Printf("Seeded %d problems, listed in %s\n", len(tree.Seeded), filepath.Join(dir, selftest.ExpectFile))
+	}
+	return nil
+}
+
+// parsePercent reads a percentage such as "10%" or "10" as a share from 0
+// to 1.
+func parsePercent(s string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil || n < 0 || n > 100 {
+		return 0, fmt.Errorf("%q is not a percentage from 0%% to 100%%", s)
+	}
+	return n / 100, nil
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunGenerateFixture(t *testing.T) {
+	inv := testInvocation()
+	inv.quiet = false
+	corpus := t.TempDir()
+	dir := filepath.Join(corpus, "seed-7")
+
+	out, _, err := captureStdout(t, func() (cmdResult, error) {
+		return resultOK, runGenerateFixture(inv, generateFixtureOptions{agents: 8, skills: 12, commands: 6, errors: "25%", seed: 7}, []string{dir})
+	})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Generated 8 agents, 12 skills, and 6 commands")
+	assert.Contains(t, out, "Seeded 7 problems")
+	agents, err := os.ReadDir(filepath.Join(dir, ".claude/agents"))
+	require.NoError(t, err)
+	assert.Len(t, agents, 8)
+
+	// The seeded problems are reported as expect.yaml says
+	out, result, err := captureStdout(t, func() (cmdResult, error) { return runSelftest(inv, []string{corpus}) })
+	require.NoError(t, err)
+	assert.Equal(t, resultOK, result, out)
+
+	err = runGenerateFixture(inv, generateFixtureOptions{agents: 1, errors: "0%"}, []string{dir})
+	assert.ErrorContains(t, err, "already has a .claude directory")
+
+	for _, opts := range []generateFixtureOptions{{agents: -1, errors: "0"}, {errors: "150%"}, {errors: "ten"}} {
+		err := runGenerateFixture(inv, opts, []string{t.TempDir()})
+		assert.Equal(t, ExitUsage, exitCodeForError(err), opts.errors)
+	}
+}
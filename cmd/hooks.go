@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// simulateOptions holds the flags of hooks simulate.
+type simulateOptions struct {
+	event string // hook event to simulate (--event)
+	tool  string // tool name tool-event matchers test (--tool)
+	match string // value other events' matchers test (--match)
+	input string // tool input JSON, or @file (--input)
+	exec  bool   // run the command hooks that fire (--exec)
+}
+
+// defaultHookTimeout is how long --exec lets a command hook run when it
+// sets no timeout, matching Claude Code's default.
+const defaultHookTimeout = 60 * time.Second
+
+// newHooksCmd builds the hooks command and its subcommands.
+func newHooksCmd(inv *invocation) *cobra.Command {
+	hooksCmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Inspect the hooks configured for a project",
+		Long: `Inspect the hooks configured in settings, plugins, and agent and skill
+frontmatter. See "cclint hooks simulate".`,
+	}
+
+	opts := &simulateOptions{}
+	hooksSimulateCmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Show which hooks would fire for an event, without running them",
+		Long: `Replay a hook event against the configured hooks and list which would fire,
+in the order they are configured, and why each of the others would not.
+Nothing runs unless --exec is given.
+
+Hooks are read from settings.json and settings.local.json, plugin
+manifests and hook files, and agent and skill frontmatter; component hooks
+load only while their agent or skill runs, and are marked with it. Claude
+Code starts the hooks that fire in parallel, and runs identical commands
+once.
+
+For tool events (PreToolUse, PostToolUse, PostToolUseFailure,
+PermissionRequest) matchers are tested against --tool: a matcher is a regex
+that must match the whole tool name, and "*" or an empty matcher matches
+every tool. --input is the tool input as JSON, or @file to read it from a
+file; the argument patterns of object matchers and "if" conditions, such
+as "Bash(git *)", are tested against its command, file_path, or url. For
+other events, --match is the value their matchers test, such as the
+SessionStart source or the PreCompact trigger.
+
+--exec runs each command hook that fires with the event JSON on stdin, in a
+scratch directory with a minimal environment (PATH, HOME and TMPDIR in the
+scratch directory, CLAUDE_PROJECT_DIR, and CLAUDE_PLUGIN_ROOT for plugin
+hooks), and reports its exit code and output. Exit code 2 is a block. This
+keeps hooks away from the project tree but is not a security boundary: run
+only hooks you trust.
+
+EXAMPLES:
+
+  cclint hooks simulate --event PreToolUse --tool Bash --input '{"command":"git push"}'
+  cclint hooks simulate --event PostToolUse --tool Edit --input @edit.json --exec
+  cclint hooks simulate --event SessionStart --match startup --format json`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return runHooksSimulate(inv, opts)
+		}),
+	}
+
+	hooksSimulateCmd.Flags().StringVar(&opts.event, "event", "", "Hook event to simulate, e.g. PreToolUse (required)")
+	hooksSimulateCmd.Flags().StringVar(&opts.tool, "tool", "", "Tool name for tool events, e.g. Bash")
+	hooksSimulateCmd.Flags().StringVar(&opts.match, "match", "", "Value matchers test for other events, e.g. startup for SessionStart")
+	hooksSimulateCmd.Flags().StringVar(&opts.input, "input", "", "Tool input as a JSON object, or @file")
+	hooksSimulateCmd.Flags().BoolVar(&opts.exec, "exec", false, "Run the command hooks that fire, in a scratch directory")
+	hooksCmd.AddCommand(hooksSimulateCmd)
+	return hooksCmd
+}
+
+// simulatedRun is a simulated hook with the outcome of running it under
+// --exec.
+type simulatedRun struct {
+	lint.SimulatedHook
+	Exec *hookExecution `json:"exec,omitempty"`
+}
+
+// hookExecution is the outcome of running a command hook.
+type hookExecution struct {
+	ExitCode int    `json:"exitCode"`
+	Blocks   bool   `json:"blocks,omitempty"` // exit code 2
+	Stdout   string `json:"stdout,omitempty"`
+	Stderr   string `json:"stderr,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// hooksSimulateReport is the JSON report of hooks simulate.
+type hooksSimulateReport struct {
+	Event string         `json:"event"`
+	Tool  string         `json:"tool,omitempty"`
+	Match string         `json:"match,omitempty"`
+	Hooks []simulatedRun `json:"hooks"`
+}
+
+func runHooksSimulate(inv *invocation, opts *simulateOptions) (cmdResult, error) {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	if cfg.Format != "console" && cfg.Format != "json" {
+		return cmdResult{}, usageErrorf("hooks simulate supports --format console or json, not %q", cfg.Format)
+	}
+	event, err := simulatedEvent(opts)
+	if err != nil {
+		return cmdResult{}, err
+	}
+
+	root := cfg.Root
+	if root == "" {
+		if root, err = project.FindProjectRoot("."); err != nil {
+			return cmdResult{}, fmt.Errorf("error finding project root: %w", err)
+		}
+	}
+	files, err := cfg.NewFileDiscovery(root).WithExclude(cfg.Exclude).DiscoverFiles()
+	if err != nil {
+		return cmdResult{}, fmt.Errorf("error discovering files: %w", err)
+	}
+
+	hooks := lint.SimulateHooks(lint.CollectHookSources(files, root), event)
+	runs := make([]simulatedRun, len(hooks))
+	for i, h := range hooks {
+		runs[i] = simulatedRun{SimulatedHook: h}
+		if opts.exec && h.Fires && h.Type == cue.TypeCommand {
+			runs[i].Exec = execHook(h, event, root)
+		}
+	}
+
+	if cfg.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return resultOK, enc.Encode(hooksSimulateReport{Event: event.Name, Tool: opts.tool, Match: opts.match, Hooks: runs})
+	}
+	printHookSimulation(event, runs, opts.exec)
+	return resultOK, nil
+}
+
+// simulatedEvent builds the event to simulate from the flags.
+func simulatedEvent(opts *simulateOptions) (lint.HookEvent, error) {
+	if opts.event == "" {
+		return lint.HookEvent{}, usageErrorf("--event is required; one of: %s", strings.Join(lint.HookEventNames(), ", "))
+	}
+	if !lint.IsHookEvent(opts.event) {
+		return lint.HookEvent{}, usageErrorf("unknown hook event %q; one of: %s", opts.event, strings.Join(lint.HookEventNames(), ", "))
+	}
+	event := lint.HookEvent{Name: opts.event, Target: opts.match}
+	if lint.IsToolHookEvent(opts.event) {
+		if opts.match != "" {
+			return event, usageErrorf("%s matchers test the tool name; use --tool, not --match", opts.event)
+		}
+		event.Target = opts.tool
+	} else if opts.tool != "" || opts.input != "" {
+		return event, usageErrorf("--tool and --input apply to tool events; %s matchers test --match", opts.event)
+	}
+
+	if opts.input != "" {
+		raw := []byte(opts.input)
+		if path, ok := strings.CutPrefix(opts.input, "@"); ok {
+			data, err := os.ReadFile(path) //nolint:gosec // G304: the user names the input file
+			if err != nil {
+				return event, usageErrorf("error reading --input: %w", err)
+			}
+			raw = data
+		}
+		if err := json.Unmarshal(raw, &event.Input); err != nil || event.Input == nil {
+			return event, usageErrorf("--input must be a JSON object: %v", err)
+		}
+	}
+	return event, nil
+}
+
+// execHook runs a command hook the way Claude Code would, with the event
+// JSON on stdin, but in a scratch directory with a minimal environment.
+func execHook(h lint.SimulatedHook, event lint.HookEvent, root string) *hookExecution {
+	scratch, err := os.MkdirTemp("", "cclint-hook-")
+	if err != nil {
+		return &hookExecution{ExitCode: -1, Error: err.Error()}
+	}
+	defer func() { _ = os.RemoveAll(scratch) }()
+
+	timeout := defaultHookTimeout
+	if secs, ok := h.Hook["timeout"].(float64); ok && secs > 0 {
+		timeout = time.Duration(secs * float64(time.Second))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	command, _ := h.Hook["command"].(string)
+	var cmd *exec.Cmd
+	if args, ok := h.Hook["args"].([]any); ok {
+		argv := make([]string, len(args))
+		for i, a := range args {
+			argv[i] = fmt.Sprint(a)
+		}
+		cmd = exec.CommandContext(ctx, command, argv...) //nolint:gosec // G204: --exec runs the project's own hooks
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // G204: --exec runs the project's own hooks
+	}
+	cmd.Dir = scratch
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + scratch,
+		"TMPDIR=" + scratch,
+		"CLAUDE_PROJECT_DIR=" + root,
+	}
+	if h.Dir != "" {
+		cmd.Env = append(cmd.Env, "CLAUDE_PLUGIN_ROOT="+h.Dir)
+	}
+	cmd.Stdin = bytes.NewReader(hookPayload(event, root))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	result := &hookExecution{}
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		result.ExitCode, result.Error = -1, fmt.Sprintf("timed out after %s", timeout)
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		result.ExitCode, result.Error = -1, err.Error()
+	}
+	result.Blocks = result.ExitCode == 2
+	result.Stdout = strings.TrimSpace(stdout.String())
+	result.Stderr = strings.TrimSpace(stderr.String())
+	return result
+}
+
+// hookPayload is the JSON a hook reads on stdin for event.
+func hookPayload(event lint.HookEvent, root string) []byte {
+	payload := map[string]any{
+		"session_id":      "cclint-simulate",
+		"transcript_path": "",
+		"cwd":             root,
+		"hook_event_name": event.Name,
+	}
+	if lint.IsToolHookEvent(event.Name) {
+		payload["tool_name"] = event.Target
+		payload["tool_input"] = event.Input
+	}
+	data, _ := json.Marshal(payload)
+	return data
+}
+
+// printHookSimulation prints the hooks that would fire, in order, then the
+// others with the reason each does not.
+func printHookSimulation(event lint.HookEvent, runs []simulatedRun, exec bool) {
+	styles := newPrintStyles()
+	target := ""
+	if event.Target != "" {
+		target = " for " + event.Target
+	}
+	if len(runs) == 0 {
+		fmt.Printf("No hooks configured for %s\n", event.Name)
+		return
+	}
+
+	var fired, skipped []simulatedRun
+	for _, r := range runs {
+		if r.Fires {
+			fired = append(fired, r)
+		} else {
+			skipped = append(skipped, r)
+		}
+	}
+
+	fmt.Println(styles.header.Render(fmt.Sprintf("%s%s: %d of %d %s would fire", event.Name, target, len(fired), len(runs), pluralWord("hook", len(runs)))))
+	for i, r := range fired {
+		fmt.Printf("\n  %d. %s\n", i+1, hookLocation(r.SimulatedHook, styles))
+		fmt.Printf("     %s %s\n", r.Type, r.Run)
+		if r.Scope != "" {
+			fmt.Println(styles.dim.Render("     only while " + r.Scope + " runs"))
+		}
+		if r.Reason != "" {
+			fmt.Println(styles.dim.Render("     " + r.Reason))
+		}
+		if exec && r.Exec == nil {
+			fmt.Println(styles.dim.Render("     not run: --exec runs command hooks only"))
+		}
+		if r.Exec != nil {
+			printHookExecution(r.Exec, styles)
+		}
+	}
+
+	if len(skipped) > 0 {
+		fmt.Println()
+		fmt.Println(styles.header.Render("Would not fire"))
+		for _, r := range skipped {
+			fmt.Printf("\n  %s\n", hookLocation(r.SimulatedHook, styles))
+			fmt.Printf("     %s %s\n", r.Type, r.Run)
+			fmt.Println(styles.tierDF.Render("     " + r.Reason))
+		}
+	}
+}
+
+// hookLocation names where a hook is configured and its matcher.
+func hookLocation(h lint.SimulatedHook, styles printStyles) string {
+	matcher := "no matcher"
+	if h.Matcher != "" {
+		matcher = fmt.Sprintf("matcher %q", h.Matcher)
+	}
+	return fmt.Sprintf("%s %s  %s", h.File, styles.dim.Render(h.Pointer), matcher)
+}
+
+// printHookExecution prints the outcome of a hook run under --exec.
+func printHookExecution(e *hookExecution, styles printStyles) {
+	status := fmt.Sprintf("exit %d", e.ExitCode)
+	switch {
+	case e.Error != "":
+		status = e.Error
+	case e.Blocks:
+		status += " (blocks)"
+	}
+	style := styles.tierA
+	if e.ExitCode != 0 {
+		style = styles.tierDF
+	}
+	fmt.Println(style.Render("     → " + status))
+	for _, out := range []string{e.Stdout, e.Stderr} {
+		for line := range strings.SplitSeq(out, "\n") {
+			if line != "" {
+				fmt.Println(styles.dim.Render("       " + line))
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooksSimulate(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".claude"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".claude", "settings.json"), []byte(`{
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "cat; echo; echo \"$CLAUDE_PROJECT_DIR\"; exit 2"}]},
+      {"matcher": "Edit|Write", "hooks": [{"type": "command", "command": "fmt.sh"}]}
+    ]
+  }
+}`), 0o600))
+
+	inv := testInvocation()
+	opts := &simulateOptions{}
+	inv.rootPath, inv.outputFormat = root, "console"
+	opts.event, opts.tool, opts.input = "PreToolUse", "Bash", `{"command": "ls"}`
+
+	out, result, err := captureStdout(t, func() (cmdResult, error) { return runHooksSimulate(inv, opts) })
+	require.NoError(t, err)
+	assert.Equal(t, ExitClean, result.ExitCode)
+	assert.Contains(t, out, "PreToolUse for Bash: 1 of 2 hooks would fire")
+	assert.Contains(t, out, `1. .claude/settings.json /hooks/PreToolUse/0/hooks/0  matcher "Bash"`)
+	assert.Contains(t, out, `matcher "Edit|Write" does not match "Bash"`)
+	assert.NotContains(t, out, "→", "nothing runs without --exec")
+
+	inv.outputFormat, opts.exec = "json", true
+	out, _, err = captureStdout(t, func() (cmdResult, error) { return runHooksSimulate(inv, opts) })
+	require.NoError(t, err)
+	var report hooksSimulateReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Len(t, report.Hooks, 2)
+	run := report.Hooks[0]
+	require.NotNil(t, run.Exec)
+	assert.Equal(t, 2, run.Exec.ExitCode)
+	assert.True(t, run.Exec.Blocks)
+	assert.Contains(t, run.Exec.Stdout, `"tool_input":{"command":"ls"}`)
+	assert.Contains(t, run.Exec.Stdout, root)
+	assert.Nil(t, report.Hooks[1].Exec, "hooks that do not fire are not run")
+}
+
+func TestHooksSimulateFlags(t *testing.T) {
+	opts := &simulateOptions{}
+
+	tests := []struct {
+		name                      string
+		event, tool, match, input string
+		wantErr                   string
+	}{
+		{name: "missing event", wantErr: "--event is required"},
+		{name: "unknown event", event: "BeforeTool", wantErr: `unknown hook event "BeforeTool"`},
+		{name: "match on tool event", event: "PreToolUse", match: "Bash", wantErr: "use --tool"},
+		{name: "tool on session event", event: "SessionStart", tool: "Bash", wantErr: "apply to tool events"},
+		{name: "input not an object", event: "PreToolUse", tool: "Bash", input: `["ls"]`, wantErr: "must be a JSON object"},
+		{name: "session event", event: "SessionStart", match: "startup"},
+		{name: "tool event", event: "PostToolUse", tool: "Edit", input: `{"file_path": "a.go"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts.event, opts.tool, opts.match, opts.input = tt.event, tt.tool, tt.match, tt.input
+			_, err := simulatedEvent(opts)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, ExitUsage, exitCodeForError(err))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// newImpactCmd builds the impact command.
+func newImpactCmd(inv *invocation) *cobra.Command {
+	return &cobra.Command{
+		Use:   "impact <file>",
+		Short: "List the components that depend on an agent or skill",
+		Long: `Report every command, agent, and skill that depends on the component in
+<file>, directly or through other components. Run it before editing or
+deleting an agent or skill to see which commands may break.
+
+Dependents are listed nearest first. Each shows how many hops away it is
+and the component it references on the way.
+
+With --format json the result is an object with the target component and a
+"dependents" array of type, name, file, depth, and via fields.
+
+EXAMPLES:
+
+  cclint impact .claude/skills/release/SKILL.md
+  cclint impact agents/code-reviewer.md --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runImpact(inv, args)
+		}),
+	}
+}
+
+// impactReport is the JSON output of cclint impact.
+type impactReport struct {
+	Type       string                `json:"type"`
+	Name       string                `json:"name"`
+	File       string                `json:"file"`
+	Dependents []crossfile.Dependent `json:"dependents"`
+}
+
+func runImpact(inv *invocation, args []string) (cmdResult, error) {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	if cfg.Format != "console" && cfg.Format != "json" {
+		return cmdResult{}, usageErrorf("impact supports --format console or json, not %q", cfg.Format)
+	}
+
+	root := cfg.Root
+	if root == "" {
+		if root, err = project.FindProjectRoot("."); err != nil {
+			return cmdResult{}, fmt.Errorf("error finding project root: %w", err)
+		}
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return cmdResult{}, fmt.Errorf("error resolving project root: %w", err)
+	}
+	target, err := filepath.Abs(args[0])
+	if err != nil {
+		return cmdResult{}, fmt.Errorf("error resolving %s: %w", args[0], err)
+	}
+	files, err := cfg.NewFileDiscovery(root).WithExclude(cfg.Exclude).DiscoverFiles()
+	if err != nil {
+		return cmdResult{}, fmt.Errorf("error discovering files: %w", err)
+	}
+	v := crossfile.NewCrossFileValidator(files, root).WithExtraBuiltinAgents(cfg.ExtraBuiltinAgents)
+	componentType, name, ok := v.ComponentAt(target)
+	if !ok {
+		return cmdResult{}, usageErrorf("%s is not a command, agent, or skill under %s", args[0], root)
+	}
+	rel, err := filepath.Rel(root, target)
+	if err != nil {
+		rel = target
+	}
+	report := impactReport{
+		Type:       componentType,
+		Name:       name,
+		File:       filepath.ToSlash(rel),
+		Dependents: v.Impact(componentType, name),
+	}
+
+	if cfg.Format == "json" {
+		if report.Dependents == nil {
+			report.Dependents = []crossfile.Dependent{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return resultOK, enc.Encode(report)
+	}
+	if len(report.Dependents) == 0 {
+		fmt.Printf("No components depend on %s %s\n", componentType, name)
+		return resultOK, nil
+	}
+	fmt.Printf("Components that depend on %s %s:\n", componentType, name)
+	for _, d := range report.Dependents {
+		fmt.Printf("  %s %s (%s) via %s, depth %d\n", d.Type, d.Name, d.Path, d.Via, d.Depth)
+	}
+	return resultOK, nil
+}
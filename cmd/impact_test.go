@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunImpact(t *testing.T) {
+	root := t.TempDir()
+	for path, contents := range map[string]string{
+		".claude/commands/deploy.md":      "---\ndescription: Deploy\n---\nTask(deployer)\n",
+		".claude/agents/deployer.md":      "---\nname: deployer\ndescription: Deploys\n---\nSkill: release\n",
+		".claude/skills/release/SKILL.md": "---\nname: release\ndescription: Release steps\n---\nbody\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(contents), 0o600))
+	}
+
+	inv := testInvocation()
+	inv.rootPath = root
+
+	tests := []struct {
+		name     string
+		file     string
+		format   string
+		wantErr  bool
+		wantText string
+		wantDeps []string
+	}{
+		{name: "skill as json", file: ".claude/skills/release/SKILL.md", format: "json", wantDeps: []string{"agent:deployer", "command:deploy"}},
+		{name: "agent as console", file: ".claude/agents/deployer.md", format: "console", wantText: "command deploy (.claude/commands/deploy.md) via agent:deployer, depth 1"},
+		{name: "command has no dependents", file: ".claude/commands/deploy.md", format: "console", wantText: "No components depend on command deploy"},
+		{name: "not a component", file: "README.md", format: "console", wantErr: true},
+		{name: "unsupported format", file: ".claude/agents/deployer.md", format: "tap", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv.outputFormat = tt.format
+			file := filepath.Join(root, tt.file)
+			out, result, err := captureStdout(t, func() (cmdResult, error) { return runImpact(inv, []string{file}) })
+			if tt.wantErr {
+				assert.Equal(t, ExitUsage, exitCodeForError(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, resultOK, result)
+			if tt.format == "console" {
+				assert.Contains(t, out, tt.wantText)
+				return
+			}
+			var report impactReport
+			require.NoError(t, json.Unmarshal([]byte(out), &report))
+			var deps []string
+			for _, d := range report.Dependents {
+				deps = append(deps, d.Type+":"+d.Name)
+			}
+			assert.Equal(t, tt.wantDeps, deps)
+		})
+	}
+}
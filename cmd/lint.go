@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/discovery"
 	"github.com/dotcommander/cclint/internal/lint"
 )
@@ -27,44 +28,61 @@ var typeLinters = map[discovery.FileType]struct {
 }
 
 // runTypeLint runs the linter for a specific file type.
-func runTypeLint(ft discovery.FileType) error {
+func runTypeLint(inv *invocation, ft discovery.FileType) (cmdResult, error) {
 	entry, ok := typeLinters[ft]
 	if !ok {
-		return fmt.Errorf("no linter for type %s", ft)
+		return cmdResult{}, usageErrorf("no linter for type %s", ft)
 	}
-	return runComponentLint(entry.Name, entry.Linter)
+	return runComponentLint(inv, entry.Name, entry.Linter)
 }
 
 // runComponentLint is the generic function that handles config loading,
 // linter execution, and output formatting for any component type.
 // This follows the Single Responsibility Principle by separating
 // orchestration from component-specific linting logic.
-func runComponentLint(linterName string, linter LinterFunc) error {
-	cfg, err := loadCLIConfig()
+func runComponentLint(inv *invocation, linterName string, linter LinterFunc) (cmdResult, error) {
+	return runComponentLintWith(inv, linterName, linter, nil)
+}
+
+// runComponentLintWith is runComponentLint with a report printed after the
+// findings, such as the context subcommand's summary section. report is
+// not called when autofixes were applied instead.
+func runComponentLintWith(inv *invocation, linterName string, linter LinterFunc, report func(*config.Config, *lint.LintSummary)) (cmdResult, error) {
+	cfg, err := loadCLIConfig(inv)
 	if err != nil {
-		return err
+		return cmdResult{}, err
 	}
 
-	result, err := runOrchestratedLint(cfg, []lint.LinterEntry{{
+	linters := []lint.LinterEntry{{
 		Name:   linterName,
 		Linter: linter,
-	}})
+	}}
+	result, err := runOrchestratedLint(inv, cfg, linters)
+	if err != nil {
+		return cmdResult{}, fmt.Errorf("error running %s linter: %w", linterName, err)
+	}
+	result, done, err := applyLintFixes(inv, cfg, linters, result)
 	if err != nil {
-		return fmt.Errorf("error running %s linter: %w", linterName, err)
+		return cmdResult{}, fmt.Errorf("error applying fixes: %w", err)
+	}
+	if done {
+		return resultOK, nil
 	}
 
-	summary := &lint.LintSummary{}
+	summary := &lint.LintSummary{Gates: result.Gates}
 	if len(result.Summaries) > 0 {
 		summary = result.Summaries[0]
 	}
 
 	if err := formatSummaryOutput(cfg, summary); err != nil {
-		return err
+		return cmdResult{}, err
+	}
+	if report != nil {
+		report(cfg, summary)
 	}
 
-	printBaselineSummary(result.BaselineIgnored, result.ErrorsIgnored, result.SuggestionsIgnored, cfg.Quiet)
+	printSuppressedSummary(result.SuppressedIssues(), cfg.Quiet())
 	printValidationReminder(cfg)
-	applyFailurePolicy(cfg, summary.TotalErrors, summary.TotalWarnings, summary.TotalSuggestions)
 
-	return nil
+	return failurePolicyResult(inv, cfg, summary), nil
 }
@@ -6,15 +6,16 @@ import (
 	"testing"
 
 	"github.com/dotcommander/cclint/internal/baseline"
-	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 // mockLinterFunc creates a mock linter function for testing
 func mockLinterFunc(summary *lint.LintSummary, err error) LinterFunc {
-	return func(rootPath string, quiet bool, verbose bool, noCycleCheck bool, exclude []string) (*lint.LintSummary, error) {
+	return func(opts lint.Options) (*lint.LintSummary, error) {
 		return summary, err
 	}
 }
@@ -25,29 +26,15 @@ func TestRunComponentLint_Success(t *testing.T) {
 	// Create minimal config structure
 	require.NoError(t, os.MkdirAll(tmpDir, 0755))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldUseBaseline := useBaseline
-	oldCreateBaseline := createBaseline
-	oldBaselinePath := baselinePath
-
-	rootPath = tmpDir
-	quiet = true
-	verbose = false
-	useBaseline = false
-	createBaseline = false
-	baselinePath = ".cclintbaseline.json"
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		useBaseline = oldUseBaseline
-		createBaseline = oldCreateBaseline
-		baselinePath = oldBaselinePath
-	}()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.verbose = false
+	inv.useBaseline = false
+	inv.createBaseline = false
+	inv.baselinePath = ".cclintbaseline.json"
 
 	// Create a successful linter
 	successSummary := &lint.LintSummary{
@@ -63,23 +50,22 @@ func TestRunComponentLint_Success(t *testing.T) {
 	linter := mockLinterFunc(successSummary, nil)
 
 	// Run component lint
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 }
 
 func TestRunComponentLint_LinterError(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	// Set global flags
-	oldRootPath := rootPath
-	rootPath = tmpDir
-	defer func() { rootPath = oldRootPath }()
+	// Set flags
+	inv := testInvocation()
+	inv.rootPath = tmpDir
 
 	// Create a failing linter
 	linter := mockLinterFunc(nil, assert.AnError)
 
 	// Run component lint
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "error running agents linter")
 }
@@ -87,23 +73,13 @@ func TestRunComponentLint_LinterError(t *testing.T) {
 func TestRunComponentLint_BaselineCreation(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldCreateBaseline := createBaseline
-	oldBaselinePath := baselinePath
-
-	rootPath = tmpDir
-	quiet = true
-	createBaseline = true
-	baselinePath = filepath.Join(tmpDir, ".cclintbaseline.json")
+	// Set flags
+	inv := testInvocation()
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		createBaseline = oldCreateBaseline
-		baselinePath = oldBaselinePath
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.createBaseline = true
+	inv.baselinePath = filepath.Join(tmpDir, ".cclintbaseline.json")
 
 	// Create linter with some issues
 	summary := &lint.LintSummary{
@@ -125,14 +101,14 @@ func TestRunComponentLint_BaselineCreation(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Run component lint
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 
 	// Verify baseline file was created
-	assert.FileExists(t, baselinePath)
+	assert.FileExists(t, inv.baselinePath)
 
 	// Verify baseline content
-	b, err := baseline.LoadBaseline(baselinePath)
+	b, err := baseline.LoadBaseline(inv.baselinePath)
 	assert.NoError(t, err)
 	assert.NotNil(t, b)
 	assert.Greater(t, len(b.Fingerprints), 0)
@@ -150,23 +126,13 @@ func TestRunComponentLint_BaselineFiltering(t *testing.T) {
 
 	require.NoError(t, b.SaveBaseline(baselineFile))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldUseBaseline := useBaseline
-	oldBaselinePath := baselinePath
+	// Set flags
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = true
-	useBaseline = true
-	baselinePath = baselineFile
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		useBaseline = oldUseBaseline
-		baselinePath = oldBaselinePath
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.useBaseline = true
+	inv.baselinePath = baselineFile
 
 	// Create linter with issues (one should be filtered)
 	summary := &lint.LintSummary{
@@ -188,7 +154,7 @@ func TestRunComponentLint_BaselineFiltering(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Run component lint
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 }
 
@@ -196,23 +162,13 @@ func TestRunComponentLint_AbsoluteBaselinePath(t *testing.T) {
 	tmpDir := t.TempDir()
 	absBaselinePath := filepath.Join(tmpDir, "custom-baseline.json")
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldCreateBaseline := createBaseline
-	oldBaselinePath := baselinePath
-
-	rootPath = tmpDir
-	quiet = true
-	createBaseline = true
-	baselinePath = absBaselinePath // Absolute path
+	// Set flags
+	inv := testInvocation()
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		createBaseline = oldCreateBaseline
-		baselinePath = oldBaselinePath
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.createBaseline = true
+	inv.baselinePath = absBaselinePath // Absolute path
 
 	// Create linter
 	summary := &lint.LintSummary{
@@ -224,7 +180,7 @@ func TestRunComponentLint_AbsoluteBaselinePath(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Run component lint
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 
 	// Verify baseline was created at absolute path
@@ -236,23 +192,13 @@ func TestRunComponentLint_RelativeBaselinePath(t *testing.T) {
 	relBaselinePath := "custom.json"
 	expectedPath := filepath.Join(tmpDir, relBaselinePath)
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldCreateBaseline := createBaseline
-	oldBaselinePath := baselinePath
+	// Set flags
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = true
-	createBaseline = true
-	baselinePath = relBaselinePath // Relative path
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		createBaseline = oldCreateBaseline
-		baselinePath = oldBaselinePath
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.createBaseline = true
+	inv.baselinePath = relBaselinePath // Relative path
 
 	// Create linter
 	summary := &lint.LintSummary{
@@ -264,7 +210,7 @@ func TestRunComponentLint_RelativeBaselinePath(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Run component lint
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 
 	// Verify baseline was created relative to root
@@ -278,23 +224,13 @@ func TestRunComponentLint_BaselineLoadError(t *testing.T) {
 	// Create an invalid baseline file
 	require.NoError(t, os.WriteFile(baselineFile, []byte("{invalid json}"), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldUseBaseline := useBaseline
-	oldBaselinePath := baselinePath
-
-	rootPath = tmpDir
-	quiet = false // Set to false to test warning message
-	useBaseline = true
-	baselinePath = baselineFile
+	// Set flags
+	inv := testInvocation()
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		useBaseline = oldUseBaseline
-		baselinePath = oldBaselinePath
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = false // Set to false to test warning message
+	inv.useBaseline = true
+	inv.baselinePath = baselineFile
 
 	// Suppress stderr to avoid cluttering test output
 	oldStderr := os.Stderr
@@ -311,30 +247,20 @@ func TestRunComponentLint_BaselineLoadError(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Should handle error gracefully and continue
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 }
 
 func TestRunComponentLint_NoBaseline(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldUseBaseline := useBaseline
-	oldCreateBaseline := createBaseline
+	// Set flags
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = true
-	useBaseline = false
-	createBaseline = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		useBaseline = oldUseBaseline
-		createBaseline = oldCreateBaseline
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.useBaseline = false
+	inv.createBaseline = false
 
 	// Create linter
 	summary := &lint.LintSummary{
@@ -346,39 +272,21 @@ func TestRunComponentLint_NoBaseline(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Run without baseline
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 }
 
 func TestRunComponentLint_VerboseOutput(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-
-	rootPath = tmpDir
-	quiet = false
-	verbose = true
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
-
-	// Mock exitFunc to capture exit calls without terminating the test process
-	originalExitFunc := exitFunc
-	exitCalled := false
-	exitCode := 0
-	exitFunc = func(code int) {
-		exitCalled = true
-		exitCode = code
-	}
-	defer func() { exitFunc = originalExitFunc }()
+	// Set flags
+	inv := testInvocation()
 
-	// Create linter with errors — should trigger exit(1) due to fail-on logic
+	inv.rootPath = tmpDir
+	inv.quiet = false
+	inv.verbose = true
+
+	// Create linter with errors — should report ExitFindings due to fail-on logic
 	summary := &lint.LintSummary{
 		ProjectRoot:   tmpDir,
 		ComponentType: "agents",
@@ -389,20 +297,19 @@ func TestRunComponentLint_VerboseOutput(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Run with verbose
-	err := runComponentLint("agents", linter)
+	res, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
-	assert.True(t, exitCalled, "expected exitFunc to be called due to errors")
-	assert.Equal(t, 1, exitCode, "expected exit code 1 for lint errors")
+	assert.Equal(t, ExitFindings, res.ExitCode, "expected exit code 1 for lint errors")
 }
 
 func TestLinterFuncSignature(t *testing.T) {
 	// Test that our mock matches the actual signature
-	var linter LinterFunc = func(rootPath string, quiet bool, verbose bool, noCycleCheck bool, exclude []string) (*lint.LintSummary, error) {
+	var linter LinterFunc = func(opts lint.Options) (*lint.LintSummary, error) {
 		return &lint.LintSummary{}, nil
 	}
 
 	// Should be able to call it with expected parameters
-	summary, err := linter("/tmp", true, false, false, nil)
+	summary, err := linter(lint.Options{Config: &config.Config{Root: "/tmp"}})
 	assert.NoError(t, err)
 	assert.NotNil(t, summary)
 }
@@ -415,26 +322,14 @@ func TestRunComponentLint_QuietMode(t *testing.T) {
 	b := baseline.CreateBaseline([]cue.ValidationError{})
 	require.NoError(t, b.SaveBaseline(baselineFile))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldUseBaseline := useBaseline
-	oldBaselinePath := baselinePath
-	oldCreateBaseline := createBaseline
-
-	rootPath = tmpDir
-	quiet = true // Quiet mode
-	useBaseline = true
-	createBaseline = true
-	baselinePath = baselineFile
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		useBaseline = oldUseBaseline
-		baselinePath = oldBaselinePath
-		createBaseline = oldCreateBaseline
-	}()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true // Quiet mode
+	inv.useBaseline = true
+	inv.createBaseline = true
+	inv.baselinePath = baselineFile
 
 	// Create linter with issues
 	summary := &lint.LintSummary{
@@ -455,15 +350,14 @@ func TestRunComponentLint_QuietMode(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Run in quiet mode - should suppress output
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 }
 
 func TestRunComponentLint_ConfigLoadError(t *testing.T) {
 	// Set root path to a location that will fail config loading
-	oldRootPath := rootPath
-	rootPath = "/nonexistent/directory/that/does/not/exist"
-	defer func() { rootPath = oldRootPath }()
+	inv := testInvocation()
+	inv.rootPath = "/nonexistent/directory/that/does/not/exist"
 
 	// Create a dummy linter (shouldn't be called)
 	linter := mockLinterFunc(&lint.LintSummary{}, nil)
@@ -471,7 +365,7 @@ func TestRunComponentLint_ConfigLoadError(t *testing.T) {
 	// Should fail at config loading stage
 	// Note: config.LoadConfig may actually succeed even with non-existent path
 	// by using defaults, so this test may not fail as expected
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	if err != nil {
 		assert.Contains(t, err.Error(), "error")
 	}
@@ -488,23 +382,13 @@ func TestRunComponentLint_BaselineFilteringWithIgnored(t *testing.T) {
 	b := baseline.CreateBaseline(testIssues)
 	require.NoError(t, b.SaveBaseline(baselineFile))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldUseBaseline := useBaseline
-	oldBaselinePath := baselinePath
+	// Set flags
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = false // Show baseline summary
-	useBaseline = true
-	baselinePath = baselineFile
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		useBaseline = oldUseBaseline
-		baselinePath = oldBaselinePath
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = false // Show baseline summary
+	inv.useBaseline = true
+	inv.baselinePath = baselineFile
 
 	// Create linter with issue that matches baseline
 	summary := &lint.LintSummary{
@@ -525,7 +409,7 @@ func TestRunComponentLint_BaselineFilteringWithIgnored(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Run - the issue should be filtered by baseline
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 }
 
@@ -533,23 +417,13 @@ func TestRunComponentLint_BaselineCreationQuietMode(t *testing.T) {
 	tmpDir := t.TempDir()
 	baselineFile := filepath.Join(tmpDir, ".cclintbaseline.json")
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldCreateBaseline := createBaseline
-	oldBaselinePath := baselinePath
-
-	rootPath = tmpDir
-	quiet = false // Show baseline creation message
-	createBaseline = true
-	baselinePath = baselineFile
+	// Set flags
+	inv := testInvocation()
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		createBaseline = oldCreateBaseline
-		baselinePath = oldBaselinePath
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = false // Show baseline creation message
+	inv.createBaseline = true
+	inv.baselinePath = baselineFile
 
 	// Create linter with some issues
 	summary := &lint.LintSummary{
@@ -581,7 +455,7 @@ func TestRunComponentLint_BaselineCreationQuietMode(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Run - should create baseline and print message
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 
 	// Verify baseline was created
@@ -599,23 +473,13 @@ func TestRunComponentLint_ExistingBaseline(t *testing.T) {
 	existingBaseline := baseline.CreateBaseline(existingIssues)
 	require.NoError(t, existingBaseline.SaveBaseline(baselineFile))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldCreateBaseline := createBaseline
-	oldBaselinePath := baselinePath
+	// Set flags
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = true
-	createBaseline = true // Re-create baseline
-	baselinePath = baselineFile
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		createBaseline = oldCreateBaseline
-		baselinePath = oldBaselinePath
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.createBaseline = true // Re-create baseline
+	inv.baselinePath = baselineFile
 
 	// Create linter with new issues
 	summary := &lint.LintSummary{
@@ -635,7 +499,7 @@ func TestRunComponentLint_ExistingBaseline(t *testing.T) {
 	linter := mockLinterFunc(summary, nil)
 
 	// Run - should overwrite existing baseline
-	err := runComponentLint("agents", linter)
+	_, err := runComponentLint(inv, "agents", linter)
 	assert.NoError(t, err)
 
 	// Verify new baseline was saved
@@ -650,31 +514,22 @@ func TestLinterFuncParameters(t *testing.T) {
 
 	tmpDir := t.TempDir()
 
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldNoCycleCheck := noCycleCheck
-
-	rootPath = tmpDir
-	quiet = true
-	verbose = true
-	noCycleCheck = true
+	inv := testInvocation()
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		noCycleCheck = oldNoCycleCheck
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.verbose = true
+	inv.noCycleCheck = true
 
-	linter := func(rp string, q bool, v bool, ncc bool, exclude []string) (*lint.LintSummary, error) {
+	linter := func(opts lint.Options) (*lint.LintSummary, error) {
 		linterCalled = true
 		// Verify parameters are passed (they come from config, not flags directly)
-		assert.NotEmpty(t, rp)
-		return &lint.LintSummary{ProjectRoot: rp}, nil
+		assert.NotEmpty(t, opts.Config.Root)
+		assert.True(t, opts.Config.NoCycleCheck)
+		return &lint.LintSummary{ProjectRoot: opts.Config.Root}, nil
 	}
 
-	_ = runComponentLint("test", linter)
+	_, _ = runComponentLint(inv, "test", linter)
 
 	// Verify linter was called
 	assert.True(t, linterCalled)
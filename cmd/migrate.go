@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/format"
+	"github.com/dotcommander/cclint/internal/migrate"
+	"github.com/spf13/cobra"
+)
+
+// migrateOptions holds the flags of the migrate command.
+type migrateOptions struct {
+	write bool
+	check bool
+	to    int
+	files []string
+	typ   string
+}
+
+// newMigrateCmd builds the migrate command.
+func newMigrateCmd(inv *invocation) *cobra.Command {
+	opts := &migrateOptions{}
+	migrateCmd := &cobra.Command{
+		Use:   "migrate [files...]",
+		Short: "Rewrite deprecated frontmatter fields to their current names",
+		Long: `Rewrite frontmatter keys that older Claude Code conventions used to their
+current names, following cclint's migration table. Only the key is changed;
+values, comments, and layout are kept.
+
+By default migrate is a dry run that prints a diff per file. A file that
+already sets the new key keeps its old one and is reported, so the two
+values can be merged by hand.
+
+SCHEMA VERSIONS:
+
+  1  Legacy: snake_case keys (allowed_tools, argument_hint, max_turns, ...)
+  2  Current: kebab-case command/skill keys, camelCase agent keys
+
+Set schemaVersion in .cclintrc to keep validating files against an older
+version until they are migrated.
+
+Fields the project retired in the deprecatedFields config block with a
+renameTo are renamed too, whatever --to says.
+
+EXAMPLES:
+
+  cclint migrate                  # Show what would change (diff)
+  cclint migrate agents           # Only agents
+  cclint migrate -w               # Rewrite files in place
+  cclint migrate --check          # Exit 1 if any file needs migrating (CI)`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runMigrate(inv, opts, args)
+		}),
+	}
+
+	migrateCmd.Flags().BoolVarP(&opts.write, "write", "w", false, "Write changes in place")
+	migrateCmd.Flags().BoolVar(&opts.check, "check", false, "Exit 1 if files would change (for CI)")
+	migrateCmd.Flags().IntVar(&opts.to, "to", migrate.CurrentSchemaVersion, "Schema version to migrate to")
+	migrateCmd.Flags().StringArrayVar(&opts.files, "file", nil, "Explicit file path(s) to migrate")
+	migrateCmd.Flags().StringVarP(&opts.typ, "type", "t", "", "Force component type (agent|command|skill|output-style)")
+	return migrateCmd
+}
+
+func runMigrate(inv *invocation, opts *migrateOptions, args []string) (cmdResult, error) {
+	if err := migrate.ValidateVersion(opts.to); err != nil {
+		return cmdResult{}, usageErrorf("invalid --to: %w", err)
+	}
+	var forced discovery.FileType
+	if opts.typ != "" {
+		ft, err := discovery.ParseFileType(opts.typ)
+		if err != nil {
+			return cmdResult{}, asUsageError(err)
+		}
+		forced = ft
+	}
+
+	cfg, err := config.LoadConfig(inv.rootPath)
+	if err != nil {
+		return cmdResult{}, usageErrorf("error loading configuration: %w", err)
+	}
+	if err := applyVerbosity(inv, cfg); err != nil {
+		return cmdResult{}, err
+	}
+
+	files, err := collectComponentFiles(args, opts.files, cfg, false)
+	if err != nil {
+		return cmdResult{}, asUsageError(err)
+	}
+	if len(files) == 0 {
+		return cmdResult{}, usageErrorf("no files to migrate")
+	}
+
+	project := cfg.DeprecatedFields.Renames()
+	changed := 0
+	for _, filePath := range files {
+		did, err := migrateOneFile(inv, opts, filePath, cfg, forced, project)
+		if err != nil {
+			return cmdResult{}, err
+		}
+		if did {
+			changed++
+		}
+	}
+
+	printMigrateSummary(inv, opts, len(files), changed)
+	if changed > 0 && cfg.SchemaVersion != 0 && cfg.SchemaVersion < opts.to && !inv.quiet {
+		fmt.Printf("After migrating, set schemaVersion: %d in your .cclintrc\n", opts.to)
+	}
+
+	if opts.check && changed > 0 {
+		return cmdResult{ExitCode: ExitFindings}, nil
+	}
+	return resultOK, nil
+}
+
+// migrateOneFile applies the migration table and the project's renames to
+// one markdown component and shows or writes the result. Returns true if
+// the file had keys to rename.
+func migrateOneFile(inv *invocation, opts *migrateOptions, filePath string, cfg *config.Config, forced discovery.FileType, project []migrate.Rename) (bool, error) {
+	absPath, err := discovery.ValidateFilePath(filePath)
+	if err != nil {
+		if !inv.quiet {
+			fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", filePath, err)
+		}
+		return false, nil
+	}
+	if !strings.HasSuffix(strings.ToLower(absPath), ".md") {
+		return false, nil
+	}
+
+	fileType := forced
+	if opts.typ == "" {
+		fileType, err = cfg.DetectFileType(absPath, cfg.Root)
+		if err != nil {
+			if inv.verbose {
+				fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", filePath, err)
+			}
+			return false, nil
+		}
+	}
+
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		if !inv.quiet {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", filePath, err)
+		}
+		return false, nil
+	}
+
+	migrated, changes := migrate.Apply(fileType.String(), string(content), opts.to, project)
+	for _, c := range changes {
+		if c.Conflict && !inv.quiet {
+			fmt.Fprintf(os.Stderr, "%s:%d: kept '%s': '%s' is already set; merge the two by hand\n", filePath, c.Line, c.From, c.To)
+		}
+	}
+	if migrated == string(content) {
+		return false, nil
+	}
+
+	switch {
+	case opts.check:
+		if !inv.quiet {
+			fmt.Printf("%s needs migrating\n", filePath)
+		}
+	case opts.write:
+		info, err := os.Stat(absPath)
+		if err != nil {
+			return false, fmt.Errorf("error writing %s: %w", absPath, err)
+		}
+		if err := os.WriteFile(absPath, []byte(migrated), info.Mode().Perm()); err != nil {
+			return false, fmt.Errorf("error writing %s: %w", absPath, err)
+		}
+		if !inv.quiet {
+			fmt.Printf("Migrated %s\n", filePath)
+		}
+	default:
+		fmt.Print(format.Diff(string(content), migrated, filePath))
+	}
+	return true, nil
+}
+
+// printMigrateSummary prints the migration summary when several files were
+// processed.
+func printMigrateSummary(inv *invocation, opts *migrateOptions, totalFiles, changedCount int) {
+	if inv.quiet || totalFiles <= 1 {
+		return
+	}
+	switch {
+	case changedCount == 0:
+		fmt.Printf("\nAll %d files use current field names\n", totalFiles)
+	case opts.write:
+		fmt.Printf("\nMigrated %d of %d files\n", changedCount, totalFiles)
+	default:
+		fmt.Printf("\n%d of %d files need migrating\n", changedCount, totalFiles)
+	}
+}
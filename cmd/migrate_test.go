@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMigrate(t *testing.T) {
+	const legacy = "---\ndescription: Review a file\nargument_hint: \"[file]\"\n---\nReview $ARGUMENTS.\n"
+	const current = "---\ndescription: Review a file\nargument-hint: \"[file]\"\n---\nReview $ARGUMENTS.\n"
+
+	tests := []struct {
+		name     string
+		check    bool
+		write    bool
+		to       int
+		wantCode int
+		want     string
+		wantErr  bool
+	}{
+		{name: "dry run leaves file", to: 2, want: legacy},
+		{name: "check reports findings", check: true, to: 2, wantCode: ExitFindings, want: legacy},
+		{name: "write renames key", write: true, to: 2, want: current},
+		{name: "migrating to legacy version is a no-op", check: true, to: 1, want: legacy},
+		{name: "unknown version", to: 9, wantErr: true, want: legacy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			file := filepath.Join(tmpDir, "commands", "review.md")
+			require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+			require.NoError(t, os.WriteFile(file, []byte(legacy), 0644))
+
+			inv := testInvocation()
+			opts := &migrateOptions{}
+			inv.rootPath, inv.quiet, opts.check, opts.write, opts.to = tmpDir, true, tt.check, tt.write, tt.to
+
+			result, err := runMigrate(inv, opts, []string{file})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, ExitUsage, exitCodeForError(err))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantCode, result.ExitCode)
+			}
+
+			got, err := os.ReadFile(file)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
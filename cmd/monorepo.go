@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/git"
+	"github.com/dotcommander/cclint/internal/output"
+	"github.com/dotcommander/cclint/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// monorepoOptions holds the flags of the monorepo command.
+type monorepoOptions struct {
+	changedPackages bool   // lint only packages touched by the git diff (--changed-packages)
+	base            string // ref the diff is taken against (--base)
+}
+
+// newMonorepoCmd builds the monorepo command.
+func newMonorepoCmd(inv *invocation) *cobra.Command {
+	opts := &monorepoOptions{}
+	monorepoCmd := &cobra.Command{
+		Use:   "monorepo [package-dirs...]",
+		Short: "Lint each package of a monorepo and report a per-package rollup",
+		Long: `Lint several project roots in one run and report them together. Each
+package is linted as its own project, with its own configuration, and the
+rollup lists every package with its file and finding subtotals, then the
+total and an overall verdict: the run fails when any package has findings
+at or above its --fail-on threshold.
+
+Without arguments the packages are found under --root, or the current
+directory: that directory and every directory holding a .claude directory or a plugin manifest
+(.claude-plugin/plugin.json). Hidden directories, node_modules, and vendor
+are not searched. Name package directories to lint only those.
+
+With --changed-packages only packages with a component changed since --base
+(default HEAD), committed or not, are linted; the others are listed as
+unchanged. Use it in CI to lint only what a pull request touches.
+
+Console output shows each linted package's findings under its root, then
+the rollup. With --format json the report has a "packages" array, each
+with root, passed, summary, and results (files relative to the package),
+plus the overall summary and passed verdict.
+
+EXAMPLES:
+
+  cclint monorepo
+  cclint monorepo packages/web packages/api
+  cclint monorepo --changed-packages --base origin/main
+  cclint monorepo --format json --output cclint-rollup.json`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runMonorepo(inv, opts, args)
+		}),
+	}
+
+	monorepoCmd.Flags().BoolVar(&opts.changedPackages, "changed-packages", false, "Lint only packages with components changed since --base")
+	monorepoCmd.Flags().StringVar(&opts.base, "base", "", "Git ref to diff against for --changed-packages (default HEAD)")
+	return monorepoCmd
+}
+
+func runMonorepo(inv *invocation, opts *monorepoOptions, args []string) (cmdResult, error) {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	if cfg.Format != "console" && cfg.Format != "json" {
+		return cmdResult{}, usageErrorf("monorepo supports --format console or json, not %q", cfg.Format)
+	}
+	if opts.base != "" && !opts.changedPackages {
+		return cmdResult{}, usageErrorf("--base requires --changed-packages")
+	}
+
+	// The monorepo root is not a package's project root, so it is --root or
+	// the current directory rather than the detected root.
+	root := inv.rootPath
+	if root == "" {
+		root = "."
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return cmdResult{}, fmt.Errorf("error resolving project root: %w", err)
+	}
+	dirs, err := monorepoPackages(root, args)
+	if err != nil {
+		return cmdResult{}, err
+	}
+
+	changed := map[string]bool{}
+	if opts.changedPackages {
+		if changed, err = changedPackageDirs(root, dirs, opts.base); err != nil {
+			return cmdResult{}, err
+		}
+	}
+
+	start := time.Now()
+	packages := make([]output.PackageResult, len(dirs))
+	for i, dir := range dirs {
+		packages[i] = output.PackageResult{Root: displayRoot(root, dir), Dir: dir}
+		if opts.changedPackages && !changed[dir] {
+			packages[i].Skipped = true
+			continue
+		}
+		if packages[i], err = lintPackage(inv, packages[i], cfg.Format == "console" && !cfg.Quiet()); err != nil {
+			return cmdResult{}, fmt.Errorf("package %s: %w", packages[i].Root, err)
+		}
+	}
+
+	if cfg.Format == "json" {
+		formatter := output.NewJSONFormatterWithVersion(cfg.Quiet(), true, cfg.Output, cfg.Version).WithBuild(cfg.Commit, cfg.BuildDate).WithConfigHash(cfg.Hash()).WithDocsURLTemplate(cfg.DocsURLTemplate)
+		if err := formatter.FormatRollup(packages, start); err != nil {
+			return cmdResult{}, fmt.Errorf("error formatting output: %w", err)
+		}
+	} else if !cfg.Quiet() {
+		color, _ := output.ParseColorMode(cfg.Color)
+		output.FormatRollup(os.Stdout, packages, color.Colorize())
+	}
+
+	if !output.RollupPassed(packages) {
+		return cmdResult{ExitCode: ExitFindings}, nil
+	}
+	return resultOK, nil
+}
+
+// monorepoPackages returns the absolute package roots: the named
+// directories, or those found under root.
+func monorepoPackages(root string, args []string) ([]string, error) {
+	if len(args) == 0 {
+		dirs, err := project.FindPackages(root)
+		if err != nil {
+			return nil, fmt.Errorf("error finding packages: %w", err)
+		}
+		if len(dirs) == 0 {
+			return nil, usageErrorf("no packages found under %s; name the package directories as arguments", root)
+		}
+		return dirs, nil
+	}
+	dirs := make([]string, 0, len(args))
+	for _, arg := range args {
+		dir, err := filepath.Abs(arg)
+		if err != nil {
+			return nil, usageErrorf("invalid package directory: %w", err)
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, usageErrorf("%s is not a directory", arg)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+// changedPackageDirs returns the packages holding a component changed
+// since base. A change outside every package touches none.
+func changedPackageDirs(root string, dirs []string, base string) (map[string]bool, error) {
+	if !git.IsGitRepo(root) {
+		return nil, usageErrorf("--changed-packages needs a git repository; %s is not in one", root)
+	}
+	files, err := git.GetChangedFilesSince(root, base)
+	if err != nil {
+		return nil, fmt.Errorf("error getting git files: %w", err)
+	}
+	changed := make(map[string]bool)
+	for _, file := range files {
+		if dir, ok := project.PackageOf(dirs, file); ok {
+			changed[dir] = true
+		}
+	}
+	return changed, nil
+}
+
+// lintPackage lints one package as its own project, with its own
+// configuration, printing its console output under its root when show is
+// set.
+func lintPackage(inv *invocation, pkg output.PackageResult, show bool) (output.PackageResult, error) {
+	pkgInv := *inv
+	pkgInv.rootPath = pkg.Dir
+
+	cfg, err := loadCLIConfig(&pkgInv)
+	if err != nil {
+		return pkg, err
+	}
+	result, err := runOrchestratedLint(&pkgInv, cfg, nil)
+	if err != nil {
+		return pkg, err
+	}
+	if show {
+		fmt.Printf("\n== %s ==\n", pkg.Root)
+		if err := formatFullRunOutput(cfg, result); err != nil {
+			return pkg, fmt.Errorf("error formatting output: %w", err)
+		}
+	}
+	pkg.Summaries = result.Summaries
+	pkg.Passed = failurePolicyResult(&pkgInv, cfg, result.Summaries...).ExitCode == ExitClean
+	return pkg, nil
+}
+
+// displayRoot names a package by its path relative to the monorepo root.
+func displayRoot(root, dir string) string {
+	if rel, err := filepath.Rel(root, dir); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return dir
+}
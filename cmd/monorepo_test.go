@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMonorepo(t *testing.T) {
+	repo := t.TempDir()
+	write := func(rel, contents string) {
+		path := filepath.Join(repo, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+	write("packages/web/.claude/agents/reviewer.md", "---\nname: reviewer\ndescription: Reviews web changes. Use PROACTIVELY after edits.\nmodel: sonnet\n---\n# Reviewer\n\nReview the diff.\n")
+	write("packages/api/.claude/agents/Bad Name.md", "---\nname: Bad Name\n---\nBody\n")
+	write("node_modules/dep/.claude/agents/ignored.md", "---\nname: ignored\n---\n")
+
+	inv := testInvocation()
+	opts := &monorepoOptions{}
+	inv.rootPath, inv.outputFormat = repo, "json"
+
+	out, result, err := captureStdout(t, func() (cmdResult, error) { return runMonorepo(inv, opts, nil) })
+	require.NoError(t, err)
+	assert.Equal(t, ExitFindings, result.ExitCode)
+
+	var report output.JSONRollupV2
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.False(t, report.Passed)
+	require.Len(t, report.Packages, 2)
+	assert.Equal(t, "packages/api", report.Packages[0].Root)
+	assert.False(t, report.Packages[0].Passed)
+	assert.Positive(t, report.Packages[0].Summary.Errors)
+	assert.Equal(t, "packages/web", report.Packages[1].Root)
+	assert.True(t, report.Packages[1].Passed)
+	assert.Equal(t, report.Packages[0].Summary.Errors+report.Packages[1].Summary.Errors, report.Summary.Errors)
+
+	// Only the package touched since the last commit is linted.
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.email=t@t", "-c", "user.name=t"}, args...)...)
+		cmd.Dir = repo
+		if outb, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v: %s", args, err, outb)
+		}
+	}
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-qm", "init")
+	write("packages/web/.claude/agents/reviewer.md", "---\nname: reviewer\ndescription: Reviews web changes. Use PROACTIVELY after edits.\nmodel: sonnet\n---\n# Reviewer\n\nReview the whole diff.\n")
+	opts.changedPackages = true
+
+	out, result, err = captureStdout(t, func() (cmdResult, error) { return runMonorepo(inv, opts, nil) })
+	require.NoError(t, err)
+	assert.Equal(t, ExitClean, result.ExitCode)
+	report = output.JSONRollupV2{}
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.True(t, report.Passed)
+	assert.True(t, report.Packages[0].Skipped, "packages/api is unchanged")
+	assert.False(t, report.Packages[1].Skipped)
+}
+
+func TestRunMonorepo_Usage(t *testing.T) {
+	inv := testInvocation()
+	opts := &monorepoOptions{}
+	inv.rootPath, inv.outputFormat = t.TempDir(), "console"
+
+	_, err := runMonorepo(inv, opts, nil)
+	assert.Equal(t, ExitUsage, exitCodeForError(err), "no packages")
+
+	_, err = runMonorepo(inv, opts, []string{filepath.Join(inv.rootPath, "missing")})
+	assert.Equal(t, ExitUsage, exitCodeForError(err), "missing directory")
+
+	opts.base = "main"
+	_, err = runMonorepo(inv, opts, []string{inv.rootPath})
+	assert.Equal(t, ExitUsage, exitCodeForError(err), "--base without --changed-packages")
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// newPermissionsCmd builds the permissions command and its subcommands.
+// The --mode flag of permissions test sets the permission mode to decide in.
+func newPermissionsCmd(inv *invocation) *cobra.Command {
+	var mode string
+	permissionsCmd := &cobra.Command{
+		Use:   "permissions",
+		Short: "Inspect the permission rules that apply to a project",
+		Long: `Inspect the permission rules of the settings hierarchy. See
+"cclint permissions test".`,
+	}
+
+	permissionsTestCmd := &cobra.Command{
+		Use:   "test <tool-use>...",
+		Short: "Show whether a tool use is allowed, asked about, or denied, and by which rule",
+		Long: `Decide a tool use the way Claude Code does and report the decision, the
+rule that made it, and the settings layer the rule comes from. Write each
+tool use as a permission rule: Bash(npm run build), Read(./.env),
+WebFetch(https://example.com), mcp__github__create_issue.
+
+The rules come from the settings hierarchy of a session started in the
+project: managed settings, .claude/settings.local.json,
+.claude/settings.json, then ~/.claude/settings.json. Deny rules are checked
+first, then ask, then allow, so a deny in any layer wins. A Bash command
+chained with &&, ||, ;, or | is denied or asked about when the whole
+command or any command in it is, and allowed only when the whole command
+or every command in it is. A tool use no rule matches
+is decided by the permission mode: defaultMode from the highest layer that
+sets one, or --mode. The other rules that match are listed after the
+deciding one.
+
+EXAMPLES:
+
+  cclint permissions test "Bash(npm run build)"
+  cclint permissions test "Bash(git push --force)" "Read(./.env)"
+  cclint permissions test "Edit(src/main.go)" --mode acceptEdits --format json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runPermissionsTest(inv, mode, args)
+		}),
+	}
+
+	permissionsTestCmd.Flags().StringVar(&mode, "mode", "", "Permission mode to decide in, overriding defaultMode ("+strings.Join(lint.PermissionModes(), "|")+")")
+	permissionsCmd.AddCommand(permissionsTestCmd)
+	return permissionsCmd
+}
+
+// permissionsTestReport is the JSON report of permissions test.
+type permissionsTestReport struct {
+	Layers    []permissionLayerRef      `json:"layers"`
+	Decisions []lint.PermissionDecision `json:"decisions"`
+}
+
+// permissionLayerRef names a settings file of the hierarchy.
+type permissionLayerRef struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+}
+
+func runPermissionsTest(inv *invocation, mode string, args []string) (cmdResult, error) {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	if cfg.Format != "console" && cfg.Format != "json" {
+		return cmdResult{}, usageErrorf("permissions test supports --format console or json, not %q", cfg.Format)
+	}
+	if mode != "" && !lint.IsPermissionMode(mode) {
+		return cmdResult{}, usageErrorf("unknown permission mode %q; one of: %s", mode, strings.Join(lint.PermissionModes(), ", "))
+	}
+
+	// The hierarchy is that of a session started in the project, so the
+	// root is --root or the detected project, never the ~/.claude fallback.
+	root := inv.rootPath
+	if root == "" {
+		if root, err = project.FindProjectRoot("."); err != nil {
+			return cmdResult{}, fmt.Errorf("error finding project root: %w", err)
+		}
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return cmdResult{}, fmt.Errorf("error resolving project root: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return cmdResult{}, fmt.Errorf("error finding home directory: %w", err)
+	}
+	settings, err := lint.LoadPermissionSettings(root, home)
+	if err != nil {
+		return cmdResult{}, fmt.Errorf("error reading settings: %w", err)
+	}
+	settings.Mode = mode
+
+	report := permissionsTestReport{Layers: []permissionLayerRef{}}
+	for _, layer := range settings.Layers {
+		report.Layers = append(report.Layers, permissionLayerRef{Name: layer.Name, File: layer.File})
+	}
+	for _, arg := range args {
+		decision, err := settings.Decide(arg)
+		if err != nil {
+			return cmdResult{}, asUsageError(err)
+		}
+		report.Decisions = append(report.Decisions, decision)
+	}
+
+	if cfg.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return resultOK, enc.Encode(report)
+	}
+	printPermissionDecisions(report, root, home)
+	return resultOK, nil
+}
+
+// printPermissionDecisions prints each decision with the rule that made it
+// and the other rules that matched.
+func printPermissionDecisions(report permissionsTestReport, root, home string) {
+	styles := newPrintStyles()
+	behaviorStyle := map[string]func(...string) string{
+		"allow": styles.tierA.Render,
+		"ask":   styles.tierC.Render,
+		"deny":  styles.tierDF.Render,
+	}
+	settingsPath := func(file string) string {
+		if rel, ok := strings.CutPrefix(file, home+string(filepath.Separator)); ok && !strings.HasPrefix(file, root+string(filepath.Separator)) {
+			return "~/" + filepath.ToSlash(rel)
+		}
+		return displayPath(root, file)
+	}
+
+	if len(report.Layers) == 0 {
+		fmt.Println(styles.dim.Render("No settings files found; the permission mode decides."))
+	}
+	for i, d := range report.Decisions {
+		if i > 0 || len(report.Layers) == 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s  %s\n", styles.header.Render(d.Request), behaviorStyle[d.Behavior](d.Behavior))
+		if d.Rule != nil {
+			fmt.Printf("  rule    %s  %s\n", d.Rule.Rule, styles.dim.Render(fmt.Sprintf("%s, %s settings, %s", d.Rule.Behavior, d.Rule.Layer, settingsPath(d.Rule.File))))
+		} else {
+			fmt.Printf("  mode    %s\n", d.Mode)
+		}
+		fmt.Printf("  reason  %s\n", d.Reason)
+		for _, m := range d.Matches {
+			if d.Rule != nil && m == *d.Rule {
+				continue
+			}
+			fmt.Println(styles.dim.Render(fmt.Sprintf("  also    %s %s  %s settings, %s", m.Behavior, m.Rule, m.Layer, settingsPath(m.File))))
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionsTest(t *testing.T) {
+	root, home := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".claude"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".claude"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".claude", "settings.json"),
+		[]byte(`{"permissions": {"allow": ["Bash(npm run:*)"], "ask": ["Bash(git push:*)"]}}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".claude", "settings.json"),
+		[]byte(`{"permissions": {"allow": ["Bash(git push:*)"], "deny": ["Bash(rm:*)"]}}`), 0o600))
+
+	inv := testInvocation()
+	inv.rootPath = root
+
+	out, result, err := captureStdout(t, func() (cmdResult, error) {
+		return runPermissionsTest(inv, "", []string{"Bash(git push origin)", "Bash(npm run build && rm -rf dist)"})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ExitClean, result.ExitCode)
+	assert.Contains(t, out, "Bash(git push origin)  ask")
+	assert.Contains(t, out, "rule    Bash(git push:*)  ask, project settings, .claude/settings.json")
+	assert.Contains(t, out, "also    allow Bash(git push:*)  user settings, ~/.claude/settings.json")
+	assert.Contains(t, out, "Bash(npm run build && rm -rf dist)  deny")
+	assert.Contains(t, out, `matches "rm -rf dist" in the command chain`)
+
+	inv.outputFormat = "json"
+	out, _, err = captureStdout(t, func() (cmdResult, error) {
+		return runPermissionsTest(inv, "bypassPermissions", []string{"Bash(make)"})
+	})
+	require.NoError(t, err)
+	var report permissionsTestReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Len(t, report.Layers, 2)
+	assert.Equal(t, "project", report.Layers[0].Name)
+	require.Len(t, report.Decisions, 1)
+	assert.Equal(t, "allow", report.Decisions[0].Behavior)
+	assert.Equal(t, "bypassPermissions", report.Decisions[0].Mode)
+
+	_, err = runPermissionsTest(inv, "yolo", []string{"Bash(make)"})
+	assert.Equal(t, ExitUsage, exitCodeForError(err))
+	_, err = runPermissionsTest(inv, "", []string{"Bash(make"})
+	assert.Equal(t, ExitUsage, exitCodeForError(err))
+}
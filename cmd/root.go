@@ -5,12 +5,14 @@ import (
 	"os"
 	"time"
 
+	"github.com/dotcommander/cclint/internal/buildinfo"
 	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/discovery"
 	"github.com/dotcommander/cclint/internal/git"
 	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/output"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
+	"github.com/spf13/pflag"
 	"golang.org/x/term"
 )
 
@@ -19,33 +21,85 @@ import (
 //	go build -ldflags "-X github.com/dotcommander/cclint/cmd.Version=1.0.0"
 var Version = "dev"
 
+// Commit and BuildDate identify the build in reports and cclint version.
+// When unset they fall back to the revision and commit time Go records for
+// builds from a checkout. Set at build time via ldflags:
+//
+//	go build -ldflags "-X github.com/dotcommander/cclint/cmd.Commit=$(git rev-parse HEAD) -X github.com/dotcommander/cclint/cmd.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
 var (
-	rootPath         string
-	quiet            bool
-	verbose          bool
-	showScores       bool
-	showImprovements bool
-	outputFormat     string
-	outputFile       string
-	failOn           string
-	typeFlag         string // Force component type (--type flag)
-	diffMode         bool   // Lint only changed files (--diff)
-	stagedMode       bool   // Lint only staged files (--staged)
-	noCycleCheck     bool   // Disable circular dependency detection
-	useBaseline      bool   // Use baseline filtering
-	createBaseline   bool   // Create/update baseline file
-	baselinePath     string // Custom baseline file path
-
-	// exitFunc is the function called to exit the program.
-	// It can be overridden in tests to prevent actual process termination.
-	exitFunc = os.Exit
+	Commit    = ""
+	BuildDate = ""
 )
 
-var rootCmd = &cobra.Command{
-	Use:     "cclint [files|dirs...]",
-	Short:   "Claude Code Lint - A comprehensive linting tool for Claude Code projects",
-	Version: Version,
-	Long: `CCLint is a linting tool for Claude Code projects that validates agent files,
+// DocsURLTemplate is the URL of a rule's documentation, with {rule} and
+// {category} placeholders; findings carry no docs URL when it is empty.
+// Set at build time via ldflags:
+//
+//	go build -ldflags "-X 'github.com/dotcommander/cclint/cmd.DocsURLTemplate=https://docs.example.com/rules/{rule}'"
+var DocsURLTemplate = ""
+
+// invocation holds the flag values of one command run. newRootCmd binds the
+// flags of a fresh command tree to it, and commands pass it down rather
+// than reading package state, so runs never share flag values.
+type invocation struct {
+	rootPath          string
+	verbosity         string
+	quiet             bool
+	verbose           bool
+	show              []string
+	showScores        bool
+	showImprovements  bool
+	outputFormat      string
+	outputFile        string
+	failOn            string
+	groupBy           string
+	maxIssuesPerFile  int
+	colorMode         string
+	snippets          bool
+	typeFlag          string   // Force component type (--type flag)
+	diffMode          bool     // Lint only changed files (--diff)
+	stagedMode        bool     // Lint only staged files (--staged)
+	fixMode           bool     // Apply autofixes (--fix)
+	fixDryRun         bool     // Print the combined autofix diff (--fix-dry-run)
+	progress          bool     // Show a progress bar instead of the spinner (--progress)
+	noCycleCheck      bool     // Disable circular dependency detection
+	useBaseline       bool     // Use baseline filtering
+	createBaseline    bool     // Create/update baseline file
+	baselinePath      string   // Custom baseline file path
+	onlyTypes         []string // Lint only these component types (--only)
+	skipTypes         []string // Skip these component types (--skip)
+	offline           bool     // Use cached remote data only (--offline)
+	enableCategories  []string // Turn rule categories on (--enable-category)
+	disableCategories []string // Turn rule categories off (--disable-category)
+	includeChains     bool     // Add delegation chains to JSON reports (--chains)
+	untrusted         bool     // Audit as third-party content (--untrusted)
+	ownerFilter       []string // Report only files of these CODEOWNERS owners (--owner)
+	blame             bool     // Add the last commit of each finding's line to JSON reports (--blame)
+
+	// changed holds the names of the flags given on the command line; see
+	// flagChanged.
+	changed map[string]bool
+
+	// scopeOnly and scopeSkip hold the parsed --only and --skip types for
+	// the full run, set by resolveTypeScope.
+	scopeOnly, scopeSkip []discovery.FileType
+}
+
+// flagChanged reports whether the named flag was given on the command line.
+// Options that keep their config-file value unless a flag sets them check
+// it; CCLINT_* variables reach them through config.LoadConfig.
+func (inv *invocation) flagChanged(name string) bool {
+	return inv.changed[name]
+}
+
+// newRootCmd builds the command tree with its flags bound to inv. Each
+// Execute builds its own, so no flag state outlives a run.
+func newRootCmd(inv *invocation) *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:     "cclint [files|dirs...]",
+		Short:   "Claude Code Lint - A comprehensive linting tool for Claude Code projects",
+		Version: buildinfo.Read(Version, Commit, BuildDate).Version,
+		Long: `CCLint is a linting tool for Claude Code projects that validates agent files,
 command files, settings, and documentation according to established patterns.
 
 USAGE MODES:
@@ -73,6 +127,22 @@ USAGE MODES:
   Type override:
     cclint --type agent x.md  Override type detection
 
+  Scoped full scan:
+    cclint --only agents,skills   Lint some types, keep cross-file checks
+    cclint --skip settings        Lint everything except some types
+
+  Ownership (CODEOWNERS):
+    cclint --owner @org/team-x    Report only files owned by a team
+    cclint --blame --format json  Add the last commit of each finding's line
+
+EXIT CODES:
+
+  0  No findings at or above the --fail-on threshold
+  1  Findings at or above the --fail-on threshold
+  2  Usage error (invalid flags, arguments, paths, or configuration)
+  3  Internal error
+  4  Quality gate failed (gates config block)
+
 EXAMPLES:
 
   # Lint a single agent
@@ -96,112 +166,136 @@ EXAMPLES:
   # Lint with baseline (only new issues fail)
   cclint --baseline
 
+  # Apply autofixes, or preview them as one diff
+  cclint --fix
+  cclint agents --fix-dry-run
+
   # Force type for file outside standard path
   cclint --type skill ./custom/methodology.md
 
+  # Fail on warnings for agents, errors everywhere else
+  cclint --fail-on error,agents=warning
+
+  # Route lint debt: one team's files, or files no one owns
+  cclint --owner @org/platform
+  cclint --owner none
+
 ⚠️  NOTE: cclint is a work in progress. Its suggestions should be validated:
    • Cross-reference with official docs: docs.anthropic.com, docs.claude.com
    • Clear violations (fake flags, >220 lines agents) are reliable
    • Style suggestions should be verified against official documentation`,
-	Args: cobra.ArbitraryArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := runRootCommand(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			exitFunc(1)
-		}
-	},
-}
-
-func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		exitFunc(1)
+		Args: cobra.ArbitraryArgs,
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runRootCommand(inv, args)
+		}),
+		// Record which flags were given, for flagChanged.
+		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			inv.changed = make(map[string]bool)
+			cmd.Flags().Visit(func(f *pflag.Flag) { inv.changed[f.Name] = true })
+		},
 	}
-}
 
-func init() {
-	cobra.OnInitialize(initConfig)
+	// Flag parse errors are invocation mistakes, not internal failures.
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return asUsageError(err)
+	})
 
 	// Use -V for version since -v is already used for verbose
 	rootCmd.Flags().BoolP("version", "V", false, "Print version information")
 
 	// Existing flags
-	rootCmd.PersistentFlags().StringVarP(&rootPath, "root", "r", "", "Project root directory (auto-detected if not specified)")
-	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress non-essential output")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
-	rootCmd.PersistentFlags().BoolVarP(&showScores, "scores", "s", false, "Show quality scores (0-100) for each component")
-	rootCmd.PersistentFlags().BoolVarP(&showImprovements, "improvements", "i", false, "Show specific improvements with point values")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "format", "f", "console", "Output format for reports (console|json|markdown)")
-	rootCmd.PersistentFlags().StringVarP(&outputFile, "output", "o", "", "Output file for reports (requires --format)")
-	rootCmd.PersistentFlags().StringVarP(&failOn, "fail-on", "", "error", "Fail build on specified level (error|warning|suggestion)")
+	rootCmd.PersistentFlags().StringVarP(&inv.rootPath, "root", "r", "", "Project root directory (auto-detected if not specified)")
+	rootCmd.PersistentFlags().StringVar(&inv.verbosity, "verbosity", "normal", "Output level (quiet|normal|verbose); does not change the exit code")
+	rootCmd.PersistentFlags().BoolVarP(&inv.quiet, "quiet", "q", false, "Shorthand for --verbosity quiet")
+	rootCmd.PersistentFlags().BoolVarP(&inv.verbose, "verbose", "v", false, "Shorthand for --verbosity verbose")
+	rootCmd.PersistentFlags().StringSliceVar(&inv.show, "show", nil, "List only these findings (errors,warnings,suggestions,info); display only, exit code still counts all")
+	rootCmd.PersistentFlags().BoolVarP(&inv.showScores, "scores", "s", false, "Show quality scores (0-100) for each component")
+	rootCmd.PersistentFlags().BoolVarP(&inv.showImprovements, "improvements", "i", false, "Show specific improvements with point values")
+	rootCmd.PersistentFlags().StringVarP(&inv.outputFormat, "format", "f", "console", "Output format for reports (console|json|jsonl|markdown|tap|teamcity|compact); jsonl streams full runs; json@1 selects the deprecated v1 JSON schema")
+	rootCmd.PersistentFlags().StringVarP(&inv.outputFile, "output", "o", "", "Output file for reports (requires --format)")
+	rootCmd.PersistentFlags().StringVar(&inv.groupBy, "group-by", "file", "Group console findings by file, rule, or severity")
+	rootCmd.PersistentFlags().IntVar(&inv.maxIssuesPerFile, "max-issues-per-file", 0, "Show at most N console findings per file (0 = no limit)")
+	rootCmd.PersistentFlags().StringVar(&inv.colorMode, "color", "auto", "Color console output (auto|always|never); auto honors NO_COLOR")
+	rootCmd.PersistentFlags().BoolVar(&inv.snippets, "snippets", true, "Show source snippets under console findings (--snippets=false to hide)")
+	rootCmd.PersistentFlags().StringVarP(&inv.failOn, "fail-on", "", "error", "Fail build on specified level (error|warning|suggestion); comma list with per-type overrides, e.g. error,agents=warning")
 
 	// Single-file mode flags
-	rootCmd.Flags().StringVarP(&typeFlag, "type", "t", "", "Force component type (agent|command|skill|settings|context|plugin|rule|output-style)")
+	rootCmd.Flags().StringVarP(&inv.typeFlag, "type", "t", "", "Force component type (agent|command|skill|settings|context|plugin|rule|output-style)")
+
+	// Scope flags for full runs
+	rootCmd.Flags().StringSliceVar(&inv.onlyTypes, "only", nil, "Full run: lint only these component types (e.g. agents,skills)")
+	rootCmd.Flags().StringSliceVar(&inv.skipTypes, "skip", nil, "Full run: skip these component types (e.g. settings)")
+	rootCmd.Flags().BoolVar(&inv.progress, "progress", false, "Show a progress bar on stderr while linting (terminal only)")
 
 	// Git integration flags
-	rootCmd.Flags().BoolVar(&diffMode, "diff", false, "Lint only uncommitted changes (staged + unstaged)")
-	rootCmd.Flags().BoolVar(&stagedMode, "staged", false, "Lint only staged files (for pre-commit hooks)")
+	rootCmd.Flags().BoolVar(&inv.diffMode, "diff", false, "Lint only uncommitted changes (staged + unstaged)")
+	rootCmd.Flags().BoolVar(&inv.stagedMode, "staged", false, "Lint only staged files (for pre-commit hooks)")
+
+	// Autofix flags
+	rootCmd.Flags().BoolVar(&inv.fixMode, "fix", false, "Apply autofixes; they are rolled back if the tree then lints worse")
+	rootCmd.Flags().BoolVar(&inv.fixDryRun, "fix-dry-run", false, "Print the combined diff of all autofixes without writing")
 
 	// Analysis flags
-	rootCmd.PersistentFlags().BoolVar(&noCycleCheck, "no-cycle-check", false, "Disable circular dependency detection")
+	rootCmd.PersistentFlags().BoolVar(&inv.noCycleCheck, "no-cycle-check", false, "Disable circular dependency detection")
+	rootCmd.PersistentFlags().BoolVar(&inv.includeChains, "chains", false, "Add the delegation chain of each command or agent to JSON reports")
 
-	// Baseline flags
-	rootCmd.PersistentFlags().BoolVar(&useBaseline, "baseline", false, "Use .cclintbaseline.json to filter known issues")
-	rootCmd.PersistentFlags().BoolVar(&createBaseline, "baseline-create", false, "Create/update baseline file from current issues")
-	rootCmd.PersistentFlags().StringVar(&baselinePath, "baseline-path", ".cclintbaseline.json", "Path to baseline file")
-
-	// Viper bindings
-	mustBindPFlag("root", "root")
-	mustBindPFlag("quiet", "quiet")
-	mustBindPFlag("verbose", "verbose")
-	mustBindPFlag("showScores", "scores")
-	mustBindPFlag("showImprovements", "improvements")
-	mustBindPFlag("format", "format")
-	mustBindPFlag("output", "output")
-	mustBindPFlag("fail-on", "fail-on")
-	mustBindPFlag("no-cycle-check", "no-cycle-check")
-}
+	// Rule category flags
+	rootCmd.PersistentFlags().StringSliceVar(&inv.enableCategories, "enable-category", nil, "Turn rule categories on (security|structure|references|style|performance), overriding the config")
+	rootCmd.PersistentFlags().StringSliceVar(&inv.disableCategories, "disable-category", nil, "Turn rule categories off (security|structure|references|style|performance)")
 
-func mustBindPFlag(key, flagName string) {
-	flag := rootCmd.PersistentFlags().Lookup(flagName)
-	if flag == nil {
-		panic(fmt.Sprintf("missing persistent flag %q", flagName))
-	}
-	if err := viper.BindPFlag(key, flag); err != nil {
-		panic(fmt.Sprintf("bind flag %q: %v", flagName, err))
-	}
-}
+	// Network flags
+	rootCmd.PersistentFlags().BoolVar(&inv.offline, "offline", false, "Use cached remote data only; checks that need the network are skipped")
 
-// shouldFail checks if the lint run should exit with failure based on the --fail-on level.
-func shouldFail(cfg *config.Config, errors, warnings, suggestions int) bool {
-	switch cfg.FailOn {
-	case "suggestion":
-		if suggestions > 0 {
-			return true
-		}
-		fallthrough
-	case "warning":
-		if warnings > 0 {
-			return true
-		}
-		fallthrough
-	default: // "error"
-		return errors > 0
-	}
+	// Audit flags
+	rootCmd.PersistentFlags().BoolVar(&inv.untrusted, "untrusted", false, "Audit as third-party content: security findings become errors and hooks are checked for network access and outside writes")
+
+	// Ownership flags
+	rootCmd.PersistentFlags().StringSliceVar(&inv.ownerFilter, "owner", nil, "Report only files owned by these CODEOWNERS owners (e.g. @org/team); none selects unowned files")
+	rootCmd.PersistentFlags().BoolVar(&inv.blame, "blame", false, "Add the last commit author and date of each finding's line to JSON reports, from git blame")
+
+	// Baseline flags
+	rootCmd.PersistentFlags().BoolVar(&inv.useBaseline, "baseline", false, "Use .cclintbaseline.json to filter known issues")
+	rootCmd.PersistentFlags().BoolVar(&inv.createBaseline, "baseline-create", false, "Create/update baseline file from current issues")
+	rootCmd.PersistentFlags().StringVar(&inv.baselinePath, "baseline-path", ".cclintbaseline.json", "Path to baseline file")
+
+	rootCmd.AddCommand(
+		newAuditCmd(inv),
+		newBadgeCmd(inv),
+		newBaselineCmd(inv),
+		newChurnCmd(inv),
+		newContextCmd(inv),
+		newDiscoverCmd(inv),
+		newFmtCmd(inv),
+		newGenerateFixtureCmd(inv),
+		newHooksCmd(inv),
+		newImpactCmd(inv),
+		newMigrateCmd(inv),
+		newMonorepoCmd(inv),
+		newPermissionsCmd(inv),
+		newSchemaCmd(inv),
+		newSelftestCmd(inv),
+		newShowCmd(inv),
+		newSnapshotCmd(inv),
+		newStatsCmd(inv),
+		newSummaryCmd(inv),
+		newTraceCmd(inv),
+		newTUICmd(inv),
+		newVersionCmd(inv),
+	)
+	return rootCmd
 }
 
-func initConfig() {
-	// Config loading is handled by config.LoadConfig — this hook only
-	// registers environment variable support so viper flag bindings work
-	// before LoadConfig is called.
-	viper.SetEnvPrefix("CCLINT")
-	viper.AutomaticEnv()
+// Execute runs the root command. It is the only place where a command
+// outcome becomes a process exit.
+func Execute() {
+	exitWithCode(executeRoot(newRootCmd(&invocation{})))
 }
 
 // startSpinner starts a braille spinner on stderr showing elapsed time.
 // It returns a stop func that clears the line when called.
 // If verbose, quiet, or stderr is not a TTY, returns a no-op stop func.
 func startSpinner(cfg *config.Config) func() {
-	if cfg.Verbose || cfg.Quiet || !term.IsTerminal(int(os.Stderr.Fd())) {
+	if cfg.Verbose() || cfg.Quiet() || !term.IsTerminal(int(os.Stderr.Fd())) {
 		return func() {}
 	}
 
@@ -233,50 +327,123 @@ func startSpinner(cfg *config.Config) func() {
 	}
 }
 
-func runLint() error {
-	cfg, err := loadCLIConfig()
+// startProgress shows that a lint run is under way: the --progress bar or
+// the spinner, both on stderr. --format jsonl shows neither, since its
+// events already report progress. It returns the Progress func that
+// updates the bar, nil without one, and a stop func that clears the line.
+func startProgress(inv *invocation, cfg *config.Config) (progress func(lint.Progress), stop func()) {
+	if cfg.Format == "jsonl" {
+		return nil, func() {}
+	}
+	if !inv.progress {
+		return nil, startSpinner(cfg)
+	}
+	if cfg.Verbose() || cfg.Quiet() || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return nil, func() {}
+	}
+
+	bar := output.NewProgressBar(os.Stderr, time.Now())
+	return bar.Update, bar.Clear
+}
+
+// runStreamingLint is runFullLint for --format jsonl. It writes each
+// file's findings as soon as they are final, and progress events while
+// files are validated, instead of buffering the report.
+func runStreamingLint(inv *invocation, cfg *config.Config) (cmdResult, error) {
+	show, err := config.ParseShow(cfg.Show)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	out, closeOut, err := output.CreateReportWriter(cfg.Output)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	w := output.NewJSONLWriter(out, time.Now()).WithShow(output.NewShowFilter(show)).WithDocsURLTemplate(cfg.DocsURLTemplate)
+	result, err := runOrchestratedLintTo(inv, cfg, nil, w.Progress, w.Summary)
+	if err != nil {
+		return cmdResult{}, closeOut(err)
+	}
+	if err := closeOut(w.Finish(result.Summaries)); err != nil {
+		return cmdResult{}, fmt.Errorf("error formatting output: %w", err)
+	}
+
+	printSuppressedSummary(result.SuppressedIssues(), cfg.Quiet())
+	return failurePolicyResult(inv, cfg, result.Summaries...), nil
+}
+
+func runLint(inv *invocation) (cmdResult, error) {
+	cfg, err := loadCLIConfig(inv)
 	if err != nil {
-		return err
+		return cmdResult{}, err
 	}
+	return runFullLint(inv, cfg)
+}
 
-	result, err := runOrchestratedLint(cfg, nil)
+// runFullLint lints every component under cfg.Root and reports the result.
+func runFullLint(inv *invocation, cfg *config.Config) (cmdResult, error) {
+	if cfg.Format == "jsonl" && !inv.fixMode && !inv.fixDryRun {
+		return runStreamingLint(inv, cfg)
+	}
+	result, err := runOrchestratedLint(inv, cfg, nil)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	result, done, err := applyLintFixes(inv, cfg, nil, result)
 	if err != nil {
-		return err
+		return cmdResult{}, fmt.Errorf("error applying fixes: %w", err)
+	}
+	if done {
+		return resultOK, nil
 	}
 
 	if err := formatFullRunOutput(cfg, result); err != nil {
-		return fmt.Errorf("error formatting output: %w", err)
+		return cmdResult{}, fmt.Errorf("error formatting output: %w", err)
 	}
 
-	printBaselineSummary(result.BaselineIgnored, result.ErrorsIgnored, result.SuggestionsIgnored, cfg.Quiet)
+	printSuppressedSummary(result.SuppressedIssues(), cfg.Quiet())
 	printValidationReminder(cfg)
-	applyFailurePolicy(cfg, result.TotalErrors, result.TotalWarnings, result.TotalSuggestions)
 
-	return nil
+	return failurePolicyResult(inv, cfg, result.Summaries...), nil
 }
 
-func runRootCommand(args []string) error {
-	if diffMode || stagedMode {
-		return runGitLint()
+func runRootCommand(inv *invocation, args []string) (cmdResult, error) {
+	if err := resolveTypeScope(inv, len(args) > 0 || inv.diffMode || inv.stagedMode); err != nil {
+		return cmdResult{}, asUsageError(err)
+	}
+
+	if inv.diffMode || inv.stagedMode {
+		if err := validateFixFlags(inv, true); err != nil {
+			return cmdResult{}, asUsageError(err)
+		}
+		return runGitLint(inv)
 	}
 
 	classified, err := classifyArgs(args)
 	if err != nil {
-		return err
+		return cmdResult{}, asUsageError(err)
+	}
+	if err := validateFixFlags(inv, len(classified.filePaths) > 0); err != nil {
+		return cmdResult{}, asUsageError(err)
 	}
 
 	switch {
 	case len(classified.filePaths) > 0:
-		return runSingleFileLint(classified.filePaths)
+		return runSingleFileLint(inv, classified.filePaths)
 	case len(classified.typeFilters) > 0:
+		// Each type runs independently; the worst exit code wins.
+		combined := resultOK
 		for _, ft := range classified.typeFilters {
-			if err := runTypeLint(ft); err != nil {
-				return err
+			res, err := runTypeLint(inv, ft)
+			if err != nil {
+				return cmdResult{}, err
+			}
+			if res.ExitCode > combined.ExitCode {
+				combined = res
 			}
 		}
-		return nil
+		return combined, nil
 	default:
-		return runLint()
+		return runLint(inv)
 	}
 }
 
@@ -315,84 +482,106 @@ func classifyArgs(args []string) (*classifiedArgs, error) {
 // runSingleFileLint lints specific files and outputs results.
 //
 // Exit codes:
-//   - 0: All files passed (no errors)
-//   - 1: One or more files had lint errors
-//   - 2: Invocation error (file not found, invalid type, etc.)
-func runSingleFileLint(files []string) error {
-	cfg, err := loadCLIConfig()
+//   - ExitClean: All files passed the --fail-on threshold
+//   - ExitFindings: One or more files had findings at or above the threshold
+//   - ExitUsage: Invocation error (no lintable files, invalid type, etc.)
+func runSingleFileLint(inv *invocation, files []string) (cmdResult, error) {
+	cfg, err := loadCLIConfig(inv)
 	if err != nil {
-		return err
+		return cmdResult{}, err
 	}
 
-	summary, err := lint.LintFiles(files, rootPath, typeFlag, cfg.Quiet, cfg.Verbose)
+	summary, err := lint.LintFiles(files, inv.rootPath, inv.typeFlag, lint.Options{Config: cfg})
 	if err != nil {
-		return err
+		return cmdResult{}, asUsageError(err)
+	}
+	if err := applyCodeOwners(inv, cfg, summary); err != nil {
+		return cmdResult{}, err
+	}
+	lint.ApplyRulesConfig(summary, cfg.Rules)
+	lint.ApplyOverrides(summary, cfg.Overrides)
+	if cfg.Untrusted {
+		lint.ApplyUntrusted(summary)
+	}
+	lint.ApplyInlineSuppressions(summary)
+	if inv.blame {
+		lint.ApplyBlame(summary, cfg.Quiet())
 	}
 
 	if err := formatSummaryOutput(cfg, summary); err != nil {
-		return fmt.Errorf("error formatting output: %w", err)
+		return cmdResult{}, fmt.Errorf("error formatting output: %w", err)
 	}
 
 	printValidationReminder(cfg)
-	applyFailurePolicy(cfg, summary.TotalErrors, summary.TotalWarnings, summary.TotalSuggestions)
 
-	return nil
+	return failurePolicyResult(inv, cfg, summary), nil
 }
 
 // runGitLint lints files based on git status (--diff or --staged)
-func runGitLint() error {
-	cfg, err := loadCLIConfig()
+func runGitLint(inv *invocation) (cmdResult, error) {
+	cfg, err := loadCLIConfig(inv)
 	if err != nil {
-		return err
+		return cmdResult{}, err
 	}
 
 	// Determine git root (use current directory if rootPath not specified)
 	gitRoot := cfg.Root
-	if rootPath == "" {
+	if inv.rootPath == "" {
 		// Use current working directory for git operations
 		gitRoot, err = os.Getwd()
 		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
+			return cmdResult{}, fmt.Errorf("failed to get current directory: %w", err)
 		}
 	}
 
 	// Check if in git repository
 	if !git.IsGitRepo(gitRoot) {
-		if !cfg.Quiet {
+		if !cfg.Quiet() {
 			fmt.Fprintf(os.Stderr, "Warning: Not in a git repository. Falling back to full lint.\n\n")
 		}
-		return runLint()
+		return runLint(inv)
 	}
 
 	// Get files from git
 	var files []string
-	if stagedMode {
+	if inv.stagedMode {
 		files, err = git.GetStagedFiles(gitRoot)
-	} else if diffMode {
+	} else if inv.diffMode {
 		files, err = git.GetChangedFiles(gitRoot)
 	}
 	if err != nil {
-		return fmt.Errorf("error getting git files: %w", err)
+		return cmdResult{}, fmt.Errorf("error getting git files: %w", err)
 	}
 
 	if len(files) == 0 {
-		if !cfg.Quiet {
+		if !cfg.Quiet() {
 			fmt.Println("No files to lint")
 		}
-		return nil
+		return resultOK, nil
 	}
 
-	summary, err := lint.LintFiles(files, gitRoot, "", cfg.Quiet, cfg.Verbose)
+	summary, err := lint.LintFiles(files, gitRoot, "", lint.Options{Config: cfg})
 	if err != nil {
-		return err
+		return cmdResult{}, err
+	}
+	if err := applyCodeOwners(inv, cfg, summary); err != nil {
+		return cmdResult{}, err
+	}
+	lint.ApplyRulesConfig(summary, cfg.Rules)
+	lint.ApplyOverrides(summary, cfg.Overrides)
+	if cfg.Untrusted {
+		lint.ApplyUntrusted(summary)
+	}
+	lint.ApplyInlineSuppressions(summary)
+	if inv.blame {
+		lint.ApplyBlame(summary, cfg.Quiet())
 	}
 
 	if err := formatSummaryOutput(cfg, summary); err != nil {
-		return fmt.Errorf("error formatting output: %w", err)
+		return cmdResult{}, fmt.Errorf("error formatting output: %w", err)
 	}
 
 	printValidationReminder(cfg)
-	applyFailurePolicy(cfg, summary.TotalErrors, summary.TotalWarnings, summary.TotalSuggestions)
 
-	return nil
+	return failurePolicyResult(inv, cfg, summary), nil
 }
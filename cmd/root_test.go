@@ -1,15 +1,25 @@
 package cmd
 
 import (
+	"encoding/json"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// testInvocation returns an invocation holding the root flag defaults, as
+// a run without flags starts with.
+func testInvocation() *invocation {
+	inv := &invocation{}
+	newRootCmd(inv)
+	return inv
+}
+
 func TestRunSingleFileLint(t *testing.T) {
 	// Create temporary test directory
 	tmpDir := t.TempDir()
@@ -60,38 +70,52 @@ This is a test agent.
 			quiet:     true,
 			wantError: false,
 		},
-		// Note: Tests for error cases (invalid files, non-existent files) are skipped
-		// because runSingleFileLint calls os.Exit() directly which terminates the test
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Set global flags
-			oldRootPath := rootPath
-			oldQuiet := quiet
-			oldVerbose := verbose
-			oldTypeFlag := typeFlag
-
-			rootPath = tt.rootPath
-			quiet = tt.quiet
-			verbose = false
-			typeFlag = tt.typeFlag
-
-			defer func() {
-				// Restore global flags
-				rootPath = oldRootPath
-				quiet = oldQuiet
-				verbose = oldVerbose
-				typeFlag = oldTypeFlag
-			}()
+			// Set flags
+			inv := testInvocation()
+
+			inv.rootPath = tt.rootPath
+			inv.quiet = tt.quiet
+			inv.verbose = false
+			inv.typeFlag = tt.typeFlag
 
 			// Run the function - only testing success cases
-			err := runSingleFileLint(tt.files)
+			_, err := runSingleFileLint(inv, tt.files)
 			assert.NoError(t, err)
 		})
 	}
 }
 
+func TestRunSingleFileLint_ErrorResults(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	inv := testInvocation()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.typeFlag = ""
+
+	// A missing file is recorded as a failed result, not a process exit
+	res, err := runSingleFileLint(inv, []string{filepath.Join(tmpDir, "agents", "missing.md")})
+	require.NoError(t, err)
+	assert.Equal(t, ExitFindings, res.ExitCode)
+
+	// An empty directory has nothing to lint — an invocation error
+	emptyDir := filepath.Join(tmpDir, "empty")
+	require.NoError(t, os.MkdirAll(emptyDir, 0755))
+	_, err = runSingleFileLint(inv, []string{emptyDir})
+	require.Error(t, err)
+	assert.Equal(t, ExitUsage, exitCodeForError(err))
+}
+
+func TestRunRootCommand_MixedArgsIsUsageError(t *testing.T) {
+	_, err := runRootCommand(testInvocation(), []string{"agents", "./some/file.md"})
+	require.Error(t, err)
+	assert.Equal(t, ExitUsage, exitCodeForError(err))
+}
+
 func TestRunGitLint(t *testing.T) {
 	// Create temporary test directory with git repo
 	tmpDir := t.TempDir()
@@ -153,18 +177,14 @@ Test agent.
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Set global flags
-			oldRootPath := rootPath
-			oldQuiet := quiet
-			oldVerbose := verbose
-			oldStagedMode := stagedMode
-			oldDiffMode := diffMode
-
-			rootPath = tt.rootPath
-			quiet = tt.quiet
-			verbose = false
-			stagedMode = tt.stagedMode
-			diffMode = tt.diffMode
+			// Set flags
+			inv := testInvocation()
+
+			inv.rootPath = tt.rootPath
+			inv.quiet = tt.quiet
+			inv.verbose = false
+			inv.stagedMode = tt.stagedMode
+			inv.diffMode = tt.diffMode
 
 			// Capture exit behavior
 			originalOsExit := osExit
@@ -174,22 +194,16 @@ Test agent.
 			defer func() { osExit = originalOsExit }()
 
 			// Run the function (may call os.Exit)
-			_ = runGitLint()
+			_, _ = runGitLint(inv)
 
 			// Just verify it doesn't panic - actual behavior depends on git state
 			// which we can't fully control in unit tests
 
-			// Restore global flags
-			rootPath = oldRootPath
-			quiet = oldQuiet
-			verbose = oldVerbose
-			stagedMode = oldStagedMode
-			diffMode = oldDiffMode
 		})
 	}
 }
 
-func TestInitConfig(t *testing.T) {
+func TestLoadCLIConfigFiles(t *testing.T) {
 	// Create temporary directory for config files
 	tmpDir := t.TempDir()
 	originalWd, err := os.Getwd()
@@ -219,8 +233,6 @@ func TestInitConfig(t *testing.T) {
 			configFile: ".cclintrc.yaml",
 			content:    "quiet: true\nverbose: false\n",
 		},
-		// Note: "invalid json config" test case removed because initConfig()
-		// calls os.Exit(1) directly which terminates the test process.
 	}
 
 	for _, tt := range tests {
@@ -236,10 +248,10 @@ func TestInitConfig(t *testing.T) {
 				require.NoError(t, os.WriteFile(configPath, []byte(tt.content), 0644))
 			}
 
-			// Run initConfig - only testing success cases
-			initConfig()
-
-			// If we reach here, the config was loaded successfully (no os.Exit called)
+			inv := testInvocation()
+			inv.rootPath = tmpDir
+			_, err := loadCLIConfig(inv)
+			require.NoError(t, err)
 		})
 	}
 }
@@ -327,16 +339,17 @@ func TestExecute_ErrorPath(t *testing.T) {
 	os.Args = []string{"cclint", "--invalid-flag-that-does-not-exist"}
 	defer func() { os.Args = oldArgs }()
 
-	// Execute should fail and call exitFunc(1)
+	// Execute should fail and exit with the usage code
 	Execute()
 
-	// Should have called exit with code 1
+	// Should have called exit with code 2
 	assert.True(t, exitCalled, "Execute should have called exit on error")
-	assert.Equal(t, 1, exitCode, "Exit code should be 1")
+	assert.Equal(t, ExitUsage, exitCode, "Exit code should be 2 for usage errors")
 }
 
 func TestRootCmdFlags(t *testing.T) {
 	// Verify that root command has expected flags
+	rootCmd := newRootCmd(testInvocation())
 	flags := rootCmd.PersistentFlags()
 
 	testCases := []struct {
@@ -346,6 +359,8 @@ func TestRootCmdFlags(t *testing.T) {
 		{"root flag", "root"},
 		{"quiet flag", "quiet"},
 		{"verbose flag", "verbose"},
+		{"verbosity flag", "verbosity"},
+		{"show flag", "show"},
 		{"scores flag", "scores"},
 		{"improvements flag", "improvements"},
 		{"format flag", "format"},
@@ -369,10 +384,30 @@ func TestRootCmdFlags(t *testing.T) {
 	assert.NotNil(t, localFlags.Lookup("type"))
 	assert.NotNil(t, localFlags.Lookup("diff"))
 	assert.NotNil(t, localFlags.Lookup("staged"))
+	assert.NotNil(t, localFlags.Lookup("only"))
+	assert.NotNil(t, localFlags.Lookup("skip"))
+	assert.NotNil(t, localFlags.Lookup("fix"))
+	assert.NotNil(t, localFlags.Lookup("fix-dry-run"))
+}
+
+func TestNewRootCmdIsolatesInvocations(t *testing.T) {
+	first, second := &invocation{}, &invocation{}
+	rootCmd := newRootCmd(first)
+	newRootCmd(second)
+
+	rootCmd.SetArgs([]string{"schema", "report", "--group-by", "rule"})
+	_, _, err := captureStdout(t, func() (cmdResult, error) { return resultOK, rootCmd.Execute() })
+	require.NoError(t, err)
+
+	assert.Equal(t, "rule", first.groupBy)
+	assert.True(t, first.flagChanged("group-by"))
+	assert.Equal(t, "file", second.groupBy, "flags bind to their own invocation")
+	assert.False(t, second.flagChanged("group-by"))
 }
 
 func TestRootCmdSubcommands(t *testing.T) {
 	// Verify subcommands are registered
+	rootCmd := newRootCmd(testInvocation())
 	commands := rootCmd.Commands()
 	commandNames := make(map[string]bool)
 	for _, cmd := range commands {
@@ -397,7 +432,8 @@ func TestExecute(t *testing.T) {
 
 func TestRootCmdRun(t *testing.T) {
 	// Test that root command Run function exists and is configured
-	assert.NotNil(t, rootCmd.Run)
+	rootCmd := newRootCmd(testInvocation())
+	assert.NotNil(t, rootCmd.RunE)
 
 	// Verify root command has correct configuration
 	assert.Equal(t, "cclint [files|dirs...]", rootCmd.Use)
@@ -422,29 +458,17 @@ func TestRunGitLint_NoFiles(t *testing.T) {
 	cmd.Dir = tmpDir
 	_ = cmd.Run()
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldStagedMode := stagedMode
-	oldDiffMode := diffMode
-
-	rootPath = tmpDir
-	quiet = true // Suppress output
-	verbose = false
-	stagedMode = true // Staged mode with nothing staged
-	diffMode = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		stagedMode = oldStagedMode
-		diffMode = oldDiffMode
-	}()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true // Suppress output
+	inv.verbose = false
+	inv.stagedMode = true // Staged mode with nothing staged
+	inv.diffMode = false
 
 	// Run with empty staging area - should return nil (no files to lint)
-	err := runGitLint()
+	_, err := runGitLint(inv)
 	assert.NoError(t, err)
 }
 
@@ -456,58 +480,34 @@ func TestRunGitLint_WithVerbose(t *testing.T) {
 	cmd.Dir = tmpDir
 	require.NoError(t, cmd.Run())
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldStagedMode := stagedMode
-	oldDiffMode := diffMode
-
-	rootPath = tmpDir
-	quiet = false
-	verbose = true // Enable verbose
-	stagedMode = true
-	diffMode = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		stagedMode = oldStagedMode
-		diffMode = oldDiffMode
-	}()
-
-	err := runGitLint()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = false
+	inv.verbose = true // Enable verbose
+	inv.stagedMode = true
+	inv.diffMode = false
+
+	_, err := runGitLint(inv)
 	assert.NoError(t, err)
 }
 
 func TestRunGitLint_NotGitRepoWithQuiet(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldStagedMode := stagedMode
-	oldDiffMode := diffMode
-
-	rootPath = tmpDir
-	quiet = false // Show warning
-	verbose = false
-	stagedMode = false
-	diffMode = true
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		stagedMode = oldStagedMode
-		diffMode = oldDiffMode
-	}()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = false // Show warning
+	inv.verbose = false
+	inv.stagedMode = false
+	inv.diffMode = true
 
 	// Not in git repo should fallback to full lint (which may fail)
 	// Just verify no panic
-	_ = runGitLint()
+	_, _ = runGitLint(inv)
 }
 
 func TestRunGitLint_WithEmptyRootPath(t *testing.T) {
@@ -527,25 +527,15 @@ func TestRunGitLint_WithEmptyRootPath(t *testing.T) {
 	require.NoError(t, os.Chdir(tmpDir))
 	defer func() { _ = os.Chdir(origWd) }()
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldStagedMode := stagedMode
-	oldDiffMode := diffMode
-
-	rootPath = "" // Empty to trigger os.Getwd() path
-	quiet = true
-	stagedMode = true
-	diffMode = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		stagedMode = oldStagedMode
-		diffMode = oldDiffMode
-	}()
-
-	err = runGitLint()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = "" // Empty to trigger os.Getwd() path
+	inv.quiet = true
+	inv.stagedMode = true
+	inv.diffMode = false
+
+	_, err = runGitLint(inv)
 	assert.NoError(t, err)
 }
 
@@ -570,26 +560,16 @@ Test content.
 `
 	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
 
-	// Set global flags with valid type override
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldTypeFlag := typeFlag
-
-	rootPath = tmpDir
-	quiet = true
-	verbose = false
-	typeFlag = "agent" // Valid type
+	// Set flags with valid type override
+	inv := testInvocation()
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		typeFlag = oldTypeFlag
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.verbose = false
+	inv.typeFlag = "agent" // Valid type
 
 	// Should work with valid type override
-	err := runSingleFileLint([]string{testFile})
+	_, err := runSingleFileLint(inv, []string{testFile})
 	assert.NoError(t, err)
 }
 
@@ -615,22 +595,14 @@ Test agent.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
+	// Set flags
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = false
-	verbose = true // Enable verbose
+	inv.rootPath = tmpDir
+	inv.quiet = false
+	inv.verbose = true // Enable verbose
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
-
-	err := runSingleFileLint([]string{agentPath})
+	_, err := runSingleFileLint(inv, []string{agentPath})
 	assert.NoError(t, err)
 }
 
@@ -656,29 +628,17 @@ Test agent.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldUseBaseline := useBaseline
-	oldCreateBaseline := createBaseline
-	oldBaselinePath := baselinePath
-
-	rootPath = tmpDir
-	quiet = true
-	useBaseline = false
-	createBaseline = false
-	baselinePath = filepath.Join(tmpDir, ".cclintbaseline.json")
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		useBaseline = oldUseBaseline
-		createBaseline = oldCreateBaseline
-		baselinePath = oldBaselinePath
-	}()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.useBaseline = false
+	inv.createBaseline = false
+	inv.baselinePath = filepath.Join(tmpDir, ".cclintbaseline.json")
 
 	// runLint calls os.Exit on errors, but should succeed here
-	err := runLint()
+	_, err := runLint(inv)
 	// May return nil or error depending on component files
 	_ = err
 }
@@ -705,38 +665,28 @@ Test agent.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldUseBaseline := useBaseline
-	oldCreateBaseline := createBaseline
-	oldBaselinePath := baselinePath
-
-	rootPath = tmpDir
-	quiet = true
-	useBaseline = false
-	createBaseline = true // Test baseline creation
-	baselinePath = filepath.Join(tmpDir, ".cclintbaseline.json")
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		useBaseline = oldUseBaseline
-		createBaseline = oldCreateBaseline
-		baselinePath = oldBaselinePath
-	}()
-
-	err := runLint()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.useBaseline = false
+	inv.createBaseline = true // Test baseline creation
+	inv.baselinePath = filepath.Join(tmpDir, ".cclintbaseline.json")
+
+	_, err := runLint(inv)
 	_ = err
 }
 
 func TestRootCmdLongDescription(t *testing.T) {
+	rootCmd := newRootCmd(testInvocation())
 	assert.Contains(t, rootCmd.Long, "File and directory mode")
 	assert.Contains(t, rootCmd.Long, "Git integration mode")
 	assert.Contains(t, rootCmd.Long, "Baseline mode")
 }
 
 func TestRootCmdVersion(t *testing.T) {
+	rootCmd := newRootCmd(testInvocation())
 	assert.Equal(t, Version, rootCmd.Version)
 }
 
@@ -780,25 +730,15 @@ Test content.
 	cmd.Dir = tmpDir
 	_ = cmd.Run()
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldStagedMode := stagedMode
-	oldDiffMode := diffMode
-
-	rootPath = tmpDir
-	quiet = true
-	stagedMode = false
-	diffMode = true // Use diff mode
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		stagedMode = oldStagedMode
-		diffMode = oldDiffMode
-	}()
-
-	err := runGitLint()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.stagedMode = false
+	inv.diffMode = true // Use diff mode
+
+	_, err := runGitLint(inv)
 	// May succeed or fail depending on files
 	_ = err
 }
@@ -843,25 +783,15 @@ Test content.
 	cmd.Dir = tmpDir
 	_ = cmd.Run()
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldStagedMode := stagedMode
-	oldDiffMode := diffMode
-
-	rootPath = tmpDir
-	quiet = true
-	stagedMode = true // Use staged mode
-	diffMode = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		stagedMode = oldStagedMode
-		diffMode = oldDiffMode
-	}()
-
-	err := runGitLint()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.stagedMode = true // Use staged mode
+	inv.diffMode = false
+
+	_, err := runGitLint(inv)
 	// May succeed or fail depending on files
 	_ = err
 }
@@ -888,22 +818,14 @@ Test agent.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
+	// Set flags
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = true // Quiet mode
-	verbose = false
+	inv.rootPath = tmpDir
+	inv.quiet = true // Quiet mode
+	inv.verbose = false
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
-
-	err := runSingleFileLint([]string{agentPath})
+	_, err := runSingleFileLint(inv, []string{agentPath})
 	assert.NoError(t, err)
 }
 
@@ -932,22 +854,14 @@ Test agent.
 	require.NoError(t, os.WriteFile(agent1, []byte(content), 0644))
 	require.NoError(t, os.WriteFile(agent2, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-
-	rootPath = tmpDir
-	quiet = true
-	verbose = false
+	// Set flags
+	inv := testInvocation()
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.verbose = false
 
-	err := runSingleFileLint([]string{agent1, agent2})
+	_, err := runSingleFileLint(inv, []string{agent1, agent2})
 	assert.NoError(t, err)
 }
 
@@ -993,29 +907,17 @@ Test agent content.
 	cmd.Dir = tmpDir
 	require.NoError(t, cmd.Run())
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldStagedMode := stagedMode
-	oldDiffMode := diffMode
-
-	rootPath = tmpDir
-	quiet = true
-	verbose = false
-	stagedMode = true
-	diffMode = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		stagedMode = oldStagedMode
-		diffMode = oldDiffMode
-	}()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.verbose = false
+	inv.stagedMode = true
+	inv.diffMode = false
 
 	// Run git lint - should find and lint staged files
-	err := runGitLint()
+	_, err := runGitLint(inv)
 	// May succeed or exit with os.Exit
 	_ = err
 }
@@ -1065,25 +967,15 @@ New agent.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldStagedMode := stagedMode
-	oldDiffMode := diffMode
-
-	rootPath = tmpDir
-	quiet = true
-	stagedMode = false
-	diffMode = true // Diff mode captures all changes
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		stagedMode = oldStagedMode
-		diffMode = oldDiffMode
-	}()
-
-	err := runGitLint()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.stagedMode = false
+	inv.diffMode = true // Diff mode captures all changes
+
+	_, err := runGitLint(inv)
 	// May succeed or fail - just verify no panic
 	_ = err
 }
@@ -1118,22 +1010,14 @@ Test.
 	cmd.Dir = tmpDir
 	_ = cmd.Run()
 
-	// Set global flags - non-quiet to test reminder output
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldStagedMode := stagedMode
+	// Set flags - non-quiet to test reminder output
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = false // Show validation reminder
-	stagedMode = true
+	inv.rootPath = tmpDir
+	inv.quiet = false // Show validation reminder
+	inv.stagedMode = true
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		stagedMode = oldStagedMode
-	}()
-
-	err := runGitLint()
+	_, err := runGitLint(inv)
 	_ = err
 }
 
@@ -1159,22 +1043,14 @@ Test agent.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags for non-quiet mode
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-
-	rootPath = tmpDir
-	quiet = false // Non-quiet to show validation reminder
-	verbose = false
+	// Set flags for non-quiet mode
+	inv := testInvocation()
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = false // Non-quiet to show validation reminder
+	inv.verbose = false
 
-	err := runSingleFileLint([]string{agentPath})
+	_, err := runSingleFileLint(inv, []string{agentPath})
 	assert.NoError(t, err)
 }
 
@@ -1251,6 +1127,7 @@ func TestClassifyArgs(t *testing.T) {
 
 func TestRootCmdVersionFlag(t *testing.T) {
 	// Test that version flag (-V) is properly configured
+	rootCmd := newRootCmd(testInvocation())
 	flag := rootCmd.Flags().Lookup("version")
 	assert.NotNil(t, flag)
 	assert.Equal(t, "V", flag.Shorthand)
@@ -1276,26 +1153,16 @@ Test.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldUseBaseline := useBaseline
-	oldCreateBaseline := createBaseline
+	// Set flags
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = true
-	useBaseline = false
-	createBaseline = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		useBaseline = oldUseBaseline
-		createBaseline = oldCreateBaseline
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.useBaseline = false
+	inv.createBaseline = false
 
 	// Run lint
-	err := runLint()
+	_, err := runLint(inv)
 	// May succeed or exit
 	_ = err
 }
@@ -1341,29 +1208,17 @@ Test agent content.
 	cmd.Dir = tmpDir
 	require.NoError(t, cmd.Run())
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldVerbose := verbose
-	oldStagedMode := stagedMode
-	oldDiffMode := diffMode
-
-	rootPath = tmpDir
-	quiet = true
-	verbose = false
-	stagedMode = true
-	diffMode = false
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		verbose = oldVerbose
-		stagedMode = oldStagedMode
-		diffMode = oldDiffMode
-	}()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.verbose = false
+	inv.stagedMode = true
+	inv.diffMode = false
 
 	// Run - this tests the full path with files
-	err := runGitLint()
+	_, err := runGitLint(inv)
 	// May succeed or call os.Exit
 	_ = err
 }
@@ -1413,25 +1268,15 @@ New agent.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldStagedMode := stagedMode
-	oldDiffMode := diffMode
-
-	rootPath = tmpDir
-	quiet = true
-	stagedMode = false
-	diffMode = true
-
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		stagedMode = oldStagedMode
-		diffMode = oldDiffMode
-	}()
-
-	err := runGitLint()
+	// Set flags
+	inv := testInvocation()
+
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.stagedMode = false
+	inv.diffMode = true
+
+	_, err := runGitLint(inv)
 	_ = err
 }
 
@@ -1458,22 +1303,14 @@ This is a good agent.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldTypeFlag := typeFlag
+	// Set flags
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = true
-	typeFlag = "" // Auto-detect type
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.typeFlag = "" // Auto-detect type
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		typeFlag = oldTypeFlag
-	}()
-
-	err := runSingleFileLint([]string{agentPath})
+	_, err := runSingleFileLint(inv, []string{agentPath})
 	assert.NoError(t, err)
 }
 
@@ -1499,22 +1336,14 @@ Test content.
 `
 	require.NoError(t, os.WriteFile(agentPath, []byte(content), 0644))
 
-	// Set global flags
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldOutputFormat := outputFormat
-
-	rootPath = tmpDir
-	quiet = true
-	outputFormat = "json"
+	// Set flags
+	inv := testInvocation()
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		outputFormat = oldOutputFormat
-	}()
+	inv.rootPath = tmpDir
+	inv.quiet = true
+	inv.outputFormat = "json"
 
-	err := runSingleFileLint([]string{agentPath})
+	_, err := runSingleFileLint(inv, []string{agentPath})
 	assert.NoError(t, err)
 }
 
@@ -1549,25 +1378,17 @@ Test.
 	_ = cmd.Run()
 
 	// Set flags - non-quiet
-	oldRootPath := rootPath
-	oldQuiet := quiet
-	oldStagedMode := stagedMode
+	inv := testInvocation()
 
-	rootPath = tmpDir
-	quiet = false
-	stagedMode = true
+	inv.rootPath = tmpDir
+	inv.quiet = false
+	inv.stagedMode = true
 
-	defer func() {
-		rootPath = oldRootPath
-		quiet = oldQuiet
-		stagedMode = oldStagedMode
-	}()
-
-	err := runGitLint()
+	_, err := runGitLint(inv)
 	_ = err
 }
 
-func TestInitConfig_WithYmlFile(t *testing.T) {
+func TestLoadCLIConfigFiles_Yml(t *testing.T) {
 	tmpDir := t.TempDir()
 	originalWd, err := os.Getwd()
 	require.NoError(t, err)
@@ -1580,8 +1401,137 @@ func TestInitConfig_WithYmlFile(t *testing.T) {
 	configPath := filepath.Join(tmpDir, ".cclintrc.yml")
 	require.NoError(t, os.WriteFile(configPath, []byte("quiet: true\n"), 0644))
 
-	// Should not panic
-	assert.NotPanics(t, func() {
-		initConfig()
-	})
+	inv := testInvocation()
+	inv.rootPath = tmpDir
+	_, err = loadCLIConfig(inv)
+	assert.NoError(t, err)
+}
+
+func TestRunLintJSONL(t *testing.T) {
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		".claude/agents/bad.md":   "---\nname: Bad_Name\n---\nbody\n",
+		".claude/commands/ok.md":  "---\ndescription: Checks the build\n---\nRun the build.\n",
+		".claude/commands/two.md": "---\ndescription: Checks the tests\n---\nRun the tests.\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0o600))
+	}
+
+	inv := testInvocation()
+	inv.rootPath, inv.outputFormat, inv.show = root, "jsonl", []string{"errors"}
+	inv.changed = map[string]bool{"show": true}
+	out, result, err := captureStdout(t, func() (cmdResult, error) { return runLint(inv) })
+	require.NoError(t, err)
+	assert.Equal(t, ExitFindings, result.ExitCode)
+
+	var types []string
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	for _, line := range lines {
+		var event struct {
+			Type          string `json:"type"`
+			ComponentType string `json:"componentType"`
+			Severity      string `json:"severity"`
+			Done, Total   int
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &event), "line %q", line)
+		types = append(types, event.Type)
+		if event.Type == "finding" {
+			assert.Equal(t, "error", event.Severity, "--show errors")
+		}
+		if event.Type == "progress" && event.ComponentType == "command" {
+			assert.Equal(t, 2, event.Total)
+		}
+	}
+	assert.Equal(t, "finding", types[0], "the agent's findings are written as soon as its file is linted")
+	assert.Contains(t, types, "progress")
+	assert.Equal(t, "summary", types[len(types)-1])
+	assert.Contains(t, lines[len(lines)-1], `"files":3,"errors":2`)
+}
+
+func TestRunLintJSONReport(t *testing.T) {
+	if err := exec.Command("git", "--version").Run(); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v: %s", args, out)
+	}
+	git("init", "-q")
+	git("config", "user.email", "test@test.com")
+	git("config", "user.name", "Test User")
+	for path, content := range map[string]string{
+		".claude/commands/deploy.md":      "---\ndescription: Deploys the app\n---\nTask(deployer)\n",
+		".claude/agents/deployer.md":      "---\nname: Deployer_Agent\ndescription: Deploys\n---\nSkill: release\n",
+		".claude/skills/release/SKILL.md": "---\nname: release\ndescription: Release steps\n---\nbody\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0o600))
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+
+	inv := testInvocation()
+	inv.rootPath, inv.outputFormat = root, "json"
+	inv.outputFile = filepath.Join(t.TempDir(), "report.json")
+	inv.includeChains, inv.blame = true, true
+	_, result, err := captureStdout(t, func() (cmdResult, error) { return runLint(inv) })
+	require.NoError(t, err)
+	assert.Equal(t, ExitFindings, result.ExitCode)
+
+	data, err := os.ReadFile(inv.outputFile)
+	require.NoError(t, err)
+	var report struct {
+		SchemaVersion int `json:"schemaVersion"`
+		Run           struct {
+			Tool        string `json:"tool"`
+			RulesetHash string `json:"rulesetHash"`
+		} `json:"run"`
+		Summary struct {
+			Files  int `json:"files"`
+			Errors int `json:"errors"`
+		} `json:"summary"`
+		Results []struct {
+			File   string `json:"file"`
+			Type   string `json:"type"`
+			Issues []struct {
+				Blame *struct {
+					Author string `json:"author"`
+				} `json:"blame"`
+			} `json:"issues"`
+		} `json:"results"`
+		Chains []struct {
+			Type string `json:"type"`
+			Name string `json:"name"`
+		} `json:"chains"`
+	}
+	require.NoError(t, json.Unmarshal(data, &report), "a full run writes one JSON report:\n%s", data)
+	assert.Equal(t, 2, report.SchemaVersion)
+	assert.Equal(t, "cclint", report.Run.Tool)
+	assert.NotEmpty(t, report.Run.RulesetHash)
+	assert.Equal(t, 3, report.Summary.Files, "every component type is in the report")
+
+	types := map[string]bool{}
+	blamed := false
+	for _, r := range report.Results {
+		types[r.Type] = true
+		for _, is := range r.Issues {
+			if is.Blame != nil {
+				blamed = true
+				assert.Equal(t, "Test User", is.Blame.Author)
+			}
+		}
+	}
+	assert.Equal(t, map[string]bool{"agent": true, "command": true, "skill": true}, types)
+	assert.True(t, blamed, "--blame adds the commit of each finding's line")
+
+	var chains []string
+	for _, c := range report.Chains {
+		chains = append(chains, c.Type+":"+c.Name)
+	}
+	assert.Contains(t, chains, "command:deploy")
 }
@@ -1,55 +1,174 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
+	"github.com/dotcommander/cclint/internal/buildinfo"
+	"github.com/dotcommander/cclint/internal/codeowners"
 	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/discovery"
 	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/output"
 	"github.com/dotcommander/cclint/internal/outputters"
 )
 
-func loadCLIConfig() (*config.Config, error) {
-	cfg, err := config.LoadConfig(rootPath)
+func loadCLIConfig(inv *invocation) (*config.Config, error) {
+	opts := cliOptions(inv)
+	if err := opts.Validate(); err != nil {
+		return nil, flagError(err)
+	}
+	cfg, err := config.LoadConfig(inv.rootPath)
 	if err != nil {
-		return nil, fmt.Errorf("error loading configuration: %w", err)
+		return nil, usageErrorf("error loading configuration: %w", err)
 	}
+	opts.Apply(cfg)
+	inv.quiet, inv.verbose = cfg.Quiet(), cfg.Verbose()
 
-	applyCLIOverrides(cfg)
+	color, _ := output.ParseColorMode(cfg.Color) // validated with the options
+	output.ApplyColorMode(color)
+	if cfg.Format == "json@1" && !cfg.Quiet() {
+		fmt.Fprintln(os.Stderr, "warning: --format json@1 is deprecated and will be removed in the next release; use --format json (schema version 2)")
+	}
+	if err := validateOwnerFilter(inv, cfg); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
-func applyCLIOverrides(cfg *config.Config) {
-	cfg.Version = Version
+// cliOptions maps the command-line flags onto config.Options. Format and
+// fail-on always carry their flag value (or its default); the console
+// options only when their flag is given, so config-file and CCLINT_*
+// values survive otherwise.
+func cliOptions(inv *invocation) config.Options {
+	build := buildinfo.Read(Version, Commit, BuildDate)
+	opts := config.Options{
+		Root:              inv.rootPath,
+		Version:           build.Version,
+		Commit:            build.Commit,
+		BuildDate:         build.BuildDate,
+		DocsURLTemplate:   DocsURLTemplate,
+		Format:            inv.outputFormat,
+		Output:            inv.outputFile,
+		FailOn:            inv.failOn,
+		Verbosity:         flagVerbosity(inv),
+		ShowScores:        inv.showScores,
+		ShowImprovements:  inv.showImprovements,
+		NoCycleCheck:      inv.noCycleCheck,
+		Untrusted:         inv.untrusted,
+		EnableCategories:  inv.enableCategories,
+		DisableCategories: inv.disableCategories,
+	}
+	if inv.flagChanged("group-by") {
+		opts.GroupBy = inv.groupBy
+	}
+	if inv.flagChanged("max-issues-per-file") {
+		opts.MaxIssuesPerFile = &inv.maxIssuesPerFile
+	}
+	if inv.flagChanged("color") {
+		opts.Color = inv.colorMode
+	}
+	if inv.flagChanged("snippets") {
+		opts.Snippets = &inv.snippets
+	}
+	if inv.flagChanged("show") {
+		opts.Show = inv.show
+	}
+	return opts
+}
+
+// optionFlags names the flag behind each config.Options field that a flag
+// sets, for usage errors.
+var optionFlags = map[string]string{
+	"format":            "--format",
+	"failOn":            "--fail-on",
+	"verbosity":         "--verbosity",
+	"show":              "--show",
+	"groupBy":           "--group-by",
+	"color":             "--color",
+	"maxIssuesPerFile":  "--max-issues-per-file",
+	"enableCategories":  "--enable-category",
+	"disableCategories": "--disable-category",
+}
 
-	if rootPath != "" {
-		cfg.Root = rootPath
+// flagError turns an invalid option into a usage error naming its flag.
+func flagError(err error) error {
+	var optErr *config.OptionError
+	if errors.As(err, &optErr) {
+		if flag, ok := optionFlags[optErr.Option]; ok {
+			return usageErrorf("invalid %s: %w", flag, optErr.Err)
+		}
 	}
+	return usageErrorf("%w", err)
+}
+
+// applyVerbosity resolves the output level: --verbosity (or
+// CCLINT_VERBOSITY) wins, then the -q and -v shorthands, then the config
+// file. The invocation's quiet and verbose flags are updated to match, since
+// the fmt and migrate helpers read them directly.
+func applyVerbosity(inv *invocation, cfg *config.Config) error {
+	if level := flagVerbosity(inv); level != "" {
+		cfg.Verbosity = level
+	}
+	if _, err := config.ParseVerbosity(string(cfg.Verbosity)); err != nil {
+		return usageErrorf("invalid --verbosity: %w", err)
+	}
+	inv.quiet, inv.verbose = cfg.Quiet(), cfg.Verbose()
+	return nil
+}
+
+// flagVerbosity returns the level requested on the command line, or "" when
+// none was.
+func flagVerbosity(inv *invocation) config.Verbosity {
+	switch {
+	case inv.flagChanged("verbosity"):
+		return config.Verbosity(inv.verbosity)
+	case inv.quiet:
+		return config.VerbosityQuiet
+	case inv.verbose:
+		return config.VerbosityVerbose
+	}
+	return ""
+}
 
-	cfg.Quiet = quiet
-	cfg.Verbose = verbose
-	cfg.ShowScores = showScores
-	cfg.ShowImprovements = showImprovements
-	cfg.Format = outputFormat
-	cfg.Output = outputFile
-	cfg.FailOn = failOn
-	cfg.NoCycleCheck = noCycleCheck
+func runOrchestratedLint(inv *invocation, cfg *config.Config, linters []lint.LinterEntry) (*lint.Result, error) {
+	return runOrchestratedLintTo(inv, cfg, linters, nil, nil)
 }
 
-func runOrchestratedLint(cfg *config.Config, linters []lint.LinterEntry) (*lint.Result, error) {
+// runOrchestratedLintTo is runOrchestratedLint with progress passed to
+// progress after each file is validated, and each file's findings passed
+// to onResult as soon as they are final; see lint.OrchestratorConfig.
+func runOrchestratedLintTo(inv *invocation, cfg *config.Config, linters []lint.LinterEntry, progress func(lint.Progress), onResult func(*lint.LintSummary)) (*lint.Result, error) {
+	owners, err := loadCodeOwners(inv, cfg)
+	if err != nil {
+		return nil, err
+	}
+	bar, stop := startProgress(inv, cfg)
+	defer stop()
+	if progress == nil {
+		progress = bar
+	}
 	orchestrator := lint.NewOrchestrator(cfg, lint.OrchestratorConfig{
-		RootPath:       rootPath,
-		UseBaseline:    useBaseline,
-		CreateBaseline: createBaseline,
-		BaselinePath:   baselinePath,
+		RootPath:       inv.rootPath,
+		UseBaseline:    inv.useBaseline,
+		CreateBaseline: inv.createBaseline,
+		BaselinePath:   inv.baselinePath,
+		CodeOwners:     owners,
+		Owners:         inv.ownerFilter,
+		Blame:          inv.blame,
+		IncludeChains:  inv.includeChains,
+		Progress:       progress,
+		OnResult:       onResult,
 	})
 	if linters != nil {
 		orchestrator.WithLinters(linters)
 	}
+	orchestrator.WithScope(inv.scopeOnly, inv.scopeSkip)
 
-	stop := startSpinner(cfg)
 	result, err := orchestrator.Run()
-	stop()
 	if err != nil {
 		return nil, err
 	}
@@ -57,36 +176,131 @@ func runOrchestratedLint(cfg *config.Config, linters []lint.LinterEntry) (*lint.
 	return result, nil
 }
 
+// validateOwnerFilter rejects --owner without a CODEOWNERS file, or with
+// --baseline-create, which would drop other owners' issues from the
+// baseline.
+func validateOwnerFilter(inv *invocation, cfg *config.Config) error {
+	if len(inv.ownerFilter) == 0 {
+		return nil
+	}
+	if inv.createBaseline {
+		return usageErrorf("--owner cannot be combined with --baseline-create; the baseline must cover every file")
+	}
+	if codeowners.Find(cfg.Root) == "" {
+		return usageErrorf("--owner needs a CODEOWNERS file in %s", strings.Join(codeowners.Locations, ", "))
+	}
+	return nil
+}
+
+// loadCodeOwners reads the project's CODEOWNERS file, if any. A file that
+// does not parse is a warning unless --owner needs it.
+func loadCodeOwners(inv *invocation, cfg *config.Config) (*codeowners.Ruleset, error) {
+	rs, err := codeowners.Load(cfg.Root)
+	if err != nil {
+		if len(inv.ownerFilter) > 0 {
+			return nil, usageErrorf("--owner: %w", err)
+		}
+		if !cfg.Quiet() {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring CODEOWNERS: %v\n", err)
+		}
+		return nil, nil
+	}
+	return rs, nil
+}
+
+// applyCodeOwners resolves the owners of a summary's files and applies
+// --owner, for runs that lint outside the orchestrator.
+func applyCodeOwners(inv *invocation, cfg *config.Config, summary *lint.LintSummary) error {
+	rs, err := loadCodeOwners(inv, cfg)
+	if err != nil {
+		return err
+	}
+	lint.ApplyCodeOwners(summary, rs, inv.ownerFilter)
+	return nil
+}
+
 func formatSummaryOutput(cfg *config.Config, summary *lint.LintSummary) error {
 	return outputters.NewOutputter(cfg).Format(summary, cfg.Format)
 }
 
+// formatFullRunOutput prints the report of a full run in cfg.Format, to
+// cfg.Output when it is set. --format jsonl reaches it only after --fix,
+// when the report is known all at once.
 func formatFullRunOutput(cfg *config.Config, result *lint.Result) error {
-	return outputters.NewOutputter(cfg).FormatAll(result.Summaries, result.StartTime)
+	return outputters.NewOutputter(cfg).FormatAll(result.Summaries, result.StartTime, cfg.Format)
 }
 
-func printBaselineSummary(total, errors, suggestions int, quiet bool) {
-	if total == 0 || quiet {
+func printSuppressedSummary(issues []lint.SuppressedIssue, quiet bool) {
+	if len(issues) == 0 || quiet {
 		return
 	}
-	fmt.Fprintf(os.Stderr, "\n%d baseline issues ignored (%d errors, %d suggestions)\n",
-		total, errors, suggestions)
+	fmt.Fprint(os.Stderr, "\n"+output.SuppressedSummary(issues))
 }
 
 func printValidationReminder(cfg *config.Config) {
-	if cfg.Quiet || !cfg.Verbose {
+	if cfg.Quiet() || !cfg.Verbose() {
 		return
 	}
 
 	fmt.Fprintln(os.Stderr, "\n  Validate suggestions against docs.anthropic.com or docs.claude.com")
 }
 
-func applyFailurePolicy(cfg *config.Config, errors, warnings, suggestions int) {
-	if createBaseline {
-		return
+// failurePolicyResult returns ExitGates when the run failed a quality gate,
+// else ExitFindings when any summary has findings at or above its --fail-on
+// threshold. Creating a baseline always succeeds.
+func failurePolicyResult(inv *invocation, cfg *config.Config, summaries ...*lint.LintSummary) cmdResult {
+	if inv.createBaseline {
+		return resultOK
+	}
+	for _, s := range summaries {
+		if s != nil && s.Gates != nil && !s.Gates.Passed {
+			return cmdResult{ExitCode: ExitGates}
+		}
+	}
+
+	return cmdResult{ExitCode: findingsExitCode(cfg, summaries...)}
+}
+
+// resolveTypeScope parses --only and --skip. They scope the full run only, so
+// they are rejected alongside file paths, type arguments, or git modes
+// (targeted), and with --baseline-create, which would drop the skipped types
+// from the baseline.
+func resolveTypeScope(inv *invocation, targeted bool) error {
+	only, err := parseTypeList(inv.onlyTypes)
+	if err != nil {
+		return fmt.Errorf("--only: %w", err)
+	}
+	skip, err := parseTypeList(inv.skipTypes)
+	if err != nil {
+		return fmt.Errorf("--skip: %w", err)
+	}
+	if len(only) > 0 || len(skip) > 0 {
+		if targeted {
+			return fmt.Errorf("--only and --skip apply to full runs; they cannot be combined with file paths, type arguments, --diff, or --staged")
+		}
+		if inv.createBaseline {
+			return fmt.Errorf("--only and --skip cannot be combined with --baseline-create; the baseline must cover every component type")
+		}
 	}
+	inv.scopeOnly, inv.scopeSkip = only, skip
+	return nil
+}
 
-	if shouldFail(cfg, errors, warnings, suggestions) {
-		exitFunc(1)
+// parseTypeList converts component type names (singular or plural) to file
+// types, dropping duplicates.
+func parseTypeList(names []string) ([]discovery.FileType, error) {
+	var types []discovery.FileType
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		ft, err := discovery.ParseFileType(name)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(types, ft) {
+			types = append(types, ft)
+		}
 	}
+	return types, nil
 }
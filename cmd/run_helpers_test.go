@@ -1,30 +1,31 @@
 package cmd
 
 import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/discovery"
 )
 
-func TestApplyCLIOverridesSetsVersion(t *testing.T) {
+func TestCLIOptionsSetsVersion(t *testing.T) {
 	oldVersion := Version
-	oldRootPath := rootPath
-	oldOutputFormat := outputFormat
-	oldFailOn := failOn
+	inv := testInvocation()
 	t.Cleanup(func() {
 		Version = oldVersion
-		rootPath = oldRootPath
-		outputFormat = oldOutputFormat
-		failOn = oldFailOn
 	})
 
 	Version = "v1.2.3-test"
-	rootPath = "/override/root"
-	outputFormat = "json"
-	failOn = "warning"
+	inv.rootPath = "/override/root"
+	inv.outputFormat = "json"
+	inv.failOn = "warning"
 
 	cfg := &config.Config{}
-	applyCLIOverrides(cfg)
+	cliOptions(inv).Apply(cfg)
 
 	if cfg.Version != "v1.2.3-test" {
 		t.Fatalf("cfg.Version = %q, want v1.2.3-test", cfg.Version)
@@ -39,3 +40,228 @@ func TestApplyCLIOverridesSetsVersion(t *testing.T) {
 		t.Fatalf("cfg.FailOn = %q, want warning", cfg.FailOn)
 	}
 }
+
+func TestApplyVerbosity(t *testing.T) {
+	inv := testInvocation()
+
+	tests := []struct {
+		name        string
+		quiet       bool
+		verbose     bool
+		configured  config.Verbosity
+		want        config.Verbosity
+		wantQuiet   bool
+		wantVerbose bool
+		wantErr     bool
+	}{
+		{name: "config value kept", configured: config.VerbosityVerbose, want: config.VerbosityVerbose, wantVerbose: true},
+		{name: "empty means normal", want: ""},
+		{name: "-q overrides config", quiet: true, configured: config.VerbosityVerbose, want: config.VerbosityQuiet, wantQuiet: true},
+		{name: "-v overrides config", verbose: true, configured: config.VerbosityQuiet, want: config.VerbosityVerbose, wantVerbose: true},
+		{name: "-q wins over -v", quiet: true, verbose: true, want: config.VerbosityQuiet, wantQuiet: true},
+		{name: "invalid level", configured: "loud", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv.quiet, inv.verbose = tt.quiet, tt.verbose
+			cfg := &config.Config{Verbosity: tt.configured}
+			err := applyVerbosity(inv, cfg)
+			if tt.wantErr {
+				if err == nil || exitCodeForError(err) != ExitUsage {
+					t.Fatalf("applyVerbosity() error = %v, want usage error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyVerbosity() error = %v", err)
+			}
+			if cfg.Verbosity != tt.want {
+				t.Errorf("cfg.Verbosity = %q, want %q", cfg.Verbosity, tt.want)
+			}
+			if inv.quiet != tt.wantQuiet || inv.verbose != tt.wantVerbose {
+				t.Errorf("quiet, verbose = %v, %v, want %v, %v", inv.quiet, inv.verbose, tt.wantQuiet, tt.wantVerbose)
+			}
+		})
+	}
+}
+
+func TestResolveTypeScope(t *testing.T) {
+	inv := testInvocation()
+
+	tests := []struct {
+		name     string
+		only     []string
+		skip     []string
+		targeted bool
+		baseline bool
+		wantOnly []discovery.FileType
+		wantSkip []discovery.FileType
+		wantErr  bool
+	}{
+		{name: "unset"},
+		{name: "plural and singular names", only: []string{"agents", "skill", "agent"}, wantOnly: []discovery.FileType{discovery.FileTypeAgent, discovery.FileTypeSkill}},
+		{name: "skip", skip: []string{"settings"}, wantSkip: []discovery.FileType{discovery.FileTypeSettings}},
+		{name: "unknown type", only: []string{"widgets"}, wantErr: true},
+		{name: "with file paths or git modes", skip: []string{"settings"}, targeted: true, wantErr: true},
+		{name: "with baseline creation", only: []string{"agents"}, baseline: true, wantErr: true},
+		{name: "targeted run without scope", targeted: true, baseline: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv.onlyTypes, inv.skipTypes, inv.createBaseline = tt.only, tt.skip, tt.baseline
+			inv.scopeOnly, inv.scopeSkip = nil, nil
+			err := resolveTypeScope(inv, tt.targeted)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("resolveTypeScope() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveTypeScope() error = %v", err)
+			}
+			if !slices.Equal(inv.scopeOnly, tt.wantOnly) || !slices.Equal(inv.scopeSkip, tt.wantSkip) {
+				t.Errorf("scope = %v / %v, want %v / %v", inv.scopeOnly, inv.scopeSkip, tt.wantOnly, tt.wantSkip)
+			}
+		})
+	}
+}
+
+func TestCategoryFlags(t *testing.T) {
+	inv := testInvocation()
+
+	tests := []struct {
+		name     string
+		enable   []string
+		disable  []string
+		config   map[string]bool
+		want     map[string]bool
+		wantFlag string
+	}{
+		{name: "no flags keeps config", config: map[string]bool{"style": false}, want: map[string]bool{"style": false}},
+		{
+			name:    "flags override config",
+			enable:  []string{"style"},
+			disable: []string{"performance"},
+			config:  map[string]bool{"style": false},
+			want:    map[string]bool{"style": true, "performance": false},
+		},
+		{name: "unknown category", disable: []string{"speed"}, wantFlag: "--disable-category"},
+		{name: "enabled and disabled", enable: []string{"style"}, disable: []string{"style"}, wantFlag: "--enable-category"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv.enableCategories, inv.disableCategories = tt.enable, tt.disable
+			opts := cliOptions(inv)
+			if tt.wantFlag != "" {
+				err := opts.Validate()
+				if err == nil {
+					t.Fatal("Validate() error = nil, want an error")
+				}
+				err = flagError(err)
+				if exitCodeForError(err) != ExitUsage || !strings.Contains(err.Error(), tt.wantFlag) {
+					t.Fatalf("flagError() = %v, want a usage error naming %s", err, tt.wantFlag)
+				}
+				return
+			}
+			if err := opts.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			cfg := &config.Config{Rules: config.RulesConfig{Categories: tt.config}}
+			opts.Apply(cfg)
+			if !maps.Equal(cfg.Rules.Categories, tt.want) {
+				t.Errorf("categories = %v, want %v", cfg.Rules.Categories, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunLintOwnerFilter(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, contents string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".claude/agents/bad.md", "---\nname: bad\n---\nBody\n")
+	write(".claude/commands/ok.md", "---\ndescription: Runs the release checklist\n---\nRun the checklist.\n")
+
+	inv := testInvocation()
+	inv.rootPath, inv.quiet, inv.outputFormat = root, true, "console"
+
+	inv.ownerFilter = []string{"@org/agents"}
+	_, err := runLint(inv)
+	if exitCodeForError(err) != ExitUsage {
+		t.Fatalf("--owner without CODEOWNERS: err = %v, want a usage error", err)
+	}
+
+	write(".github/CODEOWNERS", "* @org/platform\n/.claude/agents/ @org/agents\n")
+	inv.createBaseline = true
+	_, err = runLint(inv)
+	if exitCodeForError(err) != ExitUsage {
+		t.Fatalf("--owner with --baseline-create: err = %v, want a usage error", err)
+	}
+	inv.createBaseline = false
+
+	res, err := runLint(inv)
+	if err != nil || res.ExitCode != ExitFindings {
+		t.Errorf("agents owner: exit %d, err %v; want findings", res.ExitCode, err)
+	}
+	inv.ownerFilter = []string{"@org/platform"}
+	res, err = runLint(inv)
+	if err != nil || res.ExitCode != ExitClean {
+		t.Errorf("platform owner: exit %d, err %v; want clean", res.ExitCode, err)
+	}
+}
+
+func TestRunLintGates(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, contents string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".claude/agents/bad.md", "---\nname: bad\n---\nBody\n")
+
+	inv := testInvocation()
+	inv.rootPath, inv.quiet, inv.outputFormat = root, true, "console"
+
+	// Gates pass: the --fail-on policy still applies.
+	write(".cclintrc.yaml", "gates:\n  maxErrors: 100\n")
+	res, err := runLint(inv)
+	if err != nil || res.ExitCode != ExitFindings {
+		t.Errorf("passing gates: exit %d, err %v; want findings", res.ExitCode, err)
+	}
+
+	// No baseline to compare against fails noNewIssues.
+	write(".cclintrc.yaml", "gates:\n  noNewIssues: true\n")
+	res, err = runLint(inv)
+	if err != nil || res.ExitCode != ExitGates {
+		t.Errorf("noNewIssues without baseline: exit %d, err %v; want gate failure", res.ExitCode, err)
+	}
+
+	// Creating a baseline skips the gates; afterwards nothing is new.
+	inv.createBaseline = true
+	if res, err = runLint(inv); err != nil || res.ExitCode != ExitClean {
+		t.Fatalf("baseline create: exit %d, err %v", res.ExitCode, err)
+	}
+	inv.createBaseline = false
+	write(".cclintrc.yaml", "gates:\n  noNewIssues: true\n")
+	if res, err = runLint(inv); err != nil || res.ExitCode != ExitFindings {
+		t.Errorf("noNewIssues with baseline: exit %d, err %v; want findings from --fail-on", res.ExitCode, err)
+	}
+
+	write(".cclintrc.yaml", "gates:\n  maxErrors: -1\n")
+	if _, err = runLint(inv); exitCodeForError(err) != ExitUsage {
+		t.Errorf("invalid gates: err = %v, want a usage error", err)
+	}
+}
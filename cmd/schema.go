@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/cache"
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/output"
+	"github.com/dotcommander/cclint/internal/upstream"
+	"github.com/spf13/cobra"
+)
+
+// upstreamOptions holds the flags of schema verify-upstream.
+type upstreamOptions struct {
+	snapshot string
+	sources  []string
+	timeout  time.Duration
+	cacheTTL time.Duration
+}
+
+// schemaDefinitions maps the components the upstream check covers to their
+// embedded CUE definitions.
+var schemaDefinitions = map[string]string{
+	"settings": "#Settings",
+	"agent":    "#Agent",
+	"command":  "#Command",
+	"skill":    "#Skill",
+}
+
+// newSchemaCmd builds the schema command and its subcommands.
+func newSchemaCmd(inv *invocation) *cobra.Command {
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Inspect the embedded validation schemas",
+	}
+
+	opts := &upstreamOptions{}
+	verifyUpstreamCmd := &cobra.Command{
+		Use:   "verify-upstream",
+		Short: "Report documented Claude Code fields the embedded schemas do not know",
+		Long: `Fetch the published Claude Code documentation for settings and agent,
+command, and skill frontmatter, extract the field tables, and compare them
+with the fields cclint validates. Fields the documentation lists but cclint
+does not know mean validation is stale.
+
+The documentation pages are markdown; only tables whose first column is
+headed "Key" or "Field" are read. For a reproducible check, pass a pinned
+JSON snapshot instead:
+
+  {"settings": ["apiKeyHelper", ...], "agent": ["name", ...]}
+
+Exits 1 when documented fields are missing. With --verbose, fields cclint
+knows but the documentation does not list are shown too.
+
+Fetched pages are cached for --cache-ttl in the cclint cache directory
+($CCLINT_CACHE_DIR, or cclint under the user cache directory). With
+--offline only cached pages are used; when a page was never cached the
+check is skipped with a notice.
+
+EXAMPLES:
+
+  cclint schema verify-upstream
+  cclint schema verify-upstream --snapshot upstream-fields.json
+  cclint schema verify-upstream --source settings=https://example.com/settings.md`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return runVerifyUpstream(inv, opts)
+		}),
+	}
+
+	schemaReportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Print the JSON Schema for --format json reports",
+		Long: `Print the JSON Schema (draft 2020-12) describing the report written by
+--format json, schema version 2. Use it to validate reports in CI or to
+generate types for tools that consume them.
+
+EXAMPLES:
+
+  cclint schema report > cclint-report.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(runSchemaReport),
+	}
+
+	schemaCompletionsCmd := &cobra.Command{
+		Use:   "completions",
+		Short: "Print frontmatter and settings field data for editor autocompletion",
+		Long: `Print compact JSON describing the fields of agent, command, and skill
+frontmatter and of settings.json, for editor plugins that offer
+autocompletion and hover docs. The data is read from the same embedded CUE
+schemas that validation uses, so it cannot drift from what cclint accepts.
+
+The output maps each component to its fields, in schema order:
+
+  {"version": 1, "components": {"agent": [{"name": "model",
+    "types": ["string"], "enum": ["sonnet", ...], "doc": "..."}, ...]}}
+
+Each field lists its JSON types and, when the schema gives them, whether
+it is required, the values it names (enum), the values named for array
+elements (items), a default, a one-line doc from the schema comment, and
+the fields of a nested object. A field with an enum may still accept
+other values, such as full model IDs.
+
+EXAMPLES:
+
+  cclint schema completions > cclint-completions.json`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(runSchemaCompletions),
+	}
+
+	schemaCmd.AddCommand(verifyUpstreamCmd)
+	schemaCmd.AddCommand(schemaReportCmd)
+	schemaCmd.AddCommand(schemaCompletionsCmd)
+
+	verifyUpstreamCmd.Flags().StringVar(&opts.snapshot, "snapshot", "", "Compare against a pinned JSON snapshot instead of fetching")
+	verifyUpstreamCmd.Flags().StringArrayVar(&opts.sources, "source", nil, "Override a documentation URL (component=url)")
+	verifyUpstreamCmd.Flags().DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout for fetching the documentation")
+	verifyUpstreamCmd.Flags().DurationVar(&opts.cacheTTL, "cache-ttl", 24*time.Hour, "Reuse cached documentation younger than this")
+	return schemaCmd
+}
+
+// completionsVersion is the version of the schema completions format.
+const completionsVersion = 1
+
+func runVerifyUpstream(inv *invocation, opts *upstreamOptions) (cmdResult, error) {
+	// No config file applies here; only --verbosity and -v matter.
+	if err := applyVerbosity(inv, &config.Config{}); err != nil {
+		return cmdResult{}, err
+	}
+
+	documented, err := loadUpstreamFields(inv, opts)
+	if errors.Is(err, cache.ErrOffline) {
+		fmt.Fprintf(os.Stderr, "notice: verify-upstream skipped: %v (run once without --offline to cache it)\n", err)
+		return resultOK, nil
+	}
+	if err != nil {
+		return cmdResult{}, err
+	}
+
+	known := make(map[string][]string, len(documented))
+	for component := range documented {
+		definition, ok := schemaDefinitions[component]
+		if !ok {
+			return cmdResult{}, usageErrorf("unknown component %q. Must be one of: %s",
+				component, strings.Join(slices.Sorted(maps.Keys(schemaDefinitions)), ", "))
+		}
+		fields, err := cue.SchemaFields(definition)
+		if err != nil {
+			return cmdResult{}, err
+		}
+		for _, f := range lint.KnownFields(component) {
+			if !slices.Contains(fields, f) {
+				fields = append(fields, f)
+			}
+		}
+		known[component] = fields
+	}
+
+	stale := false
+	for _, d := range upstream.Compare(documented, known) {
+		if len(d.Unknown) == 0 {
+			fmt.Printf("%s: up to date (%d documented fields)\n", d.Component, len(documented[d.Component]))
+		} else {
+			stale = true
+			fmt.Printf("%s: %d documented fields unknown to cclint\n", d.Component, len(d.Unknown))
+			for _, f := range d.Unknown {
+				fmt.Printf("  + %s\n", f)
+			}
+		}
+		if inv.verbose {
+			for _, f := range d.Undocumented {
+				fmt.Printf("  - %s (known to cclint, not documented)\n", f)
+			}
+		}
+	}
+
+	if stale {
+		return cmdResult{ExitCode: ExitFindings}, nil
+	}
+	return resultOK, nil
+}
+
+func runSchemaReport([]string) (cmdResult, error) {
+	if _, err := os.Stdout.Write(output.ReportSchema); err != nil {
+		return cmdResult{}, err
+	}
+	return resultOK, nil
+}
+
+func runSchemaCompletions([]string) (cmdResult, error) {
+	v := cue.NewValidator()
+	if err := v.LoadSchemas(""); err != nil {
+		return cmdResult{}, err
+	}
+	components, err := v.Completions(schemaDefinitions)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	data, err := json.Marshal(struct {
+		Version    int                              `json:"version"`
+		Components map[string][]cue.CompletionField `json:"components"`
+	}{completionsVersion, components})
+	if err != nil {
+		return cmdResult{}, err
+	}
+	fmt.Println(string(data))
+	return resultOK, nil
+}
+
+// loadUpstreamFields reads the snapshot, or fetches the documentation with
+// any --source overrides applied.
+func loadUpstreamFields(inv *invocation, opts *upstreamOptions) (upstream.Snapshot, error) {
+	if opts.snapshot != "" {
+		snap, err := upstream.LoadSnapshot(opts.snapshot)
+		return snap, asUsageError(err)
+	}
+
+	sources := maps.Clone(upstream.DefaultSources)
+	for _, s := range opts.sources {
+		component, url, ok := strings.Cut(s, "=")
+		if !ok || url == "" {
+			return nil, usageErrorf("invalid --source %q. Use component=url", s)
+		}
+		if _, known := schemaDefinitions[component]; !known {
+			return nil, usageErrorf("invalid --source %q: unknown component %q", s, component)
+		}
+		sources[component] = url
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+	c, err := remoteCache(inv)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := upstream.FetchCached(ctx, http.DefaultClient, c, opts.cacheTTL, sources)
+	if errors.Is(err, cache.ErrOffline) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching upstream documentation: %w", err)
+	}
+	return snap, nil
+}
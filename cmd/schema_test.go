@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunVerifyUpstream_Snapshot(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot string
+		wantCode int
+		wantErr  bool
+	}{
+		{name: "up to date", snapshot: `{"agent": ["name", "description", "tools"], "settings": ["hooks", "permissions"]}`},
+		{name: "documented field unknown", snapshot: `{"command": ["argument-hint", "brand-new-field"]}`, wantCode: ExitFindings},
+		{name: "unknown component", snapshot: `{"widget": ["x"]}`, wantErr: true},
+		{name: "invalid snapshot", snapshot: `not json`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "upstream.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.snapshot), 0600))
+
+			result, err := runVerifyUpstream(testInvocation(), &upstreamOptions{snapshot: path})
+			if tt.wantErr {
+				assert.Equal(t, ExitUsage, exitCodeForError(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCode, result.ExitCode)
+		})
+	}
+}
+
+func TestLoadUpstreamFields_InvalidSource(t *testing.T) {
+	for _, s := range []string{"settings", "widget=https://example.com/x.md"} {
+		_, err := loadUpstreamFields(testInvocation(), &upstreamOptions{sources: []string{s}})
+		assert.Equal(t, ExitUsage, exitCodeForError(err), "source %q", s)
+	}
+}
+
+func TestRunSchemaReport(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	old := os.Stdout
+	os.Stdout = w
+	result, err := runSchemaReport(nil)
+	os.Stdout = old
+	require.NoError(t, w.Close())
+	require.NoError(t, err)
+	assert.Equal(t, resultOK, result)
+
+	var schema map[string]any
+	require.NoError(t, json.NewDecoder(r).Decode(&schema))
+	assert.Contains(t, schema["required"], "schemaVersion")
+}
+
+func TestRunVerifyUpstream_OfflineWithoutCache(t *testing.T) {
+	t.Setenv("CCLINT_CACHE_DIR", t.TempDir())
+	inv := testInvocation()
+	inv.offline = true
+
+	result, err := runVerifyUpstream(inv, &upstreamOptions{timeout: 30 * time.Second, cacheTTL: 24 * time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, resultOK, result)
+}
+
+func TestRunSchemaCompletions(t *testing.T) {
+	out, result, err := captureStdout(t, func() (cmdResult, error) { return runSchemaCompletions(nil) })
+	require.NoError(t, err)
+	assert.Equal(t, resultOK, result)
+
+	var data struct {
+		Version    int                         `json:"version"`
+		Components map[string][]map[string]any `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &data))
+	assert.Equal(t, completionsVersion, data.Version)
+	for component := range schemaDefinitions {
+		assert.NotEmpty(t, data.Components[component], component)
+	}
+}
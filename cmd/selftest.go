@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/selftest"
+	"github.com/spf13/cobra"
+)
+
+// newSelftestCmd builds the selftest command.
+func newSelftestCmd(inv *invocation) *cobra.Command {
+	return &cobra.Command{
+		Use:   "selftest [fixture-dir]",
+		Short: "Check findings against a corpus of fixture projects",
+		Long: `Lint every fixture project in a directory (default .cclint/selftest) and
+compare the findings with the ones the fixture expects. Use it to pin down
+what cclint reports for known-good and known-bad components, and to check
+that a custom configuration, such as rule severities or overrides, does
+what it is meant to.
+
+Each subdirectory with an expect.yaml is a fixture: a project root with
+its own components and, optionally, its own .cclintrc.yaml. expect.yaml
+lists the findings the fixture must report; file, rule, and severity match
+exactly and message as a substring, and fields left out match anything:
+
+  findings:
+    - file: .claude/agents/helper.md
+      rule: agent-tool-unknown
+      severity: warning
+      message: FutureTool
+
+A fixture must report exactly the listed findings, so a known-good fixture
+has none. Set partial: true to allow findings that are not listed. Exits 1
+when any fixture fails.
+
+EXAMPLES:
+
+  cclint selftest
+  cclint selftest testdata/selftest`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runSelftest(inv, args)
+		}),
+	}
+}
+
+func runSelftest(inv *invocation, args []string) (cmdResult, error) {
+	dir := selftest.DefaultDir
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	fixtures, err := selftest.Load(dir)
+	if errors.Is(err, selftest.ErrNoFixtures) {
+		return cmdResult{}, usageErrorf("%w; add a directory with an %s", err, selftest.ExpectFile)
+	}
+	if err != nil {
+		return cmdResult{}, asUsageError(err)
+	}
+
+	failed := 0
+	for _, fx := range fixtures {
+		findings, err := lintFixture(inv, fx)
+		if err != nil {
+			return cmdResult{}, fmt.Errorf("fixture %s: %w", fx.Name, err)
+		}
+		report := selftest.Check(fx, findings)
+		if report.Passed() {
+			if !inv.quiet {
+				fmt.Printf("PASS %s\n", fx.Name)
+			}
+			continue
+		}
+		failed++
+		fmt.Printf("FAIL %s\n", fx.Name)
+		for _, e := range report.Missing {
+			fmt.Printf("  missing:    %s\n", e)
+		}
+		for _, f := range report.Unexpected {
+			fmt.Printf("  unexpected: %s\n", f)
+		}
+	}
+
+	if !inv.quiet {
+		fmt.Printf("\n%d fixtures, %d failed\n", len(fixtures), failed)
+	}
+	if failed > 0 {
+		return cmdResult{ExitCode: ExitFindings}, nil
+	}
+	return resultOK, nil
+}
+
+// lintFixture lints a fixture as its own project, with its own
+// configuration, and returns what the run reports after rules config,
+// overrides, and untrusted mode have re-graded it.
+func lintFixture(inv *invocation, fx selftest.Fixture) ([]selftest.Finding, error) {
+	root, err := filepath.Abs(fx.Dir)
+	if err != nil {
+		return nil, err
+	}
+	fxInv := *inv
+	fxInv.rootPath = root
+
+	cfg, err := loadCLIConfig(&fxInv)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Verbosity = config.VerbosityQuiet
+	result, err := runOrchestratedLint(&fxInv, cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []selftest.Finding
+	for _, summary := range result.Summaries {
+		for _, r := range summary.Results {
+			for _, issues := range [][]cue.ValidationError{r.Errors, r.Warnings, r.Suggestions} {
+				for _, issue := range issues {
+					findings = append(findings, selftest.Finding{
+						File:     filepath.ToSlash(r.File),
+						Line:     issue.Line,
+						Rule:     issue.Rule,
+						Severity: issue.Severity,
+						Message:  issue.Message,
+					})
+				}
+			}
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSelftest(t *testing.T) {
+	inv := testInvocation()
+	inv.quiet = false
+
+	// The public corpus passes.
+	out, result, err := captureStdout(t, func() (cmdResult, error) { return runSelftest(inv, []string{"../testdata/selftest"}) })
+	require.NoError(t, err)
+	assert.Equal(t, resultOK, result, out)
+	assert.Contains(t, out, "PASS good-agent")
+
+	dir := t.TempDir()
+	agent := filepath.Join(dir, "wrong/.claude/agents/helper.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(agent), 0o755))
+	require.NoError(t, os.WriteFile(agent, []byte("---\nname: helper\n---\n# Helper\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "wrong/expect.yaml"), []byte("findings:\n  - rule: agent-tool-unknown\n"), 0o600))
+
+	out, result, err = captureStdout(t, func() (cmdResult, error) { return runSelftest(inv, []string{dir}) })
+	require.NoError(t, err)
+	assert.Equal(t, ExitFindings, result.ExitCode)
+	assert.Contains(t, out, "FAIL wrong")
+	assert.Contains(t, out, `missing:    rule="agent-tool-unknown"`)
+	assert.Contains(t, out, "unexpected: .claude/agents/helper.md")
+
+	_, err = runSelftest(inv, []string{t.TempDir()})
+	assert.Equal(t, ExitUsage, exitCodeForError(err))
+}
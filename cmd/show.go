@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/format"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/output"
+	"github.com/dotcommander/cclint/internal/project"
+	"github.com/dotcommander/cclint/internal/scoring"
+	"github.com/dotcommander/cclint/internal/textutil"
+	"github.com/spf13/cobra"
+)
+
+// newShowCmd builds the show command.
+func newShowCmd(inv *invocation) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <file>",
+		Short: "Print a card summarizing one component",
+		Long: `Print everything cclint knows about one component file: its detected
+type, its frontmatter as cclint fmt would write it, the commands, agents,
+and skills it references and that reference it directly, its size and
+estimated token count, its quality score, and its current findings.
+
+Findings are graded as a lint run grades them, with rules config,
+overrides, and inline suppressions applied. cclint impact lists indirect
+dependents, and cclint trace the full delegation chain.
+
+With --format json the card is printed as a JSON object with file, type,
+name, frontmatter, lines, bytes, tokens, references, referencedBy, score,
+and findings fields.
+
+EXAMPLES:
+
+  cclint show .claude/agents/code-reviewer.md
+  cclint show .claude/skills/release/SKILL.md --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runShow(inv, args)
+		}),
+	}
+}
+
+// componentCard is the JSON output of cclint show.
+type componentCard struct {
+	File         string                `json:"file"`
+	Type         string                `json:"type"`
+	Name         string                `json:"name,omitempty"`
+	Frontmatter  map[string]any        `json:"frontmatter,omitempty"`
+	Lines        int                   `json:"lines"`
+	Bytes        int                   `json:"bytes"`
+	Tokens       int                   `json:"tokens"`
+	References   []crossfile.ChainLink `json:"references"`
+	ReferencedBy []crossfile.Dependent `json:"referencedBy"`
+	Score        *scoring.QualityScore `json:"score,omitempty"`
+	Findings     []output.JSONIssueV2  `json:"findings"`
+
+	// frontmatterText is the normalized frontmatter block, for the console.
+	frontmatterText string
+}
+
+func runShow(inv *invocation, args []string) (cmdResult, error) {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	if cfg.Format != "console" && cfg.Format != "json" {
+		return cmdResult{}, usageErrorf("show supports --format console or json, not %q", cfg.Format)
+	}
+
+	root := cfg.Root
+	if root == "" {
+		if root, err = project.FindProjectRoot("."); err != nil {
+			return cmdResult{}, fmt.Errorf("error finding project root: %w", err)
+		}
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return cmdResult{}, fmt.Errorf("error resolving project root: %w", err)
+	}
+	target, err := discovery.ValidateFilePath(args[0])
+	if err != nil {
+		return cmdResult{}, asUsageError(err)
+	}
+	fileType, err := cfg.DetectFileType(target, root)
+	if err != nil {
+		return cmdResult{}, usageErrorf("%s is not a component file under %s: %w", args[0], root, err)
+	}
+
+	card, err := buildComponentCard(cfg, root, target, fileType)
+	if err != nil {
+		return cmdResult{}, err
+	}
+
+	if cfg.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return resultOK, enc.Encode(card)
+	}
+	printComponentCard(card)
+	return resultOK, nil
+}
+
+// buildComponentCard gathers the card of the component in target.
+func buildComponentCard(cfg *config.Config, root, target string, fileType discovery.FileType) (*componentCard, error) {
+	data, err := os.ReadFile(target) //nolint:gosec // G304: path given on the command line
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", target, err)
+	}
+	contents := string(data)
+	rel, err := filepath.Rel(root, target)
+	if err != nil {
+		rel = target
+	}
+	card := &componentCard{
+		File:         filepath.ToSlash(rel),
+		Type:         fileType.String(),
+		Lines:        textutil.CountLines(contents),
+		Bytes:        len(data),
+		Tokens:       textutil.EstimateTokens(contents),
+		References:   []crossfile.ChainLink{},
+		ReferencedBy: []crossfile.Dependent{},
+		Findings:     []output.JSONIssueV2{},
+	}
+	if strings.EqualFold(filepath.Ext(target), ".md") {
+		card.Frontmatter, card.frontmatterText = normalizedFrontmatter(formatOptions(cfg), fileType, contents)
+	}
+	if name, ok := card.Frontmatter["name"].(string); ok {
+		card.Name = name
+	}
+
+	files, err := cfg.NewFileDiscovery(root).WithExclude(cfg.Exclude).DiscoverFiles()
+	if err != nil {
+		return nil, fmt.Errorf("error discovering files: %w", err)
+	}
+	v := crossfile.NewCrossFileValidator(files, root).WithExtraBuiltinAgents(cfg.ExtraBuiltinAgents)
+	if componentType, name, ok := v.ComponentAt(target); ok {
+		card.Name = name
+		if link := v.TraceChain(componentType, name); link != nil {
+			for _, child := range link.Children {
+				child.Children = nil
+				card.References = append(card.References, child)
+			}
+		}
+		for _, d := range v.Impact(componentType, name) {
+			if d.Depth == 1 {
+				card.ReferencedBy = append(card.ReferencedBy, d)
+			}
+		}
+	}
+
+	quiet := *cfg
+	quiet.Verbosity = config.VerbosityQuiet
+	summary, err := lint.LintFiles([]string{target}, root, fileType.String(), lint.Options{Config: &quiet})
+	if err != nil {
+		return nil, fmt.Errorf("error linting %s: %w", card.File, err)
+	}
+	lint.ApplyRulesConfig(summary, cfg.Rules)
+	lint.ApplyOverrides(summary, cfg.Overrides)
+	if cfg.Untrusted {
+		lint.ApplyUntrusted(summary)
+	}
+	lint.ApplyInlineSuppressions(summary)
+	for _, r := range summary.Results {
+		card.Score = r.Quality
+		for _, issues := range [][]cue.ValidationError{r.Errors, r.Warnings, r.Suggestions} {
+			for _, issue := range issues {
+				card.Findings = append(card.Findings, output.JSONIssueV2{
+					Rule:     issue.Rule,
+					Severity: issue.Severity,
+					Message:  issue.Message,
+					Source:   issue.Source,
+					Line:     issue.Line,
+					Column:   issue.Column,
+					Pointer:  issue.Pointer,
+					DocsURL:  lint.RuleDocsURL(cfg.DocsURLTemplate, issue.Rule),
+				})
+			}
+		}
+	}
+	return card, nil
+}
+
+// normalizedFrontmatter returns a markdown component's frontmatter as cclint
+// fmt would write it with opts, parsed and as text. Content that does not format or
+// parse is shown as it is.
+func normalizedFrontmatter(opts format.Options, fileType discovery.FileType, contents string) (map[string]any, string) {
+	formatted, err := format.NewComponentFormatter(fileType.String(), opts).Format(contents)
+	if err != nil {
+		formatted = contents
+	}
+	fm, err := textutil.ParseYAMLFrontmatter(formatted)
+	if err != nil || len(fm.Data) == 0 {
+		return nil, ""
+	}
+	lines := strings.Split(formatted, "\n")
+	end := textutil.GetFrontmatterEndLine(formatted)
+	if end < 2 {
+		return fm.Data, ""
+	}
+	return fm.Data, strings.Join(lines[1:end-1], "\n")
+}
+
+// printComponentCard prints the card for the console.
+func printComponentCard(card *componentCard) {
+	title := card.Type
+	if card.Name != "" {
+		title += " " + card.Name
+	}
+	fmt.Println(title)
+	fmt.Printf("  File:   %s\n", card.File)
+	fmt.Printf("  Size:   %d lines, %d bytes, ~%d tokens\n", card.Lines, card.Bytes, card.Tokens)
+	if s := card.Score; s != nil {
+		fmt.Printf("  Score:  %d/100 (%s): structural %d/40, practices %d/40, composition %d/10, documentation %d/10\n",
+			s.Overall, s.Tier, s.Structural, s.Practices, s.Composition, s.Documentation)
+	}
+
+	if card.frontmatterText != "" {
+		fmt.Println("\nFrontmatter:")
+		for _, line := range strings.Split(card.frontmatterText, "\n") {
+			fmt.Println("  " + line)
+		}
+	}
+
+	if len(card.References) > 0 {
+		fmt.Println("\nReferences:")
+		for _, r := range card.References {
+			fmt.Printf("  %s %s (%s)\n", r.Type, r.Name, r.Path)
+		}
+	}
+	if len(card.ReferencedBy) > 0 {
+		fmt.Println("\nReferenced by:")
+		for _, d := range card.ReferencedBy {
+			fmt.Printf("  %s %s (%s)\n", d.Type, d.Name, d.Path)
+		}
+	}
+
+	fmt.Println("\nFindings:")
+	if len(card.Findings) == 0 {
+		fmt.Println("  none")
+	}
+	for _, f := range card.Findings {
+		location := ""
+		if f.Line > 0 {
+			location = fmt.Sprintf("line %d: ", f.Line)
+		}
+		rule := ""
+		if f.Rule != "" {
+			rule = " [" + f.Rule + "]"
+		}
+		fmt.Printf("  %-10s %s%s%s\n", f.Severity, location, f.Message, rule)
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunShow(t *testing.T) {
+	root := t.TempDir()
+	for path, contents := range map[string]string{
+		".claude/commands/deploy.md":      "---\ndescription: Deploy\n---\nTask(deployer)\n",
+		".claude/agents/deployer.md":      "---\ndescription: Deploys the app\nname: deployer\n---\nSkill: release\n",
+		".claude/skills/release/SKILL.md": "---\nname: release\ndescription: Release steps\n---\nbody\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(contents), 0o600))
+	}
+
+	inv := testInvocation()
+	inv.rootPath = root
+
+	t.Run("agent as console", func(t *testing.T) {
+		inv.outputFormat = "console"
+		file := filepath.Join(root, ".claude/agents/deployer.md")
+		out, result, err := captureStdout(t, func() (cmdResult, error) { return runShow(inv, []string{file}) })
+		require.NoError(t, err)
+		assert.Equal(t, resultOK, result)
+		assert.Contains(t, out, "agent deployer\n")
+		assert.Contains(t, out, "  File:   .claude/agents/deployer.md\n")
+		assert.Contains(t, out, "Score:  ")
+		// Normalized: name comes before description.
+		assert.Contains(t, out, "Frontmatter:\n  name: deployer\n  description: Deploys the app\n")
+		assert.Contains(t, out, "References:\n  skill release (.claude/skills/release/SKILL.md)\n")
+		assert.Contains(t, out, "Referenced by:\n  command deploy (.claude/commands/deploy.md)\n")
+		assert.Contains(t, out, "Findings:\n")
+	})
+
+	t.Run("agent as json", func(t *testing.T) {
+		inv.outputFormat = "json"
+		file := filepath.Join(root, ".claude/agents/deployer.md")
+		out, result, err := captureStdout(t, func() (cmdResult, error) { return runShow(inv, []string{file}) })
+		require.NoError(t, err)
+		assert.Equal(t, resultOK, result)
+
+		var card componentCard
+		require.NoError(t, json.Unmarshal([]byte(out), &card))
+		assert.Equal(t, ".claude/agents/deployer.md", card.File)
+		assert.Equal(t, "agent", card.Type)
+		assert.Equal(t, "deployer", card.Name)
+		assert.Equal(t, "Deploys the app", card.Frontmatter["description"])
+		assert.Positive(t, card.Lines)
+		assert.Positive(t, card.Tokens)
+		require.Len(t, card.References, 1)
+		assert.Equal(t, "release", card.References[0].Name)
+		require.Len(t, card.ReferencedBy, 1)
+		assert.Equal(t, "deploy", card.ReferencedBy[0].Name)
+		require.NotNil(t, card.Score)
+		assert.NotNil(t, card.Findings)
+	})
+
+	t.Run("findings", func(t *testing.T) {
+		inv.outputFormat = "json"
+		file := filepath.Join(root, ".claude/agents/broken.md")
+		require.NoError(t, os.WriteFile(file, []byte("---\nname: Broken_Agent\n---\nbody\n"), 0o600))
+		out, result, err := captureStdout(t, func() (cmdResult, error) { return runShow(inv, []string{file}) })
+		require.NoError(t, err)
+		assert.Equal(t, resultOK, result)
+
+		var card componentCard
+		require.NoError(t, json.Unmarshal([]byte(out), &card))
+		var severities []string
+		for _, f := range card.Findings {
+			severities = append(severities, f.Severity)
+		}
+		assert.Contains(t, severities, "error")
+	})
+
+	t.Run("not a component", func(t *testing.T) {
+		inv.outputFormat = "console"
+		_, _, err := captureStdout(t, func() (cmdResult, error) { return runShow(inv, []string{filepath.Join(root, "README.md")}) })
+		assert.Equal(t, ExitUsage, exitCodeForError(err))
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		inv.outputFormat = "tap"
+		file := filepath.Join(root, ".claude/agents/deployer.md")
+		_, _, err := captureStdout(t, func() (cmdResult, error) { return runShow(inv, []string{file}) })
+		assert.Equal(t, ExitUsage, exitCodeForError(err))
+	})
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/project"
+	"github.com/dotcommander/cclint/internal/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// newSnapshotCmd builds the snapshot command and its subcommands. Their
+// --dir flag sets the snapshot directory, relative to the project root.
+func newSnapshotCmd(inv *invocation) *cobra.Command {
+	var dir string
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Record and verify the structure of components",
+		Long: `Snapshots record the structure of every agent, command, and skill: its
+frontmatter as cclint fmt writes it, and an outline of its headings and
+code blocks. Commit them, and verify them in CI, so that a change to a
+component's structure shows up for review like a code change. Edits to
+prose alone do not change a snapshot.
+
+Snapshots are stored under .cclint/snapshots in the project root: one
+readable .snap file per component and an index.json with the SHA-256 of
+each, so the same tree always produces the same files.
+
+EXAMPLES:
+
+  cclint snapshot create
+  cclint snapshot verify`,
+	}
+
+	snapshotCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Write snapshots of every component",
+		Long: `Write a snapshot of every agent, command, and skill, replacing any earlier
+snapshots. Run it after reviewing a structural change to accept it.`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return resultOK, runSnapshotCreate(inv, dir)
+		}),
+	}
+
+	snapshotVerifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Compare components with their snapshots",
+		Long: `Compare every agent, command, and skill with its snapshot and list the
+components that were added, removed, or changed, with a diff of each
+change. Exits 1 when anything differs.`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return runSnapshotVerify(inv, dir)
+		}),
+	}
+
+	snapshotCmd.PersistentFlags().StringVar(&dir, "dir", snapshot.DefaultDir, "Snapshot directory, relative to the project root")
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCmd.AddCommand(snapshotVerifyCmd)
+	return snapshotCmd
+}
+
+// buildSnapshots discovers the project's components and snapshots them,
+// returning the loaded config, the snapshots, and the snapshot directory,
+// dir resolved against the project root.
+func buildSnapshots(inv *invocation, dir string) (*config.Config, []snapshot.Component, string, error) {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	root := cfg.Root
+	if root == "" {
+		if root, err = project.FindProjectRoot("."); err != nil {
+			return nil, nil, "", fmt.Errorf("error finding project root: %w", err)
+		}
+	}
+	files, err := cfg.NewFileDiscovery(root).WithExclude(cfg.Exclude).DiscoverFiles()
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("error discovering files: %w", err)
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	return cfg, snapshot.Build(files, formatOptions(cfg)), dir, nil
+}
+
+func runSnapshotCreate(inv *invocation, dir string) error {
+	cfg, comps, dir, err := buildSnapshots(inv, dir)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.Write(dir, comps); err != nil {
+		return err
+	}
+	if !cfg.Quiet() {
+		fmt.Printf("Wrote %d snapshots to %s\n", len(comps), dir)
+	}
+	return nil
+}
+
+func runSnapshotVerify(inv *invocation, dir string) (cmdResult, error) {
+	cfg, comps, dir, err := buildSnapshots(inv, dir)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	stored, err := snapshot.Load(dir)
+	if errors.Is(err, snapshot.ErrNoSnapshots) {
+		return cmdResult{}, usageErrorf("no snapshots in %s; run cclint snapshot create first", dir)
+	}
+	if err != nil {
+		return cmdResult{}, err
+	}
+
+	changes := snapshot.Compare(stored, comps)
+	if len(changes) == 0 {
+		if !cfg.Quiet() {
+			fmt.Printf("%d components match their snapshots\n", len(comps))
+		}
+		return resultOK, nil
+	}
+	for _, c := range changes {
+		fmt.Printf("%s %s %s\n", c.Kind, c.Type, c.Path)
+		if c.Diff != "" && !cfg.Quiet() {
+			fmt.Print(c.Diff)
+		}
+	}
+	if !cfg.Quiet() {
+		fmt.Printf("\n%d components differ from their snapshots; run cclint snapshot create to accept\n", len(changes))
+	}
+	return cmdResult{ExitCode: ExitFindings}, nil
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotCreateVerify(t *testing.T) {
+	root := t.TempDir()
+	agent := filepath.Join(root, ".claude/agents/helper.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(agent), 0o755))
+	require.NoError(t, os.WriteFile(agent, []byte("---\nname: helper\ndescription: Helps\n---\n# Helper\n\n## Steps\n"), 0o600))
+
+	inv := testInvocation()
+	inv.rootPath = root
+
+	verify := func() (string, cmdResult, error) {
+		return captureStdout(t, func() (cmdResult, error) { return runSnapshotVerify(inv, snapshot.DefaultDir) })
+	}
+
+	_, _, err := verify()
+	assert.Equal(t, ExitUsage, exitCodeForError(err), "verify without snapshots is a usage error")
+
+	_, _, err = captureStdout(t, func() (cmdResult, error) { return resultOK, runSnapshotCreate(inv, snapshot.DefaultDir) })
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(root, snapshot.DefaultDir, "index.json"))
+
+	out, result, err := verify()
+	require.NoError(t, err)
+	assert.Equal(t, resultOK, result)
+	assert.Contains(t, out, "1 components match their snapshots")
+
+	require.NoError(t, os.WriteFile(agent, []byte("---\nname: helper\ndescription: Helps\n---\n# Helper\n\n## Setup\n\n## Steps\n"), 0o600))
+	out, result, err = verify()
+	require.NoError(t, err)
+	assert.Equal(t, ExitFindings, result.ExitCode)
+	assert.Contains(t, out, "changed agent .claude/agents/helper.md")
+	assert.Contains(t, out, "+   ## Setup")
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dotcommander/cclint/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+// newStatsCmd builds the stats command. Its --top flag sets the rows per
+// breakdown in console output.
+func newStatsCmd(inv *invocation) *cobra.Command {
+	var top int
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show how often each rule fires, by rule, directory, and component type",
+		Long: `Lint every component and summarize the findings by rule ID, by directory,
+and by component type, with each group's share of all findings. Use it to
+see which rules fire most before deciding which to fix first, re-grade in
+rules config, or turn off.
+
+Findings are counted as a normal run reports them, after rules config,
+overrides, and the baseline. Each group shows its error, warning, and
+suggestion counts and how many files it touches.
+
+Console output lists the --top groups of each breakdown (default 10; 0
+lists all). With --format json every group is listed, as an object with
+files, findings, and byRule, byDirectory, and byType arrays.
+
+EXAMPLES:
+
+  cclint stats
+  cclint stats --top 0
+  cclint stats --format json`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return resultOK, runStats(inv, top)
+		}),
+	}
+	statsCmd.Flags().IntVar(&top, "top", 10, "groups to list per breakdown in console output (0 for all)")
+	return statsCmd
+}
+
+func runStats(inv *invocation, top int) error {
+	if top < 0 {
+		return usageErrorf("invalid --top: must not be negative")
+	}
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return err
+	}
+	if cfg.Format != "console" && cfg.Format != "json" {
+		return usageErrorf("stats supports --format console or json, not %q", cfg.Format)
+	}
+	result, err := runOrchestratedLint(inv, cfg, nil)
+	if err != nil {
+		return fmt.Errorf("error building stats: %w", err)
+	}
+
+	report := stats.Compute(result.Summaries)
+	if cfg.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printStats(report, top)
+	return nil
+}
+
+// printStats prints each breakdown as a table with a bar per group, scaled
+// to its share of all findings.
+func printStats(report stats.Report, top int) {
+	fmt.Printf("%d findings in %d files\n", report.Findings, report.Files)
+	if report.Findings == 0 {
+		return
+	}
+	styles := newPrintStyles()
+	for _, section := range []struct {
+		title  string
+		groups []stats.Group
+	}{
+		{"BY RULE", report.ByRule},
+		{"BY DIRECTORY", report.ByDirectory},
+		{"BY COMPONENT TYPE", report.ByType},
+	} {
+		fmt.Println()
+		fmt.Println(styles.header.Render(section.title))
+		for i, g := range section.groups {
+			if top > 0 && i == top {
+				fmt.Println(styles.dim.Render(fmt.Sprintf("  ... and %d more", len(section.groups)-top)))
+				break
+			}
+			key := g.Key
+			if key == "" {
+				key = "(no rule ID)"
+			}
+			files := "files"
+			if g.Files == 1 {
+				files = "file"
+			}
+			fmt.Printf("  %-36s %5d %5.1f%%  %s  %s\n", key, g.Findings, g.Percent,
+				renderBar(g.Findings, report.Findings, "12"),
+				styles.dim.Render(fmt.Sprintf("%dE %dW %dS in %d %s", g.Errors, g.Warnings, g.Suggestions, g.Files, files)))
+		}
+	}
+}
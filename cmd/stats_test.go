@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunStats(t *testing.T) {
+	root := t.TempDir()
+	for path, contents := range map[string]string{
+		".claude/agents/helper.md": "---\nname: helper\ndescription: Helps with things. Use PROACTIVELY when asked.\ntools: Read, FutureTool\nmodel: sonnet\n---\nHelps.\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(contents), 0o600))
+	}
+
+	inv := testInvocation()
+	inv.rootPath = root
+
+	inv.outputFormat = "json"
+	out, result, err := captureStdout(t, func() (cmdResult, error) { return resultOK, runStats(inv, 10) })
+	require.NoError(t, err)
+	assert.Equal(t, resultOK, result)
+	var report stats.Report
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.Equal(t, 1, report.Files)
+	require.NotEmpty(t, report.ByRule)
+	var rules []string
+	for _, g := range report.ByRule {
+		rules = append(rules, g.Key)
+	}
+	assert.Contains(t, rules, "agent-tool-unknown")
+	require.Len(t, report.ByDirectory, 1)
+	assert.Equal(t, ".claude/agents", report.ByDirectory[0].Key)
+	assert.Equal(t, report.Findings, report.ByDirectory[0].Findings)
+
+	inv.outputFormat = "console"
+	out, _, err = captureStdout(t, func() (cmdResult, error) { return resultOK, runStats(inv, 1) })
+	require.NoError(t, err)
+	assert.Contains(t, out, "BY RULE")
+	assert.Contains(t, out, "BY COMPONENT TYPE")
+	if len(report.ByRule) > 1 {
+		assert.Contains(t, out, "more")
+	}
+
+	_, _, err = captureStdout(t, func() (cmdResult, error) { return resultOK, runStats(inv, -1) })
+	assert.Equal(t, ExitUsage, exitCodeForError(err))
+
+	inv.outputFormat = "tap"
+	_, _, err = captureStdout(t, func() (cmdResult, error) { return resultOK, runStats(inv, 10) })
+	assert.Equal(t, ExitUsage, exitCodeForError(err))
+}
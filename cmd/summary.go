@@ -2,29 +2,26 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"sort"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/lint"
 	"github.com/spf13/cobra"
 )
 
-var summaryCmd = &cobra.Command{
-	Use:   "summary",
-	Short: "Show quality summary across all components",
-	Long: `Aggregates quality scores across all Claude Code components (agents, commands, skills)
+// newSummaryCmd builds the summary command.
+func newSummaryCmd(inv *invocation) *cobra.Command {
+	return &cobra.Command{
+		Use:   "summary",
+		Short: "Show quality summary across all components",
+		Long: `Aggregates quality scores across all Claude Code components (agents, commands, skills)
 and displays a summary report with quality distribution, top issues, and lowest-scoring components.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := runSummary(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			exitFunc(1)
-		}
-	},
-}
-
-func init() {
-	rootCmd.AddCommand(summaryCmd)
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return resultOK, runSummary(inv)
+		}),
+	}
 }
 
 // ComponentSummary holds aggregated data for summary report
@@ -33,6 +30,7 @@ type ComponentSummary struct {
 	AgentCount      int
 	CommandCount    int
 	SkillCount      int
+	DisabledCount   int
 	TierCounts      map[string]int
 	TopIssues       map[string]int
 	LowestScoring   []ScoredComponent
@@ -47,13 +45,13 @@ type ScoredComponent struct {
 	Tier  string
 }
 
-func runSummary() error {
-	cfg, err := loadCLIConfig()
+func runSummary(inv *invocation) error {
+	cfg, err := loadCLIConfig(inv)
 	if err != nil {
 		return err
 	}
 
-	result, err := runOrchestratedLint(cfg, []lint.LinterEntry{
+	result, err := runOrchestratedLint(inv, cfg, []lint.LinterEntry{
 		{Name: "agents", Linter: lint.LintAgents},
 		{Name: "commands", Linter: lint.LintCommands},
 		{Name: "skills", Linter: lint.LintSkills},
@@ -76,6 +74,7 @@ func runSummary() error {
 		case "skill":
 			summary.SkillCount = componentSummary.TotalFiles
 		}
+		summary.DisabledCount += componentSummary.DisabledFiles
 		aggregateResults(summary, componentSummary.Results)
 	}
 
@@ -83,7 +82,11 @@ func runSummary() error {
 
 	// Sort lowest scoring
 	sort.Slice(summary.LowestScoring, func(i, j int) bool {
-		return summary.LowestScoring[i].Score < summary.LowestScoring[j].Score
+		a, b := summary.LowestScoring[i], summary.LowestScoring[j]
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		return a.File < b.File
 	})
 
 	// Print summary report
@@ -205,6 +208,9 @@ func printComponentCounts(summary *ComponentSummary, styles printStyles) {
 	fmt.Printf("║ Components Analyzed: %-37d ║\n", summary.TotalComponents)
 	fmt.Printf("║   Agents: %-5d │ Commands: %-5d │ Skills: %-13d ║\n",
 		summary.AgentCount, summary.CommandCount, summary.SkillCount)
+	if summary.DisabledCount > 0 {
+		fmt.Printf("║   Disabled: %-46d ║\n", summary.DisabledCount)
+	}
 }
 
 func printQualityDistribution(summary *ComponentSummary, styles printStyles) {
@@ -249,7 +255,10 @@ func printTopIssues(summary *ComponentSummary, styles printStyles) {
 		issues = append(issues, issueCount{issue, count})
 	}
 	sort.Slice(issues, func(i, j int) bool {
-		return issues[i].count > issues[j].count
+		if issues[i].count != issues[j].count {
+			return issues[i].count > issues[j].count
+		}
+		return issues[i].issue < issues[j].issue
 	})
 
 	for i, ic := range issues {
@@ -276,7 +285,7 @@ func printLowestScoring(summary *ComponentSummary, styles printStyles) {
 		if comp.Tier == "C" {
 			tierStyle = styles.tierC
 		}
-		truncated := comp.File
+		truncated := componentLabel(comp)
 		if len(truncated) > 35 {
 			truncated = "..." + truncated[len(truncated)-32:]
 		}
@@ -288,6 +297,15 @@ func printLowestScoring(summary *ComponentSummary, styles printStyles) {
 	}
 }
 
+// componentLabel names a component in the report: commands by the slash
+// command they are invoked as, other components by file path.
+func componentLabel(comp ScoredComponent) string {
+	if comp.Type == cue.TypeCommand {
+		return crossfile.SlashCommandName(comp.File)
+	}
+	return comp.File
+}
+
 func printReportFooter(styles printStyles) {
 	fmt.Println(styles.header.Render("╚═══════════════════════════════════════════════════════════╝"))
 	fmt.Println()
@@ -3,8 +3,8 @@ package cmd
 import (
 	"testing"
 
-	"github.com/dotcommander/cclint/internal/lint"
 	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
 	"github.com/dotcommander/cclint/internal/scoring"
 	"github.com/stretchr/testify/assert"
 )
@@ -416,6 +416,20 @@ func TestScoredComponentType(t *testing.T) {
 	assert.Equal(t, "A", comp.Tier)
 }
 
+func TestComponentLabel(t *testing.T) {
+	tests := []struct {
+		comp ScoredComponent
+		want string
+	}{
+		{ScoredComponent{File: ".claude/commands/git/commit.md", Type: "command"}, "/git:commit"},
+		{ScoredComponent{File: "commands/deploy.md", Type: "command"}, "/deploy"},
+		{ScoredComponent{File: "agents/reviewer.md", Type: "agent"}, "agents/reviewer.md"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, componentLabel(tt.comp))
+	}
+}
+
 func TestAggregateResults_NilQuality(t *testing.T) {
 	// Test that aggregateResults handles nil Quality gracefully
 	summary := &ComponentSummary{
@@ -590,7 +604,7 @@ func TestPrintSummaryReport_LongIssueMessages(t *testing.T) {
 		TopIssues: map[string]int{
 			"This is a very long issue message that exceeds the normal display width and should be truncated": 5,
 			"Another long issue that describes a complex problem with many details about what went wrong":     4,
-			"Short issue":                                                                    3,
+			"Short issue": 3,
 		},
 		LowestScoring: []ScoredComponent{},
 	}
@@ -609,8 +623,8 @@ func TestRenderBar_EdgeCases(t *testing.T) {
 		expected bool // true if should return non-empty
 	}{
 		{"count equals total", 10, 10, true},
-		{"count is zero", 0, 10, true},  // Still renders dim blocks
-		{"total is zero", 5, 0, false},  // Returns empty
+		{"count is zero", 0, 10, true}, // Still renders dim blocks
+		{"total is zero", 5, 0, false}, // Returns empty
 		{"large count", 100, 100, true},
 		{"fractional fill", 3, 10, true},
 	}
@@ -734,6 +748,7 @@ func TestPrintSummaryReport_MoreThan5LowestScoring(t *testing.T) {
 
 func TestSummaryCmdInit(t *testing.T) {
 	// Verify summary command is registered
+	rootCmd := newRootCmd(testInvocation())
 	found := false
 	for _, cmd := range rootCmd.Commands() {
 		if cmd.Name() == "summary" {
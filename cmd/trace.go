@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// traceTypes are the component types trace accepts.
+var traceTypes = []string{cue.TypeCommand, cue.TypeAgent, cue.TypeSkill}
+
+// newTraceCmd builds the trace command.
+func newTraceCmd(inv *invocation) *cobra.Command {
+	return &cobra.Command{
+		Use:   "trace <type>:<name>",
+		Short: "Show the delegation chain of a command, agent, or skill",
+		Long: `Trace what a component delegates to: the agents a command calls through
+Task(), and the skills each agent loads. Every node shows its file, line
+count, and an estimated token count for the whole file.
+
+With --format json the chain is printed as a JSON object with type, name,
+file, lines, tokens, and children fields. The same objects appear under
+"chains" in JSON lint reports run with --chains.
+
+EXAMPLES:
+
+  cclint trace command:deploy
+  cclint trace agent:code-reviewer --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCommand(func(args []string) (cmdResult, error) {
+			return runTrace(inv, args)
+		}),
+	}
+}
+
+func runTrace(inv *invocation, args []string) (cmdResult, error) {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return cmdResult{}, err
+	}
+	componentType, name, ok := strings.Cut(args[0], ":")
+	if !ok || name == "" || !slices.Contains(traceTypes, componentType) {
+		return cmdResult{}, usageErrorf("invalid component %q. Use type:name, where type is one of: %s", args[0], strings.Join(traceTypes, ", "))
+	}
+	if cfg.Format != "console" && cfg.Format != "json" {
+		return cmdResult{}, usageErrorf("trace supports --format console or json, not %q", cfg.Format)
+	}
+
+	root := cfg.Root
+	if root == "" {
+		if root, err = project.FindProjectRoot("."); err != nil {
+			return cmdResult{}, fmt.Errorf("error finding project root: %w", err)
+		}
+	}
+	files, err := cfg.NewFileDiscovery(root).WithExclude(cfg.Exclude).DiscoverFiles()
+	if err != nil {
+		return cmdResult{}, fmt.Errorf("error discovering files: %w", err)
+	}
+	link := crossfile.NewCrossFileValidator(files, root).WithExtraBuiltinAgents(cfg.ExtraBuiltinAgents).TraceChain(componentType, name)
+	if link == nil {
+		return cmdResult{}, usageErrorf("%s %q not found under %s", componentType, name, root)
+	}
+
+	if cfg.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return resultOK, enc.Encode(link)
+	}
+	fmt.Print(crossfile.FormatChain(link, ""))
+	return resultOK, nil
+}
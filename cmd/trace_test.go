@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTrace(t *testing.T) {
+	root := t.TempDir()
+	for path, contents := range map[string]string{
+		".claude/commands/deploy.md":      "---\ndescription: Deploy\n---\nTask(deployer)\n",
+		".claude/agents/deployer.md":      "---\nname: deployer\ndescription: Deploys\n---\nSkill: release\n",
+		".claude/skills/release/SKILL.md": "---\nname: release\ndescription: Release steps\n---\nbody\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(contents), 0o600))
+	}
+
+	inv := testInvocation()
+	inv.rootPath = root
+
+	tests := []struct {
+		name     string
+		arg      string
+		format   string
+		wantErr  bool
+		wantLeaf string
+	}{
+		{name: "command chain as json", arg: "command:deploy", format: "json", wantLeaf: "release"},
+		{name: "agent chain as console", arg: "agent:deployer", format: "console"},
+		{name: "missing component", arg: "command:nope", format: "console", wantErr: true},
+		{name: "malformed argument", arg: "deploy", format: "console", wantErr: true},
+		{name: "unsupported format", arg: "command:deploy", format: "tap", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv.outputFormat = tt.format
+			out, result, err := captureStdout(t, func() (cmdResult, error) { return runTrace(inv, []string{tt.arg}) })
+			if tt.wantErr {
+				assert.Equal(t, ExitUsage, exitCodeForError(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, resultOK, result)
+			if tt.format == "console" {
+				assert.Contains(t, out, "deployer (agent,")
+				return
+			}
+			var link crossfile.ChainLink
+			require.NoError(t, json.Unmarshal([]byte(out), &link))
+			require.Len(t, link.Children, 1)
+			require.Len(t, link.Children[0].Children, 1)
+			leaf := link.Children[0].Children[0]
+			assert.Equal(t, tt.wantLeaf, leaf.Name)
+			assert.Equal(t, ".claude/skills/release/SKILL.md", leaf.Path)
+			assert.Positive(t, leaf.Tokens)
+		})
+	}
+}
+
+// captureStdout runs fn with os.Stdout redirected and returns what it wrote.
+func captureStdout(t *testing.T, fn func() (cmdResult, error)) (string, cmdResult, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	old := os.Stdout
+	os.Stdout = w
+	result, runErr := fn()
+	os.Stdout = old
+	require.NoError(t, w.Close())
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out), result, runErr
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/tui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// newTUICmd builds the tui command.
+func newTUICmd(inv *invocation) *cobra.Command {
+	return &cobra.Command{
+		Use:   "tui",
+		Short: "Review findings interactively in a terminal UI",
+		Long: `Opens an interactive review of all findings, grouped by component type and file.
+
+Keys:
+  j/k, arrows   Move between findings (g/G jump to first/last)
+  s             Cycle the severity filter (all, error, warning, suggestion)
+  /             Filter by rule ID or message text (esc clears)
+  e, enter      Open the finding in $VISUAL or $EDITOR, then re-lint
+  f             Apply the finding's autofix, when it has one
+  F             Format the finding's file (as "cclint fmt -w")
+  r             Re-lint
+  q             Quit`,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return resultOK, runTUI(inv)
+		}),
+	}
+}
+
+func runTUI(inv *invocation) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return usageErrorf("cclint tui requires an interactive terminal")
+	}
+
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return err
+	}
+	// Progress and project warnings would draw over the UI.
+	cfg.Verbosity = config.VerbosityQuiet
+
+	return tui.Run(tui.Options{
+		Root: cfg.Root,
+		Lint: func() ([]*lint.LintSummary, error) {
+			result, err := runOrchestratedLint(inv, cfg, nil)
+			if err != nil {
+				return nil, err
+			}
+			return result.Summaries, nil
+		},
+		Format: formatOptions(cfg),
+		In:     os.Stdin,
+		Out:    os.Stdout,
+	})
+}
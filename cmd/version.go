@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/dotcommander/cclint/internal/buildinfo"
+	"github.com/spf13/cobra"
+)
+
+// versionReport is the JSON output of cclint version.
+type versionReport struct {
+	buildinfo.Info
+	Ruleset  buildinfo.Ruleset `json:"ruleset"`
+	Features map[string]bool   `json:"features"`
+}
+
+// newVersionCmd builds the version command.
+func newVersionCmd(inv *invocation) *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Show the cclint build, its rule set, and the enabled features",
+		Long: `Show which cclint produced a report: its version, the commit and date of
+the build, and the Go version it was built with. The ruleset hash is a
+digest of the embedded schemas and the rule catalog; it changes whenever
+a schema or a rule ID does, and JSON reports record it as run.rulesetHash.
+
+Features lists the optional checks and modes the configuration turns on,
+by config key. Record it next to CI artifacts so findings can be traced to
+exactly the rule set and configuration that produced them.
+
+With --format json the output is an object with version, commit,
+buildDate, modified, goVersion, ruleset (hash, schemas, rules, ruleCount),
+and features fields.
+
+EXAMPLES:
+
+  cclint version
+  cclint version --format json`,
+		Args: cobra.NoArgs,
+		RunE: runCommand(func([]string) (cmdResult, error) {
+			return resultOK, runVersion(inv)
+		}),
+	}
+}
+
+func runVersion(inv *invocation) error {
+	cfg, err := loadCLIConfig(inv)
+	if err != nil {
+		return err
+	}
+	if cfg.Format != "console" && cfg.Format != "json" {
+		return usageErrorf("version supports --format console or json, not %q", cfg.Format)
+	}
+
+	report := versionReport{
+		Info:     buildinfo.Read(Version, Commit, BuildDate),
+		Ruleset:  buildinfo.CurrentRuleset(),
+		Features: cfg.Features(),
+	}
+	report.Features["docsUrls"] = cfg.DocsURLTemplate != ""
+	if cfg.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printVersion(report)
+	return nil
+}
+
+func printVersion(report versionReport) {
+	styles := newPrintStyles()
+	fmt.Printf("cclint %s\n", report.Version)
+	if report.Commit != "" {
+		commit := report.Commit
+		if report.Modified {
+			commit += " (modified)"
+		}
+		fmt.Printf("  commit   %s\n", commit)
+	}
+	if report.BuildDate != "" {
+		fmt.Printf("  built    %s\n", report.BuildDate)
+	}
+	fmt.Printf("  go       %s\n", report.GoVersion)
+	fmt.Printf("  ruleset  %s %s\n", report.Ruleset.Hash,
+		styles.dim.Render(fmt.Sprintf("(%d schemas, %d rules)", len(report.Ruleset.Schemas), report.Ruleset.RuleCount)))
+
+	var enabled []string
+	for name, on := range report.Features {
+		if on {
+			enabled = append(enabled, name)
+		}
+	}
+	slices.Sort(enabled)
+	if len(enabled) == 0 {
+		fmt.Printf("  features %s\n", styles.dim.Render("none"))
+		return
+	}
+	fmt.Println("  features")
+	for _, name := range enabled {
+		fmt.Printf("    %s\n", name)
+	}
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/buildinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunVersion(t *testing.T) {
+	defer func(v, c, d string) { Version, Commit, BuildDate = v, c, d }(Version, Commit, BuildDate)
+	Version, Commit, BuildDate = "1.2.3", "abc123", "2026-10-16T00:00:00Z"
+
+	inv := testInvocation()
+	inv.rootPath = t.TempDir()
+
+	inv.outputFormat = "json"
+	out, result, err := captureStdout(t, func() (cmdResult, error) { return resultOK, runVersion(inv) })
+	require.NoError(t, err)
+	assert.Equal(t, resultOK, result)
+	var report versionReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.Equal(t, "1.2.3", report.Version)
+	assert.Equal(t, "abc123", report.Commit)
+	assert.Equal(t, "2026-10-16T00:00:00Z", report.BuildDate)
+	assert.Equal(t, buildinfo.CurrentRuleset().Hash, report.Ruleset.Hash)
+	assert.Contains(t, report.Features, "rules.webAccess")
+	assert.Contains(t, report.Features, "docsUrls")
+
+	inv.outputFormat = "console"
+	out, _, err = captureStdout(t, func() (cmdResult, error) { return resultOK, runVersion(inv) })
+	require.NoError(t, err)
+	assert.Contains(t, out, "cclint 1.2.3")
+	assert.Contains(t, out, "commit   abc123")
+	assert.Contains(t, out, report.Ruleset.Hash)
+
+	inv.outputFormat = "markdown"
+	err = runVersion(inv)
+	assert.Equal(t, ExitUsage, exitCodeForError(err))
+}
@@ -0,0 +1,126 @@
+// Package badge renders a status badge for a lint run, as a standalone SVG
+// in the flat shields.io style and as a shields.io endpoint file.
+package badge
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/scoring"
+)
+
+// Colors used by the badge, named as shields.io names them.
+var colors = map[string]string{
+	"brightgreen": "#4c1",
+	"green":       "#97ca00",
+	"yellow":      "#dfb317",
+	"orange":      "#fe7d37",
+	"red":         "#e05d44",
+	"lightgrey":   "#9f9f9f",
+}
+
+// Badge is a label and a message on a colored background.
+type Badge struct {
+	Label   string
+	Message string
+	Color   string // a shields.io color name, e.g. "brightgreen"
+}
+
+// FromScore returns a badge showing an average quality score and its tier,
+// colored by tier.
+func FromScore(label string, score int) Badge {
+	tier := scoring.TierFromScore(score)
+	color := map[string]string{"A": "brightgreen", "B": "green", "C": "yellow", "D": "orange"}[tier]
+	if color == "" {
+		color = "red"
+	}
+	return Badge{Label: label, Message: fmt.Sprintf("%d %s", score, tier), Color: color}
+}
+
+// FromCounts returns a pass/fail badge: "passing" when there are no errors
+// or warnings, otherwise their counts. Errors color it red, warnings yellow.
+func FromCounts(label string, errors, warnings int) Badge {
+	var parts []string
+	if errors > 0 {
+		parts = append(parts, plural(errors, "error"))
+	}
+	if warnings > 0 {
+		parts = append(parts, plural(warnings, "warning"))
+	}
+	switch {
+	case errors > 0:
+		return Badge{Label: label, Message: strings.Join(parts, ", "), Color: "red"}
+	case warnings > 0:
+		return Badge{Label: label, Message: strings.Join(parts, ", "), Color: "yellow"}
+	}
+	return Badge{Label: label, Message: "passing", Color: "brightgreen"}
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// endpoint is the shields.io endpoint schema
+// (https://shields.io/badges/endpoint-badge).
+type endpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// Endpoint returns the badge as a shields.io endpoint file.
+func (b Badge) Endpoint() ([]byte, error) {
+	data, err := json.MarshalIndent(endpoint{SchemaVersion: 1, Label: b.Label, Message: b.Message, Color: b.Color}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// SVG returns the badge as a flat-style SVG image. Text widths are
+// estimated from the character count, as no font metrics are available.
+func (b Badge) SVG() string {
+	fill, ok := colors[b.Color]
+	if !ok {
+		fill = colors["lightgrey"]
+	}
+	lw, mw := textWidth(b.Label), textWidth(b.Message)
+	w := lw + mw
+	label, message := html.EscapeString(b.Label), html.EscapeString(b.Message)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+"\n", w, label, message)
+	fmt.Fprintf(&sb, "  <title>%s: %s</title>\n", label, message)
+	sb.WriteString(`  <linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` + "\n")
+	fmt.Fprintf(&sb, `  <clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+"\n", w)
+	sb.WriteString(`  <g clip-path="url(#r)">` + "\n")
+	fmt.Fprintf(&sb, `    <rect width="%d" height="20" fill="#555"/>`+"\n", lw)
+	fmt.Fprintf(&sb, `    <rect x="%d" width="%d" height="20" fill="%s"/>`+"\n", lw, mw, fill)
+	fmt.Fprintf(&sb, `    <rect width="%d" height="20" fill="url(#s)"/>`+"\n", w)
+	sb.WriteString("  </g>\n")
+	sb.WriteString(`  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` + "\n")
+	writeText(&sb, lw/2, label)
+	writeText(&sb, lw+mw/2, message)
+	sb.WriteString("  </g>\n")
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// writeText writes text centred on x, with the one-pixel drop shadow of
+// the flat style.
+func writeText(sb *strings.Builder, x int, text string) {
+	fmt.Fprintf(sb, `    <text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text>`+"\n", x, text)
+	fmt.Fprintf(sb, `    <text x="%d" y="14">%s</text>`+"\n", x, text)
+}
+
+// textWidth estimates the width of one badge half: about 7px per
+// character of 11px Verdana, plus 5px padding on each side.
+func textWidth(s string) int {
+	return len([]rune(s))*7 + 10
+}
@@ -0,0 +1,78 @@
+package badge
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromScore(t *testing.T) {
+	tests := []struct {
+		score     int
+		wantMsg   string
+		wantColor string
+	}{
+		{score: 92, wantMsg: "92 A", wantColor: "brightgreen"},
+		{score: 70, wantMsg: "70 B", wantColor: "green"},
+		{score: 55, wantMsg: "55 C", wantColor: "yellow"},
+		{score: 30, wantMsg: "30 D", wantColor: "orange"},
+		{score: 12, wantMsg: "12 F", wantColor: "red"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantMsg, func(t *testing.T) {
+			b := FromScore("cclint", tt.score)
+			assert.Equal(t, Badge{Label: "cclint", Message: tt.wantMsg, Color: tt.wantColor}, b)
+		})
+	}
+}
+
+func TestFromCounts(t *testing.T) {
+	tests := []struct {
+		name      string
+		errors    int
+		warnings  int
+		wantMsg   string
+		wantColor string
+	}{
+		{name: "clean", wantMsg: "passing", wantColor: "brightgreen"},
+		{name: "warnings only", warnings: 1, wantMsg: "1 warning", wantColor: "yellow"},
+		{name: "errors and warnings", errors: 2, warnings: 3, wantMsg: "2 errors, 3 warnings", wantColor: "red"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := FromCounts("cclint", tt.errors, tt.warnings)
+			assert.Equal(t, tt.wantMsg, b.Message)
+			assert.Equal(t, tt.wantColor, b.Color)
+		})
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	data, err := Badge{Label: "cclint", Message: "92 A", Color: "brightgreen"}.Endpoint()
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, map[string]any{
+		"schemaVersion": float64(1),
+		"label":         "cclint",
+		"message":       "92 A",
+		"color":         "brightgreen",
+	}, got)
+}
+
+func TestSVG(t *testing.T) {
+	svg := Badge{Label: "claude <config>", Message: "passing", Color: "brightgreen"}.SVG()
+
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.Contains(t, svg, `fill="#4c1"`)
+	assert.Contains(t, svg, "claude &lt;config&gt;")
+	assert.NotContains(t, svg, "<config>")
+	assert.Contains(t, svg, `width="174"`) // (15+7)*7 + 2*10
+
+	unknown := Badge{Label: "cclint", Message: "?", Color: "teal"}.SVG()
+	assert.Contains(t, unknown, `fill="#9f9f9f"`)
+}
@@ -87,6 +87,35 @@ func (b *Baseline) IsKnown(issue cue.ValidationError) bool {
 	return b.index[fp]
 }
 
+// Add records issues as known.
+func (b *Baseline) Add(issues []cue.ValidationError) {
+	if b.index == nil {
+		b.index = make(map[string]bool, len(issues))
+	}
+	for _, issue := range issues {
+		fp := fingerprint(issue)
+		if !b.index[fp] {
+			b.index[fp] = true
+			b.Fingerprints = append(b.Fingerprints, fp)
+		}
+	}
+	sort.Strings(b.Fingerprints)
+}
+
+// Remove forgets issues, so they are no longer known.
+func (b *Baseline) Remove(issues []cue.ValidationError) {
+	for _, issue := range issues {
+		delete(b.index, fingerprint(issue))
+	}
+	kept := b.Fingerprints[:0]
+	for _, fp := range b.Fingerprints {
+		if b.index[fp] {
+			kept = append(kept, fp)
+		}
+	}
+	b.Fingerprints = kept
+}
+
 // fingerprint creates a stable hash of an issue for comparison
 // Uses: file path + source + normalized message pattern
 func fingerprint(issue cue.ValidationError) string {
@@ -102,18 +131,25 @@ func fingerprint(issue cue.ValidationError) string {
 	return fmt.Sprintf("%x", hash)
 }
 
+// Patterns used by normalizeMessage.
+var (
+	doubleQuotedPattern = regexp.MustCompile(`"[^"]+"`)
+	singleQuotedPattern = regexp.MustCompile(`(^|\s)'([^']+)'(\s|$)`)
+	numberPattern       = regexp.MustCompile(`\b\d+\b`)
+)
+
 // normalizeMessage normalizes error messages to create stable patterns
 // Replaces specific values with placeholders to match similar issues
 func normalizeMessage(msg string) string {
 	// Replace double-quoted strings with placeholder
-	msg = regexp.MustCompile(`"[^"]+"`).ReplaceAllString(msg, `"*"`)
+	msg = doubleQuotedPattern.ReplaceAllString(msg, `"*"`)
 
 	// Replace single-quoted strings with placeholder
 	// Match only when surrounded by whitespace/start/end to avoid contractions
-	msg = regexp.MustCompile(`(^|\s)'([^']+)'(\s|$)`).ReplaceAllString(msg, `$1'*'$3`)
+	msg = singleQuotedPattern.ReplaceAllString(msg, `$1'*'$3`)
 
 	// Replace numbers with placeholder (except version-like patterns)
-	msg = regexp.MustCompile(`\b\d+\b`).ReplaceAllString(msg, `N`)
+	msg = numberPattern.ReplaceAllString(msg, `N`)
 
 	// Normalize whitespace
 	msg = strings.Join(strings.Fields(msg), " ")
@@ -218,3 +218,19 @@ func TestLoadInvalidJSON(t *testing.T) {
 		t.Error("Expected error when loading invalid JSON")
 	}
 }
+
+func TestAddRemove(t *testing.T) {
+	a := cue.ValidationError{File: "agents/a.md", Message: "first", Source: "cclint-observation"}
+	b := cue.ValidationError{File: "agents/b.md", Message: "second", Source: "cclint-observation"}
+
+	baseline := CreateBaseline(nil)
+	baseline.Add([]cue.ValidationError{a, b, a})
+	if len(baseline.Fingerprints) != 2 || !baseline.IsKnown(a) || !baseline.IsKnown(b) {
+		t.Fatalf("after Add: %d fingerprints, known %v/%v", len(baseline.Fingerprints), baseline.IsKnown(a), baseline.IsKnown(b))
+	}
+
+	baseline.Remove([]cue.ValidationError{a})
+	if len(baseline.Fingerprints) != 1 || baseline.IsKnown(a) || !baseline.IsKnown(b) {
+		t.Errorf("after Remove: %d fingerprints, known %v/%v", len(baseline.Fingerprints), baseline.IsKnown(a), baseline.IsKnown(b))
+	}
+}
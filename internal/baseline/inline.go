@@ -0,0 +1,315 @@
+package baseline
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// Inline suppression comments. In frontmatter they are YAML comments, in the
+// markdown body HTML comments, so neither shows up in the rendered file:
+//
+//	# cclint-disable-next-line rule-id[, rule-id...]
+//	<!-- cclint-disable-next-line rule-id -->
+//	<!-- cclint-disable-file rule-id -->
+//
+// A next-line comment suppresses the rules on the next line that is not
+// itself a suppression comment; above a top-level frontmatter field it
+// covers the whole field, key and value lines, since cclint fmt may rewrite
+// how the value is laid out. A file comment suppresses the rules anywhere in
+// the file. Comments inside fenced code blocks are ignored.
+const (
+	DirectiveNextLine = "cclint-disable-next-line"
+	DirectiveFile     = "cclint-disable-file"
+)
+
+var (
+	// yamlDirectivePattern matches a suppression comment in frontmatter.
+	yamlDirectivePattern = regexp.MustCompile(`^#\s*cclint-disable-(next-line|file)\s+([A-Za-z0-9_,\s-]+)$`)
+
+	// htmlDirectivePattern matches a suppression comment in the body.
+	htmlDirectivePattern = regexp.MustCompile(`^<!--\s*cclint-disable-(next-line|file)\s+([A-Za-z0-9_,\s-]+?)\s*-->$`)
+
+	// blockScalarPattern matches a frontmatter line that opens a literal or
+	// folded block scalar, whose indented lines are content, not YAML.
+	blockScalarPattern = regexp.MustCompile(`:\s*[|>][-+0-9]*\s*(#.*)?$`)
+)
+
+// Inline is the set of inline suppression comments in one file.
+type Inline struct {
+	file     map[string]bool
+	nextLine map[int]map[string]bool // target line -> rules
+	lines    []int                   // lines holding suppression comments
+}
+
+// lineKind classifies a line for placing and reading suppression comments.
+type lineKind int
+
+const (
+	lineBody        lineKind = iota // markdown body
+	lineFrontmatter                 // YAML inside the frontmatter
+	lineBlockScalar                 // content of a frontmatter block scalar
+	lineDelimiter                   // a frontmatter --- delimiter
+	lineCode                        // inside, or opening or closing, a fenced code block
+)
+
+// classifyLines returns the kind of each line of lines, which hold a file
+// without its byte order mark.
+func classifyLines(lines []string) []lineKind {
+	kinds := make([]lineKind, len(lines))
+	inFrontmatter := len(lines) > 0 && strings.TrimSpace(lines[0]) == "---"
+	inFence := false
+	blockIndent := -1 // indentation of the key that opened a block scalar
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inFrontmatter && (i == 0 || trimmed == "---"):
+			kinds[i] = lineDelimiter
+			inFrontmatter = i == 0
+		case inFrontmatter:
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+			if blockIndent >= 0 && (trimmed == "" || indent > blockIndent) {
+				kinds[i] = lineBlockScalar
+				continue
+			}
+			blockIndent = -1
+			kinds[i] = lineFrontmatter
+			if blockScalarPattern.MatchString(trimmed) {
+				blockIndent = indent
+			}
+		case strings.HasPrefix(trimmed, "```"):
+			kinds[i] = lineCode
+			inFence = !inFence
+		case inFence:
+			kinds[i] = lineCode
+		default:
+			kinds[i] = lineBody
+		}
+	}
+	return kinds
+}
+
+// parseDirective returns the kind ("next-line" or "file") and rules of a
+// suppression comment on a line of the given kind, or ok false.
+func parseDirective(line string, kind lineKind) (directive string, rules []string, ok bool) {
+	pattern := htmlDirectivePattern
+	switch kind {
+	case lineFrontmatter:
+		pattern = yamlDirectivePattern
+	case lineBody:
+	default:
+		return "", nil, false
+	}
+	m := pattern.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return "", nil, false
+	}
+	rules = strings.FieldsFunc(m[2], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	return m[1], rules, len(rules) > 0
+}
+
+// ParseInline reads the inline suppression comments of a markdown file.
+func ParseInline(contents string) Inline {
+	in := Inline{file: make(map[string]bool), nextLine: make(map[int]map[string]bool)}
+	if !strings.Contains(contents, "cclint-disable-") {
+		return in
+	}
+	lines := strings.Split(strings.TrimPrefix(contents, textutil.BOM), "\n")
+	kinds := classifyLines(lines)
+	var pending []string
+	for i, line := range lines {
+		directive, rules, ok := parseDirective(line, kinds[i])
+		if !ok {
+			if len(pending) > 0 {
+				end := i + 1
+				if isFieldKey(line, kinds[i]) {
+					end = fieldEnd(lines, kinds, i)
+				}
+				for target := i + 1; target <= end; target++ {
+					if in.nextLine[target] == nil {
+						in.nextLine[target] = make(map[string]bool, len(pending))
+					}
+					for _, rule := range pending {
+						in.nextLine[target][rule] = true
+					}
+				}
+				pending = nil
+			}
+			continue
+		}
+		in.lines = append(in.lines, i+1)
+		if directive == "file" {
+			for _, rule := range rules {
+				in.file[rule] = true
+			}
+		} else {
+			pending = append(pending, rules...)
+		}
+	}
+	return in
+}
+
+// isFieldKey reports whether line, of the given kind, starts a top-level
+// frontmatter field.
+func isFieldKey(line string, kind lineKind) bool {
+	if kind != lineFrontmatter || line == "" || strings.ContainsRune(" \t#-", rune(line[0])) {
+		return false
+	}
+	return strings.TrimSpace(line) != ""
+}
+
+// fieldEnd returns the 1-based last line of the frontmatter field whose key
+// is on lines[i]: the lines up to the next field or the closing delimiter.
+func fieldEnd(lines []string, kinds []lineKind, i int) int {
+	end := i + 1
+	for j := i + 1; j < len(lines); j++ {
+		if kinds[j] != lineFrontmatter && kinds[j] != lineBlockScalar || isFieldKey(lines[j], kinds[j]) {
+			break
+		}
+		end = j + 1
+	}
+	return end
+}
+
+// fieldStart returns the index of the key line of the top-level frontmatter
+// field that holds lines[i], or -1 when lines[i] is in no field.
+func fieldStart(lines []string, kinds []lineKind, i int) int {
+	for ; i >= 0 && (kinds[i] == lineFrontmatter || kinds[i] == lineBlockScalar); i-- {
+		if isFieldKey(lines[i], kinds[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Suppresses reports whether a comment suppresses issue. Issues without a
+// rule ID cannot be suppressed inline.
+func (in Inline) Suppresses(issue cue.ValidationError) bool {
+	if issue.Rule == "" {
+		return false
+	}
+	return in.file[issue.Rule] || in.nextLine[issue.Line][issue.Rule]
+}
+
+// Len returns the number of suppression comments.
+func (in Inline) Len() int {
+	return len(in.lines)
+}
+
+// AddInline inserts suppression comments into a markdown file for issues:
+// a next-line comment above the issue's body line, or a YAML one above the
+// frontmatter field holding the issue's line, where one can go, otherwise a
+// file comment on the last line. cclint fmt moves YAML suppression comments
+// with their field. Lines inside fenced code blocks and tables take a file
+// comment because a comment there would change the content. Returns the new
+// contents and the issues without a rule ID, which cannot be suppressed
+// inline.
+func AddInline(contents string, issues []cue.ValidationError) (string, []cue.ValidationError) {
+	bom := strings.HasPrefix(contents, textutil.BOM)
+	lines := strings.Split(strings.TrimPrefix(contents, textutil.BOM), "\n")
+	kinds := classifyLines(lines)
+
+	fileRules := make(map[string]bool)
+	lineRules := make(map[int]map[string]bool)
+	var skipped []cue.ValidationError
+	for _, issue := range issues {
+		switch {
+		case issue.Rule == "":
+			skipped = append(skipped, issue)
+		case issue.Line < 1 || issue.Line > len(lines):
+			fileRules[issue.Rule] = true
+		default:
+			target := issue.Line
+			if kinds[target-1] == lineFrontmatter || kinds[target-1] == lineBlockScalar {
+				target = fieldStart(lines, kinds, target-1) + 1
+			}
+			if target < 1 || !commentable(lines[target-1], kinds[target-1]) {
+				fileRules[issue.Rule] = true
+				continue
+			}
+			if lineRules[target] == nil {
+				lineRules[target] = make(map[string]bool)
+			}
+			lineRules[target][issue.Rule] = true
+		}
+	}
+
+	// Without a final newline the file comment becomes the last line, so
+	// issues reported on the last line move onto it.
+	if last := len(lines); len(fileRules) > 0 && lines[last-1] != "" {
+		for rule := range lineRules[last] {
+			fileRules[rule] = true
+		}
+		delete(lineRules, last)
+	}
+
+	eol := ""
+	if strings.HasSuffix(lines[0], "\r") {
+		eol = "\r"
+	}
+	if len(fileRules) > 0 {
+		comment := directiveComment(DirectiveFile, fileRules)
+		if last := len(lines) - 1; lines[last] == "" {
+			lines = slices.Insert(lines, last, comment+eol) // keep the final newline
+		} else {
+			lines[last] += "\n" + comment
+		}
+	}
+	// Insert from the last line up so earlier line numbers stay valid.
+	targets := slices.Sorted(maps.Keys(lineRules))
+	for i := len(targets) - 1; i >= 0; i-- {
+		at := targets[i] - 1
+		line := lines[at]
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		comment := directiveComment(DirectiveNextLine, lineRules[targets[i]])
+		if kinds[at] == lineFrontmatter {
+			comment = yamlDirectiveComment(DirectiveNextLine, lineRules[targets[i]])
+		}
+		lines = slices.Insert(lines, at, indent+comment+eol)
+	}
+	out := strings.Join(lines, "\n")
+	if bom {
+		out = textutil.BOM + out
+	}
+	return out, skipped
+}
+
+// commentable reports whether a next-line comment can go above line: a
+// body line outside tables or a frontmatter field key.
+func commentable(line string, kind lineKind) bool {
+	return kind == lineBody && !strings.HasPrefix(strings.TrimSpace(line), "|") || isFieldKey(line, kind)
+}
+
+// directiveComment formats a suppression comment for the markdown body.
+func directiveComment(directive string, rules map[string]bool) string {
+	return fmt.Sprintf("<!-- %s %s -->", directive, strings.Join(slices.Sorted(maps.Keys(rules)), ", "))
+}
+
+// yamlDirectiveComment formats a suppression comment for the frontmatter.
+func yamlDirectiveComment(directive string, rules map[string]bool) string {
+	return fmt.Sprintf("# %s %s", directive, strings.Join(slices.Sorted(maps.Keys(rules)), ", "))
+}
+
+// RemoveInline deletes every inline suppression comment from contents and
+// returns the new contents and the number removed.
+func RemoveInline(contents string) (string, int) {
+	in := ParseInline(contents)
+	if in.Len() == 0 {
+		return contents, 0
+	}
+	bom := strings.HasPrefix(contents, textutil.BOM)
+	lines := strings.Split(strings.TrimPrefix(contents, textutil.BOM), "\n")
+	for i := len(in.lines) - 1; i >= 0; i-- {
+		lines = slices.Delete(lines, in.lines[i]-1, in.lines[i])
+	}
+	out := strings.Join(lines, "\n")
+	if bom {
+		out = textutil.BOM + out
+	}
+	return out, in.Len()
+}
@@ -0,0 +1,184 @@
+package baseline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestParseInline(t *testing.T) {
+	contents := strings.Join([]string{
+		"---",
+		"name: a",
+		"# cclint-disable-next-line rule-fm",
+		"description: d",
+		"---",
+		"<!-- cclint-disable-next-line rule-a, rule-b -->",
+		"<!-- cclint-disable-next-line rule-c -->",
+		"Body line.",
+		"```",
+		"<!-- cclint-disable-file rule-code -->",
+		"```",
+		"<!-- cclint-disable-file rule-file -->",
+		"# cclint-disable-file rule-heading",
+		"",
+	}, "\n")
+	in := ParseInline(contents)
+
+	tests := []struct {
+		rule string
+		line int
+		want bool
+	}{
+		{"rule-fm", 4, true},
+		{"rule-a", 8, true},
+		{"rule-b", 8, true},
+		{"rule-c", 8, true},
+		{"rule-a", 9, false},
+		{"rule-file", 1, true},
+		{"rule-file", 0, true},
+		{"rule-code", 1, false},    // inside a code block
+		{"rule-heading", 1, false}, // a heading in the body, not a comment
+		{"", 8, false},
+	}
+	for _, tt := range tests {
+		issue := cue.ValidationError{Rule: tt.rule, Line: tt.line}
+		if got := in.Suppresses(issue); got != tt.want {
+			t.Errorf("Suppresses(%s at line %d) = %v, want %v", tt.rule, tt.line, got, tt.want)
+		}
+	}
+	if in.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", in.Len())
+	}
+}
+
+func TestParseInlineFieldScope(t *testing.T) {
+	contents := strings.Join([]string{
+		"---",
+		"# cclint-disable-next-line rule-tools",
+		"tools:",
+		"  - Bash",
+		"- Read",
+		"model: x",
+		"---",
+		"Body.",
+	}, "\n")
+	in := ParseInline(contents)
+	for line, want := range map[int]bool{3: true, 4: true, 5: true, 6: false, 8: false} {
+		if got := in.Suppresses(cue.ValidationError{Rule: "rule-tools", Line: line}); got != want {
+			t.Errorf("Suppresses(rule-tools at line %d) = %v, want %v", line, got, want)
+		}
+	}
+}
+
+func TestAddInline(t *testing.T) {
+	contents := strings.Join([]string{
+		"---",
+		"name: a",
+		"description: |",
+		"  long text",
+		"---",
+		"# Title",
+		"",
+		"  - item",
+		"",
+		"| a | b |",
+		"```",
+		"code",
+		"```",
+		"",
+	}, "\n")
+	issues := []cue.ValidationError{
+		{Rule: "body-rule", Line: 8},
+		{Rule: "other-rule", Line: 8},
+		{Rule: "fm-rule", Line: 2},
+		{Rule: "scalar-rule", Line: 4},
+		{Rule: "table-rule", Line: 10},
+		{Rule: "code-rule", Line: 12},
+		{Rule: "file-rule"},
+		{Message: "no rule ID", Line: 6},
+	}
+	got, skipped := AddInline(contents, issues)
+
+	want := strings.Join([]string{
+		"---",
+		"# cclint-disable-next-line fm-rule",
+		"name: a",
+		"# cclint-disable-next-line scalar-rule",
+		"description: |",
+		"  long text",
+		"---",
+		"# Title",
+		"",
+		"  <!-- cclint-disable-next-line body-rule, other-rule -->",
+		"  - item",
+		"",
+		"| a | b |",
+		"```",
+		"code",
+		"```",
+		"<!-- cclint-disable-file code-rule, file-rule, table-rule -->",
+		"",
+	}, "\n")
+	if got != want {
+		t.Errorf("AddInline() =\n%s\nwant\n%s", got, want)
+	}
+	if len(skipped) != 1 || skipped[0].Message != "no rule ID" {
+		t.Errorf("skipped = %v, want the issue without a rule ID", skipped)
+	}
+
+	// Every issue with a rule ID is suppressed once its line has moved.
+	in := ParseInline(got)
+	for _, issue := range []cue.ValidationError{{Rule: "body-rule", Line: 11}, {Rule: "fm-rule", Line: 3}, {Rule: "scalar-rule", Line: 6}, {Rule: "file-rule"}} {
+		if !in.Suppresses(issue) {
+			t.Errorf("%s at line %d is not suppressed", issue.Rule, issue.Line)
+		}
+	}
+}
+
+func TestAddInlinePreservesEncoding(t *testing.T) {
+	tests := []struct {
+		name, contents, want string
+	}{
+		{
+			name:     "CRLF and BOM",
+			contents: "\uFEFFone\r\ntwo\r\n",
+			want:     "\uFEFF<!-- cclint-disable-next-line r -->\r\none\r\ntwo\r\n<!-- cclint-disable-file f -->\r\n",
+		},
+		{
+			name:     "no final newline",
+			contents: "one\ntwo",
+			want:     "<!-- cclint-disable-next-line r -->\none\ntwo\n<!-- cclint-disable-file f -->",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := AddInline(tt.contents, []cue.ValidationError{{Rule: "r", Line: 1}, {Rule: "f"}})
+			if got != tt.want {
+				t.Errorf("AddInline() = %q, want %q", got, tt.want)
+			}
+			back, removed := RemoveInline(got)
+			if back != tt.contents || removed != 2 {
+				t.Errorf("RemoveInline() = %q, %d, want %q, 2", back, removed, tt.contents)
+			}
+		})
+	}
+}
+
+func TestAddInlineLastLineWithoutNewline(t *testing.T) {
+	// The file comment becomes the last line, where the issue then lands.
+	got, _ := AddInline("one\ntwo", []cue.ValidationError{{Rule: "r", Line: 2}, {Rule: "f"}})
+	if want := "one\ntwo\n<!-- cclint-disable-file f, r -->"; got != want {
+		t.Errorf("AddInline() = %q, want %q", got, want)
+	}
+}
+
+func TestRemoveInlineLeavesOtherComments(t *testing.T) {
+	contents := "---\n# keep me\n# cclint-disable-next-line r\nname: a\n---\n<!-- note -->\n```\n<!-- cclint-disable-file r -->\n```\n"
+	got, removed := RemoveInline(contents)
+	want := "---\n# keep me\nname: a\n---\n<!-- note -->\n```\n<!-- cclint-disable-file r -->\n```\n"
+	if got != want || removed != 1 {
+		t.Errorf("RemoveInline() = %q, %d, want %q, 1", got, removed, want)
+	}
+}
@@ -0,0 +1,116 @@
+// Package buildinfo identifies the cclint build behind a report: its
+// version, the commit it was built from, and the rule set it checks. All of
+// it comes from the binary, so it needs no network, and two builds of the
+// same commit describe themselves the same way.
+package buildinfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/types"
+)
+
+// Info describes a cclint build.
+type Info struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+	// BuildDate is the build date set at build time or, failing that, the
+	// commit time Go records, which rebuilds of the commit share.
+	BuildDate string `json:"buildDate,omitempty"`
+	// Modified is set when Go recorded uncommitted changes in the tree
+	// the binary was built from.
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Read returns the build's Info. version, commit, and date are the values
+// set at build time via ldflags; empty ones, and a "dev" version, fall back
+// to what Go embeds: the module version for "go install" builds, and the
+// vcs.revision and vcs.time of builds from a checkout.
+func Read(version, commit, date string) Info {
+	info := Info{Version: version, Commit: commit, BuildDate: date, GoVersion: runtime.Version()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if (version == "" || version == "dev") && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if date == "" {
+					info.BuildDate = s.Value
+				}
+			case "vcs.modified":
+				info.Modified = commit == "" && s.Value == "true"
+			}
+		}
+	}
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	return info
+}
+
+// Ruleset identifies the rules a build checks with: a digest of each
+// embedded schema and of the rule catalog, and one digest over them all
+// that changes whenever any of them does.
+type Ruleset struct {
+	Hash      string            `json:"hash"`
+	Schemas   map[string]string `json:"schemas"`
+	Rules     string            `json:"rules"`
+	RuleCount int               `json:"ruleCount"`
+}
+
+// CurrentRuleset returns the Ruleset of this build. It is computed once.
+var CurrentRuleset = sync.OnceValue(func() Ruleset {
+	sources, err := cue.SchemaSources()
+	if err != nil {
+		panic(err) // the schemas are embedded; this is a build defect
+	}
+	return newRuleset(sources, types.RuleCategories)
+})
+
+func newRuleset(sources map[string][]byte, rules map[string][]string) Ruleset {
+	r := Ruleset{Schemas: make(map[string]string, len(sources)), RuleCount: len(rules)}
+
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	var catalog strings.Builder
+	for _, id := range ids {
+		fmt.Fprintf(&catalog, "%s %s\n", id, strings.Join(rules[id], ","))
+	}
+	r.Rules = digest([]byte(catalog.String()))
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	all := sha256.New()
+	for _, name := range names {
+		r.Schemas[name] = digest(sources[name])
+		fmt.Fprintf(all, "%s %s\n", name, r.Schemas[name])
+	}
+	fmt.Fprintf(all, "rules %s\n", r.Rules)
+	r.Hash = "sha256:" + hex.EncodeToString(all.Sum(nil))
+	return r
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,51 @@
+package buildinfo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewRuleset(t *testing.T) {
+	sources := map[string][]byte{"agent.cue": []byte("#Agent: {}"), "skill.cue": []byte("#Skill: {}")}
+	rules := map[string][]string{"agent-a": {"structure"}, "skill-b": {"content"}}
+
+	r := newRuleset(sources, rules)
+	if !strings.HasPrefix(r.Hash, "sha256:") || len(r.Schemas) != 2 || r.RuleCount != 2 {
+		t.Fatalf("newRuleset() = %+v", r)
+	}
+	if again := newRuleset(sources, rules); again.Hash != r.Hash {
+		t.Errorf("hash not deterministic: %s != %s", again.Hash, r.Hash)
+	}
+
+	changedSchema := map[string][]byte{"agent.cue": []byte("#Agent: {name: string}"), "skill.cue": sources["skill.cue"]}
+	if got := newRuleset(changedSchema, rules); got.Hash == r.Hash || got.Rules != r.Rules {
+		t.Errorf("changing a schema: hash %s, rules %s; want a new hash and the same rules digest", got.Hash, got.Rules)
+	}
+	changedRules := map[string][]string{"agent-a": {"structure"}, "skill-b": {"security"}}
+	if got := newRuleset(sources, changedRules); got.Hash == r.Hash || got.Rules == r.Rules {
+		t.Errorf("changing a rule category left the hash at %s", got.Hash)
+	}
+}
+
+func TestCurrentRuleset(t *testing.T) {
+	r := CurrentRuleset()
+	if r.RuleCount == 0 || len(r.Schemas) == 0 {
+		t.Errorf("CurrentRuleset() = %+v, want the embedded schemas and rules", r)
+	}
+	if _, ok := r.Schemas["agent.cue"]; !ok {
+		t.Errorf("schemas = %v, want agent.cue", r.Schemas)
+	}
+}
+
+func TestRead(t *testing.T) {
+	info := Read("1.2.3", "abc123", "2026-01-02T03:04:05Z")
+	if info.Version != "1.2.3" || info.Commit != "abc123" || info.BuildDate != "2026-01-02T03:04:05Z" || info.Modified {
+		t.Errorf("Read() = %+v, want the ldflags values", info)
+	}
+	if info.GoVersion == "" {
+		t.Error("Read() left GoVersion empty")
+	}
+	if info := Read("", "", ""); info.Version == "" {
+		t.Error("Read() with no values left Version empty")
+	}
+}
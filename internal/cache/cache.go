@@ -0,0 +1,130 @@
+// Package cache stores remote data cclint fetches, such as upstream
+// documentation pages, in a shared directory so repeated runs skip the
+// network while the data is fresh. In offline mode only cached data is
+// used; callers report work that needed the network as skipped.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DirEnv overrides the cache directory.
+const DirEnv = "CCLINT_CACHE_DIR"
+
+// ErrOffline is returned in offline mode when nothing is cached for a key.
+var ErrOffline = errors.New("offline and not cached")
+
+// Cache is a directory of fetched entries keyed by an arbitrary string,
+// usually a URL. An entry's age is its file's modification time.
+type Cache struct {
+	dir     string
+	offline bool
+	now     func() time.Time
+}
+
+// DefaultDir returns $CCLINT_CACHE_DIR, or "cclint" under the user cache
+// directory.
+func DefaultDir() (string, error) {
+	if dir := os.Getenv(DirEnv); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate cache directory: %w", err)
+	}
+	return filepath.Join(base, "cclint"), nil
+}
+
+// New creates a Cache in dir. The directory is created on first write.
+func New(dir string) *Cache {
+	return &Cache{dir: dir, now: time.Now}
+}
+
+// WithOffline makes Load use cached entries only, whatever their age.
+func (c *Cache) WithOffline(offline bool) *Cache {
+	c.offline = offline
+	return c
+}
+
+// Offline reports whether the cache is in offline mode.
+func (c *Cache) Offline() bool {
+	return c != nil && c.offline
+}
+
+// Load returns the entry for key when it is younger than ttl, and otherwise
+// calls fetch and stores its result. Offline, any cached entry is returned
+// and ErrOffline is returned when there is none. A nil Cache always
+// fetches.
+func (c *Cache) Load(key string, ttl time.Duration, fetch func() ([]byte, error)) ([]byte, error) {
+	if c == nil {
+		return fetch()
+	}
+	data, age, cached := c.get(key)
+	switch {
+	case c.offline && cached:
+		return data, nil
+	case c.offline:
+		return nil, fmt.Errorf("%s: %w", key, ErrOffline)
+	case cached && age < ttl:
+		return data, nil
+	}
+
+	fresh, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.put(key, fresh); err != nil {
+		return nil, err
+	}
+	return fresh, nil
+}
+
+// get reads the entry for key and its age.
+func (c *Cache) get(key string) ([]byte, time.Duration, bool) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, false
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is derived from a hash under the cache directory
+	if err != nil {
+		return nil, 0, false
+	}
+	return data, c.now().Sub(info.ModTime()), true
+}
+
+// put writes the entry for key through a temporary file, so concurrent
+// runs never read a partial entry.
+func (c *Cache) put(key string, data []byte) error {
+	if err := os.MkdirAll(c.dir, 0o750); err != nil {
+		return fmt.Errorf("cannot create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("cannot write cache entry: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("cannot write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cannot write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("cannot write cache entry: %w", err)
+	}
+	return nil
+}
+
+// path names the file holding key's entry.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16]))
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	fetched := func(body string) func() ([]byte, error) {
+		return func() ([]byte, error) { return []byte(body), nil }
+	}
+	failing := func() ([]byte, error) { return nil, errors.New("network down") }
+
+	tests := []struct {
+		name    string
+		seed    string        // entry cached before Load, "" for none
+		age     time.Duration // age of the seeded entry
+		offline bool
+		fetch   func() ([]byte, error)
+		want    string
+		wantErr error
+	}{
+		{name: "miss fetches", fetch: fetched("new"), want: "new"},
+		{name: "fresh entry skips fetch", seed: "old", age: time.Minute, fetch: failing, want: "old"},
+		{name: "stale entry refetches", seed: "old", age: 2 * time.Hour, fetch: fetched("new"), want: "new"},
+		{name: "fetch error is returned", seed: "old", age: 2 * time.Hour, fetch: failing, wantErr: errors.New("network down")},
+		{name: "offline uses a stale entry", seed: "old", age: 48 * time.Hour, offline: true, fetch: failing, want: "old"},
+		{name: "offline miss", offline: true, fetch: fetched("new"), wantErr: ErrOffline},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(t.TempDir()).WithOffline(tt.offline)
+			if tt.seed != "" {
+				if err := c.put("key", []byte(tt.seed)); err != nil {
+					t.Fatal(err)
+				}
+				c.now = func() time.Time { return time.Now().Add(tt.age) }
+			}
+
+			got, err := c.Load("key", time.Hour, tt.fetch)
+			switch {
+			case tt.wantErr == ErrOffline:
+				if !errors.Is(err, ErrOffline) {
+					t.Fatalf("Load() error = %v, want ErrOffline", err)
+				}
+			case tt.wantErr != nil:
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("Load() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("Load() error = %v", err)
+			case string(got) != tt.want:
+				t.Errorf("Load() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadStoresFetched(t *testing.T) {
+	c := New(t.TempDir())
+	if _, err := c.Load("key", time.Hour, func() ([]byte, error) { return []byte("body"), nil }); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.WithOffline(true).Load("key", time.Hour, nil)
+	if err != nil || string(got) != "body" {
+		t.Errorf("Load() after a fetch = %q, %v; want the stored body", got, err)
+	}
+}
+
+func TestNilCacheFetches(t *testing.T) {
+	var c *Cache
+	got, err := c.Load("key", time.Hour, func() ([]byte, error) { return []byte("body"), nil })
+	if err != nil || string(got) != "body" {
+		t.Errorf("nil Cache Load() = %q, %v", got, err)
+	}
+	if c.Offline() {
+		t.Error("nil Cache reports offline")
+	}
+}
+
+func TestDefaultDir(t *testing.T) {
+	t.Setenv(DirEnv, "/tmp/cclint-cache")
+	if dir, err := DefaultDir(); err != nil || dir != "/tmp/cclint-cache" {
+		t.Errorf("DefaultDir() = %q, %v", dir, err)
+	}
+}
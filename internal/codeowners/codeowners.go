@@ -0,0 +1,133 @@
+// Package codeowners reads CODEOWNERS files and resolves the owners of a
+// path the way GitHub does: the last matching pattern wins.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// Locations are the places GitHub looks for CODEOWNERS, relative to the
+// repository root, in the order it checks them.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	Path  string // relative to the root it was loaded from
+	rules []rule
+}
+
+type rule struct {
+	patterns []string // doublestar patterns; any match selects the rule
+	owners   []string
+}
+
+// Find returns the relative path of the CODEOWNERS file under root, or ""
+// when there is none.
+func Find(root string) string {
+	for _, loc := range Locations {
+		if info, err := os.Stat(filepath.Join(root, loc)); err == nil && !info.IsDir() {
+			return loc
+		}
+	}
+	return ""
+}
+
+// Load reads the CODEOWNERS file under root. It returns nil without an
+// error when the project has none.
+func Load(root string) (*Ruleset, error) {
+	rel := Find(root)
+	if rel == "" {
+		return nil, nil
+	}
+	f, err := os.Open(filepath.Join(root, rel)) //nolint:gosec // G304: CODEOWNERS of the linted project
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(rel, f)
+}
+
+// Parse reads CODEOWNERS rules: a pattern followed by owners per line, with
+// # comments. A pattern without owners leaves matching paths unowned.
+// GitLab section headers ([Section] @owner) are skipped.
+func Parse(path string, r io.Reader) (*Ruleset, error) {
+	rs := &Ruleset{Path: path}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "[") || strings.HasPrefix(fields[0], "^[") {
+			continue
+		}
+		patterns, err := translate(strings.ReplaceAll(fields[0], `\#`, "#"))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		r := rule{patterns: patterns}
+		if len(fields) > 1 {
+			r.owners = fields[1:]
+		}
+		rs.rules = append(rs.rules, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rs, nil
+}
+
+// stripComment drops a # comment; \# is a literal #.
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] != '\\') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// translate turns a gitignore-style CODEOWNERS pattern into doublestar
+// patterns over slash-separated relative paths. A pattern with a slash
+// before its end is anchored at the root; one without matches at any
+// depth. A match on a directory covers everything beneath it, and a
+// trailing slash matches directories only.
+func translate(pattern string) ([]string, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.TrimSuffix(pattern, "/")
+	if !strings.Contains(p, "/") {
+		p = "**/" + p
+	}
+	p = strings.TrimPrefix(p, "/")
+	if p == "" || p == "**/" {
+		p = "**" // "/" or "*/": everything
+	}
+	if !doublestar.ValidatePattern(p) {
+		return nil, fmt.Errorf("invalid pattern %q", pattern)
+	}
+	if dirOnly {
+		return []string{p + "/**"}, nil
+	}
+	return []string{p, p + "/**"}, nil
+}
+
+// Owners returns the owners of relPath, a path relative to the repository
+// root, or nil when no rule assigns any.
+func (rs *Ruleset) Owners(relPath string) []string {
+	if rs == nil {
+		return nil
+	}
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "./")
+	for i := len(rs.rules) - 1; i >= 0; i-- {
+		for _, p := range rs.rules[i].patterns {
+			if ok, _ := doublestar.Match(p, relPath); ok {
+				return rs.rules[i].owners
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwners(t *testing.T) {
+	rs, err := Parse("CODEOWNERS", strings.NewReader(`
+# Default owners
+*                       @org/platform
+*.md                    @org/docs     # docs team
+/.claude/agents/        @org/agents @alice
+.claude/skills/deploy   @org/release
+docs/**/guide.md        @org/guides
+/.claude/agents/legacy.md
+\#weird                 @bob
+[Security] @org/security
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@org/platform"}},
+		{"README.md", []string{"@org/docs"}},
+		{"nested/dir/NOTES.md", []string{"@org/docs"}},
+		{".claude/agents/reviewer.md", []string{"@org/agents", "@alice"}},
+		{".claude/agents/sub/helper.md", []string{"@org/agents", "@alice"}},
+		{"pkg/.claude/agents/x.md", []string{"@org/docs"}},
+		{".claude/skills/deploy/SKILL.md", []string{"@org/release"}},
+		{".claude/skills/other/SKILL.md", []string{"@org/docs"}},
+		{"docs/a/b/guide.md", []string{"@org/guides"}},
+		{".claude/agents/legacy.md", nil},
+		{"#weird", []string{"@bob"}},
+		{"./main.go", []string{"@org/platform"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, rs.Owners(tt.path))
+		})
+	}
+}
+
+func TestOwnersDirectoryOnly(t *testing.T) {
+	rs, err := Parse("CODEOWNERS", strings.NewReader("build/ @ci\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@ci"}, rs.Owners("build/out.txt"))
+	assert.Equal(t, []string{"@ci"}, rs.Owners("src/build/out.txt"))
+	assert.Nil(t, rs.Owners("build.txt"))
+}
+
+func TestParseInvalidPattern(t *testing.T) {
+	_, err := Parse("CODEOWNERS", strings.NewReader("* @a\nsrc/a[b @b\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CODEOWNERS:2")
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	rs, err := Load(root)
+	require.NoError(t, err)
+	assert.Nil(t, rs)
+	assert.Nil(t, rs.Owners("anything"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "CODEOWNERS"), []byte("* @root\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte("* @github\n"), 0o600))
+
+	rs, err = Load(root)
+	require.NoError(t, err)
+	assert.Equal(t, ".github/CODEOWNERS", rs.Path)
+	assert.Equal(t, []string{"@github"}, rs.Owners("x.md"))
+}
@@ -1,37 +1,290 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/migrate"
+	"github.com/dotcommander/cclint/internal/types"
 	"github.com/spf13/viper"
 )
 
 // Config represents the cclint configuration
 type Config struct {
-	Root             string       `mapstructure:"root"`
-	Version          string       `mapstructure:"-"`
-	Exclude          []string     `mapstructure:"exclude"`
-	FollowSymlinks   bool         `mapstructure:"followSymlinks"`
-	Format           string       `mapstructure:"format"`
-	Output           string       `mapstructure:"output"`
-	FailOn           string       `mapstructure:"failOn"`
-	Quiet            bool         `mapstructure:"quiet"`
-	Verbose          bool         `mapstructure:"verbose"`
-	ShowScores       bool         `mapstructure:"showScores"`
-	ShowImprovements bool         `mapstructure:"showImprovements"`
-	NoCycleCheck     bool         `mapstructure:"no-cycle-check"`
-	Rules            RulesConfig  `mapstructure:"rules"`
-	Schemas          SchemaConfig `mapstructure:"schemas"`
-	Concurrency      int          `mapstructure:"concurrency"`
-	Parallel         bool         `mapstructure:"parallel"`
+	Root      string `mapstructure:"root"`
+	Version   string `mapstructure:"-"`
+	Commit    string `mapstructure:"-"`
+	BuildDate string `mapstructure:"-"`
+	// DocsURLTemplate is the rule documentation URL template set at build
+	// time; see lint.RuleDocsURL.
+	DocsURLTemplate  string                  `mapstructure:"-"`
+	Exclude          []string                `mapstructure:"exclude"`
+	Symlinks         discovery.SymlinkPolicy `mapstructure:"symlinks"`
+	Format           string                  `mapstructure:"format"`
+	Output           string                  `mapstructure:"output"`
+	GroupBy          string                  `mapstructure:"groupBy"`
+	MaxIssuesPerFile int                     `mapstructure:"maxIssuesPerFile"`
+	Color            string                  `mapstructure:"color"`
+	Snippets         bool                    `mapstructure:"snippets"`
+	FailOn           string                  `mapstructure:"failOn"`
+	Verbosity        Verbosity               `mapstructure:"verbosity"`
+	Show             []string                `mapstructure:"show"`
+	ShowScores       bool                    `mapstructure:"showScores"`
+	ShowImprovements bool                    `mapstructure:"showImprovements"`
+	NoCycleCheck     bool                    `mapstructure:"no-cycle-check"`
+	Rules            RulesConfig             `mapstructure:"rules"`
+	Overrides        Overrides               `mapstructure:"overrides"`
+	Gates            Gates                   `mapstructure:"gates"`
+	Schemas          SchemaConfig            `mapstructure:"schemas"`
+	SchemaVersion    int                     `mapstructure:"schemaVersion"` // frontmatter convention; 0 = current
+	Concurrency      int                     `mapstructure:"concurrency"`
+	Parallel         bool                    `mapstructure:"parallel"`
+	FileTypes        FileTypesConfig         `mapstructure:"fileTypes"`
+	TypeOverrides    TypeOverrides           `mapstructure:"typeOverrides"`
+	LineEndings      string                  `mapstructure:"lineEndings"`
+	Fmt              FmtConfig               `mapstructure:"fmt"`
+	// ExtraBuiltinAgents and ExtraTools extend the built-in agent and tool
+	// catalogs, for names a newer Claude Code release knows.
+	ExtraBuiltinAgents []string `mapstructure:"extraBuiltinAgents"`
+	ExtraTools         []string `mapstructure:"extraTools"`
+	// Untrusted audits the tree as third-party content (--untrusted): hook
+	// commands that reach the network or write outside the plugin root are
+	// reported, a plugin's hooks files are scanned, and security findings
+	// become errors.
+	Untrusted bool `mapstructure:"untrusted"`
+	// DeprecatedFields are the frontmatter fields this project has retired;
+	// read by hand since an entry is a message or an object.
+	DeprecatedFields DeprecatedFields `mapstructure:"-"`
 }
 
 // RulesConfig contains rule configuration
 type RulesConfig struct {
 	Strict bool `mapstructure:"strict"`
+	// WarnUnknownKeys reports frontmatter keys outside the component schema
+	// as warnings instead of suggestions, so typos like "descriptoin" can
+	// fail a --fail-on warning build.
+	WarnUnknownKeys bool `mapstructure:"warnUnknownKeys"`
+	// StrictSettingsKeys reports top-level settings.json keys Claude Code
+	// does not read as errors instead of suggestions, for environments
+	// where a misspelled key must not silently do nothing.
+	StrictSettingsKeys bool `mapstructure:"strictSettingsKeys"`
+	// ContextBudgets overrides the estimated token budget for an agent's
+	// body plus preloaded skills, keyed by model tier: haiku, sonnet, opus,
+	// or default (inherit/unspecified). Unset tiers keep built-in budgets.
+	ContextBudgets map[string]int `mapstructure:"contextBudgets"`
+	// SkillBodyMaxLines is the SKILL.md body length, in lines, past which
+	// detail should move into reference files. 0 uses the built-in limit.
+	SkillBodyMaxLines int `mapstructure:"skillBodyMaxLines"`
+	// HookTimeoutMax is the hook timeout, in seconds, past which a hook is
+	// flagged as likely to stall the session. 0 uses the built-in limit.
+	HookTimeoutMax int `mapstructure:"hookTimeoutMax"`
+	// LineLengthMax is the line length, in characters, past which a
+	// markdown component line is reported as minified or pasted content.
+	// 0 uses the default.
+	LineLengthMax int `mapstructure:"lineLengthMax"`
+	// Base64LengthMax is the length, in characters, past which embedded
+	// base64 data in a markdown component is reported. 0 uses the default.
+	Base64LengthMax int `mapstructure:"base64LengthMax"`
+	// SchemaMaxBytes and SchemaMaxDepth bound the frontmatter or settings
+	// data CUE schema validation evaluates; larger or deeper input is
+	// skipped with a warning. 0 uses the default.
+	SchemaMaxBytes int `mapstructure:"schemaMaxBytes"`
+	SchemaMaxDepth int `mapstructure:"schemaMaxDepth"`
+	// SchemaTimeout is how long, in seconds, one schema validation may run
+	// before it is abandoned with a warning. 0 uses the default.
+	SchemaTimeout int `mapstructure:"schemaTimeout"`
+	// Readability sets how strictly agent and skill descriptions are held
+	// to the readability check: off, lenient, normal, or strict. Empty
+	// means normal.
+	Readability string `mapstructure:"readability"`
+	// TemplateVariables lists intentional template variables ($NAME or
+	// {{name}}) that the unfilled-placeholder check should accept, on top of
+	// the built-in ones such as $ARGUMENTS and $CLAUDE_PROJECT_DIR.
+	TemplateVariables []string `mapstructure:"templateVariables"`
+	// Terminology configures the optional spelling and terminology check
+	// for component prose. Off by default.
+	Terminology TerminologyConfig `mapstructure:"terminology"`
+	// Models adds to or overrides the built-in model catalog, which marks
+	// superseded model IDs as deprecated or retired.
+	Models []ModelConfig `mapstructure:"models"`
+	// JSONC lets settings files use // and /* */ comments and trailing
+	// commas. Off by default, since settings files are JSON.
+	JSONC bool `mapstructure:"jsonc"`
+	// SettingsExtends is the settings key, such as "extends", that names
+	// the settings files a settings file builds on. Files are resolved,
+	// merged, and validated as one. Empty, the default, turns it off.
+	SettingsExtends string `mapstructure:"settingsExtends"`
+	// AgentsMD compares CLAUDE.md with an AGENTS.md beside it, reporting
+	// sections the two duplicate or disagree on. Off by default.
+	AgentsMD bool `mapstructure:"agentsMd"`
+	// Categories turns rule categories (security, structure, references,
+	// style, performance) off with false. A rule is skipped when one of its
+	// categories is off, unless another of its categories is set to true.
+	Categories map[string]bool `mapstructure:"categories"`
+	// Platforms lists the platforms (macos, linux, windows) that hook
+	// commands and env values must run on. Empty infers them from the
+	// settings file.
+	Platforms []string `mapstructure:"platforms"`
+	// StaleReferences configures the check for prose references to
+	// project files that no longer exist. On by default.
+	StaleReferences StaleReferencesConfig `mapstructure:"staleReferences"`
+	// WebAccess restricts the domains components may point WebFetch and
+	// WebSearch at. Empty lists leave every named HTTPS host allowed.
+	WebAccess WebAccessConfig `mapstructure:"webAccess"`
+}
+
+// ReadabilityLevels are the accepted rules.readability values.
+var ReadabilityLevels = []string{"off", "lenient", "normal", "strict"}
+
+// toolNamePattern matches the bare tool names accepted in extraTools.
+var toolNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Platforms are the accepted rules.platforms values.
+var Platforms = []string{"macos", "linux", "windows"}
+
+// ModelStatuses are the accepted rules.models status values.
+var ModelStatuses = []string{"current", "deprecated", "retired"}
+
+// ModelConfig is one model catalog entry. Replacement is the model to
+// suggest instead of a deprecated or retired one.
+type ModelConfig struct {
+	ID          string `mapstructure:"id"`
+	Status      string `mapstructure:"status"`
+	Replacement string `mapstructure:"replacement"`
+}
+
+// TerminologyConfig configures the terminology check. Terms maps a word or
+// phrase to the preferred spelling and is merged over the bundled wordlist;
+// Allow lists bundled entries to turn off for this project.
+type TerminologyConfig struct {
+	Enabled bool              `mapstructure:"enabled"`
+	Terms   map[string]string `mapstructure:"terms"`
+	Allow   []string          `mapstructure:"allow"`
+}
+
+// StaleReferencesConfig configures the stale file reference check. Allow
+// lists doublestar patterns for referenced paths that are not expected to
+// exist, such as examples or build outputs.
+type StaleReferencesConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Allow   []string `mapstructure:"allow"`
+}
+
+// WebAccessConfig is the domain policy for URLs components point WebFetch
+// and WebSearch at. A domain matches itself and its subdomains, and
+// "*.example.com" only the subdomains. DenyDomains wins over AllowDomains;
+// when AllowDomains is set, domains outside it are reported too.
+type WebAccessConfig struct {
+	AllowDomains []string `mapstructure:"allowDomains"`
+	DenyDomains  []string `mapstructure:"denyDomains"`
+}
+
+// ValidateCategories checks that every name is a rule category.
+func ValidateCategories(names []string) error {
+	for _, name := range names {
+		if !slices.Contains(types.Categories, name) {
+			return fmt.Errorf("unknown category %q. Must be one of: %s", name, strings.Join(types.Categories, ", "))
+		}
+	}
+	return nil
+}
+
+// GroupByModes are the accepted groupBy values for console output.
+var GroupByModes = []string{"file", "rule", "severity"}
+
+// ColorModes are the accepted color values for console output.
+var ColorModes = []string{"auto", "always", "never"}
+
+// LineEndingsModes are the accepted lineEndings values: auto reports only
+// files that mix CRLF and LF, lf and crlf require that style everywhere.
+var LineEndingsModes = []string{"auto", "lf", "crlf"}
+
+// ContextBudgetTiers are the model tiers accepted in rules.contextBudgets.
+var ContextBudgetTiers = []string{"haiku", "sonnet", "opus", "default"}
+
+// FileTypesConfig extends or trims the discovery registry
+// (discovery.DefaultFileTypes).
+type FileTypesConfig struct {
+	// Custom adds discovery patterns for a component type, e.g.
+	// prompts/**/*.md as agents. Custom patterns take precedence over
+	// built-in ones during type detection.
+	Custom []CustomFileType `mapstructure:"custom"`
+	// Disable lists built-in discovery patterns to turn off, e.g.
+	// "rules/**/*.md".
+	Disable []string `mapstructure:"disable"`
+}
+
+// CustomFileType maps glob patterns to a component type.
+type CustomFileType struct {
+	Type     string   `mapstructure:"type"`
+	Patterns []string `mapstructure:"patterns"`
+}
+
+// DiscoveryRegistry builds the discovery registry: typeOverrides first,
+// then the fileTypes custom patterns, then discovery.DefaultFileTypes less
+// the disabled patterns.
+func (c *Config) DiscoveryRegistry() ([]discovery.FileTypeEntry, error) {
+	overrides, err := c.TypeOverrides.entries()
+	if err != nil {
+		return nil, err
+	}
+	return c.FileTypes.registry(overrides)
+}
+
+// NewFileDiscovery returns a discoverer for root that uses the configured
+// file type registry and symlink policy.
+func (c *Config) NewFileDiscovery(root string) *discovery.FileDiscovery {
+	fd := discovery.NewFileDiscovery(root).WithFileTypes(c.fileTypes())
+	if c.Symlinks != "" {
+		fd.WithSymlinkPolicy(c.Symlinks)
+	}
+	return fd
+}
+
+// DetectFileType determines the component type of absPath under root with
+// the configured file type registry; see discovery.DetectFileType.
+func (c *Config) DetectFileType(absPath, root string) (discovery.FileType, error) {
+	return discovery.DetectFileTypeWithRegistry(absPath, root, c.fileTypes())
+}
+
+// fileTypes returns the discovery registry. LoadConfig rejects a registry
+// that does not build, so the defaults only stand in for a Config made by
+// hand.
+func (c *Config) fileTypes() []discovery.FileTypeEntry {
+	registry, err := c.DiscoveryRegistry()
+	if err != nil {
+		return discovery.DefaultFileTypes
+	}
+	return registry
+}
+
+// registry merges the configured custom and disabled patterns with
+// discovery.DefaultFileTypes, after the given leading entries.
+func (c FileTypesConfig) registry(leading []discovery.FileTypeEntry) ([]discovery.FileTypeEntry, error) {
+	custom := make([]discovery.FileTypeEntry, 0, len(leading)+len(c.Custom))
+	custom = append(custom, leading...)
+	for _, ct := range c.Custom {
+		ft, err := discovery.ParseFileType(ct.Type)
+		if err != nil {
+			return nil, fmt.Errorf("fileTypes.custom: %w", err)
+		}
+		custom = append(custom, discovery.FileTypeEntry{Type: ft, Patterns: ct.Patterns})
+	}
+	registry, err := discovery.MergeFileTypes(discovery.DefaultFileTypes, custom, c.Disable)
+	if err != nil {
+		return nil, fmt.Errorf("fileTypes: %w", err)
+	}
+	return registry, nil
 }
 
 // SchemaConfig contains schema configuration
@@ -63,9 +316,9 @@ func LoadConfig(rootPath string) (*Config, error) {
 	vp.AutomaticEnv()
 
 	// Create config instance
-	var config Config
-	if err := vp.Unmarshal(&config); err != nil {
-		return nil, fmt.Errorf("error unmarshaling config: %w", err)
+	config, err := decodeConfig(vp)
+	if err != nil {
+		return nil, err
 	}
 
 	// Override root if provided
@@ -74,39 +327,271 @@ func LoadConfig(rootPath string) (*Config, error) {
 	}
 
 	// Validate configuration
-	if err := validateConfig(&config); err != nil {
+	if err := validateConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// Default returns the configuration LoadConfig gives when no config file
+// or CCLINT_* variable sets anything, for linting from Go code without a
+// project configuration.
+func Default() *Config {
+	homeDir, _ := os.UserHomeDir()
+	vp := viper.New()
+	setDefaults(vp, homeDir)
+	config, _ := decodeConfig(vp) // the defaults always decode
+	return config
+}
+
+// decodeConfig unmarshals the settings in vp and fills in the defaults
+// that depend on legacy keys.
+func decodeConfig(vp *viper.Viper) (*Config, error) {
+	var config Config
+	if err := vp.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("error unmarshaling config: %w", err)
+	}
+	deprecated, err := parseDeprecatedFields(vp.Get("deprecatedFields"))
+	if err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
+	config.DeprecatedFields = deprecated
+
+	// Default the symlink policy, honoring the legacy followSymlinks boolean
+	if config.Symlinks == "" {
+		config.Symlinks = discovery.SymlinkWithinRoot
+		if vp.IsSet("followSymlinks") && !vp.GetBool("followSymlinks") {
+			config.Symlinks = discovery.SymlinkDeny
+		}
+	}
 
+	// Default the verbosity, honoring the legacy quiet/verbose booleans
+	if config.Verbosity == "" {
+		config.Verbosity = VerbosityNormal
+		switch {
+		case vp.GetBool("quiet"):
+			config.Verbosity = VerbosityQuiet
+		case vp.GetBool("verbose"):
+			config.Verbosity = VerbosityVerbose
+		}
+	}
 	return &config, nil
 }
 
 func setDefaults(vp *viper.Viper, homeDir string) {
 	vp.SetDefault("root", defaultRoot(homeDir))
+	vp.SetDefault("symlinks", "") // resolved after unmarshal; see LoadConfig
 	vp.SetDefault("format", "console")
+	vp.SetDefault("groupBy", "file")
+	vp.SetDefault("maxIssuesPerFile", 0)
+	vp.SetDefault("color", "auto")
+	vp.SetDefault("lineEndings", "auto")
+	vp.SetDefault("fmt.anchors", AnchorsPreserve)
+	vp.SetDefault("snippets", true)
 	vp.SetDefault("failOn", "error")
-	vp.SetDefault("followSymlinks", false)
-	vp.SetDefault("quiet", false)
-	vp.SetDefault("verbose", false)
+	vp.SetDefault("verbosity", "") // resolved after unmarshal; see LoadConfig
 	vp.SetDefault("showScores", false)
 	vp.SetDefault("showImprovements", false)
 	vp.SetDefault("no-cycle-check", false)
 	vp.SetDefault("concurrency", 10)
 	vp.SetDefault("parallel", true)
 	vp.SetDefault("rules.strict", true)
+	vp.SetDefault("rules.warnUnknownKeys", false)
+	vp.SetDefault("rules.strictSettingsKeys", false)
+	vp.SetDefault("rules.terminology.enabled", false)
+	vp.SetDefault("rules.staleReferences.enabled", true)
 	vp.SetDefault("schemas.enabled", true)
 }
 
+// Formats are the accepted output formats. "json" is the current JSON
+// report schema; "json@1" keeps the previous schema for one release.
+// "jsonl" streams findings as JSON Lines while a full run is in progress.
+var Formats = []string{"console", "json", "json@1", "json@2", "jsonl", "markdown", "tap", "teamcity", "compact"}
+
+// Hash returns a digest of the effective configuration, so reports can show
+// whether two runs used the same settings. The tool version, its docs URL
+// template, and the output format and destination are left out, since they
+// do not change findings.
+func (c *Config) Hash() string {
+	h := *c
+	h.Version, h.Commit, h.BuildDate, h.DocsURLTemplate, h.Format, h.Output = "", "", "", "", "", ""
+	data, err := json.Marshal(h)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Features reports which optional checks and modes the configuration
+// turns on, by config key, so a report can record what produced it.
+func (c *Config) Features() map[string]bool {
+	return map[string]bool{
+		"schemas":                  c.Schemas.Enabled,
+		"untrusted":                c.Untrusted,
+		"rules.strict":             c.Rules.Strict,
+		"rules.warnUnknownKeys":    c.Rules.WarnUnknownKeys,
+		"rules.strictSettingsKeys": c.Rules.StrictSettingsKeys,
+		"rules.jsonc":              c.Rules.JSONC,
+		"rules.agentsMd":           c.Rules.AgentsMD,
+		"rules.settingsExtends":    c.Rules.SettingsExtends != "",
+		"rules.terminology":        c.Rules.Terminology.Enabled,
+		"rules.staleReferences":    c.Rules.StaleReferences.Enabled,
+		"rules.webAccess":          len(c.Rules.WebAccess.AllowDomains)+len(c.Rules.WebAccess.DenyDomains) > 0,
+		"deprecatedFields":         len(c.DeprecatedFields) > 0,
+	}
+}
+
 // validateConfig validates the configuration
 func validateConfig(config *Config) error {
 	// Validate format
-	if config.Format != "console" && config.Format != "json" && config.Format != "markdown" {
-		return fmt.Errorf("invalid format: %s. Must be 'console', 'json', or 'markdown'", config.Format)
+	if !slices.Contains(Formats, config.Format) {
+		return fmt.Errorf("invalid format: %s. Must be one of: %s", config.Format, strings.Join(Formats, ", "))
+	}
+
+	// Validate console output options (empty means the default)
+	if config.GroupBy != "" && !slices.Contains(GroupByModes, config.GroupBy) {
+		return fmt.Errorf("invalid groupBy: %s. Must be one of: %s", config.GroupBy, strings.Join(GroupByModes, ", "))
+	}
+	if config.MaxIssuesPerFile < 0 {
+		return fmt.Errorf("maxIssuesPerFile must not be negative")
+	}
+	if config.Color != "" && !slices.Contains(ColorModes, config.Color) {
+		return fmt.Errorf("invalid color: %s. Must be one of: %s", config.Color, strings.Join(ColorModes, ", "))
+	}
+	if config.LineEndings != "" && !slices.Contains(LineEndingsModes, config.LineEndings) {
+		return fmt.Errorf("invalid lineEndings: %s. Must be one of: %s", config.LineEndings, strings.Join(LineEndingsModes, ", "))
+	}
+
+	// Validate verbosity and show filters
+	if _, err := ParseVerbosity(string(config.Verbosity)); err != nil {
+		return err
+	}
+	if _, err := ParseShow(config.Show); err != nil {
+		return err
+	}
+
+	// Validate failOn level (bare level or comma list with type=level overrides)
+	if _, err := ParseFailOn(config.FailOn); err != nil {
+		return err
+	}
+
+	// Validate context budgets
+	for tier, budget := range config.Rules.ContextBudgets {
+		if !slices.Contains(ContextBudgetTiers, tier) {
+			return fmt.Errorf("invalid rules.contextBudgets tier %q. Must be one of: %s", tier, strings.Join(ContextBudgetTiers, ", "))
+		}
+		if budget < 1 {
+			return fmt.Errorf("rules.contextBudgets.%s must be a positive token count", tier)
+		}
+	}
+
+	if err := ValidateCategories(slices.Collect(maps.Keys(config.Rules.Categories))); err != nil {
+		return fmt.Errorf("invalid rules.categories: %w", err)
+	}
+
+	if config.Rules.SkillBodyMaxLines < 0 {
+		return fmt.Errorf("rules.skillBodyMaxLines must not be negative")
+	}
+	if config.Rules.HookTimeoutMax < 0 {
+		return fmt.Errorf("rules.hookTimeoutMax must not be negative")
+	}
+	if config.Rules.LineLengthMax < 0 {
+		return fmt.Errorf("rules.lineLengthMax must not be negative")
+	}
+	if config.Rules.Base64LengthMax < 0 {
+		return fmt.Errorf("rules.base64LengthMax must not be negative")
+	}
+	if config.Rules.SchemaMaxBytes < 0 {
+		return fmt.Errorf("rules.schemaMaxBytes must not be negative")
+	}
+	if config.Rules.SchemaMaxDepth < 0 {
+		return fmt.Errorf("rules.schemaMaxDepth must not be negative")
+	}
+	if config.Rules.SchemaTimeout < 0 {
+		return fmt.Errorf("rules.schemaTimeout must not be negative")
+	}
+	for _, p := range config.Rules.Platforms {
+		if !slices.Contains(Platforms, p) {
+			return fmt.Errorf("invalid rules.platforms entry %q. Must be one of: %s", p, strings.Join(Platforms, ", "))
+		}
+	}
+	if r := config.Rules.Readability; r != "" && !slices.Contains(ReadabilityLevels, r) {
+		return fmt.Errorf("invalid rules.readability %q. Must be one of: %s", r, strings.Join(ReadabilityLevels, ", "))
+	}
+	for term, preferred := range config.Rules.Terminology.Terms {
+		if strings.TrimSpace(term) == "" || strings.TrimSpace(preferred) == "" {
+			return fmt.Errorf("rules.terminology.terms entries need a term and a preferred spelling")
+		}
+	}
+
+	for _, pattern := range config.Rules.StaleReferences.Allow {
+		if !doublestar.ValidatePattern(pattern) {
+			return fmt.Errorf("invalid rules.staleReferences.allow pattern %q", pattern)
+		}
+	}
+
+	for _, list := range []struct {
+		key     string
+		domains []string
+	}{
+		{"allowDomains", config.Rules.WebAccess.AllowDomains},
+		{"denyDomains", config.Rules.WebAccess.DenyDomains},
+	} {
+		for _, domain := range list.domains {
+			if d := strings.TrimPrefix(domain, "*."); d == "" || strings.ContainsAny(d, "/:*@ ") {
+				return fmt.Errorf("invalid rules.webAccess.%s entry %q: must be a domain such as example.com or *.example.com", list.key, domain)
+			}
+		}
+	}
+
+	for i, m := range config.Rules.Models {
+		if strings.TrimSpace(m.ID) == "" {
+			return fmt.Errorf("rules.models[%d] needs an id", i)
+		}
+		if !slices.Contains(ModelStatuses, m.Status) {
+			return fmt.Errorf("invalid rules.models[%d] status %q. Must be one of: %s", i, m.Status, strings.Join(ModelStatuses, ", "))
+		}
+	}
+
+	for i, name := range config.ExtraBuiltinAgents {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("extraBuiltinAgents[%d] must not be empty", i)
+		}
+	}
+	for i, name := range config.ExtraTools {
+		if !toolNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid extraTools[%d] %q: must be a bare tool name such as \"MyTool\"", i, name)
+		}
+	}
+
+	if err := config.Overrides.validate(); err != nil {
+		return err
+	}
+	if err := config.Gates.validate(); err != nil {
+		return err
+	}
+	if err := config.Fmt.validate(); err != nil {
+		return err
+	}
+
+	if config.SchemaVersion != 0 {
+		if err := migrate.ValidateVersion(config.SchemaVersion); err != nil {
+			return fmt.Errorf("invalid schemaVersion: %w", err)
+		}
+	}
+
+	// Validate symlink policy (empty means the discovery default)
+	if config.Symlinks != "" {
+		if _, err := discovery.ParseSymlinkPolicy(string(config.Symlinks)); err != nil {
+			return err
+		}
 	}
 
-	// Validate failOn level
-	if config.FailOn != "error" && config.FailOn != "warning" && config.FailOn != "suggestion" {
-		return fmt.Errorf("invalid fail-on level: %s. Must be 'error', 'warning', or 'suggestion'", config.FailOn)
+	// Validate file type registry
+	if _, err := config.DiscoveryRegistry(); err != nil {
+		return err
 	}
 
 	// Validate concurrency
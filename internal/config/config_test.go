@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/dotcommander/cclint/internal/discovery"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,9 +50,12 @@ func TestLoadConfigDefaults(t *testing.T) {
 	assert.Equal(t, filepath.Join(homeDir, ".claude"), config.Root)
 	assert.Equal(t, "console", config.Format)
 	assert.Equal(t, "error", config.FailOn)
-	assert.False(t, config.FollowSymlinks)
-	assert.False(t, config.Quiet)
-	assert.False(t, config.Verbose)
+	assert.Equal(t, discovery.SymlinkWithinRoot, config.Symlinks)
+	assert.Equal(t, "file", config.GroupBy)
+	assert.Equal(t, 0, config.MaxIssuesPerFile)
+	assert.Equal(t, "auto", config.Color)
+	assert.True(t, config.Snippets)
+	assert.Equal(t, VerbosityNormal, config.Verbosity)
 	assert.False(t, config.ShowScores)
 	assert.False(t, config.ShowImprovements)
 	assert.False(t, config.NoCycleCheck)
@@ -70,7 +74,7 @@ func TestLoadConfigFromJSON(t *testing.T) {
 	configData := map[string]any{
 		"root":             "/custom/root",
 		"exclude":          []string{"node_modules", "*.tmp"},
-		"followSymlinks":   true,
+		"symlinks":         "allow",
 		"format":           "json",
 		"output":           "report.json",
 		"failOn":           "warning",
@@ -112,12 +116,11 @@ func TestLoadConfigFromJSON(t *testing.T) {
 	// Verify values from JSON
 	assert.Equal(t, "/custom/root", config.Root)
 	assert.Equal(t, []string{"node_modules", "*.tmp"}, config.Exclude)
-	assert.True(t, config.FollowSymlinks)
+	assert.Equal(t, discovery.SymlinkAllow, config.Symlinks)
 	assert.Equal(t, "json", config.Format)
 	assert.Equal(t, "report.json", config.Output)
 	assert.Equal(t, "warning", config.FailOn)
-	assert.True(t, config.Quiet)
-	assert.False(t, config.Verbose)
+	assert.Equal(t, VerbosityQuiet, config.Verbosity)
 	assert.True(t, config.ShowScores)
 	assert.True(t, config.ShowImprovements)
 	assert.True(t, config.NoCycleCheck)
@@ -139,7 +142,7 @@ root: /yaml/root
 exclude:
   - dist
   - build
-followSymlinks: true
+followSymlinks: false
 format: markdown
 output: report.md
 failOn: suggestion
@@ -176,12 +179,11 @@ schemas:
 	// Verify values from YAML
 	assert.Equal(t, "/yaml/root", config.Root)
 	assert.Equal(t, []string{"dist", "build"}, config.Exclude)
-	assert.True(t, config.FollowSymlinks)
+	assert.Equal(t, discovery.SymlinkDeny, config.Symlinks, "legacy followSymlinks: false maps to deny")
 	assert.Equal(t, "markdown", config.Format)
 	assert.Equal(t, "report.md", config.Output)
 	assert.Equal(t, "suggestion", config.FailOn)
-	assert.False(t, config.Quiet)
-	assert.True(t, config.Verbose)
+	assert.Equal(t, VerbosityVerbose, config.Verbosity)
 	assert.True(t, config.ShowScores)
 	assert.False(t, config.ShowImprovements)
 	assert.False(t, config.NoCycleCheck)
@@ -277,7 +279,7 @@ func TestLoadConfigFromRootPath(t *testing.T) {
 
 	assert.Equal(t, rootDir, config.Root)
 	assert.Equal(t, []string{".claude/agents/**"}, config.Exclude)
-	assert.True(t, config.Quiet)
+	assert.Equal(t, VerbosityQuiet, config.Verbosity)
 }
 
 // TestLoadConfigEnvironmentVariables tests environment variable overrides
@@ -315,8 +317,7 @@ func TestLoadConfigEnvironmentVariables(t *testing.T) {
 	assert.Equal(t, "/env/root", config.Root)
 	assert.Equal(t, "console", config.Format)
 	assert.Equal(t, "warning", config.FailOn)
-	assert.True(t, config.Quiet)
-	assert.True(t, config.Verbose)
+	assert.Equal(t, VerbosityQuiet, config.Verbosity, "legacy quiet wins over verbose")
 	assert.Equal(t, 30, config.Concurrency)
 	assert.False(t, config.Parallel)
 }
@@ -439,6 +440,45 @@ func TestValidateConfigValid(t *testing.T) {
 	}
 }
 
+// TestValidateConfigJSONSchemaVersions tests the versioned json formats
+func TestValidateConfigJSONSchemaVersions(t *testing.T) {
+	for _, format := range []string{"json@1", "json@2"} {
+		config := &Config{Format: format, FailOn: "error", Concurrency: 10}
+		assert.NoError(t, validateConfig(config), format)
+	}
+
+	err := validateConfig(&Config{Format: "json@3", FailOn: "error", Concurrency: 10})
+	assert.ErrorContains(t, err, "invalid format")
+}
+
+// TestValidateConfigCategories tests rules.categories validation
+func TestValidateConfigCategories(t *testing.T) {
+	config := &Config{Format: "console", FailOn: "error", Concurrency: 10}
+	config.Rules.Categories = map[string]bool{"security": true, "style": false}
+	assert.NoError(t, validateConfig(config))
+
+	config.Rules.Categories["speed"] = false
+	assert.ErrorContains(t, validateConfig(config), `unknown category "speed"`)
+}
+
+// TestConfigHash tests that the hash covers rules but not output settings
+func TestConfigHash(t *testing.T) {
+	base := func() *Config {
+		return &Config{Root: "/r", Format: "console", FailOn: "error", Concurrency: 10}
+	}
+	hash := base().Hash()
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, hash)
+	assert.Equal(t, hash, base().Hash(), "hash must be stable")
+
+	output := base()
+	output.Format, output.Output, output.Version = "json", "report.json", "1.0.0"
+	assert.Equal(t, hash, output.Hash(), "output settings must not change the hash")
+
+	rules := base()
+	rules.FailOn = "warning"
+	assert.NotEqual(t, hash, rules.Hash())
+}
+
 // TestSaveConfig tests saving configuration to file
 func TestSaveConfig(t *testing.T) {
 	tmpDir := setupTestDir(t)
@@ -446,12 +486,11 @@ func TestSaveConfig(t *testing.T) {
 	config := &Config{
 		Root:             "/test/root",
 		Exclude:          []string{"*.tmp", "node_modules"},
-		FollowSymlinks:   true,
+		Symlinks:         discovery.SymlinkAllow,
 		Format:           "json",
 		Output:           "output.json",
 		FailOn:           "warning",
-		Quiet:            true,
-		Verbose:          false,
+		Verbosity:        VerbosityQuiet,
 		ShowScores:       true,
 		ShowImprovements: false,
 		NoCycleCheck:     true,
@@ -486,12 +525,11 @@ func TestSaveConfig(t *testing.T) {
 	// Verify all fields
 	assert.Equal(t, config.Root, loaded.Root)
 	assert.Equal(t, config.Exclude, loaded.Exclude)
-	assert.Equal(t, config.FollowSymlinks, loaded.FollowSymlinks)
+	assert.Equal(t, config.Symlinks, loaded.Symlinks)
 	assert.Equal(t, config.Format, loaded.Format)
 	assert.Equal(t, config.Output, loaded.Output)
 	assert.Equal(t, config.FailOn, loaded.FailOn)
-	assert.Equal(t, config.Quiet, loaded.Quiet)
-	assert.Equal(t, config.Verbose, loaded.Verbose)
+	assert.Equal(t, config.Verbosity, loaded.Verbosity)
 	assert.Equal(t, config.ShowScores, loaded.ShowScores)
 	assert.Equal(t, config.ShowImprovements, loaded.ShowImprovements)
 	assert.Equal(t, config.NoCycleCheck, loaded.NoCycleCheck)
@@ -587,12 +625,11 @@ func TestConfigStructFields(t *testing.T) {
 	config := Config{
 		Root:             "/test",
 		Exclude:          []string{"test"},
-		FollowSymlinks:   true,
+		Symlinks:         discovery.SymlinkAllow,
 		Format:           "json",
 		Output:           "out.json",
 		FailOn:           "error",
-		Quiet:            true,
-		Verbose:          true,
+		Verbosity:        VerbosityVerbose,
 		ShowScores:       true,
 		ShowImprovements: true,
 		NoCycleCheck:     true,
@@ -612,12 +649,11 @@ func TestConfigStructFields(t *testing.T) {
 	// Verify all fields are accessible
 	assert.Equal(t, "/test", config.Root)
 	assert.Equal(t, []string{"test"}, config.Exclude)
-	assert.True(t, config.FollowSymlinks)
+	assert.Equal(t, discovery.SymlinkAllow, config.Symlinks)
 	assert.Equal(t, "json", config.Format)
 	assert.Equal(t, "out.json", config.Output)
 	assert.Equal(t, "error", config.FailOn)
-	assert.True(t, config.Quiet)
-	assert.True(t, config.Verbose)
+	assert.Equal(t, VerbosityVerbose, config.Verbosity)
 	assert.True(t, config.ShowScores)
 	assert.True(t, config.ShowImprovements)
 	assert.True(t, config.NoCycleCheck)
@@ -735,6 +771,7 @@ func TestValidateConfigAllFormats(t *testing.T) {
 	}{
 		{"console", false},
 		{"json", true},
+		{"jsonl", false},
 		{"markdown", true},
 	}
 
@@ -878,11 +915,290 @@ func TestLoadConfigPartialConfig(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify set values
-	assert.True(t, config.Quiet)
-	assert.True(t, config.Verbose)
+	assert.Equal(t, VerbosityQuiet, config.Verbosity, "legacy quiet wins over verbose")
 
 	// Verify defaults for unset values
 	assert.Equal(t, "console", config.Format)
 	assert.Equal(t, "error", config.FailOn)
 	assert.Equal(t, 10, config.Concurrency)
 }
+
+// TestValidateConfigContextBudgets tests rules.contextBudgets,
+// rules.skillBodyMaxLines, rules.hookTimeoutMax, rules.lineLengthMax,
+// rules.base64LengthMax, rules.readability, rules.terminology.terms,
+// rules.staleReferences.allow, and rules.webAccess validation
+func TestValidateConfigContextBudgets(t *testing.T) {
+	tests := []struct {
+		name    string
+		budgets map[string]int
+		bodyMax int
+		hookMax int
+		lineMax int
+		b64Max  int
+		schema  [3]int // schemaMaxBytes, schemaMaxDepth, schemaTimeout
+		readab  string
+		terms   map[string]string
+		allow   []string
+		web     WebAccessConfig
+		wantErr string
+	}{
+		{name: "valid tiers", budgets: map[string]int{"haiku": 4000, "default": 20000}},
+		{name: "unknown tier", budgets: map[string]int{"gpt": 4000}, wantErr: "invalid rules.contextBudgets tier"},
+		{name: "non-positive budget", budgets: map[string]int{"opus": 0}, wantErr: "must be a positive token count"},
+		{name: "skill body limit", bodyMax: 300},
+		{name: "negative skill body limit", bodyMax: -1, wantErr: "rules.skillBodyMaxLines must not be negative"},
+		{name: "hook timeout limit", hookMax: 120},
+		{name: "negative hook timeout limit", hookMax: -5, wantErr: "rules.hookTimeoutMax must not be negative"},
+		{name: "content limits", lineMax: 4000, b64Max: 512},
+		{name: "negative line length limit", lineMax: -1, wantErr: "rules.lineLengthMax must not be negative"},
+		{name: "negative base64 limit", b64Max: -1, wantErr: "rules.base64LengthMax must not be negative"},
+		{name: "schema limits", schema: [3]int{1 << 20, 64, 10}},
+		{name: "negative schema size limit", schema: [3]int{-1, 0, 0}, wantErr: "rules.schemaMaxBytes must not be negative"},
+		{name: "negative schema depth limit", schema: [3]int{0, -1, 0}, wantErr: "rules.schemaMaxDepth must not be negative"},
+		{name: "negative schema timeout", schema: [3]int{0, 0, -1}, wantErr: "rules.schemaTimeout must not be negative"},
+		{name: "strict readability", readab: "strict"},
+		{name: "unknown readability level", readab: "loose", wantErr: "invalid rules.readability"},
+		{name: "terminology terms", terms: map[string]string{"sub-agent": "subagent"}},
+		{name: "empty preferred term", terms: map[string]string{"github": " "}, wantErr: "rules.terminology.terms"},
+		{name: "stale reference allow patterns", allow: []string{"examples/**", "docs/RULES.md"}},
+		{name: "invalid stale reference allow pattern", allow: []string{"docs/[a"}, wantErr: "invalid rules.staleReferences.allow pattern"},
+		{name: "web access domains", web: WebAccessConfig{AllowDomains: []string{"docs.example.com", "*.github.com"}, DenyDomains: []string{"pastebin.com"}}},
+		{name: "web access URL entry", web: WebAccessConfig{DenyDomains: []string{"https://pastebin.com"}}, wantErr: "invalid rules.webAccess.denyDomains entry"},
+		{name: "web access inner wildcard", web: WebAccessConfig{AllowDomains: []string{"docs.*.com"}}, wantErr: "invalid rules.webAccess.allowDomains entry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{
+				Format:      "console",
+				FailOn:      "error",
+				Concurrency: 10,
+				Rules: RulesConfig{
+					ContextBudgets:    tt.budgets,
+					SkillBodyMaxLines: tt.bodyMax,
+					HookTimeoutMax:    tt.hookMax,
+					LineLengthMax:     tt.lineMax,
+					Base64LengthMax:   tt.b64Max,
+					SchemaMaxBytes:    tt.schema[0],
+					SchemaMaxDepth:    tt.schema[1],
+					SchemaTimeout:     tt.schema[2],
+					Readability:       tt.readab,
+					Terminology:       TerminologyConfig{Terms: tt.terms},
+					StaleReferences:   StaleReferencesConfig{Allow: tt.allow},
+					WebAccess:         tt.web,
+				},
+			}
+			err := validateConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateConfigConsoleOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		groupBy  string
+		maxIssue int
+		color    string
+		wantErr  string
+	}{
+		{name: "defaults", groupBy: "file", color: "auto"},
+		{name: "empty means default"},
+		{name: "rule and never", groupBy: "rule", maxIssue: 5, color: "never"},
+		{name: "invalid groupBy", groupBy: "type", wantErr: "invalid groupBy"},
+		{name: "negative cap", maxIssue: -1, wantErr: "maxIssuesPerFile must not be negative"},
+		{name: "invalid color", color: "sometimes", wantErr: "invalid color"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{
+				Format:           "console",
+				FailOn:           "error",
+				Concurrency:      10,
+				GroupBy:          tt.groupBy,
+				MaxIssuesPerFile: tt.maxIssue,
+				Color:            tt.color,
+			}
+			err := validateConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateConfigLineEndings(t *testing.T) {
+	for _, mode := range append([]string{""}, LineEndingsModes...) {
+		config := &Config{Format: "console", FailOn: "error", Concurrency: 10, LineEndings: mode}
+		assert.NoError(t, validateConfig(config), mode)
+	}
+
+	config := &Config{Format: "console", FailOn: "error", Concurrency: 10, LineEndings: "cr"}
+	assert.ErrorContains(t, validateConfig(config), "invalid lineEndings: cr")
+}
+
+// TestValidateConfigFileTypes tests fileTypes validation
+func TestValidateConfigFileTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		fileTypes FileTypesConfig
+		wantErr   string
+	}{
+		{
+			name: "valid custom and disable",
+			fileTypes: FileTypesConfig{
+				Custom:  []CustomFileType{{Type: "agent", Patterns: []string{"prompts/**/*.md"}}},
+				Disable: []string{"rules/**/*.md"},
+			},
+		},
+		{
+			name:      "unknown type",
+			fileTypes: FileTypesConfig{Custom: []CustomFileType{{Type: "prompt", Patterns: []string{"prompts/*.md"}}}},
+			wantErr:   "fileTypes.custom: invalid type",
+		},
+		{
+			name:      "pattern does not compile",
+			fileTypes: FileTypesConfig{Custom: []CustomFileType{{Type: "command", Patterns: []string{"cmds/{a,b"}}}},
+			wantErr:   "invalid command pattern",
+		},
+		{
+			name:      "unknown disabled pattern",
+			fileTypes: FileTypesConfig{Disable: []string{"prompts/**/*.md"}},
+			wantErr:   "not a built-in discovery pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{
+				Format:      "console",
+				FailOn:      "error",
+				Concurrency: 10,
+				FileTypes:   tt.fileTypes,
+			}
+			err := validateConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestLoadConfigFileTypesYAML tests that the fileTypes block is unmarshaled
+func TestLoadConfigFileTypesYAML(t *testing.T) {
+	resetViper()
+	tmpDir := setupTestDir(t)
+
+	yamlContent := `fileTypes:
+  custom:
+    - type: agent
+      patterns: ["prompts/**/*.md"]
+  disable:
+    - "rules/**/*.md"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".cclintrc.yaml"), []byte(yamlContent), 0644))
+
+	config, err := LoadConfig(tmpDir)
+	require.NoError(t, err)
+	require.Len(t, config.FileTypes.Custom, 1)
+	assert.Equal(t, "agent", config.FileTypes.Custom[0].Type)
+	assert.Equal(t, []string{"prompts/**/*.md"}, config.FileTypes.Custom[0].Patterns)
+	assert.Equal(t, []string{"rules/**/*.md"}, config.FileTypes.Disable)
+}
+
+// TestValidateConfigInvalidSymlinks tests symlink policy validation
+func TestValidateConfigInvalidSymlinks(t *testing.T) {
+	config := &Config{
+		Format:      "console",
+		FailOn:      "error",
+		Concurrency: 10,
+		Symlinks:    "follow",
+	}
+
+	err := validateConfig(config)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid symlink policy")
+}
+
+func TestValidateConfigSchemaVersion(t *testing.T) {
+	for _, tt := range []struct {
+		version int
+		wantErr bool
+	}{{0, false}, {1, false}, {2, false}, {-1, true}, {3, true}} {
+		config := &Config{Format: "console", FailOn: "error", Concurrency: 10, SchemaVersion: tt.version}
+		err := validateConfig(config)
+		if tt.wantErr {
+			assert.ErrorContains(t, err, "invalid schemaVersion", "schemaVersion %d", tt.version)
+		} else {
+			assert.NoError(t, err, "schemaVersion %d", tt.version)
+		}
+	}
+}
+
+func TestValidateConfigModels(t *testing.T) {
+	tests := []struct {
+		name    string
+		models  []ModelConfig
+		wantErr string
+	}{
+		{name: "valid", models: []ModelConfig{{ID: "claude-2.1", Status: "current"}, {ID: "x", Status: "retired", Replacement: "sonnet"}}},
+		{name: "missing id", models: []ModelConfig{{Status: "deprecated"}}, wantErr: "rules.models[0] needs an id"},
+		{name: "bad status", models: []ModelConfig{{ID: "x", Status: "old"}}, wantErr: "invalid rules.models[0] status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Format: "console", FailOn: "error", Concurrency: 10, Rules: RulesConfig{Models: tt.models}}
+			err := validateConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateConfigCatalogExtensions(t *testing.T) {
+	tests := []struct {
+		name    string
+		agents  []string
+		tools   []string
+		wantErr string
+	}{
+		{name: "valid", agents: []string{"team-lead"}, tools: []string{"FutureTool", "Mcp_Bridge"}},
+		{name: "empty agent", agents: []string{"team-lead", " "}, wantErr: "extraBuiltinAgents[1] must not be empty"},
+		{name: "tool with arguments", tools: []string{"Bash(git:*)"}, wantErr: `invalid extraTools[0] "Bash(git:*)"`},
+		{name: "empty tool", tools: []string{""}, wantErr: "invalid extraTools[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Format: "console", FailOn: "error", Concurrency: 10, ExtraBuiltinAgents: tt.agents, ExtraTools: tt.tools}
+			err := validateConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateConfigPlatforms(t *testing.T) {
+	config := &Config{Format: "console", FailOn: "error", Concurrency: 10, Rules: RulesConfig{Platforms: []string{"macos", "linux", "windows"}}}
+	assert.NoError(t, validateConfig(config))
+
+	config.Rules.Platforms = []string{"linux", "darwin"}
+	assert.ErrorContains(t, validateConfig(config), `invalid rules.platforms entry "darwin"`)
+}
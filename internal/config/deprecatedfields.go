@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/migrate"
+	"github.com/dotcommander/cclint/internal/types"
+)
+
+// DeprecatedFieldTypes are the component types deprecatedFields accepts:
+// the ones with YAML frontmatter.
+var DeprecatedFieldTypes = []string{types.TypeAgent, types.TypeCommand, types.TypeSkill, types.TypeRule, types.TypeOutputStyle}
+
+// DeprecatedField is a frontmatter field a project has retired, with the
+// message to report it with and, optionally, the field that replaces it.
+type DeprecatedField struct {
+	Message  string
+	RenameTo string
+}
+
+// DeprecatedFields is the deprecatedFields config block, keyed by component
+// type and then by field name. An entry is a message, or an object with a
+// message and a renameTo field that --fix and "cclint migrate" rename the
+// field to:
+//
+//	deprecatedFields:
+//	  agent:
+//	    color: "use themes instead"
+//	    owner_team:
+//	      message: "owners live in CODEOWNERS"
+//	      renameTo: owner
+//
+// Field names are matched case-insensitively, since config keys are.
+type DeprecatedFields map[string]map[string]DeprecatedField
+
+// Lookup returns the entry for key in component files.
+func (d DeprecatedFields) Lookup(component, key string) (DeprecatedField, bool) {
+	f, ok := d[component][strings.ToLower(key)]
+	return f, ok
+}
+
+// Renames returns the entries with a renameTo as migration table entries,
+// sorted by component and field. Their version is 0, so a migration to any
+// schema version applies them.
+func (d DeprecatedFields) Renames() []migrate.Rename {
+	var renames []migrate.Rename
+	for component, fields := range d {
+		for key, f := range fields {
+			if f.RenameTo != "" {
+				renames = append(renames, migrate.Rename{Component: component, From: key, To: f.RenameTo})
+			}
+		}
+	}
+	sort.Slice(renames, func(i, j int) bool {
+		if renames[i].Component != renames[j].Component {
+			return renames[i].Component < renames[j].Component
+		}
+		return renames[i].From < renames[j].From
+	})
+	return renames
+}
+
+// parseDeprecatedFields reads the deprecatedFields block as the config
+// loader returns it, where each entry is either a message string or a map
+// with message and renameTo keys.
+func parseDeprecatedFields(raw any) (DeprecatedFields, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	components, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("deprecatedFields must map component types to fields")
+	}
+	d := make(DeprecatedFields, len(components))
+	for component, rawFields := range components {
+		component = strings.ToLower(component)
+		if !slices.Contains(DeprecatedFieldTypes, component) {
+			return nil, fmt.Errorf("invalid deprecatedFields type %q. Must be one of: %s", component, strings.Join(DeprecatedFieldTypes, ", "))
+		}
+		fields, ok := rawFields.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("deprecatedFields.%s must map field names to messages", component)
+		}
+		d[component] = make(map[string]DeprecatedField, len(fields))
+		for key, value := range fields {
+			f, err := parseDeprecatedField(value)
+			if err != nil {
+				return nil, fmt.Errorf("deprecatedFields.%s.%s: %w", component, key, err)
+			}
+			if strings.EqualFold(f.RenameTo, key) {
+				return nil, fmt.Errorf("deprecatedFields.%s.%s: renameTo must name another field", component, key)
+			}
+			d[component][strings.ToLower(key)] = f
+		}
+	}
+	return d, nil
+}
+
+func parseDeprecatedField(value any) (DeprecatedField, error) {
+	switch v := value.(type) {
+	case nil:
+		return DeprecatedField{}, nil
+	case string:
+		return DeprecatedField{Message: strings.TrimSpace(v)}, nil
+	case map[string]any:
+		var f DeprecatedField
+		for k, raw := range v {
+			s, ok := raw.(string)
+			if !ok {
+				return DeprecatedField{}, fmt.Errorf("%s must be a string", k)
+			}
+			switch strings.ToLower(k) {
+			case "message":
+				f.Message = strings.TrimSpace(s)
+			case "renameto":
+				f.RenameTo = strings.TrimSpace(s)
+				if strings.ContainsAny(f.RenameTo, " \t:\"'") {
+					return DeprecatedField{}, fmt.Errorf("invalid renameTo %q: must be a bare field name", s)
+				}
+			default:
+				return DeprecatedField{}, fmt.Errorf("unknown key %q. Must be message or renameTo", k)
+			}
+		}
+		return f, nil
+	default:
+		return DeprecatedField{}, fmt.Errorf("must be a message or an object with message and renameTo")
+	}
+}
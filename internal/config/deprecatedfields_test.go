@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigDeprecatedFields(t *testing.T) {
+	resetViper()
+	tmpDir := setupTestDir(t)
+	yamlContent := `deprecatedFields:
+  agent:
+    color: "use themes instead"
+    Owner_Team:
+      message: owners live in CODEOWNERS
+      renameTo: ownerTeam
+  command:
+    category:
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".cclintrc.yaml"), []byte(yamlContent), 0644))
+	oldWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(oldWd)
+	})
+
+	config, err := LoadConfig("")
+	require.NoError(t, err)
+
+	f, ok := config.DeprecatedFields.Lookup("agent", "color")
+	assert.True(t, ok)
+	assert.Equal(t, DeprecatedField{Message: "use themes instead"}, f)
+	f, ok = config.DeprecatedFields.Lookup("agent", "owner_team")
+	assert.True(t, ok, "field names match case-insensitively")
+	assert.Equal(t, DeprecatedField{Message: "owners live in CODEOWNERS", RenameTo: "ownerTeam"}, f)
+	_, ok = config.DeprecatedFields.Lookup("command", "category")
+	assert.True(t, ok, "an entry without a message")
+	_, ok = config.DeprecatedFields.Lookup("skill", "color")
+	assert.False(t, ok)
+
+	assert.Equal(t, []migrate.Rename{{Component: "agent", From: "owner_team", To: "ownerTeam"}}, config.DeprecatedFields.Renames())
+}
+
+func TestParseDeprecatedFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     any
+		wantErr string
+	}{
+		{name: "unset", raw: nil},
+		{name: "message", raw: map[string]any{"skill": map[string]any{"version": "tracked in plugin.json"}}},
+		{name: "not a map", raw: "color", wantErr: "must map component types"},
+		{name: "unknown type", raw: map[string]any{"settings": map[string]any{"x": "y"}}, wantErr: `invalid deprecatedFields type "settings"`},
+		{name: "fields not a map", raw: map[string]any{"agent": []any{"color"}}, wantErr: "deprecatedFields.agent must map field names"},
+		{name: "unknown entry key", raw: map[string]any{"agent": map[string]any{"color": map[string]any{"reason": "x"}}}, wantErr: `unknown key "reason"`},
+		{name: "bad renameTo", raw: map[string]any{"agent": map[string]any{"color": map[string]any{"renameto": "a: b"}}}, wantErr: "must be a bare field name"},
+		{name: "rename to itself", raw: map[string]any{"agent": map[string]any{"color": map[string]any{"renameto": "Color"}}}, wantErr: "renameTo must name another field"},
+		{name: "number", raw: map[string]any{"agent": map[string]any{"color": 3}}, wantErr: "must be a message or an object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDeprecatedFields(tt.raw)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+// Fail-on severity levels, ordered from strictest to most lenient threshold.
+const (
+	FailOnError      = "error"
+	FailOnWarning    = "warning"
+	FailOnSuggestion = "suggestion"
+)
+
+// FailOnPolicy is the parsed form of the failOn setting.
+//
+// The setting accepts a comma-separated list where a bare level sets the
+// default threshold and a type=level pair overrides it for one component
+// type:
+//
+//	error
+//	warning
+//	agents=warning,skills=error
+//	suggestion,settings=error
+//
+// Type names accept the same singular/plural spellings as the CLI type
+// filters and are normalized to the discovery.FileType name.
+type FailOnPolicy struct {
+	Default string
+	PerType map[string]string
+}
+
+// ParseFailOn parses a failOn value into a FailOnPolicy.
+// An empty value yields the default "error" threshold.
+func ParseFailOn(value string) (FailOnPolicy, error) {
+	policy := FailOnPolicy{Default: FailOnError, PerType: map[string]string{}}
+	defaultSet := false
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		typeName, level, hasType := strings.Cut(part, "=")
+		if !hasType {
+			level = typeName
+		}
+		level = strings.ToLower(strings.TrimSpace(level))
+		if !isFailOnLevel(level) {
+			return FailOnPolicy{}, fmt.Errorf("invalid fail-on level: %s. Must be 'error', 'warning', or 'suggestion'", level)
+		}
+
+		if !hasType {
+			if defaultSet && policy.Default != level {
+				return FailOnPolicy{}, fmt.Errorf("invalid fail-on value %q: conflicting default levels %s and %s", value, policy.Default, level)
+			}
+			policy.Default = level
+			defaultSet = true
+			continue
+		}
+
+		ft, err := discovery.ParseFileType(typeName)
+		if err != nil {
+			return FailOnPolicy{}, fmt.Errorf("invalid fail-on type: %w", err)
+		}
+		key := ft.String()
+		if existing, ok := policy.PerType[key]; ok && existing != level {
+			return FailOnPolicy{}, fmt.Errorf("invalid fail-on value %q: conflicting levels for %s", value, key)
+		}
+		policy.PerType[key] = level
+	}
+
+	return policy, nil
+}
+
+// Threshold returns the fail-on level that applies to componentType.
+// Unknown or empty component types use the default threshold.
+func (p FailOnPolicy) Threshold(componentType string) string {
+	if level, ok := p.PerType[componentType]; ok {
+		return level
+	}
+	if p.Default == "" {
+		return FailOnError
+	}
+	return p.Default
+}
+
+// ShouldFail reports whether the given issue counts for componentType meet
+// or exceed its fail-on threshold.
+func (p FailOnPolicy) ShouldFail(componentType string, errors, warnings, suggestions int) bool {
+	switch p.Threshold(componentType) {
+	case FailOnSuggestion:
+		return errors > 0 || warnings > 0 || suggestions > 0
+	case FailOnWarning:
+		return errors > 0 || warnings > 0
+	default:
+		return errors > 0
+	}
+}
+
+// String renders the policy back into its canonical flag form.
+func (p FailOnPolicy) String() string {
+	parts := []string{p.Threshold("")}
+	types := make([]string, 0, len(p.PerType))
+	for t := range p.PerType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		parts = append(parts, t+"="+p.PerType[t])
+	}
+	return strings.Join(parts, ",")
+}
+
+func isFailOnLevel(level string) bool {
+	switch level {
+	case FailOnError, FailOnWarning, FailOnSuggestion:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFailOn(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantDefault string
+		wantPerType map[string]string
+		wantErr     string
+	}{
+		{name: "empty uses error", value: "", wantDefault: "error", wantPerType: map[string]string{}},
+		{name: "bare level", value: "warning", wantDefault: "warning", wantPerType: map[string]string{}},
+		{name: "case insensitive level", value: "Suggestion", wantDefault: "suggestion", wantPerType: map[string]string{}},
+		{
+			name:        "per-type only",
+			value:       "agents=warning,skills=error",
+			wantDefault: "error",
+			wantPerType: map[string]string{"agent": "warning", "skill": "error"},
+		},
+		{
+			name:        "default plus overrides with spaces",
+			value:       "suggestion, settings = error ,output-styles=warning",
+			wantDefault: "suggestion",
+			wantPerType: map[string]string{"settings": "error", "output-style": "warning"},
+		},
+		{name: "repeated identical default", value: "warning,warning", wantDefault: "warning", wantPerType: map[string]string{}},
+		{name: "invalid level", value: "fatal", wantErr: "invalid fail-on level"},
+		{name: "invalid per-type level", value: "agents=loud", wantErr: "invalid fail-on level"},
+		{name: "unknown type", value: "widgets=error", wantErr: "invalid fail-on type"},
+		{name: "conflicting defaults", value: "error,warning", wantErr: "conflicting default levels"},
+		{name: "conflicting type levels", value: "agent=error,agents=warning", wantErr: "conflicting levels for agent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParseFailOn(tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDefault, policy.Default)
+			assert.Equal(t, tt.wantPerType, policy.PerType)
+		})
+	}
+}
+
+func TestFailOnPolicyShouldFail(t *testing.T) {
+	policy, err := ParseFailOn("error,agents=warning,skills=suggestion")
+	require.NoError(t, err)
+
+	tests := []struct {
+		componentType         string
+		errs, warns, suggests int
+		want                  bool
+	}{
+		{"command", 0, 3, 3, false},
+		{"command", 1, 0, 0, true},
+		{"agent", 0, 1, 0, true},
+		{"agent", 0, 0, 5, false},
+		{"skill", 0, 0, 1, true},
+		{"", 0, 2, 0, false},
+		{"unknown", 1, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		got := policy.ShouldFail(tt.componentType, tt.errs, tt.warns, tt.suggests)
+		assert.Equal(t, tt.want, got, "type=%q errors=%d warnings=%d suggestions=%d",
+			tt.componentType, tt.errs, tt.warns, tt.suggests)
+	}
+}
+
+func TestFailOnPolicyString(t *testing.T) {
+	policy, err := ParseFailOn("skills=error, warning ,agents=suggestion")
+	require.NoError(t, err)
+	assert.Equal(t, "warning,agent=suggestion,skill=error", policy.String())
+
+	assert.Equal(t, "error", FailOnPolicy{}.String())
+}
+
+func TestValidateConfigFailOnMatrix(t *testing.T) {
+	config := &Config{Format: "console", FailOn: "warning,commands=error", Concurrency: 1}
+	assert.NoError(t, validateConfig(config))
+
+	config.FailOn = "warning,commands=never"
+	err := validateConfig(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid fail-on level")
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Anchor modes for fmt.anchors.
+const (
+	AnchorsPreserve = "preserve"
+	AnchorsExpand   = "expand"
+)
+
+// AnchorModes are the accepted fmt.anchors values.
+var AnchorModes = []string{AnchorsPreserve, AnchorsExpand}
+
+// FmtConfig configures cclint fmt.
+type FmtConfig struct {
+	// Anchors says what fmt does with YAML anchors, aliases, and merge keys
+	// in frontmatter: preserve (the default) keeps them, expand writes out
+	// the values they stand for. Empty means preserve.
+	Anchors string `mapstructure:"anchors"`
+}
+
+func (f FmtConfig) validate() error {
+	if f.Anchors != "" && !slices.Contains(AnchorModes, f.Anchors) {
+		return fmt.Errorf("invalid fmt.anchors %q. Must be one of: %s", f.Anchors, strings.Join(AnchorModes, ", "))
+	}
+	return nil
+}
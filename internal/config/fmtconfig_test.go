@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfigFmt(t *testing.T) {
+	for _, mode := range []string{"", AnchorsPreserve, AnchorsExpand} {
+		config := &Config{Format: "console", FailOn: "error", Concurrency: 10, Fmt: FmtConfig{Anchors: mode}}
+		assert.NoError(t, validateConfig(config), mode)
+	}
+
+	config := &Config{Format: "console", FailOn: "error", Concurrency: 10, Fmt: FmtConfig{Anchors: "inline"}}
+	assert.ErrorContains(t, validateConfig(config), `invalid fmt.anchors "inline"`)
+}
+
+func TestLoadConfigFmtYAML(t *testing.T) {
+	resetViper()
+	tmpDir := setupTestDir(t)
+
+	config, err := LoadConfig(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, AnchorsPreserve, config.Fmt.Anchors, "default")
+
+	resetViper()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".cclintrc.yaml"), []byte("fmt:\n  anchors: expand\n"), 0644))
+	config, err = LoadConfig(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, AnchorsExpand, config.Fmt.Anchors)
+}
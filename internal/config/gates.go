@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+// MinScoreDefault is the minScore key whose threshold applies to component
+// types without their own entry.
+const MinScoreDefault = "default"
+
+// Gates is the gates block: CI quality gates the orchestrator evaluates
+// after linting. A failed gate exits with its own code, ahead of --fail-on.
+//
+//	gates:
+//	  maxErrors: 0
+//	  maxWarnings: 20
+//	  minScore:
+//	    agents: 70
+//	    default: 60
+//	  noNewIssues: true
+//
+// Limits left unset are not checked. minScore keys accept the same type
+// spellings as the CLI type filters, plus "default".
+type Gates struct {
+	MaxErrors      *int           `mapstructure:"maxErrors"`
+	MaxWarnings    *int           `mapstructure:"maxWarnings"`
+	MaxSuggestions *int           `mapstructure:"maxSuggestions"`
+	MinScore       map[string]int `mapstructure:"minScore"`
+	// NoNewIssues fails the run when it finds issues the baseline does not
+	// list, whether or not --baseline is given.
+	NoNewIssues bool `mapstructure:"noNewIssues"`
+}
+
+// Enabled reports whether any gate is configured.
+func (g Gates) Enabled() bool {
+	return g.MaxErrors != nil || g.MaxWarnings != nil || g.MaxSuggestions != nil ||
+		len(g.MinScore) > 0 || g.NoNewIssues
+}
+
+// MinScoreFor returns the minimum quality score for componentType, a
+// discovery.FileType name, falling back to the default entry.
+func (g Gates) MinScoreFor(componentType string) (int, bool) {
+	for key, score := range g.MinScore {
+		if key != MinScoreDefault && canonicalFileType(key) == componentType {
+			return score, true
+		}
+	}
+	score, ok := g.MinScore[MinScoreDefault]
+	return score, ok
+}
+
+// validate checks limits are non-negative and minScore entries name known
+// types with scores from 0 to 100.
+func (g Gates) validate() error {
+	limits := map[string]*int{
+		"maxErrors":      g.MaxErrors,
+		"maxWarnings":    g.MaxWarnings,
+		"maxSuggestions": g.MaxSuggestions,
+	}
+	for _, name := range []string{"maxErrors", "maxWarnings", "maxSuggestions"} {
+		if v := limits[name]; v != nil && *v < 0 {
+			return fmt.Errorf("invalid gates.%s %d: must not be negative", name, *v)
+		}
+	}
+
+	keys := make([]string, 0, len(g.MinScore))
+	for key := range g.MinScore {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	seen := make(map[string]string)
+	for _, key := range keys {
+		if score := g.MinScore[key]; score < 0 || score > 100 {
+			return fmt.Errorf("invalid gates.minScore.%s %d: must be between 0 and 100", key, score)
+		}
+		if key == MinScoreDefault {
+			continue
+		}
+		ft, err := discovery.ParseFileType(key)
+		if err != nil {
+			return fmt.Errorf("invalid gates.minScore type: %w", err)
+		}
+		if prev, ok := seen[ft.String()]; ok {
+			return fmt.Errorf("invalid gates.minScore: %s and %s name the same type", prev, key)
+		}
+		seen[ft.String()] = key
+	}
+	return nil
+}
+
+// canonicalFileType normalizes a type spelling to its discovery.FileType
+// name, leaving unknown names unchanged.
+func canonicalFileType(name string) string {
+	if ft, err := discovery.ParseFileType(name); err == nil {
+		return ft.String()
+	}
+	return name
+}
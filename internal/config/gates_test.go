@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatesMinScoreFor(t *testing.T) {
+	gates := Gates{MinScore: map[string]int{"agents": 70, "output-styles": 50, MinScoreDefault: 60}}
+
+	tests := []struct {
+		componentType string
+		want          int
+		wantOK        bool
+	}{
+		{"agent", 70, true},
+		{"output-style", 50, true},
+		{"skill", 60, true},
+	}
+	for _, tt := range tests {
+		got, ok := gates.MinScoreFor(tt.componentType)
+		assert.Equal(t, tt.want, got, tt.componentType)
+		assert.Equal(t, tt.wantOK, ok, tt.componentType)
+	}
+
+	_, ok := Gates{MinScore: map[string]int{"agents": 70}}.MinScoreFor("skill")
+	assert.False(t, ok, "no default entry")
+}
+
+func TestGatesEnabled(t *testing.T) {
+	zero := 0
+	assert.False(t, Gates{}.Enabled())
+	assert.True(t, Gates{MaxErrors: &zero}.Enabled())
+	assert.True(t, Gates{NoNewIssues: true}.Enabled())
+	assert.True(t, Gates{MinScore: map[string]int{"default": 50}}.Enabled())
+}
+
+func TestValidateConfigGates(t *testing.T) {
+	zero, twenty, negative := 0, 20, -1
+	tests := []struct {
+		name    string
+		gates   Gates
+		wantErr string
+	}{
+		{name: "valid", gates: Gates{MaxErrors: &zero, MaxWarnings: &twenty, MinScore: map[string]int{"agents": 70, "default": 0}, NoNewIssues: true}},
+		{name: "negative limit", gates: Gates{MaxWarnings: &negative}, wantErr: "invalid gates.maxWarnings -1"},
+		{name: "score too high", gates: Gates{MinScore: map[string]int{"skills": 101}}, wantErr: "invalid gates.minScore.skills 101"},
+		{name: "unknown type", gates: Gates{MinScore: map[string]int{"widgets": 50}}, wantErr: "invalid gates.minScore type"},
+		{name: "same type twice", gates: Gates{MinScore: map[string]int{"agent": 50, "agents": 60}}, wantErr: "agent and agents name the same type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Format: "console", FailOn: "error", Concurrency: 10, Gates: tt.gates}
+			err := validateConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoadConfigGatesYAML(t *testing.T) {
+	resetViper()
+	tmpDir := setupTestDir(t)
+
+	yamlContent := `gates:
+  maxErrors: 0
+  maxWarnings: 20
+  minScore:
+    agents: 70
+    default: 60
+  noNewIssues: true
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".cclintrc.yaml"), []byte(yamlContent), 0644))
+
+	config, err := LoadConfig(tmpDir)
+	require.NoError(t, err)
+	require.NotNil(t, config.Gates.MaxErrors)
+	assert.Equal(t, 0, *config.Gates.MaxErrors)
+	require.NotNil(t, config.Gates.MaxWarnings)
+	assert.Equal(t, 20, *config.Gates.MaxWarnings)
+	assert.Nil(t, config.Gates.MaxSuggestions)
+	assert.Equal(t, map[string]int{"agents": 70, "default": 60}, config.Gates.MinScore)
+	assert.True(t, config.Gates.NoNewIssues)
+}
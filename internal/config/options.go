@@ -0,0 +1,207 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/types"
+)
+
+// Options are the run settings a caller layers over the config file. The
+// CLI maps its flags onto them; the LSP, watch mode, and embedders fill
+// them in directly instead of faking flag state. The zero Options keeps
+// everything the config file and CCLINT_* variables say.
+type Options struct {
+	// Root is the project root; empty lets LoadConfig choose one.
+	Root string
+	// Version is the cclint version reported in output, and Commit and
+	// BuildDate the commit and date of its build.
+	Version   string
+	Commit    string
+	BuildDate string
+	// DocsURLTemplate is the rule documentation URL template of the build.
+	DocsURLTemplate string
+
+	// Format, Output, FailOn, Verbosity, GroupBy, and Color replace their
+	// config values when non-empty.
+	Format    string
+	Output    string
+	FailOn    string
+	Verbosity Verbosity
+	GroupBy   string
+	Color     string
+	// Show replaces the show filter when non-nil.
+	Show []string
+	// MaxIssuesPerFile and Snippets replace their config values when set.
+	MaxIssuesPerFile *int
+	Snippets         *bool
+
+	// ShowScores, ShowImprovements, NoCycleCheck, and Untrusted turn their
+	// setting on; false keeps the config value.
+	ShowScores       bool
+	ShowImprovements bool
+	NoCycleCheck     bool
+	Untrusted        bool
+
+	// EnableCategories and DisableCategories turn rule categories on or
+	// off over rules.categories.
+	EnableCategories  []string
+	DisableCategories []string
+	// RuleSeverities re-grades rules in every file, after the overrides
+	// block: rule ID -> error, warning, suggestion, or off.
+	RuleSeverities map[string]string
+	// Gates replaces the gates block when non-nil.
+	Gates *Gates
+}
+
+// OptionError reports an invalid Options field. Option names the field as
+// written in the config file, so callers can map it to their own spelling
+// (the CLI reports the matching flag).
+type OptionError struct {
+	Option string
+	Err    error
+}
+
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Option, e.Err)
+}
+
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks every set option, independently of any config file.
+func (o Options) Validate() error {
+	invalid := func(option string, err error) error {
+		return &OptionError{Option: option, Err: err}
+	}
+	oneOf := func(option, value string, accepted []string) error {
+		if value == "" || slices.Contains(accepted, value) {
+			return nil
+		}
+		return invalid(option, fmt.Errorf("%s. Must be one of: %s", value, strings.Join(accepted, ", ")))
+	}
+
+	if err := oneOf("format", o.Format, Formats); err != nil {
+		return err
+	}
+	if _, err := ParseFailOn(o.FailOn); err != nil {
+		return invalid("failOn", err)
+	}
+	if _, err := ParseVerbosity(string(o.Verbosity)); err != nil {
+		return invalid("verbosity", err)
+	}
+	if _, err := ParseShow(o.Show); err != nil {
+		return invalid("show", err)
+	}
+	if err := oneOf("groupBy", o.GroupBy, GroupByModes); err != nil {
+		return err
+	}
+	if err := oneOf("color", o.Color, ColorModes); err != nil {
+		return err
+	}
+	if o.MaxIssuesPerFile != nil && *o.MaxIssuesPerFile < 0 {
+		return invalid("maxIssuesPerFile", errors.New("must not be negative"))
+	}
+
+	if err := ValidateCategories(o.EnableCategories); err != nil {
+		return invalid("enableCategories", err)
+	}
+	if err := ValidateCategories(o.DisableCategories); err != nil {
+		return invalid("disableCategories", err)
+	}
+	for _, c := range o.EnableCategories {
+		if slices.Contains(o.DisableCategories, c) {
+			return invalid("enableCategories", fmt.Errorf("category %q is both enabled and disabled", c))
+		}
+	}
+	if err := validateSeverities(o.RuleSeverities); err != nil {
+		return invalid("ruleSeverities", err)
+	}
+	if o.Gates != nil {
+		if err := o.Gates.validate(); err != nil {
+			return invalid("gates", err)
+		}
+	}
+	return nil
+}
+
+// Apply layers the options over cfg. Call Validate first; Load does both.
+func (o Options) Apply(cfg *Config) {
+	setString := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	setString(&cfg.Root, o.Root)
+	setString(&cfg.Version, o.Version)
+	setString(&cfg.Commit, o.Commit)
+	setString(&cfg.BuildDate, o.BuildDate)
+	setString(&cfg.DocsURLTemplate, o.DocsURLTemplate)
+	setString(&cfg.Format, o.Format)
+	setString(&cfg.Output, o.Output)
+	setString(&cfg.FailOn, o.FailOn)
+	setString(&cfg.GroupBy, o.GroupBy)
+	setString(&cfg.Color, o.Color)
+	if o.Verbosity != "" {
+		cfg.Verbosity = o.Verbosity
+	}
+	if o.Show != nil {
+		cfg.Show = o.Show
+	}
+	if o.MaxIssuesPerFile != nil {
+		cfg.MaxIssuesPerFile = *o.MaxIssuesPerFile
+	}
+	if o.Snippets != nil {
+		cfg.Snippets = *o.Snippets
+	}
+	cfg.ShowScores = cfg.ShowScores || o.ShowScores
+	cfg.ShowImprovements = cfg.ShowImprovements || o.ShowImprovements
+	cfg.NoCycleCheck = cfg.NoCycleCheck || o.NoCycleCheck
+	cfg.Untrusted = cfg.Untrusted || o.Untrusted
+
+	if len(o.EnableCategories)+len(o.DisableCategories) > 0 {
+		if cfg.Rules.Categories == nil {
+			cfg.Rules.Categories = make(map[string]bool)
+		}
+		for _, c := range o.EnableCategories {
+			cfg.Rules.Categories[c] = true
+		}
+		for _, c := range o.DisableCategories {
+			cfg.Rules.Categories[c] = false
+		}
+	}
+	if len(o.RuleSeverities) > 0 {
+		cfg.Overrides = append(cfg.Overrides, Override{Files: []string{"**"}, Severity: o.RuleSeverities})
+	}
+	if o.Gates != nil {
+		cfg.Gates = *o.Gates
+	}
+
+	// The audited tree's own config cannot weaken untrusted mode: the
+	// security category is forced on and overrides of security rules are
+	// ignored.
+	if cfg.Untrusted {
+		if cfg.Rules.Categories == nil {
+			cfg.Rules.Categories = make(map[string]bool)
+		}
+		cfg.Rules.Categories[types.CategorySecurity] = true
+		cfg.Overrides = cfg.Overrides.WithoutCategory(types.CategorySecurity)
+	}
+}
+
+// Load validates opts, loads the configuration for opts.Root, and layers
+// opts over it. Invalid options are reported as an *OptionError.
+func Load(opts Options) (*Config, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	cfg, err := LoadConfig(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	opts.Apply(cfg)
+	return cfg, nil
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	negative := -1
+	tests := []struct {
+		name       string
+		opts       Options
+		wantOption string
+	}{
+		{name: "zero options"},
+		{name: "valid", opts: Options{Format: "json", FailOn: "warning,agents=error", Verbosity: VerbosityQuiet, Show: []string{"errors"}, GroupBy: "rule", Color: "never"}},
+		{name: "bad format", opts: Options{Format: "xml"}, wantOption: "format"},
+		{name: "bad fail-on", opts: Options{FailOn: "fatal"}, wantOption: "failOn"},
+		{name: "bad verbosity", opts: Options{Verbosity: "loud"}, wantOption: "verbosity"},
+		{name: "bad show", opts: Options{Show: []string{"everything"}}, wantOption: "show"},
+		{name: "bad group-by", opts: Options{GroupBy: "owner"}, wantOption: "groupBy"},
+		{name: "bad color", opts: Options{Color: "sometimes"}, wantOption: "color"},
+		{name: "negative max issues", opts: Options{MaxIssuesPerFile: &negative}, wantOption: "maxIssuesPerFile"},
+		{name: "unknown category", opts: Options{DisableCategories: []string{"speed"}}, wantOption: "disableCategories"},
+		{name: "category enabled and disabled", opts: Options{EnableCategories: []string{"style"}, DisableCategories: []string{"style"}}, wantOption: "enableCategories"},
+		{name: "unknown rule", opts: Options{RuleSeverities: map[string]string{"orphan-skill": "off"}}, wantOption: "ruleSeverities"},
+		{name: "bad rule severity", opts: Options{RuleSeverities: map[string]string{"orphaned-skill": "fatal"}}, wantOption: "ruleSeverities"},
+		{name: "bad gates", opts: Options{Gates: &Gates{MaxErrors: &negative}}, wantOption: "gates"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantOption == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var optErr *OptionError
+			require.True(t, errors.As(err, &optErr), "error %v is not an *OptionError", err)
+			assert.Equal(t, tt.wantOption, optErr.Option)
+			assert.ErrorContains(t, err, "invalid "+tt.wantOption+": ")
+		})
+	}
+}
+
+func TestOptionsApply(t *testing.T) {
+	maxIssues, snippets := 3, false
+	cfg := &Config{
+		Format:     "console",
+		FailOn:     "error",
+		GroupBy:    "file",
+		ShowScores: true,
+		Snippets:   true,
+		Rules:      RulesConfig{Categories: map[string]bool{"style": false}},
+		Overrides:  Overrides{{Files: []string{"plugins/**"}, Severity: map[string]string{"terminology": "error"}}},
+	}
+	Options{
+		Format:            "json",
+		MaxIssuesPerFile:  &maxIssues,
+		Snippets:          &snippets,
+		EnableCategories:  []string{"style"},
+		DisableCategories: []string{"performance"},
+		RuleSeverities:    map[string]string{"terminology": "off"},
+		Gates:             &Gates{NoNewIssues: true},
+	}.Apply(cfg)
+
+	assert.Equal(t, "json", cfg.Format)
+	assert.Equal(t, "error", cfg.FailOn, "empty options keep the config value")
+	assert.Equal(t, "file", cfg.GroupBy)
+	assert.True(t, cfg.ShowScores, "false options keep the config value")
+	assert.Equal(t, 3, cfg.MaxIssuesPerFile)
+	assert.False(t, cfg.Snippets)
+	assert.Equal(t, map[string]bool{"style": true, "performance": false}, cfg.Rules.Categories)
+	assert.Equal(t, "off", cfg.Overrides.For("plugins/p/agents/a.md")["terminology"], "rule severities win over the overrides block")
+	assert.True(t, cfg.Gates.NoNewIssues)
+}
+
+func TestOptionsApplyUntrusted(t *testing.T) {
+	cfg := &Config{
+		Rules:     RulesConfig{Categories: map[string]bool{"security": false}},
+		Overrides: Overrides{{Files: []string{"**"}, Severity: map[string]string{"hook-network-access": "off", "terminology": "off"}}},
+	}
+	Options{Untrusted: true, RuleSeverities: map[string]string{"hook-network-access": "suggestion"}}.Apply(cfg)
+
+	assert.True(t, cfg.Untrusted)
+	assert.True(t, cfg.Rules.Categories["security"])
+	assert.Equal(t, map[string]string{"terminology": "off"}, cfg.Overrides.For("agents/a.md"))
+}
+
+func TestLoad(t *testing.T) {
+	resetViper()
+	tmpDir := setupTestDir(t)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".cclintrc.yaml"), []byte("format: markdown\ngroupBy: rule\n"), 0644))
+
+	cfg, err := Load(Options{Root: tmpDir, GroupBy: "severity"})
+	require.NoError(t, err)
+	assert.Equal(t, "markdown", cfg.Format)
+	assert.Equal(t, "severity", cfg.GroupBy)
+
+	_, err = Load(Options{Root: tmpDir, Format: "xml"})
+	var optErr *OptionError
+	assert.True(t, errors.As(err, &optErr))
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/dotcommander/cclint/internal/types"
+)
+
+// SeverityOff turns a rule off in an override.
+const SeverityOff = "off"
+
+// OverrideSeverities are the accepted severities in overrides.
+var OverrideSeverities = []string{types.SeverityError, types.SeverityWarning, types.SeveritySuggestion, SeverityOff}
+
+// Override changes the severity of rules, or turns them off, for files
+// matching any of its glob patterns. Patterns use doublestar syntax and
+// match paths relative to the project root, e.g. "plugins/**".
+type Override struct {
+	Files    []string          `mapstructure:"files"`
+	Severity map[string]string `mapstructure:"severity"` // rule ID -> severity or "off"
+}
+
+// Overrides are applied in order; for a file matched by several, the last
+// one to mention a rule decides its severity.
+type Overrides []Override
+
+// For returns the rule severities the overrides set for relPath, a path
+// relative to the project root. It returns nil when no override matches.
+func (o Overrides) For(relPath string) map[string]string {
+	relPath = filepath.ToSlash(relPath)
+	var severities map[string]string
+	for _, override := range o {
+		if !override.matches(relPath) {
+			continue
+		}
+		if severities == nil {
+			severities = make(map[string]string)
+		}
+		for rule, severity := range override.Severity {
+			severities[rule] = severity
+		}
+	}
+	return severities
+}
+
+func (o Override) matches(relPath string) bool {
+	for _, pattern := range o.Files {
+		if ok, err := doublestar.Match(pattern, relPath); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks that every override has files, valid patterns, known
+// rule IDs, and accepted severities.
+func (o Overrides) validate() error {
+	for i, override := range o {
+		if len(override.Files) == 0 {
+			return fmt.Errorf("overrides[%d] needs files", i)
+		}
+		for _, pattern := range override.Files {
+			if !doublestar.ValidatePattern(pattern) {
+				return fmt.Errorf("overrides[%d]: invalid files pattern %q", i, pattern)
+			}
+		}
+		if err := validateSeverities(override.Severity); err != nil {
+			return fmt.Errorf("overrides[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateSeverities checks that a rule -> severity map names known rules
+// and accepted severities.
+func validateSeverities(severity map[string]string) error {
+	for rule, s := range severity {
+		if _, ok := types.RuleCategories[rule]; !ok {
+			return fmt.Errorf("unknown rule %q", rule)
+		}
+		if !slices.Contains(OverrideSeverities, s) {
+			return fmt.Errorf("invalid severity %q for %s. Must be one of: %s", s, rule, strings.Join(OverrideSeverities, ", "))
+		}
+	}
+	return nil
+}
+
+// WithoutCategory returns a copy of the overrides with the rules of
+// category left out, so that those rules keep their own severities.
+func (o Overrides) WithoutCategory(category string) Overrides {
+	out := make(Overrides, 0, len(o))
+	for _, override := range o {
+		severity := make(map[string]string, len(override.Severity))
+		for rule, s := range override.Severity {
+			if !slices.Contains(types.RuleCategories[rule], category) {
+				severity[rule] = s
+			}
+		}
+		out = append(out, Override{Files: override.Files, Severity: severity})
+	}
+	return out
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverridesFor(t *testing.T) {
+	overrides := Overrides{
+		{Files: []string{"plugins/**"}, Severity: map[string]string{"orphaned-skill": "error", "terminology": "off"}},
+		{Files: []string{"plugins/legacy/**", "**/*.draft.md"}, Severity: map[string]string{"orphaned-skill": "suggestion"}},
+	}
+
+	tests := []struct {
+		path string
+		want map[string]string
+	}{
+		{path: "agents/a.md", want: nil},
+		{path: "plugins/x/skills/s/SKILL.md", want: map[string]string{"orphaned-skill": "error", "terminology": "off"}},
+		{path: "plugins/legacy/skills/s/SKILL.md", want: map[string]string{"orphaned-skill": "suggestion", "terminology": "off"}},
+		{path: "agents/new.draft.md", want: map[string]string{"orphaned-skill": "suggestion"}},
+		{path: filepath.Join("plugins", "y", "agents", "a.md"), want: map[string]string{"orphaned-skill": "error", "terminology": "off"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, overrides.For(tt.path))
+		})
+	}
+}
+
+func TestOverridesWithoutCategory(t *testing.T) {
+	overrides := Overrides{
+		{Files: []string{"**"}, Severity: map[string]string{"hook-network-access": "off", "terminology": "off"}},
+	}
+	got := overrides.WithoutCategory("security")
+	assert.Equal(t, map[string]string{"terminology": "off"}, got[0].Severity)
+	assert.Equal(t, []string{"**"}, got[0].Files)
+	assert.Len(t, overrides[0].Severity, 2, "the original overrides are unchanged")
+}
+
+func TestValidateConfigOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides Overrides
+		wantErr   string
+	}{
+		{name: "valid", overrides: Overrides{{Files: []string{"plugins/**"}, Severity: map[string]string{"orphaned-skill": "error", "terminology": "off"}}}},
+		{name: "no files", overrides: Overrides{{Severity: map[string]string{"orphaned-skill": "error"}}}, wantErr: "overrides[0] needs files"},
+		{name: "bad pattern", overrides: Overrides{{Files: []string{"plugins/[a"}}}, wantErr: `invalid files pattern "plugins/[a"`},
+		{name: "unknown rule", overrides: Overrides{{Files: []string{"*"}, Severity: map[string]string{"orphan-skill": "error"}}}, wantErr: `unknown rule "orphan-skill"`},
+		{name: "bad severity", overrides: Overrides{{Files: []string{"*"}, Severity: map[string]string{"orphaned-skill": "fatal"}}}, wantErr: `invalid severity "fatal"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Format: "console", FailOn: "error", Concurrency: 10, Overrides: tt.overrides}
+			err := validateConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// TestLoadConfigOverridesYAML tests that a single files pattern is accepted
+// as well as a list
+func TestLoadConfigOverridesYAML(t *testing.T) {
+	resetViper()
+	tmpDir := setupTestDir(t)
+
+	yamlContent := `overrides:
+  - files: "plugins/**"
+    severity:
+      orphaned-skill: error
+  - files: ["agents/**", "commands/**"]
+    severity:
+      terminology: off
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".cclintrc.yaml"), []byte(yamlContent), 0644))
+
+	config, err := LoadConfig(tmpDir)
+	require.NoError(t, err)
+	require.Len(t, config.Overrides, 2)
+	assert.Equal(t, []string{"plugins/**"}, config.Overrides[0].Files)
+	assert.Equal(t, map[string]string{"orphaned-skill": "error"}, config.Overrides[0].Severity)
+	assert.Equal(t, []string{"agents/**", "commands/**"}, config.Overrides[1].Files)
+	assert.Equal(t, map[string]string{"terminology": "off"}, config.Overrides[1].Severity)
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+// TypeOverride forces the component type of every file its pattern matches:
+// the bulk form of --type, for nonstandard directory layouts.
+//
+//	typeOverrides:
+//	  - pattern: "prompts/**/*.md"
+//	    type: agent
+//
+// Patterns are doublestar globs relative to the root. Overrides are tried in
+// order ahead of fileTypes.custom and the built-in patterns, so they win
+// even where a built-in pattern assigns another type.
+type TypeOverride struct {
+	Pattern string `mapstructure:"pattern"`
+	Type    string `mapstructure:"type"`
+}
+
+// TypeOverrides is the typeOverrides config block.
+type TypeOverrides []TypeOverride
+
+// entries converts the overrides to discovery registry entries, one per
+// override so their order is kept.
+func (o TypeOverrides) entries() ([]discovery.FileTypeEntry, error) {
+	entries := make([]discovery.FileTypeEntry, 0, len(o))
+	for i, override := range o {
+		if strings.TrimSpace(override.Pattern) == "" {
+			return nil, fmt.Errorf("typeOverrides[%d] needs a pattern", i)
+		}
+		if !doublestar.ValidatePattern(override.Pattern) {
+			return nil, fmt.Errorf("typeOverrides[%d]: invalid pattern %q", i, override.Pattern)
+		}
+		ft, err := discovery.ParseFileType(override.Type)
+		if err != nil {
+			return nil, fmt.Errorf("typeOverrides[%d]: %w", i, err)
+		}
+		entries = append(entries, discovery.FileTypeEntry{Type: ft, Patterns: []string{override.Pattern}})
+	}
+	return entries, nil
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfigTypeOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides TypeOverrides
+		wantErr   string
+	}{
+		{name: "valid", overrides: TypeOverrides{{Pattern: "prompts/**/*.md", Type: "agents"}}},
+		{name: "no pattern", overrides: TypeOverrides{{Type: "agent"}}, wantErr: "typeOverrides[0] needs a pattern"},
+		{name: "bad pattern", overrides: TypeOverrides{{Pattern: "prompts/{a", Type: "agent"}}, wantErr: `typeOverrides[0]: invalid pattern "prompts/{a"`},
+		{name: "unknown type", overrides: TypeOverrides{{Pattern: "x/*.md", Type: "prompt"}}, wantErr: "typeOverrides[0]: invalid type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Format: "console", FailOn: "error", Concurrency: 10, TypeOverrides: tt.overrides}
+			err := validateConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDiscoveryRegistryTypeOverrides(t *testing.T) {
+	cfg := &Config{
+		TypeOverrides: TypeOverrides{
+			{Pattern: "agents/templates/*.md", Type: "command"},
+			{Pattern: "prompts/**/*.md", Type: "agent"},
+		},
+		FileTypes: FileTypesConfig{Custom: []CustomFileType{{Type: "skill", Patterns: []string{"prompts/skills/*.md"}}}},
+	}
+	registry, err := cfg.DiscoveryRegistry()
+	require.NoError(t, err)
+
+	root := t.TempDir()
+	tests := []struct {
+		path string
+		want discovery.FileType
+	}{
+		{"agents/templates/deploy.md", discovery.FileTypeCommand},
+		{"agents/reviewer.md", discovery.FileTypeAgent},
+		{"prompts/skills/x.md", discovery.FileTypeAgent},
+		{"prompts/review.md", discovery.FileTypeAgent},
+	}
+	for _, tt := range tests {
+		got, err := discovery.DetectFileTypeWithRegistry(filepath.Join(root, tt.path), root, registry)
+		require.NoError(t, err, tt.path)
+		assert.Equal(t, tt.want, got, tt.path)
+	}
+}
+
+func TestLoadConfigTypeOverridesYAML(t *testing.T) {
+	resetViper()
+	tmpDir := setupTestDir(t)
+
+	yamlContent := `typeOverrides:
+  - pattern: "prompts/**/*.md"
+    type: agent
+  - pattern: "workflows/*.md"
+    type: command
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".cclintrc.yaml"), []byte(yamlContent), 0644))
+
+	config, err := LoadConfig(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, TypeOverrides{
+		{Pattern: "prompts/**/*.md", Type: "agent"},
+		{Pattern: "workflows/*.md", Type: "command"},
+	}, config.TypeOverrides)
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Verbosity is how much non-finding output cclint prints: progress,
+// summaries, score breakdowns, and source tags. Which findings are listed is
+// chosen separately by Show, and neither affects the exit code.
+type Verbosity string
+
+// Verbosity levels, from least to most output.
+const (
+	VerbosityQuiet   Verbosity = "quiet"
+	VerbosityNormal  Verbosity = "normal"
+	VerbosityVerbose Verbosity = "verbose"
+)
+
+// VerbosityLevels are the accepted verbosity values.
+var VerbosityLevels = []Verbosity{VerbosityQuiet, VerbosityNormal, VerbosityVerbose}
+
+// ShowLevels are the accepted show values. Info findings are reported with
+// suggestions but can be shown or hidden on their own.
+var ShowLevels = []string{"errors", "warnings", "suggestions", "info"}
+
+// ParseVerbosity validates a verbosity value. Empty means VerbosityNormal.
+func ParseVerbosity(s string) (Verbosity, error) {
+	if s == "" {
+		return VerbosityNormal, nil
+	}
+	if v := Verbosity(s); slices.Contains(VerbosityLevels, v) {
+		return v, nil
+	}
+	return "", fmt.Errorf("invalid verbosity %q: must be quiet, normal, or verbose", s)
+}
+
+// ParseShow validates show values, accepting comma-separated lists, and
+// returns them lowercased without duplicates. Empty input yields nil, which
+// leaves the choice to the verbosity level.
+func ParseShow(values []string) ([]string, error) {
+	var show []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.ToLower(strings.TrimSpace(part))
+			if part == "" || slices.Contains(show, part) {
+				continue
+			}
+			if !slices.Contains(ShowLevels, part) {
+				return nil, fmt.Errorf("invalid show value %q: must be one of %s", part, strings.Join(ShowLevels, ", "))
+			}
+			show = append(show, part)
+		}
+	}
+	return show, nil
+}
+
+// Quiet reports whether output is limited to the exit code.
+func (c *Config) Quiet() bool {
+	return c.Verbosity == VerbosityQuiet
+}
+
+// Verbose reports whether verbose output is enabled.
+func (c *Config) Verbose() bool {
+	return c.Verbosity == VerbosityVerbose
+}
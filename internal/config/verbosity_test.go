@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseVerbosity(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Verbosity
+		wantErr bool
+	}{
+		{in: "", want: VerbosityNormal},
+		{in: "quiet", want: VerbosityQuiet},
+		{in: "normal", want: VerbosityNormal},
+		{in: "verbose", want: VerbosityVerbose},
+		{in: "debug", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseVerbosity(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseVerbosity(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseVerbosity(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseShow(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "none", in: nil, want: nil},
+		{name: "list", in: []string{"errors", "warnings"}, want: []string{"errors", "warnings"}},
+		{name: "comma separated with duplicates", in: []string{"Errors, info", "errors"}, want: []string{"errors", "info"}},
+		{name: "singular is rejected", in: []string{"error"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseShow(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseShow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseShow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfigVerbosityAndShow(t *testing.T) {
+	base := func() *Config {
+		return &Config{Format: "console", FailOn: "error", Concurrency: 1}
+	}
+
+	cfg := base()
+	cfg.Verbosity = "loud"
+	if err := validateConfig(cfg); err == nil {
+		t.Error("validateConfig() accepted verbosity \"loud\"")
+	}
+
+	cfg = base()
+	cfg.Show = []string{"errors", "hints"}
+	if err := validateConfig(cfg); err == nil {
+		t.Error("validateConfig() accepted show value \"hints\"")
+	}
+
+	cfg = base()
+	cfg.Verbosity = VerbosityQuiet
+	cfg.Show = []string{"warnings"}
+	if err := validateConfig(cfg); err != nil {
+		t.Errorf("validateConfig() error = %v", err)
+	}
+}
+
+func TestLoadConfigVerbosityOverridesLegacyKeys(t *testing.T) {
+	dir := t.TempDir()
+	rc := `{"verbosity": "verbose", "quiet": true, "show": ["errors", "info"]}`
+	if err := os.WriteFile(filepath.Join(dir, ".cclintrc.json"), []byte(rc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Verbosity != VerbosityVerbose || !cfg.Verbose() || cfg.Quiet() {
+		t.Errorf("Verbosity = %q, want verbose", cfg.Verbosity)
+	}
+	if !slices.Equal(cfg.Show, []string{"errors", "info"}) {
+		t.Errorf("Show = %v, want [errors info]", cfg.Show)
+	}
+}
@@ -0,0 +1,126 @@
+package crossfile
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// agentName returns the name Task() resolves an agent by: its frontmatter
+// name, or the file name when the field is missing.
+func (v *CrossFileValidator) agentName(f discovery.File) string {
+	if name, ok := v.frontmatterOf(f)["name"].(string); ok && strings.TrimSpace(name) != "" {
+		return strings.TrimSpace(name)
+	}
+	return ExtractAgentName(f.RelPath)
+}
+
+// taskCall formats how an agent is invoked.
+func taskCall(name string) string {
+	return "Task(" + name + ")"
+}
+
+// agentScope describes where an agent file comes from: "plugin <name>" for
+// agents shipped in a plugin cache, otherwise "project".
+func agentScope(relPath string) string {
+	parts := strings.Split(filepath.ToSlash(relPath), "/")
+	for i, part := range parts {
+		// plugins/cache/<marketplace>/<plugin>/...
+		if part == "cache" && i > 0 && parts[i-1] == "plugins" && i+2 < len(parts) {
+			return "plugin " + parts[i+2]
+		}
+	}
+	return "project"
+}
+
+// validateAgentCollisions warns when another agent resolves by the same
+// name, whether in the project, a plugin, or the user scope
+// (~/.claude/agents), since Task() then picks one of them silently; and when
+// an agent in the same directory uses the same display color. A project
+// agent and a plugin agent of the same name are reported as shadowing,
+// with the project agent taking precedence.
+func (v *CrossFileValidator) validateAgentCollisions(filePath, contents string, frontmatter map[string]any) []cue.ValidationError {
+	self := filepath.ToSlash(filePath)
+	var current discovery.File
+	found := false
+	for _, f := range v.allAgents {
+		if filepath.ToSlash(f.RelPath) == self {
+			current, found = f, true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	name := v.agentName(current)
+	scope := agentScope(current.RelPath)
+	color, _ := frontmatter["color"].(string)
+	dir := filepath.Dir(current.RelPath)
+
+	var errors []cue.ValidationError
+	for _, other := range v.allAgents {
+		if other.RelPath == current.RelPath {
+			continue
+		}
+		switch {
+		case v.agentName(other) != name:
+		case shadows(current.RelPath, other.RelPath):
+			errors = append(errors, shadowingIssue("agent", taskCall, name, filePath, other.RelPath, textutil.FindFrontmatterFieldLine(contents, "name")))
+		default:
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Agent name '%s' (%s) is also defined by %s (%s); Task(%s) resolution is ambiguous. Rename one of them", name, scope, other.RelPath, agentScope(other.RelPath), name),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentNameCollision,
+				Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
+			})
+		}
+		otherColor, _ := v.frontmatterOf(other)["color"].(string)
+		if color != "" && strings.EqualFold(otherColor, color) && filepath.Dir(other.RelPath) == dir {
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Agent color '%s' is also used by %s in the same directory; pick a distinct color so the agents are easy to tell apart", color, other.RelPath),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentColorCollision,
+				Line:     textutil.FindFrontmatterFieldLine(contents, "color"),
+			})
+		}
+	}
+
+	if scope == "project" && v.userAgentShadowed(current, name) {
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("Agent name '%s' is also defined in user scope (%s); the project agent shadows it, so Task(%s) behaves differently across machines", name, filepath.Join(v.userScopeAgentDir, name+".md"), name),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleAgentNameCollision,
+			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
+		})
+	}
+	return errors
+}
+
+// userAgentShadowed reports whether a user-scope agent file of the same name
+// exists and is not the file being linted (as it is when linting ~/.claude).
+func (v *CrossFileValidator) userAgentShadowed(current discovery.File, name string) bool {
+	if !v.hasUserAgentFile(name) {
+		return false
+	}
+	userFile, err := filepath.Abs(filepath.Join(v.userScopeAgentDir, name+".md"))
+	if err != nil {
+		return false
+	}
+	path := current.Path
+	if path == "" && v.rootPath != "" {
+		path = filepath.Join(v.rootPath, current.RelPath)
+	}
+	self, err := filepath.Abs(path)
+	return err == nil && self != userFile
+}
@@ -0,0 +1,123 @@
+package crossfile
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidateAgentCollisions(t *testing.T) {
+	agent := func(relPath, frontmatter string) discovery.File {
+		return discovery.File{RelPath: relPath, Type: discovery.FileTypeAgent, Contents: "---\n" + frontmatter + "---\nbody\n"}
+	}
+	files := []discovery.File{
+		agent("agents/reviewer.md", "name: reviewer\ncolor: blue\n"),
+		agent("agents/ops/reviewer.md", "name: reviewer\ncolor: blue\n"),
+		agent("agents/writer.md", "name: writer\ncolor: Blue\n"),
+		agent("agents/tester.md", "name: tester\ncolor: green\n"),
+		agent("agents/unnamed.md", "color: red\n"),
+		agent("plugins/cache/market/toolkit/current/agents/tester.md", "name: tester\ncolor: green\n"),
+		agent("plugins/cache/market/extras/current/agents/helper.md", "name: unnamed\n"),
+		agent("plugins/cache/market/extras/current/agents/tester.md", "name: tester\n"),
+	}
+	v := NewCrossFileValidator(files)
+	v.userScopeAgentDir = ""
+
+	tests := []struct {
+		name     string
+		filePath string
+		want     []string // rule: message fragment, in order
+	}{
+		{
+			name:     "same name in another directory",
+			filePath: "agents/ops/reviewer.md",
+			want:     []string{cue.RuleAgentNameCollision + ": also defined by agents/reviewer.md (project)"},
+		},
+		{
+			name:     "same name and same-directory color",
+			filePath: "agents/reviewer.md",
+			want: []string{
+				cue.RuleAgentNameCollision + ": also defined by agents/ops/reviewer.md",
+				cue.RuleAgentColorCollision + ": also used by agents/writer.md",
+			},
+		},
+		{
+			name:     "project agent shadows a plugin agent",
+			filePath: "agents/tester.md",
+			want: []string{
+				cue.RuleComponentShadowed + ": shadows the agent of the same name in plugin toolkit (plugins/cache/market/toolkit/current/agents/tester.md): Task(tester) runs this one, and the plugin's is reachable only as Task(toolkit:tester)",
+				cue.RuleComponentShadowed + ": in plugin extras",
+			},
+		},
+		{
+			name:     "plugin agent shadowed by a project agent",
+			filePath: "plugins/cache/market/toolkit/current/agents/tester.md",
+			want: []string{
+				cue.RuleComponentShadowed + ": Plugin toolkit's agent 'tester' is shadowed by project agent agents/tester.md",
+				cue.RuleAgentNameCollision + ": (plugin extras)",
+			},
+		},
+		{
+			name:     "file-name agent matched by plugin frontmatter name",
+			filePath: "agents/unnamed.md",
+			want:     []string{cue.RuleComponentShadowed + ": in plugin extras"},
+		},
+		{
+			name:     "same name in two plugins",
+			filePath: "plugins/cache/market/extras/current/agents/tester.md",
+			want: []string{
+				cue.RuleComponentShadowed + ": shadowed by project agent agents/tester.md",
+				cue.RuleAgentNameCollision + ": (plugin toolkit)",
+			},
+		},
+		{
+			name:     "unknown file is skipped",
+			filePath: "agents/missing.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var contents string
+			var fm map[string]any
+			for _, f := range files {
+				if f.RelPath == tt.filePath {
+					contents = f.Contents
+					fm = v.frontmatterOf(f)
+				}
+			}
+			got := v.validateAgentCollisions(tt.filePath, contents, fm)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d findings, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				rule, fragment, _ := strings.Cut(want, ": ")
+				if got[i].Rule != rule || !strings.Contains(got[i].Message, fragment) {
+					t.Errorf("finding %d = %s %q, want %s containing %q", i, got[i].Rule, got[i].Message, rule, fragment)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAgentCollisions_UserScope(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "agents/pi-agent.md", Type: discovery.FileTypeAgent, Contents: "---\nname: pi-agent\n---\nbody\n"},
+	}
+	v := NewCrossFileValidator(files, t.TempDir())
+	writeUserScopeAgentFile(t, v, "pi-agent")
+
+	got := v.validateAgentCollisions("agents/pi-agent.md", files[0].Contents, map[string]any{"name": "pi-agent"})
+	if len(got) != 1 || got[0].Rule != cue.RuleAgentNameCollision || !strings.Contains(got[0].Message, "user scope") {
+		t.Fatalf("expected a user-scope collision, got %v", got)
+	}
+
+	// Linting the user scope itself: the discovered file is the user agent.
+	v.rootPath = filepath.Dir(v.userScopeAgentDir)
+	if got := v.validateAgentCollisions("agents/pi-agent.md", files[0].Contents, map[string]any{"name": "pi-agent"}); len(got) != 0 {
+		t.Fatalf("user agent reported against itself: %v", got)
+	}
+}
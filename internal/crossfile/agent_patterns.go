@@ -0,0 +1,33 @@
+package crossfile
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+// AgentNames returns the names of every agent a reference can resolve to
+// without a plugin namespace, sorted: project agents, user-scope agents,
+// and built-in or configured subagent types.
+func (v *CrossFileValidator) AgentNames() []string {
+	names := make(map[string]bool, len(v.agents)+len(BuiltInSubagentTypes)+len(v.extraBuiltinAgents))
+	for name := range v.agents {
+		names[name] = true
+	}
+	for name := range BuiltInSubagentTypes {
+		names[name] = true
+	}
+	for _, name := range v.extraBuiltinAgents {
+		names[name] = true
+	}
+	if v.userScopeAgentDir != "" {
+		entries, _ := os.ReadDir(v.userScopeAgentDir)
+		for _, e := range entries {
+			if name, ok := strings.CutSuffix(e.Name(), ".md"); ok && !e.IsDir() {
+				names[name] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(names))
+}
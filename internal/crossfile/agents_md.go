@@ -0,0 +1,144 @@
+package crossfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// AgentsMDFile is the instructions file other coding agents read, which
+// projects often keep beside CLAUDE.md.
+const AgentsMDFile = "AGENTS.md"
+
+// mdSection is one headed section of a markdown file.
+type mdSection struct {
+	heading string
+	line    int    // 1-based line of the heading
+	body    string // non-blank lines, trimmed, up to the next heading
+}
+
+// ValidateAgentsMD compares a CLAUDE.md memory file with the AGENTS.md
+// beside it, or at the project root for .claude/CLAUDE.md. A section whose
+// heading appears in both is reported: as a duplicate when the bodies
+// match, with a suggestion to import AGENTS.md instead of copying it, and
+// as a conflict when they differ, since the two sets of instructions can
+// contradict each other. The check is skipped when no root is known or
+// there is no AGENTS.md.
+func (v *CrossFileValidator) ValidateAgentsMD(filePath, contents string) []cue.ValidationError {
+	if v.rootPath == "" {
+		return nil
+	}
+	from := filePath
+	if !filepath.IsAbs(from) {
+		from = filepath.Join(v.rootPath, from)
+	}
+	dir := filepath.Dir(from)
+	agentsDir := dir
+	if filepath.Base(dir) == ".claude" {
+		agentsDir = filepath.Dir(dir)
+	}
+	agentsPath := filepath.Join(agentsDir, AgentsMDFile)
+	raw, err := os.ReadFile(agentsPath) //nolint:gosec // G304: AGENTS.md beside the linted CLAUDE.md
+	if err != nil {
+		return nil
+	}
+
+	importRef := AgentsMDFile
+	if rel, err := filepath.Rel(dir, agentsPath); err == nil {
+		importRef = filepath.ToSlash(rel)
+	}
+	imported := false
+	for _, ref := range FindFileReferences(contents) {
+		if resolveContextImport(ref.Path, from) == agentsPath {
+			imported = true
+			break
+		}
+	}
+
+	theirs := make(map[string]mdSection)
+	for _, s := range markdownSections(string(raw)) {
+		key := strings.ToLower(s.heading)
+		if _, ok := theirs[key]; !ok {
+			theirs[key] = s
+		}
+	}
+
+	var errors []cue.ValidationError
+	for _, ours := range markdownSections(contents) {
+		other, ok := theirs[strings.ToLower(ours.heading)]
+		if !ok || ours.body == "" || other.body == "" {
+			continue
+		}
+		issue := cue.ValidationError{
+			File:   filePath,
+			Source: cue.SourceCClintObserve,
+			Line:   ours.line,
+		}
+		switch {
+		case ours.body == other.body && imported:
+			issue.Severity = cue.SeveritySuggestion
+			issue.Rule = cue.RuleContextAgentsMDDuplicate
+			issue.Message = fmt.Sprintf("Section '%s' repeats %s, which this file already imports with @%s; remove the copy", ours.heading, AgentsMDFile, importRef)
+		case ours.body == other.body:
+			issue.Severity = cue.SeveritySuggestion
+			issue.Rule = cue.RuleContextAgentsMDDuplicate
+			issue.Message = fmt.Sprintf("Section '%s' repeats %s line %d; import it with @%s instead of copying it", ours.heading, AgentsMDFile, other.line, importRef)
+		default:
+			issue.Severity = cue.SeverityWarning
+			issue.Rule = cue.RuleContextAgentsMDConflict
+			issue.Message = fmt.Sprintf("Section '%s' differs from the same section in %s line %d; keep one version, in %s, and import it with @%s so the two cannot drift apart", ours.heading, AgentsMDFile, other.line, AgentsMDFile, importRef)
+		}
+		errors = append(errors, issue)
+	}
+	return errors
+}
+
+// markdownSections splits markdown into its ## to ###### sections. The
+// document title (#) is not a section. Headings inside fenced code blocks
+// are ignored, and each body keeps its non-blank lines, trimmed, so the
+// comparison ignores indentation and spacing.
+func markdownSections(contents string) []mdSection {
+	var sections []mdSection
+	var body []string
+	flush := func() {
+		if len(sections) > 0 {
+			sections[len(sections)-1].body = strings.Join(body, "\n")
+		}
+		body = nil
+	}
+
+	inFence := false
+	for i, line := range strings.Split(contents, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(trimmed, "#") {
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			if heading := strings.TrimSpace(trimmed[level:]); level <= 6 && strings.HasPrefix(trimmed[level:], " ") && heading != "" {
+				flush()
+				if level >= 2 {
+					sections = append(sections, mdSection{heading: heading, line: i + 1})
+				} else {
+					sections = append(sections, mdSection{}) // title; collects the intro, never compared
+				}
+				continue
+			}
+		}
+		if trimmed != "" {
+			body = append(body, trimmed)
+		}
+	}
+	flush()
+
+	out := sections[:0]
+	for _, s := range sections {
+		if s.heading != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,109 @@
+package crossfile
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestValidateAgentsMD(t *testing.T) {
+	agents := "# Agents\n\nIntro.\n\n## Testing\n\nRun `go test ./...` before committing.\n\n## Style\n\nUse gofmt.\n\n```md\n## Build\n```\n"
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		file     string
+		contents string
+		want     []string // rule:line per finding, in order
+	}{
+		{
+			name:     "no AGENTS.md",
+			files:    map[string]string{},
+			file:     "CLAUDE.md",
+			contents: "## Testing\n\nRun tests.\n",
+		},
+		{
+			name:     "duplicate section",
+			files:    map[string]string{"AGENTS.md": agents},
+			file:     "CLAUDE.md",
+			contents: "# Project\n\n## Testing\n\n  Run `go test ./...` before committing.\n",
+			want:     []string{"context-agents-md-duplicate:3"},
+		},
+		{
+			name:     "conflicting section, matched case-insensitively",
+			files:    map[string]string{"AGENTS.md": agents},
+			file:     "CLAUDE.md",
+			contents: "# Project\n\n### style\n\nUse goimports.\n\n## Build\n\nmake\n",
+			want:     []string{"context-agents-md-conflict:3"},
+		},
+		{
+			name:     ".claude/CLAUDE.md compares with the root AGENTS.md",
+			files:    map[string]string{"AGENTS.md": agents},
+			file:     ".claude/CLAUDE.md",
+			contents: "## Testing\n\nRun `go test ./...` before committing.\n",
+			want:     []string{"context-agents-md-duplicate:1"},
+		},
+		{
+			name:     "empty sections are not compared",
+			files:    map[string]string{"AGENTS.md": agents},
+			file:     "CLAUDE.md",
+			contents: "## Testing\n\n## Other\n\nText.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeTree(t, root, tt.files)
+			v := NewCrossFileValidator(nil, root)
+
+			var got []string
+			for _, e := range v.ValidateAgentsMD(tt.file, tt.contents) {
+				got = append(got, fmt.Sprintf("%s:%d", e.Rule, e.Line))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateAgentsMD() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAgentsMD_Messages(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"AGENTS.md": "## Testing\n\nRun tests.\n"})
+	v := NewCrossFileValidator(nil, root)
+
+	tests := []struct {
+		name, file, contents, want string
+	}{
+		{
+			name:     "suggests the import",
+			file:     ".claude/CLAUDE.md",
+			contents: "## Testing\n\nRun tests.\n",
+			want:     "Section 'Testing' repeats AGENTS.md line 1; import it with @../AGENTS.md instead of copying it",
+		},
+		{
+			name:     "already imported",
+			file:     "CLAUDE.md",
+			contents: "@AGENTS.md\n\n## Testing\n\nRun tests.\n",
+			want:     "Section 'Testing' repeats AGENTS.md, which this file already imports with @AGENTS.md; remove the copy",
+		},
+		{
+			name:     "conflict",
+			file:     "CLAUDE.md",
+			contents: "## Testing\n\nRun all tests twice.\n",
+			want:     "Section 'Testing' differs from the same section in AGENTS.md line 1; keep one version, in AGENTS.md, and import it with @AGENTS.md so the two cannot drift apart",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := v.ValidateAgentsMD(tt.file, tt.contents)
+			if len(errs) != 1 || errs[0].Message != tt.want {
+				t.Errorf("ValidateAgentsMD() = %+v, want one finding %q", errs, tt.want)
+			}
+		})
+	}
+
+	if errs := NewCrossFileValidator(nil).ValidateAgentsMD("CLAUDE.md", "## Testing\n\nRun tests.\n"); errs != nil {
+		t.Errorf("without a project root: got %v, want nil", errs)
+	}
+}
@@ -0,0 +1,32 @@
+package crossfile
+
+import (
+	_ "embed"
+	"slices"
+
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+//go:embed catalog/agents.txt
+var builtInAgentsCatalog string
+
+// BuiltInSubagentTypes are Task() targets that exist in Claude Code's runtime,
+// not as user-defined agent files. These should not trigger "missing agent" errors.
+// Includes both built-in subagent types and model name references, read from
+// the embedded catalog/agents.txt.
+var BuiltInSubagentTypes = textutil.ParseCatalog(builtInAgentsCatalog)
+
+// WithExtraBuiltinAgents applies the extraBuiltinAgents config key: names
+// are agents the runtime provides without an agent file (subagent types of
+// a newer Claude Code, an SDK host, or an agent team setup), treated like
+// BuiltInSubagentTypes.
+func (v *CrossFileValidator) WithExtraBuiltinAgents(names []string) *CrossFileValidator {
+	v.extraBuiltinAgents = names
+	return v
+}
+
+// isBuiltinAgent reports whether name is a built-in or configured subagent
+// type.
+func (v *CrossFileValidator) isBuiltinAgent(name string) bool {
+	return BuiltInSubagentTypes[name] || slices.Contains(v.extraBuiltinAgents, name)
+}
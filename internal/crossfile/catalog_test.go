@@ -0,0 +1,21 @@
+package crossfile
+
+import (
+	"testing"
+)
+
+func TestWithExtraBuiltinAgents(t *testing.T) {
+	v := NewCrossFileValidator(nil).WithExtraBuiltinAgents([]string{"lead", "sdk-worker"})
+	for _, name := range []string{"general-purpose", "Explore", "opus", "lead", "sdk-worker"} {
+		if !v.isBuiltinAgent(name) {
+			t.Errorf("isBuiltinAgent(%q) = false, want true", name)
+		}
+	}
+
+	if BuiltInSubagentTypes["lead"] {
+		t.Error("BuiltInSubagentTypes has lead, want it only on the configured validator")
+	}
+	if NewCrossFileValidator(nil).isBuiltinAgent("lead") {
+		t.Error("isBuiltinAgent(lead) = true on a validator without extra agents")
+	}
+}
@@ -0,0 +1,108 @@
+package crossfile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// chainTool is a tool the delegation chain of a command needs, and the
+// components that need it.
+type chainTool struct {
+	tool  string
+	users []string // "agent 'x'" or "skill 'y'", in chain order
+}
+
+// validateChainTools compares a command's allowed-tools with the union of
+// tools its delegation chain needs, as TraceChain resolves it: the Task
+// tool to delegate to an agent, each agent's tools, and the allowed-tools
+// of each skill those agents reference or preload through their skills
+// list. Tools the chain needs but the command does not allow are reported
+// once, naming the components that need them. Commands without
+// allowed-tools are skipped, and agents without a tools field, which
+// inherit every tool, add only the Task tool.
+func (v *CrossFileValidator) validateChainTools(filePath, contents string, frontmatter map[string]any) []cue.ValidationError {
+	if frontmatter == nil {
+		return nil
+	}
+	declared, ok := frontmatter["allowed-tools"]
+	if !ok {
+		return nil
+	}
+	allowed := parseToolEntries(declared)
+	if len(allowed) == 0 {
+		return nil
+	}
+	chain := v.TraceChain(cue.TypeCommand, ExtractCommandName(filePath))
+	if chain == nil || len(chain.Children) == 0 {
+		return nil
+	}
+
+	var missing []*chainTool
+	byTool := make(map[string]*chainTool)
+	need := func(tool, user string) {
+		if toolAvailable(tool, allowed) {
+			return
+		}
+		t, ok := byTool[tool]
+		if !ok {
+			t = &chainTool{tool: tool}
+			byTool[tool] = t
+			missing = append(missing, t)
+		}
+		if !slices.Contains(t.users, user) {
+			t.users = append(t.users, user)
+		}
+	}
+
+	for _, agent := range chain.Children {
+		user := fmt.Sprintf("agent '%s'", agent.Name)
+		if !toolAvailable("Agent", allowed) {
+			need("Task", user)
+		}
+		agentFM := v.frontmatterOf(v.agents[agent.Name])
+		if tools, declared := agentFM["tools"]; declared {
+			for _, tool := range parseToolEntries(tools) {
+				need(tool, user)
+			}
+		}
+		skills := make([]string, 0, len(agent.Children))
+		for _, skill := range agent.Children {
+			skills = append(skills, skill.Name)
+		}
+		preloaded, _ := agentFM["skills"].([]any)
+		for _, item := range preloaded {
+			if name, ok := item.(string); ok && !slices.Contains(skills, name) {
+				skills = append(skills, name)
+			}
+		}
+		for _, name := range skills {
+			skill, ok := v.skills[name]
+			if !ok {
+				continue
+			}
+			for _, tool := range parseToolEntries(v.frontmatterOf(skill)["allowed-tools"]) {
+				need(tool, fmt.Sprintf("skill '%s'", name))
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	parts := make([]string, 0, len(missing))
+	for _, t := range missing {
+		parts = append(parts, fmt.Sprintf("%s (%s)", t.tool, strings.Join(t.users, ", ")))
+	}
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  fmt.Sprintf("Delegation chain needs %s but allowed-tools does not grant them; add them to allowed-tools or drop them from the chain", strings.Join(parts, "; ")),
+		Severity: cue.SeveritySuggestion,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleCommandChainToolsMissing,
+		Line:     textutil.FindFrontmatterFieldLine(contents, "allowed-tools"),
+	}}
+}
@@ -0,0 +1,96 @@
+package crossfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidateChainTools(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "commands/ship.md", Type: discovery.FileTypeCommand, Contents: "---\nallowed-tools: Task\n---\nTask(builder)\n"},
+		{RelPath: "commands/review.md", Type: discovery.FileTypeCommand, Contents: "---\nallowed-tools: Task\n---\nTask(reviewer)\n"},
+		{RelPath: "commands/plain.md", Type: discovery.FileTypeCommand, Contents: "---\nallowed-tools: Read\n---\nRead the file\n"},
+		{RelPath: "agents/builder.md", Type: discovery.FileTypeAgent, Contents: "---\nname: builder\ntools: Read, Bash(go test:*)\nskills: [release]\n---\nUse Skill(lint) first.\n"},
+		{RelPath: "agents/reviewer.md", Type: discovery.FileTypeAgent, Contents: "---\nname: reviewer\n---\nbody\n"},
+		{RelPath: "skills/lint/SKILL.md", Type: discovery.FileTypeSkill, Contents: "---\nname: lint\nallowed-tools: Read Bash(golangci-lint:*)\n---\nbody\n"},
+		{RelPath: "skills/release/SKILL.md", Type: discovery.FileTypeSkill, Contents: "---\nname: release\nallowed-tools: Bash(git tag:*)\n---\nbody\n"},
+	}
+	v := NewCrossFileValidator(files)
+
+	tests := []struct {
+		name        string
+		filePath    string
+		frontmatter map[string]any
+		want        []string // substrings of the message; nil for no finding
+	}{
+		{
+			name:        "agent and skill tools missing",
+			filePath:    "commands/ship.md",
+			frontmatter: map[string]any{"allowed-tools": "Task"},
+			want: []string{
+				"Read (agent 'builder', skill 'lint')",
+				"Bash(go test:*) (agent 'builder')",
+				"Bash(golangci-lint:*) (skill 'lint')",
+				"Bash(git tag:*) (skill 'release')",
+			},
+		},
+		{
+			name:        "unscoped tools cover the chain",
+			filePath:    "commands/ship.md",
+			frontmatter: map[string]any{"allowed-tools": []any{"Task", "Read", "Bash"}},
+		},
+		{
+			name:        "wildcard covers the chain",
+			filePath:    "commands/ship.md",
+			frontmatter: map[string]any{"allowed-tools": "*"},
+		},
+		{
+			name:        "Agent tool delegates like Task",
+			filePath:    "commands/review.md",
+			frontmatter: map[string]any{"allowed-tools": "Agent"},
+		},
+		{
+			name:        "delegation tool missing for agent inheriting tools",
+			filePath:    "commands/review.md",
+			frontmatter: map[string]any{"allowed-tools": "Read"},
+			want:        []string{"Task (agent 'reviewer')"},
+		},
+		{
+			name:        "no allowed-tools",
+			filePath:    "commands/ship.md",
+			frontmatter: map[string]any{"name": "ship"},
+		},
+		{
+			name:        "no delegation",
+			filePath:    "commands/plain.md",
+			frontmatter: map[string]any{"allowed-tools": "Read"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents := "---\nname: x\nallowed-tools: ...\n---\nbody\n"
+			got := v.validateChainTools(tt.filePath, contents, tt.frontmatter)
+			if tt.want == nil {
+				if len(got) != 0 {
+					t.Fatalf("got %v, want no findings", got)
+				}
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("got %d findings, want 1: %v", len(got), got)
+			}
+			if got[0].Rule != cue.RuleCommandChainToolsMissing || got[0].Severity != cue.SeveritySuggestion || got[0].Line != 3 {
+				t.Errorf("got rule %q severity %q line %d", got[0].Rule, got[0].Severity, got[0].Line)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(got[0].Message, s) {
+					t.Errorf("message %q does not contain %q", got[0].Message, s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package crossfile
+
+import (
+	"fmt"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+const (
+	// commandAgentOverlapThreshold is the word similarity at which a
+	// command's description is taken for a copy of its agent's. It is
+	// higher than skillOverlapThreshold: a command and the agent it
+	// delegates to are about the same work, so some overlap is expected.
+	commandAgentOverlapThreshold = 0.8
+
+	// commandAgentOverlapMinWords keeps very short descriptions out of the
+	// comparison, as skillOverlapMinWords does for skills.
+	commandAgentOverlapMinWords = 4
+)
+
+// validateCommandAgentOverlap suggests rewording a command whose description
+// is nearly the same as that of an agent it delegates to with Task(name).
+// A copied description says what the agent does, not when to run the
+// command, so the criteria for choosing the command are missing. Each
+// project agent is compared once, using the similarity of skill trigger
+// overlap.
+func (v *CrossFileValidator) validateCommandAgentOverlap(filePath, contents string, frontmatter map[string]any) []cue.ValidationError {
+	description, _ := frontmatter["description"].(string)
+	words := textutil.ContentWords(description)
+	if len(words) < commandAgentOverlapMinWords {
+		return nil
+	}
+
+	var errors []cue.ValidationError
+	seen := make(map[string]bool)
+	for _, task := range taskReferences(contents) {
+		name, ok := cleanAgentRef(task.Name)
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		agent, ok := v.agents[name]
+		if !ok {
+			continue
+		}
+		agentDescription, _ := v.frontmatterOf(agent)["description"].(string)
+		agentWords := textutil.ContentWords(agentDescription)
+		if len(agentWords) < commandAgentOverlapMinWords {
+			continue
+		}
+		score := textutil.WordSimilarity(words, agentWords)
+		if score < commandAgentOverlapThreshold {
+			continue
+		}
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("Command description is %.0f%% similar to agent '%s' (%s), which it delegates to; say when to run the command rather than what the agent does", score*100, name, agent.RelPath),
+			Severity: cue.SeveritySuggestion,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCommandAgentOverlap,
+			Line:     textutil.FindFrontmatterFieldLine(contents, "description"),
+		})
+	}
+	return errors
+}
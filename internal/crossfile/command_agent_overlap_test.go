@@ -0,0 +1,77 @@
+package crossfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidateCommandAgentOverlap(t *testing.T) {
+	files := []discovery.File{
+		{
+			RelPath:  "agents/pdf-reviewer.md",
+			Type:     discovery.FileTypeAgent,
+			Contents: "---\nname: pdf-reviewer\ndescription: Reviews PDF documents for layout problems and broken links.\n---\nbody\n",
+		},
+		{
+			RelPath:  "agents/terse.md",
+			Type:     discovery.FileTypeAgent,
+			Contents: "---\nname: terse\ndescription: Reviews PDFs.\n---\nbody\n",
+		},
+	}
+	v := NewCrossFileValidator(files)
+
+	tests := []struct {
+		name        string
+		description string
+		body        string
+		want        string // message fragment; empty for no finding
+	}{
+		{
+			name:        "copied description",
+			description: "Review PDF documents for layout problems and broken links.",
+			body:        "Task(pdf-reviewer): review $ARGUMENTS\nTask(pdf-reviewer): again\n",
+			want:        "100% similar to agent 'pdf-reviewer' (agents/pdf-reviewer.md)",
+		},
+		{
+			name:        "routing criteria",
+			description: "Run before publishing a release to catch PDF layout problems in the docs folder.",
+			body:        "Task(pdf-reviewer): review docs\n",
+		},
+		{
+			name:        "short agent description",
+			description: "Reviews PDFs for layout problems and links.",
+			body:        "Task(terse): review\n",
+		},
+		{
+			name:        "unknown agent",
+			description: "Reviews PDF documents for layout problems and broken links.",
+			body:        "Task(missing-agent): review\n",
+		},
+		{
+			name:        "no delegation",
+			description: "Reviews PDF documents for layout problems and broken links.",
+			body:        "Review the PDFs.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents := "---\ndescription: " + tt.description + "\n---\n" + tt.body
+			got := v.validateCommandAgentOverlap("commands/review.md", contents, map[string]any{"description": tt.description})
+			if tt.want == "" {
+				if len(got) != 0 {
+					t.Fatalf("got %v, want no findings", got)
+				}
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("got %d findings, want 1 for the agent however often it is called: %v", len(got), got)
+			}
+			if got[0].Rule != cue.RuleCommandAgentOverlap || got[0].Severity != cue.SeveritySuggestion || got[0].Line != 2 || !strings.Contains(got[0].Message, tt.want) {
+				t.Errorf("finding = %s %s line %d %q, want %s suggestion line 2 containing %q", got[0].Rule, got[0].Severity, got[0].Line, got[0].Message, cue.RuleCommandAgentOverlap, tt.want)
+			}
+		})
+	}
+}
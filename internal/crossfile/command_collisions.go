@@ -0,0 +1,80 @@
+package crossfile
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// commandBaseName returns the last segment of a namespaced command name:
+// git:commit -> commit.
+func commandBaseName(name string) string {
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// slashCall formats how a command is invoked.
+func slashCall(name string) string {
+	return "/" + name
+}
+
+// validateCommandCollisions warns when another command in the same scope
+// resolves to the same slash command, which leaves one of them unreachable,
+// and when a flat command shares its name with a namespaced one (/commit and
+// /git:commit), since typing the bare name then matches both in the picker.
+// A project command and a plugin command of the same name are reported as
+// shadowing, with the project command taking precedence.
+func (v *CrossFileValidator) validateCommandCollisions(filePath string) []cue.ValidationError {
+	self := filepath.ToSlash(filePath)
+	found := false
+	for _, f := range v.allCommands {
+		if filepath.ToSlash(f.RelPath) == self {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	name := ExtractCommandName(filePath)
+	base := commandBaseName(name)
+	scope := agentScope(filePath)
+
+	var errors []cue.ValidationError
+	for _, other := range v.allCommands {
+		if filepath.ToSlash(other.RelPath) == self {
+			continue
+		}
+		otherName := ExtractCommandName(other.RelPath)
+		if agentScope(other.RelPath) != scope {
+			if otherName == name && shadows(filePath, other.RelPath) {
+				errors = append(errors, shadowingIssue("command", slashCall, name, filePath, other.RelPath, 0))
+			}
+			continue
+		}
+		switch {
+		case otherName == name:
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Command /%s is also defined by %s; only one of them is reachable. Rename or remove one", name, other.RelPath),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandNameCollision,
+			})
+		case commandBaseName(otherName) == base && (name == base) != (otherName == base):
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Command /%s shares its name with /%s (%s); /%s matches both. Rename one so the flat and namespaced commands are distinct", name, otherName, other.RelPath, base),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandNameCollision,
+			})
+		}
+	}
+	return errors
+}
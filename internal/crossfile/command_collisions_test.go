@@ -0,0 +1,89 @@
+package crossfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidateCommandCollisions(t *testing.T) {
+	command := func(relPath string) discovery.File {
+		return discovery.File{RelPath: relPath, Type: discovery.FileTypeCommand, Contents: "body\n"}
+	}
+	files := []discovery.File{
+		command("commands/commit.md"),
+		command("commands/git/commit.md"),
+		command("commands/git/push.md"),
+		command("commands/git:push.md"),
+		command("commands/review/pr.md"),
+		command("commands/lint/pr.md"),
+		command("commands/deploy.md"),
+		command("plugins/cache/market/toolkit/current/commands/deploy.md"),
+		command("plugins/cache/market/toolkit/current/commands/git/commit-all.md"),
+	}
+	v := NewCrossFileValidator(files)
+
+	tests := []struct {
+		name     string
+		filePath string
+		want     []string // message fragments, in order
+	}{
+		{
+			name:     "flat command shadowed by namespaced",
+			filePath: "commands/commit.md",
+			want:     []string{"/commit shares its name with /git:commit"},
+		},
+		{
+			name:     "namespaced command shadowing flat",
+			filePath: "commands/git/commit.md",
+			want:     []string{"/git:commit shares its name with /commit"},
+		},
+		{
+			name:     "subdirectory and colon file name resolve the same",
+			filePath: "commands/git/push.md",
+			want:     []string{"/git:push is also defined by commands/git:push.md"},
+		},
+		{
+			name:     "same base name in two namespaces",
+			filePath: "commands/review/pr.md",
+		},
+		{
+			name:     "project command shadows a plugin command",
+			filePath: "commands/deploy.md",
+			want:     []string{"/deploy runs this one, and the plugin's is reachable only as /toolkit:deploy"},
+		},
+		{
+			name:     "plugin command shadowed by a project command",
+			filePath: "plugins/cache/market/toolkit/current/commands/deploy.md",
+			want:     []string{"shadowed by project command commands/deploy.md"},
+		},
+		{
+			name:     "plugin commands are only compared by full name",
+			filePath: "plugins/cache/market/toolkit/current/commands/git/commit-all.md",
+		},
+		{
+			name:     "unknown file is skipped",
+			filePath: "commands/missing.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v.validateCommandCollisions(tt.filePath)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d findings, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				wantRule := cue.RuleCommandNameCollision
+				if strings.Contains(want, "shadow") || strings.Contains(want, "reachable only") {
+					wantRule = cue.RuleComponentShadowed
+				}
+				if got[i].Rule != wantRule || !strings.Contains(got[i].Message, want) {
+					t.Errorf("finding %d = %s %q, want %s containing %q", i, got[i].Rule, got[i].Message, wantRule, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package crossfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// MaxContextImportDepth is how many hops of nested @path imports Claude Code
+// follows from a CLAUDE.md file; deeper imports are not loaded.
+const MaxContextImportDepth = 5
+
+// importProblem is a defect found while following one import chain.
+type importProblem struct {
+	rule  string
+	chain []string // absolute paths from the importing file to the culprit
+	ref   string   // raw @path of the culprit import
+}
+
+// ValidateContext checks the @path imports of a CLAUDE.md memory file:
+// each target must exist and stay inside the project (or the home directory
+// for @~/ imports), and the chains they start must not loop back on
+// themselves or nest deeper than MaxContextImportDepth. Relative imports
+// resolve against the importing file's directory. Findings point at the
+// import line in this file; the check is skipped when no root is known.
+func (v *CrossFileValidator) ValidateContext(filePath, contents string) []cue.ValidationError {
+	if v.rootPath == "" {
+		return nil
+	}
+	from := filePath
+	if !filepath.IsAbs(from) {
+		from = filepath.Join(v.rootPath, from)
+	}
+
+	var errors []cue.ValidationError
+	add := func(line int, severity, rule, msg string) {
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  msg,
+			Severity: severity,
+			Source:   cue.SourceAnthropicDocs,
+			Rule:     rule,
+			Line:     line,
+		})
+	}
+
+	for _, ref := range FindFileReferences(contents) {
+		target := resolveContextImport(ref.Path, from)
+		if !v.importAllowed(ref.Path, target) {
+			add(ref.Line, cue.SeverityError, cue.RuleContextImportOutside,
+				fmt.Sprintf("@%s resolves outside the project. Import files under the project root, or use @~/ for files in your home directory", ref.Path))
+			continue
+		}
+		if _, err := os.Stat(target); err != nil {
+			add(ref.Line, cue.SeverityError, cue.RuleContextImportMissing,
+				fmt.Sprintf("@%s imports a file that does not exist (resolved relative to %s)", ref.Path, filepath.Base(from)))
+			continue
+		}
+
+		for _, p := range v.walkImports([]string{from, target}, nil) {
+			chain := formatImportChain(v.rootPath, p.chain)
+			switch p.rule {
+			case cue.RuleContextImportCycle:
+				add(ref.Line, cue.SeverityError, p.rule, fmt.Sprintf("Circular @import through @%s: %s", ref.Path, chain))
+			case cue.RuleContextImportDepth:
+				add(ref.Line, cue.SeverityWarning, p.rule,
+					fmt.Sprintf("@%s nests imports deeper than %d levels; @%s is not loaded: %s", ref.Path, MaxContextImportDepth, p.ref, chain))
+			case cue.RuleContextImportMissing:
+				add(ref.Line, cue.SeverityError, p.rule, fmt.Sprintf("@%s imports @%s, which does not exist: %s", ref.Path, p.ref, chain))
+			}
+		}
+	}
+	return errors
+}
+
+// walkImports follows the imports of the last file in chain, reporting at
+// most one problem of each kind. Only markdown files are scanned for
+// further imports.
+func (v *CrossFileValidator) walkImports(chain []string, found []importProblem) []importProblem {
+	node := chain[len(chain)-1]
+	if !strings.EqualFold(filepath.Ext(node), ".md") {
+		return found
+	}
+	data, err := os.ReadFile(node) //nolint:gosec // G304: import targets inside the project
+	if err != nil {
+		return found
+	}
+
+	seen := func(rule string) bool {
+		for _, p := range found {
+			if p.rule == rule {
+				return true
+			}
+		}
+		return false
+	}
+	for _, ref := range FindFileReferences(string(data)) {
+		target := resolveContextImport(ref.Path, node)
+		next := append(append([]string(nil), chain...), target)
+		switch {
+		case containsPath(chain, target):
+			if !seen(cue.RuleContextImportCycle) {
+				found = append(found, importProblem{rule: cue.RuleContextImportCycle, chain: next, ref: ref.Path})
+			}
+		case len(chain) > MaxContextImportDepth:
+			if !seen(cue.RuleContextImportDepth) {
+				found = append(found, importProblem{rule: cue.RuleContextImportDepth, chain: next, ref: ref.Path})
+			}
+		case !v.importAllowed(ref.Path, target):
+			// Reported when the importing file itself is linted.
+		default:
+			if _, err := os.Stat(target); err != nil {
+				if !seen(cue.RuleContextImportMissing) {
+					found = append(found, importProblem{rule: cue.RuleContextImportMissing, chain: next, ref: ref.Path})
+				}
+				continue
+			}
+			found = v.walkImports(next, found)
+		}
+	}
+	return found
+}
+
+// ContextImport is one @path import reached from a CLAUDE.md file.
+type ContextImport struct {
+	Ref     string // the @path as written, without the @
+	Path    string // absolute target
+	Depth   int    // 1 for imports written in the CLAUDE.md itself
+	Missing bool   // the target does not exist
+}
+
+// ContextImports returns the import tree of a CLAUDE.md in depth-first
+// order, each import followed by the imports of its target, up to
+// MaxContextImportDepth. Out-of-project and repeated targets are skipped;
+// missing ones are listed but not followed.
+func ContextImports(rootPath, filePath, contents string) []ContextImport {
+	v := &CrossFileValidator{rootPath: rootPath}
+	from := filePath
+	if !filepath.IsAbs(from) {
+		from = filepath.Join(rootPath, from)
+	}
+
+	var imports []ContextImport
+	seen := map[string]bool{from: true}
+	var visit func(node, contents string, depth int)
+	visit = func(node, contents string, depth int) {
+		if depth > MaxContextImportDepth {
+			return
+		}
+		for _, ref := range FindFileReferences(contents) {
+			target := resolveContextImport(ref.Path, node)
+			if seen[target] || !v.importAllowed(ref.Path, target) {
+				continue
+			}
+			info, err := os.Stat(target)
+			if err == nil && info.IsDir() {
+				continue
+			}
+			seen[target] = true
+			imports = append(imports, ContextImport{Ref: ref.Path, Path: target, Depth: depth, Missing: err != nil})
+			if err == nil && strings.EqualFold(filepath.Ext(target), ".md") {
+				if data, err := os.ReadFile(target); err == nil { //nolint:gosec // G304: import targets inside the project
+					visit(target, string(data), depth+1)
+				}
+			}
+		}
+	}
+	visit(from, contents, 1)
+	return imports
+}
+
+// ContextImportFiles returns every file a CLAUDE.md pulls in through
+// @path imports, following nested imports up to MaxContextImportDepth and
+// skipping missing, out-of-project, and repeated targets. Paths are
+// absolute and in discovery order.
+func ContextImportFiles(rootPath, filePath, contents string) []string {
+	var files []string
+	for _, imp := range ContextImports(rootPath, filePath, contents) {
+		if !imp.Missing {
+			files = append(files, imp.Path)
+		}
+	}
+	return files
+}
+
+// resolveContextImport resolves an import path written in the file at from.
+func resolveContextImport(ref, from string) string {
+	path := expandHome(ref)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(from), path)
+	}
+	return filepath.Clean(path)
+}
+
+// importAllowed reports whether target may be imported: anything under the
+// project root, or under the home directory when written as @~/.
+func (v *CrossFileValidator) importAllowed(ref, target string) bool {
+	if isWithin(v.rootPath, target) {
+		return true
+	}
+	if strings.HasPrefix(ref, "~") {
+		home, err := os.UserHomeDir()
+		return err == nil && isWithin(home, target)
+	}
+	return false
+}
+
+// expandHome replaces a leading ~ with the user's home directory.
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// isWithin reports whether path is dir or below it.
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// formatImportChain renders an import chain with root-relative paths.
+func formatImportChain(root string, chain []string) string {
+	parts := make([]string, len(chain))
+	for i, p := range chain {
+		if rel, err := filepath.Rel(root, p); err == nil && isWithin(root, p) {
+			p = filepath.ToSlash(rel)
+		}
+		parts[i] = p
+	}
+	return strings.Join(parts, " -> ")
+}
@@ -0,0 +1,104 @@
+package crossfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, contents := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestValidateContext_Imports(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"docs/style.md":  "Style guide. See @./naming.md\n",
+		"docs/naming.md": "Names.\n",
+		"loop/a.md":      "@b.md\n",
+		"loop/b.md":      "@a.md\n",
+		"deep/1.md":      "@2.md\n",
+		"deep/2.md":      "@3.md\n",
+		"deep/3.md":      "@4.md\n",
+		"deep/4.md":      "@5.md\n",
+		"deep/5.md":      "@6.md\n",
+		"deep/6.md":      "too deep\n",
+		"nested/x.md":    "@gone.md\n",
+	})
+
+	tests := []struct {
+		name     string
+		contents string
+		want     []string // rule:line per finding, in order
+	}{
+		{name: "existing nested imports", contents: "# Project\n\nSee @docs/style.md\n"},
+		{name: "missing target", contents: "# P\n@docs/missing.md\n", want: []string{"context-import-missing:2"}},
+		{name: "outside project", contents: "@../outside.md\n", want: []string{"context-import-outside-project:1"}},
+		{name: "cycle", contents: "@loop/a.md\n", want: []string{"context-import-cycle:1"}},
+		{name: "depth over five", contents: "@deep/1.md\n", want: []string{"context-import-depth:1"}},
+		{name: "missing nested target", contents: "@nested/x.md\n", want: []string{"context-import-missing:1"}},
+		{name: "code blocks are not imports", contents: "```\n@docs/missing.md\n```\n`@docs/missing.md`\n"},
+	}
+
+	v := NewCrossFileValidator(nil, root)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range v.ValidateContext("CLAUDE.md", tt.contents) {
+				got = append(got, fmt.Sprintf("%s:%d", e.Rule, e.Line))
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("findings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if errs := NewCrossFileValidator(nil).ValidateContext("CLAUDE.md", "@docs/missing.md\n"); len(errs) != 0 {
+		t.Errorf("ValidateContext() without root = %v, want none", errs)
+	}
+}
+
+func TestValidateContext_ChainMessage(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"a.md": "@b.md\n", "b.md": "@a.md\n"})
+
+	errs := NewCrossFileValidator(nil, root).ValidateContext("CLAUDE.md", "@a.md\n")
+	if len(errs) != 1 || errs[0].Rule != cue.RuleContextImportCycle {
+		t.Fatalf("ValidateContext() = %v, want one cycle", errs)
+	}
+	if want := "CLAUDE.md -> a.md -> b.md -> a.md"; !strings.Contains(errs[0].Message, want) {
+		t.Errorf("message %q does not contain chain %q", errs[0].Message, want)
+	}
+}
+
+func TestContextImportFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"docs/a.md": "@b.md and @../data.json\n",
+		"docs/b.md": "@a.md\n",
+		"data.json": "{}",
+	})
+
+	got := ContextImportFiles(root, "CLAUDE.md", "@docs/a.md @missing.md @docs/a.md\n")
+	want := []string{
+		filepath.Join(root, "docs/a.md"),
+		filepath.Join(root, "docs/b.md"),
+		filepath.Join(root, "data.json"),
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("ContextImportFiles() = %v, want %v", got, want)
+	}
+}
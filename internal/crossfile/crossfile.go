@@ -2,6 +2,8 @@
 //
 // This package contains the validation orchestration logic. Related functionality
 // is split into:
+//   - scanner.go: Single-pass reference scanning (ScanReferences)
+//   - links.go: Markdown links to agents and skills
 //   - refs.go: Reference extraction (FindSkillReferences, ParseAllowedTools, etc.)
 //   - graph.go: Cycle detection and chain tracing (DetectCycles, TraceChain, etc.)
 package crossfile
@@ -12,6 +14,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
@@ -19,9 +22,6 @@ import (
 
 // Pre-compiled regex patterns for cross-file validation.
 var (
-	// validateCommandTaskPattern matches Task(agent-name) in command contents.
-	validateCommandTaskPattern = regexp.MustCompile(`Task\(([^,\)]+)`)
-
 	// flagPattern matches --flag-name patterns in command contents.
 	flagPattern = regexp.MustCompile(`--([a-z][a-z0-9-]*)`)
 
@@ -29,47 +29,29 @@ var (
 	// Matches: `--flag` | (table row), --flag: (label), `--flag` (backtick-wrapped).
 	routingFlagPattern = regexp.MustCompile("(?m)(?:`--([a-z][a-z0-9-]*)`\\s*\\||--([a-z][a-z0-9-]*)\\s*:)")
 
-	// collectSkillRefPattern matches Skill: references in agent contents.
-	collectSkillRefPattern = regexp.MustCompile(`(?m)^[^*]*\bSkill:\s*([a-z0-9][a-z0-9-]*)`)
+	// extractTaskAgentRefsPattern matches Task(agent-name) or Task(a, b) in tools field.
+	extractTaskAgentRefsPattern = regexp.MustCompile(`Task\(([^()]*)\)`)
 
-	// extractTaskAgentRefsPattern matches Task(agent-name) in tools field.
-	extractTaskAgentRefsPattern = regexp.MustCompile(`Task\(([a-z0-9][a-z0-9-]*)\)`)
+	// taskAgentNamePattern matches a single agent name inside Task(...).
+	taskAgentNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
 )
 
-// Pre-compiled regex patterns for ValidateSkill agent reference detection.
-var skillAgentPatterns = []struct {
-	pattern *regexp.Regexp
-	example string
-}{
-	// Specialist patterns (existing, most specific)
-	{regexp.MustCompile(`delegate to\s+([a-z0-9][a-z0-9-]*-specialist)`), "delegate to foo-specialist"},
-	{regexp.MustCompile(`use\s+([a-z0-9][a-z0-9-]*-specialist)`), "use foo-specialist"},
-	{regexp.MustCompile(`see\s+([a-z0-9][a-z0-9-]*-specialist)`), "see foo-specialist"},
-	{regexp.MustCompile(`Task\(([a-z0-9][a-z0-9-]*-specialist)`), "Task(foo-specialist)"},
-
-	// Generic Task() pattern - matches any agent name
-	{regexp.MustCompile(`Task\(\s*["']?([a-z0-9][a-z0-9-]*)["']?\s*[,)]`), "Task(agent-name)"},
-
-	// Narrative patterns for agents
-	{regexp.MustCompile(`delegate via\s+([a-z0-9][a-z0-9-]*)`), "delegate via agent-name"},
-	{regexp.MustCompile(`([a-z0-9][a-z0-9-]*-agent)\s+handles`), "foo-agent handles"},
-}
-
-// BuiltInSubagentTypes are Task() targets that exist in Claude Code's runtime,
-// not as user-defined agent files. These should not trigger "missing agent" errors.
-// Includes both built-in subagent types and model name references.
-var BuiltInSubagentTypes = map[string]bool{
-	// Built-in subagent types
-	"general-purpose":   true,
-	"statusline-setup":  true,
-	"Explore":           true,
-	"Plan":              true,
-	"claude-code-guide": true,
-
-	// Model names (used in Task() for model selection)
-	"haiku":  true,
-	"sonnet": true,
-	"opus":   true,
+// skillAgentRef returns the agent a skill body reference names, if it is
+// one ValidateSkill checks: "delegate to", "use", or "see" a *-specialist;
+// Task(agent-name); "delegate via agent-name"; or "foo-agent handles".
+func skillAgentRef(ref Reference) string {
+	switch ref.Kind {
+	case RefDelegateTo, RefUse, RefSee:
+		return specialistName(ref.Name)
+	case RefTask:
+		if name, ok := taskCallName(ref.Name); ok {
+			return name
+		}
+		return specialistName(leadingName(ref.Name))
+	case RefDelegateVia, RefHandles:
+		return ref.Name
+	}
+	return ""
 }
 
 // BuiltInSkillNames are Skill() targets that exist in Claude Code's runtime as
@@ -100,11 +82,20 @@ func IsPluginNamespacedRef(ref string) bool {
 
 // CrossFileValidator validates references between components
 type CrossFileValidator struct {
-	agents            map[string]discovery.File
-	skills            map[string]discovery.File
-	commands          map[string]discovery.File
-	rootPath          string
-	userScopeAgentDir string
+	agents             map[string]discovery.File
+	allAgents          []discovery.File // every agent file, including same-name duplicates
+	skills             map[string]discovery.File
+	commands           map[string]discovery.File
+	allCommands        []discovery.File // every command file, including same-name duplicates
+	rootPath           string
+	userScopeAgentDir  string
+	extraBuiltinAgents []string                         // see WithExtraBuiltinAgents
+	contents           *discovery.ContentCache          // file contents read on demand, see contentsOf
+	parsedFM           *discovery.Cache[map[string]any] // frontmatter by RelPath, see frontmatterOf
+	refs               map[string]*fileRefs             // scanned references by RelPath, see refsOf
+	dependents         map[string][]string              // reverse references by node, see ReverseIndex
+	repoOnce           sync.Once
+	repoPaths          map[string]bool // every path in the project tree, see RepoPathExists
 }
 
 // NewCrossFileValidator creates a validator with indexed files.
@@ -122,12 +113,20 @@ func NewCrossFileValidator(files []discovery.File, rootPath ...string) *CrossFil
 		v.userScopeAgentDir = filepath.Join(homeDir, ".claude", "agents")
 	}
 
+	v.scanFiles(files)
+
 	// Index files. User-space agents (agents/**/*.md) are processed first so they
 	// always win over plugin-shipped agents of the same bare name.
 	// Two passes: (1) user-space agents/skills/commands, (2) plugin agents (fill gaps only).
+	// Disabled components are left out, so they neither resolve references
+	// nor count as orphans.
 	for _, f := range files {
+		if isComponentType(f.Type) && v.refsOf(f).disabled {
+			continue
+		}
 		switch f.Type {
 		case discovery.FileTypeAgent:
+			v.allAgents = append(v.allAgents, f)
 			if isPluginAgentRelPath(f.RelPath) {
 				continue // handled in second pass
 			}
@@ -137,13 +136,14 @@ func NewCrossFileValidator(files []discovery.File, rootPath ...string) *CrossFil
 			name := ExtractSkillName(f.RelPath)
 			v.skills[name] = f
 		case discovery.FileTypeCommand:
+			v.allCommands = append(v.allCommands, f)
 			name := ExtractCommandName(f.RelPath)
 			v.commands[name] = f
 		}
 	}
 	// Second pass: plugin agents fill gaps — never overwrite a user-space entry.
 	for _, f := range files {
-		if f.Type == discovery.FileTypeAgent && isPluginAgentRelPath(f.RelPath) {
+		if f.Type == discovery.FileTypeAgent && isPluginAgentRelPath(f.RelPath) && !v.refsOf(f).disabled {
 			name := ExtractAgentName(f.RelPath)
 			if _, exists := v.agents[name]; !exists {
 				v.agents[name] = f
@@ -154,6 +154,13 @@ func NewCrossFileValidator(files []discovery.File, rootPath ...string) *CrossFil
 	return v
 }
 
+// SkillContents returns the raw contents of the indexed skill with the given
+// name, and whether it was found.
+func (v *CrossFileValidator) SkillContents(name string) (string, bool) {
+	f, ok := v.skills[name]
+	return v.contentsOf(f), ok
+}
+
 // isPluginAgentRelPath reports whether the relative path points to a plugin-shipped
 // agent file (under plugins/cache/ or .claude/plugins/cache/).
 func isPluginAgentRelPath(relPath string) bool {
@@ -167,7 +174,7 @@ func isPluginAgentRelPath(relPath string) bool {
 // lint scope) are out of local scope; locally-discovered and user-scope agents
 // resolve directly.
 func (v *CrossFileValidator) hasResolvableAgent(agentName string) bool {
-	if BuiltInSubagentTypes[agentName] || IsPluginNamespacedRef(agentName) {
+	if v.isBuiltinAgent(agentName) || IsPluginNamespacedRef(agentName) {
 		return true
 	}
 	if _, exists := v.agents[agentName]; exists {
@@ -200,14 +207,11 @@ func (v *CrossFileValidator) ValidateCommand(filePath string, contents string, f
 	var errors []cue.ValidationError
 	seenAgentErrors := make(map[string]bool)
 
-	// Find all Task(X-specialist) or Task(X) patterns
-	matches := validateCommandTaskPattern.FindAllStringSubmatch(contents, -1)
+	// Find all Task(X-specialist) or Task(X) calls
+	tasks := taskReferences(contents)
 
-	for _, match := range matches {
-		if len(match) < 2 {
-			continue
-		}
-		agentRef, ok := cleanAgentRef(match[1])
+	for _, task := range tasks {
+		agentRef, ok := cleanAgentRef(task.Name)
 		if !ok {
 			continue
 		}
@@ -221,28 +225,75 @@ func (v *CrossFileValidator) ValidateCommand(filePath string, contents string, f
 				Message:  fmt.Sprintf("Task(%s) references non-existent agent. Create agents/%s.md", agentRef, agentRef),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentRefMissing,
+				Line:     task.Line,
 			})
 		}
 	}
 
 	// Check for fake flags documented in command but not in agent or its skills
-	errors = append(errors, v.checkFakeFlags(filePath, contents, matches)...)
+	errors = append(errors, v.checkFakeFlags(filePath, contents, tasks)...)
 
 	// Check for unused allowed-tools
 	errors = append(errors, v.checkUnusedAllowedTools(filePath, contents, frontmatter)...)
 
+	// Check allowed-tools covers the tools its delegation chain needs
+	errors = append(errors, v.validateChainTools(filePath, contents, frontmatter)...)
+
 	// Check for skill references (Skill: or Skill() patterns)
 	errors = append(errors, v.checkSkillReferences(filePath, contents)...)
 
+	// Check @path file inclusions resolve from the project root
+	errors = append(errors, v.checkFileReferences(filePath, contents)...)
+
+	// Suggest differentiating descriptions copied from a delegated agent
+	errors = append(errors, v.validateCommandAgentOverlap(filePath, contents, frontmatter)...)
+
+	// Warn about commands that resolve to the same or an overlapping slash name
+	errors = append(errors, v.validateCommandCollisions(filePath)...)
+
+	// Check markdown links to agents and skills
+	errors = append(errors, v.checkComponentLinks(filePath, contents)...)
+
+	return errors
+}
+
+// checkFileReferences validates that @path file inclusions in a command body
+// point to existing files or directories. Relative paths resolve against the
+// project root, matching how Claude Code expands them; the check is skipped
+// when no root is known.
+func (v *CrossFileValidator) checkFileReferences(filePath, contents string) []cue.ValidationError {
+	if v.rootPath == "" {
+		return nil
+	}
+
+	var errors []cue.ValidationError
+	for _, ref := range FindFileReferences(contents) {
+		path := expandHome(ref.Path)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(v.rootPath, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("@%s references a file that does not exist (resolved from project root)", ref.Path),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCommandFileRefMissing,
+			Line:     ref.Line,
+		})
+	}
 	return errors
 }
 
 // checkFakeFlags detects flags documented in command but not in agent or skills.
-func (v *CrossFileValidator) checkFakeFlags(filePath, contents string, taskMatches [][]string) []cue.ValidationError {
+func (v *CrossFileValidator) checkFakeFlags(filePath, contents string, tasks []Reference) []cue.ValidationError {
 	var errors []cue.ValidationError
 
 	// Find the primary agent this command delegates to
-	primaryAgent, primaryAgentContents := v.findPrimaryAgent(taskMatches)
+	primaryAgent, primaryAgentContents := v.findPrimaryAgent(tasks)
 	if primaryAgent == "" {
 		return errors
 	}
@@ -292,6 +343,7 @@ func (v *CrossFileValidator) checkFakeFlags(filePath, contents string, taskMatch
 				Message:  fmt.Sprintf("Flag '--%s' documented but not found in agent '%s' or its skills - may be fake", flag, primaryAgent),
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandFlagUnknown,
 			})
 		}
 	}
@@ -300,17 +352,14 @@ func (v *CrossFileValidator) checkFakeFlags(filePath, contents string, taskMatch
 }
 
 // findPrimaryAgent finds the primary agent that the command delegates to.
-func (v *CrossFileValidator) findPrimaryAgent(taskMatches [][]string) (agentName, agentContents string) {
-	for _, match := range taskMatches {
-		if len(match) < 2 {
-			continue
-		}
-		agentRef, ok := cleanAgentRef(match[1])
+func (v *CrossFileValidator) findPrimaryAgent(tasks []Reference) (agentName, agentContents string) {
+	for _, task := range tasks {
+		agentRef, ok := cleanAgentRef(task.Name)
 		if !ok {
 			continue
 		}
 		if agentFile, exists := v.agents[agentRef]; exists {
-			return agentRef, agentFile.Contents
+			return agentRef, v.contentsOf(agentFile)
 		}
 	}
 	return "", ""
@@ -319,12 +368,9 @@ func (v *CrossFileValidator) findPrimaryAgent(taskMatches [][]string) (agentName
 // collectAgentSkillContents collects the contents of skills referenced by an agent.
 func (v *CrossFileValidator) collectAgentSkillContents(agentContents string) []string {
 	var skillContents []string
-	skillMatches := collectSkillRefPattern.FindAllStringSubmatch(agentContents, -1)
-	for _, sm := range skillMatches {
-		if len(sm) >= 2 {
-			if skillFile, exists := v.skills[sm[1]]; exists {
-				skillContents = append(skillContents, skillFile.Contents)
-			}
+	for _, name := range FindSkillReferences(agentContents) {
+		if skillFile, exists := v.skills[name]; exists {
+			skillContents = append(skillContents, v.contentsOf(skillFile))
 		}
 	}
 	return skillContents
@@ -384,6 +430,7 @@ func (v *CrossFileValidator) checkUnusedAllowedTools(filePath, contents string,
 			Message:  message,
 			Severity: cue.SeverityInfo,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCommandToolUnused,
 		})
 	}
 
@@ -393,20 +440,16 @@ func (v *CrossFileValidator) checkUnusedAllowedTools(filePath, contents string,
 // checkSkillReferences validates skill references in any component.
 func (v *CrossFileValidator) checkSkillReferences(filePath string, contents string) []cue.ValidationError {
 	var errors []cue.ValidationError
-	seenSkillErrors := make(map[string]bool)
 
-	skillRefs := FindSkillReferences(contents)
-	for _, skillRef := range skillRefs {
-		if seenSkillErrors[skillRef] {
-			continue
-		}
-		if _, exists := v.skills[skillRef]; !exists {
-			seenSkillErrors[skillRef] = true
+	for _, ref := range skillReferences(contents) {
+		if _, exists := v.skills[ref.Name]; !exists {
 			errors = append(errors, cue.ValidationError{
 				File:     filePath,
-				Message:  fmt.Sprintf("References non-existent skill '%s'. Create skills/%s/SKILL.md", skillRef, skillRef),
+				Message:  fmt.Sprintf("References non-existent skill '%s'. Create skills/%s/SKILL.md", ref.Name, ref.Name),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleSkillRefMissing,
+				Line:     ref.Line,
 			})
 		}
 	}
@@ -415,19 +458,22 @@ func (v *CrossFileValidator) checkSkillReferences(filePath string, contents stri
 }
 
 // ValidateAgent checks agent references to skills and team agent references.
-// It validates in-body Skill: references, frontmatter skills array, and
-// Task() agent references in the frontmatter tools field (agent teams).
+// It validates in-body Skill: references, frontmatter skills array,
+// Task() agent references in the frontmatter tools field (agent teams),
+// tool and model compatibility with the skills the agent loads, and name or
+// color collisions with other agents.
 func (v *CrossFileValidator) ValidateAgent(filePath string, contents string, frontmatter map[string]any) []cue.ValidationError {
 	var errors []cue.ValidationError
 
-	skillRefs := FindSkillReferences(contents)
-	for _, skillRef := range skillRefs {
-		if _, exists := v.skills[skillRef]; !exists {
+	for _, ref := range skillReferences(contents) {
+		if _, exists := v.skills[ref.Name]; !exists {
 			errors = append(errors, cue.ValidationError{
 				File:     filePath,
-				Message:  fmt.Sprintf("Skill: %s references non-existent skill. Create skills/%s/SKILL.md", skillRef, skillRef),
+				Message:  fmt.Sprintf("Skill: %s references non-existent skill. Create skills/%s/SKILL.md", ref.Name, ref.Name),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleSkillRefMissing,
+				Line:     ref.Line,
 			})
 		}
 	}
@@ -438,6 +484,15 @@ func (v *CrossFileValidator) ValidateAgent(filePath string, contents string, fro
 	// Validate Task() agent references in frontmatter tools field (agent teams)
 	errors = append(errors, v.validateToolsAgentRefs(filePath, frontmatter)...)
 
+	// Validate tool and model compatibility with loaded skills
+	errors = append(errors, v.validateAgentSkillCompat(filePath, contents, frontmatter)...)
+
+	// Warn about agents that share this agent's name or display color
+	errors = append(errors, v.validateAgentCollisions(filePath, contents, frontmatter)...)
+
+	// Check markdown links to agents and skills
+	errors = append(errors, v.checkComponentLinks(filePath, contents)...)
+
 	return errors
 }
 
@@ -462,6 +517,7 @@ func (v *CrossFileValidator) validateToolsAgentRefs(filePath string, frontmatter
 				Message:  fmt.Sprintf("tools field Task(%s) references non-existent agent. Create agents/%s.md", agentRef, agentRef),
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentRefMissing,
 			})
 		}
 	}
@@ -481,9 +537,13 @@ func ExtractTaskAgentRefs(tools any) []string {
 	addMatches := func(s string) {
 		matches := extractTaskAgentRefsPattern.FindAllStringSubmatch(s, -1)
 		for _, m := range matches {
-			if len(m) >= 2 && !seen[m[1]] {
-				refs = append(refs, m[1])
-				seen[m[1]] = true
+			for arg := range strings.SplitSeq(m[1], ",") {
+				name := strings.TrimSpace(arg)
+				if !taskAgentNamePattern.MatchString(name) || seen[name] {
+					continue
+				}
+				refs = append(refs, name)
+				seen[name] = true
 			}
 		}
 	}
@@ -532,6 +592,7 @@ func (v *CrossFileValidator) validateFrontmatterSkills(filePath string, frontmat
 				Message:  fmt.Sprintf("Frontmatter skills references non-existent skill '%s'. Create skills/%s/SKILL.md", skillName, skillName),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleSkillRefMissing,
 			})
 		}
 	}
@@ -544,42 +605,39 @@ func (v *CrossFileValidator) validateFrontmatterSkills(filePath string, frontmat
 func (v *CrossFileValidator) ValidateSkill(filePath string, contents string, frontmatter map[string]any) []cue.ValidationError {
 	var errors []cue.ValidationError
 
-	// Agent reference patterns - ordered from most specific to least specific
+	// Agent references in the body, reported once per agent
 	seenAgents := make(map[string]bool)
-	for _, agentPattern := range skillAgentPatterns {
-		matches := agentPattern.pattern.FindAllStringSubmatch(contents, -1)
-		for _, match := range matches {
-			if len(match) < 2 {
-				continue
-			}
-			agentRef := strings.TrimSpace(match[1])
-
-			// Skip dynamic/variable references
-			if strings.Contains(agentRef, "subagent_type") ||
-				strings.Contains(agentRef, ".") ||
-				strings.Contains(agentRef, "[") {
-				continue
-			}
+	for _, ref := range ScanReferences(contents) {
+		agentRef := skillAgentRef(ref)
+		if agentRef == "" || seenAgents[agentRef] {
+			continue
+		}
+		seenAgents[agentRef] = true
 
-			if seenAgents[agentRef] {
-				continue
-			}
-			seenAgents[agentRef] = true
-
-			if !v.hasResolvableAgent(agentRef) {
-				errors = append(errors, cue.ValidationError{
-					File:     filePath,
-					Message:  fmt.Sprintf("Skill references '%s' but agent doesn't exist. Create agents/%s.md", agentRef, agentRef),
-					Severity: cue.SeverityError,
-					Source:   cue.SourceCClintObserve,
-				})
-			}
+		if !v.hasResolvableAgent(agentRef) {
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Skill references '%s' but agent doesn't exist. Create agents/%s.md", agentRef, agentRef),
+				Severity: cue.SeverityError,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentRefMissing,
+				Line:     ref.Line,
+			})
 		}
 	}
 
 	// Validate frontmatter agent field
 	errors = append(errors, v.validateFrontmatterAgent(filePath, frontmatter)...)
 
+	// allowed-tools must be a subset of the executing agent's tools
+	errors = append(errors, v.validateSkillToolsSubset(filePath, contents, frontmatter)...)
+
+	// Descriptions too alike for Claude to choose between
+	errors = append(errors, v.validateSkillTriggerOverlap(filePath, contents, frontmatter)...)
+
+	// Check markdown links to agents and skills
+	errors = append(errors, v.checkComponentLinks(filePath, contents)...)
+
 	return errors
 }
 
@@ -601,6 +659,7 @@ func (v *CrossFileValidator) validateFrontmatterAgent(filePath string, frontmatt
 			Message:  fmt.Sprintf("Frontmatter agent field references non-existent agent '%s'. Create agents/%s.md", agentName, agentName),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleAgentRefMissing,
 		}}
 	}
 
@@ -638,19 +697,18 @@ func (v *CrossFileValidator) getAllReferencedSkills() map[string]bool {
 // collectCommandReferences collects skill references from commands.
 func (v *CrossFileValidator) collectCommandReferences(referencedSkills map[string]bool) {
 	for _, cmd := range v.commands {
+		refs := v.refsOf(cmd)
 		// Check Task() pattern
-		for _, match := range validateCommandTaskPattern.FindAllStringSubmatch(cmd.Contents, -1) {
-			if len(match) >= 2 {
-				agentRef := strings.TrimSpace(strings.Trim(match[1], `"'`))
-				if !strings.HasSuffix(agentRef, "-specialist") {
-					if _, exists := v.skills[agentRef]; exists {
-						referencedSkills[agentRef] = true
-					}
+		for _, task := range refs.tasks {
+			agentRef := strings.TrimSpace(strings.Trim(task, `"'`))
+			if !strings.HasSuffix(agentRef, "-specialist") {
+				if _, exists := v.skills[agentRef]; exists {
+					referencedSkills[agentRef] = true
 				}
 			}
 		}
 		// Check Skill() and Skill: references
-		for _, skillRef := range FindSkillReferences(cmd.Contents) {
+		for _, skillRef := range refs.skills {
 			referencedSkills[skillRef] = true
 		}
 	}
@@ -659,7 +717,7 @@ func (v *CrossFileValidator) collectCommandReferences(referencedSkills map[strin
 // collectAgentReferences collects skill references from agents.
 func (v *CrossFileValidator) collectAgentReferences(referencedSkills map[string]bool) {
 	for _, agent := range v.agents {
-		for _, skillRef := range FindSkillReferences(agent.Contents) {
+		for _, skillRef := range v.refsOf(agent).skills {
 			referencedSkills[skillRef] = true
 		}
 	}
@@ -669,8 +727,9 @@ func (v *CrossFileValidator) collectAgentReferences(referencedSkills map[string]
 func (v *CrossFileValidator) collectSkillToSkillReferencesMap(referencedSkills map[string]bool) {
 	for _, skill := range v.skills {
 		currentSkillName := ExtractSkillName(skill.RelPath)
+		contents := v.contentsOf(skill)
 		for skillName := range v.skills {
-			if skillName != currentSkillName && strings.Contains(skill.Contents, skillName) {
+			if skillName != currentSkillName && strings.Contains(contents, skillName) {
 				referencedSkills[skillName] = true
 			}
 		}
@@ -713,6 +772,7 @@ func (v *CrossFileValidator) findSkillOrphans(referencedSkills map[string]bool)
 				Message:  fmt.Sprintf("Skill '%s' has no incoming references - consider adding crossrefs from commands/agents/skills", skillName),
 				Severity: cue.SeverityInfo,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleOrphanedSkill,
 			})
 		}
 	}
@@ -247,6 +247,52 @@ func TestFindSkillReferences(t *testing.T) {
 	}
 }
 
+func TestFindFileReferences(t *testing.T) {
+	contents := "---\nname: x\nref: @fm/ignored.md\n---\n" +
+		"Review @src/main.go and @docs/guide.md.\n" +
+		"Mail user@example.com or ping @claude\n" +
+		"Install `@types/node` first\n" +
+		"```\n@code/ignored.go\n```\n" +
+		"Again @src/main.go"
+
+	got := FindFileReferences(contents)
+	want := []FileReference{
+		{Path: "src/main.go", Line: 5},
+		{Path: "docs/guide.md", Line: 5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("FindFileReferences() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("FindFileReferences()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestValidateCommand_FileReferences(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	contents := "---\nname: test\n---\nSummarize @README.md and @missing/file.go"
+
+	v := NewCrossFileValidator(nil, root)
+	errs := v.ValidateCommand("commands/test.md", contents, map[string]any{})
+	if len(errs) != 1 {
+		t.Fatalf("ValidateCommand() errors = %d, want 1: %v", len(errs), errs)
+	}
+	if errs[0].Rule != cue.RuleCommandFileRefMissing || errs[0].Line != 4 {
+		t.Errorf("got rule %q line %d, want %q line 4", errs[0].Rule, errs[0].Line, cue.RuleCommandFileRefMissing)
+	}
+
+	// Without a project root the check is skipped.
+	if errs := NewCrossFileValidator(nil).ValidateCommand("commands/test.md", contents, map[string]any{}); len(errs) != 0 {
+		t.Errorf("ValidateCommand() without root errors = %d, want 0", len(errs))
+	}
+}
+
 func TestParseAllowedTools(t *testing.T) {
 	tests := []struct {
 		name string
@@ -301,6 +347,9 @@ func TestIsToolUsed(t *testing.T) {
 		{"Read", "Read", "Use Read() tool", true},
 		{"Write", "Write", "Write tool to save", true},
 		{"specific Task", "Task(foo)", "Task(foo): do it", true},
+		{"specific Task with spacing and args", "Task(foo)", "Task( foo , prompt)", true},
+		{"specific Task after other calls", "Task(foo)", "Task(foobar) then Task(foo)", true},
+		{"specific Task prefix only", "Task(foo)", "Task(foobar)", false},
 		{"unused", "Edit", "no editing here", false},
 	}
 
@@ -360,6 +409,33 @@ func TestTraceChain(t *testing.T) {
 	if skillLink.Type != "skill" || skillLink.Name != "foo-skill" {
 		t.Errorf("TraceChain() grandchild = %s:%s, want skill:foo-skill", skillLink.Type, skillLink.Name)
 	}
+	if skillLink.Path != "skills/foo-skill/SKILL.md" || skillLink.Lines != 1 || skillLink.Tokens != 5 {
+		t.Errorf("TraceChain() grandchild = %s, %d lines, %d tokens; want skills/foo-skill/SKILL.md, 1 line, 5 tokens", skillLink.Path, skillLink.Lines, skillLink.Tokens)
+	}
+}
+
+func TestChains(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "commands/ship.md", Type: discovery.FileTypeCommand, Contents: "Task(builder)"},
+		{RelPath: "commands/build.md", Type: discovery.FileTypeCommand, Contents: "Task(builder)\nTask(missing)"},
+		{RelPath: "agents/builder.md", Type: discovery.FileTypeAgent, Contents: "Skill: go-build"},
+		{RelPath: "skills/go-build/SKILL.md", Type: discovery.FileTypeSkill, Contents: "Build steps"},
+	}
+	v := NewCrossFileValidator(files)
+
+	commands := v.Chains("command")
+	if len(commands) != 2 || commands[0].Name != "build" || commands[1].Name != "ship" {
+		t.Fatalf("Chains(command) = %+v, want build then ship", commands)
+	}
+	if len(commands[0].Children) != 1 || commands[0].Children[0].Name != "builder" {
+		t.Errorf("build chain children = %+v, want only the indexed agent builder", commands[0].Children)
+	}
+	if agents := v.Chains("agent"); len(agents) != 1 || len(agents[0].Children) != 1 {
+		t.Errorf("Chains(agent) = %+v, want builder with one skill", agents)
+	}
+	if skills := v.Chains("skill"); skills != nil {
+		t.Errorf("Chains(skill) = %+v, want nil", skills)
+	}
 }
 
 func TestFormatChain(t *testing.T) {
@@ -369,9 +445,10 @@ func TestFormatChain(t *testing.T) {
 		Lines: 10,
 		Children: []ChainLink{
 			{
-				Type:  "agent",
-				Name:  "test-specialist",
-				Lines: 50,
+				Type:   "agent",
+				Name:   "test-specialist",
+				Lines:  50,
+				Tokens: 400,
 			},
 		},
 	}
@@ -383,6 +460,9 @@ func TestFormatChain(t *testing.T) {
 	if !strings.Contains(output, "test-specialist") {
 		t.Error("FormatChain() should contain agent name")
 	}
+	if !strings.Contains(output, "(agent, 50 lines, ~400 tokens)") {
+		t.Errorf("FormatChain() should show lines and tokens, got %q", output)
+	}
 }
 
 func TestFindOrphanedSkills(t *testing.T) {
@@ -460,6 +540,10 @@ func TestCrossExtractFunctions(t *testing.T) {
 		{"agent name", ExtractAgentName, "agents/test-specialist.md", "test-specialist"},
 		{"skill name", ExtractSkillName, "skills/foo-bar/SKILL.md", "foo-bar"},
 		{"command name", ExtractCommandName, "commands/test.md", "test"},
+		{"namespaced command name", ExtractCommandName, ".claude/commands/git/commit.md", "git:commit"},
+		{"nested namespace", ExtractCommandName, "commands/a/b/c.md", "a:b:c"},
+		{"bare command file", ExtractCommandName, "deploy.md", "deploy"},
+		{"slash command name", SlashCommandName, "commands/git/commit.md", "/git:commit"},
 	}
 
 	for _, tt := range tests {
@@ -1375,6 +1459,11 @@ func TestExtractTaskAgentRefs(t *testing.T) {
 			tools: "Task(same-agent), Task(same-agent)",
 			want:  []string{"same-agent"},
 		},
+		{
+			name:  "comma-separated agents in one Task",
+			tools: "Read, Task(agent-a, agent-b)",
+			want:  []string{"agent-a", "agent-b"},
+		},
 		{
 			name:  "non-string non-array type",
 			tools: 42,
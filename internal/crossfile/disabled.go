@@ -0,0 +1,25 @@
+package crossfile
+
+import "github.com/dotcommander/cclint/internal/discovery"
+
+// IsDisabled reports whether a component's frontmatter turns it off with
+// `enabled: false` or `disabled: true`. Disabled components are still
+// validated on their own but take no part in cross-file analysis.
+func IsDisabled(frontmatter map[string]any) bool {
+	if enabled, ok := frontmatter["enabled"].(bool); ok && !enabled {
+		return true
+	}
+	disabled, _ := frontmatter["disabled"].(bool)
+	return disabled
+}
+
+// isDisabledFile reports whether a discovered component is disabled.
+func (v *CrossFileValidator) isDisabledFile(f discovery.File) bool {
+	return IsDisabled(v.frontmatterOf(f))
+}
+
+// isComponentType reports whether files of type t can be disabled through
+// frontmatter.
+func isComponentType(t discovery.FileType) bool {
+	return t == discovery.FileTypeAgent || t == discovery.FileTypeSkill || t == discovery.FileTypeCommand
+}
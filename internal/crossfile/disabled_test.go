@@ -0,0 +1,58 @@
+package crossfile
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestIsDisabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		want        bool
+	}{
+		{"no flag", map[string]any{"name": "a"}, false},
+		{"enabled false", map[string]any{"enabled": false}, true},
+		{"enabled true", map[string]any{"enabled": true}, false},
+		{"disabled true", map[string]any{"disabled": true}, true},
+		{"disabled false", map[string]any{"disabled": false}, false},
+		{"string value ignored", map[string]any{"enabled": "false"}, false},
+		{"nil frontmatter", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDisabled(tt.frontmatter); got != tt.want {
+				t.Errorf("IsDisabled(%v) = %v, want %v", tt.frontmatter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrossFileValidator_SkipsDisabled(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "skills/retired/SKILL.md", Type: discovery.FileTypeSkill, Contents: "---\nname: retired\nenabled: false\n---\nbody\n"},
+		{RelPath: "skills/used/SKILL.md", Type: discovery.FileTypeSkill, Contents: "---\nname: used\n---\nbody\n"},
+		{RelPath: "agents/old.md", Type: discovery.FileTypeAgent, Contents: "---\nname: old\ndisabled: true\n---\nSkill: used\n"},
+		{RelPath: "agents/new.md", Type: discovery.FileTypeAgent, Contents: "---\nname: new\n---\nbody\n"},
+		{RelPath: "commands/legacy.md", Type: discovery.FileTypeCommand, Contents: "---\nenabled: false\n---\nbody\n"},
+	}
+	v := NewCrossFileValidator(files)
+
+	if _, ok := v.agents["old"]; ok {
+		t.Error("disabled agent was indexed")
+	}
+	if len(v.allAgents) != 1 {
+		t.Errorf("allAgents = %d, want 1", len(v.allAgents))
+	}
+	if _, ok := v.commands["legacy"]; ok {
+		t.Error("disabled command was indexed")
+	}
+
+	// The disabled skill is not an orphan; the skill only a disabled agent
+	// references is.
+	orphans := v.FindOrphanedSkills()
+	if len(orphans) != 1 || orphans[0].File != "skills/used/SKILL.md" {
+		t.Errorf("FindOrphanedSkills() = %v, want only skills/used/SKILL.md", orphans)
+	}
+}
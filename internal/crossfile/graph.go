@@ -2,24 +2,41 @@ package crossfile
 
 import (
 	"fmt"
-	"regexp"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/dotcommander/cclint/internal/cue"
 )
 
-// Pre-compiled regex patterns for graph traversal.
-var (
-	// taskPattern matches Task(agent-name) patterns.
-	taskPattern = regexp.MustCompile(`Task\(([^,\)]+)`)
+// GraphStats summarizes the component dependency graph.
+type GraphStats struct {
+	Agents   int
+	Skills   int
+	Commands int
+	Edges    int // references between indexed components
+	Cycles   int
+}
 
-	// agentRefPatterns are patterns for extracting agent references from skill content.
-	agentRefPatterns = []*regexp.Regexp{
-		regexp.MustCompile(`delegate to\s+([a-z0-9][a-z0-9-]+)`),
-		regexp.MustCompile(`use\s+([a-z0-9][a-z0-9-]+)`),
-		regexp.MustCompile(`Task\(([a-z0-9][a-z0-9-]+)`),
+// Stats returns the size of the component graph and how many cycles it has.
+func (v *CrossFileValidator) Stats() GraphStats {
+	stats := GraphStats{
+		Agents:   len(v.agents),
+		Skills:   len(v.skills),
+		Commands: len(v.commands),
+		Cycles:   len(v.DetectCycles()),
 	}
-)
+	for name := range v.commands {
+		stats.Edges += len(v.getNeighbors(cue.TypeCommand, name))
+	}
+	for name := range v.agents {
+		stats.Edges += len(v.getNeighbors(cue.TypeAgent, name))
+	}
+	for name := range v.skills {
+		stats.Edges += len(v.getNeighbors(cue.TypeSkill, name))
+	}
+	return stats
+}
 
 // Cycle represents a circular dependency in the component graph
 type Cycle struct {
@@ -44,7 +61,7 @@ func (v *CrossFileValidator) getNeighbors(componentType, name string) []string {
 // getCommandNeighbors returns neighbors for a command component.
 func (v *CrossFileValidator) getCommandNeighbors(name string) []string {
 	if cmd, exists := v.commands[name]; exists {
-		return v.extractAgentRefsFromTask(cmd.Contents, taskPattern, "")
+		return v.extractAgentRefsFromTask(v.refsOf(cmd).tasks, "")
 	}
 	return nil
 }
@@ -54,12 +71,14 @@ func (v *CrossFileValidator) getAgentNeighbors(name string) []string {
 	var neighbors []string
 
 	if agent, exists := v.agents[name]; exists {
+		refs := v.refsOf(agent)
+
 		// Add skill references
-		skillRefs := v.findValidSkillReferences(agent.Contents)
+		skillRefs := v.findValidSkillReferences(refs.skills)
 		neighbors = append(neighbors, skillRefs...)
 
 		// Add agent references (exclude self)
-		agentRefs := v.extractAgentRefsFromTask(agent.Contents, taskPattern, name)
+		agentRefs := v.extractAgentRefsFromTask(refs.tasks, name)
 		neighbors = append(neighbors, agentRefs...)
 	}
 
@@ -72,21 +91,21 @@ func (v *CrossFileValidator) getSkillNeighbors(name string) []string {
 
 	if skill, exists := v.skills[name]; exists {
 		// Add agent references
-		agentRefs := v.extractAgentRefsFromPatterns(skill.Contents)
+		agentRefs := v.extractAgentRefsFromPatterns(v.refsOf(skill).refs)
 		neighbors = append(neighbors, agentRefs...)
 
 		// Add other skill references (exclude self)
-		skillRefs := v.findOtherSkillReferences(skill.Contents, name)
+		skillRefs := v.findOtherSkillReferences(v.refsOf(skill).skills, name)
 		neighbors = append(neighbors, skillRefs...)
 	}
 
 	return neighbors
 }
 
-// findValidSkillReferences finds skill references that exist in the validator.
-func (v *CrossFileValidator) findValidSkillReferences(contents string) []string {
+// findValidSkillReferences filters skill references to those that exist in the validator.
+func (v *CrossFileValidator) findValidSkillReferences(skillRefs []string) []string {
 	var refs []string
-	for _, skillRef := range FindSkillReferences(contents) {
+	for _, skillRef := range skillRefs {
 		if _, exists := v.skills[skillRef]; exists {
 			refs = append(refs, "skill:"+skillRef)
 		}
@@ -94,10 +113,10 @@ func (v *CrossFileValidator) findValidSkillReferences(contents string) []string
 	return refs
 }
 
-// findOtherSkillReferences finds skill references excluding the current skill.
-func (v *CrossFileValidator) findOtherSkillReferences(contents string, excludeName string) []string {
+// findOtherSkillReferences filters skill references to existing skills other than the current one.
+func (v *CrossFileValidator) findOtherSkillReferences(skillRefs []string, excludeName string) []string {
 	var refs []string
-	for _, skillRef := range FindSkillReferences(contents) {
+	for _, skillRef := range skillRefs {
 		if skillRef != excludeName {
 			if _, exists := v.skills[skillRef]; exists {
 				refs = append(refs, "skill:"+skillRef)
@@ -107,41 +126,42 @@ func (v *CrossFileValidator) findOtherSkillReferences(contents string, excludeNa
 	return refs
 }
 
-// extractAgentRefsFromTask extracts agent references from Task() calls.
+// extractAgentRefsFromTask extracts agent references from Task() arguments.
 // excludeName is used to prevent self-references (pass "" to include all).
-func (v *CrossFileValidator) extractAgentRefsFromTask(contents string, pattern *regexp.Regexp, excludeName string) []string {
+func (v *CrossFileValidator) extractAgentRefsFromTask(tasks []string, excludeName string) []string {
 	var refs []string
-	matches := pattern.FindAllStringSubmatch(contents, -1)
-	for _, match := range matches {
-		if len(match) >= 2 {
-			agentRef := strings.TrimSpace(match[1])
-			agentRef = strings.Trim(agentRef, `"'`)
-			if strings.Contains(agentRef, "subagent_type") {
-				continue
-			}
-			if excludeName != "" && agentRef == excludeName {
-				continue
-			}
-			if _, exists := v.agents[agentRef]; exists {
-				refs = append(refs, "agent:"+agentRef)
-			}
+	for _, task := range tasks {
+		agentRef := strings.TrimSpace(task)
+		agentRef = strings.Trim(agentRef, `"'`)
+		if strings.Contains(agentRef, "subagent_type") {
+			continue
+		}
+		if excludeName != "" && agentRef == excludeName {
+			continue
+		}
+		if _, exists := v.agents[agentRef]; exists {
+			refs = append(refs, "agent:"+agentRef)
 		}
 	}
 	return refs
 }
 
-// extractAgentRefsFromPatterns extracts agent references from skill content patterns.
-func (v *CrossFileValidator) extractAgentRefsFromPatterns(contents string) []string {
+// extractAgentRefsFromPatterns extracts agent references from the
+// "delegate to x", "use x", and Task(x) references in skill content.
+func (v *CrossFileValidator) extractAgentRefsFromPatterns(scanned []Reference) []string {
 	var refs []string
-	for _, re := range agentRefPatterns {
-		matches := re.FindAllStringSubmatch(contents, -1)
-		for _, match := range matches {
-			if len(match) >= 2 {
-				agentRef := strings.TrimSpace(match[1])
-				if _, exists := v.agents[agentRef]; exists {
-					refs = append(refs, "agent:"+agentRef)
-				}
-			}
+	for _, ref := range scanned {
+		var agentRef string
+		switch ref.Kind {
+		case RefDelegateTo, RefUse:
+			agentRef = ref.Name
+		case RefTask:
+			agentRef = leadingName(ref.Name)
+		default:
+			continue
+		}
+		if _, exists := v.agents[agentRef]; exists {
+			refs = append(refs, "agent:"+agentRef)
 		}
 	}
 	return refs
@@ -289,11 +309,12 @@ func FormatCycle(cycle Cycle) string {
 
 // ChainLink represents a component in the delegation chain
 type ChainLink struct {
-	Type     string // "command", "agent", "skill"
-	Name     string
-	Path     string
-	Lines    int
-	Children []ChainLink
+	Type     string      `json:"type"` // "command", "agent", "skill"
+	Name     string      `json:"name"`
+	Path     string      `json:"file"`
+	Lines    int         `json:"lines"`
+	Tokens   int         `json:"tokens"` // estimated size of the whole file
+	Children []ChainLink `json:"children,omitempty"`
 }
 
 // TraceChain traces the full delegation chain starting from a component
@@ -309,29 +330,50 @@ func (v *CrossFileValidator) TraceChain(componentType string, name string) *Chai
 	return nil
 }
 
+// Chains traces the delegation chain of every command, or of every agent
+// when componentType is "agent", in name order. Other types have no
+// delegation chains and return nil.
+func (v *CrossFileValidator) Chains(componentType string) []ChainLink {
+	var names []string
+	switch componentType {
+	case cue.TypeCommand:
+		names = slices.Sorted(maps.Keys(v.commands))
+	case cue.TypeAgent:
+		names = slices.Sorted(maps.Keys(v.agents))
+	default:
+		return nil
+	}
+	chains := make([]ChainLink, 0, len(names))
+	for _, name := range names {
+		if link := v.TraceChain(componentType, name); link != nil {
+			chains = append(chains, *link)
+		}
+	}
+	return chains
+}
+
 func (v *CrossFileValidator) traceFromCommand(name string) *ChainLink {
 	file, exists := v.commands[name]
 	if !exists {
 		return nil
 	}
 
+	refs := v.refsOf(file)
 	link := &ChainLink{
-		Type:  "command",
-		Name:  name,
-		Path:  file.RelPath,
-		Lines: strings.Count(file.Contents, "\n") + 1,
+		Type:   "command",
+		Name:   name,
+		Path:   file.RelPath,
+		Lines:  refs.lines,
+		Tokens: refs.tokens,
 	}
 
 	// Find Task() delegations
-	matches := taskPattern.FindAllStringSubmatch(file.Contents, -1)
-	for _, match := range matches {
-		if len(match) >= 2 {
-			agentRef := strings.TrimSpace(match[1])
-			agentRef = strings.Trim(agentRef, `"'`)
-			if !strings.Contains(agentRef, "subagent_type") {
-				if child := v.traceFromAgent(agentRef); child != nil {
-					link.Children = append(link.Children, *child)
-				}
+	for _, task := range refs.tasks {
+		agentRef := strings.TrimSpace(task)
+		agentRef = strings.Trim(agentRef, `"'`)
+		if !strings.Contains(agentRef, "subagent_type") {
+			if child := v.traceFromAgent(agentRef); child != nil {
+				link.Children = append(link.Children, *child)
 			}
 		}
 	}
@@ -345,16 +387,17 @@ func (v *CrossFileValidator) traceFromAgent(name string) *ChainLink {
 		return nil
 	}
 
+	refs := v.refsOf(file)
 	link := &ChainLink{
-		Type:  "agent",
-		Name:  name,
-		Path:  file.RelPath,
-		Lines: strings.Count(file.Contents, "\n") + 1,
+		Type:   "agent",
+		Name:   name,
+		Path:   file.RelPath,
+		Lines:  refs.lines,
+		Tokens: refs.tokens,
 	}
 
 	// Find Skill references using comprehensive pattern matching
-	skillRefs := FindSkillReferences(file.Contents)
-	for _, skillRef := range skillRefs {
+	for _, skillRef := range refs.skills {
 		if child := v.traceFromSkill(skillRef); child != nil {
 			link.Children = append(link.Children, *child)
 		}
@@ -369,11 +412,13 @@ func (v *CrossFileValidator) traceFromSkill(name string) *ChainLink {
 		return nil
 	}
 
+	refs := v.refsOf(file)
 	return &ChainLink{
-		Type:  "skill",
-		Name:  name,
-		Path:  file.RelPath,
-		Lines: strings.Count(file.Contents, "\n") + 1,
+		Type:   "skill",
+		Name:   name,
+		Path:   file.RelPath,
+		Lines:  refs.lines,
+		Tokens: refs.tokens,
 	}
 }
 
@@ -384,7 +429,7 @@ func FormatChain(link *ChainLink, indent string) string {
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("%s%s (%s, %d lines)\n", indent, link.Name, link.Type, link.Lines))
+	sb.WriteString(fmt.Sprintf("%s%s (%s, %d lines, ~%d tokens)\n", indent, link.Name, link.Type, link.Lines, link.Tokens))
 
 	for i, child := range link.Children {
 		prefix := "\u251C\u2500\u2500 "
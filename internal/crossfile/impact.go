@@ -0,0 +1,126 @@
+package crossfile
+
+import (
+	"cmp"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+// Dependent is a component that depends, directly or through other
+// components, on the target of an impact analysis.
+type Dependent struct {
+	Type  string `json:"type"` // "command", "agent", "skill"
+	Name  string `json:"name"`
+	Path  string `json:"file"`
+	Depth int    `json:"depth"` // 1 when it references the target itself
+	Via   string `json:"via"`   // the "type:name" node it references on the way to the target
+}
+
+// ReverseIndex maps each "type:name" node to the sorted nodes that reference
+// it: the reverse of the edges DetectCycles walks. It is built on first use.
+func (v *CrossFileValidator) ReverseIndex() map[string][]string {
+	if v.dependents != nil {
+		return v.dependents
+	}
+	v.dependents = make(map[string][]string)
+	addEdges := func(componentType string, names []string) {
+		for _, name := range names {
+			from := componentType + ":" + name
+			for _, to := range v.getNeighbors(componentType, name) {
+				if to != from && !slices.Contains(v.dependents[to], from) {
+					v.dependents[to] = append(v.dependents[to], from)
+				}
+			}
+		}
+	}
+	addEdges(cue.TypeCommand, slices.Sorted(maps.Keys(v.commands)))
+	addEdges(cue.TypeAgent, slices.Sorted(maps.Keys(v.agents)))
+	addEdges(cue.TypeSkill, slices.Sorted(maps.Keys(v.skills)))
+	for _, from := range v.dependents {
+		slices.Sort(from)
+	}
+	return v.dependents
+}
+
+// Impact returns every component that transitively depends on the given
+// component, nearest first, so authors can see what may break before
+// editing or deleting it. Each dependent is listed once, at its shortest
+// distance. Returns nil when the component is not indexed.
+func (v *CrossFileValidator) Impact(componentType, name string) []Dependent {
+	if _, ok := v.componentFile(componentType, name); !ok {
+		return nil
+	}
+	index := v.ReverseIndex()
+	target := componentType + ":" + name
+	seen := map[string]bool{target: true}
+	var dependents []Dependent
+	queue := []string{target}
+	for depth := 1; len(queue) > 0; depth++ {
+		var next []string
+		for _, node := range queue {
+			for _, from := range index[node] {
+				if seen[from] {
+					continue
+				}
+				seen[from] = true
+				fromType, fromName, _ := strings.Cut(from, ":")
+				f, _ := v.componentFile(fromType, fromName)
+				dependents = append(dependents, Dependent{
+					Type:  fromType,
+					Name:  fromName,
+					Path:  f.RelPath,
+					Depth: depth,
+					Via:   node,
+				})
+				next = append(next, from)
+			}
+		}
+		queue = next
+	}
+	slices.SortStableFunc(dependents, func(a, b Dependent) int {
+		return cmp.Or(cmp.Compare(a.Depth, b.Depth), cmp.Compare(a.Type, b.Type), cmp.Compare(a.Name, b.Name))
+	})
+	return dependents
+}
+
+// ComponentAt returns the type and name of the indexed component whose file
+// is path, matched against both the discovered path and the path relative
+// to the project root.
+func (v *CrossFileValidator) ComponentAt(path string) (componentType, name string, ok bool) {
+	path = filepath.Clean(path)
+	for _, idx := range []struct {
+		componentType string
+		files         map[string]discovery.File
+	}{
+		{cue.TypeCommand, v.commands},
+		{cue.TypeAgent, v.agents},
+		{cue.TypeSkill, v.skills},
+	} {
+		for n, f := range idx.files {
+			if filepath.Clean(f.Path) == path || filepath.Clean(f.RelPath) == path {
+				return idx.componentType, n, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// componentFile returns the indexed file of a component.
+func (v *CrossFileValidator) componentFile(componentType, name string) (discovery.File, bool) {
+	var f discovery.File
+	var ok bool
+	switch componentType {
+	case cue.TypeCommand:
+		f, ok = v.commands[name]
+	case cue.TypeAgent:
+		f, ok = v.agents[name]
+	case cue.TypeSkill:
+		f, ok = v.skills[name]
+	}
+	return f, ok
+}
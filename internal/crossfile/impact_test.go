@@ -0,0 +1,101 @@
+package crossfile
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func impactFiles() []discovery.File {
+	return []discovery.File{
+		{Path: "/p/commands/ship.md", RelPath: "commands/ship.md", Type: discovery.FileTypeCommand, Contents: "Task(builder)\nTask(reviewer)"},
+		{Path: "/p/commands/lint.md", RelPath: "commands/lint.md", Type: discovery.FileTypeCommand, Contents: "Task(reviewer)"},
+		{Path: "/p/agents/builder.md", RelPath: "agents/builder.md", Type: discovery.FileTypeAgent, Contents: "Skill: go-build"},
+		{Path: "/p/agents/reviewer.md", RelPath: "agents/reviewer.md", Type: discovery.FileTypeAgent, Contents: "Skill: go-build\nSkill: style"},
+		{Path: "/p/skills/go-build/SKILL.md", RelPath: "skills/go-build/SKILL.md", Type: discovery.FileTypeSkill, Contents: "Build steps"},
+		{Path: "/p/skills/style/SKILL.md", RelPath: "skills/style/SKILL.md", Type: discovery.FileTypeSkill, Contents: "Style guide"},
+	}
+}
+
+func TestReverseIndex(t *testing.T) {
+	v := NewCrossFileValidator(impactFiles())
+	index := v.ReverseIndex()
+
+	want := map[string][]string{
+		"agent:builder":  {"command:ship"},
+		"agent:reviewer": {"command:lint", "command:ship"},
+		"skill:go-build": {"agent:builder", "agent:reviewer"},
+		"skill:style":    {"agent:reviewer"},
+	}
+	if !reflect.DeepEqual(index, want) {
+		t.Errorf("ReverseIndex() = %v, want %v", index, want)
+	}
+}
+
+func TestImpact(t *testing.T) {
+	v := NewCrossFileValidator(impactFiles())
+
+	tests := []struct {
+		name          string
+		componentType string
+		component     string
+		want          []Dependent
+	}{
+		{
+			name:          "skill used by two agents",
+			componentType: "skill",
+			component:     "go-build",
+			want: []Dependent{
+				{Type: "agent", Name: "builder", Path: "agents/builder.md", Depth: 1, Via: "skill:go-build"},
+				{Type: "agent", Name: "reviewer", Path: "agents/reviewer.md", Depth: 1, Via: "skill:go-build"},
+				{Type: "command", Name: "lint", Path: "commands/lint.md", Depth: 2, Via: "agent:reviewer"},
+				{Type: "command", Name: "ship", Path: "commands/ship.md", Depth: 2, Via: "agent:builder"},
+			},
+		},
+		{
+			name:          "agent used by one command",
+			componentType: "agent",
+			component:     "builder",
+			want: []Dependent{
+				{Type: "command", Name: "ship", Path: "commands/ship.md", Depth: 1, Via: "agent:builder"},
+			},
+		},
+		{name: "command has no dependents", componentType: "command", component: "ship"},
+		{name: "unknown component", componentType: "skill", component: "nope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v.Impact(tt.componentType, tt.component)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Impact(%s, %s) = %+v, want %+v", tt.componentType, tt.component, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponentAt(t *testing.T) {
+	v := NewCrossFileValidator(impactFiles())
+
+	tests := []struct {
+		path     string
+		wantType string
+		wantName string
+		wantOK   bool
+	}{
+		{path: "/p/skills/style/SKILL.md", wantType: "skill", wantName: "style", wantOK: true},
+		{path: "agents/./reviewer.md", wantType: "agent", wantName: "reviewer", wantOK: true},
+		{path: "commands/ship.md", wantType: "command", wantName: "ship", wantOK: true},
+		{path: "README.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			gotType, gotName, ok := v.ComponentAt(tt.path)
+			if gotType != tt.wantType || gotName != tt.wantName || ok != tt.wantOK {
+				t.Errorf("ComponentAt(%q) = %s, %s, %v; want %s, %s, %v", tt.path, gotType, gotName, ok, tt.wantType, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package crossfile
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// fileRefs holds what reference scanning extracts from one component file.
+// It is computed once per file when the validator is built, so orphan
+// detection, cycle detection, and chain tracing do not rescan contents.
+type fileRefs struct {
+	disabled bool
+	refs     []Reference // every reference, see ScanReferences
+	skills   []string    // distinct skill references, Skill syntax or links
+	tasks    []string    // raw Task(...) arguments
+	lines    int
+	tokens   int // estimated, see textutil.EstimateTokens
+}
+
+// scanFile extracts the references of a single component file.
+func (v *CrossFileValidator) scanFile(f discovery.File) *fileRefs {
+	contents := v.contentsOf(f)
+	refs := &fileRefs{
+		disabled: v.isDisabledFile(f),
+		refs:     ScanReferences(contents),
+		lines:    strings.Count(contents, "\n") + 1,
+		tokens:   textutil.EstimateTokens(contents),
+	}
+	seen := make(map[string]bool)
+	for _, ref := range refs.refs {
+		name := ref.Name
+		if ref.Kind == RefLink {
+			if kind, linked, ok := linkedComponent(name); ok && kind == cue.TypeSkill {
+				name = linked
+			} else {
+				continue
+			}
+		}
+		switch {
+		case ref.Kind == RefTask:
+			refs.tasks = append(refs.tasks, name)
+		case (ref.Kind == RefSkill || ref.Kind == RefLink) && !seen[name]:
+			seen[name] = true
+			refs.skills = append(refs.skills, name)
+		}
+	}
+	return refs
+}
+
+// scanFiles scans every agent, skill, and command in files, spreading the
+// work across GOMAXPROCS workers. Reading and reference scanning dominate
+// validator construction on large projects; indexing itself stays serial
+// so precedence between same-name files does not depend on scheduling.
+func (v *CrossFileValidator) scanFiles(files []discovery.File) {
+	var components []discovery.File
+	for _, f := range files {
+		if isComponentType(f.Type) {
+			components = append(components, f)
+		}
+	}
+	results := make([]*fileRefs, len(components))
+
+	// Create the shared caches before the workers touch them.
+	v.initCaches()
+
+	workers := min(runtime.GOMAXPROCS(0), len(components))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = v.scanFile(components[i])
+			}
+		}()
+	}
+	for i := range components {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	v.refs = make(map[string]*fileRefs, len(components))
+	for i, f := range components {
+		v.refs[f.RelPath] = results[i]
+	}
+}
+
+// refsOf returns the scanned references of f, scanning it now if it was not
+// part of the indexed files.
+func (v *CrossFileValidator) refsOf(f discovery.File) *fileRefs {
+	if refs, ok := v.refs[f.RelPath]; ok {
+		return refs
+	}
+	if v.refs == nil {
+		v.refs = make(map[string]*fileRefs)
+	}
+	refs := v.scanFile(f)
+	v.refs[f.RelPath] = refs
+	return refs
+}
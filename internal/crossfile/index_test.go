@@ -0,0 +1,78 @@
+package crossfile
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestScanFiles(t *testing.T) {
+	var files []discovery.File
+	for i := range 50 {
+		files = append(files,
+			discovery.File{RelPath: fmt.Sprintf("agents/agent-%d.md", i), Type: discovery.FileTypeAgent,
+				Contents: fmt.Sprintf("---\nname: agent-%d\n---\nSkill: skill-%d\nTask(agent-%d)\n", i, i, (i+1)%50)},
+			discovery.File{RelPath: fmt.Sprintf("skills/skill-%d/SKILL.md", i), Type: discovery.FileTypeSkill,
+				Contents: fmt.Sprintf("---\nname: skill-%d\n---\nbody\n", i)},
+		)
+	}
+	files = append(files,
+		discovery.File{RelPath: "commands/run.md", Type: discovery.FileTypeCommand, Contents: "Task(agent-0, \"go\")\nSkill(skill-1)\n"},
+		discovery.File{RelPath: "agents/off.md", Type: discovery.FileTypeAgent, Contents: "---\nenabled: false\n---\n"},
+		discovery.File{RelPath: "CLAUDE.md", Type: discovery.FileTypeContext, Contents: "Task(agent-0)\n"},
+	)
+
+	v := NewCrossFileValidator(files)
+
+	if got := len(v.refs); got != 102 {
+		t.Fatalf("scanned %d component files, want 102", got)
+	}
+	if _, ok := v.refs["CLAUDE.md"]; ok {
+		t.Error("non-component file was scanned")
+	}
+	if !v.refs["agents/off.md"].disabled {
+		t.Error("disabled agent not marked disabled")
+	}
+	if _, ok := v.agents["off"]; ok {
+		t.Error("disabled agent was indexed")
+	}
+
+	cmd := v.refs["commands/run.md"]
+	want := &fileRefs{
+		refs: []Reference{
+			{Kind: RefTask, Name: "agent-0", Offset: 5, Line: 1},
+			{Kind: RefSkill, Name: "skill-1", Offset: 26, Line: 2},
+		},
+		skills: []string{"skill-1"},
+		tasks:  []string{"agent-0"},
+		lines:  3,
+		tokens: 9,
+	}
+	if !reflect.DeepEqual(cmd, want) {
+		t.Errorf("command refs = %+v, want %+v", cmd, want)
+	}
+
+	// Every file scanned concurrently matches a serial scan.
+	for _, f := range files {
+		if !isComponentType(f.Type) {
+			continue
+		}
+		if got, want := v.refs[f.RelPath], v.scanFile(f); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: refs = %+v, want %+v", f.RelPath, got, want)
+		}
+	}
+
+	if cycles := v.DetectCycles(); len(cycles) == 0 {
+		t.Error("expected the agent ring to be reported as a cycle")
+	}
+}
+
+func TestRefsOfUnindexedFile(t *testing.T) {
+	v := &CrossFileValidator{}
+	f := discovery.File{RelPath: "agents/late.md", Type: discovery.FileTypeAgent, Contents: "Skill: late-skill\n"}
+	if got := v.refsOf(f).skills; !reflect.DeepEqual(got, []string{"late-skill"}) {
+		t.Errorf("refsOf().skills = %v, want [late-skill]", got)
+	}
+}
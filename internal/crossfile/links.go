@@ -0,0 +1,107 @@
+package crossfile
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+// linkedComponent returns the component a markdown link target names by
+// its shape: skills/<name>/SKILL.md is a skill and agents/<name>.md an
+// agent. Other targets are ordinary document links.
+func linkedComponent(target string) (kind, name string, ok bool) {
+	p := path.Clean(filepath.ToSlash(target))
+	dir, base := path.Split(p)
+	dir = strings.TrimSuffix(dir, "/")
+	switch {
+	case base == "SKILL.md" && path.Base(path.Dir(dir)) == "skills":
+		return cue.TypeSkill, path.Base(dir), true
+	case path.Base(dir) == "agents" && strings.HasSuffix(base, ".md"):
+		return cue.TypeAgent, strings.TrimSuffix(base, ".md"), true
+	}
+	return "", "", false
+}
+
+// IsComponentLink reports whether a markdown link target names an agent or
+// skill file, which ValidateAgent, ValidateCommand, and ValidateSkill check.
+func IsComponentLink(target string) bool {
+	_, _, ok := linkedComponent(target)
+	return ok
+}
+
+// resolveLink returns the project-relative path a link target in the
+// file at relPath points to. Targets starting with "/" are relative to
+// the project root, as on a rendered repository page.
+func resolveLink(relPath, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return path.Clean(strings.TrimPrefix(target, "/"))
+	}
+	return path.Join(path.Dir(filepath.ToSlash(relPath)), target)
+}
+
+// componentTypeAt returns the type of the indexed component at relPath.
+func (v *CrossFileValidator) componentTypeAt(relPath string) (discovery.FileType, bool) {
+	for _, group := range [][]discovery.File{v.allAgents, v.allCommands} {
+		for _, f := range group {
+			if path.Clean(filepath.ToSlash(f.RelPath)) == relPath {
+				return f.Type, true
+			}
+		}
+	}
+	for _, f := range v.skills {
+		if path.Clean(filepath.ToSlash(f.RelPath)) == relPath {
+			return f.Type, true
+		}
+	}
+	return 0, false
+}
+
+// checkComponentLinks validates markdown links to agents and skills, such
+// as [code-reviewer](../agents/code-reviewer.md): the target must exist
+// and, when cclint indexes it, be the kind of component its path names.
+// Links are resolved from the linking file, so the check needs a root.
+func (v *CrossFileValidator) checkComponentLinks(filePath, contents string) []cue.ValidationError {
+	if v.rootPath == "" {
+		return nil
+	}
+	var errors []cue.ValidationError
+	seen := make(map[string]bool)
+	for _, ref := range ScanReferences(contents) {
+		if ref.Kind != RefLink || seen[ref.Name] {
+			continue
+		}
+		seen[ref.Name] = true
+		kind, name, ok := linkedComponent(ref.Name)
+		if !ok {
+			continue
+		}
+		target := resolveLink(filePath, ref.Name)
+		if _, err := os.Stat(filepath.Join(v.rootPath, filepath.FromSlash(target))); err != nil {
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Link to %s '%s' points to %s, which does not exist. Fix the path or create the %s", kind, name, target, kind),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleComponentLinkMissing,
+				Line:     ref.Line,
+			})
+			continue
+		}
+		if ft, ok := v.componentTypeAt(target); ok && ft.String() != kind {
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Link to %s reads as %s '%s', but %s is a %s", ref.Name, kind, name, target, ft),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleComponentLinkType,
+				Line:     ref.Line,
+			})
+		}
+	}
+	return errors
+}
@@ -0,0 +1,83 @@
+package crossfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestLinkedComponent(t *testing.T) {
+	tests := []struct {
+		target     string
+		kind, name string
+		ok         bool
+	}{
+		{"../agents/reviewer.md", cue.TypeAgent, "reviewer", true},
+		{"/.claude/skills/go-style/SKILL.md", cue.TypeSkill, "go-style", true},
+		{"skills/go-style/SKILL.md", cue.TypeSkill, "go-style", true},
+		{"skills/go-style/references/api.md", "", "", false},
+		{"docs/guide.md", "", "", false},
+	}
+	for _, tt := range tests {
+		kind, name, ok := linkedComponent(tt.target)
+		if kind != tt.kind || name != tt.name || ok != tt.ok {
+			t.Errorf("linkedComponent(%q) = %q, %q, %v, want %q, %q, %v", tt.target, kind, name, ok, tt.kind, tt.name, tt.ok)
+		}
+	}
+}
+
+func TestCheckComponentLinks(t *testing.T) {
+	root := t.TempDir()
+	files := []discovery.File{
+		{RelPath: "agents/reviewer.md", Type: discovery.FileTypeAgent, Contents: "Reviews code"},
+		{RelPath: "skills/go-style/SKILL.md", Type: discovery.FileTypeSkill, Contents: "Go style"},
+		{RelPath: "skills/orphan/SKILL.md", Type: discovery.FileTypeSkill, Contents: "Nobody links here"},
+		// A command file misplaced under agents/.
+		{RelPath: "agents/deploy.md", Type: discovery.FileTypeCommand, Contents: "Deploy"},
+	}
+	for _, f := range files {
+		path := filepath.Join(root, filepath.FromSlash(f.RelPath))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(f.Contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	v := NewCrossFileValidator(files, root)
+
+	contents := "Ask [the reviewer](../agents/reviewer.md) first.\n" +
+		"Follow [Go style](/skills/go-style/SKILL.md#naming) and [it again](/skills/go-style/SKILL.md).\n" +
+		"Then [the tester](../agents/tester.md) and [deploy](../agents/deploy.md).\n" +
+		"See [the guide](../docs/guide.md)."
+	errs := v.checkComponentLinks("commands/ship.md", contents)
+	if len(errs) != 2 {
+		t.Fatalf("checkComponentLinks() = %d errors, want 2: %v", len(errs), errs)
+	}
+	if errs[0].Rule != cue.RuleComponentLinkMissing || errs[0].Line != 3 {
+		t.Errorf("errs[0] = %q line %d, want %q line 3", errs[0].Rule, errs[0].Line, cue.RuleComponentLinkMissing)
+	}
+	if errs[1].Rule != cue.RuleComponentLinkType || errs[1].Line != 3 {
+		t.Errorf("errs[1] = %q line %d, want %q line 3", errs[1].Rule, errs[1].Line, cue.RuleComponentLinkType)
+	}
+
+	// Without a project root the check is skipped.
+	if errs := NewCrossFileValidator(files).checkComponentLinks("commands/ship.md", contents); len(errs) != 0 {
+		t.Errorf("checkComponentLinks() without root = %d errors, want 0", len(errs))
+	}
+}
+
+func TestFindOrphanedSkills_Links(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "agents/writer.md", Type: discovery.FileTypeAgent, Contents: "Follow [the style guide](../skills/docs-style/SKILL.md)."},
+		{RelPath: "skills/docs-style/SKILL.md", Type: discovery.FileTypeSkill, Contents: "Docs style"},
+		{RelPath: "skills/unused/SKILL.md", Type: discovery.FileTypeSkill, Contents: "Unused"},
+	}
+	orphans := NewCrossFileValidator(files).FindOrphanedSkills()
+	if len(orphans) != 1 || orphans[0].File != "skills/unused/SKILL.md" {
+		t.Errorf("FindOrphanedSkills() = %v, want only skills/unused/SKILL.md", orphans)
+	}
+}
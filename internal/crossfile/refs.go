@@ -5,60 +5,85 @@
 package crossfile
 
 import (
+	"path/filepath"
 	"regexp"
 	"strings"
 )
 
-// Pre-compiled regex patterns for skill reference detection.
-// These compile once at init instead of per-invocation.
-var (
-	// skillPlainPattern matches "Skill: foo-bar" (plain format, not inside bold markers).
-	// Note: [^*\n]* prevents matching across newlines (Go regex quirk).
-	skillPlainPattern = regexp.MustCompile(`(?m)^[^*\n]*\bSkill:\s*([a-z0-9][a-z0-9-]*)`)
-
-	// skillBoldPattern matches "**Skill**: foo-bar" (bold format).
-	skillBoldPattern = regexp.MustCompile(`(?m)\*\*Skill\*\*:\s*([a-z0-9][a-z0-9-]*)`)
-
-	// skillFuncPattern matches Skill("foo-bar") or Skill(foo-bar) (function call format).
-	skillFuncPattern = regexp.MustCompile(`(?m)Skill\(\s*["']?([a-z0-9][a-z0-9-]*)["']?\s*\)`)
-
-	// skillListPattern matches "Skills:" followed by list items.
-	skillListPattern = regexp.MustCompile(`(?m)Skills?:\s*\n\s*[-*]\s*([a-z0-9][a-z0-9-]*)`)
-
-	// skillPatterns is the ordered list of all skill reference patterns.
-	skillPatterns = []*regexp.Regexp{
-		skillPlainPattern,
-		skillBoldPattern,
-		skillFuncPattern,
-		skillListPattern,
-	}
-)
-
 // Pre-compiled regex patterns for tool parsing and matching.
 var (
 	// taskToolPattern matches Task(xxx) patterns in allowed-tools strings.
 	taskToolPattern = regexp.MustCompile(`Task\([^)]+\)`)
 )
 
-// FindSkillReferences finds all skill references in content using multiple patterns.
-// Matches: Skill: X, **Skill**: X, Skill(X), Skills: list, and code block declarations.
-func FindSkillReferences(content string) []string {
-	var skills []string
+// Pre-compiled regex patterns for @file reference detection.
+var (
+	// fileRefPattern matches "@path/to/file" or "@~/path" at line start or
+	// after whitespace, so email addresses ("user@host") are not picked up.
+	fileRefPattern = regexp.MustCompile(`(?:^|\s)@(~?[A-Za-z0-9_./-]+)`)
+
+	// inlineCodePattern matches `inline code` spans, which are stripped before
+	// scanning so "@types/node" in backticks is not treated as a file.
+	inlineCodePattern = regexp.MustCompile("`[^`]*`")
+)
+
+// FileReference is an @path file inclusion found in a component body.
+type FileReference struct {
+	Path string
+	Line int // 1-based line in the full file
+}
+
+// FindFileReferences finds @path file inclusions in the body of contents,
+// skipping frontmatter, fenced code blocks, and inline code spans. Only
+// tokens that look like paths (containing "." or "/") are returned, so
+// @mentions such as "@claude" are ignored. Trailing sentence punctuation is
+// trimmed and each path is reported once, at its first occurrence.
+func FindFileReferences(contents string) []FileReference {
+	var refs []FileReference
 	seen := make(map[string]bool)
 
-	for _, pattern := range skillPatterns {
-		matches := pattern.FindAllStringSubmatch(content, -1)
-		for _, match := range matches {
-			if len(match) >= 2 {
-				skill := strings.TrimSpace(match[1])
-				if !seen[skill] && skill != "" {
-					skills = append(skills, skill)
-					seen[skill] = true
-				}
+	lines := strings.Split(contents, "\n")
+	inFrontmatter, inCodeBlock := false, false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if i == 0 && trimmed == "---" {
+			inFrontmatter = true
+			continue
+		}
+		if inFrontmatter {
+			inFrontmatter = trimmed != "---"
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		scan := inlineCodePattern.ReplaceAllString(trimmed, "")
+		for _, m := range fileRefPattern.FindAllStringSubmatch(scan, -1) {
+			path := strings.TrimRight(m[1], ".,;:")
+			if !strings.ContainsAny(path, "./") || seen[path] {
+				continue
 			}
+			seen[path] = true
+			refs = append(refs, FileReference{Path: path, Line: i + 1})
 		}
 	}
 
+	return refs
+}
+
+// FindSkillReferences finds the distinct skills content references, in text
+// order. Matches: Skill: X (outside bold markup), **Skill**: X, Skill(X), and
+// the first item of a Skills: list. See ScanReferences.
+func FindSkillReferences(content string) []string {
+	var skills []string
+	for _, ref := range skillReferences(content) {
+		skills = append(skills, ref.Name)
+	}
 	return skills
 }
 
@@ -108,9 +133,7 @@ func IsToolUsed(tool string, contents string) bool {
 	if strings.HasPrefix(tool, "Task(") && strings.HasSuffix(tool, ")") {
 		// Extract agent name: Task(foo-specialist) -> foo-specialist
 		agentName := tool[5 : len(tool)-1]
-		// Check if Task(agentName) appears in body (with possible whitespace)
-		pattern := regexp.MustCompile(`Task\(\s*` + regexp.QuoteMeta(agentName) + `\s*[,)]`)
-		return pattern.MatchString(contents)
+		return hasTaskCall(contents, agentName)
 	}
 
 	// Check standard tools using pattern map
@@ -122,6 +145,31 @@ func IsToolUsed(tool string, contents string) bool {
 	return strings.Contains(contents, tool)
 }
 
+// hasTaskCall reports whether contents calls Task(agentName), allowing
+// whitespace around the name and further arguments after it. It scans with
+// strings rather than building a regex per agent name.
+func hasTaskCall(contents, agentName string) bool {
+	rest := contents
+	for {
+		i := strings.Index(rest, "Task(")
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len("Task("):]
+		after, ok := strings.CutPrefix(strings.TrimLeft(rest, asciiSpace), agentName)
+		if !ok {
+			continue
+		}
+		after = strings.TrimLeft(after, asciiSpace)
+		if strings.HasPrefix(after, ",") || strings.HasPrefix(after, ")") {
+			return true
+		}
+	}
+}
+
+// asciiSpace is the set of characters matched by \s in Go regular expressions.
+const asciiSpace = " \t\n\f\r"
+
 // Helper functions for cross-file validation - name extraction
 
 func ExtractAgentName(path string) string {
@@ -146,7 +194,19 @@ func ExtractSkillName(path string) string {
 
 func ExtractCommandName(path string) string {
 	// commands/foo.md -> foo
-	parts := strings.Split(path, "/")
-	filename := parts[len(parts)-1]
-	return strings.TrimSuffix(filename, ".md")
+	// commands/git/commit.md -> git:commit (subdirectories namespace the command)
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	parts[len(parts)-1] = strings.TrimSuffix(parts[len(parts)-1], ".md")
+	for i := len(parts) - 2; i >= 0; i-- {
+		if parts[i] == "commands" {
+			return strings.Join(parts[i+1:], ":")
+		}
+	}
+	return parts[len(parts)-1]
+}
+
+// SlashCommandName returns the slash command a command file is invoked as,
+// e.g. commands/git/commit.md -> /git:commit.
+func SlashCommandName(path string) string {
+	return "/" + ExtractCommandName(path)
 }
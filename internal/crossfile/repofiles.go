@@ -0,0 +1,62 @@
+package crossfile
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// repoSkipDirs are directories the project index does not descend into.
+// They are recorded, and paths beneath them are checked on disk instead.
+var repoSkipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// RepoPathExists reports whether rel, a slash-separated path relative to the
+// project root, names a file or directory in the project. The first call
+// indexes the project tree once, so rules that check many prose references
+// share one walk instead of a stat per reference; paths the index does not
+// hold, such as those under .git or node_modules, fall back to a stat. It
+// returns true when the validator was built without a project root.
+func (v *CrossFileValidator) RepoPathExists(rel string) bool {
+	if v.rootPath == "" {
+		return true
+	}
+	rel = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(rel)), "./")
+	if rel == "." || rel == "" {
+		return true
+	}
+	if strings.HasPrefix(rel, "../") || rel == ".." || filepath.IsAbs(rel) {
+		return false
+	}
+	v.repoOnce.Do(v.indexRepo)
+	if v.repoPaths[rel] {
+		return true
+	}
+	_, err := os.Lstat(filepath.Join(v.rootPath, filepath.FromSlash(rel)))
+	return err == nil
+}
+
+// indexRepo records every file and directory under the project root.
+// Unreadable entries are left out; RepoPathExists stats them instead.
+func (v *CrossFileValidator) indexRepo() {
+	v.repoPaths = make(map[string]bool)
+	root := v.rootPath
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == root {
+			return nil
+		}
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			return nil
+		}
+		v.repoPaths[filepath.ToSlash(rel)] = true
+		if d.IsDir() && repoSkipDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
@@ -0,0 +1,46 @@
+package crossfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRepoPathExists(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"scripts/build.sh", "docs/setup.md", "node_modules/pkg/index.js"} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v := NewCrossFileValidator(nil, root)
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{"scripts/build.sh", true},
+		{"./scripts/build.sh", true},
+		{"scripts", true},
+		{"docs/../scripts/build.sh", true},
+		{"node_modules/pkg/index.js", true}, // skipped by the walk, found by stat
+		{"scripts/deploy.sh", false},
+		{"../outside.md", false},
+		{".", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rel, func(t *testing.T) {
+			if got := v.RepoPathExists(tt.rel); got != tt.want {
+				t.Errorf("RepoPathExists(%q) = %v, want %v", tt.rel, got, tt.want)
+			}
+		})
+	}
+
+	if !NewCrossFileValidator(nil).RepoPathExists("anything/missing.md") {
+		t.Error("RepoPathExists without a project root should assume the path exists")
+	}
+}
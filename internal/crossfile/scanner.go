@@ -0,0 +1,316 @@
+package crossfile
+
+import "strings"
+
+// RefKind identifies the syntax a component reference was written in.
+type RefKind int
+
+const (
+	// RefTask is a Task(...) call. Name holds the raw first argument: the
+	// text up to the first comma or closing parenthesis.
+	RefTask RefKind = iota
+	// RefSkill is "Skill: x", "**Skill**: x", Skill(x), or the first item of
+	// a "Skills:" list.
+	RefSkill
+	// RefDelegateTo is "delegate to x".
+	RefDelegateTo
+	// RefDelegateVia is "delegate via x".
+	RefDelegateVia
+	// RefUse is "use x".
+	RefUse
+	// RefSee is "see x".
+	RefSee
+	// RefHandles is "x-agent handles".
+	RefHandles
+	// RefLink is a markdown link to a .md file, "[text](path.md)". Name
+	// holds the path, without a #fragment; see linkedComponent.
+	RefLink
+)
+
+// Reference is a component reference found by ScanReferences.
+type Reference struct {
+	Kind   RefKind
+	Name   string
+	Offset int // byte offset of Name in the scanned text
+	Line   int // 1-based line of Offset
+}
+
+// ScanReferences extracts every Task(), Skill, delegation, and markdown link
+// reference from content in a single left-to-right pass, in text order.
+// Phrases ("use x", "delegate to x") only match as whole words, so
+// "because x" is not a "use".
+func ScanReferences(content string) []Reference {
+	s := refScanner{src: content, line: 1}
+	for ; s.pos < len(s.src); s.pos++ {
+		switch s.src[s.pos] {
+		case '\n':
+			s.line++
+			s.starInLine = false
+		case '*':
+			s.scanBoldSkill()
+			s.starInLine = true
+		case 'T':
+			s.scanTask()
+		case 'S':
+			s.scanSkill()
+		case 'd':
+			s.scanPhrase("delegate to", RefDelegateTo)
+			s.scanPhrase("delegate via", RefDelegateVia)
+		case 'u':
+			s.scanPhrase("use", RefUse)
+		case 's':
+			s.scanPhrase("see", RefSee)
+		case 'h':
+			s.scanHandles()
+		case '[':
+			s.scanLink()
+		}
+	}
+	return s.refs
+}
+
+// refScanner is the state of one ScanReferences pass.
+type refScanner struct {
+	src        string
+	pos        int
+	line       int
+	starInLine bool // a '*' appeared earlier on the current line
+	refs       []Reference
+}
+
+// emit records a reference whose name starts at off, at or after pos.
+func (s *refScanner) emit(kind RefKind, name string, off int) {
+	line := s.line + strings.Count(s.src[s.pos:off], "\n")
+	s.refs = append(s.refs, Reference{Kind: kind, Name: name, Offset: off, Line: line})
+}
+
+// scanTask matches Task(arg.
+func (s *refScanner) scanTask() {
+	if !strings.HasPrefix(s.src[s.pos:], "Task(") {
+		return
+	}
+	start := s.pos + len("Task(")
+	end := start
+	for end < len(s.src) && s.src[end] != ',' && s.src[end] != ')' {
+		end++
+	}
+	if end > start {
+		s.emit(RefTask, s.src[start:end], start)
+	}
+}
+
+// scanSkill matches Skill(x), "Skill: x", and "Skills:" lists.
+func (s *refScanner) scanSkill() {
+	rest := s.src[s.pos:]
+	switch {
+	case strings.HasPrefix(rest, "Skill("):
+		s.scanSkillCall(s.pos + len("Skill("))
+	case strings.HasPrefix(rest, "Skill:"):
+		after := s.pos + len("Skill:")
+		if !s.starInLine && (s.pos == 0 || !isWordByte(s.src[s.pos-1])) {
+			if off := skipSpace(s.src, after); nameEnd(s.src, off) > off {
+				s.emit(RefSkill, s.src[off:nameEnd(s.src, off)], off)
+				return
+			}
+		}
+		s.scanSkillList(after)
+	case strings.HasPrefix(rest, "Skills:"):
+		s.scanSkillList(s.pos + len("Skills:"))
+	}
+}
+
+// scanSkillCall matches the argument of Skill(, optionally quoted.
+func (s *refScanner) scanSkillCall(i int) {
+	i = skipSpace(s.src, i)
+	i = skipQuote(s.src, i)
+	end := nameEnd(s.src, i)
+	if end == i {
+		return
+	}
+	j := skipSpace(s.src, skipQuote(s.src, end))
+	if j < len(s.src) && s.src[j] == ')' {
+		s.emit(RefSkill, s.src[i:end], i)
+	}
+}
+
+// scanSkillList matches the first "- x" item on a line after "Skills:".
+func (s *refScanner) scanSkillList(i int) {
+	j := skipSpace(s.src, i)
+	if !strings.Contains(s.src[i:j], "\n") || j == len(s.src) || (s.src[j] != '-' && s.src[j] != '*') {
+		return
+	}
+	off := skipSpace(s.src, j+1)
+	if end := nameEnd(s.src, off); end > off {
+		s.emit(RefSkill, s.src[off:end], off)
+	}
+}
+
+// scanBoldSkill matches "**Skill**: x".
+func (s *refScanner) scanBoldSkill() {
+	if !strings.HasPrefix(s.src[s.pos:], "**Skill**:") {
+		return
+	}
+	off := skipSpace(s.src, s.pos+len("**Skill**:"))
+	if end := nameEnd(s.src, off); end > off {
+		s.emit(RefSkill, s.src[off:end], off)
+	}
+}
+
+// scanPhrase matches phrase as a whole word followed by whitespace and a name.
+func (s *refScanner) scanPhrase(phrase string, kind RefKind) {
+	if !strings.HasPrefix(s.src[s.pos:], phrase) || (s.pos > 0 && isWordByte(s.src[s.pos-1])) {
+		return
+	}
+	after := s.pos + len(phrase)
+	off := skipSpace(s.src, after)
+	if off == after {
+		return
+	}
+	if end := nameEnd(s.src, off); end > off {
+		s.emit(kind, s.src[off:end], off)
+	}
+}
+
+// scanHandles matches "x-agent handles" by looking back from "handles" over
+// the whitespace and the agent name before it.
+func (s *refScanner) scanHandles() {
+	if !strings.HasPrefix(s.src[s.pos:], "handles") {
+		return
+	}
+	end := s.pos
+	for end > 0 && isSpaceByte(s.src[end-1]) {
+		end--
+	}
+	if end == s.pos {
+		return
+	}
+	start := end
+	for start > 0 && isNameByte(s.src[start-1]) {
+		start--
+	}
+	for start < end && s.src[start] == '-' {
+		start++
+	}
+	if name := s.src[start:end]; strings.HasSuffix(name, "-agent") && name != "-agent" {
+		line := s.line - strings.Count(s.src[start:s.pos], "\n")
+		s.refs = append(s.refs, Reference{Kind: RefHandles, Name: name, Offset: start, Line: line})
+	}
+}
+
+// scanLink matches a markdown link, "[text](path.md)" or
+// "[text](path.md#section)", to a relative .md file. Images, URLs, and links
+// spanning lines are skipped.
+func (s *refScanner) scanLink() {
+	if s.pos > 0 && s.src[s.pos-1] == '!' {
+		return
+	}
+	textEnd := strings.IndexAny(s.src[s.pos+1:], "]\n")
+	if textEnd < 0 || s.src[s.pos+1+textEnd] != ']' {
+		return
+	}
+	start := s.pos + 1 + textEnd + 1
+	if start >= len(s.src) || s.src[start] != '(' {
+		return
+	}
+	start++
+	end := start
+	for end < len(s.src) && s.src[end] != ')' && !isSpaceByte(s.src[end]) {
+		end++
+	}
+	target, _, _ := strings.Cut(s.src[start:end], "#")
+	if end == len(s.src) || s.src[end] != ')' || strings.Contains(target, ":") || !strings.HasSuffix(strings.ToLower(target), ".md") {
+		return
+	}
+	s.emit(RefLink, target, start)
+}
+
+// skillReferences returns the first RefSkill reference for each distinct
+// skill name, in text order.
+func skillReferences(content string) []Reference {
+	var refs []Reference
+	seen := make(map[string]bool)
+	for _, ref := range ScanReferences(content) {
+		if ref.Kind == RefSkill && !seen[ref.Name] {
+			seen[ref.Name] = true
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// taskReferences returns the Task() references in content, in text order.
+func taskReferences(content string) []Reference {
+	var refs []Reference
+	for _, ref := range ScanReferences(content) {
+		if ref.Kind == RefTask {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// leadingName returns the component name at the start of s, or "".
+func leadingName(s string) string {
+	return s[:nameEnd(s, 0)]
+}
+
+// taskCallName returns the agent a Task() argument names when the argument
+// is just a name, optionally quoted and padded with whitespace.
+func taskCallName(arg string) (string, bool) {
+	arg = strings.Trim(arg, asciiSpace)
+	arg = strings.TrimPrefix(arg, `"`)
+	arg = strings.TrimPrefix(arg, `'`)
+	arg = strings.TrimSuffix(arg, `"`)
+	arg = strings.TrimSuffix(arg, `'`)
+	if arg == "" || leadingName(arg) != arg {
+		return "", false
+	}
+	return arg, true
+}
+
+// specialistName returns name up to its last "-specialist" suffix, or "".
+func specialistName(name string) string {
+	i := strings.LastIndex(name, "-specialist")
+	if i <= 0 {
+		return ""
+	}
+	return name[:i+len("-specialist")]
+}
+
+// nameEnd returns the end of the component name ([a-z0-9][a-z0-9-]*)
+// starting at i, or i when there is none.
+func nameEnd(s string, i int) int {
+	if i >= len(s) || s[i] == '-' || !isNameByte(s[i]) {
+		return i
+	}
+	for i < len(s) && isNameByte(s[i]) {
+		i++
+	}
+	return i
+}
+
+func skipSpace(s string, i int) int {
+	for i < len(s) && isSpaceByte(s[i]) {
+		i++
+	}
+	return i
+}
+
+func skipQuote(s string, i int) int {
+	if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+		return i + 1
+	}
+	return i
+}
+
+func isNameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-'
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
+
+func isSpaceByte(c byte) bool {
+	return strings.IndexByte(asciiSpace, c) >= 0
+}
@@ -0,0 +1,108 @@
+package crossfile
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScanReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Reference
+	}{
+		{
+			name:    "task arguments",
+			content: "Task(foo)\nTask( \"bar\" , prompt)",
+			want: []Reference{
+				{Kind: RefTask, Name: "foo", Offset: 5, Line: 1},
+				{Kind: RefTask, Name: ` "bar" `, Offset: 15, Line: 2},
+			},
+		},
+		{
+			name:    "skill forms",
+			content: "Skill: plain\n**Skill**: bold\nLoad Skill('call')\nSkills:\n  - listed\n  - second",
+			want: []Reference{
+				{Kind: RefSkill, Name: "plain", Offset: 7, Line: 1},
+				{Kind: RefSkill, Name: "bold", Offset: 24, Line: 2},
+				{Kind: RefSkill, Name: "call", Offset: 41, Line: 3},
+				{Kind: RefSkill, Name: "listed", Offset: 60, Line: 5},
+			},
+		},
+		{
+			name:    "plain skill after bold markup on the line",
+			content: "*note* Skill: skipped\nMySkill: skipped",
+		},
+		{
+			name:    "unclosed skill call",
+			content: "Skill(open",
+		},
+		{
+			name:    "delegation phrases",
+			content: "delegate to api-specialist, delegate via\nrunner, use tool-x, see docs-specialist",
+			want: []Reference{
+				{Kind: RefDelegateTo, Name: "api-specialist", Offset: 12, Line: 1},
+				{Kind: RefDelegateVia, Name: "runner", Offset: 41, Line: 2},
+				{Kind: RefUse, Name: "tool-x", Offset: 53, Line: 2},
+				{Kind: RefSee, Name: "docs-specialist", Offset: 65, Line: 2},
+			},
+		},
+		{
+			name:    "phrases match whole words only",
+			content: "because foo, reuse bar, oversee baz, use: x",
+		},
+		{
+			name:    "agent handles",
+			content: "The deploy-agent handles it; the agent handles nothing",
+			want: []Reference{
+				{Kind: RefHandles, Name: "deploy-agent", Offset: 4, Line: 1},
+			},
+		},
+		{
+			name:    "markdown links",
+			content: "See [reviewer](../agents/reviewer.md) and\n[docs](/skills/go/SKILL.md#usage).",
+			want: []Reference{
+				{Kind: RefLink, Name: "../agents/reviewer.md", Offset: 15, Line: 1},
+				{Kind: RefLink, Name: "/skills/go/SKILL.md", Offset: 49, Line: 2},
+			},
+		},
+		{
+			name:    "images, URLs, and other files are not links",
+			content: "![logo](logo.md) [site](https://example.com/a.md) [code](main.go) [open](a.md\n[split\n](b.md)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScanReferences(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ScanReferences() = %+v, want %+v", got, tt.want)
+			}
+			for _, ref := range got {
+				if tt.content[ref.Offset:ref.Offset+len(ref.Name)] != ref.Name {
+					t.Errorf("offset %d does not point at %q", ref.Offset, ref.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestSkillAgentRef(t *testing.T) {
+	tests := []struct {
+		ref  Reference
+		want string
+	}{
+		{Reference{Kind: RefTask, Name: " 'runner' "}, "runner"},
+		{Reference{Kind: RefTask, Name: "db-specialist extra"}, "db-specialist"},
+		{Reference{Kind: RefTask, Name: "subagent_type=x"}, ""},
+		{Reference{Kind: RefUse, Name: "helper"}, ""},
+		{Reference{Kind: RefUse, Name: "db-specialist"}, "db-specialist"},
+		{Reference{Kind: RefDelegateVia, Name: "runner"}, "runner"},
+		{Reference{Kind: RefSkill, Name: "runner"}, ""},
+	}
+	for _, tt := range tests {
+		if got := skillAgentRef(tt.ref); got != tt.want {
+			t.Errorf("skillAgentRef(%+v) = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+}
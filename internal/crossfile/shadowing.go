@@ -0,0 +1,49 @@
+package crossfile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// pluginOf returns the plugin an agent or command file ships in, or "" for
+// a project file.
+func pluginOf(relPath string) string {
+	plugin, _ := strings.CutPrefix(agentScope(relPath), "plugin ")
+	if plugin == "project" {
+		return ""
+	}
+	return plugin
+}
+
+// shadows reports whether one of two files of the same name is a project
+// file and the other ships in a plugin. The project one takes precedence:
+// the bare name resolves to it, and the plugin's is reachable only by its
+// plugin-qualified name.
+func shadows(relPath, otherPath string) bool {
+	return (pluginOf(relPath) == "") != (pluginOf(otherPath) == "")
+}
+
+// shadowingIssue reports, on filePath, that a project component and a
+// plugin one share name. kind is "agent" or "command", and call formats how
+// a name is invoked, as Task(name) or /name.
+func shadowingIssue(kind string, call func(string) string, name, filePath, otherPath string, line int) cue.ValidationError {
+	var message string
+	if plugin := pluginOf(otherPath); plugin != "" {
+		message = fmt.Sprintf("Project %s '%s' shadows the %s of the same name in plugin %s (%s): %s runs this one, and the plugin's is reachable only as %s. Rename this %s if the override is not intended",
+			kind, name, kind, plugin, otherPath, call(name), call(plugin+":"+name), kind)
+	} else {
+		plugin := pluginOf(filePath)
+		message = fmt.Sprintf("Plugin %s's %s '%s' is shadowed by project %s %s: %s runs that one, so changes here only reach %s. Rename one of them if the override is not intended",
+			plugin, kind, name, kind, otherPath, call(name), call(plugin+":"+name))
+	}
+	return cue.ValidationError{
+		File:     filePath,
+		Message:  message,
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleComponentShadowed,
+		Line:     line,
+	}
+}
@@ -0,0 +1,60 @@
+package crossfile
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+const (
+	// skillOverlapThreshold is the word similarity at which two skill
+	// descriptions are too alike for Claude to choose between reliably.
+	skillOverlapThreshold = 0.6
+
+	// skillOverlapMinWords keeps very short descriptions out of the
+	// comparison, where a couple of shared words already look similar.
+	skillOverlapMinWords = 4
+)
+
+// validateSkillTriggerOverlap warns when another skill's description is
+// nearly the same as this one's. Claude picks which skill to load from the
+// descriptions alone, so two that read alike compete for the same requests.
+// Each skill of a pair reports the other, with the similarity score.
+func (v *CrossFileValidator) validateSkillTriggerOverlap(filePath, contents string, frontmatter map[string]any) []cue.ValidationError {
+	description, _ := frontmatter["description"].(string)
+	words := textutil.ContentWords(description)
+	if len(words) < skillOverlapMinWords {
+		return nil
+	}
+
+	self := filepath.ToSlash(filePath)
+	var errors []cue.ValidationError
+	for _, name := range slices.Sorted(maps.Keys(v.skills)) {
+		other := v.skills[name]
+		if filepath.ToSlash(other.RelPath) == self {
+			continue
+		}
+		otherDescription, _ := v.frontmatterOf(other)["description"].(string)
+		otherWords := textutil.ContentWords(otherDescription)
+		if len(otherWords) < skillOverlapMinWords {
+			continue
+		}
+		score := textutil.WordSimilarity(words, otherWords)
+		if score < skillOverlapThreshold {
+			continue
+		}
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("Skill description is %.0f%% similar to skill '%s' (%s); Claude may load the wrong one. Reword both around what sets each apart", score*100, name, other.RelPath),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleSkillTriggerOverlap,
+			Line:     textutil.FindFrontmatterFieldLine(contents, "description"),
+		})
+	}
+	return errors
+}
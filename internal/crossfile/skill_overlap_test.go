@@ -0,0 +1,59 @@
+package crossfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidateSkillTriggerOverlap(t *testing.T) {
+	skill := func(name, description string) discovery.File {
+		return discovery.File{
+			RelPath:  "skills/" + name + "/SKILL.md",
+			Type:     discovery.FileTypeSkill,
+			Contents: "---\nname: " + name + "\ndescription: " + description + "\n---\nbody\n",
+		}
+	}
+	files := []discovery.File{
+		skill("pdf-review", "Reviews PDF documents for layout problems. Use when checking PDF files."),
+		skill("pdf-check", "Checks PDF documents for layout problems. Use when reviewing PDF files."),
+		skill("go-lint", "Runs golangci-lint on Go packages. Use when fixing lint failures."),
+		skill("short", "PDF files."),
+	}
+	v := NewCrossFileValidator(files)
+
+	tests := []struct {
+		filePath string
+		want     []string // message fragments, in order
+	}{
+		{filePath: "skills/pdf-review/SKILL.md", want: []string{"similar to skill 'pdf-check' (skills/pdf-check/SKILL.md)"}},
+		{filePath: "skills/pdf-check/SKILL.md", want: []string{"similar to skill 'pdf-review'"}},
+		{filePath: "skills/go-lint/SKILL.md"},
+		{filePath: "skills/short/SKILL.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			var contents string
+			var fm map[string]any
+			for _, f := range files {
+				if f.RelPath == tt.filePath {
+					contents, fm = f.Contents, v.frontmatterOf(f)
+				}
+			}
+			got := v.validateSkillTriggerOverlap(tt.filePath, contents, fm)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d findings, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				if got[i].Rule != cue.RuleSkillTriggerOverlap || got[i].Line != 3 || !strings.Contains(got[i].Message, want) {
+					t.Errorf("finding %d = %s line %d %q, want %s line 3 containing %q", i, got[i].Rule, got[i].Line, got[i].Message, cue.RuleSkillTriggerOverlap, want)
+				}
+				if !strings.Contains(got[i].Message, "100% similar") {
+					t.Errorf("message %q should report the similarity score", got[i].Message)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package crossfile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// validateSkillToolsSubset checks that every allowed-tools entry of a skill
+// is available to the agent named by its agent field. A skill runs inside
+// that agent, so granting a tool the agent lacks has no effect and usually
+// signals a copy-paste mistake. Agents that preload or invoke the skill are
+// checked from the agent side (validateAgentSkillCompat) so each pairing is
+// reported once, on the file that declared it. Agents without a tools field
+// inherit every tool and are skipped.
+func (v *CrossFileValidator) validateSkillToolsSubset(filePath, contents string, frontmatter map[string]any) []cue.ValidationError {
+	if frontmatter == nil {
+		return nil
+	}
+	executor, _ := frontmatter["agent"].(string)
+	agent, ok := v.agents[executor]
+	if !ok {
+		return nil
+	}
+	missing := missingSkillTools(v.frontmatterOf(agent), frontmatter)
+	if len(missing) == 0 {
+		return nil
+	}
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  fmt.Sprintf("allowed-tools grants %s but agent '%s' runs this skill without them. Add them to the agent's tools or drop them from the skill", strings.Join(missing, ", "), executor),
+		Severity: cue.SeverityError,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleSkillToolsNotInAgent,
+		Line:     textutil.FindFrontmatterFieldLine(contents, "allowed-tools"),
+	}}
+}
+
+// validateAgentSkillCompat checks each skill an agent loads, through its
+// frontmatter skills list or a Skill(x) reference in the body, against the
+// agent: the skill's allowed-tools must be tools the agent has, and a skill
+// that pins a model must not switch an agent pinned to a different one.
+// Unknown skills are reported by ValidateAgent.
+func (v *CrossFileValidator) validateAgentSkillCompat(filePath, contents string, frontmatter map[string]any) []cue.ValidationError {
+	if frontmatter == nil {
+		return nil
+	}
+
+	var errors []cue.ValidationError
+	for _, ref := range agentSkillRefs(contents, frontmatter) {
+		skill, ok := v.skills[ref.name]
+		if !ok {
+			continue
+		}
+		skillFM := v.frontmatterOf(skill)
+		if skillFM == nil {
+			continue
+		}
+
+		if missing := missingSkillTools(frontmatter, skillFM); len(missing) > 0 {
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Skill '%s' needs %s (allowed-tools) but this agent's tools do not include them. Add them to tools or drop them from the skill", ref.name, strings.Join(missing, ", ")),
+				Severity: cue.SeverityError,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentSkillToolsMissing,
+				Line:     ref.line,
+			})
+		}
+
+		agentModel, _ := frontmatter["model"].(string)
+		skillModel, _ := skillFM["model"].(string)
+		if pinnedModel(agentModel) && pinnedModel(skillModel) && modelFamily(agentModel) != modelFamily(skillModel) {
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Skill '%s' pins model '%s' but this agent runs on '%s'; loading the skill switches models mid-task. Align the models or drop one of them", ref.name, skillModel, agentModel),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentSkillModel,
+				Line:     ref.line,
+			})
+		}
+	}
+	return errors
+}
+
+// skillRef is a skill an agent loads and the line that loads it.
+type skillRef struct {
+	name string
+	line int
+}
+
+// agentSkillRefs lists the skills an agent loads: its frontmatter skills
+// entries first, then Skill references in the body, each once.
+func agentSkillRefs(contents string, frontmatter map[string]any) []skillRef {
+	var refs []skillRef
+	seen := make(map[string]bool)
+	skillsLine := textutil.FindFrontmatterFieldLine(contents, "skills")
+	skills, _ := frontmatter["skills"].([]any)
+	for _, item := range skills {
+		if name, ok := item.(string); ok && !seen[name] {
+			seen[name] = true
+			refs = append(refs, skillRef{name: name, line: skillsLine})
+		}
+	}
+
+	for _, ref := range skillReferences(contents) {
+		if seen[ref.Name] {
+			continue
+		}
+		seen[ref.Name] = true
+		refs = append(refs, skillRef{name: ref.Name, line: ref.Line})
+	}
+	return refs
+}
+
+// missingSkillTools returns the skill's allowed-tools entries that the
+// agent cannot use, or nil when the agent has no tools field and so
+// inherits everything.
+func missingSkillTools(agentFM, skillFM map[string]any) []string {
+	agentTools, declared := agentFM["tools"]
+	if !declared {
+		return nil
+	}
+	available := parseToolEntries(agentTools)
+
+	var missing []string
+	for _, tool := range parseToolEntries(skillFM["allowed-tools"]) {
+		if !toolAvailable(tool, available) {
+			missing = append(missing, tool)
+		}
+	}
+	return missing
+}
+
+// pinnedModel reports whether a model field selects a specific model rather
+// than inheriting one.
+func pinnedModel(model string) bool {
+	return model != "" && model != "inherit"
+}
+
+// modelFamily reduces a model alias or full ID to its family, so "opus"
+// and "claude-opus-4-1" compare equal.
+func modelFamily(model string) string {
+	m := strings.ToLower(model)
+	for _, family := range []string{"haiku", "sonnet", "opus"} {
+		if strings.Contains(m, family) {
+			return family
+		}
+	}
+	return m
+}
+
+// frontmatterCacheEntries bounds how many parsed frontmatter maps the
+// validator keeps; older ones are parsed again when needed.
+const frontmatterCacheEntries = 2048
+
+// frontmatterOf parses and caches a component's frontmatter. Returns nil
+// when the frontmatter does not parse.
+func (v *CrossFileValidator) frontmatterOf(f discovery.File) map[string]any {
+	v.initCaches()
+	if fm, ok := v.parsedFM.Get(f.RelPath); ok {
+		return fm
+	}
+	var data map[string]any
+	if fm, err := textutil.ParseYAMLFrontmatter(v.contentsOf(f)); err == nil {
+		data = fm.Data
+	}
+	v.parsedFM.Put(f.RelPath, data)
+	return data
+}
+
+// contentsOf returns a component's contents, reading lazily discovered files
+// through the validator's bounded content cache.
+func (v *CrossFileValidator) contentsOf(f discovery.File) string {
+	v.initCaches()
+	return v.contents.Contents(f)
+}
+
+// initCaches creates the content and frontmatter caches on first use. Both
+// are safe for concurrent use once created.
+func (v *CrossFileValidator) initCaches() {
+	if v.contents == nil {
+		v.contents = discovery.NewContentCache(discovery.DefaultContentCacheBytes)
+	}
+	if v.parsedFM == nil {
+		v.parsedFM = discovery.NewCache(frontmatterCacheEntries, func(map[string]any) int64 { return 1 })
+	}
+}
+
+// parseToolEntries normalizes a tools or allowed-tools value into entries.
+// Strings may separate entries with commas or spaces (the agentskills.io
+// form); spaces and commas inside parentheses belong to the entry.
+func parseToolEntries(v any) []string {
+	switch t := v.(type) {
+	case string:
+		var entries []string
+		for _, part := range textutil.SplitToolList(t) {
+			entries = append(entries, splitOutsideParens(part)...)
+		}
+		return entries
+	case []any:
+		var entries []string
+		for _, item := range t {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				entries = append(entries, strings.TrimSpace(s))
+			}
+		}
+		return entries
+	}
+	return nil
+}
+
+// splitOutsideParens splits s on whitespace that is not inside parentheses.
+func splitOutsideParens(s string) []string {
+	var entries []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case (r == ' ' || r == '\t') && depth == 0:
+			if start < i {
+				entries = append(entries, s[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		entries = append(entries, s[start:])
+	}
+	return entries
+}
+
+// toolAvailable reports whether an agent granting available can use tool.
+// An unscoped agent entry covers every scoped form ("Bash" covers
+// "Bash(git:*)"), "*" covers everything, and a trailing "*" matches by
+// prefix (mcp__github__* covers mcp__github__create_issue).
+func toolAvailable(tool string, available []string) bool {
+	base := textutil.ExtractBaseToolName(tool)
+	for _, a := range available {
+		switch {
+		case a == "*", a == tool, a == base:
+			return true
+		case strings.HasSuffix(a, "*") && !strings.Contains(a, "(") &&
+			strings.HasPrefix(tool, strings.TrimSuffix(a, "*")):
+			return true
+		}
+	}
+	return false
+}
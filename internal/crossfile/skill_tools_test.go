@@ -0,0 +1,154 @@
+package crossfile
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+func TestValidateSkill_ToolsSubset(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "agents/reader.md", Type: discovery.FileTypeAgent, Contents: "---\nname: reader\ntools: Read, Grep, Bash(git:*)\nskills:\n  - review\n---\nbody\n"},
+		{RelPath: "agents/builder.md", Type: discovery.FileTypeAgent, Contents: "---\nname: builder\ntools: [Read, Bash, mcp__github__*]\n---\nbody\n"},
+		{RelPath: "agents/all.md", Type: discovery.FileTypeAgent, Contents: "---\nname: all\nskills: [review]\n---\nbody\n"},
+		{RelPath: "skills/review/SKILL.md", Type: discovery.FileTypeSkill},
+		{RelPath: "skills/ship/SKILL.md", Type: discovery.FileTypeSkill},
+	}
+	v := NewCrossFileValidator(files)
+
+	tests := []struct {
+		name        string
+		filePath    string
+		frontmatter map[string]any
+		want        []string // agents named in findings, in order
+	}{
+		{
+			name:        "subset of executing agent",
+			filePath:    "skills/review/SKILL.md",
+			frontmatter: map[string]any{"agent": "reader", "allowed-tools": "Read, Grep"},
+		},
+		{
+			name:        "scoped form must match agent scope",
+			filePath:    "skills/review/SKILL.md",
+			frontmatter: map[string]any{"agent": "reader", "allowed-tools": "Read Bash(npm:*)"},
+			want:        []string{"reader"},
+		},
+		{
+			name:        "preloading agents are checked from the agent side",
+			filePath:    "skills/review/SKILL.md",
+			frontmatter: map[string]any{"allowed-tools": "Write"},
+		},
+		{
+			name:        "executing agent inherits all tools",
+			filePath:    "skills/review/SKILL.md",
+			frontmatter: map[string]any{"agent": "all", "allowed-tools": "Write"},
+		},
+		{
+			name:        "unscoped agent tool and wildcard cover scoped entries",
+			filePath:    "skills/ship/SKILL.md",
+			frontmatter: map[string]any{"agent": "builder", "allowed-tools": []any{"Bash(go test:*)", "mcp__github__create_issue"}},
+		},
+		{
+			name:        "executing agent lacks tool",
+			filePath:    "skills/ship/SKILL.md",
+			frontmatter: map[string]any{"agent": "builder", "allowed-tools": "Edit"},
+			want:        []string{"builder"},
+		},
+		{
+			name:        "no allowed-tools",
+			filePath:    "skills/review/SKILL.md",
+			frontmatter: map[string]any{"name": "review"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents := "---\nname: x\nallowed-tools: ...\n---\nbody\n"
+			var got []cue.ValidationError
+			for _, e := range v.ValidateSkill(tt.filePath, contents, tt.frontmatter) {
+				if e.Rule == cue.RuleSkillToolsNotInAgent {
+					got = append(got, e)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d findings, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i, agent := range tt.want {
+				if !strings.Contains(got[i].Message, "agent '"+agent+"'") {
+					t.Errorf("finding %d = %q, want agent %q", i, got[i].Message, agent)
+				}
+				if got[i].Severity != cue.SeverityError || got[i].Line != 3 {
+					t.Errorf("finding %d severity/line = %s/%d, want error/3", i, got[i].Severity, got[i].Line)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAgent_SkillCompat(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "skills/review/SKILL.md", Type: discovery.FileTypeSkill, Contents: "---\nname: review\nallowed-tools: Read, Grep\n---\nbody\n"},
+		{RelPath: "skills/deploy/SKILL.md", Type: discovery.FileTypeSkill, Contents: "---\nname: deploy\nallowed-tools: Bash(kubectl:*)\nmodel: opus\n---\nbody\n"},
+		{RelPath: "skills/notes/SKILL.md", Type: discovery.FileTypeSkill, Contents: "---\nname: notes\nmodel: claude-haiku-4-5\n---\nbody\n"},
+	}
+	v := NewCrossFileValidator(files)
+
+	tests := []struct {
+		name     string
+		contents string
+		want     []string // rule:line per finding, in order
+	}{
+		{
+			name:     "compatible preloaded skill",
+			contents: "---\nname: a\ntools: Read, Grep\nskills: [review]\n---\nbody\n",
+		},
+		{
+			name:     "preloaded skill needs missing tool",
+			contents: "---\nname: a\ntools: Read\nskills: [review]\n---\nbody\n",
+			want:     []string{"agent-skill-tools-missing:4"},
+		},
+		{
+			name:     "body Skill() reference needs missing tool and conflicting model",
+			contents: "---\nname: a\ntools: Read\nmodel: haiku\n---\n\nThen run Skill(deploy).\n",
+			want:     []string{"agent-skill-tools-missing:7", "agent-skill-model-conflict:7"},
+		},
+		{
+			name:     "same model family is compatible",
+			contents: "---\nname: a\nmodel: haiku\nskills: [notes]\n---\nbody\n",
+		},
+		{
+			name:     "inherited tools and model are compatible",
+			contents: "---\nname: a\nmodel: inherit\nskills: [deploy]\n---\nbody\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, err := textutil.ParseYAMLFrontmatter(tt.contents)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range v.ValidateAgent("agents/a.md", tt.contents, fm.Data) {
+				if e.Rule == cue.RuleAgentSkillToolsMissing || e.Rule == cue.RuleAgentSkillModel {
+					got = append(got, fmt.Sprintf("%s:%d", e.Rule, e.Line))
+				}
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("findings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseToolEntries(t *testing.T) {
+	got := parseToolEntries("Read Bash(git add:*), Task(a, b)")
+	want := []string{"Read", "Bash(git add:*)", "Task(a, b)"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("parseToolEntries() = %q, want %q", got, want)
+	}
+}
@@ -31,7 +31,7 @@ func (v *CrossFileValidator) ValidateSkillReferences(rootPath string) []cue.Vali
 
 	for _, name := range skillNames {
 		skill := v.skills[name]
-		errors = append(errors, validateSingleSkillRefs(rootPath, skill.RelPath, skill.Contents)...)
+		errors = append(errors, validateSingleSkillRefs(rootPath, skill.RelPath, v.contentsOf(skill))...)
 	}
 
 	return errors
@@ -130,6 +130,7 @@ func phantomRefErrors(relPath, refsDir string, mentioned, actual []string) []cue
 				Message:  fmt.Sprintf("references/%s is mentioned but does not exist on disk", name),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleSkillReferenceFileMissing,
 			})
 		}
 	}
@@ -151,6 +152,7 @@ func orphanedRefErrors(relPath, refsDir string, mentioned, actual []string) []cu
 				Message:  fmt.Sprintf("references/%s exists but is not mentioned in SKILL.md - add a reference or remove the file", name),
 				Severity: cue.SeverityInfo,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleSkillReferenceFileUnused,
 			})
 		}
 	}
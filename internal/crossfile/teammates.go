@@ -0,0 +1,39 @@
+package crossfile
+
+import "github.com/dotcommander/cclint/internal/textutil"
+
+// HasAgent reports whether an agent reference resolves: to a built-in or
+// configured subagent type, a plugin-namespaced agent, a project agent, or
+// a user-scope agent.
+func (v *CrossFileValidator) HasAgent(name string) bool {
+	return v.hasResolvableAgent(name)
+}
+
+// AgentHasTool reports whether the project agent name may call tool, a
+// base tool name such as "TaskUpdate". An agent without a tools list
+// inherits every tool; one with a list has only the tools it names, and
+// disallowedTools removes tools either way. known is false when name is not
+// a project agent, since the tools of built-in, plugin, and user-scope
+// agents are not visible here.
+func (v *CrossFileValidator) AgentHasTool(name, tool string) (has, known bool) {
+	f, ok := v.agents[name]
+	if !ok {
+		return false, false
+	}
+	fm := v.frontmatterOf(f)
+	for _, entry := range parseToolEntries(fm["disallowedTools"]) {
+		if textutil.ExtractBaseToolName(entry) == tool {
+			return false, true
+		}
+	}
+	tools, listed := fm["tools"]
+	if !listed {
+		return true, true
+	}
+	for _, entry := range parseToolEntries(tools) {
+		if base := textutil.ExtractBaseToolName(entry); base == tool || base == "*" {
+			return true, true
+		}
+	}
+	return false, true
+}
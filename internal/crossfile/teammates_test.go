@@ -0,0 +1,62 @@
+package crossfile
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestHasAgent(t *testing.T) {
+	v := NewCrossFileValidator([]discovery.File{
+		{RelPath: "agents/reviewer.md", Type: discovery.FileTypeAgent, Contents: "---\nname: reviewer\n---\n"},
+	}).WithExtraBuiltinAgents([]string{"lead"})
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Explore", true},
+		{"lead", true},
+		{"plugin:helper", true},
+		{"reviewer", true},
+		{"tester", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.HasAgent(tt.name); got != tt.want {
+				t.Errorf("HasAgent(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentHasTool(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "agents/open.md", Type: discovery.FileTypeAgent, Contents: "---\nname: open\n---\n"},
+		{RelPath: "agents/lister.md", Type: discovery.FileTypeAgent, Contents: "---\nname: lister\ntools: Read, TaskList, TaskUpdate\n---\n"},
+		{RelPath: "agents/reader.md", Type: discovery.FileTypeAgent, Contents: "---\nname: reader\ntools: [Read, Grep]\n---\n"},
+		{RelPath: "agents/star.md", Type: discovery.FileTypeAgent, Contents: "---\nname: star\ntools: \"*\"\n---\n"},
+		{RelPath: "agents/denied.md", Type: discovery.FileTypeAgent, Contents: "---\nname: denied\ndisallowedTools: TaskUpdate\n---\n"},
+	}
+	v := NewCrossFileValidator(files)
+
+	tests := []struct {
+		agent, tool string
+		has, known  bool
+	}{
+		{"open", "TaskUpdate", true, true},
+		{"lister", "TaskUpdate", true, true},
+		{"reader", "TaskUpdate", false, true},
+		{"star", "TaskUpdate", true, true},
+		{"denied", "TaskUpdate", false, true},
+		{"denied", "TaskList", true, true},
+		{"Explore", "TaskUpdate", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.agent+"/"+tt.tool, func(t *testing.T) {
+			has, known := v.AgentHasTool(tt.agent, tt.tool)
+			if has != tt.has || known != tt.known {
+				t.Errorf("AgentHasTool(%q, %q) = %v, %v, want %v, %v", tt.agent, tt.tool, has, known, tt.has, tt.known)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package crossfile
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// ToolUse is a component that names a tool in its tool list.
+type ToolUse struct {
+	Type  string // "command", "agent", "skill"
+	Name  string
+	Path  string
+	Entry string // the tool list entry, e.g. "Bash(git:*)"
+}
+
+// ToolUses returns the components that name each tool in their tools
+// (agents) or allowed-tools (commands and skills) list, keyed by base tool
+// name ("Bash(git:*)" is keyed "Bash"). Components without a tool list
+// inherit their tools rather than name them and are not included, nor are
+// "*" entries. Components are listed in type, then name order.
+func (v *CrossFileValidator) ToolUses() map[string][]ToolUse {
+	uses := make(map[string][]ToolUse)
+	collect := func(componentType, field string, files map[string]discovery.File) {
+		for _, name := range slices.Sorted(maps.Keys(files)) {
+			f := files[name]
+			for _, entry := range parseToolEntries(v.frontmatterOf(f)[field]) {
+				base := textutil.ExtractBaseToolName(entry)
+				if base == "" || base == "*" {
+					continue
+				}
+				uses[base] = append(uses[base], ToolUse{Type: componentType, Name: name, Path: f.RelPath, Entry: entry})
+			}
+		}
+	}
+	collect(cue.TypeAgent, "tools", v.agents)
+	collect(cue.TypeCommand, "allowed-tools", v.commands)
+	collect(cue.TypeSkill, "allowed-tools", v.skills)
+	return uses
+}
@@ -0,0 +1,33 @@
+package crossfile
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestToolUses(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "agents/builder.md", Type: discovery.FileTypeAgent, Contents: "---\nname: builder\ntools: Read, Bash(go:*)\n---\n"},
+		{RelPath: "agents/open.md", Type: discovery.FileTypeAgent, Contents: "---\nname: open\n---\n"},
+		{RelPath: "agents/all.md", Type: discovery.FileTypeAgent, Contents: "---\nname: all\ntools: \"*\"\n---\n"},
+		{RelPath: "commands/ship.md", Type: discovery.FileTypeCommand, Contents: "---\nallowed-tools:\n  - Bash(git push:*)\n---\n"},
+		{RelPath: "skills/notes/SKILL.md", Type: discovery.FileTypeSkill, Contents: "---\nname: notes\nallowed-tools: Read\n---\n"},
+	}
+	got := NewCrossFileValidator(files).ToolUses()
+
+	want := map[string][]ToolUse{
+		"Read": {
+			{Type: "agent", Name: "builder", Path: "agents/builder.md", Entry: "Read"},
+			{Type: "skill", Name: "notes", Path: "skills/notes/SKILL.md", Entry: "Read"},
+		},
+		"Bash": {
+			{Type: "agent", Name: "builder", Path: "agents/builder.md", Entry: "Bash(go:*)"},
+			{Type: "command", Name: "ship", Path: "commands/ship.md", Entry: "Bash(git push:*)"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToolUses() = %+v, want %+v", got, want)
+	}
+}
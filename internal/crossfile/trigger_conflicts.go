@@ -251,5 +251,6 @@ func buildConflictError(keyword, firstFile string, uniqueTargets map[string][]st
 		Message:  fmt.Sprintf("Trigger keyword '%s' routes to conflicting targets: %s", keyword, strings.Join(parts, ", ")),
 		Severity: cue.SeverityWarning,
 		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleTriggerConflict,
 	}
 }
@@ -271,10 +271,11 @@ func (v *CrossFileValidator) validateTriggerRef(ref TriggerRef) []cue.Validation
 				Message:  fmt.Sprintf("Trigger map references non-existent skill '%s'. Create skills/%s/SKILL.md", ref.RefName, ref.RefName),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleSkillRefMissing,
 			}}
 		}
 	case "agent":
-		if BuiltInSubagentTypes[ref.RefName] {
+		if v.isBuiltinAgent(ref.RefName) {
 			return nil
 		}
 		if _, exists := v.agents[ref.RefName]; !exists {
@@ -283,6 +284,7 @@ func (v *CrossFileValidator) validateTriggerRef(ref TriggerRef) []cue.Validation
 				Message:  fmt.Sprintf("Trigger map references non-existent agent '%s'. Create agents/%s.md", ref.RefName, ref.RefName),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentRefMissing,
 			}}
 		}
 	}
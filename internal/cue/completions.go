@@ -0,0 +1,181 @@
+package cue
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+)
+
+// completionDepth bounds how deep Completions descends into nested structs.
+const completionDepth = 4
+
+// CompletionField describes one field of a schema definition for editor
+// autocompletion: its JSON types, whether it is required, the values the
+// schema names for it, and the comment that documents it.
+type CompletionField struct {
+	Name     string            `json:"name"`
+	Types    []string          `json:"types"` // string, integer, number, boolean, array, object, null
+	Required bool              `json:"required,omitempty"`
+	Enum     []any             `json:"enum,omitempty"`    // values named by the schema; an open field accepts others too
+	Items    []any             `json:"items,omitempty"`   // values named for the elements of an array field
+	Default  any               `json:"default,omitempty"` // scalar default, if the schema marks one
+	Doc      string            `json:"doc,omitempty"`     // the field's trailing comment, or the comment above it
+	Fields   []CompletionField `json:"fields,omitempty"`  // fields of an object with named keys
+}
+
+// Completions lists the fields of each component's schema definition, in
+// schema order, from the compiled schemas so the data matches validation.
+// definitions maps a component, which is also its schema file's base name,
+// to its definition, such as "agent" to "#Agent". LoadSchemas must be
+// called first.
+func (v *Validator) Completions(definitions map[string]string) (map[string][]CompletionField, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	out := make(map[string][]CompletionField, len(definitions))
+	for component, definition := range definitions {
+		schema, ok := v.schemas[component]
+		if !ok {
+			return nil, fmt.Errorf("schema %s not loaded", component)
+		}
+		def := schema.LookupPath(cue.ParsePath(definition))
+		if err := def.Err(); err != nil {
+			return nil, fmt.Errorf("schema definition %s: %w", definition, err)
+		}
+		fields, err := completionFields(def, completionDepth)
+		if err != nil {
+			return nil, fmt.Errorf("schema definition %s: %w", definition, err)
+		}
+		out[component] = fields
+	}
+	return out, nil
+}
+
+// completionFields describes the regular and optional fields of a struct.
+func completionFields(v cue.Value, depth int) ([]CompletionField, error) {
+	iter, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return nil, err
+	}
+	var fields []CompletionField
+	for iter.Next() {
+		sel := iter.Selector()
+		if !sel.IsString() {
+			continue
+		}
+		value := iter.Value()
+		field := CompletionField{
+			Name:     sel.Unquoted(),
+			Types:    jsonTypes(value.IncompleteKind()),
+			Required: !iter.IsOptional(),
+			Enum:     namedValues(value),
+			Items:    itemValues(value),
+			Doc:      fieldDoc(value),
+		}
+		if d, ok := value.Default(); ok && d.IsConcrete() && d.Kind()&(cue.StringKind|cue.NumberKind|cue.BoolKind) != 0 {
+			_ = d.Decode(&field.Default)
+		}
+		if depth > 1 && value.IncompleteKind() == cue.StructKind {
+			if field.Fields, err = completionFields(value, depth-1); err != nil {
+				return nil, err
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// jsonTypes names the JSON types a CUE kind admits. A kind that admits
+// any number is "number" alone rather than "integer" and "number".
+func jsonTypes(k cue.Kind) []string {
+	var types []string
+	for _, t := range []struct {
+		kind cue.Kind
+		name string
+	}{
+		{cue.StringKind, "string"},
+		{cue.IntKind, "integer"},
+		{cue.FloatKind, "number"},
+		{cue.BoolKind, "boolean"},
+		{cue.ListKind, "array"},
+		{cue.StructKind, "object"},
+		{cue.NullKind, "null"},
+	} {
+		if k&t.kind != 0 && (t.kind != cue.IntKind || k&cue.FloatKind == 0) {
+			types = append(types, t.name)
+		}
+	}
+	return types
+}
+
+// namedValues returns the concrete strings and numbers a value names, as
+// a disjunction or a single constant. Booleans are left out; their type
+// already says which values they take.
+func namedValues(v cue.Value) []any {
+	candidates := []cue.Value{v}
+	if op, args := v.Eval().Expr(); op == cue.OrOp {
+		candidates = args
+	}
+	var values []any
+	for _, c := range candidates {
+		if !c.IsConcrete() || c.Kind()&(cue.StringKind|cue.NumberKind) == 0 {
+			continue
+		}
+		var x any
+		if err := c.Decode(&x); err == nil {
+			values = append(values, x)
+		}
+	}
+	return values
+}
+
+// itemValues returns the values named for the elements of an array field,
+// such as the known tools of a tools list.
+func itemValues(v cue.Value) []any {
+	if v.IncompleteKind()&cue.ListKind == 0 {
+		return nil
+	}
+	candidates := []cue.Value{v}
+	if op, args := v.Eval().Expr(); op == cue.OrOp {
+		candidates = args
+	}
+	for _, c := range candidates {
+		if c.IncompleteKind() != cue.ListKind {
+			continue
+		}
+		if values := namedValues(c.LookupPath(cue.MakePath(cue.AnyIndex))); len(values) > 0 {
+			return values
+		}
+	}
+	return nil
+}
+
+// fieldDoc returns a field's trailing comment or, failing that, the comment
+// above it, on one line. Section labels such as "Optional Claude Code
+// fields" above a group of fields are not taken as documentation.
+func fieldDoc(v cue.Value) string {
+	node, ok := v.Source().(*ast.Field)
+	if !ok {
+		return ""
+	}
+	var above string
+	for _, cg := range ast.Comments(node) {
+		text := strings.Join(strings.Fields(cg.Text()), " ")
+		switch {
+		case cg.Line:
+			return text
+		case cg.Doc && !isSectionLabel(cg.Text()):
+			above = text
+		}
+	}
+	return above
+}
+
+// isSectionLabel reports whether a comment is a one-line label for the
+// group of fields below it rather than the first field's documentation.
+func isSectionLabel(text string) bool {
+	text = strings.TrimSpace(text)
+	return !strings.Contains(text, "\n") && strings.HasSuffix(strings.ToLower(text), " fields")
+}
@@ -0,0 +1,55 @@
+package cue
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompletions(t *testing.T) {
+	v := NewValidator()
+	if err := v.LoadSchemas(""); err != nil {
+		t.Fatalf("LoadSchemas() error = %v", err)
+	}
+	got, err := v.Completions(map[string]string{"agent": "#Agent", "settings": "#Settings"})
+	if err != nil {
+		t.Fatalf("Completions() error = %v", err)
+	}
+
+	fields := make(map[string]CompletionField)
+	for _, f := range got["agent"] {
+		fields[f.Name] = f
+	}
+	if name := fields["name"]; !name.Required || !slices.Equal(name.Types, []string{"string"}) || name.Doc == "" {
+		t.Errorf("name = %+v, want a required string with a doc", name)
+	}
+	if mode := fields["permissionMode"]; mode.Required || !slices.Contains(mode.Enum, any("plan")) {
+		t.Errorf("permissionMode = %+v, want an optional enum with plan", mode)
+	}
+	tools := fields["tools"]
+	if !slices.Equal(tools.Types, []string{"string", "array"}) || !slices.Equal(tools.Enum, []any{"*"}) || !slices.Contains(tools.Items, any("Read")) {
+		t.Errorf("tools = %+v, want string or array, enum [*], items with Read", tools)
+	}
+	if model := fields["model"]; !slices.Contains(model.Enum, any("sonnet")) {
+		t.Errorf("model enum = %v, want sonnet", model.Enum)
+	}
+	if doc := fields["model"].Doc; doc != "" {
+		t.Errorf("model doc = %q, want the section label skipped", doc)
+	}
+
+	var spinner *CompletionField
+	for i, f := range got["settings"] {
+		if f.Name == "spinnerTipsOverride" {
+			spinner = &got["settings"][i]
+		}
+	}
+	if spinner == nil || len(spinner.Fields) != 2 {
+		t.Fatalf("spinnerTipsOverride = %+v, want two nested fields", spinner)
+	}
+	if exclude := spinner.Fields[1]; exclude.Name != "excludeDefault" || exclude.Default != false {
+		t.Errorf("nested field = %+v, want excludeDefault defaulting to false", exclude)
+	}
+
+	if _, err := v.Completions(map[string]string{"agent": "#Missing"}); err == nil {
+		t.Error("Completions() with an unknown definition: want error")
+	}
+}
@@ -0,0 +1,95 @@
+package cue
+
+import (
+	"fmt"
+	"time"
+)
+
+// EvalLimits bound the work one schema validation may do. Input over
+// MaxBytes or nested deeper than MaxDepth is not evaluated, and an
+// evaluation still running after Timeout is abandoned; either way the file
+// gets a schema-validation-skipped warning instead of stalling the run.
+type EvalLimits struct {
+	MaxBytes int           // approximate size of the data: keys, strings, and one byte per other value
+	MaxDepth int           // levels of nested maps and lists
+	Timeout  time.Duration // per evaluation
+}
+
+// DefaultEvalLimits are generous enough for any real frontmatter or
+// settings file; they only stop pathological input.
+var DefaultEvalLimits = EvalLimits{
+	MaxBytes: 512 << 10,
+	MaxDepth: 32,
+	Timeout:  5 * time.Second,
+}
+
+// withDefaults returns the limits with each zero or negative field
+// replaced by its default.
+func (l EvalLimits) withDefaults() EvalLimits {
+	if l.MaxBytes <= 0 {
+		l.MaxBytes = DefaultEvalLimits.MaxBytes
+	}
+	if l.MaxDepth <= 0 {
+		l.MaxDepth = DefaultEvalLimits.MaxDepth
+	}
+	if l.Timeout <= 0 {
+		l.Timeout = DefaultEvalLimits.Timeout
+	}
+	return l
+}
+
+// checkInputLimits returns why data is too large to evaluate under limits,
+// as a clause for skippedValidation, or "" when it is within them. The
+// walk stops as soon as a limit is exceeded, so pathological input is
+// never traversed in full.
+func checkInputLimits(data any, limits EvalLimits) string {
+	size, tooDeep := 0, false
+	var walk func(v any, depth int) bool
+	walk = func(v any, depth int) bool {
+		if depth > limits.MaxDepth {
+			tooDeep = true
+			return false
+		}
+		switch t := v.(type) {
+		case map[string]any:
+			for k, item := range t {
+				size += len(k)
+				if !walk(item, depth+1) {
+					return false
+				}
+			}
+		case []any:
+			for _, item := range t {
+				if !walk(item, depth+1) {
+					return false
+				}
+			}
+		case string:
+			size += len(t)
+		default:
+			size++
+		}
+		return size <= limits.MaxBytes
+	}
+	if walk(data, 0) {
+		return ""
+	}
+	if tooDeep {
+		return fmt.Sprintf("input is nested more than %d levels deep", limits.MaxDepth)
+	}
+	if limits.MaxBytes < 1<<10 {
+		return fmt.Sprintf("input is larger than %d bytes", limits.MaxBytes)
+	}
+	return fmt.Sprintf("input is larger than %d KiB", limits.MaxBytes>>10)
+}
+
+// skippedValidation is the warning reported in place of schema errors when
+// a file is not evaluated.
+func skippedValidation(reason string) []ValidationError {
+	return []ValidationError{{
+		Message:  fmt.Sprintf("Schema validation skipped: %s; the other checks still ran. Shrink the data, or raise the limit in rules config", reason),
+		Severity: SeverityWarning,
+		Source:   SourceCClintObserve,
+		Rule:     RuleSchemaValidationSkipped,
+	}}
+}
@@ -0,0 +1,92 @@
+package cue
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckInputLimits(t *testing.T) {
+	limits := EvalLimits{MaxBytes: 100, MaxDepth: 3}
+	nested := func(levels int) any {
+		var v any = "leaf"
+		for range levels {
+			v = map[string]any{"k": v}
+		}
+		return v
+	}
+
+	tests := []struct {
+		name string
+		data any
+		want string
+	}{
+		{"small", map[string]any{"name": "x", "tools": []any{"Read", "Grep"}, "maxTurns": 3}, ""},
+		{"at depth limit", nested(3), ""},
+		{"too deep", nested(4), "nested more than 3 levels"},
+		{"long string", map[string]any{"description": strings.Repeat("x", 101)}, "larger than 100 bytes"},
+		{"many values", map[string]any{"tools": make([]any, 200)}, "larger than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkInputLimits(tt.data, limits)
+			if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+				t.Errorf("checkInputLimits() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewValidatorWithOptions_Limits(t *testing.T) {
+	v := NewValidatorWithOptions(ValidatorOptions{Limits: EvalLimits{MaxDepth: 8}})
+	if v.limits.MaxDepth != 8 || v.limits.MaxBytes != DefaultEvalLimits.MaxBytes || v.limits.Timeout != DefaultEvalLimits.Timeout {
+		t.Errorf("limits = %+v, want depth 8 and default size and timeout", v.limits)
+	}
+}
+
+func TestValidateSchema_Limits(t *testing.T) {
+	newValidator := func(t *testing.T, limits EvalLimits) *Validator {
+		t.Helper()
+		v := NewValidatorWithOptions(ValidatorOptions{Limits: limits})
+		if err := v.LoadSchemas(""); err != nil {
+			t.Fatalf("LoadSchemas() error = %v", err)
+		}
+		return v
+	}
+	valid := map[string]any{"name": "reviewer", "description": "Reviews code"}
+
+	t.Run("too large", func(t *testing.T) {
+		v := newValidator(t, EvalLimits{MaxBytes: 1024})
+		errs, err := v.ValidateAgent(map[string]any{"name": "reviewer", "description": strings.Repeat("x", 2048)})
+		if err != nil || len(errs) != 1 || errs[0].Rule != RuleSchemaValidationSkipped || errs[0].Severity != SeverityWarning {
+			t.Fatalf("ValidateAgent() = %v, %v; want one skipped warning", errs, err)
+		}
+		if !strings.Contains(errs[0].Message, "larger than 1 KiB") {
+			t.Errorf("message = %q", errs[0].Message)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		v := newValidator(t, EvalLimits{Timeout: time.Nanosecond})
+		skipped := false
+		for i := 0; i < 100 && !skipped; i++ {
+			errs, err := v.ValidateAgent(valid)
+			if err != nil {
+				t.Fatalf("ValidateAgent() error = %v", err)
+			}
+			skipped = len(errs) == 1 && errs[0].Rule == RuleSchemaValidationSkipped && strings.Contains(errs[0].Message, "took longer than")
+		}
+		if !skipped {
+			t.Fatal("ValidateAgent() never timed out with a 1ns limit")
+		}
+
+		// The validator keeps working on its fresh context.
+		v.limits.Timeout = DefaultEvalLimits.Timeout
+		if errs, err := v.ValidateAgent(valid); err != nil || len(errs) != 0 {
+			t.Errorf("ValidateAgent() after timeout = %v, %v; want no findings", errs, err)
+		}
+		if errs, _ := v.ValidateAgent(map[string]any{"description": "Reviews code"}); len(errs) == 0 {
+			t.Error("ValidateAgent() after timeout missed the absent name")
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package cue
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 // modelAliases are the Claude Code model aliases accepted in a `model:` field.
 // Single source for the generated CUE #Model union (see modelUnionCUE / validator.go injection).
@@ -10,11 +13,17 @@ var modelAliases = []string{"sonnet", "opus", "haiku", "fable", "best", "sonnet[
 // claude-opus-4-5 and claude-fable-5[1m]. Written exactly as it must appear in CUE.
 const claudeModelRegexCUE = `=~"^claude-[a-z0-9-]+(\\[[0-9a-z]+\\])?$"`
 
-func modelUnionCUE() string {
+// modelUnionCUE renders #Model: the aliases, every ID in catalog (so a
+// superseded ID such as claude-2.1 gets the catalog's deprecation warning
+// instead of a schema error), and the full-ID pattern.
+func modelUnionCUE(catalog ModelCatalog) string {
 	members := make([]string, 0, len(modelAliases))
 	for _, alias := range modelAliases {
 		members = append(members, `"`+alias+`"`)
 	}
+	for _, id := range catalog.ids() {
+		members = append(members, strconv.Quote(id))
+	}
 
 	return "#Model: " + strings.Join(members, " | ") + " | " + claudeModelRegexCUE
 }
@@ -0,0 +1,72 @@
+package cue
+
+import (
+	"sort"
+	"strings"
+)
+
+// Model lifecycle states in the model catalog.
+const (
+	ModelCurrent    = "current"
+	ModelDeprecated = "deprecated"
+	ModelRetired    = "retired"
+)
+
+// ModelInfo is one model catalog entry. Replacement is the model to move to
+// (usually an alias, which tracks the latest release of a family).
+type ModelInfo struct {
+	ID          string
+	Status      string
+	Replacement string
+}
+
+// defaultModelCatalog lists the superseded model IDs that frontmatter may
+// still name. Deprecated models still answer; retired ones fail requests.
+var defaultModelCatalog = []ModelInfo{
+	{ID: "claude-instant-1.2", Status: ModelRetired, Replacement: "haiku"},
+	{ID: "claude-2.0", Status: ModelRetired, Replacement: "sonnet"},
+	{ID: "claude-2.1", Status: ModelRetired, Replacement: "sonnet"},
+	{ID: "claude-3-sonnet-20240229", Status: ModelRetired, Replacement: "sonnet"},
+	{ID: "claude-3-5-sonnet-20240620", Status: ModelRetired, Replacement: "sonnet"},
+	{ID: "claude-3-5-sonnet-20241022", Status: ModelRetired, Replacement: "sonnet"},
+	{ID: "claude-3-5-sonnet-latest", Status: ModelRetired, Replacement: "sonnet"},
+	{ID: "claude-3-opus-20240229", Status: ModelDeprecated, Replacement: "opus"},
+	{ID: "claude-3-opus-latest", Status: ModelDeprecated, Replacement: "opus"},
+	{ID: "claude-3-haiku-20240307", Status: ModelDeprecated, Replacement: "haiku"},
+	{ID: "claude-3-5-haiku-20241022", Status: ModelDeprecated, Replacement: "haiku"},
+	{ID: "claude-3-5-haiku-latest", Status: ModelDeprecated, Replacement: "haiku"},
+	{ID: "claude-3-7-sonnet-20250219", Status: ModelDeprecated, Replacement: "sonnet"},
+	{ID: "claude-3-7-sonnet-latest", Status: ModelDeprecated, Replacement: "sonnet"},
+}
+
+// ModelCatalog indexes model catalog entries by lowercased ID.
+type ModelCatalog map[string]ModelInfo
+
+// NewModelCatalog merges overrides from the rules.models config key over
+// the built-in catalog, matching by ID; an override with status "current"
+// clears a built-in deprecation.
+func NewModelCatalog(overrides []ModelInfo) ModelCatalog {
+	catalog := make(ModelCatalog, len(defaultModelCatalog)+len(overrides))
+	for _, entries := range [][]ModelInfo{defaultModelCatalog, overrides} {
+		for _, m := range entries {
+			catalog[strings.ToLower(m.ID)] = m
+		}
+	}
+	return catalog
+}
+
+// Lookup returns the catalog entry for a model ID, case-insensitively.
+func (c ModelCatalog) Lookup(id string) (ModelInfo, bool) {
+	m, ok := c[strings.ToLower(id)]
+	return m, ok
+}
+
+// ids returns every catalog ID, sorted, for the #Model union.
+func (c ModelCatalog) ids() []string {
+	ids := make([]string, 0, len(c))
+	for _, m := range c {
+		ids = append(ids, m.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
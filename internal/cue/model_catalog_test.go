@@ -0,0 +1,59 @@
+package cue
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModelCatalog(t *testing.T) {
+	builtin := NewModelCatalog(nil)
+	if m, ok := builtin.Lookup("Claude-3-Opus-20240229"); !ok || m.Status != ModelDeprecated || m.Replacement != "opus" {
+		t.Errorf("Lookup(claude-3-opus) = %+v, %v; want deprecated with replacement opus", m, ok)
+	}
+	if _, ok := builtin.Lookup("claude-opus-4-5"); ok {
+		t.Error("Lookup(claude-opus-4-5) found a catalog entry, want none")
+	}
+	if _, ok := builtin.Lookup("anthropic.claude-v2:1"); ok {
+		t.Error("built-in catalog has an entry only an override adds")
+	}
+
+	catalog := NewModelCatalog([]ModelInfo{
+		{ID: "claude-3-opus-20240229", Status: ModelCurrent},
+		{ID: "anthropic.claude-v2:1", Status: ModelRetired, Replacement: "sonnet"},
+	})
+	if m, _ := catalog.Lookup("claude-3-opus-20240229"); m.Status != ModelCurrent {
+		t.Errorf("override status = %q, want current", m.Status)
+	}
+	if _, ok := catalog.Lookup("anthropic.claude-v2:1"); !ok {
+		t.Error("added model not found")
+	}
+	if union := modelUnionCUE(catalog); !strings.Contains(union, `"anthropic.claude-v2:1"`) || !strings.Contains(union, `"claude-2.1"`) {
+		t.Errorf("modelUnionCUE() missing catalog IDs: %s", union)
+	}
+}
+
+func TestNewValidatorWithOptions_Models(t *testing.T) {
+	data := map[string]any{"name": "reviewer", "description": "Reviews code", "model": "anthropic.claude-v2:1"}
+	for _, tt := range []struct {
+		name    string
+		models  []ModelInfo
+		wantErr bool
+	}{
+		{name: "built-in catalog", wantErr: true},
+		{name: "configured model", models: []ModelInfo{{ID: "anthropic.claude-v2:1", Status: ModelRetired}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidatorWithOptions(ValidatorOptions{Models: tt.models})
+			if err := v.LoadSchemas(""); err != nil {
+				t.Fatalf("LoadSchemas() error = %v", err)
+			}
+			errs, err := v.ValidateAgent(data)
+			if err != nil {
+				t.Fatalf("ValidateAgent() error = %v", err)
+			}
+			if got := len(errs) != 0; got != tt.wantErr {
+				t.Errorf("ValidateAgent() = %v, want errors %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -8,7 +8,7 @@ import (
 func TestModelUnionGenerated(t *testing.T) {
 	t.Parallel()
 
-	union := modelUnionCUE()
+	union := modelUnionCUE(NewModelCatalog(nil))
 	if !strings.HasPrefix(union, "#Model:") {
 		t.Fatalf("modelUnionCUE() must start with #Model:, got %q", union)
 	}
@@ -0,0 +1,71 @@
+package cue
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// schemaFieldPattern matches a top-level field of a definition body:
+// one tab of indentation, then a plain or quoted label.
+var schemaFieldPattern = regexp.MustCompile(`^\t"?([A-Za-z_$][A-Za-z0-9_$-]*)"?\??\s*:`)
+
+// SchemaFields lists the top-level fields that an embedded schema
+// definition such as "#Settings" or "#Agent" declares, sorted. It reads the
+// schema source rather than the compiled value, so it needs no validator.
+func SchemaFields(definition string) ([]string, error) {
+	entries, err := schemaFS.ReadDir("schemas")
+	if err != nil {
+		return nil, fmt.Errorf("could not read embedded schemas: %w", err)
+	}
+	for _, entry := range entries {
+		content, err := schemaFS.ReadFile("schemas/" + entry.Name())
+		if err != nil {
+			continue
+		}
+		if fields, ok := definitionFields(string(content), definition); ok {
+			return fields, nil
+		}
+	}
+	return nil, fmt.Errorf("schema definition %s not found", definition)
+}
+
+// definitionFields extracts the top-level fields of definition from CUE
+// source, reporting false when the definition is absent.
+func definitionFields(src, definition string) ([]string, bool) {
+	var fields []string
+	inside := false
+	for _, line := range strings.Split(src, "\n") {
+		switch {
+		case !inside:
+			inside = strings.HasPrefix(line, definition+": {")
+		case strings.HasPrefix(line, "}"):
+			sort.Strings(fields)
+			return fields, true
+		default:
+			if m := schemaFieldPattern.FindStringSubmatch(line); m != nil {
+				fields = append(fields, m[1])
+			}
+		}
+	}
+	return nil, false
+}
+
+// SchemaSources returns the source of each embedded schema file, keyed by
+// file name, such as "agent.cue".
+func SchemaSources() (map[string][]byte, error) {
+	entries, err := schemaFS.ReadDir("schemas")
+	if err != nil {
+		return nil, fmt.Errorf("could not read embedded schemas: %w", err)
+	}
+	sources := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		content, err := schemaFS.ReadFile("schemas/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("could not read embedded schema %s: %w", entry.Name(), err)
+		}
+		sources[entry.Name()] = content
+	}
+	return sources, nil
+}
@@ -0,0 +1,46 @@
+package cue
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSchemaFields(t *testing.T) {
+	tests := []struct {
+		definition string
+		want       []string
+	}{
+		{"#Agent", []string{"name", "description", "tools", "permissionMode"}},
+		{"#Command", []string{"allowed-tools", "argument-hint", "disable-model-invocation"}},
+		{"#Skill", []string{"allowed-tools", "user-invocable"}},
+		{"#Settings", []string{"hooks", "statusLine", "sandbox"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.definition, func(t *testing.T) {
+			fields, err := SchemaFields(tt.definition)
+			if err != nil {
+				t.Fatalf("SchemaFields() error = %v", err)
+			}
+			if !slices.IsSorted(fields) {
+				t.Errorf("SchemaFields() not sorted: %v", fields)
+			}
+			for _, want := range tt.want {
+				if !slices.Contains(fields, want) {
+					t.Errorf("SchemaFields() = %v, missing %q", fields, want)
+				}
+			}
+		})
+	}
+
+	if _, err := SchemaFields("#Nope"); err == nil {
+		t.Error("SchemaFields(#Nope) error = nil, want an error")
+	}
+}
+
+func TestDefinitionFields_TopLevelOnly(t *testing.T) {
+	src := "#Other: {\n\tx: int\n}\n#Def: {\n\t// comment: no\n\tname: string\n\t\"quoted-key\"?: bool\n\tnested?: {\n\t\tinner: int\n\t}\n\t...\n}\n"
+	got, ok := definitionFields(src, "#Def")
+	if want := []string{"name", "nested", "quoted-key"}; !ok || !slices.Equal(got, want) {
+		t.Errorf("definitionFields() = %v, %v; want %v", got, ok, want)
+	}
+}
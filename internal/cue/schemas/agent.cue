@@ -78,6 +78,10 @@ import (
 	requiredMcpServers?: [...string]                          // agent only runs when these MCP servers are connected (v2.1.156)
 	criticalSystemReminder_EXPERIMENTAL?: string             // experimental: reminder re-injected as a system message (v2.1.156)
 
+	// cclint fields
+	enabled?:  bool                                           // false excludes the agent from cross-file analysis
+	disabled?: bool                                           // true excludes the agent from cross-file analysis
+
 	// Allow additional fields
 	...
 }
@@ -53,6 +53,10 @@ import "strings"
 	"disable-model-invocation"?: bool                          // prevent SlashCommand tool from calling this
 	hooks?: #CommandHooks                                      // command-level hooks (PreToolUse, PostToolUse, Stop)
 
+	// cclint fields
+	enabled?:  bool                                            // false excludes the command from cross-file analysis
+	disabled?: bool                                            // true excludes the command from cross-file analysis
+
 	// Allow additional fields
 	...
 }
@@ -69,6 +69,10 @@ import "strings"
 	compatibility?: string & strings.MaxRunes(500)            // environment requirements (max 500 chars)
 	metadata?: {[string]: string | number | bool}             // arbitrary key-value mapping
 
+	// cclint fields
+	enabled?:  bool                                           // false excludes the skill from cross-file analysis
+	disabled?: bool                                           // true excludes the skill from cross-file analysis
+
 	// Allow additional fields
 	...
 }
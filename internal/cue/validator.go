@@ -5,8 +5,10 @@ import (
 	"embed"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/dotcommander/cclint/internal/textutil"
 	"github.com/dotcommander/cclint/internal/types"
@@ -34,7 +36,203 @@ const (
 	TypeCommand         = types.TypeCommand
 	TypeSkill           = types.TypeSkill
 	TypeRule            = types.TypeRule
+	TypeOutputStyle     = types.TypeOutputStyle
 	TypeHTTP            = types.TypeHTTP
+	CategorySecurity    = types.CategorySecurity
+	CategoryStructure   = types.CategoryStructure
+	CategoryReferences  = types.CategoryReferences
+	CategoryStyle       = types.CategoryStyle
+	CategoryPerformance = types.CategoryPerformance
+
+	RuleFrontmatterDuplicateKey     = types.RuleFrontmatterDuplicateKey
+	RuleFrontmatterUnknownKey       = types.RuleFrontmatterUnknownKey
+	RuleAgentToolUnknown            = types.RuleAgentToolUnknown
+	RuleAgentToolSyntax             = types.RuleAgentToolSyntax
+	RuleAgentToolDuplicate          = types.RuleAgentToolDuplicate
+	RuleAgentToolWildcardMode       = types.RuleAgentToolWildcardMode
+	RuleCommandArgHintMissing       = types.RuleCommandArgHintMissing
+	RuleCommandArgHintUnused        = types.RuleCommandArgHintUnused
+	RuleCommandArgHintArity         = types.RuleCommandArgHintArity
+	RuleCommandPositionalGap        = types.RuleCommandPositionalGap
+	RuleCommandBashSyntax           = types.RuleCommandBashSyntax
+	RuleCommandBashNotAllowed       = types.RuleCommandBashNotAllowed
+	RuleCommandFileRefMissing       = types.RuleCommandFileRefMissing
+	RuleCommandAgentOverlap         = types.RuleCommandAgentOverlap
+	RuleSettingsStatusLine          = types.RuleSettingsStatusLine
+	RuleSettingsStatusLineCmd       = types.RuleSettingsStatusLineCmd
+	RuleSettingsOutputStyle         = types.RuleSettingsOutputStyle
+	RuleAgentContextBudget          = types.RuleAgentContextBudget
+	RuleAgentMemoryConflict         = types.RuleAgentMemoryConflict
+	RuleSkillDescriptionTrigger     = types.RuleSkillDescriptionTrigger
+	RuleSkillBodySize               = types.RuleSkillBodySize
+	RuleSkillTriggerVague           = types.RuleSkillTriggerVague
+	RuleSkillTriggerOverlap         = types.RuleSkillTriggerOverlap
+	RuleSkillToolsNotInAgent        = types.RuleSkillToolsNotInAgent
+	RuleSkillToolsUnused            = types.RuleSkillToolsUnused
+	RuleSkillToolsMissing           = types.RuleSkillToolsMissing
+	RuleAgentSkillToolsMissing      = types.RuleAgentSkillToolsMissing
+	RuleAgentSkillModel             = types.RuleAgentSkillModel
+	RuleAgentSkillUnreferenced      = types.RuleAgentSkillUnreferenced
+	RuleAgentSkillUndeclared        = types.RuleAgentSkillUndeclared
+	RuleContextImportMissing        = types.RuleContextImportMissing
+	RuleContextImportOutside        = types.RuleContextImportOutside
+	RuleContextImportCycle          = types.RuleContextImportCycle
+	RuleContextImportDepth          = types.RuleContextImportDepth
+	RuleTemplatePlaceholder         = types.RuleTemplatePlaceholder
+	RuleTerminology                 = types.RuleTerminology
+	RuleFrontmatterFieldRenamed     = types.RuleFrontmatterFieldRenamed
+	RuleFrontmatterFieldDeprecated  = types.RuleFrontmatterFieldDeprecated
+	RuleModelDeprecated             = types.RuleModelDeprecated
+	RuleHookFieldInvalid            = types.RuleHookFieldInvalid
+	RuleHookFieldUnknown            = types.RuleHookFieldUnknown
+	RuleHookTimeout                 = types.RuleHookTimeout
+	RuleSettingsEnvUndefined        = types.RuleSettingsEnvUndefined
+	RuleSettingsEnvCycle            = types.RuleSettingsEnvCycle
+	RuleSettingsCredentialHelper    = types.RuleSettingsCredentialHelper
+	RuleSettingsCredentialExposure  = types.RuleSettingsCredentialExposure
+	RulePermissionsAdditionalDirs   = types.RulePermissionsAdditionalDirs
+	RulePermissionsDefaultMode      = types.RulePermissionsDefaultMode
+	RulePermissionsDisableBypass    = types.RulePermissionsDisableBypass
+	RuleAgentNameCollision          = types.RuleAgentNameCollision
+	RuleAgentColorCollision         = types.RuleAgentColorCollision
+	RuleSkillNameMismatch           = types.RuleSkillNameMismatch
+	RuleSkillDirName                = types.RuleSkillDirName
+	RuleSkillDirCollision           = types.RuleSkillDirCollision
+	RuleSkillNested                 = types.RuleSkillNested
+	RuleCommandNameCollision        = types.RuleCommandNameCollision
+	RuleComponentShadowed           = types.RuleComponentShadowed
+	RuleComponentLinkMissing        = types.RuleComponentLinkMissing
+	RuleComponentLinkType           = types.RuleComponentLinkType
+	RuleSettingsKeyUnknown          = types.RuleSettingsKeyUnknown
+	RuleWebAccessIPAddress          = types.RuleWebAccessIPAddress
+	RuleWebAccessInsecureURL        = types.RuleWebAccessInsecureURL
+	RuleWebAccessDomainDenied       = types.RuleWebAccessDomainDenied
+	RuleScaffoldLeftover            = types.RuleScaffoldLeftover
+	RuleDescriptionReadability      = types.RuleDescriptionReadability
+	RuleHookToolUnreachable         = types.RuleHookToolUnreachable
+	RuleHookScriptMissing           = types.RuleHookScriptMissing
+	RulePermissionsUnusedAllow      = types.RulePermissionsUnusedAllow
+	RulePermissionsDeniedTool       = types.RulePermissionsDeniedTool
+	RulePermissionsTaskAgentMissing = types.RulePermissionsTaskAgentMissing
+	RulePermissionsTaskNoMatch      = types.RulePermissionsTaskNoMatch
+	RuleOrphanedSkill               = types.RuleOrphanedSkill
+	RuleJSONSyntax                  = types.RuleJSONSyntax
+	RuleTeammateAgentMissing        = types.RuleTeammateAgentMissing
+	RuleTeammateAgentTools          = types.RuleTeammateAgentTools
+	RuleHookCommandSecurity         = types.RuleHookCommandSecurity
+	RuleHookNetworkAccess           = types.RuleHookNetworkAccess
+	RuleHookWriteOutsideRoot        = types.RuleHookWriteOutsideRoot
+	RuleSettingsPlatformPortability = types.RuleSettingsPlatformPortability
+	RuleStaleFileReference          = types.RuleStaleFileReference
+	RuleContextAgentsMDDuplicate    = types.RuleContextAgentsMDDuplicate
+	RuleContextAgentsMDConflict     = types.RuleContextAgentsMDConflict
+	RuleContentLongLine             = types.RuleContentLongLine
+	RuleContentBase64Blob           = types.RuleContentBase64Blob
+	RuleContentInvalidUTF8          = types.RuleContentInvalidUTF8
+	RuleContentBOM                  = types.RuleContentBOM
+	RuleContentLineEndings          = types.RuleContentLineEndings
+	RuleContentFinalNewline         = types.RuleContentFinalNewline
+	RuleFrontmatterTabIndent        = types.RuleFrontmatterTabIndent
+	RuleFrontmatterYAMLAlias        = types.RuleFrontmatterYAMLAlias
+	RuleFrontmatterSyntax           = types.RuleFrontmatterSyntax
+	RuleSettingsExtendsMissing      = types.RuleSettingsExtendsMissing
+	RuleSettingsExtendsInvalid      = types.RuleSettingsExtendsInvalid
+	RuleSettingsExtendsCycle        = types.RuleSettingsExtendsCycle
+	RuleCommandChainToolsMissing    = types.RuleCommandChainToolsMissing
+	RuleSchemaValidationSkipped     = types.RuleSchemaValidationSkipped
+	RulePluginVersionConstraint     = types.RulePluginVersionConstraint
+	RulePluginDependencyMissing     = types.RulePluginDependencyMissing
+	RulePluginDependencyVersion     = types.RulePluginDependencyVersion
+	RulePluginDependencyCycle       = types.RulePluginDependencyCycle
+	RuleSchemaViolation             = types.RuleSchemaViolation
+	RuleParseError                  = types.RuleParseError
+	RuleLintFailed                  = types.RuleLintFailed
+	RuleFieldRequired               = types.RuleFieldRequired
+	RuleFieldInvalid                = types.RuleFieldInvalid
+	RuleFieldTooLong                = types.RuleFieldTooLong
+	RuleFrontmatterFieldSwallowed   = types.RuleFrontmatterFieldSwallowed
+	RuleDescriptionAngleBrackets    = types.RuleDescriptionAngleBrackets
+	RuleDescriptionTooShort         = types.RuleDescriptionTooShort
+	RuleNameFormat                  = types.RuleNameFormat
+	RuleNameReserved                = types.RuleNameReserved
+	RuleAgentNameMismatch           = types.RuleAgentNameMismatch
+	RuleComponentFileName           = types.RuleComponentFileName
+	RuleComponentEmpty              = types.RuleComponentEmpty
+	RuleComponentSize               = types.RuleComponentSize
+	RuleVersionFormat               = types.RuleVersionFormat
+	RuleModelUnknown                = types.RuleModelUnknown
+	RuleToolUnknown                 = types.RuleToolUnknown
+	RuleToolDeprecated              = types.RuleToolDeprecated
+	RuleToolsFieldName              = types.RuleToolsFieldName
+	RuleSecretDetected              = types.RuleSecretDetected
+	RuleCrossFileSkipped            = types.RuleCrossFileSkipped
+	RuleAgentRefMissing             = types.RuleAgentRefMissing
+	RuleSkillRefMissing             = types.RuleSkillRefMissing
+	RuleTriggerConflict             = types.RuleTriggerConflict
+	RuleAgentDependencyCycle        = types.RuleAgentDependencyCycle
+	RuleAgentToolUnused             = types.RuleAgentToolUnused
+	RuleAgentDescriptionProactive   = types.RuleAgentDescriptionProactive
+	RuleAgentModelMissing           = types.RuleAgentModelMissing
+	RuleAgentSkillSuggested         = types.RuleAgentSkillSuggested
+	RuleAgentPermissionModeMissing  = types.RuleAgentPermissionModeMissing
+	RuleAgentAutonomous             = types.RuleAgentAutonomous
+	RuleAgentBloatSection           = types.RuleAgentBloatSection
+	RuleAgentInlineMethodology      = types.RuleAgentInlineMethodology
+	RuleCommandToolsWildcard        = types.RuleCommandToolsWildcard
+	RuleCommandToolNotDelegation    = types.RuleCommandToolNotDelegation
+	RuleCommandSkillWithoutTask     = types.RuleCommandSkillWithoutTask
+	RuleCommandImplementation       = types.RuleCommandImplementation
+	RuleCommandTaskNotAllowed       = types.RuleCommandTaskNotAllowed
+	RuleCommandToolUnused           = types.RuleCommandToolUnused
+	RuleCommandBloatSection         = types.RuleCommandBloatSection
+	RuleCommandExamples             = types.RuleCommandExamples
+	RuleCommandSuccessCriteria      = types.RuleCommandSuccessCriteria
+	RuleCommandUsageMissing         = types.RuleCommandUsageMissing
+	RuleCommandPreprocessEmpty      = types.RuleCommandPreprocessEmpty
+	RuleCommandPreprocessDangerous  = types.RuleCommandPreprocessDangerous
+	RuleCommandPositionalHigh       = types.RuleCommandPositionalHigh
+	RuleCommandFlagUnknown          = types.RuleCommandFlagUnknown
+	RuleSkillAllowedToolsFormat     = types.RuleSkillAllowedToolsFormat
+	RuleSkillSectionMissing         = types.RuleSkillSectionMissing
+	RuleSkillFrontmatterMissing     = types.RuleSkillFrontmatterMissing
+	RuleSkillAgentWithoutFork       = types.RuleSkillAgentWithoutFork
+	RuleSkillScriptShebang          = types.RuleSkillScriptShebang
+	RuleSkillScriptNotExecutable    = types.RuleSkillScriptNotExecutable
+	RuleSkillLinkAbsolute           = types.RuleSkillLinkAbsolute
+	RuleSkillReferenceDepth         = types.RuleSkillReferenceDepth
+	RuleSkillReferenceFileMissing   = types.RuleSkillReferenceFileMissing
+	RuleSkillReferenceFileUnused    = types.RuleSkillReferenceFileUnused
+	RuleRuleSymlinkBroken           = types.RuleRuleSymlinkBroken
+	RuleContextSectionsMissing      = types.RuleContextSectionsMissing
+	RuleContextSectionIncomplete    = types.RuleContextSectionIncomplete
+	RuleContextImportBinary         = types.RuleContextImportBinary
+	RuleContextLocalNotIgnored      = types.RuleContextLocalNotIgnored
+	RuleContextSize                 = types.RuleContextSize
+	RuleSettingsValueInvalid        = types.RuleSettingsValueInvalid
+	RuleSettingsMCPServerInvalid    = types.RuleSettingsMCPServerInvalid
+	RuleSettingsRulesInvalid        = types.RuleSettingsRulesInvalid
+	RulePermissionsInvalid          = types.RulePermissionsInvalid
+	RulePermissionsToolUnknown      = types.RulePermissionsToolUnknown
+	RuleHookEventUnknown            = types.RuleHookEventUnknown
+	RuleHookMatcherToolUnknown      = types.RuleHookMatcherToolUnknown
+	RuleHookMatcherInvalid          = types.RuleHookMatcherInvalid
+	RulePluginPathNotRelative       = types.RulePluginPathNotRelative
+	RulePluginPathTraversal         = types.RulePluginPathTraversal
+	RulePluginPathMissing           = types.RulePluginPathMissing
+	RulePluginMetadataMissing       = types.RulePluginMetadataMissing
+	RulePluginFieldDeprecated       = types.RulePluginFieldDeprecated
+	RuleKBFilename                  = types.RuleKBFilename
+	RuleKBHeadingMissing            = types.RuleKBHeadingMissing
+	RuleKBSourceMissing             = types.RuleKBSourceMissing
+	RuleKBEntrySize                 = types.RuleKBEntrySize
+)
+
+// Categories, RuleCategories, and RuleGroups are the rule registry; see
+// types.
+var (
+	Categories     = types.Categories
+	RuleCategories = types.RuleCategories
+	RuleGroups     = types.RuleGroups
 )
 
 // Validator handles CUE validation
@@ -42,13 +240,34 @@ type Validator struct {
 	mu      sync.Mutex
 	ctx     *cue.Context
 	schemas map[string]cue.Value
+	limits  EvalLimits
+	models  ModelCatalog
+}
+
+// ValidatorOptions configure a Validator. The zero value selects the
+// defaults.
+type ValidatorOptions struct {
+	// Limits bound each schema evaluation, from the rules.schemaMaxBytes,
+	// rules.schemaMaxDepth, and rules.schemaTimeout config keys; a zero
+	// field keeps its default.
+	Limits EvalLimits
+	// Models are the rules.models overrides merged over the built-in
+	// model catalog, whose IDs the #Model union accepts.
+	Models []ModelInfo
 }
 
-// NewValidator creates a new Validator instance
+// NewValidator creates a new Validator instance with the default options.
 func NewValidator() *Validator {
+	return NewValidatorWithOptions(ValidatorOptions{})
+}
+
+// NewValidatorWithOptions creates a Validator configured by opts.
+func NewValidatorWithOptions(opts ValidatorOptions) *Validator {
 	return &Validator{
 		ctx:     cuecontext.New(),
 		schemas: make(map[string]cue.Value),
+		limits:  opts.Limits.withDefaults(),
+		models:  NewModelCatalog(opts.Models),
 	}
 }
 
@@ -57,6 +276,10 @@ func (v *Validator) LoadSchemas(schemaDir string) error {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 
+	return v.loadSchemasLocked()
+}
+
+func (v *Validator) loadSchemasLocked() error {
 	// List all files in the embedded schemas directory
 	entries, err := schemaFS.ReadDir("schemas")
 	if err != nil {
@@ -77,7 +300,7 @@ func (v *Validator) LoadSchemas(schemaDir string) error {
 				gen   func() string
 			}{
 				{"#KnownTool", knownToolUnionCUE},
-				{"#Model", modelUnionCUE},
+				{"#Model", func() string { return modelUnionCUE(v.models) }},
 			} {
 				if bytes.Contains(content, []byte(inj.token)) {
 					content = append(content, []byte("\n"+inj.gen()+"\n")...)
@@ -138,7 +361,40 @@ func (v *Validator) validateSchema(schemaType string, data map[string]any) ([]Va
 	if !ok {
 		return nil, nil
 	}
-	return v.validateAgainstSchemaLocked(schema, data, schemaType)
+	limits := v.limits
+	if reason := checkInputLimits(data, limits); reason != "" {
+		return skippedValidation(reason), nil
+	}
+
+	type outcome struct {
+		errs []ValidationError
+		err  error
+	}
+	// The goroutine may outlive this call, and a timeout below replaces
+	// v.ctx and v.schemas under it. It therefore gets the context and
+	// schema by value and runs only evaluate, which never touches v.
+	ctx := v.ctx
+	done := make(chan outcome, 1)
+	go func() {
+		errs, err := evaluate(ctx, schema, data, schemaType)
+		done <- outcome{errs, err}
+	}()
+	timer := time.NewTimer(limits.Timeout)
+	defer timer.Stop()
+	select {
+	case o := <-done:
+		return o.errs, o.err
+	case <-timer.C:
+		// CUE evaluation cannot be interrupted. Leave it to finish on the
+		// old context, which nothing else uses now, and carry on with
+		// freshly compiled schemas.
+		v.ctx = cuecontext.New()
+		v.schemas = make(map[string]cue.Value)
+		if err := v.loadSchemasLocked(); err != nil {
+			return nil, err
+		}
+		return skippedValidation(fmt.Sprintf("evaluation took longer than %v", limits.Timeout)), nil
+	}
 }
 
 // validateAgainstSchema validates data against a CUE schema
@@ -150,8 +406,15 @@ func (v *Validator) validateAgainstSchema(schema cue.Value, data map[string]any,
 }
 
 func (v *Validator) validateAgainstSchemaLocked(schema cue.Value, data map[string]any, schemaType string) ([]ValidationError, error) {
+	return evaluate(v.ctx, schema, data, schemaType)
+}
+
+// evaluate validates data against a CUE schema compiled in ctx. It is not a
+// Validator method so that an abandoned evaluation cannot reach the
+// validator's state.
+func evaluate(ctx *cue.Context, schema cue.Value, data map[string]any, schemaType string) ([]ValidationError, error) {
 	// Create a CUE value from the data
-	dataValue := v.ctx.Encode(data)
+	dataValue := ctx.Encode(data)
 	if encErr := dataValue.Err(); encErr != nil {
 		return nil, fmt.Errorf("error encoding data: %w", encErr)
 	}
@@ -170,13 +433,13 @@ func (v *Validator) validateAgainstSchemaLocked(schema cue.Value, data map[strin
 	// Check if data unifies with schema (unify checks if both can be true simultaneously)
 	unified := def.Unify(dataValue)
 	if err := unified.Err(); err != nil {
-		return v.extractErrorsFromCUE(err, schemaType), nil
+		return extractErrorsFromCUE(err, schemaType), nil
 	}
 
 	// Validate concreteness - ensures required fields are present.
 	// Optional fields (name?: string) are correctly skipped by CUE.
 	if err := unified.Validate(cue.Concrete(true)); err != nil {
-		return v.extractErrorsFromCUE(err, schemaType), nil
+		return extractErrorsFromCUE(err, schemaType), nil
 	}
 
 	// Data validates successfully
@@ -185,23 +448,29 @@ func (v *Validator) validateAgainstSchemaLocked(schema cue.Value, data map[strin
 
 // extractErrorsFromCUE flattens a CUE error into one ValidationError per
 // underlying field issue, preserving each issue's path/position/message.
-func (v *Validator) extractErrorsFromCUE(err error, schemaType string) []ValidationError {
+func extractErrorsFromCUE(err error, schemaType string) []ValidationError {
 	var validationErrors []ValidationError
 
 	for _, cueErr := range cuerrors.Errors(err) {
 		pos := cueErr.Position()
 		msg := cueErr.Error()
-		if path := cueErr.Path(); len(path) > 0 {
+		path := cueErr.Path()
+		if len(path) > 0 {
 			msg = fmt.Sprintf("%s: %s", strings.Join(path, "."), msg)
 		}
-		validationErrors = append(validationErrors, ValidationError{
+		verr := ValidationError{
 			File:     "",
 			Message:  msg,
 			Severity: types.SeverityError,
 			Source:   SourceAnthropicDocs,
+			Rule:     RuleSchemaViolation,
 			Line:     pos.Line(),
 			Column:   pos.Column(),
-		})
+		}
+		if schemaType == "settings" {
+			verr.Pointer = cuePathPointer(path)
+		}
+		validationErrors = append(validationErrors, verr)
 	}
 
 	// cuerrors.Errors can return nil for some wrapped errors; never drop
@@ -212,6 +481,7 @@ func (v *Validator) extractErrorsFromCUE(err error, schemaType string) []Validat
 			Message:  err.Error(),
 			Severity: types.SeverityError,
 			Source:   SourceAnthropicDocs,
+			Rule:     RuleSchemaViolation,
 			Line:     0,
 			Column:   0,
 		})
@@ -220,6 +490,24 @@ func (v *Validator) extractErrorsFromCUE(err error, schemaType string) []Validat
 	return validationErrors
 }
 
+// cuePathPointer converts a CUE error path, such as
+// ["#Settings", "hooks", "PreToolUse", "0", "timeout"], to the JSON Pointer
+// of the same value in the validated data. Definition labels are dropped
+// and quoted labels unquoted; numeric labels are array indexes.
+func cuePathPointer(path []string) string {
+	tokens := make([]any, 0, len(path))
+	for _, label := range path {
+		if strings.HasPrefix(label, "#") {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(label); err == nil {
+			label = unquoted
+		}
+		tokens = append(tokens, label)
+	}
+	return textutil.JSONPointer(tokens...)
+}
+
 // Frontmatter represents parsed frontmatter
 type Frontmatter struct {
 	Data map[string]any
@@ -248,6 +536,7 @@ func (v *Validator) ValidateFile(path string, content string, fileType string) (
 			File:     path,
 			Message:  err.Error(),
 			Severity: types.SeverityError,
+			Rule:     RuleFrontmatterSyntax,
 		}}, nil
 	}
 
@@ -1881,3 +1881,42 @@ func TestExtractErrorsFromCUE_PerFieldErrors(t *testing.T) {
 		t.Fatalf("expected 2 distinct messages, got %d distinct: %+v", len(seen), errs)
 	}
 }
+
+func TestCuePathPointer(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path []string
+		want string
+	}{
+		{[]string{"#Settings", "hooks", "PreToolUse", "0", "hooks", "1", "command"}, "/hooks/PreToolUse/0/hooks/1/command"},
+		{[]string{"#Settings", `"mcp/servers"`, "x~y"}, "/mcp~1servers/x~0y"},
+		{[]string{"#Settings"}, ""},
+	}
+	for _, tt := range tests {
+		if got := cuePathPointer(tt.path); got != tt.want {
+			t.Errorf("cuePathPointer(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestValidateSettings_Pointer(t *testing.T) {
+	t.Parallel()
+	v := NewValidator()
+	if err := v.LoadSchemas("schemas"); err != nil {
+		t.Fatalf("Failed to load schemas: %v", err)
+	}
+	errs, err := v.ValidateSettings(map[string]any{
+		"hooks": map[string]any{"Stop": []any{map[string]any{"hooks": []any{map[string]any{"type": "command", "command": "x", "timeout": "soon"}}}}},
+	})
+	if err != nil {
+		t.Fatalf("ValidateSettings() error = %v", err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("expected a schema error for a string timeout")
+	}
+	for _, e := range errs {
+		if e.Pointer != "/hooks/Stop/0/hooks/0/timeout" {
+			t.Errorf("Pointer = %q, want /hooks/Stop/0/hooks/0/timeout (%s)", e.Pointer, e.Message)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package discovery
+
+import (
+	"container/list"
+	"os"
+	"sync"
+)
+
+// Cache is a least-recently-used cache bounded by a total cost. Each value's
+// cost comes from the cost function given to NewCache (for example its size
+// in bytes, or 1 to bound the entry count). It is safe for concurrent use.
+type Cache[V any] struct {
+	mu      sync.Mutex
+	maxCost int64
+	cost    func(V) int64
+	total   int64
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+type cacheEntry[V any] struct {
+	key   string
+	value V
+	cost  int64
+}
+
+// NewCache creates a cache holding values up to maxCost in total. A value
+// costing more than maxCost on its own is returned to callers but not kept.
+func NewCache[V any](maxCost int64, cost func(V) int64) *Cache[V] {
+	return &Cache[V]{
+		maxCost: maxCost,
+		cost:    cost,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached value for key, marking it recently used.
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*cacheEntry[V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put stores value under key, evicting the least recently used entries until
+// the total cost fits.
+func (c *Cache[V]) Put(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.total -= el.Value.(*cacheEntry[V]).cost
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+	cost := c.cost(value)
+	if cost > c.maxCost {
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry[V]{key: key, value: value, cost: cost})
+	c.total += cost
+	for c.total > c.maxCost {
+		oldest := c.order.Back()
+		entry := oldest.Value.(*cacheEntry[V])
+		c.order.Remove(oldest)
+		delete(c.entries, entry.key)
+		c.total -= entry.cost
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// DefaultContentCacheBytes bounds the file contents a ContentCache keeps.
+const DefaultContentCacheBytes = 32 << 20
+
+// ContentCache reads file contents on demand and keeps the most recently used
+// ones, up to a byte budget, so repeated cross-file lookups do not re-read
+// every file while peak memory stays bounded on large trees.
+type ContentCache struct {
+	cache *Cache[string]
+}
+
+// NewContentCache creates a content cache holding up to maxBytes of contents.
+func NewContentCache(maxBytes int64) *ContentCache {
+	return &ContentCache{cache: NewCache(maxBytes, func(s string) int64 { return int64(len(s)) })}
+}
+
+// Contents returns f's contents, from the cache when possible. Contents
+// already held by f are returned as is. Unreadable files yield "".
+func (c *ContentCache) Contents(f File) string {
+	if f.Contents != "" || f.Path == "" {
+		return f.Contents
+	}
+	if s, ok := c.cache.Get(f.Path); ok {
+		return s
+	}
+	s, err := f.Load()
+	if err != nil {
+		return ""
+	}
+	c.cache.Put(f.Path, s)
+	return s
+}
+
+// Load returns the file's contents: Contents when discovery already read
+// them or the caller set them, otherwise a fresh read of Path. Files found
+// by a lazy discovery (see WithLazyContents) are read here, one at a time.
+func (f File) Load() (string, error) {
+	if f.Contents != "" || f.Path == "" {
+		return f.Contents, nil
+	}
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
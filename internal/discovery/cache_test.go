@@ -0,0 +1,87 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCache_Eviction tests that the least recently used entries go first
+func TestCache_Eviction(t *testing.T) {
+	c := NewCache(10, func(s string) int64 { return int64(len(s)) })
+	c.Put("a", "aaaa")
+	c.Put("b", "bbbb")
+	if _, ok := c.Get("a"); !ok { // a is now the most recently used
+		t.Fatal("a missing before eviction")
+	}
+	c.Put("c", "cccc") // 12 bytes: evicts b
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("%s should still be cached", key)
+		}
+	}
+
+	c.Put("a", "a") // replacing an entry updates its cost
+	c.Put("d", "dddd")
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", c.Len())
+	}
+
+	c.Put("huge", "0123456789abc") // costs more than the whole cache
+	if _, ok := c.Get("huge"); ok {
+		t.Error("oversized value should not be cached")
+	}
+}
+
+// TestLazyDiscovery tests that lazy discovery defers reads to Load
+func TestLazyDiscovery(t *testing.T) {
+	root := t.TempDir()
+	agentPath := filepath.Join(root, ".claude", "agents", "a.md")
+	if err := os.MkdirAll(filepath.Dir(agentPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(agentPath, []byte("---\nname: a\n---\nv1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := NewFileDiscovery(root).WithLazyContents().DiscoverFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Contents != "" || files[0].Size == 0 {
+		t.Fatalf("lazy discovery = %+v, want one unread file with a size", files)
+	}
+
+	got, err := files[0].Load()
+	if err != nil || got != "---\nname: a\n---\nv1\n" {
+		t.Fatalf("Load() = %q, %v", got, err)
+	}
+
+	cache := NewContentCache(DefaultContentCacheBytes)
+	if got := cache.Contents(files[0]); got != "---\nname: a\n---\nv1\n" {
+		t.Fatalf("Contents() = %q", got)
+	}
+	// Served from the cache after the file changes on disk.
+	if err := os.WriteFile(agentPath, []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := cache.Contents(files[0]); got != "---\nname: a\n---\nv1\n" {
+		t.Errorf("cached Contents() = %q", got)
+	}
+
+	// Files that already hold contents, or have no path, are not read.
+	preset := File{Path: agentPath, Contents: "preset"}
+	if got, _ := preset.Load(); got != "preset" {
+		t.Errorf("Load() with contents = %q", got)
+	}
+	if got := cache.Contents(File{RelPath: "agents/x.md"}); got != "" {
+		t.Errorf("Contents() without a path = %q", got)
+	}
+	if _, err := (File{Path: filepath.Join(root, "missing.md")}).Load(); err == nil {
+		t.Error("Load() of a missing file should fail")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/bmatcuk/doublestar/v4"
@@ -81,8 +82,15 @@ var DefaultFileTypes = []FileTypeEntry{
 		FallbackPathSubstring: "",
 	},
 	{
-		Type:                  FileTypeCommand,
-		Patterns:              []string{".claude/commands/**/*.md", "commands/**/*.md"},
+		Type: FileTypeCommand,
+		Patterns: []string{
+			".claude/commands/**/*.md",
+			"commands/**/*.md",
+			// Plugin-shipped commands, so project commands that shadow them
+			// can be reported; see the agent patterns.
+			"plugins/cache/*/*/current/commands/**/*.md",
+			".claude/plugins/cache/*/*/current/commands/**/*.md",
+		},
 		FallbackBasenames:     nil,
 		FallbackPathSubstring: "",
 	},
@@ -91,6 +99,67 @@ var DefaultFileTypes = []FileTypeEntry{
 // typePatterns mirrors the canonical detection registry for tests and diagnostics.
 var typePatterns = buildTypePatterns(DefaultFileTypes)
 
+// MergeFileTypes builds a registry from defaults with the disabled patterns
+// removed and the custom entries prepended, so custom patterns win type
+// detection over built-in ones. It returns an error if a custom pattern does
+// not compile or a disabled pattern is not one of the defaults.
+func MergeFileTypes(defaults, custom []FileTypeEntry, disabled []string) ([]FileTypeEntry, error) {
+	for _, entry := range custom {
+		if entry.Type == FileTypeUnknown {
+			return nil, fmt.Errorf("custom file type entry has no type")
+		}
+		if len(entry.Patterns) == 0 {
+			return nil, fmt.Errorf("custom %s entry has no patterns", entry.Type)
+		}
+		for _, pattern := range append(slices.Clone(entry.Patterns), entry.DetectPatterns...) {
+			if !doublestar.ValidatePattern(pattern) {
+				return nil, fmt.Errorf("invalid %s pattern %q", entry.Type, pattern)
+			}
+		}
+	}
+
+	off := make(map[string]bool, len(disabled))
+	for _, pattern := range disabled {
+		off[pattern] = true
+	}
+	found := make(map[string]bool, len(disabled))
+
+	merged := slices.Clone(custom)
+	for _, entry := range defaults {
+		keep := func(pattern string) bool {
+			if off[pattern] {
+				found[pattern] = true
+				return false
+			}
+			return true
+		}
+		entry.Patterns = filterPatterns(entry.Patterns, keep)
+		entry.DetectPatterns = filterPatterns(entry.DetectPatterns, keep)
+		if len(entry.Patterns) == 0 && len(entry.DetectPatterns) == 0 && len(entry.FallbackBasenames) == 0 {
+			continue
+		}
+		merged = append(merged, entry)
+	}
+
+	for _, pattern := range disabled {
+		if !found[pattern] {
+			return nil, fmt.Errorf("cannot disable %q: not a built-in discovery pattern", pattern)
+		}
+	}
+	return merged, nil
+}
+
+// filterPatterns returns the patterns for which keep reports true.
+func filterPatterns(patterns []string, keep func(string) bool) []string {
+	var out []string
+	for _, p := range patterns {
+		if keep(p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
 func buildTypePatterns(entries []FileTypeEntry) []TypePattern {
 	patterns := make([]TypePattern, 0)
 	for _, entry := range entries {
@@ -131,6 +200,12 @@ func (e FileTypeEntry) detectPatterns() []string {
 //	fileType, err := DetectFileType("/home/user/.claude/agents/my-agent.md", "/home/user/.claude")
 //	// fileType == FileTypeAgent
 func DetectFileType(absPath, rootPath string) (FileType, error) {
+	return DetectFileTypeWithRegistry(absPath, rootPath, DefaultFileTypes)
+}
+
+// DetectFileTypeWithRegistry is DetectFileType with a custom registry,
+// typically DefaultFileTypes merged with the fileTypes config block.
+func DetectFileTypeWithRegistry(absPath, rootPath string, registry []FileTypeEntry) (FileType, error) {
 	// Compute relative path for pattern matching
 	relPath, err := filepath.Rel(rootPath, absPath)
 	if err != nil {
@@ -145,7 +220,7 @@ func DetectFileType(absPath, rootPath string) (FileType, error) {
 		return FileTypeUnknown, fmt.Errorf("file is outside project root: %s", absPath)
 	}
 
-	fileType, err := detectFileTypeFromRelativePath(relPath)
+	fileType, err := detectFileTypeFromRelativePath(buildTypePatterns(registry), relPath)
 	if err != nil {
 		return FileTypeUnknown, err
 	}
@@ -154,7 +229,7 @@ func DetectFileType(absPath, rootPath string) (FileType, error) {
 	}
 
 	// Fallback: match by basename for files outside standard directories.
-	if fileType = detectFileTypeFromBasename(absPath); fileType != FileTypeUnknown {
+	if fileType = detectFileTypeFromBasename(registry, absPath); fileType != FileTypeUnknown {
 		return fileType, nil
 	}
 
@@ -253,20 +328,24 @@ func ValidateFilePath(path string) (absPath string, err error) {
 	}
 
 	// Check for null bytes (binary indicator)
-	if bytes.Contains(buf[:n], []byte{0}) {
+	if isBinary(buf[:n]) {
 		return "", fmt.Errorf("file appears to be binary, not text: %s", absPath)
 	}
 
 	return absPath, nil
 }
 
-// File represents a discovered file with its metadata
+// File represents a discovered file with its metadata. Contents is empty
+// when the file came from a lazy discovery; read it with Load.
 type File struct {
 	Path     string
 	RelPath  string
 	Size     int64
 	Type     FileType
 	Contents string
+	// Pattern is the discovery glob that matched the file, or "" when the
+	// file was not found by discovery.
+	Pattern string
 }
 
 // FileType categorizes discovered files
@@ -336,21 +415,84 @@ func ParseFileType(s string) (FileType, error) {
 	}
 }
 
+// SymlinkPolicy controls how discovery treats symlinks in matched paths.
+type SymlinkPolicy string
+
+const (
+	// SymlinkDeny skips every symlinked path and records it as skipped.
+	SymlinkDeny SymlinkPolicy = "deny"
+	// SymlinkWithinRoot follows symlinks whose target resolves inside the
+	// project root and skips the rest.
+	SymlinkWithinRoot SymlinkPolicy = "within-root"
+	// SymlinkAllow follows every symlink that resolves.
+	SymlinkAllow SymlinkPolicy = "allow"
+)
+
+// SymlinkPolicies lists the valid symlink policies.
+var SymlinkPolicies = []SymlinkPolicy{SymlinkDeny, SymlinkWithinRoot, SymlinkAllow}
+
+// ParseSymlinkPolicy converts a string to a SymlinkPolicy.
+func ParseSymlinkPolicy(s string) (SymlinkPolicy, error) {
+	p := SymlinkPolicy(strings.ToLower(strings.TrimSpace(s)))
+	if slices.Contains(SymlinkPolicies, p) {
+		return p, nil
+	}
+	return "", fmt.Errorf("invalid symlink policy %q: valid policies are deny, within-root, allow", s)
+}
+
+// SkippedSymlink records a matched path that discovery skipped because of
+// the symlink policy.
+type SkippedSymlink struct {
+	RelPath string
+	Target  string
+	Reason  string
+	Type    FileType // the type of the pattern that matched it
+}
+
+// Reasons discovery passes over a file, as reported in SkippedFile.
+const (
+	SkipExcluded  = "excluded"       // matched an exclude pattern
+	SkipBinary    = "binary"         // NUL bytes in the first 512 bytes
+	SkipSymlink   = "symlink"        // refused by the symlink policy
+	SkipAmbiguous = "ambiguous type" // no discovery pattern matches
+)
+
+// SkippedFile records a candidate file discovery did not return.
+type SkippedFile struct {
+	RelPath string
+	Reason  string // one of the Skip constants
+	Detail  string // the exclude pattern, symlink target, or a hint
+}
+
 // FileDiscovery manages file discovery operations
 type FileDiscovery struct {
-	rootPath       string
-	followSymlinks bool
-	exclude        []string
+	rootPath string
+	registry []FileTypeEntry
+	symlinks SymlinkPolicy
+	exclude  []string
+	skipped  []SkippedSymlink
+	passed   []SkippedFile
+	lazy     bool
 }
 
-// NewFileDiscovery creates a new FileDiscovery instance
-func NewFileDiscovery(rootPath string, followSymlinks bool) *FileDiscovery {
+// NewFileDiscovery creates a new FileDiscovery instance with the default
+// registry and the within-root symlink policy; see WithFileTypes and
+// WithSymlinkPolicy.
+func NewFileDiscovery(rootPath string) *FileDiscovery {
 	return &FileDiscovery{
-		rootPath:       rootPath,
-		followSymlinks: followSymlinks,
+		rootPath: rootPath,
+		registry: DefaultFileTypes,
+		symlinks: SymlinkWithinRoot,
 	}
 }
 
+// WithFileTypes sets the registry used by DiscoverFiles and Explain,
+// typically DefaultFileTypes merged with the fileTypes config block.
+func (fd *FileDiscovery) WithFileTypes(registry []FileTypeEntry) *FileDiscovery {
+	fd.registry = registry
+	return fd
+}
+
 // WithExclude sets glob patterns for files to exclude from discovery.
 // Patterns are matched against relative paths using doublestar.Match.
 func (fd *FileDiscovery) WithExclude(patterns []string) *FileDiscovery {
@@ -358,26 +500,70 @@ func (fd *FileDiscovery) WithExclude(patterns []string) *FileDiscovery {
 	return fd
 }
 
+// WithSymlinkPolicy overrides the symlink policy for this discoverer.
+func (fd *FileDiscovery) WithSymlinkPolicy(p SymlinkPolicy) *FileDiscovery {
+	fd.symlinks = p
+	return fd
+}
+
+// WithLazyContents makes discovery record files without reading them;
+// callers read each file with File.Load (or a ContentCache) when needed, so
+// memory does not grow with the size of the whole tree.
+func (fd *FileDiscovery) WithLazyContents() *FileDiscovery {
+	fd.lazy = true
+	return fd
+}
+
+// Skipped returns the symlinked paths skipped by the last discovery run.
+func (fd *FileDiscovery) Skipped() []SkippedSymlink {
+	return fd.skipped
+}
+
+// SkippedFiles returns the matched files the last discovery run left out
+// because they were excluded or binary, sorted by relative path.
+// Symlinks refused by the policy are reported by Skipped.
+func (fd *FileDiscovery) SkippedFiles() []SkippedFile {
+	return fd.passed
+}
+
 // DiscoverFiles finds all relevant files in the project.
-// It iterates over the DefaultFileTypes registry, making it easy to add
-// new component types without modifying this method.
+// It iterates over the discoverer's registry (DefaultFileTypes unless set
+// with WithFileTypes), making it easy to add new component types without
+// modifying this method.
 func (fd *FileDiscovery) DiscoverFiles() ([]File, error) {
-	return fd.DiscoverFilesWithRegistry(DefaultFileTypes)
+	return fd.DiscoverFilesWithRegistry(fd.registry)
 }
 
 // DiscoverFilesWithRegistry finds files using a custom registry.
-// This allows filtering or extending the default file types.
+// This allows filtering or extending the default file types. A file matched
+// by several entries is reported once, with the type of the first entry.
+// Files are returned sorted by relative path.
 func (fd *FileDiscovery) DiscoverFilesWithRegistry(registry []FileTypeEntry) ([]File, error) {
 	var files []File
+	seen := make(map[string]bool)
+	fd.skipped = nil
+	fd.passed = nil
 
 	for _, ftc := range registry {
 		discovered, err := fd.findFilesByPattern(ftc.Patterns, ftc.Type)
 		if err != nil {
 			return nil, fmt.Errorf("error discovering %s files: %w", ftc.Type.String(), err)
 		}
-		files = append(files, discovered...)
+		for _, f := range discovered {
+			if seen[f.Path] {
+				continue
+			}
+			seen[f.Path] = true
+			files = append(files, f)
+		}
 	}
 
+	slices.SortFunc(files, func(a, b File) int {
+		return strings.Compare(a.RelPath, b.RelPath)
+	})
+	slices.SortFunc(fd.passed, func(a, b SkippedFile) int {
+		return strings.Compare(a.RelPath, b.RelPath)
+	})
 	return files, nil
 }
 
@@ -395,6 +581,7 @@ func (fd *FileDiscovery) findFilesByPattern(patterns []string, fileType FileType
 		for _, match := range matches {
 			f, ok := fd.processMatch(match, fileType)
 			if ok {
+				f.Pattern = pattern
 				files = append(files, f)
 			}
 		}
@@ -405,72 +592,132 @@ func (fd *FileDiscovery) findFilesByPattern(patterns []string, fileType FileType
 
 // processMatch converts a glob match into a File, returning false if the match should be skipped.
 func (fd *FileDiscovery) processMatch(match string, fileType FileType) (File, bool) {
-	if fd.isExcluded(match) {
+	if pattern := fd.excludedBy(match); pattern != "" {
+		fd.pass(match, SkipExcluded, "exclude pattern "+pattern)
 		return File{}, false
 	}
 	fullPath := filepath.Join(fd.rootPath, match)
 
+	if fd.hasSymlink(match) && !fd.allowSymlink(match, fullPath, fileType) {
+		return File{}, false
+	}
+
 	info, err := os.Stat(fullPath)
 	if err != nil || info.IsDir() {
 		return File{}, false
 	}
 
-	if info.Mode()&os.ModeSymlink != 0 {
-		resolved, resolvedInfo, ok := fd.resolveSymlink(fullPath)
-		if !ok {
+	f := File{
+		Path:    fullPath,
+		RelPath: match,
+		Size:    info.Size(),
+		Type:    fileType,
+	}
+	if fd.lazy {
+		if isBinaryFile(fullPath) {
+			fd.pass(match, SkipBinary, "")
 			return File{}, false
 		}
-		match = resolved
-		info = resolvedInfo
+		return f, true
 	}
 
 	contents, err := os.ReadFile(fullPath)
 	if err != nil {
 		return File{}, false
 	}
+	if isBinary(contents) {
+		fd.pass(match, SkipBinary, "")
+		return File{}, false
+	}
+	f.Contents = string(contents)
+	return f, true
+}
 
-	relPath := match
-	return File{
-		Path:     fullPath,
-		RelPath:  relPath,
-		Size:     info.Size(),
-		Type:     fileType,
-		Contents: string(contents),
-	}, true
+// pass records relPath as skipped, once per discovery run.
+func (fd *FileDiscovery) pass(relPath, reason, detail string) {
+	if !slices.ContainsFunc(fd.passed, func(s SkippedFile) bool { return s.RelPath == relPath }) {
+		fd.passed = append(fd.passed, SkippedFile{RelPath: relPath, Reason: reason, Detail: detail})
+	}
 }
 
-// isExcluded checks if a relative path matches any exclude pattern.
-func (fd *FileDiscovery) isExcluded(relPath string) bool {
+// excludedBy returns the first exclude pattern matching relPath, or "".
+func (fd *FileDiscovery) excludedBy(relPath string) string {
 	for _, pattern := range fd.exclude {
 		if matched, err := doublestar.Match(pattern, relPath); err == nil && matched {
-			return true
+			return pattern
 		}
 	}
-	return false
+	return ""
 }
 
-// resolveSymlink follows a symlink if configured, returning the resolved path and info.
-// Returns false if the symlink should be skipped.
-func (fd *FileDiscovery) resolveSymlink(fullPath string) (string, os.FileInfo, bool) {
-	if !fd.followSymlinks {
-		return "", nil, false
-	}
+// isBinary reports whether data looks binary: a NUL byte in its first 512
+// bytes, the same check ValidateFilePath applies to single files.
+func isBinary(data []byte) bool {
+	return bytes.IndexByte(data[:min(len(data), 512)], 0) >= 0
+}
 
-	realPath, err := filepath.EvalSymlinks(fullPath)
+// isBinaryFile applies isBinary to the start of the file at path. Files that
+// cannot be read are left for the linter to report.
+func isBinaryFile(path string) bool {
+	f, err := os.Open(path)
 	if err != nil {
-		return "", nil, false
+		return false
 	}
+	defer func() { _ = f.Close() }()
+	buf := make([]byte, 512)
+	n, _ := f.Read(buf)
+	return isBinary(buf[:n])
+}
 
-	if !strings.HasPrefix(realPath, fd.rootPath) {
-		return "", nil, false
+// hasSymlink reports whether the file at relPath, or any directory between
+// it and the root, is a symlink. Each component is checked with os.Lstat so
+// the result does not depend on how the glob walker treats links.
+func (fd *FileDiscovery) hasSymlink(relPath string) bool {
+	current := fd.rootPath
+	for _, part := range strings.Split(filepath.ToSlash(relPath), "/") {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if err != nil {
+			return false
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// allowSymlink applies the symlink policy to a symlinked match, recording
+// the match as skipped when it is not followed.
+func (fd *FileDiscovery) allowSymlink(relPath, fullPath string, fileType FileType) bool {
+	skip := func(target, reason string) bool {
+		if !slices.ContainsFunc(fd.skipped, func(s SkippedSymlink) bool { return s.RelPath == relPath }) {
+			fd.skipped = append(fd.skipped, SkippedSymlink{RelPath: relPath, Target: target, Reason: reason, Type: fileType})
+		}
+		return false
 	}
 
-	info, err := os.Stat(realPath)
+	target, err := filepath.EvalSymlinks(fullPath)
 	if err != nil {
-		return "", nil, false
+		return skip("", "broken symlink")
 	}
 
-	return realPath, info, true
+	switch fd.symlinks {
+	case SymlinkAllow:
+		return true
+	case SymlinkDeny:
+		return skip(target, "symlinks: deny")
+	default:
+		root, err := filepath.EvalSymlinks(fd.rootPath)
+		if err != nil {
+			root = fd.rootPath
+		}
+		rel, err := filepath.Rel(root, target)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return skip(target, "target outside project root")
+		}
+		return true
+	}
 }
 
 // determineFileType determines the file type based on its path.
@@ -478,15 +725,15 @@ func (fd *FileDiscovery) resolveSymlink(fullPath string) (string, os.FileInfo, b
 // like matching "/my-agents-backup/" when looking for "/agents/".
 func (fd *FileDiscovery) determineFileType(path string) FileType {
 	normalizedPath := filepath.ToSlash(path)
-	fileType, err := detectFileTypeFromRelativePath(normalizedPath)
+	fileType, err := detectFileTypeFromRelativePath(buildTypePatterns(fd.registry), normalizedPath)
 	if err == nil && fileType != FileTypeUnknown {
 		return fileType
 	}
-	return detectFileTypeFromBasename(normalizedPath)
+	return detectFileTypeFromBasename(fd.registry, normalizedPath)
 }
 
-func detectFileTypeFromRelativePath(relPath string) (FileType, error) {
-	for _, tp := range typePatterns {
+func detectFileTypeFromRelativePath(patterns []TypePattern, relPath string) (FileType, error) {
+	for _, tp := range patterns {
 		matched, err := doublestar.Match(tp.Pattern, relPath)
 		if err != nil {
 			return FileTypeUnknown, fmt.Errorf("invalid detection pattern %q: %w", tp.Pattern, err)
@@ -499,11 +746,11 @@ func detectFileTypeFromRelativePath(relPath string) (FileType, error) {
 	return FileTypeUnknown, nil
 }
 
-func detectFileTypeFromBasename(path string) FileType {
+func detectFileTypeFromBasename(registry []FileTypeEntry, path string) FileType {
 	basename := filepath.Base(path)
 	normalizedPath := filepath.ToSlash(path)
 
-	for _, entry := range DefaultFileTypes {
+	for _, entry := range registry {
 		for _, candidate := range entry.FallbackBasenames {
 			if !strings.EqualFold(basename, candidate) {
 				continue
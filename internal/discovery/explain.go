@@ -0,0 +1,120 @@
+package discovery
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// componentDirs are the directory names whose .md and .json files Explain
+// treats as candidates: such a file that no pattern matches is likely a
+// misplaced component.
+var componentDirs = []string{".claude", ".claude-plugin", "agents", "commands", "skills", "rules", "output-styles"}
+
+// explainSkipDirs are directories Explain does not walk into.
+var explainSkipDirs = []string{".git", "node_modules"}
+
+// Explanation is a dry run of discovery: the files a lint run would check,
+// with the pattern that matched each, and the candidates it passes over.
+type Explanation struct {
+	Files   []File
+	Skipped []SkippedFile
+}
+
+// Explain runs discovery without reading file contents and lists the
+// candidates it did not return: excluded and binary matches, symlinks the
+// policy refused, and .md and .json files that no pattern matches but that
+// sit under a component directory or carry a component file name.
+func (fd *FileDiscovery) Explain() (*Explanation, error) {
+	lazy := fd.lazy
+	fd.lazy = true
+	files, err := fd.DiscoverFiles()
+	fd.lazy = lazy
+	if err != nil {
+		return nil, err
+	}
+
+	skipped := slices.Clone(fd.passed)
+	for _, s := range fd.skipped {
+		detail := s.Reason
+		if s.Target != "" {
+			detail = "-> " + s.Target + " (" + s.Reason + ")"
+		}
+		skipped = append(skipped, SkippedFile{RelPath: s.RelPath, Reason: SkipSymlink, Detail: detail})
+	}
+
+	known := make(map[string]bool, len(files)+len(skipped))
+	for _, f := range files {
+		known[f.RelPath] = true
+	}
+	for _, s := range skipped {
+		known[s.RelPath] = true
+	}
+
+	err = filepath.WalkDir(fd.rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories hold nothing discovery could find.
+			return nil
+		}
+		if d.IsDir() {
+			if path != fd.rootPath && slices.Contains(explainSkipDirs, d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".md" && ext != ".json" {
+			return nil
+		}
+		rel, err := filepath.Rel(fd.rootPath, path)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if known[rel] || !fd.isCandidate(rel) {
+			return nil
+		}
+		if pattern := fd.excludedBy(rel); pattern != "" {
+			skipped = append(skipped, SkippedFile{RelPath: rel, Reason: SkipExcluded, Detail: "exclude pattern " + pattern})
+			return nil
+		}
+		skipped = append(skipped, SkippedFile{RelPath: rel, Reason: SkipAmbiguous, Detail: fd.ambiguousHint(rel)})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking %s: %w", fd.rootPath, err)
+	}
+
+	slices.SortFunc(skipped, func(a, b SkippedFile) int {
+		return strings.Compare(a.RelPath, b.RelPath)
+	})
+	return &Explanation{Files: files, Skipped: skipped}, nil
+}
+
+// isCandidate reports whether relPath sits under a component directory or
+// has the base name of a component file, such as a nested CLAUDE.md.
+func (fd *FileDiscovery) isCandidate(relPath string) bool {
+	parts := strings.Split(relPath, "/")
+	for _, dir := range parts[:len(parts)-1] {
+		if slices.Contains(componentDirs, dir) {
+			return true
+		}
+	}
+	base := parts[len(parts)-1]
+	for _, entry := range fd.registry {
+		if slices.ContainsFunc(entry.FallbackBasenames, func(name string) bool { return strings.EqualFold(name, base) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// ambiguousHint says how an unmatched candidate could still be linted.
+func (fd *FileDiscovery) ambiguousHint(relPath string) string {
+	if ft := fd.determineFileType(relPath); ft != FileTypeUnknown {
+		return fmt.Sprintf("no discovery pattern matches; linted directly it is a %s", ft)
+	}
+	return "no discovery pattern matches; lint it directly with --type"
+}
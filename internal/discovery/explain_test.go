@@ -0,0 +1,62 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel string, data []byte) {
+		path := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+	}
+	write(".claude/agents/reviewer.md", []byte("---\nname: reviewer\n---\n"))
+	write(".claude/agents/logo.md", []byte("\x89PNG\x00\x00"))
+	write(".claude/agents/old/legacy.md", []byte("---\nname: legacy\n---\n"))
+	write(".claude/agent/typo.md", []byte("---\nname: typo\n---\n"))
+	write("src/CLAUDE.md", []byte("# Nested\n"))
+	write("docs/guide.md", []byte("# Not a component\n"))
+	write(".git/agents/x.md", []byte("ignored"))
+
+	fd := NewFileDiscovery(root).WithExclude([]string{"**/old/**"})
+	got, err := fd.Explain()
+	require.NoError(t, err)
+
+	require.Len(t, got.Files, 1)
+	assert.Equal(t, ".claude/agents/reviewer.md", got.Files[0].RelPath)
+	assert.Equal(t, FileTypeAgent, got.Files[0].Type)
+	assert.Equal(t, ".claude/agents/**/*.md", got.Files[0].Pattern)
+	assert.Empty(t, got.Files[0].Contents, "Explain reads no contents")
+
+	assert.Equal(t, []SkippedFile{
+		{RelPath: ".claude/agent/typo.md", Reason: SkipAmbiguous, Detail: "no discovery pattern matches; lint it directly with --type"},
+		{RelPath: ".claude/agents/logo.md", Reason: SkipBinary},
+		{RelPath: ".claude/agents/old/legacy.md", Reason: SkipExcluded, Detail: "exclude pattern **/old/**"},
+		{RelPath: "src/CLAUDE.md", Reason: SkipAmbiguous, Detail: "no discovery pattern matches; linted directly it is a context"},
+	}, got.Skipped)
+}
+
+func TestDiscoverFiles_SkipsBinary(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "agents"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "agents", "bin.md"), []byte("a\x00b"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "agents", "ok.md"), []byte("text"), 0o600))
+
+	for _, lazy := range []bool{false, true} {
+		fd := NewFileDiscovery(root)
+		if lazy {
+			fd.WithLazyContents()
+		}
+		files, err := fd.DiscoverFiles()
+		require.NoError(t, err)
+		require.Len(t, files, 1, "lazy=%v", lazy)
+		assert.Equal(t, "agents/ok.md", files[0].RelPath)
+		assert.Equal(t, []SkippedFile{{RelPath: "agents/bin.md", Reason: SkipBinary}}, fd.SkippedFiles())
+	}
+}
@@ -0,0 +1,143 @@
+// Package fix applies edits to several files as one unit: after Commit every
+// file holds its new contents, and after Rollback every file holds its old
+// ones.
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/format"
+)
+
+// Edit replaces the contents of one file.
+type Edit struct {
+	Path   string // file to rewrite
+	Name   string // path shown in diffs, usually relative to the project root
+	Before string // contents the edit was planned against
+	After  string
+}
+
+// Transaction stages edits in temporary files beside their targets, swaps
+// them in together with Apply, and keeps the originals until Commit or
+// Rollback. Each swap is a rename within one directory, so no file is ever
+// seen half-written.
+type Transaction struct {
+	edits   []Edit
+	staged  []string // temporary file holding each edit's After
+	backups []string // original of each applied edit, in apply order
+	done    bool
+}
+
+// Begin stages every edit. It fails, leaving no files behind, when a
+// target no longer holds the contents its edit was planned against.
+func Begin(edits []Edit) (*Transaction, error) {
+	t := &Transaction{edits: edits}
+	for _, e := range edits {
+		tmp, err := stage(e)
+		if err != nil {
+			t.removeStaged()
+			return nil, err
+		}
+		t.staged = append(t.staged, tmp)
+	}
+	return t, nil
+}
+
+// stage writes e.After to a temporary file next to e.Path, with e.Path's
+// permissions.
+func stage(e Edit) (string, error) {
+	info, err := os.Stat(e.Path)
+	if err != nil {
+		return "", fmt.Errorf("cannot stage %s: %w", e.Name, err)
+	}
+	current, err := os.ReadFile(e.Path)
+	if err != nil {
+		return "", fmt.Errorf("cannot stage %s: %w", e.Name, err)
+	}
+	if string(current) != e.Before {
+		return "", fmt.Errorf("cannot stage %s: file changed since it was linted", e.Name)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(e.Path), ".cclint-fix-*")
+	if err != nil {
+		return "", fmt.Errorf("cannot stage %s: %w", e.Name, err)
+	}
+	_, werr := tmp.WriteString(e.After)
+	if err := errors.Join(werr, tmp.Close(), os.Chmod(tmp.Name(), info.Mode().Perm())); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("cannot stage %s: %w", e.Name, err)
+	}
+	return tmp.Name(), nil
+}
+
+// Apply swaps every staged file in, moving each original aside. If any
+// swap fails, the files already swapped are restored before the error is
+// returned.
+func (t *Transaction) Apply() error {
+	for i, e := range t.edits {
+		backup := t.staged[i] + ".orig"
+		if err := os.Rename(e.Path, backup); err != nil {
+			return errors.Join(fmt.Errorf("cannot apply %s: %w", e.Name, err), t.Rollback())
+		}
+		t.backups = append(t.backups, backup)
+		if err := os.Rename(t.staged[i], e.Path); err != nil {
+			return errors.Join(fmt.Errorf("cannot apply %s: %w", e.Name, err), t.Rollback())
+		}
+		t.staged[i] = ""
+	}
+	return nil
+}
+
+// Commit keeps the applied edits and removes the originals.
+func (t *Transaction) Commit() error {
+	if t.done {
+		return nil
+	}
+	t.done = true
+	var errs []error
+	for _, backup := range t.backups {
+		if err := os.Remove(backup); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	t.removeStaged()
+	return errors.Join(errs...)
+}
+
+// Rollback restores every original moved aside by Apply and removes the
+// staged files.
+func (t *Transaction) Rollback() error {
+	if t.done {
+		return nil
+	}
+	t.done = true
+	var errs []error
+	for i := len(t.backups) - 1; i >= 0; i-- {
+		if err := os.Rename(t.backups[i], t.edits[i].Path); err != nil {
+			errs = append(errs, fmt.Errorf("cannot restore %s (original kept at %s): %w", t.edits[i].Name, t.backups[i], err))
+		}
+	}
+	t.removeStaged()
+	return errors.Join(errs...)
+}
+
+// removeStaged deletes staged files that were not swapped in.
+func (t *Transaction) removeStaged() {
+	for _, tmp := range t.staged {
+		if tmp != "" {
+			_ = os.Remove(tmp)
+		}
+	}
+}
+
+// Diff returns the combined diff of edits, one file after another.
+func Diff(edits []Edit) string {
+	var sb strings.Builder
+	for _, e := range edits {
+		sb.WriteString(format.Diff(e.Before, e.After, e.Name))
+	}
+	return sb.String()
+}
@@ -0,0 +1,154 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o640); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// dirEntries returns the names in dir, to catch leftover staged files.
+func dirEntries(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestTransaction(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit bool
+		want   map[string]string
+	}{
+		{name: "commit keeps new contents", commit: true, want: map[string]string{"a.md": "a2", "b.md": "b2"}},
+		{name: "rollback restores originals", want: map[string]string{"a.md": "a1", "b.md": "b1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{"a.md": "a1", "b.md": "b1"})
+			edits := []Edit{
+				{Path: filepath.Join(dir, "a.md"), Name: "a.md", Before: "a1", After: "a2"},
+				{Path: filepath.Join(dir, "b.md"), Name: "b.md", Before: "b1", After: "b2"},
+			}
+
+			tx, err := Begin(edits)
+			if err != nil {
+				t.Fatalf("Begin() error = %v", err)
+			}
+			if got := readFile(t, edits[0].Path); got != "a1" {
+				t.Errorf("after Begin a.md = %q, want it untouched", got)
+			}
+			if err := tx.Apply(); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got := readFile(t, edits[1].Path); got != "b2" {
+				t.Errorf("after Apply b.md = %q, want b2", got)
+			}
+
+			if tt.commit {
+				err = tx.Commit()
+			} else {
+				err = tx.Rollback()
+			}
+			if err != nil {
+				t.Fatalf("finish error = %v", err)
+			}
+			for name, want := range tt.want {
+				path := filepath.Join(dir, name)
+				if got := readFile(t, path); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+				if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o640 {
+					t.Errorf("%s mode = %v (%v), want 0640", name, info.Mode().Perm(), err)
+				}
+			}
+			if names := dirEntries(t, dir); len(names) != 2 {
+				t.Errorf("directory holds %v, want only a.md and b.md", names)
+			}
+		})
+	}
+}
+
+func TestBeginStaleFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.md": "a1", "b.md": "edited meanwhile"})
+	_, err := Begin([]Edit{
+		{Path: filepath.Join(dir, "a.md"), Name: "a.md", Before: "a1", After: "a2"},
+		{Path: filepath.Join(dir, "b.md"), Name: "b.md", Before: "b1", After: "b2"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "b.md") {
+		t.Fatalf("Begin() error = %v, want one naming b.md", err)
+	}
+	if names := dirEntries(t, dir); len(names) != 2 {
+		t.Errorf("directory holds %v, want staged files removed", names)
+	}
+}
+
+func TestApplyFailureRestores(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.md": "a1", "b.md": "b1"})
+	edits := []Edit{
+		{Path: filepath.Join(dir, "a.md"), Name: "a.md", Before: "a1", After: "a2"},
+		{Path: filepath.Join(dir, "b.md"), Name: "b.md", Before: "b1", After: "b2"},
+	}
+	tx, err := Begin(edits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Remove b.md after staging so its swap fails halfway through Apply.
+	if err := os.Remove(edits[1].Path); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Apply(); err == nil {
+		t.Fatal("Apply() error = nil, want failure for b.md")
+	}
+	if got := readFile(t, edits[0].Path); got != "a1" {
+		t.Errorf("a.md = %q, want a1 restored", got)
+	}
+	if names := dirEntries(t, dir); len(names) != 1 {
+		t.Errorf("directory holds %v, want only a.md", names)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	got := Diff([]Edit{
+		{Name: "a.md", Before: "x\ny", After: "x\nz"},
+		{Name: "b.md", Before: "same", After: "same"},
+		{Name: "c.md", Before: "p", After: "q"},
+	})
+	for _, want := range []string{"--- a.md", "+ z", "--- c.md", "- p"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Diff() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "b.md") {
+		t.Errorf("Diff() lists unchanged b.md:\n%s", got)
+	}
+}
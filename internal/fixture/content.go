@@ -0,0 +1,197 @@
+package fixture
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// domains are the parts of an imagined project that components work on.
+var domains = []string{
+	"api", "auth", "billing", "cache", "checkout", "cli", "database", "deploy",
+	"docs", "email", "frontend", "graphql", "infra", "logging", "metrics",
+	"migration", "mobile", "payments", "search", "security", "storage", "queue",
+}
+
+// role is what a kind of agent does, when it is used, and how.
+type role struct {
+	does, when string
+	tools      []string
+	steps      []string
+}
+
+// roleNames are the keys of roles, in a fixed order.
+var roleNames = []string{"reviewer", "debugger", "planner", "tester", "auditor", "writer", "optimizer", "migrator"}
+
+var roles = map[string]role{
+	"reviewer":  {"Reviews %s changes for correctness, style, and risk", "after the user changes %s code", []string{"Read", "Grep", "Glob"}, []string{"Read the changed files with Read.", "Grep for callers of every changed function.", "Report findings by severity, most severe first."}},
+	"debugger":  {"Finds the root cause of %s failures", "when %s tests or requests fail", []string{"Read", "Grep", "Bash"}, []string{"Reproduce the failure with Bash.", "Read the code on the failing path.", "Grep for recent changes to that path.", "Explain the cause and propose a fix."}},
+	"planner":   {"Breaks %s features into small, reviewable tasks", "before the user starts new %s features", []string{"Read", "Glob"}, []string{"Glob for the modules the feature touches.", "Read their entry points.", "List the tasks in the order they can land."}},
+	"tester":    {"Writes and runs tests for %s code", "after the user adds %s behavior without tests", []string{"Read", "Write", "Bash"}, []string{"Read the code under test.", "Write table-driven tests next to it.", "Run them with Bash and fix what fails."}},
+	"auditor":   {"Audits %s code for security and compliance problems", "before releasing %s changes", []string{"Read", "Grep"}, []string{"Grep for secrets, unsafe calls, and missing checks.", "Read each match in context.", "Report each problem with its file and line."}},
+	"writer":    {"Writes and updates %s documentation", "when %s behavior changes", []string{"Read", "Edit"}, []string{"Read the changed code.", "Edit the matching documentation pages.", "Keep examples runnable."}},
+	"optimizer": {"Finds and removes %s performance bottlenecks", "when the user reports slow %s paths", []string{"Read", "Bash"}, []string{"Profile the slow path with Bash.", "Read the hottest functions.", "Propose the smallest change that helps."}},
+	"migrator":  {"Plans and applies %s schema and API migrations", "when %s interfaces change", []string{"Read", "Edit", "Grep"}, []string{"Grep for every use of the old interface.", "Edit each use to the new one.", "Read the result back and check nothing was missed."}},
+}
+
+// subjects are the topics within each domain that skills cover.
+var subjects = map[string][]string{
+	"api":       {"request validation", "error responses", "pagination cursors", "endpoint versioning"},
+	"auth":      {"session tokens", "password hashing", "role checks", "oauth callbacks"},
+	"billing":   {"invoice generation", "proration rules", "tax rates", "usage metering"},
+	"cache":     {"key naming", "eviction policy", "warmup jobs", "stale reads"},
+	"checkout":  {"cart totals", "discount codes", "address forms", "order confirmation"},
+	"cli":       {"flag parsing", "exit codes", "help text", "shell completion"},
+	"database":  {"index design", "query plans", "connection pooling", "transaction boundaries"},
+	"deploy":    {"rollout stages", "rollback steps", "health probes", "release tagging"},
+	"docs":      {"api reference", "tutorial structure", "code samples", "changelog entries"},
+	"email":     {"template rendering", "bounce handling", "unsubscribe links", "delivery retries"},
+	"frontend":  {"component state", "form validation", "bundle size", "accessibility labels"},
+	"graphql":   {"resolver batching", "schema evolution", "query complexity", "field deprecation"},
+	"infra":     {"terraform modules", "network policies", "secret rotation", "autoscaling limits"},
+	"logging":   {"log levels", "structured fields", "trace correlation", "retention windows"},
+	"metrics":   {"counter naming", "histogram buckets", "alert thresholds", "dashboard layout"},
+	"migration": {"backfill jobs", "dual writes", "column renames", "data verification"},
+	"mobile":    {"offline sync", "push notifications", "app permissions", "crash reports"},
+	"payments":  {"card tokenization", "refund flows", "webhook verification", "idempotency keys"},
+	"search":    {"ranking signals", "synonym lists", "index rebuilds", "query parsing"},
+	"security":  {"input sanitization", "dependency audits", "csrf protection", "threat models"},
+	"storage":   {"object lifecycle", "upload limits", "signed urls", "replication lag"},
+	"queue":     {"message ordering", "dead letters", "consumer scaling", "retry backoff"},
+}
+
+// skillKinds are the kinds of skill, by name suffix, with the phrases
+// their descriptions open and close with.
+var skillKinds = []struct{ name, phrase, when string }{
+	{"conventions", "Conventions", "Use when writing %s"},
+	{"patterns", "Design patterns", "Use when designing %s"},
+	{"runbook", "Runbook", "Use when %s fails in production"},
+	{"checklist", "Checklist", "Use before merging %s"},
+	{"troubleshooting", "Troubleshooting", "Use when debugging %s"},
+	{"style-guide", "Style guide", "Use when documenting %s"},
+}
+
+// commandNames are the verbs commands are named after.
+var commandNames = []string{"review", "test", "deploy", "check", "release", "audit", "explain", "bootstrap"}
+
+// namer hands out unique component names, so that no two components of a
+// tree share one.
+type namer map[string]int
+
+// next returns base, numbered if it was handed out before.
+func (n namer) next(base string) string {
+	n[base]++
+	if count := n[base]; count > 1 {
+		return fmt.Sprintf("%s-%d", base, count)
+	}
+	return base
+}
+
+// deal returns n tuples of indexes into slices of the given sizes, every
+// combination once in random order before any repeats, so that names and
+// descriptions stay distinct for as long as they can.
+func deal(r *rand.Rand, n int, sizes ...int) [][]int {
+	total := 1
+	for _, size := range sizes {
+		total *= size
+	}
+	if total == 0 {
+		return nil
+	}
+	tuples := make([][]int, 0, n)
+	for len(tuples) < n {
+		for _, k := range r.Perm(total) {
+			if len(tuples) == n {
+				break
+			}
+			tuple := make([]int, len(sizes))
+			for i := len(sizes) - 1; i >= 0; i-- {
+				tuple[i], k = k%sizes[i], k/sizes[i]
+			}
+			tuples = append(tuples, tuple)
+		}
+	}
+	return tuples
+}
+
+func pick[T any](r *rand.Rand, s []T) T {
+	return s[r.IntN(len(s))]
+}
+
+func title(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// newAgent builds an agent for a domain and role tuple, given the skills
+// it may preload.
+func newAgent(r *rand.Rand, names namer, tuple []int, skills []*component) *component {
+	domain, roleName := domains[tuple[0]], roleNames[tuple[1]]
+	rl := roles[roleName]
+	name := names.next(domain + "-" + roleName)
+	c := &component{kind: "agent", name: name}
+	c.set("name", name)
+	c.set("description", fmt.Sprintf("%s. Use PROACTIVELY %s.", fmt.Sprintf(rl.does, domain), fmt.Sprintf(rl.when, domain)))
+	c.set("tools", strings.Join(rl.tools, ", "))
+	c.set("model", pick(r, []string{"sonnet", "haiku", "opus", "inherit"}))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "You are the %s %s for this project.\n\n## Workflow\n\n", domain, roleName)
+	for i, step := range rl.steps {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, step)
+	}
+	if len(skills) > 0 && r.IntN(2) == 0 {
+		skill := pick(r, skills).name
+		c.set("skills", skill)
+		fmt.Fprintf(&b, "\nFollow the %s skill for project conventions.\n", skill)
+	}
+	fmt.Fprintf(&b, "\n## Output\n\nA short summary for the user, then the details, each with its file and line.\n")
+	c.body = b.String()
+	return c
+}
+
+// newSkill builds a skill for a domain, subject, and kind tuple.
+func newSkill(r *rand.Rand, names namer, tuple []int) *component {
+	domain := domains[tuple[0]]
+	subject := subjects[domain][tuple[1]]
+	kind := skillKinds[tuple[2]]
+	name := names.next(domain + "-" + strings.ReplaceAll(subject, " ", "-") + "-" + kind.name)
+	c := &component{kind: "skill", name: name, dir: name}
+	c.set("name", name)
+	c.set("description", fmt.Sprintf("%s for %s in %s. %s.", kind.phrase, subject, domain, fmt.Sprintf(kind.when, subject)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s: %s\n\n## Steps\n\n", kind.phrase, subject)
+	steps := []string{
+		"Read the module's README before changing it.",
+		"Keep changes small and behind a flag when they touch users.",
+		"Add a test for every bug fix.",
+		"Log errors once, where they are handled.",
+		"Run the full test suite before asking for review.",
+	}
+	for i, n := 0, 3+r.IntN(3); i < n; i++ {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, steps[i])
+	}
+	fmt.Fprintf(&b, "\n## Examples\n\nA change to %s updates the %s code, its tests, and its docs in one commit.\n", subject, domain)
+	fmt.Fprintf(&b, "\n## Anti-Patterns\n\n- Handling %s differently in each caller.\n", subject)
+	c.body = b.String()
+	return c
+}
+
+// newCommand builds a command for a verb and domain tuple, given the
+// agents it may delegate to.
+func newCommand(r *rand.Rand, names namer, tuple []int, agents []*component) *component {
+	verb, domain := commandNames[tuple[0]], domains[tuple[1]]
+	c := &component{kind: "command", name: names.next(verb + "-" + domain)}
+	c.set("description", fmt.Sprintf("%s the %s code", title(verb), domain))
+	c.set("argument-hint", `"[path]"`)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s the %s code in $ARGUMENTS.\n\n", title(verb), domain)
+	if len(agents) > 0 {
+		fmt.Fprintf(&b, "Delegate the work to the %s agent, then summarize what it found.\n", pick(r, agents).name)
+	} else {
+		b.WriteString("Summarize what you found, most important first.\n")
+	}
+	c.body = b.String()
+	return c
+}
@@ -0,0 +1,201 @@
+// Package fixture generates synthetic .claude trees: agents, skills, and
+// commands that read like a real project's, with a share of them seeded with
+// known problems. The same options and seed always produce the same tree,
+// so a tree can be rebuilt from a bug report instead of attached to it.
+package fixture
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/selftest"
+	"gopkg.in/yaml.v3"
+)
+
+// Options size a generated tree.
+type Options struct {
+	Agents   int
+	Skills   int
+	Commands int
+	// ErrorRate is the share of components, 0 to 1, seeded with a problem.
+	ErrorRate float64
+	Seed      uint64
+}
+
+// File is one generated file.
+type File struct {
+	Path    string // relative to the tree root, slash-separated
+	Content string
+}
+
+// Tree is a generated project tree.
+type Tree struct {
+	Files []File
+	// Seeded lists the findings the seeded problems cause, one per seeded
+	// component, in the selftest expectation format.
+	Seeded []selftest.Expectation
+}
+
+// problem is a defect a component can be seeded with: a change to its
+// frontmatter and the finding it causes.
+type problem struct {
+	apply  func(c *component)
+	expect selftest.Expectation
+}
+
+// component is a generated component before it is rendered.
+type component struct {
+	kind        string
+	name        string
+	dir         string // skills only: the directory, normally the name
+	frontmatter [][2]string
+	body        string
+}
+
+var agentProblems = []problem{
+	{
+		apply:  func(c *component) { c.set("tools", "Read, Grep, FutureSearch") },
+		expect: selftest.Expectation{Rule: cue.RuleAgentToolUnknown, Severity: cue.SeverityWarning, Message: "FutureSearch"},
+	},
+	{
+		apply:  func(c *component) { c.unset("description") },
+		expect: selftest.Expectation{Severity: cue.SeverityError, Message: "Required field 'description' is missing"},
+	},
+	{
+		apply:  func(c *component) { c.set("permissionMode", "yolo") },
+		expect: selftest.Expectation{Severity: cue.SeverityError, Message: `Invalid permissionMode value "yolo"`},
+	},
+}
+
+var skillProblems = []problem{
+	{
+		apply:  func(c *component) { c.dir = c.name + "-old" },
+		expect: selftest.Expectation{Rule: cue.RuleSkillNameMismatch, Severity: cue.SeverityError},
+	},
+	{
+		apply:  func(c *component) { c.set("context", "isolated") },
+		expect: selftest.Expectation{Severity: cue.SeverityError, Message: "context field must be 'fork'"},
+	},
+}
+
+var commandProblems = []problem{
+	{
+		apply: func(c *component) {
+			c.set("argument-hint", `"[target]"`)
+			c.body = strings.ReplaceAll(c.body, "$ARGUMENTS", "the target")
+		},
+		expect: selftest.Expectation{Rule: cue.RuleCommandArgHintUnused, Severity: cue.SeverityWarning},
+	},
+	{
+		apply:  func(c *component) { c.set("allowed-tools", "Task, Bahs") },
+		expect: selftest.Expectation{Severity: cue.SeverityWarning, Message: "Unknown tool 'Bahs'"},
+	},
+}
+
+// Generate builds a tree for opts. Components are seeded at random, but
+// from opts.Seed, so the result depends on opts alone.
+func Generate(opts Options) Tree {
+	r := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15)) //nolint:gosec // G404: reproducible fixtures, not secrets
+	names := make(namer)
+
+	var skills, agents, commands []*component
+	for _, d := range deal(r, opts.Skills, len(domains), len(subjects[domains[0]]), len(skillKinds)) {
+		skills = append(skills, newSkill(r, names, d))
+	}
+	for _, d := range deal(r, opts.Agents, len(domains), len(roleNames)) {
+		agents = append(agents, newAgent(r, names, d, skills))
+	}
+	for _, d := range deal(r, opts.Commands, len(commandNames), len(domains)) {
+		commands = append(commands, newCommand(r, names, d, agents))
+	}
+
+	all := append(append(append([]*component{}, agents...), skills...), commands...)
+	seeded := int(math.Round(opts.ErrorRate * float64(len(all))))
+	var tree Tree
+	for _, i := range r.Perm(len(all))[:min(seeded, len(all))] {
+		c := all[i]
+		p := pick(r, map[string][]problem{"agent": agentProblems, "skill": skillProblems, "command": commandProblems}[c.kind])
+		p.apply(c)
+		expect := p.expect
+		expect.File = c.path()
+		tree.Seeded = append(tree.Seeded, expect)
+	}
+	for _, c := range all {
+		tree.Files = append(tree.Files, File{Path: c.path(), Content: c.render()})
+	}
+	return tree
+}
+
+// Write writes the tree under dir, with an expect.yaml listing the seeded
+// findings so that "cclint selftest" can check them. It refuses to write
+// into a directory that already has a .claude tree.
+func (t Tree) Write(dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".claude")); err == nil {
+		return fmt.Errorf("%s already has a .claude directory", dir)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	expect, err := yaml.Marshal(selftest.Fixture{Partial: true, Findings: t.Seeded})
+	if err != nil {
+		return err
+	}
+	files := append(t.Files, File{Path: selftest.ExpectFile, Content: string(expect)})
+	for _, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(f.Content), 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *component) path() string {
+	switch c.kind {
+	case "agent":
+		return ".claude/agents/" + c.name + ".md"
+	case "skill":
+		return ".claude/skills/" + c.dir + "/SKILL.md"
+	default:
+		return ".claude/commands/" + c.name + ".md"
+	}
+}
+
+// set sets a frontmatter field, in place if it is already there.
+func (c *component) set(key, value string) {
+	for i, kv := range c.frontmatter {
+		if kv[0] == key {
+			c.frontmatter[i][1] = value
+			return
+		}
+	}
+	c.frontmatter = append(c.frontmatter, [2]string{key, value})
+}
+
+func (c *component) unset(key string) {
+	for i, kv := range c.frontmatter {
+		if kv[0] == key {
+			c.frontmatter = append(c.frontmatter[:i], c.frontmatter[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *component) render() string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, kv := range c.frontmatter {
+		fmt.Fprintf(&b, "%s: %s\n", kv[0], kv[1])
+	}
+	b.WriteString("---\n\n")
+	b.WriteString(c.body)
+	return b.String()
+}
@@ -0,0 +1,104 @@
+package fixture
+
+import (
+	"math/rand/v2"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/selftest"
+)
+
+func TestGenerate(t *testing.T) {
+	opts := Options{Agents: 30, Skills: 40, Commands: 30, ErrorRate: 0.1, Seed: 3}
+	tree := Generate(opts)
+
+	counts := make(map[string]int)
+	paths := make(map[string]bool)
+	for _, f := range tree.Files {
+		if paths[f.Path] {
+			t.Errorf("duplicate file %s", f.Path)
+		}
+		paths[f.Path] = true
+		counts[strings.Split(f.Path, "/")[1]]++
+		if !strings.HasPrefix(f.Content, "---\n") {
+			t.Errorf("%s has no frontmatter", f.Path)
+		}
+	}
+	if counts["agents"] != 30 || counts["skills"] != 40 || counts["commands"] != 30 {
+		t.Errorf("counts = %v, want 30 agents, 40 skills, 30 commands", counts)
+	}
+	if len(tree.Seeded) != 10 {
+		t.Fatalf("seeded = %d, want 10", len(tree.Seeded))
+	}
+	for _, e := range tree.Seeded {
+		if !paths[e.File] {
+			t.Errorf("seeded finding in %s, which was not generated", e.File)
+		}
+	}
+
+	again := Generate(opts)
+	if !equalTrees(tree, again) {
+		t.Error("the same options generated different trees")
+	}
+	opts.Seed = 4
+	if equalTrees(tree, Generate(opts)) {
+		t.Error("another seed generated the same tree")
+	}
+
+	if clean := Generate(Options{Agents: 5, Skills: 5, Commands: 5}); len(clean.Seeded) != 0 {
+		t.Errorf("seeded %d problems at an error rate of 0", len(clean.Seeded))
+	}
+	if all := Generate(Options{Agents: 2, Skills: 2, Commands: 2, ErrorRate: 1}); len(all.Seeded) != 6 {
+		t.Errorf("seeded %d problems at an error rate of 1, want 6", len(all.Seeded))
+	}
+}
+
+func TestDeal(t *testing.T) {
+	seen := make(map[[2]int]bool)
+	tuples := deal(rand.New(rand.NewPCG(1, 2)), 12, 3, 4)
+	if len(tuples) != 12 {
+		t.Fatalf("dealt %d tuples, want 12", len(tuples))
+	}
+	for _, tuple := range tuples {
+		key := [2]int{tuple[0], tuple[1]}
+		if seen[key] {
+			t.Errorf("combination %v dealt twice before all were dealt", key)
+		}
+		seen[key] = true
+	}
+	if got := deal(rand.New(rand.NewPCG(1, 2)), 5, 2, 1); len(got) != 5 {
+		t.Errorf("dealt %d tuples past the combinations, want 5", len(got))
+	}
+}
+
+func TestWriteRefusesExistingTree(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "fixture")
+	tree := Generate(Options{Agents: 1, Seed: 1})
+	if err := tree.Write(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := selftest.Load(filepath.Dir(dir)); err != nil {
+		t.Errorf("generated tree is not a selftest fixture: %v", err)
+	}
+	if err := tree.Write(dir); err == nil {
+		t.Error("Write() overwrote an existing .claude tree")
+	}
+}
+
+func equalTrees(a, b Tree) bool {
+	if len(a.Files) != len(b.Files) || len(a.Seeded) != len(b.Seeded) {
+		return false
+	}
+	for i := range a.Files {
+		if a.Files[i] != b.Files[i] {
+			return false
+		}
+	}
+	for i := range a.Seeded {
+		if a.Seeded[i] != b.Seeded[i] {
+			return false
+		}
+	}
+	return true
+}
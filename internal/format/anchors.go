@@ -0,0 +1,108 @@
+package format
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Options configure the component formatters.
+type Options struct {
+	// ExpandAnchors sets what the formatters do with YAML anchors, aliases,
+	// and merge keys in frontmatter, from the fmt.anchors config key. By
+	// default they are preserved: fields are reordered around them and the
+	// YAML is otherwise written as found. When set, aliases and merge keys
+	// are replaced by the values they stand for.
+	ExpandAnchors bool
+}
+
+// usesAnchors reports whether node or anything under it has an anchor, an
+// alias, or a merge key.
+func usesAnchors(node *yaml.Node) bool {
+	if node.Anchor != "" || node.Kind == yaml.AliasNode || node.Tag == "!!merge" {
+		return true
+	}
+	return slices.ContainsFunc(node.Content, usesAnchors)
+}
+
+// reorderAnchoredFrontmatter puts the top-level fields of a frontmatter
+// mapping in canonical order without resolving its anchors, aliases, or
+// merge keys. When the canonical order would move an alias above its
+// anchor, the fields keep their original order.
+func reorderAnchoredFrontmatter(root *yaml.Node, priorityFields []string) (string, error) {
+	if root.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("frontmatter is not a mapping")
+	}
+
+	type field struct{ key, value *yaml.Node }
+	fields := make([]field, 0, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		fields = append(fields, field{root.Content[i], root.Content[i+1]})
+	}
+	rank := func(f field) int {
+		if i := slices.Index(priorityFields, f.key.Value); i >= 0 {
+			return i
+		}
+		return len(priorityFields)
+	}
+	sorted := slices.Clone(fields)
+	slices.SortStableFunc(sorted, func(a, b field) int {
+		if c := cmp.Compare(rank(a), rank(b)); c != 0 || rank(a) < len(priorityFields) {
+			return c
+		}
+		return strings.Compare(a.key.Value, b.key.Value)
+	})
+
+	content := make([]*yaml.Node, 0, len(root.Content))
+	for _, f := range sorted {
+		content = append(content, f.key, f.value)
+	}
+	if anchorsBeforeAliases(content, map[string]bool{}) {
+		root.Content = content
+	}
+	untagMergeKeys(root)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// untagMergeKeys clears the resolved !!merge tag of merge keys under node,
+// which the encoder would otherwise write out as "!!merge <<".
+func untagMergeKeys(node *yaml.Node) {
+	if node.Tag == "!!merge" {
+		node.Tag = ""
+	}
+	for _, child := range node.Content {
+		untagMergeKeys(child)
+	}
+}
+
+// anchorsBeforeAliases reports whether every alias in nodes, in document
+// order, comes after the anchor it refers to. defined collects the anchors
+// seen so far.
+func anchorsBeforeAliases(nodes []*yaml.Node, defined map[string]bool) bool {
+	for _, node := range nodes {
+		if node.Kind == yaml.AliasNode && !defined[node.Value] {
+			return false
+		}
+		if node.Anchor != "" {
+			defined[node.Anchor] = true
+		}
+		if !anchorsBeforeAliases(node.Content, defined) {
+			return false
+		}
+	}
+	return true
+}
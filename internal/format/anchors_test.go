@@ -0,0 +1,45 @@
+package format
+
+import "testing"
+
+func TestFormatAnchors(t *testing.T) {
+	tests := []struct {
+		name   string
+		expand bool
+		input  string
+		want   string
+	}{
+		{
+			name:  "preserved and reordered",
+			input: "---\ntools: &t Read, Grep\nname: a\nallowed: *t\nhooks:\n  <<: {model: sonnet}\n---\nBody\n",
+			want:  "---\nname: a\ntools: &t Read, Grep\nallowed: *t\nhooks:\n  <<: {model: sonnet}\n---\nBody\n",
+		},
+		{
+			name:  "order kept when reordering would move an alias above its anchor",
+			input: "---\nzebra: &z 1\nname: *z\n---\nBody\n",
+			want:  "---\nzebra: &z 1\nname: *z\n---\nBody\n",
+		},
+		{
+			name:   "expanded",
+			expand: true,
+			input:  "---\ntools: &t Read, Grep\nname: a\nallowed: *t\nhooks:\n  <<: {model: sonnet}\n---\nBody\n",
+			want:   "---\nname: a\ntools: Read, Grep\nallowed: Read, Grep\nhooks:\n  model: sonnet\n---\nBody\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewComponentFormatter("agent", Options{ExpandAnchors: tt.expand})
+			got, err := formatter.Format(tt.input)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format() =\n%q\nwant\n%q", got, tt.want)
+			}
+			again, err := formatter.Format(got)
+			if err != nil || again != got {
+				t.Errorf("Format() is not idempotent: %q, %v", again, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// ToLF strips a leading byte order mark and rewrites CRLF line breaks as
+// LF, the form the formatters work on. It also returns the line break the
+// lineEndings policy ("auto", "lf", or "crlf") asks for, which FromLF
+// restores; under auto that is the break the content uses most.
+func ToLF(content, lineEndings string) (text, eol string) {
+	content = strings.TrimPrefix(content, textutil.BOM)
+	return textutil.ConvertLineBreaks(content, "\n"), textutil.LineBreak(lineEndings, content)
+}
+
+// FromLF rewrites the LF line breaks of text as eol and ensures non-empty
+// text ends with a line break.
+func FromLF(text, eol string) string {
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return textutil.ConvertLineBreaks(text, eol)
+}
+
+// expandFrontmatterTabs replaces the tabs in the indentation of each
+// frontmatter line with spaces, since YAML rejects tab indentation.
+func expandFrontmatterTabs(frontmatter string) string {
+	lines := strings.Split(frontmatter, "\n")
+	for i, line := range lines {
+		lines[i] = textutil.ExpandIndentTabs(line)
+	}
+	return strings.Join(lines, "\n")
+}
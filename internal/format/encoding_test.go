@@ -0,0 +1,59 @@
+package format
+
+import "testing"
+
+func TestToLFFromLF(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		lineEndings string
+		want        string
+	}{
+		{
+			name:  "BOM stripped and final newline added",
+			input: "\uFEFF{\n  \"a\": 1\n}",
+			want:  "{\n  \"a\": 1\n}\n",
+		},
+		{
+			name:  "auto keeps the majority style",
+			input: "a\r\nb\r\nc\n",
+			want:  "a\r\nb\r\nc\r\n",
+		},
+		{
+			name:        "lf policy",
+			input:       "a\r\nb\r\n",
+			lineEndings: "lf",
+			want:        "a\nb\n",
+		},
+		{
+			name:        "crlf policy",
+			input:       "a\nb",
+			lineEndings: "crlf",
+			want:        "a\r\nb\r\n",
+		},
+		{
+			name:  "empty stays empty",
+			input: "",
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, eol := ToLF(tt.input, tt.lineEndings)
+			if got := FromLF(text, eol); got != tt.want {
+				t.Errorf("FromLF(ToLF()) = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatComponentExpandsFrontmatterTabs(t *testing.T) {
+	got, err := NewComponentFormatter("agent", Options{}).Format("---\nname: a\nhooks:\n\tStop:\n\t\t- x\n---\n\tcode\n")
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	want := "---\nname: a\nhooks:\n  Stop:\n    - x\n---\n\tcode\n"
+	if got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
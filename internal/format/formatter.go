@@ -22,16 +22,16 @@ type Formatter interface {
 type ComponentFormatter struct{}
 
 // NewComponentFormatter creates a formatter for a specific component type.
-func NewComponentFormatter(componentType string) Formatter {
+func NewComponentFormatter(componentType string, opts Options) Formatter {
 	switch componentType {
 	case "agent":
-		return &AgentFormatter{}
+		return &AgentFormatter{opts: opts}
 	case "command":
-		return &CommandFormatter{}
+		return &CommandFormatter{opts: opts}
 	case "skill":
-		return &SkillFormatter{}
+		return &SkillFormatter{opts: opts}
 	default:
-		return &SkillFormatter{}
+		return &SkillFormatter{opts: opts}
 	}
 }
 
@@ -60,8 +60,20 @@ func parseFrontmatterRaw(content string) parseResult {
 }
 
 // normalizeFrontmatter reorders and normalizes YAML frontmatter fields.
-// Priority fields come first, then others alphabetically.
-func normalizeFrontmatter(yamlContent string, priorityFields []string) (string, error) {
+// Priority fields come first, then others alphabetically. Frontmatter with
+// anchors is only reordered unless expandAnchors is set; see
+// Options.ExpandAnchors. Comments are dropped, except cclint-disable
+// suppression comments, which stay above their field.
+func normalizeFrontmatter(yamlContent string, priorityFields []string, expandAnchors bool) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlContent), &doc); err != nil {
+		return "", err
+	}
+	if !expandAnchors && len(doc.Content) > 0 && usesAnchors(doc.Content[0]) {
+		return reorderAnchoredFrontmatter(doc.Content[0], priorityFields)
+	}
+	comments := suppressionComments(&doc)
+
 	// Extract key-value pairs
 	data := make(map[string]any)
 	if err := yaml.Unmarshal([]byte(yamlContent), &data); err != nil {
@@ -92,6 +104,9 @@ func normalizeFrontmatter(yamlContent string, priorityFields []string) (string,
 	var buf bytes.Buffer
 	for _, key := range orderedKeys {
 		value := data[key]
+		for _, comment := range comments[key] {
+			buf.WriteString(comment + "\n")
+		}
 
 		// Serialize the key-value pair
 		var fieldBuf bytes.Buffer
@@ -111,12 +126,59 @@ func normalizeFrontmatter(yamlContent string, priorityFields []string) (string,
 		buf.WriteString(fieldStr)
 		buf.WriteString("\n")
 	}
+	for _, comment := range comments[""] {
+		buf.WriteString(comment + "\n")
+	}
 
 	result := buf.String()
 	// Remove final trailing newline
 	return strings.TrimSuffix(result, "\n"), nil
 }
 
+// suppressionComments collects the cclint-disable comments of a frontmatter
+// document by top-level key: those above a field or anywhere in its value,
+// which a next-line comment above the field covers. Comments below a field
+// or outside every field, which the YAML parser keeps as foot comments, are
+// under "" so they stay at the end.
+func suppressionComments(doc *yaml.Node) map[string][]string {
+	comments := make(map[string][]string)
+	add := func(key, text string) {
+		for _, line := range strings.Split(text, "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "#") && strings.Contains(line, "cclint-disable-") {
+				comments[key] = append(comments[key], line)
+			}
+		}
+	}
+	own := func(key string, n *yaml.Node) {
+		add(key, n.HeadComment)
+		add("", n.FootComment)
+	}
+	var all func(key string, n *yaml.Node)
+	all = func(key string, n *yaml.Node) {
+		own(key, n)
+		for _, child := range n.Content {
+			all(key, child)
+		}
+	}
+
+	own("", doc)
+	if len(doc.Content) == 0 {
+		return comments
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		all("", root)
+		return comments
+	}
+	own("", root)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		all(root.Content[i].Value, root.Content[i])
+		all(root.Content[i].Value, root.Content[i+1])
+	}
+	return comments
+}
+
 // normalizeMarkdown normalizes markdown body content.
 // hasFrontmatter indicates if this body follows frontmatter.
 func normalizeMarkdown(body string, hasFrontmatter bool) string {
@@ -142,7 +204,7 @@ func normalizeMarkdown(body string, hasFrontmatter bool) string {
 }
 
 // formatComponent formats a component file with the given priority field ordering.
-func formatComponent(content string, priorityFields []string) (string, error) {
+func formatComponent(content string, priorityFields []string, opts Options) (string, error) {
 	result := parseFrontmatterRaw(content)
 	if result.err != nil {
 		return content, result.err
@@ -152,7 +214,7 @@ func formatComponent(content string, priorityFields []string) (string, error) {
 		return normalizeMarkdown(result.body, false), nil
 	}
 
-	normalizedFM, err := normalizeFrontmatter(result.frontmatter, priorityFields)
+	normalizedFM, err := normalizeFrontmatter(expandFrontmatterTabs(result.frontmatter), priorityFields, opts.ExpandAnchors)
 	if err != nil {
 		return content, err
 	}
@@ -162,24 +224,24 @@ func formatComponent(content string, priorityFields []string) (string, error) {
 }
 
 // AgentFormatter formats agent files.
-type AgentFormatter struct{}
+type AgentFormatter struct{ opts Options }
 
 func (f *AgentFormatter) Format(content string) (string, error) {
-	return formatComponent(content, []string{"name", "description", "model", "tools", "allowed-tools"})
+	return formatComponent(content, []string{"name", "description", "model", "tools", "allowed-tools"}, f.opts)
 }
 
 // CommandFormatter formats command files.
-type CommandFormatter struct{}
+type CommandFormatter struct{ opts Options }
 
 func (f *CommandFormatter) Format(content string) (string, error) {
-	return formatComponent(content, []string{"name", "description", "allowed-tools"})
+	return formatComponent(content, []string{"name", "description", "allowed-tools"}, f.opts)
 }
 
 // SkillFormatter formats skill files.
-type SkillFormatter struct{}
+type SkillFormatter struct{ opts Options }
 
 func (f *SkillFormatter) Format(content string) (string, error) {
-	return formatComponent(content, []string{"name", "description"})
+	return formatComponent(content, []string{"name", "description"}, f.opts)
 }
 
 // Diff computes a simple unified diff between original and formatted content.
@@ -286,7 +286,7 @@ alpha: first`,
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := normalizeFrontmatter(tt.yaml, tt.priorityFields)
+			result, err := normalizeFrontmatter(tt.yaml, tt.priorityFields, false)
 			if err != nil {
 				t.Fatalf("normalizeFrontmatter() error = %v", err)
 			}
@@ -419,7 +419,7 @@ func TestNewComponentFormatter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			formatter := NewComponentFormatter(tt.componentType)
+			formatter := NewComponentFormatter(tt.componentType, Options{})
 			actualType := strings.TrimPrefix(strings.TrimPrefix(strings.ReplaceAll(strings.Replace(fmt.Sprintf("%T", formatter), "github.com/dotcommander/cclint/internal/", "", 1), ".", ""), "*"), "format")
 			expectedShort := strings.TrimPrefix(strings.TrimPrefix(tt.expectedType, "*format."), "*")
 
@@ -626,11 +626,37 @@ description: test`,
 	}
 }
 
+func TestNormalizeFrontmatterKeepsSuppressions(t *testing.T) {
+	input := `# a plain comment
+tools:
+  # cclint-disable-next-line agent-tool-unknown
+  - Bashh
+# cclint-disable-next-line frontmatter-unknown-key
+colour: red
+name: test
+# cclint-disable-file agent-model-missing`
+
+	got, err := normalizeFrontmatter(input, []string{"name", "tools"}, false)
+	if err != nil {
+		t.Fatalf("normalizeFrontmatter() error = %v", err)
+	}
+	want := `name: test
+# cclint-disable-next-line agent-tool-unknown
+tools:
+  - Bashh
+# cclint-disable-next-line frontmatter-unknown-key
+colour: red
+# cclint-disable-file agent-model-missing`
+	if got != want {
+		t.Errorf("normalizeFrontmatter() =\n%s\nwant\n%s", got, want)
+	}
+}
+
 func TestNormalizeFrontmatterInvalidYAML(t *testing.T) {
 	invalidYAML := `name: test
 bad: [unclosed array`
 
-	_, err := normalizeFrontmatter(invalidYAML, []string{"name"})
+	_, err := normalizeFrontmatter(invalidYAML, []string{"name"}, false)
 	if err == nil {
 		t.Error("Expected error for invalid YAML")
 	}
@@ -650,7 +676,7 @@ list:
   - b
   - c`
 
-	result, err := normalizeFrontmatter(complexYAML, []string{"name"})
+	result, err := normalizeFrontmatter(complexYAML, []string{"name"}, false)
 	if err != nil {
 		t.Errorf("Unexpected error with complex YAML: %v", err)
 	}
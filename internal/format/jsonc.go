@@ -0,0 +1,32 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// StripJSONC removes // and /* */ comments and trailing commas from a JSONC
+// document, turning it into plain JSON. Lines left empty by a removed
+// comment are dropped and trailing whitespace is trimmed; everything else,
+// including indentation and blank lines, is kept as written.
+func StripJSONC(content string) string {
+	blanked, changed := textutil.BlankJSONC(content)
+	if !changed {
+		return content
+	}
+	original := strings.Split(content, "\n")
+	lines := strings.Split(blanked, "\n")
+	kept := lines[:0]
+	for i, line := range lines {
+		trimmed := strings.TrimRight(line, " \t\r")
+		if trimmed == "" && strings.TrimSpace(original[i]) != "" {
+			continue
+		}
+		if strings.HasSuffix(original[i], "\r") {
+			trimmed += "\r"
+		}
+		kept = append(kept, trimmed)
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,39 @@
+package format
+
+import "testing"
+
+func TestStripJSONC(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain JSON unchanged",
+			input: "{\n  \"a\": 1\n}\n",
+			want:  "{\n  \"a\": 1\n}\n",
+		},
+		{
+			name:  "comment lines dropped, blank lines kept",
+			input: "{\n  // Model\n  \"model\": \"sonnet\",\n\n  /* Permissions\n     below */\n  \"permissions\": {}\n}\n",
+			want:  "{\n  \"model\": \"sonnet\",\n\n  \"permissions\": {}\n}\n",
+		},
+		{
+			name:  "trailing comment and commas",
+			input: "{\n  \"allow\": [\"Read\", \"Bash\",], // tools\n}\n",
+			want:  "{\n  \"allow\": [\"Read\", \"Bash\" ]\n}\n",
+		},
+		{
+			name:  "CRLF kept",
+			input: "{\r\n  // note\r\n  \"a\": 1\r\n}\r\n",
+			want:  "{\r\n  \"a\": 1\r\n}\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripJSONC(tt.input); got != tt.want {
+				t.Errorf("StripJSONC() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LineBlame is the commit that last changed a line, as git blame reports
+// it.
+type LineBlame struct {
+	Commit string    `json:"commit"`
+	Author string    `json:"author"`
+	Email  string    `json:"email"`
+	Date   time.Time `json:"date"` // author date
+}
+
+// BlameFile returns the last commit of each line of file, keyed by 1-based
+// line number. file is relative to rootPath or absolute. Lines that are not
+// committed yet are left out. Returns an empty map if rootPath is not in a
+// git repository or the file is not tracked.
+func BlameFile(rootPath, file string) (map[int]LineBlame, error) {
+	if !IsGitRepo(rootPath) {
+		return map[int]LineBlame{}, nil
+	}
+	cmd, cancel := gitCommand(rootPath, "blame", "--line-porcelain", "--", file)
+	defer cancel()
+	output, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, gitTimeoutError("blame", err, nil)
+		}
+		return map[int]LineBlame{}, nil
+	}
+	return parseBlame(string(output)), nil
+}
+
+// parseBlame reads git blame --line-porcelain output: for each line, a
+// header with the commit and the original and final line numbers, the
+// commit's author fields, and the line's content after a tab. Lines not
+// committed yet are blamed on the all-zero commit and left out.
+func parseBlame(output string) map[int]LineBlame {
+	blame := make(map[int]LineBlame)
+	var current LineBlame
+	line := 0
+	for _, text := range strings.Split(output, "\n") {
+		if strings.HasPrefix(text, "\t") {
+			if line > 0 && strings.Trim(current.Commit, "0") != "" {
+				blame[line] = current
+			}
+			line = 0
+			continue
+		}
+		key, value, _ := strings.Cut(text, " ")
+		switch key {
+		case "author":
+			current.Author = value
+		case "author-mail":
+			current.Email = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "author-time":
+			if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+				current.Date = time.Unix(secs, 0).UTC()
+			}
+		default:
+			if line != 0 || !isCommitHash(key) {
+				continue
+			}
+			fields := strings.Fields(value)
+			if len(fields) < 2 {
+				continue
+			}
+			if n, err := strconv.Atoi(fields[1]); err == nil {
+				current = LineBlame{Commit: key}
+				line = n
+			}
+		}
+	}
+	return blame
+}
+
+// isCommitHash reports whether s is a full SHA-1 or SHA-256 hex hash.
+func isCommitHash(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	return strings.Trim(s, "0123456789abcdef") == ""
+}
@@ -0,0 +1,78 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBlame(t *testing.T) {
+	t.Parallel()
+	commit := strings.Repeat("ab", 20)
+	output := commit + " 1 1 2\n" +
+		"author Alice\nauthor-mail <alice@x.com>\nauthor-time 1772445600\nauthor-tz +0000\nsummary first\nfilename a.md\n\t---\n" +
+		commit + " 2 2\n" +
+		"author Alice\nauthor-mail <alice@x.com>\nauthor-time 1772445600\nauthor-tz +0000\nsummary first\nfilename a.md\n\tname: a\n" +
+		strings.Repeat("0", 40) + " 3 3 1\n" +
+		"author Not Committed Yet\nauthor-mail <not.committed.yet>\nauthor-time 1772449200\nfilename a.md\n\tnew\n"
+
+	blame := parseBlame(output)
+	if len(blame) != 2 {
+		t.Fatalf("parseBlame() = %v, want lines 1 and 2 without the uncommitted line", blame)
+	}
+	want := LineBlame{Commit: commit, Author: "Alice", Email: "alice@x.com", Date: time.Unix(1772445600, 0).UTC()}
+	if got := blame[2]; got != want {
+		t.Errorf("blame[2] = %+v, want %+v", got, want)
+	}
+}
+
+func TestBlameFile(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE=2026-03-02T10:00:00Z", "GIT_COMMITTER_DATE=2026-03-02T10:00:00Z")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+	if err := exec.Command("git", "--version").Run(); err != nil {
+		t.Skip("git not available, skipping integration test")
+	}
+	run("init", "-q")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test User")
+
+	file := filepath.Join(tmpDir, "a.md")
+	if err := os.WriteFile(file, []byte("one\ntwo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if blame, err := BlameFile(tmpDir, "a.md"); err != nil || len(blame) != 0 {
+		t.Errorf("BlameFile() of an untracked file = %v, %v; want no lines", blame, err)
+	}
+	run("add", ".")
+	run("commit", "-q", "-m", "add a")
+	if err := os.WriteFile(file, []byte("one\ntwo\nthree\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	blame, err := BlameFile(tmpDir, file)
+	if err != nil {
+		t.Fatalf("BlameFile() error = %v", err)
+	}
+	if len(blame) != 2 {
+		t.Fatalf("BlameFile() = %v, want the 2 committed lines", blame)
+	}
+	if b := blame[2]; b.Author != "Test User" || b.Email != "test@test.com" || !b.Date.Equal(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("blame[2] = %+v, want Test User's commit of 2026-03-02", b)
+	}
+
+	if blame, err := BlameFile(t.TempDir(), "a.md"); err != nil || len(blame) != 0 {
+		t.Errorf("BlameFile() outside a repository = %v, %v; want no lines", blame, err)
+	}
+}
@@ -109,6 +109,49 @@ func GetChangedFiles(rootPath string) ([]string, error) {
 	return filterRelevantFiles(combineGitOutputs(string(output), untracked), rootPath)
 }
 
+// GetChangedFilesSince returns absolute paths of the component files that
+// differ from base: changes committed since HEAD and base diverged, plus
+// uncommitted and untracked changes. Unlike GetChangedFiles, deleted files
+// are included, since removing a component can break the rest of its
+// project. Paths are relative to rootPath before being made absolute, so
+// rootPath need not be the top of the repository. An empty base means
+// HEAD. Returns empty slice if not in a git repository.
+func GetChangedFilesSince(rootPath, base string) ([]string, error) {
+	if !IsGitRepo(rootPath) {
+		return []string{}, nil
+	}
+	if base == "" {
+		base = "HEAD"
+	}
+
+	mbCmd, cancelMB := gitCommand(rootPath, "merge-base", base, "HEAD")
+	mergeBase, err := mbCmd.CombinedOutput()
+	cancelMB()
+	if err != nil {
+		return nil, gitTimeoutError("merge-base "+base, err, mergeBase)
+	}
+
+	cmd, cancel := gitCommand(rootPath, "diff", "--name-only", "--relative", strings.TrimSpace(string(mergeBase)))
+	defer cancel()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, gitTimeoutError("diff "+base, err, output)
+	}
+
+	untracked, err := getUntrackedFiles(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, line := range strings.Split(combineGitOutputs(string(output), untracked), "\n") {
+		if line != "" && isRelevantFile(line) {
+			files = append(files, filepath.Join(rootPath, line))
+		}
+	}
+	return files, nil
+}
+
 // IsGitRepo checks if the given directory is within a git repository.
 func IsGitRepo(rootPath string) bool {
 	cmd, cancel := gitCommand(rootPath, "rev-parse", "--git-dir")
@@ -613,3 +613,64 @@ func TestFilterRelevantFiles_WhitespaceHandling(t *testing.T) {
 		t.Errorf("expected 1 file, got %d", len(filtered))
 	}
 }
+
+func TestGetChangedFilesSince(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.email=test@test.com", "-c", "user.name=Test User"}, args...)...)
+		cmd.Dir = tmpDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v failed, skipping integration test: %v: %s", args, err, out)
+		}
+	}
+	write := func(rel string) {
+		path := filepath.Join(tmpDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(rel), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	write("pkg/a/agents/kept.md")
+	write("pkg/a/agents/removed.md")
+	run("add", ".")
+	run("commit", "-qm", "initial")
+	run("checkout", "-qb", "feature")
+	write("pkg/b/agents/new.md")
+	run("add", ".")
+	run("commit", "-qm", "feature")
+	run("rm", "-q", "pkg/a/agents/removed.md")
+	write("pkg/c/CLAUDE.md")
+	write("pkg/c/notes.txt")
+
+	files, err := GetChangedFilesSince(tmpDir, "main")
+	if err != nil {
+		t.Fatalf("GetChangedFilesSince() error = %v", err)
+	}
+	want := map[string]bool{
+		filepath.Join(tmpDir, "pkg/b/agents/new.md"):     true, // committed on the branch
+		filepath.Join(tmpDir, "pkg/a/agents/removed.md"): true, // deleted, not committed
+		filepath.Join(tmpDir, "pkg/c/CLAUDE.md"):         true, // untracked
+	}
+	if len(files) != len(want) {
+		t.Fatalf("GetChangedFilesSince() = %v, want %d files", files, len(want))
+	}
+	for _, f := range files {
+		if !want[f] {
+			t.Errorf("unexpected file %s", f)
+		}
+	}
+
+	// Paths stay correct when rootPath is below the top of the repository.
+	files, err = GetChangedFilesSince(filepath.Join(tmpDir, "pkg", "c"), "main")
+	if err != nil {
+		t.Fatalf("GetChangedFilesSince() error = %v", err)
+	}
+	if len(files) != 1 || files[0] != filepath.Join(tmpDir, "pkg/c/CLAUDE.md") {
+		t.Errorf("GetChangedFilesSince(pkg/c) = %v, want only pkg/c/CLAUDE.md", files)
+	}
+}
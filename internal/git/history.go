@@ -0,0 +1,101 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FileChurn is how often one file changed in the commit history read by
+// GetFileChurn. Path is relative to the root path, slash-separated.
+type FileChurn struct {
+	Path        string    `json:"file"`
+	Commits     int       `json:"commits"`
+	Authors     int       `json:"authors"`
+	LastChanged time.Time `json:"lastChanged"`
+}
+
+// GetFileChurn reads the commit history of the files under rootPath and
+// returns, for each file, the number of commits that changed it, the
+// number of distinct author emails, and the date of the latest one. since
+// is passed to git log --since ("90 days ago", "2026-01-01"); empty reads
+// the whole history. Merge commits are not counted, and renames are not
+// followed, so a renamed file's history starts at the rename. Files are
+// sorted by commits, most first, then by path. Returns empty slice if not
+// in a git repository or before the first commit.
+func GetFileChurn(rootPath, since string) ([]FileChurn, error) {
+	if !IsGitRepo(rootPath) {
+		return []FileChurn{}, nil
+	}
+	checkCmd, cancelCheck := gitCommand(rootPath, "rev-parse", "HEAD")
+	checkErr := checkCmd.Run()
+	cancelCheck()
+	if checkErr != nil {
+		if errors.Is(checkErr, context.DeadlineExceeded) {
+			return nil, gitTimeoutError("rev-parse HEAD", checkErr, nil)
+		}
+		return []FileChurn{}, nil
+	}
+
+	args := []string{"-c", "core.quotepath=off", "log", "--relative", "--no-merges", "--name-only", "--format=%x00%ae%x09%cI"}
+	if since != "" {
+		args = append(args, "--since="+since)
+	}
+	cmd, cancel := gitCommand(rootPath, args...)
+	defer cancel()
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, gitTimeoutError("log", err, output)
+	}
+	return parseChurnLog(string(output)), nil
+}
+
+// parseChurnLog counts the commits per file in git log output where each
+// commit is a NUL, its author email and ISO commit date separated by a
+// tab, and the names of the files it changed, one per line.
+func parseChurnLog(output string) []FileChurn {
+	byPath := make(map[string]*FileChurn)
+	authors := make(map[string]map[string]bool)
+	for _, commit := range strings.Split(output, "\x00") {
+		header, files, _ := strings.Cut(commit, "\n")
+		email, date, ok := strings.Cut(header, "\t")
+		if !ok {
+			continue
+		}
+		when, _ := time.Parse(time.RFC3339, strings.TrimSpace(date))
+		for _, file := range strings.Split(files, "\n") {
+			file = strings.TrimSpace(file)
+			if file == "" {
+				continue
+			}
+			fc, ok := byPath[file]
+			if !ok {
+				fc = &FileChurn{Path: file}
+				byPath[file] = fc
+				authors[file] = make(map[string]bool)
+			}
+			fc.Commits++
+			if !authors[file][email] {
+				authors[file][email] = true
+				fc.Authors++
+			}
+			if when.After(fc.LastChanged) {
+				fc.LastChanged = when
+			}
+		}
+	}
+
+	churn := make([]FileChurn, 0, len(byPath))
+	for _, fc := range byPath {
+		churn = append(churn, *fc)
+	}
+	sort.Slice(churn, func(i, j int) bool {
+		if churn[i].Commits != churn[j].Commits {
+			return churn[i].Commits > churn[j].Commits
+		}
+		return churn[i].Path < churn[j].Path
+	})
+	return churn
+}
@@ -0,0 +1,90 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseChurnLog(t *testing.T) {
+	t.Parallel()
+	output := "\x00a@x.com\t2026-03-02T10:00:00+00:00\n\nagents/a.md\nCLAUDE.md\n" +
+		"\x00b@x.com\t2026-03-01T10:00:00+00:00\n\nagents/a.md\n" +
+		"\x00a@x.com\t2026-02-01T10:00:00+00:00\n\nagents/a.md\ncommands/c.md\n"
+
+	churn := parseChurnLog(output)
+	if len(churn) != 3 {
+		t.Fatalf("parseChurnLog() = %v, want 3 files", churn)
+	}
+	want := FileChurn{Path: "agents/a.md", Commits: 3, Authors: 2, LastChanged: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)}
+	if got := churn[0]; got.Path != want.Path || got.Commits != want.Commits || got.Authors != want.Authors || !got.LastChanged.Equal(want.LastChanged) {
+		t.Errorf("churn[0] = %+v, want %+v", got, want)
+	}
+	if churn[1].Path != "CLAUDE.md" || churn[2].Path != "commands/c.md" {
+		t.Errorf("files with one commit = %s, %s; want them sorted by path", churn[1].Path, churn[2].Path)
+	}
+}
+
+func TestGetFileChurn(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	commitDate := ""
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+commitDate, "GIT_COMMITTER_DATE="+commitDate)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+	if err := exec.Command("git", "--version").Run(); err != nil {
+		t.Skip("git not available, skipping integration test")
+	}
+	run("init", "-q")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test User")
+
+	churn, err := GetFileChurn(tmpDir, "")
+	if err != nil || len(churn) != 0 {
+		t.Fatalf("GetFileChurn() before the first commit = %v, %v; want no files", churn, err)
+	}
+
+	sub := filepath.Join(tmpDir, "project", "agents")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i, contents := range []string{"one", "two", "three"} {
+		if err := os.WriteFile(filepath.Join(sub, "a.md"), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			if err := os.WriteFile(filepath.Join(tmpDir, "README.md"), []byte("outside"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		commitDate = fmt.Sprintf("2026-0%d-01T12:00:00Z", i+1)
+		run("add", ".")
+		run("commit", "-q", "-m", contents)
+	}
+
+	churn, err = GetFileChurn(filepath.Join(tmpDir, "project"), "")
+	if err != nil {
+		t.Fatalf("GetFileChurn() error = %v", err)
+	}
+	if len(churn) != 1 || churn[0].Path != "agents/a.md" || churn[0].Commits != 3 || churn[0].Authors != 1 {
+		t.Errorf("GetFileChurn() = %+v, want agents/a.md with 3 commits by 1 author, paths relative to the root", churn)
+	}
+
+	churn, err = GetFileChurn(tmpDir, "2026-02-15")
+	if err != nil || len(churn) != 1 || churn[0].Commits != 1 {
+		t.Errorf("GetFileChurn() since 2026-02-15 = %+v, %v; want only the last commit", churn, err)
+	}
+
+	if churn, err := GetFileChurn(t.TempDir(), ""); err != nil || len(churn) != 0 {
+		t.Errorf("GetFileChurn() outside a repository = %v, %v; want no files", churn, err)
+	}
+}
@@ -83,7 +83,7 @@ func TestValidateAgentBestPractices(t *testing.T) {
 }
 
 func TestAgentLinterPostProcessBatch(t *testing.T) {
-	linter := NewAgentLinter()
+	linter := NewAgentLinter("", nil)
 
 	tests := []struct {
 		name             string
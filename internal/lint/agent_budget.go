@@ -0,0 +1,83 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// defaultAgentContextBudgets are the estimated token budgets for an agent's
+// body plus preloaded skills, keyed by model tier. Smaller models get less
+// headroom because a large system prompt crowds out their working context.
+var defaultAgentContextBudgets = map[string]int{
+	"haiku":   8000,
+	"sonnet":  16000,
+	"opus":    24000,
+	"default": 16000,
+}
+
+// agentContextBudget returns the budget of tier: its entry in overrides,
+// the rules.contextBudgets config block, or else the default.
+func agentContextBudget(overrides map[string]int, tier string) int {
+	if budget, ok := overrides[tier]; ok {
+		return budget
+	}
+	return defaultAgentContextBudgets[tier]
+}
+
+// modelTier maps an agent model value to a budget tier.
+func modelTier(model string) string {
+	m := strings.ToLower(model)
+	for _, tier := range []string{"haiku", "sonnet", "opus"} {
+		if strings.Contains(m, tier) {
+			return tier
+		}
+	}
+	return "default"
+}
+
+// validateAgentContextBudget warns when an agent's body plus the skills it
+// preloads via the frontmatter skills array exceeds the estimated token
+// budget for its model tier, with budgets overriding the defaults. Skills
+// that cannot be resolved are skipped; the cross-file validator reports
+// them separately.
+func validateAgentContextBudget(crossValidator *crossfile.CrossFileValidator, budgets map[string]int, data map[string]any, filePath, contents string) []cue.ValidationError {
+	total := textutil.EstimateTokens(extractBody(contents))
+
+	var loaded []string
+	if skills, ok := data["skills"].([]any); ok && crossValidator != nil {
+		for _, item := range skills {
+			name, ok := item.(string)
+			if !ok {
+				continue
+			}
+			if skillContents, found := crossValidator.SkillContents(name); found {
+				total += textutil.EstimateTokens(skillContents)
+				loaded = append(loaded, name)
+			}
+		}
+	}
+
+	model, _ := data["model"].(string)
+	tier := modelTier(model)
+	budget := agentContextBudget(budgets, tier)
+	if total <= budget {
+		return nil
+	}
+
+	detail := "agent body"
+	if len(loaded) > 0 {
+		detail = fmt.Sprintf("agent body plus preloaded skills (%s)", strings.Join(loaded, ", "))
+	}
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  fmt.Sprintf("Estimated context of ~%d tokens for %s exceeds the %s budget of %d. Move reference material into on-demand skills or raise rules.contextBudgets.%s.", total, detail, tier, budget, tier),
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleAgentContextBudget,
+		Line:     textutil.FindFrontmatterFieldLine(contents, "skills"),
+	}}
+}
@@ -0,0 +1,118 @@
+package lint
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestModelTier(t *testing.T) {
+	tests := []struct {
+		model string
+		want  string
+	}{
+		{"haiku", "haiku"},
+		{"claude-sonnet-4-5", "sonnet"},
+		{"Opus", "opus"},
+		{"inherit", "default"},
+		{"", "default"},
+	}
+	for _, tt := range tests {
+		if got := modelTier(tt.model); got != tt.want {
+			t.Errorf("modelTier(%q) = %q, want %q", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestValidateAgentContextBudget(t *testing.T) {
+	budgets := map[string]int{"haiku": 100, "sonnet": 1000}
+	bigSkill := "---\nname: big\ndescription: d\n---\n" + strings.Repeat("reference ", 200)
+	cv := crossfile.NewCrossFileValidator([]discovery.File{
+		{RelPath: "skills/big/SKILL.md", Type: discovery.FileTypeSkill, Contents: bigSkill},
+	})
+
+	tests := []struct {
+		name     string
+		data     map[string]any
+		body     string
+		wantWarn bool
+	}{
+		{
+			name: "small body within budget",
+			data: map[string]any{"model": "haiku"},
+			body: "short body",
+		},
+		{
+			name:     "large body over haiku budget",
+			data:     map[string]any{"model": "haiku"},
+			body:     strings.Repeat("x", 500),
+			wantWarn: true,
+		},
+		{
+			name: "same body fits sonnet budget",
+			data: map[string]any{"model": "sonnet"},
+			body: strings.Repeat("x", 500),
+		},
+		{
+			name:     "preloaded skill pushes over budget",
+			data:     map[string]any{"model": "haiku", "skills": []any{"big"}},
+			body:     "short body",
+			wantWarn: true,
+		},
+		{
+			name: "unknown skill is skipped",
+			data: map[string]any{"model": "haiku", "skills": []any{"missing"}},
+			body: "short body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents := "---\nname: test\nskills: []\n---\n" + tt.body
+			got := validateAgentContextBudget(cv, budgets, tt.data, "agents/test.md", contents)
+			if (len(got) > 0) != tt.wantWarn {
+				t.Fatalf("validateAgentContextBudget() = %v, wantWarn %v", got, tt.wantWarn)
+			}
+			if tt.wantWarn {
+				if got[0].Rule != cue.RuleAgentContextBudget {
+					t.Errorf("rule = %q, want %q", got[0].Rule, cue.RuleAgentContextBudget)
+				}
+				if got[0].Line != 3 {
+					t.Errorf("line = %d, want 3", got[0].Line)
+				}
+			}
+		})
+	}
+}
+
+func TestAgentContextBudget(t *testing.T) {
+	overrides := map[string]int{"opus": 50000}
+
+	if got := agentContextBudget(overrides, "opus"); got != 50000 {
+		t.Errorf("opus budget = %d, want 50000", got)
+	}
+	if got := agentContextBudget(overrides, "haiku"); got != defaultAgentContextBudgets["haiku"] {
+		t.Errorf("haiku budget = %d, want default %d", got, defaultAgentContextBudgets["haiku"])
+	}
+}
+
+func TestAgentLinter_ContextBudgetFromConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.Rules.ContextBudgets = map[string]int{"haiku": 10}
+	cv := crossfile.NewCrossFileValidator(nil)
+	data := map[string]any{"model": "haiku"}
+	contents := "---\nname: test\nmodel: haiku\n---\n" + strings.Repeat("x", 200)
+	overBudget := func(issue cue.ValidationError) bool { return issue.Rule == cue.RuleAgentContextBudget }
+
+	if got := NewAgentLinter("", nil).ValidateCrossFile(cv, "agents/test.md", contents, data); slices.ContainsFunc(got, overBudget) {
+		t.Errorf("default budgets: got %v, want no %s", got, cue.RuleAgentContextBudget)
+	}
+	if got := NewAgentLinter("", cfg).ValidateCrossFile(cv, "agents/test.md", contents, data); !slices.ContainsFunc(got, overBudget) {
+		t.Errorf("configured budgets: got %v, want %s", got, cue.RuleAgentContextBudget)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/textutil"
 )
@@ -32,12 +33,14 @@ var validModes = map[string]bool{
 }
 
 // validateUnknownFields checks for unsupported frontmatter fields.
-func validateUnknownFields(data map[string]any, filePath, contents string) []cue.ValidationError {
-	return checkUnknownFields(data, filePath, contents, unknownFieldCheck{
-		known:    knownAgentFields,
-		label:    "frontmatter field",
-		suffix:   ". Valid fields: " + sortedMapKeys(knownAgentFields),
-		findLine: textutil.FindFrontmatterFieldLine,
+func validateUnknownFields(cfg *config.Config, data map[string]any, filePath, contents string) []cue.ValidationError {
+	return checkUnknownFields(cfg, data, filePath, contents, unknownFieldCheck{
+		known:     knownAgentFields,
+		label:     "frontmatter field",
+		suffix:    ". Valid fields: " + sortedMapKeys(knownAgentFields),
+		rule:      cue.RuleFrontmatterUnknownKey,
+		findLine:  textutil.FindFrontmatterFieldLine,
+		component: cue.TypeAgent,
 	})
 }
 
@@ -51,6 +54,7 @@ func validateRequiredFields(data map[string]any, filePath, contents string) []cu
 			Message:  "Required field 'name' is missing or empty",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldRequired,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
 		})
 	} else {
@@ -63,6 +67,7 @@ func validateRequiredFields(data map[string]any, filePath, contents string) []cu
 			Message:  "Required field 'description' is missing or empty",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldRequired,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "description"),
 		})
 	} else if !strings.Contains(strings.ToUpper(description), "PROACTIVELY") {
@@ -71,6 +76,7 @@ func validateRequiredFields(data map[string]any, filePath, contents string) []cu
 			Message:  "Consider adding 'Use PROACTIVELY when...' pattern in description for agent discoverability",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleAgentDescriptionProactive,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "description"),
 		})
 	}
@@ -94,6 +100,7 @@ func validateAgentColor(data map[string]any, filePath string) []cue.ValidationEr
 		Message:  fmt.Sprintf("Invalid color '%s'. Valid colors are: red, blue, green, yellow, purple, orange, pink, cyan, gray, magenta, white", color),
 		Severity: cue.SeveritySuggestion,
 		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleFieldInvalid,
 	}}
 }
 
@@ -113,17 +120,23 @@ func validateAgentMemory(data map[string]any, filePath, contents string) []cue.V
 		Message:  fmt.Sprintf("Invalid memory scope '%s'. Valid scopes: user, project, local", memory),
 		Severity: cue.SeverityError,
 		Source:   cue.SourceAnthropicDocs,
+		Rule:     cue.RuleFieldInvalid,
 		Line:     textutil.FindFrontmatterFieldLine(contents, "memory"),
 	}}
 }
 
-// validateAgentModel validates the model field.
-func validateAgentModel(data map[string]any, filePath, contents string) []cue.ValidationError {
+// validateAgentModel validates the model field against catalog.
+func validateAgentModel(catalog cue.ModelCatalog, data map[string]any, filePath, contents string) []cue.ValidationError {
 	model, ok := data["model"].(string)
 	if !ok {
 		return nil
 	}
 
+	// Catalog entries get a lifecycle warning (or none, for IDs the config
+	// declares current) instead of the unknown-model warning.
+	if _, known := catalog.Lookup(model); known {
+		return checkModelCatalog(catalog, data, filePath, contents)
+	}
 	if validModelPattern.MatchString(model) {
 		return nil
 	}
@@ -133,6 +146,7 @@ func validateAgentModel(data map[string]any, filePath, contents string) []cue.Va
 		Message:  fmt.Sprintf("Unknown model %q. Valid models: haiku, sonnet, opus, fable, best, inherit, opusplan (with optional version suffix like sonnet[1m]), or full model ID (claude-*)", model),
 		Severity: cue.SeverityWarning,
 		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleModelUnknown,
 		Line:     textutil.FindFrontmatterFieldLine(contents, "model"),
 	}}
 }
@@ -155,6 +169,7 @@ func validateAgentMCPServers(mcpServers any, filePath, contents string) []cue.Va
 			Message:  "mcpServers must be an array of server name strings",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldInvalid,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "mcpServers"),
 		}}
 	}
@@ -168,6 +183,7 @@ func validateAgentMCPServers(mcpServers any, filePath, contents string) []cue.Va
 				Message:  fmt.Sprintf("mcpServers[%d] must be a non-empty string", i),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleFieldInvalid,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "mcpServers"),
 			})
 		}
@@ -191,6 +207,7 @@ func validateAgentPermissionMode(data map[string]any, filePath, contents string)
 		Message:  fmt.Sprintf("Invalid permissionMode value %q; must be one of: default, acceptEdits, delegate, dontAsk, bypassPermissions, plan", permMode),
 		Severity: cue.SeverityError,
 		Source:   cue.SourceAnthropicDocs,
+		Rule:     cue.RuleFieldInvalid,
 		Line:     textutil.FindFrontmatterFieldLine(contents, "permissionMode"),
 	}}
 }
@@ -212,6 +229,7 @@ func validateAgentMaxTurns(data map[string]any, filePath, contents string) []cue
 			Message:  fmt.Sprintf("Invalid maxTurns value %d; must be a positive integer", v),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldInvalid,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "maxTurns"),
 		}}
 	case float64:
@@ -223,6 +241,7 @@ func validateAgentMaxTurns(data map[string]any, filePath, contents string) []cue
 			Message:  fmt.Sprintf("Invalid maxTurns value %v; must be a positive integer", v),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldInvalid,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "maxTurns"),
 		}}
 	default:
@@ -231,6 +250,7 @@ func validateAgentMaxTurns(data map[string]any, filePath, contents string) []cue
 			Message:  fmt.Sprintf("Invalid maxTurns value %v; must be a positive integer", maxTurns),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldInvalid,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "maxTurns"),
 		}}
 	}
@@ -253,6 +273,7 @@ func validateAgentAutonomousPattern(data map[string]any, filePath, contents stri
 		Message:  "Agent uses maxTurns with permissionMode 'dontAsk' - this is a common pattern for autonomous sub-agents.",
 		Severity: cue.SeverityInfo,
 		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleAgentAutonomous,
 		Line:     textutil.FindFrontmatterFieldLine(contents, "maxTurns"),
 	}}
 }
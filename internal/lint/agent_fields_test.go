@@ -117,7 +117,7 @@ func TestValidateAgentSpecific(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := validateAgentSpecific(tt.data, tt.filePath, tt.contents)
+			errors := validateAgentSpecific(defaultConfig(), tt.data, tt.filePath, tt.contents)
 
 			errCount := 0
 			suggCount := 0
@@ -1,14 +1,15 @@
 package lint
 
 import (
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 )
 
 // validateAgentHooks validates the hooks field.
-func validateAgentHooks(data map[string]any, filePath string) []cue.ValidationError {
+func validateAgentHooks(cfg *config.Config, data map[string]any, filePath string) []cue.ValidationError {
 	hooks, ok := data["hooks"]
 	if !ok {
 		return nil
 	}
-	return ValidateComponentHooks(hooks, filePath)
+	return ValidateComponentHooks(hooks, filePath, cfg)
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
@@ -16,6 +17,9 @@ import (
 // scoring, improvements, and batch post-processing (cycle detection).
 type AgentLinter struct {
 	BaseLinter
+	// RootPath is the project root directory, used to resolve hook
+	// scripts. Empty string disables that filesystem check.
+	RootPath string
 }
 
 // Compile-time interface compliance checks
@@ -28,8 +32,11 @@ var (
 )
 
 // NewAgentLinter creates a new AgentLinter.
-func NewAgentLinter() *AgentLinter {
-	return &AgentLinter{}
+// rootPath is the project root for resolving hook scripts. Pass empty
+// string to skip filesystem checks.
+// cfg supplies the rule settings; nil uses the defaults.
+func NewAgentLinter(rootPath string, cfg *config.Config) *AgentLinter {
+	return &AgentLinter{BaseLinter: BaseLinter{cfg: cfg}, RootPath: rootPath}
 }
 
 func (l *AgentLinter) Type() string {
@@ -49,13 +56,8 @@ func (l *AgentLinter) ValidateCUE(validator *cue.Validator, data map[string]any)
 }
 
 func (l *AgentLinter) ValidateSpecific(data map[string]any, filePath, contents string) []cue.ValidationError {
-	errors := validateAgentSpecific(data, filePath, contents)
-
-	// Validate allowed-tools
-	toolWarnings := textutil.ValidateAllowedTools(data, filePath, contents)
-	errors = append(errors, toolWarnings...)
-
-	return errors
+	errors := validateAgentSpecific(l.Config(), data, filePath, contents)
+	return append(errors, validateHookToolCoverage(l.Config().ExtraTools, data, "tools", l.RootPath, filePath, contents)...)
 }
 
 // ValidateCrossFile implements CrossFileValidatable interface
@@ -63,7 +65,9 @@ func (l *AgentLinter) ValidateCrossFile(crossValidator *crossfile.CrossFileValid
 	if crossValidator == nil {
 		return nil
 	}
-	return crossValidator.ValidateAgent(filePath, contents, data)
+	errors := crossValidator.ValidateAgent(filePath, contents, data)
+	errors = append(errors, validateAgentContextBudget(crossValidator, l.Config().Rules.ContextBudgets, data, filePath, contents)...)
+	return append(errors, validateAgentSkillSync(crossValidator, data, filePath, contents)...)
 }
 
 // Score implements Scorable interface
@@ -78,8 +82,13 @@ func (l *AgentLinter) GetImprovements(contents string, data map[string]any) []te
 	return textutil.GetAgentImprovements(contents, data)
 }
 
-// PostProcessBatch implements BatchPostProcessor for cycle detection.
+// PostProcessBatch implements BatchPostProcessor for cycle detection and
+// shared-memory conflict detection.
 func (l *AgentLinter) PostProcessBatch(ctx *LinterContext, summary *LintSummary) {
+	for relPath, issues := range findMemoryConflicts(ctx.Files) {
+		addWarningsToSummary(summary, relPath, issues)
+	}
+
 	if !ctx.NoCycleCheck {
 		cycles := ctx.CrossValidator.DetectCycles()
 		cyclesReported := make(map[string]bool)
@@ -97,6 +106,17 @@ func (l *AgentLinter) PostProcessBatch(ctx *LinterContext, summary *LintSummary)
 	}
 }
 
+// addWarningsToSummary appends warnings to the result for file.
+func addWarningsToSummary(summary *LintSummary, file string, warnings []cue.ValidationError) {
+	for i := range summary.Results {
+		if summary.Results[i].File == file {
+			summary.Results[i].Warnings = append(summary.Results[i].Warnings, warnings...)
+			summary.TotalWarnings += len(warnings)
+			return
+		}
+	}
+}
+
 // reportCycleError reports a cycle error to all agents involved in the cycle.
 func (l *AgentLinter) reportCycleError(summary *LintSummary, cycle crossfile.Cycle, cycleDesc string) {
 	agentsInCycle := extractAgentsFromCycle(cycle.Path)
@@ -128,6 +148,7 @@ func addCycleToSummary(summary *LintSummary, agentName, cycleDesc string) {
 				Message:  fmt.Sprintf("Circular dependency detected: %s", cycleDesc),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentDependencyCycle,
 			})
 			summary.TotalErrors++
 			if summary.Results[i].Success {
@@ -63,7 +63,7 @@ func TestValidateAgentMaxTurnsDontAskInfo(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			contents := "---\nname: test\ndescription: test. Use PROACTIVELY when testing.\n---\n"
-			errors := validateAgentSpecific(tt.data, "agents/test.md", contents)
+			errors := validateAgentSpecific(defaultConfig(), tt.data, "agents/test.md", contents)
 
 			foundInfo := false
 			for _, e := range errors {
@@ -0,0 +1,119 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+// memoryDirectivePattern matches an imperative about an action, e.g.
+// "always record", "never write", "do not update". Negative forms come first
+// so "must not" is not read as "must".
+var memoryDirectivePattern = regexp.MustCompile(`(?i)\b(must not|do not|don't|never|always|must)\s+([a-z]+)`)
+
+// memoryDirectives extracts the actions an agent body prescribes (true) or
+// forbids (false) on lines that mention memory. Actions the body both
+// prescribes and forbids are dropped as ambiguous.
+func memoryDirectives(body string) map[string]bool {
+	directives := make(map[string]bool)
+	ambiguous := make(map[string]bool)
+	for _, line := range strings.Split(body, "\n") {
+		if !strings.Contains(strings.ToLower(line), "memory") {
+			continue
+		}
+		for _, m := range memoryDirectivePattern.FindAllStringSubmatch(line, -1) {
+			verb := strings.ToLower(m[2])
+			positive := strings.EqualFold(m[1], "always") || strings.EqualFold(m[1], "must")
+			if prev, seen := directives[verb]; seen && prev != positive {
+				ambiguous[verb] = true
+			}
+			directives[verb] = positive
+		}
+	}
+	for verb := range ambiguous {
+		delete(directives, verb)
+	}
+	return directives
+}
+
+// memoryAgent is an agent that declares a memory scope.
+type memoryAgent struct {
+	relPath    string
+	name       string
+	directives map[string]bool
+}
+
+// findMemoryConflicts groups agents by declared memory scope and reports,
+// per agent file, warnings for every other agent in the same scope whose
+// memory instructions contradict it (one says "always update", the other
+// "never update").
+func findMemoryConflicts(files []discovery.File) map[string][]cue.ValidationError {
+	byScope := make(map[string][]memoryAgent)
+	for _, f := range files {
+		if f.Type != discovery.FileTypeAgent {
+			continue
+		}
+		contents, err := f.Load()
+		if err != nil {
+			continue
+		}
+		data, body, err := parseFrontmatter(contents)
+		if err != nil || crossfile.IsDisabled(data) {
+			continue
+		}
+		scope, ok := data["memory"].(string)
+		if !ok || !validScopes[scope] {
+			continue
+		}
+		name, _ := data["name"].(string)
+		if name == "" {
+			name = extractBaseFilename(f.RelPath)
+		}
+		byScope[scope] = append(byScope[scope], memoryAgent{
+			relPath:    f.RelPath,
+			name:       name,
+			directives: memoryDirectives(body),
+		})
+	}
+
+	issues := make(map[string][]cue.ValidationError)
+	for scope, agents := range byScope {
+		for i, a := range agents {
+			for j, b := range agents {
+				if i == j {
+					continue
+				}
+				verbs := conflictingVerbs(a.directives, b.directives)
+				if len(verbs) == 0 {
+					continue
+				}
+				issues[a.relPath] = append(issues[a.relPath], cue.ValidationError{
+					File:     a.relPath,
+					Message:  fmt.Sprintf("Shares %s memory scope with agent '%s' but their memory instructions conflict on: %s", scope, b.name, strings.Join(verbs, ", ")),
+					Severity: cue.SeverityWarning,
+					Source:   cue.SourceCClintObserve,
+					Rule:     cue.RuleAgentMemoryConflict,
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// conflictingVerbs returns the sorted actions that a prescribes and b
+// forbids, or vice versa.
+func conflictingVerbs(a, b map[string]bool) []string {
+	var verbs []string
+	for verb, positive := range a {
+		if other, ok := b[verb]; ok && other != positive {
+			verbs = append(verbs, verb)
+		}
+	}
+	sort.Strings(verbs)
+	return verbs
+}
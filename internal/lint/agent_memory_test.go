@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestMemoryDirectives(t *testing.T) {
+	body := `Always update memory after each task.
+Never delete memory entries.
+Always read the code first.
+Do not update memory twice. Always update memory once.`
+
+	got := memoryDirectives(body)
+	want := map[string]bool{"delete": false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("memoryDirectives() = %v, want %v", got, want)
+	}
+}
+
+func TestFindMemoryConflicts(t *testing.T) {
+	agent := func(name, memory, body string) discovery.File {
+		return discovery.File{
+			RelPath:  "agents/" + name + ".md",
+			Type:     discovery.FileTypeAgent,
+			Contents: "---\nname: " + name + "\ndescription: d\nmemory: " + memory + "\n---\n" + body,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		files []discovery.File
+		want  map[string]int
+	}{
+		{
+			name: "conflict in shared scope",
+			files: []discovery.File{
+				agent("writer", "project", "Always update memory with findings."),
+				agent("reader", "project", "Never update memory; only read it."),
+			},
+			want: map[string]int{"agents/writer.md": 1, "agents/reader.md": 1},
+		},
+		{
+			name: "different scopes do not conflict",
+			files: []discovery.File{
+				agent("writer", "project", "Always update memory with findings."),
+				agent("reader", "user", "Never update memory; only read it."),
+			},
+			want: map[string]int{},
+		},
+		{
+			name: "compatible instructions",
+			files: []discovery.File{
+				agent("a", "project", "Always update memory."),
+				agent("b", "project", "Always update memory too."),
+			},
+			want: map[string]int{},
+		},
+		{
+			name: "invalid scope ignored",
+			files: []discovery.File{
+				agent("a", "global", "Always update memory."),
+				agent("b", "global", "Never update memory."),
+			},
+			want: map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findMemoryConflicts(tt.files)
+			if len(got) != len(tt.want) {
+				t.Fatalf("findMemoryConflicts() = %v, want files %v", got, tt.want)
+			}
+			for file, n := range tt.want {
+				if len(got[file]) != n {
+					t.Errorf("%s: got %d issues, want %d", file, len(got[file]), n)
+				}
+				for _, issue := range got[file] {
+					if issue.Rule != cue.RuleAgentMemoryConflict {
+						t.Errorf("%s: rule = %q, want %q", file, issue.Rule, cue.RuleAgentMemoryConflict)
+					}
+				}
+			}
+		})
+	}
+}
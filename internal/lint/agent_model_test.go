@@ -141,7 +141,7 @@ func TestValidateAgentSpecificModelFields(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := validateAgentSpecific(tt.data, tt.filePath, tt.contents)
+			errors := validateAgentSpecific(defaultConfig(), tt.data, tt.filePath, tt.contents)
 
 			errCount := 0
 			suggCount := 0
@@ -207,7 +207,7 @@ func TestValidateAgentModelValues(t *testing.T) {
 			}
 			contents := "---\nname: test\ndescription: test. Use PROACTIVELY when testing.\nmodel: " + tt.model + "\n---\n"
 
-			errors := validateAgentSpecific(data, "agents/test.md", contents)
+			errors := validateAgentSpecific(defaultConfig(), data, "agents/test.md", contents)
 
 			warnings := 0
 			for _, e := range errors {
@@ -24,6 +24,7 @@ func validateAgentName(name, filePath, contents string) []cue.ValidationError {
 			Message:  "Name must contain only lowercase letters, numbers, and hyphens",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleNameFormat,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
 		})
 	}
@@ -35,6 +36,7 @@ func validateAgentName(name, filePath, contents string) []cue.ValidationError {
 			Message:  fmt.Sprintf("Name '%s' is a reserved word and cannot be used", name),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleNameReserved,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
 		})
 	}
@@ -47,6 +49,7 @@ func validateAgentName(name, filePath, contents string) []cue.ValidationError {
 			Message:  fmt.Sprintf("Name %q doesn't match filename %q", name, filename),
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleAgentNameMismatch,
 		})
 	}
 
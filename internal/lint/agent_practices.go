@@ -76,6 +76,7 @@ func checkAgentBloatSections(contents, filePath string) []cue.ValidationError {
 				Message:  bp.message,
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentBloatSection,
 			})
 		}
 	}
@@ -92,6 +93,7 @@ func checkAgentInlineMethodology(contents, filePath string) []cue.ValidationErro
 				Message:  ip.message,
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentInlineMethodology,
 			})
 		}
 	}
@@ -110,6 +112,7 @@ func checkAgentMissingFields(data map[string]any, contents, filePath string) []c
 			Message:  "Agent lacks 'model' specification. Consider adding 'model: sonnet' or appropriate model for optimal performance.",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleAgentModelMissing,
 			Line:     fmEndLine,
 		})
 	}
@@ -123,6 +126,7 @@ func checkAgentMissingFields(data map[string]any, contents, filePath string) []c
 			Message:  "No skill reference found. If methodology is reusable, consider extracting to a skill.",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleAgentSkillSuggested,
 			Line:     textutil.FindSectionLine(contents, "Foundation"),
 		})
 	}
@@ -134,6 +138,7 @@ func checkAgentMissingFields(data map[string]any, contents, filePath string) []c
 			Message:  "Agent has editing tools but no permissionMode. Consider 'permissionMode: acceptEdits' for seamless file edits.",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleAgentPermissionModeMissing,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "tools"),
 		})
 	}
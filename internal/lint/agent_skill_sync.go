@@ -0,0 +1,112 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// validateAgentSkillSync keeps an agent's frontmatter skills array and its
+// body in step. A preloaded skill the body never mentions costs context on
+// every run for nothing, and a skill the body invokes by name (Skill: x,
+// Skill(x)) but does not preload is fetched mid-task. The second check only
+// runs when the agent already preloads skills, which signals that auto-load
+// is intended, and only for skills that exist. Both findings carry an
+// autofix (see fixers).
+func validateAgentSkillSync(crossValidator *crossfile.CrossFileValidator, data map[string]any, filePath, contents string) []cue.ValidationError {
+	declared := declaredSkills(data)
+	if len(declared) == 0 {
+		return nil
+	}
+	body := extractBody(contents)
+	bodyStart := textutil.GetFrontmatterEndLine(contents)
+
+	var out []cue.ValidationError
+	for _, name := range declared {
+		if mentionsSkill(body, name) {
+			continue
+		}
+		out = append(out, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("Skill '%s' is preloaded via frontmatter skills but the body never mentions it. Reference it where it applies or remove it from skills", name),
+			Severity: cue.SeveritySuggestion,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleAgentSkillUnreferenced,
+			Line:     skillEntryLine(contents, name),
+		})
+	}
+
+	for _, name := range crossfile.FindSkillReferences(body) {
+		if slices.Contains(declared, name) {
+			continue
+		}
+		if _, exists := crossValidator.SkillContents(name); !exists {
+			continue
+		}
+		line := textutil.FindLineNumber(body, name)
+		if line > 0 && bodyStart > 0 {
+			line += bodyStart - 1
+		}
+		out = append(out, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("Skill '%s' is referenced in the body but missing from frontmatter skills. Add it so it is preloaded with the others", name),
+			Severity: cue.SeveritySuggestion,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleAgentSkillUndeclared,
+			Line:     line,
+		})
+	}
+	return out
+}
+
+// declaredSkills returns the string entries of the frontmatter skills array.
+func declaredSkills(data map[string]any) []string {
+	items, _ := data["skills"].([]any)
+	var names []string
+	for _, item := range items {
+		if name, ok := item.(string); ok && name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// mentionsSkill reports whether body names the skill as a whole word, either
+// verbatim ("code-review") or spelled out ("code review").
+func mentionsSkill(body, name string) bool {
+	words := strings.Split(regexp.QuoteMeta(name), "-")
+	pattern := `(?i)(^|[^a-z0-9-])` + strings.Join(words, `[-_ ]`) + `($|[^a-z0-9-])`
+	return regexp.MustCompile(pattern).MatchString(body)
+}
+
+// skillEntryLine returns the line of a skills array entry: its own line in
+// a block list, otherwise the skills field line.
+func skillEntryLine(contents, name string) int {
+	fieldLine := textutil.FindFrontmatterFieldLine(contents, "skills")
+	if fieldLine == 0 {
+		return 0
+	}
+	lines := strings.Split(contents, "\n")
+	for i := fieldLine; i < len(lines) && isBlockListItem(lines[i]); i++ {
+		if blockListValue(lines[i]) == name {
+			return i + 1
+		}
+	}
+	return fieldLine
+}
+
+// isBlockListItem reports whether line is a "- value" sequence entry.
+func isBlockListItem(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "- ")
+}
+
+// blockListValue returns the unquoted value of a "- value" entry.
+func blockListValue(line string) string {
+	value := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "-"))
+	return strings.Trim(value, `"'`)
+}
@@ -0,0 +1,135 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidateAgentSkillSync(t *testing.T) {
+	cv := crossfile.NewCrossFileValidator([]discovery.File{
+		{RelPath: "skills/code-review/SKILL.md", Type: discovery.FileTypeSkill},
+		{RelPath: "skills/testing/SKILL.md", Type: discovery.FileTypeSkill},
+		{RelPath: "skills/deploy/SKILL.md", Type: discovery.FileTypeSkill},
+	})
+
+	tests := []struct {
+		name     string
+		contents string
+		want     []string // rule:line per finding, in order
+	}{
+		{
+			name:     "declared and mentioned",
+			contents: "---\nname: a\nskills: [code-review]\n---\nFollow the code review checklist.\n",
+		},
+		{
+			name:     "declared but never mentioned",
+			contents: "---\nname: a\nskills:\n  - code-review\n  - testing\n---\nUse the code-review steps.\n",
+			want:     []string{"agent-skill-unreferenced:5"},
+		},
+		{
+			name:     "referenced but not declared",
+			contents: "---\nname: a\nskills: [testing]\n---\nRun testing first.\n\nSkill: deploy\n",
+			want:     []string{"agent-skill-undeclared:7"},
+		},
+		{
+			name:     "no skills array means on-demand loading",
+			contents: "---\nname: a\n---\nSkill: deploy\n",
+		},
+		{
+			name:     "unknown referenced skill is left to cross-file checks",
+			contents: "---\nname: a\nskills: [testing]\n---\ntesting, then Skill: ghost\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _, err := parseFrontmatter(tt.contents)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range validateAgentSkillSync(cv, data, "agents/a.md", tt.contents) {
+				if e.Severity != cue.SeveritySuggestion {
+					t.Errorf("severity = %s, want suggestion", e.Severity)
+				}
+				got = append(got, fmt.Sprintf("%s:%d", e.Rule, e.Line))
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("findings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixFor_SkillsArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		skill    string
+		contents string
+		want     string
+	}{
+		{
+			name:     "remove from flow list",
+			rule:     cue.RuleAgentSkillUnreferenced,
+			skill:    "b",
+			contents: "---\nname: x\nskills: [a, b]\n---\nbody\n",
+			want:     "---\nname: x\nskills: [a]\n---\nbody\n",
+		},
+		{
+			name:     "remove from block list",
+			rule:     cue.RuleAgentSkillUnreferenced,
+			skill:    "a",
+			contents: "---\nskills:\n  - a\n  - b\nmodel: haiku\n---\n",
+			want:     "---\nskills:\n  - b\nmodel: haiku\n---\n",
+		},
+		{
+			name:     "removing last entry drops the field",
+			rule:     cue.RuleAgentSkillUnreferenced,
+			skill:    "a",
+			contents: "---\nname: x\nskills:\n  - a\n---\n",
+			want:     "---\nname: x\n---\n",
+		},
+		{
+			name:     "add to flow list",
+			rule:     cue.RuleAgentSkillUndeclared,
+			skill:    "c",
+			contents: "---\nskills: [a, b]\n---\n",
+			want:     "---\nskills: [a, b, c]\n---\n",
+		},
+		{
+			name:     "add to block list keeps indent",
+			rule:     cue.RuleAgentSkillUndeclared,
+			skill:    "c",
+			contents: "---\nskills:\n    - a\nmodel: haiku\n---\n",
+			want:     "---\nskills:\n    - a\n    - c\nmodel: haiku\n---\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := cue.ValidationError{Rule: tt.rule, Line: 2, Message: fmt.Sprintf("Skill '%s' is out of sync", tt.skill)}
+			fix, ok := FixFor(issue, tt.contents)
+			if !ok {
+				t.Fatal("FixFor() ok = false, want true")
+			}
+			got, err := fix.Apply(tt.contents)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Apply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	already := cue.ValidationError{Rule: cue.RuleAgentSkillUndeclared, Line: 2, Message: "Skill 'a' is missing"}
+	if _, ok := FixFor(already, "---\nskills: [a]\n---\n"); ok {
+		t.Error("FixFor() offered to add a skill that is already declared")
+	}
+}
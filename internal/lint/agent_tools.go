@@ -6,11 +6,9 @@ import (
 	"strings"
 
 	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
 )
 
-// bodyToolNegativePattern matches lines that explicitly disclaim a tool (e.g. "do not use Bash").
-var bodyToolNegativePattern = regexp.MustCompile(`(?i)\b(do not use|don't use|never use|avoid using|not use)\b`)
-
 // countTools returns the number of tool entries in a frontmatter tools value.
 // It handles both a comma-separated string and a []any slice.
 func countTools(tools any) int {
@@ -58,10 +56,10 @@ func validateBodyToolMismatch(data map[string]any, filePath, contents string) []
 		}
 		foundPositive := false
 		for _, line := range lines {
-			if bodyToolNegativePattern.MatchString(line) {
+			if textutil.ToolDisclaimerPattern.MatchString(line) {
 				continue
 			}
-			if containsToolReference(line, toolName) {
+			if textutil.ContainsToolReference(line, toolName) {
 				foundPositive = true
 				break
 			}
@@ -74,6 +72,7 @@ func validateBodyToolMismatch(data map[string]any, filePath, contents string) []
 				Message:  fmt.Sprintf("Tool %q declared in frontmatter but not referenced in agent body — verify the tool is actually used", toolName),
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleAgentToolUnused,
 			})
 		}
 	}
@@ -114,38 +113,127 @@ func extractDeclaredTools(tools any) map[string]bool {
 	return result
 }
 
-// containsToolReference reports whether line contains a reference to toolName
-// using word-boundary logic: the preceding char must not be a letter and the
-// following char must not be a lowercase letter (allows camelCase boundaries
-// like "WebSearch" vs "Search").
-func containsToolReference(line, toolName string) bool {
-	idx := 0
-	for {
-		pos := strings.Index(line[idx:], toolName)
-		if pos < 0 {
-			return false
-		}
-		abs := idx + pos
-
-		// Check preceding character.
-		if abs > 0 {
-			prev := rune(line[abs-1])
-			if (prev >= 'a' && prev <= 'z') || (prev >= 'A' && prev <= 'Z') {
-				idx = abs + 1
+// taskArgPattern matches a single agent name argument inside Task(...) or Agent(...).
+var taskArgPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// restrictiveModes are permissionMode values that block most tool calls,
+// making a wildcard tools grant misleading.
+var restrictiveModes = map[string]bool{"plan": true, "dontAsk": true}
+
+// validateAgentTools validates each entry of the agent tools field against the
+// known tool catalog, checks Task(...) argument syntax, flags duplicate entries,
+// and warns when a wildcard grant is paired with a restrictive permissionMode.
+func validateAgentTools(extraTools []string, data map[string]any, filePath, contents string) []cue.ValidationError {
+	tools, ok := data["tools"]
+	if !ok || tools == nil {
+		return nil
+	}
+
+	line := textutil.FindFrontmatterFieldLine(contents, "tools")
+	issue := func(rule, severity, msg string) cue.ValidationError {
+		return cue.ValidationError{
+			File:     filePath,
+			Message:  msg,
+			Severity: severity,
+			Source:   cue.SourceCClintObserve,
+			Rule:     rule,
+			Line:     line,
+		}
+	}
+
+	var entries []string
+	var errors []cue.ValidationError
+	switch v := tools.(type) {
+	case string:
+		entries = textutil.SplitToolList(v)
+	case []any:
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok || strings.TrimSpace(s) == "" {
+				errors = append(errors, issue(cue.RuleAgentToolSyntax, cue.SeverityError,
+					fmt.Sprintf("tools[%d]: each entry must be a non-empty string", i)))
 				continue
 			}
+			entries = append(entries, strings.TrimSpace(s))
+		}
+	default:
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	hasWildcard := false
+	for _, entry := range entries {
+		if seen[entry] {
+			errors = append(errors, issue(cue.RuleAgentToolDuplicate, cue.SeverityWarning,
+				fmt.Sprintf("Duplicate tool %q in tools", entry)))
+			continue
 		}
+		seen[entry] = true
 
-		// Check following character.
-		after := abs + len(toolName)
-		if after < len(line) {
-			next := rune(line[after])
-			if next >= 'a' && next <= 'z' {
-				idx = abs + 1
-				continue
+		if entry == "*" {
+			hasWildcard = true
+			continue
+		}
+
+		if msg := toolEntrySyntaxError(entry); msg != "" {
+			errors = append(errors, issue(cue.RuleAgentToolSyntax, cue.SeverityError,
+				fmt.Sprintf("Malformed tool %q in tools: %s", entry, msg)))
+			continue
+		}
+
+		base := canonicalToolName(entry)
+		if !isKnownTool(base, extraTools) {
+			msg := fmt.Sprintf("Unknown tool '%s' in tools. Check spelling, or list it in extraTools if your Claude Code release has it.", entry)
+			if match, ok := textutil.ClosestMatch(base, knownFieldNames(textutil.KnownTools)); ok {
+				msg += fmt.Sprintf(" (did you mean '%s'?)", match)
 			}
+			errors = append(errors, issue(cue.RuleAgentToolUnknown, cue.SeverityWarning, msg))
 		}
+		if msg, deprecated := textutil.DeprecatedTools[base]; deprecated {
+			errors = append(errors, issue(cue.RuleAgentToolUnknown, cue.SeverityWarning,
+				fmt.Sprintf("Deprecated tool '%s' in tools. %s", entry, msg)))
+		}
+	}
 
-		return true
+	if mode, ok := data["permissionMode"].(string); ok && hasWildcard && restrictiveModes[mode] {
+		errors = append(errors, issue(cue.RuleAgentToolWildcardMode, cue.SeverityWarning,
+			fmt.Sprintf("tools: \"*\" grants every tool, but permissionMode %q blocks most of them — list the tools the agent actually needs", mode)))
+	}
+
+	return errors
+}
+
+// toolEntrySyntaxError returns a description of what is wrong with a single
+// tools entry such as "Bash(git:*)" or "Task(worker, reviewer)", or "" if the
+// entry is well-formed.
+func toolEntrySyntaxError(entry string) string {
+	open := strings.Index(entry, "(")
+	if open < 0 {
+		if strings.Contains(entry, ")") {
+			return "unbalanced parentheses"
+		}
+		return ""
+	}
+	if open == 0 {
+		return "missing tool name before '('"
+	}
+	if !strings.HasSuffix(entry, ")") || strings.Count(entry, "(") != strings.Count(entry, ")") {
+		return "unbalanced parentheses"
+	}
+
+	base := entry[:open]
+	args := entry[open+1 : len(entry)-1]
+	if strings.TrimSpace(args) == "" {
+		return "empty argument list"
+	}
+	if base != "Task" && base != "Agent" {
+		return ""
+	}
+	for arg := range strings.SplitSeq(args, ",") {
+		name := strings.TrimSpace(arg)
+		if !taskArgPattern.MatchString(name) {
+			return fmt.Sprintf("%s() arguments must be comma-separated agent names (lowercase letters, numbers, hyphens), got %q", base, name)
+		}
 	}
+	return ""
 }
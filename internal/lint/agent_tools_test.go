@@ -0,0 +1,115 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestValidateAgentTools(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       map[string]any
+		extraTools []string
+		wantRules  []string
+	}{
+		{
+			name:      "no tools field",
+			data:      map[string]any{"name": "test"},
+			wantRules: nil,
+		},
+		{
+			name:      "known tools string",
+			data:      map[string]any{"tools": "Read, Grep, Bash(git add:*, git commit:*)"},
+			wantRules: nil,
+		},
+		{
+			name:      "known tools array with mcp",
+			data:      map[string]any{"tools": []any{"Read", "mcp__github__search", "Task(worker, reviewer)"}},
+			wantRules: nil,
+		},
+		{
+			name:      "unknown tool in string",
+			data:      map[string]any{"tools": "Read, Grpe"},
+			wantRules: []string{cue.RuleAgentToolUnknown},
+		},
+		{
+			name:      "unknown tool in array",
+			data:      map[string]any{"tools": []any{"Read", "Teleport"}},
+			wantRules: []string{cue.RuleAgentToolUnknown},
+		},
+		{
+			name:       "tool listed in extraTools",
+			data:       map[string]any{"tools": []any{"Read", "Teleport"}},
+			extraTools: []string{"Teleport"},
+			wantRules:  nil,
+		},
+		{
+			name:      "deprecated tool",
+			data:      map[string]any{"tools": "TaskOutput"},
+			wantRules: []string{cue.RuleAgentToolUnknown},
+		},
+		{
+			name:      "duplicate entry",
+			data:      map[string]any{"tools": "Read, Write, Read"},
+			wantRules: []string{cue.RuleAgentToolDuplicate},
+		},
+		{
+			name:      "Task with invalid agent name",
+			data:      map[string]any{"tools": "Task(My Agent)"},
+			wantRules: []string{cue.RuleAgentToolSyntax},
+		},
+		{
+			name:      "Task with empty argument",
+			data:      map[string]any{"tools": "Task(worker, )"},
+			wantRules: []string{cue.RuleAgentToolSyntax},
+		},
+		{
+			name:      "empty argument list",
+			data:      map[string]any{"tools": "Task()"},
+			wantRules: []string{cue.RuleAgentToolSyntax},
+		},
+		{
+			name:      "unbalanced parentheses",
+			data:      map[string]any{"tools": "Bash(git:*"},
+			wantRules: []string{cue.RuleAgentToolSyntax},
+		},
+		{
+			name:      "non-string array entry",
+			data:      map[string]any{"tools": []any{"Read", 42}},
+			wantRules: []string{cue.RuleAgentToolSyntax},
+		},
+		{
+			name:      "wildcard with plan mode",
+			data:      map[string]any{"tools": "*", "permissionMode": "plan"},
+			wantRules: []string{cue.RuleAgentToolWildcardMode},
+		},
+		{
+			name:      "wildcard with acceptEdits mode",
+			data:      map[string]any{"tools": "*", "permissionMode": "acceptEdits"},
+			wantRules: nil,
+		},
+		{
+			name:      "explicit tools with dontAsk mode",
+			data:      map[string]any{"tools": "Read", "permissionMode": "dontAsk"},
+			wantRules: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateAgentTools(tt.extraTools, tt.data, "agents/test.md", "---\ntools: x\n---\n")
+			if len(got) != len(tt.wantRules) {
+				t.Fatalf("validateAgentTools() returned %d issues, want %d: %v", len(got), len(tt.wantRules), got)
+			}
+			for i, want := range tt.wantRules {
+				if got[i].Rule != want {
+					t.Errorf("issue %d rule = %q, want %q (%s)", i, got[i].Rule, want, got[i].Message)
+				}
+				if got[i].Line != 2 {
+					t.Errorf("issue %d line = %d, want 2", i, got[i].Line)
+				}
+			}
+		})
+	}
+}
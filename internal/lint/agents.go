@@ -3,7 +3,10 @@ package lint
 import (
 	"time"
 
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/git"
 	"github.com/dotcommander/cclint/internal/scoring"
 	"github.com/dotcommander/cclint/internal/textutil"
 )
@@ -19,6 +22,15 @@ type LintResult struct {
 	Success      bool
 	Duration     int64
 	Quality      *scoring.QualityScore
+	// Disabled is set for components turned off with `enabled: false` or
+	// `disabled: true`; they skip cross-file validation.
+	Disabled bool
+	// Owners are the file's CODEOWNERS owners, when the project has a
+	// CODEOWNERS file and a rule assigns any.
+	Owners []string
+	// Blame is the last commit of each line with a finding, keyed by line,
+	// when requested with ApplyBlame.
+	Blame map[int]git.LineBlame
 }
 
 // LintSummary summarizes all linting results
@@ -32,8 +44,23 @@ type LintSummary struct {
 	TotalErrors      int
 	TotalWarnings    int
 	TotalSuggestions int
+	DisabledFiles    int
+	BloatedFiles     int // files with long lines, base64 blobs, or invalid UTF-8
 	Duration         int64
 	Results          []LintResult
+	// CodeOwners is the CODEOWNERS file the results' owners come from, or
+	// "" when ownership was not resolved.
+	CodeOwners string
+	// Suppressed lists issues hidden by the baseline, for reporting.
+	Suppressed []SuppressedIssue
+	// Graph describes the cross-file component graph, when one was built.
+	Graph *crossfile.GraphStats
+	// Chains holds the delegation chain of each command or agent in the
+	// run, when requested with Options.IncludeChains.
+	Chains []crossfile.ChainLink
+	// Gates is the quality gate result of the run the summary belongs to,
+	// when the config has a gates block.
+	Gates *GateResult
 }
 
 // applyResultToSummary accumulates a single LintResult's counters into summary.
@@ -48,15 +75,61 @@ func applyResultToSummary(summary *LintSummary, result LintResult) {
 	summary.TotalErrors += len(result.Errors)
 	summary.TotalWarnings += len(result.Warnings)
 	summary.TotalSuggestions += len(result.Suggestions)
+	if result.Disabled {
+		summary.DisabledFiles++
+	}
+	if hasBloatedContent(result) {
+		summary.BloatedFiles++
+	}
+}
+
+// MergeSummaries combines the per-component summaries of a full run into
+// one, for reports that describe the whole run at once. Results, baseline
+// suppressions, and delegation chains are concatenated in summary order and
+// the totals summed; the graph, gates, and CODEOWNERS path are run-wide, so
+// the first summary that has them supplies them. The merged summary has no
+// ComponentType; each result's Type still names its component.
+func MergeSummaries(summaries []*LintSummary, startTime time.Time) *LintSummary {
+	merged := &LintSummary{StartTime: startTime}
+	for _, s := range summaries {
+		if s == nil {
+			continue
+		}
+		if merged.ProjectRoot == "" {
+			merged.ProjectRoot = s.ProjectRoot
+		}
+		merged.TotalFiles += s.TotalFiles
+		merged.SuccessfulFiles += s.SuccessfulFiles
+		merged.FailedFiles += s.FailedFiles
+		merged.TotalErrors += s.TotalErrors
+		merged.TotalWarnings += s.TotalWarnings
+		merged.TotalSuggestions += s.TotalSuggestions
+		merged.DisabledFiles += s.DisabledFiles
+		merged.BloatedFiles += s.BloatedFiles
+		merged.Duration += s.Duration
+		merged.Results = append(merged.Results, s.Results...)
+		merged.Suppressed = append(merged.Suppressed, s.Suppressed...)
+		merged.Chains = append(merged.Chains, s.Chains...)
+		if merged.CodeOwners == "" {
+			merged.CodeOwners = s.CodeOwners
+		}
+		if merged.Graph == nil {
+			merged.Graph = s.Graph
+		}
+		if merged.Gates == nil {
+			merged.Gates = s.Gates
+		}
+	}
+	return merged
 }
 
 // LintAgents runs linting on agent files using the generic linter.
-func LintAgents(rootPath string, quiet bool, verbose bool, noCycleCheck bool, exclude []string) (*LintSummary, error) {
-	ctx, err := NewLinterContext(rootPath, quiet, verbose, noCycleCheck, exclude)
+func LintAgents(opts Options) (*LintSummary, error) {
+	ctx, err := NewLinterContext(opts)
 	if err != nil {
 		return nil, err
 	}
-	return lintBatch(ctx, NewAgentLinter()), nil
+	return lintBatch(ctx, NewAgentLinter(ctx.RootPath, ctx.Config)), nil
 }
 
 // knownAgentFields lists valid frontmatter fields per Anthropic docs
@@ -81,21 +154,23 @@ var knownAgentFields = map[string]bool{
 	"triggers":                            true,
 	"requiredMcpServers":                  true, // Optional: agent only runs when these MCP servers are connected (v2.1.156)
 	"criticalSystemReminder_EXPERIMENTAL": true, // Optional (experimental): reminder re-injected as a system message (v2.1.156)
+	"enabled":                             true, // cclint: false excludes the agent from cross-file analysis
+	"disabled":                            true, // cclint: true excludes the agent from cross-file analysis
 }
 
 // validateAgentSpecific implements agent-specific validation rules.
 // Orchestrates validation by delegating to focused check functions.
-func validateAgentSpecific(data map[string]any, filePath string, contents string) []cue.ValidationError {
+func validateAgentSpecific(cfg *config.Config, data map[string]any, filePath string, contents string) []cue.ValidationError {
 	var errors []cue.ValidationError
 
 	// Frontmatter field validation
-	errors = append(errors, validateUnknownFields(data, filePath, contents)...)
+	errors = append(errors, validateUnknownFields(cfg, data, filePath, contents)...)
 	errors = append(errors, validateRequiredFields(data, filePath, contents)...)
 
 	// Individual field validation
 	errors = append(errors, validateAgentColor(data, filePath)...)
 	errors = append(errors, validateAgentMemory(data, filePath, contents)...)
-	errors = append(errors, validateAgentModel(data, filePath, contents)...)
+	errors = append(errors, validateAgentModel(modelCatalog(cfg), data, filePath, contents)...)
 	errors = append(errors, validateAgentMCPServersField(data, filePath, contents)...)
 	errors = append(errors, validateAgentPermissionMode(data, filePath, contents)...)
 	errors = append(errors, validateAgentMaxTurns(data, filePath, contents)...)
@@ -103,9 +178,13 @@ func validateAgentSpecific(data map[string]any, filePath string, contents string
 
 	// Cross-field validation
 	errors = append(errors, textutil.ValidateToolFieldName(data, filePath, contents, "agent")...)
-	errors = append(errors, validateAgentHooks(data, filePath)...)
+	errors = append(errors, validateAgentTools(cfg.ExtraTools, data, filePath, contents)...)
+	errors = append(errors, validateAgentHooks(cfg, data, filePath)...)
 	errors = append(errors, validateAgentBestPractices(filePath, contents, data)...)
 	errors = append(errors, validateBodyToolMismatch(data, filePath, contents)...)
+	errors = append(errors, validateTemplatePlaceholders(filePath, contents, cfg.Rules.TemplateVariables)...)
+	errors = append(errors, checkScaffoldLeftovers(data, filePath, contents)...)
+	errors = append(errors, checkDescriptionReadability(cfg.Rules.Readability, data, filePath, contents)...)
 
 	return errors
 }
@@ -53,7 +53,7 @@ func TestValidateAgentNoUnknownFieldSuggestion(t *testing.T) {
 	}
 	contents := "---\nname: test\ndescription: test. Use PROACTIVELY when testing.\nrequiredMcpServers:\n  - filesystem\ncriticalSystemReminder_EXPERIMENTAL: Always confirm before deleting.\n---\n"
 
-	errors := validateAgentSpecific(data, "agents/test.md", contents)
+	errors := validateAgentSpecific(defaultConfig(), data, "agents/test.md", contents)
 	for _, e := range errors {
 		for _, f := range fields {
 			if strings.Contains(e.Message, "Unknown frontmatter field") && strings.Contains(e.Message, f) {
@@ -0,0 +1,459 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/migrate"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// Fix is a mechanical edit that resolves a single finding.
+type Fix struct {
+	// Description says what the fix changes, e.g. "Remove duplicate tools".
+	Description string
+	// Apply returns the file contents with the fix applied.
+	Apply func(contents string) (string, error)
+}
+
+// TextEdit is a fix as a single replacement: the text from Start up to End
+// is replaced by NewText.
+type TextEdit struct {
+	Start   Position
+	End     Position // exclusive
+	NewText string
+}
+
+// Position is a place in a file. Line and Column are 1-based, and Column
+// counts bytes.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Edit returns the change the fix makes to contents, as one replacement of
+// the whole lines that differ, without writing anything. Reports carry it
+// so editors and bots can offer the fix.
+func (f Fix) Edit(contents string) (TextEdit, error) {
+	fixed, err := f.Apply(contents)
+	if err != nil {
+		return TextEdit{}, err
+	}
+	start := commonPrefixLen(contents, fixed)
+	start = strings.LastIndexByte(contents[:start], '\n') + 1
+	end := len(contents) - commonSuffixLen(contents[start:], fixed[start:])
+	if end > start && contents[end-1] != '\n' {
+		if i := strings.IndexByte(contents[end:], '\n'); i >= 0 {
+			end += i + 1
+		} else {
+			end = len(contents)
+		}
+	}
+	return TextEdit{
+		Start:   offsetPosition(contents, start),
+		End:     offsetPosition(contents, end),
+		NewText: fixed[start : len(fixed)-(len(contents)-end)],
+	}, nil
+}
+
+// commonPrefixLen returns how many leading bytes a and b share.
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// commonSuffixLen returns how many trailing bytes a and b share.
+func commonSuffixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[len(a)-1-n] == b[len(b)-1-n] {
+		n++
+	}
+	return n
+}
+
+// offsetPosition converts a byte offset in contents to a Position.
+func offsetPosition(contents string, offset int) Position {
+	before := contents[:offset]
+	return Position{
+		Line:   strings.Count(before, "\n") + 1,
+		Column: len(before) - strings.LastIndexByte(before, '\n'),
+	}
+}
+
+// fixers builds a Fix for a finding from the file contents, reporting false
+// when the finding cannot be fixed mechanically (e.g. a YAML list that
+// spans several lines). Keyed by rule ID.
+var fixers = map[string]func(issue cue.ValidationError, contents string) (Fix, bool){
+	cue.RuleAgentToolDuplicate:         fixDuplicateTools,
+	cue.RuleCommandArgHintUnused:       fixRemoveFieldLine("argument-hint"),
+	cue.RuleFrontmatterDuplicateKey:    fixDuplicateKey,
+	cue.RuleAgentSkillUnreferenced:     fixRemoveSkill,
+	cue.RuleAgentSkillUndeclared:       fixAddSkill,
+	cue.RuleFrontmatterFieldRenamed:    fixRenamedField,
+	cue.RuleFrontmatterFieldDeprecated: fixDeprecatedField,
+	cue.RuleModelDeprecated:            fixModelReplacement,
+	cue.RuleContentBOM:                 fixBOM,
+	cue.RuleContentLineEndings:         fixLineEndings,
+	cue.RuleFrontmatterTabIndent:       fixTabIndent,
+	cue.RuleContentFinalNewline:        fixFinalNewline,
+}
+
+// HasFixer reports whether findings of rule may have an autofix.
+func HasFixer(rule string) bool {
+	_, ok := fixers[rule]
+	return ok
+}
+
+// FixFor returns the autofix for issue, if its rule has one that applies
+// to contents.
+func FixFor(issue cue.ValidationError, contents string) (Fix, bool) {
+	fixer, ok := fixers[issue.Rule]
+	if !ok || issue.Line < 1 {
+		return Fix{}, false
+	}
+	return fixer(issue, contents)
+}
+
+// fixDuplicateTools rewrites an inline tools/allowed-tools list without its
+// repeated entries, keeping the first occurrence of each.
+func fixDuplicateTools(issue cue.ValidationError, contents string) (Fix, bool) {
+	lines := strings.Split(contents, "\n")
+	if issue.Line > len(lines) {
+		return Fix{}, false
+	}
+	key, value, ok := strings.Cut(lines[issue.Line-1], ":")
+	value = strings.TrimSpace(value)
+	if !ok || value == "" || strings.ContainsAny(value, `[]"'`) {
+		return Fix{}, false
+	}
+
+	var kept []string
+	for _, entry := range textutil.SplitToolList(value) {
+		if !slices.Contains(kept, entry) {
+			kept = append(kept, entry)
+		}
+	}
+	line := fmt.Sprintf("%s: %s", key, strings.Join(kept, ", "))
+
+	return Fix{
+		Description: "Remove duplicate tool entries",
+		Apply: func(contents string) (string, error) {
+			return replaceLine(contents, issue.Line, line, true)
+		},
+	}, true
+}
+
+// fixRemoveFieldLine deletes a single-line frontmatter field.
+func fixRemoveFieldLine(field string) func(cue.ValidationError, string) (Fix, bool) {
+	return func(issue cue.ValidationError, contents string) (Fix, bool) {
+		lines := strings.Split(contents, "\n")
+		if issue.Line > len(lines) || !strings.HasPrefix(lines[issue.Line-1], field+":") {
+			return Fix{}, false
+		}
+		return Fix{
+			Description: fmt.Sprintf("Remove the %s field", field),
+			Apply: func(contents string) (string, error) {
+				return replaceLine(contents, issue.Line, "", false)
+			},
+		}, true
+	}
+}
+
+// fixDuplicateKey deletes the earlier definition of a repeated top-level
+// key, which is the value YAML loaders discard. Only single-line earlier
+// definitions are removed; block values are left for a human.
+func fixDuplicateKey(issue cue.ValidationError, contents string) (Fix, bool) {
+	fm, err := textutil.ParseYAMLFrontmatter(contents)
+	if err != nil {
+		return Fix{}, false
+	}
+	idx := slices.IndexFunc(fm.DuplicateKeys, func(d textutil.DuplicateKey) bool { return d.Line == issue.Line })
+	if idx < 0 || strings.Contains(fm.DuplicateKeys[idx].Path, ".") {
+		return Fix{}, false
+	}
+	first := fm.DuplicateKeys[idx].FirstLine
+
+	lines := strings.Split(contents, "\n")
+	if first < 1 || first >= len(lines) {
+		return Fix{}, false
+	}
+	_, value, _ := strings.Cut(lines[first-1], ":")
+	next := lines[first]
+	if strings.TrimSpace(value) == "" || strings.HasPrefix(next, " ") || strings.HasPrefix(next, "\t") {
+		return Fix{}, false
+	}
+
+	return Fix{
+		Description: fmt.Sprintf("Remove the earlier '%s' definition on line %d", fm.DuplicateKeys[idx].Path, first),
+		Apply: func(contents string) (string, error) {
+			return replaceLine(contents, first, "", false)
+		},
+	}, true
+}
+
+// fixRenamedField renames a frontmatter key to its current name, as
+// "cclint migrate" would.
+func fixRenamedField(issue cue.ValidationError, contents string) (Fix, bool) {
+	lines := strings.Split(contents, "\n")
+	if issue.Line > len(lines) {
+		return Fix{}, false
+	}
+	key, _, _ := strings.Cut(lines[issue.Line-1], ":")
+	idx := slices.IndexFunc(migrate.Renames, func(r migrate.Rename) bool { return r.From == key })
+	if idx < 0 || textutil.FindFrontmatterFieldLine(contents, migrate.Renames[idx].To) != 0 {
+		return Fix{}, false
+	}
+	r := migrate.Renames[idx]
+	line := r.To + strings.TrimPrefix(lines[issue.Line-1], r.From)
+
+	return Fix{
+		Description: fmt.Sprintf("Rename '%s' to '%s'", r.From, r.To),
+		Apply: func(contents string) (string, error) {
+			return replaceLine(contents, issue.Line, line, true)
+		},
+	}, true
+}
+
+// fixModelReplacement swaps a deprecated or retired model for the
+// replacement the model catalog suggests, which the finding carries.
+func fixModelReplacement(issue cue.ValidationError, contents string) (Fix, bool) {
+	lines := strings.Split(contents, "\n")
+	if issue.Line > len(lines) || issue.Replacement == "" {
+		return Fix{}, false
+	}
+	key, value, ok := strings.Cut(lines[issue.Line-1], ":")
+	if !ok || key != "model" {
+		return Fix{}, false
+	}
+	replacement := issue.Replacement
+	model := strings.Trim(strings.TrimSpace(value), `"'`)
+
+	return Fix{
+		Description: fmt.Sprintf("Replace model '%s' with '%s'", model, replacement),
+		Apply: func(contents string) (string, error) {
+			return replaceLine(contents, issue.Line, "model: "+replacement, true)
+		},
+	}, true
+}
+
+// fixRemoveSkill drops the named skill from the frontmatter skills array,
+// and the skills field itself when it was the only entry.
+func fixRemoveSkill(issue cue.ValidationError, contents string) (Fix, bool) {
+	name, ok := quotedName(issue.Message)
+	if !ok {
+		return Fix{}, false
+	}
+	list, ok := findSkillsList(contents)
+	if !ok || !slices.Contains(list.entries, name) {
+		return Fix{}, false
+	}
+	return Fix{
+		Description: fmt.Sprintf("Remove '%s' from skills", name),
+		Apply: func(contents string) (string, error) {
+			list, ok := findSkillsList(contents)
+			if !ok {
+				return contents, fmt.Errorf("skills field not found")
+			}
+			return list.without(contents, name), nil
+		},
+	}, true
+}
+
+// fixAddSkill appends the named skill to the frontmatter skills array.
+func fixAddSkill(issue cue.ValidationError, contents string) (Fix, bool) {
+	name, ok := quotedName(issue.Message)
+	if !ok {
+		return Fix{}, false
+	}
+	list, ok := findSkillsList(contents)
+	if !ok || slices.Contains(list.entries, name) {
+		return Fix{}, false
+	}
+	return Fix{
+		Description: fmt.Sprintf("Add '%s' to skills", name),
+		Apply: func(contents string) (string, error) {
+			list, ok := findSkillsList(contents)
+			if !ok {
+				return contents, fmt.Errorf("skills field not found")
+			}
+			return list.with(contents, name), nil
+		},
+	}, true
+}
+
+// fixBOM strips the leading byte order mark.
+func fixBOM(_ cue.ValidationError, contents string) (Fix, bool) {
+	if !strings.HasPrefix(contents, textutil.BOM) {
+		return Fix{}, false
+	}
+	return Fix{
+		Description: "Remove the byte order mark",
+		Apply: func(contents string) (string, error) {
+			return strings.TrimPrefix(contents, textutil.BOM), nil
+		},
+	}, true
+}
+
+// fixLineEndings rewrites every line break in the style the lineEndings
+// policy asks for, which the finding names; under auto, the style the file
+// uses most.
+func fixLineEndings(issue cue.ValidationError, contents string) (Fix, bool) {
+	policy := textutil.LineEndingsAuto
+	switch {
+	case strings.HasSuffix(issue.Message, "convert the file to LF"):
+		policy = textutil.LineEndingsLF
+	case strings.HasSuffix(issue.Message, "convert the file to CRLF"):
+		policy = textutil.LineEndingsCRLF
+	}
+	eol := textutil.LineBreak(policy, contents)
+	name := "LF"
+	if eol == "\r\n" {
+		name = "CRLF"
+	}
+	return Fix{
+		Description: "Convert line endings to " + name,
+		Apply: func(contents string) (string, error) {
+			return textutil.ConvertLineBreaks(contents, eol), nil
+		},
+	}, true
+}
+
+// fixTabIndent replaces the tabs in the indentation of every frontmatter
+// line with two spaces each.
+func fixTabIndent(_ cue.ValidationError, contents string) (Fix, bool) {
+	if len(tabIndentedFrontmatter(contents)) == 0 {
+		return Fix{}, false
+	}
+	return Fix{
+		Description: "Indent frontmatter with spaces instead of tabs",
+		Apply: func(contents string) (string, error) {
+			lines := strings.Split(contents, "\n")
+			for _, n := range tabIndentedFrontmatter(contents) {
+				lines[n-1] = textutil.ExpandIndentTabs(lines[n-1])
+			}
+			return strings.Join(lines, "\n"), nil
+		},
+	}, true
+}
+
+// fixFinalNewline appends a line break in the file's line ending style.
+func fixFinalNewline(_ cue.ValidationError, contents string) (Fix, bool) {
+	if contents == "" || strings.HasSuffix(contents, "\n") {
+		return Fix{}, false
+	}
+	return Fix{
+		Description: "Add a final newline",
+		Apply: func(contents string) (string, error) {
+			return contents + textutil.LineBreak(textutil.LineEndingsAuto, contents), nil
+		},
+	}, true
+}
+
+// quotedName returns the first 'single-quoted' token of a finding message,
+// which names the subject of the finding.
+func quotedName(msg string) (string, bool) {
+	_, rest, ok := strings.Cut(msg, "'")
+	if !ok {
+		return "", false
+	}
+	name, _, ok := strings.Cut(rest, "'")
+	return name, ok && name != ""
+}
+
+// skillsList locates the frontmatter skills array in either flow form
+// (skills: [a, b]) or block form (skills: followed by "- a" lines).
+type skillsList struct {
+	field   int // 0-based line of "skills:"
+	items   []int
+	entries []string
+	flow    bool
+	indent  string
+}
+
+func findSkillsList(contents string) (skillsList, bool) {
+	line := textutil.FindFrontmatterFieldLine(contents, "skills")
+	if line == 0 {
+		return skillsList{}, false
+	}
+	lines := strings.Split(contents, "\n")
+	l := skillsList{field: line - 1, indent: "  "}
+	_, value, _ := strings.Cut(lines[l.field], ":")
+	value = strings.TrimSpace(value)
+
+	switch {
+	case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+		l.flow = true
+		for _, entry := range strings.Split(strings.Trim(value, "[]"), ",") {
+			if entry = strings.Trim(strings.TrimSpace(entry), `"'`); entry != "" {
+				l.entries = append(l.entries, entry)
+			}
+		}
+	case value == "":
+		for i := l.field + 1; i < len(lines) && isBlockListItem(lines[i]); i++ {
+			l.items = append(l.items, i)
+			l.entries = append(l.entries, blockListValue(lines[i]))
+			l.indent = lines[i][:len(lines[i])-len(strings.TrimLeft(lines[i], " \t"))]
+		}
+	default:
+		return skillsList{}, false
+	}
+	return l, true
+}
+
+func (l skillsList) without(contents, name string) string {
+	lines := strings.Split(contents, "\n")
+	kept := slices.DeleteFunc(slices.Clone(l.entries), func(e string) bool { return e == name })
+
+	if len(kept) == 0 {
+		drop := append([]int{l.field}, l.items...)
+		slices.Reverse(drop)
+		for _, i := range drop {
+			lines = slices.Delete(lines, i, i+1)
+		}
+		return strings.Join(lines, "\n")
+	}
+	if l.flow {
+		lines[l.field] = "skills: [" + strings.Join(kept, ", ") + "]"
+		return strings.Join(lines, "\n")
+	}
+	for j := len(l.items) - 1; j >= 0; j-- {
+		if l.entries[j] == name {
+			lines = slices.Delete(lines, l.items[j], l.items[j]+1)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (l skillsList) with(contents, name string) string {
+	lines := strings.Split(contents, "\n")
+	if l.flow {
+		lines[l.field] = "skills: [" + strings.Join(append(l.entries, name), ", ") + "]"
+		return strings.Join(lines, "\n")
+	}
+	at := l.field + 1
+	if len(l.items) > 0 {
+		at = l.items[len(l.items)-1] + 1
+	}
+	lines = slices.Insert(lines, at, l.indent+"- "+name)
+	return strings.Join(lines, "\n")
+}
+
+// replaceLine replaces (keep=true) or deletes (keep=false) the 1-based line
+// n of contents.
+func replaceLine(contents string, n int, replacement string, keep bool) (string, error) {
+	lines := strings.Split(contents, "\n")
+	if n < 1 || n > len(lines) {
+		return contents, fmt.Errorf("line %d out of range", n)
+	}
+	if keep {
+		lines[n-1] = replacement
+	} else {
+		lines = slices.Delete(lines, n-1, n)
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,207 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestFixFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		issue    cue.ValidationError
+		contents string
+		want     string
+		wantOK   bool
+	}{
+		{
+			name:     "duplicate tools inline",
+			issue:    cue.ValidationError{Rule: cue.RuleAgentToolDuplicate, Line: 3},
+			contents: "---\nname: a\ntools: Read, Bash(git:*, npm:*), Read\n---\nbody\n",
+			want:     "---\nname: a\ntools: Read, Bash(git:*, npm:*)\n---\nbody\n",
+			wantOK:   true,
+		},
+		{
+			name:     "duplicate tools in flow sequence is not fixed",
+			issue:    cue.ValidationError{Rule: cue.RuleAgentToolDuplicate, Line: 2},
+			contents: "---\ntools: [Read, Read]\n---\n",
+			wantOK:   false,
+		},
+		{
+			name:     "unused argument-hint removed",
+			issue:    cue.ValidationError{Rule: cue.RuleCommandArgHintUnused, Line: 3},
+			contents: "---\ndescription: d\nargument-hint: <file>\n---\nbody\n",
+			want:     "---\ndescription: d\n---\nbody\n",
+			wantOK:   true,
+		},
+		{
+			name:     "duplicate key removes earlier definition",
+			issue:    cue.ValidationError{Rule: cue.RuleFrontmatterDuplicateKey, Line: 4},
+			contents: "---\nname: a\nmodel: haiku\nmodel: sonnet\n---\nbody\n",
+			want:     "---\nname: a\nmodel: sonnet\n---\nbody\n",
+			wantOK:   true,
+		},
+		{
+			name:     "renamed field gets its current name",
+			issue:    cue.ValidationError{Rule: cue.RuleFrontmatterFieldRenamed, Line: 2},
+			contents: "---\nargument_hint: \"[file]\"\n---\nbody\n",
+			want:     "---\nargument-hint: \"[file]\"\n---\nbody\n",
+			wantOK:   true,
+		},
+		{
+			name:     "renamed field not fixed when the new key is set",
+			issue:    cue.ValidationError{Rule: cue.RuleFrontmatterFieldRenamed, Line: 2},
+			contents: "---\nargument_hint: a\nargument-hint: b\n---\n",
+			wantOK:   false,
+		},
+		{
+			name:     "deprecated model replaced",
+			issue:    cue.ValidationError{Rule: cue.RuleModelDeprecated, Line: 3, Message: "Model 'claude-3-opus-20240229' is deprecated; use 'opus' instead", Replacement: "opus"},
+			contents: "---\nname: a\nmodel: \"claude-3-opus-20240229\"\n---\n",
+			want:     "---\nname: a\nmodel: opus\n---\n",
+			wantOK:   true,
+		},
+		{
+			name:     "deprecated model without a replacement not fixed",
+			issue:    cue.ValidationError{Rule: cue.RuleModelDeprecated, Line: 3, Message: "Model 'claude-3-opus-20240229' is deprecated; use 'opus' instead"},
+			contents: "---\nname: a\nmodel: claude-3-opus-20240229\n---\n",
+			wantOK:   false,
+		},
+		{
+			name:     "byte order mark removed",
+			issue:    cue.ValidationError{Rule: cue.RuleContentBOM, Line: 1},
+			contents: "\uFEFF---\nname: a\n---\n",
+			want:     "---\nname: a\n---\n",
+			wantOK:   true,
+		},
+		{
+			name:     "mixed line endings converted to the majority",
+			issue:    cue.ValidationError{Rule: cue.RuleContentLineEndings, Line: 2},
+			contents: "---\r\nname: a\n---\r\n",
+			want:     "---\r\nname: a\r\n---\r\n",
+			wantOK:   true,
+		},
+		{
+			name:     "line endings converted to the configured style",
+			issue:    cue.ValidationError{Rule: cue.RuleContentLineEndings, Line: 1, Message: "Uses CRLF line endings on 3 lines, first on line 1, but lineEndings is lf; convert the file to LF"},
+			contents: "---\r\nname: a\r\n---\r\n",
+			want:     "---\nname: a\n---\n",
+			wantOK:   true,
+		},
+		{
+			name:     "frontmatter tabs become spaces",
+			issue:    cue.ValidationError{Rule: cue.RuleFrontmatterTabIndent, Line: 3},
+			contents: "---\nhooks:\n\tStop:\n\t\t- x\n---\n\tbody\n",
+			want:     "---\nhooks:\n  Stop:\n    - x\n---\n\tbody\n",
+			wantOK:   true,
+		},
+		{
+			name:     "final newline added in the file's style",
+			issue:    cue.ValidationError{Rule: cue.RuleContentFinalNewline, Line: 2},
+			contents: "{\r\n}",
+			want:     "{\r\n}\r\n",
+			wantOK:   true,
+		},
+		{
+			name:     "rule without fixer",
+			issue:    cue.ValidationError{Rule: cue.RuleAgentToolUnknown, Line: 2},
+			contents: "---\ntools: Grpe\n---\n",
+			wantOK:   false,
+		},
+		{
+			name:     "no line",
+			issue:    cue.ValidationError{Rule: cue.RuleAgentToolDuplicate},
+			contents: "---\ntools: Read, Read\n---\n",
+			wantOK:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fix, ok := FixFor(tt.issue, tt.contents)
+			if ok != tt.wantOK {
+				t.Fatalf("FixFor() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			got, err := fix.Apply(tt.contents)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Apply() = %q, want %q", got, tt.want)
+			}
+			if fix.Description == "" {
+				t.Error("Fix.Description is empty")
+			}
+		})
+	}
+}
+
+func TestFixEdit(t *testing.T) {
+	tests := []struct {
+		name     string
+		issue    cue.ValidationError
+		contents string
+		want     TextEdit
+	}{
+		{
+			name:     "line replaced",
+			issue:    cue.ValidationError{Rule: cue.RuleAgentToolDuplicate, Line: 3},
+			contents: "---\nname: a\ntools: Read, Grep, Read\n---\nbody\n",
+			want:     TextEdit{Start: Position{3, 1}, End: Position{4, 1}, NewText: "tools: Read, Grep\n"},
+		},
+		{
+			name:     "line deleted",
+			issue:    cue.ValidationError{Rule: cue.RuleCommandArgHintUnused, Line: 3},
+			contents: "---\ndescription: d\nargument-hint: <file>\n---\nbody\n",
+			want:     TextEdit{Start: Position{3, 1}, End: Position{4, 1}},
+		},
+		{
+			name:     "line inserted",
+			issue:    cue.ValidationError{Rule: cue.RuleAgentSkillUndeclared, Line: 2, Message: "Skill 'b' is used but not listed"},
+			contents: "---\nskills:\n  - a\n---\nbody\n",
+			want:     TextEdit{Start: Position{4, 1}, End: Position{4, 1}, NewText: "  - b\n"},
+		},
+		{
+			name:     "last line without newline",
+			issue:    cue.ValidationError{Rule: cue.RuleModelDeprecated, Line: 2, Message: "Model 'claude-3-opus-20240229' is deprecated; use 'opus' instead", Replacement: "opus"},
+			contents: "---\nmodel: claude-3-opus-20240229",
+			want:     TextEdit{Start: Position{2, 1}, End: Position{2, 30}, NewText: "model: opus"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fix, ok := FixFor(tt.issue, tt.contents)
+			if !ok {
+				t.Fatal("FixFor() found no fix")
+			}
+			got, err := fix.Edit(tt.contents)
+			if err != nil {
+				t.Fatalf("Edit() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Edit() = %+v, want %+v", got, tt.want)
+			}
+			applied, _ := fix.Apply(tt.contents)
+			if spliced := splice(tt.contents, got); spliced != applied {
+				t.Errorf("edit gives %q, Apply gives %q", spliced, applied)
+			}
+		})
+	}
+}
+
+// splice applies e to contents.
+func splice(contents string, e TextEdit) string {
+	offset := func(p Position) int {
+		lines := strings.SplitAfter(contents, "\n")
+		n := 0
+		for _, line := range lines[:p.Line-1] {
+			n += len(line)
+		}
+		return n + p.Column - 1
+	}
+	return contents[:offset(e.Start)] + e.NewText + contents[offset(e.End):]
+}
@@ -5,7 +5,8 @@ import (
 	"github.com/dotcommander/cclint/internal/cue"
 )
 
-// FilterResults filters issues based on baseline, returning filtered issues and count of ignored issues
+// FilterResults filters issues based on baseline, returning filtered issues and count of ignored issues.
+// Ignored issues are recorded in summary.Suppressed.
 func FilterResults(summary *LintSummary, b *baseline.Baseline) (totalIgnored, errorsIgnored, suggestionsIgnored int) {
 	if b == nil {
 		return 0, 0, 0 // No baseline, no filtering
@@ -18,21 +19,23 @@ func FilterResults(summary *LintSummary, b *baseline.Baseline) (totalIgnored, er
 		result := &summary.Results[i]
 
 		// Filter errors
-		filteredErrors, errign := filterIssues(result.Errors, b.IsKnown)
+		filteredErrors, errIgnored := filterIssues(result.Errors, b.IsKnown)
 		result.Errors = filteredErrors
-		errIgn += errign
-		total += errign
+		errIgn += len(errIgnored)
 
 		// Filter warnings
-		filteredWarnings, warnign := filterIssues(result.Warnings, b.IsKnown)
+		filteredWarnings, warnIgnored := filterIssues(result.Warnings, b.IsKnown)
 		result.Warnings = filteredWarnings
-		total += warnign
 
 		// Filter suggestions
-		filteredSuggestions, suggign := filterIssues(result.Suggestions, b.IsKnown)
+		filteredSuggestions, suggIgnored := filterIssues(result.Suggestions, b.IsKnown)
 		result.Suggestions = filteredSuggestions
-		suggIgn += suggign
-		total += suggign
+		suggIgn += len(suggIgnored)
+
+		total += len(errIgnored) + len(warnIgnored) + len(suggIgnored)
+		recordSuppressed(summary, errIgnored, SuppressionBaseline)
+		recordSuppressed(summary, warnIgnored, SuppressionBaseline)
+		recordSuppressed(summary, suggIgnored, SuppressionBaseline)
 
 		// Update success status based on filtered errors
 		result.Success = len(result.Errors) == 0
@@ -45,13 +48,12 @@ func FilterResults(summary *LintSummary, b *baseline.Baseline) (totalIgnored, er
 }
 
 // filterIssues filters a slice of issues using the provided filter function.
-// Returns the filtered slice and the count of ignored issues.
-func filterIssues(issues []cue.ValidationError, filter func(cue.ValidationError) bool) ([]cue.ValidationError, int) {
-	filtered := make([]cue.ValidationError, 0, len(issues))
-	ignored := 0
+// Returns the kept issues and the ignored ones.
+func filterIssues(issues []cue.ValidationError, filter func(cue.ValidationError) bool) (filtered, ignored []cue.ValidationError) {
+	filtered = make([]cue.ValidationError, 0, len(issues))
 	for _, issue := range issues {
 		if filter(issue) {
-			ignored++
+			ignored = append(ignored, issue)
 		} else {
 			filtered = append(filtered, issue)
 		}
@@ -61,9 +63,13 @@ func filterIssues(issues []cue.ValidationError, filter func(cue.ValidationError)
 
 // recalculateTotals recalculates the summary totals based on the current results.
 func recalculateTotals(summary *LintSummary) {
-	var totalErrors, totalSuggestions, successfulFiles, failedFiles int
+	var totalErrors, totalWarnings, totalSuggestions, successfulFiles, failedFiles, bloatedFiles int
 	for _, result := range summary.Results {
+		if hasBloatedContent(result) {
+			bloatedFiles++
+		}
 		totalErrors += len(result.Errors)
+		totalWarnings += len(result.Warnings)
 		totalSuggestions += len(result.Suggestions)
 		if result.Success {
 			successfulFiles++
@@ -72,9 +78,11 @@ func recalculateTotals(summary *LintSummary) {
 		}
 	}
 	summary.TotalErrors = totalErrors
+	summary.TotalWarnings = totalWarnings
 	summary.TotalSuggestions = totalSuggestions
 	summary.SuccessfulFiles = successfulFiles
 	summary.FailedFiles = failedFiles
+	summary.BloatedFiles = bloatedFiles
 }
 
 // CollectAllIssues collects all validation errors from a summary (for baseline creation)
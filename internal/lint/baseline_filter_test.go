@@ -88,6 +88,15 @@ func TestFilterResults(t *testing.T) {
 			if suggsIgnored != tt.wantSuggsIgnored {
 				t.Errorf("FilterResults() suggsIgnored = %d, want %d", suggsIgnored, tt.wantSuggsIgnored)
 			}
+
+			if len(tt.summary.Suppressed) != tt.wantTotalIgnored {
+				t.Errorf("FilterResults() recorded %d suppressed issues, want %d", len(tt.summary.Suppressed), tt.wantTotalIgnored)
+			}
+			for _, s := range tt.summary.Suppressed {
+				if s.Source != SuppressionBaseline {
+					t.Errorf("suppressed issue source = %q, want %q", s.Source, SuppressionBaseline)
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,61 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/git"
+)
+
+// ApplyBlame records the last commit of each line with a finding, for
+// findings with a line, in the results of summary. Files outside a git
+// repository, untracked files, and uncommitted lines get no blame. A git
+// error on one file is a warning, unless quiet, and leaves that file
+// without blame.
+func ApplyBlame(summary *LintSummary, quiet bool) {
+	if summary == nil || summary.ProjectRoot == "" || !git.IsGitRepo(summary.ProjectRoot) {
+		return
+	}
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		lines := findingLines(result)
+		if len(lines) == 0 {
+			continue
+		}
+		file := result.File
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(summary.ProjectRoot, file)
+		}
+		blame, err := git.BlameFile(summary.ProjectRoot, file)
+		if err != nil {
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "Warning: no blame for %s: %v\n", result.File, err)
+			}
+			continue
+		}
+		result.Blame = nil
+		for line := range lines {
+			if b, ok := blame[line]; ok {
+				if result.Blame == nil {
+					result.Blame = make(map[int]git.LineBlame)
+				}
+				result.Blame[line] = b
+			}
+		}
+	}
+}
+
+// findingLines returns the lines that findings of result are on.
+func findingLines(result *LintResult) map[int]bool {
+	lines := make(map[int]bool)
+	for _, issues := range [][]cue.ValidationError{result.Errors, result.Warnings, result.Suggestions} {
+		for _, issue := range issues {
+			if issue.Line > 0 {
+				lines[issue.Line] = true
+			}
+		}
+	}
+	return lines
+}
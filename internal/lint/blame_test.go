@@ -0,0 +1,66 @@
+package lint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestApplyBlame(t *testing.T) {
+	if err := exec.Command("git", "--version").Run(); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("config", "user.email", "alice@example.com")
+	git("config", "user.name", "Alice")
+	agent := filepath.Join(root, "agents", "a.md")
+	if err := os.MkdirAll(filepath.Dir(agent), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(agent, []byte("---\nname: A\n---\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "add agent")
+	if err := os.WriteFile(agent, []byte("---\nname: A\nmodel: x\n---\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	issue := func(line int) cue.ValidationError {
+		return cue.ValidationError{Message: "m", Severity: cue.SeverityWarning, Line: line}
+	}
+	summary := &LintSummary{
+		ProjectRoot: root,
+		Results: []LintResult{
+			{File: "agents/a.md", Errors: []cue.ValidationError{issue(2)}, Warnings: []cue.ValidationError{issue(3), issue(0)}},
+			{File: filepath.Join(root, "agents", "clean.md")},
+		},
+	}
+	ApplyBlame(summary, true)
+
+	blame := summary.Results[0].Blame
+	if len(blame) != 1 || blame[2].Author != "Alice" || blame[2].Email != "alice@example.com" {
+		t.Errorf("Blame = %+v, want Alice's commit for line 2 only; line 3 is uncommitted", blame)
+	}
+	if summary.Results[1].Blame != nil {
+		t.Errorf("file without findings got blame %v", summary.Results[1].Blame)
+	}
+
+	outside := &LintSummary{ProjectRoot: t.TempDir(), Results: []LintResult{{File: "a.md", Errors: []cue.ValidationError{issue(1)}}}}
+	ApplyBlame(outside, true)
+	if outside.Results[0].Blame != nil {
+		t.Errorf("Blame outside a git repository = %v, want none", outside.Results[0].Blame)
+	}
+}
@@ -1,6 +1,7 @@
 package lint
 
 import (
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
@@ -25,8 +26,9 @@ var (
 )
 
 // NewCommandLinter creates a new CommandLinter.
-func NewCommandLinter() *CommandLinter {
-	return &CommandLinter{}
+// cfg supplies the rule settings; nil uses the defaults.
+func NewCommandLinter(cfg *config.Config) *CommandLinter {
+	return &CommandLinter{BaseLinter: BaseLinter{cfg: cfg}}
 }
 
 func (l *CommandLinter) Type() string {
@@ -46,10 +48,10 @@ func (l *CommandLinter) ValidateCUE(validator *cue.Validator, data map[string]an
 }
 
 func (l *CommandLinter) ValidateSpecific(data map[string]any, filePath, contents string) []cue.ValidationError {
-	errors := validateCommandSpecific(data, filePath, contents)
+	errors := validateCommandSpecific(l.Config(), data, filePath, contents)
 
 	// Validate allowed-tools
-	toolWarnings := textutil.ValidateAllowedTools(data, filePath, contents)
+	toolWarnings := textutil.ValidateAllowedTools(l.Config().ExtraTools, data, filePath, contents)
 	errors = append(errors, toolWarnings...)
 
 	return errors
@@ -0,0 +1,134 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// inlineBashPattern matches inline !`command` pre-execution snippets.
+var inlineBashPattern = regexp.MustCompile("(?:^|\\s)!`([^`]*)`")
+
+// shellSyntaxTimeout bounds a single `sh -n` parse of a preprocessing command.
+const shellSyntaxTimeout = 2 * time.Second
+
+// extractBashDirectives returns the shell commands a body line asks Claude Code
+// to run before the prompt is sent: either the whole line prefixed with "!"
+// (optionally wrapped in backticks) or inline !`command` snippets. ok is false
+// when the line has no directive. Markdown images ("![alt](src)") are skipped.
+func extractBashDirectives(trimmed string) (commands []string, ok bool) {
+	if strings.HasPrefix(trimmed, "![") {
+		return nil, false
+	}
+	if strings.HasPrefix(trimmed, "!") {
+		command := strings.TrimSpace(trimmed[1:])
+		if len(command) >= 2 && strings.HasPrefix(command, "`") && strings.HasSuffix(command, "`") {
+			command = strings.TrimSpace(command[1 : len(command)-1])
+		}
+		return []string{command}, true
+	}
+	for _, m := range inlineBashPattern.FindAllStringSubmatch(trimmed, -1) {
+		commands = append(commands, strings.TrimSpace(m[1]))
+	}
+	return commands, len(commands) > 0
+}
+
+// hasBashDirectives reports whether the command body contains any non-empty
+// preprocessing directive outside frontmatter and code blocks.
+func hasBashDirectives(contents string) bool {
+	found := false
+	withBodyLines(contents, func(_ int, trimmed string) {
+		if found {
+			return
+		}
+		commands, _ := extractBashDirectives(trimmed)
+		for _, c := range commands {
+			if c != "" {
+				found = true
+				return
+			}
+		}
+	})
+	return found
+}
+
+// checkShellSyntax parses command with the shell's -n (no-exec) mode. bash is
+// preferred since Claude Code runs directives through the user's shell; plain
+// sh is the fallback. Returns nil when the command parses, when no shell is
+// installed, or when the parse times out — the check is advisory only.
+func checkShellSyntax(command string) error {
+	shell, err := exec.LookPath("bash")
+	if err != nil {
+		if shell, err = exec.LookPath("sh"); err != nil {
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shellSyntaxTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, shell, "-n", "-c", command).CombinedOutput() //nolint:gosec // G204: -n parses without executing
+	if err == nil || ctx.Err() != nil {
+		return nil
+	}
+	return errors.New(shellErrorSummary(string(out)))
+}
+
+// shellErrorSummary reduces shell parse output such as
+// "bash: -c: line 1: syntax error near unexpected token `)'" to its first
+// "syntax error ..." clause.
+func shellErrorSummary(out string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	if idx := strings.Index(strings.ToLower(line), "syntax error"); idx >= 0 {
+		return line[idx:]
+	}
+	if line == "" {
+		return "syntax error"
+	}
+	return line
+}
+
+// checkBashDirectivesAllowed warns when the body pre-executes shell commands
+// but allowed-tools does not grant Bash; Claude Code refuses to run the
+// directives without it.
+func checkBashDirectivesAllowed(data map[string]any, filePath, contents string) []cue.ValidationError {
+	if !hasBashDirectives(contents) {
+		return nil
+	}
+
+	var tools []string
+	switch v := data["allowed-tools"].(type) {
+	case string:
+		tools = textutil.SplitToolList(v)
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				tools = append(tools, strings.TrimSpace(s))
+			}
+		}
+	}
+	for _, tool := range tools {
+		if tool == "*" || textutil.ExtractBaseToolName(tool) == "Bash" {
+			return nil
+		}
+	}
+
+	line := textutil.FindFrontmatterFieldLine(contents, "allowed-tools")
+	if line == 0 {
+		line = textutil.GetFrontmatterEndLine(contents)
+	}
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  fmt.Sprintf("Command uses '!' preprocessing directives but allowed-tools does not include Bash. Add a scoped entry such as %q.", "Bash(git status:*)"),
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleCommandBashNotAllowed,
+		Line:     line,
+	}}
+}
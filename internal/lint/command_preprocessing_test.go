@@ -0,0 +1,116 @@
+package lint
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestExtractBashDirectives(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		want   []string
+		wantOK bool
+	}{
+		{"plain line", "Just text", nil, false},
+		{"prefixed line", "!git status", []string{"git status"}, true},
+		{"prefixed backticks", "!`git diff HEAD`", []string{"git diff HEAD"}, true},
+		{"empty directive", "!", []string{""}, true},
+		{"inline snippets", "Branch: !`git branch --show-current`, log: !`git log -1`", []string{"git branch --show-current", "git log -1"}, true},
+		{"markdown image", "![diagram](arch.png)", nil, false},
+		{"exclamation in prose", "Done! Now run tests", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := extractBashDirectives(tt.line)
+			if ok != tt.wantOK || len(got) != len(tt.want) {
+				t.Fatalf("extractBashDirectives(%q) = %q, %v; want %q, %v", tt.line, got, ok, tt.want, tt.wantOK)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("extractBashDirectives(%q)[%d] = %q, want %q", tt.line, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCommandPreprocessingSyntax(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no POSIX shell available")
+	}
+
+	tests := []struct {
+		name       string
+		contents   string
+		wantSyntax bool
+	}{
+		{"valid pipeline", "---\nname: test\n---\n!git log --oneline | head -5", false},
+		{"valid inline", "---\nname: test\n---\nStatus: !`git status --short`", false},
+		{"unterminated quote", "---\nname: test\n---\n!echo \"unterminated", true},
+		{"unbalanced if", "---\nname: test\n---\n!`if true; then echo hi`", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := validateCommandPreprocessing("commands/test.md", tt.contents)
+			gotSyntax := false
+			for _, issue := range issues {
+				if issue.Rule == cue.RuleCommandBashSyntax {
+					gotSyntax = true
+					if issue.Line != 4 {
+						t.Errorf("syntax issue line = %d, want 4", issue.Line)
+					}
+				}
+			}
+			if gotSyntax != tt.wantSyntax {
+				t.Errorf("syntax issue = %v, want %v (issues: %v)", gotSyntax, tt.wantSyntax, issues)
+			}
+		})
+	}
+}
+
+func TestCheckBashDirectivesAllowed(t *testing.T) {
+	body := "\n---\n!`git status`"
+	tests := []struct {
+		name     string
+		contents string
+		data     map[string]any
+		wantLine int // 0 means no warning expected
+	}{
+		{"no directives", "---\nname: test\n---\nPlain body", map[string]any{}, 0},
+		{"directive without allowed-tools", "---\nname: test" + body, map[string]any{}, 3},
+		{"directive without Bash", "---\nallowed-tools: Task" + body, map[string]any{"allowed-tools": "Task"}, 2},
+		{"scoped Bash", "---\nallowed-tools: Bash(git status:*)" + body, map[string]any{"allowed-tools": "Bash(git status:*)"}, 0},
+		{"Bash in list", "---\nallowed-tools: x" + body, map[string]any{"allowed-tools": []any{"Task", "Bash"}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := checkBashDirectivesAllowed(tt.data, "commands/test.md", tt.contents)
+			if (len(issues) > 0) != (tt.wantLine > 0) {
+				t.Fatalf("checkBashDirectivesAllowed() = %v, want warning at line %d", issues, tt.wantLine)
+			}
+			if tt.wantLine > 0 && issues[0].Line != tt.wantLine {
+				t.Errorf("warning line = %d, want %d", issues[0].Line, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestCheckCommandToolAllowlistBashWithDirectives(t *testing.T) {
+	data := map[string]any{"allowed-tools": "Task, Bash(git status:*)"}
+
+	withDirective := "---\nallowed-tools: Task, Bash(git status:*)\n---\n!`git status`"
+	if issues := checkCommandToolAllowlist(data, "commands/test.md", withDirective); len(issues) != 0 {
+		t.Errorf("Bash required by directives should not warn, got %v", issues)
+	}
+
+	without := "---\nallowed-tools: Task, Bash(git status:*)\n---\nNo directives"
+	if issues := checkCommandToolAllowlist(data, "commands/test.md", without); len(issues) != 1 {
+		t.Errorf("Bash without directives should warn once, got %v", issues)
+	}
+}
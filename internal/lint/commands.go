@@ -3,10 +3,12 @@ package lint
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/textutil"
 )
@@ -15,12 +17,12 @@ import (
 const frontmatterDelimiter = "---"
 
 // LintCommands runs linting on command files using the generic linter.
-func LintCommands(rootPath string, quiet bool, verbose bool, noCycleCheck bool, exclude []string) (*LintSummary, error) {
-	ctx, err := NewLinterContext(rootPath, quiet, verbose, noCycleCheck, exclude)
+func LintCommands(opts Options) (*LintSummary, error) {
+	ctx, err := NewLinterContext(opts)
 	if err != nil {
 		return nil, err
 	}
-	return lintBatch(ctx, NewCommandLinter()), nil
+	return lintBatch(ctx, NewCommandLinter(ctx.Config)), nil
 }
 
 // knownCommandFields lists valid frontmatter fields per Anthropic docs
@@ -35,18 +37,22 @@ var knownCommandFields = map[string]bool{
 	"disable-model-invocation": true, // Optional: prevent SlashCommand tool from calling
 	"hooks":                    true, // Optional: command-level hooks (PreToolUse, PostToolUse, Stop)
 	"triggers":                 true,
+	"enabled":                  true, // cclint: false excludes the command from cross-file analysis
+	"disabled":                 true, // cclint: true excludes the command from cross-file analysis
 }
 
 // validateCommandSpecific implements command-specific validation rules
-func validateCommandSpecific(data map[string]any, filePath string, contents string) []cue.ValidationError {
+func validateCommandSpecific(cfg *config.Config, data map[string]any, filePath string, contents string) []cue.ValidationError {
 	var errors []cue.ValidationError
 
 	// Check for unknown frontmatter fields - helps catch fabricated/deprecated fields
-	errors = append(errors, checkUnknownFields(data, filePath, contents, unknownFieldCheck{
-		known:    knownCommandFields,
-		label:    "frontmatter field",
-		suffix:   ". Valid fields: " + sortedMapKeys(knownCommandFields),
-		findLine: textutil.FindFrontmatterFieldLine,
+	errors = append(errors, checkUnknownFields(cfg, data, filePath, contents, unknownFieldCheck{
+		known:     knownCommandFields,
+		label:     "frontmatter field",
+		suffix:    ". Valid fields: " + sortedMapKeys(knownCommandFields),
+		rule:      cue.RuleFrontmatterUnknownKey,
+		findLine:  textutil.FindFrontmatterFieldLine,
+		component: cue.TypeCommand,
 	})...)
 
 	// Note: name is optional in frontmatter - it's derived from filename (per Anthropic docs)
@@ -58,6 +64,7 @@ func validateCommandSpecific(data map[string]any, filePath string, contents stri
 				Message:  "Name must be lowercase alphanumeric with hyphens only",
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleNameFormat,
 			})
 		}
 	}
@@ -68,6 +75,18 @@ func validateCommandSpecific(data map[string]any, filePath string, contents stri
 	// Validate allowed-tools only contains permitted tools
 	errors = append(errors, checkCommandToolAllowlist(data, filePath, contents)...)
 
+	// Validate allowed-tools grants Bash when the body pre-executes shell commands
+	errors = append(errors, checkBashDirectivesAllowed(data, filePath, contents)...)
+
+	// Flag scaffold placeholders left unfilled in the body
+	errors = append(errors, validateTemplatePlaceholders(filePath, contents, cfg.Rules.TemplateVariables)...)
+
+	// Flag template text an init tool or copied example left behind
+	errors = append(errors, checkScaffoldLeftovers(data, filePath, contents)...)
+
+	// Warn about deprecated or retired models
+	errors = append(errors, checkModelCatalog(modelCatalog(cfg), data, filePath, contents)...)
+
 	return errors
 }
 
@@ -96,23 +115,24 @@ func checkCommandToolAllowlist(data map[string]any, filePath string, contents st
 			Message:  `command declares wildcard "*" in allowed-tools — commands should only use Task, Agent, Skill, AskUserQuestion`,
 			Severity: cue.SeverityError,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCommandToolsWildcard,
 			Line:     line,
 		}}
 	}
 
+	// Bash is required, not a smell, when the body has ! pre-execution lines
+	usesBash := hasBashDirectives(contents)
+
 	var errors []cue.ValidationError
-	for tool := range strings.SplitSeq(tools, ",") {
-		tool = strings.TrimSpace(tool)
-		if tool == "" {
-			continue
-		}
+	for _, tool := range textutil.SplitToolList(tools) {
 		base := textutil.ExtractBaseToolName(tool)
-		if !commandAllowedTools[base] {
+		if !commandAllowedTools[base] && (base != "Bash" || !usesBash) {
 			errors = append(errors, cue.ValidationError{
 				File:     filePath,
 				Message:  fmt.Sprintf("command declares tool %q in allowed-tools — commands should prefer delegation tools (Task, Agent, Skill, AskUserQuestion)", tool),
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandToolNotDelegation,
 				Line:     line,
 			})
 		}
@@ -154,6 +174,7 @@ func checkSkillWithoutTaskDelegation(filePath string, contents string, data map[
 		Message:  "command dispatches to Skill() without Task() delegation — commands must delegate through agents",
 		Severity: cue.SeverityError,
 		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleCommandSkillWithoutTask,
 	}}
 }
 
@@ -204,6 +225,7 @@ func checkImplementationPatterns(contents, filePath string) []cue.ValidationErro
 			Message:  "Command contains implementation steps. Consider delegating to a specialist agent instead.",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCommandImplementation,
 		}}
 	}
 	return nil
@@ -216,6 +238,7 @@ func checkAllowedToolsWithTask(contents, filePath string) []cue.ValidationError
 			Message:  "Command uses Task() but lacks 'allowed-tools' permission. Add 'allowed-tools: Task' to frontmatter.",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCommandTaskNotAllowed,
 		}}
 	}
 	return nil
@@ -245,6 +268,7 @@ func checkBloatSections(contents, filePath string, hasTaskDelegation bool) []cue
 				Message:  section.message,
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandBloatSection,
 			})
 		}
 	}
@@ -259,6 +283,7 @@ func checkExcessiveExamples(contents, filePath string) []cue.ValidationError {
 			Message:  fmt.Sprintf("Command has %d code examples. Best practice: max 2 examples.", exampleCount),
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCommandExamples,
 		}}
 	}
 	return nil
@@ -273,6 +298,7 @@ func checkSuccessCriteriaFormat(contents, filePath string) []cue.ValidationError
 			Message:  "Success criteria should use checkbox format '- [ ]' not prose",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCommandSuccessCriteria,
 		}}
 	}
 	return nil
@@ -285,6 +311,7 @@ func checkFatCommandUsage(contents, filePath string, lines int, hasTaskDelegatio
 			Message:  "Fat command without Task delegation lacks '## Usage' section. Consider delegating to a specialist agent.",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCommandUsageMissing,
 		}}
 	}
 	return nil
@@ -357,26 +384,41 @@ func validateCommandPreprocessing(filePath string, contents string) []cue.Valida
 // appendPreprocessingIssues checks for preprocessing directives and adds issues.
 // Returns the updated issues slice.
 func appendPreprocessingIssues(issues []cue.ValidationError, trimmed, filePath string, lineNum int) []cue.ValidationError {
-	// Must start with ! to be a preprocessing directive
-	if !strings.HasPrefix(trimmed, "!") {
+	directives, ok := extractBashDirectives(trimmed)
+	if !ok {
 		return issues
 	}
 
-	command := strings.TrimSpace(trimmed[1:])
+	for _, command := range directives {
+		// Empty command after !
+		if command == "" {
+			issues = append(issues, cue.ValidationError{
+				File:     filePath,
+				Message:  "Empty preprocessing directive '!' with no command",
+				Severity: cue.SeverityError,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandPreprocessEmpty,
+				Line:     lineNum,
+			})
+			continue
+		}
+
+		// Check for dangerous patterns
+		issues = appendDangerousPatternIssue(issues, command, filePath, lineNum)
 
-	// Empty command after !
-	if command == "" {
-		return append(issues, cue.ValidationError{
-			File:     filePath,
-			Message:  "Empty preprocessing directive '!' with no command",
-			Severity: cue.SeverityError,
-			Source:   cue.SourceCClintObserve,
-			Line:     lineNum,
-		})
+		// Check the snippet parses as shell
+		if err := checkShellSyntax(command); err != nil {
+			issues = append(issues, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Preprocessing command %q has a shell syntax error: %v", command, err),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandBashSyntax,
+				Line:     lineNum,
+			})
+		}
 	}
-
-	// Check for dangerous patterns
-	return appendDangerousPatternIssue(issues, command, filePath, lineNum)
+	return issues
 }
 
 // appendDangerousPatternIssue checks for dangerous command patterns and adds an issue if found.
@@ -388,6 +430,7 @@ func appendDangerousPatternIssue(issues []cue.ValidationError, command, filePath
 				Message:  fmt.Sprintf("Dangerous preprocessing command: %s", dp.message),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandPreprocessDangerous,
 				Line:     lineNum,
 			})
 			break
@@ -402,9 +445,15 @@ var positionalArgPattern = regexp.MustCompile(`\$(\d+)`)
 // argumentsPattern matches $ARGUMENTS substitution variable.
 var argumentsPattern = regexp.MustCompile(`\$ARGUMENTS`)
 
+// hintPlaceholderPattern matches one argument placeholder in an argument-hint,
+// such as "<file>" or "[--force]".
+var hintPlaceholderPattern = regexp.MustCompile(`<[^<>]+>|\[[^\[\]]+\]`)
+
 // validateCommandSubstitution checks $ARGUMENTS and $N substitution variable usage.
 // Commands using substitution should declare argument-hint for discoverability,
-// positional args should be sequential, and high positional args are likely unintended.
+// a declared argument-hint should be referenced by the body and cover every
+// positional arg, positional args should be sequential, and high positional
+// args are likely unintended.
 func validateCommandSubstitution(filePath string, contents string, data map[string]any) []cue.ValidationError {
 	var issues []cue.ValidationError
 
@@ -414,21 +463,34 @@ func validateCommandSubstitution(filePath string, contents string, data map[stri
 	hasArguments := argumentsPattern.MatchString(body)
 	positionalMatches := positionalArgPattern.FindAllStringSubmatch(body, -1)
 
-	// No substitution variables found - nothing to validate
-	if !hasArguments && len(positionalMatches) == 0 {
-		return nil
-	}
-
 	// Collect unique positional arg numbers
 	positionalNums := collectPositionalArgs(positionalMatches)
 
+	hint, hasHint := data["argument-hint"]
+
+	// No substitution variables found - a declared hint is never referenced
+	if !hasArguments && len(positionalNums) == 0 {
+		if hintStr, ok := hint.(string); ok && strings.TrimSpace(hintStr) != "" {
+			issues = append(issues, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("Command declares argument-hint %q but its body never references $ARGUMENTS or $1..$9. Reference the arguments or remove the hint.", hintStr),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandArgHintUnused,
+				Line:     textutil.FindFrontmatterFieldLine(contents, "argument-hint"),
+			})
+		}
+		return issues
+	}
+
 	// Check: commands using substitution should have argument-hint for discoverability
-	if _, hasHint := data["argument-hint"]; !hasHint {
+	if !hasHint {
 		issues = append(issues, cue.ValidationError{
 			File:     filePath,
 			Message:  "Command uses substitution variables ($ARGUMENTS or $N) but lacks 'argument-hint' in frontmatter. Add argument-hint to describe expected arguments.",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCommandArgHintMissing,
 			Line:     textutil.GetFrontmatterEndLine(contents),
 		})
 	}
@@ -436,9 +498,36 @@ func validateCommandSubstitution(filePath string, contents string, data map[stri
 	// Check: positional args start at $1, are sequential, and stay below $10.
 	issues = append(issues, checkPositionalArgSequence(positionalNums, filePath, contents)...)
 
+	// Check: the hint describes at least as many arguments as the body uses.
+	if hintStr, ok := hint.(string); ok {
+		issues = append(issues, checkArgumentHintArity(hintStr, positionalNums, filePath, contents)...)
+	}
+
 	return issues
 }
 
+// checkArgumentHintArity flags positional args beyond the number of
+// placeholders ("<file>", "[--force]") declared in argument-hint. Free-form
+// hints without placeholders are not checked.
+func checkArgumentHintArity(hint string, positionalNums []int, filePath, contents string) []cue.ValidationError {
+	placeholders := len(hintPlaceholderPattern.FindAllString(hint, -1))
+	if placeholders == 0 || len(positionalNums) == 0 {
+		return nil
+	}
+	maxArg := slices.Max(positionalNums)
+	if maxArg <= placeholders {
+		return nil
+	}
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  fmt.Sprintf("Command uses $%d but argument-hint %q describes only %d argument(s). Add the missing arguments to the hint.", maxArg, hint, placeholders),
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleCommandArgHintArity,
+		Line:     textutil.FindFrontmatterFieldLine(contents, "argument-hint"),
+	}}
+}
+
 // checkPositionalArgSequence flags positional args that don't start at $1, skip
 // a number, or reach the $10+ "likely unintended" range. positionalNums is
 // sorted in place; returns nil when there are no positional args.
@@ -455,6 +544,7 @@ func checkPositionalArgSequence(positionalNums []int, filePath, contents string)
 				Message:  fmt.Sprintf("Positional argument $%d used without $1. Arguments should start at $1.", n),
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandPositionalGap,
 				Line:     findSubstitutionLine(contents, fmt.Sprintf("$%d", n)),
 			})
 			break
@@ -465,6 +555,7 @@ func checkPositionalArgSequence(positionalNums []int, filePath, contents string)
 				Message:  fmt.Sprintf("Positional argument gap: $%d used without $%d. Arguments should be sequential.", n, positionalNums[idx-1]+1),
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandPositionalGap,
 				Line:     findSubstitutionLine(contents, fmt.Sprintf("$%d", n)),
 			})
 			break
@@ -478,6 +569,7 @@ func checkPositionalArgSequence(positionalNums []int, filePath, contents string)
 				Message:  fmt.Sprintf("High positional argument $%d detected. Commands with 10+ arguments are likely unintended. Consider using $ARGUMENTS instead.", maxArg),
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleCommandPositionalHigh,
 				Line:     findSubstitutionLine(contents, fmt.Sprintf("$%d", maxArg)),
 			})
 	}
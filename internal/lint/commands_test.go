@@ -111,7 +111,7 @@ func TestValidateCommandSpecific(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := validateCommandSpecific(tt.data, tt.filePath, tt.contents)
+			errors := validateCommandSpecific(defaultConfig(), tt.data, tt.filePath, tt.contents)
 
 			errCount := 0
 			suggCount := 0
@@ -456,8 +456,29 @@ func TestValidateCommandSubstitution(t *testing.T) {
 			name:         "$5 without $1 (gap from start, high jump)",
 			contents:     "---\nname: test\nargument-hint: <a>\n---\nUse $5 here",
 			data:         map[string]any{"name": "test", "argument-hint": "<a>"},
+			wantWarnings: 2,
+			wantContains: []string{"$5 used without $1", "describes only 1 argument"},
+		},
+		{
+			name:         "argument-hint declared but never referenced",
+			contents:     "---\nname: test\nargument-hint: <query>\n---\nJust a normal body",
+			data:         map[string]any{"name": "test", "argument-hint": "<query>"},
+			wantWarnings: 1,
+			wantContains: []string{"never references $ARGUMENTS"},
+		},
+		{
+			name:         "positional beyond hint placeholders",
+			contents:     "---\nname: test\nargument-hint: <file> [--force]\n---\nUse $1 $2 $3",
+			data:         map[string]any{"name": "test", "argument-hint": "<file> [--force]"},
 			wantWarnings: 1,
-			wantContains: []string{"$5 used without $1"},
+			wantContains: []string{"uses $3 but argument-hint"},
+		},
+		{
+			name:         "free-form hint skips arity check",
+			contents:     "---\nname: test\nargument-hint: file and options\n---\nUse $1 $2 $3",
+			data:         map[string]any{"name": "test", "argument-hint": "file and options"},
+			wantWarnings: 0,
+			wantSuggs:    0,
 		},
 	}
 
@@ -0,0 +1,183 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// plainToolNamePattern matches a matcher alternative that names one tool
+// rather than a regex ("Bash", not "mcp__.*" or "Edit.*").
+var plainToolNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+// validateHookToolCoverage joins a component's frontmatter hooks with its
+// tool list (tools for agents, allowed-tools for skills). A tool hook whose
+// matcher names only tools the component cannot use never fires, and a
+// command hook that can fire but runs a script that does not exist fails
+// every time it does. Scripts resolve against rootPath, with
+// ${CLAUDE_PLUGIN_ROOT} expanded for components inside a plugin; the script
+// check is skipped when rootPath is empty. Malformed hooks are left to
+// ValidateComponentHooks.
+func validateHookToolCoverage(extraTools []string, data map[string]any, toolsField, rootPath, filePath, contents string) []cue.ValidationError {
+	hooks, ok := data["hooks"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	declared, restricted := declaredToolSet(data[toolsField])
+	line := textutil.FindFrontmatterFieldLine(contents, "hooks")
+	issue := func(rule, msg string) cue.ValidationError {
+		return cue.ValidationError{
+			File:     filePath,
+			Message:  msg,
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     rule,
+			Line:     line,
+		}
+	}
+
+	var errors []cue.ValidationError
+	for _, event := range slices.Sorted(maps.Keys(hooks)) {
+		entries, _ := hooks[event].([]any)
+		for i, entry := range entries {
+			entryMap, ok := entry.(map[string]any)
+			if !ok {
+				continue
+			}
+			if matcherRequiredEvents[event] && restricted {
+				if tools := hookMatcherTools(entryMap["matcher"], extraTools); len(tools) > 0 {
+					missing := slices.DeleteFunc(tools, func(t string) bool { return declaresTool(declared, t) })
+					if len(missing) == len(tools) {
+						errors = append(errors, issue(cue.RuleHookToolUnreachable,
+							fmt.Sprintf("Event '%s' hook %d matches %s, which %s does not grant; the hook can never fire", event, i, strings.Join(missing, ", "), toolsField)))
+						continue
+					}
+				}
+			}
+			if rootPath == "" {
+				continue
+			}
+			for _, command := range hookCommands(entryMap) {
+				if msg := checkHookScript(command, rootPath, filePath); msg != "" {
+					errors = append(errors, issue(cue.RuleHookScriptMissing,
+						fmt.Sprintf("Event '%s' hook %d: %s", event, i, msg)))
+				}
+			}
+		}
+	}
+	return errors
+}
+
+// declaredToolSet returns the base names of the tools a tool list grants.
+// restricted is false when the field is absent or holds the "*" wildcard,
+// so every tool is available.
+func declaredToolSet(tools any) (declared map[string]bool, restricted bool) {
+	entries := extractDeclaredTools(tools)
+	if entries == nil || entries["*"] {
+		return nil, false
+	}
+	declared = make(map[string]bool, len(entries))
+	for entry := range entries {
+		declared[canonicalToolName(entry)] = true
+	}
+	return declared, true
+}
+
+// declaresTool reports whether declared grants tool. Task and Agent are the
+// old and new names of the same tool.
+func declaresTool(declared map[string]bool, tool string) bool {
+	switch tool {
+	case "Task", "Agent":
+		return declared["Task"] || declared["Agent"]
+	}
+	return declared[tool]
+}
+
+// hookMatcherTools returns the known tools, built-in or in extraTools, a
+// hook matcher names, or nil
+// when any alternative is a pattern (such as "*" or "mcp__.*") that cannot
+// be checked statically. Matchers are either a string like "Edit|Write" or
+// an object with a toolName.
+func hookMatcherTools(matcher any, extraTools []string) []string {
+	var pattern string
+	switch m := matcher.(type) {
+	case string:
+		pattern = m
+	case map[string]any:
+		name, _ := m["toolName"].(string)
+		pattern = textutil.ExtractBaseToolName(name)
+	}
+	if pattern == "" {
+		return nil
+	}
+	var tools []string
+	for alt := range strings.SplitSeq(pattern, "|") {
+		alt = strings.TrimSpace(alt)
+		if !plainToolNamePattern.MatchString(alt) || !isKnownTool(alt, extraTools) {
+			return nil
+		}
+		tools = append(tools, alt)
+	}
+	return tools
+}
+
+// hookCommands returns the command strings of a hook entry's command hooks.
+func hookCommands(entry map[string]any) []string {
+	inner, _ := entry["hooks"].([]any)
+	var commands []string
+	for _, h := range inner {
+		hook, ok := h.(map[string]any)
+		if !ok || hook["type"] != cue.TypeCommand {
+			continue
+		}
+		if command, ok := hook["command"].(string); ok && command != "" {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// checkHookScript returns a problem description when command runs a script
+// that does not exist, or "" when it exists or cannot be resolved
+// statically.
+func checkHookScript(command, rootPath, filePath string) string {
+	if strings.Contains(command, "CLAUDE_PLUGIN_ROOT") {
+		path := filePath
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(rootPath, path)
+		}
+		root := pluginRootOf(path)
+		if root == "" {
+			return ""
+		}
+		command = strings.NewReplacer("${CLAUDE_PLUGIN_ROOT}", root, "$CLAUDE_PLUGIN_ROOT", root).Replace(command)
+	}
+	script, _, ok := resolveCommandScript(command, rootPath)
+	if !ok {
+		return ""
+	}
+	if _, err := os.Stat(script); err != nil {
+		return fmt.Sprintf("command script %q does not exist", script)
+	}
+	return ""
+}
+
+// pluginRootOf returns the nearest ancestor directory of path that holds a
+// .claude-plugin directory, or "" when path is not inside a plugin.
+func pluginRootOf(path string) string {
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if info, err := os.Stat(filepath.Join(dir, ".claude-plugin")); err == nil && info.IsDir() {
+			return dir
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestValidateHookToolCoverage(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{".claude/hooks", "plugins/kit/.claude-plugin", "plugins/kit/agents", "plugins/kit/scripts"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{".claude/hooks/guard.sh", "plugins/kit/scripts/fmt.sh"} {
+		if err := os.WriteFile(filepath.Join(root, f), []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hook := func(event, matcher, command string) string {
+		return "hooks:\n  " + event + ":\n    - matcher: \"" + matcher + "\"\n      hooks:\n        - type: command\n          command: " + command + "\n"
+	}
+	tests := []struct {
+		name     string
+		filePath string
+		field    string
+		fm       string
+		want     []string // rule: message fragment, in order
+	}{
+		{
+			name:  "hook on an undeclared tool",
+			field: "tools",
+			fm:    "tools: Read, Grep\n" + hook("PreToolUse", "Bash", "echo ok"),
+			want:  []string{cue.RuleHookToolUnreachable + ": matches Bash, which tools does not grant"},
+		},
+		{
+			name:  "one matched tool is declared",
+			field: "tools",
+			fm:    "tools: Read, Edit\n" + hook("PostToolUse", "Edit|Write", "echo ok"),
+		},
+		{
+			name:  "scoped tool entry grants the tool",
+			field: "allowed-tools",
+			fm:    "allowed-tools: Bash(git:*)\n" + hook("PreToolUse", "Bash", "echo ok"),
+		},
+		{
+			name:  "Task grants Agent",
+			field: "tools",
+			fm:    "tools: Task\n" + hook("PreToolUse", "Agent", "echo ok"),
+		},
+		{
+			name:  "no tools field grants everything",
+			field: "tools",
+			fm:    hook("PreToolUse", "Bash", "echo ok"),
+		},
+		{
+			name:  "regex matcher is not checked",
+			field: "tools",
+			fm:    "tools: Read\n" + hook("PreToolUse", "mcp__.*", "echo ok"),
+		},
+		{
+			name:  "missing project script",
+			field: "tools",
+			fm:    "tools: Bash\n" + hook("PreToolUse", "Bash", `"$CLAUDE_PROJECT_DIR/.claude/hooks/missing.sh"`),
+			want:  []string{cue.RuleHookScriptMissing + ": missing.sh\" does not exist"},
+		},
+		{
+			name:  "existing project script on a lifecycle event",
+			field: "tools",
+			fm:    "tools: Read\nhooks:\n  Stop:\n    - hooks:\n        - type: command\n          command: bash .claude/hooks/guard.sh\n",
+		},
+		{
+			name:  "unreachable hook is not also checked for its script",
+			field: "tools",
+			fm:    "tools: Read\n" + hook("PreToolUse", "Bash", "./missing.sh"),
+			want:  []string{cue.RuleHookToolUnreachable + ": matches Bash"},
+		},
+		{
+			name:     "plugin root scripts",
+			filePath: "plugins/kit/agents/a.md",
+			field:    "tools",
+			fm: "tools: Edit\nhooks:\n  PostToolUse:\n    - matcher: Edit\n      hooks:\n" +
+				"        - type: command\n          command: ${CLAUDE_PLUGIN_ROOT}/scripts/fmt.sh\n" +
+				"        - type: command\n          command: ${CLAUDE_PLUGIN_ROOT}/scripts/gone.sh\n",
+			want: []string{cue.RuleHookScriptMissing + ": gone.sh\" does not exist"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents := "---\nname: a\n" + tt.fm + "---\nbody\n"
+			data, _, err := parseFrontmatter(contents)
+			if err != nil {
+				t.Fatal(err)
+			}
+			filePath := tt.filePath
+			if filePath == "" {
+				filePath = "agents/a.md"
+			}
+			got := validateHookToolCoverage(nil, data, tt.field, root, filePath, contents)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d findings, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				rule, fragment, _ := strings.Cut(want, ": ")
+				if got[i].Rule != rule || !strings.Contains(got[i].Message, fragment) {
+					t.Errorf("finding %d = %s %q, want %s containing %q", i, got[i].Rule, got[i].Message, rule, fragment)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateHookToolCoverage_NoRootSkipsScripts(t *testing.T) {
+	contents := "---\nname: a\ntools: Bash\nhooks:\n  PreToolUse:\n    - matcher: Bash\n      hooks:\n        - type: command\n          command: ./missing.sh\n---\nbody\n"
+	data, _, err := parseFrontmatter(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := validateHookToolCoverage(nil, data, "tools", "", "agents/a.md", contents); len(got) != 0 {
+		t.Fatalf("expected no findings without a root, got %v", got)
+	}
+}
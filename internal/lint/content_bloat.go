@@ -0,0 +1,143 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+const (
+	// defaultLineLengthMax is the line length, in characters, past which a
+	// line is reported as minified or pasted content.
+	defaultLineLengthMax = 2000
+	// defaultBase64LengthMax is the length, in characters, past which an
+	// embedded base64 run is reported.
+	defaultBase64LengthMax = 1024
+)
+
+// base64RunPattern matches a run of base64 characters, with its padding.
+// RE2 caps repetition counts at 1000, so runs are matched from 64
+// characters and filtered by length afterwards.
+var base64RunPattern = regexp.MustCompile(`[A-Za-z0-9+/]{64,}={0,2}`)
+
+// bloatRules are the rules CheckContentBloat reports; a file with any of
+// them counts toward LintSummary.BloatedFiles.
+var bloatRules = map[string]bool{
+	cue.RuleContentLongLine:    true,
+	cue.RuleContentBase64Blob:  true,
+	cue.RuleContentInvalidUTF8: true,
+}
+
+// CheckContentBloat reports content in a markdown component that inflates
+// the context window and usually comes from an accidental paste: lines
+// longer than lineLengthMax characters, base64 runs longer than
+// base64LengthMax, and bytes that are not valid UTF-8. The limits come from
+// the rules.lineLengthMax and rules.base64LengthMax config keys; 0 selects
+// a default. Long lines are reported once per file, with a count; a line
+// holding a reported base64 blob does not also count as long.
+func CheckContentBloat(contents, filePath string, lineLengthMax, base64LengthMax int) []cue.ValidationError {
+	if !strings.EqualFold(filepath.Ext(filePath), ".md") {
+		return nil
+	}
+	if lineLengthMax <= 0 {
+		lineLengthMax = defaultLineLengthMax
+	}
+	if base64LengthMax <= 0 {
+		base64LengthMax = defaultBase64LengthMax
+	}
+	issue := func(rule string, line int, msg string) cue.ValidationError {
+		return cue.ValidationError{
+			File:     filePath,
+			Message:  msg,
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     rule,
+			Line:     line,
+		}
+	}
+
+	var out []cue.ValidationError
+	if !utf8.ValidString(contents) {
+		line, count := invalidUTF8(contents)
+		out = append(out, issue(cue.RuleContentInvalidUTF8, line,
+			fmt.Sprintf("Contains %s of invalid UTF-8, first on line %d; the file may be binary or mis-encoded. Re-save it as UTF-8 text", pluralCount(count, "byte sequence"), line)))
+	}
+
+	var longLines []int
+	longest, longestLine := 0, 0
+	for i, line := range strings.Split(contents, "\n") {
+		blob := false
+		for _, m := range base64RunPattern.FindAllString(line, -1) {
+			if len(m) > base64LengthMax {
+				blob = true
+				out = append(out, issue(cue.RuleContentBase64Blob, i+1,
+					fmt.Sprintf("Embedded base64 data (%s characters, over %d); move it to a file and reference the path instead", formatThousands(len(m)), base64LengthMax)))
+			}
+		}
+		if n := utf8.RuneCountInString(line); n > lineLengthMax && !blob {
+			longLines = append(longLines, i+1)
+			if n > longest {
+				longest, longestLine = n, i+1
+			}
+		}
+	}
+	if len(longLines) > 0 {
+		out = append(out, issue(cue.RuleContentLongLine, longLines[0],
+			fmt.Sprintf("%s longer than %d characters (longest: line %d, %s characters), which suggests minified or pasted content; wrap the text or move the data to a file",
+				pluralCount(len(longLines), "line"), lineLengthMax, longestLine, formatThousands(longest))))
+	}
+	return out
+}
+
+// invalidUTF8 returns the line of the first invalid UTF-8 sequence in s and
+// the number of invalid sequences.
+func invalidUTF8(s string) (firstLine, count int) {
+	line := 1
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			if count == 0 {
+				firstLine = line
+			}
+			count++
+		case r == '\n':
+			line++
+		}
+		i += size
+	}
+	return firstLine, count
+}
+
+// hasBloatedContent reports whether a result has a content bloat finding.
+func hasBloatedContent(result LintResult) bool {
+	for _, issues := range [][]cue.ValidationError{result.Errors, result.Warnings, result.Suggestions} {
+		for _, issue := range issues {
+			if bloatRules[issue.Rule] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pluralCount formats n with noun, pluralized with "s" unless n is 1.
+func pluralCount(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// formatThousands formats n with comma thousands separators.
+func formatThousands(n int) string {
+	s := fmt.Sprint(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
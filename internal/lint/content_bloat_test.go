@@ -0,0 +1,110 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestCheckContentBloat(t *testing.T) {
+	blob := strings.Repeat("QUJD", 300) // 1,200 base64 characters
+	long := strings.Repeat("word ", 500)
+
+	tests := []struct {
+		name     string
+		filePath string
+		contents string
+		want     []string // rule:line per finding, in order
+		message  string   // substring of the first finding
+	}{
+		{
+			name:     "clean",
+			filePath: "agents/a.md",
+			contents: "---\nname: a\n---\nShort lines only.\n",
+		},
+		{
+			name:     "long lines are reported once",
+			filePath: "agents/a.md",
+			contents: "intro\n" + long + "\n" + long + "x\n",
+			want:     []string{"content-long-line:2"},
+			message:  "2 lines longer than 2000 characters (longest: line 3, 2,501 characters)",
+		},
+		{
+			name:     "base64 blob",
+			filePath: "skills/s/SKILL.md",
+			contents: "![logo](data:image/png;base64," + blob + ")\n",
+			want:     []string{"content-base64-blob:1"},
+			message:  "Embedded base64 data (1,200 characters, over 1024)",
+		},
+		{
+			name:     "a long line holding a blob counts only as a blob",
+			filePath: "agents/a.md",
+			contents: "data: " + strings.Repeat(blob, 2) + "\n",
+			want:     []string{"content-base64-blob:1"},
+		},
+		{
+			name:     "short base64 and hashes",
+			filePath: "agents/a.md",
+			contents: "sha: " + strings.Repeat("ab12", 32) + "\n",
+		},
+		{
+			name:     "invalid UTF-8",
+			filePath: "commands/c.md",
+			contents: "ok\nbad \xff\xfe here\n",
+			want:     []string{"content-invalid-utf8:2"},
+			message:  "Contains 2 byte sequences of invalid UTF-8, first on line 2",
+		},
+		{
+			name:     "non-markdown files are skipped",
+			filePath: ".claude/settings.json",
+			contents: "{\"a\": \"" + long + "\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := CheckContentBloat(tt.contents, tt.filePath, 0, 0)
+			var got []string
+			for _, issue := range issues {
+				got = append(got, fmt.Sprintf("%s:%d", issue.Rule, issue.Line))
+				if issue.Severity != cue.SeverityWarning {
+					t.Errorf("severity = %q, want warning", issue.Severity)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if tt.message != "" && !strings.Contains(issues[0].Message, tt.message) {
+				t.Errorf("message = %q, want it to contain %q", issues[0].Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestCheckContentBloat_Limits(t *testing.T) {
+	contents := strings.Repeat("x", 101) + "\n" + strings.Repeat("A", 65) + "\n"
+	issues := CheckContentBloat(contents, "agents/a.md", 100, 64)
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2: %v", len(issues), issues)
+	}
+
+	if issues := CheckContentBloat(contents, "agents/a.md", 0, 0); len(issues) != 0 {
+		t.Errorf("0 should restore the defaults; got %v", issues)
+	}
+}
+
+func TestBloatedFilesCount(t *testing.T) {
+	summary := &LintSummary{}
+	applyResultToSummary(summary, LintResult{Success: true, Warnings: []cue.ValidationError{{Rule: cue.RuleContentLongLine, Severity: cue.SeverityWarning}}})
+	applyResultToSummary(summary, LintResult{Success: true, Warnings: []cue.ValidationError{{Rule: cue.RuleTerminology, Severity: cue.SeverityWarning}}})
+	if summary.BloatedFiles != 1 {
+		t.Errorf("BloatedFiles = %d, want 1", summary.BloatedFiles)
+	}
+
+	summary.Results = []LintResult{{Success: true}}
+	recalculateTotals(summary)
+	if summary.BloatedFiles != 0 {
+		t.Errorf("after recalculation BloatedFiles = %d, want 0", summary.BloatedFiles)
+	}
+}
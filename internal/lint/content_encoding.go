@@ -0,0 +1,120 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// CheckContentEncoding reports encoding problems in a markdown or JSON
+// component: a leading byte order mark, line breaks that break the
+// lineEndings policy, tab-indented frontmatter lines, and a missing final
+// newline. Each rule is reported at most once per file, at its first line.
+//
+// lineEndings is the lineEndings config key: "lf" or "crlf" reports every
+// other line break, and "auto" (or empty) reports only files that mix the
+// two.
+func CheckContentEncoding(contents, filePath, lineEndings string) []cue.ValidationError {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if (ext != ".md" && ext != ".json") || contents == "" {
+		return nil
+	}
+	issue := func(rule, severity string, line int, msg string) cue.ValidationError {
+		return cue.ValidationError{
+			File:     filePath,
+			Message:  msg,
+			Severity: severity,
+			Source:   cue.SourceCClintObserve,
+			Rule:     rule,
+			Line:     line,
+		}
+	}
+
+	var out []cue.ValidationError
+	if strings.HasPrefix(contents, textutil.BOM) {
+		out = append(out, issue(cue.RuleContentBOM, cue.SeverityWarning, 1,
+			"File starts with a UTF-8 byte order mark, which can stop frontmatter or JSON from parsing; re-save it without the BOM"))
+	}
+	if line, msg := lineEndingViolation(contents, lineEndings); line > 0 {
+		out = append(out, issue(cue.RuleContentLineEndings, cue.SeverityWarning, line, msg))
+	}
+	if ext == ".md" {
+		if lines := tabIndentedFrontmatter(contents); len(lines) > 0 {
+			out = append(out, issue(cue.RuleFrontmatterTabIndent, cue.SeverityWarning, lines[0],
+				fmt.Sprintf("Frontmatter indents %s with tabs, first on line %d; YAML does not allow tab indentation, so indent with spaces", pluralCount(len(lines), "line"), lines[0])))
+		}
+	}
+	if !strings.HasSuffix(contents, "\n") {
+		out = append(out, issue(cue.RuleContentFinalNewline, cue.SeveritySuggestion, strings.Count(contents, "\n")+1,
+			"File does not end with a newline; add one so editors and diffs treat the last line as complete"))
+	}
+	return out
+}
+
+// lineEndingViolation returns the first line whose line break breaks the
+// lineEndings policy, with a message, or 0 when every break conforms.
+func lineEndingViolation(contents, lineEndings string) (int, string) {
+	lf, crlf := textutil.CountLineBreaks(contents)
+	if lf == 0 && crlf == 0 {
+		return 0, ""
+	}
+	switch lineEndings {
+	case textutil.LineEndingsLF:
+		if crlf == 0 {
+			return 0, ""
+		}
+		line := firstLineBreak(contents, true)
+		return line, fmt.Sprintf("Uses CRLF line endings on %s, first on line %d, but lineEndings is lf; convert the file to LF", pluralCount(crlf, "line"), line)
+	case textutil.LineEndingsCRLF:
+		if lf == 0 {
+			return 0, ""
+		}
+		line := firstLineBreak(contents, false)
+		return line, fmt.Sprintf("Uses LF line endings on %s, first on line %d, but lineEndings is crlf; convert the file to CRLF", pluralCount(lf, "line"), line)
+	}
+	if lf == 0 || crlf == 0 {
+		return 0, ""
+	}
+	// Report the minority style, which is the one a fix converts.
+	minority := "CRLF"
+	if crlf > lf {
+		minority = "LF"
+	}
+	line := firstLineBreak(contents, minority == "CRLF")
+	return line, fmt.Sprintf("Mixes CRLF and LF line endings (%d CRLF, %d LF), first %s on line %d; convert the file to one style", crlf, lf, minority, line)
+}
+
+// firstLineBreak returns the first line ending in CRLF, or in a bare LF
+// when crlf is false; 0 when there is none.
+func firstLineBreak(contents string, crlf bool) int {
+	lines := strings.Split(contents, "\n")
+	for i, line := range lines[:len(lines)-1] { // the last element has no line break
+		if strings.HasSuffix(line, "\r") == crlf {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// tabIndentedFrontmatter returns the frontmatter lines whose indentation
+// contains a tab.
+func tabIndentedFrontmatter(contents string) []int {
+	lines := strings.Split(strings.TrimPrefix(contents, textutil.BOM), "\n")
+	if len(lines) == 0 || strings.TrimRight(lines[0], "\r") != "---" {
+		return nil
+	}
+	var tabbed []int
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if line == "---" {
+			return tabbed
+		}
+		if strings.Contains(line[:len(line)-len(strings.TrimLeft(line, " \t"))], "\t") {
+			tabbed = append(tabbed, i+1)
+		}
+	}
+	return nil // unclosed frontmatter is reported by the parser
+}
@@ -0,0 +1,117 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestCheckContentEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   string
+		filePath string
+		contents string
+		want     []string // rule:line per finding, in order
+		message  string   // substring of the first finding
+	}{
+		{
+			name:     "clean",
+			filePath: "agents/a.md",
+			contents: "---\nname: a\n---\nBody.\n",
+		},
+		{
+			name:     "byte order mark",
+			filePath: ".claude/settings.json",
+			contents: "\uFEFF{}\n",
+			want:     []string{"content-bom:1"},
+			message:  "UTF-8 byte order mark",
+		},
+		{
+			name:     "consistent CRLF passes under auto",
+			filePath: "agents/a.md",
+			contents: "---\r\nname: a\r\n---\r\nBody.\r\n",
+		},
+		{
+			name:     "mixed endings report the minority",
+			filePath: "agents/a.md",
+			contents: "one\r\ntwo\r\nthree\nfour\r\n",
+			want:     []string{"content-line-endings:3"},
+			message:  "Mixes CRLF and LF line endings (3 CRLF, 1 LF), first LF on line 3",
+		},
+		{
+			name:     "lf policy",
+			policy:   "lf",
+			filePath: "commands/c.md",
+			contents: "one\ntwo\r\nthree\r\n",
+			want:     []string{"content-line-endings:2"},
+			message:  "Uses CRLF line endings on 2 lines, first on line 2, but lineEndings is lf",
+		},
+		{
+			name:     "crlf policy",
+			policy:   "crlf",
+			filePath: "commands/c.md",
+			contents: "one\r\ntwo\n",
+			want:     []string{"content-line-endings:2"},
+			message:  "Uses LF line endings on 1 line, first on line 2, but lineEndings is crlf",
+		},
+		{
+			name:     "tab-indented frontmatter",
+			filePath: "agents/a.md",
+			contents: "---\nname: a\nhooks:\n\tStop:\n\t\t- x\n---\n\tcode in the body\n",
+			want:     []string{"frontmatter-tab-indent:4"},
+			message:  "Frontmatter indents 2 lines with tabs, first on line 4",
+		},
+		{
+			name:     "tabs after the indentation are fine",
+			filePath: "agents/a.md",
+			contents: "---\nname: a\t# comment\n---\n",
+		},
+		{
+			name:     "missing final newline",
+			filePath: "skills/s/SKILL.md",
+			contents: "---\nname: s\n---\nBody.",
+			want:     []string{"content-final-newline:4"},
+		},
+		{
+			name:     "other files are skipped",
+			filePath: "scripts/hook.sh",
+			contents: "\uFEFFecho\r\nhi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := CheckContentEncoding(tt.contents, tt.filePath, tt.policy)
+			var got []string
+			for _, issue := range issues {
+				got = append(got, fmt.Sprintf("%s:%d", issue.Rule, issue.Line))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if tt.message != "" && !strings.Contains(issues[0].Message, tt.message) {
+				t.Errorf("message = %q, want it to contain %q", issues[0].Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestCheckContentEncodingSeverities(t *testing.T) {
+	issues := CheckContentEncoding("\uFEFF---\n\tname: a\r\n---\nBody.", "agents/a.md", "")
+	want := map[string]string{
+		cue.RuleContentBOM:           cue.SeverityWarning,
+		cue.RuleContentLineEndings:   cue.SeverityWarning,
+		cue.RuleFrontmatterTabIndent: cue.SeverityWarning,
+		cue.RuleContentFinalNewline:  cue.SeveritySuggestion,
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues, want %d: %v", len(issues), len(want), issues)
+	}
+	for _, issue := range issues {
+		if issue.Severity != want[issue.Rule] {
+			t.Errorf("%s severity = %q, want %q", issue.Rule, issue.Severity, want[issue.Rule])
+		}
+	}
+}
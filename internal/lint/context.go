@@ -3,7 +3,9 @@ package lint
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
@@ -18,6 +20,9 @@ type LinterContext struct {
 	Quiet          bool
 	Verbose        bool
 	NoCycleCheck   bool
+	IncludeChains  bool
+	Progress       func(Progress)
+	Config         *config.Config
 	Validator      *cue.Validator
 	Discoverer     *discovery.FileDiscovery
 	Files          []discovery.File
@@ -26,9 +31,12 @@ type LinterContext struct {
 
 // NewLinterContext creates a new LinterContext with all dependencies initialized.
 // It handles project root detection, schema loading, file discovery, and
-// cross-file validator setup.
-func NewLinterContext(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LinterContext, error) {
+// cross-file validator setup, as opts.Config directs.
+func NewLinterContext(opts Options) (*LinterContext, error) {
+	cfg := opts.config()
+
 	// Find project root if not provided
+	rootPath := cfg.Root
 	if rootPath == "" {
 		var err error
 		rootPath, err = project.FindProjectRoot(".")
@@ -38,17 +46,18 @@ func NewLinterContext(rootPath string, quiet, verbose, noCycleCheck bool, exclud
 	}
 
 	// Initialize validator
-	validator := cue.NewValidator()
+	validator := newValidator(cfg)
 
 	// Load schemas (soft failure - continue with Go validation)
 	if err := validator.LoadSchemas(""); err != nil {
-		if !quiet {
+		if !cfg.Quiet() {
 			fmt.Fprintf(os.Stderr, "Warning: CUE schemas not loaded, using Go validation\n")
 		}
 	}
 
 	// Initialize discoverer
-	discoverer := discovery.NewFileDiscovery(rootPath, false).WithExclude(exclude)
+	// Contents are read per file while linting, not all up front
+	discoverer := cfg.NewFileDiscovery(rootPath).WithExclude(cfg.Exclude).WithLazyContents()
 
 	// Discover all files
 	files, err := discoverer.DiscoverFiles()
@@ -57,13 +66,16 @@ func NewLinterContext(rootPath string, quiet, verbose, noCycleCheck bool, exclud
 	}
 
 	// Initialize cross-file validator
-	crossValidator := crossfile.NewCrossFileValidator(files, rootPath)
+	crossValidator := crossfile.NewCrossFileValidator(files, rootPath).WithExtraBuiltinAgents(cfg.ExtraBuiltinAgents)
 
 	return &LinterContext{
 		RootPath:       rootPath,
-		Quiet:          quiet,
-		Verbose:        verbose,
-		NoCycleCheck:   noCycleCheck,
+		Quiet:          cfg.Quiet(),
+		Verbose:        cfg.Verbose(),
+		NoCycleCheck:   cfg.NoCycleCheck,
+		IncludeChains:  opts.IncludeChains,
+		Progress:       opts.Progress,
+		Config:         cfg,
 		Validator:      validator,
 		Discoverer:     discoverer,
 		Files:          files,
@@ -71,6 +83,19 @@ func NewLinterContext(rootPath string, quiet, verbose, noCycleCheck bool, exclud
 	}, nil
 }
 
+// newValidator creates a CUE validator with the schema limits and model
+// catalog in cfg.
+func newValidator(cfg *config.Config) *cue.Validator {
+	return cue.NewValidatorWithOptions(cue.ValidatorOptions{
+		Limits: cue.EvalLimits{
+			MaxBytes: cfg.Rules.SchemaMaxBytes,
+			MaxDepth: cfg.Rules.SchemaMaxDepth,
+			Timeout:  time.Duration(cfg.Rules.SchemaTimeout) * time.Second,
+		},
+		Models: modelInfos(cfg.Rules.Models),
+	})
+}
+
 // FilterFilesByType returns files matching the specified type.
 func (ctx *LinterContext) FilterFilesByType(fileType discovery.FileType) []discovery.File {
 	var filtered []discovery.File
@@ -82,6 +107,26 @@ func (ctx *LinterContext) FilterFilesByType(fileType discovery.FileType) []disco
 	return filtered
 }
 
+// reportSkippedSymlinks warns about each symlink of type fileType that
+// discovery skipped, so a component outside the root is never dropped from
+// the run unnoticed. Each linter reports the symlinks of its own type, so a
+// run warns once per symlink in scope.
+func (ctx *LinterContext) reportSkippedSymlinks(fileType discovery.FileType) {
+	if ctx.Quiet || ctx.Discoverer == nil {
+		return
+	}
+	for _, s := range ctx.Discoverer.Skipped() {
+		if s.Type != fileType {
+			continue
+		}
+		target := ""
+		if s.Target != "" {
+			target = " -> " + s.Target
+		}
+		fmt.Fprintf(os.Stderr, "warning: skipped symlink %s%s (%s)\n", s.RelPath, target, s.Reason)
+	}
+}
+
 // NewSummary creates an initialized LintSummary with the total file count.
 func (ctx *LinterContext) NewSummary(totalFiles int) *LintSummary {
 	return &LintSummary{
@@ -103,10 +148,10 @@ func (ctx *LinterContext) LogProcessedWithSuggestions(filePath string, errorCoun
 }
 
 // LintContext runs linting on CLAUDE.md context files.
-func LintContext(rootPath string, quiet bool, verbose bool, noCycleCheck bool, exclude []string) (*LintSummary, error) {
-	ctx, err := NewLinterContext(rootPath, quiet, verbose, noCycleCheck, exclude)
+func LintContext(opts Options) (*LintSummary, error) {
+	ctx, err := NewLinterContext(opts)
 	if err != nil {
 		return nil, err
 	}
-	return lintBatch(ctx, NewContextLinter()), nil
+	return lintBatch(ctx, NewContextLinter(ctx.Config)), nil
 }
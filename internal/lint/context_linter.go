@@ -6,24 +6,30 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
 	"github.com/dotcommander/cclint/internal/textutil"
 )
 
 // ContextLinter implements ComponentLinter for CLAUDE.md context files.
-// It implements only the core ComponentLinter interface - no optional capabilities.
-// Context files don't need scoring, improvements, or cross-file validation.
+// Beyond the core interface it only implements cross-file validation, for
+// @path imports; context files don't need scoring or improvements.
 type ContextLinter struct {
 	BaseLinter
 }
 
-// Compile-time interface compliance check
-var _ ComponentLinter = (*ContextLinter)(nil)
+// Compile-time interface compliance checks
+var (
+	_ ComponentLinter      = (*ContextLinter)(nil)
+	_ CrossFileValidatable = (*ContextLinter)(nil)
+)
 
 // NewContextLinter creates a new ContextLinter.
-func NewContextLinter() *ContextLinter {
-	return &ContextLinter{}
+// cfg supplies the rule settings; nil uses the defaults.
+func NewContextLinter(cfg *config.Config) *ContextLinter {
+	return &ContextLinter{BaseLinter: BaseLinter{cfg: cfg}}
 }
 
 func (l *ContextLinter) Type() string {
@@ -67,6 +73,20 @@ func (l *ContextLinter) ValidateSpecific(data map[string]any, filePath, contents
 	return validateContextSpecific(data, filePath, contents)
 }
 
+// ValidateCrossFile implements CrossFileValidatable: it resolves @path
+// imports against the project and, when the rules.agentsMd config key is
+// on, compares the file with the AGENTS.md beside it.
+func (l *ContextLinter) ValidateCrossFile(crossValidator *crossfile.CrossFileValidator, filePath, contents string, data map[string]any) []cue.ValidationError {
+	if crossValidator == nil {
+		return nil
+	}
+	errors := crossValidator.ValidateContext(filePath, contents)
+	if l.Config().Rules.AgentsMD {
+		errors = append(errors, crossValidator.ValidateAgentsMD(filePath, contents)...)
+	}
+	return errors
+}
+
 // parseMarkdownSections parses markdown content into sections.
 // Each section is a map with "heading" and "content" keys.
 func parseMarkdownSections(content string) []any {
@@ -152,6 +172,7 @@ func validateContextSpecific(data map[string]any, filePath, contents string) []c
 			File:     filePath,
 			Message:  "No sections found in CLAUDE.md",
 			Severity: cue.SeveritySuggestion,
+			Rule:     cue.RuleContextSectionsMissing,
 		})
 	} else {
 		errors = append(errors, validateContextSections(sections, filePath)...)
@@ -176,6 +197,7 @@ func validateContextSections(sections []any, filePath string) []cue.ValidationEr
 				File:     filePath,
 				Message:  fmt.Sprintf("Section %d: missing heading", i),
 				Severity: cue.SeverityWarning,
+				Rule:     cue.RuleContextSectionIncomplete,
 			})
 		}
 		// h1 sections are document titles — don't require body content
@@ -186,6 +208,7 @@ func validateContextSections(sections []any, filePath string) []cue.ValidationEr
 					File:     filePath,
 					Message:  fmt.Sprintf("Section %d: missing content", i),
 					Severity: cue.SeverityWarning,
+					Rule:     cue.RuleContextSectionIncomplete,
 				})
 			}
 		}
@@ -213,6 +236,7 @@ func checkBinaryIncludes(contents, filePath string) []cue.ValidationError {
 				Message:  fmt.Sprintf("@include references binary file '%s' which will be skipped by Claude Code", includePath),
 				Severity: cue.SeverityWarning,
 				Source:   "binary-include",
+				Rule:     cue.RuleContextImportBinary,
 			})
 		}
 	}
@@ -10,7 +10,7 @@ import (
 
 func TestLintContext(t *testing.T) {
 	// Test with empty directory
-	summary, err := LintContext("testdata/empty", false, false, true, nil)
+	summary, err := LintContext(testOptions("testdata/empty"))
 	if err != nil {
 		t.Fatalf("LintContext() error = %v", err)
 	}
@@ -393,7 +393,7 @@ func TestLintContextWithFile(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	summary, err := LintContext(tmpDir, false, false, true, nil)
+	summary, err := LintContext(testOptions(tmpDir))
 	if err != nil {
 		t.Fatalf("LintContext() error = %v", err)
 	}
@@ -443,7 +443,7 @@ func TestLintContextH1TitleNoFalsePositive(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	summary, err := LintContext(tmpDir, false, false, true, nil)
+	summary, err := LintContext(testOptions(tmpDir))
 	if err != nil {
 		t.Fatalf("LintContext() error = %v", err)
 	}
@@ -457,3 +457,37 @@ func TestLintContextH1TitleNoFalsePositive(t *testing.T) {
 		}
 	}
 }
+
+func TestLintContextAgentsMD(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"CLAUDE.md": "# Project\n\n## Testing\n\nRun the tests.\n",
+		"AGENTS.md": "# Agents\n\n## Testing\n\nRun the tests.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, on := range []bool{false, true} {
+		opts := testOptions(tmpDir)
+		opts.Config.Rules.AgentsMD = on
+		summary, err := LintContext(opts)
+		if err != nil {
+			t.Fatalf("LintContext() error = %v", err)
+		}
+
+		found := false
+		for _, result := range summary.Results {
+			for _, s := range result.Suggestions {
+				if s.Rule == cue.RuleContextAgentsMDDuplicate {
+					found = true
+				}
+			}
+		}
+		if found != on {
+			t.Errorf("with rules.agentsMd %v: duplicate reported = %v", on, found)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+var (
+	// schemaFieldPattern matches the field path that starts a CUE schema
+	// error, e.g. `#Agent.description:` or `#Command."allowed-tools":`,
+	// capturing the top-level field.
+	schemaFieldPattern = regexp.MustCompile(`^#\w+\.(?:"([^"]+)"|([\w-]+))`)
+
+	// messageFieldPattern matches a check message that names the field it is
+	// about, e.g. "Required field 'description' is missing or empty".
+	messageFieldPattern = regexp.MustCompile(`[Ff]ield '([\w-]+)`)
+
+	// frontmatterKeyPattern matches a top-level frontmatter key.
+	frontmatterKeyPattern = regexp.MustCompile(`^([\w-]+):`)
+)
+
+// lineRange is the first and last line (1-based) a finding is about.
+type lineRange struct {
+	start, end int
+}
+
+// findingKey identifies the problem a finding reports: the lines it is
+// about and its rule group (cue.RuleGroups). A finding about a field the
+// file lacks has no lines and is keyed by the field's name instead.
+type findingKey struct {
+	lines lineRange
+	field string
+	group string
+}
+
+// dedupeSummary drops findings that restate a problem another finding of
+// the same file already reports, in every result of summary. The CUE
+// schema and the component checks both validate field values, so a missing
+// description, for one, comes out twice in different words. Findings are
+// the same problem when they share a file, a line range, and a rule group;
+// see dedupeResult. It runs where a linter's results are merged, after the
+// batch checks, so it sees every finding at every severity. Markdown files
+// are read from disk for the lines of their frontmatter fields.
+func dedupeSummary(summary *LintSummary) {
+	if summary == nil {
+		return
+	}
+
+	changed := false
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		contents := func() string {
+			if !strings.EqualFold(filepath.Ext(result.File), ".md") {
+				return ""
+			}
+			path := result.File
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(summary.ProjectRoot, path)
+			}
+			data, err := os.ReadFile(path) //nolint:gosec // G304: path of a linted file
+			if err != nil {
+				return ""
+			}
+			return string(data)
+		}
+		changed = dedupeResult(result, contents) || changed
+	}
+
+	if changed {
+		recalculateTotals(summary)
+	}
+}
+
+// dedupeResult drops the findings of result that restate another of its
+// findings, and reports whether it changed result. A finding's lines are
+// those of the frontmatter field it is about, or its own line; contents
+// returns the file, and is only called when two findings could be the same
+// problem. Where a check reports a problem the schema also reports, the
+// schema violations are dropped and the check's findings are raised to the
+// most severe of them, so neither the count nor the severity of the
+// problem changes. Where only the schema reports it, its violations
+// collapse to the first one, since CUE lists every failed alternative of a
+// disjunction.
+func dedupeResult(result *LintResult, contents func() string) bool {
+	var issues []cue.ValidationError
+	grouped := 0
+	for _, bucket := range [][]cue.ValidationError{result.Errors, result.Warnings, result.Suggestions} {
+		for _, issue := range bucket {
+			if cue.RuleGroups[issue.Rule] != "" {
+				grouped++
+			}
+			issues = append(issues, issue)
+		}
+	}
+	if grouped < 2 {
+		return false
+	}
+
+	// A problem is checked when a rule other than its group's reports it;
+	// severity is the most severe of the group rule's findings.
+	type problem struct {
+		checked  bool
+		severity string
+	}
+	fields := frontmatterFieldSpans(contents())
+	problems := make(map[findingKey]*problem)
+	keys := make([]*findingKey, len(issues))
+	for i, issue := range issues {
+		key, ok := issueKey(issue, fields)
+		if !ok {
+			continue
+		}
+		keys[i] = &key
+		p := problems[key]
+		if p == nil {
+			p = &problem{}
+			problems[key] = p
+		}
+		if issue.Rule != key.group {
+			p.checked = true
+		} else if severityRank(issue.Severity) > severityRank(p.severity) {
+			p.severity = issue.Severity
+		}
+	}
+
+	kept := issues[:0]
+	reported := make(map[findingKey]bool)
+	changed := false
+	for i, issue := range issues {
+		if key := keys[i]; key != nil {
+			p := problems[*key]
+			switch {
+			case issue.Rule == key.group && (p.checked || reported[*key]):
+				changed = true
+				continue
+			case issue.Rule != key.group && severityRank(p.severity) > severityRank(issue.Severity):
+				issue.Severity = p.severity
+				changed = true
+			}
+			reported[*key] = true
+		}
+		kept = append(kept, issue)
+	}
+	if !changed {
+		return false
+	}
+
+	result.Errors, result.Warnings, result.Suggestions = nil, nil, nil
+	categorizeIssues(result, kept)
+	result.Success = len(result.Errors) == 0
+	return true
+}
+
+// issueKey returns the key of the problem issue reports, or false when
+// issue is not grouped or cannot be placed. A finding located by a JSON
+// Pointer is about its own line. Otherwise a schema violation is about the
+// field its path starts with, since its line is the schema's, and a check's
+// finding about the field its message names or whose lines it points at.
+func issueKey(issue cue.ValidationError, fields map[string]lineRange) (findingKey, bool) {
+	group := cue.RuleGroups[issue.Rule]
+	if group == "" {
+		return findingKey{}, false
+	}
+	if issue.Pointer != "" && issue.Line > 0 {
+		return findingKey{lines: lineRange{issue.Line, issue.Line}, group: group}, true
+	}
+
+	field := schemaField(issue)
+	if issue.Rule != cue.RuleSchemaViolation {
+		if m := messageFieldPattern.FindStringSubmatch(issue.Message); m != nil {
+			field = m[1]
+		} else {
+			field = fieldAt(fields, issue.Line)
+		}
+	}
+	switch {
+	case field != "":
+		if lines, ok := fields[field]; ok {
+			return findingKey{lines: lines, group: group}, true
+		}
+		return findingKey{field: field, group: group}, true
+	case issue.Line > 0 && issue.Rule != cue.RuleSchemaViolation:
+		return findingKey{lines: lineRange{issue.Line, issue.Line}, group: group}, true
+	}
+	return findingKey{}, false
+}
+
+// severityRank orders severities from suggestions (and info) up to errors;
+// "" ranks below all of them.
+func severityRank(severity string) int {
+	switch severity {
+	case cue.SeverityError:
+		return 3
+	case cue.SeverityWarning:
+		return 2
+	case "":
+		return 0
+	}
+	return 1
+}
+
+// schemaField returns the top-level field a CUE schema error is about, or
+// "" for an issue from another check.
+func schemaField(issue cue.ValidationError) string {
+	if issue.Rule != cue.RuleSchemaViolation {
+		return ""
+	}
+	m := schemaFieldPattern.FindStringSubmatch(issue.Message)
+	if m == nil {
+		return ""
+	}
+	return m[1] + m[2]
+}
+
+// fieldAt returns the frontmatter field whose lines include line, or "".
+func fieldAt(fields map[string]lineRange, line int) string {
+	for field, lines := range fields {
+		if line >= lines.start && line <= lines.end {
+			return field
+		}
+	}
+	return ""
+}
+
+// frontmatterFieldSpans maps each top-level frontmatter field to its lines
+// (1-based), from its key to the last line of its value, so a nested value
+// or list item falls within its key's range.
+func frontmatterFieldSpans(contents string) map[string]lineRange {
+	fields := make(map[string]lineRange)
+	lines := strings.Split(contents, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return fields
+	}
+	current := ""
+	for i, line := range lines[1:] {
+		if strings.TrimSpace(line) == "---" {
+			break
+		}
+		n := i + 2
+		if m := frontmatterKeyPattern.FindStringSubmatch(line); m != nil {
+			current = m[1]
+			fields[current] = lineRange{n, n}
+		} else if current != "" {
+			lines := fields[current]
+			lines.end = n
+			fields[current] = lines
+		}
+	}
+	return fields
+}
+
+// dropLostKeyIssues drops findings about frontmatter keys whose values
+// could not be parsed. Their absence from the recovered data is not a
+// missing field; the frontmatter-syntax error already covers them.
+// Findings that only point at a lost key's lines are kept, since those
+// come from checks of the raw text.
+func dropLostKeyIssues(result *LintResult, lost map[string]bool) {
+	if len(lost) == 0 {
+		return
+	}
+	isLost := func(issue cue.ValidationError) bool {
+		if issue.Rule == cue.RuleFrontmatterSyntax {
+			return false
+		}
+		if field := schemaField(issue); field != "" {
+			return lost[field]
+		}
+		m := messageFieldPattern.FindStringSubmatch(issue.Message)
+		return m != nil && lost[m[1]]
+	}
+	for _, issues := range []*[]cue.ValidationError{&result.Errors, &result.Warnings, &result.Suggestions} {
+		kept := (*issues)[:0]
+		for _, issue := range *issues {
+			if !isLost(issue) {
+				kept = append(kept, issue)
+			}
+		}
+		*issues = kept
+	}
+}
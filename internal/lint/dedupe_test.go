@@ -0,0 +1,162 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupeResult(t *testing.T) {
+	contents := "---\nname: Helper_X\ntools:\n  - Read\n  - 5\n---\n# Helper\n"
+	schema := func(msg string) cue.ValidationError {
+		return cue.ValidationError{Message: msg, Severity: cue.SeverityError, Source: cue.SourceAnthropicDocs, Rule: cue.RuleSchemaViolation, Line: 60}
+	}
+	check := func(rule, msg string, line int) cue.ValidationError {
+		return cue.ValidationError{File: "a.md", Message: msg, Severity: cue.SeverityError, Source: cue.SourceAnthropicDocs, Rule: rule, Line: line}
+	}
+
+	tests := []struct {
+		name   string
+		errors []cue.ValidationError
+		want   []string
+	}{
+		{
+			name: "check on the field line wins",
+			errors: []cue.ValidationError{
+				schema(`#Agent.name: #Agent.name: invalid value "Helper_X" (out of bound =~"^[a-z0-9-]+$")`),
+				check(cue.RuleNameFormat, "Name must contain only lowercase letters, numbers, and hyphens", 2),
+			},
+			want: []string{"Name must contain only lowercase letters, numbers, and hyphens"},
+		},
+		{
+			name: "message naming a missing field wins",
+			errors: []cue.ValidationError{
+				schema(`#Agent.description: #Agent.description: incomplete value !=""`),
+				check(cue.RuleFieldRequired, "Required field 'description' is missing or empty", 0),
+			},
+			want: []string{"Required field 'description' is missing or empty"},
+		},
+		{
+			name: "nested value falls within its key's lines",
+			errors: []cue.ValidationError{
+				schema(`#Agent.tools: #Agent.tools.1: conflicting values 5 and string`),
+				check(cue.RuleFieldInvalid, "Invalid tool entry", 5),
+			},
+			want: []string{"Invalid tool entry"},
+		},
+		{
+			name: "disjunction alternatives collapse",
+			errors: []cue.ValidationError{
+				schema(`#Command."allowed-tools": #Command."allowed-tools": 3 errors in empty disjunction:`),
+				schema(`#Command."allowed-tools": #Command."allowed-tools": conflicting values 5 and "*"`),
+				schema(`#Command.model: #Command.model: conflicting values 5 and string`),
+			},
+			want: []string{
+				`#Command."allowed-tools": #Command."allowed-tools": 3 errors in empty disjunction:`,
+				`#Command.model: #Command.model: conflicting values 5 and string`,
+			},
+		},
+		{
+			name: "other fields and ungrouped rules are kept",
+			errors: []cue.ValidationError{
+				schema(`#Agent.name: #Agent.name: invalid value`),
+				check(cue.RuleFieldInvalid, "Invalid maxTurns value -1", 9),
+				check(cue.RuleSecretDetected, "Possible API key", 2),
+			},
+			want: []string{
+				`#Agent.name: #Agent.name: invalid value`,
+				"Invalid maxTurns value -1",
+				"Possible API key",
+			},
+		},
+		{
+			name: "checks of one field are all kept",
+			errors: []cue.ValidationError{
+				check(cue.RuleFieldInvalid, "Invalid tool entry", 4),
+				check(cue.RuleFieldInvalid, "Invalid tool entry 5", 5),
+			},
+			want: []string{"Invalid tool entry", "Invalid tool entry 5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := LintResult{Errors: tt.errors}
+			dedupeResult(&result, func() string { return contents })
+			var got []string
+			for _, e := range result.Errors {
+				got = append(got, e.Message)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDedupeResultRaisesWeakerChecks(t *testing.T) {
+	result := LintResult{
+		Errors:   []cue.ValidationError{{Message: "#Agent.model: #Agent.model: conflicting values", Severity: cue.SeverityError, Source: cue.SourceAnthropicDocs, Rule: cue.RuleSchemaViolation}},
+		Warnings: []cue.ValidationError{{Message: `Unknown model "gpt4"`, Severity: cue.SeverityWarning, Source: cue.SourceCClintObserve, Rule: cue.RuleModelUnknown, Line: 2}},
+		Success:  false,
+	}
+	changed := dedupeResult(&result, func() string { return "---\nmodel: gpt4\n---\n" })
+
+	assert.True(t, changed)
+	require.Len(t, result.Errors, 1, "the check reports the problem at the schema's severity")
+	assert.Equal(t, cue.RuleModelUnknown, result.Errors[0].Rule)
+	assert.Equal(t, cue.SeverityError, result.Errors[0].Severity)
+	assert.Empty(t, result.Warnings)
+	assert.False(t, result.Success)
+}
+
+func TestDedupeResultJSONPointer(t *testing.T) {
+	pointer := "/hooks/PreToolUse/0/hooks/0/timeout"
+	schema := func(msg string) cue.ValidationError {
+		return cue.ValidationError{Message: msg, Severity: cue.SeverityError, Rule: cue.RuleSchemaViolation, Line: 7, Pointer: pointer}
+	}
+	result := LintResult{Errors: []cue.ValidationError{
+		schema("#Settings.hooks.PreToolUse.0.hooks.0.timeout: 2 errors in empty disjunction:"),
+		schema("#Settings.hooks.PreToolUse.0.hooks.0.timeout: conflicting values -5 and int"),
+		{File: "settings.json", Message: "'timeout' must be a positive integer number of seconds, got -5", Severity: cue.SeverityError, Rule: cue.RuleHookTimeout, Line: 7, Pointer: pointer},
+		{File: "settings.json", Message: "'command' must be a non-empty string", Severity: cue.SeverityError, Rule: cue.RuleHookFieldInvalid, Line: 8, Pointer: "/hooks/PreToolUse/0/hooks/1/command"},
+	}}
+	dedupeResult(&result, func() string { return "" })
+
+	var rules []string
+	for _, e := range result.Errors {
+		rules = append(rules, e.Rule)
+	}
+	assert.Equal(t, []string{cue.RuleHookTimeout, cue.RuleHookFieldInvalid}, rules)
+}
+
+func TestDedupeSummary(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.md"), []byte("---\nname: Helper_X\n---\n"), 0o600))
+	summary := &LintSummary{
+		ProjectRoot: root,
+		TotalFiles:  1,
+		Results: []LintResult{{
+			File: "a.md",
+			Errors: []cue.ValidationError{
+				{Message: `#Agent.name: #Agent.name: invalid value "Helper_X"`, Severity: cue.SeverityError, Rule: cue.RuleSchemaViolation, Line: 60},
+				{File: "a.md", Message: "Name must contain only lowercase letters, numbers, and hyphens", Severity: cue.SeverityError, Rule: cue.RuleNameFormat, Line: 2},
+			},
+		}},
+	}
+	recalculateTotals(summary)
+	require.Equal(t, 2, summary.TotalErrors)
+
+	dedupeSummary(summary)
+
+	assert.Equal(t, 1, summary.TotalErrors)
+	assert.Equal(t, cue.RuleNameFormat, summary.Results[0].Errors[0].Rule)
+}
+
+func TestRuleGroupsAreRules(t *testing.T) {
+	for rule, group := range cue.RuleGroups {
+		assert.NotEmpty(t, cue.RuleCategories[rule], "rule %s", rule)
+		assert.Equal(t, group, cue.RuleGroups[group], "group %s of %s must be its own group", group, rule)
+	}
+}
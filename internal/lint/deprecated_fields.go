@@ -0,0 +1,74 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// CheckDeprecatedFields warns about every frontmatter field of a component
+// that deprecated, the project's deprecatedFields config, retires, with the
+// configured message and, for a field with a replacement, how to rename it.
+func CheckDeprecatedFields(deprecated config.DeprecatedFields, data map[string]any, filePath, contents, component string) []cue.ValidationError {
+	if len(deprecated[component]) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var issues []cue.ValidationError
+	for _, key := range keys {
+		f, ok := deprecated.Lookup(component, key)
+		if !ok {
+			continue
+		}
+		message := fmt.Sprintf("Frontmatter field '%s' is deprecated in this project", key)
+		if f.Message != "" {
+			message += ": " + strings.TrimRight(f.Message, ".")
+		}
+		if f.RenameTo != "" {
+			message += fmt.Sprintf(". Rename it to '%s', or run 'cclint migrate'", f.RenameTo)
+		}
+		issues = append(issues, cue.ValidationError{
+			File:        filePath,
+			Message:     message,
+			Severity:    cue.SeverityWarning,
+			Source:      cue.SourceCClintObserve,
+			Rule:        cue.RuleFrontmatterFieldDeprecated,
+			Line:        textutil.FindFrontmatterFieldLine(contents, key),
+			Replacement: f.RenameTo,
+		})
+	}
+	return issues
+}
+
+// fixDeprecatedField renames a deprecated frontmatter key to the field its
+// deprecatedFields entry names, as "cclint migrate" would. The check looks
+// the entry up by the key and carries its renameTo on the finding; findings
+// without one are left alone.
+func fixDeprecatedField(issue cue.ValidationError, contents string) (Fix, bool) {
+	lines := strings.Split(contents, "\n")
+	if issue.Line > len(lines) {
+		return Fix{}, false
+	}
+	key, _, _ := strings.Cut(lines[issue.Line-1], ":")
+	to := issue.Replacement
+	if to == "" || textutil.FindFrontmatterFieldLine(contents, to) != 0 {
+		return Fix{}, false
+	}
+	line := to + strings.TrimPrefix(lines[issue.Line-1], key)
+
+	return Fix{
+		Description: fmt.Sprintf("Rename '%s' to '%s'", key, to),
+		Apply: func(contents string) (string, error) {
+			return replaceLine(contents, issue.Line, line, true)
+		},
+	}, true
+}
@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestCheckDeprecatedFields(t *testing.T) {
+	cfg := config.Default()
+	cfg.DeprecatedFields = config.DeprecatedFields{
+		cue.TypeAgent: {
+			"color":      {Message: "use themes instead."},
+			"owner_team": {Message: "owners live in CODEOWNERS", RenameTo: "ownerTeam"},
+			"legacy":     {},
+		},
+	}
+
+	const contents = "---\nname: a\ncolor: blue\nOwner_Team: core\nlegacy: true\n---\nBody.\n"
+	data := map[string]any{"name": "a", "color": "blue", "Owner_Team": "core", "legacy": true}
+
+	got := CheckDeprecatedFields(cfg.DeprecatedFields, data, "agents/a.md", contents, cue.TypeAgent)
+	want := []struct {
+		line    int
+		message string
+	}{
+		{4, "Frontmatter field 'Owner_Team' is deprecated in this project: owners live in CODEOWNERS. Rename it to 'ownerTeam', or run 'cclint migrate'"},
+		{3, "Frontmatter field 'color' is deprecated in this project: use themes instead"},
+		{5, "Frontmatter field 'legacy' is deprecated in this project"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d findings, want %d: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Message != w.message || got[i].Line != w.line {
+			t.Errorf("finding %d = %q at line %d, want %q at line %d", i, got[i].Message, got[i].Line, w.message, w.line)
+		}
+		if got[i].Rule != cue.RuleFrontmatterFieldDeprecated || got[i].Severity != cue.SeverityWarning {
+			t.Errorf("finding %d rule/severity = %s/%s", i, got[i].Rule, got[i].Severity)
+		}
+	}
+
+	if got := CheckDeprecatedFields(cfg.DeprecatedFields, data, "commands/a.md", contents, cue.TypeCommand); len(got) != 0 {
+		t.Errorf("command findings = %v, want none", got)
+	}
+
+	// The unknown-field check leaves deprecated keys to this one
+	for _, issue := range validateAgentSpecific(cfg, data, "agents/a.md", contents) {
+		if issue.Rule == cue.RuleFrontmatterUnknownKey && strings.Contains(issue.Message, "Owner_Team") {
+			t.Errorf("deprecated key also reported as unknown: %s", issue.Message)
+		}
+	}
+
+	if got[0].Replacement != "ownerTeam" || got[1].Replacement != "" {
+		t.Errorf("replacements = %q, %q, want the renameTo of each entry", got[0].Replacement, got[1].Replacement)
+	}
+
+	// The fix reads the replacement from the finding, not its message
+	issue := got[0]
+	issue.Message = "Frontmatter field 'Owner_Team' is deprecated"
+	fix, ok := FixFor(issue, contents)
+	if !ok {
+		t.Fatal("FixFor() found no fix for a field with renameTo")
+	}
+	fixed, err := fix.Apply(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "---\nname: a\ncolor: blue\nownerTeam: core\nlegacy: true\n---\nBody.\n"; fixed != want {
+		t.Errorf("fixed = %q, want %q", fixed, want)
+	}
+	if _, ok := FixFor(got[1], contents); ok {
+		t.Error("FixFor() fixed a field without renameTo")
+	}
+	if _, ok := FixFor(got[0], strings.Replace(contents, "legacy:", "ownerTeam:", 1)); ok {
+		t.Error("FixFor() renamed onto a field that is already set")
+	}
+}
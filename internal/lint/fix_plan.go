@@ -0,0 +1,103 @@
+package lint
+
+import (
+	"cmp"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// FileFix is the combined autofix edit to one file.
+type FileFix struct {
+	RelPath string
+	Path    string // RelPath joined to the project root
+	Before  string
+	After   string
+	Applied []string // descriptions of the fixes applied, bottom of the file first
+	Skipped int      // fixes left for a later run because an earlier fix moved their line
+}
+
+// PlanFixes applies every autofix available for the findings in summaries
+// to an in-memory copy of each file; nothing is written. Within a file,
+// fixes apply from the last line up, so each one still finds its line.
+// A fix whose line was changed or shifted by a fix already applied is
+// skipped and counted, as is a fix that fails; the next run picks it up.
+// Files left unchanged are omitted. Files are returned in path order.
+func PlanFixes(summaries []*LintSummary) []FileFix {
+	type fileIssues struct {
+		path   string
+		issues []cue.ValidationError
+	}
+	byFile := make(map[string]*fileIssues)
+	for _, summary := range summaries {
+		for _, result := range summary.Results {
+			path := result.File
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(summary.ProjectRoot, path)
+			}
+			fi, ok := byFile[result.File]
+			if !ok {
+				fi = &fileIssues{path: path}
+				byFile[result.File] = fi
+			}
+			for _, issues := range [][]cue.ValidationError{result.Errors, result.Warnings, result.Suggestions} {
+				for _, issue := range issues {
+					if _, ok := fixers[issue.Rule]; ok && issue.Line > 0 {
+						fi.issues = append(fi.issues, issue)
+					}
+				}
+			}
+		}
+	}
+
+	var plan []FileFix
+	for _, relPath := range slices.Sorted(maps.Keys(byFile)) {
+		fi := byFile[relPath]
+		if len(fi.issues) == 0 {
+			continue
+		}
+		contents, err := os.ReadFile(fi.path)
+		if err != nil {
+			continue
+		}
+		ff := FileFix{RelPath: relPath, Path: fi.path, Before: string(contents), After: string(contents)}
+		slices.SortStableFunc(fi.issues, func(a, b cue.ValidationError) int { return cmp.Compare(b.Line, a.Line) })
+		stable := strings.Count(ff.After, "\n") + 1 // lines 1..stable are as the findings saw them
+		for _, issue := range fi.issues {
+			if issue.Line > stable {
+				ff.Skipped++
+				continue
+			}
+			fix, ok := FixFor(issue, ff.After)
+			if !ok {
+				continue
+			}
+			fixed, err := fix.Apply(ff.After)
+			if err != nil {
+				ff.Skipped++
+				continue
+			}
+			stable = min(stable, commonPrefixLines(ff.After, fixed))
+			ff.After = fixed
+			ff.Applied = append(ff.Applied, fix.Description)
+		}
+		if ff.After != ff.Before {
+			plan = append(plan, ff)
+		}
+	}
+	return plan
+}
+
+// commonPrefixLines returns how many leading lines a and b share.
+func commonPrefixLines(a, b string) int {
+	al, bl := strings.Split(a, "\n"), strings.Split(b, "\n")
+	n := 0
+	for n < len(al) && n < len(bl) && al[n] == bl[n] {
+		n++
+	}
+	return n
+}
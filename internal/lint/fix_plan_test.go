@@ -0,0 +1,74 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestPlanFixes(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"commands/c.md": "---\nargument-hint: <x>\nallowed-tools: Read, Read\n---\nbody\n",
+		"agents/a.md":   "---\nname: a\ntools: Grep, Grep\n---\nbody\n",
+		"agents/ok.md":  "---\nname: ok\n---\nbody\n",
+	}
+	for rel, contents := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dup := func(file string, line int) cue.ValidationError {
+		return cue.ValidationError{File: file, Rule: cue.RuleAgentToolDuplicate, Line: line}
+	}
+	summaries := []*LintSummary{
+		{ProjectRoot: root, Results: []LintResult{
+			{File: "commands/c.md", Suggestions: []cue.ValidationError{
+				{File: "commands/c.md", Rule: cue.RuleCommandArgHintUnused, Line: 2},
+				dup("commands/c.md", 3),
+			}},
+		}},
+		{ProjectRoot: root, Results: []LintResult{
+			// The same line reported twice: the second fix is skipped.
+			{File: "agents/a.md", Warnings: []cue.ValidationError{dup("agents/a.md", 3), dup("agents/a.md", 3)}},
+			{File: "agents/ok.md", Errors: []cue.ValidationError{{File: "agents/ok.md", Rule: "no-fixer", Line: 1}}},
+		}},
+	}
+
+	plan := PlanFixes(summaries)
+	if len(plan) != 2 {
+		t.Fatalf("PlanFixes() = %d files, want 2: %+v", len(plan), plan)
+	}
+
+	tests := []struct {
+		relPath     string
+		wantAfter   string
+		wantApplied int
+		wantSkipped int
+	}{
+		{relPath: "agents/a.md", wantAfter: "---\nname: a\ntools: Grep\n---\nbody\n", wantApplied: 1, wantSkipped: 1},
+		{relPath: "commands/c.md", wantAfter: "---\nallowed-tools: Read\n---\nbody\n", wantApplied: 2},
+	}
+	for i, tt := range tests {
+		ff := plan[i]
+		if ff.RelPath != tt.relPath {
+			t.Fatalf("plan[%d] = %s, want %s", i, ff.RelPath, tt.relPath)
+		}
+		if ff.Path != filepath.Join(root, tt.relPath) || ff.Before != files[tt.relPath] {
+			t.Errorf("%s: Path = %s, Before = %q; want the file on disk", tt.relPath, ff.Path, ff.Before)
+		}
+		if ff.After != tt.wantAfter {
+			t.Errorf("%s: After = %q, want %q", tt.relPath, ff.After, tt.wantAfter)
+		}
+		if len(ff.Applied) != tt.wantApplied || ff.Skipped != tt.wantSkipped {
+			t.Errorf("%s: applied %v, skipped %d; want %d applied, %d skipped", tt.relPath, ff.Applied, ff.Skipped, tt.wantApplied, tt.wantSkipped)
+		}
+	}
+}
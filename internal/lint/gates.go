@@ -0,0 +1,155 @@
+package lint
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/dotcommander/cclint/internal/baseline"
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+// Gate names, as reported in GateCheck.Gate. A score gate is named
+// GateMinScore + "." + the component type it judges, e.g. "minScore.agent".
+const (
+	GateMaxErrors      = "maxErrors"
+	GateMaxWarnings    = "maxWarnings"
+	GateMaxSuggestions = "maxSuggestions"
+	GateMinScore       = "minScore"
+	GateNoNewIssues    = "noNewIssues"
+)
+
+// GateCheck is the outcome of one configured quality gate.
+type GateCheck struct {
+	Gate string
+	// Limit is the configured maximum, or minimum for a score gate.
+	Limit int
+	// Actual is the run's count, or the lowest score for a score gate.
+	Actual int
+	Passed bool
+	// Files lists the files below a score gate's minimum, lowest first.
+	Files []string
+	// Detail explains a gate that could not be evaluated.
+	Detail string
+}
+
+// GateResult is the outcome of the gates config block for a run.
+type GateResult struct {
+	Passed bool
+	Checks []GateCheck
+}
+
+// EvaluateGates checks the run's summaries against gates. The noNewIssues
+// gate compares the remaining issues against b and fails when b is nil,
+// since there is nothing to compare against. It returns nil when no gate is
+// configured.
+func EvaluateGates(gates config.Gates, summaries []*LintSummary, b *baseline.Baseline) *GateResult {
+	if !gates.Enabled() {
+		return nil
+	}
+
+	var errs, warnings, suggestions int
+	for _, s := range summaries {
+		if s == nil {
+			continue
+		}
+		errs += s.TotalErrors
+		warnings += s.TotalWarnings
+		suggestions += s.TotalSuggestions
+	}
+
+	var checks []GateCheck
+	maxCheck := func(gate string, limit *int, actual int) {
+		if limit != nil {
+			checks = append(checks, GateCheck{Gate: gate, Limit: *limit, Actual: actual, Passed: actual <= *limit})
+		}
+	}
+	maxCheck(GateMaxErrors, gates.MaxErrors, errs)
+	maxCheck(GateMaxWarnings, gates.MaxWarnings, warnings)
+	maxCheck(GateMaxSuggestions, gates.MaxSuggestions, suggestions)
+	checks = append(checks, scoreGates(gates, summaries)...)
+	if gates.NoNewIssues {
+		checks = append(checks, newIssuesGate(summaries, b))
+	}
+
+	result := &GateResult{Passed: true, Checks: checks}
+	for _, c := range checks {
+		if !c.Passed {
+			result.Passed = false
+		}
+	}
+	return result
+}
+
+// scoreGates checks the quality score of each scored, enabled result
+// against the minimum for its type, one check per type with scored files.
+func scoreGates(gates config.Gates, summaries []*LintSummary) []GateCheck {
+	type scored struct {
+		file  string
+		score int
+	}
+	byType := make(map[string][]scored)
+	for _, s := range summaries {
+		if s == nil {
+			continue
+		}
+		for _, r := range s.Results {
+			if r.Quality == nil || r.Disabled {
+				continue
+			}
+			typ := cmp.Or(r.Type, s.ComponentType)
+			if ft, err := discovery.ParseFileType(typ); err == nil {
+				typ = ft.String()
+			}
+			if _, ok := gates.MinScoreFor(typ); ok {
+				byType[typ] = append(byType[typ], scored{r.File, r.Quality.Overall})
+			}
+		}
+	}
+
+	types := make([]string, 0, len(byType))
+	for typ := range byType {
+		types = append(types, typ)
+	}
+	slices.Sort(types)
+
+	checks := make([]GateCheck, 0, len(types))
+	for _, typ := range types {
+		files := byType[typ]
+		slices.SortStableFunc(files, func(a, b scored) int {
+			return cmp.Or(cmp.Compare(a.score, b.score), cmp.Compare(a.file, b.file))
+		})
+		limit, _ := gates.MinScoreFor(typ)
+		check := GateCheck{Gate: GateMinScore + "." + typ, Limit: limit, Actual: files[0].score, Passed: true}
+		for _, f := range files {
+			if f.score >= limit {
+				break
+			}
+			check.Passed = false
+			check.Files = append(check.Files, f.file)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// newIssuesGate counts the issues the baseline does not list.
+func newIssuesGate(summaries []*LintSummary, b *baseline.Baseline) GateCheck {
+	check := GateCheck{Gate: GateNoNewIssues}
+	if b == nil {
+		check.Detail = "no baseline to compare against; create one with --baseline-create"
+		return check
+	}
+	for _, s := range summaries {
+		if s == nil {
+			continue
+		}
+		for _, issue := range CollectAllIssues(s) {
+			if !b.IsKnown(issue) {
+				check.Actual++
+			}
+		}
+	}
+	check.Passed = check.Actual == 0
+	return check
+}
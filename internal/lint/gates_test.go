@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/baseline"
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/scoring"
+)
+
+func gateSummaries() []*LintSummary {
+	known := cue.ValidationError{File: "agents/a.md", Message: "known", Severity: cue.SeverityWarning}
+	summary := &LintSummary{
+		ComponentType: "agents",
+		Results: []LintResult{
+			{File: "agents/a.md", Type: "agent", Warnings: []cue.ValidationError{known}, Quality: &scoring.QualityScore{Overall: 55}},
+			{File: "agents/b.md", Type: "agent", Quality: &scoring.QualityScore{Overall: 90}},
+			{File: "agents/c.md", Type: "agent", Quality: &scoring.QualityScore{Overall: 40}, Disabled: true},
+			{File: "skills/s/SKILL.md", Type: "skill", Quality: &scoring.QualityScore{Overall: 65}},
+		},
+	}
+	recalculateTotals(summary)
+	return []*LintSummary{summary, nil}
+}
+
+func TestEvaluateGates(t *testing.T) {
+	if got := EvaluateGates(config.Gates{}, gateSummaries(), nil); got != nil {
+		t.Errorf("EvaluateGates without gates = %+v, want nil", got)
+	}
+
+	zero, one := 0, 1
+	gates := config.Gates{
+		MaxErrors:   &zero,
+		MaxWarnings: &zero,
+		MinScore:    map[string]int{"agents": 60, "default": 50},
+	}
+	got := EvaluateGates(gates, gateSummaries(), nil)
+	want := []GateCheck{
+		{Gate: GateMaxErrors, Limit: 0, Actual: 0, Passed: true},
+		{Gate: GateMaxWarnings, Limit: 0, Actual: 1},
+		{Gate: "minScore.agent", Limit: 60, Actual: 55, Files: []string{"agents/a.md"}},
+		{Gate: "minScore.skill", Limit: 50, Actual: 65, Passed: true},
+	}
+	if got.Passed || len(got.Checks) != len(want) {
+		t.Fatalf("EvaluateGates = %+v, want failing %+v", got, want)
+	}
+	for i := range want {
+		g, w := got.Checks[i], want[i]
+		if g.Gate != w.Gate || g.Limit != w.Limit || g.Actual != w.Actual || g.Passed != w.Passed || !slices.Equal(g.Files, w.Files) {
+			t.Errorf("check %d = %+v, want %+v", i, g, w)
+		}
+	}
+
+	gates = config.Gates{MaxWarnings: &one, MinScore: map[string]int{"skills": 60}}
+	if got := EvaluateGates(gates, gateSummaries(), nil); !got.Passed || len(got.Checks) != 2 {
+		t.Errorf("EvaluateGates = %+v, want two passing checks", got)
+	}
+}
+
+func TestEvaluateGatesNoNewIssues(t *testing.T) {
+	gates := config.Gates{NoNewIssues: true}
+
+	got := EvaluateGates(gates, gateSummaries(), nil)
+	if got.Passed || got.Checks[0].Detail == "" {
+		t.Errorf("without a baseline = %+v, want a failing check with detail", got)
+	}
+
+	b := baseline.CreateBaseline(CollectAllIssues(gateSummaries()[0]))
+	if got := EvaluateGates(gates, gateSummaries(), b); !got.Passed || got.Checks[0].Actual != 0 {
+		t.Errorf("with a matching baseline = %+v, want pass", got)
+	}
+
+	summaries := gateSummaries()
+	summaries[0].Results[1].Errors = []cue.ValidationError{{File: "agents/b.md", Message: "new", Severity: cue.SeverityError}}
+	if got := EvaluateGates(gates, summaries, b); got.Passed || got.Checks[0].Actual != 1 {
+		t.Errorf("with a new issue = %+v, want one new issue", got)
+	}
+}
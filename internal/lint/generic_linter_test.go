@@ -440,7 +440,7 @@ func TestValidateAllowedToolsShared(t *testing.T) {
 	data := map[string]any{
 		"allowed-tools": "Read, Write",
 	}
-	errors := ValidateAllowedToolsShared(data, "test.md", "")
+	errors := ValidateAllowedToolsShared(nil, data, "test.md", "")
 	// Verify function exists and returns without panic
 	_ = errors
 }
@@ -0,0 +1,352 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// HookSource is one place hooks are configured, with its hooks in the
+// settings layout ({Event: [entries]}).
+type HookSource struct {
+	File       string // relative to the project root when inside it
+	Scope      string // "agent:<name>" or "skill:<name>" for component hooks, which load only while that component runs; "" otherwise
+	Dir        string // the plugin root for plugin hooks, expanded for ${CLAUDE_PLUGIN_ROOT}
+	Hooks      map[string]any
+	Pointer    string // JSON Pointer of the hooks object in File
+	DisableAll bool   // a settings file that sets disableAllHooks
+}
+
+// CollectHookSources gathers the hooks configured in files, in the order
+// Claude Code loads them: settings files, each followed by its
+// settings.local.json, then plugin hooks, then agent and skill frontmatter
+// hooks. Files that do not parse are skipped; linting reports them.
+func CollectHookSources(files []discovery.File, rootPath string) []HookSource {
+	var settings, plugins, components []HookSource
+	for _, f := range files {
+		path := f.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(rootPath, f.RelPath)
+		}
+		contents := f.Contents
+		if contents == "" {
+			data, err := os.ReadFile(path) //nolint:gosec // G304: discovered under the project root
+			if err != nil {
+				continue
+			}
+			contents = string(data)
+		}
+		switch f.Type {
+		case discovery.FileTypeSettings:
+			settings = appendSettingsSource(settings, f.RelPath, contents)
+			local := filepath.Join(filepath.Dir(path), "settings.local.json")
+			if data, err := os.ReadFile(local); err == nil { //nolint:gosec // G304: sibling of a discovered settings file
+				settings = appendSettingsSource(settings, filepath.ToSlash(filepath.Join(filepath.Dir(f.RelPath), "settings.local.json")), string(data))
+			}
+		case discovery.FileTypePlugin:
+			plugins = append(plugins, pluginHookSources(path, f.RelPath, contents)...)
+		case discovery.FileTypeAgent, discovery.FileTypeSkill:
+			if src, ok := componentHookSource(f, contents); ok {
+				components = append(components, src)
+			}
+		}
+	}
+	return slices.Concat(settings, plugins, components)
+}
+
+// appendSettingsSource adds a settings file that configures hooks or
+// disables them.
+func appendSettingsSource(sources []HookSource, rel, contents string) []HookSource {
+	blanked, _ := textutil.BlankJSONC(contents)
+	var data map[string]any
+	if json.Unmarshal([]byte(blanked), &data) != nil {
+		return sources
+	}
+	hooks, _ := data["hooks"].(map[string]any)
+	disable, _ := data["disableAllHooks"].(bool)
+	if len(hooks) == 0 && !disable {
+		return sources
+	}
+	return append(sources, HookSource{File: rel, Hooks: hooks, Pointer: "/hooks", DisableAll: disable})
+}
+
+// pluginHookSources returns a plugin's hooks: those inline in its manifest
+// and those in its hook files.
+func pluginHookSources(manifest, rel, contents string) []HookSource {
+	var data map[string]any
+	if json.Unmarshal([]byte(contents), &data) != nil {
+		return nil
+	}
+	pluginRoot := filepath.Dir(filepath.Dir(manifest))
+	relRoot := filepath.Dir(filepath.Dir(rel))
+	var sources []HookSource
+	if h, ok := data["hooks"].(map[string]any); ok {
+		sources = append(sources, HookSource{File: rel, Dir: pluginRoot, Hooks: h, Pointer: "/hooks"})
+	}
+	for _, file := range pluginHookFiles(data) {
+		if hooks, ok := readPluginHooks(pluginRoot, file); ok {
+			src := HookSource{File: filepath.ToSlash(filepath.Join(relRoot, file)), Dir: pluginRoot, Hooks: hookEvents(hooks)}
+			if _, wrapped := hooks["hooks"].(map[string]any); wrapped {
+				src.Pointer = "/hooks"
+			}
+			sources = append(sources, src)
+		}
+	}
+	return sources
+}
+
+// componentHookSource returns the frontmatter hooks of an agent or skill.
+func componentHookSource(f discovery.File, contents string) (HookSource, bool) {
+	fm, err := textutil.ParseYAMLFrontmatter(contents)
+	if err != nil {
+		return HookSource{}, false
+	}
+	hooks, ok := fm.Data["hooks"].(map[string]any)
+	if !ok || len(hooks) == 0 {
+		return HookSource{}, false
+	}
+	name, _ := fm.Data["name"].(string)
+	scope := cue.TypeAgent
+	if f.Type == discovery.FileTypeSkill {
+		scope = cue.TypeSkill
+		if name == "" {
+			name = filepath.Base(filepath.Dir(f.RelPath))
+		}
+	} else if name == "" {
+		name = strings.TrimSuffix(filepath.Base(f.RelPath), filepath.Ext(f.RelPath))
+	}
+	return HookSource{File: f.RelPath, Scope: scope + ":" + name, Hooks: hooks, Pointer: "/hooks"}, true
+}
+
+// IsHookEvent reports whether event is a hook event Claude Code knows.
+func IsHookEvent(event string) bool {
+	return validHookEvents[event]
+}
+
+// IsToolHookEvent reports whether event is tool-scoped, so its matchers
+// test the tool name.
+func IsToolHookEvent(event string) bool {
+	return matcherRequiredEvents[event]
+}
+
+// HookEventNames returns the known hook events, sorted.
+func HookEventNames() []string {
+	return slices.Sorted(maps.Keys(validHookEvents))
+}
+
+// HookEvent is the event a hook simulation replays.
+type HookEvent struct {
+	Name   string
+	Target string         // what matchers test: the tool name for tool events; the source, trigger, or type for others
+	Input  map[string]any // the tool input, for argument patterns and "if" conditions
+}
+
+// SimulatedHook is one hook configured for the simulated event and whether
+// it would fire. Reason says why a hook does not fire, or what the
+// simulation could not check for one that does.
+type SimulatedHook struct {
+	File    string         `json:"file"`
+	Scope   string         `json:"scope,omitempty"`
+	Pointer string         `json:"pointer"`
+	Matcher string         `json:"matcher"`
+	Type    string         `json:"type"`
+	Run     string         `json:"run"` // the command, url, or prompt
+	Fires   bool           `json:"fires"`
+	Reason  string         `json:"reason,omitempty"`
+	Dir     string         `json:"-"` // the plugin root of plugin hooks
+	Hook    map[string]any `json:"-"`
+}
+
+// SimulateHooks evaluates every hook configured for event in sources, in
+// load order, without running any. A hook fires when its entry's matcher
+// selects the target and its "if" condition, if any, holds for the input.
+// Identical command hooks run once, so repeats are reported as
+// duplicates. Claude Code starts the hooks that fire in parallel; the
+// order is the order they are configured in.
+func SimulateHooks(sources []HookSource, event HookEvent) []SimulatedHook {
+	disabledBy := ""
+	for _, src := range sources {
+		if src.DisableAll {
+			disabledBy = src.File
+			break
+		}
+	}
+
+	var hooks []SimulatedHook
+	seen := make(map[string]string)
+	for _, src := range sources {
+		entries, _ := src.Hooks[event.Name].([]any)
+		for i, e := range entries {
+			entry, _ := e.(map[string]any)
+			matches, reason := matchHookEntry(entry["matcher"], event)
+			inner, _ := entry["hooks"].([]any)
+			for j, h := range inner {
+				hook, _ := h.(map[string]any)
+				sim := SimulatedHook{
+					File:    src.File,
+					Scope:   src.Scope,
+					Pointer: src.Pointer + textutil.JSONPointer(event.Name, i, "hooks", j),
+					Matcher: matcherString(entry["matcher"]),
+					Type:    stringField(hook, "type"),
+					Run:     hookRun(hook),
+					Fires:   matches,
+					Reason:  reason,
+					Dir:     src.Dir,
+					Hook:    hook,
+				}
+				if sim.Fires {
+					sim.Fires, sim.Reason = checkHookIf(hook, event, sim.Reason)
+				}
+				if sim.Fires && disabledBy != "" {
+					sim.Fires, sim.Reason = false, "disableAllHooks is set in "+disabledBy
+				}
+				if sim.Fires && sim.Type == cue.TypeCommand {
+					key := sim.Run + "\x00" + src.Scope
+					if first, ok := seen[key]; ok {
+						sim.Fires, sim.Reason = false, "duplicate of "+first+"; identical commands run once"
+					} else {
+						seen[key] = sim.File + " " + sim.Pointer
+					}
+				}
+				hooks = append(hooks, sim)
+			}
+		}
+	}
+	return hooks
+}
+
+// matchHookEntry reports whether a hook entry's matcher selects the
+// event's target. A missing, empty, or "*" matcher selects everything. A
+// string matcher is a regex that must match the whole target, so "Edit"
+// does not select "NotebookEdit". An object matcher names a tool the way a
+// permission rule does, such as "Bash(npm *)".
+func matchHookEntry(matcher any, event HookEvent) (bool, string) {
+	switch m := matcher.(type) {
+	case nil:
+		return true, ""
+	case string:
+		if m == "" || m == "*" {
+			return true, ""
+		}
+		if event.Target == "" {
+			return true, fmt.Sprintf("matcher %q not checked without a target", m)
+		}
+		re, err := regexp.Compile(`^(?:` + m + `)$`)
+		if err != nil {
+			return false, fmt.Sprintf("matcher %q is not a valid regex: %v", m, err)
+		}
+		if !re.MatchString(event.Target) {
+			return false, fmt.Sprintf("matcher %q does not match %q", m, event.Target)
+		}
+		return true, ""
+	case map[string]any:
+		rule, _ := m["toolName"].(string)
+		if rule == "" {
+			return false, "matcher object has no toolName"
+		}
+		return matchToolRule(rule, "matcher", event)
+	}
+	return false, fmt.Sprintf("matcher of type %T is not a string or object", matcher)
+}
+
+// checkHookIf applies a hook's "if" condition, a permission rule such as
+// "Bash(git *)" tested against the tool and its input. reason is kept when
+// the condition holds.
+func checkHookIf(hook map[string]any, event HookEvent, reason string) (bool, string) {
+	cond, _ := hook["if"].(string)
+	if cond == "" {
+		return true, reason
+	}
+	ok, why := matchToolRule(cond, "if", event)
+	if ok && why == "" {
+		why = reason
+	}
+	return ok, why
+}
+
+// matchToolRule tests a permission-rule pattern, Tool or Tool(args), against
+// the event's tool and input. The argument pattern is matched against the
+// tool's main input field (command, file_path, url, ...) with * as a
+// wildcard and a trailing :* as a prefix match; without that field in the
+// input it is not checked.
+func matchToolRule(rule, label string, event HookEvent) (bool, string) {
+	tool := textutil.ExtractBaseToolName(rule)
+	if event.Target == "" {
+		return true, fmt.Sprintf("%s %q not checked without a tool", label, rule)
+	}
+	if tool != event.Target && tool != "*" {
+		return false, fmt.Sprintf("%s %q does not match tool %q", label, rule, event.Target)
+	}
+	open, closing := strings.IndexByte(rule, '('), strings.LastIndexByte(rule, ')')
+	if open < 0 || closing < open {
+		return true, ""
+	}
+	pattern := rule[open+1 : closing]
+	value, ok := primaryToolInput(event.Input)
+	if !ok {
+		return true, fmt.Sprintf("%s %q argument pattern not checked without --input", label, rule)
+	}
+	if !ruleArgPattern(pattern).MatchString(value) {
+		return false, fmt.Sprintf("%s %q does not match %q", label, rule, value)
+	}
+	return true, ""
+}
+
+// primaryInputFields are the tool input fields permission-rule arguments
+// match, in the order they are looked for.
+var primaryInputFields = []string{"command", "file_path", "notebook_path", "path", "url", "pattern", "query"}
+
+// primaryToolInput returns the input field a permission-rule argument
+// pattern is tested against.
+func primaryToolInput(input map[string]any) (string, bool) {
+	for _, field := range primaryInputFields {
+		if v, ok := input[field].(string); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// matcherString shows a matcher as written: a string as is, an object by
+// its toolName.
+func matcherString(matcher any) string {
+	switch m := matcher.(type) {
+	case string:
+		return m
+	case map[string]any:
+		name, _ := m["toolName"].(string)
+		return name
+	}
+	return ""
+}
+
+// hookRun returns what a hook runs: its command, url, or prompt.
+func hookRun(hook map[string]any) string {
+	for _, field := range []string{"command", "url", "prompt"} {
+		if v, ok := hook[field].(string); ok {
+			if field == "command" {
+				if args, ok := hook["args"].([]any); ok {
+					for _, a := range args {
+						v += " " + fmt.Sprint(a)
+					}
+				}
+			}
+			return v
+		}
+	}
+	return ""
+}
+
+func stringField(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
@@ -0,0 +1,113 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchHookEntry(t *testing.T) {
+	bash := HookEvent{Name: "PreToolUse", Target: "Bash", Input: map[string]any{"command": "git push origin"}}
+	tests := []struct {
+		name    string
+		matcher any
+		event   HookEvent
+		want    bool
+		reason  string
+	}{
+		{"no matcher", nil, bash, true, ""},
+		{"wildcard", "*", bash, true, ""},
+		{"exact", "Bash", bash, true, ""},
+		{"alternation", "Edit|Bash", bash, true, ""},
+		{"whole name only", "Edit", HookEvent{Target: "NotebookEdit"}, false, `matcher "Edit" does not match "NotebookEdit"`},
+		{"regex", "mcp__.*", HookEvent{Target: "mcp__github__search"}, true, ""},
+		{"invalid regex", "Bash(", bash, false, `matcher "Bash(" is not a valid regex`},
+		{"no target", "Bash", HookEvent{}, true, "not checked without a target"},
+		{"object", map[string]any{"toolName": "Bash(git *)"}, bash, true, ""},
+		{"object args differ", map[string]any{"toolName": "Bash(npm *)"}, bash, false, `does not match "git push origin"`},
+		{"object without input", map[string]any{"toolName": "Bash(npm *)"}, HookEvent{Target: "Bash"}, true, "not checked without --input"},
+		{"object prefix", map[string]any{"toolName": "Bash(git push:*)"}, bash, true, ""},
+		{"object other tool", map[string]any{"toolName": "Write"}, bash, false, `does not match tool "Bash"`},
+		{"object without toolName", map[string]any{}, bash, false, "no toolName"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := matchHookEntry(tt.matcher, tt.event)
+			assert.Equal(t, tt.want, got)
+			if tt.reason == "" {
+				assert.Empty(t, reason)
+			} else {
+				assert.Contains(t, reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestSimulateHooks(t *testing.T) {
+	entry := func(matcher any, hooks ...map[string]any) map[string]any {
+		inner := make([]any, len(hooks))
+		for i, h := range hooks {
+			inner[i] = h
+		}
+		return map[string]any{"matcher": matcher, "hooks": inner}
+	}
+	command := func(c string) map[string]any { return map[string]any{"type": "command", "command": c} }
+	sources := []HookSource{
+		{File: ".claude/settings.json", Pointer: "/hooks", Hooks: map[string]any{"PreToolUse": []any{
+			entry("Bash", command("guard.sh"), map[string]any{"type": "command", "command": "git-guard.sh", "if": "Bash(git *)"}),
+			entry("Edit|Write", command("fmt.sh")),
+		}}},
+		{File: ".claude/settings.local.json", Pointer: "/hooks", Hooks: map[string]any{"PreToolUse": []any{
+			entry("*", command("guard.sh")),
+		}}},
+		{File: ".claude/agents/a.md", Scope: "agent:a", Pointer: "/hooks", Hooks: map[string]any{"PreToolUse": []any{
+			entry("Bash", command("guard.sh")),
+		}}},
+	}
+
+	hooks := SimulateHooks(sources, HookEvent{Name: "PreToolUse", Target: "Bash", Input: map[string]any{"command": "ls"}})
+	require.Len(t, hooks, 5)
+	assert.True(t, hooks[0].Fires)
+	assert.Equal(t, "/hooks/PreToolUse/0/hooks/0", hooks[0].Pointer)
+	assert.False(t, hooks[1].Fires, "the if condition does not hold for ls")
+	assert.False(t, hooks[2].Fires)
+	assert.False(t, hooks[3].Fires)
+	assert.Contains(t, hooks[3].Reason, "duplicate of .claude/settings.json /hooks/PreToolUse/0/hooks/0")
+	assert.True(t, hooks[4].Fires, "component hooks are not deduplicated against session hooks")
+
+	sources[1].DisableAll = true
+	for _, h := range SimulateHooks(sources, HookEvent{Name: "PreToolUse", Target: "Bash"}) {
+		assert.False(t, h.Fires)
+	}
+	assert.Empty(t, SimulateHooks(sources, HookEvent{Name: "Stop"}))
+}
+
+func TestCollectHookSources(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, contents string) {
+		path := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+	write(".claude/settings.json", `{"hooks": {"Stop": [{"hooks": [{"type": "command", "command": "a"}]}]}}`)
+	write(".claude/settings.local.json", `{"disableAllHooks": false, "model": "opus"}`)
+	write(".claude/agents/rev.md", "---\nname: reviewer\nhooks:\n  Stop:\n    - hooks:\n        - type: command\n          command: b\n---\n")
+	write(".claude/skills/deploy/SKILL.md", "---\ndescription: no hooks\n---\n")
+	write("plug/.claude-plugin/plugin.json", `{"name": "plug"}`)
+	write("plug/hooks/hooks.json", `{"hooks": {"Stop": [{"hooks": [{"type": "command", "command": "c"}]}]}}`)
+
+	files, err := discovery.NewFileDiscovery(root).DiscoverFiles()
+	require.NoError(t, err)
+	sources := CollectHookSources(files, root)
+	require.Len(t, sources, 3, "settings.local.json has no hooks")
+	assert.Equal(t, ".claude/settings.json", sources[0].File)
+	assert.Equal(t, "plug/hooks/hooks.json", sources[1].File)
+	assert.Equal(t, filepath.Join(root, "plug"), sources[1].Dir)
+	assert.Equal(t, "/hooks", sources[1].Pointer)
+	assert.Equal(t, ".claude/agents/rev.md", sources[2].File)
+	assert.Equal(t, "agent:reviewer", sources[2].Scope)
+}
@@ -201,6 +201,7 @@ func DetectImportCycles(files map[string]string) []cue.ValidationError {
 			Message:  fmt.Sprintf("Circular @import detected: %s", FormatImportCycle(cycle)),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleContextImportCycle,
 		})
 	}
 
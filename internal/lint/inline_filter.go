@@ -0,0 +1,59 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/baseline"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// SuppressionInline is the suppression source for issues hidden by a
+// cclint-disable comment in their file.
+const SuppressionInline = "inline"
+
+// ApplyInlineSuppressions drops issues that a cclint-disable comment in
+// their markdown file suppresses, recording them as suppressed. Like
+// baseline filtering it runs after linting, reading each file with issues
+// from disk.
+func ApplyInlineSuppressions(summary *LintSummary) {
+	if summary == nil {
+		return
+	}
+
+	changed := false
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		if !strings.EqualFold(filepath.Ext(result.File), ".md") ||
+			len(result.Errors)+len(result.Warnings)+len(result.Suggestions) == 0 {
+			continue
+		}
+		path := result.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(summary.ProjectRoot, path)
+		}
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path of a linted file
+		if err != nil {
+			continue
+		}
+		inline := baseline.ParseInline(string(data))
+		if inline.Len() == 0 {
+			continue
+		}
+
+		var errs, warns, suggs []cue.ValidationError
+		result.Errors, errs = filterIssues(result.Errors, inline.Suppresses)
+		result.Warnings, warns = filterIssues(result.Warnings, inline.Suppresses)
+		result.Suggestions, suggs = filterIssues(result.Suggestions, inline.Suppresses)
+		for _, ignored := range [][]cue.ValidationError{errs, warns, suggs} {
+			recordSuppressed(summary, ignored, SuppressionInline)
+			changed = changed || len(ignored) > 0
+		}
+		result.Success = len(result.Errors) == 0
+	}
+
+	if changed {
+		recalculateTotals(summary)
+	}
+}
@@ -0,0 +1,51 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestApplyInlineSuppressions(t *testing.T) {
+	root := t.TempDir()
+	contents := "---\nname: a\n---\n<!-- cclint-disable-next-line rule-line -->\nBody.\n<!-- cclint-disable-file rule-file -->\n"
+	if err := os.MkdirAll(filepath.Join(root, "agents"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "agents/a.md"), []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	newSummary := func() *LintSummary {
+		return &LintSummary{
+			ProjectRoot: root,
+			Results: []LintResult{{
+				File: "agents/a.md",
+				Errors: []cue.ValidationError{
+					{File: "agents/a.md", Rule: "rule-file", Severity: cue.SeverityError, Line: 2},
+				},
+				Warnings: []cue.ValidationError{
+					{File: "agents/a.md", Rule: "rule-line", Severity: cue.SeverityWarning, Line: 5},
+					{File: "agents/a.md", Rule: "rule-line", Severity: cue.SeverityWarning, Line: 2},
+					{File: "agents/a.md", Severity: cue.SeverityWarning, Line: 5, Message: "no rule ID"},
+				},
+			}},
+			TotalErrors:   1,
+			TotalWarnings: 3,
+		}
+	}
+
+	summary := newSummary()
+	ApplyInlineSuppressions(summary)
+	if summary.TotalErrors != 0 || summary.TotalWarnings != 2 {
+		t.Errorf("totals = %d errors, %d warnings, want 0 and 2", summary.TotalErrors, summary.TotalWarnings)
+	}
+	if !summary.Results[0].Success {
+		t.Error("result should succeed once its only error is suppressed")
+	}
+	if len(summary.Suppressed) != 2 || summary.Suppressed[0].Source != SuppressionInline {
+		t.Errorf("Suppressed = %v, want 2 inline entries", summary.Suppressed)
+	}
+}
@@ -41,7 +41,7 @@ Test foundation
 		t.Fatal(err)
 	}
 
-	summary, err := LintAgents(tmpDir, true, false, false, nil)
+	summary, err := LintAgents(testOptions(tmpDir))
 	if err != nil {
 		t.Fatalf("LintAgents() error = %v", err)
 	}
@@ -70,7 +70,7 @@ Task(test-specialist): do something
 		t.Fatal(err)
 	}
 
-	summary, err := LintCommands(tmpDir, true, false, false, nil)
+	summary, err := LintCommands(testOptions(tmpDir))
 	if err != nil {
 		t.Fatalf("LintCommands() error = %v", err)
 	}
@@ -103,7 +103,7 @@ Content here
 		t.Fatal(err)
 	}
 
-	summary, err := LintSkills(tmpDir, true, false, false, nil)
+	summary, err := LintSkills(testOptions(tmpDir))
 	if err != nil {
 		t.Fatalf("LintSkills() error = %v", err)
 	}
@@ -135,7 +135,7 @@ Content
 		t.Fatal(err)
 	}
 
-	summary, err := LintRules(tmpDir, true, false, false, nil)
+	summary, err := LintRules(testOptions(tmpDir))
 	if err != nil {
 		t.Fatalf("LintRules() error = %v", err)
 	}
@@ -157,7 +157,7 @@ func TestSingleFileContextEnsure(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	ctx, err := NewSingleFileLinterContext(testFile, tmpDir, "", false, false)
+	ctx, err := NewSingleFileLinterContext(testFile, tmpDir, "", Options{})
 	if err != nil {
 		t.Fatalf("NewSingleFileLinterContext() error = %v", err)
 	}
@@ -301,7 +301,7 @@ func TestSingleFileLinterTypes(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			ctx, err := NewSingleFileLinterContext(filePath, tmpDir, "", false, false)
+			ctx, err := NewSingleFileLinterContext(filePath, tmpDir, "", Options{})
 			if err != nil {
 				t.Fatalf("NewSingleFileLinterContext() error = %v", err)
 			}
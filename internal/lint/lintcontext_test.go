@@ -3,11 +3,22 @@ package lint
 import (
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/discovery"
 )
 
+// testOptions returns the options of a quiet run over root with the
+// default configuration.
+func testOptions(root string) Options {
+	cfg := config.Default()
+	cfg.Root = root
+	cfg.Verbosity = config.VerbosityQuiet
+	return Options{Config: cfg}
+}
+
 func TestNewLinterContext(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -67,7 +78,15 @@ func TestNewLinterContext(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx, err := NewLinterContext(tt.rootPath, tt.quiet, tt.verbose, tt.noCycleCheck, nil)
+			cfg := config.Default()
+			cfg.Root, cfg.NoCycleCheck = tt.rootPath, tt.noCycleCheck
+			switch {
+			case tt.quiet:
+				cfg.Verbosity = config.VerbosityQuiet
+			case tt.verbose:
+				cfg.Verbosity = config.VerbosityVerbose
+			}
+			ctx, err := NewLinterContext(Options{Config: cfg})
 
 			if tt.wantErr {
 				if err == nil {
@@ -200,7 +219,7 @@ func TestLinterContextAutoDiscoverRoot(t *testing.T) {
 	}
 
 	// Test with empty rootPath - should auto-discover
-	ctx, err := NewLinterContext("", false, false, false, nil)
+	ctx, err := NewLinterContext(Options{Config: &config.Config{}})
 	if err != nil {
 		t.Fatalf("NewLinterContext() with empty root failed: %v", err)
 	}
@@ -209,3 +228,40 @@ func TestLinterContextAutoDiscoverRoot(t *testing.T) {
 		t.Error("NewLinterContext() with empty root should discover root")
 	}
 }
+
+// TestIncludeChainsPerRun tests that --chains applies to the run that asks
+// for it, so concurrent runs can differ.
+func TestIncludeChainsPerRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	commandsDir := filepath.Join(tmpDir, ".claude", "commands")
+	if err := os.MkdirAll(commandsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	command := "---\ndescription: Build the project\n---\nRun the build.\n"
+	if err := os.WriteFile(filepath.Join(commandsDir, "build.md"), []byte(command), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	with, without := testOptions(tmpDir), testOptions(tmpDir)
+	with.IncludeChains = true
+	summaries := make([]*LintSummary, 2)
+	var wg sync.WaitGroup
+	for i, opts := range []Options{with, without} {
+		wg.Go(func() {
+			summary, err := LintCommands(opts)
+			if err != nil {
+				t.Errorf("LintCommands() error = %v", err)
+				return
+			}
+			summaries[i] = summary
+		})
+	}
+	wg.Wait()
+
+	if summaries[0] == nil || len(summaries[0].Chains) != 1 {
+		t.Errorf("chains with IncludeChains = %+v, want one", summaries[0])
+	}
+	if summaries[1] == nil || summaries[1].Chains != nil {
+		t.Errorf("chains without IncludeChains = %+v, want none", summaries[1])
+	}
+}
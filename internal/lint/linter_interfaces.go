@@ -13,9 +13,11 @@ package lint
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
@@ -39,6 +41,9 @@ type ComponentLinter interface {
 	// FileType returns the discovery.FileType for filtering
 	FileType() discovery.FileType
 
+	// Config returns the configuration whose rule settings the checks use
+	Config() *config.Config
+
 	// ParseContent parses the file content and returns frontmatter data and body.
 	// For JSON files, body may be empty.
 	ParseContent(contents string) (data map[string]any, body string, err error)
@@ -83,6 +88,14 @@ type Improvable interface {
 	GetImprovements(contents string, data map[string]any) []textutil.ImprovementRecommendation
 }
 
+// DataResolver is an optional interface for linters whose files build on
+// data from other files.
+type DataResolver interface {
+	// ResolveData returns the data to validate in place of the file's own,
+	// and the problems found resolving it.
+	ResolveData(filePath, contents string, data map[string]any) (map[string]any, []cue.ValidationError)
+}
+
 // PostProcessable is an optional interface for linters needing result post-processing.
 type PostProcessable interface {
 	// PostProcess allows type-specific post-processing of results.
@@ -104,26 +117,64 @@ func lintFileCore(filePath, contents string, linter ComponentLinter, validator *
 		return result
 	}
 
-	// Parse content
+	// Encoding checks look at the raw bytes, so they run even when parsing fails
+	categorizeIssues(&result, CheckContentEncoding(contents, filePath, linter.Config().LineEndings))
+
+	// Parse content. Frontmatter that does not parse is reported, and the
+	// keys that could be recovered are validated as usual
 	data, body, parseErr := linter.ParseContent(contents)
-	if parseErr != nil {
-		result.Errors = append(result.Errors, cue.ValidationError{
+	var recovered *frontmatterError
+	if errors.As(parseErr, &recovered) {
+		result.Errors = append(result.Errors, recovered.issues(filePath)...)
+		data, body = recovered.fm.Data, recovered.fm.Body
+	} else if parseErr != nil {
+		issue := cue.ValidationError{
 			File:     filePath,
 			Message:  parseErr.Error(),
 			Severity: cue.SeverityError,
-		})
+			Rule:     cue.RuleParseError,
+		}
+		var pe *positionError
+		if errors.As(parseErr, &pe) {
+			issue.Line, issue.Column = pe.line, pe.column
+			issue.Rule = cue.RuleJSONSyntax
+		}
+		result.Errors = append(result.Errors, issue)
 		result.Success = false
 		return result
 	}
 
+	// Merge in the files this one builds on - optional capability
+	if dr, ok := linter.(DataResolver); ok {
+		var resolveErrors []cue.ValidationError
+		data, resolveErrors = dr.ResolveData(filePath, contents, data)
+		categorizeIssues(&result, resolveErrors)
+	}
+
+	// Read legacy keys under their current names when an older schema
+	// version is selected
+	aliasLegacyKeys(schemaVersion(linter.Config()), linter.Type(), data)
+
 	// Check for swallowed frontmatter fields (block scalar absorbed siblings)
 	swallowedWarnings := DetectSwallowedFields(contents, filePath, linter.Type())
 	categorizeIssues(&result, swallowedWarnings)
 
+	// Check for repeated frontmatter keys (parser keeps the last value)
+	categorizeIssues(&result, DetectDuplicateKeys(contents, filePath))
+
+	// Check for aliases and merge keys other loaders may not resolve
+	categorizeIssues(&result, DetectYAMLAliases(contents, filePath))
+
 	// Run all validation steps
 	runCUEValidation(&result, filePath, linter, validator, data)
 	runComponentSpecificValidation(&result, linter, data, filePath, contents)
 	runBestPracticeValidation(&result, linter, filePath, contents, data)
+
+	// Fields the project retired in its deprecatedFields config
+	categorizeIssues(&result, CheckDeprecatedFields(linter.Config().DeprecatedFields, data, filePath, contents, linter.Type()))
+
+	// Disabled components are schema-validated but skip cross-file checks
+	result.Disabled = crossfile.IsDisabled(data)
 	runCrossFileValidation(crossFileValidationParams{
 		result:         &result,
 		linter:         linter,
@@ -137,6 +188,29 @@ func lintFileCore(filePath, contents string, linter ComponentLinter, validator *
 	secretWarnings := textutil.DetectSecrets(contents, filePath)
 	result.Warnings = append(result.Warnings, secretWarnings...)
 
+	// Long lines, base64 blobs, and invalid UTF-8 (common to all types)
+	rules := linter.Config().Rules
+	categorizeIssues(&result, CheckContentBloat(contents, filePath, rules.LineLengthMax, rules.Base64LengthMax))
+
+	// Terminology and typos in prose (opt-in via rules.terminology)
+	categorizeIssues(&result, CheckTerminology(rules.Terminology, contents, filePath))
+
+	// Prose references to project files that no longer exist
+	if !result.Disabled {
+		categorizeIssues(&result, CheckStaleReferences(rules.StaleReferences, crossValidator, linter.Type(), contents, filePath))
+	}
+
+	// WebFetch and WebSearch targets outside the web access policy
+	categorizeIssues(&result, CheckWebAccess(rules.WebAccess, data, filePath, contents, linter.Type()))
+
+	// Findings about keys that could not be parsed
+	if recovered != nil {
+		dropLostKeyIssues(&result, recovered.lostKeys())
+	}
+
+	// Lines for findings located only by a JSON Pointer
+	resolvePointerLines(&result, contents)
+
 	// Quality scoring - optional capability
 	if sc, ok := linter.(Scorable); ok {
 		if score := sc.Score(contents, data, body); score != nil {
@@ -195,9 +269,18 @@ func runCUEValidation(result *LintResult, filePath string, linter ComponentLinte
 			File:     filePath,
 			Message:  fmt.Sprintf("Validation error: %v", cueErr),
 			Severity: cue.SeverityError,
+			Rule:     cue.RuleSchemaViolation,
 		})
-	} else if cueErrors != nil {
-		result.Errors = append(result.Errors, cueErrors...)
+	} else {
+		// Schema violations keep an empty File, which existing baseline
+		// fingerprints depend on; other findings such as
+		// schema-validation-skipped name the file like every other rule.
+		for i := range cueErrors {
+			if cueErrors[i].Rule != cue.RuleSchemaViolation && cueErrors[i].File == "" {
+				cueErrors[i].File = filePath
+			}
+		}
+		categorizeIssues(result, cueErrors)
 	}
 }
 
@@ -231,7 +314,7 @@ type crossFileValidationParams struct {
 
 // runCrossFileValidation runs cross-file validation checks.
 func runCrossFileValidation(params crossFileValidationParams) {
-	if params.crossValidator == nil {
+	if params.crossValidator == nil || params.result.Disabled {
 		return
 	}
 
@@ -278,6 +361,7 @@ func lintComponent(ctx *SingleFileLinterContext, linter ComponentLinter) LintRes
 			Message:  message,
 			Severity: cue.SeverityInfo,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleCrossFileSkipped,
 		})
 	}
 
@@ -294,16 +378,18 @@ type BatchPostProcessor interface {
 // It orchestrates batch linting using a ComponentLinter.
 func lintBatch(ctx *LinterContext, linter ComponentLinter) *LintSummary {
 	files := ctx.FilterFilesByType(linter.FileType())
+	ctx.reportSkippedSymlinks(linter.FileType())
 	summary := ctx.NewSummary(len(files))
 	summary.ComponentType = linter.Type()
 
-	for _, file := range files {
+	for i, file := range files {
 		result := lintBatchFile(ctx, file, linter)
 
 		applyResultToSummary(summary, result)
 
 		summary.Results = append(summary.Results, result)
 		ctx.LogProcessed(file.RelPath, len(result.Errors))
+		ctx.reportProgress(Progress{ComponentType: linter.Type(), Done: i + 1, Total: len(files), Result: &result})
 	}
 
 	// Call post-processor if the linter implements it
@@ -311,13 +397,38 @@ func lintBatch(ctx *LinterContext, linter ComponentLinter) *LintSummary {
 		pp.PostProcessBatch(ctx, summary)
 	}
 
+	// Findings that restate a problem another finding already reports
+	dedupeSummary(summary)
+
+	if ctx.CrossValidator != nil {
+		stats := ctx.CrossValidator.Stats()
+		summary.Graph = &stats
+		if ctx.IncludeChains {
+			summary.Chains = ctx.CrossValidator.Chains(linter.Type())
+		}
+	}
+
+	SortSummary(summary)
 	return summary
 }
 
 // lintBatchFile lints a single file in batch mode.
 // Delegates to lintFileCore for the actual validation logic.
 func lintBatchFile(ctx *LinterContext, file discovery.File, linter ComponentLinter) LintResult {
-	return lintFileCore(file.RelPath, file.Contents, linter, ctx.Validator, ctx.CrossValidator)
+	contents, err := file.Load()
+	if err != nil {
+		return LintResult{
+			File: file.RelPath,
+			Type: linter.Type(),
+			Errors: []cue.ValidationError{{
+				File:     file.RelPath,
+				Message:  fmt.Sprintf("Error reading file: %v", err),
+				Severity: cue.SeverityError,
+				Rule:     cue.RuleLintFailed,
+			}},
+		}
+	}
+	return lintFileCore(file.RelPath, contents, linter, ctx.Validator, ctx.CrossValidator)
 }
 
 // =============================================================================
@@ -337,6 +448,7 @@ func ValidateSemver(version, filePath string, line int) *cue.ValidationError {
 			Message:  fmt.Sprintf("Version '%s' should follow semver format (e.g., '1.0.0')", version),
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleVersionFormat,
 			Line:     line,
 		}
 	}
@@ -344,33 +456,98 @@ func ValidateSemver(version, filePath string, line int) *cue.ValidationError {
 }
 
 // parseFrontmatter parses YAML frontmatter from markdown content.
-// Returns (data, body, error).
+// Returns (data, body, error). Frontmatter that does not parse is returned
+// as a *frontmatterError holding the keys that could be recovered.
 func parseFrontmatter(contents string) (map[string]any, string, error) {
-	fm, err := textutil.ParseYAMLFrontmatter(contents)
-	if err != nil {
-		return nil, "", fmt.Errorf("error parsing frontmatter: %v", err)
+	fm, syntaxErrs := textutil.RecoverYAMLFrontmatter(contents)
+	if len(syntaxErrs) > 0 {
+		return nil, "", &frontmatterError{fm: fm, syntax: syntaxErrs}
 	}
 	return fm.Data, fm.Body, nil
 }
 
+// frontmatterError is frontmatter YAML that does not parse. fm holds the
+// top-level keys that parse on their own, so the rest of the file can
+// still be validated, and syntax the errors of the entries that do not.
+type frontmatterError struct {
+	fm     *textutil.Frontmatter
+	syntax []textutil.YAMLSyntaxError
+}
+
+func (e *frontmatterError) Error() string {
+	return fmt.Sprintf("error parsing frontmatter: line %d: %s", e.syntax[0].Line, e.syntax[0].Message)
+}
+
+// issues returns a frontmatter-syntax error for each syntax error.
+func (e *frontmatterError) issues(filePath string) []cue.ValidationError {
+	issues := make([]cue.ValidationError, 0, len(e.syntax))
+	for _, se := range e.syntax {
+		msg := "Invalid frontmatter YAML: " + se.Message
+		if se.Key != "" {
+			msg += fmt.Sprintf("; '%s' could not be read, so it is not checked", se.Key)
+		}
+		issues = append(issues, cue.ValidationError{
+			File:     filePath,
+			Message:  msg,
+			Severity: cue.SeverityError,
+			Source:   cue.SourceCClintObserve,
+			Line:     se.Line,
+			Rule:     cue.RuleFrontmatterSyntax,
+		})
+	}
+	return issues
+}
+
+// lostKeys returns the top-level keys whose values could not be read.
+func (e *frontmatterError) lostKeys() map[string]bool {
+	lost := make(map[string]bool)
+	for _, se := range e.syntax {
+		if se.Key != "" {
+			lost[se.Key] = true
+		}
+	}
+	return lost
+}
+
 // parseJSONContent parses JSON content into a map.
-// Returns (data, "", error) - body is empty for JSON.
+// Returns (data, "", error) - body is empty for JSON. Syntax errors are
+// returned as a *positionError pointing at the offending character.
 func parseJSONContent(contents string) (map[string]any, string, error) {
 	var data map[string]any
 	if err := json.Unmarshal([]byte(contents), &data); err != nil {
-		return nil, "", fmt.Errorf("invalid JSON: %v", err)
+		return nil, "", newJSONError(contents, err, "")
 	}
 	return data, "", nil
 }
 
+// positionError is a parse error at a known line and column, reported on
+// the finding so the console can show a caret under it.
+type positionError struct {
+	msg          string
+	line, column int
+}
+
+func (e *positionError) Error() string { return e.msg }
+
+// newJSONError wraps a json.Unmarshal error with its position in contents,
+// when known. hint is appended to the message.
+func newJSONError(contents string, err error, hint string) error {
+	msg := fmt.Sprintf("invalid JSON: %v%s", err, hint)
+	line, column, ok := textutil.JSONErrorPosition(contents, err)
+	if !ok {
+		return errors.New(msg)
+	}
+	return &positionError{msg: msg, line: line, column: column}
+}
+
 // =============================================================================
 // Base Linter Implementation
 // =============================================================================
 
-// BaseLinter is an empty struct that component-specific linters can embed.
-// With the ISP refactoring, optional capabilities are now separate interfaces
-// that linters implement only when needed. This struct remains for embedding
-// to signal "I'm a component linter" but provides no default methods.
+// BaseLinter is embedded by component-specific linters. It holds the
+// configuration the linter was created with, which supplies the rule
+// settings of its checks. With the ISP refactoring, optional capabilities
+// are separate interfaces that linters implement only when needed.
 //
 // Optional interfaces a linter can implement:
 //   - PreValidator: for filename/empty content checks
@@ -380,9 +557,21 @@ func parseJSONContent(contents string) (map[string]any, string, error) {
 //   - Improvable: for improvement recommendations
 //   - PostProcessable: for result post-processing
 //   - BatchPostProcessor: for batch-level post-processing (cycle detection)
-type BaseLinter struct{}
+type BaseLinter struct {
+	cfg *config.Config
+}
+
+// Config returns the configuration the linter was created with, or
+// config.Default when it was created without one.
+func (b BaseLinter) Config() *config.Config {
+	if b.cfg == nil {
+		return defaultConfig()
+	}
+	return b.cfg
+}
 
 // ValidateAllowedToolsShared is a shared helper for tool validation.
-func ValidateAllowedToolsShared(data map[string]any, filePath, contents string) []cue.ValidationError {
-	return textutil.ValidateAllowedTools(data, filePath, contents)
+// extraTools are the configured tools beyond the built-in ones.
+func ValidateAllowedToolsShared(extraTools []string, data map[string]any, filePath, contents string) []cue.ValidationError {
+	return textutil.ValidateAllowedTools(extraTools, data, filePath, contents)
 }
@@ -9,7 +9,7 @@ import (
 
 // TestPluginLinterScore tests plugin linter scoring
 func TestPluginLinterScore(t *testing.T) {
-	linter := NewPluginLinter("")
+	linter := NewPluginLinter("", nil)
 	data := map[string]any{
 		"name":        "test-plugin",
 		"description": "A comprehensive test plugin",
@@ -24,7 +24,7 @@ func TestPluginLinterScore(t *testing.T) {
 
 // TestPluginLinterGetImprovements tests plugin linter improvements
 func TestPluginLinterGetImprovements(t *testing.T) {
-	linter := NewPluginLinter("")
+	linter := NewPluginLinter("", nil)
 	data := map[string]any{
 		"name":        "test-plugin",
 		"description": "Short",
@@ -38,7 +38,7 @@ func TestPluginLinterGetImprovements(t *testing.T) {
 
 // TestPluginLinterValidateCUE tests plugin linter CUE validation
 func TestPluginLinterValidateCUE(t *testing.T) {
-	linter := NewPluginLinter("")
+	linter := NewPluginLinter("", nil)
 	validator := cue.NewValidator()
 
 	errors, _ := linter.ValidateCUE(validator, map[string]any{"name": "test"})
@@ -50,7 +50,7 @@ func TestPluginLinterValidateCUE(t *testing.T) {
 
 // TestSettingsLinterValidateCUE tests settings linter CUE validation
 func TestSettingsLinterValidateCUE(t *testing.T) {
-	linter := NewSettingsLinter()
+	linter := NewSettingsLinter("", nil)
 	validator := cue.NewValidator()
 
 	errors, _ := linter.ValidateCUE(validator, map[string]any{"theme": "dark"})
@@ -62,7 +62,7 @@ func TestSettingsLinterValidateCUE(t *testing.T) {
 
 // TestContextLinterValidateCUE tests context linter CUE validation
 func TestContextLinterValidateCUE(t *testing.T) {
-	linter := NewContextLinter()
+	linter := NewContextLinter(nil)
 	validator := cue.NewValidator()
 
 	errors, _ := linter.ValidateCUE(validator, map[string]any{"sections": []any{}})
@@ -10,7 +10,7 @@ import (
 // Test all linter implementations for basic interface compliance
 
 func TestAgentLinter(t *testing.T) {
-	linter := NewAgentLinter()
+	linter := NewAgentLinter("", nil)
 
 	if linter.Type() != "agent" {
 		t.Errorf("AgentLinter.Type() = %q, want %q", linter.Type(), "agent")
@@ -38,7 +38,7 @@ func TestAgentLinter(t *testing.T) {
 }
 
 func TestCommandLinter(t *testing.T) {
-	linter := NewCommandLinter()
+	linter := NewCommandLinter(nil)
 
 	if linter.Type() != "command" {
 		t.Errorf("CommandLinter.Type() = %q, want %q", linter.Type(), "command")
@@ -66,7 +66,7 @@ func TestCommandLinter(t *testing.T) {
 }
 
 func TestSkillLinter(t *testing.T) {
-	linter := NewSkillLinter()
+	linter := NewSkillLinter("", nil)
 
 	if linter.Type() != "skill" {
 		t.Errorf("SkillLinter.Type() = %q, want %q", linter.Type(), "skill")
@@ -124,7 +124,7 @@ func TestSkillLinter(t *testing.T) {
 }
 
 func TestSettingsLinter(t *testing.T) {
-	linter := NewSettingsLinter()
+	linter := NewSettingsLinter("", nil)
 
 	if linter.Type() != "settings" {
 		t.Errorf("SettingsLinter.Type() = %q, want %q", linter.Type(), "settings")
@@ -152,7 +152,7 @@ func TestSettingsLinter(t *testing.T) {
 }
 
 func TestContextLinter(t *testing.T) {
-	linter := NewContextLinter()
+	linter := NewContextLinter(nil)
 
 	if linter.Type() != "context" {
 		t.Errorf("ContextLinter.Type() = %q, want %q", linter.Type(), "context")
@@ -177,7 +177,7 @@ func TestContextLinter(t *testing.T) {
 }
 
 func TestPluginLinter(t *testing.T) {
-	linter := NewPluginLinter("")
+	linter := NewPluginLinter("", nil)
 
 	if linter.Type() != "plugin" {
 		t.Errorf("PluginLinter.Type() = %q, want %q", linter.Type(), "plugin")
@@ -207,7 +207,7 @@ func TestPluginLinter(t *testing.T) {
 // Test batch post-processing
 func TestBatchPostProcessor(t *testing.T) {
 	// The AgentLinter implements BatchPostProcessor for cycle detection
-	linter := NewAgentLinter()
+	linter := NewAgentLinter("", nil)
 
 	// Create a valid context with CrossValidator
 	files := []discovery.File{
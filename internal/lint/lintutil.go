@@ -5,7 +5,9 @@ package lint
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -22,6 +24,27 @@ var knownFrontmatterFields = map[string]map[string]bool{
 	"skill":   knownSkillFields,
 }
 
+// settingsGoFields are settings keys the #Settings schema leaves undeclared
+// but the linter knows: env, and the keys validateSettingsSpecific checks.
+var settingsGoFields = []string{"env", "hooks", "mcpServers", "permissions", "rules"}
+
+// KnownFields lists, sorted, the fields the linter itself recognizes for a
+// component type beyond its CUE schema: the frontmatter fields accepted by
+// the unknown-field check, or the settings keys validated in Go. Returns
+// nil for other types.
+func KnownFields(componentType string) []string {
+	switch componentType {
+	case "settings":
+		return slices.Clone(settingsGoFields)
+	case cue.TypeOutputStyle:
+		return slices.Sorted(maps.Keys(knownOutputStyleFields))
+	}
+	if fields, ok := knownFrontmatterFields[componentType]; ok {
+		return slices.Sorted(maps.Keys(fields))
+	}
+	return nil
+}
+
 // blockScalarPattern matches YAML block scalar indicators (| or >) with optional modifiers.
 var blockScalarPattern = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9_-]*)\s*:\s*[|>][-+]?\s*$`)
 
@@ -58,6 +81,80 @@ func DetectSwallowedFields(contents, filePath, componentType string) []cue.Valid
 	return detectSwallowedBlockScalarFields(fmLines, fmStart, filePath, fields)
 }
 
+// DetectDuplicateKeys reports frontmatter keys that are defined more than once.
+//
+// YAML loaders disagree on duplicates — some reject the document, others keep
+// the last value silently — so a repeated key almost always means an edit
+// landed in the wrong place. The frontmatter parser keeps the last value and
+// records each repeat; this turns those records into errors.
+func DetectDuplicateKeys(contents, filePath string) []cue.ValidationError {
+	fm, err := textutil.ParseYAMLFrontmatter(contents)
+	if err != nil {
+		return nil
+	}
+
+	var errors []cue.ValidationError
+	for _, dup := range fm.DuplicateKeys {
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("Duplicate frontmatter key '%s' (first defined on line %d) — only the last value is used", dup.Path, dup.FirstLine),
+			Severity: cue.SeverityError,
+			Source:   cue.SourceCClintObserve,
+			Line:     dup.Line,
+			Rule:     cue.RuleFrontmatterDuplicateKey,
+		})
+	}
+	return errors
+}
+
+// DetectYAMLAliases reports frontmatter that uses YAML aliases or merge
+// keys. The frontmatter parser resolves them, so cclint checks the values
+// they stand for, but Claude Code's own frontmatter loader may not: merge
+// keys are a YAML 1.1 extension, and some loaders leave aliases unresolved.
+// One warning per file, at the first use.
+func DetectYAMLAliases(contents, filePath string) []cue.ValidationError {
+	fm, err := textutil.ParseYAMLFrontmatter(contents)
+	if err != nil || len(fm.Aliases) == 0 {
+		return nil
+	}
+
+	var aliases, merges int
+	for _, a := range fm.Aliases {
+		if a.Merge {
+			merges++
+		} else {
+			aliases++
+		}
+	}
+	var uses []string
+	if merges > 0 {
+		uses = append(uses, pluralCount(merges, "merge key"))
+	}
+	if aliases == 1 {
+		uses = append(uses, "1 alias")
+	} else if aliases > 1 {
+		uses = append(uses, fmt.Sprintf("%d aliases", aliases))
+	}
+	first, pronoun := fm.Aliases[0], "them"
+	if len(fm.Aliases) == 1 {
+		pronoun = "it"
+	}
+	msg := fmt.Sprintf("Frontmatter uses %s in YAML, first on line %d; cclint resolves %s, but Claude Code may not", strings.Join(uses, " and "), first.Line, pronoun)
+	if merges > 0 {
+		msg += " (<< merge keys are not part of YAML 1.2)"
+	}
+	msg += ". Write the values out in full, or run cclint fmt with fmt.anchors: expand"
+
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  msg,
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceCClintObserve,
+		Line:     first.Line,
+		Rule:     cue.RuleFrontmatterYAMLAlias,
+	}}
+}
+
 func findFrontmatterBounds(lines []string) (int, int, bool) {
 	fmStart, fmEnd := -1, -1
 	for i, line := range lines {
@@ -115,6 +212,7 @@ func findSwallowedFieldsForScalar(fmLines []string, fmStart int, filePath string
 			Message:  fmt.Sprintf("Field '%s' appears to be swallowed by block scalar '%s: |' above — it is parsed as text, not a separate field", candidateKey, scalarField),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleFrontmatterFieldSwallowed,
 			Line:     lineNum,
 		})
 	}
@@ -152,6 +250,7 @@ func descriptionFieldError(fieldName, message, filePath, fileContents string) *c
 		Message:  fmt.Sprintf("%s %s", fieldName, message),
 		Severity: cue.SeverityError,
 		Source:   cue.SourceAnthropicDocs,
+		Rule:     cue.RuleDescriptionAngleBrackets,
 		Line:     textutil.FindFrontmatterFieldLine(fileContents, strings.ToLower(fieldName)),
 	}
 }
@@ -205,6 +304,7 @@ func CheckSizeLimit(contents string, limit int, tolerance float64, componentType
 			Message:  message,
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleComponentSize,
 			Line:     1,
 		}
 	}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
 	"github.com/dotcommander/cclint/internal/textutil"
@@ -36,6 +37,7 @@ func CheckClaudeLocalGitignore(rootPath string) []cue.ValidationError {
 			Message:  "CLAUDE.local.md exists but no .gitignore found - this file should not be committed to version control",
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleContextLocalNotIgnored,
 		})
 		return errors
 	}
@@ -47,6 +49,7 @@ func CheckClaudeLocalGitignore(rootPath string) []cue.ValidationError {
 			Message:  "CLAUDE.local.md exists but is not in .gitignore - add 'CLAUDE.local.md' to .gitignore to prevent committing personal preferences",
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleContextLocalNotIgnored,
 		})
 	}
 
@@ -107,6 +110,7 @@ func matchGitignorePattern(pattern, filename string) bool {
 
 // CheckCombinedMemorySize checks if total CLAUDE.md + always-loaded rules size exceeds threshold.
 // Rules with paths: frontmatter are conditionally loaded and don't count toward the threshold.
+// Files that CLAUDE.md pulls in through @path imports load with it and count once each.
 func CheckCombinedMemorySize(rootPath string, files []discovery.File) []cue.ValidationError {
 	var errors []cue.ValidationError
 
@@ -114,19 +118,35 @@ func CheckCombinedMemorySize(rootPath string, files []discovery.File) []cue.Vali
 	var conditionalSize int64
 
 	// Sum up context files (CLAUDE.md) - always loaded
+	counted := make(map[string]bool)
 	for _, f := range files {
-		if f.Type == discovery.FileTypeContext {
-			alwaysLoadedSize += int64(len(f.Contents))
+		counted[filepath.Join(rootPath, f.RelPath)] = true
+	}
+	for _, f := range files {
+		if f.Type != discovery.FileTypeContext {
+			continue
+		}
+		contents, _ := f.Load()
+		alwaysLoadedSize += int64(len(contents))
+		for _, imported := range crossfile.ContextImportFiles(rootPath, f.RelPath, contents) {
+			if counted[imported] {
+				continue
+			}
+			counted[imported] = true
+			if info, err := os.Stat(imported); err == nil {
+				alwaysLoadedSize += info.Size()
+			}
 		}
 	}
 
 	// Sum up rule files, separating always-loaded from conditional
 	for _, f := range files {
 		if f.Type == discovery.FileTypeRule {
-			if ruleHasPathsConstraint(f.Contents) {
-				conditionalSize += int64(len(f.Contents))
+			contents, _ := f.Load()
+			if ruleHasPathsConstraint(contents) {
+				conditionalSize += int64(len(contents))
 			} else {
-				alwaysLoadedSize += int64(len(f.Contents))
+				alwaysLoadedSize += int64(len(contents))
 			}
 		}
 	}
@@ -145,6 +165,7 @@ func CheckCombinedMemorySize(rootPath string, files []discovery.File) []cue.Vali
 			Message:  formatSizeWarning(alwaysLoadedSize, conditionalSize, thresholdBytes),
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleContextSize,
 		})
 	}
 
@@ -167,9 +188,9 @@ func formatSizeWarning(alwaysLoaded, conditional, threshold int64) string {
 	thresholdKB := float64(threshold) / 1024
 
 	msg := fmt.Sprintf(
-		"Always-loaded memory (CLAUDE.md + global rules) is %.1fKB, exceeds %.0fKB threshold. "+
+		"Always-loaded memory (CLAUDE.md with its @imports + global rules) is %.1fKB, exceeds %.0fKB threshold. "+
 			"Large memory files increase token usage and may slow down Claude Code startup. "+
-			"Consider: moving detailed docs to @imports, or adding paths: frontmatter to make rules conditional.",
+			"Consider: moving detailed docs into skills or path-scoped rules, or adding paths: frontmatter to make rules conditional.",
 		alwaysKB, thresholdKB)
 
 	if conditional > 0 {
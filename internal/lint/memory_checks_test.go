@@ -3,6 +3,7 @@ package lint
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/dotcommander/cclint/internal/discovery"
@@ -201,6 +202,24 @@ func TestCheckCombinedMemorySize(t *testing.T) {
 	}
 }
 
+func TestCheckCombinedMemorySize_CountsImports(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "guide.md"), make([]byte, 15*1024), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	files := []discovery.File{
+		{Type: discovery.FileTypeContext, RelPath: "CLAUDE.md", Contents: string(make([]byte, 10*1024)) + "\nSee @guide.md twice: @guide.md\n"},
+	}
+
+	errors := CheckCombinedMemorySize(tmpDir, files)
+	if len(errors) != 1 {
+		t.Fatalf("CheckCombinedMemorySize() = %d warnings, want 1 (CLAUDE.md plus its import exceed 20KB)", len(errors))
+	}
+	if !strings.Contains(errors[0].Message, "25.") {
+		t.Errorf("message %q should count the imported file once", errors[0].Message)
+	}
+}
+
 func TestRuleHasPathsConstraint(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,54 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// modelCatalog returns the built-in model catalog with the rules.models
+// overrides in cfg merged over it.
+func modelCatalog(cfg *config.Config) cue.ModelCatalog {
+	return cue.NewModelCatalog(modelInfos(cfg.Rules.Models))
+}
+
+// modelInfos converts rules.models config entries to catalog entries.
+func modelInfos(models []config.ModelConfig) []cue.ModelInfo {
+	entries := make([]cue.ModelInfo, 0, len(models))
+	for _, m := range models {
+		entries = append(entries, cue.ModelInfo{ID: m.ID, Status: m.Status, Replacement: m.Replacement})
+	}
+	return entries
+}
+
+// checkModelCatalog warns when the model field names a model the catalog
+// marks deprecated or retired, suggesting its replacement.
+func checkModelCatalog(catalog cue.ModelCatalog, data map[string]any, filePath, contents string) []cue.ValidationError {
+	model, ok := data["model"].(string)
+	if !ok {
+		return nil
+	}
+	info, ok := catalog.Lookup(model)
+	if !ok || info.Status == cue.ModelCurrent {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Model '%s' is deprecated", model)
+	if info.Status == cue.ModelRetired {
+		msg = fmt.Sprintf("Model '%s' is retired and no longer answers requests", model)
+	}
+	if info.Replacement != "" {
+		msg += fmt.Sprintf("; use '%s' instead", info.Replacement)
+	}
+	return []cue.ValidationError{{
+		File:        filePath,
+		Message:     msg,
+		Severity:    cue.SeverityWarning,
+		Source:      cue.SourceAnthropicDocs,
+		Rule:        cue.RuleModelDeprecated,
+		Line:        textutil.FindFrontmatterFieldLine(contents, "model"),
+		Replacement: info.Replacement,
+	}}
+}
@@ -0,0 +1,69 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestCheckModelCatalog(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     string
+		overrides []config.ModelConfig
+		want      string // message substring; empty means no finding
+	}{
+		{name: "deprecated model", model: "claude-3-opus-20240229", want: "Model 'claude-3-opus-20240229' is deprecated; use 'opus' instead"},
+		{name: "retired model", model: "claude-2.1", want: "is retired and no longer answers requests; use 'sonnet'"},
+		{name: "current alias", model: "sonnet"},
+		{name: "current full ID", model: "claude-opus-4-5"},
+		{
+			name:      "config marks a model current",
+			model:     "claude-3-haiku-20240307",
+			overrides: []config.ModelConfig{{ID: "claude-3-haiku-20240307", Status: "current"}},
+		},
+		{
+			name:      "config deprecates a model",
+			model:     "claude-sonnet-4-20250514",
+			overrides: []config.ModelConfig{{ID: "claude-sonnet-4-20250514", Status: "deprecated", Replacement: "sonnet"}},
+			want:      "is deprecated; use 'sonnet' instead",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Rules.Models = tt.overrides
+			catalog := modelCatalog(cfg)
+
+			contents := "---\nname: a\nmodel: " + tt.model + "\n---\n"
+			data := map[string]any{"model": tt.model}
+			for _, check := range []struct {
+				name string
+				fn   func() []cue.ValidationError
+			}{
+				{"command", func() []cue.ValidationError { return checkModelCatalog(catalog, data, "commands/a.md", contents) }},
+				{"agent", func() []cue.ValidationError { return validateAgentModel(catalog, data, "agents/a.md", contents) }},
+			} {
+				got := check.fn()
+				if tt.want == "" {
+					if len(got) != 0 {
+						t.Errorf("%s: got %v, want no findings", check.name, got)
+					}
+					continue
+				}
+				if len(got) != 1 || !strings.Contains(got[0].Message, tt.want) {
+					t.Fatalf("%s: got %v, want one finding containing %q", check.name, got, tt.want)
+				}
+				if got[0].Rule != cue.RuleModelDeprecated || got[0].Severity != cue.SeverityWarning || got[0].Line != 3 {
+					t.Errorf("%s: rule/severity/line = %s/%s/%d", check.name, got[0].Rule, got[0].Severity, got[0].Line)
+				}
+				if !strings.Contains(got[0].Message, "use '"+got[0].Replacement+"' instead") || got[0].Replacement == "" {
+					t.Errorf("%s: replacement = %q, want the one the message names", check.name, got[0].Replacement)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package lint
+
+import (
+	"sync"
+
+	"github.com/dotcommander/cclint/internal/config"
+)
+
+// Options configures a lint run: the configuration it checks against and
+// the switches a caller sets per run. Each run reads only its own Options,
+// so runs with different settings can proceed side by side.
+type Options struct {
+	// Config supplies the project root, the exclude patterns, the
+	// verbosity, and the rule settings. Nil uses config.Default.
+	Config *config.Config
+	// IncludeChains traces the delegation chain of every command or agent
+	// into LintSummary.Chains (--chains).
+	IncludeChains bool
+	// Progress, when set, is called after each file a batch validates, with
+	// the file's result, for --format jsonl events and the --progress bar.
+	Progress func(Progress)
+}
+
+// config returns the configuration of the run.
+func (o Options) config() *config.Config {
+	if o.Config == nil {
+		return defaultConfig()
+	}
+	return o.Config
+}
+
+// defaultConfig is the configuration of runs and linters given none. It is
+// shared, so it must not be changed.
+var defaultConfig = sync.OnceValue(config.Default)
@@ -5,16 +5,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/dotcommander/cclint/internal/baseline"
+	"github.com/dotcommander/cclint/internal/codeowners"
 	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
 )
 
 // LinterFunc is the function signature for component linters.
-type LinterFunc func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error)
+type LinterFunc func(opts Options) (*LintSummary, error)
 
 // DefaultLinters returns the standard set of component linters.
 func DefaultLinters() []LinterEntry {
@@ -35,6 +37,28 @@ type OrchestratorConfig struct {
 	UseBaseline    bool
 	CreateBaseline bool
 	BaselinePath   string
+	// CodeOwners resolves the owners of each linted file; nil when the
+	// project has no CODEOWNERS file.
+	CodeOwners *codeowners.Ruleset
+	// Owners limits the results to files owned by one of these owners
+	// (--owner).
+	Owners []string
+	// Blame records the last commit of each line with a finding (--blame).
+	Blame bool
+	// IncludeChains adds the delegation chain of each command or agent to
+	// the summaries (--chains).
+	IncludeChains bool
+	// KeepInlineSuppressed reports the issues cclint-disable comments
+	// suppress, so cclint baseline from-inline can see them.
+	KeepInlineSuppressed bool
+	// Progress, when set, is called after each file a batch validates.
+	Progress func(Progress)
+	// OnResult, when set, receives the findings of each file as soon as the
+	// file is validated, as a one-file summary that is re-graded,
+	// suppressed, and baseline-filtered like the final report. Batch checks
+	// that span a component type add findings to earlier files; those follow
+	// in another one-file summary for their file once the type is done.
+	OnResult func(*LintSummary)
 }
 
 // Orchestrator coordinates the linting process across all component types.
@@ -42,6 +66,8 @@ type Orchestrator struct {
 	cfg     *config.Config
 	opts    OrchestratorConfig
 	linters []LinterEntry
+	only    []discovery.FileType
+	skip    []discovery.FileType
 }
 
 // LinterEntry pairs a component name with its linter function.
@@ -65,6 +91,23 @@ func (o *Orchestrator) WithLinters(linters []LinterEntry) *Orchestrator {
 	return o
 }
 
+// WithScope limits the run to component types in only (all types when
+// empty), minus those in skip. Out-of-scope components are not linted, but
+// stay visible to cross-file validation so references to them resolve.
+func (o *Orchestrator) WithScope(only, skip []discovery.FileType) *Orchestrator {
+	o.only = only
+	o.skip = skip
+	return o
+}
+
+// inScope reports whether components of type ft are linted in this run.
+func (o *Orchestrator) inScope(ft discovery.FileType) bool {
+	if len(o.only) > 0 && !slices.Contains(o.only, ft) {
+		return false
+	}
+	return !slices.Contains(o.skip, ft)
+}
+
 // Result holds the outcome of a lint run.
 type Result struct {
 	StartTime          time.Time
@@ -77,6 +120,8 @@ type Result struct {
 	ErrorsIgnored      int
 	SuggestionsIgnored int
 	Summaries          []*LintSummary
+	// Gates is the outcome of the gates config block; nil without one.
+	Gates *GateResult
 }
 
 // Run executes the full lint workflow.
@@ -88,7 +133,7 @@ func (o *Orchestrator) Run() (*Result, error) {
 
 	// Load baseline if requested
 	b, err := o.loadBaseline(baselineFile)
-	if err != nil && !o.cfg.Quiet {
+	if err != nil && !o.cfg.Quiet() {
 		fmt.Fprintf(os.Stderr, "Warning: Failed to load baseline: %v\n", err)
 	}
 
@@ -102,7 +147,9 @@ func (o *Orchestrator) Run() (*Result, error) {
 	}
 
 	// Run project-wide memory checks
-	o.runMemoryChecks()
+	if o.inScope(discovery.FileTypeContext) {
+		o.runMemoryChecks()
+	}
 
 	// Create/update baseline if requested
 	if o.opts.CreateBaseline {
@@ -114,19 +161,46 @@ func (o *Orchestrator) Run() (*Result, error) {
 		return result, nil
 	}
 
+	if o.cfg.Gates.Enabled() {
+		o.evaluateGates(b, baselineFile, result)
+	}
+
 	// Note: Output formatting and summary printing is handled by cmd layer
 	// to avoid import cycles with the output package
 
 	return result, nil
 }
 
+// evaluateGates checks the run against the gates config block and attaches
+// the outcome to the result and each summary for reporting. The noNewIssues
+// gate reads the baseline even when --baseline is not given.
+func (o *Orchestrator) evaluateGates(b *baseline.Baseline, baselineFile string, result *Result) {
+	if b == nil && o.cfg.Gates.NoNewIssues {
+		var err error
+		if _, statErr := os.Stat(baselineFile); statErr == nil {
+			b, err = baseline.LoadBaseline(baselineFile)
+		}
+		if err != nil && !o.cfg.Quiet() {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to load baseline: %v\n", err)
+		}
+	}
+	result.Gates = EvaluateGates(o.cfg.Gates, result.Summaries, b)
+	for _, s := range result.Summaries {
+		s.Gates = result.Gates
+	}
+}
+
 // runAllLinters runs all configured linters and collects results.
 func (o *Orchestrator) runAllLinters(b *baseline.Baseline, result *Result) ([]cue.ValidationError, []*LintSummary, error) {
 	var allIssues []cue.ValidationError
 	var allSummaries []*LintSummary
 
 	for _, l := range o.linters {
-		summary, err := l.Linter(o.cfg.Root, o.cfg.Quiet, o.cfg.Verbose, o.cfg.NoCycleCheck, o.cfg.Exclude)
+		if ft, err := discovery.ParseFileType(l.Name); err == nil && !o.inScope(ft) {
+			continue
+		}
+		stream := o.newResultStream(b)
+		summary, err := l.Linter(Options{Config: o.cfg, IncludeChains: o.opts.IncludeChains, Progress: stream.progress})
 		if err != nil {
 			return nil, nil, fmt.Errorf("error running %s linter: %w", l.Name, err)
 		}
@@ -136,6 +210,8 @@ func (o *Orchestrator) runAllLinters(b *baseline.Baseline, result *Result) ([]cu
 			continue
 		}
 
+		o.applyPolicies(summary)
+
 		// Collect issues for baseline creation
 		if o.opts.CreateBaseline {
 			allIssues = append(allIssues, CollectAllIssues(summary)...)
@@ -148,9 +224,13 @@ func (o *Orchestrator) runAllLinters(b *baseline.Baseline, result *Result) ([]cu
 			result.ErrorsIgnored += errIgnored
 			result.SuggestionsIgnored += suggIgnored
 		}
+		if o.opts.Blame {
+			ApplyBlame(summary, o.cfg.Quiet())
+		}
 
 		// Collect summary for compact output
 		allSummaries = append(allSummaries, summary)
+		stream.flush(summary)
 
 		// Accumulate totals
 		result.TotalFiles += summary.TotalFiles
@@ -163,7 +243,7 @@ func (o *Orchestrator) runAllLinters(b *baseline.Baseline, result *Result) ([]cu
 		result.Summaries = allSummaries
 
 		// Progressive output in verbose mode
-		if o.cfg.Verbose && !o.cfg.Quiet {
+		if o.cfg.Verbose() && !o.cfg.Quiet() {
 			status := "✓"
 			if summary.TotalErrors > 0 {
 				status = "✗"
@@ -175,6 +255,22 @@ func (o *Orchestrator) runAllLinters(b *baseline.Baseline, result *Result) ([]cu
 	return allIssues, allSummaries, nil
 }
 
+// applyPolicies records the owners of summary's files and re-grades its
+// issues per the rules config, per-path overrides, and untrusted mode, and
+// drops inline-suppressed ones unless KeepInlineSuppressed, before
+// baselining sees them.
+func (o *Orchestrator) applyPolicies(summary *LintSummary) {
+	ApplyCodeOwners(summary, o.opts.CodeOwners, o.opts.Owners)
+	ApplyRulesConfig(summary, o.cfg.Rules)
+	ApplyOverrides(summary, o.cfg.Overrides)
+	if o.cfg.Untrusted {
+		ApplyUntrusted(summary)
+	}
+	if !o.opts.KeepInlineSuppressed {
+		ApplyInlineSuppressions(summary)
+	}
+}
+
 // resolveBaselinePath returns the absolute path to the baseline file.
 func (o *Orchestrator) resolveBaselinePath() string {
 	baselineFile := o.opts.BaselinePath
@@ -209,7 +305,7 @@ func (o *Orchestrator) saveBaseline(issues []cue.ValidationError, baselineFile s
 		return fmt.Errorf("failed to save baseline: %w", err)
 	}
 
-	if !o.cfg.Quiet {
+	if !o.cfg.Quiet() {
 		fmt.Printf("\nBaseline created: %s (%d issues)\n", baselineFile, len(b.Fingerprints))
 	}
 
@@ -218,7 +314,7 @@ func (o *Orchestrator) saveBaseline(issues []cue.ValidationError, baselineFile s
 
 // runMemoryChecks performs project-wide memory checks.
 func (o *Orchestrator) runMemoryChecks() {
-	if o.cfg.Quiet {
+	if o.cfg.Quiet() {
 		return
 	}
 
@@ -230,7 +326,7 @@ func (o *Orchestrator) runMemoryChecks() {
 	}
 
 	// Check combined memory size
-	fd := discovery.NewFileDiscovery(o.cfg.Root, false)
+	fd := o.cfg.NewFileDiscovery(o.cfg.Root).WithLazyContents()
 	allFiles, err := fd.DiscoverFiles()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "warning: unable to run combined memory checks: %v\n", err)
@@ -1,14 +1,19 @@
 package lint
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/dotcommander/cclint/internal/baseline"
 	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
 )
 
 // =============================================================================
@@ -17,10 +22,9 @@ import (
 
 func TestNewOrchestrator(t *testing.T) {
 	cfg := &config.Config{
-		Root:    "/test/root",
-		Format:  "console",
-		Quiet:   false,
-		Verbose: true,
+		Root:      "/test/root",
+		Format:    "console",
+		Verbosity: config.VerbosityVerbose,
 	}
 
 	opts := OrchestratorConfig{
@@ -64,7 +68,7 @@ func TestWithLinters(t *testing.T) {
 	customLinters := []LinterEntry{
 		{
 			Name: "test-linter",
-			Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+			Linter: func(opts Options) (*LintSummary, error) {
 				return &LintSummary{}, nil
 			},
 		},
@@ -292,9 +296,9 @@ func TestSaveBaseline(t *testing.T) {
 	}
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  true, // Quiet mode to avoid output
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityQuiet, // Quiet mode to avoid output
 	}
 	opts := OrchestratorConfig{
 		RootPath:     tmpDir,
@@ -332,10 +336,9 @@ func TestRun_Success(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := &config.Config{
-		Root:    tmpDir,
-		Format:  "console",
-		Quiet:   true,
-		Verbose: false,
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityQuiet,
 	}
 
 	opts := OrchestratorConfig{
@@ -350,9 +353,9 @@ func TestRun_Success(t *testing.T) {
 
 	successLinter := LinterEntry{
 		Name: "test-linter",
-		Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+		Linter: func(opts Options) (*LintSummary, error) {
 			return &LintSummary{
-				ProjectRoot:      rootPath,
+				ProjectRoot:      opts.Config.Root,
 				ComponentType:    "test",
 				StartTime:        time.Now(),
 				TotalFiles:       2,
@@ -401,10 +404,9 @@ func TestRun_WithErrors(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := &config.Config{
-		Root:    tmpDir,
-		Format:  "console",
-		Quiet:   true,
-		Verbose: false,
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityQuiet,
 	}
 
 	opts := OrchestratorConfig{
@@ -418,9 +420,9 @@ func TestRun_WithErrors(t *testing.T) {
 
 	errorLinter := LinterEntry{
 		Name: "error-linter",
-		Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+		Linter: func(opts Options) (*LintSummary, error) {
 			return &LintSummary{
-				ProjectRoot:      rootPath,
+				ProjectRoot:      opts.Config.Root,
 				ComponentType:    "test",
 				StartTime:        time.Now(),
 				TotalFiles:       1,
@@ -474,9 +476,9 @@ func TestRun_SkipEmptyResults(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  true,
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityQuiet,
 	}
 
 	opts := OrchestratorConfig{
@@ -488,7 +490,7 @@ func TestRun_SkipEmptyResults(t *testing.T) {
 
 	emptyLinter := LinterEntry{
 		Name: "empty-linter",
-		Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+		Linter: func(opts Options) (*LintSummary, error) {
 			return &LintSummary{
 				TotalFiles: 0, // No files found
 				Results:    []LintResult{},
@@ -519,9 +521,9 @@ func TestRun_CreateBaseline(t *testing.T) {
 	baselinePath := filepath.Join(tmpDir, ".cclintbaseline.json")
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  true,
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityQuiet,
 	}
 
 	opts := OrchestratorConfig{
@@ -535,7 +537,7 @@ func TestRun_CreateBaseline(t *testing.T) {
 
 	linter := LinterEntry{
 		Name: "test-linter",
-		Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+		Linter: func(opts Options) (*LintSummary, error) {
 			return &LintSummary{
 				TotalFiles:  1,
 				TotalErrors: 1,
@@ -600,9 +602,9 @@ func TestRun_WithBaselineFiltering(t *testing.T) {
 	}
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  true,
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityQuiet,
 	}
 
 	opts := OrchestratorConfig{
@@ -616,7 +618,7 @@ func TestRun_WithBaselineFiltering(t *testing.T) {
 
 	linter := LinterEntry{
 		Name: "test-linter",
-		Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+		Linter: func(opts Options) (*LintSummary, error) {
 			return &LintSummary{
 				TotalFiles:  1,
 				TotalErrors: 2,
@@ -661,9 +663,9 @@ func TestRun_MultipleLinters(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  true,
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityQuiet,
 	}
 
 	opts := OrchestratorConfig{
@@ -675,7 +677,7 @@ func TestRun_MultipleLinters(t *testing.T) {
 
 	linter1 := LinterEntry{
 		Name: "linter-1",
-		Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+		Linter: func(opts Options) (*LintSummary, error) {
 			return &LintSummary{
 				TotalFiles:       2,
 				TotalErrors:      1,
@@ -692,7 +694,7 @@ func TestRun_MultipleLinters(t *testing.T) {
 
 	linter2 := LinterEntry{
 		Name: "linter-2",
-		Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+		Linter: func(opts Options) (*LintSummary, error) {
 			return &LintSummary{
 				TotalFiles:       1,
 				TotalErrors:      0,
@@ -741,9 +743,9 @@ func TestRun_LinterError(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  true,
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityQuiet,
 	}
 
 	opts := OrchestratorConfig{
@@ -755,7 +757,7 @@ func TestRun_LinterError(t *testing.T) {
 
 	failingLinter := LinterEntry{
 		Name: "failing-linter",
-		Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+		Linter: func(opts Options) (*LintSummary, error) {
 			return nil, os.ErrNotExist // Return an error
 		},
 	}
@@ -788,9 +790,9 @@ func TestRun_BaselineLoadError_NotQuiet(t *testing.T) {
 	}
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  false, // NOT quiet - should print warning
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityNormal, // NOT quiet - should print warning
 	}
 
 	opts := OrchestratorConfig{
@@ -838,9 +840,9 @@ func TestRun_BaselineFilteringSummary_NotQuiet(t *testing.T) {
 	}
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  false, // NOT quiet - should print summary
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityNormal, // NOT quiet - should print summary
 	}
 
 	opts := OrchestratorConfig{
@@ -854,7 +856,7 @@ func TestRun_BaselineFilteringSummary_NotQuiet(t *testing.T) {
 
 	linter := LinterEntry{
 		Name: "test-linter",
-		Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+		Linter: func(opts Options) (*LintSummary, error) {
 			return &LintSummary{
 				TotalFiles:  1,
 				TotalErrors: 1,
@@ -894,9 +896,9 @@ func TestRun_ValidationReminder_NotQuiet(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  false, // NOT quiet - should print reminder
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityNormal, // NOT quiet - should print reminder
 	}
 
 	opts := OrchestratorConfig{
@@ -908,7 +910,7 @@ func TestRun_ValidationReminder_NotQuiet(t *testing.T) {
 
 	successLinter := LinterEntry{
 		Name: "test-linter",
-		Linter: func(rootPath string, quiet, verbose, noCycleCheck bool, exclude []string) (*LintSummary, error) {
+		Linter: func(opts Options) (*LintSummary, error) {
 			return &LintSummary{
 				TotalFiles: 1,
 				Results:    []LintResult{{File: "test.md", Success: true}},
@@ -944,9 +946,9 @@ func TestSaveBaseline_NotQuiet(t *testing.T) {
 	}
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  false, // NOT quiet - should print output
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityNormal, // NOT quiet - should print output
 	}
 	opts := OrchestratorConfig{
 		RootPath:     tmpDir,
@@ -982,9 +984,9 @@ func TestRunMemoryChecks(t *testing.T) {
 	}
 
 	cfg := &config.Config{
-		Root:   tmpDir,
-		Format: "console",
-		Quiet:  false, // Not quiet so checks run
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityNormal, // Not quiet so checks run
 	}
 
 	opts := OrchestratorConfig{
@@ -1006,8 +1008,8 @@ func TestRunMemoryChecks_QuietMode(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := &config.Config{
-		Root:  tmpDir,
-		Quiet: true, // Quiet mode - checks should be skipped
+		Root:      tmpDir,
+		Verbosity: config.VerbosityQuiet, // Quiet mode - checks should be skipped
 	}
 
 	opts := OrchestratorConfig{
@@ -1020,3 +1022,155 @@ func TestRunMemoryChecks_QuietMode(t *testing.T) {
 	// Should return early without errors
 	orch.runMemoryChecks()
 }
+
+// =============================================================================
+// Test Run - scoped to component types
+// =============================================================================
+
+func TestRun_WithScope(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Root: tmpDir, Format: "console", Verbosity: config.VerbosityQuiet}
+
+	var ran []string
+	entry := func(name string) LinterEntry {
+		return LinterEntry{
+			Name: name,
+			Linter: func(opts Options) (*LintSummary, error) {
+				ran = append(ran, name)
+				return &LintSummary{TotalFiles: 1, SuccessfulFiles: 1}, nil
+			},
+		}
+	}
+	linters := []LinterEntry{entry("agents"), entry("commands"), entry("skills"), entry("settings"), entry("custom")}
+
+	tests := []struct {
+		name string
+		only []discovery.FileType
+		skip []discovery.FileType
+		want []string
+	}{
+		{name: "no scope", want: []string{"agents", "commands", "skills", "settings", "custom"}},
+		{name: "only", only: []discovery.FileType{discovery.FileTypeAgent, discovery.FileTypeSkill}, want: []string{"agents", "skills", "custom"}},
+		{name: "skip", skip: []discovery.FileType{discovery.FileTypeSettings}, want: []string{"agents", "commands", "skills", "custom"}},
+		{name: "skip wins over only", only: []discovery.FileType{discovery.FileTypeAgent}, skip: []discovery.FileType{discovery.FileTypeAgent}, want: []string{"custom"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran = nil
+			orch := NewOrchestrator(cfg, OrchestratorConfig{RootPath: tmpDir}).WithLinters(linters).WithScope(tt.only, tt.skip)
+			result, err := orch.Run()
+			if err != nil {
+				t.Fatalf("Run() error: %v", err)
+			}
+			if !slices.Equal(ran, tt.want) {
+				t.Errorf("ran %v, want %v", ran, tt.want)
+			}
+			if result.TotalFiles != len(tt.want) {
+				t.Errorf("TotalFiles = %d, want %d", result.TotalFiles, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestRun_WithScopeReportsSkippedSymlinks(t *testing.T) {
+	tmpDir := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "shared.md")
+	if err := os.WriteFile(outside, []byte("---\nname: shared\ndescription: Shared\n---\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{".claude/agents", ".claude/commands"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(outside, filepath.Join(tmpDir, dir, "shared.md")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &config.Config{Root: tmpDir, Format: "console", Symlinks: discovery.SymlinkDeny}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldStderr := os.Stderr
+	os.Stderr = w
+	_, runErr := NewOrchestrator(cfg, OrchestratorConfig{RootPath: tmpDir}).WithScope([]discovery.FileType{discovery.FileTypeAgent}, nil).Run()
+	os.Stderr = oldStderr
+	_ = w.Close()
+	stderr, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatalf("Run() error: %v", runErr)
+	}
+
+	if !strings.Contains(string(stderr), "warning: skipped symlink .claude/agents/shared.md -> ") {
+		t.Errorf("stderr = %q, want a warning for the agent symlink", stderr)
+	}
+	if strings.Contains(string(stderr), ".claude/commands/shared.md") {
+		t.Errorf("stderr = %q, want no warning for the out-of-scope command symlink", stderr)
+	}
+	if n := strings.Count(string(stderr), "skipped symlink"); n != 1 {
+		t.Errorf("got %d symlink warnings, want 1", n)
+	}
+}
+
+func TestRun_OnResult(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		Root:      tmpDir,
+		Format:    "console",
+		Verbosity: config.VerbosityQuiet,
+		Overrides: config.Overrides{{Files: []string{"**"}, Severity: map[string]string{"noisy": "off"}}},
+	}
+
+	var events []string
+	issue := func(file, message, rule string) cue.ValidationError {
+		return cue.ValidationError{File: file, Message: message, Severity: cue.SeverityWarning, Rule: rule}
+	}
+	entry := func(name string, files ...string) LinterEntry {
+		return LinterEntry{
+			Name: name,
+			Linter: func(opts Options) (*LintSummary, error) {
+				events = append(events, "lint "+name)
+				summary := &LintSummary{ComponentType: name, TotalFiles: len(files)}
+				for i, file := range files {
+					result := LintResult{File: file, Warnings: []cue.ValidationError{
+						issue(file, "Noisy", "noisy"), issue(file, "Kept", "kept"),
+					}}
+					summary.Results = append(summary.Results, result)
+					opts.Progress(Progress{ComponentType: name, Done: i + 1, Total: len(files), Result: &result})
+				}
+				// A batch check adds a finding to an earlier file.
+				if len(files) > 0 {
+					summary.Results[0].Warnings = append(summary.Results[0].Warnings, issue(files[0], "Batch", "batch"))
+				}
+				return summary, nil
+			},
+		}
+	}
+	var progress []Progress
+	orch := NewOrchestrator(cfg, OrchestratorConfig{
+		RootPath: tmpDir,
+		Progress: func(p Progress) { progress = append(progress, p) },
+		OnResult: func(s *LintSummary) {
+			var messages []string
+			for _, w := range s.Results[0].Warnings {
+				messages = append(messages, w.Message)
+			}
+			events = append(events, fmt.Sprintf("%s %s %v", s.ComponentType, s.Results[0].File, messages))
+		},
+	}).WithLinters([]LinterEntry{entry("agent", "a.md", "b.md"), entry("command")})
+
+	if _, err := orch.Run(); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	want := []string{
+		"lint agent", "agent a.md [Kept]", "agent b.md [Kept]", "agent a.md [Batch]",
+		"lint command",
+	}
+	if !slices.Equal(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+	if len(progress) != 2 || progress[1].Done != 2 || progress[1].Total != 2 {
+		t.Errorf("progress = %+v, want 2 of 2 agents", progress)
+	}
+}
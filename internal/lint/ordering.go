@@ -0,0 +1,43 @@
+package lint
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// SortSummary puts results in file path order and each result's findings in
+// (file, line, rule ID, message) order, so reports are identical across runs
+// regardless of discovery or post-processing order. Formatters render in
+// summary order, so sorting here makes every output format stable.
+func SortSummary(summary *LintSummary) {
+	if summary == nil {
+		return
+	}
+	slices.SortStableFunc(summary.Results, func(a, b LintResult) int {
+		return cmp.Compare(a.File, b.File)
+	})
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		SortIssues(result.Errors)
+		SortIssues(result.Warnings)
+		SortIssues(result.Suggestions)
+	}
+}
+
+// SortIssues orders findings by file, then line, then rule ID, then message.
+// Findings without a line (0) sort before line 1, keeping file-level issues
+// at the top of each file.
+func SortIssues(issues []cue.ValidationError) {
+	slices.SortStableFunc(issues, compareIssues)
+}
+
+func compareIssues(a, b cue.ValidationError) int {
+	return cmp.Or(
+		cmp.Compare(a.File, b.File),
+		cmp.Compare(a.Line, b.Line),
+		cmp.Compare(a.Rule, b.Rule),
+		cmp.Compare(a.Message, b.Message),
+	)
+}
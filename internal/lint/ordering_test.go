@@ -0,0 +1,40 @@
+package lint
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestSortSummary(t *testing.T) {
+	summary := &LintSummary{
+		Results: []LintResult{
+			{
+				File: "commands/b.md",
+				Warnings: []cue.ValidationError{
+					{File: "commands/b.md", Line: 9, Rule: "rule-a", Message: "late"},
+					{File: "commands/b.md", Line: 2, Rule: "rule-b", Message: "second"},
+					{File: "commands/b.md", Line: 2, Rule: "rule-a", Message: "z"},
+					{File: "commands/b.md", Line: 2, Rule: "rule-a", Message: "a"},
+					{File: "commands/b.md", Message: "file-level"},
+				},
+			},
+			{File: "agents/a.md"},
+		},
+	}
+
+	SortSummary(summary)
+
+	if summary.Results[0].File != "agents/a.md" {
+		t.Errorf("first result = %q, want agents/a.md", summary.Results[0].File)
+	}
+	var got []string
+	for _, w := range summary.Results[1].Warnings {
+		got = append(got, w.Message)
+	}
+	want := []string{"file-level", "a", "z", "second", "late"}
+	if !slices.Equal(got, want) {
+		t.Errorf("warning order = %v, want %v", got, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
 	"github.com/dotcommander/cclint/internal/scoring"
@@ -11,12 +12,12 @@ import (
 )
 
 // LintOutputStyles runs linting on output style files using the generic linter.
-func LintOutputStyles(rootPath string, quiet bool, verbose bool, noCycleCheck bool, exclude []string) (*LintSummary, error) {
-	ctx, err := NewLinterContext(rootPath, quiet, verbose, noCycleCheck, exclude)
+func LintOutputStyles(opts Options) (*LintSummary, error) {
+	ctx, err := NewLinterContext(opts)
 	if err != nil {
 		return nil, err
 	}
-	return lintBatch(ctx, NewOutputStyleLinter()), nil
+	return lintBatch(ctx, NewOutputStyleLinter(ctx.Config)), nil
 }
 
 // OutputStyleLinter implements ComponentLinter for output style markdown files.
@@ -40,8 +41,9 @@ var knownOutputStyleFields = map[string]bool{
 }
 
 // NewOutputStyleLinter creates a new OutputStyleLinter.
-func NewOutputStyleLinter() *OutputStyleLinter {
-	return &OutputStyleLinter{}
+// cfg supplies the rule settings; nil uses the defaults.
+func NewOutputStyleLinter(cfg *config.Config) *OutputStyleLinter {
+	return &OutputStyleLinter{BaseLinter: BaseLinter{cfg: cfg}}
 }
 
 func (l *OutputStyleLinter) Type() string {
@@ -63,6 +65,7 @@ func (l *OutputStyleLinter) PreValidate(filePath, contents string) []cue.Validat
 			Message:  "Output style files must have .md extension",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleComponentFileName,
 		})
 	}
 
@@ -73,6 +76,7 @@ func (l *OutputStyleLinter) PreValidate(filePath, contents string) []cue.Validat
 			Message:  "Output style file is empty",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleComponentEmpty,
 			Abort:    true,
 		})
 	}
@@ -81,11 +85,7 @@ func (l *OutputStyleLinter) PreValidate(filePath, contents string) []cue.Validat
 }
 
 func (l *OutputStyleLinter) ParseContent(contents string) (map[string]any, string, error) {
-	fm, err := textutil.ParseYAMLFrontmatter(contents)
-	if err != nil {
-		return nil, "", fmt.Errorf("error parsing frontmatter: %v", err)
-	}
-	return fm.Data, fm.Body, nil
+	return parseFrontmatter(contents)
 }
 
 func (l *OutputStyleLinter) ValidateCUE(validator *cue.Validator, data map[string]any) ([]cue.ValidationError, error) {
@@ -97,11 +97,13 @@ func (l *OutputStyleLinter) ValidateSpecific(data map[string]any, filePath, cont
 	var errors []cue.ValidationError
 
 	// Check for unknown frontmatter fields
-	errors = append(errors, checkUnknownFields(data, filePath, contents, unknownFieldCheck{
-		known:    knownOutputStyleFields,
-		label:    "frontmatter field",
-		suffix:   ". Valid fields: " + sortedMapKeys(knownOutputStyleFields),
-		findLine: textutil.FindFrontmatterFieldLine,
+	errors = append(errors, checkUnknownFields(l.Config(), data, filePath, contents, unknownFieldCheck{
+		known:     knownOutputStyleFields,
+		label:     "frontmatter field",
+		suffix:    ". Valid fields: " + sortedMapKeys(knownOutputStyleFields),
+		rule:      cue.RuleFrontmatterUnknownKey,
+		findLine:  textutil.FindFrontmatterFieldLine,
+		component: cue.TypeOutputStyle,
 	})...)
 
 	// Required: name field
@@ -111,6 +113,7 @@ func (l *OutputStyleLinter) ValidateSpecific(data map[string]any, filePath, cont
 			Message:  "Required field 'name' is missing or empty",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldRequired,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
 		})
 	} else {
@@ -124,6 +127,7 @@ func (l *OutputStyleLinter) ValidateSpecific(data map[string]any, filePath, cont
 			Message:  "Required field 'description' is missing or empty",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldRequired,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "description"),
 		})
 	}
@@ -136,6 +140,7 @@ func (l *OutputStyleLinter) ValidateSpecific(data map[string]any, filePath, cont
 				Message:  fmt.Sprintf("keep-coding-instructions must be a boolean (got '%v')", kci),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleFieldInvalid,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "keep-coding-instructions"),
 			})
 		}
@@ -149,6 +154,7 @@ func (l *OutputStyleLinter) ValidateSpecific(data map[string]any, filePath, cont
 			Message:  "Output style body is empty - add markdown content for system prompt customization",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleComponentEmpty,
 		})
 	}
 
@@ -165,6 +171,7 @@ func validateOutputStyleName(name, filePath, contents string) []cue.ValidationEr
 			Message:  "Name must contain only lowercase letters, numbers, and hyphens (kebab-case)",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleNameFormat,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
 		})
 	}
@@ -175,6 +182,7 @@ func validateOutputStyleName(name, filePath, contents string) []cue.ValidationEr
 			Message:  fmt.Sprintf("Name '%s' cannot start or end with a hyphen", name),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleNameFormat,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
 		})
 	}
@@ -8,7 +8,7 @@ import (
 )
 
 func TestOutputStyleLinterType(t *testing.T) {
-	linter := NewOutputStyleLinter()
+	linter := NewOutputStyleLinter(nil)
 	if linter.Type() != "output-style" {
 		t.Errorf("Type() = %q, want %q", linter.Type(), "output-style")
 	}
@@ -18,7 +18,7 @@ func TestOutputStyleLinterType(t *testing.T) {
 }
 
 func TestOutputStyleLinterValidOutput(t *testing.T) {
-	linter := NewOutputStyleLinter()
+	linter := NewOutputStyleLinter(nil)
 	contents := "---\nname: concise-technical\ndescription: A concise technical writing style\nkeep-coding-instructions: true\n---\n\nWrite in a concise, technical style.\n"
 
 	data, body, err := linter.ParseContent(contents)
@@ -48,7 +48,7 @@ func TestOutputStyleLinterValidOutput(t *testing.T) {
 }
 
 func TestOutputStyleLinterMissingName(t *testing.T) {
-	linter := NewOutputStyleLinter()
+	linter := NewOutputStyleLinter(nil)
 	contents := "---\ndescription: A style without a name\n---\n\nSome body content.\n"
 
 	data, _, err := linter.ParseContent(contents)
@@ -70,7 +70,7 @@ func TestOutputStyleLinterMissingName(t *testing.T) {
 }
 
 func TestOutputStyleLinterMissingDescription(t *testing.T) {
-	linter := NewOutputStyleLinter()
+	linter := NewOutputStyleLinter(nil)
 	contents := "---\nname: test-style\n---\n\nSome body content.\n"
 
 	data, _, err := linter.ParseContent(contents)
@@ -92,7 +92,7 @@ func TestOutputStyleLinterMissingDescription(t *testing.T) {
 }
 
 func TestOutputStyleLinterNonBooleanKeepCodingInstructions(t *testing.T) {
-	linter := NewOutputStyleLinter()
+	linter := NewOutputStyleLinter(nil)
 	contents := "---\nname: test-style\ndescription: A test style\nkeep-coding-instructions: \"yes\"\n---\n\nSome body content.\n"
 
 	data, _, err := linter.ParseContent(contents)
@@ -114,7 +114,7 @@ func TestOutputStyleLinterNonBooleanKeepCodingInstructions(t *testing.T) {
 }
 
 func TestOutputStyleLinterEmptyBody(t *testing.T) {
-	linter := NewOutputStyleLinter()
+	linter := NewOutputStyleLinter(nil)
 	contents := "---\nname: test-style\ndescription: A test style\n---\n"
 
 	data, _, err := linter.ParseContent(contents)
@@ -136,7 +136,7 @@ func TestOutputStyleLinterEmptyBody(t *testing.T) {
 }
 
 func TestOutputStyleLinterInvalidNameFormat(t *testing.T) {
-	linter := NewOutputStyleLinter()
+	linter := NewOutputStyleLinter(nil)
 	contents := "---\nname: My Style\ndescription: A test style\n---\n\nBody content.\n"
 
 	data, _, err := linter.ParseContent(contents)
@@ -158,7 +158,7 @@ func TestOutputStyleLinterInvalidNameFormat(t *testing.T) {
 }
 
 func TestOutputStyleLinterUnknownField(t *testing.T) {
-	linter := NewOutputStyleLinter()
+	linter := NewOutputStyleLinter(nil)
 	contents := "---\nname: test-style\ndescription: A test style\nunknown-field: value\n---\n\nBody content.\n"
 
 	data, _, err := linter.ParseContent(contents)
@@ -180,7 +180,7 @@ func TestOutputStyleLinterUnknownField(t *testing.T) {
 }
 
 func TestOutputStyleLinterNameStartsWithHyphen(t *testing.T) {
-	linter := NewOutputStyleLinter()
+	linter := NewOutputStyleLinter(nil)
 	contents := "---\nname: -bad-name\ndescription: A test style\n---\n\nBody content.\n"
 
 	data, _, err := linter.ParseContent(contents)
@@ -204,7 +204,7 @@ func TestOutputStyleLinterNameStartsWithHyphen(t *testing.T) {
 func TestOutputStyleLinterCompileTimeChecks(t *testing.T) {
 	// Verify interface compliance at compile time (already done via var _ declarations,
 	// but this test ensures the linter satisfies the expected interfaces).
-	var linter any = NewOutputStyleLinter()
+	var linter any = NewOutputStyleLinter(nil)
 
 	if _, ok := linter.(ComponentLinter); !ok {
 		t.Error("OutputStyleLinter does not implement ComponentLinter")
@@ -219,7 +219,7 @@ func TestOutputStyleLinterCompileTimeChecks(t *testing.T) {
 
 func TestOutputStyleLinterIntegration(t *testing.T) {
 	// Test the full linting pipeline via lintFileCore
-	linter := NewOutputStyleLinter()
+	linter := NewOutputStyleLinter(nil)
 	validator := cue.NewValidator()
 	_ = validator.LoadSchemas("")
 
@@ -0,0 +1,139 @@
+package lint
+
+import (
+	"cmp"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/codeowners"
+)
+
+// NoOwner is the --owner value that selects files no CODEOWNERS rule
+// assigns, and the label of their row in the owner breakdown.
+const NoOwner = "none"
+
+// ApplyCodeOwners records the CODEOWNERS owners of each result and, when
+// filter is non-empty, keeps only the results owned by one of the listed
+// owners (compared case-insensitively; NoOwner selects unowned files).
+// Totals are recalculated after filtering. A nil ruleset leaves the summary
+// unchanged.
+func ApplyCodeOwners(summary *LintSummary, rs *codeowners.Ruleset, filter []string) {
+	if summary == nil || rs == nil {
+		return
+	}
+	summary.CodeOwners = rs.Path
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		relPath := result.File
+		if filepath.IsAbs(relPath) && summary.ProjectRoot != "" {
+			if rel, err := filepath.Rel(summary.ProjectRoot, relPath); err == nil {
+				relPath = rel
+			}
+		}
+		result.Owners = rs.Owners(relPath)
+	}
+	if len(filter) == 0 {
+		return
+	}
+
+	kept := make(map[string]bool)
+	summary.Results = slices.DeleteFunc(summary.Results, func(r LintResult) bool {
+		if ownedByAny(r.Owners, filter) {
+			kept[r.File] = true
+			return false
+		}
+		return true
+	})
+	summary.Suppressed = slices.DeleteFunc(summary.Suppressed, func(s SuppressedIssue) bool {
+		return !kept[s.File]
+	})
+	summary.TotalFiles = len(summary.Results)
+	summary.DisabledFiles = 0
+	for _, r := range summary.Results {
+		if r.Disabled {
+			summary.DisabledFiles++
+		}
+	}
+	recalculateTotals(summary)
+}
+
+// ownedByAny reports whether owners includes one of filter.
+func ownedByAny(owners, filter []string) bool {
+	for _, f := range filter {
+		if strings.EqualFold(f, NoOwner) && len(owners) == 0 {
+			return true
+		}
+		if slices.ContainsFunc(owners, func(o string) bool { return strings.EqualFold(o, f) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnerCount totals the files and findings of one CODEOWNERS owner.
+type OwnerCount struct {
+	Owner       string // NoOwner for files no rule assigns
+	Files       int
+	Failed      int
+	Errors      int
+	Warnings    int
+	Suggestions int
+}
+
+// CountByOwner breaks the results of summaries down by owner. A file with
+// several owners counts toward each. Owners are sorted by errors, then
+// warnings, then name, with unowned files last. It returns nil when no
+// summary has CODEOWNERS data.
+func CountByOwner(summaries ...*LintSummary) []OwnerCount {
+	counts := make(map[string]*OwnerCount)
+	found := false
+	for _, s := range summaries {
+		if s == nil || s.CodeOwners == "" {
+			continue
+		}
+		found = true
+		for _, r := range s.Results {
+			owners := r.Owners
+			if len(owners) == 0 {
+				owners = []string{NoOwner}
+			}
+			for _, o := range owners {
+				c := counts[o]
+				if c == nil {
+					c = &OwnerCount{Owner: o}
+					counts[o] = c
+				}
+				c.Files++
+				if !r.Success {
+					c.Failed++
+				}
+				c.Errors += len(r.Errors)
+				c.Warnings += len(r.Warnings)
+				c.Suggestions += len(r.Suggestions)
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	out := make([]OwnerCount, 0, len(counts))
+	for _, c := range counts {
+		out = append(out, *c)
+	}
+	slices.SortFunc(out, func(a, b OwnerCount) int {
+		if (a.Owner == NoOwner) != (b.Owner == NoOwner) {
+			if a.Owner == NoOwner {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(
+			cmp.Compare(b.Errors, a.Errors),
+			cmp.Compare(b.Warnings, a.Warnings),
+			cmp.Compare(a.Owner, b.Owner),
+		)
+	})
+	return out
+}
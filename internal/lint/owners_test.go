@@ -0,0 +1,120 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/codeowners"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func ownersSummary(t *testing.T) (*LintSummary, *codeowners.Ruleset) {
+	t.Helper()
+	rs, err := codeowners.Parse("CODEOWNERS", strings.NewReader("/.claude/agents/ @org/agents @alice\n/.claude/skills/ @org/skills\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := []cue.ValidationError{{Message: "bad", Severity: cue.SeverityError}}
+	warns := []cue.ValidationError{{Message: "meh", Severity: cue.SeverityWarning}}
+	summary := &LintSummary{
+		ProjectRoot: "/repo",
+		Results: []LintResult{
+			{File: ".claude/agents/a.md", Errors: errs},
+			{File: "/repo/.claude/skills/s/SKILL.md", Warnings: warns, Success: true},
+			{File: ".claude/commands/c.md", Errors: errs, Warnings: warns},
+		},
+		Suppressed: []SuppressedIssue{{File: ".claude/commands/c.md", Source: SuppressionBaseline}},
+	}
+	recalculateTotals(summary)
+	summary.TotalFiles = 3
+	return summary, rs
+}
+
+func TestApplyCodeOwners(t *testing.T) {
+	summary, rs := ownersSummary(t)
+	ApplyCodeOwners(summary, rs, nil)
+
+	if summary.CodeOwners != "CODEOWNERS" {
+		t.Errorf("CodeOwners = %q", summary.CodeOwners)
+	}
+	want := [][]string{{"@org/agents", "@alice"}, {"@org/skills"}, nil}
+	for i, r := range summary.Results {
+		if strings.Join(r.Owners, ",") != strings.Join(want[i], ",") {
+			t.Errorf("%s: owners = %v, want %v", r.File, r.Owners, want[i])
+		}
+	}
+	if summary.TotalFiles != 3 || summary.TotalErrors != 2 {
+		t.Errorf("unfiltered totals changed: %+v", summary)
+	}
+}
+
+func TestApplyCodeOwnersFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     []string
+		wantFiles  []string
+		wantErrors int
+	}{
+		{"team", []string{"@org/skills"}, []string{"/repo/.claude/skills/s/SKILL.md"}, 0},
+		{"case-insensitive", []string{"@ALICE"}, []string{".claude/agents/a.md"}, 1},
+		{"several owners", []string{"@org/skills", "@alice"}, []string{".claude/agents/a.md", "/repo/.claude/skills/s/SKILL.md"}, 1},
+		{"unowned", []string{NoOwner}, []string{".claude/commands/c.md"}, 1},
+		{"no match", []string{"@nobody"}, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, rs := ownersSummary(t)
+			ApplyCodeOwners(summary, rs, tt.filter)
+
+			var files []string
+			for _, r := range summary.Results {
+				files = append(files, r.File)
+			}
+			if strings.Join(files, ",") != strings.Join(tt.wantFiles, ",") {
+				t.Errorf("files = %v, want %v", files, tt.wantFiles)
+			}
+			if summary.TotalFiles != len(tt.wantFiles) || summary.TotalErrors != tt.wantErrors {
+				t.Errorf("TotalFiles = %d, TotalErrors = %d; want %d, %d", summary.TotalFiles, summary.TotalErrors, len(tt.wantFiles), tt.wantErrors)
+			}
+			wantSuppressed := 0
+			if tt.name == "unowned" {
+				wantSuppressed = 1
+			}
+			if len(summary.Suppressed) != wantSuppressed {
+				t.Errorf("Suppressed = %v, want %d", summary.Suppressed, wantSuppressed)
+			}
+		})
+	}
+}
+
+func TestApplyCodeOwnersNoRuleset(t *testing.T) {
+	summary, _ := ownersSummary(t)
+	ApplyCodeOwners(summary, nil, []string{"@org/agents"})
+	if len(summary.Results) != 3 || summary.CodeOwners != "" {
+		t.Errorf("summary changed without a ruleset: %+v", summary)
+	}
+}
+
+func TestCountByOwner(t *testing.T) {
+	if got := CountByOwner(&LintSummary{}); got != nil {
+		t.Errorf("CountByOwner without CODEOWNERS = %v, want nil", got)
+	}
+
+	summary, rs := ownersSummary(t)
+	ApplyCodeOwners(summary, rs, nil)
+	got := CountByOwner(summary, nil)
+	want := []OwnerCount{
+		{Owner: "@alice", Files: 1, Failed: 1, Errors: 1},
+		{Owner: "@org/agents", Files: 1, Failed: 1, Errors: 1},
+		{Owner: "@org/skills", Files: 1, Warnings: 1},
+		{Owner: NoOwner, Files: 1, Failed: 1, Errors: 1, Warnings: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("CountByOwner = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("CountByOwner[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
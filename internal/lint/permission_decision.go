@@ -0,0 +1,514 @@
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// managedSettingsPaths are where Claude Code reads managed (enterprise)
+// settings on each platform.
+var managedSettingsPaths = map[string]string{
+	"darwin":  "/Library/Application Support/ClaudeCode/managed-settings.json",
+	"linux":   "/etc/claude-code/managed-settings.json",
+	"windows": `C:\Program Files\ClaudeCode\managed-settings.json`,
+}
+
+// managedSettingsPath is the managed settings file of this platform; tests
+// point it elsewhere.
+var managedSettingsPath = managedSettingsPaths[runtime.GOOS]
+
+// readTools are covered by Read rules.
+var readTools = []string{"Read", "Glob", "Grep", "LS", "NotebookRead"}
+
+// readOnlyTools need no approval in the default permission mode.
+var readOnlyTools = append(slices.Clone(readTools), "TodoWrite")
+
+// editTools are covered by Edit rules and approved by acceptEdits mode.
+var editTools = []string{"Edit", "Write", "MultiEdit", "NotebookEdit"}
+
+// PermissionLayer is one settings file of the permission hierarchy and the
+// permission rules it contributes.
+type PermissionLayer struct {
+	Name          string // managed, local, project, or user
+	File          string
+	Base          string // the directory "/path" rules resolve against
+	Allow         []string
+	Ask           []string
+	Deny          []string
+	DefaultMode   string
+	DisableBypass bool // disableBypassPermissionsMode is "disable"
+	ManagedOnly   bool // allowManagedPermissionRulesOnly
+}
+
+// PermissionSettings is the merged permission hierarchy of a session
+// started in Root.
+type PermissionSettings struct {
+	Root   string
+	Home   string
+	Layers []PermissionLayer // highest precedence first
+	Mode   string            // overrides the settings' defaultMode, as --permission-mode does
+}
+
+// LoadPermissionSettings reads the settings files that apply to a session
+// started in root, highest precedence first: managed settings, the
+// project's .claude/settings.local.json and .claude/settings.json, then the
+// user's ~/.claude/settings.json. Missing files are skipped. A file that
+// does not parse is an error, since its rules would silently be left out.
+func LoadPermissionSettings(root, home string) (*PermissionSettings, error) {
+	settings := &PermissionSettings{Root: root, Home: home}
+	candidates := []PermissionLayer{
+		{Name: "managed", File: managedSettingsPath, Base: root},
+		{Name: "local", File: filepath.Join(root, ".claude", "settings.local.json"), Base: root},
+		{Name: "project", File: filepath.Join(root, ".claude", "settings.json"), Base: root},
+		{Name: "user", File: filepath.Join(home, ".claude", "settings.json"), Base: home},
+	}
+	seen := make(map[string]bool)
+	for _, layer := range candidates {
+		if layer.File == "" || seen[layer.File] {
+			continue
+		}
+		seen[layer.File] = true
+		data, err := os.ReadFile(layer.File) //nolint:gosec // G304: the settings hierarchy of the project and user
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := layer.parse(string(data)); err != nil {
+			return nil, fmt.Errorf("%s: %w", layer.File, err)
+		}
+		settings.Layers = append(settings.Layers, layer)
+	}
+	return settings, nil
+}
+
+// parse fills the layer's rules and mode from its settings file.
+func (l *PermissionLayer) parse(contents string) error {
+	blanked, _ := textutil.BlankJSONC(contents)
+	var data struct {
+		Permissions struct {
+			Allow                        []any  `json:"allow"`
+			Ask                          []any  `json:"ask"`
+			Deny                         []any  `json:"deny"`
+			DefaultMode                  string `json:"defaultMode"`
+			DisableBypassPermissionsMode string `json:"disableBypassPermissionsMode"`
+		} `json:"permissions"`
+		AllowManagedPermissionRulesOnly bool `json:"allowManagedPermissionRulesOnly"`
+	}
+	if err := json.Unmarshal([]byte(blanked), &data); err != nil {
+		return err
+	}
+	p := data.Permissions
+	l.Allow, l.Ask, l.Deny = ruleStrings(p.Allow), ruleStrings(p.Ask), ruleStrings(p.Deny)
+	l.DefaultMode = p.DefaultMode
+	l.DisableBypass = p.DisableBypassPermissionsMode == "disable"
+	l.ManagedOnly = data.AllowManagedPermissionRulesOnly
+	return nil
+}
+
+// ruleStrings keeps the non-empty string entries of a rule list; linting
+// reports the others.
+func ruleStrings(entries []any) []string {
+	var rules []string
+	for _, e := range entries {
+		if s, ok := e.(string); ok && s != "" {
+			rules = append(rules, s)
+		}
+	}
+	return rules
+}
+
+// PermissionMatch is a permission rule that matches a tool use.
+type PermissionMatch struct {
+	Behavior string `json:"behavior"` // allow, ask, or deny
+	Rule     string `json:"rule"`
+	Layer    string `json:"layer"`
+	File     string `json:"file"`
+}
+
+// PermissionDecision is what Claude Code decides for a tool use, and why.
+type PermissionDecision struct {
+	Request  string            `json:"request"`
+	Behavior string            `json:"decision"` // allow, ask, or deny
+	Rule     *PermissionMatch  `json:"rule,omitempty"`
+	Mode     string            `json:"mode,omitempty"` // set when the permission mode decides
+	Reason   string            `json:"reason"`
+	Matches  []PermissionMatch `json:"matches"`
+}
+
+// permissionBehaviors are the rule lists in the order Claude Code checks
+// them: a deny anywhere in the hierarchy beats an ask, and an ask beats an
+// allow, whatever layer each comes from.
+var permissionBehaviors = []string{"deny", "ask", "allow"}
+
+// Decide evaluates a tool use, written as a permission rule such as
+// "Bash(npm run build)" or "Read(./src/main.go)", against the hierarchy.
+// Deny rules are checked first, then ask, then allow; within each list the
+// highest-precedence layer that matches is reported. A Bash command chained
+// with &&, ||, ;, or | is denied or asked about when the whole command or
+// any part is, and allowed only when the whole command or every part is. A
+// tool use no rule matches is decided by the permission mode.
+func (s *PermissionSettings) Decide(request string) (PermissionDecision, error) {
+	tool, arg, err := parseToolUse(request)
+	if err != nil {
+		return PermissionDecision{}, err
+	}
+	decision := PermissionDecision{Request: request, Matches: []PermissionMatch{}}
+	// parts holds the whole argument and, for a chained Bash command, each
+	// command of the chain after it.
+	parts := []string{arg}
+	if tool == "Bash" {
+		if split := splitBashCommand(arg); len(split) > 1 {
+			parts = append(parts, split...)
+		}
+	}
+	chained := len(parts) > 1
+
+	// matched holds, per behavior, the index in Matches of the first rule
+	// matching each part, or -1.
+	matched := make(map[string][]int)
+	for _, behavior := range permissionBehaviors {
+		matched[behavior] = slices.Repeat([]int{-1}, len(parts))
+		for _, layer := range s.ruleLayers() {
+			for _, rule := range layer.rules(behavior) {
+				found := false
+				for i, part := range parts {
+					if !s.ruleMatches(rule, layer, tool, part) {
+						continue
+					}
+					if !found {
+						decision.Matches = append(decision.Matches, PermissionMatch{Behavior: behavior, Rule: rule, Layer: layer.Name, File: layer.File})
+						found = true
+					}
+					if matched[behavior][i] < 0 {
+						matched[behavior][i] = len(decision.Matches) - 1
+					}
+				}
+			}
+		}
+	}
+
+	for _, behavior := range []string{"deny", "ask"} {
+		for i, idx := range matched[behavior] {
+			if idx < 0 {
+				continue
+			}
+			m := &decision.Matches[idx]
+			decision.Behavior, decision.Rule = behavior, m
+			decision.Reason = fmt.Sprintf("%s rule in %s settings", behavior, m.Layer)
+			if i > 0 {
+				decision.Reason += fmt.Sprintf(" matches %q in the command chain", parts[i])
+			}
+			return decision, nil
+		}
+	}
+	allowed := matched["allow"]
+	if allowed[0] >= 0 {
+		m := &decision.Matches[allowed[0]]
+		decision.Behavior, decision.Rule = "allow", m
+		decision.Reason = "allow rule in " + m.Layer + " settings"
+		return decision, nil
+	}
+	if chained {
+		commands := allowed[1:]
+		missing := slices.Index(commands, -1)
+		if missing < 0 {
+			decision.Behavior, decision.Rule = "allow", &decision.Matches[commands[0]]
+			decision.Reason = "allow rules cover every command in the chain"
+			return decision, nil
+		}
+		if slices.ContainsFunc(commands, func(idx int) bool { return idx >= 0 }) {
+			decision.Reason = fmt.Sprintf("no allow rule covers %q in the command chain; ", parts[missing+1])
+		}
+	}
+	s.decideByMode(&decision, tool)
+	return decision, nil
+}
+
+// ruleLayers returns the layers whose rules apply: only the managed layer
+// when it sets allowManagedPermissionRulesOnly.
+func (s *PermissionSettings) ruleLayers() []PermissionLayer {
+	for _, layer := range s.Layers {
+		if layer.Name == "managed" && layer.ManagedOnly {
+			return []PermissionLayer{layer}
+		}
+	}
+	return s.Layers
+}
+
+func (l PermissionLayer) rules(behavior string) []string {
+	switch behavior {
+	case "allow":
+		return l.Allow
+	case "ask":
+		return l.Ask
+	}
+	return l.Deny
+}
+
+// decideByMode decides a tool use no rule matches by the permission mode:
+// the defaultMode of the highest-precedence layer that sets one.
+func (s *PermissionSettings) decideByMode(d *PermissionDecision, tool string) {
+	d.Mode = s.Mode
+	for _, layer := range s.Layers {
+		if d.Mode == "" && layer.DefaultMode != "" {
+			d.Mode = layer.DefaultMode
+		}
+	}
+	if d.Mode == "" {
+		d.Mode = "default"
+	}
+	if d.Mode == "bypassPermissions" && slices.ContainsFunc(s.Layers, func(l PermissionLayer) bool { return l.DisableBypass }) {
+		d.Mode = "default"
+		d.Reason += "bypassPermissions is disabled; "
+	}
+
+	readOnly, edit := slices.Contains(readOnlyTools, tool), slices.Contains(editTools, tool)
+	switch {
+	case d.Mode == "bypassPermissions":
+		d.Behavior, d.Reason = "allow", d.Reason+"bypassPermissions mode allows every tool"
+	case d.Mode == "dontAsk":
+		d.Behavior, d.Reason = "deny", d.Reason+"dontAsk mode denies tools no rule allows"
+	case d.Mode == "auto":
+		d.Behavior, d.Reason = "ask", d.Reason+"no rule matches; in auto mode a classifier decides instead of prompting"
+	case readOnly:
+		d.Behavior, d.Reason = "allow", d.Reason+"read-only tools need no approval"
+	case edit && d.Mode == "acceptEdits":
+		d.Behavior, d.Reason = "allow", d.Reason+"acceptEdits mode allows file edits"
+	case edit && d.Mode == "plan":
+		d.Behavior, d.Reason = "deny", d.Reason+"plan mode does not edit files"
+	default:
+		d.Behavior, d.Reason = "ask", d.Reason+"no rule matches, so Claude Code asks"
+	}
+}
+
+// IsPermissionMode reports whether mode is a permission mode Claude Code
+// knows.
+func IsPermissionMode(mode string) bool {
+	return slices.Contains(permissionModes, mode)
+}
+
+// PermissionModes returns the permission modes, in documentation order.
+func PermissionModes() []string {
+	return slices.Clone(permissionModes)
+}
+
+// parseToolUse splits a tool use such as "Bash(npm test)" into the tool and
+// its argument.
+func parseToolUse(request string) (tool, arg string, err error) {
+	request = strings.TrimSpace(request)
+	tool = textutil.ExtractBaseToolName(request)
+	if tool == "" || strings.ContainsAny(tool, " \t") {
+		return "", "", fmt.Errorf("invalid tool use %q: write it as a permission rule, such as Bash(npm test) or Read(./src/main.go)", request)
+	}
+	if rest, ok := strings.CutPrefix(request, tool+"("); ok {
+		if arg, ok = strings.CutSuffix(rest, ")"); !ok {
+			return "", "", fmt.Errorf("invalid tool use %q: missing closing parenthesis", request)
+		}
+	} else if request != tool {
+		return "", "", fmt.Errorf("invalid tool use %q: write it as a permission rule, such as Bash(npm test) or Read(./src/main.go)", request)
+	}
+	return tool, strings.TrimSpace(arg), nil
+}
+
+// ruleMatches reports whether a permission rule from layer covers a use of
+// tool with arg. A rule without an argument, or with (*), covers every use
+// of its tool; a rule with one covers no argument-less use.
+func (s *PermissionSettings) ruleMatches(rule string, layer PermissionLayer, tool, arg string) bool {
+	ruleTool := textutil.ExtractBaseToolName(rule)
+	if !ruleCoversTool(ruleTool, tool) {
+		return false
+	}
+	open, closing := strings.IndexByte(rule, '('), strings.LastIndexByte(rule, ')')
+	if open < 0 || closing < open {
+		return true
+	}
+	pattern := strings.TrimSpace(rule[open+1 : closing])
+	if pattern == "" || pattern == "*" {
+		return true
+	}
+	if arg == "" {
+		return false
+	}
+	switch {
+	case ruleTool == "Read" || ruleTool == "Edit":
+		return s.pathRuleMatches(pattern, layer.Base, arg)
+	case ruleTool == "WebFetch" && strings.HasPrefix(pattern, "domain:"):
+		return domainMatches(strings.TrimPrefix(pattern, "domain:"), arg)
+	}
+	return ruleArgPattern(pattern).MatchString(arg)
+}
+
+// ruleCoversTool reports whether a rule naming ruleTool applies to tool.
+// Edit rules cover every file-editing tool and Read rules every file-reading
+// one; Task and Agent are the same tool; an MCP server rule covers its
+// tools.
+func ruleCoversTool(ruleTool, tool string) bool {
+	switch {
+	case ruleTool == tool:
+		return true
+	case ruleTool == "Edit":
+		return slices.Contains(editTools, tool)
+	case ruleTool == "Read":
+		return slices.Contains(readTools, tool)
+	case ruleTool == "Task" || ruleTool == "Agent":
+		return tool == "Task" || tool == "Agent"
+	case strings.HasPrefix(ruleTool, "mcp__"):
+		if prefix, ok := strings.CutSuffix(ruleTool, "*"); ok {
+			return strings.HasPrefix(tool, prefix)
+		}
+		return strings.HasPrefix(tool, ruleTool+"__")
+	}
+	return false
+}
+
+// ruleArgPattern compiles a permission-rule argument pattern: * matches
+// anything, and a trailing :* or " *" matches the prefix alone or followed
+// by arguments, so "ls:*" covers "ls -la" but not "lsof".
+func ruleArgPattern(pattern string) *regexp.Regexp {
+	prefix, words := strings.CutSuffix(pattern, ":*")
+	if !words {
+		prefix, words = strings.CutSuffix(pattern, " *")
+	}
+	if !words {
+		prefix = pattern
+	}
+	parts := strings.Split(prefix, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	expr := `^` + strings.Join(parts, ".*")
+	if words {
+		expr += `(?:\s.*)?`
+	}
+	return regexp.MustCompile(`(?s)` + expr + `$`)
+}
+
+// pathRuleMatches matches a Read or Edit rule's gitignore-style path
+// pattern against a path. Patterns starting with // are absolute, ~/ is the
+// home directory, / is relative to the settings file's project, and others
+// are relative to the working directory; a pattern without a slash matches
+// at any depth. A pattern naming a directory covers everything under it.
+func (s *PermissionSettings) pathRuleMatches(pattern, base, path string) bool {
+	anywhere := !strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	var dir string
+	switch {
+	case strings.HasPrefix(pattern, "//"):
+		pattern = pattern[1:]
+	case strings.HasPrefix(pattern, "~/"):
+		dir, pattern = s.Home, pattern[2:]
+	case strings.HasPrefix(pattern, "/"):
+		dir, pattern = base, pattern[1:]
+	default:
+		dir, pattern = s.Root, strings.TrimPrefix(pattern, "./")
+	}
+	expr := `^`
+	if dir != "" {
+		expr += regexp.QuoteMeta(filepath.ToSlash(filepath.Clean(dir))) + `/`
+	}
+	if anywhere {
+		expr += `(?:.*/)?`
+	}
+	expr += globExpr(strings.TrimSuffix(pattern, "/")) + `(?:/.*)?$`
+	return regexp.MustCompile(expr).MatchString(s.resolvePath(path))
+}
+
+// resolvePath makes a tool use's path absolute: ~/ is the home directory
+// and relative paths are relative to the working directory.
+func (s *PermissionSettings) resolvePath(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		path = filepath.Join(s.Home, rest)
+	} else if !filepath.IsAbs(path) {
+		path = filepath.Join(s.Root, path)
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// globExpr translates a gitignore-style glob to a regex: ** crosses
+// directories, * and ? do not.
+func globExpr(glob string) string {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString(`(?:.*/)?`)
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(`.*`)
+			i++
+		case glob[i] == '*':
+			sb.WriteString(`[^/]*`)
+		case glob[i] == '?':
+			sb.WriteString(`[^/]`)
+		default:
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	return sb.String()
+}
+
+// domainMatches reports whether a WebFetch target, a URL or domain:host,
+// is on domain or, for *.example.com, one of its subdomains.
+func domainMatches(domain, target string) bool {
+	host := strings.TrimPrefix(target, "domain:")
+	if u, err := url.Parse(target); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+	if suffix, ok := strings.CutPrefix(domain, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+	return strings.EqualFold(host, domain)
+}
+
+// splitBashCommand splits a command line on the operators that chain
+// commands (&&, ||, ;, |, and newlines), outside quotes.
+func splitBashCommand(command string) []string {
+	var parts []string
+	var current strings.Builder
+	var quote byte
+	flush := func() {
+		if part := strings.TrimSpace(current.String()); part != "" {
+			parts = append(parts, part)
+		}
+		current.Reset()
+	}
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' && i+1 < len(command) {
+				current.WriteByte(c)
+				i++
+				c = command[i]
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '\\' && i+1 < len(command):
+			current.WriteByte(c)
+			i++
+			c = command[i]
+		case c == ';' || c == '\n' || c == '|' || (c == '&' && i+1 < len(command) && command[i+1] == '&'):
+			if i+1 < len(command) && (c == '&' || c == '|') && command[i+1] == c {
+				i++
+			}
+			flush()
+			continue
+		}
+		current.WriteByte(c)
+	}
+	flush()
+	return parts
+}
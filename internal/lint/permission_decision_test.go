@@ -0,0 +1,166 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionDecide(t *testing.T) {
+	settings := &PermissionSettings{
+		Root: "/work/app",
+		Home: "/home/dev",
+		Layers: []PermissionLayer{
+			{Name: "local", File: "local.json", Base: "/work/app", Allow: []string{"Bash(git push:*)"}},
+			{Name: "project", File: "project.json", Base: "/work/app",
+				Allow: []string{"Bash(npm run:*)", "Bash(git status)", "Bash(make build && make test)", "Read(./src/**)", "mcp__github", "WebFetch(domain:*.example.com)"},
+				Ask:   []string{"Bash(git push:*)"},
+				Deny:  []string{"Read(.env)", "Bash(rm *)", "Edit(/config/**)"}},
+			{Name: "user", File: "user.json", Base: "/home/dev", Allow: []string{"Read(~/notes/*.md)"}, DefaultMode: "acceptEdits"},
+		},
+	}
+	tests := []struct {
+		request  string
+		behavior string
+		rule     string
+		layer    string
+		reason   string
+	}{
+		{"Bash(npm run build)", "allow", "Bash(npm run:*)", "project", ""},
+		{"Bash(npm run)", "allow", "Bash(npm run:*)", "project", ""},
+		{"Bash(npm runx)", "ask", "", "", "no rule matches"},
+		{"Bash(git push origin main)", "ask", "Bash(git push:*)", "project", "ask rule"},
+		{"Bash(rm -rf dist)", "deny", "Bash(rm *)", "project", ""},
+		{"Bash(rmdir dist)", "ask", "", "", ""},
+		{"Bash(npm run build && rm -rf /)", "deny", "Bash(rm *)", "project", `"rm -rf /" in the command chain`},
+		{"Bash(git status; npm run lint | tee out)", "ask", "", "", `no allow rule covers "tee out"`},
+		{"Bash(git status && npm run lint)", "allow", "Bash(git status)", "project", "every command"},
+		{"Bash(make build && make test)", "allow", "Bash(make build && make test)", "project", "allow rule in project settings"},
+		{"Bash(make build && make test && make lint)", "ask", "", "", "no rule matches"},
+		{"Bash(echo 'a && rm b')", "ask", "", "", "no rule matches"},
+		{"Read(.env)", "deny", "Read(.env)", "project", ""},
+		{"Read(packages/web/.env)", "deny", "Read(.env)", "project", ""},
+		{"Grep(./.env)", "deny", "Read(.env)", "project", ""},
+		{"Read(src/cmd/main.go)", "allow", "Read(./src/**)", "project", ""},
+		{"Read(~/notes/todo.md)", "allow", "Read(~/notes/*.md)", "user", ""},
+		{"Read(/etc/hosts)", "allow", "", "", "read-only"},
+		{"Write(config/app.yaml)", "deny", "Edit(/config/**)", "project", ""},
+		{"Edit(main.go)", "allow", "", "", "acceptEdits"},
+		{"mcp__github__create_issue", "allow", "mcp__github", "project", ""},
+		{"mcp__gitlab__create_issue", "ask", "", "", ""},
+		{"WebFetch(https://docs.example.com/api)", "allow", "WebFetch(domain:*.example.com)", "project", ""},
+		{"WebFetch(https://example.org)", "ask", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.request, func(t *testing.T) {
+			d, err := settings.Decide(tt.request)
+			require.NoError(t, err)
+			assert.Equal(t, tt.behavior, d.Behavior, d.Reason)
+			if tt.rule == "" {
+				assert.Nil(t, d.Rule)
+				assert.Equal(t, "acceptEdits", d.Mode)
+			} else if assert.NotNil(t, d.Rule) {
+				assert.Equal(t, tt.rule, d.Rule.Rule)
+				assert.Equal(t, tt.layer, d.Rule.Layer)
+			}
+			if tt.reason != "" {
+				assert.Contains(t, d.Reason, tt.reason)
+			}
+		})
+	}
+
+	d, err := settings.Decide("Bash(git push origin)")
+	require.NoError(t, err)
+	require.Len(t, d.Matches, 2, "the overridden allow in the local layer is listed")
+	assert.Equal(t, PermissionMatch{Behavior: "allow", Rule: "Bash(git push:*)", Layer: "local", File: "local.json"}, d.Matches[1])
+}
+
+func TestPermissionDecideModes(t *testing.T) {
+	tests := []struct {
+		mode      string
+		bypassOff bool
+		request   string
+		behavior  string
+	}{
+		{"", false, "Bash(make)", "ask"},
+		{"", false, "Glob(**/*.go)", "allow"},
+		{"default", false, "Edit(a.go)", "ask"},
+		{"plan", false, "Edit(a.go)", "deny"},
+		{"dontAsk", false, "Bash(make)", "deny"},
+		{"bypassPermissions", false, "Bash(make)", "allow"},
+		{"bypassPermissions", true, "Bash(make)", "ask"},
+	}
+	for _, tt := range tests {
+		settings := &PermissionSettings{Root: "/p", Mode: tt.mode, Layers: []PermissionLayer{{Name: "project", DisableBypass: tt.bypassOff}}}
+		d, err := settings.Decide(tt.request)
+		require.NoError(t, err)
+		assert.Equal(t, tt.behavior, d.Behavior, "%s in mode %q: %s", tt.request, tt.mode, d.Reason)
+	}
+}
+
+func TestPermissionManagedOnly(t *testing.T) {
+	settings := &PermissionSettings{Layers: []PermissionLayer{
+		{Name: "managed", ManagedOnly: true, Deny: []string{"WebFetch"}},
+		{Name: "project", Allow: []string{"Bash(make)"}},
+	}}
+	d, err := settings.Decide("Bash(make)")
+	require.NoError(t, err)
+	assert.Equal(t, "ask", d.Behavior, "project rules are ignored under allowManagedPermissionRulesOnly")
+}
+
+func TestParseToolUse(t *testing.T) {
+	tool, arg, err := parseToolUse(" Bash(npm run build) ")
+	require.NoError(t, err)
+	assert.Equal(t, "Bash", tool)
+	assert.Equal(t, "npm run build", arg)
+
+	tool, arg, err = parseToolUse("WebSearch")
+	require.NoError(t, err)
+	assert.Equal(t, "WebSearch", tool)
+	assert.Empty(t, arg)
+
+	for _, bad := range []string{"", "Bash(npm", "run build", "Bash(x) y"} {
+		_, _, err := parseToolUse(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestSplitBashCommand(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, splitBashCommand("a && b || c; d | e"))
+	assert.Equal(t, []string{`echo "x && y"`, "z"}, splitBashCommand(`echo "x && y" && z`))
+	assert.Equal(t, []string{`echo a\;b`}, splitBashCommand(`echo a\;b`))
+	assert.Equal(t, []string{"sleep 1 &"}, splitBashCommand("sleep 1 &"))
+}
+
+func TestLoadPermissionSettings(t *testing.T) {
+	dir := t.TempDir()
+	root, home := filepath.Join(dir, "project"), filepath.Join(dir, "home")
+	write := func(path, contents string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+	managed := filepath.Join(dir, "managed-settings.json")
+	write(managed, `{"permissions": {"deny": ["WebFetch"]}, "allowManagedPermissionRulesOnly": true}`)
+	write(filepath.Join(root, ".claude", "settings.json"), "{\n  // JSONC comments are allowed\n  \"permissions\": {\"allow\": [\"Bash(make)\", 3], \"defaultMode\": \"plan\"}\n}")
+	write(filepath.Join(home, ".claude", "settings.json"), `{"permissions": {"disableBypassPermissionsMode": "disable"}}`)
+
+	prev := managedSettingsPath
+	defer func() { managedSettingsPath = prev }()
+	managedSettingsPath = managed
+
+	settings, err := LoadPermissionSettings(root, home)
+	require.NoError(t, err)
+	require.Len(t, settings.Layers, 3)
+	assert.Equal(t, "managed", settings.Layers[0].Name)
+	assert.True(t, settings.Layers[0].ManagedOnly)
+	assert.Equal(t, []string{"Bash(make)"}, settings.Layers[1].Allow)
+	assert.Equal(t, "plan", settings.Layers[1].DefaultMode)
+	assert.True(t, settings.Layers[2].DisableBypass)
+
+	write(filepath.Join(root, ".claude", "settings.local.json"), `{"permissions": `)
+	_, err = LoadPermissionSettings(root, home)
+	assert.ErrorContains(t, err, "settings.local.json")
+}
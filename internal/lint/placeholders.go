@@ -0,0 +1,105 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// defaultTemplateVariables are $VARIABLES Claude Code substitutes in
+// component bodies, so they are never reported as unfilled.
+var defaultTemplateVariables = map[string]bool{
+	"ARGUMENTS":          true,
+	"CLAUDE_PROJECT_DIR": true,
+	"CLAUDE_PLUGIN_ROOT": true,
+	"CLAUDE_SESSION_ID":  true,
+}
+
+// templateVariableSet returns the built-in allowlist of intentional
+// template variables extended with names, from the rules.templateVariables
+// config key. Entries may be written bare (PROJECT), as $PROJECT, or as
+// {{project}}.
+func templateVariableSet(names []string) map[string]bool {
+	set := maps.Clone(defaultTemplateVariables)
+	for _, name := range names {
+		if name = normalizeTemplateVariable(name); name != "" {
+			set[name] = true
+		}
+	}
+	return set
+}
+
+// normalizeTemplateVariable strips the $ or {{ }} decoration from name.
+func normalizeTemplateVariable(name string) string {
+	name = strings.TrimSpace(name)
+	name = strings.TrimPrefix(name, "$")
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "{{"), "}}")
+	return strings.TrimSpace(name)
+}
+
+var (
+	// mustachePattern matches {{var}} and {{ var }} placeholders.
+	mustachePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+	// envVarPattern matches $VARIABLE and ${VARIABLE} (uppercase, so the
+	// positional $1..$9 arguments are not matched).
+	envVarPattern = regexp.MustCompile(`\$\{?([A-Z][A-Z0-9_]*)\}?`)
+
+	// angleHolderPattern matches scaffold placeholders such as <your-name>,
+	// <insert description>, <TODO>, or <PROJECT_NAME>, but not HTML tags.
+	angleHolderPattern = regexp.MustCompile(`<((?i:your|insert|replace|enter|fill|todo|tbd|placeholder)[^<>\n]*|[A-Z][A-Z0-9]*_[A-Z0-9_]+|TODO|TBD)>`)
+
+	// inlineCodeSpan matches `code` spans, which are skipped.
+	inlineCodeSpan = regexp.MustCompile("`[^`]*`")
+)
+
+// validateTemplatePlaceholders warns about scaffold placeholders left in a
+// component body: {{var}}, $VARIABLE, and <your-...>-style markers.
+// Fenced code, inline code, and !shell pre-execution lines are skipped
+// because shell variables are expected there, as are the built-in
+// variables and those in allowed, the rules.templateVariables config key.
+// Each placeholder is reported once.
+func validateTemplatePlaceholders(filePath, contents string, allowed []string) []cue.ValidationError {
+	templateVariables := templateVariableSet(allowed)
+	var out []cue.ValidationError
+	seen := make(map[string]bool)
+	report := func(line int, placeholder string) {
+		if seen[placeholder] {
+			return
+		}
+		seen[placeholder] = true
+		out = append(out, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("Unfilled template placeholder %s. Replace it, or add it to rules.templateVariables if it is intentional", placeholder),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleTemplatePlaceholder,
+			Line:     line,
+		})
+	}
+
+	withBodyLines(contents, func(lineNum int, trimmed string) {
+		if strings.HasPrefix(trimmed, "!") || strings.HasPrefix(trimmed, "```") {
+			return
+		}
+		text := inlineCodeSpan.ReplaceAllString(trimmed, "")
+
+		for _, m := range mustachePattern.FindAllStringSubmatch(text, -1) {
+			if !templateVariables[m[1]] {
+				report(lineNum, "{{"+m[1]+"}}")
+			}
+		}
+		for _, m := range envVarPattern.FindAllStringSubmatch(text, -1) {
+			if !templateVariables[m[1]] {
+				report(lineNum, "$"+m[1])
+			}
+		}
+		for _, m := range angleHolderPattern.FindAllString(text, -1) {
+			report(lineNum, m)
+		}
+	})
+	return out
+}
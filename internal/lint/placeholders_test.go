@@ -0,0 +1,70 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateTemplatePlaceholders(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []string // placeholder:line per finding, in order
+	}{
+		{
+			name:     "mustache variable",
+			contents: "---\nname: a\n---\nDeploy {{service_name}} to prod.\n",
+			want:     []string{"{{service_name}}:4"},
+		},
+		{
+			name:     "env-style variable",
+			contents: "---\nname: a\n---\nOpen a PR against $TARGET_BRANCH.\n",
+			want:     []string{"$TARGET_BRANCH:4"},
+		},
+		{
+			name:     "angle placeholders",
+			contents: "---\nname: a\n---\nOwner: <your-team>\nProject: <PROJECT_NAME>\nStatus: <TODO>\n",
+			want:     []string{"<your-team>:4", "<PROJECT_NAME>:5", "<TODO>:6"},
+		},
+		{
+			name:     "built-in variables and positional args",
+			contents: "---\nname: a\n---\nFix $ARGUMENTS in ${CLAUDE_PROJECT_DIR} using $1 and $2.\n",
+		},
+		{
+			name:     "html tags and argument docs are not placeholders",
+			contents: "---\nname: a\n---\n<details><summary>More</summary></details>\nUsage: /fix <file>\n",
+		},
+		{
+			name:     "code is skipped",
+			contents: "---\nname: a\n---\nRun `echo $HOME`.\n```bash\nexport FOO={{bar}}\n```\n!git log $BRANCH\n",
+		},
+		{
+			name:     "reported once",
+			contents: "---\nname: a\n---\n{{x}} and {{ x }}\n{{x}}\n",
+			want:     []string{"{{x}}:4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range validateTemplatePlaceholders("commands/a.md", tt.contents, nil) {
+				placeholder := strings.Fields(strings.TrimPrefix(e.Message, "Unfilled template placeholder "))[0]
+				got = append(got, fmt.Sprintf("%s:%d", strings.TrimSuffix(placeholder, "."), e.Line))
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("findings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTemplatePlaceholders_Allowlist(t *testing.T) {
+	allowed := []string{"$TARGET_BRANCH", "{{ service }}", "REGION"}
+
+	contents := "---\nname: a\n---\n{{service}} in $REGION on $TARGET_BRANCH via $ARGUMENTS\n"
+	if got := validateTemplatePlaceholders("commands/a.md", contents, allowed); len(got) != 0 {
+		t.Errorf("allowlisted variables reported: %v", got)
+	}
+}
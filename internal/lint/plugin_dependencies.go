@@ -0,0 +1,444 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// claudeCodeEngine is the engines key holding the Claude Code versions a
+// plugin supports.
+const claudeCodeEngine = "claude-code"
+
+// pluginDependency is one entry of a plugin's dependencies: a plugin name,
+// optionally with a version range.
+type pluginDependency struct {
+	Name    string
+	Version string // the range as written, "" for any version
+	Index   int
+}
+
+// parsePluginDependencies reads the dependencies array: plugin names, or
+// objects with a name and an optional version range. Malformed entries are
+// left out; validatePluginDependencies reports them.
+func parsePluginDependencies(data map[string]any) []pluginDependency {
+	entries, _ := data["dependencies"].([]any)
+	var deps []pluginDependency
+	for i, e := range entries {
+		switch v := e.(type) {
+		case string:
+			if v != "" {
+				deps = append(deps, pluginDependency{Name: v, Index: i})
+			}
+		case map[string]any:
+			name, _ := v["name"].(string)
+			version, _ := v["version"].(string)
+			if name != "" {
+				deps = append(deps, pluginDependency{Name: name, Version: version, Index: i})
+			}
+		}
+	}
+	return deps
+}
+
+// validatePluginDependencies checks the shape of dependencies and engines
+// and the syntax of their version ranges. Whether dependencies resolve is
+// checked across plugins by PostProcessBatch.
+func validatePluginDependencies(data map[string]any, filePath, contents string) []cue.ValidationError {
+	var errors []cue.ValidationError
+	issue := func(field, rule, severity, pointer, msg string) {
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  msg,
+			Severity: severity,
+			Source:   cue.SourceCClintObserve,
+			Rule:     rule,
+			Line:     FindJSONFieldLine(contents, field),
+			Pointer:  pointer,
+		})
+	}
+
+	if raw, ok := data["dependencies"]; ok {
+		entries, isArray := raw.([]any)
+		if !isArray {
+			issue("dependencies", cue.RuleFieldInvalid, cue.SeverityError, "/dependencies", "'dependencies' must be an array of plugin names or {\"name\", \"version\"} objects")
+		}
+		self, _ := data["name"].(string)
+		seen := make(map[string]int)
+		for i, e := range entries {
+			pointer := textutil.JSONPointer("dependencies", i)
+			var name, version string
+			switch v := e.(type) {
+			case string:
+				name = v
+			case map[string]any:
+				name, _ = v["name"].(string)
+				if rng, ok := v["version"]; ok {
+					if version, ok = rng.(string); !ok {
+						issue("dependencies", cue.RulePluginVersionConstraint, cue.SeverityError, pointer+"/version", fmt.Sprintf("dependencies[%d]: 'version' must be a version range string", i))
+					}
+				}
+			}
+			if name == "" {
+				issue("dependencies", cue.RuleFieldInvalid, cue.SeverityError, pointer, fmt.Sprintf("dependencies[%d]: each entry must be a plugin name or an object with a 'name'", i))
+				continue
+			}
+			if name == self {
+				issue("dependencies", cue.RulePluginDependencyCycle, cue.SeverityError, pointer, fmt.Sprintf("dependencies[%d]: plugin '%s' depends on itself", i, name))
+			}
+			if first, dup := seen[name]; dup {
+				issue("dependencies", cue.RuleFieldInvalid, cue.SeverityWarning, pointer, fmt.Sprintf("dependencies[%d]: '%s' repeats entry %d", i, name, first))
+			}
+			seen[name] = i
+			if version != "" {
+				if msg := checkVersionRange(version); msg != "" {
+					issue("dependencies", cue.RulePluginVersionConstraint, cue.SeverityError, pointer+"/version", fmt.Sprintf("dependencies[%d]: %s", i, msg))
+				}
+			}
+		}
+	}
+
+	if raw, ok := data["engines"]; ok {
+		engines, isObject := raw.(map[string]any)
+		if !isObject {
+			issue("engines", cue.RuleFieldInvalid, cue.SeverityError, "/engines", fmt.Sprintf("'engines' must be an object such as {\"%s\": \">=2.1.0\"}", claudeCodeEngine))
+		}
+		for _, key := range slices.Sorted(maps.Keys(engines)) {
+			pointer := textutil.JSONPointer("engines", key)
+			rng, isString := engines[key].(string)
+			switch {
+			case key != claudeCodeEngine:
+				issue("engines", cue.RuleFieldInvalid, cue.SeverityWarning, pointer, fmt.Sprintf("engines: unknown engine '%s'; Claude Code reads only '%s'", key, claudeCodeEngine))
+			case !isString:
+				issue("engines", cue.RulePluginVersionConstraint, cue.SeverityError, pointer, fmt.Sprintf("engines.%s must be a version range string", key))
+			default:
+				if msg := checkVersionRange(rng); msg != "" {
+					issue("engines", cue.RulePluginVersionConstraint, cue.SeverityError, pointer, fmt.Sprintf("engines.%s: %s", key, msg))
+				}
+			}
+		}
+	}
+	return errors
+}
+
+// checkVersionRange describes what is wrong with a version range, or
+// returns "" when it is valid and some version satisfies it.
+func checkVersionRange(rng string) string {
+	r, err := textutil.ParseVersionRange(rng)
+	if err != nil {
+		return err.Error() + "; use npm range syntax such as ^1.2.0 or >=2.1.0 <3.0.0"
+	}
+	if !r.Satisfiable() {
+		return fmt.Sprintf("no version satisfies the range %q", rng)
+	}
+	return ""
+}
+
+// pluginNode is a plugin manifest in dependency resolution.
+type pluginNode struct {
+	Name     string
+	Version  string
+	RelPath  string // "" for plugins only listed in a marketplace
+	Scope    string // the marketplace directory the plugin belongs to, or "" for none
+	Deps     []pluginDependency
+	Engine   string
+	Contents string
+}
+
+// findPluginDependencyIssues resolves each plugin's dependencies among the
+// plugins of its marketplace: the nearest directory above it with a
+// .claude-plugin/marketplace.json, whose listed plugins count as well as the
+// manifests found on disk. Plugins outside any marketplace resolve among
+// each other. A dependency that is missing, whose version is outside the
+// required range, or whose Claude Code range excludes every version the
+// dependent supports is reported, as is every dependency cycle, on each
+// plugin in it. Marketplace and cache plugins are not checked.
+func findPluginDependencyIssues(files []discovery.File, rootPath string) map[string][]cue.ValidationError {
+	nodes := collectPluginNodes(files, rootPath)
+	byScope := make(map[string]map[string]*pluginNode)
+	for _, n := range nodes {
+		if byScope[n.Scope] == nil {
+			byScope[n.Scope] = make(map[string]*pluginNode)
+		}
+		if _, dup := byScope[n.Scope][n.Name]; !dup || n.RelPath != "" {
+			byScope[n.Scope][n.Name] = n
+		}
+	}
+
+	issues := make(map[string][]cue.ValidationError)
+	add := func(n *pluginNode, dep pluginDependency, rule, severity, msg string) {
+		issues[n.RelPath] = append(issues[n.RelPath], cue.ValidationError{
+			File:     n.RelPath,
+			Message:  msg,
+			Severity: severity,
+			Source:   cue.SourceCClintObserve,
+			Rule:     rule,
+			Line:     FindJSONFieldLine(n.Contents, "dependencies"),
+			Pointer:  textutil.JSONPointer("dependencies", dep.Index),
+		})
+	}
+	for _, n := range nodes {
+		if n.RelPath == "" || isExternalPlugin(n.RelPath) {
+			continue
+		}
+		where := "this project"
+		if n.Scope != "" {
+			where = "marketplace " + displayScope(rootPath, n.Scope)
+		}
+		for _, dep := range n.Deps {
+			target, ok := byScope[n.Scope][dep.Name]
+			if !ok {
+				add(n, dep, cue.RulePluginDependencyMissing, cue.SeverityError,
+					fmt.Sprintf("dependencies[%d]: plugin '%s' is not in %s", dep.Index, dep.Name, where))
+				continue
+			}
+			if msg := dependencyVersionIssue(dep, target); msg != "" {
+				add(n, dep, cue.RulePluginDependencyVersion, cue.SeverityError, fmt.Sprintf("dependencies[%d]: %s", dep.Index, msg))
+			} else if msg := engineConflict(n, target); msg != "" {
+				add(n, dep, cue.RulePluginDependencyVersion, cue.SeverityWarning, fmt.Sprintf("dependencies[%d]: %s", dep.Index, msg))
+			}
+		}
+	}
+
+	for _, scope := range slices.Sorted(maps.Keys(byScope)) {
+		for _, cycle := range pluginDependencyCycles(byScope[scope]) {
+			desc := strings.Join(cycle, " → ")
+			for _, name := range cycle[:len(cycle)-1] {
+				n := byScope[scope][name]
+				if n.RelPath == "" || isExternalPlugin(n.RelPath) {
+					continue
+				}
+				next := cycle[slices.Index(cycle, name)+1]
+				for _, dep := range n.Deps {
+					if dep.Name == next {
+						add(n, dep, cue.RulePluginDependencyCycle, cue.SeverityError, "Plugin dependency cycle: "+desc)
+						break
+					}
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// dependencyVersionIssue describes why target does not satisfy dep's
+// version range, or returns "". Ranges that do not parse are reported by
+// validatePluginDependencies.
+func dependencyVersionIssue(dep pluginDependency, target *pluginNode) string {
+	if dep.Version == "" {
+		return ""
+	}
+	rng, err := textutil.ParseVersionRange(dep.Version)
+	if err != nil {
+		return ""
+	}
+	if target.Version == "" {
+		return fmt.Sprintf("plugin '%s' declares no version, so the range %q cannot be satisfied", dep.Name, dep.Version)
+	}
+	v, err := textutil.ParseVersion(target.Version)
+	if err != nil {
+		return ""
+	}
+	if !rng.Contains(v) {
+		return fmt.Sprintf("plugin '%s' is version %s, outside the required range %q", dep.Name, target.Version, dep.Version)
+	}
+	return ""
+}
+
+// engineConflict describes a dependency whose Claude Code range shares no
+// version with the dependent's, so the two can never load together.
+func engineConflict(n, target *pluginNode) string {
+	if n.Engine == "" || target.Engine == "" {
+		return ""
+	}
+	a, errA := textutil.ParseVersionRange(n.Engine)
+	b, errB := textutil.ParseVersionRange(target.Engine)
+	if errA != nil || errB != nil || a.Intersects(b) {
+		return ""
+	}
+	return fmt.Sprintf("plugin '%s' requires Claude Code %q, which no version in this plugin's range %q satisfies", target.Name, target.Engine, n.Engine)
+}
+
+// pluginDependencyCycles returns each dependency cycle among plugins once,
+// as names with the first repeated at the end, starting from its
+// alphabetically first plugin.
+func pluginDependencyCycles(plugins map[string]*pluginNode) [][]string {
+	const (
+		white = iota
+		gray
+		black
+	)
+	color := make(map[string]int)
+	var cycles [][]string
+	seen := make(map[string]bool)
+	var path []string
+	var visit func(name string)
+	visit = func(name string) {
+		color[name] = gray
+		path = append(path, name)
+		for _, dep := range plugins[name].Deps {
+			if _, ok := plugins[dep.Name]; !ok || dep.Name == name {
+				continue
+			}
+			switch color[dep.Name] {
+			case white:
+				visit(dep.Name)
+			case gray:
+				start := slices.Index(path, dep.Name)
+				cycle := rotateCycle(path[start:])
+				if key := strings.Join(cycle, "\x00"); !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, append(cycle, cycle[0]))
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		color[name] = black
+	}
+	for _, name := range slices.Sorted(maps.Keys(plugins)) {
+		if color[name] == white {
+			visit(name)
+		}
+	}
+	return cycles
+}
+
+// rotateCycle starts a cycle at its alphabetically first member.
+func rotateCycle(cycle []string) []string {
+	first := slices.Index(cycle, slices.Min(cycle))
+	return slices.Concat(cycle[first:], cycle[:first])
+}
+
+// collectPluginNodes reads the plugin manifests among files and the
+// plugins listed in the marketplaces they belong to.
+func collectPluginNodes(files []discovery.File, rootPath string) []*pluginNode {
+	var nodes []*pluginNode
+	marketplaces := make(map[string]bool)
+	for _, f := range files {
+		if f.Type != discovery.FileTypePlugin {
+			continue
+		}
+		contents, err := f.Load()
+		if err != nil {
+			continue
+		}
+		var data map[string]any
+		if json.Unmarshal([]byte(contents), &data) != nil {
+			continue
+		}
+		name, _ := data["name"].(string)
+		if name == "" {
+			continue
+		}
+		path := f.Path
+		if path == "" {
+			path = filepath.Join(rootPath, f.RelPath)
+		}
+		n := &pluginNode{
+			Name:     name,
+			RelPath:  f.RelPath,
+			Scope:    marketplaceOf(filepath.Dir(filepath.Dir(path))),
+			Deps:     parsePluginDependencies(data),
+			Contents: contents,
+		}
+		n.Version, _ = data["version"].(string)
+		if engines, ok := data["engines"].(map[string]any); ok {
+			n.Engine, _ = engines[claudeCodeEngine].(string)
+		}
+		nodes = append(nodes, n)
+		if n.Scope != "" {
+			marketplaces[n.Scope] = true
+		}
+	}
+	for _, dir := range slices.Sorted(maps.Keys(marketplaces)) {
+		nodes = append(nodes, marketplacePlugins(dir)...)
+	}
+	return nodes
+}
+
+// marketplaceOf returns the nearest directory at or above pluginRoot that
+// holds .claude-plugin/marketplace.json, or "".
+func marketplaceOf(pluginRoot string) string {
+	for dir := pluginRoot; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".claude-plugin", "marketplace.json")); err == nil {
+			return dir
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return ""
+		}
+	}
+}
+
+// marketplacePlugins returns the plugins a marketplace.json lists, with
+// their declared versions.
+func marketplacePlugins(dir string) []*pluginNode {
+	raw, err := os.ReadFile(filepath.Join(dir, ".claude-plugin", "marketplace.json")) //nolint:gosec // G304: marketplace of a linted plugin
+	if err != nil {
+		return nil
+	}
+	var marketplace struct {
+		Plugins []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"plugins"`
+	}
+	if json.Unmarshal(raw, &marketplace) != nil {
+		return nil
+	}
+	var nodes []*pluginNode
+	for _, p := range marketplace.Plugins {
+		if p.Name != "" {
+			nodes = append(nodes, &pluginNode{Name: p.Name, Version: p.Version, Scope: dir})
+		}
+	}
+	return nodes
+}
+
+// displayScope names a marketplace directory relative to the project root.
+func displayScope(rootPath, dir string) string {
+	if rel, err := filepath.Rel(rootPath, dir); err == nil && !strings.HasPrefix(rel, "..") {
+		if rel == "." {
+			return "at the project root"
+		}
+		return filepath.ToSlash(rel)
+	}
+	return dir
+}
+
+// addIssuesToSummary appends issues to the result for file by severity,
+// keeping the summary's counts in step.
+func addIssuesToSummary(summary *LintSummary, file string, issues []cue.ValidationError) {
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		if result.File != file {
+			continue
+		}
+		for _, issue := range issues {
+			switch issue.Severity {
+			case cue.SeverityError:
+				result.Errors = append(result.Errors, issue)
+				summary.TotalErrors++
+				if result.Success {
+					result.Success = false
+					summary.SuccessfulFiles--
+					summary.FailedFiles++
+				}
+			case cue.SeverityWarning:
+				result.Warnings = append(result.Warnings, issue)
+				summary.TotalWarnings++
+			default:
+				result.Suggestions = append(result.Suggestions, issue)
+				summary.TotalSuggestions++
+			}
+		}
+		return
+	}
+}
@@ -0,0 +1,178 @@
+package lint
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidatePluginDependencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifest  string
+		wantRules []string // rules of the expected issues, "" for unruled ones
+	}{
+		{
+			name:     "no dependencies",
+			manifest: `{"name": "a"}`,
+		},
+		{
+			name:     "names and ranges",
+			manifest: `{"name": "a", "dependencies": ["b", {"name": "c", "version": "^1.2.0"}], "engines": {"claude-code": ">=2.1.0"}}`,
+		},
+		{
+			name:      "dependencies not an array",
+			manifest:  `{"name": "a", "dependencies": {"b": "^1.0.0"}}`,
+			wantRules: []string{cue.RuleFieldInvalid},
+		},
+		{
+			name:      "entry without a name",
+			manifest:  `{"name": "a", "dependencies": [{"version": "^1.0.0"}]}`,
+			wantRules: []string{cue.RuleFieldInvalid},
+		},
+		{
+			name:      "invalid range",
+			manifest:  `{"name": "a", "dependencies": [{"name": "b", "version": "latest"}]}`,
+			wantRules: []string{cue.RulePluginVersionConstraint},
+		},
+		{
+			name:      "unsatisfiable range",
+			manifest:  `{"name": "a", "dependencies": [{"name": "b", "version": ">=2.0.0 <1.0.0"}]}`,
+			wantRules: []string{cue.RulePluginVersionConstraint},
+		},
+		{
+			name:      "depends on itself",
+			manifest:  `{"name": "a", "dependencies": ["a"]}`,
+			wantRules: []string{cue.RulePluginDependencyCycle},
+		},
+		{
+			name:      "duplicate entry",
+			manifest:  `{"name": "a", "dependencies": ["b", {"name": "b"}]}`,
+			wantRules: []string{cue.RuleFieldInvalid},
+		},
+		{
+			name:      "invalid engine range",
+			manifest:  `{"name": "a", "engines": {"claude-code": "2.x.y"}}`,
+			wantRules: []string{cue.RulePluginVersionConstraint},
+		},
+		{
+			name:      "unknown engine",
+			manifest:  `{"name": "a", "engines": {"node": ">=20"}}`,
+			wantRules: []string{cue.RuleFieldInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data map[string]any
+			if err := json.Unmarshal([]byte(tt.manifest), &data); err != nil {
+				t.Fatal(err)
+			}
+			errs := validatePluginDependencies(data, ".claude-plugin/plugin.json", tt.manifest)
+			var rules []string
+			for _, e := range errs {
+				rules = append(rules, e.Rule)
+			}
+			if !slices.Equal(rules, tt.wantRules) {
+				t.Errorf("rules = %q, want %q (issues: %v)", rules, tt.wantRules, errs)
+			}
+		})
+	}
+}
+
+// writePluginTree writes files under a temp root and returns the root with
+// the plugin manifests among them as discovered files.
+func writePluginTree(t *testing.T, files map[string]string) (string, []discovery.File) {
+	t.Helper()
+	root := t.TempDir()
+	var plugins []discovery.File
+	for rel, contents := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if strings.HasSuffix(rel, ".claude-plugin/plugin.json") {
+			plugins = append(plugins, discovery.File{Path: path, RelPath: rel, Type: discovery.FileTypePlugin, Contents: contents})
+		}
+	}
+	return root, plugins
+}
+
+func TestFindPluginDependencyIssues(t *testing.T) {
+	root, files := writePluginTree(t, map[string]string{
+		".claude-plugin/marketplace.json":           `{"name": "m", "plugins": [{"name": "listed", "version": "3.0.0"}]}`,
+		"plugins/app/.claude-plugin/plugin.json":    `{"name": "app", "engines": {"claude-code": "<2.0.0"}, "dependencies": ["core", {"name": "util", "version": "^2.0.0"}, "listed", "ghost", {"name": "bare", "version": "^1.0.0"}, {"name": "modern"}]}`,
+		"plugins/core/.claude-plugin/plugin.json":   `{"name": "core", "version": "1.0.0"}`,
+		"plugins/util/.claude-plugin/plugin.json":   `{"name": "util", "version": "1.4.0"}`,
+		"plugins/bare/.claude-plugin/plugin.json":   `{"name": "bare"}`,
+		"plugins/modern/.claude-plugin/plugin.json": `{"name": "modern", "version": "1.0.0", "engines": {"claude-code": ">=2.1.0"}}`,
+	})
+
+	issues := findPluginDependencyIssues(files, root)
+	got := issues["plugins/app/.claude-plugin/plugin.json"]
+	want := []struct{ rule, severity, text string }{
+		{cue.RulePluginDependencyVersion, cue.SeverityError, "'util' is version 1.4.0"},
+		{cue.RulePluginDependencyMissing, cue.SeverityError, "'ghost' is not in marketplace at the project root"},
+		{cue.RulePluginDependencyVersion, cue.SeverityError, "'bare' declares no version"},
+		{cue.RulePluginDependencyVersion, cue.SeverityWarning, "'modern' requires Claude Code"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d issues, want %d: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Rule != w.rule || got[i].Severity != w.severity || !strings.Contains(got[i].Message, w.text) {
+			t.Errorf("issue %d = %s %s %q, want %s %s containing %q", i, got[i].Rule, got[i].Severity, got[i].Message, w.rule, w.severity, w.text)
+		}
+	}
+	if len(issues) != 1 {
+		t.Errorf("issues reported on %d files, want only the dependent: %v", len(issues), issues)
+	}
+}
+
+func TestFindPluginDependencyCycles(t *testing.T) {
+	root, files := writePluginTree(t, map[string]string{
+		"a/.claude-plugin/plugin.json": `{"name": "a", "dependencies": ["b"]}`,
+		"b/.claude-plugin/plugin.json": `{"name": "b", "dependencies": ["c", "d"]}`,
+		"c/.claude-plugin/plugin.json": `{"name": "c", "dependencies": ["a"]}`,
+		"d/.claude-plugin/plugin.json": `{"name": "d"}`,
+	})
+
+	issues := findPluginDependencyIssues(files, root)
+	for _, rel := range []string{"a", "b", "c"} {
+		got := issues[rel+"/.claude-plugin/plugin.json"]
+		if len(got) != 1 || got[0].Rule != cue.RulePluginDependencyCycle {
+			t.Fatalf("%s: issues = %v, want one cycle", rel, got)
+		}
+		if !strings.Contains(got[0].Message, "a → b → c → a") {
+			t.Errorf("%s: message = %q", rel, got[0].Message)
+		}
+	}
+	if got := issues["d/.claude-plugin/plugin.json"]; len(got) != 0 {
+		t.Errorf("d: issues = %v, want none", got)
+	}
+}
+
+func TestPluginLinterPostProcessBatch(t *testing.T) {
+	root, files := writePluginTree(t, map[string]string{
+		"a/.claude-plugin/plugin.json": `{"name": "a", "dependencies": ["missing"]}`,
+	})
+	summary := &LintSummary{
+		TotalFiles:      1,
+		SuccessfulFiles: 1,
+		Results:         []LintResult{{File: "a/.claude-plugin/plugin.json", Success: true}},
+	}
+
+	NewPluginLinter(root, nil).PostProcessBatch(&LinterContext{RootPath: root, Files: files}, summary)
+
+	if summary.TotalErrors != 1 || summary.FailedFiles != 1 || summary.SuccessfulFiles != 0 || summary.Results[0].Success {
+		t.Errorf("summary = %+v, want one failed file with one error", summary)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 )
 
@@ -31,10 +32,12 @@ var knownPluginFields = map[string]bool{
 	"themes":         true, // Optional: color theme components (deprecated top-level v2.1.129+ - prefer experimental.themes)
 	"experimental":   true, // Optional: wrapper for experimental components (themes, monitors) (v2.1.129+)
 	"defaultEnabled": true, // Optional: set false to disable plugin by default (v2.1.154+)
+	"dependencies":   true, // Optional: plugins this plugin requires, with version ranges
+	"engines":        true, // Optional: supported Claude Code versions ("claude-code" range)
 }
 
-func validateUnknownPluginFields(data map[string]any, filePath, contents string) []cue.ValidationError {
-	return checkUnknownFields(data, filePath, contents, unknownFieldCheck{
+func validateUnknownPluginFields(cfg *config.Config, data map[string]any, filePath, contents string) []cue.ValidationError {
+	return checkUnknownFields(cfg, data, filePath, contents, unknownFieldCheck{
 		known:    knownPluginFields,
 		label:    "plugin field",
 		suffix:   "",
@@ -50,6 +53,7 @@ func validatePluginName(data map[string]any, filePath, contents string) []cue.Va
 			Message:  "Required field 'name' is missing or empty",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldRequired,
 			Line:     FindJSONFieldLine(contents, "name"),
 		}}
 	}
@@ -61,6 +65,7 @@ func validatePluginName(data map[string]any, filePath, contents string) []cue.Va
 			Message:  fmt.Sprintf("Name '%s' is a reserved word and cannot be used", name),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleNameReserved,
 			Line:     FindJSONFieldLine(contents, "name"),
 		})
 	}
@@ -71,6 +76,7 @@ func validatePluginName(data map[string]any, filePath, contents string) []cue.Va
 			Message:  fmt.Sprintf("Name exceeds 64 character limit (%d chars)", len(name)),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldTooLong,
 			Line:     FindJSONFieldLine(contents, "name"),
 		})
 	}
@@ -86,6 +92,7 @@ func validatePluginDescription(data map[string]any, filePath, contents string) [
 			Message:  "Required field 'description' is missing or empty",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldRequired,
 			Line:     FindJSONFieldLine(contents, "description"),
 		}}
 	}
@@ -96,6 +103,7 @@ func validatePluginDescription(data map[string]any, filePath, contents string) [
 			Message:  fmt.Sprintf("Description exceeds 1024 character limit (%d chars)", len(desc)),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldTooLong,
 			Line:     FindJSONFieldLine(contents, "description"),
 		}}
 	}
@@ -111,6 +119,7 @@ func validatePluginVersion(data map[string]any, filePath, contents string) []cue
 			Message:  "Consider adding 'version' field in semver format (e.g., 1.0.0)",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleVersionFormat,
 			Line:     FindJSONFieldLine(contents, "version"),
 		}}
 	}
@@ -135,6 +144,7 @@ func validatePluginAuthor(data map[string]any, filePath, contents string) []cue.
 			Message:  "Required field 'author' is missing",
 			Severity: severity,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldRequired,
 			Line:     FindJSONFieldLine(contents, "author"),
 		}}
 	}
@@ -145,6 +155,7 @@ func validatePluginAuthor(data map[string]any, filePath, contents string) []cue.
 			Message:  "Required field 'author.name' is missing or empty",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldRequired,
 			Line:     FindJSONFieldLine(contents, "name"),
 		}}
 	}
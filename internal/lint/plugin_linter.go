@@ -1,6 +1,9 @@
 package lint
 
 import (
+	"slices"
+
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
 	"github.com/dotcommander/cclint/internal/scoring"
@@ -18,16 +21,18 @@ type PluginLinter struct {
 
 // Compile-time interface compliance checks
 var (
-	_ ComponentLinter = (*PluginLinter)(nil)
-	_ Scorable        = (*PluginLinter)(nil)
-	_ Improvable      = (*PluginLinter)(nil)
+	_ ComponentLinter    = (*PluginLinter)(nil)
+	_ Scorable           = (*PluginLinter)(nil)
+	_ Improvable         = (*PluginLinter)(nil)
+	_ BatchPostProcessor = (*PluginLinter)(nil)
 )
 
 // NewPluginLinter creates a new PluginLinter.
 // rootPath is the project root for resolving relative paths in plugin manifests.
 // Pass empty string to skip path existence validation.
-func NewPluginLinter(rootPath string) *PluginLinter {
-	return &PluginLinter{RootPath: rootPath}
+// cfg supplies the rule settings; nil uses the defaults.
+func NewPluginLinter(rootPath string, cfg *config.Config) *PluginLinter {
+	return &PluginLinter{BaseLinter: BaseLinter{cfg: cfg}, RootPath: rootPath}
 }
 
 func (l *PluginLinter) Type() string {
@@ -48,11 +53,27 @@ func (l *PluginLinter) ValidateCUE(validator *cue.Validator, data map[string]any
 }
 
 func (l *PluginLinter) ValidateSpecific(data map[string]any, filePath, contents string) []cue.ValidationError {
-	errors := validatePluginSpecific(data, filePath, contents)
+	errors := validatePluginSpecific(l.Config(), data, filePath, contents)
 	errors = append(errors, validatePluginPathsExist(data, l.RootPath, filePath, contents)...)
+	if l.Config().Untrusted {
+		errors = append(errors, validatePluginHooks(l.Config(), data, l.RootPath, filePath, contents)...)
+	}
 	return errors
 }
 
+// PostProcessBatch implements BatchPostProcessor for plugin dependency
+// resolution, version compatibility, and cycle detection.
+func (l *PluginLinter) PostProcessBatch(ctx *LinterContext, summary *LintSummary) {
+	for relPath, issues := range findPluginDependencyIssues(ctx.Files, ctx.RootPath) {
+		if ctx.NoCycleCheck {
+			issues = slices.DeleteFunc(issues, func(e cue.ValidationError) bool {
+				return e.Rule == cue.RulePluginDependencyCycle
+			})
+		}
+		addIssuesToSummary(summary, relPath, issues)
+	}
+}
+
 // Score implements Scorable interface
 func (l *PluginLinter) Score(contents string, data map[string]any, body string) *scoring.QualityScore {
 	scorer := scoring.NewPluginScorer()
@@ -88,6 +88,7 @@ func checkPath(path string, field string, filePath string, contents string) []cu
 			Message:  fmt.Sprintf("Plugin paths must be relative (start with \"./\"): found \"%s\"", path),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RulePluginPathNotRelative,
 			Line:     FindJSONFieldLine(contents, field),
 		})
 	}
@@ -98,6 +99,7 @@ func checkPath(path string, field string, filePath string, contents string) []cu
 			Message:  fmt.Sprintf("Path '%s' in '%s' contains '..', which risks traversal outside the plugin root", path, field),
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RulePluginPathTraversal,
 			Line:     FindJSONFieldLine(contents, field),
 		})
 	}
@@ -152,6 +154,7 @@ func validatePluginPathsExist(data map[string]any, rootPath, filePath, contents
 					Message:  fmt.Sprintf("Path '%s' in '%s' does not exist relative to plugin directory", p, field),
 					Severity: cue.SeverityWarning,
 					Source:   cue.SourceCClintObserve,
+					Rule:     cue.RulePluginPathMissing,
 					Line:     FindJSONFieldLine(contents, field),
 				})
 			}
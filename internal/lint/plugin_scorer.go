@@ -62,6 +62,7 @@ func validatePluginBestPractices(filePath string, contents string, data map[stri
 			Message:  "Consider " + strings.ToLower(gap.Improvement[:1]) + gap.Improvement[1:],
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RulePluginMetadataMissing,
 		})
 	}
 
@@ -73,6 +74,7 @@ func validatePluginBestPractices(filePath string, contents string, data map[stri
 				Message:  fmt.Sprintf("Top-level '%s' is deprecated - move under 'experimental.%s' (v2.1.129+; top-level still works but `claude plugin validate` warns)", field, field),
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RulePluginFieldDeprecated,
 				Line:     FindJSONFieldLine(contents, field),
 			})
 		}
@@ -85,6 +87,7 @@ func validatePluginBestPractices(filePath string, contents string, data map[stri
 			Message:  fmt.Sprintf("Description is only %d chars - consider expanding for clarity", len(desc)),
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleDescriptionTooShort,
 			Line:     FindJSONFieldLine(contents, "description"),
 		})
 	}
@@ -9,7 +9,7 @@ import (
 
 func TestLintPlugins(t *testing.T) {
 	// Test with empty directory
-	summary, err := LintPlugins("testdata/empty", false, false, true, nil)
+	summary, err := LintPlugins(testOptions("testdata/empty"))
 	if err != nil {
 		t.Fatalf("LintPlugins() error = %v", err)
 	}
@@ -310,7 +310,7 @@ func TestValidatePluginSpecific(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			allIssues := validatePluginSpecific(tt.data, tt.filePath, tt.contents)
+			allIssues := validatePluginSpecific(defaultConfig(), tt.data, tt.filePath, tt.contents)
 
 			// Count only errors and warnings (not suggestions)
 			errorCount := 0
@@ -611,7 +611,7 @@ func TestSchemaFieldNoUnknownSuggestion(t *testing.T) {
 		"version": "1.0.0",
 		"author":  map[string]any{"name": "Test Author"},
 	}
-	issues := validatePluginSpecific(data, "plugin.json", `{"$schema":"https://example.com/plugin-schema.json","name":"test-plugin"}`)
+	issues := validatePluginSpecific(defaultConfig(), data, "plugin.json", `{"$schema":"https://example.com/plugin-schema.json","name":"test-plugin"}`)
 	for _, issue := range issues {
 		if issue.Severity == "suggestion" && strings.Contains(issue.Message, "$schema") {
 			t.Errorf("unexpected suggestion for $schema field: %s", issue.Message)
@@ -0,0 +1,46 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// resolvePointerLines gives issues that carry a JSON Pointer but no line
+// the line and column of the value it points at, for JSON files such as
+// settings.json. A pointer to a value the file lacks, such as a missing
+// required field, resolves to its nearest enclosing value. Issues in files
+// that are not JSON keep their pointer and stay unplaced.
+func resolvePointerLines(result *LintResult, contents string) {
+	var positions map[string]textutil.JSONPosition
+	parsed := false
+	for _, issues := range [][]cue.ValidationError{result.Errors, result.Warnings, result.Suggestions} {
+		for i := range issues {
+			if issues[i].Pointer == "" || issues[i].Line != 0 {
+				continue
+			}
+			if !parsed {
+				blanked, _ := textutil.BlankJSONC(contents)
+				positions, _ = textutil.JSONPositions(blanked)
+				parsed = true
+			}
+			if pos, ok := pointerPosition(positions, issues[i].Pointer); ok {
+				issues[i].Line, issues[i].Column = pos.Line, pos.Column
+			}
+		}
+	}
+}
+
+// pointerPosition looks pointer up in positions, trimming reference tokens
+// until a value that exists is found. The whole document is not a match:
+// line 1 says nothing about where the problem is.
+func pointerPosition(positions map[string]textutil.JSONPosition, pointer string) (textutil.JSONPosition, bool) {
+	for pointer != "" {
+		if pos, ok := positions[pointer]; ok {
+			return pos, true
+		}
+		pointer = pointer[:strings.LastIndexByte(pointer, '/')]
+	}
+	return textutil.JSONPosition{}, false
+}
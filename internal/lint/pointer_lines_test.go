@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+func TestLintSettings_PointerLines(t *testing.T) {
+	contents := `{
+  "hooks": {
+    "PreToolUse": [
+      {
+        "matcher": "Bash",
+        "hooks": [
+          {"type": "command", "command": "echo ok"},
+          {
+            "type": "command",
+            "command": "cat ../../secrets",
+            "timeout": -1
+          }
+        ]
+      }
+    ]
+  },
+  "rules": ["src/**", ""]
+}
+`
+	validator := cue.NewValidator()
+	if err := validator.LoadSchemas(""); err != nil {
+		t.Fatalf("LoadSchemas() error = %v", err)
+	}
+	result := lintFileCore(".claude/settings.json", contents, NewSettingsLinter("", nil), validator, nil)
+
+	want := map[string]int{
+		"/hooks/PreToolUse/0/hooks/1/command": 10,
+		"/hooks/PreToolUse/0/hooks/1/timeout": 11,
+		"/rules/1":                            17,
+	}
+	found := make(map[string]bool)
+	for _, issues := range [][]cue.ValidationError{result.Errors, result.Warnings, result.Suggestions} {
+		for _, issue := range issues {
+			line, ok := want[issue.Pointer]
+			if !ok {
+				continue
+			}
+			found[issue.Pointer] = true
+			if issue.Line != line {
+				t.Errorf("%s: line = %d, want %d (%s)", issue.Pointer, issue.Line, line, issue.Message)
+			}
+		}
+	}
+	for pointer := range want {
+		if !found[pointer] {
+			t.Errorf("no issue with pointer %s; errors %+v, warnings %+v", pointer, result.Errors, result.Warnings)
+		}
+	}
+}
+
+func TestPointerPosition(t *testing.T) {
+	positions := map[string]textutil.JSONPosition{
+		"":                      {Line: 1, Column: 1},
+		"/hooks":                {Line: 2, Column: 3},
+		"/hooks/Stop/0":         {Line: 4, Column: 7},
+		"/permissions/allow/~1": {Line: 9, Column: 5},
+	}
+	tests := []struct {
+		pointer string
+		want    int
+		ok      bool
+	}{
+		{"/hooks/Stop/0", 4, true},
+		{"/hooks/Stop/0/hooks", 4, true},
+		{"/permissions/allow/~1", 9, true},
+		{"/model", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		pos, ok := pointerPosition(positions, tt.pointer)
+		if ok != tt.ok || pos.Line != tt.want {
+			t.Errorf("pointerPosition(%q) = %d, %v; want %d, %v", tt.pointer, pos.Line, ok, tt.want, tt.ok)
+		}
+	}
+}
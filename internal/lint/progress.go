@@ -0,0 +1,20 @@
+package lint
+
+// Progress reports how far a batch has got: Done of Total files of one
+// component type are validated.
+type Progress struct {
+	ComponentType string
+	Done          int
+	Total         int
+	// Result is the result of the file validated last, before the batch's
+	// cross-file checks and the run's re-grading and suppressions.
+	Result *LintResult
+}
+
+// reportProgress passes a batch's progress to the run's Progress func, if
+// any.
+func (ctx *LinterContext) reportProgress(p Progress) {
+	if ctx.Progress != nil {
+		ctx.Progress(p)
+	}
+}
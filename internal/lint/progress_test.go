@@ -0,0 +1,43 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestOptionsProgress(t *testing.T) {
+	tmpDir := t.TempDir()
+	commandsDir := filepath.Join(tmpDir, ".claude", "commands")
+	if err := os.MkdirAll(commandsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.md", "b.md", "c.md"} {
+		if err := os.WriteFile(filepath.Join(commandsDir, name), []byte("---\ndescription: Test\n---\nBody\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []Progress
+	opts := testOptions(tmpDir)
+	opts.Progress = func(p Progress) {
+		if p.Result == nil || p.Result.Type != "command" {
+			t.Errorf("progress %d/%d result = %+v, want the command just validated", p.Done, p.Total, p.Result)
+		}
+		p.Result = nil
+		got = append(got, p)
+	}
+	if _, err := LintCommands(opts); err != nil {
+		t.Fatalf("LintCommands() error = %v", err)
+	}
+
+	want := []Progress{
+		{ComponentType: "command", Done: 1, Total: 3},
+		{ComponentType: "command", Done: 2, Total: 3},
+		{ComponentType: "command", Done: 3, Total: 3},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("progress = %v, want %v", got, want)
+	}
+}
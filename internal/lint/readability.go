@@ -0,0 +1,43 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// checkDescriptionReadability suggests tightening an agent or skill
+// description whose sentences run long, lean on passive voice, or (at
+// strict) never say when to use the component. Descriptions drive the
+// model's delegation decisions, so they should read at a glance.
+//
+// level is the rules.readability config key: off, lenient, normal, or
+// strict. An empty or unknown level selects normal.
+func checkDescriptionReadability(level string, data map[string]any, filePath, contents string) []cue.ValidationError {
+	if level == textutil.ReadabilityOff {
+		return nil
+	}
+	limits, ok := textutil.ReadabilityLevels[level]
+	if !ok {
+		limits = textutil.ReadabilityLevels[textutil.ReadabilityNormal]
+	}
+	desc, _ := data["description"].(string)
+	problems := textutil.MeasureReadability(desc).Check(limits)
+	if len(problems) == 0 {
+		return nil
+	}
+	line := textutil.FindFrontmatterFieldLine(contents, "description")
+	out := make([]cue.ValidationError, 0, len(problems))
+	for _, p := range problems {
+		out = append(out, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("Description readability: %s", p),
+			Severity: cue.SeveritySuggestion,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleDescriptionReadability,
+			Line:     line,
+		})
+	}
+	return out
+}
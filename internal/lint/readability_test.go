@@ -0,0 +1,46 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestCheckDescriptionReadability(t *testing.T) {
+	passive := "---\nname: a\ndescription: Code is reviewed. Reports are written.\n---\nbody\n"
+	tests := []struct {
+		name     string
+		level    string
+		contents string
+		want     []string // message fragments, in order
+	}{
+		{name: "clean", contents: "---\nname: a\ndescription: Reviews Go code. Use after edits.\n---\nbody\n"},
+		{name: "passive at normal", contents: passive, want: []string{"2 of 2 sentences use passive voice"}},
+		{name: "passive at strict", level: "strict", contents: passive, want: []string{"passive voice", "directive"}},
+		{name: "off", level: "off", contents: passive},
+		{name: "unknown level means normal", level: "loose", contents: passive, want: []string{"passive voice"}},
+		{name: "no description", contents: "---\nname: a\n---\nbody\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _, err := parseFrontmatter(tt.contents)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := checkDescriptionReadability(tt.level, data, "agents/a.md", tt.contents)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d findings, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i, e := range got {
+				if e.Rule != cue.RuleDescriptionReadability || e.Severity != cue.SeveritySuggestion || e.Line != 3 {
+					t.Errorf("finding %+v has the wrong rule, severity, or line", e)
+				}
+				if !strings.Contains(e.Message, tt.want[i]) {
+					t.Errorf("finding %d = %q, want it to contain %q", i, e.Message, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -59,6 +59,7 @@ func CheckReflectOutput(rootPath string) []cue.ValidationError {
 				Message:  "KB filename '" + d.Name() + "' must be a 4-10 word lowercase-hyphenated slug (e.g. 'race-condition-in-channel-close.md')",
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleKBFilename,
 			})
 		}
 
@@ -74,6 +75,7 @@ func CheckReflectOutput(rootPath string) []cue.ValidationError {
 				Message:  "KB entry is missing an H1 heading ('# ...')",
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleKBHeadingMissing,
 			})
 		}
 		if !strings.Contains(content, "(source:") {
@@ -82,6 +84,7 @@ func CheckReflectOutput(rootPath string) []cue.ValidationError {
 				Message:  "KB entry is missing a source attribution (a line containing '(source:')",
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleKBSourceMissing,
 			})
 		}
 
@@ -92,6 +95,7 @@ func CheckReflectOutput(rootPath string) []cue.ValidationError {
 				Message:  "KB entry has only " + strconv.Itoa(n) + " non-empty lines (< " + strconv.Itoa(ReflectMinBodyLines) + ") — candidate to fold into another entry",
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleKBEntrySize,
 			})
 		} else if n > ReflectMaxBodyLines {
 			errors = append(errors, cue.ValidationError{
@@ -99,6 +103,7 @@ func CheckReflectOutput(rootPath string) []cue.ValidationError {
 				Message:  "KB entry has " + strconv.Itoa(n) + " non-empty lines (> " + strconv.Itoa(ReflectMaxBodyLines) + ") — candidate to split",
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleKBEntrySize,
 			})
 		}
 
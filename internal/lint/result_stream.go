@@ -0,0 +1,114 @@
+package lint
+
+import (
+	"slices"
+
+	"github.com/dotcommander/cclint/internal/baseline"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// resultStream passes the findings of one linter run to
+// OrchestratorConfig.OnResult: each file's as the file is validated, then
+// the ones the batch checks added, so every finding of the final report is
+// passed once.
+type resultStream struct {
+	o *Orchestrator
+	b *baseline.Baseline
+	// sent counts the findings passed so far, per file.
+	sent map[string]map[cue.ValidationError]int
+}
+
+func (o *Orchestrator) newResultStream(b *baseline.Baseline) *resultStream {
+	return &resultStream{o: o, b: b, sent: make(map[string]map[cue.ValidationError]int)}
+}
+
+// progress is the Progress func of the linter run. It passes on the file p
+// reports, deduplicated and with the run's policies and baseline applied to
+// a copy of its result, and then forwards p to OrchestratorConfig.Progress.
+func (s *resultStream) progress(p Progress) {
+	if s.o.opts.Progress != nil {
+		defer s.o.opts.Progress(p)
+	}
+	if s.o.opts.OnResult == nil || p.Result == nil {
+		return
+	}
+
+	result := *p.Result
+	result.Errors = slices.Clone(result.Errors)
+	result.Warnings = slices.Clone(result.Warnings)
+	result.Suggestions = slices.Clone(result.Suggestions)
+	summary := &LintSummary{
+		ProjectRoot:   s.o.cfg.Root,
+		ComponentType: p.ComponentType,
+		TotalFiles:    1,
+		Results:       []LintResult{result},
+	}
+	dedupeSummary(summary)
+	s.o.applyPolicies(summary)
+	if s.o.opts.UseBaseline && s.b != nil {
+		FilterResults(summary, s.b)
+	}
+	if s.o.opts.Blame {
+		ApplyBlame(summary, true)
+	}
+	recalculateTotals(summary)
+	s.send(summary)
+}
+
+// flush passes on the findings of summary, the run's final summary, that
+// were not passed while its files were validated.
+func (s *resultStream) flush(summary *LintSummary) {
+	if s.o.opts.OnResult == nil {
+		return
+	}
+	for _, result := range summary.Results {
+		sent := s.sent[result.File]
+		unsent := func(issues []cue.ValidationError) []cue.ValidationError {
+			var kept []cue.ValidationError
+			for _, issue := range issues {
+				if sent[issue] > 0 {
+					sent[issue]--
+					continue
+				}
+				kept = append(kept, issue)
+			}
+			return kept
+		}
+		added := result
+		added.Errors = unsent(result.Errors)
+		added.Warnings = unsent(result.Warnings)
+		added.Suggestions = unsent(result.Suggestions)
+		if len(added.Errors)+len(added.Warnings)+len(added.Suggestions) == 0 {
+			continue
+		}
+		one := &LintSummary{
+			ProjectRoot:   summary.ProjectRoot,
+			ComponentType: summary.ComponentType,
+			CodeOwners:    summary.CodeOwners,
+			TotalFiles:    1,
+			Results:       []LintResult{added},
+		}
+		recalculateTotals(one)
+		s.o.opts.OnResult(one)
+	}
+}
+
+// send passes summary to OnResult and counts its findings as sent.
+func (s *resultStream) send(summary *LintSummary) {
+	if len(summary.Results) == 0 {
+		return
+	}
+	for _, result := range summary.Results {
+		sent := s.sent[result.File]
+		if sent == nil {
+			sent = make(map[cue.ValidationError]int)
+			s.sent[result.File] = sent
+		}
+		for _, issues := range [][]cue.ValidationError{result.Errors, result.Warnings, result.Suggestions} {
+			for _, issue := range issues {
+				sent[issue]++
+			}
+		}
+	}
+	s.o.opts.OnResult(summary)
+}
@@ -0,0 +1,23 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/types"
+)
+
+// RuleDocsURL returns the documentation URL of rule from template, the URL
+// template configured at build time, or "" when template is empty or the
+// rule is not in the registry. {rule} is replaced by the rule ID and
+// {category} by its first category, so the docs can live on any host.
+func RuleDocsURL(template, rule string) string {
+	categories, ok := types.RuleCategories[rule]
+	if template == "" || !ok {
+		return ""
+	}
+	category := ""
+	if len(categories) > 0 {
+		category = categories[0]
+	}
+	return strings.NewReplacer("{rule}", rule, "{category}", category).Replace(template)
+}
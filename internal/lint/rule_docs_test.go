@@ -0,0 +1,29 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/types"
+)
+
+func TestRuleDocsURL(t *testing.T) {
+	if got := RuleDocsURL("", types.RuleContentBOM); got != "" {
+		t.Errorf("RuleDocsURL() without a template = %q, want empty", got)
+	}
+
+	const template = "https://docs.example.com/{category}/{rule}"
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{types.RuleContentBOM, "https://docs.example.com/structure/content-bom"},
+		{types.RuleSkillToolsNotInAgent, "https://docs.example.com/references/skill-allowed-tools-not-in-agent"},
+		{"not-a-rule", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := RuleDocsURL(template, tt.rule); got != tt.want {
+			t.Errorf("RuleDocsURL(%q) = %q, want %q", tt.rule, got, tt.want)
+		}
+	}
+}
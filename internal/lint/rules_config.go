@@ -0,0 +1,153 @@
+package lint
+
+import (
+	"path/filepath"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// SuppressionCategory is the suppression source for issues whose rule
+// belongs to a disabled category.
+const SuppressionCategory = "category"
+
+// SuppressionOverride is the suppression source for issues whose rule an
+// override turns off for their file.
+const SuppressionOverride = "override"
+
+// ApplyRulesConfig re-grades and filters issues in summary according to the
+// rules block of the configuration. Like baseline filtering it runs after
+// linting, so the component linters stay free of configuration plumbing.
+func ApplyRulesConfig(summary *LintSummary, rules config.RulesConfig) {
+	if summary == nil {
+		return
+	}
+
+	changed := false
+	if rules.WarnUnknownKeys {
+		changed = escalate(summary, cue.RuleFrontmatterUnknownKey, cue.SeverityWarning)
+	}
+	if rules.StrictSettingsKeys {
+		changed = escalate(summary, cue.RuleSettingsKeyUnknown, cue.SeverityError) || changed
+	}
+	if len(rules.Categories) > 0 {
+		changed = filterCategories(summary, rules.Categories) || changed
+	}
+
+	if changed {
+		recalculateTotals(summary)
+		SortSummary(summary)
+	}
+}
+
+// escalate re-grades the suggestions with the given rule to severity, a
+// warning or an error.
+func escalate(summary *LintSummary, rule, severity string) bool {
+	changed := false
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		kept := result.Suggestions[:0]
+		for _, issue := range result.Suggestions {
+			if issue.Rule == rule {
+				issue.Severity = severity
+				categorizeIssues(result, []cue.ValidationError{issue})
+				changed = true
+				continue
+			}
+			kept = append(kept, issue)
+		}
+		result.Suggestions = kept
+		result.Success = len(result.Errors) == 0
+	}
+	return changed
+}
+
+// filterCategories drops issues whose rule is in a disabled category,
+// recording them as suppressed.
+func filterCategories(summary *LintSummary, categories map[string]bool) bool {
+	disabled := func(issue cue.ValidationError) bool {
+		return CategoryDisabled(issue.Rule, categories)
+	}
+	changed := false
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		var errs, warns, suggs []cue.ValidationError
+		result.Errors, errs = filterIssues(result.Errors, disabled)
+		result.Warnings, warns = filterIssues(result.Warnings, disabled)
+		result.Suggestions, suggs = filterIssues(result.Suggestions, disabled)
+		for _, ignored := range [][]cue.ValidationError{errs, warns, suggs} {
+			recordSuppressed(summary, ignored, SuppressionCategory)
+			changed = changed || len(ignored) > 0
+		}
+		result.Success = len(result.Errors) == 0
+	}
+	return changed
+}
+
+// CategoryDisabled reports whether categories turn rule off: some category
+// of the rule is disabled (false) and none is explicitly enabled (true).
+// Rules without categories, including issues with no rule ID, stay on.
+func CategoryDisabled(rule string, categories map[string]bool) bool {
+	disabled := false
+	for _, c := range cue.RuleCategories[rule] {
+		on, set := categories[c]
+		if set && on {
+			return false
+		}
+		disabled = disabled || set
+	}
+	return disabled
+}
+
+// ApplyOverrides re-grades issues per file according to the overrides
+// block of the configuration: each result's file, relative to the project
+// root, selects the rule severities that apply to it. Issues whose rule is
+// set to "off" are dropped and recorded as suppressed. Like
+// ApplyRulesConfig it runs after linting and before baseline filtering.
+func ApplyOverrides(summary *LintSummary, overrides config.Overrides) {
+	if summary == nil || len(overrides) == 0 {
+		return
+	}
+
+	changed := false
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		relPath := result.File
+		if filepath.IsAbs(relPath) && summary.ProjectRoot != "" {
+			if rel, err := filepath.Rel(summary.ProjectRoot, relPath); err == nil {
+				relPath = rel
+			}
+		}
+		severities := overrides.For(relPath)
+		if len(severities) == 0 {
+			continue
+		}
+
+		var all, dropped []cue.ValidationError
+		for _, issues := range [][]cue.ValidationError{result.Errors, result.Warnings, result.Suggestions} {
+			for _, issue := range issues {
+				severity, ok := severities[issue.Rule]
+				switch {
+				case !ok || issue.Rule == "":
+				case severity == config.SeverityOff:
+					dropped = append(dropped, issue)
+					changed = true
+					continue
+				case severity != issue.Severity:
+					issue.Severity = severity
+					changed = true
+				}
+				all = append(all, issue)
+			}
+		}
+		result.Errors, result.Warnings, result.Suggestions = nil, nil, nil
+		categorizeIssues(result, all)
+		recordSuppressed(summary, dropped, SuppressionOverride)
+		result.Success = len(result.Errors) == 0
+	}
+
+	if changed {
+		recalculateTotals(summary)
+		SortSummary(summary)
+	}
+}
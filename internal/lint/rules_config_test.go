@@ -0,0 +1,267 @@
+package lint
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// TestRuleCategoriesComplete checks that every rule ID declared in the
+// types package is tagged with at least one known category.
+func TestRuleCategoriesComplete(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "../types/types.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rules []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || len(spec.Values) != 1 || !strings.HasPrefix(spec.Names[0].Name, "Rule") {
+			return true
+		}
+		if lit, ok := spec.Values[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			id, _ := strconv.Unquote(lit.Value)
+			rules = append(rules, id)
+		}
+		return true
+	})
+	if len(rules) == 0 {
+		t.Fatal("found no rule constants")
+	}
+
+	for _, rule := range rules {
+		categories := cue.RuleCategories[rule]
+		if len(categories) == 0 {
+			t.Errorf("rule %s has no category", rule)
+		}
+		for _, c := range categories {
+			if !slices.Contains(cue.Categories, c) {
+				t.Errorf("rule %s has unknown category %q", rule, c)
+			}
+		}
+	}
+	if len(cue.RuleCategories) != len(rules) {
+		t.Errorf("RuleCategories has %d entries for %d rules", len(cue.RuleCategories), len(rules))
+	}
+}
+
+// TestFindingsHaveRule checks that every finding the checks build carries a
+// rule ID, so categories, overrides, and inline suppressions reach it. A
+// literal without a Rule field passes when the function sets the Rule of
+// the variable that holds it.
+func TestFindingsHaveRule(t *testing.T) {
+	fset := token.NewFileSet()
+	for _, dir := range []string{".", "../crossfile", "../cue", "../textutil"} {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range files {
+			if strings.HasSuffix(path, "_test.go") {
+				continue
+			}
+			f, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil {
+					continue
+				}
+				for _, lit := range literalsWithoutRule(fn.Body) {
+					t.Errorf("%s: ValidationError has no Rule", fset.Position(lit.Pos()))
+				}
+			}
+		}
+	}
+}
+
+// literalsWithoutRule returns the ValidationError literals in body that set
+// a Message but no Rule, leaving out those assigned to a variable whose
+// Rule body sets.
+func literalsWithoutRule(body *ast.BlockStmt) []*ast.CompositeLit {
+	ruleSet := make(map[string]bool)
+	assigned := make(map[*ast.CompositeLit]string)
+	var lits []*ast.CompositeLit
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if sel, ok := lhs.(*ast.SelectorExpr); ok && sel.Sel.Name == "Rule" {
+					if id, ok := sel.X.(*ast.Ident); ok {
+						ruleSet[id.Name] = true
+					}
+				}
+				if id, ok := lhs.(*ast.Ident); ok && i < len(n.Rhs) {
+					if lit, ok := n.Rhs[i].(*ast.CompositeLit); ok {
+						assigned[lit] = id.Name
+					}
+				}
+			}
+		case *ast.CompositeLit:
+			if isFindingLiteral(n) {
+				lits = append(lits, n)
+			}
+			// Elements of a []ValidationError literal omit the type.
+			if arr, ok := n.Type.(*ast.ArrayType); ok && isValidationErrorType(arr.Elt) {
+				for _, elt := range n.Elts {
+					if lit, ok := elt.(*ast.CompositeLit); ok && hasKey(lit, "Message") && !hasKey(lit, "Rule") {
+						lits = append(lits, lit)
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	var missing []*ast.CompositeLit
+	for _, lit := range lits {
+		if name, ok := assigned[lit]; ok && ruleSet[name] {
+			continue
+		}
+		missing = append(missing, lit)
+	}
+	return missing
+}
+
+// isFindingLiteral reports whether lit is a ValidationError literal that
+// sets a Message but no Rule.
+func isFindingLiteral(lit *ast.CompositeLit) bool {
+	return lit.Type != nil && isValidationErrorType(lit.Type) && hasKey(lit, "Message") && !hasKey(lit, "Rule")
+}
+
+func isValidationErrorType(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name == "ValidationError"
+	case *ast.SelectorExpr:
+		return e.Sel.Name == "ValidationError"
+	}
+	return false
+}
+
+func hasKey(lit *ast.CompositeLit, key string) bool {
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if id, ok := kv.Key.(*ast.Ident); ok && id.Name == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestCategoryDisabled(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       string
+		categories map[string]bool
+		want       bool
+	}{
+		{name: "no config", rule: cue.RuleTerminology},
+		{name: "category off", rule: cue.RuleTerminology, categories: map[string]bool{cue.CategoryStyle: false}, want: true},
+		{name: "other category off", rule: cue.RuleTerminology, categories: map[string]bool{cue.CategorySecurity: false}},
+		{name: "category on", rule: cue.RuleTerminology, categories: map[string]bool{cue.CategoryStyle: true}},
+		{
+			name:       "one category off",
+			rule:       cue.RuleContextImportOutside,
+			categories: map[string]bool{cue.CategoryReferences: false},
+			want:       true,
+		},
+		{
+			name:       "enabled category wins",
+			rule:       cue.RuleContextImportOutside,
+			categories: map[string]bool{cue.CategoryReferences: false, cue.CategorySecurity: true},
+		},
+		{name: "no rule ID", rule: "", categories: map[string]bool{cue.CategoryStyle: false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CategoryDisabled(tt.rule, tt.categories); got != tt.want {
+				t.Errorf("CategoryDisabled(%q, %v) = %v, want %v", tt.rule, tt.categories, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyRulesConfigCategories(t *testing.T) {
+	summary := &LintSummary{
+		TotalFiles:  1,
+		FailedFiles: 1,
+		Results: []LintResult{{
+			File: "settings.json",
+			Errors: []cue.ValidationError{
+				{File: "settings.json", Message: "secret", Severity: cue.SeverityError, Rule: cue.RuleSettingsCredentialExposure},
+			},
+			Suggestions: []cue.ValidationError{
+				{File: "settings.json", Message: "spelling", Severity: cue.SeveritySuggestion, Rule: cue.RuleTerminology},
+				{File: "settings.json", Message: "no rule", Severity: cue.SeveritySuggestion},
+			},
+		}},
+	}
+
+	ApplyRulesConfig(summary, config.RulesConfig{Categories: map[string]bool{
+		cue.CategorySecurity: false,
+		cue.CategoryStyle:    false,
+	}})
+
+	r := summary.Results[0]
+	if len(r.Errors) != 0 || len(r.Suggestions) != 1 || r.Suggestions[0].Message != "no rule" {
+		t.Fatalf("kept errors %v, suggestions %v; want only the unclassified suggestion", r.Errors, r.Suggestions)
+	}
+	if !r.Success || summary.TotalErrors != 0 || summary.TotalSuggestions != 1 || summary.FailedFiles != 0 {
+		t.Errorf("totals not recalculated: %+v", summary)
+	}
+	if len(summary.Suppressed) != 2 || summary.Suppressed[0].Source != SuppressionCategory {
+		t.Errorf("Suppressed = %+v, want both filtered issues from %q", summary.Suppressed, SuppressionCategory)
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	orphan := func(file string) cue.ValidationError {
+		return cue.ValidationError{File: file, Message: "orphan", Severity: cue.SeverityInfo, Rule: cue.RuleOrphanedSkill}
+	}
+	summary := &LintSummary{
+		ProjectRoot:      "/proj",
+		TotalFiles:       3,
+		SuccessfulFiles:  3,
+		TotalSuggestions: 4,
+		Results: []LintResult{
+			{File: "plugins/p/skills/a/SKILL.md", Success: true, Suggestions: []cue.ValidationError{
+				orphan("plugins/p/skills/a/SKILL.md"),
+				{File: "plugins/p/skills/a/SKILL.md", Message: "spelling", Severity: cue.SeveritySuggestion, Rule: cue.RuleTerminology},
+			}},
+			{File: "/proj/plugins/p/skills/b/SKILL.md", Success: true, Suggestions: []cue.ValidationError{orphan("/proj/plugins/p/skills/b/SKILL.md")}},
+			{File: "skills/c/SKILL.md", Success: true, Suggestions: []cue.ValidationError{orphan("skills/c/SKILL.md")}},
+		},
+	}
+
+	ApplyOverrides(summary, config.Overrides{{
+		Files:    []string{"plugins/**"},
+		Severity: map[string]string{cue.RuleOrphanedSkill: cue.SeverityError, cue.RuleTerminology: config.SeverityOff},
+	}})
+
+	for _, r := range summary.Results[:2] {
+		if len(r.Errors) != 1 || r.Errors[0].Severity != cue.SeverityError || len(r.Suggestions) != 0 || r.Success {
+			t.Errorf("%s: errors %v, suggestions %v; want the orphan escalated to an error", r.File, r.Errors, r.Suggestions)
+		}
+	}
+	if r := summary.Results[2]; len(r.Errors) != 0 || len(r.Suggestions) != 1 {
+		t.Errorf("%s outside the override changed: errors %v, suggestions %v", r.File, r.Errors, r.Suggestions)
+	}
+	if summary.TotalErrors != 2 || summary.TotalSuggestions != 1 || summary.FailedFiles != 2 {
+		t.Errorf("totals not recalculated: %+v", summary)
+	}
+	if len(summary.Suppressed) != 1 || summary.Suppressed[0].Rule != cue.RuleTerminology || summary.Suppressed[0].Source != SuppressionOverride {
+		t.Errorf("Suppressed = %+v, want the terminology issue from %q", summary.Suppressed, SuppressionOverride)
+	}
+}
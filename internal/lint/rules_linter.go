@@ -8,18 +8,19 @@ import (
 	"strings"
 
 	"github.com/bmatcuk/doublestar/v4"
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
 	"github.com/dotcommander/cclint/internal/textutil"
 )
 
 // LintRules runs linting on .claude/rules/*.md files using the generic linter.
-func LintRules(rootPath string, quiet bool, verbose bool, noCycleCheck bool, exclude []string) (*LintSummary, error) {
-	ctx, err := NewLinterContext(rootPath, quiet, verbose, noCycleCheck, exclude)
+func LintRules(opts Options) (*LintSummary, error) {
+	ctx, err := NewLinterContext(opts)
 	if err != nil {
 		return nil, err
 	}
-	return lintBatch(ctx, NewRuleLinter()), nil
+	return lintBatch(ctx, NewRuleLinter(ctx.Config)), nil
 }
 
 // RuleLinter implements ComponentLinter for .claude/rules/*.md files.
@@ -36,8 +37,9 @@ var (
 )
 
 // NewRuleLinter creates a new RuleLinter.
-func NewRuleLinter() *RuleLinter {
-	return &RuleLinter{}
+// cfg supplies the rule settings; nil uses the defaults.
+func NewRuleLinter(cfg *config.Config) *RuleLinter {
+	return &RuleLinter{BaseLinter: BaseLinter{cfg: cfg}}
 }
 
 func (l *RuleLinter) Type() string {
@@ -58,6 +60,7 @@ func (l *RuleLinter) PreValidate(filePath, contents string) []cue.ValidationErro
 			Message:  "Rule files must have .md extension",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleComponentFileName,
 		})
 	}
 
@@ -68,6 +71,7 @@ func (l *RuleLinter) PreValidate(filePath, contents string) []cue.ValidationErro
 			Message:  "Rule file is empty",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleComponentEmpty,
 			Abort:    true,
 		})
 	}
@@ -94,6 +98,7 @@ func validateSymlinkTarget(filePath string) []cue.ValidationError {
 			Message:  fmt.Sprintf("Symlink target does not exist or is inaccessible: %v", err),
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleRuleSymlinkBroken,
 		}}
 	}
 	if _, err := os.Stat(target); err != nil {
@@ -102,6 +107,7 @@ func validateSymlinkTarget(filePath string) []cue.ValidationError {
 			Message:  fmt.Sprintf("Symlink target not found: %s", target),
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleRuleSymlinkBroken,
 		}}
 	}
 	return nil
@@ -166,6 +172,7 @@ func validatePathsGlob(paths any, filePath, contents string) []cue.ValidationErr
 			Message:  "paths: field must be a string",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldInvalid,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "paths"),
 		})
 		return errors
@@ -187,6 +194,7 @@ func validatePathsGlob(paths any, filePath, contents string) []cue.ValidationErr
 				Message:  fmt.Sprintf("Invalid glob pattern %q: %v", pattern, err),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleFieldInvalid,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "paths"),
 			})
 		}
@@ -269,14 +277,14 @@ func validateBalancedBraces(pattern string) error {
 	return nil
 }
 
+// importPattern matches @imports (not in code spans).
+// Per docs: @path/to/import syntax, not evaluated in code spans/blocks
+var importPattern = regexp.MustCompile(`(?m)^[^` + "`" + `]*@([~./][^\s]+)`)
+
 // validateImports checks @import references in content
 func validateImports(contents, filePath string) []cue.ValidationError {
 	var errors []cue.ValidationError
 
-	// Pattern to match @imports (not in code blocks)
-	// Per docs: @path/to/import syntax, not evaluated in code spans/blocks
-	importPattern := regexp.MustCompile(`(?m)^[^` + "`" + `]*@([~./][^\s]+)`)
-
 	// Find imports not in code blocks
 	lines := strings.Split(contents, "\n")
 	inCodeBlock := false
@@ -305,6 +313,7 @@ func validateImports(contents, filePath string) []cue.ValidationError {
 					Message:  fmt.Sprintf("Import target does not exist: @%s", match[1]),
 					Severity: cue.SeverityWarning,
 					Source:   cue.SourceCClintObserve,
+					Rule:     cue.RuleContextImportMissing,
 					Line:     lineNum + 1,
 				})
 			}
@@ -347,7 +356,11 @@ func (l *RuleLinter) PostProcessBatch(ctx *LinterContext, summary *LintSummary)
 		if absPath == "" {
 			absPath = filepath.Join(ctx.RootPath, file.RelPath)
 		}
-		fileMap[absPath] = file.Contents
+		contents, err := file.Load()
+		if err != nil {
+			continue
+		}
+		fileMap[absPath] = contents
 	}
 
 	if len(fileMap) == 0 {
@@ -217,14 +217,14 @@ func TestValidateImports(t *testing.T) {
 }
 
 func TestRuleLinterType(t *testing.T) {
-	linter := NewRuleLinter()
+	linter := NewRuleLinter(nil)
 	if linter.Type() != "rule" {
 		t.Errorf("RuleLinter.Type() = %q, want %q", linter.Type(), "rule")
 	}
 }
 
 func TestRuleLinterFileType(t *testing.T) {
-	linter := NewRuleLinter()
+	linter := NewRuleLinter(nil)
 	expected := "rule"
 	got := linter.Type()
 	if got != expected {
@@ -233,7 +233,7 @@ func TestRuleLinterFileType(t *testing.T) {
 }
 
 func TestRuleLinterPreValidate(t *testing.T) {
-	linter := NewRuleLinter()
+	linter := NewRuleLinter(nil)
 
 	tests := []struct {
 		name         string
@@ -280,7 +280,7 @@ func TestRuleLinterPreValidate(t *testing.T) {
 }
 
 func TestRuleLinterParseContent(t *testing.T) {
-	linter := NewRuleLinter()
+	linter := NewRuleLinter(nil)
 
 	tests := []struct {
 		name     string
@@ -325,7 +325,7 @@ func TestRuleLinterParseContent(t *testing.T) {
 }
 
 func TestRuleLinterValidateCUE(t *testing.T) {
-	linter := NewRuleLinter()
+	linter := NewRuleLinter(nil)
 	errors, err := linter.ValidateCUE(nil, nil)
 
 	if err != nil {
@@ -338,7 +338,7 @@ func TestRuleLinterValidateCUE(t *testing.T) {
 }
 
 func TestRuleLinterValidateSpecific(t *testing.T) {
-	linter := NewRuleLinter()
+	linter := NewRuleLinter(nil)
 
 	tests := []struct {
 		name         string
@@ -378,7 +378,7 @@ func TestRuleLinterValidateSpecific(t *testing.T) {
 }
 
 func TestRuleLinterPreValidateSymlinks(t *testing.T) {
-	linter := NewRuleLinter()
+	linter := NewRuleLinter(nil)
 	tmpDir := t.TempDir()
 
 	// Create a target file
@@ -498,7 +498,7 @@ func TestValidateImportsEdgeCases(t *testing.T) {
 }
 
 func TestRuleLinterValidateBestPractices(t *testing.T) {
-	linter := NewRuleLinter()
+	linter := NewRuleLinter(nil)
 	tmpDir := t.TempDir()
 
 	// Create a valid import target
@@ -0,0 +1,82 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+var (
+	// scaffoldNamePattern matches the names init tools and examples give
+	// new components: my-agent, example-skill, new-command, agent-name.
+	scaffoldNamePattern = regexp.MustCompile(`^((my|example|sample|your|new|test|demo)-(new-)?(agent|command|skill|plugin)|(agent|command|skill|plugin)-name)$`)
+
+	// scaffoldTextPatterns match template text left in prose: TODO markers,
+	// "add your ... here" prompts, lorem ipsum, and example.com URLs.
+	scaffoldTextPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\b(TODO|FIXME|TBD):.*`),
+		regexp.MustCompile(`(?i)\b(describe|add|insert|write|put|enter)\s+(your|the)\s+[a-z ]{0,40}?\bhere\b`),
+		regexp.MustCompile(`(?i)\blorem ipsum\b`),
+		regexp.MustCompile(`(?i)\bhttps?://([a-z0-9-]+\.)*example\.(com|org|net)\b\S*`),
+	}
+
+	// scaffoldDescriptions are whole descriptions that are only a stub.
+	scaffoldDescriptions = []string{"todo", "tbd", "description", "a description", "description here", "your description"}
+)
+
+// checkScaffoldLeftovers warns about template text an init tool or copied
+// example left behind: a placeholder name such as my-agent, a stub
+// description, and TODO markers, "add your ... here" prompts, lorem ipsum,
+// or example.com URLs in the body. Fenced and inline code are skipped, and
+// each leftover is reported once.
+func checkScaffoldLeftovers(data map[string]any, filePath, contents string) []cue.ValidationError {
+	var out []cue.ValidationError
+	seen := make(map[string]bool)
+	report := func(line int, leftover string) {
+		if seen[leftover] {
+			return
+		}
+		seen[leftover] = true
+		out = append(out, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("Scaffold leftover %q. Replace template text before shipping the component", leftover),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleScaffoldLeftover,
+			Line:     line,
+		})
+	}
+
+	if name, ok := data["name"].(string); ok && scaffoldNamePattern.MatchString(name) {
+		report(textutil.FindFrontmatterFieldLine(contents, "name"), name)
+	}
+	if desc, ok := data["description"].(string); ok {
+		line := textutil.FindFrontmatterFieldLine(contents, "description")
+		if slices.Contains(scaffoldDescriptions, strings.ToLower(strings.Trim(strings.TrimSpace(desc), "."))) {
+			report(line, desc)
+		}
+		for _, leftover := range scaffoldText(desc) {
+			report(line, leftover)
+		}
+	}
+
+	withBodyLines(contents, func(lineNum int, trimmed string) {
+		for _, leftover := range scaffoldText(inlineCodeSpan.ReplaceAllString(trimmed, "")) {
+			report(lineNum, leftover)
+		}
+	})
+	return out
+}
+
+// scaffoldText returns the template text matches in s.
+func scaffoldText(s string) []string {
+	var matches []string
+	for _, p := range scaffoldTextPatterns {
+		matches = append(matches, p.FindAllString(s, -1)...)
+	}
+	return matches
+}
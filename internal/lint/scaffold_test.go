@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestCheckScaffoldLeftovers(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []string // leftover:line per finding, in order
+	}{
+		{
+			name:     "placeholder name and TODO description",
+			contents: "---\nname: my-agent\ndescription: \"TODO: describe your agent\"\n---\nReview code.\n",
+			want:     []string{"my-agent:2", "TODO: describe your agent:3"},
+		},
+		{
+			name:     "stub description",
+			contents: "---\nname: reviewer\ndescription: Description here.\n---\nReview code.\n",
+			want:     []string{"Description here.:3"},
+		},
+		{
+			name:     "body leftovers",
+			contents: "---\nname: reviewer\n---\nLorem ipsum dolor sit amet.\nAdd your instructions here.\nDocs: https://docs.example.com/guide\n",
+			want: []string{
+				"Lorem ipsum:4",
+				"Add your instructions here:5",
+				"https://docs.example.com/guide:6",
+			},
+		},
+		{
+			name:     "code and real names are skipped",
+			contents: "---\nname: agent-reviewer\ndescription: Reviews pull requests\n---\nUse `curl https://example.com`.\n```\n# TODO: fill in\n```\nKeep a todo list of findings.\n",
+		},
+		{
+			name:     "reported once",
+			contents: "---\nname: a\n---\nlorem ipsum\nlorem ipsum\n",
+			want:     []string{"lorem ipsum:4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _, err := parseFrontmatter(tt.contents)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range checkScaffoldLeftovers(data, "agents/a.md", tt.contents) {
+				if e.Rule != cue.RuleScaffoldLeftover || e.Severity != cue.SeverityWarning {
+					t.Errorf("finding %+v has the wrong rule or severity", e)
+				}
+				var leftover string
+				if _, err := fmt.Sscanf(e.Message, "Scaffold leftover %q.", &leftover); err != nil {
+					t.Fatalf("unexpected message %q", e.Message)
+				}
+				got = append(got, fmt.Sprintf("%s:%d", leftover, e.Line))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("findings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
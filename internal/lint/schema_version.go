@@ -0,0 +1,55 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/migrate"
+)
+
+// schemaVersion returns the frontmatter convention files are validated
+// against, from the schemaVersion config key; 0 selects the current one.
+// Under an older version, keys that version still used are accepted and
+// read as their current names.
+func schemaVersion(cfg *config.Config) int {
+	if cfg.SchemaVersion == 0 {
+		return migrate.CurrentSchemaVersion
+	}
+	return cfg.SchemaVersion
+}
+
+// legacyKeyAccepted reports whether key is a renamed key that schema
+// version still uses.
+func legacyKeyAccepted(version int, component, key string) bool {
+	r, ok := migrate.Lookup(component, key)
+	return ok && r.Version > version
+}
+
+// aliasLegacyKeys copies the values of legacy keys accepted under schema
+// version to their current names, so the component checks validate them.
+// Keys already set under the current name win.
+func aliasLegacyKeys(version int, component string, data map[string]any) {
+	for key, value := range data {
+		r, ok := migrate.Lookup(component, key)
+		if !ok || r.Version <= version {
+			continue
+		}
+		if _, set := data[r.To]; !set {
+			data[r.To] = value
+		}
+	}
+}
+
+// renamedFieldIssue reports a frontmatter key that the selected schema
+// version has renamed.
+func renamedFieldIssue(r migrate.Rename, filePath string, line int) cue.ValidationError {
+	return cue.ValidationError{
+		File:     filePath,
+		Message:  fmt.Sprintf("Frontmatter field '%s' was renamed to '%s' in schema version %d and is ignored under its old name. Rename it, or run 'cclint migrate'", r.From, r.To, r.Version),
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleFrontmatterFieldRenamed,
+		Line:     line,
+	}
+}
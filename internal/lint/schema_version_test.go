@@ -0,0 +1,52 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/migrate"
+)
+
+func TestSchemaVersion_LegacyKeys(t *testing.T) {
+	const contents = "---\ndescription: Review a file\nargument_hint: \"[file]\"\n---\nReview the file.\n"
+
+	tests := []struct {
+		name        string
+		version     int
+		wantRenamed bool
+		wantAlias   bool
+	}{
+		{name: "current version reports renamed key", version: 0, wantRenamed: true},
+		{name: "legacy version accepts and aliases key", version: migrate.OldestSchemaVersion, wantAlias: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.SchemaVersion = tt.version
+
+			data := map[string]any{"description": "Review a file", "argument_hint": "[file]"}
+			aliasLegacyKeys(schemaVersion(cfg), cue.TypeCommand, data)
+			if _, ok := data["argument-hint"]; ok != tt.wantAlias {
+				t.Errorf("argument-hint aliased = %v, want %v", ok, tt.wantAlias)
+			}
+
+			var renamed, unknown int
+			for _, issue := range validateCommandSpecific(cfg, data, "commands/review.md", contents) {
+				switch issue.Rule {
+				case cue.RuleFrontmatterFieldRenamed:
+					renamed++
+					if issue.Line != 3 || issue.Severity != cue.SeverityWarning {
+						t.Errorf("renamed issue line/severity = %d/%s, want 3/warning", issue.Line, issue.Severity)
+					}
+				case cue.RuleFrontmatterUnknownKey:
+					unknown++
+				}
+			}
+			if (renamed == 1) != tt.wantRenamed || unknown != 0 {
+				t.Errorf("renamed = %d, unknown = %d; want renamed %v and no unknown-key findings", renamed, unknown, tt.wantRenamed)
+			}
+		})
+	}
+}
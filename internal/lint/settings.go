@@ -5,16 +5,17 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 )
 
 // LintSettings runs linting on settings files using the generic linter.
-func LintSettings(rootPath string, quiet bool, verbose bool, noCycleCheck bool, exclude []string) (*LintSummary, error) {
-	ctx, err := NewLinterContext(rootPath, quiet, verbose, noCycleCheck, exclude)
+func LintSettings(opts Options) (*LintSummary, error) {
+	ctx, err := NewLinterContext(opts)
 	if err != nil {
 		return nil, err
 	}
-	return lintBatch(ctx, NewSettingsLinter()), nil
+	return lintBatch(ctx, NewSettingsLinter(ctx.RootPath, ctx.Config)), nil
 }
 
 // Valid hook events according to Anthropic documentation
@@ -99,27 +100,27 @@ func eventLabel(events map[string]bool) string {
 }
 
 // validateSettingsSpecific implements settings-specific validation rules
-func validateSettingsSpecific(data map[string]any, filePath string) []cue.ValidationError {
+func validateSettingsSpecific(cfg *config.Config, data map[string]any, filePath string) []cue.ValidationError {
 	var errors []cue.ValidationError
 
 	// Check hooks structure if present
 	if hooks, ok := data["hooks"]; ok {
-		errors = append(errors, validateHooks(hooks, filePath)...)
+		errors = append(errors, withPointer(validateHooks(hooks, filePath, cfg), "/hooks")...)
 	}
 
 	// Check permissions structure if present
 	if perms, ok := data["permissions"]; ok {
-		errors = append(errors, validatePermissions(perms, filePath)...)
+		errors = append(errors, withPointer(validatePermissions(perms, filePath, cfg.ExtraTools), "/permissions")...)
 	}
 
 	// Check mcpServers structure if present
 	if mcpServers, ok := data["mcpServers"]; ok {
-		errors = append(errors, validateMCPServers(mcpServers, filePath)...)
+		errors = append(errors, withPointer(validateMCPServers(mcpServers, filePath), "/mcpServers")...)
 	}
 
 	// Check rules array if present
 	if rules, ok := data["rules"]; ok {
-		errors = append(errors, validateRules(rules, filePath)...)
+		errors = append(errors, withPointer(validateRules(rules, filePath), "/rules")...)
 	}
 
 	// Check cleanupPeriodDays is >= 1 (v2.1.89+: 0 silently disables transcript persistence)
@@ -129,9 +130,23 @@ func validateSettingsSpecific(data map[string]any, filePath string) []cue.Valida
 				Message:  "cleanupPeriodDays must be >= 1; 0 silently disables transcript persistence",
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleSettingsValueInvalid,
+				Pointer:  "/cleanupPeriodDays",
 			})
 		}
 	}
 
 	return errors
 }
+
+// withPointer sets pointer on the issues that do not point at a more
+// specific value already, so a section's checks can point at their
+// entries and fall back to the section.
+func withPointer(errs []cue.ValidationError, pointer string) []cue.ValidationError {
+	for i := range errs {
+		if errs[i].Pointer == "" {
+			errs[i].Pointer = pointer
+		}
+	}
+	return errs
+}
@@ -0,0 +1,127 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// credentialHelperFields are the settings keys that name a command Claude
+// Code runs to obtain or refresh credentials.
+var credentialHelperFields = []string{
+	"apiKeyHelper",
+	"awsAuthRefresh",
+	"awsCredentialExport",
+	"gcpAuthRefresh",
+	"otelHeadersHelper",
+}
+
+// maxHelperScriptBytes caps how much of a helper script is scanned.
+const maxHelperScriptBytes = 256 << 10
+
+var (
+	// credentialFileWritePattern matches a redirect or tee into a file.
+	// Writes to /dev/* (stderr, null) and fd duplication (>&2) are excluded.
+	credentialFileWritePattern = regexp.MustCompile(`(?:>>?|\btee\s+(?:-a\s+)?)\s*([^\s|&;>()]+)`)
+
+	// restrictivePermsPattern matches a umask or chmod that keeps the file
+	// private to its owner.
+	restrictivePermsPattern = regexp.MustCompile(`\bumask\s+0?0?77\b|\bchmod\s+(?:0?[4-7]00\b|go-\w*r|og-\w*r)`)
+
+	// worldReadableChmodPattern matches a chmod granting read to others.
+	worldReadableChmodPattern = regexp.MustCompile(`\bchmod\s+(?:0?[0-7]{2}[4-7]\b|a?\+r|o\+\w*r)`)
+)
+
+// validateCredentialHelpers checks the credential helper settings: each must
+// be a non-empty command string, a path-like script must exist and be
+// executable, and the command (or the script it runs) must not write
+// credentials to a world-readable file. Script checks are skipped when
+// rootPath is empty.
+func validateCredentialHelpers(data map[string]any, rootPath, filePath, contents string) []cue.ValidationError {
+	var errors []cue.ValidationError
+	for _, field := range credentialHelperFields {
+		raw, ok := data[field]
+		if !ok {
+			continue
+		}
+		line := FindJSONFieldLine(contents, field)
+		issue := func(rule, severity, source, msg string) cue.ValidationError {
+			return cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("%s: %s", field, msg),
+				Severity: severity,
+				Source:   source,
+				Rule:     rule,
+				Line:     line,
+			}
+		}
+
+		command, ok := raw.(string)
+		if !ok || strings.TrimSpace(command) == "" {
+			errors = append(errors, issue(cue.RuleSettingsCredentialHelper, cue.SeverityError, cue.SourceAnthropicDocs,
+				"must be a non-empty command string"))
+			continue
+		}
+
+		if msg := credentialWriteProblem(command); msg != "" {
+			errors = append(errors, issue(cue.RuleSettingsCredentialExposure, cue.SeverityWarning, cue.SourceCClintObserve, msg))
+		}
+
+		if rootPath == "" {
+			continue
+		}
+		if msg := checkStatusLineScript(command, rootPath); msg != "" {
+			errors = append(errors, issue(cue.RuleSettingsCredentialHelper, cue.SeverityWarning, cue.SourceCClintObserve, msg))
+			continue
+		}
+		if msg := credentialScriptProblem(command, rootPath); msg != "" {
+			errors = append(errors, issue(cue.RuleSettingsCredentialExposure, cue.SeverityWarning, cue.SourceCClintObserve, msg))
+		}
+	}
+	return errors
+}
+
+// credentialScriptProblem scans the script a helper command runs for
+// world-readable credential writes.
+func credentialScriptProblem(command, rootPath string) string {
+	script, _, ok := resolveCommandScript(command, rootPath)
+	if !ok {
+		return ""
+	}
+	f, err := os.Open(script) //nolint:gosec // G304: script path comes from the linted settings file
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+	buf := make([]byte, maxHelperScriptBytes)
+	n, _ := f.Read(buf)
+	if msg := credentialWriteProblem(string(buf[:n])); msg != "" {
+		return fmt.Sprintf("script %q %s", script, msg)
+	}
+	return ""
+}
+
+// credentialWriteProblem describes how shell source exposes the credentials
+// it handles, or returns "". A helper's output is a secret, so any file it
+// writes should be private: a chmod granting read to others, or a file write
+// with no umask 077 / chmod 600 (the default umask leaves files
+// world-readable), is reported.
+func credentialWriteProblem(src string) string {
+	if m := worldReadableChmodPattern.FindString(src); m != "" {
+		return fmt.Sprintf("makes credential files world-readable (%q); use chmod 600", m)
+	}
+	if restrictivePermsPattern.MatchString(src) {
+		return ""
+	}
+	for _, m := range credentialFileWritePattern.FindAllStringSubmatch(src, -1) {
+		target := strings.Trim(m[1], `"'`)
+		if strings.HasPrefix(target, "/dev/") || strings.HasPrefix(target, "&") || target == "" {
+			continue
+		}
+		return fmt.Sprintf("writes credentials to %q with the default umask, leaving it world-readable; set umask 077 or chmod 600 the file", target)
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestValidateCredentialHelpers(t *testing.T) {
+	root := t.TempDir()
+	claudeDir := filepath.Join(root, ".claude")
+	if err := os.MkdirAll(claudeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	scripts := map[string]struct {
+		body string
+		mode os.FileMode
+	}{
+		"key.sh":     {"#!/bin/sh\nsecret-tool lookup service anthropic\n", 0o755},
+		"cache.sh":   {"#!/bin/sh\naws sts get-session-token > ~/.aws/session.json\ncat ~/.aws/session.json\n", 0o755},
+		"private.sh": {"#!/bin/sh\numask 077\naws sts get-session-token > ~/.aws/session.json\n", 0o755},
+		"plain.sh":   {"#!/bin/sh\necho key\n", 0o644},
+	}
+	for name, s := range scripts {
+		if err := os.WriteFile(filepath.Join(claudeDir, name), []byte(s.body), s.mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		data      map[string]any
+		rootPath  string
+		wantRules []string
+	}{
+		{
+			name:     "executable helper script",
+			data:     map[string]any{"apiKeyHelper": ".claude/key.sh"},
+			rootPath: root,
+		},
+		{
+			name:     "bare command on PATH",
+			data:     map[string]any{"awsAuthRefresh": "aws sso login --profile dev"},
+			rootPath: root,
+		},
+		{
+			name:      "not a string",
+			data:      map[string]any{"apiKeyHelper": []any{".claude/key.sh"}},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsCredentialHelper},
+		},
+		{
+			name:      "empty command",
+			data:      map[string]any{"awsCredentialExport": " "},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsCredentialHelper},
+		},
+		{
+			name:      "missing script",
+			data:      map[string]any{"apiKeyHelper": "~/bin/does-not-exist-key.sh"},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsCredentialHelper},
+		},
+		{
+			name:      "non-executable script",
+			data:      map[string]any{"apiKeyHelper": ".claude/plain.sh"},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsCredentialHelper},
+		},
+		{
+			name:      "inline write with default umask",
+			data:      map[string]any{"awsCredentialExport": "aws configure export-credentials | tee /tmp/creds.json"},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsCredentialExposure},
+		},
+		{
+			name:      "world-readable chmod",
+			data:      map[string]any{"awsAuthRefresh": "aws sso login && chmod 644 ~/.aws/sso/cache/*.json"},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsCredentialExposure},
+		},
+		{
+			name:     "stderr redirect is not a file write",
+			data:     map[string]any{"apiKeyHelper": "get-key 2>/dev/null || echo missing >&2"},
+			rootPath: root,
+		},
+		{
+			name:      "script writes credentials with default umask",
+			data:      map[string]any{"awsCredentialExport": "sh .claude/cache.sh"},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsCredentialExposure},
+		},
+		{
+			name:     "script restricts umask",
+			data:     map[string]any{"awsCredentialExport": ".claude/private.sh"},
+			rootPath: root,
+		},
+		{
+			name:     "scripts not checked without root",
+			data:     map[string]any{"apiKeyHelper": ".claude/missing.sh"},
+			rootPath: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateCredentialHelpers(tt.data, tt.rootPath, ".claude/settings.json", "{}")
+			if len(got) != len(tt.wantRules) {
+				t.Fatalf("validateCredentialHelpers() returned %d issues, want %d: %v", len(got), len(tt.wantRules), got)
+			}
+			for i, want := range tt.wantRules {
+				if got[i].Rule != want {
+					t.Errorf("issue %d rule = %q, want %q (%s)", i, got[i].Rule, want, got[i].Message)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// envReferencePattern matches ${VAR} references, including shell forms with
+// a modifier such as ${VAR:-default}.
+var envReferencePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)([:?+=-][^}]*)?\}`)
+
+// claudeEnvVariables are variables Claude Code sets for hook and MCP server
+// processes, plus the standard process variables every shell has.
+var claudeEnvVariables = map[string]bool{
+	"CLAUDE_PROJECT_DIR": true,
+	"CLAUDE_PLUGIN_ROOT": true,
+	"CLAUDE_PLUGIN_DATA": true,
+	"CLAUDE_ENV_FILE":    true,
+	"CLAUDE_CODE_REMOTE": true,
+	"CLAUDE_SESSION_ID":  true,
+	"HOME":               true,
+	"USER":               true,
+	"PATH":               true,
+	"PWD":                true,
+	"SHELL":              true,
+	"TMPDIR":             true,
+}
+
+// envReference is one ${VAR} occurrence and where it was found.
+type envReference struct {
+	Name     string
+	Raw      string
+	Location string
+	// Fallback is set for ${VAR:-default} and ${VAR=default}, which
+	// still expand when VAR is unset.
+	Fallback bool
+}
+
+// validateEnvReferences audits ${VAR} references in the env block and in
+// hook and MCP server configuration. A reference must name a variable the
+// env block defines, a Claude Code variable, or one listed in
+// templateVars, the rules.templateVariables config key; env values must
+// not reference each other in a cycle.
+func validateEnvReferences(data map[string]any, filePath, contents string, templateVars []string) []cue.ValidationError {
+	templateVariables := templateVariableSet(templateVars)
+	env, _ := data["env"].(map[string]any)
+
+	var refs []envReference
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		refs = append(refs, findEnvReferences(env[key], fmt.Sprintf("env '%s'", key))...)
+	}
+	refs = append(refs, hookEnvReferences(data["hooks"])...)
+	if servers, ok := data["mcpServers"].(map[string]any); ok {
+		for _, name := range slices.Sorted(maps.Keys(servers)) {
+			refs = append(refs, findEnvReferences(servers[name], fmt.Sprintf("mcpServers '%s'", name))...)
+		}
+	}
+
+	var errors []cue.ValidationError
+	reported := make(map[string]bool)
+	for _, ref := range refs {
+		if _, defined := env[ref.Name]; defined || ref.Fallback || reported[ref.Name] ||
+			claudeEnvVariables[ref.Name] || templateVariables[ref.Name] {
+			continue
+		}
+		reported[ref.Name] = true
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("%s: references ${%s}, which is not defined in the env block or a Claude Code variable; it expands empty unless set in the launching shell", ref.Location, ref.Name),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleSettingsEnvUndefined,
+			Line:     textutil.FindLineNumber(contents, ref.Raw),
+		})
+	}
+
+	for _, cycle := range envCycles(env) {
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("env values reference each other in a cycle: %s", strings.Join(cycle, " -> ")),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleSettingsEnvCycle,
+			Line:     FindJSONFieldLine(contents, cycle[0]),
+		})
+	}
+	return errors
+}
+
+// hookEnvReferences collects references from every hook entry, labelled the
+// way hook validation messages are.
+func hookEnvReferences(hooks any) []envReference {
+	events, ok := hooks.(map[string]any)
+	if !ok {
+		return nil
+	}
+	var refs []envReference
+	for _, event := range slices.Sorted(maps.Keys(events)) {
+		matchers, _ := events[event].([]any)
+		for i, m := range matchers {
+			matcher, _ := m.(map[string]any)
+			inner, _ := matcher["hooks"].([]any)
+			for j, hook := range inner {
+				location := fmt.Sprintf("Event '%s' hook %d inner hook %d", event, i, j)
+				refs = append(refs, findEnvReferences(hook, location)...)
+			}
+		}
+	}
+	return refs
+}
+
+// findEnvReferences walks a JSON value and returns the references in its
+// strings, in a stable order.
+func findEnvReferences(v any, location string) []envReference {
+	var refs []envReference
+	switch val := v.(type) {
+	case string:
+		for _, m := range envReferencePattern.FindAllStringSubmatch(val, -1) {
+			modifier := strings.TrimPrefix(m[2], ":")
+			refs = append(refs, envReference{
+				Name:     m[1],
+				Raw:      m[0],
+				Location: location,
+				Fallback: strings.HasPrefix(modifier, "-") || strings.HasPrefix(modifier, "="),
+			})
+		}
+	case []any:
+		for _, item := range val {
+			refs = append(refs, findEnvReferences(item, location)...)
+		}
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(val)) {
+			refs = append(refs, findEnvReferences(val[key], location)...)
+		}
+	}
+	return refs
+}
+
+// envCycles returns each cycle among env values that reference other env
+// keys, as the path of keys ending where it started. Each cycle is reported
+// once, starting from its alphabetically first key.
+func envCycles(env map[string]any) [][]string {
+	edges := make(map[string][]string, len(env))
+	for key, value := range env {
+		s, _ := value.(string)
+		for _, m := range envReferencePattern.FindAllStringSubmatch(s, -1) {
+			if _, ok := env[m[1]]; ok && !slices.Contains(edges[key], m[1]) {
+				edges[key] = append(edges[key], m[1])
+			}
+		}
+		slices.Sort(edges[key])
+	}
+
+	var cycles [][]string
+	seen := make(map[string]bool)
+	for _, start := range slices.Sorted(maps.Keys(edges)) {
+		path := []string{start}
+		var walk func(key string) bool
+		walk = func(key string) bool {
+			for _, next := range edges[key] {
+				if next == start {
+					cycles = append(cycles, append(slices.Clone(path), start))
+					return true
+				}
+				// Keys before start were already tried as cycle starts.
+				if next < start || slices.Contains(path, next) {
+					continue
+				}
+				path = append(path, next)
+				if walk(next) {
+					return true
+				}
+				path = path[:len(path)-1]
+			}
+			return false
+		}
+		if !seen[start] && walk(start) {
+			for _, key := range cycles[len(cycles)-1] {
+				seen[key] = true
+			}
+		}
+	}
+	return cycles
+}
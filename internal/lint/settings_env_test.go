@@ -0,0 +1,94 @@
+package lint
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestValidateEnvReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		want     []string // rule: message fragment, in order
+	}{
+		{
+			name: "defined and Claude variables",
+			settings: `{
+  "env": {"LOG_DIR": "${CLAUDE_PROJECT_DIR}/logs"},
+  "hooks": {"Stop": [{"hooks": [{"type": "command", "command": "tee ${LOG_DIR}/stop.log"}]}]}
+}`,
+		},
+		{
+			name: "fallback expansion",
+			settings: `{
+  "mcpServers": {"db": {"command": "db-mcp", "args": ["--url", "${DB_URL:-sqlite://local}"]}}
+}`,
+		},
+		{
+			name: "undefined in hook command",
+			settings: `{
+  "hooks": {"Stop": [{"hooks": [{"type": "command", "command": "notify ${SLACK_HOOK}"}]}]}
+}`,
+			want: []string{cue.RuleSettingsEnvUndefined + ": Event 'Stop' hook 0 inner hook 0: references ${SLACK_HOOK}"},
+		},
+		{
+			name: "undefined in MCP env reported once",
+			settings: `{
+  "mcpServers": {"gh": {"command": "gh-mcp", "env": {"TOKEN": "${GH_TOKEN}", "ALT": "${GH_TOKEN}"}}}
+}`,
+			want: []string{cue.RuleSettingsEnvUndefined + ": mcpServers 'gh': references ${GH_TOKEN}"},
+		},
+		{
+			name: "bare $VAR not audited",
+			settings: `{
+  "hooks": {"Stop": [{"hooks": [{"type": "command", "command": "echo $UNSET"}]}]}
+}`,
+		},
+		{
+			name: "env cycle",
+			settings: `{
+  "env": {"A": "${B}/x", "B": "${C}", "C": "${A}", "D": "${A}"}
+}`,
+			want: []string{cue.RuleSettingsEnvCycle + ": cycle: A -> B -> C -> A"},
+		},
+		{
+			name:     "self reference",
+			settings: `{"env": {"EXTRA_PATH": "${EXTRA_PATH}:/opt/bin"}}`,
+			want:     []string{cue.RuleSettingsEnvCycle + ": cycle: EXTRA_PATH -> EXTRA_PATH"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data map[string]any
+			if err := json.Unmarshal([]byte(tt.settings), &data); err != nil {
+				t.Fatal(err)
+			}
+			errors := validateEnvReferences(data, "settings.json", tt.settings, nil)
+			if len(errors) != len(tt.want) {
+				t.Fatalf("got %d issues, want %d: %+v", len(errors), len(tt.want), errors)
+			}
+			for i, want := range tt.want {
+				rule, fragment, _ := strings.Cut(want, ": ")
+				if errors[i].Rule != rule || !strings.Contains(errors[i].Message, fragment) {
+					t.Errorf("issue %d = %s %q, want %s containing %q", i, errors[i].Rule, errors[i].Message, rule, fragment)
+				}
+				if errors[i].Line == 0 {
+					t.Errorf("issue %d has no line", i)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateEnvReferencesTemplateVariables(t *testing.T) {
+	data := map[string]any{"mcpServers": map[string]any{
+		"gh": map[string]any{"command": "gh-mcp", "env": map[string]any{"TOKEN": "${GH_TOKEN}"}},
+	}}
+	if errors := validateEnvReferences(data, "settings.json", "", []string{"GH_TOKEN"}); len(errors) != 0 {
+		t.Errorf("allowlisted variable reported: %+v", errors)
+	}
+}
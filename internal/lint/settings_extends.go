@@ -0,0 +1,167 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// ResolveData merges the settings files the file extends under its own
+// values, so the chain is validated as the one settings file it stands
+// for. The extends key names a file or a list of files, relative to the
+// file that names it; later files override earlier ones, and the file's
+// own values override them all. The extends key, such as "extends", comes
+// from the rules.settingsExtends config key. Resolution is skipped when no
+// extends key is configured or RootPath is empty.
+func (l *SettingsLinter) ResolveData(filePath, contents string, data map[string]any) (map[string]any, []cue.ValidationError) {
+	key := l.Config().Rules.SettingsExtends
+	if key == "" || l.RootPath == "" {
+		return data, nil
+	}
+	path := filePath
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(l.RootPath, path)
+	}
+	r := &extendsResolver{rootPath: l.RootPath, filePath: filePath, key: key, allowJSONC: l.Config().Rules.JSONC}
+	merged := r.resolve(filepath.Clean(path), data, nil)
+	return merged, r.errors
+}
+
+// extendsResolver walks an extends chain, collecting the problems it finds
+// as findings on the settings file being linted.
+type extendsResolver struct {
+	rootPath string
+	filePath string
+	// key is the settings key that names the files a settings file
+	// extends.
+	key        string
+	allowJSONC bool
+	errors     []cue.ValidationError
+}
+
+// resolve returns data with the files it extends merged under it. path is
+// data's file, and stack the files that extend it, outermost first.
+func (r *extendsResolver) resolve(path string, data map[string]any, stack []string) map[string]any {
+	value, extends := data[r.key]
+	if !extends {
+		return data
+	}
+	stack = append(stack, path)
+	own := maps.Clone(data)
+	delete(own, r.key)
+
+	refs, ok := extendsRefs(value)
+	if !ok {
+		r.report(cue.RuleSettingsExtendsInvalid, "'%s'%s must name a settings file or a list of them", r.key, r.in(stack))
+		return own
+	}
+
+	merged := map[string]any{}
+	for _, ref := range refs {
+		target := ref
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		target = filepath.Clean(target)
+		if i := slices.Index(stack, target); i >= 0 {
+			chain := make([]string, 0, len(stack)-i+1)
+			for _, p := range stack[i:] {
+				chain = append(chain, r.display(p))
+			}
+			chain = append(chain, r.display(target))
+			r.report(cue.RuleSettingsExtendsCycle, "Settings extends cycle: %s", strings.Join(chain, " → "))
+			continue
+		}
+		raw, err := os.ReadFile(target) //nolint:gosec // G304: path named by the settings file being linted
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				r.report(cue.RuleSettingsExtendsMissing, "Extended settings file '%s'%s does not exist", r.display(target), r.in(stack))
+			} else {
+				r.report(cue.RuleSettingsExtendsMissing, "Extended settings file '%s'%s cannot be read: %v", r.display(target), r.in(stack), err)
+			}
+			continue
+		}
+		base, _, err := parseSettingsJSON(string(raw), r.allowJSONC)
+		if err != nil {
+			var pe *positionError
+			if errors.As(err, &pe) {
+				err = fmt.Errorf("%s at line %d, column %d", pe.msg, pe.line, pe.column)
+			}
+			r.report(cue.RuleSettingsExtendsInvalid, "Extended settings file '%s'%s is not valid: %v", r.display(target), r.in(stack), err)
+			continue
+		}
+		merged = mergeSettings(merged, r.resolve(target, base, stack))
+	}
+	return mergeSettings(merged, own)
+}
+
+// report adds a finding at the extends key of the linted file.
+func (r *extendsResolver) report(rule, format string, args ...any) {
+	r.errors = append(r.errors, cue.ValidationError{
+		File:     r.filePath,
+		Message:  fmt.Sprintf(format, args...),
+		Severity: cue.SeverityError,
+		Source:   cue.SourceCClintObserve,
+		Rule:     rule,
+		Pointer:  textutil.JSONPointer(r.key),
+	})
+}
+
+// in names the file a problem is in when it is not the linted file.
+func (r *extendsResolver) in(stack []string) string {
+	if len(stack) < 2 {
+		return ""
+	}
+	return " (in " + r.display(stack[len(stack)-1]) + ")"
+}
+
+// display returns path relative to the project root when it is inside it.
+func (r *extendsResolver) display(path string) string {
+	if rel, err := filepath.Rel(r.rootPath, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// extendsRefs returns the files an extends value names: a string, or a
+// list of strings.
+func extendsRefs(v any) ([]string, bool) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, v != ""
+	case []any:
+		refs := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok || s == "" {
+				return nil, false
+			}
+			refs = append(refs, s)
+		}
+		return refs, true
+	}
+	return nil, false
+}
+
+// mergeSettings returns base with over's values on top. Objects merge key
+// by key; any other value in over, lists included, replaces base's.
+func mergeSettings(base, over map[string]any) map[string]any {
+	merged := maps.Clone(base)
+	for k, v := range over {
+		if vm, ok := v.(map[string]any); ok {
+			if bm, ok := merged[k].(map[string]any); ok {
+				merged[k] = mergeSettings(bm, vm)
+				continue
+			}
+		}
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,196 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestMergeSettings(t *testing.T) {
+	base := map[string]any{
+		"model":       "haiku",
+		"permissions": map[string]any{"allow": []any{"Read"}, "deny": []any{"Bash(rm:*)"}},
+		"env":         map[string]any{"A": "1"},
+	}
+	over := map[string]any{
+		"model":       "sonnet",
+		"permissions": map[string]any{"allow": []any{"Grep"}},
+		"env":         "not an object",
+	}
+	want := map[string]any{
+		"model":       "sonnet",
+		"permissions": map[string]any{"allow": []any{"Grep"}, "deny": []any{"Bash(rm:*)"}},
+		"env":         "not an object",
+	}
+	if got := mergeSettings(base, over); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeSettings() = %v, want %v", got, want)
+	}
+	if _, ok := base["permissions"].(map[string]any)["allow"].([]any); !ok || len(base["permissions"].(map[string]any)["allow"].([]any)) != 1 {
+		t.Errorf("mergeSettings() changed base: %v", base)
+	}
+}
+
+func TestSettingsResolveData(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		files    map[string]string
+		want     map[string]any
+		wantRule string
+		wantMsg  string
+	}{
+		{
+			name: "off by default",
+			files: map[string]string{
+				".claude/settings.json": `{"extends": "base.json", "model": "sonnet"}`,
+			},
+			want: map[string]any{"extends": "base.json", "model": "sonnet"},
+		},
+		{
+			name: "chain merged under the file",
+			key:  "extends",
+			files: map[string]string{
+				".claude/settings.json": `{"extends": ["team.json", "local.json"], "model": "sonnet"}`,
+				".claude/team.json":     `{"extends": "../shared/org.json", "model": "haiku", "env": {"TEAM": "1"}}`,
+				".claude/local.json":    `{"env": {"LOCAL": "1"}}`,
+				"shared/org.json":       `{"permissions": {"allow": ["Read"]}, "env": {"ORG": "1"}}`,
+			},
+			want: map[string]any{
+				"model":       "sonnet",
+				"permissions": map[string]any{"allow": []any{"Read"}},
+				"env":         map[string]any{"ORG": "1", "TEAM": "1", "LOCAL": "1"},
+			},
+		},
+		{
+			name: "custom key",
+			key:  "$include",
+			files: map[string]string{
+				".claude/settings.json": `{"$include": "base.json"}`,
+				".claude/base.json":     `{"model": "opus"}`,
+			},
+			want: map[string]any{"model": "opus"},
+		},
+		{
+			name: "missing file",
+			key:  "extends",
+			files: map[string]string{
+				".claude/settings.json": `{"extends": "nope.json", "model": "sonnet"}`,
+			},
+			want:     map[string]any{"model": "sonnet"},
+			wantRule: cue.RuleSettingsExtendsMissing,
+			wantMsg:  "Extended settings file '.claude/nope.json' does not exist",
+		},
+		{
+			name: "missing file further down the chain",
+			key:  "extends",
+			files: map[string]string{
+				".claude/settings.json": `{"extends": "base.json"}`,
+				".claude/base.json":     `{"extends": "gone.json", "model": "haiku"}`,
+			},
+			want:     map[string]any{"model": "haiku"},
+			wantRule: cue.RuleSettingsExtendsMissing,
+			wantMsg:  "Extended settings file '.claude/gone.json' (in .claude/base.json) does not exist",
+		},
+		{
+			name: "invalid JSON",
+			key:  "extends",
+			files: map[string]string{
+				".claude/settings.json": `{"extends": "base.json"}`,
+				".claude/base.json":     "{\n  \"model\": \"haiku\",\n}\n",
+			},
+			want:     map[string]any{},
+			wantRule: cue.RuleSettingsExtendsInvalid,
+			wantMsg:  "Extended settings file '.claude/base.json' is not valid: invalid JSON",
+		},
+		{
+			name: "value that is not a path",
+			key:  "extends",
+			files: map[string]string{
+				".claude/settings.json": `{"extends": ["base.json", 3]}`,
+			},
+			want:     map[string]any{},
+			wantRule: cue.RuleSettingsExtendsInvalid,
+			wantMsg:  "'extends' must name a settings file or a list of them",
+		},
+		{
+			name: "cycle",
+			key:  "extends",
+			files: map[string]string{
+				".claude/settings.json": `{"extends": "a.json"}`,
+				".claude/a.json":        `{"extends": "b.json", "model": "haiku"}`,
+				".claude/b.json":        `{"extends": "a.json"}`,
+			},
+			want:     map[string]any{"model": "haiku"},
+			wantRule: cue.RuleSettingsExtendsCycle,
+			wantMsg:  "Settings extends cycle: .claude/a.json → .claude/b.json → .claude/a.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Rules.SettingsExtends = tt.key
+			root := t.TempDir()
+			for path, contents := range tt.files {
+				full := filepath.Join(root, path)
+				if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(full, []byte(contents), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			contents := tt.files[".claude/settings.json"]
+			data, _, err := parseSettingsJSON(contents, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, errs := NewSettingsLinter(root, cfg).ResolveData(".claude/settings.json", contents, data)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("data = %v, want %v", got, tt.want)
+			}
+			if tt.wantRule == "" {
+				if len(errs) != 0 {
+					t.Errorf("errors = %v, want none", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Rule != tt.wantRule || !strings.Contains(errs[0].Message, tt.wantMsg) {
+				t.Fatalf("errors = %v, want one %s containing %q", errs, tt.wantRule, tt.wantMsg)
+			}
+			if errs[0].File != ".claude/settings.json" || errs[0].Pointer != "/"+tt.key {
+				t.Errorf("finding at %s%s, want the %s key of .claude/settings.json", errs[0].File, errs[0].Pointer, tt.key)
+			}
+		})
+	}
+}
+
+func TestSettingsExtendsValidatesMergedData(t *testing.T) {
+	cfg := config.Default()
+	cfg.Rules.SettingsExtends = "extends"
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".claude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	contents := "{\n  \"extends\": \"missing.json\",\n  \"model\": \"sonnet\"\n}\n"
+	if err := os.WriteFile(filepath.Join(root, ".claude/base.json"), []byte(`{"statusLine": {"type": "bogus"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result := lintFileCore(".claude/settings.json", contents, NewSettingsLinter(root, cfg), cue.NewValidator(), nil)
+	if len(result.Errors) != 1 || result.Errors[0].Rule != cue.RuleSettingsExtendsMissing || result.Errors[0].Line != 2 {
+		t.Errorf("errors = %v, want one %s on line 2", result.Errors, cue.RuleSettingsExtendsMissing)
+	}
+
+	contents = strings.Replace(contents, "missing.json", "base.json", 1)
+	result = lintFileCore(".claude/settings.json", contents, NewSettingsLinter(root, cfg), cue.NewValidator(), nil)
+	if result.Success {
+		t.Errorf("errors = %v, want the base file's statusLine to be validated", result.Errors)
+	}
+}
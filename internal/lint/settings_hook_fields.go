@@ -0,0 +1,222 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// defaultHookTimeoutMax is the hook timeout, in seconds, past which a hook
+// is flagged: the session waits on a blocking hook for the whole timeout.
+const defaultHookTimeoutMax = 600
+
+// hookFieldKind is the JSON shape a hook field must have.
+type hookFieldKind int
+
+const (
+	hookFieldString hookFieldKind = iota
+	hookFieldBool
+	hookFieldStringList
+	hookFieldStringMap
+	hookFieldTimeout
+)
+
+// knownHookFields mirrors the #HookCommand schema: every key a hook entry
+// may carry and the shape of its value.
+var knownHookFields = map[string]hookFieldKind{
+	"type":            hookFieldString,
+	"command":         hookFieldString,
+	"args":            hookFieldStringList,
+	"prompt":          hookFieldString,
+	"url":             hookFieldString,
+	"headers":         hookFieldStringMap,
+	"allowedEnvVars":  hookFieldStringList,
+	"statusMessage":   hookFieldString,
+	"async":           hookFieldBool,
+	"asyncRewake":     hookFieldBool,
+	"rewakeMessage":   hookFieldString,
+	"rewakeSummary":   hookFieldString,
+	"timeout":         hookFieldTimeout,
+	"once":            hookFieldBool,
+	"continueOnBlock": hookFieldBool,
+	"if":              hookFieldString,
+}
+
+// hookFieldTypes restricts fields that only one hook type understands.
+var hookFieldTypes = map[string]string{
+	"args":           cue.TypeCommand,
+	"async":          cue.TypeCommand,
+	"asyncRewake":    cue.TypeCommand,
+	"rewakeMessage":  cue.TypeCommand,
+	"rewakeSummary":  cue.TypeCommand,
+	"headers":        cue.TypeHTTP,
+	"allowedEnvVars": cue.TypeHTTP,
+}
+
+// syncOnlyHookEvents are events whose hook output is the result (a
+// permission decision, rewritten message text, a worktree path). An async
+// hook returns before producing it, so the output is dropped.
+var syncOnlyHookEvents = map[string]bool{
+	"PermissionRequest": true,
+	"MessageDisplay":    true,
+	"WorktreeCreate":    true,
+	"Elicitation":       true,
+}
+
+// issue builds a validation error located at this hook entry.
+func (c hookContext) issue(severity, rule, msg string) cue.ValidationError {
+	source := cue.SourceAnthropicDocs
+	if severity == cue.SeveritySuggestion {
+		source = cue.SourceCClintObserve
+	}
+	return cue.ValidationError{
+		File:     c.FilePath,
+		Message:  fmt.Sprintf("Event '%s' hook %d inner hook %d: %s", c.EventName, c.HookIdx, c.InnerIdx, msg),
+		Severity: severity,
+		Source:   source,
+		Rule:     rule,
+	}
+}
+
+// validateInnerHookFields checks the optional fields of a hook entry: value
+// shapes, fields used with the wrong hook type or event, the timeout range,
+// and keys the schema does not know.
+func validateInnerHookFields(hookMap map[string]any, hookType string, ctx hookContext) []cue.ValidationError {
+	var errors []cue.ValidationError
+	for _, key := range slices.Sorted(maps.Keys(hookMap)) {
+		errors = append(errors, withPointer(validateInnerHookField(hookMap[key], key, hookType, ctx), ctx.pointer(key))...)
+	}
+
+	if async, _ := hookMap["async"].(bool); async && hookType == cue.TypeCommand && syncOnlyHookEvents[ctx.EventName] {
+		errors = append(errors, withPointer([]cue.ValidationError{ctx.issue(cue.SeverityWarning, cue.RuleHookFieldInvalid,
+			fmt.Sprintf("event '%s' does not support async hooks; its hook output is discarded when the hook runs in the background", ctx.EventName))},
+			ctx.pointer("async"))...)
+	}
+	if _, ok := hookMap["continueOnBlock"]; ok && ctx.EventName != "PostToolUse" {
+		errors = append(errors, withPointer([]cue.ValidationError{ctx.issue(cue.SeverityError, cue.RuleHookFieldInvalid,
+			"'continueOnBlock' is only valid for PostToolUse hooks")},
+			ctx.pointer("continueOnBlock"))...)
+	}
+	return errors
+}
+
+// validateInnerHookField checks one field of a hook entry: that it is
+// known, allowed for the hook type, and of the right shape.
+func validateInnerHookField(v any, key, hookType string, ctx hookContext) []cue.ValidationError {
+	kind, known := knownHookFields[key]
+	if !known {
+		return []cue.ValidationError{unknownHookField(key, ctx)}
+	}
+	if want, ok := hookFieldTypes[key]; ok && want != hookType {
+		return []cue.ValidationError{ctx.issue(cue.SeverityError, cue.RuleHookFieldInvalid,
+			fmt.Sprintf("'%s' is only valid for type '%s' hooks", key, want))}
+	}
+	if kind == hookFieldTimeout {
+		return validateHookTimeout(v, ctx)
+	}
+	if !hookFieldHasKind(v, kind) {
+		return []cue.ValidationError{ctx.issue(cue.SeverityError, cue.RuleHookFieldInvalid,
+			fmt.Sprintf("'%s' must be %s", key, hookFieldKindLabel(kind)))}
+	}
+	return nil
+}
+
+// validateHookTimeout requires a positive whole number of seconds and flags
+// timeouts over rules.hookTimeoutMax.
+func validateHookTimeout(v any, ctx hookContext) []cue.ValidationError {
+	seconds, ok := hookTimeoutSeconds(v)
+	if !ok || seconds <= 0 {
+		return []cue.ValidationError{ctx.issue(cue.SeverityError, cue.RuleHookTimeout,
+			fmt.Sprintf("'timeout' must be a positive integer number of seconds, got %v", v))}
+	}
+	hookTimeoutMax := ctx.Config.Rules.HookTimeoutMax
+	if hookTimeoutMax <= 0 {
+		hookTimeoutMax = defaultHookTimeoutMax
+	}
+	if seconds > hookTimeoutMax {
+		return []cue.ValidationError{ctx.issue(cue.SeverityWarning, cue.RuleHookTimeout,
+			fmt.Sprintf("timeout of %ds exceeds the %ds limit (rules.hookTimeoutMax); a stuck hook blocks the session that long", seconds, hookTimeoutMax))}
+	}
+	return nil
+}
+
+// hookTimeoutSeconds reads a whole-number timeout from JSON (float64) or
+// YAML (int) input.
+func hookTimeoutSeconds(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case uint64:
+		return int(min(n, math.MaxInt32)), true
+	case float64:
+		if n != math.Trunc(n) {
+			return 0, false
+		}
+		return int(max(min(n, math.MaxInt32), math.MinInt32)), true
+	}
+	return 0, false
+}
+
+func unknownHookField(key string, ctx hookContext) cue.ValidationError {
+	names := slices.Sorted(maps.Keys(knownHookFields))
+	msg := fmt.Sprintf("unknown field '%s'", key)
+	if match, ok := textutil.ClosestMatch(key, names); ok {
+		msg += fmt.Sprintf(" (did you mean '%s'?)", match)
+	}
+	msg += ". Valid fields: " + strings.Join(names, ", ")
+	return ctx.issue(cue.SeveritySuggestion, cue.RuleHookFieldUnknown, msg)
+}
+
+func hookFieldHasKind(v any, kind hookFieldKind) bool {
+	switch kind {
+	case hookFieldString:
+		_, ok := v.(string)
+		return ok
+	case hookFieldBool:
+		_, ok := v.(bool)
+		return ok
+	case hookFieldStringList:
+		items, ok := v.([]any)
+		if !ok {
+			return false
+		}
+		for _, item := range items {
+			if _, ok := item.(string); !ok {
+				return false
+			}
+		}
+		return true
+	case hookFieldStringMap:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return false
+		}
+		for _, val := range m {
+			if _, ok := val.(string); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+func hookFieldKindLabel(kind hookFieldKind) string {
+	switch kind {
+	case hookFieldBool:
+		return "a boolean"
+	case hookFieldStringList:
+		return "an array of strings"
+	case hookFieldStringMap:
+		return "an object of string values"
+	default:
+		return "a string"
+	}
+}
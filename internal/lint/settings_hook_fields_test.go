@@ -0,0 +1,98 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestValidateInnerHookFields(t *testing.T) {
+	hook := func(event string, hookFields map[string]any) map[string]any {
+		return map[string]any{
+			event: []any{
+				map[string]any{
+					"matcher": "Bash",
+					"hooks":   []any{hookFields},
+				},
+			},
+		}
+	}
+	cmd := func(extra map[string]any) map[string]any {
+		fields := map[string]any{"type": "command", "command": "echo hi"}
+		for k, v := range extra {
+			fields[k] = v
+		}
+		return fields
+	}
+
+	tests := []struct {
+		name         string
+		hooks        map[string]any
+		wantRule     string
+		wantSeverity string
+		wantMessage  string
+	}{
+		{name: "valid optional fields", hooks: hook("PostToolUse", cmd(map[string]any{
+			"timeout": float64(60), "statusMessage": "Checking", "once": true, "if": "Bash(git *)", "continueOnBlock": true,
+		}))},
+		{name: "YAML int timeout", hooks: hook("PreToolUse", cmd(map[string]any{"timeout": 45}))},
+		{name: "timeout at limit", hooks: hook("PreToolUse", cmd(map[string]any{"timeout": float64(600)}))},
+		{name: "zero timeout", hooks: hook("PreToolUse", cmd(map[string]any{"timeout": float64(0)})),
+			wantRule: cue.RuleHookTimeout, wantSeverity: cue.SeverityError, wantMessage: "must be a positive integer"},
+		{name: "fractional timeout", hooks: hook("PreToolUse", cmd(map[string]any{"timeout": 1.5})),
+			wantRule: cue.RuleHookTimeout, wantSeverity: cue.SeverityError, wantMessage: "must be a positive integer"},
+		{name: "string timeout", hooks: hook("PreToolUse", cmd(map[string]any{"timeout": "30"})),
+			wantRule: cue.RuleHookTimeout, wantSeverity: cue.SeverityError, wantMessage: "must be a positive integer"},
+		{name: "timeout over limit", hooks: hook("PreToolUse", cmd(map[string]any{"timeout": float64(3600)})),
+			wantRule: cue.RuleHookTimeout, wantSeverity: cue.SeverityWarning, wantMessage: "exceeds the 600s limit"},
+		{name: "non-bool async", hooks: hook("PostToolUse", cmd(map[string]any{"async": "yes"})),
+			wantRule: cue.RuleHookFieldInvalid, wantSeverity: cue.SeverityError, wantMessage: "'async' must be a boolean"},
+		{name: "async on prompt hook", hooks: hook("Stop", map[string]any{"type": "prompt", "prompt": "check", "async": true}),
+			wantRule: cue.RuleHookFieldInvalid, wantSeverity: cue.SeverityError, wantMessage: "only valid for type 'command'"},
+		{name: "async on sync-only event", hooks: hook("PermissionRequest", cmd(map[string]any{"async": true})),
+			wantRule: cue.RuleHookFieldInvalid, wantSeverity: cue.SeverityWarning, wantMessage: "does not support async hooks"},
+		{name: "non-string statusMessage", hooks: hook("PostToolUse", cmd(map[string]any{"statusMessage": 3.0})),
+			wantRule: cue.RuleHookFieldInvalid, wantSeverity: cue.SeverityError, wantMessage: "'statusMessage' must be a string"},
+		{name: "headers on command hook", hooks: hook("PostToolUse", cmd(map[string]any{"headers": map[string]any{}})),
+			wantRule: cue.RuleHookFieldInvalid, wantSeverity: cue.SeverityError, wantMessage: "only valid for type 'http'"},
+		{name: "continueOnBlock outside PostToolUse", hooks: hook("PreToolUse", cmd(map[string]any{"continueOnBlock": true})),
+			wantRule: cue.RuleHookFieldInvalid, wantSeverity: cue.SeverityError, wantMessage: "only valid for PostToolUse"},
+		{name: "unknown field", hooks: hook("PostToolUse", cmd(map[string]any{"timout": float64(30)})),
+			wantRule: cue.RuleHookFieldUnknown, wantSeverity: cue.SeveritySuggestion, wantMessage: "did you mean 'timeout'?). Valid fields: allowedEnvVars"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := validateHooks(tt.hooks, "settings.json", defaultConfig())
+			if tt.wantRule == "" {
+				for _, err := range errors {
+					t.Errorf("unexpected issue: %s: %s", err.Severity, err.Message)
+				}
+				return
+			}
+			if len(errors) != 1 {
+				t.Fatalf("got %d issues, want 1: %+v", len(errors), errors)
+			}
+			got := errors[0]
+			if got.Rule != tt.wantRule || got.Severity != tt.wantSeverity || !strings.Contains(got.Message, tt.wantMessage) {
+				t.Errorf("got %s/%s %q, want %s/%s containing %q", got.Rule, got.Severity, got.Message, tt.wantRule, tt.wantSeverity, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestValidateHookTimeout_Limit(t *testing.T) {
+	cfg := config.Default()
+	cfg.Rules.HookTimeoutMax = 60
+	ctx := hookContext{EventName: "Stop", FilePath: "settings.json", Config: cfg}
+	if errs := validateHookTimeout(float64(90), ctx); len(errs) != 1 || !strings.Contains(errs[0].Message, "60s limit") {
+		t.Errorf("timeout 90 with limit 60: got %+v", errs)
+	}
+
+	ctx.Config = defaultConfig()
+	if errs := validateHookTimeout(float64(90), ctx); len(errs) != 0 {
+		t.Errorf("timeout 90 with default limit: got %+v", errs)
+	}
+}
@@ -3,21 +3,24 @@ package lint
 import (
 	"fmt"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
 )
 
-// validateHooks validates hooks for settings (full event set)
-func validateHooks(hooks any, filePath string) []cue.ValidationError {
-	return validateHooksWithEvents(hooks, filePath, validHookEvents, eventLabel(validHookEvents))
+// validateHooks validates hooks for settings (full event set). cfg supplies
+// the hook timeout limit and untrusted mode.
+func validateHooks(hooks any, filePath string, cfg *config.Config) []cue.ValidationError {
+	return validateHooksWithEvents(hooks, filePath, validHookEvents, eventLabel(validHookEvents), cfg)
 }
 
 // ValidateComponentHooks validates hooks for agents and skills (scoped event set)
-func ValidateComponentHooks(hooks any, filePath string) []cue.ValidationError {
-	return validateHooksWithEvents(hooks, filePath, validComponentHookEvents, eventLabel(validComponentHookEvents))
+func ValidateComponentHooks(hooks any, filePath string, cfg *config.Config) []cue.ValidationError {
+	return validateHooksWithEvents(hooks, filePath, validComponentHookEvents, eventLabel(validComponentHookEvents), cfg)
 }
 
 // validateHooksWithEvents validates the hooks section with specified allowed events
-func validateHooksWithEvents(hooks any, filePath string, allowedEvents map[string]bool, eventLabel string) []cue.ValidationError {
+func validateHooksWithEvents(hooks any, filePath string, allowedEvents map[string]bool, eventLabel string, cfg *config.Config) []cue.ValidationError {
 	var errors []cue.ValidationError
 
 	hooksMap, ok := hooks.(map[string]any)
@@ -27,25 +30,28 @@ func validateHooksWithEvents(hooks any, filePath string, allowedEvents map[strin
 			Message:  "hooks must be an object mapping event names to hook configurations",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookFieldInvalid,
 		})
 		return errors
 	}
 
 	// Validate each event name and its hooks
 	for eventName, eventConfig := range hooksMap {
-		errors = append(errors, validateHookEvent(eventName, eventConfig, filePath, allowedEvents, eventLabel)...)
+		errors = append(errors, withPointer(validateHookEvent(eventName, eventConfig, filePath, allowedEvents, eventLabel, cfg),
+			textutil.JSONPointer("hooks", eventName))...)
 	}
 
 	return errors
 }
 
-func validateHookEvent(eventName string, eventConfig any, filePath string, allowedEvents map[string]bool, eventLabel string) []cue.ValidationError {
+func validateHookEvent(eventName string, eventConfig any, filePath string, allowedEvents map[string]bool, eventLabel string, cfg *config.Config) []cue.ValidationError {
 	if !allowedEvents[eventName] {
 		return []cue.ValidationError{{
 			File:     filePath,
 			Message:  fmt.Sprintf("Unknown hook event '%s'. Valid events: %s", eventName, eventLabel),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookEventUnknown,
 		}}
 	}
 
@@ -56,19 +62,21 @@ func validateHookEvent(eventName string, eventConfig any, filePath string, allow
 			Message:  fmt.Sprintf("Event '%s': hook configuration must be an array", eventName),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookFieldInvalid,
 		}}
 	}
 
 	var errors []cue.ValidationError
 	for i, hookMatcher := range hookArray {
-		errors = append(errors, validateHookMatcher(hookMatcher, eventName, i, filePath)...)
+		errors = append(errors, withPointer(validateHookMatcher(hookMatcher, eventName, i, filePath, cfg),
+			textutil.JSONPointer("hooks", eventName, i))...)
 	}
 
 	return errors
 }
 
 // validateHookMatcher validates a single hook matcher entry within an event.
-func validateHookMatcher(hookMatcher any, eventName string, idx int, filePath string) []cue.ValidationError {
+func validateHookMatcher(hookMatcher any, eventName string, idx int, filePath string, cfg *config.Config) []cue.ValidationError {
 	var errors []cue.ValidationError
 
 	hookMatcherMap, ok := hookMatcher.(map[string]any)
@@ -78,11 +86,12 @@ func validateHookMatcher(hookMatcher any, eventName string, idx int, filePath st
 			Message:  fmt.Sprintf("Event '%s' hook %d: must be an object with 'matcher' and 'hooks' fields", eventName, idx),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookFieldInvalid,
 		}}
 	}
 
 	// Check for required 'matcher' field and validate toolName if present
-	errors = append(errors, validateHookMatcherField(hookMatcherMap, eventName, idx, filePath)...)
+	errors = append(errors, validateHookMatcherField(hookMatcherMap, eventName, idx, filePath, cfg.ExtraTools)...)
 
 	// Check for required 'hooks' field
 	innerHooks, exists := hookMatcherMap["hooks"]
@@ -92,6 +101,7 @@ func validateHookMatcher(hookMatcher any, eventName string, idx int, filePath st
 			Message:  fmt.Sprintf("Event '%s' hook %d: missing required field 'hooks'", eventName, idx),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookFieldInvalid,
 		})
 	}
 
@@ -102,11 +112,13 @@ func validateHookMatcher(hookMatcher any, eventName string, idx int, filePath st
 			Message:  fmt.Sprintf("Event '%s' hook %d: 'hooks' field must be an array", eventName, idx),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookFieldInvalid,
 		})
 	}
 
 	for j, innerHook := range innerHooksArray {
-		errors = append(errors, validateInnerHook(innerHook, eventName, idx, j, filePath)...)
+		errors = append(errors, withPointer(validateInnerHook(innerHook, eventName, idx, j, filePath, cfg),
+			textutil.JSONPointer("hooks", eventName, idx, "hooks", j))...)
 	}
 
 	return errors
@@ -114,7 +126,7 @@ func validateHookMatcher(hookMatcher any, eventName string, idx int, filePath st
 
 // validateHookMatcherField validates the matcher field of a hook matcher entry.
 // 'matcher' is required only for tool-scoped events; lifecycle events omit it.
-func validateHookMatcherField(hookMatcherMap map[string]any, eventName string, idx int, filePath string) []cue.ValidationError {
+func validateHookMatcherField(hookMatcherMap map[string]any, eventName string, idx int, filePath string, extraTools []string) []cue.ValidationError {
 	matcherVal, matcherExists := hookMatcherMap["matcher"]
 	if !matcherExists {
 		if matcherRequiredEvents[eventName] {
@@ -123,6 +135,7 @@ func validateHookMatcherField(hookMatcherMap map[string]any, eventName string, i
 				Message:  fmt.Sprintf("Event '%s' hook %d: missing required field 'matcher'", eventName, idx),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleHookFieldInvalid,
 			}}
 		}
 		return nil
@@ -144,17 +157,20 @@ func validateHookMatcherField(hookMatcherMap map[string]any, eventName string, i
 	}
 
 	location := fmt.Sprintf("Event '%s' hook %d matcher", eventName, idx)
-	return validateMatcherToolName(toolNameStr, location, filePath)
+	return withPointer(validateMatcherToolName(toolNameStr, location, filePath, extraTools),
+		textutil.JSONPointer("hooks", eventName, idx, "matcher", "toolName"))
 }
 
-// validateInnerHook validates a single inner hook entry (type, command/prompt fields).
-func validateInnerHook(innerHook any, eventName string, hookIdx, innerIdx int, filePath string) []cue.ValidationError {
+// validateInnerHook validates a single inner hook entry (type, command/prompt
+// fields, then the optional fields).
+func validateInnerHook(innerHook any, eventName string, hookIdx, innerIdx int, filePath string, cfg *config.Config) []cue.ValidationError {
 	fail := func(msg string) []cue.ValidationError {
 		return []cue.ValidationError{{
 			File:     filePath,
 			Message:  fmt.Sprintf("Event '%s' hook %d inner hook %d: %s", eventName, hookIdx, innerIdx, msg),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookFieldInvalid,
 		}}
 	}
 
@@ -177,8 +193,9 @@ func validateInnerHook(innerHook any, eventName string, hookIdx, innerIdx int, f
 		return fail(fmt.Sprintf("invalid type '%s'. Valid types: command, prompt, agent, http", hookTypeStr))
 	}
 
-	hookCtx := hookContext{EventName: eventName, HookIdx: hookIdx, InnerIdx: innerIdx, FilePath: filePath}
-	return validateInnerHookType(innerHookMap, hookTypeStr, hookCtx)
+	hookCtx := hookContext{EventName: eventName, HookIdx: hookIdx, InnerIdx: innerIdx, FilePath: filePath, Config: cfg}
+	errors := validateInnerHookType(innerHookMap, hookTypeStr, hookCtx)
+	return append(errors, validateInnerHookFields(innerHookMap, hookTypeStr, hookCtx)...)
 }
 
 // hookContext holds context information for hook validation
@@ -187,6 +204,14 @@ type hookContext struct {
 	HookIdx   int
 	InnerIdx  int
 	FilePath  string
+	// Config supplies the rule settings hook checks read.
+	Config *config.Config
+}
+
+// pointer returns the JSON Pointer of this hook entry, or of one of its
+// fields, within the data holding the hooks key.
+func (c hookContext) pointer(field ...any) string {
+	return textutil.JSONPointer(append([]any{"hooks", c.EventName, c.HookIdx, "hooks", c.InnerIdx}, field...)...)
 }
 
 // validateInnerHookType validates type-specific requirements for a hook entry.
@@ -216,6 +241,7 @@ func validateCommandInnerHook(hookMap map[string]any, ctx hookContext) []cue.Val
 			Message:  fmt.Sprintf("Event '%s' hook %d inner hook %d: type 'command' requires 'command' or 'args' field", ctx.EventName, ctx.HookIdx, ctx.InnerIdx),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookFieldInvalid,
 		}}
 	}
 
@@ -239,6 +265,7 @@ func validatePromptInnerHook(hookMap map[string]any, ctx hookContext) []cue.Vali
 			Message:  fmt.Sprintf("Event '%s' hook %d inner hook %d: event '%s' does not support prompt hooks. Prompt hooks only supported for: Stop, SubagentStop, UserPromptSubmit, PreToolUse, PermissionRequest", ctx.EventName, ctx.HookIdx, ctx.InnerIdx, ctx.EventName),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookFieldInvalid,
 		})
 	}
 	if _, exists := hookMap["prompt"]; !exists {
@@ -247,13 +274,17 @@ func validatePromptInnerHook(hookMap map[string]any, ctx hookContext) []cue.Vali
 			Message:  fmt.Sprintf("Event '%s' hook %d inner hook %d: type 'prompt' requires 'prompt' field", ctx.EventName, ctx.HookIdx, ctx.InnerIdx),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookFieldInvalid,
 		})
 	}
 	return errors
 }
 
 func validateHTTPInnerHook(hookMap map[string]any, ctx hookContext) []cue.ValidationError {
-	if _, exists := hookMap["url"]; exists {
+	if url, exists := hookMap["url"]; exists {
+		if ctx.Config.Untrusted {
+			return untrustedHTTPHook(url, ctx)
+		}
 		return nil
 	}
 
@@ -262,5 +293,6 @@ func validateHTTPInnerHook(hookMap map[string]any, ctx hookContext) []cue.Valida
 		Message:  fmt.Sprintf("Event '%s' hook %d inner hook %d: type 'http' requires 'url' field", ctx.EventName, ctx.HookIdx, ctx.InnerIdx),
 		Severity: cue.SeverityError,
 		Source:   cue.SourceAnthropicDocs,
+		Rule:     cue.RuleHookFieldInvalid,
 	}}
 }
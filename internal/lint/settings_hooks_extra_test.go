@@ -77,7 +77,7 @@ func TestValidateHooksValidEvents(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := validateHooks(tt.hooks, "settings.json")
+			errors := validateHooks(tt.hooks, "settings.json", defaultConfig())
 			if len(errors) != tt.wantErrorCount {
 				t.Errorf("validateHooks() error count = %d, want %d", len(errors), tt.wantErrorCount)
 				for _, err := range errors {
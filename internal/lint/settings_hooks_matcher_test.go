@@ -124,7 +124,7 @@ func TestValidateHooksWithToolNameMatcher(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := validateHooks(tt.hooks, "settings.json")
+			errors := validateHooks(tt.hooks, "settings.json", defaultConfig())
 			if len(errors) != tt.wantErrorCount {
 				t.Errorf("validateHooks() error count = %d, want %d", len(errors), tt.wantErrorCount)
 				for _, err := range errors {
@@ -207,7 +207,7 @@ func TestValidateComponentHooks(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := ValidateComponentHooks(tt.hooks, "agent.md")
+			errors := ValidateComponentHooks(tt.hooks, "agent.md", defaultConfig())
 			if len(errors) != tt.wantErrorCount {
 				t.Errorf("ValidateComponentHooks() error count = %d, want %d", len(errors), tt.wantErrorCount)
 				for _, err := range errors {
@@ -249,7 +249,7 @@ func TestMatcherOptionalForLifecycleEvents(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := validateHooks(tt.hooks, "settings.json")
+			errors := validateHooks(tt.hooks, "settings.json", defaultConfig())
 			if len(errors) != tt.wantErrorCount {
 				t.Errorf("validateHooks() error count = %d, want %d", len(errors), tt.wantErrorCount)
 				for _, err := range errors {
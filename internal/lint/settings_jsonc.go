@@ -0,0 +1,30 @@
+package lint
+
+import (
+	"encoding/json"
+
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// parseSettingsJSON parses a settings file. allowJSONC, the rules.jsonc
+// config key, lets it use JSONC: // and /* */ comments and trailing commas.
+// With JSONC allowed, comments and trailing commas are blanked out first; blanking keeps every other
+// character in place, so error positions and the line lookups of later
+// checks still match the file. Without it, a file that is only invalid
+// because of them gets a hint pointing at rules.jsonc.
+func parseSettingsJSON(contents string, allowJSONC bool) (map[string]any, string, error) {
+	blanked, isJSONC := textutil.BlankJSONC(contents)
+	parsed := contents
+	if allowJSONC {
+		parsed = blanked
+	}
+	var data map[string]any
+	if err := json.Unmarshal([]byte(parsed), &data); err != nil {
+		hint := ""
+		if !allowJSONC && isJSONC && json.Valid([]byte(blanked)) {
+			hint = " (comments and trailing commas are not JSON; set rules.jsonc: true to accept them)"
+		}
+		return nil, "", newJSONError(parsed, err, hint)
+	}
+	return data, "", nil
+}
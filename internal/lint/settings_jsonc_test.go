@@ -0,0 +1,70 @@
+package lint
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+const jsoncSettings = `{
+  // Default model for this project
+  "model": "sonnet",
+  "permissions": {
+    "allow": ["Read",],
+  },
+}
+`
+
+func TestParseSettingsJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		allow     bool
+		contents  string
+		wantErr   string
+		wantLine  int
+		wantCol   int
+		wantModel string
+	}{
+		{name: "JSONC allowed", allow: true, contents: jsoncSettings, wantModel: "sonnet"},
+		{name: "JSONC rejected with hint", contents: jsoncSettings, wantErr: "set rules.jsonc: true", wantLine: 2, wantCol: 3},
+		{name: "syntax error position", contents: "{\n  \"model\": \"sonnet\"\n  \"env\": {}\n}\n", wantErr: "invalid JSON", wantLine: 3, wantCol: 3},
+		{name: "JSONC allowed still reports real errors", allow: true, contents: "{\n  // c\n  \"model\": sonnet\n}\n", wantErr: "invalid JSON", wantLine: 3, wantCol: 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _, err := parseSettingsJSON(tt.contents, tt.allow)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("parseSettingsJSON() error = %v", err)
+				}
+				if data["model"] != tt.wantModel {
+					t.Errorf("model = %v, want %q", data["model"], tt.wantModel)
+				}
+				return
+			}
+			var pe *positionError
+			if !errors.As(err, &pe) {
+				t.Fatalf("error %v is not a positionError", err)
+			}
+			if !strings.Contains(pe.Error(), tt.wantErr) || pe.line != tt.wantLine || pe.column != tt.wantCol {
+				t.Errorf("error = %q at %d:%d, want %q at %d:%d", pe.Error(), pe.line, pe.column, tt.wantErr, tt.wantLine, tt.wantCol)
+			}
+			if !tt.allow && tt.name != "JSONC rejected with hint" && strings.Contains(pe.Error(), "rules.jsonc") {
+				t.Errorf("hint given for a file that is not valid JSONC: %q", pe.Error())
+			}
+		})
+	}
+}
+
+func TestSettingsJSONSyntaxFinding(t *testing.T) {
+	result := lintFileCore(".claude/settings.json", "{\n  \"model\": \"sonnet\",\n}\n", NewSettingsLinter("", nil), nil, nil)
+	if len(result.Errors) != 1 {
+		t.Fatalf("errors = %v, want one syntax error", result.Errors)
+	}
+	got := result.Errors[0]
+	if got.Rule != cue.RuleJSONSyntax || got.Line != 3 || got.Column != 1 {
+		t.Errorf("finding = %+v, want %s at 3:1", got, cue.RuleJSONSyntax)
+	}
+}
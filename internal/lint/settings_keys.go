@@ -0,0 +1,182 @@
+package lint
+
+import (
+	"maps"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// knownSettingsKeys lists the top-level settings.json keys Claude Code reads.
+// Nested objects such as permissions and sandbox are validated by their own
+// checks.
+var knownSettingsKeys = map[string]bool{
+	"$schema": true, // JSON Schema reference for editors
+
+	// Core sections.
+	"permissions": true,
+	"hooks":       true,
+	"env":         true,
+	"mcpServers":  true,
+	"rules":       true,
+
+	// Model selection.
+	"model":                  true,
+	"advisorModel":           true,
+	"modelOverrides":         true,
+	"fallbackModel":          true, // v2.1.166+
+	"availableModels":        true,
+	"enforceAvailableModels": true, // v2.1.175+
+	"agent":                  true, // default subagent, v2.1.157+
+	"outputStyle":            true,
+	"language":               true, // v2.1.0+
+
+	// Status line and spinner.
+	"statusLine":          true,
+	"subagentStatusLine":  true,
+	"refreshInterval":     true, // status line refresh, v2.1.97+
+	"spinnerTipsEnabled":  true,
+	"spinnerTipsOverride": true, // v2.1.45+
+	"spinnerVerbs":        true,
+
+	// Auth and credential helpers.
+	"apiKeyHelper":        true,
+	"awsAuthRefresh":      true,
+	"awsCredentialExport": true,
+	"gcpAuthRefresh":      true,
+	"proxyAuthHelper":     true,
+	"otelHeadersHelper":   true,
+	"forceLoginMethod":    true,
+	"forceLoginOrgUUID":   true,
+
+	// Permission and directory scope.
+	"additionalDirectories":             true,
+	"defaultMode":                       true,
+	"symlinkDirectories":                true,
+	"sparsePaths":                       true,
+	"skipDangerousModePermissionPrompt": true,
+	"skipAutoPermissionPrompt":          true,
+	"skipWebFetchPreflight":             true,
+	"autoMode":                          true, // v2.1.136+
+	"useAutoModeDuringPlan":             true,
+
+	// Hooks and skills.
+	"disableAllHooks":            true, // v2.1.49+
+	"allowManagedHooksOnly":      true, // v2.1.101+
+	"disableSkillShellExecution": true, // v2.1.91+
+	"disableBundledSkills":       true, // v2.1.169+
+	"skillOverrides":             true, // v2.1.129+
+	"skillListingBudgetFraction": true,
+	"skillListingMaxDescChars":   true,
+
+	// MCP servers.
+	"enableAllProjectMcpServers":      true,
+	"enabledMcpjsonServers":           true,
+	"disabledMcpjsonServers":          true,
+	"allowedMcpServers":               true,
+	"deniedMcpServers":                true,
+	"allowManagedMcpServersOnly":      true,
+	"allowManagedPermissionRulesOnly": true,
+	"allowAllClaudeAiMcps":            true, // v2.1.149+
+
+	// Plugins and marketplaces.
+	"enabledPlugins":               true, // v2.1.45+
+	"extraKnownMarketplaces":       true, // v2.1.45+
+	"strictKnownMarketplaces":      true,
+	"blockedMarketplaces":          true,
+	"pluginSuggestionMarketplaces": true, // v2.1.152+
+	"allowedChannelPlugins":        true, // v2.1.84+
+	"channelsEnabled":              true, // v2.1.128+
+
+	// Sessions, memory, and worktrees.
+	"cleanupPeriodDays":        true,
+	"autoCompactEnabled":       true,
+	"autoCompactWindow":        true,
+	"fileCheckpointingEnabled": true,
+	"alwaysThinkingEnabled":    true,
+	"showThinkingSummaries":    true, // v2.1.88+
+	"autoMemoryDirectory":      true, // v2.1.74+
+	"autoMemoryEnabled":        true,
+	"autoDreamEnabled":         true,
+	"plansDirectory":           true, // v2.1.9+
+	"respectGitignore":         true, // v2.1.0+
+	"worktree":                 true, // v2.1.76+
+	"sandbox":                  true, // v2.1.83+
+	"attribution":              true,
+	"includeCoAuthoredBy":      true, // superseded by attribution
+	"prUrlTemplate":            true, // v2.1.119+
+	"todoFeatureEnabled":       true,
+	"teammateMode":             true,
+	"enableWorkflows":          true,
+	"disableWorkflows":         true,
+	"ultracode":                true,
+
+	"workflowKeywordTriggerEnabled": true,
+
+	// Display and input.
+	"tui":                            true, // v2.1.110+
+	"autoScrollEnabled":              true, // v2.1.110+
+	"wheelScrollAccelerationEnabled": true, // v2.1.174+
+	"verbose":                        true,
+	"editorMode":                     true,
+	"hideVimModeIndicator":           true,
+	"syntaxHighlightingDisabled":     true,
+	"promptSuggestionEnabled":        true,
+	"terminalProgressBarEnabled":     true,
+	"showMessageTimestamps":          true,
+	"showTurnDuration":               true,
+	"terminalTitleFromRename":        true,
+	"prefersReducedMotion":           true,
+	"showClearContextOnPlanAccept":   true,
+	"switchModelsOnFlag":             true,
+	"defaultShell":                   true,
+	"fastMode":                       true,
+	"fastModePerSessionOptIn":        true,
+	"autoSubmit":                     true,
+	"footerLinksRegexes":             true, // v2.1.176+
+	"companyAnnouncements":           true,
+
+	// Notifications and reminders.
+	"preferredNotifChannel":   true,
+	"awaySummaryEnabled":      true,
+	"agentPushNotifEnabled":   true,
+	"inputNeededNotifEnabled": true,
+	"voice":                   true,
+	"voiceEnabled":            true,
+	"breakReminder":           true,
+	"breakThresholdMinutes":   true,
+	"quietHours":              true,
+
+	// Updates and remote control.
+	"autoUpdatesChannel":     true,
+	"remoteControlAtStartup": true,
+	"disableRemoteControl":   true,
+	"feedbackSurveyRate":     true, // v2.1.76+
+
+	// Managed (enterprise) settings.
+	"disableDeepLinkRegistration": true, // v2.1.83+
+	"forceRemoteSettingsRefresh":  true, // v2.1.92+
+	"allowManagedDomainsOnly":     true, // v2.1.126+
+	"allowManagedReadPathsOnly":   true, // v2.1.126+
+	"parentSettingsBehavior":      true, // v2.1.133+
+	"wslInheritsWindowsSettings":  true, // v2.1.118+
+	"requiredMinimumVersion":      true, // v2.1.163+
+	"requiredMaximumVersion":      true, // v2.1.163+
+}
+
+// validateSettingsKeys reports top-level settings keys outside
+// knownSettingsKeys, with a "did you mean" hint when the key looks like a
+// typo of a known one. The extends key, when configured, is known too.
+func validateSettingsKeys(cfg *config.Config, data map[string]any, filePath, contents string) []cue.ValidationError {
+	known := knownSettingsKeys
+	if extendsKey := cfg.Rules.SettingsExtends; extendsKey != "" {
+		known = maps.Clone(known)
+		known[extendsKey] = true
+	}
+	return checkUnknownFields(cfg, data, filePath, contents, unknownFieldCheck{
+		known:    known,
+		label:    "settings key",
+		rule:     cue.RuleSettingsKeyUnknown,
+		findLine: FindJSONFieldLine,
+	})
+}
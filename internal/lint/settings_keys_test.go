@@ -0,0 +1,87 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestValidateSettingsKeys(t *testing.T) {
+	tests := []struct {
+		name         string
+		contents     string
+		extendsKey   string
+		wantMessages []string
+		wantLine     int
+	}{
+		{
+			name:     "known keys",
+			contents: `{"permissions": {}, "statusLine": {}, "spinnerTipsEnabled": false, "spinnerVerbs": {}, "$schema": "x"}`,
+		},
+		{
+			name:         "typo with a suggestion",
+			contents:     "{\n  \"model\": \"sonnet\",\n  \"permisions\": {}\n}",
+			wantMessages: []string{"Unknown settings key 'permisions' (did you mean 'permissions'?)"},
+			wantLine:     3,
+		},
+		{
+			name:         "wrong case",
+			contents:     `{"statusline": {}}`,
+			wantMessages: []string{"Unknown settings key 'statusline' (did you mean 'statusLine'?)"},
+			wantLine:     1,
+		},
+		{
+			name:         "unknown key without a close match",
+			contents:     `{"frobnicate": true}`,
+			wantMessages: []string{"Unknown settings key 'frobnicate'"},
+			wantLine:     1,
+		},
+		{
+			name:       "configured extends key",
+			contents:   `{"extends": "base.json"}`,
+			extendsKey: "extends",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Rules.SettingsExtends = tt.extendsKey
+			data, _, err := parseSettingsJSON(tt.contents, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs := validateSettingsKeys(cfg, data, "settings.json", tt.contents)
+			if len(errs) != len(tt.wantMessages) {
+				t.Fatalf("validateSettingsKeys() = %v, want %v", errs, tt.wantMessages)
+			}
+			for i, want := range tt.wantMessages {
+				e := errs[i]
+				if e.Message != want || e.Rule != cue.RuleSettingsKeyUnknown || e.Severity != cue.SeveritySuggestion || e.Line != tt.wantLine {
+					t.Errorf("issue = %q %s %s line %d, want %q suggestion line %d", e.Message, e.Rule, e.Severity, e.Line, want, tt.wantLine)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyRulesConfigStrictSettingsKeys(t *testing.T) {
+	summary := &LintSummary{
+		TotalSuggestions: 1,
+		Results: []LintResult{{
+			Success: true,
+			Suggestions: []cue.ValidationError{
+				{Message: "Unknown settings key 'permisions'", Severity: cue.SeveritySuggestion, Rule: cue.RuleSettingsKeyUnknown},
+			},
+		}},
+	}
+
+	ApplyRulesConfig(summary, config.RulesConfig{StrictSettingsKeys: true})
+	if summary.TotalSuggestions != 0 || summary.TotalErrors != 1 {
+		t.Fatalf("totals = %d suggestions, %d errors; want 0, 1", summary.TotalSuggestions, summary.TotalErrors)
+	}
+	if summary.Results[0].Success {
+		t.Error("result still successful after escalating to an error")
+	}
+}
@@ -1,23 +1,39 @@
 package lint
 
 import (
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
 )
 
 // SettingsLinter implements ComponentLinter for settings files.
-// It implements only the core ComponentLinter interface - no optional capabilities.
-// Settings files don't need scoring, improvements, cross-file validation, etc.
+// Its optional capabilities are CrossFileValidatable, which checks
+// permissions against the tools components use and the agents Task()
+// entries name, and teammate hooks against the agents they name, and
+// DataResolver, which merges the files a
+// settings file extends. Settings files don't need scoring or improvements.
 type SettingsLinter struct {
 	BaseLinter
+	// RootPath is the project root directory, used to resolve statusLine
+	// scripts and project output styles. Empty string disables those
+	// filesystem checks.
+	RootPath string
 }
 
 // Compile-time interface compliance check
-var _ ComponentLinter = (*SettingsLinter)(nil)
+var (
+	_ ComponentLinter      = (*SettingsLinter)(nil)
+	_ CrossFileValidatable = (*SettingsLinter)(nil)
+	_ DataResolver         = (*SettingsLinter)(nil)
+)
 
 // NewSettingsLinter creates a new SettingsLinter.
-func NewSettingsLinter() *SettingsLinter {
-	return &SettingsLinter{}
+// rootPath is the project root for resolving statusLine scripts and output
+// styles. Pass empty string to skip filesystem checks.
+// cfg supplies the rule settings; nil uses the defaults.
+func NewSettingsLinter(rootPath string, cfg *config.Config) *SettingsLinter {
+	return &SettingsLinter{BaseLinter: BaseLinter{cfg: cfg}, RootPath: rootPath}
 }
 
 func (l *SettingsLinter) Type() string {
@@ -29,7 +45,7 @@ func (l *SettingsLinter) FileType() discovery.FileType {
 }
 
 func (l *SettingsLinter) ParseContent(contents string) (map[string]any, string, error) {
-	return parseJSONContent(contents)
+	return parseSettingsJSON(contents, l.Config().Rules.JSONC)
 }
 
 func (l *SettingsLinter) ValidateCUE(validator *cue.Validator, data map[string]any) ([]cue.ValidationError, error) {
@@ -37,5 +53,23 @@ func (l *SettingsLinter) ValidateCUE(validator *cue.Validator, data map[string]a
 }
 
 func (l *SettingsLinter) ValidateSpecific(data map[string]any, filePath, contents string) []cue.ValidationError {
-	return validateSettingsSpecific(data, filePath)
+	errors := validateSettingsSpecific(l.Config(), data, filePath)
+	errors = append(errors, validateSettingsKeys(l.Config(), data, filePath, contents)...)
+	errors = append(errors, validateStatusLines(data, l.RootPath, filePath, contents)...)
+	errors = append(errors, validateOutputStyleSetting(data, l.RootPath, filePath, contents)...)
+	errors = append(errors, validateEnvReferences(data, filePath, contents, l.Config().Rules.TemplateVariables)...)
+	errors = append(errors, validatePlatformPortability(l.Config().Rules.Platforms, data, filePath, contents)...)
+	errors = append(errors, validateCredentialHelpers(data, l.RootPath, filePath, contents)...)
+	errors = append(errors, validateAdditionalDirectories(data, l.RootPath, filePath, contents)...)
+	return errors
+}
+
+// ValidateCrossFile checks the permissions allow and deny lists against the
+// tools the project's agents, commands, and skills list, the agents that
+// Task() permission entries name, and the teammate agents that agent team
+// hooks name.
+func (l *SettingsLinter) ValidateCrossFile(crossValidator *crossfile.CrossFileValidator, filePath, contents string, data map[string]any) []cue.ValidationError {
+	errors := validatePermissionToolUsage(crossValidator.ToolUses(), data, filePath, contents)
+	errors = append(errors, validateTaskPermissions(crossValidator, data, filePath)...)
+	return append(errors, validateTeammateHooks(crossValidator, data, filePath, contents)...)
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
 )
 
 // validateMCPServers validates the mcpServers configuration map.
@@ -18,6 +19,7 @@ func validateMCPServers(mcpServers any, filePath string) []cue.ValidationError {
 			Message:  "mcpServers must be an object mapping server names to configurations",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleSettingsMCPServerInvalid,
 		})
 		return errors
 	}
@@ -29,6 +31,7 @@ func validateMCPServers(mcpServers any, filePath string) []cue.ValidationError {
 				Message:  "mcpServers: server name must not be empty",
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleSettingsMCPServerInvalid,
 			})
 			continue
 		}
@@ -40,11 +43,13 @@ func validateMCPServers(mcpServers any, filePath string) []cue.ValidationError {
 				Message:  fmt.Sprintf("mcpServers '%s': server configuration must be an object", serverName),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleSettingsMCPServerInvalid,
 			})
 			continue
 		}
 
-		errors = append(errors, validateMCPServerEntry(serverName, serverMap, filePath)...)
+		errors = append(errors, withPointer(validateMCPServerEntry(serverName, serverMap, filePath),
+			textutil.JSONPointer("mcpServers", serverName))...)
 	}
 
 	return errors
@@ -62,6 +67,7 @@ func validateMCPServerEntry(serverName string, serverMap map[string]any, filePat
 			Message:  fmt.Sprintf("mcpServers '%s': missing required field 'command'", serverName),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleSettingsMCPServerInvalid,
 		})
 	} else if cmdStr, ok := cmdVal.(string); !ok {
 		errors = append(errors, cue.ValidationError{
@@ -69,6 +75,7 @@ func validateMCPServerEntry(serverName string, serverMap map[string]any, filePat
 			Message:  fmt.Sprintf("mcpServers '%s': 'command' must be a string", serverName),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleSettingsMCPServerInvalid,
 		})
 	} else if cmdStr == "" {
 		errors = append(errors, cue.ValidationError{
@@ -76,6 +83,7 @@ func validateMCPServerEntry(serverName string, serverMap map[string]any, filePat
 			Message:  fmt.Sprintf("mcpServers '%s': 'command' must not be empty", serverName),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleSettingsMCPServerInvalid,
 		})
 	}
 
@@ -97,6 +105,7 @@ func validateMCPServerEntry(serverName string, serverMap map[string]any, filePat
 				Message:  fmt.Sprintf("mcpServers '%s': 'cwd' must be a string", serverName),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleSettingsMCPServerInvalid,
 			})
 		}
 	}
@@ -113,6 +122,7 @@ func validateMCPServerArgs(serverName string, argsVal any, filePath string) []cu
 			Message:  fmt.Sprintf("mcpServers '%s': 'args' must be an array of strings", serverName),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleSettingsMCPServerInvalid,
 		}}
 	}
 	var errors []cue.ValidationError
@@ -123,6 +133,7 @@ func validateMCPServerArgs(serverName string, argsVal any, filePath string) []cu
 				Message:  fmt.Sprintf("mcpServers '%s': args[%d] must be a string", serverName, i),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleSettingsMCPServerInvalid,
 			})
 		}
 	}
@@ -138,6 +149,7 @@ func validateMCPServerEnv(serverName string, envVal any, filePath string) []cue.
 			Message:  fmt.Sprintf("mcpServers '%s': 'env' must be an object with string values", serverName),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleSettingsMCPServerInvalid,
 		}}
 	}
 	var errors []cue.ValidationError
@@ -148,6 +160,7 @@ func validateMCPServerEnv(serverName string, envVal any, filePath string) []cue.
 				Message:  fmt.Sprintf("mcpServers '%s': env '%s' value must be a string", serverName, envKey),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleSettingsMCPServerInvalid,
 			})
 		}
 	}
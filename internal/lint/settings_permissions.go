@@ -2,15 +2,26 @@ package lint
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/textutil"
 )
 
+// permissionModes are the permissions.defaultMode values Claude Code accepts.
+var permissionModes = []string{"default", "acceptEdits", "plan", "auto", "dontAsk", "bypassPermissions"}
+
+// permissionKeys are the keys of the permissions object, in the order they
+// are listed in messages.
+var permissionKeys = []string{"allow", "deny", "ask", "additionalDirectories", "defaultMode", "disableBypassPermissionsMode"}
+
 // validatePermissions validates the permissions section of settings.json.
-// Expected structure: {"allow": ["Bash(npm*)", ...], "deny": ["Bash(rm*)", ...], "ask": ["Bash(rm*)", ...]}
-func validatePermissions(perms any, filePath string) []cue.ValidationError {
+// Expected structure: {"allow": ["Bash(npm*)", ...], "deny": ["Bash(rm*)", ...], "ask": ["Bash(rm*)", ...],
+// "additionalDirectories": ["../docs"], "defaultMode": "acceptEdits", "disableBypassPermissionsMode": "disable"}
+func validatePermissions(perms any, filePath string, extraTools []string) []cue.ValidationError {
 	var errors []cue.ValidationError
 
 	permsMap, ok := perms.(map[string]any)
@@ -20,29 +31,164 @@ func validatePermissions(perms any, filePath string) []cue.ValidationError {
 			Message:  "permissions must be an object with optional 'allow', 'deny', and 'ask' arrays",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RulePermissionsInvalid,
 		})
 		return errors
 	}
 
 	for key, val := range permsMap {
-		if key != "allow" && key != "deny" && key != "ask" {
+		errors = append(errors, withPointer(validatePermissionKey(key, val, permsMap, filePath, extraTools),
+			textutil.JSONPointer("permissions", key))...)
+	}
+
+	return errors
+}
+
+// validatePermissionKey validates one key of the permissions section.
+func validatePermissionKey(key string, val any, permsMap map[string]any, filePath string, extraTools []string) []cue.ValidationError {
+	var errors []cue.ValidationError
+	switch key {
+	case "allow", "deny", "ask":
+		errors = append(errors, validatePermissionEntries(val, key, filePath, extraTools)...)
+	case "additionalDirectories":
+		errors = append(errors, validateAdditionalDirectoryList(val, filePath)...)
+	case "defaultMode":
+		errors = append(errors, validateDefaultMode(val, permsMap, filePath)...)
+	case "disableBypassPermissionsMode":
+		if val != "disable" {
 			errors = append(errors, cue.ValidationError{
 				File:     filePath,
-				Message:  fmt.Sprintf("permissions: unknown key '%s'. Only 'allow', 'deny', and 'ask' are supported", key),
+				Message:  fmt.Sprintf("permissions.disableBypassPermissionsMode must be \"disable\" (got %v)", formatMissing(val)),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RulePermissionsDisableBypass,
 			})
+		}
+	default:
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("permissions: unknown key '%s'. Supported keys: %s", key, strings.Join(permissionKeys, ", ")),
+			Severity: cue.SeverityError,
+			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleSettingsKeyUnknown,
+		})
+	}
+	return errors
+}
+
+// validateDefaultMode checks permissions.defaultMode against the permission
+// modes, and flags bypassPermissions when the same file disables it.
+func validateDefaultMode(val any, permsMap map[string]any, filePath string) []cue.ValidationError {
+	issue := func(severity, msg string) []cue.ValidationError {
+		return []cue.ValidationError{{
+			File:     filePath,
+			Message:  "permissions.defaultMode " + msg,
+			Severity: severity,
+			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RulePermissionsDefaultMode,
+		}}
+	}
+
+	mode, ok := val.(string)
+	if !ok || !slices.Contains(permissionModes, mode) {
+		return issue(cue.SeverityError, fmt.Sprintf("must be one of: %s (got %v)", strings.Join(permissionModes, ", "), formatMissing(val)))
+	}
+	if mode == "bypassPermissions" && permsMap["disableBypassPermissionsMode"] == "disable" {
+		return issue(cue.SeverityWarning, "is bypassPermissions, but disableBypassPermissionsMode disables that mode")
+	}
+	return nil
+}
+
+// validateAdditionalDirectoryList checks that permissions.additionalDirectories
+// is an array of non-empty path strings without duplicates.
+func validateAdditionalDirectoryList(val any, filePath string) []cue.ValidationError {
+	issue := func(msg string) cue.ValidationError {
+		return cue.ValidationError{
+			File:     filePath,
+			Message:  "permissions.additionalDirectories" + msg,
+			Severity: cue.SeverityError,
+			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RulePermissionsAdditionalDirs,
+		}
+	}
+
+	arr, ok := val.([]any)
+	if !ok {
+		return []cue.ValidationError{issue(" must be an array of directory paths")}
+	}
+
+	var errors []cue.ValidationError
+	seen := make(map[string]int, len(arr))
+	for i, entry := range arr {
+		dir, ok := entry.(string)
+		if !ok || strings.TrimSpace(dir) == "" {
+			errors = append(errors, issue(fmt.Sprintf("[%d]: each entry must be a non-empty path string", i)))
+			continue
+		}
+		clean := filepath.Clean(dir)
+		if first, dup := seen[clean]; dup {
+			dupe := issue(fmt.Sprintf("[%d]: '%s' duplicates entry %d", i, dir, first))
+			dupe.Severity = cue.SeverityWarning
+			errors = append(errors, dupe)
 			continue
 		}
+		seen[clean] = i
+	}
+	return errors
+}
 
-		errors = append(errors, validatePermissionEntries(val, key, filePath)...)
+// validateAdditionalDirectories checks that each permissions.additionalDirectories
+// entry exists and is a directory. Relative paths resolve against rootPath;
+// entries with unexpanded variables are skipped, as is the whole check when
+// rootPath is empty.
+func validateAdditionalDirectories(data map[string]any, rootPath, filePath, contents string) []cue.ValidationError {
+	perms, _ := data["permissions"].(map[string]any)
+	dirs, _ := perms["additionalDirectories"].([]any)
+	if rootPath == "" || len(dirs) == 0 {
+		return nil
 	}
 
+	var errors []cue.ValidationError
+	line := FindJSONFieldLine(contents, "additionalDirectories")
+	for i, entry := range dirs {
+		dir, _ := entry.(string)
+		if strings.TrimSpace(dir) == "" || strings.Contains(dir, "$") {
+			continue
+		}
+		path := dir
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				continue
+			}
+			path = filepath.Join(home, rest)
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(rootPath, path)
+		}
+
+		var msg string
+		if info, err := os.Stat(path); err != nil {
+			msg = fmt.Sprintf("'%s' does not exist", dir)
+		} else if !info.IsDir() {
+			msg = fmt.Sprintf("'%s' is not a directory", dir)
+		}
+		if msg != "" {
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("permissions.additionalDirectories[%d]: %s", i, msg),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RulePermissionsAdditionalDirs,
+				Line:     line,
+			})
+		}
+	}
 	return errors
 }
 
 // validatePermissionEntries validates a single permission list (allow, deny, or ask).
-func validatePermissionEntries(entries any, listName string, filePath string) []cue.ValidationError {
+func validatePermissionEntries(entries any, listName string, filePath string, extraTools []string) []cue.ValidationError {
 	var errors []cue.ValidationError
 
 	arr, ok := entries.([]any)
@@ -52,6 +198,7 @@ func validatePermissionEntries(entries any, listName string, filePath string) []
 			Message:  fmt.Sprintf("permissions.%s must be an array of tool permission strings", listName),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RulePermissionsInvalid,
 		})
 		return errors
 	}
@@ -64,18 +211,20 @@ func validatePermissionEntries(entries any, listName string, filePath string) []
 				Message:  fmt.Sprintf("permissions.%s[%d]: each entry must be a non-empty string", listName, i),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RulePermissionsInvalid,
 			})
 			continue
 		}
 
 		// Extract tool name from patterns like "Bash(npm*)" or plain "Read"
 		toolName := canonicalToolName(str)
-		if !isKnownTool(toolName) {
+		if !isKnownTool(toolName, extraTools) {
 			errors = append(errors, cue.ValidationError{
 				File:     filePath,
 				Message:  fmt.Sprintf("permissions.%s[%d]: unrecognized tool name '%s' in '%s'", listName, i, toolName, str),
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RulePermissionsToolUnknown,
 			})
 		}
 	}
@@ -93,10 +242,11 @@ func canonicalToolName(entry string) string {
 	return base
 }
 
-// isKnownTool checks whether a tool name is in the known tools set.
-func isKnownTool(name string) bool {
+// isKnownTool checks whether a tool name is a built-in tool or one of
+// extraTools.
+func isKnownTool(name string, extraTools []string) bool {
 	if name == "mcp__" {
 		return true
 	}
-	return textutil.KnownTools[name]
+	return textutil.IsKnownTool(name, extraTools)
 }
@@ -1,9 +1,13 @@
 package lint
 
 import (
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 
+	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/textutil"
 )
 
@@ -137,6 +141,56 @@ func TestValidatePermissions(t *testing.T) {
 			},
 			wantErrors: 0,
 		},
+		{
+			name: "valid directory and mode keys",
+			perms: map[string]any{
+				"additionalDirectories":        []any{"../docs", "~/shared"},
+				"defaultMode":                  "acceptEdits",
+				"disableBypassPermissionsMode": "disable",
+			},
+			wantErrors: 0,
+		},
+		{
+			name: "additionalDirectories not an array",
+			perms: map[string]any{
+				"additionalDirectories": "../docs",
+			},
+			wantErrors:   1,
+			wantSeverity: "error",
+		},
+		{
+			name: "duplicate additional directory",
+			perms: map[string]any{
+				"additionalDirectories": []any{"../docs", "../docs/"},
+			},
+			wantErrors:   1,
+			wantSeverity: "warning",
+		},
+		{
+			name: "invalid defaultMode",
+			perms: map[string]any{
+				"defaultMode": "yolo",
+			},
+			wantErrors:   1,
+			wantSeverity: "error",
+		},
+		{
+			name: "bypass default mode while disabled",
+			perms: map[string]any{
+				"defaultMode":                  "bypassPermissions",
+				"disableBypassPermissionsMode": "disable",
+			},
+			wantErrors:   1,
+			wantSeverity: "warning",
+		},
+		{
+			name: "disableBypassPermissionsMode boolean",
+			perms: map[string]any{
+				"disableBypassPermissionsMode": true,
+			},
+			wantErrors:   1,
+			wantSeverity: "error",
+		},
 		{
 			name: "mixed valid and unknown",
 			perms: map[string]any{
@@ -149,7 +203,7 @@ func TestValidatePermissions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errs := validatePermissions(tt.perms, "settings.json")
+			errs := validatePermissions(tt.perms, "settings.json", nil)
 			if len(errs) != tt.wantErrors {
 				t.Errorf("validatePermissions() error count = %d, want %d", len(errs), tt.wantErrors)
 				for _, e := range errs {
@@ -205,3 +259,41 @@ func TestCanonicalToolName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAdditionalDirectories(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "docs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "notes.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		dirs     []any
+		rootPath string
+		want     []string
+	}{
+		{name: "existing relative and absolute", dirs: []any{"docs", root}, rootPath: root},
+		{name: "missing directory", dirs: []any{"docs", "missing"}, rootPath: root, want: []string{"[1]: 'missing' does not exist"}},
+		{name: "file, not directory", dirs: []any{"notes.txt"}, rootPath: root, want: []string{"[0]: 'notes.txt' is not a directory"}},
+		{name: "variables skipped", dirs: []any{"$WORKSPACE/lib"}, rootPath: root},
+		{name: "no root", dirs: []any{"missing"}, rootPath: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := map[string]any{"permissions": map[string]any{"additionalDirectories": tt.dirs}}
+			got := validateAdditionalDirectories(data, tt.rootPath, ".claude/settings.json", "{}")
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d issues, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				if got[i].Rule != cue.RulePermissionsAdditionalDirs || !strings.Contains(got[i].Message, want) {
+					t.Errorf("issue %d = %s %q, want message containing %q", i, got[i].Rule, got[i].Message, want)
+				}
+			}
+		})
+	}
+}
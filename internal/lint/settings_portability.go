@@ -0,0 +1,222 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// Platform names, as used in rules.platforms.
+const (
+	platformMacOS   = "macos"
+	platformLinux   = "linux"
+	platformWindows = "windows"
+)
+
+// platformLabels are the names messages use for platforms.
+var platformLabels = map[string]string{
+	platformMacOS:   "macOS",
+	platformLinux:   "Linux",
+	platformWindows: "Windows",
+}
+
+// platformConstruct is a hook command or env value construct that works
+// only on some platforms.
+type platformConstruct struct {
+	name      string // for messages; %s is replaced by the matched text
+	pattern   *regexp.Regexp
+	platforms []string
+	env       bool // also checked in env values, which are not commands
+}
+
+// commandPattern matches one of names run as a command.
+func commandPattern(names string) *regexp.Regexp {
+	return regexp.MustCompile(`(?:^|[\s|;&(])(?:` + names + `)(?:\s|$)`)
+}
+
+var (
+	unixPlatforms = []string{platformMacOS, platformLinux}
+
+	platformConstructs = []platformConstruct{
+		{name: "cmd /c", pattern: regexp.MustCompile(`(?i)(?:^|[\s|;&(])cmd(?:\.exe)?\s+/[ck]\b`), platforms: []string{platformWindows}},
+		{name: "powershell", pattern: regexp.MustCompile(`(?i)(?:^|[\s|;&(])powershell(?:\.exe)?(?:\s|$)`), platforms: []string{platformWindows}},
+		{name: "a %s script", pattern: regexp.MustCompile(`(?i)\.(?:bat|cmd|ps1)\b`), platforms: []string{platformWindows}},
+		{name: "a backslash path", pattern: regexp.MustCompile(`\b[A-Za-z]:\\|(?:^|[\s"'=;])\.{1,2}\\|\w\\[\w.-]+\.\w`), platforms: []string{platformWindows}, env: true},
+		{name: "%s", pattern: regexp.MustCompile(`%[A-Z_][A-Z0-9_]+%`), platforms: []string{platformWindows}, env: true},
+		{name: "%s", pattern: commandPattern("bash|sh|zsh"), platforms: unixPlatforms},
+		{name: "a .sh script", pattern: regexp.MustCompile(`\.sh\b`), platforms: unixPlatforms},
+		{name: "/dev/null", pattern: regexp.MustCompile(`/dev/null`), platforms: unixPlatforms},
+		{name: "chmod", pattern: commandPattern("chmod"), platforms: unixPlatforms},
+		{name: "%s", pattern: commandPattern("osascript|pbcopy|pbpaste|afplay|launchctl"), platforms: []string{platformMacOS}},
+		{name: "a /Users/ path", pattern: regexp.MustCompile(`(?:^|[\s"'=:])/Users/`), platforms: []string{platformMacOS}, env: true},
+		{name: "%s", pattern: commandPattern("notify-send|xdg-open|xclip|xsel|paplay|systemctl"), platforms: []string{platformLinux}},
+		{name: "a /home/ path", pattern: regexp.MustCompile(`(?:^|[\s"'=:])/home/`), platforms: []string{platformLinux}, env: true},
+	}
+)
+
+// platformUse is a construct found at a location in a settings file.
+type platformUse struct {
+	location  string
+	construct platformConstruct
+	text      string // the matched text
+}
+
+// label describes the use for a message.
+func (u platformUse) label() string {
+	if strings.Contains(u.construct.name, "%s") {
+		return fmt.Sprintf(u.construct.name, u.text)
+	}
+	return u.construct.name
+}
+
+// validatePlatformPortability reports hook commands and env values that
+// use constructs specific to one platform. targetPlatforms is the
+// rules.platforms config key (macos, linux, windows); when set, any
+// construct that does not run on every listed platform is reported.
+// Without it, the platforms are inferred: when some hooks use Windows
+// constructs and others Unix ones, the less common kind is reported,
+// Windows on a tie.
+func validatePlatformPortability(targetPlatforms []string, data map[string]any, filePath, contents string) []cue.ValidationError {
+	var uses []platformUse
+	env, _ := data["env"].(map[string]any)
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		if value, ok := env[key].(string); ok {
+			uses = append(uses, findPlatformUses(value, fmt.Sprintf("env '%s'", key), true)...)
+		}
+	}
+	events, _ := data["hooks"].(map[string]any)
+	for _, event := range slices.Sorted(maps.Keys(events)) {
+		matchers, _ := events[event].([]any)
+		for i, m := range matchers {
+			matcher, _ := m.(map[string]any)
+			inner, _ := matcher["hooks"].([]any)
+			for j, h := range inner {
+				hook, _ := h.(map[string]any)
+				if cmd, ok := hook["command"].(string); ok {
+					uses = append(uses, findPlatformUses(cmd, fmt.Sprintf("Event '%s' hook %d inner hook %d", event, i, j), false)...)
+				}
+			}
+		}
+	}
+
+	var flagged []platformUse
+	reason := make(map[string]string) // location -> why its constructs are flagged
+	if len(targetPlatforms) > 0 {
+		for _, use := range uses {
+			missing := slices.DeleteFunc(slices.Clone(targetPlatforms), func(p string) bool {
+				return slices.Contains(use.construct.platforms, p)
+			})
+			if len(missing) > 0 {
+				flagged = append(flagged, use)
+				reason[use.location] = "rules.platforms also targets " + platformList(missing)
+			}
+		}
+	} else {
+		flagged = mixedPlatformUses(uses)
+		for _, use := range flagged {
+			other := "Windows"
+			if isWindowsOnly(use.construct) {
+				other = "macOS and Linux"
+			}
+			reason[use.location] = fmt.Sprintf("the rest of this file is written for %s; set rules.platforms to the platforms you target", other)
+		}
+	}
+
+	// One finding per location, naming every construct flagged there.
+	var errors []cue.ValidationError
+	for len(flagged) > 0 {
+		location := flagged[0].location
+		var names []string
+		var platforms []string
+		for _, use := range flagged {
+			if use.location != location {
+				continue
+			}
+			names = append(names, use.label())
+			if platforms == nil {
+				platforms = use.construct.platforms
+			} else {
+				platforms = slices.DeleteFunc(slices.Clone(platforms), func(p string) bool {
+					return !slices.Contains(use.construct.platforms, p)
+				})
+			}
+		}
+		verb := "works"
+		if len(names) > 1 {
+			verb = "work"
+		}
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("%s: uses %s, which %s only on %s; %s", location, joinAnd(names), verb, platformList(platforms), reason[location]),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleSettingsPlatformPortability,
+			Line:     textutil.FindLineNumber(contents, flagged[0].text),
+		})
+		flagged = slices.DeleteFunc(flagged, func(use platformUse) bool { return use.location == location })
+	}
+	return errors
+}
+
+// mixedPlatformUses returns the uses to report when no platforms are
+// declared: none unless some locations use Windows constructs and others
+// Unix ones, and then those of the kind fewer locations use, Windows on a
+// tie.
+func mixedPlatformUses(uses []platformUse) []platformUse {
+	windows, unix := make(map[string]bool), make(map[string]bool)
+	for _, use := range uses {
+		if isWindowsOnly(use.construct) {
+			windows[use.location] = true
+		} else {
+			unix[use.location] = true
+		}
+	}
+	if len(windows) == 0 || len(unix) == 0 {
+		return nil
+	}
+	flagWindows := len(windows) <= len(unix)
+	return slices.DeleteFunc(slices.Clone(uses), func(use platformUse) bool {
+		return isWindowsOnly(use.construct) != flagWindows
+	})
+}
+
+// findPlatformUses returns the platform-specific constructs in s. Env values
+// are checked for paths and variable references only.
+func findPlatformUses(s, location string, envValue bool) []platformUse {
+	var uses []platformUse
+	for _, c := range platformConstructs {
+		if envValue && !c.env {
+			continue
+		}
+		if m := c.pattern.FindString(s); m != "" {
+			uses = append(uses, platformUse{location: location, construct: c, text: strings.Trim(m, " \t|;&()\"'=:")})
+		}
+	}
+	return uses
+}
+
+func isWindowsOnly(c platformConstruct) bool {
+	return slices.Equal(c.platforms, []string{platformWindows})
+}
+
+// platformList joins platform names for a message, e.g. "macOS and Linux".
+func platformList(platforms []string) string {
+	labels := make([]string, len(platforms))
+	for i, p := range platforms {
+		labels[i] = platformLabels[p]
+	}
+	return joinAnd(labels)
+}
+
+// joinAnd joins items as a list in prose, e.g. "a, b and c".
+func joinAnd(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
@@ -0,0 +1,89 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/stretchr/testify/assert"
+)
+
+// commandHooks builds a hooks block with one command hook per event.
+func commandHooks(commands map[string]string) map[string]any {
+	hooks := make(map[string]any)
+	for event, cmd := range commands {
+		hooks[event] = []any{map[string]any{"matcher": "", "hooks": []any{
+			map[string]any{"type": "command", "command": cmd},
+		}}}
+	}
+	return hooks
+}
+
+func TestValidatePlatformPortability(t *testing.T) {
+	tests := []struct {
+		name      string
+		platforms []string
+		data      map[string]any
+		want      []string
+	}{
+		{
+			name: "unix hooks only",
+			data: map[string]any{"hooks": commandHooks(map[string]string{"Stop": "bash ./lint.sh 2>/dev/null", "Notification": "osascript -e 'beep'"})},
+		},
+		{
+			name: "powershell among unix hooks",
+			data: map[string]any{"hooks": commandHooks(map[string]string{
+				"Stop":        "./scripts/check.sh",
+				"SessionEnd":  "bash cleanup.sh",
+				"PostToolUse": `powershell -File .\scripts\fmt.ps1`,
+			})},
+			want: []string{"Event 'PostToolUse' hook 0 inner hook 0: uses powershell, a .ps1 script and a backslash path, which work only on Windows; the rest of this file is written for macOS and Linux"},
+		},
+		{
+			name: "unix hook among windows hooks",
+			data: map[string]any{
+				"env":   map[string]any{"TOOLS": `C:\tools`},
+				"hooks": commandHooks(map[string]string{"Stop": `cmd /c %APPDATA%\fmt.bat`, "SessionEnd": "notify-send done"}),
+			},
+			want: []string{"Event 'SessionEnd' hook 0 inner hook 0: uses notify-send, which works only on Linux; the rest of this file is written for Windows"},
+		},
+		{
+			name:      "declared platforms",
+			platforms: []string{"macos", "linux"},
+			data: map[string]any{
+				"env":   map[string]any{"CACHE": "/Users/alice/cache", "MODE": "fast"},
+				"hooks": commandHooks(map[string]string{"Stop": "bash ./lint.sh", "Notification": "osascript -e 'beep'"}),
+			},
+			want: []string{
+				"env 'CACHE': uses a /Users/ path, which works only on macOS; rules.platforms also targets Linux",
+				"Event 'Notification' hook 0 inner hook 0: uses osascript, which works only on macOS; rules.platforms also targets Linux",
+			},
+		},
+		{
+			name:      "declared windows",
+			platforms: []string{"windows", "linux"},
+			data:      map[string]any{"hooks": commandHooks(map[string]string{"Stop": "chmod +x run && ./run > /dev/null", "SessionEnd": "echo %USERPROFILE%"})},
+			want: []string{
+				"Event 'SessionEnd' hook 0 inner hook 0: uses %USERPROFILE%, which works only on Windows; rules.platforms also targets Linux",
+				"Event 'Stop' hook 0 inner hook 0: uses /dev/null and chmod, which work only on macOS and Linux; rules.platforms also targets Windows",
+			},
+		},
+		{
+			name:      "date formats and escapes are not windows constructs",
+			platforms: []string{"linux"},
+			data:      map[string]any{"hooks": commandHooks(map[string]string{"Stop": `date +%Y%m%d >> log.txt; printf "a\nb"`})},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range validatePlatformPortability(tt.platforms, tt.data, "settings.json", "") {
+				assert.Equal(t, cue.RuleSettingsPlatformPortability, e.Rule)
+				got = append(got, e.Message)
+			}
+			assert.Len(t, got, len(tt.want))
+			for i := range min(len(got), len(tt.want)) {
+				assert.Contains(t, got[i], tt.want[i])
+			}
+		})
+	}
+}
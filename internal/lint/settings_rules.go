@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
 )
 
 // validateRules validates the rules array in settings.json.
@@ -21,6 +22,7 @@ func validateRules(rules any, filePath string) []cue.ValidationError {
 			Message:  "rules must be an array of glob pattern strings",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleSettingsRulesInvalid,
 		})
 		return errors
 	}
@@ -33,6 +35,8 @@ func validateRules(rules any, filePath string) []cue.ValidationError {
 				Message:  fmt.Sprintf("rules[%d]: each entry must be a non-empty string", i),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleSettingsRulesInvalid,
+				Pointer:  textutil.JSONPointer("rules", i),
 			})
 			continue
 		}
@@ -44,6 +48,8 @@ func validateRules(rules any, filePath string) []cue.ValidationError {
 				Message:  fmt.Sprintf("rules[%d]: invalid glob pattern %q: %v", i, str, err),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleSettingsRulesInvalid,
+				Pointer:  textutil.JSONPointer("rules", i),
 			})
 			continue
 		}
@@ -55,6 +61,8 @@ func validateRules(rules any, filePath string) []cue.ValidationError {
 				Message:  fmt.Sprintf("rules[%d]: absolute path %q is not portable; use relative glob patterns", i, str),
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleSettingsPlatformPortability,
+				Pointer:  textutil.JSONPointer("rules", i),
 			})
 		}
 	}
@@ -65,7 +73,7 @@ func validateRules(rules any, filePath string) []cue.ValidationError {
 // validateMatcherToolName validates a toolName pattern from a hook matcher.
 // Patterns look like "Bash(npm*)", "Edit", "mcp__server_tool", etc.
 // Returns errors if the base tool name is unrecognized or the glob portion is invalid.
-func validateMatcherToolName(toolNamePattern string, location string, filePath string) []cue.ValidationError {
+func validateMatcherToolName(toolNamePattern string, location string, filePath string, extraTools []string) []cue.ValidationError {
 	var errors []cue.ValidationError
 
 	if toolNamePattern == "" {
@@ -74,12 +82,13 @@ func validateMatcherToolName(toolNamePattern string, location string, filePath s
 
 	// Extract the base tool name
 	baseTool := canonicalToolName(toolNamePattern)
-	if !isKnownTool(baseTool) {
+	if !isKnownTool(baseTool, extraTools) {
 		errors = append(errors, cue.ValidationError{
 			File:     filePath,
 			Message:  fmt.Sprintf("%s: unrecognized tool name '%s' in toolName pattern '%s'", location, baseTool, toolNamePattern),
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleHookMatcherToolUnknown,
 		})
 	}
 
@@ -103,6 +112,7 @@ func validateToolNameGlob(toolNamePattern, location, filePath string) []cue.Vali
 			Message:  fmt.Sprintf("%s: unclosed parenthesis in toolName pattern '%s'", location, toolNamePattern),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookMatcherInvalid,
 		}}
 	}
 
@@ -113,6 +123,7 @@ func validateToolNameGlob(toolNamePattern, location, filePath string) []cue.Vali
 			Message:  fmt.Sprintf("%s: empty glob pattern in parentheses for toolName '%s'", location, toolNamePattern),
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleHookMatcherInvalid,
 		}}
 	}
 
@@ -122,6 +133,7 @@ func validateToolNameGlob(toolNamePattern, location, filePath string) []cue.Vali
 			Message:  fmt.Sprintf("%s: invalid glob in toolName '%s': %v", location, toolNamePattern, err),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleHookMatcherInvalid,
 		}}
 	}
 
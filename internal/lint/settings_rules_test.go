@@ -216,7 +216,7 @@ func TestValidateMatcherToolName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errs := validateMatcherToolName(tt.toolNamePattern, "test location", "test.json")
+			errs := validateMatcherToolName(tt.toolNamePattern, "test location", "test.json", nil)
 			if len(errs) != tt.wantErrorCount {
 				t.Errorf("validateMatcherToolName(%q) error count = %d, want %d", tt.toolNamePattern, len(errs), tt.wantErrorCount)
 				for _, e := range errs {
@@ -297,7 +297,7 @@ func TestValidateSettingsSpecificWithRules(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := validateSettingsSpecific(tt.data, "settings.json")
+			errors := validateSettingsSpecific(defaultConfig(), tt.data, "settings.json")
 			if len(errors) != tt.wantErrorCount {
 				t.Errorf("validateSettingsSpecific() error count = %d, want %d", len(errors), tt.wantErrorCount)
 				for _, err := range errors {
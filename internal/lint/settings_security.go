@@ -8,8 +8,18 @@ import (
 	"github.com/dotcommander/cclint/internal/cue"
 )
 
+var (
+	// unquotedVarPattern matches $VAR or ${VAR} not preceded by quote and not followed by quote.
+	unquotedVarPattern = regexp.MustCompile(`[^"']\$\{?[A-Za-z_][A-Za-z0-9_]*\}?[^"']|^\$\{?[A-Za-z_][A-Za-z0-9_]*\}?[^"']`)
+
+	// absolutePathPattern matches a quoted absolute path under a user or system directory.
+	absolutePathPattern = regexp.MustCompile(`["']/(?:Users|home|var|tmp|etc)/[^\s"']+`)
+)
+
 // validateHookCommandSecurity checks for security issues in hook commands.
-// Delegates to specific check functions for each security concern.
+// Delegates to specific check functions for each security concern. In
+// untrusted mode it also reports network access and writes outside the
+// plugin root.
 func validateHookCommandSecurity(cmd string, ctx hookContext) []cue.ValidationError {
 	location := fmt.Sprintf("Event '%s' hook %d inner hook %d", ctx.EventName, ctx.HookIdx, ctx.InnerIdx)
 
@@ -19,14 +29,15 @@ func validateHookCommandSecurity(cmd string, ctx hookContext) []cue.ValidationEr
 	warnings = append(warnings, checkHardcodedPaths(cmd, location, ctx.FilePath)...)
 	warnings = append(warnings, checkSensitiveFileAccess(cmd, location, ctx.FilePath)...)
 	warnings = append(warnings, checkDangerousPatterns(cmd, location, ctx.FilePath)...)
+	if ctx.Config.Untrusted {
+		warnings = append(warnings, checkUntrustedHookCommand(cmd, location, ctx.FilePath)...)
+	}
 
-	return warnings
+	return withPointer(warnings, ctx.pointer("command"))
 }
 
 // checkUnquotedVariables detects unquoted variable expansion.
 func checkUnquotedVariables(cmd, location, filePath string) []cue.ValidationError {
-	// Matches $VAR or ${VAR} not preceded by quote and not followed by quote
-	unquotedVarPattern := regexp.MustCompile(`[^"']\$\{?[A-Za-z_][A-Za-z0-9_]*\}?[^"']|^\$\{?[A-Za-z_][A-Za-z0-9_]*\}?[^"']`)
 	if !unquotedVarPattern.MatchString(cmd) {
 		return nil
 	}
@@ -42,6 +53,7 @@ func checkUnquotedVariables(cmd, location, filePath string) []cue.ValidationErro
 		Message:  fmt.Sprintf("%s: Unquoted variable expansion detected. Use \"$VAR\" to prevent word splitting", location),
 		Severity: cue.SeverityWarning,
 		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleHookCommandSecurity,
 	}}
 }
 
@@ -55,12 +67,12 @@ func checkPathTraversal(cmd, location, filePath string) []cue.ValidationError {
 		Message:  fmt.Sprintf("%s: Path traversal '..' detected in hook command - potential security risk", location),
 		Severity: cue.SeverityWarning,
 		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleHookCommandSecurity,
 	}}
 }
 
 // checkHardcodedPaths detects hardcoded absolute paths without $CLAUDE_PROJECT_DIR.
 func checkHardcodedPaths(cmd, location, filePath string) []cue.ValidationError {
-	absolutePathPattern := regexp.MustCompile(`["']/(?:Users|home|var|tmp|etc)/[^\s"']+`)
 	if !absolutePathPattern.MatchString(cmd) || strings.Contains(cmd, "$CLAUDE_PROJECT_DIR") {
 		return nil
 	}
@@ -69,6 +81,7 @@ func checkHardcodedPaths(cmd, location, filePath string) []cue.ValidationError {
 		Message:  fmt.Sprintf("%s: Hardcoded absolute path detected. Consider using $CLAUDE_PROJECT_DIR for portability", location),
 		Severity: cue.SeverityWarning,
 		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleHookCommandSecurity,
 	}}
 }
 
@@ -95,6 +108,7 @@ func checkSensitiveFileAccess(cmd, location, filePath string) []cue.ValidationEr
 				Message:  fmt.Sprintf("%s: %s", location, sp.message),
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleHookCommandSecurity,
 			})
 		}
 	}
@@ -122,6 +136,7 @@ func checkDangerousPatterns(cmd, location, filePath string) []cue.ValidationErro
 				Message:  fmt.Sprintf("%s: %s", location, dp.message),
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleHookCommandSecurity,
 			})
 		}
 	}
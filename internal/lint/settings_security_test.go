@@ -94,7 +94,7 @@ func TestValidateHookCommandSecurity(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := hookContext{EventName: "TestEvent", HookIdx: 0, InnerIdx: 0, FilePath: "test.json"}
+			ctx := hookContext{EventName: "TestEvent", HookIdx: 0, InnerIdx: 0, FilePath: "test.json", Config: defaultConfig()}
 			warnings := validateHookCommandSecurity(tt.command, ctx)
 			if len(warnings) != tt.wantWarningCount {
 				t.Errorf("validateHookCommandSecurity() warning count = %d, want %d", len(warnings), tt.wantWarningCount)
@@ -0,0 +1,223 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// statusLineFields are the settings keys that configure a status line command.
+var statusLineFields = []string{"statusLine", "subagentStatusLine"}
+
+// scriptInterpreters are commands that take the script they run as
+// their first argument, e.g. "bash .claude/statusline.sh". For these the
+// script must exist but need not be executable.
+var scriptInterpreters = map[string]bool{
+	"sh": true, "bash": true, "zsh": true, "fish": true,
+	"node": true, "bun": true, "deno": true,
+	"python": true, "python3": true, "ruby": true, "perl": true,
+}
+
+// builtinOutputStyles are the output styles that ship with Claude Code.
+var builtinOutputStyles = map[string]bool{"default": true, "explanatory": true, "learning": true}
+
+// validateStatusLines validates statusLine and subagentStatusLine objects:
+// type must be "command", command must be a non-empty string, padding must
+// be a non-negative number, and a path-like script must exist (and be
+// executable when run directly). Script checks are skipped when rootPath is
+// empty.
+func validateStatusLines(data map[string]any, rootPath, filePath, contents string) []cue.ValidationError {
+	var errors []cue.ValidationError
+
+	for _, field := range statusLineFields {
+		raw, ok := data[field]
+		if !ok {
+			continue
+		}
+		line := FindJSONFieldLine(contents, field)
+		issue := func(rule, severity, msg string) cue.ValidationError {
+			return cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("%s: %s", field, msg),
+				Severity: severity,
+				Source:   cue.SourceAnthropicDocs,
+				Rule:     rule,
+				Line:     line,
+			}
+		}
+
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			errors = append(errors, issue(cue.RuleSettingsStatusLine, cue.SeverityError,
+				`must be an object such as {"type": "command", "command": "~/.claude/statusline.sh"}`))
+			continue
+		}
+
+		if typ, _ := obj["type"].(string); typ != "command" {
+			errors = append(errors, issue(cue.RuleSettingsStatusLine, cue.SeverityError,
+				fmt.Sprintf("type must be \"command\" (got %v)", formatMissing(obj["type"]))))
+		}
+
+		if padding, ok := obj["padding"]; ok {
+			if n, isNum := padding.(float64); !isNum || n < 0 {
+				errors = append(errors, issue(cue.RuleSettingsStatusLine, cue.SeverityError,
+					fmt.Sprintf("padding must be a non-negative number (got %v)", padding)))
+			}
+		}
+
+		command, _ := obj["command"].(string)
+		if strings.TrimSpace(command) == "" {
+			errors = append(errors, issue(cue.RuleSettingsStatusLine, cue.SeverityError,
+				"command must be a non-empty string"))
+			continue
+		}
+
+		if rootPath == "" {
+			continue
+		}
+		if msg := checkStatusLineScript(command, rootPath); msg != "" {
+			errors = append(errors, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("%s: %s", field, msg),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleSettingsStatusLineCmd,
+				Line:     line,
+			})
+		}
+	}
+
+	return errors
+}
+
+// formatMissing renders a possibly-absent JSON value for error messages.
+func formatMissing(v any) string {
+	if v == nil {
+		return "missing"
+	}
+	return fmt.Sprintf("%q", fmt.Sprint(v))
+}
+
+// checkStatusLineScript resolves the script a status line command runs and
+// returns a problem description, or "" when the script is fine or cannot be
+// resolved statically (bare commands looked up on PATH, unexpanded variables).
+func checkStatusLineScript(command, rootPath string) string {
+	script, needExec, ok := resolveCommandScript(command, rootPath)
+	if !ok {
+		return ""
+	}
+
+	info, err := os.Stat(script)
+	if err != nil {
+		return fmt.Sprintf("command script %q does not exist", script)
+	}
+	if info.IsDir() {
+		return fmt.Sprintf("command script %q is a directory", script)
+	}
+	if needExec && info.Mode().Perm()&0o111 == 0 {
+		return fmt.Sprintf("command script %q is not executable; run chmod +x or invoke it via an interpreter (e.g. \"bash %s\")", script, strings.Fields(command)[0])
+	}
+	return ""
+}
+
+// resolveCommandScript returns the path of the script a command runs and
+// whether it is run directly (so must be executable). ok is false when the
+// command is a bare name looked up on PATH or contains unexpanded variables.
+func resolveCommandScript(command, rootPath string) (script string, needExec, ok bool) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return "", false, false
+	}
+	script, needExec = fields[0], true
+	if scriptInterpreters[filepath.Base(script)] {
+		if len(fields) < 2 || strings.HasPrefix(fields[1], "-") {
+			return "", false, false
+		}
+		script, needExec = fields[1], false
+	}
+
+	script = strings.Trim(script, `"'`)
+	for _, v := range []string{"${CLAUDE_PROJECT_DIR}", "$CLAUDE_PROJECT_DIR"} {
+		script = strings.ReplaceAll(script, v, rootPath)
+	}
+	if rest, ok := strings.CutPrefix(script, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false, false
+		}
+		script = filepath.Join(home, rest)
+	}
+	if !strings.Contains(script, "/") || strings.Contains(script, "$") {
+		return "", false, false
+	}
+	if !filepath.IsAbs(script) {
+		script = filepath.Join(rootPath, script)
+	}
+	return script, needExec, true
+}
+
+// validateOutputStyleSetting checks that the outputStyle setting names a
+// built-in style or an output style file in the project or user
+// output-styles directory. Plugin-namespaced styles ("plugin:style") are not
+// checked, and the file lookup is skipped when rootPath is empty.
+func validateOutputStyleSetting(data map[string]any, rootPath, filePath, contents string) []cue.ValidationError {
+	style, ok := data["outputStyle"].(string)
+	if !ok || rootPath == "" || strings.Contains(style, ":") {
+		return nil
+	}
+	if builtinOutputStyles[strings.ToLower(style)] {
+		return nil
+	}
+
+	dirs := []string{filepath.Join(rootPath, ".claude", "output-styles")}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".claude", "output-styles"))
+	}
+	for _, dir := range dirs {
+		if outputStyleDirHas(dir, style) {
+			return nil
+		}
+	}
+
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  fmt.Sprintf("outputStyle %q does not match a built-in style (default, Explanatory, Learning) or a file in .claude/output-styles/", style),
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleSettingsOutputStyle,
+		Line:     FindJSONFieldLine(contents, "outputStyle"),
+	}}
+}
+
+// outputStyleDirHas reports whether dir contains an output style whose
+// filename stem or frontmatter name matches style (case-insensitive).
+func outputStyleDirHas(dir, style string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".md") {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSuffix(e.Name(), ".md"), style) {
+			return true
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, e.Name())) //nolint:gosec // G304: path comes from ReadDir of a fixed directory
+		if err != nil {
+			continue
+		}
+		fm, err := textutil.ParseYAMLFrontmatter(string(raw))
+		if err != nil {
+			continue
+		}
+		if name, ok := fm.Data["name"].(string); ok && strings.EqualFold(name, style) {
+			return true
+		}
+	}
+	return false
+}
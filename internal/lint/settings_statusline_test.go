@@ -0,0 +1,148 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestValidateStatusLines(t *testing.T) {
+	root := t.TempDir()
+	claudeDir := filepath.Join(root, ".claude")
+	if err := os.MkdirAll(claudeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(claudeDir, "statusline.sh"), []byte("#!/bin/sh\necho hi\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(claudeDir, "plain.sh"), []byte("echo hi\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		data      map[string]any
+		rootPath  string
+		wantRules []string
+	}{
+		{
+			name:      "no statusLine",
+			data:      map[string]any{},
+			rootPath:  root,
+			wantRules: nil,
+		},
+		{
+			name:      "executable script",
+			data:      map[string]any{"statusLine": map[string]any{"type": "command", "command": ".claude/statusline.sh", "padding": float64(0)}},
+			rootPath:  root,
+			wantRules: nil,
+		},
+		{
+			name:      "project dir variable",
+			data:      map[string]any{"statusLine": map[string]any{"type": "command", "command": "$CLAUDE_PROJECT_DIR/.claude/statusline.sh"}},
+			rootPath:  root,
+			wantRules: nil,
+		},
+		{
+			name:      "non-executable script via interpreter",
+			data:      map[string]any{"statusLine": map[string]any{"type": "command", "command": "bash .claude/plain.sh"}},
+			rootPath:  root,
+			wantRules: nil,
+		},
+		{
+			name:      "bare command on PATH is not checked",
+			data:      map[string]any{"statusLine": map[string]any{"type": "command", "command": "npx -y ccstatusline"}},
+			rootPath:  root,
+			wantRules: nil,
+		},
+		{
+			name:      "non-executable script run directly",
+			data:      map[string]any{"statusLine": map[string]any{"type": "command", "command": ".claude/plain.sh"}},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsStatusLineCmd},
+		},
+		{
+			name:      "missing script",
+			data:      map[string]any{"subagentStatusLine": map[string]any{"type": "command", "command": "python3 .claude/missing.py"}},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsStatusLineCmd},
+		},
+		{
+			name:      "missing script without root is not checked",
+			data:      map[string]any{"statusLine": map[string]any{"type": "command", "command": ".claude/missing.sh"}},
+			rootPath:  "",
+			wantRules: nil,
+		},
+		{
+			name:      "wrong type and negative padding",
+			data:      map[string]any{"statusLine": map[string]any{"type": "script", "command": "echo hi", "padding": float64(-1)}},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsStatusLine, cue.RuleSettingsStatusLine},
+		},
+		{
+			name:      "missing type and command",
+			data:      map[string]any{"statusLine": map[string]any{}},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsStatusLine, cue.RuleSettingsStatusLine},
+		},
+		{
+			name:      "not an object",
+			data:      map[string]any{"statusLine": "echo hi"},
+			rootPath:  root,
+			wantRules: []string{cue.RuleSettingsStatusLine},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateStatusLines(tt.data, tt.rootPath, ".claude/settings.json", "{}")
+			if len(got) != len(tt.wantRules) {
+				t.Fatalf("validateStatusLines() returned %d issues, want %d: %v", len(got), len(tt.wantRules), got)
+			}
+			for i, want := range tt.wantRules {
+				if got[i].Rule != want {
+					t.Errorf("issue %d rule = %q, want %q (%s)", i, got[i].Rule, want, got[i].Message)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateOutputStyleSetting(t *testing.T) {
+	root := t.TempDir()
+	stylesDir := filepath.Join(root, ".claude", "output-styles")
+	if err := os.MkdirAll(stylesDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(stylesDir, "terse.md"), []byte("---\nname: Terse Mode\ndescription: Short answers\n---\nBe brief."), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		style    any
+		rootPath string
+		wantWarn bool
+	}{
+		{"built-in", "Explanatory", root, false},
+		{"file stem", "terse", root, false},
+		{"frontmatter name", "terse mode", root, false},
+		{"plugin namespaced", "my-plugin:fancy", root, false},
+		{"unknown", "verbose", root, true},
+		{"unknown without root", "verbose", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateOutputStyleSetting(map[string]any{"outputStyle": tt.style}, tt.rootPath, ".claude/settings.json", "{\n  \"outputStyle\": \"x\"\n}")
+			if (len(got) > 0) != tt.wantWarn {
+				t.Fatalf("validateOutputStyleSetting() = %v, want warning %v", got, tt.wantWarn)
+			}
+			if tt.wantWarn && (got[0].Rule != cue.RuleSettingsOutputStyle || got[0].Line != 2) {
+				t.Errorf("got rule %q line %d, want %q line 2", got[0].Rule, got[0].Line, cue.RuleSettingsOutputStyle)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// validateTaskPermissions checks the agents that Task(...) and Agent(...)
+// entries in the permissions allow, ask, and deny lists name. An entry for
+// one agent must resolve to one; a wildcard such as Task(quality*) must
+// match at least one, using the same matching as permission decisions.
+// Entries without an argument or with (*) cover every agent and are not
+// checked, and neither are wildcards over plugin-namespaced agents.
+func validateTaskPermissions(cv *crossfile.CrossFileValidator, data map[string]any, filePath string) []cue.ValidationError {
+	perms, _ := data["permissions"].(map[string]any)
+	var errors []cue.ValidationError
+	var agents []string
+	for _, list := range []string{"allow", "ask", "deny"} {
+		entries, _ := perms[list].([]any)
+		for i, entry := range entries {
+			perm, _ := entry.(string)
+			pattern, ok := taskPermissionPattern(perm)
+			if !ok {
+				continue
+			}
+			pointer := textutil.JSONPointer("permissions", list, i)
+			if !strings.Contains(pattern, "*") {
+				if !cv.HasAgent(pattern) {
+					errors = append(errors, cue.ValidationError{
+						File:     filePath,
+						Message:  fmt.Sprintf("permissions.%s[%d]: '%s' names agent '%s', but no such agent exists; create agents/%s.md or list it in extraBuiltinAgents", list, i, perm, pattern, pattern),
+						Severity: cue.SeverityError,
+						Source:   cue.SourceCClintObserve,
+						Rule:     cue.RulePermissionsTaskAgentMissing,
+						Pointer:  pointer,
+					})
+				}
+				continue
+			}
+			if strings.Contains(pattern, ":") {
+				continue
+			}
+			if agents == nil {
+				agents = cv.AgentNames()
+			}
+			if !anyAgentMatches(agents, pattern) {
+				errors = append(errors, cue.ValidationError{
+					File:     filePath,
+					Message:  fmt.Sprintf("permissions.%s[%d]: '%s' matches no agent; fix the pattern or remove the entry", list, i, perm),
+					Severity: cue.SeverityWarning,
+					Source:   cue.SourceCClintObserve,
+					Rule:     cue.RulePermissionsTaskNoMatch,
+					Pointer:  pointer,
+				})
+			}
+		}
+	}
+	return errors
+}
+
+// taskPermissionPattern returns the agent pattern of a Task(...) or
+// Agent(...) permission entry. ok is false for other tools and for entries
+// that cover every agent.
+func taskPermissionPattern(perm string) (pattern string, ok bool) {
+	if tool := textutil.ExtractBaseToolName(perm); tool != "Task" && tool != "Agent" {
+		return "", false
+	}
+	open, closing := strings.IndexByte(perm, '('), strings.LastIndexByte(perm, ')')
+	if open < 0 || closing < open {
+		return "", false
+	}
+	pattern = strings.TrimSpace(perm[open+1 : closing])
+	if pattern == "" || pattern == "*" {
+		return "", false
+	}
+	return pattern, true
+}
+
+// anyAgentMatches reports whether a permission pattern matches one of
+// agents.
+func anyAgentMatches(agents []string, pattern string) bool {
+	re := ruleArgPattern(pattern)
+	for _, name := range agents {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidateTaskPermissions(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".claude", "agents"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".claude", "agents", "personal-helper.md"), []byte("---\nname: personal-helper\n---\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	files := []discovery.File{
+		{RelPath: "agents/quality-checker.md", Type: discovery.FileTypeAgent, Contents: "---\nname: quality-checker\n---\nChecks.\n"},
+		{RelPath: "agents/quality-fixer.md", Type: discovery.FileTypeAgent, Contents: "---\nname: quality-fixer\n---\nFixes.\n"},
+	}
+	cv := crossfile.NewCrossFileValidator(files)
+
+	tests := []struct {
+		name         string
+		perms        map[string]any
+		wantRules    []string
+		wantPointers []string
+	}{
+		{
+			name:  "agents and wildcards that resolve",
+			perms: map[string]any{"allow": []any{"Task(quality-checker)", "Agent(quality*)", "Task(personal-*)", "Task(Explore)"}},
+		},
+		{
+			name:  "whole-tool, plugin, and other entries are skipped",
+			perms: map[string]any{"allow": []any{"Task", "Task(*)", "Task(other-plugin:reviewer)", "Task(other-plugin:*)", "Bash(quality*)"}},
+		},
+		{
+			name:         "missing agent",
+			perms:        map[string]any{"allow": []any{"Read"}, "deny": []any{"Task(quality-reviewer)"}},
+			wantRules:    []string{cue.RulePermissionsTaskAgentMissing},
+			wantPointers: []string{"/permissions/deny/0"},
+		},
+		{
+			name:         "wildcard matching no agent",
+			perms:        map[string]any{"ask": []any{"Task(quality*)", "Task(security*)"}},
+			wantRules:    []string{cue.RulePermissionsTaskNoMatch},
+			wantPointers: []string{"/permissions/ask/1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateTaskPermissions(cv, map[string]any{"permissions": tt.perms}, "settings.json")
+			if len(got) != len(tt.wantRules) {
+				t.Fatalf("got %+v, want rules %v", got, tt.wantRules)
+			}
+			for i, e := range got {
+				if e.Rule != tt.wantRules[i] || e.Pointer != tt.wantPointers[i] {
+					t.Errorf("issue %d = %s at %s, want %s at %s", i, e.Rule, e.Pointer, tt.wantRules[i], tt.wantPointers[i])
+				}
+			}
+			if len(got) > 0 && tt.wantRules[0] == cue.RulePermissionsTaskAgentMissing && got[0].Severity != cue.SeverityError {
+				t.Errorf("missing agent severity = %s, want error", got[0].Severity)
+			}
+		})
+	}
+}
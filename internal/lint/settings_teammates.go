@@ -0,0 +1,92 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// teammateHookTools are the agent team hook events whose matcher names
+// teammate agents, and the tools a teammate needs for the event to be of
+// use: TaskCompleted fires when a teammate marks a task done with
+// TaskUpdate, and a TeammateIdle hook that sends a teammate back to work
+// expects it to find and claim the next task.
+var teammateHookTools = map[string][]string{
+	"TaskCompleted": {"TaskUpdate"},
+	"TeammateIdle":  {"TaskList", "TaskUpdate"},
+}
+
+// teammateNamePattern matches one agent name in a teammate hook matcher,
+// optionally plugin-namespaced. Alternatives that are regular expressions
+// rather than names are not checked.
+var teammateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*(:[A-Za-z0-9][A-Za-z0-9_-]*)?$`)
+
+// validateTeammateHooks checks the teammate agents that TeammateIdle and
+// TaskCompleted hook matchers name ("reviewer|tester"): each must resolve
+// to an agent, and a project agent must be able to call the tools the
+// event relies on. Entries with no matcher or "*" apply to every teammate
+// and are not checked.
+func validateTeammateHooks(cv *crossfile.CrossFileValidator, data map[string]any, filePath, contents string) []cue.ValidationError {
+	events, _ := data["hooks"].(map[string]any)
+	var errors []cue.ValidationError
+	for _, event := range slices.Sorted(maps.Keys(teammateHookTools)) {
+		entries, _ := events[event].([]any)
+		line := FindJSONFieldLine(contents, event)
+		for i, e := range entries {
+			entry, _ := e.(map[string]any)
+			matcher, _ := entry["matcher"].(string)
+			for _, name := range teammateNames(matcher) {
+				errors = append(errors, checkTeammate(cv, event, i, name, filePath, line)...)
+			}
+		}
+	}
+	return errors
+}
+
+// teammateNames splits a teammate hook matcher into the agent names it
+// lists. It returns nil for a matcher that applies to every teammate.
+func teammateNames(matcher string) []string {
+	var names []string
+	for alt := range strings.SplitSeq(matcher, "|") {
+		alt = strings.TrimSpace(alt)
+		if teammateNamePattern.MatchString(alt) {
+			names = append(names, alt)
+		}
+	}
+	return names
+}
+
+func checkTeammate(cv *crossfile.CrossFileValidator, event string, idx int, name, filePath string, line int) []cue.ValidationError {
+	if !cv.HasAgent(name) {
+		return []cue.ValidationError{{
+			File:     filePath,
+			Message:  fmt.Sprintf("Event '%s' hook %d: matcher names teammate '%s', but no agent '%s' exists; create agents/%s.md or list it in extraBuiltinAgents", event, idx, name, name, name),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleTeammateAgentMissing,
+			Line:     line,
+		}}
+	}
+	var missing []string
+	for _, tool := range teammateHookTools[event] {
+		if has, known := cv.AgentHasTool(name, tool); known && !has {
+			missing = append(missing, tool)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  fmt.Sprintf("Event '%s' hook %d: teammate '%s' cannot call %s, which %s relies on; list it in the agent's tools and keep it out of disallowedTools", event, idx, name, strings.Join(missing, ", "), event),
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleTeammateAgentTools,
+		Line:     line,
+	}}
+}
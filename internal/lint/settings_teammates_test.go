@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidateTeammateHooks(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "agents/reviewer.md", Type: discovery.FileTypeAgent, Contents: "---\nname: reviewer\ntools: Read, Grep, TaskList, TaskUpdate\n---\nReviews.\n"},
+		{RelPath: "agents/writer.md", Type: discovery.FileTypeAgent, Contents: "---\nname: writer\n---\nInherits every tool.\n"},
+		{RelPath: "agents/reader.md", Type: discovery.FileTypeAgent, Contents: "---\nname: reader\ntools: Read, TaskList\n---\nReads.\n"},
+	}
+	cv := crossfile.NewCrossFileValidator(files)
+
+	hook := func(matcher any) []any {
+		entry := map[string]any{"hooks": []any{map[string]any{"type": "command", "command": "check.sh"}}}
+		if matcher != nil {
+			entry["matcher"] = matcher
+		}
+		return []any{entry}
+	}
+	tests := []struct {
+		name      string
+		hooks     map[string]any
+		extra     []string
+		wantRules []string
+	}{
+		{
+			name:  "teammates exist with the tools they need",
+			hooks: map[string]any{"TeammateIdle": hook("reviewer|writer"), "TaskCompleted": hook("reviewer")},
+		},
+		{
+			name:  "no matcher applies to every teammate",
+			hooks: map[string]any{"TeammateIdle": hook(nil), "TaskCompleted": hook("*")},
+		},
+		{
+			name:      "missing teammate",
+			hooks:     map[string]any{"TaskCompleted": hook("reviewer|tester")},
+			wantRules: []string{cue.RuleTeammateAgentMissing},
+		},
+		{
+			name:  "extra built-in agent",
+			hooks: map[string]any{"TaskCompleted": hook("tester")},
+			extra: []string{"tester"},
+		},
+		{
+			name:      "teammate lacks a required tool",
+			hooks:     map[string]any{"TeammateIdle": hook("reader"), "TaskCompleted": hook("reader")},
+			wantRules: []string{cue.RuleTeammateAgentTools, cue.RuleTeammateAgentTools},
+		},
+		{
+			name:  "regex alternatives and other events are skipped",
+			hooks: map[string]any{"TeammateIdle": hook("test.*"), "PreToolUse": hook("missing")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateTeammateHooks(cv.WithExtraBuiltinAgents(tt.extra), map[string]any{"hooks": tt.hooks}, "settings.json", "")
+			var rules []string
+			for _, e := range got {
+				rules = append(rules, e.Rule)
+			}
+			if len(rules) != len(tt.wantRules) {
+				t.Fatalf("got rules %v, want %v (%+v)", rules, tt.wantRules, got)
+			}
+			for i := range rules {
+				if rules[i] != tt.wantRules[i] {
+					t.Errorf("rule[%d] = %s, want %s", i, rules[i], tt.wantRules[i])
+				}
+			}
+		})
+	}
+}
@@ -6,7 +6,7 @@ import (
 
 func TestLintSettings(t *testing.T) {
 	// Test with empty directory
-	summary, err := LintSettings("testdata/empty", false, false, true, nil)
+	summary, err := LintSettings(testOptions("testdata/empty"))
 	if err != nil {
 		t.Fatalf("LintSettings() error = %v", err)
 	}
@@ -203,7 +203,7 @@ func TestValidateSettingsSpecific(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := validateSettingsSpecific(tt.data, "settings.json")
+			errors := validateSettingsSpecific(defaultConfig(), tt.data, "settings.json")
 			if len(errors) != tt.wantErrorCount {
 				t.Errorf("validateSettingsSpecific() error count = %d, want %d", len(errors), tt.wantErrorCount)
 				for _, err := range errors {
@@ -0,0 +1,116 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// validatePermissionToolUsage joins the permissions allow and deny lists
+// with the tools the project's components name (see
+// crossfile.ToolUses). An allow entry for a tool no component names is
+// likely cruft, though the main session may still rely on it, so it is only
+// a suggestion and is skipped when no component has a tool list. A deny
+// entry that blocks a tool a component names makes every call to it fail.
+// Malformed lists are left to validatePermissions.
+func validatePermissionToolUsage(uses map[string][]crossfile.ToolUse, data map[string]any, filePath, contents string) []cue.ValidationError {
+	perms, _ := data["permissions"].(map[string]any)
+	if len(perms) == 0 || len(uses) == 0 {
+		return nil
+	}
+
+	var errors []cue.ValidationError
+	allow, _ := perms["allow"].([]any)
+	allowLine := FindJSONFieldLine(contents, "allow")
+	for i, entry := range allow {
+		perm, _ := entry.(string)
+		if perm == "" || len(permissionUsers(uses, perm, false)) > 0 {
+			continue
+		}
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("permissions.allow[%d]: no agent, command, or skill lists '%s' in its tools; remove the entry if the main session does not need it", i, perm),
+			Severity: cue.SeveritySuggestion,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RulePermissionsUnusedAllow,
+			Line:     allowLine,
+		})
+	}
+
+	deny, _ := perms["deny"].([]any)
+	denyLine := FindJSONFieldLine(contents, "deny")
+	for i, entry := range deny {
+		perm, _ := entry.(string)
+		users := permissionUsers(uses, perm, true)
+		if perm == "" || len(users) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(users))
+		for _, u := range users {
+			names = append(names, fmt.Sprintf("%s '%s' (%s)", u.Type, u.Name, u.Path))
+		}
+		errors = append(errors, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("permissions.deny[%d]: '%s' blocks a tool that %s lists; its calls will fail at runtime", i, perm, strings.Join(names, ", ")),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RulePermissionsDeniedTool,
+			Line:     denyLine,
+		})
+	}
+	return errors
+}
+
+// permissionUsers returns the components whose tool lists name the tool a
+// permission entry covers. With blocking set, only uses the entry blocks
+// outright count: a deny of the whole tool ("Bash", "Bash(*)"), or of
+// exactly the entry the component lists. A deny such as "Bash(rm:*)" does
+// not block a component that lists plain "Bash".
+func permissionUsers(uses map[string][]crossfile.ToolUse, perm string, blocking bool) []crossfile.ToolUse {
+	base := textutil.ExtractBaseToolName(perm)
+	wholeTool := !strings.Contains(perm, "(") || strings.HasSuffix(perm, "(*)")
+	var users []crossfile.ToolUse
+	for _, tool := range slices.Sorted(maps.Keys(uses)) {
+		if !permissionMatchesTool(base, tool) {
+			continue
+		}
+		for _, u := range uses[tool] {
+			if !blocking || wholeTool || u.Entry == perm {
+				users = append(users, u)
+			}
+		}
+	}
+	return users
+}
+
+// permissionMatchesTool reports whether a permission's base tool name and a
+// component's base tool name refer to the same tool. Task and Agent are the
+// old and new names of one tool; MCP names match by server ("mcp__github"
+// covers "mcp__github__create_issue") and by trailing "*".
+func permissionMatchesTool(perm, tool string) bool {
+	alias := func(name string) string {
+		if name == "Agent" {
+			return "Task"
+		}
+		return name
+	}
+	perm, tool = alias(perm), alias(tool)
+	if perm == tool {
+		return true
+	}
+	if !strings.HasPrefix(perm, "mcp__") || !strings.HasPrefix(tool, "mcp__") {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(perm, "*"); ok {
+		return strings.HasPrefix(tool, prefix)
+	}
+	if prefix, ok := strings.CutSuffix(tool, "*"); ok {
+		return strings.HasPrefix(perm, prefix)
+	}
+	return strings.HasPrefix(tool, perm+"__") || strings.HasPrefix(perm, tool+"__")
+}
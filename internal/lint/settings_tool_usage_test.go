@@ -0,0 +1,98 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidatePermissionToolUsage(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: "agents/builder.md", Type: discovery.FileTypeAgent, Contents: "---\nname: builder\ntools: Read, Bash(go:*), Agent\n---\nBuilds.\n"},
+		{RelPath: "agents/open.md", Type: discovery.FileTypeAgent, Contents: "---\nname: open\n---\nInherits every tool.\n"},
+		{RelPath: "skills/gh/SKILL.md", Type: discovery.FileTypeSkill, Contents: "---\nname: gh\nallowed-tools: mcp__github__create_issue\n---\nFiles issues.\n"},
+	}
+	uses := crossfile.NewCrossFileValidator(files).ToolUses()
+
+	tests := []struct {
+		name      string
+		perms     map[string]any
+		uses      map[string][]crossfile.ToolUse
+		wantRules []string
+	}{
+		{
+			name:  "allows for used tools",
+			perms: map[string]any{"allow": []any{"Read", "Bash(npm:*)", "Task", "mcp__github"}},
+		},
+		{
+			name:      "allow for a tool no component lists",
+			perms:     map[string]any{"allow": []any{"Read", "WebFetch"}},
+			wantRules: []string{cue.RulePermissionsUnusedAllow},
+		},
+		{
+			name:  "no component has a tool list",
+			perms: map[string]any{"allow": []any{"WebFetch"}},
+			uses:  map[string][]crossfile.ToolUse{},
+		},
+		{
+			name:      "deny blocks a whole tool",
+			perms:     map[string]any{"deny": []any{"Bash", "mcp__github__*"}},
+			wantRules: []string{cue.RulePermissionsDeniedTool, cue.RulePermissionsDeniedTool},
+		},
+		{
+			name:      "deny of the exact entry",
+			perms:     map[string]any{"deny": []any{"Bash(go:*)"}},
+			wantRules: []string{cue.RulePermissionsDeniedTool},
+		},
+		{
+			name:  "narrow deny leaves the tool usable",
+			perms: map[string]any{"deny": []any{"Bash(rm:*)", "Read(./.env)", "Write"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := uses
+			if tt.uses != nil {
+				u = tt.uses
+			}
+			data := map[string]any{"permissions": tt.perms}
+			got := validatePermissionToolUsage(u, data, "settings.json", "")
+			var rules []string
+			for _, e := range got {
+				rules = append(rules, e.Rule)
+			}
+			if len(rules) != len(tt.wantRules) {
+				t.Fatalf("got rules %v, want %v (%+v)", rules, tt.wantRules, got)
+			}
+			for i := range rules {
+				if rules[i] != tt.wantRules[i] {
+					t.Errorf("rule[%d] = %s, want %s", i, rules[i], tt.wantRules[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPermissionMatchesTool(t *testing.T) {
+	tests := []struct {
+		perm, tool string
+		want       bool
+	}{
+		{"Bash", "Bash", true},
+		{"Task", "Agent", true},
+		{"Read", "Write", false},
+		{"mcp__github", "mcp__github__create_issue", true},
+		{"mcp__github__create_issue", "mcp__github", true},
+		{"mcp__github__*", "mcp__github__list", true},
+		{"mcp__git", "mcp__github__list", false},
+		{"mcp__slack__post", "mcp__github__*", false},
+	}
+	for _, tt := range tests {
+		if got := permissionMatchesTool(tt.perm, tt.tool); got != tt.want {
+			t.Errorf("permissionMatchesTool(%q, %q) = %v, want %v", tt.perm, tt.tool, got, tt.want)
+		}
+	}
+}
@@ -15,10 +15,10 @@
 // # Usage
 //
 //	// Lint a single file
-//	summary, err := LintSingleFile("./agents/my-agent.md", "", false, false)
+//	summary, err := LintSingleFile("./agents/my-agent.md", "", "", Options{})
 //
 //	// Lint multiple files
-//	summary, err := LintFiles([]string{"a.md", "b.md"}, "", "", false, false)
+//	summary, err := LintFiles([]string{"a.md", "b.md"}, "", "", Options{})
 package lint
 
 import (
@@ -30,6 +30,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
@@ -48,11 +49,10 @@ type DiscoveryCache struct {
 	err   error
 }
 
-// Get returns cached discovery results, performing discovery on first call.
-// The rootPath is used to create the FileDiscovery instance.
-func (dc *DiscoveryCache) Get(rootPath string) ([]discovery.File, error) {
+// Get returns cached discovery results, performing discovery with
+// discoverer on first call.
+func (dc *DiscoveryCache) Get(discoverer *discovery.FileDiscovery) ([]discovery.File, error) {
 	dc.once.Do(func() {
-		discoverer := discovery.NewFileDiscovery(rootPath, false)
 		dc.files, dc.err = discoverer.DiscoverFiles()
 	})
 	return dc.files, dc.err
@@ -68,6 +68,7 @@ type SingleFileLinterContext struct {
 	File      discovery.File
 	Quiet     bool
 	Verbose   bool
+	Config    *config.Config
 	Validator *cue.Validator
 	Warnings  []cue.ValidationError
 
@@ -85,8 +86,7 @@ type SingleFileRequest struct {
 	FilePath       string
 	RootPath       string
 	TypeOverride   string
-	Quiet          bool
-	Verbose        bool
+	Options        Options
 	DiscoveryCache *DiscoveryCache
 }
 
@@ -103,17 +103,15 @@ type SingleFileRequest struct {
 //   - filePath: Path to the file (absolute or relative)
 //   - rootPath: Project root (empty to auto-detect)
 //   - typeOverride: Force component type (empty to auto-detect)
-//   - quiet: Suppress non-essential output
-//   - verbose: Enable verbose output
+//   - opts: The configuration to lint with; its root is not used
 //
 // Returns an error with actionable message for any validation failure.
-func NewSingleFileLinterContext(filePath, rootPath, typeOverride string, quiet, verbose bool) (*SingleFileLinterContext, error) {
+func NewSingleFileLinterContext(filePath, rootPath, typeOverride string, opts Options) (*SingleFileLinterContext, error) {
 	return newSingleFileLinterContext(SingleFileRequest{
 		FilePath:     filePath,
 		RootPath:     rootPath,
 		TypeOverride: typeOverride,
-		Quiet:        quiet,
-		Verbose:      verbose,
+		Options:      opts,
 	})
 }
 
@@ -154,7 +152,7 @@ func newSingleFileLinterContext(req SingleFileRequest) (*SingleFileLinterContext
 			return nil, err
 		}
 	} else {
-		fileType, err = discovery.DetectFileType(absPath, rootPath)
+		fileType, err = req.Options.config().DetectFileType(absPath, rootPath)
 		if err != nil {
 			return nil, err
 		}
@@ -188,7 +186,8 @@ func newSingleFileLinterContext(req SingleFileRequest) (*SingleFileLinterContext
 	}
 
 	// Initialize CUE validator
-	validator := cue.NewValidator()
+	cfg := req.Options.config()
+	validator := newValidator(cfg)
 	var warnings []cue.ValidationError
 	if err := validator.LoadSchemas(""); err != nil {
 		warnings = append(warnings, cue.ValidationError{
@@ -196,14 +195,16 @@ func newSingleFileLinterContext(req SingleFileRequest) (*SingleFileLinterContext
 			Message:  fmt.Sprintf("CUE schemas not loaded, using Go validation: %v", err),
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleSchemaValidationSkipped,
 		})
 	}
 
 	return &SingleFileLinterContext{
 		RootPath:       rootPath,
 		File:           file,
-		Quiet:          req.Quiet,
-		Verbose:        req.Verbose,
+		Quiet:          cfg.Quiet(),
+		Verbose:        cfg.Verbose(),
+		Config:         cfg,
 		Validator:      validator,
 		Warnings:       warnings,
 		discoveryCache: req.DiscoveryCache,
@@ -229,15 +230,16 @@ func (ctx *SingleFileLinterContext) EnsureCrossFileValidator() *crossfile.CrossF
 	// Use shared cache if available, otherwise discover directly
 	var files []discovery.File
 	var err error
+	cfg := ctx.config()
+	discoverer := cfg.NewFileDiscovery(ctx.RootPath)
 	if ctx.discoveryCache != nil {
-		files, err = ctx.discoveryCache.Get(ctx.RootPath)
+		files, err = ctx.discoveryCache.Get(discoverer)
 	} else {
-		discoverer := discovery.NewFileDiscovery(ctx.RootPath, false)
 		files, err = discoverer.DiscoverFiles()
 	}
 
 	if err == nil && len(files) > 0 {
-		ctx.crossValidator = crossfile.NewCrossFileValidator(files, ctx.RootPath)
+		ctx.crossValidator = crossfile.NewCrossFileValidator(files, ctx.RootPath).WithExtraBuiltinAgents(cfg.ExtraBuiltinAgents)
 	} else {
 		ctx.crossLoadErr = err
 	}
@@ -246,6 +248,14 @@ func (ctx *SingleFileLinterContext) EnsureCrossFileValidator() *crossfile.CrossF
 	return ctx.crossValidator
 }
 
+// config returns the context's configuration, or the defaults when unset.
+func (ctx *SingleFileLinterContext) config() *config.Config {
+	if ctx.Config == nil {
+		return defaultConfig()
+	}
+	return ctx.Config
+}
+
 // findProjectRootForFile attempts to find the project root for a given file.
 // Falls back to inferring from .claude directory structure.
 func findProjectRootForFile(absPath string) (string, error) {
@@ -286,20 +296,17 @@ func findProjectRootForFile(absPath string) (string, error) {
 //   - filePath: Path to the file (absolute or relative)
 //   - rootPath: Project root (empty to auto-detect)
 //   - typeOverride: Force component type (empty to auto-detect)
-//   - quiet: Suppress non-essential output
-//   - verbose: Enable verbose output
+//   - opts: The configuration to lint with; its root is not used
 //
-// Exit codes (for CLI):
-//   - 0: Success (no errors)
-//   - 1: Lint errors found
-//   - 2: Invalid invocation (returned as error)
-func LintSingleFile(filePath, rootPath, typeOverride string, quiet, verbose bool) (*LintSummary, error) {
+// Findings are reported in the summary; invocation problems (missing file,
+// unknown type) are returned as an error. The caller decides how either
+// maps to a process exit.
+func LintSingleFile(filePath, rootPath, typeOverride string, opts Options) (*LintSummary, error) {
 	return lintSingleFileRequest(SingleFileRequest{
 		FilePath:     filePath,
 		RootPath:     rootPath,
 		TypeOverride: typeOverride,
-		Quiet:        quiet,
-		Verbose:      verbose,
+		Options:      opts,
 	})
 }
 
@@ -340,10 +347,12 @@ func lintSingleFileRequest(req SingleFileRequest) (*LintSummary, error) {
 	}
 	result.Warnings = append(result.Warnings, ctx.Warnings...)
 	result.Success = len(result.Errors) == 0
+	dedupeResult(&result, func() string { return ctx.File.Contents })
 
 	// Update summary
 	applyResultToSummary(summary, result)
 	summary.Results = []LintResult{result}
+	SortSummary(summary)
 	summary.Duration = time.Since(summary.StartTime).Milliseconds()
 
 	return summary, nil
@@ -360,14 +369,14 @@ type fileWithHint struct {
 // contained .md and .json files. Non-directory paths are kept as-is.
 // Hidden child directories (.git, etc.) are skipped during traversal.
 //
-// When typeOverride is empty, files are filtered through DetectFileType so
+// When typeOverride is empty, files are filtered through cfg.DetectFileType so
 // that non-component files (references/, prompts/, usage-data/, etc.) are
 // silently excluded. If DetectFileType fails, the walked directory's base
 // name is checked via ParseFileType as a fallback — this allows singular
 // directory names (e.g., "command/", "agent/") to work alongside the
 // standard plural forms. When typeOverride is set, all .md/.json files are
 // included since the user is explicitly declaring the component type.
-func expandDirectories(paths []string, typeOverride string) ([]fileWithHint, error) {
+func expandDirectories(cfg *config.Config, paths []string, typeOverride string) ([]fileWithHint, error) {
 	result := make([]fileWithHint, 0, len(paths))
 	for _, p := range paths {
 		info, err := os.Stat(p)
@@ -411,7 +420,7 @@ func expandDirectories(paths []string, typeOverride string) ([]fileWithHint, err
 			if err != nil {
 				return err
 			}
-			if _, err := discovery.DetectFileType(absPath, rootPath); err == nil {
+			if _, err := cfg.DetectFileType(absPath, rootPath); err == nil {
 				result = append(result, fileWithHint{Path: path})
 			} else if dirHint != "" {
 				// Skills require SKILL.md filename — don't apply dirHint to arbitrary .md files
@@ -437,14 +446,13 @@ func expandDirectories(paths []string, typeOverride string) ([]fileWithHint, err
 //   - filePaths: Paths to files (absolute or relative)
 //   - rootPath: Project root (empty to auto-detect per file)
 //   - typeOverride: Force component type (empty to auto-detect)
-//   - quiet: Suppress non-essential output
-//   - verbose: Enable verbose output
-func LintFiles(filePaths []string, rootPath, typeOverride string, quiet, verbose bool) (*LintSummary, error) {
+//   - opts: The configuration to lint with; its root is not used
+func LintFiles(filePaths []string, rootPath, typeOverride string, opts Options) (*LintSummary, error) {
 	if len(filePaths) == 0 {
 		return nil, fmt.Errorf("no files specified")
 	}
 
-	expanded, err := expandDirectories(filePaths, typeOverride)
+	expanded, err := expandDirectories(opts.config(), filePaths, typeOverride)
 	if err != nil {
 		return nil, err
 	}
@@ -473,8 +481,7 @@ func LintFiles(filePaths []string, rootPath, typeOverride string, quiet, verbose
 			FilePath:       fh.Path,
 			RootPath:       rootPath,
 			TypeOverride:   effectiveType,
-			Quiet:          quiet,
-			Verbose:        verbose,
+			Options:        opts,
 			DiscoveryCache: cache,
 		})
 		if err != nil {
@@ -487,6 +494,7 @@ func LintFiles(filePaths []string, rootPath, typeOverride string, quiet, verbose
 					File:     fh.Path,
 					Message:  err.Error(),
 					Severity: cue.SeverityError,
+					Rule:     cue.RuleLintFailed,
 				}},
 			})
 			summary.TotalFiles++
@@ -507,6 +515,7 @@ func LintFiles(filePaths []string, rootPath, typeOverride string, quiet, verbose
 	}
 
 	summary.ProjectRoot = firstRoot
+	SortSummary(summary)
 	summary.Duration = time.Since(summary.StartTime).Milliseconds()
 
 	return summary, nil
@@ -514,40 +523,40 @@ func LintFiles(filePaths []string, rootPath, typeOverride string, quiet, verbose
 
 // lintSingleAgent lints a single agent file using the generic linter.
 func lintSingleAgent(ctx *SingleFileLinterContext) LintResult {
-	return lintComponent(ctx, NewAgentLinter())
+	return lintComponent(ctx, NewAgentLinter(ctx.RootPath, ctx.Config))
 }
 
 // lintSingleCommand lints a single command file using the generic linter.
 func lintSingleCommand(ctx *SingleFileLinterContext) LintResult {
-	return lintComponent(ctx, NewCommandLinter())
+	return lintComponent(ctx, NewCommandLinter(ctx.Config))
 }
 
 // lintSingleSkill lints a single skill file using the generic linter.
 func lintSingleSkill(ctx *SingleFileLinterContext) LintResult {
-	return lintComponent(ctx, NewSkillLinter())
+	return lintComponent(ctx, NewSkillLinter(ctx.RootPath, ctx.Config))
 }
 
 // lintSingleSettings lints a single settings file using the generic linter.
 func lintSingleSettings(ctx *SingleFileLinterContext) LintResult {
-	return lintComponent(ctx, NewSettingsLinter())
+	return lintComponent(ctx, NewSettingsLinter(ctx.RootPath, ctx.Config))
 }
 
 // lintSingleContext lints a single CLAUDE.md context file using the generic linter.
 func lintSingleContext(ctx *SingleFileLinterContext) LintResult {
-	return lintComponent(ctx, NewContextLinter())
+	return lintComponent(ctx, NewContextLinter(ctx.Config))
 }
 
 // lintSinglePlugin lints a single plugin.json file using the generic linter.
 func lintSinglePlugin(ctx *SingleFileLinterContext) LintResult {
-	return lintComponent(ctx, NewPluginLinter(ctx.RootPath))
+	return lintComponent(ctx, NewPluginLinter(ctx.RootPath, ctx.Config))
 }
 
 // lintSingleOutputStyle lints a single output style file using the generic linter.
 func lintSingleOutputStyle(ctx *SingleFileLinterContext) LintResult {
-	return lintComponent(ctx, NewOutputStyleLinter())
+	return lintComponent(ctx, NewOutputStyleLinter(ctx.Config))
 }
 
 // lintSingleRule lints a single rule file using the generic linter.
 func lintSingleRule(ctx *SingleFileLinterContext) LintResult {
-	return lintComponent(ctx, NewRuleLinter())
+	return lintComponent(ctx, NewRuleLinter(ctx.Config))
 }
@@ -3,8 +3,10 @@ package lint
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
 )
 
@@ -242,13 +244,13 @@ No name or description.
 		wantErr     bool // Expect error to be returned (not in results)
 	}{
 		{"valid agent", validAgent, "", true, 0, false},
-		{"invalid agent", invalidAgent, "", false, 4, false}, // Missing name and description (2 CUE + 2 Go checks)
+		{"invalid agent", invalidAgent, "", false, 2, false}, // Missing name and description (CUE duplicates dropped)
 		{"nonexistent", filepath.Join(tmpDir, "nope.md"), "", false, 0, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			summary, err := LintSingleFile(tt.file, tmpDir, tt.typeOver, true, false)
+			summary, err := LintSingleFile(tt.file, tmpDir, tt.typeOver, testOptions(""))
 
 			// For nonexistent files, error is returned directly
 			if tt.wantErr {
@@ -282,6 +284,73 @@ No name or description.
 	}
 }
 
+func TestLintSingleFile_SchemaSkipped(t *testing.T) {
+	tmpDir := t.TempDir()
+	createDirs(t, tmpDir, ".claude/agents")
+	file := filepath.Join(tmpDir, ".claude/agents/deep.md")
+	content := "---\nname: deep\ndescription: A test agent for testing purposes. Use PROACTIVELY when testing.\nextra: " +
+		strings.Repeat("[", 40) + strings.Repeat("]", 40) + "\n---\n\n## Foundation\n\nBody.\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := LintSingleFile(file, tmpDir, "", testOptions(""))
+	if err != nil {
+		t.Fatalf("LintSingleFile() error = %v", err)
+	}
+	result := summary.Results[0]
+	var skipped []cue.ValidationError
+	for _, w := range result.Warnings {
+		if w.Rule == cue.RuleSchemaValidationSkipped {
+			skipped = append(skipped, w)
+		}
+	}
+	if len(skipped) != 1 || skipped[0].File == "" {
+		t.Fatalf("warnings = %v, want one %s naming the file", result.Warnings, cue.RuleSchemaValidationSkipped)
+	}
+	if !result.Success {
+		t.Errorf("errors = %v, want the file to pass", result.Errors)
+	}
+}
+
+func TestLintSingleFile_FrontmatterRecovery(t *testing.T) {
+	tmpDir := t.TempDir()
+	createDirs(t, tmpDir, ".claude/agents")
+	file := filepath.Join(tmpDir, ".claude/agents/broken.md")
+	content := "---\nname: Broken_Agent\ndescription: Use when: reviewing code\ntools: [Read, Grep\nmodel: sonnet\n---\n\n## Foundation\n\nBody.\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := LintSingleFile(file, tmpDir, "", testOptions(""))
+	if err != nil {
+		t.Fatalf("LintSingleFile() error = %v", err)
+	}
+	result := summary.Results[0]
+
+	var syntaxLines []int
+	var nameInvalid bool
+	for _, e := range result.Errors {
+		switch {
+		case e.Rule == cue.RuleFrontmatterSyntax:
+			syntaxLines = append(syntaxLines, e.Line)
+		case strings.Contains(e.Message, "'description'"):
+			t.Errorf("unexpected finding about the unreadable description: %s", e.Message)
+		case strings.HasPrefix(e.Message, "Name must contain only lowercase"):
+			nameInvalid = true
+		}
+	}
+	if len(syntaxLines) != 2 || syntaxLines[0] != 3 || syntaxLines[1] != 4 {
+		t.Errorf("%s errors on lines %v, want [3 4]; errors: %v", cue.RuleFrontmatterSyntax, syntaxLines, result.Errors)
+	}
+	if !nameInvalid {
+		t.Errorf("errors = %v, want the recovered name to be validated", result.Errors)
+	}
+	if result.Success {
+		t.Error("Success = true, want false")
+	}
+}
+
 // TestLintFiles tests multi-file linting.
 func TestLintFiles(t *testing.T) {
 	// Create test files
@@ -310,7 +379,7 @@ Task(test): Do something
 		t.Fatal(err)
 	}
 
-	summary, err := LintFiles([]string{validAgent, validCommand}, tmpDir, "", true, false)
+	summary, err := LintFiles([]string{validAgent, validCommand}, tmpDir, "", testOptions(""))
 	if err != nil {
 		t.Fatalf("LintFiles() error: %v", err)
 	}
@@ -367,7 +436,7 @@ Test skill content.
 		t.Fatal(err)
 	}
 
-	summary, err := LintSingleFile(skillFile, tmpDir, "", true, false)
+	summary, err := LintSingleFile(skillFile, tmpDir, "", testOptions(""))
 	if err != nil {
 		t.Fatalf("LintSingleFile(skill) error: %v", err)
 	}
@@ -431,7 +500,7 @@ func TestExpandDirectories(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := expandDirectories(tt.paths, tt.typeOver)
+			result, err := expandDirectories(defaultConfig(), tt.paths, tt.typeOver)
 			if err != nil {
 				t.Fatalf("expandDirectories() error = %v", err)
 			}
@@ -480,7 +549,7 @@ Test agent foundation.
 		// FilePath is a relative path that only exists under tmpDir, not the
 		// cwd. With RootPath set, it must be resolved there and NOT error with
 		// "file not found".
-		summary, err := LintSingleFile(relPath, tmpDir, "", true, false)
+		summary, err := LintSingleFile(relPath, tmpDir, "", testOptions(""))
 		if err != nil {
 			if containsString(err.Error(), "file not found") {
 				t.Fatalf("relative path not resolved against --root: %v", err)
@@ -500,7 +569,7 @@ Test agent foundation.
 
 		// Same as above but with a leading "./" to exercise that form too.
 		prefixed := "./" + relPath
-		summary, err := LintSingleFile(prefixed, tmpDir, "", true, false)
+		summary, err := LintSingleFile(prefixed, tmpDir, "", testOptions(""))
 		if err != nil {
 			if containsString(err.Error(), "file not found") {
 				t.Fatalf("prefixed relative path not resolved against --root: %v", err)
@@ -519,7 +588,7 @@ Test agent foundation.
 		// cwd. Since the file only exists under tmpDir (not the cwd), this
 		// must fail with "file not found" — proving the fix did not change
 		// the default cwd-relative behavior.
-		_, err := LintSingleFile(relPath, "", "", true, false)
+		_, err := LintSingleFile(relPath, "", "", testOptions(""))
 		if err == nil {
 			t.Fatal("expected file-not-found error for relative path under empty root, got nil")
 		}
@@ -532,7 +601,7 @@ Test agent foundation.
 		t.Parallel()
 
 		// Sanity guard: an absolute path must keep working regardless of root.
-		summary, err := LintSingleFile(absFile, tmpDir, "", true, false)
+		summary, err := LintSingleFile(absFile, tmpDir, "", testOptions(""))
 		if err != nil {
 			t.Fatalf("absolute path failed unexpectedly: %v", err)
 		}
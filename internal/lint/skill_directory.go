@@ -72,6 +72,7 @@ func validateScriptsDirectory(scriptsDir, skillPath string) []cue.ValidationErro
 					Message:  fmt.Sprintf("Script '%s' missing shebang (e.g., #!/usr/bin/env python3)", relPath),
 					Severity: cue.SeveritySuggestion,
 					Source:   cue.SourceAgentSkillsIO,
+					Rule:     cue.RuleSkillScriptShebang,
 				})
 			}
 		}
@@ -86,6 +87,7 @@ func validateScriptsDirectory(scriptsDir, skillPath string) []cue.ValidationErro
 					Message:  fmt.Sprintf("Script '%s' is not executable (chmod +x)", relPath),
 					Severity: cue.SeveritySuggestion,
 					Source:   cue.SourceAgentSkillsIO,
+					Rule:     cue.RuleSkillScriptNotExecutable,
 				})
 			}
 		}
@@ -94,13 +96,14 @@ func validateScriptsDirectory(scriptsDir, skillPath string) []cue.ValidationErro
 	return issues
 }
 
+// markdownLinkPattern matches markdown links: [text](path) or [text](path "title").
+var markdownLinkPattern = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+
 // validateRelativePaths checks for absolute paths in markdown links.
 func validateRelativePaths(contents, skillPath string) []cue.ValidationError {
 	var issues []cue.ValidationError
 
-	// Match markdown links: [text](path) or [text](path "title")
-	linkPattern := regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
-	matches := linkPattern.FindAllStringSubmatch(contents, -1)
+	matches := markdownLinkPattern.FindAllStringSubmatch(contents, -1)
 
 	for _, match := range matches {
 		if len(match) < 3 {
@@ -121,6 +124,7 @@ func validateRelativePaths(contents, skillPath string) []cue.ValidationError {
 				Message:  fmt.Sprintf("Use relative path instead of absolute: '%s'", linkPath),
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceAgentSkillsIO,
+				Rule:     cue.RuleSkillLinkAbsolute,
 				Line:     line,
 			})
 		}
@@ -134,8 +138,7 @@ func validateReferenceDepth(contents string, skillDir, skillPath string) []cue.V
 	var issues []cue.ValidationError
 
 	// Extract markdown links to local files
-	linkPattern := regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
-	matches := linkPattern.FindAllStringSubmatch(contents, -1)
+	matches := markdownLinkPattern.FindAllStringSubmatch(contents, -1)
 
 	for _, match := range matches {
 		if len(match) < 3 {
@@ -153,7 +156,7 @@ func validateReferenceDepth(contents string, skillDir, skillPath string) []cue.V
 		}
 
 		// Check for nested references in the linked file
-		if nestedIssues := checkNestedReferences(linkPath, skillDir, skillPath, linkPattern); len(nestedIssues) > 0 {
+		if nestedIssues := checkNestedReferences(linkPath, skillDir, skillPath, markdownLinkPattern); len(nestedIssues) > 0 {
 			issues = append(issues, nestedIssues...)
 		}
 	}
@@ -223,6 +226,7 @@ func checkNestedReferences(linkPath, skillDir, skillPath string, linkPattern *re
 				Message:  fmt.Sprintf("Reference chain detected: SKILL.md → %s → %s (keep references 1 level deep)", linkPath, nestedPath),
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceAgentSkillsIO,
+				Rule:     cue.RuleSkillReferenceDepth,
 			})
 			break // Only report once per referenced file
 		}
@@ -0,0 +1,194 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// defaultSkillBodyMaxLines is the SKILL.md body size past which detail
+// should move into reference files that load on demand.
+const defaultSkillBodyMaxLines = 500
+
+// skillTriggerPhrases mark a description that says when to use the skill.
+var skillTriggerPhrases = []string{
+	"use when", "use for", "use proactively", "use this skill", "use after", "use before",
+	"when ", "whenever", "invoke ", "triggers on", "trigger on",
+	"not for", "covers", "handles",
+}
+
+// validateSkillDescriptionTrigger warns when a skill description does not
+// state when the skill applies. Claude decides whether to load a skill from
+// its description alone, so the trigger conditions belong there rather
+// than in the body.
+func validateSkillDescriptionTrigger(description, filePath, contents string) []cue.ValidationError {
+	if strings.TrimSpace(description) == "" {
+		return nil
+	}
+	lower := strings.ToLower(description)
+	for _, phrase := range skillTriggerPhrases {
+		if strings.Contains(lower, phrase) {
+			return nil
+		}
+	}
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  "Skill description does not say when to use the skill. Add trigger phrases like 'Use when...' or 'Use for...' so Claude knows when to load it",
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceAnthropicDocs,
+		Rule:     cue.RuleSkillDescriptionTrigger,
+		Line:     textutil.FindFrontmatterFieldLine(contents, "description"),
+	}}
+}
+
+// skillClauseTriggers introduce the clause that names when a skill applies.
+// Unlike skillTriggerPhrases they exclude "covers" and "handles", which
+// describe what the skill does rather than when to load it.
+var skillClauseTriggers = []string{
+	"use when", "use for", "use proactively", "use after", "use before",
+	"when ", "whenever", "invoke ", "triggers on", "trigger on", "not for",
+}
+
+// skillTriggerVerbs make a trigger clause concrete on their own: "Use when
+// debugging" names a task, "Use when needed" does not.
+var skillTriggerVerbs = []string{
+	"add", "analy", "audit", "build", "commit", "configur", "convert", "creat", "debug",
+	"deploy", "design", "document", "edit", "extract", "fix", "format", "generat",
+	"install", "lint", "migrat", "optimi", "pars", "plan", "publish", "quer", "refactor",
+	"releas", "renam", "review", "scaffold", "search", "summari", "test", "translat",
+	"upgrad", "validat", "writ",
+}
+
+// skillGenericWords say nothing about when a skill applies.
+var skillGenericWords = map[string]bool{
+	"anything": true, "applicable": true, "appropriate": true, "ask": true, "asked": true,
+	"case": true, "general": true, "help": true, "helpful": true, "need": true, "needed": true,
+	"necessary": true, "relevant": true, "required": true, "situation": true, "something": true,
+	"stuff": true, "task": true, "thing": true, "useful": true, "various": true, "want": true,
+	"work": true, "working": true,
+}
+
+var (
+	// fileExtensionPattern matches a file extension such as ".proto" or "*.tsx".
+	fileExtensionPattern = regexp.MustCompile(`(^|[\s*(])\.[a-z0-9]{1,6}\b`)
+
+	// clauseEndPattern matches the end of a sentence or clause; a period
+	// only counts when whitespace follows, so ".proto" stays in the clause.
+	clauseEndPattern = regexp.MustCompile(`[.;!?](\s|$)|\n`)
+)
+
+// validateSkillTriggerSpecificity suggests sharper wording when a skill
+// description has trigger phrases but none of them names a file type, a
+// task, or a subject, as in "Use when needed". Descriptions without any
+// trigger phrase are left to validateSkillDescriptionTrigger.
+func validateSkillTriggerSpecificity(description, filePath, contents string) []cue.ValidationError {
+	clauses := skillTriggerClauses(description)
+	if len(clauses) == 0 {
+		return nil
+	}
+	for _, clause := range clauses {
+		if concreteTriggerClause(clause.text) {
+			return nil
+		}
+	}
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  fmt.Sprintf("Skill trigger '%s' is vague; name the files, tasks, or subjects that should load the skill, e.g. 'Use when editing .proto files'", clauses[0].phrase),
+		Severity: cue.SeveritySuggestion,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleSkillTriggerVague,
+		Line:     textutil.FindFrontmatterFieldLine(contents, "description"),
+	}}
+}
+
+// triggerClause is the text following a trigger phrase, up to the end of
+// its sentence.
+type triggerClause struct {
+	phrase string // the trigger phrase and its clause, lowercased
+	text   string // the clause alone
+}
+
+// skillTriggerClauses returns the clause after each trigger phrase in
+// description, in order of appearance.
+func skillTriggerClauses(description string) []triggerClause {
+	lower := strings.ToLower(description)
+	var clauses []triggerClause
+	covered := -1 // "use when" also contains "when "; keep the longer match
+	for i := 0; i < len(lower); i++ {
+		if i < covered || (i > 0 && isWordByte(lower[i-1])) {
+			continue
+		}
+		for _, trigger := range skillClauseTriggers {
+			if !strings.HasPrefix(lower[i:], trigger) {
+				continue
+			}
+			start := i + len(trigger)
+			end := len(lower)
+			if loc := clauseEndPattern.FindStringIndex(lower[start:]); loc != nil {
+				end = start + loc[0]
+			}
+			clauses = append(clauses, triggerClause{
+				phrase: strings.TrimSpace(lower[i:end]),
+				text:   strings.TrimSpace(lower[start:end]),
+			})
+			covered = end
+			break
+		}
+	}
+	return clauses
+}
+
+// concreteTriggerClause reports whether a trigger clause names a file type,
+// an action, or at least two non-generic subject words.
+func concreteTriggerClause(clause string) bool {
+	if fileExtensionPattern.MatchString(clause) {
+		return true
+	}
+	subjects := 0
+	for _, word := range textutil.ContentWords(clause) {
+		if skillGenericWords[word] {
+			continue
+		}
+		for _, verb := range skillTriggerVerbs {
+			if strings.HasPrefix(word, verb) {
+				return true
+			}
+		}
+		subjects++
+	}
+	return subjects >= 2
+}
+
+// validateSkillBodySize warns when the SKILL.md body outgrows maxLines,
+// from the rules.skillBodyMaxLines config key; 0 uses the default.
+// Everything in SKILL.md loads with the skill; reference files under the
+// skill directory load only when needed.
+func validateSkillBodySize(filePath, contents string, maxLines int) []cue.ValidationError {
+	if maxLines <= 0 {
+		maxLines = defaultSkillBodyMaxLines
+	}
+	body := strings.TrimSpace(extractBody(contents))
+	if body == "" {
+		return nil
+	}
+	lines := textutil.CountLines(body)
+	if lines <= maxLines {
+		return nil
+	}
+
+	line := 1
+	if strings.HasPrefix(contents, frontmatterDelimiter) {
+		line = textutil.GetFrontmatterEndLine(contents) + 1
+	}
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  fmt.Sprintf("Skill body is %d lines, over the %d-line limit (rules.skillBodyMaxLines). Move detailed material into reference files (e.g. references/*.md) and link them from SKILL.md", lines, maxLines),
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceAnthropicDocs,
+		Rule:     cue.RuleSkillBodySize,
+		Line:     line,
+	}}
+}
@@ -0,0 +1,119 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestValidateSkillDescriptionTrigger(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		wantWarn    bool
+	}{
+		{name: "use when", description: "Formats Go code. Use when the user asks to tidy imports."},
+		{name: "whenever", description: "Runs the linter whenever Go files change."},
+		{name: "not for", description: "Release notes writer, not for changelog entries."},
+		{name: "no trigger", description: "Formats Go code and tidies imports.", wantWarn: true},
+		{name: "empty description", description: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents := "---\nname: fmt\ndescription: " + tt.description + "\n---\n"
+			got := validateSkillDescriptionTrigger(tt.description, "skills/fmt/SKILL.md", contents)
+			if (len(got) > 0) != tt.wantWarn {
+				t.Fatalf("validateSkillDescriptionTrigger() = %v, wantWarn %v", got, tt.wantWarn)
+			}
+			if !tt.wantWarn {
+				return
+			}
+			if got[0].Rule != cue.RuleSkillDescriptionTrigger || got[0].Severity != cue.SeverityWarning {
+				t.Errorf("rule/severity = %s/%s, want %s/warning", got[0].Rule, got[0].Severity, cue.RuleSkillDescriptionTrigger)
+			}
+			if got[0].Line != 3 {
+				t.Errorf("Line = %d, want 3", got[0].Line)
+			}
+		})
+	}
+}
+
+func TestValidateSkillTriggerSpecificity(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		wantPhrase  string // empty when no suggestion is expected
+	}{
+		{name: "file type", description: "Protobuf helper. Use when editing .proto files."},
+		{name: "glob", description: "Use for *.tsx components."},
+		{name: "action verb", description: "Go helper. Use when debugging."},
+		{name: "subject words", description: "Use when the Kafka consumer lags."},
+		{name: "no trigger phrase", description: "Formats Go code."},
+		{name: "when needed", description: "Formats Go code. Use when needed.", wantPhrase: "use when needed"},
+		{name: "user asks", description: "Helper. Use this skill whenever the user asks for help.", wantPhrase: "whenever the user asks for help"},
+		{name: "one concrete clause is enough", description: "Use when needed. Use for migrating Postgres schemas."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents := "---\nname: fmt\ndescription: " + tt.description + "\n---\n"
+			got := validateSkillTriggerSpecificity(tt.description, "skills/fmt/SKILL.md", contents)
+			if (len(got) > 0) != (tt.wantPhrase != "") {
+				t.Fatalf("validateSkillTriggerSpecificity() = %v, want phrase %q", got, tt.wantPhrase)
+			}
+			if tt.wantPhrase == "" {
+				return
+			}
+			if got[0].Rule != cue.RuleSkillTriggerVague || got[0].Severity != cue.SeveritySuggestion || got[0].Line != 3 {
+				t.Errorf("rule/severity/line = %s/%s/%d, want %s/suggestion/3", got[0].Rule, got[0].Severity, got[0].Line, cue.RuleSkillTriggerVague)
+			}
+			if !strings.Contains(got[0].Message, "'"+tt.wantPhrase+"'") {
+				t.Errorf("message %q should quote %q", got[0].Message, tt.wantPhrase)
+			}
+		})
+	}
+}
+
+func TestValidateSkillBodySize(t *testing.T) {
+	frontmatter := "---\nname: big\ndescription: d\n---\n"
+	tests := []struct {
+		name     string
+		contents string
+		wantWarn bool
+	}{
+		{name: "at limit", contents: frontmatter + strings.Repeat("line\n", 10)},
+		{name: "over limit", contents: frontmatter + strings.Repeat("line\n", 11), wantWarn: true},
+		{name: "frontmatter does not count", contents: "---\n" + strings.Repeat("k: v\n", 20) + "---\nshort\n"},
+		{name: "empty body", contents: frontmatter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateSkillBodySize("skills/big/SKILL.md", tt.contents, 10)
+			if (len(got) > 0) != tt.wantWarn {
+				t.Fatalf("validateSkillBodySize() = %v, wantWarn %v", got, tt.wantWarn)
+			}
+			if !tt.wantWarn {
+				return
+			}
+			if got[0].Rule != cue.RuleSkillBodySize || got[0].Line != 5 {
+				t.Errorf("rule/line = %s/%d, want %s/5", got[0].Rule, got[0].Line, cue.RuleSkillBodySize)
+			}
+			if !strings.Contains(got[0].Message, "references/") {
+				t.Errorf("message %q should recommend reference files", got[0].Message)
+			}
+		})
+	}
+}
+
+func TestValidateSkillBodySize_DefaultLimit(t *testing.T) {
+	contents := "---\nname: big\ndescription: d\n---\n" + strings.Repeat("line\n", 200)
+	if got := validateSkillBodySize("skills/big/SKILL.md", contents, 0); len(got) != 0 {
+		t.Errorf("200 lines with the default limit: got %v, want none", got)
+	}
+	if got := validateSkillBodySize("skills/big/SKILL.md", contents, 100); len(got) != 1 {
+		t.Errorf("200 lines with a 100-line limit: got %v, want one warning", got)
+	}
+}
@@ -0,0 +1,141 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+// skillDirName returns the directory a skill file lives in, or "" when the
+// file sits directly in a skills root and has no directory of its own.
+func skillDirName(filePath string) string {
+	dir := filepath.Base(filepath.Dir(filePath))
+	if dir == "." || dir == "skills" || dir == ".claude" || dir == string(filepath.Separator) {
+		return ""
+	}
+	return dir
+}
+
+// validateSkillDirName checks that the skill directory is kebab-case. Claude
+// Code takes the skill's name from its directory when the name field is
+// absent, and agentskills.io requires the two to match.
+func validateSkillDirName(filePath string) []cue.ValidationError {
+	dir := skillDirName(filePath)
+	if dir == "" || (isKebabCase(dir) && !strings.HasPrefix(dir, "-") && !strings.HasSuffix(dir, "-") && !strings.Contains(dir, "--")) {
+		return nil
+	}
+	return []cue.ValidationError{{
+		File:     filePath,
+		Message:  fmt.Sprintf("Skill directory '%s' should be kebab-case (lowercase letters, digits, and single hyphens); the directory name is the skill name", dir),
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceAgentSkillsIO,
+		Rule:     cue.RuleSkillDirName,
+	}}
+}
+
+// applySkillLayoutIssues reports skill directories that collide on
+// case-insensitive filesystems and skill directories holding more than one
+// skill file.
+func applySkillLayoutIssues(ctx *LinterContext, summary *LintSummary) {
+	for _, issue := range skillLayoutIssues(ctx.FilterFilesByType(discovery.FileTypeSkill)) {
+		if issue.Severity == cue.SeverityError {
+			summary.TotalErrors++
+			if attachIssueToSummary(summary, issue, attachAsError, true) {
+				summary.FailedFiles++
+			}
+		} else {
+			summary.TotalWarnings++
+			attachIssueToSummary(summary, issue, attachAsWarning, true)
+		}
+	}
+}
+
+// skillLayoutIssues checks the skill files as a set:
+//   - directories that differ only in case are one directory on macOS and
+//     Windows, so one skill silently replaces the other;
+//   - a directory with both SKILL.md and skill.md, or a skill nested inside
+//     another skill's directory, is loaded as two skills while the outer one
+//     also ships the inner one as a resource.
+func skillLayoutIssues(files []discovery.File) []cue.ValidationError {
+	byDir := make(map[string][]string)
+	for _, f := range files {
+		dir := filepath.Dir(f.RelPath)
+		byDir[dir] = append(byDir[dir], f.RelPath)
+	}
+	dirs := slices.Sorted(maps.Keys(byDir))
+
+	byFold := make(map[string][]string)
+	for _, dir := range dirs {
+		key := strings.ToLower(dir)
+		byFold[key] = append(byFold[key], dir)
+	}
+
+	var issues []cue.ValidationError
+	for _, dir := range dirs {
+		paths := byDir[dir]
+		for _, other := range byFold[strings.ToLower(dir)] {
+			if other == dir {
+				continue
+			}
+			for _, p := range paths {
+				issues = append(issues, cue.ValidationError{
+					File:     p,
+					Message:  fmt.Sprintf("Skill directory '%s' differs from '%s' only in case; on macOS and Windows they are the same directory and one skill replaces the other", dir, other),
+					Severity: cue.SeverityError,
+					Source:   cue.SourceCClintObserve,
+					Rule:     cue.RuleSkillDirCollision,
+				})
+			}
+		}
+
+		if len(paths) > 1 {
+			for _, p := range paths {
+				issues = append(issues, cue.ValidationError{
+					File:     p,
+					Message:  fmt.Sprintf("Directory '%s' holds %d skill files (%s); keep exactly one SKILL.md per skill directory", dir, len(paths), strings.Join(baseNames(paths), ", ")),
+					Severity: cue.SeverityError,
+					Source:   cue.SourceAgentSkillsIO,
+					Rule:     cue.RuleSkillNested,
+				})
+			}
+		}
+
+		if outer := enclosingSkillDir(dir, byDir); outer != "" {
+			for _, p := range paths {
+				issues = append(issues, cue.ValidationError{
+					File:     p,
+					Message:  fmt.Sprintf("Skill is nested inside skill directory '%s', so it is loaded on its own and also bundled with the outer skill; move it to its own directory under skills/", outer),
+					Severity: cue.SeverityWarning,
+					Source:   cue.SourceCClintObserve,
+					Rule:     cue.RuleSkillNested,
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// enclosingSkillDir returns the nearest ancestor of dir that is itself a
+// skill directory, or "". Skills roots are not skill directories.
+func enclosingSkillDir(dir string, skillDirs map[string][]string) string {
+	for parent := filepath.Dir(dir); parent != dir; dir, parent = parent, filepath.Dir(parent) {
+		if _, ok := skillDirs[parent]; ok && skillDirName(filepath.Join(parent, "SKILL.md")) != "" {
+			return parent
+		}
+	}
+	return ""
+}
+
+// baseNames returns the file name of each path.
+func baseNames(paths []string) []string {
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = filepath.Base(p)
+	}
+	return names
+}
@@ -0,0 +1,107 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/discovery"
+)
+
+func TestValidateSkillDirName(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: ".claude/skills/pdf-tools/SKILL.md"},
+		{path: ".claude/skills/SKILL.md"},
+		{path: "SKILL.md"},
+		{path: ".claude/skills/PdfTools/SKILL.md", want: true},
+		{path: "skills/pdf_tools/SKILL.md", want: true},
+		{path: "skills/-pdf/SKILL.md", want: true},
+		{path: "skills/pdf--tools/SKILL.md", want: true},
+	}
+	for _, tt := range tests {
+		got := validateSkillDirName(tt.path)
+		if (len(got) > 0) != tt.want {
+			t.Errorf("validateSkillDirName(%q) = %v, want issue %v", tt.path, got, tt.want)
+			continue
+		}
+		if tt.want && got[0].Rule != cue.RuleSkillDirName {
+			t.Errorf("validateSkillDirName(%q) rule = %q", tt.path, got[0].Rule)
+		}
+	}
+}
+
+func TestSkillLayoutIssues(t *testing.T) {
+	skill := func(relPath string) discovery.File {
+		return discovery.File{RelPath: relPath, Type: discovery.FileTypeSkill}
+	}
+
+	tests := []struct {
+		name  string
+		files []discovery.File
+		want  []string // file: rule, in order
+	}{
+		{
+			name: "separate skills",
+			files: []discovery.File{
+				skill(".claude/skills/alpha/SKILL.md"),
+				skill(".claude/skills/group/beta/SKILL.md"),
+				skill("skills/alpha/SKILL.md"),
+			},
+		},
+		{
+			name: "case-insensitive collision",
+			files: []discovery.File{
+				skill(".claude/skills/PDF/SKILL.md"),
+				skill(".claude/skills/pdf/SKILL.md"),
+			},
+			want: []string{
+				".claude/skills/PDF/SKILL.md: " + cue.RuleSkillDirCollision,
+				".claude/skills/pdf/SKILL.md: " + cue.RuleSkillDirCollision,
+			},
+		},
+		{
+			name: "two skill files in one directory",
+			files: []discovery.File{
+				skill("skills/alpha/SKILL.md"),
+				skill("skills/alpha/skill.md"),
+			},
+			want: []string{
+				"skills/alpha/SKILL.md: " + cue.RuleSkillNested,
+				"skills/alpha/skill.md: " + cue.RuleSkillNested,
+			},
+		},
+		{
+			name: "skill nested in another skill",
+			files: []discovery.File{
+				skill(".claude/skills/outer/SKILL.md"),
+				skill(".claude/skills/outer/examples/inner/SKILL.md"),
+			},
+			want: []string{".claude/skills/outer/examples/inner/SKILL.md: " + cue.RuleSkillNested},
+		},
+		{
+			name: "skill file at the skills root is not a parent",
+			files: []discovery.File{
+				skill(".claude/skills/SKILL.md"),
+				skill(".claude/skills/alpha/SKILL.md"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := skillLayoutIssues(tt.files)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d issues, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				file, rule, _ := strings.Cut(want, ": ")
+				if got[i].File != file || got[i].Rule != rule {
+					t.Errorf("issue %d = %s %s, want %s %s", i, got[i].File, got[i].Rule, file, rule)
+				}
+			}
+		})
+	}
+}
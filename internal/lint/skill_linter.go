@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/discovery"
@@ -16,6 +17,9 @@ import (
 // cross-file validation, scoring, improvements, and batch post-processing.
 type SkillLinter struct {
 	BaseLinter
+	// RootPath is the project root directory, used to resolve hook
+	// scripts. Empty string disables that filesystem check.
+	RootPath string
 }
 
 // Compile-time interface compliance checks
@@ -30,8 +34,11 @@ var (
 )
 
 // NewSkillLinter creates a new SkillLinter.
-func NewSkillLinter() *SkillLinter {
-	return &SkillLinter{}
+// rootPath is the project root for resolving hook scripts. Pass empty
+// string to skip filesystem checks.
+// cfg supplies the rule settings; nil uses the defaults.
+func NewSkillLinter(rootPath string, cfg *config.Config) *SkillLinter {
+	return &SkillLinter{BaseLinter: BaseLinter{cfg: cfg}, RootPath: rootPath}
 }
 
 func (l *SkillLinter) Type() string {
@@ -53,6 +60,7 @@ func (l *SkillLinter) PreValidate(filePath, contents string) []cue.ValidationErr
 			Message:  "Skill file must be named SKILL.md",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleComponentFileName,
 		})
 	}
 
@@ -63,6 +71,7 @@ func (l *SkillLinter) PreValidate(filePath, contents string) []cue.ValidationErr
 			Message:  "Skill file is empty",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleComponentEmpty,
 			Abort:    true,
 		})
 	}
@@ -89,12 +98,13 @@ func (l *SkillLinter) ValidateSpecific(data map[string]any, filePath, contents s
 	var errors []cue.ValidationError
 
 	// Check for unknown frontmatter fields - helps catch fabricated/deprecated fields
-	errors = append(errors, checkUnknownSkillFields(data, filePath, contents)...)
+	errors = append(errors, checkUnknownSkillFields(l.Config(), data, filePath, contents)...)
 
 	// Name validation (reserved words, format, directory match)
 	if name, ok := data["name"].(string); ok {
 		errors = append(errors, validateSkillName(name, filePath, contents)...)
 	}
+	errors = append(errors, validateSkillDirName(filePath)...)
 
 	// Validate context field: only valid value is "fork"
 	errors = append(errors, validateSkillContextField(data, filePath, contents)...)
@@ -110,10 +120,23 @@ func (l *SkillLinter) ValidateSpecific(data map[string]any, filePath, contents s
 	// Validate argument-hint field
 	errors = append(errors, validateSkillArgumentHint(data, filePath, contents)...)
 
+	// Warn about deprecated or retired models
+	errors = append(errors, checkModelCatalog(modelCatalog(l.Config()), data, filePath, contents)...)
+
+	// Flag template text an init tool or copied example left behind
+	errors = append(errors, checkScaffoldLeftovers(data, filePath, contents)...)
+
+	// Suggest shorter, active-voice descriptions
+	errors = append(errors, checkDescriptionReadability(l.Config().Rules.Readability, data, filePath, contents)...)
+
 	// Validate hooks (scoped to component events: PreToolUse, PostToolUse, Stop)
 	if hooks, ok := data["hooks"]; ok {
-		errors = append(errors, ValidateComponentHooks(hooks, filePath)...)
+		errors = append(errors, ValidateComponentHooks(hooks, filePath, l.Config())...)
 	}
+	errors = append(errors, validateHookToolCoverage(l.Config().ExtraTools, data, "allowed-tools", l.RootPath, filePath, contents)...)
+
+	// Least privilege: allowed-tools entries the body never uses, and tools its steps need
+	errors = append(errors, validateSkillAllowedTools(l.Config().ExtraTools, data, filePath, contents)...)
 
 	// Frontmatter suggestion
 	errors = append(errors, checkSkillFrontmatter(filePath, contents)...)
@@ -123,7 +146,7 @@ func (l *SkillLinter) ValidateSpecific(data map[string]any, filePath, contents s
 
 // ValidateBestPractices implements BestPracticeValidator interface
 func (l *SkillLinter) ValidateBestPractices(filePath, contents string, data map[string]any) []cue.ValidationError {
-	return validateSkillBestPractices(filePath, contents, data)
+	return validateSkillBestPractices(l.Config(), filePath, contents, data)
 }
 
 // ValidateCrossFile implements CrossFileValidatable interface
@@ -146,8 +169,9 @@ func (l *SkillLinter) GetImprovements(contents string, data map[string]any) []te
 	return textutil.GetSkillImprovements(contents, data)
 }
 
-// PostProcessBatch implements BatchPostProcessor — thin orchestrator over four named helpers.
+// PostProcessBatch implements BatchPostProcessor — thin orchestrator over five named helpers.
 func (l *SkillLinter) PostProcessBatch(ctx *LinterContext, summary *LintSummary) {
+	applySkillLayoutIssues(ctx, summary)
 	applyOrphanedSkills(ctx, summary)
 	applyGhostTriggers(ctx, summary)
 	applyTriggerConflicts(ctx, summary)
@@ -2,13 +2,14 @@ package lint
 
 import "github.com/dotcommander/cclint/internal/cue"
 
-// attachKind selects which LintResult slice (Errors vs Suggestions) an issue
-// is appended to, plus the side effects of the error path (mark Success=false
+// attachKind selects which LintResult slice (Errors, Warnings, or
+// Suggestions) an issue is appended to, plus the side effects of the error path (mark Success=false
 // on attach, increment FailedFiles on create).
 type attachKind int
 
 const (
 	attachAsError attachKind = iota
+	attachAsWarning
 	attachAsSuggestion
 )
 
@@ -25,10 +26,13 @@ func attachIssueToSummary(summary *LintSummary, issue cue.ValidationError, kind
 		if result.File != issue.File {
 			continue
 		}
-		if kind == attachAsError {
+		switch kind {
+		case attachAsError:
 			summary.Results[i].Errors = append(summary.Results[i].Errors, issue)
 			summary.Results[i].Success = false
-		} else {
+		case attachAsWarning:
+			summary.Results[i].Warnings = append(summary.Results[i].Warnings, issue)
+		default:
 			summary.Results[i].Suggestions = append(summary.Results[i].Suggestions, issue)
 		}
 		return false
@@ -37,10 +41,14 @@ func attachIssueToSummary(summary *LintSummary, issue cue.ValidationError, kind
 		return false
 	}
 	entry := LintResult{File: issue.File, Type: "skill"}
-	if kind == attachAsError {
+	switch kind {
+	case attachAsError:
 		entry.Success = false
 		entry.Errors = []cue.ValidationError{issue}
-	} else {
+	case attachAsWarning:
+		entry.Success = true // warnings do not fail the build
+		entry.Warnings = []cue.ValidationError{issue}
+	default:
 		entry.Success = true // suggestions do not fail the build
 		entry.Suggestions = []cue.ValidationError{issue}
 	}
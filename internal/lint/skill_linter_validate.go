@@ -2,20 +2,22 @@ package lint
 
 import (
 	"fmt"
-	"path/filepath"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/textutil"
 )
 
 // checkUnknownSkillFields checks for unknown frontmatter fields in skill files.
-func checkUnknownSkillFields(data map[string]any, filePath, contents string) []cue.ValidationError {
-	return checkUnknownFields(data, filePath, contents, unknownFieldCheck{
-		known:    knownSkillFields,
-		label:    "frontmatter field",
-		suffix:   ". See https://agentskills.io/specification for valid fields",
-		findLine: textutil.FindFrontmatterFieldLine,
+func checkUnknownSkillFields(cfg *config.Config, data map[string]any, filePath, contents string) []cue.ValidationError {
+	return checkUnknownFields(cfg, data, filePath, contents, unknownFieldCheck{
+		known:     knownSkillFields,
+		label:     "frontmatter field",
+		suffix:    ". See https://agentskills.io/specification for valid fields",
+		rule:      cue.RuleFrontmatterUnknownKey,
+		findLine:  textutil.FindFrontmatterFieldLine,
+		component: cue.TypeSkill,
 	})
 }
 
@@ -29,6 +31,7 @@ func validateSkillContextField(data map[string]any, filePath, contents string) [
 				Message:  fmt.Sprintf("context field must be 'fork' (got '%v')", ctxVal),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleFieldInvalid,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "context"),
 			}}
 		}
@@ -48,6 +51,7 @@ func validateSkillBooleanFields(data map[string]any, filePath, contents string)
 				Message:  fmt.Sprintf("user-invocable field must be a boolean (got '%v')", uiVal),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleFieldInvalid,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "user-invocable"),
 			})
 		}
@@ -61,6 +65,7 @@ func validateSkillBooleanFields(data map[string]any, filePath, contents string)
 				Message:  fmt.Sprintf("disable-model-invocation field must be a boolean (got '%v')", dmiVal),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleFieldInvalid,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "disable-model-invocation"),
 			})
 		}
@@ -80,6 +85,7 @@ func validateSkillArgumentHint(data map[string]any, filePath, contents string) [
 				Message:  fmt.Sprintf("argument-hint field must be a string (got '%v')", ahVal),
 				Severity: cue.SeverityError,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleFieldInvalid,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "argument-hint"),
 			})
 		} else if strings.TrimSpace(ahStr) == "" {
@@ -88,6 +94,7 @@ func validateSkillArgumentHint(data map[string]any, filePath, contents string) [
 				Message:  "argument-hint field is empty - provide a hint for autocomplete (e.g., 'PR number or URL')",
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceAnthropicDocs,
+				Rule:     cue.RuleFieldInvalid,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "argument-hint"),
 			})
 		}
@@ -112,6 +119,7 @@ func checkSkillFrontmatter(filePath, contents string) []cue.ValidationError {
 		Message:  suggestion,
 		Severity: cue.SeveritySuggestion,
 		Source:   cue.SourceAnthropicDocs,
+		Rule:     cue.RuleSkillFrontmatterMissing,
 	}}
 }
 
@@ -125,6 +133,7 @@ func validateSkillName(name, filePath, contents string) []cue.ValidationError {
 			Message:  fmt.Sprintf("Name '%s' is a reserved word and cannot be used", name),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleNameReserved,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
 		})
 	}
@@ -136,6 +145,7 @@ func validateSkillName(name, filePath, contents string) []cue.ValidationError {
 			Message:  fmt.Sprintf("Skill name '%s' cannot start or end with a hyphen", name),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAgentSkillsIO,
+			Rule:     cue.RuleNameFormat,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
 		})
 	}
@@ -147,19 +157,19 @@ func validateSkillName(name, filePath, contents string) []cue.ValidationError {
 			Message:  fmt.Sprintf("Skill name '%s' contains consecutive hyphens (--) which are not allowed", name),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAgentSkillsIO,
+			Rule:     cue.RuleNameFormat,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
 		})
 	}
 
 	// Rule 050: Name must match parent directory name (agentskills.io spec)
-	parentDir := filepath.Base(filepath.Dir(filePath))
-	isSpecialDir := parentDir == "." || parentDir == "skills" || parentDir == ".claude"
-	if !isSpecialDir && name != parentDir {
+	if parentDir := skillDirName(filePath); parentDir != "" && name != parentDir {
 		errors = append(errors, cue.ValidationError{
 			File:     filePath,
 			Message:  fmt.Sprintf("Skill name '%s' must match parent directory name '%s' (agentskills.io spec: name field)", name, parentDir),
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAgentSkillsIO,
+			Rule:     cue.RuleSkillNameMismatch,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "name"),
 		})
 	}
@@ -176,6 +186,7 @@ func validateSkillAgentField(agentVal any, data map[string]any, filePath, conten
 			Message:  "agent field must be a non-empty string",
 			Severity: cue.SeverityError,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldInvalid,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "agent"),
 		}}
 	}
@@ -188,6 +199,7 @@ func validateSkillAgentField(agentVal any, data map[string]any, filePath, conten
 			Message:  "agent field is set but context is not 'fork' - consider adding 'context: fork' for sub-agent execution",
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleSkillAgentWithoutFork,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "agent"),
 		}}
 	}
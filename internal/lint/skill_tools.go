@@ -0,0 +1,49 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// validateSkillAllowedTools holds a skill's allowed-tools to least
+// privilege. allowed-tools pre-approves its tools while the skill is
+// active, so an entry the body never mentions is a grant nothing needs, and
+// a step that invokes a tool allowed-tools leaves out stops for a
+// permission prompt. Skills without allowed-tools, or with "*", are left
+// alone; see textutil.AnalyzeSkillTools for how mentions are found.
+// extraTools are the configured tools beyond the built-in ones.
+func validateSkillAllowedTools(extraTools []string, data map[string]any, filePath, contents string) []cue.ValidationError {
+	use, ok := textutil.AnalyzeSkillTools(data["allowed-tools"], extractBody(contents), extraTools)
+	if !ok {
+		return nil
+	}
+
+	var suggestions []cue.ValidationError
+	fieldLine := textutil.FindFrontmatterFieldLine(contents, "allowed-tools")
+	for _, entry := range use.Unused {
+		suggestions = append(suggestions, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("allowed-tools pre-approves '%s', but the skill never mentions it; remove it to keep the skill least-privilege", entry),
+			Severity: cue.SeveritySuggestion,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleSkillToolsUnused,
+			Line:     fieldLine,
+		})
+	}
+
+	// Body lines count from the closing --- of the frontmatter.
+	offset := textutil.GetFrontmatterEndLine(contents) - 1
+	for _, inv := range use.Missing {
+		suggestions = append(suggestions, cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("Skill step uses %s, which allowed-tools does not grant; add it to allowed-tools or the step stops for a permission prompt", inv.Tool),
+			Severity: cue.SeveritySuggestion,
+			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleSkillToolsMissing,
+			Line:     offset + inv.Line,
+		})
+	}
+	return suggestions
+}
@@ -0,0 +1,29 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestValidateSkillAllowedTools(t *testing.T) {
+	contents := "---\nname: release\ndescription: Cuts a release\nallowed-tools: Read Bash(git tag:*) WebFetch\n---\n" +
+		"## Steps\n\n1. Read CHANGELOG.md.\n2. Tag with Bash(git tag v1.2.3).\n3. Use the Edit tool to bump the version.\n"
+	data := map[string]any{"allowed-tools": "Read Bash(git tag:*) WebFetch"}
+
+	errs := validateSkillAllowedTools(nil, data, "skills/release/SKILL.md", contents)
+	if len(errs) != 2 {
+		t.Fatalf("validateSkillAllowedTools() = %v, want 2 issues", errs)
+	}
+	if e := errs[0]; e.Rule != cue.RuleSkillToolsUnused || e.Line != 4 || e.Severity != cue.SeveritySuggestion {
+		t.Errorf("errs[0] = %s line %d %s, want %s line 4 suggestion", e.Rule, e.Line, e.Severity, cue.RuleSkillToolsUnused)
+	}
+	if e := errs[1]; e.Rule != cue.RuleSkillToolsMissing || e.Line != 10 {
+		t.Errorf("errs[1] = %s line %d, want %s line 10", e.Rule, e.Line, cue.RuleSkillToolsMissing)
+	}
+
+	// Without allowed-tools the skill pre-approves nothing to compare.
+	if errs := validateSkillAllowedTools(nil, map[string]any{}, "skills/release/SKILL.md", contents); len(errs) != 0 {
+		t.Errorf("validateSkillAllowedTools() without allowed-tools = %v, want none", errs)
+	}
+}
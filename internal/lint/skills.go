@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
 	"github.com/dotcommander/cclint/internal/textutil"
 )
@@ -35,21 +36,25 @@ var knownSkillFields = map[string]bool{
 	"version":      true, // Optional: semver version string
 	"cross_verify": true, // Optional: signal cross-model verification for high-stakes output
 	"triggers":     true,
+	// cclint fields
+	"enabled":  true, // false excludes the skill from cross-file analysis
+	"disabled": true, // true excludes the skill from cross-file analysis
 }
 
 // LintSkills runs linting on skill files using the generic linter.
-func LintSkills(rootPath string, quiet bool, verbose bool, noCycleCheck bool, exclude []string) (*LintSummary, error) {
-	ctx, err := NewLinterContext(rootPath, quiet, verbose, noCycleCheck, exclude)
+func LintSkills(opts Options) (*LintSummary, error) {
+	ctx, err := NewLinterContext(opts)
 	if err != nil {
 		return nil, err
 	}
-	return lintBatch(ctx, NewSkillLinter()), nil
+	return lintBatch(ctx, NewSkillLinter(ctx.RootPath, ctx.Config)), nil
 }
 
-// validateSkillBestPractices checks opinionated best practices for skills
-func validateSkillBestPractices(filePath string, contents string, fmData map[string]any) []cue.ValidationError {
+// validateSkillBestPractices checks opinionated best practices for skills,
+// with the rule settings of cfg
+func validateSkillBestPractices(cfg *config.Config, filePath string, contents string, fmData map[string]any) []cue.ValidationError {
 	suggestions := validateBasicSkillFields(fmData, filePath, contents)
-	suggestions = append(suggestions, validateSkillContentSections(filePath, contents)...)
+	suggestions = append(suggestions, validateSkillContentSections(filePath, contents, cfg.Rules.SkillBodyMaxLines)...)
 	suggestions = append(suggestions, textutil.ValidateToolFieldName(fmData, filePath, contents, "skill")...)
 	suggestions = append(suggestions, validateAgentSkillsOSpecFields(fmData, filePath, contents)...)
 	suggestions = append(suggestions, ValidateSkillDirectory(filePath, contents)...)
@@ -73,6 +78,7 @@ func validateBasicSkillFields(fmData map[string]any, filePath, contents string)
 			Message:  fmt.Sprintf("argument-hint is %d chars - keep under 80 for readability in autocomplete", len(ah)),
 			Severity: cue.SeverityWarning,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     cue.RuleFieldTooLong,
 			Line:     textutil.FindFrontmatterFieldLine(contents, "argument-hint"),
 		})
 	}
@@ -88,13 +94,12 @@ func validateBasicSkillFields(fmData map[string]any, filePath, contents string)
 }
 
 // validateSkillContentSections validates content sections of skills.
-func validateSkillContentSections(filePath, contents string) []cue.ValidationError {
+// bodyMaxLines is the rules.skillBodyMaxLines limit.
+func validateSkillContentSections(filePath, contents string, bodyMaxLines int) []cue.ValidationError {
 	var suggestions []cue.ValidationError
 
-	// Check skill size - recommend references for large skills (±10% tolerance: 500 base) - OUR OBSERVATION
-	if sizeErr := CheckSizeLimit(contents, 500, 0.10, "skill", filePath); sizeErr != nil {
-		suggestions = append(suggestions, *sizeErr)
-	}
+	// Check body size - recommend splitting into reference files (rules.skillBodyMaxLines)
+	suggestions = append(suggestions, validateSkillBodySize(filePath, contents, bodyMaxLines)...)
 
 	// Check for Anti-Patterns section
 	suggestions = append(suggestions, checkSkillAntiPatternsSection(filePath, contents)...)
@@ -105,6 +110,10 @@ func validateSkillContentSections(filePath, contents string) []cue.ValidationErr
 	return suggestions
 }
 
+// allowedToolTokenPattern matches one space-delimited allowed-tools entry,
+// e.g. Read or Bash(git:*).
+var allowedToolTokenPattern = regexp.MustCompile(`^[A-Z][a-zA-Z]+(\([^)]+\))?$`)
+
 // validateAgentSkillsOSpecFields validates fields per agentskills.io spec.
 func validateAgentSkillsOSpecFields(fmData map[string]any, filePath, contents string) []cue.ValidationError {
 	var suggestions []cue.ValidationError
@@ -112,15 +121,15 @@ func validateAgentSkillsOSpecFields(fmData map[string]any, filePath, contents st
 
 	// Rule 052: Validate allowed-tools format
 	if allowedTools, ok := fmData["allowed-tools"].(string); ok && allowedTools != "*" {
-		toolPattern := regexp.MustCompile(`^[A-Z][a-zA-Z]+(\([^)]+\))?$`)
 		tokens := strings.Fields(allowedTools)
 		for _, token := range tokens {
-			if !toolPattern.MatchString(token) {
+			if !allowedToolTokenPattern.MatchString(token) {
 				warnings = append(warnings, cue.ValidationError{
 					File:     filePath,
 					Message:  "allowed-tools format should be space-delimited tool names (e.g., 'Bash(git:*) Read Write')",
 					Severity: cue.SeverityWarning,
 					Source:   cue.SourceAgentSkillsIO,
+					Rule:     cue.RuleSkillAllowedToolsFormat,
 					Line:     textutil.FindFrontmatterFieldLine(contents, "allowed-tools"),
 				})
 				break
@@ -155,6 +164,7 @@ func checkSkillAntiPatternsSection(filePath, contents string) []cue.ValidationEr
 			Message:  "Consider adding '## Anti-Patterns' section to document common mistakes.",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleSkillSectionMissing,
 		}}
 	}
 	return nil
@@ -179,6 +189,7 @@ func checkSkillExamplesSection(filePath, contents string) []cue.ValidationError
 			Message:  "Consider adding '## Examples' section to illustrate skill usage.",
 			Severity: cue.SeveritySuggestion,
 			Source:   cue.SourceCClintObserve,
+			Rule:     cue.RuleSkillSectionMissing,
 		}}
 	}
 	return nil
@@ -193,6 +204,7 @@ func validateSkillLicenseField(fmData map[string]any, filePath, contents string)
 				Message:  "license field is empty - provide SPDX identifier (e.g., 'MIT', 'Apache-2.0') or license file reference",
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceAgentSkillsIO,
+				Rule:     cue.RuleFieldInvalid,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "license"),
 			}}
 		}
@@ -210,6 +222,7 @@ func validateSkillCompatibilityField(fmData map[string]any, filePath, contents s
 				Message:  fmt.Sprintf("compatibility field is %d chars (max 500 per agentskills.io spec)", len(compat)),
 				Severity: cue.SeverityWarning,
 				Source:   cue.SourceAgentSkillsIO,
+				Rule:     cue.RuleFieldTooLong,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "compatibility"),
 			})
 		}
@@ -227,6 +240,7 @@ func validateSkillMetadataField(fmData map[string]any, filePath, contents string
 				Message:  "metadata field should be key-value mapping (e.g., metadata:\\n  author: example-org\\n  version: \"1.0\")",
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceAgentSkillsIO,
+				Rule:     cue.RuleFieldInvalid,
 				Line:     textutil.FindFrontmatterFieldLine(contents, "metadata"),
 			}}
 		}
@@ -242,6 +256,7 @@ func validateSkillMetadataField(fmData map[string]any, filePath, contents string
 					Message:  fmt.Sprintf("metadata.%s should be primitive value (string, number, or boolean)", key),
 					Severity: cue.SeveritySuggestion,
 					Source:   cue.SourceAgentSkillsIO,
+					Rule:     cue.RuleFieldInvalid,
 					Line:     textutil.FindFrontmatterFieldLine(contents, "metadata"),
 				}}
 			}
@@ -262,12 +277,13 @@ func validateSkillDescription(description, filePath, contents string) []cue.Vali
 
 	// Every description finding points at the same frontmatter line and source.
 	descLine := textutil.FindFrontmatterFieldLine(contents, "description")
-	add := func(severity, msg string) {
+	add := func(rule, severity, msg string) {
 		out = append(out, cue.ValidationError{
 			File:     filePath,
 			Message:  msg,
 			Severity: severity,
 			Source:   cue.SourceAnthropicDocs,
+			Rule:     rule,
 			Line:     descLine,
 		})
 	}
@@ -276,42 +292,36 @@ func validateSkillDescription(description, filePath, contents string) []cue.Vali
 	firstPersonStarts := []string{"I ", "I'm ", "I'll ", "I've ", "My ", "We ", "We're "}
 	for _, fp := range firstPersonStarts {
 		if strings.HasPrefix(description, fp) {
-			add(cue.SeveritySuggestion, "Skill description should use third person (e.g., 'Analyzes...' not 'I analyze...')")
+			add(cue.RuleDescriptionReadability, cue.SeveritySuggestion, "Skill description should use third person (e.g., 'Analyzes...' not 'I analyze...')")
 			break
 		}
 	}
 
 	if strings.HasPrefix(description, "You ") {
-		add(cue.SeveritySuggestion, "Skill description should describe what it does, not address the user")
+		add(cue.RuleDescriptionReadability, cue.SeveritySuggestion, "Skill description should describe what it does, not address the user")
 	}
 
 	if len(description) < 50 {
-		add(cue.SeveritySuggestion, fmt.Sprintf("Description is only %d chars. Aim for 50+ to help with skill discovery.", len(description)))
+		add(cue.RuleDescriptionTooShort, cue.SeveritySuggestion, fmt.Sprintf("Description is only %d chars. Aim for 50+ to help with skill discovery.", len(description)))
 	}
 
 	if len(description) > 1536 {
-		add(cue.SeverityWarning, fmt.Sprintf("Description is %d chars, exceeding the 1536-character limit. Skill descriptions over 1536 chars are truncated by Claude Code (v2.1.105).", len(description)))
+		add(cue.RuleFieldTooLong, cue.SeverityWarning, fmt.Sprintf("Description is %d chars, exceeding the 1536-character limit. Skill descriptions over 1536 chars are truncated by Claude Code (v2.1.105).", len(description)))
 	}
 
-	lower := strings.ToLower(description)
-	hasTrigger := strings.Contains(lower, "use when") ||
-		strings.Contains(lower, "use for") ||
-		strings.Contains(lower, "use proactively") ||
-		strings.Contains(lower, "not for") ||
-		strings.Contains(lower, "covers") ||
-		strings.Contains(lower, "handles")
-	if !hasTrigger && len(description) > 0 {
-		add(cue.SeveritySuggestion, "Consider adding trigger phrases like 'Use when...' or 'Use for...' to help skill discovery")
-	}
+	out = append(out, validateSkillDescriptionTrigger(description, filePath, contents)...)
+	out = append(out, validateSkillTriggerSpecificity(description, filePath, contents)...)
 
 	return out
 }
 
+// leadingHeadingPattern matches a "# Heading" at the start of content.
+var leadingHeadingPattern = regexp.MustCompile(`^#\s+([^\n]+)`)
+
 // extractSkillName extracts the skill name from the first heading
 func extractSkillName(content, filePath string) string {
 	// Try to match "# Heading" pattern
-	re := regexp.MustCompile(`^#\s+([^\n]+)`)
-	matches := re.FindStringSubmatch(content)
+	matches := leadingHeadingPattern.FindStringSubmatch(content)
 	if len(matches) > 1 {
 		name := strings.TrimSpace(matches[1])
 		// Clean up common patterns
@@ -103,7 +103,7 @@ func TestValidateSkillBestPractices(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			suggestions := validateSkillBestPractices(tt.filePath, tt.contents, tt.fmData)
+			suggestions := validateSkillBestPractices(defaultConfig(), tt.filePath, tt.contents, tt.fmData)
 
 			for _, want := range tt.wantContains {
 				found := false
@@ -198,7 +198,7 @@ func TestKnownSkillFields(t *testing.T) {
 }
 
 func TestSkillLinterPreValidate(t *testing.T) {
-	linter := NewSkillLinter()
+	linter := NewSkillLinter("", nil)
 
 	tests := []struct {
 		name         string
@@ -260,7 +260,7 @@ func TestSkillLinterPreValidate(t *testing.T) {
 }
 
 func TestSkillLinterValidateSpecific(t *testing.T) {
-	linter := NewSkillLinter()
+	linter := NewSkillLinter("", nil)
 
 	tests := []struct {
 		name            string
@@ -554,14 +554,14 @@ func TestValidateSkillNameDirectoryMatch(t *testing.T) {
 }
 
 func TestSkillLinterType(t *testing.T) {
-	linter := NewSkillLinter()
+	linter := NewSkillLinter("", nil)
 	if linter.Type() != "skill" {
 		t.Errorf("SkillLinter.Type() = %q, want %q", linter.Type(), "skill")
 	}
 }
 
 func TestSkillLinterParseContent(t *testing.T) {
-	linter := NewSkillLinter()
+	linter := NewSkillLinter("", nil)
 
 	tests := []struct {
 		name        string
@@ -0,0 +1,127 @@
+package lint
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// staleRefTypes are the component types whose prose is checked for
+// references to project files.
+var staleRefTypes = map[string]bool{
+	cue.TypeAgent: true,
+	cue.TypeSkill: true,
+	"context":     true,
+}
+
+// proseFilePathPattern matches a relative file path in prose, such as
+// scripts/build.sh or ./docs/setup.md: two or more segments, the last with
+// an extension. The path must start a word, so the tail of an absolute,
+// home, or ${VAR}/ path is not taken for one.
+var proseFilePathPattern = regexp.MustCompile("(?:^|[\\s(\\[`\"'])((?:\\.{1,2}/)*[\\w.-]+(?:/[\\w.-]+)*/[\\w-][\\w.-]*\\.[A-Za-z0-9]+)")
+
+// CheckStaleReferences reports file paths mentioned in the prose of an
+// agent, skill, or CLAUDE.md that no longer exist in the project, once per
+// path. A path resolves against the project root or the file's own
+// directory. It is reported only when the directory it points into still
+// exists, so example paths from other projects are not flagged; paths
+// matching cfg.Allow are skipped, and nothing is reported unless
+// cfg.Enabled.
+func CheckStaleReferences(cfg config.StaleReferencesConfig, cv *crossfile.CrossFileValidator, componentType, contents, filePath string) []cue.ValidationError {
+	if cv == nil || !cfg.Enabled || !staleRefTypes[componentType] {
+		return nil
+	}
+	dir := path.Dir(filepath.ToSlash(filePath))
+
+	var out []cue.ValidationError
+	seen := make(map[string]bool)
+	withBodyLines(contents, func(lineNum int, trimmed string) {
+		text := proseURLPattern.ReplaceAllString(trimmed, "")
+		for _, m := range proseFilePathPattern.FindAllStringSubmatchIndex(text, -1) {
+			if m[3] < len(text) && text[m[3]] == '/' {
+				continue // a directory such as docs/v1.2/
+			}
+			ref := strings.TrimRight(text[m[2]:m[3]], ".")
+			if seen[ref] || staleRefAllowed(cfg.Allow, ref) {
+				continue
+			}
+			if strings.HasSuffix(text[:m[2]], "](") && crossfile.IsComponentLink(ref) {
+				continue // reported as component-link-missing instead
+			}
+			seen[ref] = true
+			if !isStaleRef(cv, dir, ref) {
+				continue
+			}
+			out = append(out, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("References '%s', which does not exist in the project; update or remove the reference, or add it to rules.staleReferences.allow", ref),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleStaleFileReference,
+				Line:     lineNum,
+			})
+		}
+	})
+	return out
+}
+
+// isStaleRef reports whether ref resolves to nothing from the project root
+// or from dir, while the first directory it names exists from one of them.
+func isStaleRef(cv *crossfile.CrossFileValidator, dir, ref string) bool {
+	var bases []string
+	if !strings.HasPrefix(ref, "../") {
+		bases = append(bases, ".")
+	}
+	if dir != "." {
+		bases = append(bases, dir)
+	}
+
+	stale := false
+	for _, base := range bases {
+		target := path.Join(base, ref)
+		if cv.RepoPathExists(target) {
+			return false
+		}
+		if first := firstNamedDir(base, ref); first != "" && cv.RepoPathExists(first) {
+			stale = true
+		}
+	}
+	return stale
+}
+
+// firstNamedDir returns the directory ref first names beyond any leading
+// ./ and ../, joined to base: "scripts" for scripts/ci/build.sh. It returns
+// "" when ref climbs out of the project.
+func firstNamedDir(base, ref string) string {
+	segments := strings.Split(path.Clean(ref), "/")
+	for i, seg := range segments[:len(segments)-1] {
+		if seg != ".." {
+			first := path.Join(append([]string{base}, segments[:i+1]...)...)
+			if first == ".." || strings.HasPrefix(first, "../") {
+				return ""
+			}
+			return first
+		}
+	}
+	return ""
+}
+
+// staleRefAllowed reports whether ref matches a rules.staleReferences.allow
+// pattern, as written or cleaned.
+func staleRefAllowed(patterns []string, ref string) bool {
+	for _, pattern := range patterns {
+		for _, p := range []string{ref, path.Clean(ref)} {
+			if ok, err := doublestar.Match(pattern, p); err == nil && ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestCheckStaleReferences(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"scripts/build.sh", "docs/setup.md", ".claude/skills/deploy/references/guide.md", ".claude/agents/builder.md"} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cv := crossfile.NewCrossFileValidator(nil, root)
+
+	tests := []struct {
+		name          string
+		componentType string
+		filePath      string
+		body          string
+		allow         []string
+		disabled      bool
+		want          []string
+	}{
+		{
+			name:          "existing paths",
+			componentType: cue.TypeAgent,
+			filePath:      ".claude/agents/builder.md",
+			body:          "Run scripts/build.sh, then read `./docs/setup.md`.",
+		},
+		{
+			name:          "removed file in an existing directory",
+			componentType: cue.TypeAgent,
+			filePath:      ".claude/agents/builder.md",
+			body:          "See scripts/deploy.sh. Then see scripts/deploy.sh again.",
+			want:          []string{"scripts/deploy.sh"},
+		},
+		{
+			name:          "link relative to the skill directory",
+			componentType: cue.TypeSkill,
+			filePath:      ".claude/skills/deploy/SKILL.md",
+			body:          "See [guide](references/guide.md) and [old](references/old.md).",
+			want:          []string{"references/old.md"},
+		},
+		{
+			name:          "links to components are left to component-link-missing",
+			componentType: cue.TypeAgent,
+			filePath:      ".claude/agents/builder.md",
+			body:          "Ask [the tester](../agents/tester.md), not ../agents/gone.md.",
+			want:          []string{"../agents/gone.md"},
+		},
+		{
+			name:          "CLAUDE.md",
+			componentType: "context",
+			filePath:      "CLAUDE.md",
+			body:          "Setup lives in docs/install.md.",
+			want:          []string{"docs/install.md"},
+		},
+		{
+			name:          "example paths outside the project tree",
+			componentType: cue.TypeAgent,
+			filePath:      ".claude/agents/builder.md",
+			body:          "Components live in src/components/Button.tsx; see github.com/org/repo.git.",
+		},
+		{
+			name:          "absolute, home, variable, and URL paths",
+			componentType: cue.TypeAgent,
+			filePath:      ".claude/agents/builder.md",
+			body:          "Not /scripts/a.sh, ~/scripts/b.sh, ${ROOT}/scripts/c.sh, or https://example.com/scripts/d.sh.",
+		},
+		{
+			name:          "fenced code is skipped",
+			componentType: cue.TypeAgent,
+			filePath:      ".claude/agents/builder.md",
+			body:          "```\nscripts/gone.sh\n```",
+		},
+		{
+			name:          "allow pattern",
+			componentType: cue.TypeAgent,
+			filePath:      ".claude/agents/builder.md",
+			body:          "Never recreate docs/RULES.md or docs/old/notes.md.",
+			allow:         []string{"docs/RULES.md", "docs/old/**"},
+		},
+		{
+			name:          "disabled",
+			componentType: cue.TypeAgent,
+			filePath:      ".claude/agents/builder.md",
+			body:          "See scripts/deploy.sh.",
+			disabled:      true,
+		},
+		{
+			name:          "commands are not checked",
+			componentType: cue.TypeCommand,
+			filePath:      ".claude/commands/build.md",
+			body:          "See scripts/deploy.sh.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.StaleReferencesConfig{Enabled: !tt.disabled, Allow: tt.allow}
+			contents := "---\nname: x\nsee: scripts/frontmatter.sh\n---\n" + tt.body + "\n"
+			issues := CheckStaleReferences(cfg, cv, tt.componentType, contents, tt.filePath)
+			var got []string
+			for _, issue := range issues {
+				if issue.Rule != cue.RuleStaleFileReference || issue.Severity != cue.SeverityWarning {
+					t.Errorf("unexpected issue %+v", issue)
+				}
+				got = append(got, issue.Message)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d issues %v, want %d", len(got), got, len(tt.want))
+			}
+			for i, ref := range tt.want {
+				if want := "References '" + ref + "'"; !strings.HasPrefix(got[i], want) {
+					t.Errorf("issue %d = %q, want prefix %q", i, got[i], want)
+				}
+			}
+		})
+	}
+
+	if issues := CheckStaleReferences(config.StaleReferencesConfig{Enabled: true}, nil, cue.TypeAgent, "See scripts/deploy.sh.\n", "agents/a.md"); issues != nil {
+		t.Errorf("without a cross-file validator: got %v, want nil", issues)
+	}
+}
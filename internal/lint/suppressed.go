@@ -0,0 +1,74 @@
+package lint
+
+import (
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// SuppressionBaseline is the suppression source for issues the baseline
+// file lists as known.
+const SuppressionBaseline = "baseline"
+
+// unclassifiedRule is the rule key for suppressed issues that carry no rule ID.
+const unclassifiedRule = "unclassified"
+
+// SuppressedIssue records an issue hidden from the report, so the report can
+// say what was ignored and why.
+type SuppressedIssue struct {
+	File     string
+	Rule     string
+	Severity string
+	// Source is what suppressed the issue, e.g. SuppressionBaseline.
+	Source string
+}
+
+// SuppressionReport counts suppressed issues per rule, file, and
+// suppression source.
+type SuppressionReport struct {
+	Total    int
+	ByRule   map[string]int
+	ByFile   map[string]int
+	BySource map[string]int
+}
+
+// SummarizeSuppressed aggregates suppressed issues into counts. Issues with
+// no rule ID are counted under "unclassified".
+func SummarizeSuppressed(issues []SuppressedIssue) SuppressionReport {
+	report := SuppressionReport{
+		Total:    len(issues),
+		ByRule:   make(map[string]int),
+		ByFile:   make(map[string]int),
+		BySource: make(map[string]int),
+	}
+	for _, issue := range issues {
+		rule := issue.Rule
+		if rule == "" {
+			rule = unclassifiedRule
+		}
+		report.ByRule[rule]++
+		report.ByFile[issue.File]++
+		report.BySource[issue.Source]++
+	}
+	return report
+}
+
+// recordSuppressed appends issues hidden by source to the summary.
+func recordSuppressed(summary *LintSummary, issues []cue.ValidationError, source string) {
+	for _, issue := range issues {
+		summary.Suppressed = append(summary.Suppressed, SuppressedIssue{
+			File:     issue.File,
+			Rule:     issue.Rule,
+			Severity: issue.Severity,
+			Source:   source,
+		})
+	}
+}
+
+// SuppressedIssues returns the issues suppressed across every summary of
+// the run.
+func (r *Result) SuppressedIssues() []SuppressedIssue {
+	var issues []SuppressedIssue
+	for _, s := range r.Summaries {
+		issues = append(issues, s.Suppressed...)
+	}
+	return issues
+}
@@ -0,0 +1,40 @@
+package lint
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestSummarizeSuppressed(t *testing.T) {
+	issues := []SuppressedIssue{
+		{File: "agents/a.md", Rule: "agent-name-collision", Source: SuppressionBaseline},
+		{File: "agents/a.md", Rule: "agent-name-collision", Source: SuppressionBaseline},
+		{File: "agents/b.md", Rule: "", Source: SuppressionBaseline},
+	}
+
+	report := SummarizeSuppressed(issues)
+
+	if report.Total != 3 {
+		t.Errorf("Total = %d, want 3", report.Total)
+	}
+	if want := map[string]int{"agent-name-collision": 2, "unclassified": 1}; !maps.Equal(report.ByRule, want) {
+		t.Errorf("ByRule = %v, want %v", report.ByRule, want)
+	}
+	if want := map[string]int{"agents/a.md": 2, "agents/b.md": 1}; !maps.Equal(report.ByFile, want) {
+		t.Errorf("ByFile = %v, want %v", report.ByFile, want)
+	}
+	if want := map[string]int{SuppressionBaseline: 3}; !maps.Equal(report.BySource, want) {
+		t.Errorf("BySource = %v, want %v", report.BySource, want)
+	}
+}
+
+func TestResultSuppressedIssues(t *testing.T) {
+	result := &Result{Summaries: []*LintSummary{
+		{Suppressed: []SuppressedIssue{{File: "a.md"}}},
+		{},
+		{Suppressed: []SuppressedIssue{{File: "b.md"}, {File: "c.md"}}},
+	}}
+	if got := len(result.SuppressedIssues()); got != 3 {
+		t.Errorf("SuppressedIssues() returned %d issues, want 3", got)
+	}
+}
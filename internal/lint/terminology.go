@@ -0,0 +1,151 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// defaultTerms is the bundled wordlist for the terminology check: Claude
+// Code terminology, misspelled tool names, and common prose typos, each
+// mapped to the preferred spelling. Keys are matched case-insensitively.
+var defaultTerms = map[string]string{
+	// Terminology
+	"sub-agent":    "subagent",
+	"sub agent":    "subagent",
+	"front-matter": "frontmatter",
+	"front matter": "frontmatter",
+
+	// Tool names
+	"beash":     "Bash",
+	"bsah":      "Bash",
+	"grpe":      "Grep",
+	"gerp":      "Grep",
+	"raed":      "Read",
+	"wirte":     "Write",
+	"wrtie":     "Write",
+	"eidt":      "Edit",
+	"glbo":      "Glob",
+	"webfecth":  "WebFetch",
+	"websaerch": "WebSearch",
+	"todowirte": "TodoWrite",
+
+	// Common typos
+	"teh":        "the",
+	"recieve":    "receive",
+	"seperate":   "separate",
+	"occured":    "occurred",
+	"definately": "definitely",
+	"enviroment": "environment",
+	"dependancy": "dependency",
+	"accross":    "across",
+	"arguement":  "argument",
+	"paramter":   "parameter",
+	"retreive":   "retrieve",
+	"succesful":  "successful",
+	"lenght":     "length",
+	"untill":     "until",
+	"refered":    "referred",
+}
+
+// termSet is a compiled wordlist.
+type termSet struct {
+	terms     []string
+	preferred []string
+	matcher   *textutil.Matcher
+}
+
+// newTermSet compiles the wordlist of the rules.terminology config key:
+// project terms are merged over the bundled wordlist and allowed terms
+// removed from it.
+func newTermSet(cfg config.TerminologyConfig) *termSet {
+
+	merged := make(map[string]string, len(defaultTerms)+len(cfg.Terms))
+	for term, preferred := range defaultTerms {
+		merged[term] = preferred
+	}
+	for term, preferred := range cfg.Terms {
+		merged[strings.ToLower(strings.TrimSpace(term))] = strings.TrimSpace(preferred)
+	}
+	for _, term := range cfg.Allow {
+		delete(merged, strings.ToLower(strings.TrimSpace(term)))
+	}
+
+	set := &termSet{}
+	for term := range merged {
+		set.terms = append(set.terms, term)
+	}
+	sort.Strings(set.terms)
+	for _, term := range set.terms {
+		set.preferred = append(set.preferred, merged[term])
+	}
+	set.matcher = textutil.NewMatcher(set.terms)
+	return set
+}
+
+// proseURLPattern matches URLs, which are skipped by the terminology check.
+var proseURLPattern = regexp.MustCompile(`https?://\S+`)
+
+// CheckTerminology reports non-preferred terms and typos in the prose of a
+// markdown component, once per term. Frontmatter, fenced and inline code,
+// and URLs are skipped. Returns nil unless cfg, the rules.terminology
+// config key, enables the check.
+func CheckTerminology(cfg config.TerminologyConfig, contents, filePath string) []cue.ValidationError {
+	if !cfg.Enabled || !strings.EqualFold(filepath.Ext(filePath), ".md") {
+		return nil
+	}
+	set := newTermSet(cfg)
+
+	var out []cue.ValidationError
+	seen := make(map[int]bool)
+	withBodyLines(contents, func(lineNum int, trimmed string) {
+		if strings.HasPrefix(trimmed, "```") {
+			return
+		}
+		text := inlineCodeSpan.ReplaceAllString(trimmed, "")
+		text = proseURLPattern.ReplaceAllString(text, "")
+
+		for _, m := range set.matcher.FindAll(text) {
+			if seen[m.Pattern] || !wholeWord(text, m.Start, m.End) {
+				continue
+			}
+			found := text[m.Start:m.End]
+			if found == set.preferred[m.Pattern] {
+				continue // a case-only term already written the preferred way
+			}
+			seen[m.Pattern] = true
+			out = append(out, cue.ValidationError{
+				File:     filePath,
+				Message:  fmt.Sprintf("%q: use %q instead", found, set.preferred[m.Pattern]),
+				Severity: cue.SeverityWarning,
+				Source:   cue.SourceCClintObserve,
+				Rule:     cue.RuleTerminology,
+				Line:     lineNum,
+			})
+		}
+	})
+	return out
+}
+
+// wholeWord reports whether text[start:end] is a whole word, allowing a
+// plural "s" after it.
+func wholeWord(text string, start, end int) bool {
+	if start > 0 && isWordByte(text[start-1]) {
+		return false
+	}
+	if end < len(text) && (text[end] == 's' || text[end] == 'S') {
+		end++
+	}
+	return end >= len(text) || !isWordByte(text[end])
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 0x80 ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestCheckTerminology(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.TerminologyConfig
+		filePath string
+		contents string
+		want     []string // expected message substrings, in order
+		wantLine int      // line of the first finding, if any
+	}{
+		{
+			name:     "disabled by default",
+			filePath: "agents/a.md",
+			contents: "---\nname: a\n---\nDelegate to a sub-agent.\n",
+		},
+		{
+			name:     "bundled terms and tool typos",
+			cfg:      config.TerminologyConfig{Enabled: true},
+			filePath: "agents/a.md",
+			contents: "---\nname: a\n---\n\nDelegate to sub-agents.\nRun Beash, then a sub-agent again.\n",
+			want:     []string{`"sub-agent": use "subagent"`, `"Beash": use "Bash"`},
+			wantLine: 5,
+		},
+		{
+			name:     "whole words only",
+			cfg:      config.TerminologyConfig{Enabled: true},
+			filePath: "commands/c.md",
+			contents: "---\ndescription: c\n---\nTehran and stehen are fine; so is blabeash.\n",
+		},
+		{
+			name:     "code, urls, and frontmatter skipped",
+			cfg:      config.TerminologyConfig{Enabled: true},
+			filePath: "commands/c.md",
+			contents: "---\ndescription: recieve\n---\nUse `teh` here.\nSee https://example.com/sub-agent\n```\nteh\n```\n",
+		},
+		{
+			name:     "project terms and allowlist",
+			cfg:      config.TerminologyConfig{Enabled: true, Terms: map[string]string{"Github": "GitHub"}, Allow: []string{"teh"}},
+			filePath: "skills/s/SKILL.md",
+			contents: "---\nname: s\n---\nteh Github API, and GitHub again.\n",
+			want:     []string{`"Github": use "GitHub"`},
+			wantLine: 4,
+		},
+		{
+			name:     "non-markdown files skipped",
+			cfg:      config.TerminologyConfig{Enabled: true},
+			filePath: ".claude/settings.json",
+			contents: `{"note": "teh"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckTerminology(tt.cfg, tt.contents, tt.filePath)
+			if len(got) != len(tt.want) {
+				t.Fatalf("CheckTerminology() = %d issues %v, want %d", len(got), got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i].Message, want) {
+					t.Errorf("issue %d message = %q, want it to contain %q", i, got[i].Message, want)
+				}
+				if got[i].Rule != cue.RuleTerminology || got[i].Severity != cue.SeverityWarning {
+					t.Errorf("issue %d rule/severity = %s/%s", i, got[i].Rule, got[i].Severity)
+				}
+			}
+			if len(got) > 0 && got[0].Line != tt.wantLine {
+				t.Errorf("first issue line = %d, want %d", got[0].Line, tt.wantLine)
+			}
+		})
+	}
+}
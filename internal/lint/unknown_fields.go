@@ -3,7 +3,10 @@ package lint
 import (
 	"fmt"
 
+	"github.com/dotcommander/cclint/internal/config"
 	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/migrate"
+	"github.com/dotcommander/cclint/internal/textutil"
 )
 
 // unknownFieldCheck models the per-component divergences of the unknown-field
@@ -14,24 +17,54 @@ type unknownFieldCheck struct {
 	known    map[string]bool // valid field set for this component
 	label    string          // e.g. "frontmatter field" or "plugin field"
 	suffix   string          // pre-rendered suffix appended after the field name (may be empty)
+	rule     string          // Rule identifier stamped on each issue (may be empty)
 	findLine func(contents, key string) int
+	// component selects the migration table entries for renamed keys
+	// (may be empty for components without frontmatter history).
+	component string
 }
 
 // checkUnknownFields emits a "suggestion" for every key in data not present in
 // c.known. Message shape: "Unknown <label> '<key>'<suffix>". Every existing
-// caller's exact message is preserved by constructing c.suffix at the call site.
-func checkUnknownFields(data map[string]any, filePath, contents string, c unknownFieldCheck) []cue.ValidationError {
+// caller's exact message is preserved by constructing c.suffix at the call site;
+// a " (did you mean '<field>'?)" hint is appended when the key is a likely typo.
+// Keys the migration table renamed are accepted while the schema version
+// cfg selects still uses them, and reported as renamed once it does not. Keys
+// the project deprecated in cfg are left to CheckDeprecatedFields.
+func checkUnknownFields(cfg *config.Config, data map[string]any, filePath, contents string, c unknownFieldCheck) []cue.ValidationError {
 	var errors []cue.ValidationError
 	for key := range data {
+		if _, ok := cfg.DeprecatedFields.Lookup(c.component, key); ok {
+			continue // reported by CheckDeprecatedFields
+		}
+		if r, ok := migrate.Lookup(c.component, key); ok && !c.known[key] {
+			if !legacyKeyAccepted(schemaVersion(cfg), c.component, key) {
+				errors = append(errors, renamedFieldIssue(r, filePath, c.findLine(contents, key)))
+			}
+			continue
+		}
 		if !c.known[key] {
+			message := fmt.Sprintf("Unknown %s '%s'%s", c.label, key, c.suffix)
+			if match, ok := textutil.ClosestMatch(key, knownFieldNames(c.known)); ok {
+				message += fmt.Sprintf(" (did you mean '%s'?)", match)
+			}
 			errors = append(errors, cue.ValidationError{
 				File:     filePath,
-				Message:  fmt.Sprintf("Unknown %s '%s'%s", c.label, key, c.suffix),
+				Message:  message,
 				Severity: cue.SeveritySuggestion,
 				Source:   cue.SourceCClintObserve,
 				Line:     c.findLine(contents, key),
+				Rule:     c.rule,
 			})
 		}
 	}
 	return errors
 }
+
+func knownFieldNames(known map[string]bool) []string {
+	names := make([]string, 0, len(known))
+	for k := range known {
+		names = append(names, k)
+	}
+	return names
+}
@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/crossfile"
 	"github.com/dotcommander/cclint/internal/cue"
 )
 
@@ -34,7 +36,7 @@ func TestUnknownFieldMessagesPreserved(t *testing.T) {
 	}{
 		{
 			name: "agent",
-			run:  func() []cue.ValidationError { return validateUnknownFields(data(), filePath, "") },
+			run:  func() []cue.ValidationError { return validateUnknownFields(defaultConfig(), data(), filePath, "") },
 			check: func(t *testing.T, msg string) {
 				const want = "Unknown frontmatter field 'zzz'. Valid fields: "
 				if !strings.HasPrefix(msg, want) {
@@ -44,7 +46,7 @@ func TestUnknownFieldMessagesPreserved(t *testing.T) {
 		},
 		{
 			name: "command",
-			run:  func() []cue.ValidationError { return validateCommandSpecific(data(), filePath, "") },
+			run:  func() []cue.ValidationError { return validateCommandSpecific(defaultConfig(), data(), filePath, "") },
 			check: func(t *testing.T, msg string) {
 				const want = "Unknown frontmatter field 'zzz'. Valid fields: "
 				if !strings.HasPrefix(msg, want) {
@@ -54,7 +56,7 @@ func TestUnknownFieldMessagesPreserved(t *testing.T) {
 		},
 		{
 			name: "output-style",
-			run:  func() []cue.ValidationError { return NewOutputStyleLinter().ValidateSpecific(data(), filePath, "") },
+			run:  func() []cue.ValidationError { return NewOutputStyleLinter(nil).ValidateSpecific(data(), filePath, "") },
 			check: func(t *testing.T, msg string) {
 				const want = "Unknown frontmatter field 'zzz'. Valid fields: "
 				if !strings.HasPrefix(msg, want) {
@@ -64,7 +66,7 @@ func TestUnknownFieldMessagesPreserved(t *testing.T) {
 		},
 		{
 			name: "skill",
-			run:  func() []cue.ValidationError { return checkUnknownSkillFields(data(), filePath, "") },
+			run:  func() []cue.ValidationError { return checkUnknownSkillFields(defaultConfig(), data(), filePath, "") },
 			check: func(t *testing.T, msg string) {
 				const want = "Unknown frontmatter field 'zzz'. See https://agentskills.io/specification for valid fields"
 				if msg != want {
@@ -74,7 +76,9 @@ func TestUnknownFieldMessagesPreserved(t *testing.T) {
 		},
 		{
 			name: "plugin",
-			run:  func() []cue.ValidationError { return validateUnknownPluginFields(data(), filePath, "") },
+			run: func() []cue.ValidationError {
+				return validateUnknownPluginFields(defaultConfig(), data(), filePath, "")
+			},
 			check: func(t *testing.T, msg string) {
 				const want = "Unknown plugin field 'zzz'"
 				if msg != want {
@@ -101,3 +105,163 @@ func TestUnknownFieldMessagesPreserved(t *testing.T) {
 		})
 	}
 }
+
+func TestUnknownFieldTypoHint(t *testing.T) {
+	t.Parallel()
+
+	errs := validateUnknownFields(defaultConfig(), map[string]any{"descriptoin": "x"}, "test.md", "")
+	e, ok := findUnknownErr(errs)
+	if !ok {
+		t.Fatal("expected unknown-field issue for 'descriptoin'")
+	}
+	if !strings.HasSuffix(e.Message, "(did you mean 'description'?)") {
+		t.Errorf("message = %q, want did-you-mean hint for description", e.Message)
+	}
+	if e.Rule != cue.RuleFrontmatterUnknownKey {
+		t.Errorf("Rule = %q, want %q", e.Rule, cue.RuleFrontmatterUnknownKey)
+	}
+
+	// Keys with no near neighbour get no hint
+	errs = validateUnknownFields(defaultConfig(), map[string]any{"zzz": true}, "test.md", "")
+	if e, _ := findUnknownErr(errs); strings.Contains(e.Message, "did you mean") {
+		t.Errorf("unexpected hint in %q", e.Message)
+	}
+}
+
+func TestDetectDuplicateKeys(t *testing.T) {
+	t.Parallel()
+
+	contents := "---\nname: first\ndescription: d\nname: second\n---\nbody\n"
+	errs := DetectDuplicateKeys(contents, "agents/dup.md")
+	if len(errs) != 1 {
+		t.Fatalf("got %d errors, want 1: %+v", len(errs), errs)
+	}
+	e := errs[0]
+	if e.Line != 4 || e.Severity != cue.SeverityError || e.Rule != cue.RuleFrontmatterDuplicateKey {
+		t.Errorf("unexpected issue: %+v", e)
+	}
+	if !strings.Contains(e.Message, "'name' (first defined on line 2)") {
+		t.Errorf("message = %q", e.Message)
+	}
+
+	if errs := DetectDuplicateKeys("---\nname: a\n---\n", "x.md"); len(errs) != 0 {
+		t.Errorf("clean frontmatter produced %d errors", len(errs))
+	}
+	if errs := DetectDuplicateKeys(`{"name": "a"}`, "plugin.json"); len(errs) != 0 {
+		t.Errorf("JSON content produced %d errors", len(errs))
+	}
+}
+
+func TestDetectYAMLAliases(t *testing.T) {
+	t.Parallel()
+
+	contents := "---\nname: a\ntools: &t Read\nx-base: &base {model: sonnet}\nhooks:\n  <<: *base\nallowed: *t\n---\nbody\n"
+	errs := DetectYAMLAliases(contents, "agents/a.md")
+	if len(errs) != 1 {
+		t.Fatalf("got %d issues, want 1: %+v", len(errs), errs)
+	}
+	e := errs[0]
+	if e.Line != 6 || e.Severity != cue.SeverityWarning || e.Rule != cue.RuleFrontmatterYAMLAlias {
+		t.Errorf("unexpected issue: %+v", e)
+	}
+	if !strings.Contains(e.Message, "uses 1 merge key and 1 alias in YAML, first on line 6") || !strings.Contains(e.Message, "not part of YAML 1.2") {
+		t.Errorf("message = %q", e.Message)
+	}
+
+	if errs := DetectYAMLAliases("---\nname: a\ntools: &t Read\n---\n", "x.md"); len(errs) != 0 {
+		t.Errorf("an unused anchor produced %d issues", len(errs))
+	}
+	if errs := DetectYAMLAliases("---\na: &x 1\nb: *x\nc: *x\n---\n", "x.md"); len(errs) != 1 || !strings.Contains(errs[0].Message, "2 aliases") || strings.Contains(errs[0].Message, "1.2") {
+		t.Errorf("aliases only: %+v", errs)
+	}
+}
+
+func TestLintFileCoreKeepsLintingAfterDuplicateKey(t *testing.T) {
+	t.Parallel()
+
+	contents := "---\nname: dup-agent\nname: dup-agent\ndescripton: typo\n---\n\nBody.\n"
+	result := lintFileCore("agents/dup-agent.md", contents, NewAgentLinter("", nil), cue.NewValidator(), nil)
+
+	var sawDuplicate, sawUnknown bool
+	for _, e := range result.Errors {
+		if e.Rule == cue.RuleFrontmatterDuplicateKey {
+			sawDuplicate = true
+		}
+	}
+	for _, e := range result.Suggestions {
+		if e.Rule == cue.RuleFrontmatterUnknownKey {
+			sawUnknown = true
+		}
+	}
+	if !sawDuplicate {
+		t.Error("expected duplicate-key error")
+	}
+	if !sawUnknown {
+		t.Error("expected unknown-key suggestion — later checks must still run")
+	}
+}
+
+func TestLintFileCoreSkipsCrossFileWhenDisabled(t *testing.T) {
+	t.Parallel()
+
+	crossValidator := crossfile.NewCrossFileValidator(nil)
+	missingAgent := func(r LintResult) bool {
+		for _, e := range r.Errors {
+			if strings.Contains(e.Message, "non-existent agent") {
+				return true
+			}
+		}
+		return false
+	}
+
+	enabled := lintFileCore("commands/run.md", "---\ndescription: Run\n---\nTask(ghost-agent)\n", NewCommandLinter(nil), cue.NewValidator(), crossValidator)
+	if enabled.Disabled || !missingAgent(enabled) {
+		t.Errorf("enabled command: Disabled = %v, missing-agent error = %v", enabled.Disabled, missingAgent(enabled))
+	}
+
+	disabled := lintFileCore("commands/run.md", "---\ndescription: Run\nenabled: false\n---\nTask(ghost-agent)\n", NewCommandLinter(nil), cue.NewValidator(), crossValidator)
+	if !disabled.Disabled || missingAgent(disabled) {
+		t.Errorf("disabled command: Disabled = %v, missing-agent error = %v", disabled.Disabled, missingAgent(disabled))
+	}
+
+	summary := &LintSummary{}
+	applyResultToSummary(summary, enabled)
+	applyResultToSummary(summary, disabled)
+	if summary.DisabledFiles != 1 {
+		t.Errorf("DisabledFiles = %d, want 1", summary.DisabledFiles)
+	}
+}
+
+func TestApplyRulesConfigWarnUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	newSummary := func() *LintSummary {
+		return &LintSummary{
+			TotalSuggestions: 2,
+			Results: []LintResult{{
+				Success: true,
+				Suggestions: []cue.ValidationError{
+					{Message: "Unknown frontmatter field 'x'", Severity: cue.SeveritySuggestion, Rule: cue.RuleFrontmatterUnknownKey},
+					{Message: "other", Severity: cue.SeveritySuggestion},
+				},
+			}},
+		}
+	}
+
+	off := newSummary()
+	ApplyRulesConfig(off, config.RulesConfig{})
+	if off.TotalSuggestions != 2 || off.TotalWarnings != 0 {
+		t.Errorf("disabled mode changed totals: %+v", off)
+	}
+
+	on := newSummary()
+	ApplyRulesConfig(on, config.RulesConfig{WarnUnknownKeys: true})
+	if on.TotalSuggestions != 1 || on.TotalWarnings != 1 {
+		t.Fatalf("totals = %d suggestions, %d warnings; want 1, 1", on.TotalSuggestions, on.TotalWarnings)
+	}
+	if w := on.Results[0].Warnings[0]; w.Severity != cue.SeverityWarning || w.Rule != cue.RuleFrontmatterUnknownKey {
+		t.Errorf("escalated issue = %+v", w)
+	}
+
+	ApplyRulesConfig(nil, config.RulesConfig{WarnUnknownKeys: true})
+}
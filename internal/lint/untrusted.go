@@ -0,0 +1,259 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+var (
+	// networkCommandPattern matches a network client run as a command.
+	networkCommandPattern = regexp.MustCompile(`(?:^|[\s|;&(])(curl|wget|nc|ncat|netcat|socat|telnet|ssh|scp|sftp|ftp|rsync)(?:\s|$)`)
+
+	// networkTargetPattern matches a URL or a bash /dev/tcp or /dev/udp socket.
+	networkTargetPattern = regexp.MustCompile(`https?://[^\s"']+|/dev/(?:tcp|udp)/[^\s"']+`)
+
+	// fileCommandPattern matches a command that creates, changes, or removes
+	// the files it names, with its arguments.
+	fileCommandPattern = regexp.MustCompile(`(?:^|[\s|;&(])(cp|mv|ln|install|rm|touch|mkdir|chmod|chown)\s+([^|&;)]*)`)
+)
+
+// checkUntrustedHookCommand reports what a hook command reaches beyond the
+// component: the network, and files outside the plugin root and project.
+func checkUntrustedHookCommand(cmd, location, filePath string) []cue.ValidationError {
+	var errors []cue.ValidationError
+	issue := func(rule, msg string) cue.ValidationError {
+		return cue.ValidationError{
+			File:     filePath,
+			Message:  fmt.Sprintf("%s: %s", location, msg),
+			Severity: cue.SeverityWarning,
+			Source:   cue.SourceCClintObserve,
+			Rule:     rule,
+		}
+	}
+
+	if m := networkCommandPattern.FindStringSubmatch(cmd); m != nil {
+		errors = append(errors, issue(cue.RuleHookNetworkAccess,
+			fmt.Sprintf("runs '%s', which can send data off the machine; review what it sends and where", m[1])))
+	} else if m := networkTargetPattern.FindString(cmd); m != "" {
+		errors = append(errors, issue(cue.RuleHookNetworkAccess,
+			fmt.Sprintf("reaches the network (%s); review what it sends and where", m)))
+	}
+
+	if targets := outsideWriteTargets(cmd); len(targets) > 0 {
+		errors = append(errors, issue(cue.RuleHookWriteOutsideRoot,
+			fmt.Sprintf("writes outside the plugin root and project: %s", strings.Join(targets, ", "))))
+	}
+	return errors
+}
+
+// untrustedHTTPHook reports an http hook, which posts hook input, including
+// tool arguments and file contents, to its url.
+func untrustedHTTPHook(url any, ctx hookContext) []cue.ValidationError {
+	return []cue.ValidationError{{
+		File:     ctx.FilePath,
+		Message:  fmt.Sprintf("Event '%s' hook %d inner hook %d: sends hook input to %v; review what it receives", ctx.EventName, ctx.HookIdx, ctx.InnerIdx, url),
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceCClintObserve,
+		Rule:     cue.RuleHookNetworkAccess,
+		Pointer:  ctx.pointer("url"),
+	}}
+}
+
+// outsideWriteTargets returns the paths cmd writes, by redirect, tee, or a
+// file command, that lie outside the plugin root and the project, sorted.
+// For cp, mv, ln, and install only the destination counts.
+func outsideWriteTargets(cmd string) []string {
+	seen := make(map[string]bool)
+	add := func(target string) {
+		if target = strings.Trim(target, `"'`); writesOutside(target) {
+			seen[target] = true
+		}
+	}
+	for _, m := range credentialFileWritePattern.FindAllStringSubmatch(cmd, -1) {
+		add(m[1])
+	}
+	for _, m := range fileCommandPattern.FindAllStringSubmatch(cmd, -1) {
+		var args []string
+		for _, arg := range strings.Fields(m[2]) {
+			if !strings.HasPrefix(arg, "-") {
+				args = append(args, arg)
+			}
+		}
+		if len(args) == 0 {
+			continue
+		}
+		switch m[1] {
+		case "cp", "mv", "ln", "install":
+			add(args[len(args)-1])
+		default:
+			for _, arg := range args {
+				add(arg)
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// writesOutside reports whether a write target lies outside the plugin root
+// and the project: an absolute or home path, or one that climbs out with
+// "..". Relative paths resolve in the project, and /dev/* is not a file.
+func writesOutside(target string) bool {
+	switch {
+	case target == "" || strings.HasPrefix(target, "&") || strings.HasPrefix(target, "/dev/"):
+		return false
+	case target == ".." || strings.HasPrefix(target, "../") || strings.Contains(target, "/../") || strings.HasSuffix(target, "/.."):
+		return true
+	case strings.Contains(target, "CLAUDE_PLUGIN_ROOT") || strings.Contains(target, "CLAUDE_PROJECT_DIR"):
+		return false
+	}
+	for _, prefix := range []string{"/", "~", "$HOME", "${HOME}"} {
+		if strings.HasPrefix(target, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePluginHooks scans a plugin's hooks in untrusted mode: inline
+// hooks in the manifest, the hook files it names, and hooks/hooks.json.
+// Command and http hooks get the hook security checks; findings are
+// reported on the manifest, prefixed with where the hook is defined.
+func validatePluginHooks(cfg *config.Config, data map[string]any, rootPath, filePath, contents string) []cue.ValidationError {
+	manifest := filePath
+	if !filepath.IsAbs(manifest) {
+		manifest = filepath.Join(rootPath, manifest)
+	}
+	pluginRoot := filepath.Dir(filepath.Dir(manifest))
+	line := FindJSONFieldLine(contents, "hooks")
+
+	var errors []cue.ValidationError
+	scan := func(label string, hooks any, line int) {
+		for _, e := range pluginHookIssues(cfg, hooks, filePath) {
+			e.Message = label + ": " + e.Message
+			e.Line = line
+			errors = append(errors, e)
+		}
+	}
+
+	if h, ok := data["hooks"].(map[string]any); ok {
+		scan("plugin.json hooks", h, line)
+	}
+	for _, rel := range pluginHookFiles(data) {
+		if hooks, ok := readPluginHooks(pluginRoot, rel); ok {
+			scan(rel, hooks, 0)
+		}
+	}
+	return errors
+}
+
+// pluginHookFiles returns the hook files of a plugin manifest, relative to
+// the plugin root: hooks/hooks.json and the paths its hooks field names.
+func pluginHookFiles(data map[string]any) []string {
+	files := []string{"hooks/hooks.json"}
+	if _, inline := data["hooks"].(map[string]any); inline {
+		return files
+	}
+	for _, p := range extractPaths(data["hooks"]) {
+		if p = filepath.ToSlash(filepath.Clean(p)); !slices.Contains(files, p) {
+			files = append(files, p)
+		}
+	}
+	return files
+}
+
+// readPluginHooks reads a plugin hook file. Paths outside the plugin root
+// are not read; checkPath reports them.
+func readPluginHooks(pluginRoot, rel string) (map[string]any, bool) {
+	if filepath.IsAbs(rel) || strings.HasPrefix(rel, "..") {
+		return nil, false
+	}
+	raw, err := os.ReadFile(filepath.Join(pluginRoot, rel)) //nolint:gosec // G304: path comes from the linted plugin manifest
+	if err != nil {
+		return nil, false
+	}
+	var hooks map[string]any
+	if json.Unmarshal(raw, &hooks) != nil {
+		return nil, false
+	}
+	return hooks, true
+}
+
+// hookEvents returns the event map of a hooks object in the hooks.json
+// layout ({"hooks": {Event: [...]}}) or bare.
+func hookEvents(hooks any) map[string]any {
+	events, _ := hooks.(map[string]any)
+	if inner, ok := events["hooks"].(map[string]any); ok {
+		return inner
+	}
+	return events
+}
+
+// pluginHookIssues runs the command and http hook checks over a hooks
+// object, in the hooks.json layout ({"hooks": {Event: [...]}}) or bare.
+func pluginHookIssues(cfg *config.Config, hooks any, filePath string) []cue.ValidationError {
+	events := hookEvents(hooks)
+	var errors []cue.ValidationError
+	for _, event := range slices.Sorted(maps.Keys(events)) {
+		matchers, _ := events[event].([]any)
+		for i, m := range matchers {
+			matcher, _ := m.(map[string]any)
+			inner, _ := matcher["hooks"].([]any)
+			for j, h := range inner {
+				hook, _ := h.(map[string]any)
+				ctx := hookContext{EventName: event, HookIdx: i, InnerIdx: j, FilePath: filePath, Config: cfg}
+				switch hook["type"] {
+				case cue.TypeCommand:
+					if cmd, ok := hook["command"].(string); ok {
+						errors = append(errors, validateHookCommandSecurity(cmd, ctx)...)
+					}
+				case cue.TypeHTTP:
+					if url, ok := hook["url"]; ok {
+						errors = append(errors, untrustedHTTPHook(url, ctx)...)
+					}
+				}
+			}
+		}
+	}
+	return errors
+}
+
+// ApplyUntrusted escalates every finding of a security-category rule to an
+// error, for --untrusted runs. It runs after ApplyOverrides, so an
+// override in the audited tree cannot downgrade them.
+func ApplyUntrusted(summary *LintSummary) {
+	if summary == nil {
+		return
+	}
+
+	changed := false
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		var all []cue.ValidationError
+		for _, issues := range [][]cue.ValidationError{result.Errors, result.Warnings, result.Suggestions} {
+			for _, issue := range issues {
+				if issue.Severity != cue.SeverityError && slices.Contains(cue.RuleCategories[issue.Rule], cue.CategorySecurity) {
+					issue.Severity = cue.SeverityError
+					changed = true
+				}
+				all = append(all, issue)
+			}
+		}
+		result.Errors, result.Warnings, result.Suggestions = nil, nil, nil
+		categorizeIssues(result, all)
+		result.Success = len(result.Errors) == 0
+	}
+
+	if changed {
+		recalculateTotals(summary)
+		SortSummary(summary)
+	}
+}
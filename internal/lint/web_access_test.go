@@ -0,0 +1,94 @@
+package lint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+func TestCheckWebAccess(t *testing.T) {
+	tests := []struct {
+		name          string
+		componentType string
+		contents      string
+		policy        config.WebAccessConfig
+		want          []string // rule@line
+	}{
+		{
+			name:          "https host",
+			componentType: cue.TypeAgent,
+			contents:      "---\nname: a\n---\nUse WebFetch on https://docs.example.com/api.",
+		},
+		{
+			name:          "raw IP and plain HTTP",
+			componentType: cue.TypeAgent,
+			contents:      "---\nname: a\n---\nUse WebFetch to read https://203.0.113.7/status.\nThen WebFetch http://example.com/feed, twice: http://example.com/feed",
+			want:          []string{"web-access-ip-address@4", "web-access-insecure-url@5"},
+		},
+		{
+			name:          "code blocks are checked",
+			componentType: cue.TypeCommand,
+			contents:      "```\nWebFetch(url: \"http://[2001:db8::1]:8080/x\")\n```",
+			want:          []string{"web-access-ip-address@2"},
+		},
+		{
+			name:          "lines without a web tool",
+			componentType: cue.TypeSkill,
+			contents:      "See http://203.0.113.7/ for the legacy console.",
+		},
+		{
+			name:          "loopback hosts",
+			componentType: cue.TypeAgent,
+			contents:      "WebFetch http://localhost:3000 and http://127.0.0.1:8080/health",
+		},
+		{
+			name:          "other component types",
+			componentType: "settings",
+			contents:      "WebFetch http://203.0.113.7/",
+		},
+		{
+			name:          "denied domain",
+			componentType: cue.TypeAgent,
+			contents:      "WebFetch https://gist.pastebin.com/raw/1 and https://docs.example.com",
+			policy:        config.WebAccessConfig{DenyDomains: []string{"pastebin.com"}},
+			want:          []string{"web-access-domain-denied@1"},
+		},
+		{
+			name:          "outside the allowlist",
+			componentType: cue.TypeSkill,
+			contents:      "WebSearch, then WebFetch https://docs.example.com or https://api.github.com or https://github.com",
+			policy:        config.WebAccessConfig{AllowDomains: []string{"example.com", "*.github.com"}},
+			want:          []string{"web-access-domain-denied@1"},
+		},
+		{
+			name:          "WebFetch domain entries in tools",
+			componentType: cue.TypeAgent,
+			contents:      "---\nname: a\ntools: Read, WebFetch(domain:10.0.0.5), WebFetch(domain:docs.example.com)\n---\nBody",
+			policy:        config.WebAccessConfig{DenyDomains: []string{"example.com"}},
+			want:          []string{"web-access-ip-address@3", "web-access-domain-denied@3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _, _ := parseFrontmatter(tt.contents)
+			var got []string
+			for _, e := range CheckWebAccess(tt.policy, data, "a.md", tt.contents, tt.componentType) {
+				if e.Severity != cue.SeverityWarning {
+					t.Errorf("severity = %s, want warning: %s", e.Severity, e.Message)
+				}
+				got = append(got, fmt.Sprintf("%s@%d", e.Rule, e.Line))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("CheckWebAccess() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("CheckWebAccess()[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package migrate holds the frontmatter schema history: which keys older
+// Claude Code conventions used, the schema version that renamed them, and
+// the rewriter behind "cclint migrate".
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/types"
+)
+
+// Schema versions. Version 1 is the legacy convention that spelled
+// frontmatter keys in snake_case; version 2 is the current one, with
+// kebab-case command/skill keys and camelCase agent keys.
+const (
+	OldestSchemaVersion  = 1
+	CurrentSchemaVersion = 2
+)
+
+// Rename is one entry of the migration table: in Component files, key From
+// was renamed to To in schema version Version.
+type Rename struct {
+	Component string
+	From      string
+	To        string
+	Version   int
+}
+
+// Renames is the migration table, oldest version first.
+var Renames = []Rename{
+	{Component: types.TypeAgent, From: "disallowed_tools", To: "disallowedTools", Version: 2},
+	{Component: types.TypeAgent, From: "permission_mode", To: "permissionMode", Version: 2},
+	{Component: types.TypeAgent, From: "max_turns", To: "maxTurns", Version: 2},
+	{Component: types.TypeAgent, From: "mcp_servers", To: "mcpServers", Version: 2},
+	{Component: types.TypeAgent, From: "initial_prompt", To: "initialPrompt", Version: 2},
+	{Component: types.TypeCommand, From: "allowed_tools", To: "allowed-tools", Version: 2},
+	{Component: types.TypeCommand, From: "argument_hint", To: "argument-hint", Version: 2},
+	{Component: types.TypeCommand, From: "disable_model_invocation", To: "disable-model-invocation", Version: 2},
+	{Component: types.TypeSkill, From: "allowed_tools", To: "allowed-tools", Version: 2},
+	{Component: types.TypeSkill, From: "argument_hint", To: "argument-hint", Version: 2},
+	{Component: types.TypeSkill, From: "disable_model_invocation", To: "disable-model-invocation", Version: 2},
+	{Component: types.TypeSkill, From: "user_invocable", To: "user-invocable", Version: 2},
+	{Component: types.TypeOutputStyle, From: "keep_coding_instructions", To: "keep-coding-instructions", Version: 2},
+}
+
+// Lookup returns the table entry that renamed key in component files.
+func Lookup(component, key string) (Rename, bool) {
+	for _, r := range Renames {
+		if r.Component == component && r.From == key {
+			return r, true
+		}
+	}
+	return Rename{}, false
+}
+
+// ValidateVersion checks that v names a known schema version.
+func ValidateVersion(v int) error {
+	if v < OldestSchemaVersion || v > CurrentSchemaVersion {
+		return fmt.Errorf("unknown schema version %d. Must be %d-%d", v, OldestSchemaVersion, CurrentSchemaVersion)
+	}
+	return nil
+}
+
+// Change is one key rename made, or skipped, by Apply.
+type Change struct {
+	Rename
+	Line int
+	// Conflict is set when the file already has the new key; the old key is
+	// then left for the author to merge by hand.
+	Conflict bool
+}
+
+// Apply renames every top-level frontmatter key of a component that the
+// table retired at or before schema version to, and every key project
+// renames, the project's own deprecations, matched case-insensitively. Only
+// the key is rewritten, so values, comments, and layout are kept. Returns
+// the new contents and the changes, including skipped conflicts.
+func Apply(component, contents string, to int, project []Rename) (string, []Change) {
+	lines := strings.Split(contents, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return contents, nil
+	}
+	end := 0
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end == 0 {
+		return contents, nil
+	}
+
+	keys := make(map[string]bool)
+	for _, line := range lines[1:end] {
+		if key, ok := topLevelKey(line); ok {
+			keys[key] = true
+		}
+	}
+
+	var changes []Change
+	for i := 1; i < end; i++ {
+		key, ok := topLevelKey(lines[i])
+		if !ok {
+			continue
+		}
+		r, ok := lookupProject(project, component, key)
+		if !ok {
+			r, ok = Lookup(component, key)
+		}
+		if !ok || r.Version > to {
+			continue
+		}
+		r.From = key
+		change := Change{Rename: r, Line: i + 1}
+		if keys[r.To] {
+			change.Conflict = true
+		} else {
+			lines[i] = r.To + strings.TrimPrefix(lines[i], key)
+			keys[r.To] = true
+		}
+		changes = append(changes, change)
+	}
+	return strings.Join(lines, "\n"), changes
+}
+
+// lookupProject returns the project rename of key in component files.
+func lookupProject(project []Rename, component, key string) (Rename, bool) {
+	for _, r := range project {
+		if r.Component == component && strings.EqualFold(r.From, key) {
+			return r, true
+		}
+	}
+	return Rename{}, false
+}
+
+// topLevelKey returns the key of an unindented "key:" frontmatter line.
+func topLevelKey(line string) (string, bool) {
+	if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || line[0] == '-' {
+		return "", false
+	}
+	key, _, ok := strings.Cut(line, ":")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" || strings.ContainsAny(key, " \"'") {
+		return "", false
+	}
+	return key, true
+}
@@ -0,0 +1,125 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/types"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name         string
+		component    string
+		contents     string
+		to           int
+		want         string
+		wantChanges  int
+		wantConflict bool
+		project      []Rename
+	}{
+		{
+			name:        "renames legacy command keys",
+			component:   types.TypeCommand,
+			contents:    "---\nallowed_tools: Read, Grep  # read-only\nargument_hint: \"[file]\"\n---\nBody uses allowed_tools: here\n",
+			to:          CurrentSchemaVersion,
+			want:        "---\nallowed-tools: Read, Grep  # read-only\nargument-hint: \"[file]\"\n---\nBody uses allowed_tools: here\n",
+			wantChanges: 2,
+		},
+		{
+			name:        "agent keys become camelCase, nested keys untouched",
+			component:   types.TypeAgent,
+			contents:    "---\nname: a\nmax_turns: 5\nhooks:\n  max_turns: 1\n---\n",
+			to:          CurrentSchemaVersion,
+			want:        "---\nname: a\nmaxTurns: 5\nhooks:\n  max_turns: 1\n---\n",
+			wantChanges: 1,
+		},
+		{
+			name:         "existing new key is a conflict",
+			component:    types.TypeSkill,
+			contents:     "---\nuser_invocable: false\nuser-invocable: true\n---\n",
+			to:           CurrentSchemaVersion,
+			want:         "---\nuser_invocable: false\nuser-invocable: true\n---\n",
+			wantChanges:  1,
+			wantConflict: true,
+		},
+		{
+			name:      "older target version keeps keys",
+			component: types.TypeCommand,
+			contents:  "---\nargument_hint: x\n---\n",
+			to:        OldestSchemaVersion,
+			want:      "---\nargument_hint: x\n---\n",
+		},
+		{
+			name:      "keys renamed only for their component",
+			component: types.TypeAgent,
+			contents:  "---\nargument_hint: x\n---\n",
+			to:        CurrentSchemaVersion,
+			want:      "---\nargument_hint: x\n---\n",
+		},
+		{
+			name:        "project renames apply at any version, case-insensitively",
+			component:   types.TypeAgent,
+			contents:    "---\nname: a\nOwner_Team: core\n---\n",
+			to:          OldestSchemaVersion,
+			project:     []Rename{{Component: types.TypeAgent, From: "owner_team", To: "owner"}},
+			want:        "---\nname: a\nowner: core\n---\n",
+			wantChanges: 1,
+		},
+		{
+			name:        "project renames only for their component",
+			component:   types.TypeCommand,
+			contents:    "---\nowner_team: core\nallowed_tools: Read\n---\n",
+			to:          CurrentSchemaVersion,
+			project:     []Rename{{Component: types.TypeAgent, From: "owner_team", To: "owner"}},
+			want:        "---\nowner_team: core\nallowed-tools: Read\n---\n",
+			wantChanges: 1,
+		},
+		{
+			name:      "no frontmatter",
+			component: types.TypeCommand,
+			contents:  "argument_hint: x\n",
+			to:        CurrentSchemaVersion,
+			want:      "argument_hint: x\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changes := Apply(tt.component, tt.contents, tt.to, tt.project)
+			if got != tt.want {
+				t.Errorf("Apply() contents = %q, want %q", got, tt.want)
+			}
+			if len(changes) != tt.wantChanges {
+				t.Fatalf("Apply() changes = %v, want %d", changes, tt.wantChanges)
+			}
+			if tt.wantChanges > 0 && changes[0].Conflict != tt.wantConflict {
+				t.Errorf("changes[0].Conflict = %v, want %v", changes[0].Conflict, tt.wantConflict)
+			}
+		})
+	}
+}
+
+func TestRenamesTable(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, r := range Renames {
+		key := r.Component + "/" + r.From
+		if seen[key] {
+			t.Errorf("duplicate rename for %s", key)
+		}
+		seen[key] = true
+		if err := ValidateVersion(r.Version); err != nil || r.Version == OldestSchemaVersion {
+			t.Errorf("rename %s has version %d; renames must land after version %d", key, r.Version, OldestSchemaVersion)
+		}
+		if _, ok := Lookup(r.Component, r.To); ok {
+			t.Errorf("rename %s targets %s, which is itself renamed", key, r.To)
+		}
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	for v, wantErr := range map[int]bool{0: true, 1: false, CurrentSchemaVersion: false, CurrentSchemaVersion + 1: true} {
+		if err := ValidateVersion(v); (err != nil) != wantErr {
+			t.Errorf("ValidateVersion(%d) error = %v, wantErr %v", v, err, wantErr)
+		}
+	}
+}
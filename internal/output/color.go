@@ -0,0 +1,70 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// ColorMode selects when console output is colored.
+type ColorMode string
+
+const (
+	ColorAuto   ColorMode = "auto"   // color terminals unless NO_COLOR is set
+	ColorAlways ColorMode = "always" // color even when piped
+	ColorNever  ColorMode = "never"  // plain text
+)
+
+// ColorModes lists the accepted --color values.
+var ColorModes = []ColorMode{ColorAuto, ColorAlways, ColorNever}
+
+// ParseColorMode validates a --color value. Empty means ColorAuto.
+func ParseColorMode(s string) (ColorMode, error) {
+	if s == "" {
+		return ColorAuto, nil
+	}
+	if m := ColorMode(s); slices.Contains(ColorModes, m) {
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid color %q: must be auto, always, or never", s)
+}
+
+// Colorize reports whether the formatters should style their output. In
+// auto mode lipgloss still drops the escape codes when stdout is not a
+// terminal; NO_COLOR (https://no-color.org) also turns off the
+// celebration animation.
+func (m ColorMode) Colorize() bool {
+	switch m {
+	case ColorNever:
+		return false
+	case ColorAlways:
+		return true
+	default:
+		return os.Getenv("NO_COLOR") == ""
+	}
+}
+
+// hyperlink wraps text in an OSC 8 hyperlink to url when output is styled,
+// so terminals that support it make the text clickable and others show it
+// as is. Plain output, and text without a url, are left unchanged.
+func hyperlink(url, text string) string {
+	if url == "" || lipgloss.ColorProfile() == termenv.Ascii {
+		return text
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// ApplyColorMode configures the shared lipgloss renderer for m. Always
+// forces ANSI colors even when stdout is piped; never strips them; auto
+// keeps lipgloss's own terminal and environment detection.
+func ApplyColorMode(m ColorMode) {
+	switch m {
+	case ColorAlways:
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	case ColorNever:
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestParseColorMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ColorMode
+		wantErr bool
+	}{
+		{"", ColorAuto, false},
+		{"auto", ColorAuto, false},
+		{"always", ColorAlways, false},
+		{"never", ColorNever, false},
+		{"sometimes", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseColorMode(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseColorMode(%q) = %q, %v; want %q, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestColorMode_Colorize(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    ColorMode
+		noColor string
+		want    bool
+	}{
+		{"auto", ColorAuto, "", true},
+		{"auto with NO_COLOR", ColorAuto, "1", false},
+		{"always ignores NO_COLOR", ColorAlways, "1", true},
+		{"never", ColorNever, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			if got := tt.mode.Colorize(); got != tt.want {
+				t.Errorf("Colorize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHyperlink(t *testing.T) {
+	defer lipgloss.SetColorProfile(lipgloss.ColorProfile())
+
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	if got, want := hyperlink("https://docs.example.com/r", "msg"), "\x1b]8;;https://docs.example.com/r\x1b\\msg\x1b]8;;\x1b\\"; got != want {
+		t.Errorf("hyperlink() = %q, want %q", got, want)
+	}
+	if got := hyperlink("", "msg"); got != "msg" {
+		t.Errorf("hyperlink() without a url = %q, want the text", got)
+	}
+
+	lipgloss.SetColorProfile(termenv.Ascii)
+	if got := hyperlink("https://docs.example.com/r", "msg"); got != "msg" {
+		t.Errorf("hyperlink() on plain output = %q, want the text", got)
+	}
+}
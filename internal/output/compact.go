@@ -21,6 +21,8 @@ type CompactFormatter struct {
 	showScores       bool
 	showImprovements bool
 	startTime        time.Time
+	opts             ConsoleOptions
+	sources          *sourceCache // nil unless opts.Snippets
 }
 
 // NewCompactFormatter creates a new CompactFormatter.
@@ -32,9 +34,22 @@ func NewCompactFormatter(quiet, verbose, showScores, showImprovements bool, star
 		showScores:       showScores,
 		showImprovements: showImprovements,
 		startTime:        startTime,
+		opts:             ConsoleOptions{GroupBy: GroupByFile, Color: ColorAuto},
 	}
 }
 
+// WithOptions sets grouping, the per-file cap, color mode, source
+// snippets, and the root used for relative paths.
+func (f *CompactFormatter) WithOptions(opts ConsoleOptions) *CompactFormatter {
+	f.opts = opts
+	f.colorize = opts.Color.Colorize()
+	f.sources = nil
+	if opts.Snippets {
+		f.sources = newSourceCache(opts.Root)
+	}
+	return f
+}
+
 // FormatAll formats multiple lint summaries in compact style.
 func (f *CompactFormatter) FormatAll(summaries []*lint.LintSummary) error {
 	if f.quiet {
@@ -49,8 +64,9 @@ func (f *CompactFormatter) FormatAll(summaries []*lint.LintSummary) error {
 
 	// Aggregate totals and collect errors/suggestions from all summaries
 	var totalFiles, totalErrors, totalSuggestions int
-	var allErrors []errorEntry
-	var allSuggestions []errorEntry
+	var allErrors []FlatIssue
+	var allWarnings []FlatIssue
+	var allSuggestions []FlatIssue
 
 	for _, s := range summaries {
 		if s.TotalFiles == 0 {
@@ -59,7 +75,7 @@ func (f *CompactFormatter) FormatAll(summaries []*lint.LintSummary) error {
 		totalFiles += s.TotalFiles
 		totalErrors += s.TotalErrors
 		totalSuggestions += s.TotalSuggestions
-		allErrors, allSuggestions = f.collectErrorsAndSuggestions(s, allErrors, allSuggestions)
+		allErrors, allWarnings, allSuggestions = f.collectErrorsAndSuggestions(s, allErrors, allWarnings, allSuggestions)
 	}
 
 	if f.verbose {
@@ -87,6 +103,7 @@ func (f *CompactFormatter) FormatAll(summaries []*lint.LintSummary) error {
 		}
 
 		f.printAllErrors(allErrors, boldStyle, redStyle)
+		f.printAllWarnings(allWarnings, boldStyle)
 		f.printAllSuggestions(allSuggestions, dimStyle)
 		f.printSummaryLine(summaryLineParams{
 			totalFiles:       totalFiles,
@@ -97,15 +114,19 @@ func (f *CompactFormatter) FormatAll(summaries []*lint.LintSummary) error {
 			redStyle:         redStyle,
 		})
 	} else {
-		// Default: minimal PASS/FAIL line + errors only
+		// Default: minimal PASS/FAIL line + errors, plus whatever --show adds
 		f.printMinimalResult(totalFiles, totalErrors, allErrors, boldStyle, redStyle)
+		f.printAllWarnings(allWarnings, boldStyle)
+		f.printAllSuggestions(allSuggestions, dimStyle)
 	}
+	FormatOwnerBreakdown(os.Stdout, summaries, f.colorize, f.verbose)
+	FormatGates(os.Stdout, summaries, f.colorize)
 
 	return nil
 }
 
 // printMinimalResult prints a single PASS/FAIL line plus errors for the default (non-verbose) path.
-func (f *CompactFormatter) printMinimalResult(totalFiles, totalErrors int, allErrors []errorEntry, boldStyle, redStyle lipgloss.Style) {
+func (f *CompactFormatter) printMinimalResult(totalFiles, totalErrors int, allErrors []FlatIssue, boldStyle, redStyle lipgloss.Style) {
 	duration := time.Since(f.startTime)
 	greenStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
 
@@ -131,10 +152,10 @@ func (f *CompactFormatter) printMinimalResult(totalFiles, totalErrors int, allEr
 }
 
 // countErrorFiles counts the number of unique files that have at least one error.
-func countErrorFiles(errors []errorEntry) int {
+func countErrorFiles(errors []FlatIssue) int {
 	seen := make(map[string]bool)
 	for _, e := range errors {
-		seen[e.file] = true
+		seen[e.File] = true
 	}
 	return len(seen)
 }
@@ -163,16 +184,23 @@ type statusInfo struct {
 
 // getStatusInfo returns the status icon, text, and style for a summary.
 func getStatusInfo(s *lint.LintSummary, maxCountLen int, greenStyle, redStyle lipgloss.Style) statusInfo {
+	counts := ""
+	if s.DisabledFiles > 0 {
+		counts = fmt.Sprintf(", %d disabled", s.DisabledFiles)
+	}
+	if s.BloatedFiles > 0 {
+		counts += fmt.Sprintf(", %d with bloated content", s.BloatedFiles)
+	}
 	if s.FailedFiles > 0 {
 		return statusInfo{
 			icon:  "✗",
-			text:  fmt.Sprintf("%*d/%d passed", maxCountLen, s.SuccessfulFiles, s.TotalFiles),
+			text:  fmt.Sprintf("%*d/%d passed%s", maxCountLen, s.SuccessfulFiles, s.TotalFiles, counts),
 			style: redStyle,
 		}
 	}
 	return statusInfo{
 		icon:  "✓",
-		text:  fmt.Sprintf("%*d passed", maxCountLen, s.TotalFiles),
+		text:  fmt.Sprintf("%*d passed%s", maxCountLen, s.TotalFiles, counts),
 		style: greenStyle,
 	}
 }
@@ -200,31 +228,31 @@ func (f *CompactFormatter) printStatusLine(p statusLineParams) {
 	}
 }
 
-// collectErrorsAndSuggestions aggregates errors and suggestions from a summary.
-func (f *CompactFormatter) collectErrorsAndSuggestions(s *lint.LintSummary, allErrors, allSuggestions []errorEntry) ([]errorEntry, []errorEntry) {
+// collectErrorsAndSuggestions aggregates the errors and suggestions from a
+// summary that pass the --show filter. Compact output leaves warnings out
+// unless --show names them.
+func (f *CompactFormatter) collectErrorsAndSuggestions(s *lint.LintSummary, allErrors, allWarnings, allSuggestions []FlatIssue) ([]FlatIssue, []FlatIssue, []FlatIssue) {
 	for _, is := range BuildFlatIssues(s) {
+		if !f.opts.Show.Shows(is, f.verbose) {
+			continue
+		}
 		switch is.Severity {
 		case SeverityError:
-			allErrors = append(allErrors, errorEntry{
-				componentType: is.ComponentType,
-				file:          is.File,
-				err:           is.Err,
-			})
-		case SeveritySuggestion:
-			if f.verbose {
-				allSuggestions = append(allSuggestions, errorEntry{
-					componentType: is.ComponentType,
-					file:          is.File,
-					err:           is.Err,
-				})
+			allErrors = append(allErrors, is)
+		case SeverityWarning:
+			if f.opts.Show != nil {
+				allWarnings = append(allWarnings, is)
 			}
+		case SeveritySuggestion:
+			allSuggestions = append(allSuggestions, is)
 		}
 	}
-	return allErrors, allSuggestions
+	return allErrors, allWarnings, allSuggestions
 }
 
-// printAllErrors prints all errors grouped by file.
-func (f *CompactFormatter) printAllErrors(allErrors []errorEntry, boldStyle, redStyle lipgloss.Style) {
+// printAllErrors prints all errors grouped by file, or by rule with
+// --group-by rule.
+func (f *CompactFormatter) printAllErrors(allErrors []FlatIssue, boldStyle, redStyle lipgloss.Style) {
 	if len(allErrors) == 0 {
 		return
 	}
@@ -235,25 +263,29 @@ func (f *CompactFormatter) printAllErrors(allErrors []errorEntry, boldStyle, red
 	} else {
 		fmt.Println("Errors:")
 	}
+	f.printIssueGroups(allErrors, "error", redStyle)
+}
 
-	// Group errors by file
-	currentFile := ""
-	for _, e := range allErrors {
-		if e.file != currentFile {
-			currentFile = e.file
-			if f.colorize {
-				fmt.Printf("  %s\n", redStyle.Render(e.file))
-			} else {
-				fmt.Printf("  %s\n", e.file)
-			}
-		}
-		f.printError(e.err, "error")
+// printAllWarnings prints the warnings --show asked for, grouped like
+// errors.
+func (f *CompactFormatter) printAllWarnings(allWarnings []FlatIssue, boldStyle lipgloss.Style) {
+	if len(allWarnings) == 0 {
+		return
+	}
+
+	fmt.Println()
+	if f.colorize {
+		fmt.Println(boldStyle.Render("Warnings:"))
+	} else {
+		fmt.Println("Warnings:")
 	}
+	f.printIssueGroups(allWarnings, "warning", lipgloss.NewStyle().Foreground(lipgloss.Color("3")))
 }
 
-// printAllSuggestions prints all suggestions grouped by file.
-func (f *CompactFormatter) printAllSuggestions(allSuggestions []errorEntry, dimStyle lipgloss.Style) {
-	if !f.verbose || len(allSuggestions) == 0 {
+// printAllSuggestions prints all suggestions grouped by file, or by rule
+// with --group-by rule.
+func (f *CompactFormatter) printAllSuggestions(allSuggestions []FlatIssue, dimStyle lipgloss.Style) {
+	if len(allSuggestions) == 0 {
 		return
 	}
 
@@ -263,14 +295,39 @@ func (f *CompactFormatter) printAllSuggestions(allSuggestions []errorEntry, dimS
 	} else {
 		fmt.Println("Suggestions:")
 	}
+	f.printIssueGroups(allSuggestions, "suggestion", lipgloss.NewStyle())
+}
 
-	currentFile := ""
-	for _, e := range allSuggestions {
-		if e.file != currentFile {
-			currentFile = e.file
-			fmt.Printf("  %s\n", e.file)
+// printIssueGroups prints one severity section's issues under file or rule
+// headers, applying the per-file cap. Output is already split by severity,
+// so GroupBySeverity prints the same as GroupByFile here.
+func (f *CompactFormatter) printIssueGroups(issues []FlatIssue, severity string, headerStyle lipgloss.Style) {
+	issues, hidden := capPerFile(issues, f.opts.MaxIssuesPerFile)
+	byRule := f.opts.GroupBy == GroupByRule
+	groupBy := GroupByFile
+	if byRule {
+		groupBy = GroupByRule
+	}
+
+	for _, g := range groupIssues(issues, groupBy) {
+		header := g.Key
+		if !byRule {
+			header = displayPath(f.opts.Root, header)
+		}
+		if f.colorize {
+			header = headerStyle.Render(header)
+		}
+		fmt.Printf("  %s\n", header)
+
+		for _, is := range g.Issues {
+			f.printError(is.Err, severity, byRule)
+		}
+		if !byRule && hidden[g.Key] > 0 {
+			fmt.Printf("    … %d more %s\n", hidden[g.Key], pluralizeCount(severity, hidden[g.Key]))
 		}
-		f.printError(e.err, "suggestion")
+	}
+	if n := totalHidden(hidden); byRule && n > 0 {
+		fmt.Printf("    … %d more %s hidden by --max-issues-per-file\n", n, pluralizeCount(severity, n))
 	}
 }
 
@@ -327,13 +384,16 @@ func (f *CompactFormatter) Format(summary *lint.LintSummary) error {
 	return f.FormatAll([]*lint.LintSummary{summary})
 }
 
-// printError prints a single error with indentation.
-func (f *CompactFormatter) printError(err cue.ValidationError, severity string) {
+// printError prints a single error with indentation. withLocation prefixes
+// the file and line, for output that is not already grouped by file.
+func (f *CompactFormatter) printError(err cue.ValidationError, severity string, withLocation bool) {
 	var style lipgloss.Style
 	if f.colorize {
 		switch severity {
 		case "error":
 			style = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+		case "warning":
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
 		case "suggestion":
 			style = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
 		default:
@@ -342,23 +402,26 @@ func (f *CompactFormatter) printError(err cue.ValidationError, severity string)
 	}
 
 	prefix := "    ✘ "
-	if severity == "suggestion" {
+	switch severity {
+	case "warning":
+		prefix = "    ⚠ "
+	case "suggestion":
 		prefix = "    💡 "
 	}
 
 	msg := err.Message
+	if withLocation {
+		loc := displayPath(f.opts.Root, err.File)
+		if err.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", loc, err.Line)
+		}
+		msg = loc + ": " + msg
+	}
 	if f.colorize {
-		fmt.Printf("%s%s\n", prefix, style.Render(msg))
-	} else {
-		fmt.Printf("%s%s\n", prefix, msg)
+		msg = style.Render(msg)
 	}
-}
-
-// errorEntry groups an error with its source file and component type.
-type errorEntry struct {
-	componentType string
-	file          string
-	err           cue.ValidationError
+	fmt.Printf("%s%s\n", prefix, hyperlink(lint.RuleDocsURL(f.opts.DocsURLTemplate, err.Rule), msg))
+	printSnippet(f.sources, err, style, f.colorize)
 }
 
 // irregularPlurals maps component type names that don't pluralize by appending 's'.
@@ -3,6 +3,7 @@ package output
 import (
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -19,6 +20,8 @@ type ConsoleFormatter struct {
 	colorize         bool
 	showScores       bool
 	showImprovements bool
+	opts             ConsoleOptions
+	sources          *sourceCache // nil unless opts.Snippets
 }
 
 // NewConsoleFormatter creates a new ConsoleFormatter
@@ -29,9 +32,22 @@ func NewConsoleFormatter(quiet, verbose, showScores, showImprovements bool) *Con
 		colorize:         true,
 		showScores:       showScores,
 		showImprovements: showImprovements,
+		opts:             ConsoleOptions{GroupBy: GroupByFile, Color: ColorAuto},
 	}
 }
 
+// WithOptions sets grouping, the per-file cap, color mode, source
+// snippets, and the root used for relative paths.
+func (f *ConsoleFormatter) WithOptions(opts ConsoleOptions) *ConsoleFormatter {
+	f.opts = opts
+	f.colorize = opts.Color.Colorize()
+	f.sources = nil
+	if opts.Snippets {
+		f.sources = newSourceCache(opts.Root)
+	}
+	return f
+}
+
 // Format formats the lint summary for console output
 func (f *ConsoleFormatter) Format(summary *lint.LintSummary) error {
 	if f.quiet {
@@ -47,6 +63,8 @@ func (f *ConsoleFormatter) Format(summary *lint.LintSummary) error {
 
 	// Show summary
 	f.printSummary(summary)
+	FormatOwnerBreakdown(os.Stdout, []*lint.LintSummary{summary}, f.colorize, f.verbose)
+	FormatGates(os.Stdout, []*lint.LintSummary{summary}, f.colorize)
 
 	// Show conclusion
 	f.printConclusion(summary)
@@ -59,9 +77,15 @@ func (f *ConsoleFormatter) printHeader(summary *lint.LintSummary) {
 	// No header in simplified UX
 }
 
-// printFileResults prints results for each file
+// printFileResults prints results for each file, or hands off to
+// printGroupedResults when grouping by rule or severity.
 func (f *ConsoleFormatter) printFileResults(summary *lint.LintSummary) {
 	issues := BuildFlatIssues(summary)
+	if f.opts.GroupBy == GroupByRule || f.opts.GroupBy == GroupBySeverity {
+		f.printGroupedResults(issues)
+		return
+	}
+
 	for i := range summary.Results {
 		fileIssues := issuesForResult(issues, i)
 		if !f.shouldShowFile(&summary.Results[i], fileIssues) {
@@ -75,10 +99,38 @@ func (f *ConsoleFormatter) printFileResults(summary *lint.LintSummary) {
 	}
 }
 
+// printGroupedResults prints one block per rule or severity. Scores and
+// improvements are per file, so they only appear when grouping by file.
+func (f *ConsoleFormatter) printGroupedResults(issues []FlatIssue) {
+	visible, hidden := capPerFile(f.opts.Show.filter(issues, f.verbose), f.opts.MaxIssuesPerFile)
+
+	headerStyle := lipgloss.NewStyle()
+	if f.colorize {
+		headerStyle = headerStyle.Bold(true)
+	}
+	for _, g := range groupIssues(visible, f.opts.GroupBy) {
+		header := g.Key
+		if f.opts.GroupBy == GroupBySeverity {
+			header = pluralize(header)
+		}
+		fmt.Printf("%s (%d)\n", headerStyle.Render(header), len(g.Issues))
+		for _, is := range g.Issues {
+			f.printValidationError(is.Err, string(is.Severity))
+		}
+	}
+	if n := totalHidden(hidden); n > 0 {
+		fmt.Printf("    … %d more %s hidden by --max-issues-per-file\n", n, pluralizeCount("issue", n))
+	}
+}
+
 // shouldShowFile determines if a file result should be displayed.
 func (f *ConsoleFormatter) shouldShowFile(result *lint.LintResult, fileIssues []FlatIssue) bool {
-	hasIssues := countBySeverity(fileIssues, SeverityError) > 0 || countBySeverity(fileIssues, SeverityWarning) > 0
-	return hasIssues || f.verbose
+	return slices.ContainsFunc(fileIssues, f.shows) || f.verbose
+}
+
+// shows reports whether an issue passes the --show filter.
+func (f *ConsoleFormatter) shows(is FlatIssue) bool {
+	return f.opts.Show.Shows(is, f.verbose)
 }
 
 // printFileHeader prints the file header with status icon and quality score.
@@ -87,7 +139,7 @@ func (f *ConsoleFormatter) printFileHeader(result *lint.LintResult, fileIssues [
 	fileStyle := f.getFileStyle(fileIssues)
 	scoreStr := f.formatScoreString(result)
 
-	fmt.Printf("%s %s%s\n", fileStyle.Render(status), result.File, scoreStr)
+	fmt.Printf("%s %s%s\n", fileStyle.Render(status), displayPath(f.opts.Root, result.File), scoreStr)
 }
 
 // getFileStatus returns the status icon for a file result.
@@ -145,13 +197,23 @@ func (f *ConsoleFormatter) getScoreStyle(tier string) lipgloss.Style {
 	}
 }
 
-// printFileIssues prints all errors, warnings, and suggestions for a file.
+// printFileIssues prints all errors, warnings, and suggestions for a file,
+// up to the --max-issues-per-file cap.
 func (f *ConsoleFormatter) printFileIssues(fileIssues []FlatIssue) {
+	shown, hidden := 0, 0
 	for _, is := range fileIssues {
-		if is.Severity == SeveritySuggestion && !f.verbose {
+		if !f.shows(is) {
+			continue
+		}
+		if f.opts.MaxIssuesPerFile > 0 && shown >= f.opts.MaxIssuesPerFile {
+			hidden++
 			continue
 		}
 		f.printValidationError(is.Err, string(is.Severity))
+		shown++
+	}
+	if hidden > 0 {
+		fmt.Printf("    … %d more %s\n", hidden, pluralizeCount("issue", hidden))
 	}
 }
 
@@ -236,11 +298,14 @@ func (f *ConsoleFormatter) printValidationError(err cue.ValidationError, severit
 		}
 	}
 
+	file := displayPath(f.opts.Root, err.File)
+	message := hyperlink(lint.RuleDocsURL(f.opts.DocsURLTemplate, err.Rule), err.Message)
 	if err.Line > 0 {
-		fmt.Printf("%s%s:%d: %s%s\n", prefix, style.Render(err.File), err.Line, err.Message, sourceTag)
+		fmt.Printf("%s%s:%d: %s%s\n", prefix, style.Render(file), err.Line, message, sourceTag)
 	} else {
-		fmt.Printf("%s%s: %s%s\n", prefix, style.Render(err.File), err.Message, sourceTag)
+		fmt.Printf("%s%s: %s%s\n", prefix, style.Render(file), message, sourceTag)
 	}
+	printSnippet(f.sources, err, style, f.colorize)
 }
 
 // printSummary prints the summary statistics
@@ -258,16 +323,23 @@ func (f *ConsoleFormatter) printSummary(summary *lint.LintSummary) {
 		return
 	}
 
+	counts := ""
+	if summary.DisabledFiles > 0 {
+		counts = fmt.Sprintf(", %d disabled", summary.DisabledFiles)
+	}
+	if summary.BloatedFiles > 0 {
+		counts += fmt.Sprintf(", %d with bloated content", summary.BloatedFiles)
+	}
 	duration := time.Since(summary.StartTime)
 	if f.verbose {
-		fmt.Printf("\n%d/%d passed, %d errors, %d suggestions (%v)\n",
+		fmt.Printf("\n%d/%d passed, %d errors, %d suggestions%s (%v)\n",
 			summary.SuccessfulFiles, summary.TotalFiles,
-			summary.TotalErrors, summary.TotalSuggestions,
+			summary.TotalErrors, summary.TotalSuggestions, counts,
 			duration.Round(time.Millisecond))
 	} else {
-		fmt.Printf("\n%d/%d passed, %d errors (%v)\n",
+		fmt.Printf("\n%d/%d passed, %d errors%s (%v)\n",
 			summary.SuccessfulFiles, summary.TotalFiles,
-			summary.TotalErrors,
+			summary.TotalErrors, counts,
 			duration.Round(time.Millisecond))
 	}
 }
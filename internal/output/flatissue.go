@@ -22,7 +22,7 @@ const (
 // within each result Errors then Warnings then Suggestions — identical to the
 // pre-IR traversal order in every formatter.
 type FlatIssue struct {
-	ComponentType string // from LintSummary.ComponentType
+	ComponentType string // from LintSummary.ComponentType, else LintResult.Type
 	File          string // from LintResult.File
 	ResultIndex   int    // index into LintSummary.Results (per summary)
 	Severity      Severity
@@ -38,9 +38,14 @@ func BuildFlatIssues(summary *lint.LintSummary) []FlatIssue {
 	var issues []FlatIssue
 	for i := range summary.Results {
 		result := &summary.Results[i]
+		componentType := summary.ComponentType
+		if componentType == "" {
+			// A merged full-run summary spans component types.
+			componentType = result.Type
+		}
 		for _, e := range result.Errors {
 			issues = append(issues, FlatIssue{
-				ComponentType: summary.ComponentType,
+				ComponentType: componentType,
 				File:          result.File,
 				ResultIndex:   i,
 				Severity:      SeverityError,
@@ -49,7 +54,7 @@ func BuildFlatIssues(summary *lint.LintSummary) []FlatIssue {
 		}
 		for _, w := range result.Warnings {
 			issues = append(issues, FlatIssue{
-				ComponentType: summary.ComponentType,
+				ComponentType: componentType,
 				File:          result.File,
 				ResultIndex:   i,
 				Severity:      SeverityWarning,
@@ -58,7 +63,7 @@ func BuildFlatIssues(summary *lint.LintSummary) []FlatIssue {
 		}
 		for _, s := range result.Suggestions {
 			issues = append(issues, FlatIssue{
-				ComponentType: summary.ComponentType,
+				ComponentType: componentType,
 				File:          result.File,
 				ResultIndex:   i,
 				Severity:      SeveritySuggestion,
@@ -0,0 +1,112 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// FormatGates writes the outcome of each quality gate in the gates config
+// block. Nothing is written when no summary carries a gate result.
+func FormatGates(w io.Writer, summaries []*lint.LintSummary, colorize bool) {
+	gates := gateResult(summaries...)
+	if gates == nil || len(gates.Checks) == 0 {
+		return
+	}
+
+	boldStyle := lipgloss.NewStyle().Bold(true)
+	greenStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	redStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	render := func(style lipgloss.Style, s string) string {
+		if colorize {
+			return style.Render(s)
+		}
+		return s
+	}
+
+	verdict := render(greenStyle, "passed")
+	if !gates.Passed {
+		verdict = render(redStyle, "failed")
+	}
+	width := 0
+	for _, c := range gates.Checks {
+		width = max(width, len(c.Gate))
+	}
+	fmt.Fprintf(w, "\n%s %s\n", render(boldStyle, "Quality gates"), verdict)
+	for _, c := range gates.Checks {
+		line := fmt.Sprintf("%s%s  %s", c.Gate, strings.Repeat(" ", width-len(c.Gate)), gateOutcome(c))
+		if c.Passed {
+			fmt.Fprintln(w, "  "+render(greenStyle, "✓ "+line))
+		} else {
+			fmt.Fprintln(w, "  "+render(redStyle, "✗ "+line))
+		}
+	}
+}
+
+// gateOutcome describes a gate's actual value against its limit.
+func gateOutcome(c lint.GateCheck) string {
+	switch {
+	case c.Detail != "":
+		return c.Detail
+	case strings.HasPrefix(c.Gate, lint.GateMinScore+"."):
+		out := fmt.Sprintf("lowest %d (min %d)", c.Actual, c.Limit)
+		if len(c.Files) > 0 {
+			out += ": " + strings.Join(c.Files, ", ")
+		}
+		return out
+	case c.Gate == lint.GateNoNewIssues:
+		return fmt.Sprintf("%d new %s", c.Actual, pluralizeCount("issue", c.Actual))
+	default:
+		return fmt.Sprintf("%d (max %d)", c.Actual, c.Limit)
+	}
+}
+
+// gateResult returns the gate result the summaries carry, if any.
+func gateResult(summaries ...*lint.LintSummary) *lint.GateResult {
+	for _, s := range summaries {
+		if s != nil && s.Gates != nil {
+			return s.Gates
+		}
+	}
+	return nil
+}
+
+// convertGatesV2 maps the gate result to its version 2 form; nil when the
+// config has no gates block.
+func convertGatesV2(summaries ...*lint.LintSummary) *JSONGatesV2 {
+	gates := gateResult(summaries...)
+	if gates == nil {
+		return nil
+	}
+	out := &JSONGatesV2{Passed: gates.Passed, Checks: make([]JSONGateCheckV2, len(gates.Checks))}
+	for i, c := range gates.Checks {
+		out.Checks[i] = JSONGateCheckV2{
+			Gate:   c.Gate,
+			Passed: c.Passed,
+			Limit:  c.Limit,
+			Actual: c.Actual,
+			Files:  c.Files,
+			Detail: c.Detail,
+		}
+	}
+	return out
+}
+
+// JSONGatesV2 is the outcome of the gates config block.
+type JSONGatesV2 struct {
+	Passed bool              `json:"passed"`
+	Checks []JSONGateCheckV2 `json:"checks"`
+}
+
+// JSONGateCheckV2 is the outcome of one quality gate.
+type JSONGateCheckV2 struct {
+	Gate   string   `json:"gate"`
+	Passed bool     `json:"passed"`
+	Limit  int      `json:"limit"`
+	Actual int      `json:"actual"`
+	Files  []string `json:"files,omitempty"`
+	Detail string   `json:"detail,omitempty"`
+}
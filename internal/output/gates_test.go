@@ -0,0 +1,40 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func TestFormatGates(t *testing.T) {
+	summary := &lint.LintSummary{Gates: &lint.GateResult{Checks: []lint.GateCheck{
+		{Gate: lint.GateMaxErrors, Limit: 0, Actual: 0, Passed: true},
+		{Gate: lint.GateMaxWarnings, Limit: 20, Actual: 25},
+		{Gate: lint.GateMinScore + ".agent", Limit: 70, Actual: 55, Files: []string{"agents/a.md", "agents/b.md"}},
+		{Gate: lint.GateNoNewIssues, Actual: 1},
+	}}}
+
+	var buf bytes.Buffer
+	FormatGates(&buf, []*lint.LintSummary{nil, summary}, false)
+	out := buf.String()
+	for _, want := range []string{
+		"Quality gates failed",
+		"  ✓ maxErrors       0 (max 0)",
+		"  ✗ maxWarnings     25 (max 20)",
+		"  ✗ minScore.agent  lowest 55 (min 70): agents/a.md, agents/b.md",
+		"  ✗ noNewIssues     1 new issue",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("gates missing %q:\n%s", want, out)
+		}
+	}
+
+	// No gates block, no section.
+	buf.Reset()
+	FormatGates(&buf, []*lint.LintSummary{{}}, false)
+	if buf.Len() != 0 {
+		t.Errorf("gates without a gates block:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,158 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// GroupBy selects how the console formatters group findings.
+type GroupBy string
+
+const (
+	GroupByFile     GroupBy = "file"     // one block per file (default)
+	GroupByRule     GroupBy = "rule"     // one block per rule ID
+	GroupBySeverity GroupBy = "severity" // errors, then warnings, then suggestions
+)
+
+// GroupByModes lists the accepted --group-by values.
+var GroupByModes = []GroupBy{GroupByFile, GroupByRule, GroupBySeverity}
+
+// ParseGroupBy validates a --group-by value. Empty means GroupByFile.
+func ParseGroupBy(s string) (GroupBy, error) {
+	if s == "" {
+		return GroupByFile, nil
+	}
+	if g := GroupBy(s); slices.Contains(GroupByModes, g) {
+		return g, nil
+	}
+	return "", fmt.Errorf("invalid group-by %q: must be file, rule, or severity", s)
+}
+
+// ConsoleOptions configures the console formatters.
+type ConsoleOptions struct {
+	GroupBy GroupBy
+	// MaxIssuesPerFile caps the findings printed per file; 0 means no cap.
+	MaxIssuesPerFile int
+	Color            ColorMode
+	// Root is the project root; paths inside it are shown relative to it
+	// and relative finding paths are read from it for snippets.
+	Root string
+	// Snippets prints the offending source line, with context and a caret,
+	// beneath each finding that has a line number.
+	Snippets bool
+	// Show selects the severities listed; nil keeps the verbosity default.
+	Show ShowFilter
+	// DocsURLTemplate links each finding's message to its rule's docs;
+	// see lint.RuleDocsURL. Empty leaves messages unlinked.
+	DocsURLTemplate string
+}
+
+// noRuleKey labels findings that have no rule ID when grouping by rule.
+const noRuleKey = "(no rule)"
+
+// issueGroup is one block of grouped output.
+type issueGroup struct {
+	Key    string
+	Issues []FlatIssue
+}
+
+// severityOrder ranks severities for GroupBySeverity.
+var severityOrder = map[Severity]int{SeverityError: 0, SeverityWarning: 1, SeveritySuggestion: 2}
+
+// groupIssues splits issues into blocks. File groups keep first-appearance
+// order, rule groups sort by rule ID (findings without one last), and
+// severity groups run from errors to suggestions. Issues keep their input
+// order within a group.
+func groupIssues(issues []FlatIssue, by GroupBy) []issueGroup {
+	key := func(is FlatIssue) string { return is.File }
+	switch by {
+	case GroupByRule:
+		key = func(is FlatIssue) string { return cmp.Or(is.Err.Rule, noRuleKey) }
+	case GroupBySeverity:
+		key = func(is FlatIssue) string { return string(is.Severity) }
+	}
+
+	var groups []issueGroup
+	index := make(map[string]int)
+	for _, is := range issues {
+		k := key(is)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, issueGroup{Key: k})
+		}
+		groups[i].Issues = append(groups[i].Issues, is)
+	}
+
+	switch by {
+	case GroupByRule:
+		slices.SortStableFunc(groups, func(a, b issueGroup) int {
+			return cmp.Or(
+				cmp.Compare(boolRank(a.Key == noRuleKey), boolRank(b.Key == noRuleKey)),
+				strings.Compare(a.Key, b.Key),
+			)
+		})
+	case GroupBySeverity:
+		slices.SortStableFunc(groups, func(a, b issueGroup) int {
+			return cmp.Compare(severityOrder[Severity(a.Key)], severityOrder[Severity(b.Key)])
+		})
+	}
+	return groups
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// capPerFile keeps at most limit issues per file, in order, and returns
+// how many were dropped for each file. A limit of 0 keeps everything.
+func capPerFile(issues []FlatIssue, limit int) ([]FlatIssue, map[string]int) {
+	if limit <= 0 {
+		return issues, nil
+	}
+	kept := make([]FlatIssue, 0, len(issues))
+	seen := make(map[string]int)
+	hidden := make(map[string]int)
+	for _, is := range issues {
+		seen[is.File]++
+		if seen[is.File] > limit {
+			hidden[is.File]++
+			continue
+		}
+		kept = append(kept, is)
+	}
+	return kept, hidden
+}
+
+// totalHidden sums the counts returned by capPerFile.
+func totalHidden(hidden map[string]int) int {
+	n := 0
+	for _, c := range hidden {
+		n += c
+	}
+	return n
+}
+
+// displayPath shows an absolute path inside root relative to root, and
+// leaves every other path as reported.
+func displayPath(root, path string) string {
+	if root == "" || !filepath.IsAbs(path) {
+		return path
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(absRoot, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,220 @@
+package output
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func TestParseGroupBy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    GroupBy
+		wantErr bool
+	}{
+		{"", GroupByFile, false},
+		{"file", GroupByFile, false},
+		{"rule", GroupByRule, false},
+		{"severity", GroupBySeverity, false},
+		{"type", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseGroupBy(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseGroupBy(%q) = %q, %v; want %q, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func groupingIssues() []FlatIssue {
+	return []FlatIssue{
+		{File: "b.md", Severity: SeverityWarning, Err: cue.ValidationError{File: "b.md", Rule: "rule-b", Message: "w1"}},
+		{File: "b.md", Severity: SeverityError, Err: cue.ValidationError{File: "b.md", Message: "e1"}},
+		{File: "a.md", Severity: SeveritySuggestion, Err: cue.ValidationError{File: "a.md", Rule: "rule-a", Message: "s1"}},
+		{File: "a.md", Severity: SeverityError, Err: cue.ValidationError{File: "a.md", Rule: "rule-b", Message: "e2"}},
+	}
+}
+
+func TestGroupIssues(t *testing.T) {
+	tests := []struct {
+		by       GroupBy
+		wantKeys []string
+		wantLen  []int
+	}{
+		{GroupByFile, []string{"b.md", "a.md"}, []int{2, 2}},
+		{GroupByRule, []string{"rule-a", "rule-b", noRuleKey}, []int{1, 2, 1}},
+		{GroupBySeverity, []string{"error", "warning", "suggestion"}, []int{2, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.by), func(t *testing.T) {
+			groups := groupIssues(groupingIssues(), tt.by)
+			if len(groups) != len(tt.wantKeys) {
+				t.Fatalf("got %d groups, want %d", len(groups), len(tt.wantKeys))
+			}
+			for i, g := range groups {
+				if g.Key != tt.wantKeys[i] || len(g.Issues) != tt.wantLen[i] {
+					t.Errorf("group %d = %q (%d issues), want %q (%d)", i, g.Key, len(g.Issues), tt.wantKeys[i], tt.wantLen[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCapPerFile(t *testing.T) {
+	kept, hidden := capPerFile(groupingIssues(), 1)
+	if len(kept) != 2 || kept[0].Err.Message != "w1" || kept[1].Err.Message != "s1" {
+		t.Errorf("kept = %+v, want first issue of each file", kept)
+	}
+	if hidden["a.md"] != 1 || hidden["b.md"] != 1 || totalHidden(hidden) != 2 {
+		t.Errorf("hidden = %v, want one per file", hidden)
+	}
+
+	kept, hidden = capPerFile(groupingIssues(), 0)
+	if len(kept) != 4 || hidden != nil {
+		t.Errorf("limit 0 kept %d, hidden %v; want all and nil", len(kept), hidden)
+	}
+}
+
+func TestDisplayPath(t *testing.T) {
+	root := t.TempDir()
+	tests := []struct {
+		name string
+		root string
+		path string
+		want string
+	}{
+		{"inside root", root, filepath.Join(root, "agents", "a.md"), filepath.Join("agents", "a.md")},
+		{"outside root", root, filepath.Join(filepath.Dir(root), "other.md"), filepath.Join(filepath.Dir(root), "other.md")},
+		{"already relative", root, "agents/a.md", "agents/a.md"},
+		{"no root", "", filepath.Join(root, "a.md"), filepath.Join(root, "a.md")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := displayPath(tt.root, tt.path); got != tt.want {
+				t.Errorf("displayPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func groupingSummary(root string) *lint.LintSummary {
+	file := filepath.Join(root, "agents", "a.md")
+	return &lint.LintSummary{
+		ComponentType: "agent",
+		TotalFiles:    1,
+		FailedFiles:   1,
+		TotalErrors:   2,
+		StartTime:     time.Now(),
+		Results: []lint.LintResult{{
+			File: file,
+			Type: "agent",
+			Errors: []cue.ValidationError{
+				{File: file, Line: 2, Rule: "rule-x", Message: "first error"},
+				{File: file, Line: 3, Rule: "rule-y", Message: "second error"},
+			},
+			Warnings: []cue.ValidationError{
+				{File: file, Line: 4, Rule: "rule-x", Message: "a warning"},
+			},
+		}},
+	}
+}
+
+func TestConsoleFormatter_GroupingOptions(t *testing.T) {
+	root := t.TempDir()
+	tests := []struct {
+		name            string
+		opts            ConsoleOptions
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:            "by file with relative paths",
+			opts:            ConsoleOptions{GroupBy: GroupByFile, Color: ColorNever, Root: root},
+			wantContains:    []string{"✗ agents/a.md\n", "agents/a.md:2: first error", "agents/a.md:4: a warning"},
+			wantNotContains: []string{root},
+		},
+		{
+			name:         "by rule",
+			opts:         ConsoleOptions{GroupBy: GroupByRule, Color: ColorNever, Root: root},
+			wantContains: []string{"rule-x (2)\n", "rule-y (1)\n"},
+		},
+		{
+			name:         "by severity",
+			opts:         ConsoleOptions{GroupBy: GroupBySeverity, Color: ColorNever, Root: root},
+			wantContains: []string{"errors (2)\n", "warnings (1)\n"},
+		},
+		{
+			name:            "per-file cap",
+			opts:            ConsoleOptions{GroupBy: GroupByFile, MaxIssuesPerFile: 1, Color: ColorNever, Root: root},
+			wantContains:    []string{"first error", "… 2 more issues"},
+			wantNotContains: []string{"second error", "a warning"},
+		},
+		{
+			name:            "per-file cap by rule",
+			opts:            ConsoleOptions{GroupBy: GroupByRule, MaxIssuesPerFile: 2, Color: ColorNever, Root: root},
+			wantContains:    []string{"… 1 more issue hidden by --max-issues-per-file"},
+			wantNotContains: []string{"a warning"},
+		},
+		{
+			name:            "show warnings only",
+			opts:            ConsoleOptions{GroupBy: GroupByFile, Color: ColorNever, Root: root, Show: NewShowFilter([]string{"warnings"})},
+			wantContains:    []string{"agents/a.md:4: a warning"},
+			wantNotContains: []string{"first error", "second error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewConsoleFormatter(false, false, false, false).WithOptions(tt.opts)
+			out := captureStdout(t, func() { _ = f.Format(groupingSummary(root)) })
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(out, notWant) {
+					t.Errorf("output contains %q:\n%s", notWant, out)
+				}
+			}
+		})
+	}
+}
+
+func TestCompactFormatter_GroupByRule(t *testing.T) {
+	root := t.TempDir()
+	f := NewCompactFormatter(false, false, false, false, time.Now()).
+		WithOptions(ConsoleOptions{GroupBy: GroupByRule, MaxIssuesPerFile: 1, Color: ColorNever, Root: root})
+	out := captureStdout(t, func() { _ = f.FormatAll([]*lint.LintSummary{groupingSummary(root)}) })
+
+	for _, want := range []string{"  rule-x\n", "agents/a.md:2: first error", "… 1 more error hidden by --max-issues-per-file"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "second error") {
+		t.Errorf("output should respect the per-file cap:\n%s", out)
+	}
+}
+
+func TestCompactFormatter_Show(t *testing.T) {
+	root := t.TempDir()
+	f := NewCompactFormatter(false, false, false, false, time.Now()).
+		WithOptions(ConsoleOptions{Color: ColorNever, Root: root, Show: NewShowFilter([]string{"warnings"})})
+	out := captureStdout(t, func() { _ = f.FormatAll([]*lint.LintSummary{groupingSummary(root)}) })
+
+	if !strings.Contains(out, "Warnings:") || !strings.Contains(out, "⚠ a warning") {
+		t.Errorf("output missing the requested warnings:\n%s", out)
+	}
+	if strings.Contains(out, "first error") {
+		t.Errorf("output lists errors that --show excluded:\n%s", out)
+	}
+	if !strings.Contains(out, "FAIL") {
+		t.Errorf("the result line should still count hidden errors:\n%s", out)
+	}
+}
@@ -12,10 +12,17 @@ import (
 
 // JSONFormatter formats output as JSON
 type JSONFormatter struct {
-	quiet      bool
-	indent     bool
-	outputFile string
-	version    string
+	quiet         bool
+	indent        bool
+	outputFile    string
+	version       string
+	commit        string
+	buildDate     string
+	schemaVersion int
+	configHash    string
+	docsURLs      string
+	show          ShowFilter
+	now           func() time.Time
 }
 
 // NewJSONFormatter creates a new JSONFormatter
@@ -29,20 +36,74 @@ func NewJSONFormatterWithVersion(quiet bool, indent bool, outputFile, version st
 		version = "dev"
 	}
 	return &JSONFormatter{
-		quiet:      quiet,
-		indent:     indent,
-		outputFile: outputFile,
-		version:    version,
+		quiet:         quiet,
+		indent:        indent,
+		outputFile:    outputFile,
+		version:       version,
+		schemaVersion: JSONSchemaVersion,
+		now:           time.Now,
 	}
 }
 
-// Format formats the lint summary as JSON
+// WithSchemaVersion selects the report schema: 1 for the previous
+// snake_case report, 2 (the default) for the current one.
+func (f *JSONFormatter) WithSchemaVersion(v int) *JSONFormatter {
+	f.schemaVersion = v
+	return f
+}
+
+// WithConfigHash records the effective configuration digest in version 2
+// reports (see config.Config.Hash).
+func (f *JSONFormatter) WithConfigHash(hash string) *JSONFormatter {
+	f.configHash = hash
+	return f
+}
+
+// WithBuild records the commit and build date of the cclint build in
+// version 2 reports, next to its version (see buildinfo.Read).
+func (f *JSONFormatter) WithBuild(commit, buildDate string) *JSONFormatter {
+	f.commit, f.buildDate = commit, buildDate
+	return f
+}
+
+// WithClock sets the clock used for the report timestamp and duration.
+// Tests use a fixed clock to compare reports byte for byte.
+func (f *JSONFormatter) WithClock(now func() time.Time) *JSONFormatter {
+	f.now = now
+	return f
+}
+
+// WithDocsURLTemplate adds a docsUrl to each finding with a rule, from
+// template; see lint.RuleDocsURL.
+func (f *JSONFormatter) WithDocsURLTemplate(template string) *JSONFormatter {
+	f.docsURLs = template
+	return f
+}
+
+// WithShow limits the listed findings to the --show severities. Summary
+// totals still count every finding.
+func (f *JSONFormatter) WithShow(show ShowFilter) *JSONFormatter {
+	f.show = show
+	return f
+}
+
+// Format formats the lint summary as JSON in the selected schema version.
 func (f *JSONFormatter) Format(summary *lint.LintSummary) error {
+	if f.schemaVersion == 1 {
+		return f.formatV1(summary)
+	}
+	return f.formatV2(summary)
+}
+
+// formatV1 renders the version 1 report, kept for one release behind
+// --format json@1.
+func (f *JSONFormatter) formatV1(summary *lint.LintSummary) error {
+	now := f.now()
 	report := JSONReport{
 		Header: JSONHeader{
 			Tool:      "cclint",
 			Version:   f.version,
-			Timestamp: time.Now().Format(time.RFC3339),
+			Timestamp: now.Format(time.RFC3339),
 		},
 		Summary: JSONSummary{
 			TotalFiles:       summary.TotalFiles,
@@ -51,14 +112,26 @@ func (f *JSONFormatter) Format(summary *lint.LintSummary) error {
 			TotalErrors:      summary.TotalErrors,
 			TotalWarnings:    summary.TotalWarnings,
 			TotalSuggestions: summary.TotalSuggestions,
-			Duration:         time.Since(summary.StartTime).Round(time.Millisecond).String(),
+			DisabledFiles:    summary.DisabledFiles,
+			Duration:         now.Sub(summary.StartTime).Round(time.Millisecond).String(),
 		},
-		Results: convertResults(summary.Results),
+		Suppressed: convertSuppressed(summary.Suppressed),
+		Results:    convertResults(f.show.results(summary.Results)),
 	}
 
 	return f.writeJSON(report)
 }
 
+// convertSuppressed summarizes suppressed issues, or returns nil when
+// nothing was suppressed so the section is omitted.
+func convertSuppressed(issues []lint.SuppressedIssue) *JSONSuppressed {
+	if len(issues) == 0 {
+		return nil
+	}
+	r := lint.SummarizeSuppressed(issues)
+	return &JSONSuppressed{Total: r.Total, ByRule: r.ByRule, ByFile: r.ByFile, BySource: r.BySource}
+}
+
 // convertResults maps lint results to JSON-serializable form.
 func convertResults(results []lint.LintResult) []JSONResult {
 	out := make([]JSONResult, len(results))
@@ -74,6 +147,7 @@ func convertResult(r lint.LintResult) JSONResult {
 		File:        r.File,
 		Type:        r.Type,
 		Success:     r.Success,
+		Disabled:    r.Disabled,
 		Duration:    r.Duration,
 		Errors:      convertValidationErrors(r.Errors),
 		Warnings:    convertValidationErrors(r.Warnings),
@@ -106,13 +180,15 @@ func convertValidationErrors(errs []cue.ValidationError) []JSONValidationError {
 			Source:   e.Source,
 			Line:     e.Line,
 			Column:   e.Column,
+			Rule:     e.Rule,
+			Pointer:  e.Pointer,
 		}
 	}
 	return out
 }
 
 // writeJSON marshals the report and writes it to file or stdout.
-func (f *JSONFormatter) writeJSON(report JSONReport) error {
+func (f *JSONFormatter) writeJSON(report any) error {
 	var jsonBytes []byte
 	var err error
 
@@ -136,11 +212,21 @@ func (f *JSONFormatter) writeJSON(report JSONReport) error {
 	return nil
 }
 
-// JSONReport represents the complete JSON report structure
+// JSONReport represents the complete version 1 JSON report structure
 type JSONReport struct {
-	Header  JSONHeader   `json:"header"`
-	Summary JSONSummary  `json:"summary"`
-	Results []JSONResult `json:"results"`
+	Header     JSONHeader      `json:"header"`
+	Summary    JSONSummary     `json:"summary"`
+	Suppressed *JSONSuppressed `json:"suppressed,omitempty"`
+	Results    []JSONResult    `json:"results"`
+}
+
+// JSONSuppressed counts the issues suppressed from the report (for example
+// by the baseline), per rule, file, and suppression source.
+type JSONSuppressed struct {
+	Total    int            `json:"total"`
+	ByRule   map[string]int `json:"by_rule"`
+	ByFile   map[string]int `json:"by_file"`
+	BySource map[string]int `json:"by_source"`
 }
 
 // JSONHeader contains report metadata
@@ -158,6 +244,7 @@ type JSONSummary struct {
 	TotalErrors      int    `json:"total_errors"`
 	TotalWarnings    int    `json:"total_warnings"`
 	TotalSuggestions int    `json:"total_suggestions"`
+	DisabledFiles    int    `json:"disabled_files,omitempty"`
 	Duration         string `json:"duration"`
 }
 
@@ -166,6 +253,7 @@ type JSONResult struct {
 	File        string                `json:"file"`
 	Type        string                `json:"type"`
 	Success     bool                  `json:"success"`
+	Disabled    bool                  `json:"disabled,omitempty"`
 	Duration    int64                 `json:"duration_ms,omitempty"`
 	Errors      []JSONValidationError `json:"errors,omitempty"`
 	Warnings    []JSONValidationError `json:"warnings,omitempty"`
@@ -191,4 +279,6 @@ type JSONValidationError struct {
 	Source   string `json:"source,omitempty"`
 	Line     int    `json:"line,omitempty"`
 	Column   int    `json:"column,omitempty"`
+	Rule     string `json:"rule,omitempty"`
+	Pointer  string `json:"pointer,omitempty"`
 }
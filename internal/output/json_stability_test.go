@@ -0,0 +1,153 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// stabilityFixture is a small project whose files produce findings at
+// several severities, lines, and rules.
+var stabilityFixture = map[string]string{
+	".claude/agents/zeta.md": `---
+name: zeta
+description: Zeta agent
+model: gpt-4
+tools: Read, Grpe, Read
+---
+Body without much guidance.
+`,
+	".claude/agents/alpha.md": `---
+name: Alpha_Agent
+description: Alpha agent. Use PROACTIVELY when reviewing.
+unknownField: true
+---
+## Steps
+1. Do things
+`,
+	".claude/commands/mid.md": `---
+description: Mid command
+argument-hint: <target>
+---
+Run against $2 and $1.
+`,
+	".claude/skills/beta/SKILL.md": `---
+name: beta
+description: Beta skill
+---
+# Beta
+`,
+}
+
+// writeStabilityFixture materializes stabilityFixture under a temp root and
+// returns the root and the absolute file paths in sorted order.
+func writeStabilityFixture(t *testing.T) (string, []string) {
+	t.Helper()
+	root := t.TempDir()
+	var paths []string
+	for rel, contents := range stabilityFixture {
+		abs := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(abs, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, abs)
+	}
+	slices.Sort(paths)
+	return root, paths
+}
+
+// stableJSON renders summary with a fixed clock so only lint content can
+// change the bytes.
+func stableJSON(t *testing.T, summary *lint.LintSummary) []byte {
+	t.Helper()
+	fixed := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	summary.StartTime = fixed
+	summary.Duration = 0
+
+	out := filepath.Join(t.TempDir(), "report.json")
+	f := NewJSONFormatterWithVersion(false, true, out, "test").WithClock(func() time.Time { return fixed })
+	if err := f.Format(summary); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// quietOptions returns the options of a quiet run over root with the
+// default configuration.
+func quietOptions(root string) lint.Options {
+	cfg := config.Default()
+	cfg.Root = root
+	cfg.Verbosity = config.VerbosityQuiet
+	return lint.Options{Config: cfg}
+}
+
+// TestJSONOutput_ByteStable asserts that JSON reports are byte-identical
+// across repeated runs and regardless of the order files are given in.
+func TestJSONOutput_ByteStable(t *testing.T) {
+	root, paths := writeStabilityFixture(t)
+
+	reversed := slices.Clone(paths)
+	slices.Reverse(reversed)
+
+	var reports [][]byte
+	for _, order := range [][]string{paths, reversed, paths} {
+		summary, err := lint.LintFiles(order, root, "", quietOptions(root))
+		if err != nil {
+			t.Fatalf("LintFiles() error = %v", err)
+		}
+		reports = append(reports, stableJSON(t, summary))
+	}
+	for i := 1; i < len(reports); i++ {
+		if !bytes.Equal(reports[0], reports[i]) {
+			t.Errorf("run %d JSON differs from run 0:\n--- run 0\n%s\n--- run %d\n%s", i, reports[0], i, reports[i])
+		}
+	}
+
+	var batch [][]byte
+	for range 2 {
+		summary, err := lint.LintAgents(quietOptions(root))
+		if err != nil {
+			t.Fatalf("LintAgents() error = %v", err)
+		}
+		batch = append(batch, stableJSON(t, summary))
+	}
+	if !bytes.Equal(batch[0], batch[1]) {
+		t.Errorf("batch JSON differs between runs:\n%s\n---\n%s", batch[0], batch[1])
+	}
+}
+
+// TestJSONOutput_ShuffledSummaryIsStable asserts that SortSummary removes any
+// dependence on the order results and findings were produced in.
+func TestJSONOutput_ShuffledSummaryIsStable(t *testing.T) {
+	root, paths := writeStabilityFixture(t)
+	summary, err := lint.LintFiles(paths, root, "", quietOptions(root))
+	if err != nil {
+		t.Fatalf("LintFiles() error = %v", err)
+	}
+	want := stableJSON(t, summary)
+
+	slices.Reverse(summary.Results)
+	for i := range summary.Results {
+		slices.Reverse(summary.Results[i].Errors)
+		slices.Reverse(summary.Results[i].Warnings)
+		slices.Reverse(summary.Results[i].Suggestions)
+	}
+	lint.SortSummary(summary)
+
+	if got := stableJSON(t, summary); !bytes.Equal(got, want) {
+		t.Errorf("JSON after shuffle + SortSummary differs:\n%s\n---\n%s", got, want)
+	}
+}
@@ -341,7 +341,7 @@ func TestJSONFormatter_Format(t *testing.T) {
 			var output string
 			if tt.outputFile == "" {
 				oldStdout := captureStdout(t, func() {
-					formatter := NewJSONFormatter(tt.quiet, tt.indent, tt.outputFile)
+					formatter := NewJSONFormatter(tt.quiet, tt.indent, tt.outputFile).WithSchemaVersion(1)
 					if err := formatter.Format(tt.summary); err != nil {
 						t.Fatalf("Format() error = %v", err)
 					}
@@ -375,7 +375,7 @@ func TestJSONFormatter_WriteToFile(t *testing.T) {
 		},
 	}
 
-	formatter := NewJSONFormatter(false, true, outputFile)
+	formatter := NewJSONFormatter(false, true, outputFile).WithSchemaVersion(1)
 	if err := formatter.Format(summary); err != nil {
 		t.Fatalf("Format() error = %v", err)
 	}
@@ -411,7 +411,7 @@ func TestJSONFormatter_WriteToFileError(t *testing.T) {
 		Results:    []lint.LintResult{},
 	}
 
-	formatter := NewJSONFormatter(false, true, outputFile)
+	formatter := NewJSONFormatter(false, true, outputFile).WithSchemaVersion(1)
 	err := formatter.Format(summary)
 
 	if err == nil {
@@ -431,7 +431,7 @@ func TestJSONFormatter_DurationFormat(t *testing.T) {
 	}
 
 	output := captureStdout(t, func() {
-		formatter := NewJSONFormatter(false, true, "")
+		formatter := NewJSONFormatter(false, true, "").WithSchemaVersion(1)
 		if err := formatter.Format(summary); err != nil {
 			t.Fatalf("Format() error = %v", err)
 		}
@@ -495,7 +495,7 @@ func TestNewJSONFormatterWithVersion(t *testing.T) {
 	}
 
 	output := captureStdout(t, func() {
-		formatter := NewJSONFormatterWithVersion(false, true, "", "v9.9.9-test")
+		formatter := NewJSONFormatterWithVersion(false, true, "", "v9.9.9-test").WithSchemaVersion(1)
 		if err := formatter.Format(summary); err != nil {
 			t.Fatalf("Format() error = %v", err)
 		}
@@ -577,7 +577,7 @@ func TestJSONFormatter_AllFieldsPopulated(t *testing.T) {
 	}
 
 	output := captureStdout(t, func() {
-		formatter := NewJSONFormatter(false, true, "")
+		formatter := NewJSONFormatter(false, true, "").WithSchemaVersion(1)
 		if err := formatter.Format(summary); err != nil {
 			t.Fatalf("Format() error = %v", err)
 		}
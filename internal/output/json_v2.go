@@ -0,0 +1,358 @@
+package output
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/buildinfo"
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/git"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// JSONSchemaVersion is the JSON report schema written by default.
+const JSONSchemaVersion = 2
+
+// ReportSchema is the JSON Schema describing the version 2 report.
+//
+//go:embed schema/report.v2.schema.json
+var ReportSchema []byte
+
+// formatV2 renders the version 2 report.
+func (f *JSONFormatter) formatV2(summary *lint.LintSummary) error {
+	now := f.now()
+	report := JSONReportV2{
+		SchemaVersion: 2,
+		Run:           f.runV2(now, summary.StartTime, summary.ComponentType),
+		Summary: JSONSummaryV2{
+			Files:       summary.TotalFiles,
+			Passed:      summary.SuccessfulFiles,
+			Failed:      summary.FailedFiles,
+			Disabled:    summary.DisabledFiles,
+			Bloated:     summary.BloatedFiles,
+			Errors:      summary.TotalErrors,
+			Warnings:    summary.TotalWarnings,
+			Suggestions: summary.TotalSuggestions,
+			Suppressed:  len(summary.Suppressed),
+		},
+		Results:    convertResultsV2(f.show.results(summary.Results), newFixSource(summary.ProjectRoot), f.docsURLs),
+		Suppressed: convertSuppressedV2(summary.Suppressed),
+	}
+	if g := summary.Graph; g != nil {
+		report.Graph = &JSONGraphV2{
+			Agents:   g.Agents,
+			Skills:   g.Skills,
+			Commands: g.Commands,
+			Edges:    g.Edges,
+			Cycles:   g.Cycles,
+		}
+	}
+	report.Chains = summary.Chains
+	report.Owners = convertOwnersV2(summary)
+	report.Gates = convertGatesV2(summary)
+	return f.writeJSON(report)
+}
+
+// convertResultsV2 maps lint results to their version 2 form. Findings with
+// an autofix that applies to the file carry it as a suggested fix, and
+// findings with a rule the docs URL docsURLs gives them.
+func convertResultsV2(results []lint.LintResult, fixes *fixSource, docsURLs string) []JSONResultV2 {
+	out := make([]JSONResultV2, len(results))
+	for i, r := range results {
+		jr := JSONResultV2{
+			File:       r.File,
+			Type:       r.Type,
+			Success:    r.Success,
+			Disabled:   r.Disabled,
+			DurationMs: r.Duration,
+			Owners:     r.Owners,
+			Issues:     make([]JSONIssueV2, 0, len(r.Errors)+len(r.Warnings)+len(r.Suggestions)),
+		}
+		jr.Issues = appendIssuesV2(jr.Issues, r, r.Errors, fixes, docsURLs)
+		jr.Issues = appendIssuesV2(jr.Issues, r, r.Warnings, fixes, docsURLs)
+		jr.Issues = appendIssuesV2(jr.Issues, r, r.Suggestions, fixes, docsURLs)
+		if q := r.Quality; q != nil {
+			jr.Score = &JSONScoreV2{
+				Overall:       q.Overall,
+				Tier:          q.Tier,
+				Structural:    q.Structural,
+				Practices:     q.Practices,
+				Composition:   q.Composition,
+				Documentation: q.Documentation,
+			}
+		}
+		out[i] = jr
+	}
+	return out
+}
+
+// appendIssuesV2 appends errs, findings of r, as version 2 issues.
+func appendIssuesV2(issues []JSONIssueV2, r lint.LintResult, errs []cue.ValidationError, fixes *fixSource, docsURLs string) []JSONIssueV2 {
+	for _, e := range errs {
+		issues = append(issues, JSONIssueV2{
+			Rule:         e.Rule,
+			Severity:     e.Severity,
+			Message:      e.Message,
+			Source:       e.Source,
+			Line:         e.Line,
+			Column:       e.Column,
+			Pointer:      e.Pointer,
+			DocsURL:      lint.RuleDocsURL(docsURLs, e.Rule),
+			SuggestedFix: fixes.suggest(r.File, e),
+			Blame:        convertBlameV2(r.Blame, e.Line),
+		})
+	}
+	return issues
+}
+
+// convertBlameV2 returns the blame of line, or nil when it has none.
+func convertBlameV2(blame map[int]git.LineBlame, line int) *JSONBlameV2 {
+	b, ok := blame[line]
+	if !ok {
+		return nil
+	}
+	return &JSONBlameV2{Commit: b.Commit, Author: b.Author, Email: b.Email, Date: b.Date}
+}
+
+// fixSource computes suggested fixes from the linted files, reading each
+// file once. Relative paths are tried against the root first, then as
+// given, like the console snippets.
+type fixSource struct {
+	root  string
+	files map[string]*string // nil when the file cannot be read
+}
+
+func newFixSource(root string) *fixSource {
+	return &fixSource{root: root, files: make(map[string]*string)}
+}
+
+// suggest returns the suggested fix for issue in file, or nil when its rule
+// has no autofix or the autofix does not apply to the file as it is.
+func (s *fixSource) suggest(file string, issue cue.ValidationError) *JSONFixV2 {
+	if s == nil || !lint.HasFixer(issue.Rule) {
+		return nil
+	}
+	contents, ok := s.contents(file)
+	if !ok {
+		return nil
+	}
+	fix, ok := lint.FixFor(issue, contents)
+	if !ok {
+		return nil
+	}
+	edit, err := fix.Edit(contents)
+	if err != nil {
+		return nil
+	}
+	return &JSONFixV2{
+		Description: fix.Description,
+		Range: JSONRangeV2{
+			Start: JSONPositionV2{Line: edit.Start.Line, Column: edit.Start.Column},
+			End:   JSONPositionV2{Line: edit.End.Line, Column: edit.End.Column},
+		},
+		Replacement: edit.NewText,
+	}
+}
+
+func (s *fixSource) contents(file string) (string, bool) {
+	if c, ok := s.files[file]; ok {
+		if c == nil {
+			return "", false
+		}
+		return *c, true
+	}
+	var data []byte
+	err := os.ErrNotExist
+	if !filepath.IsAbs(file) && s.root != "" {
+		data, err = os.ReadFile(filepath.Join(s.root, file)) //nolint:gosec // G304: path of a linted file
+	}
+	if err != nil {
+		data, err = os.ReadFile(file) //nolint:gosec // G304: path of a linted file
+	}
+	if err != nil {
+		s.files[file] = nil
+		return "", false
+	}
+	c := string(data)
+	s.files[file] = &c
+	return c, true
+}
+
+// convertSuppressedV2 lists and counts suppressed issues. The section is
+// always present so consumers need not check for it.
+func convertSuppressedV2(issues []lint.SuppressedIssue) JSONSuppressedV2 {
+	r := lint.SummarizeSuppressed(issues)
+	out := JSONSuppressedV2{
+		Total:    r.Total,
+		ByRule:   r.ByRule,
+		ByFile:   r.ByFile,
+		BySource: r.BySource,
+		Issues:   make([]JSONSuppressedIssueV2, len(issues)),
+	}
+	for i, is := range issues {
+		out.Issues[i] = JSONSuppressedIssueV2{File: is.File, Rule: is.Rule, Severity: is.Severity, Source: is.Source}
+	}
+	return out
+}
+
+// JSONReportV2 is the version 2 JSON report. Field names are camelCase;
+// ReportSchema documents every field.
+type JSONReportV2 struct {
+	SchemaVersion int              `json:"schemaVersion"`
+	Run           JSONRunV2        `json:"run"`
+	Summary       JSONSummaryV2    `json:"summary"`
+	Results       []JSONResultV2   `json:"results"`
+	Suppressed    JSONSuppressedV2 `json:"suppressed"`
+	Graph         *JSONGraphV2     `json:"graph,omitempty"`
+	// Chains is the delegation chain of each command or agent, with --chains.
+	Chains []crossfile.ChainLink `json:"chains,omitempty"`
+	// Owners breaks files and findings down by CODEOWNERS owner, when the
+	// project has a CODEOWNERS file.
+	Owners []JSONOwnerV2 `json:"owners,omitempty"`
+	// Gates is the outcome of the gates config block, when there is one.
+	Gates *JSONGatesV2 `json:"gates,omitempty"`
+}
+
+// JSONRunV2 describes the run that produced the report: the cclint build,
+// its rule set, and the configuration, so the findings can be traced to
+// exactly what produced them.
+type JSONRunV2 struct {
+	Tool          string `json:"tool"`
+	Version       string `json:"version"`
+	Commit        string `json:"commit,omitempty"`
+	BuildDate     string `json:"buildDate,omitempty"`
+	RulesetHash   string `json:"rulesetHash"`
+	Timestamp     string `json:"timestamp"`
+	ConfigHash    string `json:"configHash,omitempty"`
+	DurationMs    int64  `json:"durationMs"`
+	ComponentType string `json:"componentType,omitempty"`
+}
+
+// runV2 describes a run that started at start and ends at now.
+func (f *JSONFormatter) runV2(now, start time.Time, componentType string) JSONRunV2 {
+	return JSONRunV2{
+		Tool:          "cclint",
+		Version:       f.version,
+		Commit:        f.commit,
+		BuildDate:     f.buildDate,
+		RulesetHash:   buildinfo.CurrentRuleset().Hash,
+		Timestamp:     now.Format(time.RFC3339),
+		ConfigHash:    f.configHash,
+		DurationMs:    now.Sub(start).Milliseconds(),
+		ComponentType: componentType,
+	}
+}
+
+// JSONSummaryV2 counts files and findings across the run.
+type JSONSummaryV2 struct {
+	Files       int `json:"files"`
+	Passed      int `json:"passed"`
+	Failed      int `json:"failed"`
+	Disabled    int `json:"disabled"`
+	Bloated     int `json:"bloated"` // files with long lines, base64 blobs, or invalid UTF-8
+	Errors      int `json:"errors"`
+	Warnings    int `json:"warnings"`
+	Suggestions int `json:"suggestions"`
+	Suppressed  int `json:"suppressed"`
+}
+
+// JSONResultV2 is one file's result.
+type JSONResultV2 struct {
+	File       string        `json:"file"`
+	Type       string        `json:"type"`
+	Success    bool          `json:"success"`
+	Disabled   bool          `json:"disabled"`
+	DurationMs int64         `json:"durationMs"`
+	Owners     []string      `json:"owners,omitempty"` // CODEOWNERS owners
+	Issues     []JSONIssueV2 `json:"issues"`
+	Score      *JSONScoreV2  `json:"score,omitempty"`
+}
+
+// JSONIssueV2 is one finding. Issues are listed errors first, then
+// warnings, then suggestions.
+type JSONIssueV2 struct {
+	Rule     string `json:"rule,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Source   string `json:"source,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	// Pointer is the JSON Pointer of the value the finding is about, for
+	// settings and other JSON data.
+	Pointer string `json:"pointer,omitempty"`
+	// DocsURL is the documentation page of the finding's rule, when the
+	// build sets a docs URL template.
+	DocsURL string `json:"docsUrl,omitempty"`
+	// SuggestedFix is the autofix for the finding, when it has one that
+	// applies to the file; --fix applies the same edit.
+	SuggestedFix *JSONFixV2 `json:"suggestedFix,omitempty"`
+	// Blame is the last commit of the finding's line, with --blame.
+	Blame *JSONBlameV2 `json:"blame,omitempty"`
+}
+
+// JSONBlameV2 is the commit that last changed a line, from git blame.
+type JSONBlameV2 struct {
+	Commit string    `json:"commit"`
+	Author string    `json:"author"`
+	Email  string    `json:"email"`
+	Date   time.Time `json:"date"`
+}
+
+// JSONFixV2 is a suggested fix: replace the text in Range with Replacement.
+type JSONFixV2 struct {
+	Description string      `json:"description"`
+	Range       JSONRangeV2 `json:"range"`
+	Replacement string      `json:"replacement"`
+}
+
+// JSONRangeV2 is a span of a file, from Start up to but not including End.
+type JSONRangeV2 struct {
+	Start JSONPositionV2 `json:"start"`
+	End   JSONPositionV2 `json:"end"`
+}
+
+// JSONPositionV2 is a 1-based line and byte column.
+type JSONPositionV2 struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// JSONScoreV2 is a component's quality score.
+type JSONScoreV2 struct {
+	Overall       int    `json:"overall"`
+	Tier          string `json:"tier"`
+	Structural    int    `json:"structural"`
+	Practices     int    `json:"practices"`
+	Composition   int    `json:"composition"`
+	Documentation int    `json:"documentation"`
+}
+
+// JSONSuppressedV2 lists the issues hidden from the report (for example by
+// the baseline) with counts per rule, file, and suppression source.
+type JSONSuppressedV2 struct {
+	Total    int                     `json:"total"`
+	ByRule   map[string]int          `json:"byRule"`
+	ByFile   map[string]int          `json:"byFile"`
+	BySource map[string]int          `json:"bySource"`
+	Issues   []JSONSuppressedIssueV2 `json:"issues"`
+}
+
+// JSONSuppressedIssueV2 is one suppressed issue.
+type JSONSuppressedIssueV2 struct {
+	File     string `json:"file"`
+	Rule     string `json:"rule,omitempty"`
+	Severity string `json:"severity"`
+	Source   string `json:"source"`
+}
+
+// JSONGraphV2 summarizes the cross-file component graph.
+type JSONGraphV2 struct {
+	Agents   int `json:"agents"`
+	Skills   int `json:"skills"`
+	Commands int `json:"commands"`
+	Edges    int `json:"edges"`
+	Cycles   int `json:"cycles"`
+}
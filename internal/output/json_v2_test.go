@@ -0,0 +1,361 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/buildinfo"
+	"github.com/dotcommander/cclint/internal/crossfile"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/git"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/scoring"
+)
+
+// v2Summary is a summary that populates every optional report section.
+func v2Summary() *lint.LintSummary {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &lint.LintSummary{
+		ComponentType:    "agents",
+		StartTime:        start,
+		TotalFiles:       2,
+		SuccessfulFiles:  1,
+		FailedFiles:      1,
+		TotalErrors:      1,
+		TotalWarnings:    1,
+		TotalSuggestions: 1,
+		DisabledFiles:    1,
+		BloatedFiles:     1,
+		CodeOwners:       ".github/CODEOWNERS",
+		Results: []lint.LintResult{
+			{File: "agents/a.md", Type: "agent", Success: true, Disabled: true},
+			{
+				File: "agents/b.md", Type: "agent", Duration: 7, Owners: []string{"@org/agents"},
+				Suggestions: []cue.ValidationError{{Message: "s", Severity: cue.SeveritySuggestion}},
+				Warnings:    []cue.ValidationError{{Message: "w", Severity: cue.SeverityWarning, Rule: "agent-color-collision", Line: 3}},
+				Errors:      []cue.ValidationError{{Message: "e", Severity: cue.SeverityError, Source: cue.SourceAnthropicDocs, Line: 2, Column: 1}},
+				Quality:     &scoring.QualityScore{Overall: 72, Tier: "B", Structural: 30, Practices: 30, Composition: 6, Documentation: 6},
+				Blame: map[int]git.LineBlame{2: {
+					Commit: strings.Repeat("4f", 20), Author: "Alice", Email: "alice@example.com",
+					Date: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC),
+				}},
+			},
+		},
+		Suppressed: []lint.SuppressedIssue{{File: "agents/b.md", Rule: "skill-name-mismatch", Severity: cue.SeverityWarning, Source: lint.SuppressionBaseline}},
+		Graph:      &crossfile.GraphStats{Agents: 2, Skills: 1, Commands: 1, Edges: 3, Cycles: 1},
+		Chains: []crossfile.ChainLink{{
+			Type: "command", Name: "deploy", Path: "commands/deploy.md", Lines: 12, Tokens: 90,
+			Children: []crossfile.ChainLink{{Type: "agent", Name: "b", Path: "agents/b.md", Lines: 40, Tokens: 300}},
+		}},
+		Gates: &lint.GateResult{Checks: []lint.GateCheck{
+			{Gate: lint.GateMaxErrors, Limit: 0, Actual: 1},
+			{Gate: lint.GateMinScore + ".agent", Limit: 80, Actual: 72, Files: []string{"agents/b.md"}},
+			{Gate: lint.GateNoNewIssues, Detail: "no baseline to compare against"},
+		}},
+	}
+}
+
+// formatV2Report renders summary as a version 2 report with a fixed clock.
+func formatV2Report(t *testing.T, summary *lint.LintSummary) []byte {
+	t.Helper()
+	out := filepath.Join(t.TempDir(), "report.json")
+	clock := summary.StartTime.Add(1500 * time.Millisecond)
+	f := NewJSONFormatterWithVersion(false, true, out, "1.2.3").
+		WithConfigHash("sha256:"+strings.Repeat("ab", 32)).
+		WithBuild("4138a98", "2026-10-16T00:00:00Z").
+		WithClock(func() time.Time { return clock })
+	if err := f.Format(summary); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestJSONFormatter_V2(t *testing.T) {
+	var report JSONReportV2
+	if err := json.Unmarshal(formatV2Report(t, v2Summary()), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	if report.SchemaVersion != 2 {
+		t.Errorf("schemaVersion = %d, want 2", report.SchemaVersion)
+	}
+	if report.Run.Version != "1.2.3" || report.Run.DurationMs != 1500 || report.Run.ComponentType != "agents" ||
+		!strings.HasPrefix(report.Run.ConfigHash, "sha256:") {
+		t.Errorf("run = %+v", report.Run)
+	}
+	if report.Run.Commit != "4138a98" || report.Run.BuildDate != "2026-10-16T00:00:00Z" ||
+		report.Run.RulesetHash != buildinfo.CurrentRuleset().Hash {
+		t.Errorf("run build = %+v", report.Run)
+	}
+	wantSummary := JSONSummaryV2{Files: 2, Passed: 1, Failed: 1, Disabled: 1, Bloated: 1, Errors: 1, Warnings: 1, Suggestions: 1, Suppressed: 1}
+	if report.Summary != wantSummary {
+		t.Errorf("summary = %+v, want %+v", report.Summary, wantSummary)
+	}
+
+	if got := report.Results[0].Issues; got == nil || len(got) != 0 {
+		t.Errorf("clean file issues = %#v, want an empty list", got)
+	}
+	var severities []string
+	for _, is := range report.Results[1].Issues {
+		severities = append(severities, is.Severity)
+	}
+	if want := []string{"error", "warning", "suggestion"}; !slices.Equal(severities, want) {
+		t.Errorf("issue order = %v, want %v", severities, want)
+	}
+	if report.Results[1].Issues[1].Rule != "agent-color-collision" {
+		t.Errorf("rule ID not carried: %+v", report.Results[1].Issues[1])
+	}
+	if report.Results[1].Score == nil || report.Results[1].Score.Tier != "B" {
+		t.Errorf("score = %+v", report.Results[1].Score)
+	}
+
+	if report.Suppressed.Total != 1 || len(report.Suppressed.Issues) != 1 || report.Suppressed.ByRule["skill-name-mismatch"] != 1 {
+		t.Errorf("suppressed = %+v", report.Suppressed)
+	}
+	if report.Graph == nil || *report.Graph != (JSONGraphV2{Agents: 2, Skills: 1, Commands: 1, Edges: 3, Cycles: 1}) {
+		t.Errorf("graph = %+v", report.Graph)
+	}
+	if len(report.Chains) != 1 || len(report.Chains[0].Children) != 1 || report.Chains[0].Children[0].Tokens != 300 {
+		t.Errorf("chains = %+v", report.Chains)
+	}
+	if report.Results[0].Owners != nil || !slices.Equal(report.Results[1].Owners, []string{"@org/agents"}) {
+		t.Errorf("result owners = %v, %v", report.Results[0].Owners, report.Results[1].Owners)
+	}
+	wantOwners := []JSONOwnerV2{
+		{Owner: "@org/agents", Files: 1, Failed: 1, Errors: 1, Warnings: 1, Suggestions: 1},
+		{Owner: lint.NoOwner, Files: 1},
+	}
+	if !slices.Equal(report.Owners, wantOwners) {
+		t.Errorf("owners = %+v, want %+v", report.Owners, wantOwners)
+	}
+	if report.Gates == nil || report.Gates.Passed || len(report.Gates.Checks) != 3 ||
+		report.Gates.Checks[1].Gate != "minScore.agent" || !slices.Equal(report.Gates.Checks[1].Files, []string{"agents/b.md"}) {
+		t.Errorf("gates = %+v", report.Gates)
+	}
+}
+
+func TestJSONFormatter_V1StillAvailable(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "report.json")
+	if err := NewJSONFormatter(false, false, out).WithSchemaVersion(1).Format(v2Summary()); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := raw["schemaVersion"]; ok {
+		t.Error("v1 report carries schemaVersion")
+	}
+	if _, ok := raw["header"]; !ok {
+		t.Error("v1 report lacks header")
+	}
+}
+
+// TestReportSchema checks reports against ReportSchema, so the published
+// schema and the report cannot drift apart.
+func TestReportSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(ReportSchema, &schema); err != nil {
+		t.Fatalf("ReportSchema is not valid JSON: %v", err)
+	}
+
+	summaries := map[string]*lint.LintSummary{
+		"every section": v2Summary(),
+		"empty run":     {StartTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for name, summary := range summaries {
+		t.Run(name, func(t *testing.T) {
+			var report any
+			if err := json.Unmarshal(formatV2Report(t, summary), &report); err != nil {
+				t.Fatal(err)
+			}
+			for _, problem := range checkSchema(schema, schema, report, "$") {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+// checkSchema validates value against the subset of JSON Schema that
+// ReportSchema uses: $ref, type, const, enum, required, properties, items,
+// and additionalProperties.
+func checkSchema(root, schema map[string]any, value any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		def := root["$defs"].(map[string]any)[strings.TrimPrefix(ref, "#/$defs/")]
+		return checkSchema(root, def.(map[string]any), value, path)
+	}
+	if c, ok := schema["const"]; ok && fmt.Sprint(c) != fmt.Sprint(value) {
+		return []string{fmt.Sprintf("%s = %v, want %v", path, value, c)}
+	}
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, value) {
+		return []string{fmt.Sprintf("%s = %v, not in %v", path, value, enum)}
+	}
+
+	var problems []string
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return []string{path + " is not an object"}
+		}
+		required, _ := schema["required"].([]any)
+		for _, req := range required {
+			if _, ok := obj[req.(string)]; !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s is required", path, req))
+			}
+		}
+		props, _ := schema["properties"].(map[string]any)
+		for key, v := range obj {
+			switch sub := props[key]; {
+			case sub != nil:
+				problems = append(problems, checkSchema(root, sub.(map[string]any), v, path+"."+key)...)
+			case schema["additionalProperties"] == false:
+				problems = append(problems, fmt.Sprintf("%s.%s is not in the schema", path, key))
+			default:
+				if extra, ok := schema["additionalProperties"].(map[string]any); ok {
+					problems = append(problems, checkSchema(root, extra, v, path+"."+key)...)
+				}
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return []string{path + " is not an array"}
+		}
+		for i, item := range items {
+			problems = append(problems, checkSchema(root, schema["items"].(map[string]any), item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			problems = append(problems, path+" is not a string")
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != float64(int64(n)) {
+			problems = append(problems, path+" is not an integer")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			problems = append(problems, path+" is not a boolean")
+		}
+	}
+	return problems
+}
+
+func TestJSONFormatter_V2SuggestedFix(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "agents"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	contents := "---\nname: a\ntools: Read, Grep, Read\n---\nUse Read and Grep.\n"
+	if err := os.WriteFile(filepath.Join(root, "agents/a.md"), []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	summary := &lint.LintSummary{
+		StartTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		ProjectRoot: root,
+		TotalFiles:  1,
+		Results: []lint.LintResult{{
+			File: "agents/a.md", Type: "agent", Success: true,
+			Warnings: []cue.ValidationError{
+				{File: "agents/a.md", Message: "Duplicate tool", Severity: cue.SeverityWarning, Rule: cue.RuleAgentToolDuplicate, Line: 3},
+				{File: "agents/a.md", Message: "Unknown tool", Severity: cue.SeverityWarning, Rule: cue.RuleAgentToolUnknown, Line: 3},
+			},
+		}},
+	}
+
+	data := formatV2Report(t, summary)
+	var report JSONReportV2
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	issues := report.Results[0].Issues
+	want := &JSONFixV2{
+		Description: "Remove duplicate tool entries",
+		Range:       JSONRangeV2{Start: JSONPositionV2{Line: 3, Column: 1}, End: JSONPositionV2{Line: 4, Column: 1}},
+		Replacement: "tools: Read, Grep\n",
+	}
+	if got := issues[0].SuggestedFix; got == nil || *got != *want {
+		t.Errorf("suggestedFix = %+v, want %+v", got, want)
+	}
+	if issues[1].SuggestedFix != nil {
+		t.Errorf("rule without a fixer has suggestedFix %+v", issues[1].SuggestedFix)
+	}
+
+	var schema, raw map[string]any
+	if err := json.Unmarshal(ReportSchema, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	for _, problem := range checkSchema(schema, schema, raw, "$") {
+		t.Error(problem)
+	}
+}
+
+func TestJSONFormatter_V2DocsURL(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "report.json")
+	f := NewJSONFormatter(false, true, out).WithDocsURLTemplate("https://docs.example.com/rules/{rule}")
+	if err := f.Format(v2Summary()); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report JSONReportV2
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	docs := map[string]string{}
+	for _, is := range report.Results[1].Issues {
+		docs[is.Message] = is.DocsURL
+	}
+	want := map[string]string{"e": "", "w": "https://docs.example.com/rules/agent-color-collision", "s": ""}
+	if !maps.Equal(docs, want) {
+		t.Errorf("docsUrl by message = %v, want %v; findings without a rule have none", docs, want)
+	}
+
+	var schema, raw map[string]any
+	if err := json.Unmarshal(ReportSchema, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	for _, problem := range checkSchema(schema, schema, raw, "$") {
+		t.Error(problem)
+	}
+}
+
+func TestJSONFormatter_V2Blame(t *testing.T) {
+	var report JSONReportV2
+	if err := json.Unmarshal(formatV2Report(t, v2Summary()), &report); err != nil {
+		t.Fatal(err)
+	}
+	blamed := map[string]string{}
+	for _, is := range report.Results[1].Issues {
+		if is.Blame != nil {
+			blamed[is.Message] = is.Blame.Author + " " + is.Blame.Date.Format(time.DateOnly)
+		}
+	}
+	want := map[string]string{"e": "Alice 2026-03-02"}
+	if !maps.Equal(blamed, want) {
+		t.Errorf("blame by message = %v, want %v; only lines with blame get one", blamed, want)
+	}
+}
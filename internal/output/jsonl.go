@@ -0,0 +1,213 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// DefaultProgressInterval is how often JSONLWriter writes progress events
+// while a component type is being linted.
+const DefaultProgressInterval = time.Second
+
+// JSONLFinding is a finding event: one issue with the file and component
+// type it belongs to.
+type JSONLFinding struct {
+	Type          string `json:"type"` // "finding"
+	File          string `json:"file"`
+	ComponentType string `json:"componentType"`
+	JSONIssueV2
+}
+
+// JSONLProgress is a progress event: Done of Total files of a component
+// type are validated.
+type JSONLProgress struct {
+	Type          string `json:"type"` // "progress"
+	ComponentType string `json:"componentType"`
+	Done          int    `json:"done"`
+	Total         int    `json:"total"`
+	ElapsedMs     int64  `json:"elapsedMs"`
+}
+
+// JSONLSummary is the last event of a run, with its totals.
+type JSONLSummary struct {
+	Type        string `json:"type"` // "summary"
+	Files       int    `json:"files"`
+	Errors      int    `json:"errors"`
+	Warnings    int    `json:"warnings"`
+	Suggestions int    `json:"suggestions"`
+	Suppressed  int    `json:"suppressed"`
+	DurationMs  int64  `json:"durationMs"`
+}
+
+// JSONLWriter writes a run as JSON Lines, one event per line, as the run
+// produces them: finding events for each file once its findings are
+// final, progress events at most once per interval and when a type is
+// done, and a summary event at the end. The first write error stops all
+// further output and is returned by Finish.
+type JSONLWriter struct {
+	enc       *json.Encoder
+	show      ShowFilter
+	docsURLs  string
+	startTime time.Time
+	interval  time.Duration
+	now       func() time.Time
+	lastTick  time.Time
+	err       error
+}
+
+// NewJSONLWriter creates a JSONLWriter for a run that started at startTime.
+func NewJSONLWriter(w io.Writer, startTime time.Time) *JSONLWriter {
+	return &JSONLWriter{
+		enc:       json.NewEncoder(w),
+		startTime: startTime,
+		interval:  DefaultProgressInterval,
+		now:       time.Now,
+	}
+}
+
+// WithShow limits the finding events to the --show severities.
+func (w *JSONLWriter) WithShow(show ShowFilter) *JSONLWriter {
+	w.show = show
+	return w
+}
+
+// WithDocsURLTemplate adds a docsUrl to each finding with a rule, from
+// template; see lint.RuleDocsURL.
+func (w *JSONLWriter) WithDocsURLTemplate(template string) *JSONLWriter {
+	w.docsURLs = template
+	return w
+}
+
+// WithProgressInterval sets how often progress events are written; 0
+// writes one for every file.
+func (w *JSONLWriter) WithProgressInterval(d time.Duration) *JSONLWriter {
+	w.interval = d
+	return w
+}
+
+// Progress writes a progress event when the interval has passed since the
+// last one, or when p completes its component type.
+func (w *JSONLWriter) Progress(p lint.Progress) {
+	now := w.now()
+	if p.Done < p.Total && now.Sub(w.lastTick) < w.interval {
+		return
+	}
+	w.lastTick = now
+	w.write(JSONLProgress{
+		Type:          "progress",
+		ComponentType: p.ComponentType,
+		Done:          p.Done,
+		Total:         p.Total,
+		ElapsedMs:     now.Sub(w.startTime).Milliseconds(),
+	})
+}
+
+// Summary writes a finding event for each issue in summary, which may hold
+// a single file.
+func (w *JSONLWriter) Summary(summary *lint.LintSummary) {
+	fixes := newFixSource(summary.ProjectRoot)
+	for _, is := range w.show.filter(BuildFlatIssues(summary), true) {
+		w.write(JSONLFinding{
+			Type:          "finding",
+			File:          is.File,
+			ComponentType: is.ComponentType,
+			JSONIssueV2: JSONIssueV2{
+				Rule:         is.Err.Rule,
+				Severity:     is.Err.Severity,
+				Message:      is.Err.Message,
+				Source:       is.Err.Source,
+				Line:         is.Err.Line,
+				Column:       is.Err.Column,
+				Pointer:      is.Err.Pointer,
+				DocsURL:      lint.RuleDocsURL(w.docsURLs, is.Err.Rule),
+				SuggestedFix: fixes.suggest(is.File, is.Err),
+				Blame:        convertBlameV2(summary.Results[is.ResultIndex].Blame, is.Err.Line),
+			},
+		})
+	}
+}
+
+// Finish writes the summary event with the totals of summaries and returns
+// the first write error of the run.
+func (w *JSONLWriter) Finish(summaries []*lint.LintSummary) error {
+	event := JSONLSummary{Type: "summary", DurationMs: w.now().Sub(w.startTime).Milliseconds()}
+	for _, s := range summaries {
+		event.Files += s.TotalFiles
+		event.Errors += s.TotalErrors
+		event.Warnings += s.TotalWarnings
+		event.Suggestions += s.TotalSuggestions
+		event.Suppressed += len(s.Suppressed)
+	}
+	w.write(event)
+	return w.err
+}
+
+func (w *JSONLWriter) write(event any) {
+	if w.err != nil {
+		return
+	}
+	if err := w.enc.Encode(event); err != nil {
+		w.err = fmt.Errorf("error writing JSON lines: %w", err)
+	}
+}
+
+// JSONLFormatter writes a single summary as JSON Lines, for runs that lint
+// one component type or a set of files and have nothing to stream.
+type JSONLFormatter struct {
+	outputFile string
+	show       ShowFilter
+	docsURLs   string
+}
+
+// NewJSONLFormatter creates a new JSONLFormatter.
+func NewJSONLFormatter(outputFile string) *JSONLFormatter {
+	return &JSONLFormatter{outputFile: outputFile}
+}
+
+// WithShow limits the finding events to the --show severities.
+func (f *JSONLFormatter) WithShow(show ShowFilter) *JSONLFormatter {
+	f.show = show
+	return f
+}
+
+// WithDocsURLTemplate adds a docsUrl to each finding with a rule, from
+// template; see lint.RuleDocsURL.
+func (f *JSONLFormatter) WithDocsURLTemplate(template string) *JSONLFormatter {
+	f.docsURLs = template
+	return f
+}
+
+// Format writes the summary's finding events and the summary event.
+func (f *JSONLFormatter) Format(summary *lint.LintSummary) error {
+	out, closeOut, err := CreateReportWriter(f.outputFile)
+	if err != nil {
+		return err
+	}
+	w := NewJSONLWriter(out, summary.StartTime).WithShow(f.show).WithDocsURLTemplate(f.docsURLs)
+	w.Summary(summary)
+	return closeOut(w.Finish([]*lint.LintSummary{summary}))
+}
+
+// CreateReportWriter opens outputFile for writing, or returns stdout when
+// it is empty. The returned func closes the file and joins any close error
+// onto err.
+func CreateReportWriter(outputFile string) (io.Writer, func(err error) error, error) {
+	if outputFile == "" {
+		return os.Stdout, func(err error) error { return err }, nil
+	}
+	file, err := os.OpenFile(outputFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) //nolint:gosec // G304: --output path
+	if err != nil {
+		return nil, nil, fmt.Errorf("error writing to file %s: %w", outputFile, err)
+	}
+	return file, func(err error) error {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			return fmt.Errorf("error writing to file %s: %w", outputFile, closeErr)
+		}
+		return err
+	}, nil
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func TestJSONLWriter(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := start
+	var buf bytes.Buffer
+	w := NewJSONLWriter(&buf, start).WithShow(NewShowFilter([]string{"errors", "warnings"}))
+	w.now = func() time.Time { return clock }
+
+	clock = start.Add(1500 * time.Millisecond)
+	w.Progress(lint.Progress{ComponentType: "agent", Done: 1, Total: 3})
+	clock = start.Add(1800 * time.Millisecond)
+	w.Progress(lint.Progress{ComponentType: "agent", Done: 2, Total: 3}) // within the interval
+	w.Progress(lint.Progress{ComponentType: "agent", Done: 3, Total: 3}) // completes the type
+	w.Summary(&lint.LintSummary{
+		ComponentType: "agent",
+		TotalFiles:    3,
+		TotalErrors:   1,
+		Results: []lint.LintResult{
+			{
+				File:        "agents/a.md",
+				Errors:      []cue.ValidationError{{Message: "bad", Severity: cue.SeverityError, Rule: "agent-name", Line: 4}},
+				Suggestions: []cue.ValidationError{{Message: "fyi", Severity: cue.SeveritySuggestion}},
+			},
+		},
+		Suppressed: []lint.SuppressedIssue{{File: "agents/b.md"}},
+	})
+	if err := w.Finish([]*lint.LintSummary{{TotalFiles: 3, TotalErrors: 1, TotalSuggestions: 1}, {TotalFiles: 2, TotalWarnings: 4}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"type":"progress","componentType":"agent","done":1,"total":3,"elapsedMs":1500}
+{"type":"progress","componentType":"agent","done":3,"total":3,"elapsedMs":1800}
+{"type":"finding","file":"agents/a.md","componentType":"agent","rule":"agent-name","severity":"error","message":"bad","line":4}
+{"type":"summary","files":5,"errors":1,"warnings":4,"suggestions":1,"suppressed":0,"durationMs":1800}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("output =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestJSONLFormatter_Format(t *testing.T) {
+	summary := &lint.LintSummary{
+		ComponentType: "skill",
+		StartTime:     time.Now(),
+		TotalFiles:    1,
+		TotalWarnings: 1,
+		Results: []lint.LintResult{
+			{File: "skills/s/SKILL.md", Warnings: []cue.ValidationError{{Message: "w", Severity: cue.SeverityWarning, Source: cue.SourceAnthropicDocs}}},
+		},
+	}
+
+	out := filepath.Join(t.TempDir(), "report.jsonl")
+	if err := NewJSONLFormatter(out).Format(summary); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want a finding and a summary:\n%s", len(lines), data)
+	}
+	if want := `{"type":"finding","file":"skills/s/SKILL.md","componentType":"skill","severity":"warning","message":"w","source":"` + cue.SourceAnthropicDocs + `"}`; string(lines[0]) != want {
+		t.Errorf("finding = %s, want %s", lines[0], want)
+	}
+	if !bytes.HasPrefix(lines[1], []byte(`{"type":"summary","files":1,"errors":0,"warnings":1,`)) {
+		t.Errorf("summary = %s", lines[1])
+	}
+}
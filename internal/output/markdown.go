@@ -2,7 +2,9 @@ package output
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,6 +17,7 @@ type MarkdownFormatter struct {
 	quiet      bool
 	verbose    bool
 	outputFile string
+	show       ShowFilter
 }
 
 // NewMarkdownFormatter creates a new MarkdownFormatter
@@ -26,12 +29,20 @@ func NewMarkdownFormatter(quiet, verbose bool, outputFile string) *MarkdownForma
 	}
 }
 
+// WithShow limits the listed findings to the --show severities. The
+// summary table still counts every finding.
+func (f *MarkdownFormatter) WithShow(show ShowFilter) *MarkdownFormatter {
+	f.show = show
+	return f
+}
+
 // Format formats the lint summary as Markdown
 func (f *MarkdownFormatter) Format(summary *lint.LintSummary) error {
 	var builder strings.Builder
 
 	f.writeHeader(&builder, summary)
 	f.writeSummaryTable(&builder, summary)
+	f.writeSuppressed(&builder, summary)
 	f.writeDetailedResults(&builder, summary)
 	f.writeConclusion(&builder, summary)
 
@@ -56,9 +67,40 @@ func (f *MarkdownFormatter) writeSummaryTable(builder *strings.Builder, summary
 	builder.WriteString(fmt.Sprintf("| Errors | %d |\n", summary.TotalErrors))
 	builder.WriteString(fmt.Sprintf("| Warnings | %d |\n", summary.TotalWarnings))
 	builder.WriteString(fmt.Sprintf("| Suggestions | %d |\n", summary.TotalSuggestions))
+	if summary.DisabledFiles > 0 {
+		builder.WriteString(fmt.Sprintf("| Disabled | %d |\n", summary.DisabledFiles))
+	}
+	if summary.BloatedFiles > 0 {
+		builder.WriteString(fmt.Sprintf("| Bloated content | %d |\n", summary.BloatedFiles))
+	}
 	builder.WriteString("\n")
 }
 
+// writeSuppressed lists the issues the baseline hid, counted by source,
+// rule, and file. Omitted when nothing was suppressed.
+func (f *MarkdownFormatter) writeSuppressed(builder *strings.Builder, summary *lint.LintSummary) {
+	if len(summary.Suppressed) == 0 {
+		return
+	}
+	report := lint.SummarizeSuppressed(summary.Suppressed)
+	builder.WriteString(fmt.Sprintf("## Suppressed Issues (%d)\n\n", report.Total))
+	for _, section := range []struct {
+		heading string
+		counts  map[string]int
+	}{
+		{"Source", report.BySource},
+		{"Rule", report.ByRule},
+		{"File", report.ByFile},
+	} {
+		builder.WriteString(fmt.Sprintf("| %s | Count |\n", section.heading))
+		builder.WriteString("|--------|-------|\n")
+		for _, key := range slices.Sorted(maps.Keys(section.counts)) {
+			builder.WriteString(fmt.Sprintf("| %s | %d |\n", key, section.counts[key]))
+		}
+		builder.WriteString("\n")
+	}
+}
+
 func (f *MarkdownFormatter) writeDetailedResults(builder *strings.Builder, summary *lint.LintSummary) {
 	builder.WriteString("## Detailed Results\n\n")
 
@@ -76,7 +118,7 @@ func (f *MarkdownFormatter) writeTableOfContents(builder *strings.Builder, summa
 		return
 	}
 	builder.WriteString("### Files\n\n")
-	issues := BuildFlatIssues(summary)
+	issues := f.show.filter(BuildFlatIssues(summary), true)
 	for i := range summary.Results {
 		result := summary.Results[i]
 		if !f.shouldRenderResult(result, issuesForResult(issues, i)) {
@@ -89,7 +131,7 @@ func (f *MarkdownFormatter) writeTableOfContents(builder *strings.Builder, summa
 }
 
 func (f *MarkdownFormatter) writeFileResults(builder *strings.Builder, summary *lint.LintSummary) {
-	issues := BuildFlatIssues(summary)
+	issues := f.show.filter(BuildFlatIssues(summary), true)
 	for i := range summary.Results {
 		result := summary.Results[i]
 		fileIssues := issuesForResult(issues, i)
@@ -147,9 +189,14 @@ func (f *MarkdownFormatter) writeConclusion(builder *strings.Builder, summary *l
 }
 
 func (f *MarkdownFormatter) writeOutput(content string) error {
-	if f.outputFile != "" {
-		if err := os.WriteFile(f.outputFile, []byte(content), 0600); err != nil {
-			return fmt.Errorf("error writing to file %s: %w", f.outputFile, err)
+	return writeReport(f.outputFile, content)
+}
+
+// writeReport writes content to outputFile, or to stdout when it is empty.
+func writeReport(outputFile, content string) error {
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, []byte(content), 0600); err != nil {
+			return fmt.Errorf("error writing to file %s: %w", outputFile, err)
 		}
 		return nil
 	}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// FormatOwnerBreakdown writes the files and findings of each CODEOWNERS
+// owner, so lint debt can be routed to the teams that own it. Nothing is
+// written when the run has no CODEOWNERS data or no findings; suggestions
+// count as findings only when verbose.
+func FormatOwnerBreakdown(w io.Writer, summaries []*lint.LintSummary, colorize, verbose bool) {
+	counts := lint.CountByOwner(summaries...)
+	findings := 0
+	for _, c := range counts {
+		findings += c.Errors + c.Warnings
+		if verbose {
+			findings += c.Suggestions
+		}
+	}
+	if findings == 0 {
+		return
+	}
+	source := ""
+	for _, s := range summaries {
+		if s != nil && s.CodeOwners != "" {
+			source = s.CodeOwners
+			break
+		}
+	}
+
+	boldStyle := lipgloss.NewStyle().Bold(true)
+	redStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	render := func(style lipgloss.Style, s string) string {
+		if colorize {
+			return style.Render(s)
+		}
+		return s
+	}
+
+	width := 0
+	for _, c := range counts {
+		width = max(width, len(c.Owner))
+	}
+	fmt.Fprintf(w, "\n%s %s\n", render(boldStyle, "By owner"), render(dimStyle, "("+source+")"))
+	for _, c := range counts {
+		line := fmt.Sprintf("  %s%s  %s", c.Owner, strings.Repeat(" ", width-len(c.Owner)), ownerCounts(c))
+		switch {
+		case c.Errors > 0:
+			line = render(redStyle, line)
+		case c.Warnings+c.Suggestions == 0:
+			line = render(dimStyle, line)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// ownerCounts formats an owner's file and finding counts.
+func ownerCounts(c lint.OwnerCount) string {
+	return fmt.Sprintf("%d %s  %d failed  %d %s  %d %s  %d %s",
+		c.Files, pluralizeCount("file", c.Files),
+		c.Failed,
+		c.Errors, pluralizeCount("error", c.Errors),
+		c.Warnings, pluralizeCount("warning", c.Warnings),
+		c.Suggestions, pluralizeCount("suggestion", c.Suggestions))
+}
+
+// convertOwnersV2 maps the owner breakdown to its version 2 form; nil when
+// the run has no CODEOWNERS data.
+func convertOwnersV2(summaries ...*lint.LintSummary) []JSONOwnerV2 {
+	counts := lint.CountByOwner(summaries...)
+	if counts == nil {
+		return nil
+	}
+	out := make([]JSONOwnerV2, len(counts))
+	for i, c := range counts {
+		out[i] = JSONOwnerV2{
+			Owner:       c.Owner,
+			Files:       c.Files,
+			Failed:      c.Failed,
+			Errors:      c.Errors,
+			Warnings:    c.Warnings,
+			Suggestions: c.Suggestions,
+		}
+	}
+	return out
+}
+
+// JSONOwnerV2 counts the files and findings of one CODEOWNERS owner.
+type JSONOwnerV2 struct {
+	Owner       string `json:"owner"`
+	Files       int    `json:"files"`
+	Failed      int    `json:"failed"`
+	Errors      int    `json:"errors"`
+	Warnings    int    `json:"warnings"`
+	Suggestions int    `json:"suggestions"`
+}
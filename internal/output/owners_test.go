@@ -0,0 +1,65 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func ownerSummary() *lint.LintSummary {
+	return &lint.LintSummary{
+		ComponentType:    "agent",
+		StartTime:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		CodeOwners:       ".github/CODEOWNERS",
+		TotalFiles:       2,
+		SuccessfulFiles:  1,
+		FailedFiles:      1,
+		TotalErrors:      1,
+		TotalSuggestions: 1,
+		Results: []lint.LintResult{
+			{File: "agents/a.md", Type: "agent", Owners: []string{"@org/agents"}, Errors: []cue.ValidationError{{Message: "bad", Severity: cue.SeverityError}}},
+			{File: "agents/b.md", Type: "agent", Success: true, Suggestions: []cue.ValidationError{{Message: "hm", Severity: cue.SeveritySuggestion}}},
+		},
+	}
+}
+
+func TestFormatOwnerBreakdown(t *testing.T) {
+	var buf bytes.Buffer
+	FormatOwnerBreakdown(&buf, []*lint.LintSummary{ownerSummary()}, false, false)
+	out := buf.String()
+	for _, want := range []string{
+		"By owner (.github/CODEOWNERS)",
+		"  @org/agents  1 file  1 failed  1 error  0 warnings  0 suggestions",
+		"  none         1 file  0 failed  0 errors  0 warnings  1 suggestion",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("breakdown missing %q:\n%s", want, out)
+		}
+	}
+
+	// Suggestions alone are findings only in verbose mode.
+	summary := ownerSummary()
+	summary.Results = summary.Results[1:]
+	buf.Reset()
+	FormatOwnerBreakdown(&buf, []*lint.LintSummary{summary}, false, false)
+	if buf.Len() != 0 {
+		t.Errorf("breakdown without errors or warnings:\n%s", buf.String())
+	}
+	FormatOwnerBreakdown(&buf, []*lint.LintSummary{summary}, false, true)
+	if !strings.Contains(buf.String(), "By owner") {
+		t.Errorf("verbose breakdown missing:\n%s", buf.String())
+	}
+
+	// No CODEOWNERS, no breakdown.
+	summary = ownerSummary()
+	summary.CodeOwners = ""
+	buf.Reset()
+	FormatOwnerBreakdown(&buf, []*lint.LintSummary{summary}, false, true)
+	if buf.Len() != 0 {
+		t.Errorf("breakdown without CODEOWNERS:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// ProblemMatcherFormatter formats output for --format compact: one
+// "file:line:col: severity rule-id message" line per finding, the shape
+// VS Code problem matchers, Vim's quickfix errorformat, and Emacs
+// compilation-mode already parse. Suggestions and info findings are
+// reported as "info", the lowest severity editors recognize; findings
+// without a rule ID use "cclint".
+type ProblemMatcherFormatter struct {
+	outputFile string
+	show       ShowFilter
+}
+
+// NewProblemMatcherFormatter creates a new ProblemMatcherFormatter
+func NewProblemMatcherFormatter(outputFile string) *ProblemMatcherFormatter {
+	return &ProblemMatcherFormatter{outputFile: outputFile}
+}
+
+// WithShow limits the reported findings to the --show severities.
+func (f *ProblemMatcherFormatter) WithShow(show ShowFilter) *ProblemMatcherFormatter {
+	f.show = show
+	return f
+}
+
+// Format writes one line per finding. Findings without a position point at
+// line 1, column 1, and multi-line messages are joined onto one line.
+func (f *ProblemMatcherFormatter) Format(summary *lint.LintSummary) error {
+	var b strings.Builder
+	for _, is := range f.show.filter(BuildFlatIssues(summary), true) {
+		fmt.Fprintf(&b, "%s:%d:%d: %s %s %s\n",
+			is.File, max(is.Err.Line, 1), max(is.Err.Column, 1), problemMatcherSeverity(is),
+			cmp.Or(is.Err.Rule, "cclint"), strings.Join(strings.Fields(is.Err.Message), " "))
+	}
+	return writeReport(f.outputFile, b.String())
+}
+
+// problemMatcherSeverity maps a finding to error, warning, or info.
+func problemMatcherSeverity(is FlatIssue) string {
+	switch is.Severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "info"
+}
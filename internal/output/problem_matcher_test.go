@@ -0,0 +1,63 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func TestProblemMatcherFormatter_Format(t *testing.T) {
+	summary := &lint.LintSummary{
+		Results: []lint.LintResult{
+			{
+				File: "agents/a.md",
+				Errors: []cue.ValidationError{
+					{Message: "bad name\n  use kebab-case", Severity: cue.SeverityError, Rule: "agent-name", Line: 4, Column: 7},
+				},
+				Warnings: []cue.ValidationError{{Message: "no position", Severity: cue.SeverityWarning}},
+			},
+			{
+				File:        "skills/s/SKILL.md",
+				Suggestions: []cue.ValidationError{{Message: "fyi", Severity: cue.SeveritySuggestion, Rule: "skill-body-size", Line: 12}},
+			},
+		},
+		Suppressed: []lint.SuppressedIssue{{File: "agents/a.md"}},
+	}
+
+	tests := []struct {
+		name string
+		show ShowFilter
+		want string
+	}{
+		{
+			name: "every finding",
+			want: "agents/a.md:4:7: error agent-name bad name use kebab-case\n" +
+				"agents/a.md:1:1: warning cclint no position\n" +
+				"skills/s/SKILL.md:12:1: info skill-body-size fyi\n",
+		},
+		{
+			name: "errors only",
+			show: NewShowFilter([]string{"errors"}),
+			want: "agents/a.md:4:7: error agent-name bad name use kebab-case\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "compact.txt")
+			if err := NewProblemMatcherFormatter(out).WithShow(tt.show).Format(summary); err != nil {
+				t.Fatal(err)
+			}
+			data, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(data); got != tt.want {
+				t.Errorf("Format() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// progressBarWidth is the number of cells in the progress bar.
+const progressBarWidth = 24
+
+// ProgressBar draws a one-line progress bar for the component type being
+// linted, redrawn in place, for the console formatters (--progress).
+type ProgressBar struct {
+	w         io.Writer
+	startTime time.Time
+	now       func() time.Time
+	width     int // length of the last line drawn, for Clear
+}
+
+// NewProgressBar creates a ProgressBar drawing on w, usually a terminal's
+// stderr.
+func NewProgressBar(w io.Writer, startTime time.Time) *ProgressBar {
+	return &ProgressBar{w: w, startTime: startTime, now: time.Now}
+}
+
+// Update redraws the bar for p.
+func (b *ProgressBar) Update(p lint.Progress) {
+	filled := 0
+	if p.Total > 0 {
+		filled = progressBarWidth * p.Done / p.Total
+	}
+	line := fmt.Sprintf("%s %s %d/%d %ds",
+		strings.Repeat("█", filled)+strings.Repeat("░", progressBarWidth-filled),
+		p.ComponentType, p.Done, p.Total, int(b.now().Sub(b.startTime).Seconds()))
+	n := len([]rune(line))
+	fmt.Fprintf(b.w, "\r%s%s", line, strings.Repeat(" ", max(b.width-n, 0)))
+	b.width = n
+}
+
+// Clear erases the bar so the report starts on a clean line.
+func (b *ProgressBar) Clear() {
+	if b.width > 0 {
+		fmt.Fprintf(b.w, "\r%s\r", strings.Repeat(" ", b.width))
+		b.width = 0
+	}
+}
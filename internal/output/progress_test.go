@@ -0,0 +1,35 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func TestProgressBar(t *testing.T) {
+	start := time.Now()
+	var buf bytes.Buffer
+	b := NewProgressBar(&buf, start)
+	b.now = func() time.Time { return start.Add(2 * time.Second) }
+
+	b.Update(lint.Progress{ComponentType: "skill", Done: 12, Total: 24})
+	want := "\r████████████░░░░░░░░░░░░ skill 12/24 2s"
+	if got := buf.String(); got != want {
+		t.Errorf("Update() = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	b.Update(lint.Progress{ComponentType: "rule", Done: 1, Total: 1})
+	if got, want := buf.String(), "\r████████████████████████ rule 1/1 2s   "; got != want {
+		t.Errorf("Update() = %q, want %q; a shorter line must erase the old one", got, want)
+	}
+
+	buf.Reset()
+	b.Clear()
+	if got, want := buf.String(), "\r"+strings.Repeat(" ", 36)+"\r"; got != want {
+		t.Errorf("Clear() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// PackageResult is one package of a monorepo run: a root linted as its own
+// project, with its own configuration.
+type PackageResult struct {
+	Root      string // as displayed, relative to the monorepo root
+	Dir       string // absolute package root
+	Summaries []*lint.LintSummary
+	Passed    bool // no failed quality gate or findings at or above the --fail-on threshold
+	Skipped   bool // not linted because nothing in it changed
+}
+
+// rollupSummary totals a package's summaries.
+func rollupSummary(summaries []*lint.LintSummary) JSONSummaryV2 {
+	var s JSONSummaryV2
+	for _, sum := range summaries {
+		s.add(JSONSummaryV2{
+			Files:       sum.TotalFiles,
+			Passed:      sum.SuccessfulFiles,
+			Failed:      sum.FailedFiles,
+			Disabled:    sum.DisabledFiles,
+			Bloated:     sum.BloatedFiles,
+			Errors:      sum.TotalErrors,
+			Warnings:    sum.TotalWarnings,
+			Suggestions: sum.TotalSuggestions,
+			Suppressed:  len(sum.Suppressed),
+		})
+	}
+	return s
+}
+
+func (s *JSONSummaryV2) add(o JSONSummaryV2) {
+	s.Files += o.Files
+	s.Passed += o.Passed
+	s.Failed += o.Failed
+	s.Disabled += o.Disabled
+	s.Bloated += o.Bloated
+	s.Errors += o.Errors
+	s.Warnings += o.Warnings
+	s.Suggestions += o.Suggestions
+	s.Suppressed += o.Suppressed
+}
+
+// RollupPassed is the overall verdict of a monorepo run: every linted
+// package passed.
+func RollupPassed(packages []PackageResult) bool {
+	for _, p := range packages {
+		if !p.Skipped && !p.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatRollup writes the per-package table of a monorepo run, with
+// subtotals for each package, the total, and the overall verdict.
+func FormatRollup(w io.Writer, packages []PackageResult, colorize bool) {
+	greenStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	redStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	render := func(style lipgloss.Style, s string) string {
+		if colorize {
+			return style.Render(s)
+		}
+		return s
+	}
+
+	width := 0
+	for _, p := range packages {
+		width = max(width, len(p.Root))
+	}
+
+	var total JSONSummaryV2
+	linted := 0
+	fmt.Fprintln(w)
+	for _, p := range packages {
+		padding := strings.Repeat(" ", width-len(p.Root))
+		if p.Skipped {
+			fmt.Fprintln(w, render(dimStyle, fmt.Sprintf("- %s%s  unchanged", p.Root, padding)))
+			continue
+		}
+		linted++
+		s := rollupSummary(p.Summaries)
+		total.add(s)
+		line := fmt.Sprintf("%s%s  %s", p.Root, padding, rollupCounts(s))
+		if p.Passed {
+			fmt.Fprintln(w, render(greenStyle, "✓ "+line))
+		} else {
+			fmt.Fprintln(w, render(redStyle, "✗ "+line))
+		}
+	}
+
+	verdict := render(greenStyle, "PASS")
+	if !RollupPassed(packages) {
+		verdict = render(redStyle, "FAIL")
+	}
+	fmt.Fprintf(w, "\n%s  %d/%d %s linted  %s\n", verdict, linted, len(packages), pluralizeCount("package", len(packages)), rollupCounts(total))
+}
+
+// rollupCounts formats a summary's file and finding counts.
+func rollupCounts(s JSONSummaryV2) string {
+	return fmt.Sprintf("%d %s  %d %s  %d %s  %d %s",
+		s.Files, pluralizeCount("file", s.Files),
+		s.Errors, pluralizeCount("error", s.Errors),
+		s.Warnings, pluralizeCount("warning", s.Warnings),
+		s.Suggestions, pluralizeCount("suggestion", s.Suggestions))
+}
+
+// JSONRollupV2 is the JSON report of a monorepo run: each package's
+// results and subtotals, the total, and the overall verdict.
+type JSONRollupV2 struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Run           JSONRunV2       `json:"run"`
+	Passed        bool            `json:"passed"`
+	Summary       JSONSummaryV2   `json:"summary"`
+	Packages      []JSONPackageV2 `json:"packages"`
+}
+
+// JSONPackageV2 is one package of a monorepo report. Result files are
+// relative to the package root.
+type JSONPackageV2 struct {
+	Root    string         `json:"root"`
+	Skipped bool           `json:"skipped,omitempty"`
+	Passed  bool           `json:"passed"`
+	Summary JSONSummaryV2  `json:"summary"`
+	Results []JSONResultV2 `json:"results"`
+}
+
+// FormatRollup renders the monorepo report of packages, in the version 2
+// layout.
+func (f *JSONFormatter) FormatRollup(packages []PackageResult, startTime time.Time) error {
+	now := f.now()
+	report := JSONRollupV2{
+		SchemaVersion: 2,
+		Run:           f.runV2(now, startTime, ""),
+		Passed:        RollupPassed(packages),
+		Packages:      make([]JSONPackageV2, len(packages)),
+	}
+	for i, p := range packages {
+		pkg := JSONPackageV2{Root: p.Root, Skipped: p.Skipped, Passed: p.Passed || p.Skipped, Results: []JSONResultV2{}}
+		if !p.Skipped {
+			pkg.Summary = rollupSummary(p.Summaries)
+			fixes := newFixSource(p.Dir)
+			for _, s := range p.Summaries {
+				pkg.Results = append(pkg.Results, convertResultsV2(f.show.results(s.Results), fixes, f.docsURLs)...)
+			}
+			report.Summary.add(pkg.Summary)
+		}
+		report.Packages[i] = pkg
+	}
+	return f.writeJSON(report)
+}
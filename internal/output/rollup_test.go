@@ -0,0 +1,68 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func rollupPackages() []PackageResult {
+	return []PackageResult{
+		{Root: "packages/api", Passed: false, Summaries: []*lint.LintSummary{{
+			TotalFiles: 2, SuccessfulFiles: 1, FailedFiles: 1, TotalErrors: 1, TotalWarnings: 2,
+			Results: []lint.LintResult{{File: "agents/a.md", Type: "agent", Errors: []cue.ValidationError{{Message: "bad", Severity: cue.SeverityError}}}},
+		}}},
+		{Root: "packages/web", Passed: true, Summaries: []*lint.LintSummary{
+			{TotalFiles: 1, SuccessfulFiles: 1, TotalSuggestions: 1},
+			{TotalFiles: 1, SuccessfulFiles: 1},
+		}},
+		{Root: "tools", Skipped: true},
+	}
+}
+
+func TestFormatRollup(t *testing.T) {
+	var buf bytes.Buffer
+	FormatRollup(&buf, rollupPackages(), false)
+	out := buf.String()
+	for _, want := range []string{
+		"✗ packages/api  2 files  1 error  2 warnings  0 suggestions",
+		"✓ packages/web  2 files  0 errors  0 warnings  1 suggestion",
+		"- tools         unchanged",
+		"FAIL  2/3 packages linted  4 files  1 error  2 warnings  1 suggestion",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rollup missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestJSONFormatter_FormatRollup(t *testing.T) {
+	fixed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	out := captureStdout(t, func() {
+		f := NewJSONFormatterWithVersion(false, false, "", "1.2.3").WithClock(func() time.Time { return fixed })
+		if err := f.FormatRollup(rollupPackages(), fixed); err != nil {
+			t.Fatalf("FormatRollup() error = %v", err)
+		}
+	})
+	var report JSONRollupV2
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if report.Passed {
+		t.Error("passed = true with a failing package")
+	}
+	if report.Summary.Files != 4 || report.Summary.Errors != 1 || report.Summary.Suggestions != 1 {
+		t.Errorf("summary = %+v, want 4 files, 1 error, 1 suggestion", report.Summary)
+	}
+	if len(report.Packages) != 3 || len(report.Packages[0].Results) != 1 || report.Packages[0].Results[0].File != "agents/a.md" {
+		t.Errorf("packages = %+v", report.Packages)
+	}
+	if p := report.Packages[2]; !p.Skipped || !p.Passed || p.Results == nil {
+		t.Errorf("skipped package = %+v, want skipped, passed, empty results", p)
+	}
+}
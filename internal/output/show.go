@@ -0,0 +1,82 @@
+package output
+
+import (
+	"slices"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// ShowFilter selects which findings the formatters list, keyed by the
+// show values "errors", "warnings", "suggestions", and "info". A nil filter
+// keeps each formatter's default: console output lists errors and warnings,
+// adding suggestions and info when verbose, and reports list everything.
+// Filtering is display-only; summary totals and exit codes still count
+// every finding.
+type ShowFilter map[string]bool
+
+// NewShowFilter builds a filter from validated show values (see
+// config.ParseShow). No values yields nil.
+func NewShowFilter(show []string) ShowFilter {
+	if len(show) == 0 {
+		return nil
+	}
+	s := make(ShowFilter, len(show))
+	for _, v := range show {
+		s[v] = true
+	}
+	return s
+}
+
+// Shows reports whether is should be listed. withSuggestions is the
+// formatter's default for suggestions and info under a nil filter.
+func (s ShowFilter) Shows(is FlatIssue, withSuggestions bool) bool {
+	kind := showKind(is)
+	if s == nil {
+		return withSuggestions || kind == "errors" || kind == "warnings"
+	}
+	return s[kind]
+}
+
+// filter returns the issues the filter shows.
+func (s ShowFilter) filter(issues []FlatIssue, withSuggestions bool) []FlatIssue {
+	return slices.DeleteFunc(slices.Clone(issues), func(is FlatIssue) bool {
+		return !s.Shows(is, withSuggestions)
+	})
+}
+
+// results returns copies of results holding only the findings the filter
+// shows, for formatters that render LintResult directly.
+func (s ShowFilter) results(results []lint.LintResult) []lint.LintResult {
+	if s == nil {
+		return results
+	}
+	keep := func(sev Severity, errs []cue.ValidationError) []cue.ValidationError {
+		return slices.DeleteFunc(slices.Clone(errs), func(e cue.ValidationError) bool {
+			return !s.Shows(FlatIssue{Severity: sev, Err: e}, true)
+		})
+	}
+	out := make([]lint.LintResult, len(results))
+	for i, r := range results {
+		r.Errors = keep(SeverityError, r.Errors)
+		r.Warnings = keep(SeverityWarning, r.Warnings)
+		r.Suggestions = keep(SeveritySuggestion, r.Suggestions)
+		out[i] = r
+	}
+	return out
+}
+
+// showKind maps an issue to its show value. Info findings are stored with
+// suggestions and told apart by their own severity.
+func showKind(is FlatIssue) string {
+	switch is.Severity {
+	case SeverityError:
+		return "errors"
+	case SeverityWarning:
+		return "warnings"
+	}
+	if is.Err.Severity == cue.SeverityInfo {
+		return "info"
+	}
+	return "suggestions"
+}
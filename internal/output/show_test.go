@@ -0,0 +1,58 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func TestShowFilter_Shows(t *testing.T) {
+	errIssue := FlatIssue{Severity: SeverityError, Err: cue.ValidationError{Severity: cue.SeverityError}}
+	warnIssue := FlatIssue{Severity: SeverityWarning, Err: cue.ValidationError{Severity: cue.SeverityWarning}}
+	suggIssue := FlatIssue{Severity: SeveritySuggestion, Err: cue.ValidationError{Severity: cue.SeveritySuggestion}}
+	infoIssue := FlatIssue{Severity: SeveritySuggestion, Err: cue.ValidationError{Severity: cue.SeverityInfo}}
+
+	tests := []struct {
+		name            string
+		show            []string
+		withSuggestions bool
+		want            [4]bool // error, warning, suggestion, info
+	}{
+		{name: "default", want: [4]bool{true, true, false, false}},
+		{name: "default with suggestions", withSuggestions: true, want: [4]bool{true, true, true, true}},
+		{name: "errors only", show: []string{"errors"}, withSuggestions: true, want: [4]bool{true, false, false, false}},
+		{name: "info without suggestions", show: []string{"info"}, want: [4]bool{false, false, false, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewShowFilter(tt.show)
+			for i, is := range []FlatIssue{errIssue, warnIssue, suggIssue, infoIssue} {
+				if got := f.Shows(is, tt.withSuggestions); got != tt.want[i] {
+					t.Errorf("Shows(%s) = %v, want %v", showKind(is), got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestShowFilter_Results(t *testing.T) {
+	results := []lint.LintResult{{
+		File:        "a.md",
+		Errors:      []cue.ValidationError{{Message: "e", Severity: cue.SeverityError}},
+		Warnings:    []cue.ValidationError{{Message: "w", Severity: cue.SeverityWarning}},
+		Suggestions: []cue.ValidationError{{Message: "s", Severity: cue.SeveritySuggestion}, {Message: "i", Severity: cue.SeverityInfo}},
+	}}
+
+	got := NewShowFilter([]string{"warnings", "info"}).results(results)
+	if len(got[0].Errors) != 0 || len(got[0].Warnings) != 1 || len(got[0].Suggestions) != 1 || got[0].Suggestions[0].Message != "i" {
+		t.Errorf("results() = %+v", got[0])
+	}
+	if len(results[0].Errors) != 1 || len(results[0].Suggestions) != 2 {
+		t.Error("results() modified its input")
+	}
+	if got := ShowFilter(nil).results(results); &got[0] != &results[0] {
+		t.Error("nil filter should return results unchanged")
+	}
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dotcommander/cclint/internal/cue"
+)
+
+// snippetContext is how many lines are shown before and after the
+// offending line.
+const snippetContext = 2
+
+// sourceCache loads the linted files on demand so the console formatters
+// can quote them. Files that cannot be read are remembered as missing.
+type sourceCache struct {
+	root  string
+	read  func(path string) ([]byte, error)
+	files map[string][]string
+}
+
+func newSourceCache(root string) *sourceCache {
+	return &sourceCache{root: root, read: os.ReadFile, files: make(map[string][]string)}
+}
+
+// lines returns file's lines. Relative paths are tried against the root
+// first, then as given (explicit file arguments are relative to the
+// working directory).
+func (c *sourceCache) lines(file string) ([]string, bool) {
+	if lines, ok := c.files[file]; ok {
+		return lines, lines != nil
+	}
+	var data []byte
+	err := os.ErrNotExist
+	if !filepath.IsAbs(file) && c.root != "" {
+		data, err = c.read(filepath.Join(c.root, file))
+	}
+	if err != nil {
+		data, err = c.read(file)
+	}
+	if err != nil {
+		c.files[file] = nil
+		return nil, false
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	c.files[file] = lines
+	return lines, true
+}
+
+// printSnippet prints the excerpt for err beneath its finding line. A nil
+// cache (snippets disabled) or an unreadable file prints nothing.
+func printSnippet(sources *sourceCache, err cue.ValidationError, caretStyle lipgloss.Style, colorize bool) {
+	if sources == nil || err.Line < 1 {
+		return
+	}
+	lines, ok := sources.lines(err.File)
+	if !ok {
+		return
+	}
+	gutterStyle := lipgloss.NewStyle()
+	if colorize {
+		gutterStyle = gutterStyle.Foreground(lipgloss.Color("8")) // dim
+	} else {
+		caretStyle = lipgloss.NewStyle()
+	}
+	for _, line := range snippetLines(lines, err, caretStyle, gutterStyle) {
+		fmt.Println(line)
+	}
+}
+
+// snippetLines renders the excerpt for err: context lines, the offending
+// line, and a caret line under the offending column. Returns nil when the
+// finding has no line or the line is out of range.
+func snippetLines(lines []string, err cue.ValidationError, caretStyle, gutterStyle lipgloss.Style) []string {
+	if err.Line < 1 || err.Line > len(lines) {
+		return nil
+	}
+	first := max(1, err.Line-snippetContext)
+	last := min(len(lines), err.Line+snippetContext)
+	// A trailing newline leaves an empty final element; don't show it.
+	if last == len(lines) && last > err.Line && lines[last-1] == "" {
+		last--
+	}
+	width := len(fmt.Sprint(last))
+
+	var out []string
+	for n := first; n <= last; n++ {
+		text := lines[n-1]
+		out = append(out, fmt.Sprintf("      %s %s", gutterStyle.Render(fmt.Sprintf("%*d |", width, n)), text))
+		if n != err.Line {
+			continue
+		}
+		col, span := locateColumn(text, err)
+		caret := strings.Repeat("^", span)
+		out = append(out, fmt.Sprintf("      %s %s%s", gutterStyle.Render(strings.Repeat(" ", width)+" |"),
+			caretIndent(text[:col-1]), caretStyle.Render(caret)))
+	}
+	return out
+}
+
+// locateColumn maps a finding to a 1-based byte column and a caret width
+// in line. A reported column wins; otherwise the first quoted token in the
+// message ("Read", 'name') is looked up in the line, falling back to the
+// line's text after indentation.
+func locateColumn(line string, err cue.ValidationError) (col, span int) {
+	if err.Column > 0 && err.Column <= len(line)+1 {
+		return err.Column, 1
+	}
+	if token := quotedToken(err.Message); token != "" {
+		if i := strings.Index(line, token); i >= 0 {
+			return i + 1, utf8.RuneCountInString(token)
+		}
+	}
+	trimmed := strings.TrimLeft(line, " \t")
+	start := len(line) - len(trimmed)
+	return start + 1, max(1, utf8.RuneCountInString(strings.TrimRight(trimmed, " \t")))
+}
+
+// quotedToken returns the first "double" or 'single' quoted text in msg.
+func quotedToken(msg string) string {
+	for _, q := range []string{`"`, `'`} {
+		_, rest, ok := strings.Cut(msg, q)
+		if !ok {
+			continue
+		}
+		if token, _, ok := strings.Cut(rest, q); ok && token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// caretIndent turns the text before the caret into matching whitespace,
+// keeping tabs so the caret lines up in the terminal.
+func caretIndent(prefix string) string {
+	var b strings.Builder
+	for _, r := range prefix {
+		if r == '\t' {
+			b.WriteRune('\t')
+			continue
+		}
+		b.WriteString(strings.Repeat(" ", lipgloss.Width(string(r))))
+	}
+	return b.String()
+}
@@ -0,0 +1,164 @@
+package output
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func TestLocateColumn(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		err      cue.ValidationError
+		wantCol  int
+		wantSpan int
+	}{
+		{"reported column", "model: foo", cue.ValidationError{Column: 8, Message: `Unknown model "bar"`}, 8, 1},
+		{"quoted token", "tools: Read, Bash", cue.ValidationError{Message: `Unknown tool "Bash"`}, 14, 4},
+		{"single quotes", "  name: x", cue.ValidationError{Message: "Field 'name' is reserved"}, 3, 4},
+		{"token not in line falls back to text", "  model: foo  ", cue.ValidationError{Message: "Required field 'description' is missing"}, 3, 10},
+		{"column past end ignored", "ab", cue.ValidationError{Column: 9}, 1, 2},
+		{"empty line", "", cue.ValidationError{}, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col, span := locateColumn(tt.line, tt.err)
+			if col != tt.wantCol || span != tt.wantSpan {
+				t.Errorf("locateColumn() = (%d, %d), want (%d, %d)", col, span, tt.wantCol, tt.wantSpan)
+			}
+		})
+	}
+}
+
+func TestSnippetLines(t *testing.T) {
+	lines := strings.Split("---\nname: a\ndescription: b\ntools: Read, Read\nmodel: x\n---\n", "\n")
+	plain := lipgloss.NewStyle()
+
+	got := snippetLines(lines, cue.ValidationError{Line: 4, Message: `Duplicate tool "Read"`}, plain, plain)
+	want := []string{
+		"      2 | name: a",
+		"      3 | description: b",
+		"      4 | tools: Read, Read",
+		"        |        ^^^^",
+		"      5 | model: x",
+		"      6 | ---",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("snippetLines() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Context is clamped at the edges of the file, and the empty element
+	// after the trailing newline is not shown.
+	edges := []struct {
+		line        int
+		first, last string
+		count       int
+	}{
+		{line: 1, first: "      1 |", last: "      3 |", count: 4},
+		{line: 2, first: "      1 |", last: "      4 |", count: 5},
+		{line: 5, first: "      3 |", last: "      6 |", count: 5},
+		{line: 6, first: "      4 |", last: "        |", count: 4},
+	}
+	for _, e := range edges {
+		got := snippetLines(lines, cue.ValidationError{Line: e.line, Column: 1}, plain, plain)
+		if len(got) != e.count || !strings.HasPrefix(got[0], e.first) || !strings.HasPrefix(got[len(got)-1], e.last) {
+			t.Errorf("snippetLines() at line %d = %q", e.line, got)
+		}
+	}
+
+	if got := snippetLines(lines, cue.ValidationError{Line: 0}, plain, plain); got != nil {
+		t.Errorf("snippetLines() without a line = %q, want nil", got)
+	}
+	if got := snippetLines(lines, cue.ValidationError{Line: 99}, plain, plain); got != nil {
+		t.Errorf("snippetLines() out of range = %q, want nil", got)
+	}
+}
+
+func TestSnippetLines_TabIndent(t *testing.T) {
+	plain := lipgloss.NewStyle()
+	got := snippetLines([]string{"\tkey: \"x\""}, cue.ValidationError{Line: 1, Message: `bad "x"`}, plain, plain)
+	if len(got) != 2 || !strings.HasSuffix(got[1], "| \t      ^") {
+		t.Errorf("caret line = %q, want tab preserved before caret", got)
+	}
+}
+
+func TestSourceCache(t *testing.T) {
+	reads := 0
+	c := newSourceCache("/root")
+	c.read = func(path string) ([]byte, error) {
+		reads++
+		if path == filepath.Join("/root", "a.md") {
+			return []byte("one\r\ntwo"), nil
+		}
+		return nil, errors.New("missing")
+	}
+
+	lines, ok := c.lines("a.md")
+	if !ok || len(lines) != 2 || lines[1] != "two" {
+		t.Fatalf("lines(a.md) = %q, %v", lines, ok)
+	}
+	if _, ok := c.lines("a.md"); !ok || reads != 1 {
+		t.Errorf("second lookup read the file again (reads = %d)", reads)
+	}
+	if _, ok := c.lines("b.md"); ok {
+		t.Error("lines(b.md) ok = true for a missing file")
+	}
+	if _, ok := c.lines("b.md"); ok || reads != 3 {
+		t.Errorf("missing file re-read (reads = %d, want 3)", reads)
+	}
+}
+
+func TestFormatters_Snippets(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join("agents", "a.md")
+	if err := os.MkdirAll(filepath.Join(root, "agents"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, file), []byte("---\nname: a\nmodel: foo\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	summary := &lint.LintSummary{
+		ComponentType: "agent",
+		TotalFiles:    1,
+		FailedFiles:   1,
+		TotalErrors:   1,
+		StartTime:     time.Now(),
+		Results: []lint.LintResult{{
+			File:   file,
+			Type:   "agent",
+			Errors: []cue.ValidationError{{File: file, Line: 3, Message: `Unknown model "foo"`}},
+		}},
+	}
+	want := "      3 | model: foo\n        |        ^^^\n"
+
+	opts := ConsoleOptions{GroupBy: GroupByFile, Color: ColorNever, Root: root, Snippets: true}
+	console := captureStdout(t, func() {
+		_ = NewConsoleFormatter(false, false, false, false).WithOptions(opts).Format(summary)
+	})
+	if !strings.Contains(console, want) {
+		t.Errorf("console output missing snippet:\n%s", console)
+	}
+
+	compact := captureStdout(t, func() {
+		_ = NewCompactFormatter(false, false, false, false, time.Now()).WithOptions(opts).FormatAll([]*lint.LintSummary{summary})
+	})
+	if !strings.Contains(compact, want) {
+		t.Errorf("compact output missing snippet:\n%s", compact)
+	}
+
+	opts.Snippets = false
+	plain := captureStdout(t, func() {
+		_ = NewConsoleFormatter(false, false, false, false).WithOptions(opts).Format(summary)
+	})
+	if strings.Contains(plain, "model: foo") {
+		t.Errorf("snippets disabled but printed:\n%s", plain)
+	}
+}
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// maxSuppressedRules caps how many rules the console summary names.
+const maxSuppressedRules = 5
+
+// SuppressedSummary renders the console summary of suppressed issues: the
+// total with a count per suppression source, then the rules suppressed most
+// often. Returns "" when nothing was suppressed.
+func SuppressedSummary(issues []lint.SuppressedIssue) string {
+	if len(issues) == 0 {
+		return ""
+	}
+	report := lint.SummarizeSuppressed(issues)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s suppressed in %d %s (%s)\n",
+		report.Total, pluralizeCount("issue", report.Total),
+		len(report.ByFile), pluralizeCount("file", len(report.ByFile)),
+		formatCounts(report.BySource, 0))
+	fmt.Fprintf(&b, "  by rule: %s\n", formatCounts(report.ByRule, maxSuppressedRules))
+	return b.String()
+}
+
+// formatCounts renders "key n, key n" ordered by count, highest first, then
+// by key. A positive limit keeps that many entries and notes the rest.
+func formatCounts(counts map[string]int, limit int) string {
+	keys := slices.SortedFunc(maps.Keys(counts), func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+	more := 0
+	if limit > 0 && len(keys) > limit {
+		keys, more = keys[:limit], len(keys)-limit
+	}
+	parts := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s %d", k, counts[k]))
+	}
+	if more > 0 {
+		parts = append(parts, fmt.Sprintf("+%d more", more))
+	}
+	return strings.Join(parts, ", ")
+}
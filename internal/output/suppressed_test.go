@@ -0,0 +1,70 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func suppressedFixture() []lint.SuppressedIssue {
+	var issues []lint.SuppressedIssue
+	for rule, n := range map[string]int{"rule-a": 4, "rule-b": 3, "rule-c": 2, "rule-d": 2, "rule-e": 1, "rule-f": 1} {
+		for range n {
+			issues = append(issues, lint.SuppressedIssue{File: "agents/" + rule + ".md", Rule: rule, Source: lint.SuppressionBaseline})
+		}
+	}
+	return issues
+}
+
+func TestSuppressedSummary(t *testing.T) {
+	if got := SuppressedSummary(nil); got != "" {
+		t.Errorf("SuppressedSummary(nil) = %q, want empty", got)
+	}
+
+	got := SuppressedSummary(suppressedFixture())
+	want := "13 issues suppressed in 6 files (baseline 13)\n" +
+		"  by rule: rule-a 4, rule-b 3, rule-c 2, rule-d 2, rule-e 1, +1 more\n"
+	if got != want {
+		t.Errorf("SuppressedSummary() =\n%s\nwant\n%s", got, want)
+	}
+
+	single := SuppressedSummary([]lint.SuppressedIssue{{File: "a.md", Source: lint.SuppressionBaseline}})
+	if !strings.HasPrefix(single, "1 issue suppressed in 1 file (baseline 1)") || !strings.Contains(single, "unclassified 1") {
+		t.Errorf("SuppressedSummary(single) = %q", single)
+	}
+}
+
+func TestConvertSuppressed(t *testing.T) {
+	if got := convertSuppressed(nil); got != nil {
+		t.Errorf("convertSuppressed(nil) = %+v, want nil", got)
+	}
+	got := convertSuppressed(suppressedFixture())
+	if got.Total != 13 || got.ByRule["rule-a"] != 4 || got.ByFile["agents/rule-b.md"] != 3 || got.BySource[lint.SuppressionBaseline] != 13 {
+		t.Errorf("convertSuppressed() = %+v", got)
+	}
+}
+
+func TestMarkdownFormatter_WriteSuppressed(t *testing.T) {
+	f := NewMarkdownFormatter(false, false, "")
+
+	var empty strings.Builder
+	f.writeSuppressed(&empty, &lint.LintSummary{})
+	if empty.Len() != 0 {
+		t.Errorf("writeSuppressed() wrote %q for an empty summary", empty.String())
+	}
+
+	var b strings.Builder
+	f.writeSuppressed(&b, &lint.LintSummary{Suppressed: suppressedFixture()})
+	for _, want := range []string{
+		"## Suppressed Issues (13)",
+		"| Source | Count |",
+		"| baseline | 13 |",
+		"| rule-a | 4 |",
+		"| agents/rule-f.md | 1 |",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("writeSuppressed() missing %q:\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// TAPFormatter formats output as TAP version 13: one test point per file,
+// failing when the file has errors, with its findings in a YAML diagnostic
+// block. Suppressed issues are counted in a trailing comment.
+type TAPFormatter struct {
+	outputFile string
+	show       ShowFilter
+}
+
+// NewTAPFormatter creates a new TAPFormatter
+func NewTAPFormatter(outputFile string) *TAPFormatter {
+	return &TAPFormatter{outputFile: outputFile}
+}
+
+// WithShow limits the listed findings to the --show severities. Test point
+// results still reflect every finding.
+func (f *TAPFormatter) WithShow(show ShowFilter) *TAPFormatter {
+	f.show = show
+	return f
+}
+
+// Format formats the lint summary as TAP
+func (f *TAPFormatter) Format(summary *lint.LintSummary) error {
+	var b strings.Builder
+	b.WriteString("TAP version 13\n")
+	fmt.Fprintf(&b, "1..%d\n", len(summary.Results))
+
+	issues := f.show.filter(BuildFlatIssues(summary), true)
+	for i, r := range summary.Results {
+		status := "ok"
+		if !r.Success {
+			status = "not ok"
+		}
+		fmt.Fprintf(&b, "%s %d - %s", status, i+1, tapDescription(r.File))
+		if r.Disabled {
+			b.WriteString(" # SKIP disabled")
+		}
+		b.WriteString("\n")
+		writeTAPDiagnostics(&b, issuesForResult(issues, i))
+	}
+	if n := len(summary.Suppressed); n > 0 {
+		fmt.Fprintf(&b, "# %d issues suppressed\n", n)
+	}
+
+	return writeReport(f.outputFile, b.String())
+}
+
+// writeTAPDiagnostics writes issues as an indented YAML block. Omitted when
+// there are none.
+func writeTAPDiagnostics(b *strings.Builder, issues []FlatIssue) {
+	if len(issues) == 0 {
+		return
+	}
+	b.WriteString("  ---\n  issues:\n")
+	for _, is := range issues {
+		fmt.Fprintf(b, "    - severity: %s\n", is.Severity)
+		fmt.Fprintf(b, "      message: %s\n", yamlQuote(is.Err.Message))
+		if is.Err.Rule != "" {
+			fmt.Fprintf(b, "      rule: %s\n", is.Err.Rule)
+		}
+		if is.Err.Line > 0 {
+			fmt.Fprintf(b, "      line: %d\n", is.Err.Line)
+		}
+	}
+	b.WriteString("  ...\n")
+}
+
+// tapDescription keeps a file path from being read as a TAP directive.
+func tapDescription(s string) string {
+	return strings.ReplaceAll(s, "#", `\#`)
+}
+
+// yamlQuote renders s as a double-quoted YAML scalar.
+func yamlQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func TestTAPFormatter_Format(t *testing.T) {
+	summary := &lint.LintSummary{
+		Results: []lint.LintResult{
+			{File: "agents/clean.md", Success: true},
+			{
+				File: "agents/bad.md",
+				Errors: []cue.ValidationError{
+					{Message: `missing "name"`, Severity: cue.SeverityError, Rule: "agent-name", Line: 2},
+				},
+				Suggestions: []cue.ValidationError{{Message: "add an example", Severity: cue.SeveritySuggestion}},
+			},
+			{File: "agents/off#1.md", Success: true, Disabled: true},
+		},
+		Suppressed: []lint.SuppressedIssue{{File: "agents/clean.md"}},
+	}
+
+	tests := []struct {
+		name string
+		show ShowFilter
+		want string
+	}{
+		{
+			name: "all findings",
+			want: "TAP version 13\n1..3\n" +
+				"ok 1 - agents/clean.md\n" +
+				"not ok 2 - agents/bad.md\n" +
+				"  ---\n  issues:\n" +
+				"    - severity: error\n      message: \"missing \\\"name\\\"\"\n      rule: agent-name\n      line: 2\n" +
+				"    - severity: suggestion\n      message: \"add an example\"\n" +
+				"  ...\n" +
+				"ok 3 - agents/off\\#1.md # SKIP disabled\n" +
+				"# 1 issues suppressed\n",
+		},
+		{
+			name: "show filter keeps the test point result",
+			show: NewShowFilter([]string{"suggestions"}),
+			want: "TAP version 13\n1..3\n" +
+				"ok 1 - agents/clean.md\n" +
+				"not ok 2 - agents/bad.md\n" +
+				"  ---\n  issues:\n" +
+				"    - severity: suggestion\n      message: \"add an example\"\n" +
+				"  ...\n" +
+				"ok 3 - agents/off\\#1.md # SKIP disabled\n" +
+				"# 1 issues suppressed\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := captureStdout(t, func() {
+				if err := NewTAPFormatter("").WithShow(tt.show).Format(summary); err != nil {
+					t.Fatal(err)
+				}
+			})
+			if got != tt.want {
+				t.Errorf("Format() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
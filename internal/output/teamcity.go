@@ -0,0 +1,74 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// teamCityDefaultType is the inspection type of findings without a rule ID.
+const teamCityDefaultType = "cclint"
+
+// TeamCityFormatter formats output as TeamCity service messages, reporting
+// each finding as a code inspection so it shows inline in the build.
+type TeamCityFormatter struct {
+	outputFile string
+	show       ShowFilter
+}
+
+// NewTeamCityFormatter creates a new TeamCityFormatter
+func NewTeamCityFormatter(outputFile string) *TeamCityFormatter {
+	return &TeamCityFormatter{outputFile: outputFile}
+}
+
+// WithShow limits the reported findings to the --show severities.
+func (f *TeamCityFormatter) WithShow(show ShowFilter) *TeamCityFormatter {
+	f.show = show
+	return f
+}
+
+// Format formats the lint summary as TeamCity service messages. Each
+// inspection type is declared once, before its first inspection. The
+// suppressed issue count is reported as a build statistic.
+func (f *TeamCityFormatter) Format(summary *lint.LintSummary) error {
+	var b strings.Builder
+	var declared []string
+	for _, is := range f.show.filter(BuildFlatIssues(summary), true) {
+		typeID := cmp.Or(is.Err.Rule, teamCityDefaultType)
+		if !slices.Contains(declared, typeID) {
+			declared = append(declared, typeID)
+			fmt.Fprintf(&b, "##teamcity[inspectionType id='%s' name='%s' category='cclint' description='%s']\n",
+				teamCityEscape(typeID), teamCityEscape(typeID), teamCityEscape(cmp.Or(is.Err.Source, "cclint")))
+		}
+		fmt.Fprintf(&b, "##teamcity[inspection typeId='%s' message='%s' file='%s' line='%d' SEVERITY='%s']\n",
+			teamCityEscape(typeID), teamCityEscape(is.Err.Message), teamCityEscape(is.File),
+			max(is.Err.Line, 1), teamCitySeverity(is))
+	}
+	if n := len(summary.Suppressed); n > 0 {
+		fmt.Fprintf(&b, "##teamcity[buildStatisticValue key='cclintSuppressedIssues' value='%d']\n", n)
+	}
+	return writeReport(f.outputFile, b.String())
+}
+
+// teamCitySeverity maps a finding to a TeamCity inspection severity.
+func teamCitySeverity(is FlatIssue) string {
+	switch {
+	case is.Severity == SeverityError:
+		return "ERROR"
+	case is.Severity == SeverityWarning:
+		return "WARNING"
+	case is.Err.Severity == cue.SeverityInfo:
+		return "INFO"
+	}
+	return "WEAK WARNING"
+}
+
+// teamCityEscape escapes a service message attribute value.
+func teamCityEscape(s string) string {
+	r := strings.NewReplacer("|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]")
+	return r.Replace(s)
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func TestTeamCityFormatter_Format(t *testing.T) {
+	summary := &lint.LintSummary{
+		Results: []lint.LintResult{
+			{
+				File: "agents/a.md",
+				Errors: []cue.ValidationError{
+					{Message: "bad [x]\nit's wrong", Severity: cue.SeverityError, Rule: "agent-name", Line: 4},
+				},
+				Warnings: []cue.ValidationError{{Message: "w", Severity: cue.SeverityWarning, Source: cue.SourceAnthropicDocs}},
+			},
+			{
+				File:        "skills/s/SKILL.md",
+				Warnings:    []cue.ValidationError{{Message: "again", Severity: cue.SeverityWarning, Rule: "agent-name", Line: 1}},
+				Suggestions: []cue.ValidationError{{Message: "fyi", Severity: cue.SeverityInfo}},
+			},
+		},
+		Suppressed: []lint.SuppressedIssue{{File: "agents/a.md"}, {File: "agents/a.md"}},
+	}
+
+	out := filepath.Join(t.TempDir(), "teamcity.txt")
+	if err := NewTeamCityFormatter(out).Format(summary); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "##teamcity[inspectionType id='agent-name' name='agent-name' category='cclint' description='cclint']\n" +
+		"##teamcity[inspection typeId='agent-name' message='bad |[x|]|nit|'s wrong' file='agents/a.md' line='4' SEVERITY='ERROR']\n" +
+		"##teamcity[inspectionType id='cclint' name='cclint' category='cclint' description='" + cue.SourceAnthropicDocs + "']\n" +
+		"##teamcity[inspection typeId='cclint' message='w' file='agents/a.md' line='1' SEVERITY='WARNING']\n" +
+		"##teamcity[inspection typeId='agent-name' message='again' file='skills/s/SKILL.md' line='1' SEVERITY='WARNING']\n" +
+		"##teamcity[inspection typeId='cclint' message='fyi' file='skills/s/SKILL.md' line='1' SEVERITY='INFO']\n" +
+		"##teamcity[buildStatisticValue key='cclintSuppressedIssues' value='2']\n"
+	if got := string(data); got != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTeamCitySeverity(t *testing.T) {
+	tests := []struct {
+		is   FlatIssue
+		want string
+	}{
+		{FlatIssue{Severity: SeverityError}, "ERROR"},
+		{FlatIssue{Severity: SeverityWarning}, "WARNING"},
+		{FlatIssue{Severity: SeveritySuggestion, Err: cue.ValidationError{Severity: cue.SeveritySuggestion}}, "WEAK WARNING"},
+		{FlatIssue{Severity: SeveritySuggestion, Err: cue.ValidationError{Severity: cue.SeverityInfo}}, "INFO"},
+	}
+	for _, tt := range tests {
+		if got := teamCitySeverity(tt.is); got != tt.want {
+			t.Errorf("teamCitySeverity(%+v) = %q, want %q", tt.is, got, tt.want)
+		}
+	}
+}
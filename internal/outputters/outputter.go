@@ -42,15 +42,72 @@ func NewDefaultFormatterFactory(cfg *config.Config) *DefaultFormatterFactory {
 	return &DefaultFormatterFactory{cfg: cfg}
 }
 
+// The console formatters implement Formatter alongside the report
+// formatters.
+var (
+	_ Formatter = (*output.ConsoleFormatter)(nil)
+	_ Formatter = (*output.CompactFormatter)(nil)
+	_ Formatter = (*output.JSONFormatter)(nil)
+	_ Formatter = (*output.JSONLFormatter)(nil)
+	_ Formatter = (*output.MarkdownFormatter)(nil)
+	_ Formatter = (*output.TAPFormatter)(nil)
+	_ Formatter = (*output.TeamCityFormatter)(nil)
+	_ Formatter = (*output.ProblemMatcherFormatter)(nil)
+)
+
 // CreateFormatter implements FormatterFactory interface.
 func (f *DefaultFormatterFactory) CreateFormatter(format string) (Formatter, error) {
 	switch format {
 	case "console":
-		return output.NewConsoleFormatter(f.cfg.Quiet, f.cfg.Verbose, f.cfg.ShowScores, f.cfg.ShowImprovements), nil
-	case "json":
-		return output.NewJSONFormatterWithVersion(f.cfg.Quiet, true, f.cfg.Output, f.cfg.Version), nil
+		opts, err := consoleOptions(f.cfg)
+		if err != nil {
+			return nil, err
+		}
+		return output.NewConsoleFormatter(f.cfg.Quiet(), f.cfg.Verbose(), f.cfg.ShowScores, f.cfg.ShowImprovements).WithOptions(opts), nil
+	case "json", "json@1", "json@2":
+		show, err := showFilter(f.cfg)
+		if err != nil {
+			return nil, err
+		}
+		formatter := output.NewJSONFormatterWithVersion(f.cfg.Quiet(), true, f.cfg.Output, f.cfg.Version).
+			WithShow(show).
+			WithBuild(f.cfg.Commit, f.cfg.BuildDate).
+			WithConfigHash(f.cfg.Hash()).
+			WithDocsURLTemplate(f.cfg.DocsURLTemplate)
+		if format == "json@1" {
+			formatter.WithSchemaVersion(1)
+		}
+		return formatter, nil
+	case "jsonl":
+		show, err := showFilter(f.cfg)
+		if err != nil {
+			return nil, err
+		}
+		return output.NewJSONLFormatter(f.cfg.Output).WithShow(show).WithDocsURLTemplate(f.cfg.DocsURLTemplate), nil
 	case "markdown":
-		return output.NewMarkdownFormatter(f.cfg.Quiet, f.cfg.Verbose, f.cfg.Output), nil
+		show, err := showFilter(f.cfg)
+		if err != nil {
+			return nil, err
+		}
+		return output.NewMarkdownFormatter(f.cfg.Quiet(), f.cfg.Verbose(), f.cfg.Output).WithShow(show), nil
+	case "tap":
+		show, err := showFilter(f.cfg)
+		if err != nil {
+			return nil, err
+		}
+		return output.NewTAPFormatter(f.cfg.Output).WithShow(show), nil
+	case "teamcity":
+		show, err := showFilter(f.cfg)
+		if err != nil {
+			return nil, err
+		}
+		return output.NewTeamCityFormatter(f.cfg.Output).WithShow(show), nil
+	case "compact":
+		show, err := showFilter(f.cfg)
+		if err != nil {
+			return nil, err
+		}
+		return output.NewProblemMatcherFormatter(f.cfg.Output).WithShow(show), nil
 	default:
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
@@ -103,14 +160,59 @@ func (o *Outputter) Format(summary *lint.LintSummary, format string) error {
 	return formatter.Format(summary)
 }
 
-// FormatAll formats multiple lint summaries using the compact formatter.
-// This is used for the full scan mode where multiple component types are linted.
-func (o *Outputter) FormatAll(summaries []*lint.LintSummary, startTime time.Time) error {
-	if o.config.Quiet {
+// FormatAll formats the summaries of a full scan, where multiple component
+// types are linted, in the given format. The console format lists each
+// component type in turn with the compact formatter; every other format
+// reports the run as one summary merged with lint.MergeSummaries.
+func (o *Outputter) FormatAll(summaries []*lint.LintSummary, startTime time.Time, format string) error {
+	if format != "console" {
+		return o.Format(lint.MergeSummaries(summaries, startTime), format)
+	}
+	if o.config.Quiet() {
 		return nil
 	}
 
+	opts, err := consoleOptions(o.config)
+	if err != nil {
+		return err
+	}
+
 	// Use compact formatter for multi-summary output
-	formatter := output.NewCompactFormatter(o.config.Quiet, o.config.Verbose, o.config.ShowScores, o.config.ShowImprovements, startTime)
+	formatter := output.NewCompactFormatter(o.config.Quiet(), o.config.Verbose(), o.config.ShowScores, o.config.ShowImprovements, startTime).WithOptions(opts)
 	return formatter.FormatAll(summaries)
 }
+
+// consoleOptions builds the console grouping, cap, and color settings from
+// the configuration.
+func consoleOptions(cfg *config.Config) (output.ConsoleOptions, error) {
+	groupBy, err := output.ParseGroupBy(cfg.GroupBy)
+	if err != nil {
+		return output.ConsoleOptions{}, err
+	}
+	color, err := output.ParseColorMode(cfg.Color)
+	if err != nil {
+		return output.ConsoleOptions{}, err
+	}
+	show, err := showFilter(cfg)
+	if err != nil {
+		return output.ConsoleOptions{}, err
+	}
+	return output.ConsoleOptions{
+		GroupBy:          groupBy,
+		MaxIssuesPerFile: cfg.MaxIssuesPerFile,
+		Color:            color,
+		Root:             cfg.Root,
+		Snippets:         cfg.Snippets,
+		Show:             show,
+		DocsURLTemplate:  cfg.DocsURLTemplate,
+	}, nil
+}
+
+// showFilter builds the --show filter from the configuration.
+func showFilter(cfg *config.Config) (output.ShowFilter, error) {
+	show, err := config.ParseShow(cfg.Show)
+	if err != nil {
+		return nil, err
+	}
+	return output.NewShowFilter(show), nil
+}
@@ -1,12 +1,17 @@
 package outputters
 
 import (
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/dotcommander/cclint/internal/lint"
 	"github.com/dotcommander/cclint/internal/config"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
 )
 
 // =============================================================================
@@ -53,8 +58,6 @@ func TestNewOutputter(t *testing.T) {
 	cfg := &config.Config{
 		Root:             "/test/root",
 		Format:           "console",
-		Quiet:            false,
-		Verbose:          false,
 		ShowScores:       false,
 		ShowImprovements: false,
 	}
@@ -335,8 +338,7 @@ func TestNewDefaultFormatterFactory(t *testing.T) {
 
 func TestDefaultFormatterFactory_CreateFormatter_Console(t *testing.T) {
 	cfg := &config.Config{
-		Quiet:            true,
-		Verbose:          false,
+		Verbosity:        config.VerbosityQuiet,
 		ShowScores:       true,
 		ShowImprovements: false,
 	}
@@ -358,7 +360,6 @@ func TestDefaultFormatterFactory_CreateFormatter_Console(t *testing.T) {
 
 func TestDefaultFormatterFactory_CreateFormatter_JSON(t *testing.T) {
 	cfg := &config.Config{
-		Quiet:  false,
 		Output: "/tmp/output.json",
 	}
 
@@ -374,11 +375,46 @@ func TestDefaultFormatterFactory_CreateFormatter_JSON(t *testing.T) {
 	}
 }
 
+func TestDefaultFormatterFactory_CreateFormatter_JSONSchemaVersion(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantKey string // top-level key only that schema version writes
+	}{
+		{format: "json", wantKey: "schemaVersion"},
+		{format: "json@2", wantKey: "schemaVersion"},
+		{format: "json@1", wantKey: "header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "report.json")
+			formatter, err := NewDefaultFormatterFactory(&config.Config{Output: out}).CreateFormatter(tt.format)
+			if err != nil {
+				t.Fatalf("CreateFormatter(%q) error = %v", tt.format, err)
+			}
+			if err := formatter.Format(&lint.LintSummary{StartTime: time.Now()}); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+
+			data, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var report map[string]any
+			if err := json.Unmarshal(data, &report); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := report[tt.wantKey]; !ok {
+				t.Errorf("report for %q lacks %q: %s", tt.format, tt.wantKey, data)
+			}
+		})
+	}
+}
+
 func TestDefaultFormatterFactory_CreateFormatter_Markdown(t *testing.T) {
 	cfg := &config.Config{
-		Quiet:   false,
-		Verbose: true,
-		Output:  "/tmp/output.md",
+		Verbosity: config.VerbosityVerbose,
+		Output:    "/tmp/output.md",
 	}
 
 	factory := NewDefaultFormatterFactory(cfg)
@@ -429,11 +465,41 @@ func TestDefaultFormatterFactory_CreateFormatter_AllFormats(t *testing.T) {
 			format:  "json",
 			wantErr: false,
 		},
+		{
+			name:    "json v1 format",
+			format:  "json@1",
+			wantErr: false,
+		},
+		{
+			name:    "json v2 format",
+			format:  "json@2",
+			wantErr: false,
+		},
+		{
+			name:    "jsonl format",
+			format:  "jsonl",
+			wantErr: false,
+		},
 		{
 			name:    "markdown format",
 			format:  "markdown",
 			wantErr: false,
 		},
+		{
+			name:    "tap format",
+			format:  "tap",
+			wantErr: false,
+		},
+		{
+			name:    "teamcity format",
+			format:  "teamcity",
+			wantErr: false,
+		},
+		{
+			name:    "compact format",
+			format:  "compact",
+			wantErr: false,
+		},
 		{
 			name:    "invalid format",
 			format:  "yaml",
@@ -488,8 +554,7 @@ func TestOutputter_Format_Integration(t *testing.T) {
 			name: "console format success",
 			config: &config.Config{
 				Root:       "/test/root",
-				Quiet:      false,
-				Verbose:    true,
+				Verbosity:  config.VerbosityVerbose,
 				ShowScores: true,
 			},
 			summary: &lint.LintSummary{
@@ -519,9 +584,9 @@ func TestOutputter_Format_Integration(t *testing.T) {
 		{
 			name: "markdown format success",
 			config: &config.Config{
-				Root:    "/test/root",
-				Output:  "/tmp/output.md",
-				Verbose: true,
+				Root:      "/test/root",
+				Output:    "/tmp/output.md",
+				Verbosity: config.VerbosityVerbose,
 			},
 			summary: &lint.LintSummary{
 				ComponentType:   "skills",
@@ -663,3 +728,60 @@ func TestOutputter_MultipleFormats(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputter_FormatAll_MergesForFactory(t *testing.T) {
+	formatter := &mockFormatter{}
+	factory := &mockFormatterFactory{formatter: formatter}
+	outputter := NewOutputterWithFactory(&config.Config{Root: "/test/root"}, factory)
+
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	summaries := []*lint.LintSummary{
+		{ComponentType: "agent", TotalFiles: 1, TotalErrors: 2, Results: []lint.LintResult{{File: "a.md", Type: "agent"}}},
+		{ComponentType: "command", TotalFiles: 2, TotalWarnings: 1, Results: []lint.LintResult{{File: "b.md", Type: "command"}, {File: "c.md", Type: "command"}}},
+	}
+	if err := outputter.FormatAll(summaries, start, "tap"); err != nil {
+		t.Fatalf("FormatAll() error = %v", err)
+	}
+	if factory.requestedFormat != "tap" {
+		t.Errorf("requested format = %q, want tap", factory.requestedFormat)
+	}
+	got := formatter.summary
+	if got == nil {
+		t.Fatal("formatter was not called")
+	}
+	if got.TotalFiles != 3 || got.TotalErrors != 2 || got.TotalWarnings != 1 || len(got.Results) != 3 {
+		t.Errorf("merged summary = %d files, %d errors, %d warnings, %d results; want 3, 2, 1, 3",
+			got.TotalFiles, got.TotalErrors, got.TotalWarnings, len(got.Results))
+	}
+	if !got.StartTime.Equal(start) || got.ProjectRoot != "/test/root" {
+		t.Errorf("merged summary start %v root %q", got.StartTime, got.ProjectRoot)
+	}
+}
+
+func TestOutputter_FormatAll_ReportFormats(t *testing.T) {
+	summaries := []*lint.LintSummary{
+		{ComponentType: "agent", TotalFiles: 1, FailedFiles: 1, TotalErrors: 1, Results: []lint.LintResult{{File: "a.md", Type: "agent", Errors: []cue.ValidationError{{File: "a.md", Message: "broken", Severity: cue.SeverityError}}}}},
+		{ComponentType: "command", TotalFiles: 1, SuccessfulFiles: 1, Results: []lint.LintResult{{File: "b.md", Type: "command", Success: true}}},
+	}
+	for format, want := range map[string]string{
+		"tap":      "1..2",
+		"teamcity": "##teamcity[inspection ",
+		"json":     `"schemaVersion": 2`,
+		"jsonl":    `"type":"summary","files":2`,
+	} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "report")
+			outputter := NewOutputter(&config.Config{Root: "/test/root", Output: path})
+			if err := outputter.FormatAll(summaries, time.Now(), format); err != nil {
+				t.Fatalf("FormatAll(%q) error = %v", format, err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), want) {
+				t.Errorf("FormatAll(%q) wrote\n%s\nwant it to contain %q", format, data, want)
+			}
+		})
+	}
+}
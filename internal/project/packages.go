@@ -0,0 +1,71 @@
+package project
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// packageSkipDirs are directories FindPackages does not descend into.
+var packageSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// FindPackages returns the Claude Code packages of a monorepo: root and the
+// directories beneath it holding a .claude directory or a plugin manifest
+// (.claude-plugin/plugin.json). Hidden directories, node_modules, and
+// vendor are not searched. Paths are absolute and sorted.
+func FindPackages(root string) ([]string, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	var packages []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && (strings.HasPrefix(d.Name(), ".") || packageSkipDirs[d.Name()]) {
+			return filepath.SkipDir
+		}
+		if isPackageRoot(path) {
+			packages = append(packages, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(packages)
+	return packages, nil
+}
+
+// isPackageRoot reports whether dir holds Claude Code components of its own.
+func isPackageRoot(dir string) bool {
+	if info, err := os.Stat(filepath.Join(dir, ".claude")); err == nil && info.IsDir() {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(dir, ".claude-plugin", "plugin.json"))
+	return err == nil
+}
+
+// PackageOf returns the innermost of packages containing path, which must
+// be absolute like the packages.
+func PackageOf(packages []string, path string) (string, bool) {
+	best := ""
+	for _, pkg := range packages {
+		if (path == pkg || strings.HasPrefix(path, pkg+string(filepath.Separator))) && len(pkg) > len(best) {
+			best = pkg
+		}
+	}
+	return best, best != ""
+}
@@ -0,0 +1,63 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFindPackages(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{
+		".claude",
+		"packages/web/.claude",
+		"packages/api/nested/.claude",
+		"plugins/tool/.claude-plugin",
+		"plugins/draft/.claude-plugin",
+		"node_modules/dep/.claude",
+		".hidden/pkg/.claude",
+		"docs",
+	} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "plugins/tool/.claude-plugin/plugin.json"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FindPackages(root)
+	if err != nil {
+		t.Fatalf("FindPackages() error = %v", err)
+	}
+	var rel []string
+	for _, p := range got {
+		r, _ := filepath.Rel(root, p)
+		rel = append(rel, filepath.ToSlash(r))
+	}
+	want := []string{".", "packages/api/nested", "packages/web", "plugins/tool"}
+	if !slices.Equal(rel, want) {
+		t.Errorf("FindPackages() = %v, want %v", rel, want)
+	}
+}
+
+func TestPackageOf(t *testing.T) {
+	packages := []string{"/repo", "/repo/packages/web", "/repo/packages/web-admin"}
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{"/repo/packages/web/.claude/agents/a.md", "/repo/packages/web", true},
+		{"/repo/packages/web-admin/CLAUDE.md", "/repo/packages/web-admin", true},
+		{"/repo/CLAUDE.md", "/repo", true},
+		{"/elsewhere/CLAUDE.md", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := PackageOf(packages, filepath.FromSlash(tt.path))
+		if got != filepath.FromSlash(tt.want) || ok != tt.ok {
+			t.Errorf("PackageOf(%q) = %q, %v; want %q, %v", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -93,6 +93,9 @@ func (s *AgentScorer) scorePractices(bodyContent string) (int, []Metric) {
 	// PROACTIVELY/WHEN in description (5 points)
 	add("WHEN triggers in description", s.hasProactiveTriggers(desc), 5)
 
+	// Readable description (5 points)
+	add("Readable description", isReadableDescription(desc), 5)
+
 	return practices, details
 }
 
@@ -479,3 +479,37 @@ func generateFullContent(frontmatter map[string]any, body string) string {
 	// Simple content generation - just return body since we're passing frontmatter separately
 	return body
 }
+
+func TestAgentScorer_ReadableDescription(t *testing.T) {
+	tests := []struct {
+		name       string
+		desc       string
+		wantPassed bool
+	}{
+		{name: "short active sentences", desc: "Reviews Go code for bugs. Use when a PR is ready.", wantPassed: true},
+		{name: "passive voice", desc: "Code is reviewed. Bugs are reported."},
+		{name: "overlong sentence", desc: "Reviews " + strings.Repeat("code ", 35) + "carefully."},
+		{name: "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{"name": "test", "description": tt.desc}
+			score := NewAgentScorer().Score("", frontmatter, "Content")
+
+			for _, m := range score.Details {
+				if m.Name != "Readable description" {
+					continue
+				}
+				if m.Category != "practices" || m.MaxPoints != 5 {
+					t.Errorf("metric = %+v, want a 5-point practices metric", m)
+				}
+				if m.Passed != tt.wantPassed {
+					t.Errorf("Passed = %v, want %v", m.Passed, tt.wantPassed)
+				}
+				return
+			}
+			t.Fatal("Readable description metric not found")
+		})
+	}
+}
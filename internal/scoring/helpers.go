@@ -2,6 +2,8 @@ package scoring
 
 import (
 	"regexp"
+
+	"github.com/dotcommander/cclint/internal/textutil"
 )
 
 // FieldSpec defines a required field with its point value
@@ -156,6 +158,14 @@ func recordMetric(details *[]Metric, category, name string, passed bool, points
 	return 0
 }
 
+// isReadableDescription reports whether desc is present and passes the
+// readability check at normal strictness: short sentences, mostly active
+// voice.
+func isReadableDescription(desc string) bool {
+	r := textutil.MeasureReadability(desc)
+	return r.Sentences > 0 && len(r.Check(textutil.ReadabilityLevels[textutil.ReadabilityNormal])) == 0
+}
+
 // hasStringValue reports whether frontmatter[field] is a non-empty string.
 func hasStringValue(frontmatter map[string]any, field string) bool {
 	s, _ := frontmatter[field].(string)
@@ -3,13 +3,17 @@ package scoring
 import (
 	"regexp"
 	"strings"
+
+	"github.com/dotcommander/cclint/internal/textutil"
 )
 
 // antiPatternsSection is the standardized name for anti-patterns sections.
 const antiPatternsSection = "Anti-Patterns section"
 
 // SkillScorer scores skill files on a 0-100 scale
-type SkillScorer struct{}
+type SkillScorer struct {
+	frontmatter map[string]any // set by Score before delegation
+}
 
 // NewSkillScorer creates a new SkillScorer
 func NewSkillScorer() *SkillScorer {
@@ -18,6 +22,7 @@ func NewSkillScorer() *SkillScorer {
 
 // Score evaluates a skill and returns a QualityScore
 func (s *SkillScorer) Score(content string, frontmatter map[string]any, bodyContent string) QualityScore {
+	s.frontmatter = frontmatter
 	return computeCombinedScore(content, frontmatter, bodyContent, s)
 }
 
@@ -77,8 +82,46 @@ func (s *SkillScorer) antiPatternsFallback(content string, sectionName string) b
 	return false
 }
 
-// scorePractices scores the best practices adherence of a skill.
+// scorePractices scores the best practices adherence of a skill, less the
+// least-privilege deduction.
 func (s *SkillScorer) scorePractices(bodyContent string) (int, []Metric) {
+	practices, details := s.scoreMethodPractices(bodyContent)
+	return s.deductUnusedTools(practices, details, bodyContent)
+}
+
+// Least-privilege deduction: points per allowed-tools entry the skill never
+// uses, and the most deducted.
+const (
+	unusedToolPenalty    = 2
+	maxUnusedToolPenalty = 6
+)
+
+// deductUnusedTools takes points off practices for allowed-tools entries
+// the body never mentions, since each pre-approves a tool nothing needs.
+// Skills that declare allowed-tools get a "security" metric recording the
+// deduction, worth no points of its own.
+func (s *SkillScorer) deductUnusedTools(practices int, details []Metric, bodyContent string) (int, []Metric) {
+	use, ok := textutil.AnalyzeSkillTools(s.frontmatter["allowed-tools"], bodyContent, nil)
+	if !ok {
+		return practices, details
+	}
+	penalty := min(len(use.Unused)*unusedToolPenalty, maxUnusedToolPenalty, practices)
+	note := ""
+	if len(use.Unused) > 0 {
+		note = "Unused: " + strings.Join(use.Unused, ", ")
+	}
+	details = append(details, Metric{
+		Category: "security",
+		Name:     "Least-privilege allowed-tools",
+		Points:   -penalty,
+		Passed:   len(use.Unused) == 0,
+		Note:     note,
+	})
+	return practices - penalty, details
+}
+
+// scoreMethodPractices scores the methodology practices of a skill.
+func (s *SkillScorer) scoreMethodPractices(bodyContent string) (int, []Metric) {
 	lineCount := strings.Count(bodyContent, "\n") + 1
 	if isThinRouter(bodyContent, lineCount) {
 		return scoreThinRouterPractices(bodyContent)
@@ -208,11 +251,15 @@ func (s *SkillScorer) scoreDocumentation(frontmatter map[string]any, bodyContent
 	documentation += descPoints
 	details = append(details, descMetric)
 
-	// Code examples (5 points)
+	// Code examples (3 points)
 	codePoints, codeMetric := s.scoreCodeExamples(bodyContent)
 	documentation += codePoints
 	details = append(details, codeMetric)
 
+	// Readable description (2 points)
+	desc, _ := frontmatter["description"].(string)
+	documentation += recordMetric(&details, "documentation", "Readable description", isReadableDescription(desc), 2)
+
 	return documentation, details
 }
 
@@ -255,10 +302,10 @@ func (s *SkillScorer) scoreCodeExamples(bodyContent string) (int, Metric) {
 
 	switch {
 	case codeBlockCount >= 6:
-		codePoints = 5
+		codePoints = 3
 		codeNote = "Rich examples"
 	case codeBlockCount >= 3:
-		codePoints = 3
+		codePoints = 2
 		codeNote = "Adequate examples"
 	case codeBlockCount >= 1:
 		codePoints = 1
@@ -271,7 +318,7 @@ func (s *SkillScorer) scoreCodeExamples(bodyContent string) (int, Metric) {
 		Category:  "documentation",
 		Name:      "Code examples",
 		Points:    codePoints,
-		MaxPoints: 5,
+		MaxPoints: 3,
 		Passed:    codeBlockCount >= 3,
 		Note:      codeNote,
 	}
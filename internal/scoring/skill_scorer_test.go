@@ -432,14 +432,14 @@ func TestSkillScorer_CodeExamples(t *testing.T) {
 		{
 			name:       "Rich examples (>=6 backtick marks)",
 			body:       "```\na\n```\n```\nb\n```\n```\nc\n```", // 6 backtick marks
-			wantPoints: 5,
+			wantPoints: 3,
 			wantNote:   "Rich examples",
 			wantPassed: true,
 		},
 		{
 			name:       "Adequate examples (3-5 backtick marks)",
 			body:       "```\na\n```\n```\nb\n```", // 4 backtick marks
-			wantPoints: 3,
+			wantPoints: 2,
 			wantNote:   "Adequate examples",
 			wantPassed: true,
 		},
@@ -1062,3 +1062,46 @@ Score = quality * completeness
 		}
 	}
 }
+
+func TestSkillScorer_LeastPrivilege(t *testing.T) {
+	body := "## Quick Reference\n\nRead(references/api.md) before answering.\n"
+	base := NewSkillScorer().Score(body, map[string]any{"name": "api", "description": "API reference"}, body)
+
+	tests := []struct {
+		name        string
+		allowed     string
+		wantPenalty int
+		wantPassed  bool
+	}{
+		{"all tools used", "Read", 0, true},
+		{"one unused tool", "Read Write", 2, false},
+		{"deduction is capped", "Read Write Edit Bash WebFetch", 6, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{"name": "api", "description": "API reference", "allowed-tools": tt.allowed}
+			score := NewSkillScorer().Score(body, frontmatter, body)
+			if score.Practices != base.Practices-tt.wantPenalty {
+				t.Errorf("practices = %d, want %d", score.Practices, base.Practices-tt.wantPenalty)
+			}
+			var found bool
+			for _, d := range score.Details {
+				if d.Name == "Least-privilege allowed-tools" {
+					found = true
+					if d.Category != "security" || d.Points != -tt.wantPenalty || d.Passed != tt.wantPassed {
+						t.Errorf("metric = %+v, want security, %d points, passed %v", d, -tt.wantPenalty, tt.wantPassed)
+					}
+				}
+			}
+			if !found {
+				t.Error("least-privilege metric missing")
+			}
+		})
+	}
+
+	for _, d := range base.Details {
+		if d.Category == "security" {
+			t.Errorf("skill without allowed-tools has metric %+v", d)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Package selftest checks the linter against a corpus of fixture projects:
+// each fixture is a small project tree with an expect.yaml listing the
+// findings a lint of it must report.
+package selftest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDir is where fixtures are looked for, relative to the current
+// directory.
+const DefaultDir = ".cclint/selftest"
+
+// ExpectFile names the expectations file that marks a fixture directory.
+const ExpectFile = "expect.yaml"
+
+// ErrNoFixtures is returned by Load when a directory holds no fixtures.
+var ErrNoFixtures = errors.New("no fixtures found")
+
+// Expectation describes a finding a fixture must produce. Empty fields
+// match anything; Message matches as a substring.
+type Expectation struct {
+	File     string `yaml:"file,omitempty"`
+	Rule     string `yaml:"rule,omitempty"`
+	Severity string `yaml:"severity,omitempty"`
+	Message  string `yaml:"message,omitempty"`
+}
+
+// Fixture is one project in the corpus.
+type Fixture struct {
+	Name string `yaml:"-"` // directory name
+	Dir  string `yaml:"-"` // project root to lint
+	// Partial allows findings that no expectation matches. By default a
+	// fixture must report exactly the listed findings, so a known-good
+	// fixture is one with no expectations.
+	Partial  bool          `yaml:"partial"`
+	Findings []Expectation `yaml:"findings"`
+}
+
+// Finding is an issue reported by a lint of a fixture.
+type Finding struct {
+	File     string // relative to the fixture, slash-separated
+	Line     int
+	Rule     string
+	Severity string
+	Message  string
+}
+
+// String formats f as file:line: severity [rule] message.
+func (f Finding) String() string {
+	loc := f.File
+	if f.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", loc, f.Line)
+	}
+	rule := ""
+	if f.Rule != "" {
+		rule = " [" + f.Rule + "]"
+	}
+	return fmt.Sprintf("%s: %s%s %s", loc, f.Severity, rule, f.Message)
+}
+
+// String formats e with the fields it constrains.
+func (e Expectation) String() string {
+	var parts []string
+	for _, kv := range [][2]string{{"file", e.File}, {"rule", e.Rule}, {"severity", e.Severity}, {"message", e.Message}} {
+		if kv[1] != "" {
+			parts = append(parts, fmt.Sprintf("%s=%q", kv[0], kv[1]))
+		}
+	}
+	if len(parts) == 0 {
+		return "any finding"
+	}
+	return strings.Join(parts, " ")
+}
+
+// Matches reports whether f satisfies e.
+func (e Expectation) Matches(f Finding) bool {
+	return (e.File == "" || filepath.ToSlash(e.File) == f.File) &&
+		(e.Rule == "" || e.Rule == f.Rule) &&
+		(e.Severity == "" || e.Severity == f.Severity) &&
+		strings.Contains(f.Message, e.Message)
+}
+
+// Load reads the fixtures in dir: each immediate subdirectory with an
+// expect.yaml, in name order.
+func Load(dir string) ([]Fixture, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixtures: %w", err)
+	}
+	var fixtures []Fixture
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		fixtureDir := filepath.Join(dir, entry.Name())
+		raw, err := os.ReadFile(filepath.Join(fixtureDir, ExpectFile)) //nolint:gosec // G304: path is inside the fixture directory
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", entry.Name(), err)
+		}
+		fx := Fixture{Name: entry.Name(), Dir: fixtureDir}
+		if err := yaml.Unmarshal(raw, &fx); err != nil {
+			return nil, fmt.Errorf("error parsing %s/%s: %w", entry.Name(), ExpectFile, err)
+		}
+		fixtures = append(fixtures, fx)
+	}
+	if len(fixtures) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrNoFixtures, dir)
+	}
+	return fixtures, nil
+}
+
+// Report is the outcome of checking one fixture.
+type Report struct {
+	Fixture    Fixture
+	Missing    []Expectation // expectations no finding matched
+	Unexpected []Finding     // findings no expectation matched, unless Partial
+}
+
+// Passed reports whether the fixture produced what it expects.
+func (r Report) Passed() bool {
+	return len(r.Missing) == 0 && len(r.Unexpected) == 0
+}
+
+// Check compares the findings of a lint of fx with its expectations.
+func Check(fx Fixture, findings []Finding) Report {
+	report := Report{Fixture: fx}
+	for _, e := range fx.Findings {
+		if !slices.ContainsFunc(findings, e.Matches) {
+			report.Missing = append(report.Missing, e)
+		}
+	}
+	if !fx.Partial {
+		for _, f := range findings {
+			if !slices.ContainsFunc(fx.Findings, func(e Expectation) bool { return e.Matches(f) }) {
+				report.Unexpected = append(report.Unexpected, f)
+			}
+		}
+	}
+	return report
+}
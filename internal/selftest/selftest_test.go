@@ -0,0 +1,92 @@
+package selftest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, contents string) {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+
+	_, err := Load(dir)
+	require.ErrorIs(t, err, ErrNoFixtures)
+
+	write("b/expect.yaml", "partial: true\nfindings:\n  - rule: agent-tool-unknown\n")
+	write("a/expect.yaml", "findings: []\n")
+	write("notes/README.md", "not a fixture")
+	fixtures, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, fixtures, 2)
+	assert.Equal(t, "a", fixtures[0].Name)
+	assert.Equal(t, filepath.Join(dir, "a"), fixtures[0].Dir)
+	assert.Empty(t, fixtures[0].Findings)
+	assert.True(t, fixtures[1].Partial)
+	assert.Equal(t, []Expectation{{Rule: "agent-tool-unknown"}}, fixtures[1].Findings)
+
+	write("c/expect.yaml", "findings: {")
+	_, err = Load(dir)
+	assert.ErrorContains(t, err, "c/expect.yaml")
+}
+
+func TestCheck(t *testing.T) {
+	unknown := Finding{File: ".claude/agents/a.md", Line: 4, Rule: "agent-tool-unknown", Severity: "warning", Message: "Unknown tool 'FutureTool' in tools"}
+	model := Finding{File: ".claude/agents/a.md", Severity: "suggestion", Message: "Agent lacks 'model' specification"}
+
+	tests := []struct {
+		name           string
+		fixture        Fixture
+		findings       []Finding
+		wantMissing    int
+		wantUnexpected int
+	}{
+		{name: "known good", fixture: Fixture{}, findings: nil},
+		{name: "known good reports something", fixture: Fixture{}, findings: []Finding{model}, wantUnexpected: 1},
+		{
+			name:     "exact match",
+			fixture:  Fixture{Findings: []Expectation{{File: ".claude/agents/a.md", Rule: "agent-tool-unknown", Message: "FutureTool"}, {Severity: "suggestion"}}},
+			findings: []Finding{unknown, model},
+		},
+		{
+			name:           "wrong severity",
+			fixture:        Fixture{Findings: []Expectation{{Rule: "agent-tool-unknown", Severity: "error"}}},
+			findings:       []Finding{unknown},
+			wantMissing:    1,
+			wantUnexpected: 1,
+		},
+		{
+			name:     "partial ignores the rest",
+			fixture:  Fixture{Partial: true, Findings: []Expectation{{Rule: "agent-tool-unknown"}}},
+			findings: []Finding{unknown, model},
+		},
+		{
+			name:        "partial still needs its findings",
+			fixture:     Fixture{Partial: true, Findings: []Expectation{{File: "other.md"}}},
+			findings:    []Finding{unknown},
+			wantMissing: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Check(tt.fixture, tt.findings)
+			assert.Len(t, report.Missing, tt.wantMissing)
+			assert.Len(t, report.Unexpected, tt.wantUnexpected)
+			assert.Equal(t, tt.wantMissing == 0 && tt.wantUnexpected == 0, report.Passed())
+		})
+	}
+}
+
+func TestFindingString(t *testing.T) {
+	f := Finding{File: "a.md", Line: 3, Rule: "terminology", Severity: "warning", Message: "Use 'Claude Code'"}
+	assert.Equal(t, "a.md:3: warning [terminology] Use 'Claude Code'", f.String())
+	assert.Equal(t, `rule="terminology" message="Use"`, Expectation{Rule: "terminology", Message: "Use"}.String())
+	assert.Equal(t, "any finding", Expectation{}.String())
+}
@@ -0,0 +1,304 @@
+// Package snapshot records a normalized form of each component, its
+// formatted frontmatter and its structural outline, so that structural
+// changes to agents, commands, and skills can be reviewed like code.
+package snapshot
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/format"
+)
+
+// DefaultDir is where snapshots are stored, relative to the project root.
+const DefaultDir = ".cclint/snapshots"
+
+// indexFile lists the snapshots in a snapshot directory.
+const indexFile = "index.json"
+
+// indexVersion is the version of the index file format.
+const indexVersion = 1
+
+// Component is the snapshot of one component.
+type Component struct {
+	Type string `json:"type"` // "agent", "command", "skill"
+	Path string `json:"path"` // relative to the project root, slash-separated
+	File string `json:"file"` // snapshot file name in the snapshot directory
+	Hash string `json:"sha256"`
+	Text string `json:"-"` // the normalized representation
+}
+
+// index is the contents of index.json.
+type index struct {
+	Version    int         `json:"version"`
+	Components []Component `json:"components"`
+}
+
+// Change is a difference between a stored and a current snapshot.
+type Change struct {
+	Kind string // "added", "removed", or "changed"
+	Type string
+	Path string
+	Diff string // for changed components, stored vs current normalized text
+}
+
+// snapshotTypes are the component types that are snapshotted.
+var snapshotTypes = map[discovery.FileType]bool{
+	discovery.FileTypeAgent:   true,
+	discovery.FileTypeCommand: true,
+	discovery.FileTypeSkill:   true,
+}
+
+// Build snapshots every agent, command, and skill in files, in path order,
+// with frontmatter formatted by opts.
+func Build(files []discovery.File, opts format.Options) []Component {
+	var comps []Component
+	for _, f := range files {
+		if !snapshotTypes[f.Type] {
+			continue
+		}
+		path := filepath.ToSlash(f.RelPath)
+		text := Normalize(f.Type.String(), path, f.Contents, opts)
+		comps = append(comps, Component{
+			Type: f.Type.String(),
+			Path: path,
+			File: fileName(path),
+			Hash: hash(text),
+			Text: text,
+		})
+	}
+	slices.SortFunc(comps, func(a, b Component) int { return cmp.Compare(a.Path, b.Path) })
+	return comps
+}
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	fencePattern   = regexp.MustCompile("^\\s*(```|~~~)\\s*([^`\\s]*)")
+)
+
+// Normalize returns the representation of a component that its snapshot
+// stores: the frontmatter as cclint fmt writes it with opts, then the outline of the
+// body, one line per heading (indented by level) and per fenced code block
+// (by language). Prose edits do not change it; adding, removing, or
+// reordering sections and fields does.
+func Normalize(componentType, path, contents string, opts format.Options) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s\n", componentType, path)
+
+	frontmatter, body := splitFormatted(componentType, contents, opts)
+	sb.WriteString("\n[frontmatter]\n")
+	if frontmatter != "" {
+		sb.WriteString(frontmatter + "\n")
+	}
+
+	sb.WriteString("\n[outline]\n")
+	fence := ""
+	for line := range strings.SplitSeq(body, "\n") {
+		if m := fencePattern.FindStringSubmatch(line); m != nil {
+			switch {
+			case fence == "":
+				fence = m[1]
+				lang := m[2]
+				if lang == "" {
+					lang = "text"
+				}
+				fmt.Fprintf(&sb, "  code %s\n", lang)
+			case m[1] == fence && m[2] == "":
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			fmt.Fprintf(&sb, "%s%s %s\n", strings.Repeat("  ", len(m[1])-1), m[1], m[2])
+		}
+	}
+	return sb.String()
+}
+
+// splitFormatted formats contents as cclint fmt does and splits it into
+// frontmatter and body. Frontmatter that does not parse is kept as written.
+func splitFormatted(componentType, contents string, opts format.Options) (frontmatter, body string) {
+	formatted, err := format.NewComponentFormatter(componentType, opts).Format(contents)
+	if err != nil {
+		formatted = contents
+	}
+	rest, ok := strings.CutPrefix(strings.TrimLeft(formatted, " \t"), "---")
+	if !ok {
+		return "", formatted
+	}
+	frontmatter, body, ok = strings.Cut(rest, "\n---")
+	if !ok {
+		return "", formatted
+	}
+	return strings.Trim(frontmatter, "\n"), body
+}
+
+// fileName returns the snapshot file name for a component path: the path
+// with separators and unusual characters replaced, plus a short hash of the
+// path so distinct paths never share a file.
+func fileName(path string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, strings.TrimSuffix(strings.TrimLeft(path, "./"), ".md"))
+	return fmt.Sprintf("%s-%s.snap", safe, hash(path)[:8])
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// Write replaces the snapshots in dir with comps. Snapshot files from an
+// earlier Write that are no longer listed are removed.
+func Write(dir string, comps []Component) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error creating %s: %w", dir, err)
+	}
+	old, err := filepath.Glob(filepath.Join(dir, "*.snap"))
+	if err != nil {
+		return err
+	}
+	keep := make(map[string]bool, len(comps))
+	for _, c := range comps {
+		keep[c.File] = true
+		if err := os.WriteFile(filepath.Join(dir, c.File), []byte(c.Text), 0o644); err != nil {
+			return fmt.Errorf("error writing snapshot: %w", err)
+		}
+	}
+	for _, path := range old {
+		if !keep[filepath.Base(path)] {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("error removing stale snapshot: %w", err)
+			}
+		}
+	}
+
+	idx := index{Version: indexVersion, Components: comps}
+	if idx.Components == nil {
+		idx.Components = []Component{}
+	}
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, indexFile), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("error writing snapshot index: %w", err)
+	}
+	return nil
+}
+
+// ErrNoSnapshots is returned by Load when dir holds no snapshot index.
+var ErrNoSnapshots = errors.New("no snapshots found")
+
+// Load reads the snapshots in dir, keyed by component path. A snapshot
+// file that is missing leaves its component's Text empty; comparison still
+// uses the hash from the index.
+func Load(dir string) (map[string]Component, error) {
+	data, err := os.ReadFile(filepath.Join(dir, indexFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoSnapshots
+	}
+	if err != nil {
+		return nil, err
+	}
+	var idx index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("invalid snapshot index %s: %w", filepath.Join(dir, indexFile), err)
+	}
+	if idx.Version != indexVersion {
+		return nil, fmt.Errorf("unsupported snapshot index version %d", idx.Version)
+	}
+	stored := make(map[string]Component, len(idx.Components))
+	for _, c := range idx.Components {
+		if text, err := os.ReadFile(filepath.Join(dir, filepath.Base(c.File))); err == nil {
+			c.Text = string(text)
+		}
+		stored[c.Path] = c
+	}
+	return stored, nil
+}
+
+// Compare returns how current differs from stored, in path order.
+func Compare(stored map[string]Component, current []Component) []Change {
+	var changes []Change
+	seen := make(map[string]bool, len(current))
+	for _, c := range current {
+		seen[c.Path] = true
+		old, ok := stored[c.Path]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: "added", Type: c.Type, Path: c.Path})
+		case old.Hash != c.Hash:
+			changes = append(changes, Change{Kind: "changed", Type: c.Type, Path: c.Path, Diff: diffLines(old.Text, c.Text, c.Path)})
+		}
+	}
+	for path, old := range stored {
+		if !seen[path] {
+			changes = append(changes, Change{Kind: "removed", Type: old.Type, Path: path})
+		}
+	}
+	slices.SortFunc(changes, func(a, b Change) int { return cmp.Compare(a.Path, b.Path) })
+	return changes
+}
+
+// diffLines returns a line diff of a stored and a current snapshot,
+// aligned on their longest common subsequence so that an inserted section
+// shows as one added line. Unchanged lines are prefixed with two spaces.
+func diffLines(stored, current, path string) string {
+	a := strings.Split(strings.TrimSuffix(stored, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(current, "\n"), "\n")
+	// lcs[i][j] is the length of the common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s (snapshot)\n+++ %s (current)\n", path, path)
+	line := func(prefix, text string) {
+		sb.WriteString(strings.TrimRight(prefix+text, " ") + "\n")
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			line("  ", a[i])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			line("+ ", b[j])
+			j++
+		default:
+			line("- ", a[i])
+			i++
+		}
+	}
+	return sb.String()
+}
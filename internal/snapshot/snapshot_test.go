@@ -0,0 +1,129 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/discovery"
+	"github.com/dotcommander/cclint/internal/format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const agentContents = `---
+tools: Read
+name: reviewer
+description: Reviews code
+---
+# Reviewer
+
+Reviews code for bugs.
+
+## Workflow ##
+
+~~~bash
+# not a heading
+git diff
+~~~
+
+### Checks
+
+` + "```" + `
+plain
+` + "```" + `
+`
+
+func TestNormalize(t *testing.T) {
+	want := `agent .claude/agents/reviewer.md
+
+[frontmatter]
+name: reviewer
+description: Reviews code
+tools: Read
+
+[outline]
+# Reviewer
+  ## Workflow
+  code bash
+    ### Checks
+  code text
+`
+	assert.Equal(t, want, Normalize("agent", ".claude/agents/reviewer.md", agentContents, format.Options{}))
+}
+
+func TestNormalizeIgnoresProse(t *testing.T) {
+	edited := agentContents + "\nMore prose, no new sections.\n"
+	assert.Equal(t,
+		Normalize("agent", "a.md", agentContents, format.Options{}),
+		Normalize("agent", "a.md", edited, format.Options{}))
+}
+
+func TestNormalizeWithoutFrontmatter(t *testing.T) {
+	got := Normalize("command", "c.md", "# Title\nbody\n", format.Options{})
+	assert.Equal(t, "command c.md\n\n[frontmatter]\n\n[outline]\n# Title\n", got)
+}
+
+func TestFileName(t *testing.T) {
+	a := fileName(".claude/agents/reviewer.md")
+	assert.Regexp(t, `^claude_agents_reviewer-[0-9a-f]{8}\.snap$`, a)
+	assert.Equal(t, a, fileName(".claude/agents/reviewer.md"), "file names are stable")
+	assert.NotEqual(t, fileName("a/b.md"), fileName("a_b.md"), "similar paths get distinct files")
+}
+
+func TestBuild(t *testing.T) {
+	files := []discovery.File{
+		{RelPath: ".claude/skills/z/SKILL.md", Type: discovery.FileTypeSkill, Contents: "---\nname: z\n---\n"},
+		{RelPath: ".claude/settings.json", Type: discovery.FileTypeSettings, Contents: "{}"},
+		{RelPath: ".claude/agents/a.md", Type: discovery.FileTypeAgent, Contents: agentContents},
+	}
+	comps := Build(files, format.Options{})
+	require.Len(t, comps, 2)
+	assert.Equal(t, ".claude/agents/a.md", comps[0].Path)
+	assert.Equal(t, "skill", comps[1].Type)
+	assert.Len(t, comps[0].Hash, 64)
+}
+
+func TestWriteLoadCompare(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	_, err := Load(dir)
+	require.ErrorIs(t, err, ErrNoSnapshots)
+
+	original := Build([]discovery.File{
+		{RelPath: "agents/a.md", Type: discovery.FileTypeAgent, Contents: agentContents},
+		{RelPath: "commands/gone.md", Type: discovery.FileTypeCommand, Contents: "# Gone\n"},
+	}, format.Options{})
+	require.NoError(t, Write(dir, original))
+
+	stored, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	assert.Equal(t, original[0].Text, stored["agents/a.md"].Text)
+	assert.Empty(t, Compare(stored, original))
+
+	current := Build([]discovery.File{
+		{RelPath: "agents/a.md", Type: discovery.FileTypeAgent, Contents: agentContents + "\n## Output\n"},
+		{RelPath: "skills/new/SKILL.md", Type: discovery.FileTypeSkill, Contents: "# New\n"},
+	}, format.Options{})
+	changes := Compare(stored, current)
+	require.Len(t, changes, 3)
+	assert.Equal(t, []string{"changed", "removed", "added"}, []string{changes[0].Kind, changes[1].Kind, changes[2].Kind})
+	assert.Contains(t, changes[0].Diff, "+   ## Output\n")
+	assert.NotContains(t, changes[0].Diff, "\n- ")
+
+	// Rewriting drops the snapshot file of the removed command.
+	require.NoError(t, Write(dir, current))
+	snaps, err := filepath.Glob(filepath.Join(dir, "*.snap"))
+	require.NoError(t, err)
+	assert.Len(t, snaps, 2)
+	stored, err = Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, Compare(stored, current))
+}
+
+func TestLoadRejectsUnknownVersion(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, indexFile), []byte(`{"version": 9, "components": []}`), 0o600))
+	_, err := Load(dir)
+	assert.ErrorContains(t, err, "unsupported snapshot index version 9")
+}
@@ -0,0 +1,99 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/git"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// LowScore is the quality score below which a component counts as low
+// quality for churn: tiers D and F.
+const LowScore = 50
+
+// ChurnReport pairs how often each component changed with its current
+// findings and score. Files are sorted by commits, most first, then by
+// findings, then by file. Only components changed in the history read are
+// listed.
+type ChurnReport struct {
+	Since      string       `json:"since,omitempty"` // git log --since value; empty for all history
+	MinCommits int          `json:"minCommits"`
+	Files      []ChurnEntry `json:"files"`
+	Candidates int          `json:"candidates"` // entries marked Candidate
+}
+
+// ChurnEntry is one component's churn and current quality. Score is nil
+// for component types without quality scoring. Candidate marks a refactor
+// candidate: changed at least MinCommits times, and low quality, with an
+// error or a score below LowScore.
+type ChurnEntry struct {
+	File        string    `json:"file"`
+	Type        string    `json:"type"`
+	Commits     int       `json:"commits"`
+	Authors     int       `json:"authors"`
+	LastChanged time.Time `json:"lastChanged"`
+	Errors      int       `json:"errors"`
+	Warnings    int       `json:"warnings"`
+	Suggestions int       `json:"suggestions"`
+	Score       *int      `json:"score,omitempty"`
+	Tier        string    `json:"tier,omitempty"`
+	Candidate   bool      `json:"candidate"`
+}
+
+// LowQuality reports whether the entry has an error or a low score.
+func (e ChurnEntry) LowQuality() bool {
+	return e.Errors > 0 || (e.Score != nil && *e.Score < LowScore)
+}
+
+// ComputeChurn joins the history of a project with the summaries of a lint
+// run of it. history paths and summary files are matched relative to each
+// summary's project root.
+func ComputeChurn(summaries []*lint.LintSummary, history []git.FileChurn, since string, minCommits int) ChurnReport {
+	byPath := make(map[string]git.FileChurn, len(history))
+	for _, fc := range history {
+		byPath[fc.Path] = fc
+	}
+
+	report := ChurnReport{Since: since, MinCommits: minCommits, Files: []ChurnEntry{}}
+	for _, summary := range summaries {
+		for _, r := range summary.Results {
+			file := relFile(summary.ProjectRoot, r.File)
+			fc, ok := byPath[file]
+			if !ok {
+				continue
+			}
+			entry := ChurnEntry{
+				File:        file,
+				Type:        r.Type,
+				Commits:     fc.Commits,
+				Authors:     fc.Authors,
+				LastChanged: fc.LastChanged,
+				Errors:      len(r.Errors),
+				Warnings:    len(r.Warnings),
+				Suggestions: len(r.Suggestions),
+			}
+			if r.Quality != nil {
+				score := r.Quality.Overall
+				entry.Score, entry.Tier = &score, r.Quality.Tier
+			}
+			entry.Candidate = entry.Commits >= minCommits && entry.LowQuality()
+			if entry.Candidate {
+				report.Candidates++
+			}
+			report.Files = append(report.Files, entry)
+		}
+	}
+
+	sort.Slice(report.Files, func(i, j int) bool {
+		a, b := report.Files[i], report.Files[j]
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		if fa, fb := a.Errors+a.Warnings+a.Suggestions, b.Errors+b.Warnings+b.Suggestions; fa != fb {
+			return fa > fb
+		}
+		return a.File < b.File
+	})
+	return report
+}
@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/git"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/scoring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeChurn(t *testing.T) {
+	errIssue := cue.ValidationError{Severity: cue.SeverityError, Message: "m"}
+	summaries := []*lint.LintSummary{
+		{
+			ProjectRoot: "/p",
+			Results: []lint.LintResult{
+				{File: ".claude/agents/busy-broken.md", Type: "agent", Errors: []cue.ValidationError{errIssue}, Quality: &scoring.QualityScore{Overall: 80, Tier: "B"}},
+				{File: "/p/.claude/agents/busy-weak.md", Type: "agent", Quality: &scoring.QualityScore{Overall: 40, Tier: "D"}},
+				{File: ".claude/agents/busy-good.md", Type: "agent", Quality: &scoring.QualityScore{Overall: 90, Tier: "A"}},
+				{File: ".claude/agents/quiet-broken.md", Type: "agent", Errors: []cue.ValidationError{errIssue}},
+				{File: ".claude/agents/untouched.md", Type: "agent"},
+			},
+		},
+		{
+			ProjectRoot: "/p",
+			Results:     []lint.LintResult{{File: ".claude/settings.json", Type: "settings"}},
+		},
+	}
+	history := []git.FileChurn{
+		{Path: ".claude/agents/busy-broken.md", Commits: 9, Authors: 3},
+		{Path: ".claude/agents/busy-weak.md", Commits: 9, Authors: 1},
+		{Path: ".claude/agents/busy-good.md", Commits: 12, Authors: 2},
+		{Path: ".claude/agents/quiet-broken.md", Commits: 2, Authors: 1},
+		{Path: ".claude/settings.json", Commits: 5, Authors: 1},
+		{Path: "README.md", Commits: 30, Authors: 4},
+	}
+
+	report := ComputeChurn(summaries, history, "90 days ago", 5)
+	assert.Equal(t, "90 days ago", report.Since)
+	assert.Equal(t, 2, report.Candidates)
+
+	var files []string
+	var candidates []string
+	for _, e := range report.Files {
+		files = append(files, e.File)
+		if e.Candidate {
+			candidates = append(candidates, e.File)
+		}
+	}
+	assert.Equal(t, []string{
+		".claude/agents/busy-good.md",
+		".claude/agents/busy-broken.md",
+		".claude/agents/busy-weak.md",
+		".claude/settings.json",
+		".claude/agents/quiet-broken.md",
+	}, files)
+	assert.Equal(t, []string{".claude/agents/busy-broken.md", ".claude/agents/busy-weak.md"}, candidates)
+
+	assert.Nil(t, report.Files[3].Score, "settings have no score")
+	assert.Equal(t, 80, *report.Files[1].Score)
+	assert.Equal(t, "B", report.Files[1].Tier)
+}
@@ -0,0 +1,125 @@
+// Package stats summarizes the findings of a lint run by rule, directory,
+// and component type, so a team can see which rules fire most and decide
+// which to prioritize, re-grade, or turn off.
+package stats
+
+import (
+	"math"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+// Report is the finding breakdown of one lint run. Groups in each list are
+// sorted by finding count, most first, then by key.
+type Report struct {
+	Files       int     `json:"files"`    // files linted
+	Findings    int     `json:"findings"` // errors, warnings, and suggestions reported
+	ByRule      []Group `json:"byRule"`
+	ByDirectory []Group `json:"byDirectory"`
+	ByType      []Group `json:"byType"`
+}
+
+// Group counts the findings that share a rule ID, directory, or component
+// type. Key is empty for findings without a rule ID. Files is the number of
+// files with at least one finding in the group, and Percent its share of
+// all findings, to one decimal place.
+type Group struct {
+	Key         string  `json:"key"`
+	Findings    int     `json:"findings"`
+	Errors      int     `json:"errors"`
+	Warnings    int     `json:"warnings"`
+	Suggestions int     `json:"suggestions"`
+	Files       int     `json:"files"`
+	Percent     float64 `json:"percent"`
+}
+
+// counter accumulates the groups of one breakdown.
+type counter struct {
+	groups map[string]*Group
+	files  map[string]map[string]bool // files seen per key
+}
+
+func newCounter() *counter {
+	return &counter{groups: make(map[string]*Group), files: make(map[string]map[string]bool)}
+}
+
+func (c *counter) add(key, file string, issue cue.ValidationError) {
+	g, ok := c.groups[key]
+	if !ok {
+		g = &Group{Key: key}
+		c.groups[key] = g
+		c.files[key] = make(map[string]bool)
+	}
+	g.Findings++
+	switch issue.Severity {
+	case cue.SeverityError:
+		g.Errors++
+	case cue.SeverityWarning:
+		g.Warnings++
+	default:
+		g.Suggestions++
+	}
+	if !c.files[key][file] {
+		c.files[key][file] = true
+		g.Files++
+	}
+}
+
+// sorted returns the groups, most findings first, with their share of
+// total findings filled in.
+func (c *counter) sorted(total int) []Group {
+	groups := make([]Group, 0, len(c.groups))
+	for _, g := range c.groups {
+		if total > 0 {
+			g.Percent = math.Round(float64(g.Findings)*1000/float64(total)) / 10
+		}
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Findings != groups[j].Findings {
+			return groups[i].Findings > groups[j].Findings
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// Compute builds the report for the summaries of a lint run. Findings are
+// counted as the run reports them, after rules config, overrides, and the
+// baseline; suppressed findings are not counted.
+func Compute(summaries []*lint.LintSummary) Report {
+	byRule, byDir, byType := newCounter(), newCounter(), newCounter()
+	var report Report
+	for _, summary := range summaries {
+		for _, r := range summary.Results {
+			report.Files++
+			file := relFile(summary.ProjectRoot, r.File)
+			for _, issues := range [][]cue.ValidationError{r.Errors, r.Warnings, r.Suggestions} {
+				for _, issue := range issues {
+					report.Findings++
+					byRule.add(issue.Rule, file, issue)
+					byDir.add(path.Dir(file), file, issue)
+					byType.add(r.Type, file, issue)
+				}
+			}
+		}
+	}
+	report.ByRule = byRule.sorted(report.Findings)
+	report.ByDirectory = byDir.sorted(report.Findings)
+	report.ByType = byType.sorted(report.Findings)
+	return report
+}
+
+// relFile returns file relative to root, slash-separated.
+func relFile(root, file string) string {
+	if filepath.IsAbs(file) && root != "" {
+		if rel, err := filepath.Rel(root, file); err == nil {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(file)
+}
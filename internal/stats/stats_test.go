@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompute(t *testing.T) {
+	issue := func(rule, severity string) cue.ValidationError {
+		return cue.ValidationError{Rule: rule, Severity: severity, Message: "m"}
+	}
+	summaries := []*lint.LintSummary{
+		{
+			ProjectRoot: "/p",
+			Results: []lint.LintResult{
+				{
+					File:     ".claude/agents/a.md",
+					Type:     "agent",
+					Errors:   []cue.ValidationError{issue("", cue.SeverityError)},
+					Warnings: []cue.ValidationError{issue("agent-tool-unknown", cue.SeverityWarning), issue("agent-tool-unknown", cue.SeverityWarning)},
+				},
+				{
+					File:     "/p/.claude/agents/b.md",
+					Type:     "agent",
+					Warnings: []cue.ValidationError{issue("agent-tool-unknown", cue.SeverityWarning)},
+				},
+			},
+		},
+		{
+			Results: []lint.LintResult{
+				{
+					File:        ".claude/skills/s/SKILL.md",
+					Type:        "skill",
+					Suggestions: []cue.ValidationError{issue("orphaned-skill", cue.SeveritySuggestion)},
+				},
+				{File: ".claude/skills/t/SKILL.md", Type: "skill"},
+			},
+		},
+	}
+
+	report := Compute(summaries)
+	assert.Equal(t, 4, report.Files)
+	assert.Equal(t, 5, report.Findings)
+	assert.Equal(t, []Group{
+		{Key: "agent-tool-unknown", Findings: 3, Warnings: 3, Files: 2, Percent: 60},
+		{Key: "", Findings: 1, Errors: 1, Files: 1, Percent: 20},
+		{Key: "orphaned-skill", Findings: 1, Suggestions: 1, Files: 1, Percent: 20},
+	}, report.ByRule)
+	assert.Equal(t, []Group{
+		{Key: ".claude/agents", Findings: 4, Errors: 1, Warnings: 3, Files: 2, Percent: 80},
+		{Key: ".claude/skills/s", Findings: 1, Suggestions: 1, Files: 1, Percent: 20},
+	}, report.ByDirectory)
+	assert.Equal(t, []Group{
+		{Key: "agent", Findings: 4, Errors: 1, Warnings: 3, Files: 2, Percent: 80},
+		{Key: "skill", Findings: 1, Suggestions: 1, Files: 1, Percent: 20},
+	}, report.ByType)
+}
+
+func TestComputeEmpty(t *testing.T) {
+	report := Compute(nil)
+	assert.Zero(t, report.Findings)
+	assert.Empty(t, report.ByRule)
+	assert.NotNil(t, report.ByRule, "groups marshal as [] rather than null")
+}
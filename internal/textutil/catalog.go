@@ -0,0 +1,36 @@
+package textutil
+
+import (
+	_ "embed"
+	"slices"
+	"strings"
+)
+
+//go:embed catalog/tools.txt
+var knownToolsCatalog string
+
+// ParseCatalog parses an embedded name catalog: one name per line, with
+// blank lines and text after # ignored.
+func ParseCatalog(data string) map[string]bool {
+	names := make(map[string]bool)
+	for line := range strings.SplitSeq(data, "\n") {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if name := strings.TrimSpace(line); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// KnownTools is the set of built-in Claude Code tool names, read from the
+// embedded catalog/tools.txt.
+var KnownTools = ParseCatalog(knownToolsCatalog)
+
+// IsKnownTool reports whether name is a built-in tool or one of
+// extraTools, the names the extraTools config key adds for a newer Claude
+// Code release.
+func IsKnownTool(name string, extraTools []string) bool {
+	return KnownTools[name] || slices.Contains(extraTools, name)
+}
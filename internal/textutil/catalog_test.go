@@ -0,0 +1,29 @@
+package textutil
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestParseCatalog(t *testing.T) {
+	got := ParseCatalog("# header\n\nRead\n  Write  # trailing comment\n*\n#Skipped\n")
+	want := []string{"*", "Read", "Write"}
+	if keys := slices.Sorted(maps.Keys(got)); !slices.Equal(keys, want) {
+		t.Errorf("ParseCatalog = %v, want %v", keys, want)
+	}
+}
+
+func TestIsKnownTool(t *testing.T) {
+	for _, name := range []string{"Read", "Bash", "TaskUpdate", "*"} {
+		if !IsKnownTool(name, nil) {
+			t.Errorf("IsKnownTool(%q, nil) = false, want true", name)
+		}
+	}
+	if IsKnownTool("FutureTool", nil) {
+		t.Error("IsKnownTool(FutureTool, nil) = true, want false")
+	}
+	if !IsKnownTool("FutureTool", []string{"FutureTool"}) {
+		t.Error("IsKnownTool(FutureTool, [FutureTool]) = false, want true")
+	}
+}
@@ -0,0 +1,106 @@
+package textutil
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// BlankJSONC replaces the JSONC extensions in s, // and /* */ comments and
+// trailing commas before } or ], with spaces, leaving line breaks in place.
+// The result has the same length and line structure as s, so positions in
+// it are positions in s. It reports whether anything was blanked.
+func BlankJSONC(s string) (string, bool) {
+	b := []byte(s)
+	changed := false
+	blank := func(from, to int) {
+		for i := from; i < to; i++ {
+			if b[i] != '\n' && b[i] != '\r' {
+				b[i] = ' '
+			}
+		}
+		changed = true
+	}
+
+	// Comments first, so that a comment between a comma and its closing
+	// bracket does not hide the trailing comma.
+	for i := 0; i < len(b); i++ {
+		switch {
+		case b[i] == '"':
+			i = stringEnd(b, i)
+		case b[i] == '/' && i+1 < len(b) && b[i+1] == '/':
+			end := i + 2
+			for end < len(b) && b[end] != '\n' {
+				end++
+			}
+			blank(i, end)
+			i = end - 1
+		case b[i] == '/' && i+1 < len(b) && b[i+1] == '*':
+			end := strings.Index(string(b[i+2:]), "*/")
+			if end < 0 {
+				continue // unterminated; leave it for the JSON parser to report
+			}
+			end += i + 4
+			blank(i, end)
+			i = end - 1
+		}
+	}
+
+	for i := 0; i < len(b); i++ {
+		switch b[i] {
+		case '"':
+			i = stringEnd(b, i)
+		case ',':
+			next := i + 1
+			for next < len(b) && strings.IndexByte(" \t\r\n", b[next]) >= 0 {
+				next++
+			}
+			if next < len(b) && (b[next] == '}' || b[next] == ']') {
+				blank(i, i+1)
+			}
+		}
+	}
+	return string(b), changed
+}
+
+// stringEnd returns the index of the quote closing the JSON string that
+// opens at b[start], or the last index when the string is unterminated.
+func stringEnd(b []byte, start int) int {
+	for i := start + 1; i < len(b); i++ {
+		switch b[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return len(b) - 1
+}
+
+// JSONErrorPosition returns the 1-based line and byte column in contents
+// of a json.Unmarshal error: the offending character of a syntax error, or
+// the start of the mistyped value of a type error. ok is false for other
+// errors.
+func JSONErrorPosition(contents string, err error) (line, column int, ok bool) {
+	// Offset counts the bytes read, including the offending one.
+	var read int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		read = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		read = typeErr.Offset
+	default:
+		return 0, 0, false
+	}
+	offset := read - 1
+	// At the end of input, point just past the last non-space character.
+	if read >= int64(len(contents)) || offset < 0 {
+		offset = int64(len(strings.TrimRight(contents, " \t\r\n")))
+	}
+	before := contents[:offset]
+	line = strings.Count(before, "\n") + 1
+	column = len(before) - strings.LastIndexByte(before, '\n')
+	return line, column, true
+}
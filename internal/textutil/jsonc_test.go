@@ -0,0 +1,65 @@
+package textutil
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestBlankJSONC(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		want        string
+		wantChanged bool
+	}{
+		{name: "plain JSON", in: `{"a": [1, 2]}`, want: `{"a": [1, 2]}`},
+		{name: "line comment", in: "{\n  // note\n  \"a\": 1\n}", want: "{\n         \n  \"a\": 1\n}", wantChanged: true},
+		{name: "block comment keeps line breaks", in: "{/* a\nb */\"a\": 1}", want: "{    \n    \"a\": 1}", wantChanged: true},
+		{name: "trailing commas", in: `{"a": [1, 2,], "b": 3,}`, want: `{"a": [1, 2 ], "b": 3 }`, wantChanged: true},
+		{name: "comment hides trailing comma", in: "[1, // last\n]", want: "[1         \n]", wantChanged: true},
+		{name: "comment markers in strings", in: `{"url": "https://x.io/*", "s": "a,]"}`, want: `{"url": "https://x.io/*", "s": "a,]"}`},
+		{name: "escaped quote", in: `{"s": "\" // not a comment"}`, want: `{"s": "\" // not a comment"}`},
+		{name: "unterminated block comment", in: `{"a": 1 /* open`, want: `{"a": 1 /* open`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := BlankJSONC(tt.in)
+			if got != tt.want || changed != tt.wantChanged {
+				t.Errorf("BlankJSONC(%q) = %q, %v; want %q, %v", tt.in, got, changed, tt.want, tt.wantChanged)
+			}
+			if len(got) != len(tt.in) {
+				t.Errorf("length changed from %d to %d", len(tt.in), len(got))
+			}
+		})
+	}
+}
+
+func TestJSONErrorPosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		wantLine int
+		wantCol  int
+	}{
+		{name: "bad character", contents: "{\n  \"a\": 1,\n  }\n", wantLine: 3, wantCol: 3},
+		{name: "bad literal", contents: "{\n  \"a\": tru\n}", wantLine: 2, wantCol: 11},
+		{name: "unexpected end", contents: "{\n  \"a\": 1\n", wantLine: 2, wantCol: 9},
+		{name: "empty", contents: "", wantLine: 1, wantCol: 1},
+		{name: "wrong type", contents: "[1]", wantLine: 1, wantCol: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data map[string]any
+			err := json.Unmarshal([]byte(tt.contents), &data)
+			line, col, ok := JSONErrorPosition(tt.contents, err)
+			if !ok || line != tt.wantLine || col != tt.wantCol {
+				t.Errorf("JSONErrorPosition(%q, %v) = %d, %d, %v; want %d, %d", tt.contents, err, line, col, ok, tt.wantLine, tt.wantCol)
+			}
+		})
+	}
+
+	if _, _, ok := JSONErrorPosition("{}", errors.New("other")); ok {
+		t.Error("JSONErrorPosition reported a position for a non-JSON error")
+	}
+}
@@ -0,0 +1,125 @@
+package textutil
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONPosition is the 1-based line and byte column of a value in a JSON
+// document.
+type JSONPosition struct {
+	Line   int
+	Column int
+}
+
+// JSONPointer builds an RFC 6901 JSON Pointer from path tokens: strings are
+// object keys and ints array indexes. No tokens is the whole document, "".
+func JSONPointer(tokens ...any) string {
+	var sb strings.Builder
+	for _, tok := range tokens {
+		sb.WriteByte('/')
+		switch t := tok.(type) {
+		case int:
+			sb.WriteString(strconv.Itoa(t))
+		case string:
+			sb.WriteString(escapePointerToken(t))
+		}
+	}
+	return sb.String()
+}
+
+// pointerEscaper escapes "~" and "/" in a JSON Pointer reference token.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapePointerToken(s string) string {
+	return pointerEscaper.Replace(s)
+}
+
+// jsonFrame is an object or array being walked by JSONPositions.
+type jsonFrame struct {
+	pointer string
+	array   bool
+	index   int    // next array index
+	key     string // current object key
+	wantKey bool   // the next object token is a key or the closing brace
+}
+
+// JSONPositions maps the JSON Pointer of every value in a JSON document to
+// where it starts: the key of an object member, so a finding lands on the
+// line naming the field, or the value itself for array elements and the
+// document. Run JSONC through BlankJSONC first; blanking keeps positions.
+func JSONPositions(contents string) (map[string]JSONPosition, error) {
+	dec := json.NewDecoder(strings.NewReader(contents))
+	dec.UseNumber()
+	positions := make(map[string]JSONPosition)
+	// Tokens come in order, so the line count advances with them.
+	scanned, line, lineStart := 0, 1, 0
+	position := func(offset int) JSONPosition {
+		for ; scanned < offset; scanned++ {
+			if contents[scanned] == '\n' {
+				line, lineStart = line+1, scanned+1
+			}
+		}
+		return JSONPosition{Line: line, Column: offset - lineStart + 1}
+	}
+
+	var stack []*jsonFrame
+	for {
+		start := skipJSONSeparators(contents, int(dec.InputOffset()))
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return positions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		delim, isDelim := tok.(json.Delim)
+		if isDelim && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		pointer := ""
+		if len(stack) > 0 {
+			top := stack[len(stack)-1]
+			switch {
+			case top.array:
+				pointer = top.pointer + "/" + strconv.Itoa(top.index)
+				top.index++
+				positions[pointer] = position(start)
+			case top.wantKey:
+				top.key, _ = tok.(string)
+				top.wantKey = false
+				positions[top.pointer+"/"+escapePointerToken(top.key)] = position(start)
+				continue
+			default:
+				pointer = top.pointer + "/" + escapePointerToken(top.key)
+				top.wantKey = true
+			}
+		} else {
+			positions[""] = position(start)
+		}
+
+		if isDelim {
+			stack = append(stack, &jsonFrame{pointer: pointer, array: delim == '[', wantKey: delim == '{'})
+		}
+	}
+}
+
+// skipJSONSeparators returns the offset of the next token at or after i,
+// past whitespace and the ':' and ',' separators the decoder does not
+// report as tokens.
+func skipJSONSeparators(s string, i int) int {
+	for i < len(s) {
+		switch s[i] {
+		case ' ', '\t', '\r', '\n', ':', ',':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
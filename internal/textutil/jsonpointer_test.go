@@ -0,0 +1,67 @@
+package textutil
+
+import (
+	"testing"
+)
+
+func TestJSONPointer(t *testing.T) {
+	tests := []struct {
+		tokens []any
+		want   string
+	}{
+		{nil, ""},
+		{[]any{"hooks", "PreToolUse", 0, "hooks", 1, "command"}, "/hooks/PreToolUse/0/hooks/1/command"},
+		{[]any{"env", "a/b~c"}, "/env/a~1b~0c"},
+	}
+	for _, tt := range tests {
+		if got := JSONPointer(tt.tokens...); got != tt.want {
+			t.Errorf("JSONPointer(%v) = %q, want %q", tt.tokens, got, tt.want)
+		}
+	}
+}
+
+func TestJSONPositions(t *testing.T) {
+	contents := `{
+  "hooks": {
+    "PreToolUse": [
+      {
+        "matcher": "Bash",
+        "hooks": [
+          {"type": "command", "command": "a.sh"},
+          {"type": "command", "command": "b.sh"}
+        ]
+      }
+    ]
+  },
+  "env": {"a/b": "1"},
+  "empty": [],
+  "n": 3
+}`
+	positions, err := JSONPositions(contents)
+	if err != nil {
+		t.Fatalf("JSONPositions() error = %v", err)
+	}
+	tests := []struct {
+		pointer string
+		want    JSONPosition
+	}{
+		{"", JSONPosition{1, 1}},
+		{"/hooks", JSONPosition{2, 3}},
+		{"/hooks/PreToolUse/0", JSONPosition{4, 7}},
+		{"/hooks/PreToolUse/0/matcher", JSONPosition{5, 9}},
+		{"/hooks/PreToolUse/0/hooks/1", JSONPosition{8, 11}},
+		{"/hooks/PreToolUse/0/hooks/1/command", JSONPosition{8, 31}},
+		{"/env/a~1b", JSONPosition{13, 11}},
+		{"/empty", JSONPosition{14, 3}},
+		{"/n", JSONPosition{15, 3}},
+	}
+	for _, tt := range tests {
+		if got, ok := positions[tt.pointer]; !ok || got != tt.want {
+			t.Errorf("positions[%q] = %v (found %v), want %v", tt.pointer, got, ok, tt.want)
+		}
+	}
+
+	if _, err := JSONPositions(`{"a": }`); err == nil {
+		t.Error("JSONPositions() on invalid JSON: want error")
+	}
+}
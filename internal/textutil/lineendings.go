@@ -0,0 +1,52 @@
+package textutil
+
+import "strings"
+
+// BOM is the UTF-8 byte order mark.
+const BOM = "\uFEFF"
+
+// Line ending policies, as accepted by the lineEndings config key. An
+// empty policy means LineEndingsAuto.
+const (
+	LineEndingsAuto = "auto"
+	LineEndingsLF   = "lf"
+	LineEndingsCRLF = "crlf"
+)
+
+// CountLineBreaks returns the number of bare LF and of CRLF line breaks in s.
+func CountLineBreaks(s string) (lf, crlf int) {
+	crlf = strings.Count(s, "\r\n")
+	return strings.Count(s, "\n") - crlf, crlf
+}
+
+// LineBreak returns the line break the lineEndings policy asks for in s:
+// "\r\n" for crlf, "\n" for lf, and for auto whichever break s uses most,
+// LF on a tie.
+func LineBreak(policy, s string) string {
+	switch policy {
+	case LineEndingsCRLF:
+		return "\r\n"
+	case LineEndingsLF:
+		return "\n"
+	}
+	if lf, crlf := CountLineBreaks(s); crlf > lf {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// ConvertLineBreaks rewrites every line break in s as eol ("\n" or "\r\n").
+func ConvertLineBreaks(s, eol string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	if eol == "\n" {
+		return s
+	}
+	return strings.ReplaceAll(s, "\n", eol)
+}
+
+// ExpandIndentTabs replaces each tab in the leading whitespace of line with
+// two spaces, the indentation YAML accepts.
+func ExpandIndentTabs(line string) string {
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	return strings.ReplaceAll(line[:indent], "\t", "  ") + line[indent:]
+}
@@ -282,33 +282,18 @@ func GetSkillImprovements(content string, data map[string]any) []ImprovementReco
 		})
 	}
 
-	return recs
-}
+	if _, body, ok := splitFrontmatter(content); ok {
+		if use, ok := AnalyzeSkillTools(data["allowed-tools"], body, nil); ok && len(use.Unused) > 0 {
+			recs = append(recs, ImprovementRecommendation{
+				Description: "Remove allowed-tools the skill never uses: " + strings.Join(use.Unused, ", "),
+				PointValue:  min(2*len(use.Unused), 6),
+				Line:        FindFrontmatterFieldLine(content, "allowed-tools"),
+				Severity:    SeverityMedium,
+			})
+		}
+	}
 
-// KnownTools is the set of valid Claude Code tool names.
-// Source: Claude Code documentation.
-var KnownTools = map[string]bool{
-	// File operations
-	"Read": true, "Write": true, "Edit": true, "MultiEdit": true,
-	"Glob": true, "Grep": true, "LS": true,
-	// Execution
-	"Bash": true, "Task": true, "Agent": true,
-	// Web
-	"WebFetch": true, "WebSearch": true,
-	// Interactive
-	"AskUserQuestion": true, "TodoWrite": true,
-	"TaskCreate": true, "TaskUpdate": true, "TaskList": true, "TaskGet": true, "TaskStop": true,
-	// Special
-	"Skill": true, "LSP": true, "NotebookEdit": true,
-	"EnterPlanMode": true, "ExitPlanMode": true,
-	"EnterWorktree": true, "ExitWorktree": true,
-	"KillShell": true, "TaskOutput": true, "SendMessage": true, "Monitor": true,
-	"RemoteTrigger": true, "Workflow": true,
-	// Scheduling / background
-	"CronCreate": true, "CronDelete": true, "CronList": true,
-	"ScheduleWakeup": true, "PushNotification": true, "REPL": true,
-	// Wildcards
-	"*": true,
+	return recs
 }
 
 // DeprecatedTools maps tool names to their deprecation message.
@@ -316,8 +301,9 @@ var DeprecatedTools = map[string]string{
 	"TaskOutput": "TaskOutput is deprecated (v2.1.83) — use Read on the background task's output file path instead",
 }
 
-// ValidateAllowedTools validates that allowed-tools and tools fields contain known tool names
-func ValidateAllowedTools(data map[string]any, filePath string, contents string) []types.ValidationError {
+// ValidateAllowedTools validates that allowed-tools and tools fields contain
+// known tool names: built-in tools and those in extraTools.
+func ValidateAllowedTools(extraTools []string, data map[string]any, filePath string, contents string) []types.ValidationError {
 	var warnings []types.ValidationError
 
 	// Check both "tools" and "allowed-tools" fields
@@ -329,18 +315,15 @@ func ValidateAllowedTools(data map[string]any, filePath string, contents string)
 			continue
 		}
 		// Parse comma-separated tools
-		for tool := range strings.SplitSeq(tools, ",") {
-			tool = strings.TrimSpace(tool)
-			if tool == "" {
-				continue
-			}
+		for _, tool := range SplitToolList(tools) {
 			baseTool := ExtractBaseToolName(tool)
-			if !KnownTools[baseTool] {
+			if !IsKnownTool(baseTool, extraTools) {
 				warnings = append(warnings, types.ValidationError{
 					File:     filePath,
-					Message:  fmt.Sprintf("Unknown tool '%s' in %s. Check spelling or verify it's a valid tool.", tool, field),
+					Message:  fmt.Sprintf("Unknown tool '%s' in %s. Check spelling, or list it in extraTools if your Claude Code release has it.", tool, field),
 					Severity: types.SeverityWarning,
 					Source:   types.SourceCClintObserve,
+					Rule:     types.RuleToolUnknown,
 					Line:     FindFrontmatterFieldLine(contents, field),
 				})
 			}
@@ -350,6 +333,7 @@ func ValidateAllowedTools(data map[string]any, filePath string, contents string)
 					Message:  fmt.Sprintf("Deprecated tool '%s' in %s. %s", tool, field, msg),
 					Severity: types.SeverityWarning,
 					Source:   types.SourceCClintObserve,
+					Rule:     types.RuleToolDeprecated,
 					Line:     FindFrontmatterFieldLine(contents, field),
 				})
 			}
@@ -359,6 +343,36 @@ func ValidateAllowedTools(data map[string]any, filePath string, contents string)
 	return warnings
 }
 
+// SplitToolList splits a comma-separated tools string into trimmed, non-empty
+// entries. Commas inside parentheses belong to the entry, so
+// "Task(a, b), Bash(git add:*)" yields two entries, not three.
+func SplitToolList(tools string) []string {
+	var entries []string
+	depth, start := 0, 0
+	flush := func(end int) {
+		if entry := strings.TrimSpace(tools[start:end]); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	for i, r := range tools {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				flush(i)
+				start = i + 1
+			}
+		}
+	}
+	flush(len(tools))
+	return entries
+}
+
 // extractBaseToolName returns the base tool name from patterns like "Task(name)" or "Bash(npm:*)".
 func ExtractBaseToolName(tool string) string {
 	baseTool := tool
@@ -386,6 +400,7 @@ func ValidateToolFieldName(data map[string]any, filePath string, contents string
 				Message:  "Agents must use 'tools:', not 'allowed-tools:'. Rename the field.",
 				Severity: types.SeverityError,
 				Source:   types.SourceCClintObserve,
+				Rule:     types.RuleToolsFieldName,
 				Line:     FindFrontmatterFieldLine(contents, "allowed-tools"),
 			})
 		}
@@ -397,6 +412,7 @@ func ValidateToolFieldName(data map[string]any, filePath string, contents string
 				Message:  fmt.Sprintf("%ss must use 'allowed-tools:', not 'tools:'. Rename the field.", cases.Title(language.English).String(componentType)),
 				Severity: types.SeverityError,
 				Source:   types.SourceCClintObserve,
+				Rule:     types.RuleToolsFieldName,
 				Line:     FindFrontmatterFieldLine(contents, "tools"),
 			})
 		}
@@ -518,9 +534,19 @@ func DetectSecrets(contents string, filePath string) []types.ValidationError {
 			Message:  sp.message,
 			Severity: types.SeverityWarning,
 			Source:   types.SourceCClintObserve,
+			Rule:     types.RuleSecretDetected,
 			Line:     lineNum,
 		})
 	}
 
 	return warnings
 }
+
+// charsPerToken is the rough characters-per-token ratio used to estimate
+// prompt size for English prose and markdown.
+const charsPerToken = 4
+
+// EstimateTokens returns a rough token count for text.
+func EstimateTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
@@ -1,6 +1,7 @@
 package textutil
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -155,7 +156,7 @@ func TestValidateAllowedTools(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := ValidateAllowedTools(tt.data, tt.filePath, "")
+			errors := ValidateAllowedTools(nil, tt.data, tt.filePath, "")
 			if len(errors) < tt.wantErrCount {
 				t.Errorf("ValidateAllowedTools() errors = %d, want at least %d", len(errors), tt.wantErrCount)
 			}
@@ -410,11 +411,18 @@ func TestValidateAllowedToolsUnknownTool(t *testing.T) {
 			},
 			wantWarnings: 0,
 		},
+		{
+			name: "comma inside parentheses",
+			data: map[string]any{
+				"allowed-tools": "Bash(git add:*, git commit:*), Read",
+			},
+			wantWarnings: 0,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			warnings := ValidateAllowedTools(tt.data, "test.md", "---\nallowed-tools: test\n---\n")
+			warnings := ValidateAllowedTools(nil, tt.data, "test.md", "---\nallowed-tools: test\n---\n")
 			if len(warnings) != tt.wantWarnings {
 				t.Errorf("ValidateAllowedTools() warnings = %d, want %d", len(warnings), tt.wantWarnings)
 				for _, w := range warnings {
@@ -425,6 +433,29 @@ func TestValidateAllowedToolsUnknownTool(t *testing.T) {
 	}
 }
 
+func TestSplitToolList(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"simple", "Read, Write", []string{"Read", "Write"}},
+		{"empty entries dropped", "Read,, ,Write,", []string{"Read", "Write"}},
+		{"comma in parens", "Task(a, b), Bash(git add:*)", []string{"Task(a, b)", "Bash(git add:*)"}},
+		{"unbalanced close", "Read), Write", []string{"Read)", "Write"}},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitToolList(tt.input)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+				t.Errorf("SplitToolList(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidateToolFieldNameEdgeCases(t *testing.T) {
 	tests := []struct {
 		name          string
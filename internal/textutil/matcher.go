@@ -0,0 +1,92 @@
+package textutil
+
+// Matcher finds occurrences of many patterns in a single pass over the text
+// (Aho-Corasick), so checking a document against a large wordlist costs
+// roughly the same as scanning it once. Matching is ASCII case-insensitive.
+type Matcher struct {
+	next     []map[byte]int // trie transitions per state
+	fail     []int          // longest proper suffix that is also a trie path
+	out      [][]int        // pattern indexes ending at each state
+	patterns []string
+}
+
+// Match is one occurrence of a pattern: Pattern indexes the slice given to
+// NewMatcher, and text[Start:End] is the matched text.
+type Match struct {
+	Pattern    int
+	Start, End int
+}
+
+// NewMatcher builds a matcher for patterns. Empty patterns are ignored.
+func NewMatcher(patterns []string) *Matcher {
+	m := &Matcher{next: []map[byte]int{{}}, fail: []int{0}, out: [][]int{nil}, patterns: patterns}
+
+	for i, p := range patterns {
+		if p == "" {
+			continue
+		}
+		state := 0
+		for j := 0; j < len(p); j++ {
+			c := foldASCII(p[j])
+			nxt, ok := m.next[state][c]
+			if !ok {
+				nxt = len(m.next)
+				m.next = append(m.next, map[byte]int{})
+				m.fail = append(m.fail, 0)
+				m.out = append(m.out, nil)
+				m.next[state][c] = nxt
+			}
+			state = nxt
+		}
+		m.out[state] = append(m.out[state], i)
+	}
+
+	// Breadth-first over the trie: a state's failure link is found by
+	// following its parent's failure links until one has the same edge.
+	queue := make([]int, 0, len(m.next))
+	for _, s := range m.next[0] {
+		queue = append(queue, s)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for c, child := range m.next[state] {
+			queue = append(queue, child)
+			f := m.fail[state]
+			for f > 0 && m.next[f][c] == 0 {
+				f = m.fail[f]
+			}
+			if target, ok := m.next[f][c]; ok && target != child {
+				m.fail[child] = target
+			}
+			m.out[child] = append(m.out[child], m.out[m.fail[child]]...)
+		}
+	}
+	return m
+}
+
+// FindAll returns every occurrence of every pattern in text, including
+// overlapping ones, ordered by end position.
+func (m *Matcher) FindAll(text string) []Match {
+	var matches []Match
+	state := 0
+	for i := 0; i < len(text); i++ {
+		c := foldASCII(text[i])
+		for state > 0 && m.next[state][c] == 0 {
+			state = m.fail[state]
+		}
+		state = m.next[state][c] // 0 (root) when there is no edge
+		for _, p := range m.out[state] {
+			matches = append(matches, Match{Pattern: p, Start: i + 1 - len(m.patterns[p]), End: i + 1})
+		}
+	}
+	return matches
+}
+
+// foldASCII lowercases ASCII letters.
+func foldASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
@@ -0,0 +1,55 @@
+package textutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMatcher_FindAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		text     string
+		want     []string // pattern@start per match, in order
+	}{
+		{
+			name:     "overlapping patterns",
+			patterns: []string{"he", "she", "his", "hers"},
+			text:     "ushers",
+			want:     []string{"she@1", "he@2", "hers@2"},
+		},
+		{
+			name:     "case insensitive",
+			patterns: []string{"sub-agent"},
+			text:     "A Sub-Agent runs",
+			want:     []string{"sub-agent@2"},
+		},
+		{
+			name:     "failure links across partial matches",
+			patterns: []string{"abcd", "bc"},
+			text:     "abcabcd",
+			want:     []string{"bc@1", "bc@4", "abcd@3"},
+		},
+		{
+			name:     "no match and empty pattern",
+			patterns: []string{"", "xyz"},
+			text:     "abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, m := range NewMatcher(tt.patterns).FindAll(tt.text) {
+				if !strings.EqualFold(tt.text[m.Start:m.End], tt.patterns[m.Pattern]) {
+					t.Errorf("match %v covers %q, want %q", m, tt.text[m.Start:m.End], tt.patterns[m.Pattern])
+				}
+				got = append(got, fmt.Sprintf("%s@%d", tt.patterns[m.Pattern], m.Start))
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
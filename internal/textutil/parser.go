@@ -8,12 +8,44 @@ import (
 
 // Frontmatter represents parsed frontmatter data
 type Frontmatter struct {
-	Data  map[string]any
-	Body  string
+	Data map[string]any
+	Body string
+	// DuplicateKeys lists mapping keys that appeared more than once. The
+	// last occurrence wins in Data, matching what most YAML loaders keep.
+	DuplicateKeys []DuplicateKey
+	// Aliases lists the aliases (*name) and merge keys (<<) in the
+	// frontmatter, in document order. Data holds the values they resolve
+	// to; explicit keys win over merged ones.
+	Aliases []Alias
+}
+
+// Alias is a use of a YAML anchor in frontmatter. Name is the anchor the
+// alias refers to, empty for a merge key whose value is an inline mapping.
+// Merge is set when the alias is the value of a << merge key. Line is the
+// 1-based file line.
+type Alias struct {
+	Name  string
+	Line  int
+	Merge bool
+}
+
+// DuplicateKey describes a repeated mapping key in frontmatter.
+// Path is the dotted key path ("hooks.PreToolUse" for nested maps).
+// Line and FirstLine are 1-based file line numbers of the repeat and of
+// the first occurrence.
+type DuplicateKey struct {
+	Path      string
+	Line      int
+	FirstLine int
 }
 
 // ParseYAMLFrontmatter extracts YAML frontmatter from markdown content.
 // Frontmatter must start at the beginning of the file with "---".
+//
+// Duplicate mapping keys are tolerated: the YAML is parsed at node level,
+// repeats are recorded in DuplicateKeys, and only the last occurrence is
+// decoded. This keeps the rest of the file lintable instead of failing the
+// whole parse on a single repeated key.
 func ParseYAMLFrontmatter(content string) (*Frontmatter, error) {
 	// Frontmatter must start at the very beginning of the file
 	trimmed := strings.TrimLeft(content, " \t")
@@ -25,29 +57,143 @@ func ParseYAMLFrontmatter(content string) (*Frontmatter, error) {
 		}, nil
 	}
 
-	// Split content by ---
-	parts := strings.SplitN(content, "---", 3)
-
-	// If we have less than 3 parts, there's no closing ---
-	if len(parts) < 3 {
+	frontmatterYAML, body, ok := splitFrontmatter(content)
+	if !ok {
 		return &Frontmatter{
 			Data: make(map[string]any),
 			Body: content,
 		}, nil
 	}
 
-	// The frontmatter is the part between the first pair of ---
-	frontmatterYAML := parts[1]
-	body := parts[2]
+	// Parse YAML content at node level so duplicate keys can be reported.
+	// The YAML text starts on the same line as the opening ---, so node
+	// line numbers are already file line numbers.
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(frontmatterYAML), &doc); err != nil {
+		return nil, err
+	}
 
-	// Parse YAML content
 	var data map[string]any
-	if err := yaml.Unmarshal([]byte(frontmatterYAML), &data); err != nil {
-		return nil, err
+	var duplicates []DuplicateKey
+	var aliases []Alias
+	if len(doc.Content) > 0 {
+		duplicates = dropDuplicateKeys(doc.Content[0], "")
+		aliases = collectAliases(doc.Content[0])
+		if err := doc.Content[0].Decode(&data); err != nil {
+			return nil, err
+		}
 	}
 
 	return &Frontmatter{
-		Data: data,
-		Body: body,
+		Data:          data,
+		Body:          body,
+		DuplicateKeys: duplicates,
+		Aliases:       aliases,
 	}, nil
-}
\ No newline at end of file
+}
+
+// splitFrontmatter splits content into the YAML between the first pair of
+// --- markers and the body after them. ok is false when there is no
+// closing ---.
+func splitFrontmatter(content string) (frontmatterYAML, body string, ok bool) {
+	parts := strings.SplitN(content, "---", 3)
+	if len(parts) < 3 {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// dropDuplicateKeys removes all but the last occurrence of each key from
+// mapping nodes (recursively) and returns the removed repeats.
+func dropDuplicateKeys(node *yaml.Node, prefix string) []DuplicateKey {
+	var duplicates []DuplicateKey
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		firstLine := make(map[string]int)
+		lastIndex := make(map[string]int)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Kind != yaml.ScalarNode || key.Tag == "!!merge" {
+				continue
+			}
+			if _, seen := firstLine[key.Value]; seen {
+				duplicates = append(duplicates, DuplicateKey{
+					Path:      joinKeyPath(prefix, key.Value),
+					Line:      key.Line,
+					FirstLine: firstLine[key.Value],
+				})
+			} else {
+				firstLine[key.Value] = key.Line
+			}
+			lastIndex[key.Value] = i
+		}
+
+		kept := node.Content[:0]
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Kind == yaml.ScalarNode && key.Tag != "!!merge" && lastIndex[key.Value] != i {
+				continue
+			}
+			duplicates = append(duplicates, dropDuplicateKeys(value, joinKeyPath(prefix, key.Value))...)
+			kept = append(kept, key, value)
+		}
+		node.Content = kept
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			duplicates = append(duplicates, dropDuplicateKeys(child, prefix)...)
+		}
+	}
+
+	return duplicates
+}
+
+// collectAliases returns the aliases and merge keys under node, in document
+// order. Aliases are not followed, so each is reported once where it is
+// written.
+func collectAliases(node *yaml.Node) []Alias {
+	var aliases []Alias
+	switch node.Kind {
+	case yaml.AliasNode:
+		aliases = append(aliases, Alias{Name: node.Value, Line: node.Line})
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Kind == yaml.ScalarNode && key.Tag == "!!merge" {
+				aliases = append(aliases, mergeAliases(key, value)...)
+				continue
+			}
+			aliases = append(aliases, collectAliases(key)...)
+			aliases = append(aliases, collectAliases(value)...)
+		}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			aliases = append(aliases, collectAliases(child)...)
+		}
+	}
+	return aliases
+}
+
+// mergeAliases records the value of a << merge key: each alias it merges,
+// or the key itself for an inline mapping.
+func mergeAliases(key, value *yaml.Node) []Alias {
+	switch value.Kind {
+	case yaml.AliasNode:
+		return []Alias{{Name: value.Value, Line: value.Line, Merge: true}}
+	case yaml.SequenceNode:
+		var aliases []Alias
+		for _, child := range value.Content {
+			aliases = append(aliases, mergeAliases(child, child)...)
+		}
+		return aliases
+	default:
+		return append([]Alias{{Line: key.Line, Merge: true}}, collectAliases(value)...)
+	}
+}
+
+func joinKeyPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
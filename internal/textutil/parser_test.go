@@ -555,3 +555,80 @@ func BenchmarkParseYAMLFrontmatter_LargeFrontmatter(b *testing.B) {
 		_, _ = ParseYAMLFrontmatter(input)
 	}
 }
+
+func TestParseYAMLFrontmatter_DuplicateKeys(t *testing.T) {
+	t.Run("top_level_last_wins", func(t *testing.T) {
+		input := "---\nname: first\nmodel: sonnet\nname: second\n---\nBody"
+		result, err := ParseYAMLFrontmatter(input)
+		require.NoError(t, err)
+		assert.Equal(t, "second", result.Data["name"])
+		assert.Equal(t, "sonnet", result.Data["model"])
+		assert.Equal(t, []DuplicateKey{{Path: "name", Line: 4, FirstLine: 2}}, result.DuplicateKeys)
+	})
+
+	t.Run("nested_mapping", func(t *testing.T) {
+		input := "---\nname: x\nhooks:\n  PreToolUse: a\n  PreToolUse: b\n---\n"
+		result, err := ParseYAMLFrontmatter(input)
+		require.NoError(t, err)
+		hooks, ok := result.Data["hooks"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "b", hooks["PreToolUse"])
+		assert.Equal(t, []DuplicateKey{{Path: "hooks.PreToolUse", Line: 5, FirstLine: 4}}, result.DuplicateKeys)
+	})
+
+	t.Run("triple_repeat", func(t *testing.T) {
+		input := "---\na: 1\na: 2\na: 3\n---\n"
+		result, err := ParseYAMLFrontmatter(input)
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Data["a"])
+		require.Len(t, result.DuplicateKeys, 2)
+		assert.Equal(t, 2, result.DuplicateKeys[1].FirstLine)
+		assert.Equal(t, 4, result.DuplicateKeys[1].Line)
+	})
+
+	t.Run("no_duplicates", func(t *testing.T) {
+		result, err := ParseYAMLFrontmatter("---\nname: x\n---\n")
+		require.NoError(t, err)
+		assert.Empty(t, result.DuplicateKeys)
+	})
+}
+
+func TestParseYAMLFrontmatter_Aliases(t *testing.T) {
+	t.Run("alias_resolves_to_anchor", func(t *testing.T) {
+		input := "---\nx-tools: &tools Read, Grep\nname: a\ntools: *tools\n---\n"
+		result, err := ParseYAMLFrontmatter(input)
+		require.NoError(t, err)
+		assert.Equal(t, "Read, Grep", result.Data["tools"])
+		assert.Equal(t, []Alias{{Name: "tools", Line: 4}}, result.Aliases)
+	})
+
+	t.Run("merge_key_explicit_wins", func(t *testing.T) {
+		input := "---\nx-base: &base\n  model: sonnet\n  color: blue\nname: a\nhooks:\n  <<: *base\n  model: haiku\n---\n"
+		result, err := ParseYAMLFrontmatter(input)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"model": "haiku", "color": "blue"}, result.Data["hooks"])
+		assert.Equal(t, []Alias{{Name: "base", Line: 7, Merge: true}}, result.Aliases)
+	})
+
+	t.Run("merge_sequence_first_wins", func(t *testing.T) {
+		input := "---\na: &a {k: 1}\nb: &b {k: 2, j: 3}\nc:\n  <<: [*a, *b]\n---\n"
+		result, err := ParseYAMLFrontmatter(input)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"k": 1, "j": 3}, result.Data["c"])
+		assert.Equal(t, []Alias{{Name: "a", Line: 5, Merge: true}, {Name: "b", Line: 5, Merge: true}}, result.Aliases)
+	})
+
+	t.Run("inline_merge", func(t *testing.T) {
+		input := "---\nname: a\n<<: {model: opus}\n---\n"
+		result, err := ParseYAMLFrontmatter(input)
+		require.NoError(t, err)
+		assert.Equal(t, "opus", result.Data["model"])
+		assert.Equal(t, []Alias{{Line: 3, Merge: true}}, result.Aliases)
+	})
+
+	t.Run("no_aliases", func(t *testing.T) {
+		result, err := ParseYAMLFrontmatter("---\nname: x\n---\n")
+		require.NoError(t, err)
+		assert.Empty(t, result.Aliases)
+	})
+}
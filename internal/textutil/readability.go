@@ -0,0 +1,96 @@
+package textutil
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Readability holds simple readability metrics for a short piece of prose
+// such as a component description.
+type Readability struct {
+	Sentences       int
+	Words           int
+	LongestSentence int      // words in the longest sentence
+	Passive         []string // passive phrases, one per passive sentence
+	Directive       bool     // a sentence opens with an imperative such as "Use"
+}
+
+// ReadabilityLimits are the thresholds a description is held to.
+type ReadabilityLimits struct {
+	MaxSentenceWords int
+	MaxPassiveRatio  float64 // passive sentences / sentences
+	RequireDirective bool
+}
+
+// Readability strictness levels, as set by the rules.readability key.
+const (
+	ReadabilityOff     = "off"
+	ReadabilityLenient = "lenient"
+	ReadabilityNormal  = "normal"
+	ReadabilityStrict  = "strict"
+)
+
+// ReadabilityLevels maps each strictness level except "off" to its limits.
+var ReadabilityLevels = map[string]ReadabilityLimits{
+	ReadabilityLenient: {MaxSentenceWords: 40, MaxPassiveRatio: 0.5},
+	ReadabilityNormal:  {MaxSentenceWords: 30, MaxPassiveRatio: 0.34},
+	ReadabilityStrict:  {MaxSentenceWords: 25, MaxPassiveRatio: 0, RequireDirective: true},
+}
+
+var (
+	// exampleBlockPattern matches the <example> blocks agent descriptions
+	// use to show delegation; they are dialogue, not prose to measure.
+	exampleBlockPattern = regexp.MustCompile(`(?s)<example>.*?</example>`)
+	sentenceEndPattern  = regexp.MustCompile(`[.!?]+(\s+|$)|\n+`)
+	// passivePattern matches a form of "to be" followed, optionally after an
+	// adverb, by a regular or common irregular past participle.
+	passivePattern = regexp.MustCompile(`(?i)\b(is|are|was|were|be|been|being)\s+(\w+ly\s+)?(\w+ed|built|done|given|known|made|run|seen|sent|shown|taken|written)\b`)
+)
+
+// directiveVerbs open a sentence that tells the model when to act.
+var directiveVerbs = map[string]bool{
+	"use": true, "invoke": true, "call": true, "delegate": true, "trigger": true,
+	"apply": true, "load": true, "consult": true, "run": true, "spawn": true,
+}
+
+// MeasureReadability computes readability metrics for text. Sentences end
+// at ".", "!", "?", or a line break; <example> blocks are skipped.
+func MeasureReadability(text string) Readability {
+	var r Readability
+	text = exampleBlockPattern.ReplaceAllString(text, "")
+	for _, sentence := range sentenceEndPattern.Split(text, -1) {
+		words := strings.Fields(sentence)
+		if len(words) == 0 {
+			continue
+		}
+		r.Sentences++
+		r.Words += len(words)
+		r.LongestSentence = max(r.LongestSentence, len(words))
+		if m := passivePattern.FindString(sentence); m != "" {
+			r.Passive = append(r.Passive, m)
+		}
+		if directiveVerbs[strings.ToLower(strings.Trim(words[0], `*_"'`))] {
+			r.Directive = true
+		}
+	}
+	return r
+}
+
+// Check returns one human-readable problem per limit r exceeds.
+func (r Readability) Check(l ReadabilityLimits) []string {
+	if r.Sentences == 0 {
+		return nil
+	}
+	var problems []string
+	if r.LongestSentence > l.MaxSentenceWords {
+		problems = append(problems, fmt.Sprintf("longest sentence has %d words (limit %d); split it so the trigger conditions stand out", r.LongestSentence, l.MaxSentenceWords))
+	}
+	if float64(len(r.Passive))/float64(r.Sentences) > l.MaxPassiveRatio {
+		problems = append(problems, fmt.Sprintf("%d of %d sentences use passive voice (%q); say who does what", len(r.Passive), r.Sentences, r.Passive[0]))
+	}
+	if l.RequireDirective && !r.Directive {
+		problems = append(problems, "no sentence opens with a directive such as \"Use when ...\"; tell the model when to delegate")
+	}
+	return problems
+}
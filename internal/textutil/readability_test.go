@@ -0,0 +1,87 @@
+package textutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasureReadability(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		wantSentences int
+		wantLongest   int
+		wantPassive   []string
+		wantDirective bool
+	}{
+		{
+			name:          "active with directive",
+			text:          "Reviews Go code for bugs. Use when a PR is ready!",
+			wantSentences: 2,
+			wantLongest:   6,
+			wantDirective: true,
+		},
+		{
+			name:          "passive regular and irregular",
+			text:          "Code is reviewed here. Reports are written to disk. Tests run fast",
+			wantSentences: 3,
+			wantLongest:   5,
+			wantPassive:   []string{"is reviewed", "are written"},
+		},
+		{
+			name:          "adverb between be and participle",
+			text:          "Errors are quickly fixed.",
+			wantSentences: 1,
+			wantLongest:   4,
+			wantPassive:   []string{"are quickly fixed"},
+		},
+		{
+			name:          "line breaks end sentences and examples are skipped",
+			text:          "Use PROACTIVELY for refactors\n<example>\nuser: it was broken\n</example>",
+			wantSentences: 1,
+			wantLongest:   4,
+			wantDirective: true,
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := MeasureReadability(tt.text)
+			assert.Equal(t, tt.wantSentences, r.Sentences)
+			assert.Equal(t, tt.wantLongest, r.LongestSentence)
+			assert.Equal(t, tt.wantPassive, r.Passive)
+			assert.Equal(t, tt.wantDirective, r.Directive)
+		})
+	}
+}
+
+func TestReadabilityCheck(t *testing.T) {
+	long := "Handles " + strings.Repeat("word ", 32) + "now."
+	tests := []struct {
+		name  string
+		text  string
+		level string
+		want  int
+	}{
+		{name: "clean at normal", text: "Reviews code. Use after edits.", level: ReadabilityNormal},
+		{name: "long sentence at normal", text: long, level: ReadabilityNormal, want: 1},
+		{name: "long sentence within lenient", text: long, level: ReadabilityLenient},
+		{name: "one passive in three at normal", text: "Reviews code. Output is saved. Use after edits.", level: ReadabilityNormal},
+		{name: "one passive in three at strict", text: "Reviews code. Output is saved. Use after edits.", level: ReadabilityStrict, want: 1},
+		{name: "mostly passive at lenient", text: "Code is reviewed. Output is saved.", level: ReadabilityLenient, want: 1},
+		{name: "no directive at strict", text: "Reviews code.", level: ReadabilityStrict, want: 1},
+		{name: "empty has no problems", level: ReadabilityStrict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MeasureReadability(tt.text).Check(ReadabilityLevels[tt.level])
+			assert.Len(t, got, tt.want, "%v", got)
+		})
+	}
+}
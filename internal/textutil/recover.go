@@ -0,0 +1,147 @@
+package textutil
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlErrorPattern matches the position yaml.v3 puts in a syntax error,
+// e.g. "yaml: line 3: mapping values are not allowed in this context".
+var yamlErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// YAMLSyntaxError is a syntax error in frontmatter YAML. Line is the 1-based
+// file line. Key is the top-level key whose entry the error is in, and
+// whose value could not be recovered; it is empty when the error is not
+// inside a key's entry.
+type YAMLSyntaxError struct {
+	Line    int
+	Key     string
+	Message string
+}
+
+// RecoverYAMLFrontmatter parses frontmatter that ParseYAMLFrontmatter
+// rejects, keeping what can be read. The YAML is split into top-level
+// entries, a key line and the indented lines, list items, and comments
+// after it, and each entry is parsed on its own. Data holds the keys of
+// the entries that parse; each entry that does not is returned as a syntax
+// error. When every entry parses on its own, the error of the document as
+// a whole is returned instead.
+//
+// Content ParseYAMLFrontmatter accepts is returned as it parses, with no
+// errors.
+func RecoverYAMLFrontmatter(content string) (*Frontmatter, []YAMLSyntaxError) {
+	fm, err := ParseYAMLFrontmatter(content)
+	if err == nil {
+		return fm, nil
+	}
+	frontmatterYAML, body, _ := splitFrontmatter(content)
+	fm = &Frontmatter{Data: make(map[string]any), Body: body}
+
+	var errs []YAMLSyntaxError
+	keyLines := make(map[string]int)
+	lines := strings.Split(frontmatterYAML, "\n")
+	for _, entry := range splitYAMLEntries(lines) {
+		// Pad with newlines so the parser's line numbers are file lines:
+		// line i of the frontmatter YAML is file line i+1.
+		text := strings.Repeat("\n", entry.start) + strings.Join(lines[entry.start:entry.end], "\n")
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(text), &doc); err != nil {
+			errs = append(errs, entry.syntaxError(err))
+			continue
+		}
+		if len(doc.Content) == 0 {
+			continue // comments only
+		}
+		node := doc.Content[0]
+		if node.Kind != yaml.MappingNode {
+			errs = append(errs, YAMLSyntaxError{Line: entry.start + 1, Key: entry.key, Message: "expected a key: value pair"})
+			continue
+		}
+		fm.DuplicateKeys = append(fm.DuplicateKeys, dropDuplicateKeys(node, "")...)
+		aliases := collectAliases(node)
+		var data map[string]any
+		if err := node.Decode(&data); err != nil {
+			errs = append(errs, entry.syntaxError(err))
+			continue
+		}
+		fm.Aliases = append(fm.Aliases, aliases...)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if first, seen := keyLines[key.Value]; seen {
+				fm.DuplicateKeys = append(fm.DuplicateKeys, DuplicateKey{Path: key.Value, Line: key.Line, FirstLine: first})
+			} else {
+				keyLines[key.Value] = key.Line
+			}
+		}
+		for k, v := range data {
+			fm.Data[k] = v
+		}
+	}
+
+	if len(errs) == 0 {
+		errs = append(errs, yamlSyntaxError(err, 1, ""))
+	}
+	return fm, errs
+}
+
+// yamlEntry is a top-level frontmatter entry: lines [start, end) of the
+// frontmatter YAML, and the key it defines.
+type yamlEntry struct {
+	start, end int
+	key        string
+}
+
+// syntaxError converts the error of parsing the entry on its own. yaml.v3
+// counts lines from 0 in parser errors and from 1 in scanner errors, so
+// the line is kept within the entry.
+func (e yamlEntry) syntaxError(err error) YAMLSyntaxError {
+	se := yamlSyntaxError(err, e.start+1, e.key)
+	se.Line = min(max(se.Line, e.start+1), e.end)
+	return se
+}
+
+// splitYAMLEntries splits frontmatter YAML lines into top-level entries.
+// An entry starts at each line with no indentation that is not a comment
+// or a list item; lines before the first entry form one of their own.
+func splitYAMLEntries(lines []string) []yamlEntry {
+	var entries []yamlEntry
+	current := yamlEntry{}
+	for i, line := range lines {
+		if i == 0 || line == "" || strings.ContainsRune(" \t#-", rune(line[0])) {
+			continue
+		}
+		if i > current.start {
+			current.end = i
+			entries = append(entries, current)
+		}
+		current = yamlEntry{start: i, key: entryKey(line)}
+	}
+	current.end = len(lines)
+	return append(entries, current)
+}
+
+// entryKey returns the key a top-level entry line defines, or "" when the
+// line has no key: separator.
+func entryKey(line string) string {
+	key, _, ok := strings.Cut(line, ":")
+	if !ok {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(key), `"'`)
+}
+
+// yamlSyntaxError converts a yaml.v3 error to a YAMLSyntaxError, at line
+// when the error does not carry its own.
+func yamlSyntaxError(err error, line int, key string) YAMLSyntaxError {
+	msg := err.Error()
+	if m := yamlErrorPattern.FindStringSubmatch(msg); m != nil {
+		if n, convErr := strconv.Atoi(m[1]); convErr == nil {
+			line = n
+		}
+		msg = m[2]
+	}
+	return YAMLSyntaxError{Line: line, Key: key, Message: strings.TrimPrefix(msg, "yaml: ")}
+}
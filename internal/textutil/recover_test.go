@@ -0,0 +1,75 @@
+package textutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverYAMLFrontmatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantData map[string]any
+		wantErrs []YAMLSyntaxError
+	}{
+		{
+			name:     "valid frontmatter",
+			input:    "---\nname: a\nmodel: sonnet\n---\nbody\n",
+			wantData: map[string]any{"name": "a", "model": "sonnet"},
+		},
+		{
+			name:     "unquoted colon in one value",
+			input:    "---\nname: a\ndescription: Use when: reviewing\nmodel: sonnet\n---\nbody\n",
+			wantData: map[string]any{"name": "a", "model": "sonnet"},
+			wantErrs: []YAMLSyntaxError{{Line: 3, Key: "description", Message: "mapping values are not allowed in this context"}},
+		},
+		{
+			name: "two broken entries",
+			input: "---\nname: a\ntools: [Read, Grep\ndescription: Reviews code\n" +
+				"color: \"blue\nmodel: sonnet\n---\n",
+			wantData: map[string]any{"name": "a", "description": "Reviews code", "model": "sonnet"},
+			wantErrs: []YAMLSyntaxError{
+				{Line: 3, Key: "tools"},
+				{Line: 5, Key: "color"},
+			},
+		},
+		{
+			name:     "nested values and list items stay with their key",
+			input:    "---\nname: a\nhooks:\n  Stop:\n    - command: x\nskills:\n- one\n- two\nbad: [\n---\n",
+			wantData: map[string]any{"name": "a", "hooks": map[string]any{"Stop": []any{map[string]any{"command": "x"}}}, "skills": []any{"one", "two"}},
+			wantErrs: []YAMLSyntaxError{{Line: 9, Key: "bad"}},
+		},
+		{
+			name:     "line without a key",
+			input:    "---\nname: a\njust some text\n---\n",
+			wantData: map[string]any{"name": "a"},
+			wantErrs: []YAMLSyntaxError{{Line: 3, Message: "expected a key: value pair"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, errs := RecoverYAMLFrontmatter(tt.input)
+			require.NotNil(t, fm)
+			assert.Equal(t, tt.wantData, fm.Data)
+			require.Len(t, errs, len(tt.wantErrs), "errors: %+v", errs)
+			for i, want := range tt.wantErrs {
+				assert.Equal(t, want.Line, errs[i].Line, "line of error %d", i)
+				assert.Equal(t, want.Key, errs[i].Key, "key of error %d", i)
+				if want.Message != "" {
+					assert.Equal(t, want.Message, errs[i].Message)
+				}
+				assert.NotEmpty(t, errs[i].Message)
+			}
+		})
+	}
+}
+
+func TestRecoverYAMLFrontmatter_DuplicateKeysAcrossEntries(t *testing.T) {
+	fm, errs := RecoverYAMLFrontmatter("---\nname: a\nbad: [\nname: b\n---\n")
+	require.Len(t, errs, 1)
+	assert.Equal(t, "b", fm.Data["name"])
+	assert.Equal(t, []DuplicateKey{{Path: "name", Line: 4, FirstLine: 2}}, fm.DuplicateKeys)
+}
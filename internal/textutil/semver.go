@@ -0,0 +1,308 @@
+package textutil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version. Build metadata is dropped; a prerelease
+// sorts before its release.
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 string
+}
+
+// versionPattern matches a full version, with an optional leading v.
+var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// ParseVersion parses a full semantic version such as 1.2.3 or v2.0.0-rc.1.
+func ParseVersion(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, fmt.Errorf("%q is not a semantic version (major.minor.patch)", s)
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	patch, _ := strconv.Atoi(m[3])
+	return Version{Major: major, Minor: minor, Patch: patch, Pre: m[4]}, nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Compare returns -1, 0, or 1 as v sorts before, with, or after o.
+// Prereleases compare by their identifiers, numeric ones numerically.
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+	switch {
+	case v.Pre == o.Pre:
+		return 0
+	case v.Pre == "":
+		return 1
+	case o.Pre == "":
+		return -1
+	}
+	a, b := strings.Split(v.Pre, "."), strings.Split(o.Pre, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		x, errX := strconv.Atoi(a[i])
+		y, errY := strconv.Atoi(b[i])
+		switch {
+		case errX == nil && errY == nil:
+			return sign(x - y)
+		case errX == nil:
+			return -1
+		case errY == nil:
+			return 1
+		}
+		return sign(strings.Compare(a[i], b[i]))
+	}
+	return sign(len(a) - len(b))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// bound is one end of a version interval; a nil version is unbounded.
+type bound struct {
+	v         *Version
+	inclusive bool
+}
+
+// interval is the versions between a lower and an upper bound.
+type interval struct {
+	lo, hi bound
+}
+
+// VersionRange is a version constraint in npm syntax: comparators such as
+// >=1.2.0 <2.0.0 joined by spaces, ^ and ~ ranges, x wildcards, hyphen
+// ranges, and alternatives separated by ||.
+type VersionRange struct {
+	alts []interval
+}
+
+// comparatorPattern matches one comparator: an operator and a version
+// that may be partial or use x wildcards.
+var comparatorPattern = regexp.MustCompile(`^(>=|<=|>|<|=|\^|~)?\s*v?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// ParseVersionRange parses an npm-style version range.
+func ParseVersionRange(s string) (VersionRange, error) {
+	var r VersionRange
+	for alt := range strings.SplitSeq(s, "||") {
+		iv, err := parseRangeSet(strings.TrimSpace(alt))
+		if err != nil {
+			return VersionRange{}, fmt.Errorf("invalid version range %q: %w", s, err)
+		}
+		r.alts = append(r.alts, iv)
+	}
+	return r, nil
+}
+
+// parseRangeSet parses the comparators of one alternative into the
+// interval they allow.
+func parseRangeSet(s string) (interval, error) {
+	var iv interval
+	if s == "" || s == "*" || s == "x" || s == "X" {
+		return iv, nil
+	}
+	if from, to, ok := strings.Cut(s, " - "); ok {
+		lo, err := parseComparator(strings.TrimSpace(from))
+		if err != nil {
+			return iv, err
+		}
+		hi, err := parseComparator("<=" + strings.TrimSpace(to))
+		if err != nil {
+			return iv, err
+		}
+		iv.lo, iv.hi = lo.lo, hi.hi
+		return iv, nil
+	}
+	// Operators may be separated from their version: ">= 1.2.0".
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if strings.Trim(f, "<>=^~") == "" && i+1 < len(fields) {
+			i++
+			f += fields[i]
+		}
+		c, err := parseComparator(f)
+		if err != nil {
+			return iv, err
+		}
+		iv = iv.intersect(c)
+	}
+	return iv, nil
+}
+
+// parseComparator turns one comparator into the interval it allows.
+func parseComparator(s string) (interval, error) {
+	m := comparatorPattern.FindStringSubmatch(s)
+	if m == nil {
+		return interval{}, fmt.Errorf("%q is not a version comparator", s)
+	}
+	op := m[1]
+	precision := len(nonWildcard(m[2:5]))
+	if precision == 0 {
+		if op == "<" || op == ">" {
+			return interval{lo: bound{&Version{Major: 1}, true}, hi: bound{&Version{}, false}}, nil // nothing is below or above every version
+		}
+		return interval{}, nil
+	}
+	parts := make([]int, 3)
+	for i, p := range nonWildcard(m[2:5]) {
+		parts[i], _ = strconv.Atoi(p)
+	}
+	full := precision == 3
+	v := Version{Major: parts[0], Minor: parts[1], Patch: parts[2]}
+	if full {
+		v.Pre = m[5]
+	}
+	// next is the first version past the given precision: 1.2 → 1.3.0.
+	next := func(p int) *Version {
+		n := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+		switch p {
+		case 1:
+			n = Version{Major: v.Major + 1}
+		case 2:
+			n = Version{Major: v.Major, Minor: v.Minor + 1}
+		default:
+			n.Patch++
+		}
+		return &n
+	}
+	lo := bound{&v, true}
+	switch op {
+	case ">=":
+		return interval{lo: lo}, nil
+	case ">":
+		if full {
+			return interval{lo: bound{&v, false}}, nil
+		}
+		return interval{lo: bound{next(precision), true}}, nil
+	case "<":
+		return interval{hi: bound{&v, false}}, nil
+	case "<=":
+		if full {
+			return interval{hi: bound{&v, true}}, nil
+		}
+		return interval{hi: bound{next(precision), false}}, nil
+	case "^":
+		switch {
+		case v.Major > 0 || precision == 1:
+			return interval{lo: lo, hi: bound{next(1), false}}, nil
+		case v.Minor > 0 || precision == 2:
+			return interval{lo: lo, hi: bound{next(2), false}}, nil
+		}
+		return interval{lo: lo, hi: bound{next(3), false}}, nil
+	case "~":
+		if precision == 1 {
+			return interval{lo: lo, hi: bound{next(1), false}}, nil
+		}
+		return interval{lo: lo, hi: bound{next(2), false}}, nil
+	}
+	if full {
+		return interval{lo: lo, hi: bound{&v, true}}, nil
+	}
+	return interval{lo: lo, hi: bound{next(precision), false}}, nil
+}
+
+// nonWildcard returns the leading numeric parts of a partial version.
+func nonWildcard(parts []string) []string {
+	var out []string
+	for _, p := range parts {
+		if p == "" || strings.ContainsAny(p, "xX*") {
+			break
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// intersect narrows iv to the versions o also allows.
+func (iv interval) intersect(o interval) interval {
+	if o.lo.v != nil && (iv.lo.v == nil || o.lo.v.Compare(*iv.lo.v) > 0 || (o.lo.v.Compare(*iv.lo.v) == 0 && !o.lo.inclusive)) {
+		iv.lo = o.lo
+	}
+	if o.hi.v != nil && (iv.hi.v == nil || o.hi.v.Compare(*iv.hi.v) < 0 || (o.hi.v.Compare(*iv.hi.v) == 0 && !o.hi.inclusive)) {
+		iv.hi = o.hi
+	}
+	return iv
+}
+
+func (iv interval) contains(v Version) bool {
+	if iv.lo.v != nil {
+		if c := v.Compare(*iv.lo.v); c < 0 || (c == 0 && !iv.lo.inclusive) {
+			return false
+		}
+	}
+	if iv.hi.v != nil {
+		if c := v.Compare(*iv.hi.v); c > 0 || (c == 0 && !iv.hi.inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+func (iv interval) empty() bool {
+	if iv.lo.v == nil || iv.hi.v == nil {
+		return false
+	}
+	c := iv.lo.v.Compare(*iv.hi.v)
+	return c > 0 || (c == 0 && !(iv.lo.inclusive && iv.hi.inclusive))
+}
+
+// Contains reports whether v satisfies the range.
+func (r VersionRange) Contains(v Version) bool {
+	for _, iv := range r.alts {
+		if iv.contains(v) {
+			return true
+		}
+	}
+	return len(r.alts) == 0
+}
+
+// Satisfiable reports whether any version satisfies the range, so that
+// ">=2.0.0 <1.0.0" is caught.
+func (r VersionRange) Satisfiable() bool {
+	for _, iv := range r.alts {
+		if !iv.empty() {
+			return true
+		}
+	}
+	return len(r.alts) == 0
+}
+
+// Intersects reports whether some version satisfies both ranges.
+func (r VersionRange) Intersects(o VersionRange) bool {
+	if len(r.alts) == 0 || len(o.alts) == 0 {
+		return r.Satisfiable() && o.Satisfiable()
+	}
+	for _, a := range r.alts {
+		for _, b := range o.alts {
+			if !a.intersect(b).empty() {
+				return true
+			}
+		}
+	}
+	return false
+}
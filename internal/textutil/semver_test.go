@@ -0,0 +1,100 @@
+package textutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := ParseVersion("v2.1.0-rc.1+build.5")
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 2, Minor: 1, Pre: "rc.1"}, v)
+	assert.Equal(t, "2.1.0-rc.1", v.String())
+
+	for _, bad := range []string{"", "1.2", "1.2.x", "latest", "1.2.3.4"} {
+		_, err := ParseVersion(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.10.0", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := ParseVersion(ordered[i-1])
+		b, _ := ParseVersion(ordered[i])
+		assert.Equal(t, -1, a.Compare(b), "%s < %s", a, b)
+		assert.Equal(t, 1, b.Compare(a), "%s > %s", b, a)
+	}
+}
+
+func TestVersionRange(t *testing.T) {
+	tests := []struct {
+		rng string
+		in  []string
+		out []string
+	}{
+		{"*", []string{"0.0.1", "9.9.9"}, nil},
+		{"", []string{"1.0.0"}, nil},
+		{"1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		{"=1.2.3", []string{"1.2.3"}, []string{"1.2.2"}},
+		{">=1.2.0 <2.0.0", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0"}},
+		{">= 1.2.0", []string{"1.2.0"}, []string{"1.1.0"}},
+		{"^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"1.x", []string{"1.0.0", "1.5.2"}, []string{"2.0.0", "0.9.0"}},
+		{"1.2", []string{"1.2.0", "1.2.7"}, []string{"1.3.0"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{"1.2.3 - 2.3", []string{"1.2.3", "2.3.9"}, []string{"1.2.2", "2.4.0"}},
+		{"^1.0.0 || ^3.0.0", []string{"1.5.0", "3.1.0"}, []string{"2.0.0"}},
+	}
+	for _, tt := range tests {
+		r, err := ParseVersionRange(tt.rng)
+		require.NoError(t, err, tt.rng)
+		for _, s := range tt.in {
+			v, _ := ParseVersion(s)
+			assert.True(t, r.Contains(v), "%q contains %s", tt.rng, s)
+		}
+		for _, s := range tt.out {
+			v, _ := ParseVersion(s)
+			assert.False(t, r.Contains(v), "%q excludes %s", tt.rng, s)
+		}
+		assert.True(t, r.Satisfiable(), tt.rng)
+	}
+
+	for _, bad := range []string{"latest", ">=1.2.3 foo", "^^1", "1.2.3 -", "=>1.0.0"} {
+		_, err := ParseVersionRange(bad)
+		assert.Error(t, err, bad)
+	}
+	for _, empty := range []string{">=2.0.0 <1.0.0", ">1.0.0 <1.0.0", "<*", "^1.0.0 <1.0.0"} {
+		r, err := ParseVersionRange(empty)
+		require.NoError(t, err, empty)
+		assert.False(t, r.Satisfiable(), empty)
+	}
+	r, _ := ParseVersionRange(">=2.0.0 <1.0.0 || 3.x")
+	assert.True(t, r.Satisfiable())
+}
+
+func TestVersionRangeIntersects(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{">=2.1.0", "<2.0.0", false},
+		{">=2.1.0", "^2.0.0", true},
+		{"^1.0.0 || ^3.0.0", "~3.2", true},
+		{"1.2.3", ">1.2.3", false},
+		{"*", ">=1.0.0", true},
+	}
+	for _, tt := range tests {
+		a, _ := ParseVersionRange(tt.a)
+		b, _ := ParseVersionRange(tt.b)
+		assert.Equal(t, tt.want, a.Intersects(b), "%q and %q", tt.a, tt.b)
+		assert.Equal(t, tt.want, b.Intersects(a), "%q and %q", tt.b, tt.a)
+	}
+}
@@ -0,0 +1,61 @@
+package textutil
+
+import (
+	"sort"
+	"strings"
+)
+
+// ClosestMatch returns the candidate nearest to s by edit distance, for
+// "did you mean" hints on misspelled keys such as "descriptoin". Only
+// candidates within a small distance (at most 2 edits, and less than half
+// the length of s) qualify; ok is false when nothing is close enough.
+// Ties resolve to the alphabetically first candidate so hints are stable.
+func ClosestMatch(s string, candidates []string) (match string, ok bool) {
+	needle := strings.ToLower(s)
+	maxDistance := min(2, (len(needle)-1)/2)
+	if maxDistance < 1 {
+		return "", false
+	}
+
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	best := maxDistance + 1
+	for _, c := range sorted {
+		if c == s {
+			continue
+		}
+		if d := editDistance(needle, strings.ToLower(c)); d < best {
+			best, match = d, c
+		}
+	}
+	return match, best <= maxDistance
+}
+
+// editDistance is the Damerau-Levenshtein (optimal string alignment)
+// distance, so a single transposition ("descriptoin") costs one edit.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
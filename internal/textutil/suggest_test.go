@@ -0,0 +1,46 @@
+package textutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosestMatch(t *testing.T) {
+	fields := []string{"name", "description", "model", "tools", "allowed-tools", "color"}
+
+	tests := []struct {
+		input     string
+		wantMatch string
+		wantOK    bool
+	}{
+		{"descriptoin", "description", true}, // transposition
+		{"desciption", "description", true},  // deletion
+		{"Model", "model", true},             // case only
+		{"tols", "tools", true},
+		{"allowed_tools", "allowed-tools", true},
+		{"nmae", "name", true},
+		{"zzz", "", false},
+		{"completely-unrelated", "", false},
+		{"ab", "", false}, // too short to guess
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			match, ok := ClosestMatch(tt.input, fields)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantMatch, match)
+			}
+		})
+	}
+}
+
+func TestClosestMatchDeterministicTies(t *testing.T) {
+	// "cat" is one edit from both; the alphabetically first wins every time
+	for range 10 {
+		match, ok := ClosestMatch("cat", []string{"cut", "bat"})
+		assert.True(t, ok)
+		assert.Equal(t, "bat", match)
+	}
+}
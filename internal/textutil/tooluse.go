@@ -0,0 +1,180 @@
+package textutil
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ToolDisclaimerPattern matches lines that explicitly disclaim a tool (e.g.
+// "do not use Bash"); a tool named on such a line is not being used.
+var ToolDisclaimerPattern = regexp.MustCompile(`(?i)\b(do not use|don't use|never use|avoid using|not use)\b`)
+
+// ContainsToolReference reports whether line contains a reference to toolName
+// using word-boundary logic: the preceding char must not be a letter and the
+// following char must not be a lowercase letter (allows camelCase boundaries
+// like "WebSearch" vs "Search").
+func ContainsToolReference(line, toolName string) bool {
+	idx := 0
+	for {
+		pos := strings.Index(line[idx:], toolName)
+		if pos < 0 {
+			return false
+		}
+		abs := idx + pos
+
+		// Check preceding character.
+		if abs > 0 {
+			prev := rune(line[abs-1])
+			if (prev >= 'a' && prev <= 'z') || (prev >= 'A' && prev <= 'Z') {
+				idx = abs + 1
+				continue
+			}
+		}
+
+		// Check following character.
+		after := abs + len(toolName)
+		if after < len(line) {
+			next := rune(line[after])
+			if next >= 'a' && next <= 'z' {
+				idx = abs + 1
+				continue
+			}
+		}
+
+		return true
+	}
+}
+
+// SkillToolUse compares a skill's allowed-tools with the tools its body
+// uses.
+type SkillToolUse struct {
+	// Unused lists the allowed-tools entries whose tool the body never
+	// mentions, in declaration order.
+	Unused []string
+	// Missing lists the tools a step invokes that allowed-tools does not
+	// grant, one per tool, at its first invocation.
+	Missing []ToolInvocation
+}
+
+// ToolInvocation is a body line that invokes a tool.
+type ToolInvocation struct {
+	Tool string
+	// Line is the 1-based line within the body.
+	Line int
+}
+
+// toolInvocationPattern matches a step that invokes a tool: a call such as
+// Bash(git status) or Read(references/api.md), or an instruction to use,
+// call, or run "the X tool".
+var toolInvocationPattern = regexp.MustCompile(`\b([A-Z][A-Za-z]+)\(|(?i:\b(?:use|call|run|invoke)\s+(?:the\s+)?)([A-Z][A-Za-z]+)\s+tool\b`)
+
+// AnalyzeSkillTools compares the allowed-tools value of a skill with its
+// body; extraTools are tools beyond the built-in ones that a step may
+// invoke. ok is false when allowed-tools is absent, empty, or "*", since the
+// skill then pre-approves either nothing or everything.
+//
+// An entry is unused when its tool is named nowhere in the body, outside
+// lines that disclaim it. A tool is missing when a step invokes it, through
+// a call like Bash(...) or "use the Read tool" outside code blocks, and no
+// entry grants it; Bash(git:*) grants Bash, and Task and Agent are the same
+// tool.
+func AnalyzeSkillTools(allowedTools any, body string, extraTools []string) (use SkillToolUse, ok bool) {
+	entries := skillToolEntries(allowedTools)
+	if len(entries) == 0 || slices.Contains(entries, "*") {
+		return use, false
+	}
+	granted := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		granted[canonicalTool(ExtractBaseToolName(entry))] = true
+	}
+
+	lines := strings.Split(body, "\n")
+	for _, entry := range entries {
+		tool := ExtractBaseToolName(entry)
+		if !slices.ContainsFunc(lines, func(line string) bool { return mentionsTool(line, tool) }) {
+			use.Unused = append(use.Unused, entry)
+		}
+	}
+
+	reported := make(map[string]bool)
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || ToolDisclaimerPattern.MatchString(line) {
+			continue
+		}
+		for _, m := range toolInvocationPattern.FindAllStringSubmatch(line, -1) {
+			tool := m[1] + m[2]
+			if !IsKnownTool(tool, extraTools) || granted[canonicalTool(tool)] || reported[canonicalTool(tool)] {
+				continue
+			}
+			reported[canonicalTool(tool)] = true
+			use.Missing = append(use.Missing, ToolInvocation{Tool: tool, Line: i + 1})
+		}
+	}
+	return use, true
+}
+
+// mentionsTool reports whether line names tool other than to disclaim it.
+// Task and Agent are the old and new names of the same tool.
+func mentionsTool(line, tool string) bool {
+	if ToolDisclaimerPattern.MatchString(line) {
+		return false
+	}
+	if canonicalTool(tool) == "Task" {
+		return ContainsToolReference(line, "Task") || ContainsToolReference(line, "Agent")
+	}
+	return ContainsToolReference(line, tool)
+}
+
+// canonicalTool folds Agent into Task, its former name.
+func canonicalTool(tool string) string {
+	if tool == "Agent" {
+		return "Task"
+	}
+	return tool
+}
+
+// skillToolEntries returns the entries of a skill's allowed-tools value.
+// Skills list tools separated by spaces, as agentskills.io specifies, or by
+// commas as commands do; separators inside parentheses belong to the entry,
+// so "Bash(git add:*) Read" is two entries.
+func skillToolEntries(allowedTools any) []string {
+	var entries []string
+	switch v := allowedTools.(type) {
+	case string:
+		depth, start := 0, 0
+		flush := func(end int) {
+			if entry := strings.TrimSpace(v[start:end]); entry != "" {
+				entries = append(entries, entry)
+			}
+			start = end + 1
+		}
+		for i, r := range v {
+			switch r {
+			case '(':
+				depth++
+			case ')':
+				if depth > 0 {
+					depth--
+				}
+			case ',', ' ', '\t':
+				if depth == 0 {
+					flush(i)
+				}
+			}
+		}
+		flush(len(v))
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				entries = append(entries, strings.TrimSpace(s))
+			}
+		}
+	}
+	return entries
+}
@@ -0,0 +1,76 @@
+package textutil
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestContainsToolReference(t *testing.T) {
+	tests := []struct {
+		line, tool string
+		want       bool
+	}{
+		{"Use Read to open the file", "Read", true},
+		{"Run `Bash(git status)`", "Bash", true},
+		{"Look it up with WebSearch", "Search", false},
+		{"Already done", "Read", false},
+		{"Readme first", "Read", false},
+	}
+	for _, tt := range tests {
+		if got := ContainsToolReference(tt.line, tt.tool); got != tt.want {
+			t.Errorf("ContainsToolReference(%q, %q) = %v, want %v", tt.line, tt.tool, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeSkillTools(t *testing.T) {
+	body := "\n## Steps\n\n1. Read the spec with Read(references/spec.md).\n" +
+		"2. Run Bash(go test ./...) and use the Grep tool to find failures.\n" +
+		"3. Do not use WebFetch for this.\n" +
+		"```\nWrite(notes.md)\n```\n" +
+		"4. Hand off with Agent(reviewer).\n"
+
+	tests := []struct {
+		name        string
+		allowed     any
+		wantOK      bool
+		wantUnused  []string
+		wantMissing []ToolInvocation
+	}{
+		{name: "absent", allowed: nil},
+		{name: "wildcard", allowed: "*"},
+		{
+			name:        "space-delimited with scopes",
+			allowed:     "Read Bash(go test:*) WebFetch Edit",
+			wantOK:      true,
+			wantUnused:  []string{"WebFetch", "Edit"},
+			wantMissing: []ToolInvocation{{Tool: "Grep", Line: 5}, {Tool: "Agent", Line: 10}},
+		},
+		{
+			name:        "comma-delimited with Task for Agent",
+			allowed:     "Read, Bash, Grep, Task",
+			wantOK:      true,
+			wantMissing: nil,
+		},
+		{
+			name:       "list",
+			allowed:    []any{"Read", "Bash", "Grep", "Agent", "Write"},
+			wantOK:     true,
+			wantUnused: nil, // Write is mentioned in the code block
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			use, ok := AnalyzeSkillTools(tt.allowed, body, nil)
+			if ok != tt.wantOK {
+				t.Fatalf("AnalyzeSkillTools() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !slices.Equal(use.Unused, tt.wantUnused) {
+				t.Errorf("Unused = %v, want %v", use.Unused, tt.wantUnused)
+			}
+			if !slices.Equal(use.Missing, tt.wantMissing) {
+				t.Errorf("Missing = %v, want %v", use.Missing, tt.wantMissing)
+			}
+		})
+	}
+}
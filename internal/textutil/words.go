@@ -0,0 +1,73 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords are dropped by ContentWords: function words, and the words
+// nearly every component description shares ("Use when ... this skill").
+var stopWords = map[string]bool{
+	"a": true, "about": true, "all": true, "also": true, "an": true, "and": true, "any": true,
+	"are": true, "as": true, "at": true, "be": true, "by": true, "can": true, "do": true,
+	"e": true, "eg": true, "etc": true, "for": true, "from": true, "g": true, "i": true,
+	"if": true, "in": true, "into": true, "is": true, "it": true, "its": true, "like": true,
+	"not": true, "of": true, "on": true, "or": true, "other": true, "so": true, "such": true,
+	"that": true, "the": true, "their": true, "them": true, "then": true, "these": true,
+	"this": true, "those": true, "to": true, "use": true, "used": true, "user": true,
+	"via": true, "was": true, "when": true, "whenever": true, "where": true, "which": true,
+	"while": true, "who": true, "will": true, "with": true, "you": true, "your": true,
+	"skill": true, "agent": true, "claude": true,
+}
+
+// ContentWords returns the distinct meaningful words of s, lowercased, in
+// first-seen order. Stop words are dropped and a trailing "s", "ed", or
+// "ing" is trimmed, so "Reviews PDF files" and "reviewing a PDF file" have
+// the same content words.
+func ContentWords(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(map[string]bool, len(fields))
+	var words []string
+	for _, w := range fields {
+		if stopWords[w] {
+			continue
+		}
+		switch {
+		case len(w) > 5 && strings.HasSuffix(w, "ing"):
+			w = w[:len(w)-3]
+		case len(w) > 4 && strings.HasSuffix(w, "ed") && !strings.HasSuffix(w, "eed"):
+			w = w[:len(w)-2]
+		case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+			w = w[:len(w)-1]
+		}
+		if stopWords[w] || seen[w] {
+			continue
+		}
+		seen[w] = true
+		words = append(words, w)
+	}
+	return words
+}
+
+// WordSimilarity is the Jaccard similarity of two word sets, from 0 (no
+// word in common) to 1 (the same words).
+func WordSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inA := make(map[string]bool, len(a))
+	for _, w := range a {
+		inA[w] = true
+	}
+	shared, union := 0, len(a)
+	for _, w := range b {
+		if inA[w] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	return float64(shared) / float64(union)
+}
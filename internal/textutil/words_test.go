@@ -0,0 +1,42 @@
+package textutil
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestContentWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"Use when reviewing PDF files.", []string{"review", "pdf", "file"}},
+		{"Reviews PDF files; use this skill for PDF review", []string{"review", "pdf", "file"}},
+		{"Handles class and process names", []string{"handle", "class", "process", "name"}},
+		{"Checked and agreed", []string{"check", "agreed"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := ContentWords(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("ContentWords(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWordSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want float64
+	}{
+		{[]string{"go", "test"}, []string{"go", "test"}, 1},
+		{[]string{"go", "test", "lint"}, []string{"go", "test", "fmt"}, 0.5},
+		{[]string{"go"}, []string{"rust"}, 0},
+		{nil, []string{"go"}, 0},
+	}
+	for _, tt := range tests {
+		if got := WordSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("WordSimilarity(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+// Package tui implements the interactive review mode behind "cclint tui":
+// a terminal list of findings grouped by component type and file, with
+// severity and rule filters, $EDITOR integration, re-linting, and autofixes.
+//
+// The Model is a pure state machine (keys in, actions and a rendered string
+// out) so it can be tested without a terminal; Run wires it to one.
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+	"github.com/dotcommander/cclint/internal/output"
+)
+
+// Key is a decoded key press: a named key (KeyUp, KeyEnter, ...) or the
+// typed character itself.
+type Key string
+
+const (
+	KeyUp        Key = "up"
+	KeyDown      Key = "down"
+	KeyPageUp    Key = "pgup"
+	KeyPageDown  Key = "pgdown"
+	KeyEnter     Key = "enter"
+	KeyEsc       Key = "esc"
+	KeyBackspace Key = "backspace"
+	KeyCtrlC     Key = "ctrl+c"
+)
+
+// Action is what the runner must do after a key press.
+type Action int
+
+const (
+	ActionNone   Action = iota
+	ActionQuit          // leave the TUI
+	ActionEdit          // open the selected finding in $EDITOR
+	ActionRelint        // re-run the linters
+	ActionFix           // apply the selected finding's autofix
+	ActionFormat        // run the canonical formatter on the selected file
+)
+
+// severityCycle is the order the "s" key steps through; "" shows all.
+var severityCycle = []string{"", cue.SeverityError, cue.SeverityWarning, cue.SeveritySuggestion}
+
+// Item is one finding in the list.
+type Item struct {
+	Type     string // component type, e.g. "agent"
+	File     string // path as reported by the linter
+	Severity string
+	Issue    cue.ValidationError
+}
+
+// Model holds the TUI state.
+type Model struct {
+	items    []Item
+	visible  []int // indexes into items that pass the filters
+	cursor   int   // index into visible
+	offset   int   // first visible row drawn
+	severity string
+	query    string // rule ID or message substring filter
+	editing  bool   // typing into the filter prompt
+	status   string
+}
+
+// NewModel builds a model from lint summaries.
+func NewModel(summaries []*lint.LintSummary) *Model {
+	m := &Model{}
+	m.SetSummaries(summaries)
+	return m
+}
+
+// SetSummaries replaces the findings, e.g. after a re-lint, keeping the
+// cursor on the same file where possible.
+func (m *Model) SetSummaries(summaries []*lint.LintSummary) {
+	prev, hadPrev := m.Selected()
+
+	m.items = m.items[:0]
+	for _, s := range summaries {
+		for _, is := range output.BuildFlatIssues(s) {
+			m.items = append(m.items, Item{
+				Type:     componentType(s, is),
+				File:     is.File,
+				Severity: string(is.Severity),
+				Issue:    is.Err,
+			})
+		}
+	}
+	slices.SortStableFunc(m.items, func(a, b Item) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+	m.applyFilters()
+
+	if hadPrev {
+		if i := slices.IndexFunc(m.visible, func(idx int) bool { return m.items[idx].File == prev.File }); i >= 0 {
+			m.cursor = i
+		}
+	}
+	m.clampCursor()
+}
+
+// componentType prefers the summary's component type and falls back to the
+// result type for summaries that mix types (explicit file lists).
+func componentType(s *lint.LintSummary, is output.FlatIssue) string {
+	if s.ComponentType != "" {
+		return s.ComponentType
+	}
+	if is.ResultIndex < len(s.Results) {
+		return s.Results[is.ResultIndex].Type
+	}
+	return "unknown"
+}
+
+// Selected returns the finding under the cursor.
+func (m *Model) Selected() (Item, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return Item{}, false
+	}
+	return m.items[m.visible[m.cursor]], true
+}
+
+// SetStatus sets the message shown in the footer.
+func (m *Model) SetStatus(format string, args ...any) {
+	m.status = fmt.Sprintf(format, args...)
+}
+
+// HandleKey updates the state for a key press and returns the action the
+// runner should perform.
+func (m *Model) HandleKey(k Key) Action {
+	if k == KeyCtrlC {
+		return ActionQuit
+	}
+	if m.editing {
+		m.handleQueryKey(k)
+		return ActionNone
+	}
+
+	switch k {
+	case "q":
+		return ActionQuit
+	case KeyUp, "k":
+		m.cursor--
+	case KeyDown, "j":
+		m.cursor++
+	case KeyPageUp:
+		m.cursor -= 10
+	case KeyPageDown:
+		m.cursor += 10
+	case "g":
+		m.cursor = 0
+	case "G":
+		m.cursor = len(m.visible) - 1
+	case "s":
+		i := slices.Index(severityCycle, m.severity)
+		m.severity = severityCycle[(i+1)%len(severityCycle)]
+		m.applyFilters()
+	case "/":
+		m.editing = true
+	case KeyEsc:
+		m.query = ""
+		m.applyFilters()
+	case KeyEnter, "e":
+		return m.actionIfSelected(ActionEdit)
+	case "r":
+		return ActionRelint
+	case "f":
+		return m.actionIfSelected(ActionFix)
+	case "F":
+		return m.actionIfSelected(ActionFormat)
+	}
+	m.clampCursor()
+	return ActionNone
+}
+
+func (m *Model) actionIfSelected(a Action) Action {
+	if _, ok := m.Selected(); !ok {
+		return ActionNone
+	}
+	return a
+}
+
+// handleQueryKey edits the filter prompt.
+func (m *Model) handleQueryKey(k Key) {
+	switch k {
+	case KeyEnter:
+		m.editing = false
+	case KeyEsc:
+		m.editing = false
+		m.query = ""
+	case KeyBackspace:
+		_, size := utf8.DecodeLastRuneInString(m.query)
+		m.query = m.query[:len(m.query)-size]
+	default:
+		if utf8.RuneCountInString(string(k)) == 1 {
+			m.query += string(k)
+		}
+	}
+	m.applyFilters()
+}
+
+// applyFilters recomputes the visible rows from the severity and query
+// filters.
+func (m *Model) applyFilters() {
+	m.visible = m.visible[:0]
+	q := strings.ToLower(m.query)
+	for i, it := range m.items {
+		if m.severity != "" && it.Severity != m.severity {
+			continue
+		}
+		if q != "" && !strings.Contains(strings.ToLower(it.Issue.Rule), q) &&
+			!strings.Contains(strings.ToLower(it.Issue.Message), q) {
+			continue
+		}
+		m.visible = append(m.visible, i)
+	}
+	m.clampCursor()
+}
+
+func (m *Model) clampCursor() {
+	m.cursor = max(0, min(m.cursor, len(m.visible)-1))
+}
+
+var (
+	headerStyle   = lipgloss.NewStyle().Bold(true)
+	typeStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	fileStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+	severityStyle = map[string]lipgloss.Style{
+		cue.SeverityError:      lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+		cue.SeverityWarning:    lipgloss.NewStyle().Foreground(lipgloss.Color("11")),
+		cue.SeveritySuggestion: lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+	}
+)
+
+// helpLine lists the key bindings.
+const helpLine = "j/k move  s severity  / filter  esc clear  e edit  f fix  F format  r re-lint  q quit"
+
+// View renders the model into a screen of the given size.
+func (m *Model) View(width, height int) string {
+	var b strings.Builder
+
+	severity := m.severity
+	if severity == "" {
+		severity = "all"
+	}
+	filter := m.query
+	if m.editing {
+		filter += "▏"
+	}
+	fmt.Fprintf(&b, "%s  %d/%d findings  severity: %s  filter: %s\n",
+		headerStyle.Render("cclint review"), len(m.visible), len(m.items), severity, filter)
+
+	rows := m.rows(width)
+	bodyHeight := max(1, height-3)
+	selectedRow := slices.IndexFunc(rows, func(r row) bool { return r.selected })
+	if selectedRow >= 0 {
+		if selectedRow < m.offset {
+			m.offset = selectedRow
+		}
+		if selectedRow >= m.offset+bodyHeight {
+			m.offset = selectedRow - bodyHeight + 1
+		}
+	}
+	m.offset = max(0, min(m.offset, len(rows)-bodyHeight))
+
+	for i := m.offset; i < len(rows) && i < m.offset+bodyHeight; i++ {
+		b.WriteString(rows[i].text)
+		b.WriteByte('\n')
+	}
+	for i := len(rows) - m.offset; i < bodyHeight; i++ {
+		b.WriteByte('\n')
+	}
+	status := m.status
+	if len(m.visible) == 0 {
+		status = cmp.Or(status, "No findings match the current filters")
+	}
+
+	fmt.Fprintf(&b, "%s\n", truncate(status, width))
+	b.WriteString(dimStyle.Render(truncate(helpLine, width)))
+	return b.String()
+}
+
+type row struct {
+	text     string
+	selected bool
+}
+
+// rows lays out type and file headers followed by their findings.
+func (m *Model) rows(width int) []row {
+	var rows []row
+	lastType, lastFile := "", ""
+	for vi, idx := range m.visible {
+		it := m.items[idx]
+		if it.Type != lastType {
+			rows = append(rows, row{text: typeStyle.Render(it.Type)})
+			lastType, lastFile = it.Type, ""
+		}
+		if it.File != lastFile {
+			rows = append(rows, row{text: "  " + fileStyle.Render(it.File)})
+			lastFile = it.File
+		}
+
+		loc := ""
+		if it.Issue.Line > 0 {
+			loc = fmt.Sprintf("%d: ", it.Issue.Line)
+		}
+		rule := ""
+		if it.Issue.Rule != "" {
+			rule = " [" + it.Issue.Rule + "]"
+		}
+		text := truncate(fmt.Sprintf("    %-10s %s%s%s", it.Severity, loc, it.Issue.Message, rule), width)
+		if vi == m.cursor {
+			rows = append(rows, row{text: selectedStyle.Render(text), selected: true})
+			continue
+		}
+		if style, ok := severityStyle[it.Severity]; ok {
+			text = style.Render(text)
+		}
+		rows = append(rows, row{text: text})
+	}
+	return rows
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 || len([]rune(s)) <= width {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:max(0, width-1)]) + "…"
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/cclint/internal/cue"
+	"github.com/dotcommander/cclint/internal/lint"
+)
+
+func testSummaries() []*lint.LintSummary {
+	return []*lint.LintSummary{
+		{
+			ComponentType: "agent",
+			Results: []lint.LintResult{
+				{
+					File:   "agents/a.md",
+					Errors: []cue.ValidationError{{File: "agents/a.md", Message: "missing name", Line: 1, Rule: "agent-name"}},
+					Warnings: []cue.ValidationError{
+						{File: "agents/a.md", Message: "duplicate tool", Line: 3, Rule: cue.RuleAgentToolDuplicate},
+					},
+				},
+			},
+		},
+		{
+			ComponentType: "command",
+			Results: []lint.LintResult{
+				{
+					File:        "commands/c.md",
+					Suggestions: []cue.ValidationError{{File: "commands/c.md", Message: "add a hint", Line: 2}},
+				},
+			},
+		},
+	}
+}
+
+func TestModel_SeverityFilter(t *testing.T) {
+	m := NewModel(testSummaries())
+	if len(m.visible) != 3 {
+		t.Fatalf("visible = %d, want 3", len(m.visible))
+	}
+
+	wantCounts := []int{1, 1, 1, 3} // error, warning, suggestion, all
+	for i, want := range wantCounts {
+		m.HandleKey("s")
+		if len(m.visible) != want {
+			t.Errorf("after %d presses of s: visible = %d, want %d (severity %q)", i+1, len(m.visible), want, m.severity)
+		}
+	}
+}
+
+func TestModel_QueryFilter(t *testing.T) {
+	m := NewModel(testSummaries())
+	for _, k := range []Key{"/", "t", "o", "o", "l", KeyEnter} {
+		m.HandleKey(k)
+	}
+	if m.query != "tool" || m.editing {
+		t.Fatalf("query = %q editing = %v, want \"tool\" false", m.query, m.editing)
+	}
+	if len(m.visible) != 1 {
+		t.Fatalf("visible = %d, want 1", len(m.visible))
+	}
+	if it, _ := m.Selected(); it.Issue.Rule != cue.RuleAgentToolDuplicate {
+		t.Errorf("selected rule = %q, want %q", it.Issue.Rule, cue.RuleAgentToolDuplicate)
+	}
+
+	m.HandleKey("/")
+	m.HandleKey(KeyBackspace)
+	m.HandleKey(KeyEsc)
+	if m.query != "" || len(m.visible) != 3 {
+		t.Errorf("after esc: query = %q visible = %d, want empty and 3", m.query, len(m.visible))
+	}
+}
+
+func TestModel_NavigationAndActions(t *testing.T) {
+	m := NewModel(testSummaries())
+
+	m.HandleKey(KeyUp)
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d after up at top, want 0", m.cursor)
+	}
+	m.HandleKey("G")
+	if it, _ := m.Selected(); it.File != "commands/c.md" {
+		t.Errorf("selected after G = %q, want commands/c.md", it.File)
+	}
+
+	tests := []struct {
+		key  Key
+		want Action
+	}{
+		{"q", ActionQuit},
+		{KeyCtrlC, ActionQuit},
+		{"e", ActionEdit},
+		{KeyEnter, ActionEdit},
+		{"r", ActionRelint},
+		{"f", ActionFix},
+		{"F", ActionFormat},
+		{"x", ActionNone},
+	}
+	for _, tt := range tests {
+		if got := m.HandleKey(tt.key); got != tt.want {
+			t.Errorf("HandleKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+
+	empty := NewModel(nil)
+	if got := empty.HandleKey("f"); got != ActionNone {
+		t.Errorf("fix with no selection = %v, want ActionNone", got)
+	}
+}
+
+func TestModel_SetSummariesKeepsFile(t *testing.T) {
+	m := NewModel(testSummaries())
+	m.HandleKey("G")
+
+	m.SetSummaries(testSummaries())
+	if it, _ := m.Selected(); it.File != "commands/c.md" {
+		t.Errorf("selected after relint = %q, want commands/c.md", it.File)
+	}
+}
+
+func TestModel_View(t *testing.T) {
+	m := NewModel(testSummaries())
+	view := m.View(120, 20)
+
+	for _, want := range []string{"3/3 findings", "agent", "agents/a.md", "missing name", "[agent-tool-duplicate]", "commands/c.md", "q quit"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+	if lines := strings.Count(view, "\n") + 1; lines != 20 {
+		t.Errorf("View() has %d lines, want 20", lines)
+	}
+}
+
+func TestDecodeKeys(t *testing.T) {
+	got := decodeKeys([]byte("j\x1b[A\x1b[B\x1b[5~\r\x7f\x03é\x1b"))
+	want := []Key{"j", KeyUp, KeyDown, KeyPageUp, KeyEnter, KeyBackspace, KeyCtrlC, "é", KeyEsc}
+	if len(got) != len(want) {
+		t.Fatalf("decodeKeys() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("key %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
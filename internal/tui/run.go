@@ -0,0 +1,279 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dotcommander/cclint/internal/format"
+	"github.com/dotcommander/cclint/internal/lint"
+	"golang.org/x/term"
+)
+
+// ANSI sequences for the alternate screen and cursor handling.
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	exitAltScreen  = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+)
+
+// Options configures Run.
+type Options struct {
+	// Root is the project root; relative finding paths resolve against it.
+	Root string
+	// Lint runs the linters and returns fresh summaries.
+	Lint func() ([]*lint.LintSummary, error)
+	// Format configures the formatter the format action runs.
+	Format format.Options
+	// In and Out are the terminal; In must be a TTY.
+	In  *os.File
+	Out io.Writer
+}
+
+// Run lints once, then shows the review UI until the user quits.
+func Run(opts Options) error {
+	fd := int(opts.In.Fd())
+	if !term.IsTerminal(fd) {
+		return errors.New("cclint tui requires an interactive terminal")
+	}
+
+	summaries, err := opts.Lint()
+	if err != nil {
+		return err
+	}
+	m := NewModel(summaries)
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("cannot enter raw mode: %w", err)
+	}
+	fmt.Fprint(opts.Out, enterAltScreen)
+	defer func() {
+		fmt.Fprint(opts.Out, exitAltScreen)
+		_ = term.Restore(fd, state)
+	}()
+
+	r := &runner{opts: opts, model: m, fd: fd, state: state}
+	return r.loop()
+}
+
+type runner struct {
+	opts  Options
+	model *Model
+	fd    int
+	state *term.State
+}
+
+func (r *runner) loop() error {
+	buf := make([]byte, 64)
+	for {
+		r.render()
+		n, err := r.opts.In.Read(buf)
+		if err != nil {
+			return err
+		}
+		for _, k := range decodeKeys(buf[:n]) {
+			switch r.model.HandleKey(k) {
+			case ActionQuit:
+				return nil
+			case ActionEdit:
+				r.edit()
+			case ActionRelint:
+				r.relint("Re-linted")
+			case ActionFix:
+				r.fix()
+			case ActionFormat:
+				r.format()
+			}
+		}
+	}
+}
+
+func (r *runner) render() {
+	width, height, err := term.GetSize(r.fd)
+	if err != nil {
+		width, height = 100, 30
+	}
+	view := r.model.View(width, height)
+	// Raw mode disables output post-processing, so newlines need a CR.
+	fmt.Fprint(r.opts.Out, clearScreen+strings.ReplaceAll(view, "\n", "\r\n"))
+}
+
+// path resolves a finding's file against the project root.
+func (r *runner) path(file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(r.opts.Root, file)
+}
+
+func (r *runner) relint(done string) {
+	summaries, err := r.opts.Lint()
+	if err != nil {
+		r.model.SetStatus("Lint failed: %v", err)
+		return
+	}
+	r.model.SetSummaries(summaries)
+	r.model.SetStatus("%s: %d findings", done, len(r.model.items))
+}
+
+// edit suspends the UI and opens the selected finding in $VISUAL/$EDITOR,
+// then re-lints.
+func (r *runner) edit() {
+	it, _ := r.model.Selected()
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		r.model.SetStatus("Set $EDITOR to open files")
+		return
+	}
+
+	args := strings.Fields(editor)
+	if it.Issue.Line > 0 {
+		args = append(args, "+"+strconv.Itoa(it.Issue.Line))
+	}
+	args = append(args, r.path(it.File))
+
+	fmt.Fprint(r.opts.Out, exitAltScreen)
+	_ = term.Restore(r.fd, r.state)
+	cmd := exec.Command(args[0], args[1:]...) //nolint:gosec // G204: the user's own $EDITOR
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	runErr := cmd.Run()
+	if state, err := term.MakeRaw(r.fd); err == nil {
+		r.state = state
+	}
+	fmt.Fprint(r.opts.Out, enterAltScreen)
+
+	if runErr != nil {
+		r.model.SetStatus("Editor failed: %v", runErr)
+		return
+	}
+	r.relint("Re-linted after edit")
+}
+
+// fix applies the selected finding's autofix and re-lints.
+func (r *runner) fix() {
+	it, _ := r.model.Selected()
+	path := r.path(it.File)
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		r.model.SetStatus("Cannot read %s: %v", it.File, err)
+		return
+	}
+	fix, ok := lint.FixFor(it.Issue, string(contents))
+	if !ok {
+		r.model.SetStatus("No autofix for this finding; press F to format the file or e to edit")
+		return
+	}
+	fixed, err := fix.Apply(string(contents))
+	if err != nil {
+		r.model.SetStatus("Fix failed: %v", err)
+		return
+	}
+	if err := writeFile(path, fixed); err != nil {
+		r.model.SetStatus("Cannot write %s: %v", it.File, err)
+		return
+	}
+	r.relint(fix.Description)
+}
+
+// format runs the canonical formatter (as "cclint fmt -w") on the selected
+// file and re-lints.
+func (r *runner) format() {
+	it, _ := r.model.Selected()
+	if !strings.HasSuffix(strings.ToLower(it.File), ".md") {
+		r.model.SetStatus("Only markdown components can be formatted")
+		return
+	}
+	path := r.path(it.File)
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		r.model.SetStatus("Cannot read %s: %v", it.File, err)
+		return
+	}
+	formatted, err := format.NewComponentFormatter(it.Type, r.opts.Format).Format(string(contents))
+	if err != nil {
+		r.model.SetStatus("Format failed: %v", err)
+		return
+	}
+	if formatted == string(contents) {
+		r.model.SetStatus("%s is already formatted", it.File)
+		return
+	}
+	if err := writeFile(path, formatted); err != nil {
+		r.model.SetStatus("Cannot write %s: %v", it.File, err)
+		return
+	}
+	r.relint("Formatted " + it.File)
+}
+
+// writeFile replaces path's contents, keeping its permissions.
+func writeFile(path, contents string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(contents), info.Mode().Perm())
+}
+
+// decodeKeys splits raw terminal input into key presses.
+func decodeKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		switch {
+		case b[0] == 0x1b && len(b) >= 3 && b[1] == '[':
+			seq, n := csiKey(b)
+			if seq != "" {
+				keys = append(keys, seq)
+			}
+			b = b[n:]
+			continue
+		case b[0] == 0x1b:
+			keys = append(keys, KeyEsc)
+		case b[0] == 0x03:
+			keys = append(keys, KeyCtrlC)
+		case b[0] == '\r' || b[0] == '\n':
+			keys = append(keys, KeyEnter)
+		case b[0] == 0x7f || b[0] == 0x08:
+			keys = append(keys, KeyBackspace)
+		default:
+			r := []rune(string(b))
+			if len(r) == 0 {
+				return keys
+			}
+			keys = append(keys, Key(string(r[0])))
+			b = b[len(string(r[0])):]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// csiKey decodes an ESC [ sequence, returning the key ("" if unknown) and
+// the number of bytes consumed.
+func csiKey(b []byte) (Key, int) {
+	switch b[2] {
+	case 'A':
+		return KeyUp, 3
+	case 'B':
+		return KeyDown, 3
+	}
+	if len(b) >= 4 && b[3] == '~' {
+		switch b[2] {
+		case '5':
+			return KeyPageUp, 4
+		case '6':
+			return KeyPageDown, 4
+		}
+		return "", 4
+	}
+	return "", 3
+}
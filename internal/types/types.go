@@ -11,6 +11,21 @@ type ValidationError struct {
 	Source   string // anthropic-docs, cclint-observation, agentskills-io
 	Line     int
 	Column   int
+	// Rule is a stable identifier for the check that produced the issue
+	// (e.g. "frontmatter-duplicate-key"). Policies that re-grade or filter
+	// specific checks key off Rule rather than message text. Every check
+	// sets one; RuleCategories lists them.
+	Rule string
+	// Pointer is the RFC 6901 JSON Pointer of the value the issue is about,
+	// e.g. /hooks/PreToolUse/0/hooks/1/command, for findings on settings
+	// and other JSON data. Lines of findings without one are derived from
+	// it. Empty when the issue is not about a particular value.
+	Pointer string
+	// Replacement is the value an autofix writes in place of the offending
+	// one, such as the successor of a deprecated model or the name a
+	// deprecated field is renamed to. Empty when the check suggests none.
+	// Not emitted to JSON output; the message names it.
+	Replacement string `json:"-"`
 	// Abort, when true on a SeverityError, signals pre-validation to
 	// short-circuit further checks for this file (typed replacement for the
 	// prior strings.Contains(Message, "is empty") sniff). This is an
@@ -25,6 +40,415 @@ const (
 	SourceAgentSkillsIO = "agentskills-io"     // agentskills.io specification
 )
 
+// Rule identifier constants.
+const (
+	RuleFrontmatterDuplicateKey     = "frontmatter-duplicate-key"
+	RuleFrontmatterUnknownKey       = "frontmatter-unknown-key"
+	RuleAgentToolUnknown            = "agent-tool-unknown"
+	RuleAgentToolSyntax             = "agent-tool-syntax"
+	RuleAgentToolDuplicate          = "agent-tool-duplicate"
+	RuleAgentToolWildcardMode       = "agent-tool-wildcard-mode"
+	RuleCommandArgHintMissing       = "command-argument-hint-missing"
+	RuleCommandArgHintUnused        = "command-argument-hint-unused"
+	RuleCommandArgHintArity         = "command-argument-hint-arity"
+	RuleCommandPositionalGap        = "command-positional-gap"
+	RuleCommandBashSyntax           = "command-bash-syntax"
+	RuleCommandBashNotAllowed       = "command-bash-not-allowed"
+	RuleCommandFileRefMissing       = "command-file-ref-missing"
+	RuleCommandAgentOverlap         = "command-agent-description-overlap"
+	RuleSettingsStatusLine          = "settings-statusline-invalid"
+	RuleSettingsStatusLineCmd       = "settings-statusline-command"
+	RuleSettingsOutputStyle         = "settings-output-style-unknown"
+	RuleAgentContextBudget          = "agent-context-budget"
+	RuleAgentMemoryConflict         = "agent-memory-conflict"
+	RuleSkillDescriptionTrigger     = "skill-description-trigger"
+	RuleSkillBodySize               = "skill-body-size"
+	RuleSkillTriggerVague           = "skill-trigger-vague"
+	RuleSkillTriggerOverlap         = "skill-trigger-overlap"
+	RuleSkillToolsNotInAgent        = "skill-allowed-tools-not-in-agent"
+	RuleSkillToolsUnused            = "skill-allowed-tools-unused"
+	RuleSkillToolsMissing           = "skill-allowed-tools-missing"
+	RuleAgentSkillToolsMissing      = "agent-skill-tools-missing"
+	RuleAgentSkillModel             = "agent-skill-model-conflict"
+	RuleAgentSkillUnreferenced      = "agent-skill-unreferenced"
+	RuleAgentSkillUndeclared        = "agent-skill-undeclared"
+	RuleContextImportMissing        = "context-import-missing"
+	RuleContextImportOutside        = "context-import-outside-project"
+	RuleContextImportCycle          = "context-import-cycle"
+	RuleContextImportDepth          = "context-import-depth"
+	RuleTemplatePlaceholder         = "template-placeholder-unfilled"
+	RuleTerminology                 = "terminology"
+	RuleFrontmatterFieldRenamed     = "frontmatter-field-renamed"
+	RuleFrontmatterFieldDeprecated  = "frontmatter-field-deprecated"
+	RuleModelDeprecated             = "model-deprecated"
+	RuleHookFieldInvalid            = "hook-field-invalid"
+	RuleHookFieldUnknown            = "hook-field-unknown"
+	RuleHookTimeout                 = "hook-timeout"
+	RuleSettingsEnvUndefined        = "settings-env-undefined"
+	RuleSettingsEnvCycle            = "settings-env-cycle"
+	RuleSettingsCredentialHelper    = "settings-credential-helper"
+	RuleSettingsCredentialExposure  = "settings-credential-exposure"
+	RulePermissionsAdditionalDirs   = "permissions-additional-directories"
+	RulePermissionsDefaultMode      = "permissions-default-mode"
+	RulePermissionsDisableBypass    = "permissions-disable-bypass"
+	RuleAgentNameCollision          = "agent-name-collision"
+	RuleAgentColorCollision         = "agent-color-collision"
+	RuleSkillNameMismatch           = "skill-name-mismatch"
+	RuleSkillDirName                = "skill-dir-name"
+	RuleSkillDirCollision           = "skill-dir-collision"
+	RuleSkillNested                 = "skill-nested"
+	RuleCommandNameCollision        = "command-name-collision"
+	RuleComponentShadowed           = "component-shadowed"
+	RuleComponentLinkMissing        = "component-link-missing"
+	RuleComponentLinkType           = "component-link-type"
+	RuleSettingsKeyUnknown          = "settings-key-unknown"
+	RuleWebAccessIPAddress          = "web-access-ip-address"
+	RuleWebAccessInsecureURL        = "web-access-insecure-url"
+	RuleWebAccessDomainDenied       = "web-access-domain-denied"
+	RuleScaffoldLeftover            = "scaffold-leftover"
+	RuleDescriptionReadability      = "description-readability"
+	RuleHookToolUnreachable         = "hook-tool-unreachable"
+	RuleHookScriptMissing           = "hook-script-missing"
+	RulePermissionsUnusedAllow      = "permissions-unused-allow"
+	RulePermissionsDeniedTool       = "permissions-denied-tool"
+	RulePermissionsTaskAgentMissing = "permissions-task-agent-missing"
+	RulePermissionsTaskNoMatch      = "permissions-task-no-match"
+	RuleOrphanedSkill               = "orphaned-skill"
+	RuleJSONSyntax                  = "json-syntax"
+	RuleTeammateAgentMissing        = "teammate-agent-missing"
+	RuleTeammateAgentTools          = "teammate-agent-tools"
+	RuleHookCommandSecurity         = "hook-command-security"
+	RuleHookNetworkAccess           = "hook-network-access"
+	RuleHookWriteOutsideRoot        = "hook-write-outside-root"
+	RuleSettingsPlatformPortability = "settings-platform-portability"
+	RuleStaleFileReference          = "stale-file-reference"
+	RuleContextAgentsMDDuplicate    = "context-agents-md-duplicate"
+	RuleContextAgentsMDConflict     = "context-agents-md-conflict"
+	RuleContentLongLine             = "content-long-line"
+	RuleContentBase64Blob           = "content-base64-blob"
+	RuleContentInvalidUTF8          = "content-invalid-utf8"
+	RuleContentBOM                  = "content-bom"
+	RuleContentLineEndings          = "content-line-endings"
+	RuleContentFinalNewline         = "content-final-newline"
+	RuleFrontmatterTabIndent        = "frontmatter-tab-indent"
+	RuleFrontmatterYAMLAlias        = "frontmatter-yaml-alias"
+	RuleFrontmatterSyntax           = "frontmatter-syntax"
+	RuleSettingsExtendsMissing      = "settings-extends-missing"
+	RuleSettingsExtendsInvalid      = "settings-extends-invalid"
+	RuleSettingsExtendsCycle        = "settings-extends-cycle"
+	RuleCommandChainToolsMissing    = "command-chain-tools-missing"
+	RuleSchemaValidationSkipped     = "schema-validation-skipped"
+	RulePluginVersionConstraint     = "plugin-version-constraint"
+	RulePluginDependencyMissing     = "plugin-dependency-missing"
+	RulePluginDependencyVersion     = "plugin-dependency-version"
+	RulePluginDependencyCycle       = "plugin-dependency-cycle"
+	RuleSchemaViolation             = "schema-violation"
+	RuleParseError                  = "parse-error"
+	RuleLintFailed                  = "lint-failed"
+	RuleFieldRequired               = "field-required"
+	RuleFieldInvalid                = "field-invalid"
+	RuleFieldTooLong                = "field-too-long"
+	RuleFrontmatterFieldSwallowed   = "frontmatter-field-swallowed"
+	RuleDescriptionAngleBrackets    = "description-angle-brackets"
+	RuleDescriptionTooShort         = "description-too-short"
+	RuleNameFormat                  = "name-format"
+	RuleNameReserved                = "name-reserved"
+	RuleAgentNameMismatch           = "agent-name-mismatch"
+	RuleComponentFileName           = "component-file-name"
+	RuleComponentEmpty              = "component-empty"
+	RuleComponentSize               = "component-size"
+	RuleVersionFormat               = "version-format"
+	RuleModelUnknown                = "model-unknown"
+	RuleToolUnknown                 = "tool-unknown"
+	RuleToolDeprecated              = "tool-deprecated"
+	RuleToolsFieldName              = "tools-field-name"
+	RuleSecretDetected              = "secret-detected"
+	RuleCrossFileSkipped            = "crossfile-skipped"
+	RuleAgentRefMissing             = "agent-ref-missing"
+	RuleSkillRefMissing             = "skill-ref-missing"
+	RuleTriggerConflict             = "trigger-conflict"
+	RuleAgentDependencyCycle        = "agent-dependency-cycle"
+	RuleAgentToolUnused             = "agent-tool-unused"
+	RuleAgentDescriptionProactive   = "agent-description-proactive"
+	RuleAgentModelMissing           = "agent-model-missing"
+	RuleAgentSkillSuggested         = "agent-skill-suggested"
+	RuleAgentPermissionModeMissing  = "agent-permission-mode-missing"
+	RuleAgentAutonomous             = "agent-autonomous"
+	RuleAgentBloatSection           = "agent-bloat-section"
+	RuleAgentInlineMethodology      = "agent-inline-methodology"
+	RuleCommandToolsWildcard        = "command-tools-wildcard"
+	RuleCommandToolNotDelegation    = "command-tool-not-delegation"
+	RuleCommandSkillWithoutTask     = "command-skill-without-task"
+	RuleCommandImplementation       = "command-implementation-steps"
+	RuleCommandTaskNotAllowed       = "command-task-not-allowed"
+	RuleCommandToolUnused           = "command-allowed-tools-unused"
+	RuleCommandBloatSection         = "command-bloat-section"
+	RuleCommandExamples             = "command-examples-count"
+	RuleCommandSuccessCriteria      = "command-success-criteria-format"
+	RuleCommandUsageMissing         = "command-usage-missing"
+	RuleCommandPreprocessEmpty      = "command-preprocess-empty"
+	RuleCommandPreprocessDangerous  = "command-preprocess-dangerous"
+	RuleCommandPositionalHigh       = "command-positional-high"
+	RuleCommandFlagUnknown          = "command-flag-unknown"
+	RuleSkillAllowedToolsFormat     = "skill-allowed-tools-format"
+	RuleSkillSectionMissing         = "skill-section-missing"
+	RuleSkillFrontmatterMissing     = "skill-frontmatter-missing"
+	RuleSkillAgentWithoutFork       = "skill-agent-without-fork"
+	RuleSkillScriptShebang          = "skill-script-shebang"
+	RuleSkillScriptNotExecutable    = "skill-script-not-executable"
+	RuleSkillLinkAbsolute           = "skill-link-absolute"
+	RuleSkillReferenceDepth         = "skill-reference-depth"
+	RuleSkillReferenceFileMissing   = "skill-reference-file-missing"
+	RuleSkillReferenceFileUnused    = "skill-reference-file-unused"
+	RuleRuleSymlinkBroken           = "rule-symlink-broken"
+	RuleContextSectionsMissing      = "context-sections-missing"
+	RuleContextSectionIncomplete    = "context-section-incomplete"
+	RuleContextImportBinary         = "context-import-binary"
+	RuleContextLocalNotIgnored      = "context-local-not-ignored"
+	RuleContextSize                 = "context-size"
+	RuleSettingsValueInvalid        = "settings-value-invalid"
+	RuleSettingsMCPServerInvalid    = "settings-mcp-server-invalid"
+	RuleSettingsRulesInvalid        = "settings-rules-invalid"
+	RulePermissionsInvalid          = "permissions-invalid"
+	RulePermissionsToolUnknown      = "permissions-tool-unknown"
+	RuleHookEventUnknown            = "hook-event-unknown"
+	RuleHookMatcherToolUnknown      = "hook-matcher-tool-unknown"
+	RuleHookMatcherInvalid          = "hook-matcher-invalid"
+	RulePluginPathNotRelative       = "plugin-path-not-relative"
+	RulePluginPathTraversal         = "plugin-path-traversal"
+	RulePluginPathMissing           = "plugin-path-missing"
+	RulePluginMetadataMissing       = "plugin-metadata-missing"
+	RulePluginFieldDeprecated       = "plugin-field-deprecated"
+	RuleKBFilename                  = "kb-filename"
+	RuleKBHeadingMissing            = "kb-heading-missing"
+	RuleKBSourceMissing             = "kb-source-missing"
+	RuleKBEntrySize                 = "kb-entry-size"
+)
+
+// Rule category constants.
+const (
+	CategorySecurity    = "security"
+	CategoryStructure   = "structure"
+	CategoryReferences  = "references"
+	CategoryStyle       = "style"
+	CategoryPerformance = "performance"
+)
+
+// Categories lists every rule category, in display order.
+var Categories = []string{CategorySecurity, CategoryStructure, CategoryReferences, CategoryStyle, CategoryPerformance}
+
+// RuleCategories tags each rule identifier with its categories. Every rule
+// constant above has an entry, and every issue carries one of them.
+var RuleCategories = map[string][]string{
+	RuleFrontmatterDuplicateKey:     {CategoryStructure},
+	RuleFrontmatterUnknownKey:       {CategoryStructure},
+	RuleAgentToolUnknown:            {CategoryStructure},
+	RuleAgentToolSyntax:             {CategoryStructure},
+	RuleAgentToolDuplicate:          {CategoryStyle},
+	RuleAgentToolWildcardMode:       {CategorySecurity},
+	RuleCommandArgHintMissing:       {CategoryStyle},
+	RuleCommandArgHintUnused:        {CategoryStyle},
+	RuleCommandArgHintArity:         {CategoryStructure},
+	RuleCommandPositionalGap:        {CategoryStructure},
+	RuleCommandBashSyntax:           {CategoryStructure},
+	RuleCommandBashNotAllowed:       {CategorySecurity},
+	RuleCommandFileRefMissing:       {CategoryReferences},
+	RuleCommandAgentOverlap:         {CategoryReferences},
+	RuleSettingsStatusLine:          {CategoryStructure},
+	RuleSettingsStatusLineCmd:       {CategoryReferences},
+	RuleSettingsOutputStyle:         {CategoryReferences},
+	RuleAgentContextBudget:          {CategoryPerformance},
+	RuleAgentMemoryConflict:         {CategoryReferences},
+	RuleSkillDescriptionTrigger:     {CategoryStyle},
+	RuleSkillBodySize:               {CategoryPerformance},
+	RuleSkillTriggerVague:           {CategoryStyle},
+	RuleSkillTriggerOverlap:         {CategoryReferences},
+	RuleSkillToolsNotInAgent:        {CategoryReferences, CategorySecurity},
+	RuleSkillToolsUnused:            {CategorySecurity},
+	RuleSkillToolsMissing:           {CategorySecurity},
+	RuleAgentSkillToolsMissing:      {CategoryReferences},
+	RuleAgentSkillModel:             {CategoryReferences},
+	RuleAgentSkillUnreferenced:      {CategoryReferences},
+	RuleAgentSkillUndeclared:        {CategoryReferences},
+	RuleContextImportMissing:        {CategoryReferences},
+	RuleContextImportOutside:        {CategoryReferences, CategorySecurity},
+	RuleContextImportCycle:          {CategoryReferences},
+	RuleContextImportDepth:          {CategoryReferences, CategoryPerformance},
+	RuleStaleFileReference:          {CategoryReferences},
+	RuleContextAgentsMDDuplicate:    {CategoryReferences, CategoryPerformance},
+	RuleContextAgentsMDConflict:     {CategoryReferences},
+	RuleContentLongLine:             {CategoryPerformance},
+	RuleContentBase64Blob:           {CategoryPerformance},
+	RuleContentInvalidUTF8:          {CategoryStructure},
+	RuleContentBOM:                  {CategoryStructure},
+	RuleContentLineEndings:          {CategoryStyle},
+	RuleContentFinalNewline:         {CategoryStyle},
+	RuleFrontmatterTabIndent:        {CategoryStructure},
+	RuleFrontmatterYAMLAlias:        {CategoryStructure},
+	RuleFrontmatterSyntax:           {CategoryStructure},
+	RuleSettingsExtendsMissing:      {CategoryReferences},
+	RuleSettingsExtendsInvalid:      {CategoryStructure},
+	RuleSettingsExtendsCycle:        {CategoryReferences},
+	RuleCommandChainToolsMissing:    {CategoryReferences},
+	RuleSchemaValidationSkipped:     {CategoryPerformance},
+	RulePluginVersionConstraint:     {CategoryStructure},
+	RulePluginDependencyMissing:     {CategoryReferences},
+	RulePluginDependencyVersion:     {CategoryReferences},
+	RulePluginDependencyCycle:       {CategoryReferences},
+	RuleTemplatePlaceholder:         {CategoryStyle},
+	RuleTerminology:                 {CategoryStyle},
+	RuleFrontmatterFieldRenamed:     {CategoryStructure},
+	RuleFrontmatterFieldDeprecated:  {CategoryStructure},
+	RuleModelDeprecated:             {CategoryStructure},
+	RuleHookFieldInvalid:            {CategoryStructure},
+	RuleHookFieldUnknown:            {CategoryStructure},
+	RuleHookTimeout:                 {CategoryPerformance},
+	RuleSettingsEnvUndefined:        {CategoryReferences},
+	RuleSettingsEnvCycle:            {CategoryReferences},
+	RuleSettingsCredentialHelper:    {CategorySecurity},
+	RuleSettingsCredentialExposure:  {CategorySecurity},
+	RulePermissionsAdditionalDirs:   {CategorySecurity},
+	RulePermissionsDefaultMode:      {CategorySecurity},
+	RulePermissionsDisableBypass:    {CategorySecurity},
+	RuleAgentNameCollision:          {CategoryReferences},
+	RuleAgentColorCollision:         {CategoryStyle},
+	RuleSkillNameMismatch:           {CategoryStructure},
+	RuleSkillDirName:                {CategoryStructure},
+	RuleSkillDirCollision:           {CategoryReferences},
+	RuleSkillNested:                 {CategoryStructure},
+	RuleCommandNameCollision:        {CategoryReferences},
+	RuleComponentShadowed:           {CategoryReferences},
+	RuleComponentLinkMissing:        {CategoryReferences},
+	RuleComponentLinkType:           {CategoryReferences},
+	RuleSettingsKeyUnknown:          {CategoryStructure},
+	RuleWebAccessIPAddress:          {CategorySecurity},
+	RuleWebAccessInsecureURL:        {CategorySecurity},
+	RuleWebAccessDomainDenied:       {CategorySecurity},
+	RuleScaffoldLeftover:            {CategoryStyle},
+	RuleDescriptionReadability:      {CategoryStyle},
+	RuleHookToolUnreachable:         {CategoryStructure},
+	RuleHookScriptMissing:           {CategoryStructure, CategoryReferences},
+	RulePermissionsUnusedAllow:      {CategorySecurity},
+	RulePermissionsDeniedTool:       {CategoryReferences},
+	RulePermissionsTaskAgentMissing: {CategoryReferences},
+	RulePermissionsTaskNoMatch:      {CategoryReferences},
+	RuleOrphanedSkill:               {CategoryReferences},
+	RuleJSONSyntax:                  {CategoryStructure},
+	RuleTeammateAgentMissing:        {CategoryReferences},
+	RuleTeammateAgentTools:          {CategoryReferences},
+	RuleHookCommandSecurity:         {CategorySecurity},
+	RuleHookNetworkAccess:           {CategorySecurity},
+	RuleHookWriteOutsideRoot:        {CategorySecurity},
+	RuleSettingsPlatformPortability: {CategoryStructure},
+	RuleSchemaViolation:             {CategoryStructure},
+	RuleParseError:                  {CategoryStructure},
+	RuleLintFailed:                  {CategoryStructure},
+	RuleFieldRequired:               {CategoryStructure},
+	RuleFieldInvalid:                {CategoryStructure},
+	RuleFieldTooLong:                {CategoryStructure},
+	RuleFrontmatterFieldSwallowed:   {CategoryStructure},
+	RuleDescriptionAngleBrackets:    {CategoryStructure},
+	RuleDescriptionTooShort:         {CategoryStyle},
+	RuleNameFormat:                  {CategoryStructure},
+	RuleNameReserved:                {CategoryStructure},
+	RuleAgentNameMismatch:           {CategoryStyle},
+	RuleComponentFileName:           {CategoryStructure},
+	RuleComponentEmpty:              {CategoryStructure},
+	RuleComponentSize:               {CategoryPerformance},
+	RuleVersionFormat:               {CategoryStyle},
+	RuleModelUnknown:                {CategoryStructure},
+	RuleToolUnknown:                 {CategoryStructure},
+	RuleToolDeprecated:              {CategoryStructure},
+	RuleToolsFieldName:              {CategoryStructure},
+	RuleSecretDetected:              {CategorySecurity},
+	RuleCrossFileSkipped:            {CategoryReferences},
+	RuleAgentRefMissing:             {CategoryReferences},
+	RuleSkillRefMissing:             {CategoryReferences},
+	RuleTriggerConflict:             {CategoryReferences},
+	RuleAgentDependencyCycle:        {CategoryReferences},
+	RuleAgentToolUnused:             {CategorySecurity},
+	RuleAgentDescriptionProactive:   {CategoryStyle},
+	RuleAgentModelMissing:           {CategoryStyle},
+	RuleAgentSkillSuggested:         {CategoryStyle},
+	RuleAgentPermissionModeMissing:  {CategorySecurity},
+	RuleAgentAutonomous:             {CategorySecurity},
+	RuleAgentBloatSection:           {CategoryPerformance},
+	RuleAgentInlineMethodology:      {CategoryPerformance},
+	RuleCommandToolsWildcard:        {CategorySecurity},
+	RuleCommandToolNotDelegation:    {CategoryStructure},
+	RuleCommandSkillWithoutTask:     {CategoryStructure},
+	RuleCommandImplementation:       {CategoryStyle},
+	RuleCommandTaskNotAllowed:       {CategorySecurity},
+	RuleCommandToolUnused:           {CategorySecurity},
+	RuleCommandBloatSection:         {CategoryPerformance},
+	RuleCommandExamples:             {CategoryPerformance},
+	RuleCommandSuccessCriteria:      {CategoryStyle},
+	RuleCommandUsageMissing:         {CategoryStyle},
+	RuleCommandPreprocessEmpty:      {CategoryStructure},
+	RuleCommandPreprocessDangerous:  {CategorySecurity},
+	RuleCommandPositionalHigh:       {CategoryStructure},
+	RuleCommandFlagUnknown:          {CategoryReferences},
+	RuleSkillAllowedToolsFormat:     {CategoryStructure},
+	RuleSkillSectionMissing:         {CategoryStyle},
+	RuleSkillFrontmatterMissing:     {CategoryStructure},
+	RuleSkillAgentWithoutFork:       {CategoryStructure},
+	RuleSkillScriptShebang:          {CategoryStructure},
+	RuleSkillScriptNotExecutable:    {CategoryStructure},
+	RuleSkillLinkAbsolute:           {CategoryReferences},
+	RuleSkillReferenceDepth:         {CategoryReferences, CategoryPerformance},
+	RuleSkillReferenceFileMissing:   {CategoryReferences},
+	RuleSkillReferenceFileUnused:    {CategoryReferences},
+	RuleRuleSymlinkBroken:           {CategoryReferences},
+	RuleContextSectionsMissing:      {CategoryStructure},
+	RuleContextSectionIncomplete:    {CategoryStructure},
+	RuleContextImportBinary:         {CategoryReferences},
+	RuleContextLocalNotIgnored:      {CategorySecurity},
+	RuleContextSize:                 {CategoryPerformance},
+	RuleSettingsValueInvalid:        {CategoryStructure},
+	RuleSettingsMCPServerInvalid:    {CategoryStructure},
+	RuleSettingsRulesInvalid:        {CategoryStructure},
+	RulePermissionsInvalid:          {CategoryStructure},
+	RulePermissionsToolUnknown:      {CategoryStructure},
+	RuleHookEventUnknown:            {CategoryStructure},
+	RuleHookMatcherToolUnknown:      {CategoryStructure},
+	RuleHookMatcherInvalid:          {CategoryStructure},
+	RulePluginPathNotRelative:       {CategoryStructure},
+	RulePluginPathTraversal:         {CategorySecurity},
+	RulePluginPathMissing:           {CategoryReferences},
+	RulePluginMetadataMissing:       {CategoryStyle},
+	RulePluginFieldDeprecated:       {CategoryStructure},
+	RuleKBFilename:                  {CategoryStyle},
+	RuleKBHeadingMissing:            {CategoryStructure},
+	RuleKBSourceMissing:             {CategoryReferences},
+	RuleKBEntrySize:                 {CategoryPerformance},
+}
+
+// RuleGroups maps each rule whose findings can restate another rule's to
+// the rule of its group. The CUE schema and the component checks both
+// validate field values, so a check that enforces a schema constraint in
+// its own words is grouped with schema-violation; findings of one group at
+// the same place in a file are one problem and are reported once. Rules
+// not listed are never deduplicated.
+var RuleGroups = map[string]string{
+	RuleSchemaViolation:          RuleSchemaViolation,
+	RuleFieldRequired:            RuleSchemaViolation,
+	RuleFieldInvalid:             RuleSchemaViolation,
+	RuleFieldTooLong:             RuleSchemaViolation,
+	RuleNameFormat:               RuleSchemaViolation,
+	RuleNameReserved:             RuleSchemaViolation,
+	RuleDescriptionAngleBrackets: RuleSchemaViolation,
+	RuleModelUnknown:             RuleSchemaViolation,
+	RuleSkillAllowedToolsFormat:  RuleSchemaViolation,
+	RuleHookTimeout:              RuleSchemaViolation,
+	RuleHookFieldInvalid:         RuleSchemaViolation,
+	RuleHookEventUnknown:         RuleSchemaViolation,
+	RuleSettingsValueInvalid:     RuleSchemaViolation,
+	RuleSettingsMCPServerInvalid: RuleSchemaViolation,
+	RuleSettingsRulesInvalid:     RuleSchemaViolation,
+	RulePermissionsInvalid:       RuleSchemaViolation,
+	RulePluginVersionConstraint:  RuleSchemaViolation,
+}
+
 // Severity level constants.
 const (
 	SeverityError      = "error"
@@ -35,10 +459,11 @@ const (
 
 // Component type constants.
 const (
-	TypeAgent   = "agent"
-	TypeCommand = "command"
-	TypeSkill   = "skill"
-	TypeRule    = "rule"
+	TypeAgent       = "agent"
+	TypeCommand     = "command"
+	TypeSkill       = "skill"
+	TypeRule        = "rule"
+	TypeOutputStyle = "output-style"
 )
 
 // Hook type constants.
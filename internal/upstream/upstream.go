@@ -0,0 +1,169 @@
+// Package upstream compares the fields documented by Claude Code against
+// the fields cclint validates, so stale schemas are noticed early.
+package upstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/cache"
+)
+
+// DefaultSources are the published documentation pages, in markdown, that
+// list each component's fields.
+var DefaultSources = map[string]string{
+	"settings": "https://code.claude.com/docs/en/settings.md",
+	"agent":    "https://code.claude.com/docs/en/sub-agents.md",
+	"command":  "https://code.claude.com/docs/en/slash-commands.md",
+	"skill":    "https://code.claude.com/docs/en/skills.md",
+}
+
+// maxDocBytes caps how much of a documentation page is read.
+const maxDocBytes = 4 << 20
+
+// Snapshot maps a component type to the field names documented for it. A
+// pinned snapshot is stored as JSON in the same shape.
+type Snapshot map[string][]string
+
+// LoadSnapshot reads a pinned JSON snapshot.
+func LoadSnapshot(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: user-supplied snapshot path
+	if err != nil {
+		return nil, fmt.Errorf("cannot read snapshot: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	return snap, nil
+}
+
+// Fetch downloads each source page and extracts its documented fields.
+func Fetch(ctx context.Context, client *http.Client, sources map[string]string) (Snapshot, error) {
+	return FetchCached(ctx, client, nil, 0, sources)
+}
+
+// FetchCached is Fetch reading pages through c, which serves pages younger
+// than ttl without a request. Offline, it fails with cache.ErrOffline for
+// pages that were never cached.
+func FetchCached(ctx context.Context, client *http.Client, c *cache.Cache, ttl time.Duration, sources map[string]string) (Snapshot, error) {
+	snap := make(Snapshot, len(sources))
+	for component, url := range sources {
+		body, err := c.Load(url, ttl, func() ([]byte, error) { return fetchPage(ctx, client, url) })
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", component, err)
+		}
+		fields := FieldsFromMarkdown(string(body))
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("%s: no field table found in %s", component, url)
+		}
+		snap[component] = fields
+	}
+	return snap, nil
+}
+
+// fetchPage downloads one documentation page.
+func fetchPage(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocBytes))
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return body, nil
+}
+
+var (
+	// tableRowPattern splits a markdown table row into its first cell.
+	tableRowPattern = regexp.MustCompile(`^\|\s*([^|]*?)\s*\|`)
+	// fieldCellPattern matches a first cell holding one backticked field
+	// name. Names start lowercase, which skips environment variables.
+	fieldCellPattern = regexp.MustCompile("^`([a-z][A-Za-z0-9_-]*)`$")
+)
+
+// FieldsFromMarkdown extracts field names from the markdown tables of a
+// documentation page whose first column is headed "Key" or "Field" (the
+// layout of the settings and frontmatter reference tables). Other tables,
+// such as environment variables or nested permission keys, are ignored.
+// Returns the names sorted, each once.
+func FieldsFromMarkdown(doc string) []string {
+	var fields []string
+	inTable, fieldTable := false, false
+	for _, line := range strings.Split(doc, "\n") {
+		m := tableRowPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			inTable = false
+			continue
+		}
+		if !inTable {
+			inTable = true
+			header := strings.ToLower(m[1])
+			fieldTable = header == "key" || header == "field"
+			continue
+		}
+		if !fieldTable {
+			continue
+		}
+		if f := fieldCellPattern.FindStringSubmatch(m[1]); f != nil && !slices.Contains(fields, f[1]) {
+			fields = append(fields, f[1])
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Drift is the comparison for one component: documented fields cclint does
+// not know, and known fields the documentation no longer lists.
+type Drift struct {
+	Component    string
+	Unknown      []string
+	Undocumented []string
+}
+
+// Compare diffs the documented fields against known, component by
+// component, in sorted component order.
+func Compare(documented Snapshot, known map[string][]string) []Drift {
+	components := make([]string, 0, len(documented))
+	for c := range documented {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+
+	drifts := make([]Drift, 0, len(components))
+	for _, c := range components {
+		d := Drift{Component: c}
+		for _, f := range documented[c] {
+			if !slices.Contains(known[c], f) {
+				d.Unknown = append(d.Unknown, f)
+			}
+		}
+		for _, f := range known[c] {
+			if !slices.Contains(documented[c], f) {
+				d.Undocumented = append(d.Undocumented, f)
+			}
+		}
+		sort.Strings(d.Unknown)
+		sort.Strings(d.Undocumented)
+		drifts = append(drifts, d)
+	}
+	return drifts
+}
@@ -0,0 +1,152 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/cclint/internal/cache"
+)
+
+const settingsDoc = `# Settings
+
+| Key            | Description            | Example |
+| :------------- | :--------------------- | :------ |
+| ` + "`apiKeyHelper`" + ` | Script that prints a key | ` + "`/bin/gen.sh`" + ` |
+| ` + "`model`" + `        | Default model          | ` + "`\"opus\"`" + ` |
+| ` + "`model`" + `        | Repeated row           | |
+
+## Environment variables
+
+| Variable | Purpose |
+| :------- | :------ |
+| ` + "`ANTHROPIC_API_KEY`" + ` | API key |
+
+## Permission settings
+
+| Keys | Description |
+| :--- | :---------- |
+| ` + "`allow`" + ` | Allowed rules |
+
+| Field | Required |
+| :---- | :------- |
+| ` + "`statusLine`" + ` | No |
+| ` + "`sandbox.enabled`" + ` | No |
+`
+
+func TestFieldsFromMarkdown(t *testing.T) {
+	got := FieldsFromMarkdown(settingsDoc)
+	want := []string{"apiKeyHelper", "model", "statusLine"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldsFromMarkdown() = %v, want %v", got, want)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	documented := Snapshot{
+		"settings": {"apiKeyHelper", "model", "newSetting"},
+		"agent":    {"name", "tools"},
+	}
+	known := map[string][]string{
+		"settings": {"model", "apiKeyHelper", "legacySetting"},
+		"agent":    {"name", "tools"},
+	}
+
+	got := Compare(documented, known)
+	want := []Drift{
+		{Component: "agent"},
+		{Component: "settings", Unknown: []string{"newSetting"}, Undocumented: []string{"legacySetting"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/settings.md":
+			_, _ = w.Write([]byte(settingsDoc))
+		case "/empty.md":
+			_, _ = w.Write([]byte("# Nothing here\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	snap, err := Fetch(context.Background(), srv.Client(), map[string]string{"settings": srv.URL + "/settings.md"})
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if want := []string{"apiKeyHelper", "model", "statusLine"}; !reflect.DeepEqual(snap["settings"], want) {
+		t.Errorf("Fetch() settings = %v, want %v", snap["settings"], want)
+	}
+
+	for _, path := range []string{"/missing.md", "/empty.md"} {
+		if _, err := Fetch(context.Background(), srv.Client(), map[string]string{"agent": srv.URL + path}); err == nil {
+			t.Errorf("Fetch(%s) error = nil, want an error", path)
+		}
+	}
+}
+
+func TestFetchCached(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(settingsDoc))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	sources := map[string]string{"settings": srv.URL + "/settings.md"}
+	for range 2 {
+		if _, err := FetchCached(context.Background(), srv.Client(), cache.New(dir), time.Hour, sources); err != nil {
+			t.Fatalf("FetchCached() error = %v", err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 (second fetch served from cache)", requests)
+	}
+
+	offline := cache.New(dir).WithOffline(true)
+	snap, err := FetchCached(context.Background(), srv.Client(), offline, 0, sources)
+	if err != nil || len(snap["settings"]) == 0 {
+		t.Errorf("offline FetchCached() = %v, %v; want the cached fields", snap, err)
+	}
+	_, err = FetchCached(context.Background(), srv.Client(), offline, 0, map[string]string{"agent": srv.URL + "/agents.md"})
+	if !errors.Is(err, cache.ErrOffline) {
+		t.Errorf("offline FetchCached() of an uncached page error = %v, want ErrOffline", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, offline mode must not fetch", requests)
+	}
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.json")
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(good, []byte(`{"agent": ["name", "tools"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bad, []byte(`["name"]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := LoadSnapshot(good)
+	if err != nil || !reflect.DeepEqual(snap, Snapshot{"agent": {"name", "tools"}}) {
+		t.Errorf("LoadSnapshot(good) = %v, %v", snap, err)
+	}
+	for _, path := range []string{bad, filepath.Join(dir, "missing.json")} {
+		if _, err := LoadSnapshot(path); err == nil {
+			t.Errorf("LoadSnapshot(%s) error = nil, want an error", filepath.Base(path))
+		}
+	}
+}